@@ -56,11 +56,16 @@ ASSETS = ["demo", "gui/build/appicon.png", "social-preview.png"]
 # keeping the detailed provider references next to the Command Reference summary
 # tables. Same-group entries stay contiguous, so literate-nav renders one parent;
 # within a group they follow this dict's key order (not the alphabetical page
-# discovery order) — hence aws, gcloud, azure, the project's canonical order.
+# discovery order) — hence aws, gcloud, azure, vault, kubernetes, sops, local, the project's canonical order.
 DOC_NAV = {
     "aws.md": {"group": "Command Details", "after": "command-reference.md"},
     "gcloud.md": {"group": "Command Details", "after": "command-reference.md"},
     "azure.md": {"group": "Command Details", "after": "command-reference.md"},
+    "vault.md": {"group": "Command Details", "after": "command-reference.md"},
+    "kubernetes.md": {"group": "Command Details", "after": "command-reference.md"},
+    "sops.md": {"group": "Command Details", "after": "command-reference.md"},
+    "local.md": {"group": "Command Details", "after": "command-reference.md"},
+    "plugins.md": {"group": "Command Details", "after": "command-reference.md"},
     "staging-state-transitions.md": {"group": "Command Details", "after": "command-reference.md"},
 }
 
@@ -134,8 +134,8 @@ class DocNavConfigTests(unittest.TestCase):
     def test_doc_nav_declares_canonical_group_and_order(self):
         # main() orders grouped docs by this dict's key order (not alphabetical
         # discovery order), so the declared order is the source of truth for the
-        # "Command Details" nav group: AWS, Google Cloud, Azure, then lifecycle.
-        self.assertEqual(list(b.DOC_NAV), ["aws.md", "gcloud.md", "azure.md", "staging-state-transitions.md"])
+        # "Command Details" nav group: AWS, Google Cloud, Azure, Vault, Kubernetes, SOPS, Local, then lifecycle.
+        self.assertEqual(list(b.DOC_NAV), ["aws.md", "gcloud.md", "azure.md", "vault.md", "kubernetes.md", "sops.md", "local.md", "plugins.md", "staging-state-transitions.md"])
         for cfg in b.DOC_NAV.values():
             self.assertEqual(cfg["group"], "Command Details")
             self.assertEqual(cfg["after"], "command-reference.md")
@@ -31,9 +31,9 @@ func launchGUI(ctx context.Context, initial provider.Scope, service string) (con
 
 // guiScope builds the initial GUI launch scope for provider p from the flags
 // present on the launching command: --project (Google Cloud), --vault-name /
-// --store-name / --namespace (Azure). Absent flags stay empty and are hydrated
-// from the environment inside the GUI (flag wins over env). AWS carries no scope
-// flag (region from config).
+// --store-name / --namespace (Azure), --profile / --role-arn and friends (AWS).
+// Absent flags stay empty and are hydrated from the environment inside the GUI
+// (flag wins over env).
 func guiScope(cmd *cli.Command, p provider.Provider) provider.Scope {
 	s := provider.Scope{Provider: p}
 
@@ -49,7 +49,14 @@ func guiScope(cmd *cli.Command, p provider.Provider) provider.Scope {
 		// hydrateScope (#425 follow-up).
 		s.AppConfigNamespace = cmd.String("namespace")
 	case provider.ProviderAWS:
-		// region comes from the ambient AWS config; no scope flag.
+		// The region comes from the ambient AWS config; the flags only select
+		// credentials. The GUI has no terminal, so a role that needs MFA
+		// cannot be assumed from it.
+		s.AWSProfile = cmd.String("profile")
+		s.AWSRoleARN = cmd.String("role-arn")
+		s.AWSExternalID = cmd.String("external-id")
+		s.AWSSessionName = cmd.String("session-name")
+		s.AWSMFASerial = cmd.String("mfa-serial")
 	}
 
 	return s
@@ -15,9 +15,9 @@ import (
 	"testing"
 )
 
-// TestNoAWSSDKOutsideProviderAWS enforces that only internal/provider (which
-// includes internal/provider/aws, .../gcloud, .../azure) may import a cloud
-// SDK. It walks the entire internal/ tree plus cmd/ and fails loudly if any
+// TestNoAWSSDKOutsideProviderAWS enforces that only the adapter packages under
+// internal/provider (internal/provider/aws, .../gcloud, .../azure, ...) may
+// import a cloud SDK; the provider package itself is the SDK-free seam. It walks the entire internal/ tree plus cmd/ and fails loudly if any
 // other non-test package reintroduces a direct cloud-SDK dependency, which
 // would break provider pluggability.
 func TestNoAWSSDKOutsideProviderAWS(t *testing.T) {
@@ -29,21 +29,22 @@ func TestNoAWSSDKOutsideProviderAWS(t *testing.T) {
 	// allowedRoots subtrees which are pruned from the walk.
 	guardedRoots := []string{".", "../cmd"}
 
-	// allowedRoots are the subtrees (relative to this package dir) that are
-	// permitted to import a cloud SDK and are therefore skipped during the walk.
-	allowedRoots := map[string]struct{}{
-		"provider": {},
-	}
+	// adapterRoot is the directory (relative to this package dir) whose
+	// subtrees are permitted to import a cloud SDK and are therefore skipped
+	// during the walk. Its own files (the provider package) are still guarded.
+	adapterRoot := "provider"
 
 	// forbiddenPrefixes are import paths banned in non-test packages under a
 	// guarded root. SDK service packages are matched by prefix so their
 	// subpackages (e.g. .../service/ssm/types, .../secretmanager/apiv1/...) are
 	// caught too.
 	forbiddenPrefixes := []string{
-		"github.com/aws/aws-sdk-go-v2/service/ssm",
-		"github.com/aws/aws-sdk-go-v2/service/secretsmanager",
+		"github.com/aws/aws-sdk-go-v2",
+		"github.com/aws/smithy-go",
 		"cloud.google.com/go/secretmanager",
+		"google.golang.org/grpc",
 		"github.com/Azure/azure-sdk-for-go",
+		"k8s.io",
 		"github.com/mpyw/suve/internal/api/paramapi",
 		"github.com/mpyw/suve/internal/api/secretapi",
 	}
@@ -67,7 +68,7 @@ func TestNoAWSSDKOutsideProviderAWS(t *testing.T) {
 			}
 
 			if d.IsDir() {
-				if _, ok := allowedRoots[path]; ok {
+				if filepath.Dir(path) == adapterRoot {
 					return filepath.SkipDir
 				}
 
@@ -91,8 +92,8 @@ func TestNoAWSSDKOutsideProviderAWS(t *testing.T) {
 
 				if isForbidden(importPath) {
 					t.Errorf(
-						"%s imports %q: the AWS SDK must stay behind the provider seam "+
-							"(only internal/provider/aws may import it)",
+						"%s imports %q: cloud SDKs must stay behind the provider seam "+
+							"(only the adapter packages under internal/provider may import them)",
 						path, importPath,
 					)
 				}
@@ -28,8 +28,8 @@ type ServiceCapability struct {
 	HasVersionHistory bool `json:"hasVersionHistory"`
 	// HasVersionSpecifiers is true when #VERSION/~SHIFT specifiers apply.
 	HasVersionSpecifiers bool `json:"hasVersionSpecifiers"`
-	// HasTags is true when tag/label read+write is supported (false for Azure
-	// App Configuration).
+	// HasTags is true when tag/label read+write is supported (false for SOPS
+	// files, whose keys carry no metadata).
 	HasTags bool `json:"hasTags"`
 	// TagsPerVersion is true when tags are scoped to a specific version rather
 	// than the resource (Azure Key Vault only): each version has its own tags,
@@ -37,7 +37,7 @@ type ServiceCapability struct {
 	// latest version. Every other provider keeps tags at the resource level.
 	TagsPerVersion bool `json:"tagsPerVersion"`
 	// HasRestore is true when a soft-deleted item can be restored (AWS Secrets
-	// Manager only).
+	// Manager, Azure Key Vault, and Vault KV v2).
 	HasRestore bool `json:"hasRestore"`
 	// HasStaging is true when the frontend's staging workflow applies to this
 	// service (every provider service today); the frontend hides the staging
@@ -67,14 +67,16 @@ type ServiceCapability struct {
 
 // ProviderCapability describes a provider and the services it offers.
 type ProviderCapability struct {
-	// Provider is the internal key ("aws" | "googlecloud" | "azure").
+	// Provider is the internal key ("aws" | "googlecloud" | "azure" | "vault" |
+	// "kubernetes" | "sops" | "local" | "plugin"). A plugin's capability is not
+	// listed by All: it comes from the plugin's handshake.
 	Provider string `json:"provider"`
 	// DisplayName is the provider label (e.g. "Google Cloud").
 	DisplayName string `json:"displayName"`
 	// ScopeFields lists the provider-level scope inputs the frontend must collect
-	// (e.g. ["project"] for Google Cloud). Empty for AWS (ambient config) and for
-	// Azure, whose per-service vault/store names are collected by the service's
-	// own view.
+	// (e.g. ["project"] for Google Cloud). Empty for AWS (ambient config), for
+	// local (one fixed directory), and for Azure, whose per-service vault/store
+	// names are collected by the service's own view.
 	ScopeFields []string `json:"scopeFields"`
 	// Services are the param/secret services this provider offers, in stable
 	// display order.
@@ -84,7 +86,8 @@ type ProviderCapability struct {
 // All returns the static capability descriptor for every provider, driving
 // provider-selection and control-visibility in the frontends. Display names:
 // AWS {Param, Secret}, Google Cloud {Secret}, Azure {App Configuration,
-// Key Vault}.
+// Key Vault}, Vault {KV}, Kubernetes {ConfigMap, Secret}, SOPS {File}, Local
+// {Param, Secret}.
 func All() []ProviderCapability {
 	return []ProviderCapability{
 		{
@@ -139,5 +142,71 @@ func All() []ProviderCapability {
 				},
 			},
 		},
+		{
+			Provider:    string(provider.ProviderVault),
+			DisplayName: "Vault",
+			ScopeFields: []string{"address", "mount"},
+			Services: []ServiceCapability{
+				// KV v2: delete is a soft delete of the current version (undeletable),
+				// tags are the path's custom_metadata, and there is no description.
+				{
+					Service: serviceSecret, DisplayName: "KV",
+					HasVersionHistory: true, HasVersionSpecifiers: true, HasTags: true, HasRestore: true,
+					HasStaging: true, HasForceDelete: false, HasRecoveryWindow: false, HasDescription: false,
+				},
+			},
+		},
+		{
+			Provider:    string(provider.ProviderKubernetes),
+			DisplayName: "Kubernetes",
+			ScopeFields: []string{"context", "namespace"},
+			Services: []ServiceCapability{
+				// ConfigMap and Secret keys are unversioned; tags are the object's
+				// labels, shared by every key of the object.
+				{
+					Service: serviceParam, DisplayName: "ConfigMap",
+					HasVersionHistory: false, HasVersionSpecifiers: false, HasTags: true, HasRestore: false,
+					HasStaging: true, HasForceDelete: false, HasRecoveryWindow: false, HasDescription: false,
+				},
+				{
+					Service: serviceSecret, DisplayName: displayNameSecret,
+					HasVersionHistory: false, HasVersionSpecifiers: false, HasTags: true, HasRestore: false,
+					HasStaging: true, HasForceDelete: false, HasRecoveryWindow: false, HasDescription: false,
+				},
+			},
+		},
+		{
+			Provider:    string(provider.ProviderSOPS),
+			DisplayName: "SOPS",
+			ScopeFields: []string{"file"},
+			Services: []ServiceCapability{
+				// Leaf keys of an encrypted file: versions are the git commits that
+				// changed a key, and keys carry no tags or description.
+				{
+					Service: serviceSecret, DisplayName: "File",
+					HasVersionHistory: true, HasVersionSpecifiers: true, HasTags: false, HasRestore: false,
+					HasStaging: true, HasForceDelete: false, HasRecoveryWindow: false, HasDescription: false,
+				},
+			},
+		},
+		{
+			Provider:    string(provider.ProviderLocal),
+			DisplayName: "Local",
+			ScopeFields: []string{},
+			Services: []ServiceCapability{
+				// The offline JSON-file stores model AWS Parameter Store and
+				// Secrets Manager, so they offer the same controls.
+				{
+					Service: serviceParam, DisplayName: "Param",
+					HasVersionHistory: true, HasVersionSpecifiers: true, HasTags: true, HasRestore: false,
+					HasStaging: true, HasForceDelete: false, HasRecoveryWindow: false, HasDescription: true,
+				},
+				{
+					Service: serviceSecret, DisplayName: displayNameSecret,
+					HasVersionHistory: true, HasVersionSpecifiers: true, HasTags: true, HasRestore: true,
+					HasStaging: true, HasForceDelete: true, HasRecoveryWindow: true, HasDescription: true,
+				},
+			},
+		},
 	}
 }
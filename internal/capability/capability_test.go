@@ -57,6 +57,12 @@ func TestAll_StagingAndDeleteFlags(t *testing.T) {
 		{string(provider.ProviderGoogleCloud), "secret", true, false, false, false},
 		{string(provider.ProviderAzure), "param", true, false, false, false},
 		{string(provider.ProviderAzure), "secret", true, false, false, true},
+		{string(provider.ProviderVault), "secret", true, false, false, true},
+		{string(provider.ProviderKubernetes), "param", true, false, false, false},
+		{string(provider.ProviderKubernetes), "secret", true, false, false, false},
+		{string(provider.ProviderSOPS), "secret", true, false, false, false},
+		{string(provider.ProviderLocal), "param", true, false, false, false},
+		{string(provider.ProviderLocal), "secret", true, true, true, true},
 	}
 
 	for _, tt := range tests {
@@ -86,16 +92,16 @@ func TestAll_EveryServiceHasStaging(t *testing.T) {
 
 // TestAll_ForceDeleteAndRecoveryWindowAWSSecretOnly pins the AWS-only invariant:
 // both force-delete and the per-delete recovery window are Secrets Manager
-// features. Azure Key Vault soft-deletes (Restore recovers) but neither purges
-// on force nor exposes a per-delete recovery window (retention is a vault
-// property), so no other provider/service may report these.
+// features (also offered by the local secret store, which models it). Azure Key
+// Vault soft-deletes (Restore recovers) but neither purges on force nor exposes
+// a per-delete recovery window (retention is a vault property), so no other
+// provider/service may report these.
 func TestAll_ForceDeleteAndRecoveryWindowAWSSecretOnly(t *testing.T) {
 	t.Parallel()
 
 	for _, p := range capability.All() {
 		for _, s := range p.Services {
-			isAWSSecret := p.Provider == string(provider.ProviderAWS) && s.Service == "secret"
-			if !isAWSSecret {
+			if !isSecretsManagerShaped(p.Provider, s.Service) {
 				assert.False(t, s.HasForceDelete, "%s/%s must not offer force-delete", p.Provider, s.Service)
 				assert.False(t, s.HasRecoveryWindow, "%s/%s must not have a recovery window", p.Provider, s.Service)
 			}
@@ -131,15 +137,17 @@ func TestAll_TagsPerVersionAzureKeyVaultOnly(t *testing.T) {
 }
 
 // TestAll_RestoreSoftDeleteProvidersOnly pins that Restore is offered exactly by
-// the soft-delete services: AWS Secrets Manager and Azure Key Vault.
+// the soft-delete services: AWS Secrets Manager (and its local model), Azure Key
+// Vault and Vault KV v2.
 func TestAll_RestoreSoftDeleteProvidersOnly(t *testing.T) {
 	t.Parallel()
 
 	for _, p := range capability.All() {
 		for _, s := range p.Services {
-			isAWSSecret := p.Provider == string(provider.ProviderAWS) && s.Service == "secret"
 			isAzureKeyVault := p.Provider == string(provider.ProviderAzure) && s.Service == "secret"
-			assert.Equal(t, isAWSSecret || isAzureKeyVault, s.HasRestore, "%s/%s HasRestore", p.Provider, s.Service)
+			isVault := p.Provider == string(provider.ProviderVault)
+			want := isSecretsManagerShaped(p.Provider, s.Service) || isAzureKeyVault || isVault
+			assert.Equal(t, want, s.HasRestore, "%s/%s HasRestore", p.Provider, s.Service)
 		}
 	}
 }
@@ -184,7 +192,17 @@ func TestAll_ProviderShape(t *testing.T) {
 		{provider: string(provider.ProviderAWS), scopeFields: []string{}, services: []string{"param", "secret"}},
 		{provider: string(provider.ProviderGoogleCloud), scopeFields: []string{"project"}, services: []string{"secret"}},
 		{provider: string(provider.ProviderAzure), scopeFields: []string{}, services: []string{"param", "secret"}},
+		{provider: string(provider.ProviderVault), scopeFields: []string{"address", "mount"}, services: []string{"secret"}},
+		{provider: string(provider.ProviderKubernetes), scopeFields: []string{"context", "namespace"}, services: []string{"param", "secret"}},
+		{provider: string(provider.ProviderSOPS), scopeFields: []string{"file"}, services: []string{"secret"}},
+		{provider: string(provider.ProviderLocal), scopeFields: []string{}, services: []string{"param", "secret"}},
 	}
 
 	assert.Equal(t, want, got)
 }
+
+// isSecretsManagerShaped reports whether a service follows Secrets Manager's
+// delete semantics: AWS Secrets Manager itself and the local secret store.
+func isSecretsManagerShaped(p, service string) bool {
+	return service == "secret" && (p == string(provider.ProviderAWS) || p == string(provider.ProviderLocal))
+}
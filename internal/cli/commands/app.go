@@ -12,17 +12,21 @@ import (
 	"github.com/samber/lo"
 	"github.com/urfave/cli/v3"
 
+	"github.com/mpyw/suve/internal/cli/commands/auth"
 	awscmd "github.com/mpyw/suve/internal/cli/commands/aws"
-	"github.com/mpyw/suve/internal/cli/commands/aws/param"
-	"github.com/mpyw/suve/internal/cli/commands/aws/secret"
-	"github.com/mpyw/suve/internal/cli/commands/aws/stage"
 	"github.com/mpyw/suve/internal/cli/commands/azure"
 	"github.com/mpyw/suve/internal/cli/commands/gcloud"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/commands/kubernetes"
+	"github.com/mpyw/suve/internal/cli/commands/local"
+	"github.com/mpyw/suve/internal/cli/commands/plugin"
+	"github.com/mpyw/suve/internal/cli/commands/sops"
+	"github.com/mpyw/suve/internal/cli/commands/vault"
 	"github.com/mpyw/suve/internal/cli/output"
 	"github.com/mpyw/suve/internal/debug"
 	"github.com/mpyw/suve/internal/provider"
 	"github.com/mpyw/suve/internal/provider/detect"
+	providerplugin "github.com/mpyw/suve/internal/provider/plugin"
 )
 
 // Version is set by goreleaser via ldflags.
@@ -31,12 +35,59 @@ import (
 var Version = "dev"
 
 const baseUsage = "Git-like CLI for AWS Parameter Store / Secrets Manager, " +
-	"Google Cloud Secret Manager, and Azure Key Vault / App Configuration"
+	"Google Cloud Secret Manager, Azure Key Vault / App Configuration, HashiCorp Vault, Kubernetes, SOPS files, " +
+	"an offline local store, and external provider plugins"
 
 // MakeApp creates a new CLI application instance, resolving the flat
-// `param` / `secret` aliases from the current environment.
+// `param` / `secret` aliases from the current environment and any --provider
+// on the command line.
 func MakeApp() *cli.Command {
-	return MakeAppWithDetect(detect.Resolve(detect.OSEnvironment()))
+	return MakeAppWithDetect(detect.Resolve(detectEnvironment(os.Args)))
+}
+
+// detectEnvironment is the OS environment with a --provider found in args
+// overlaid onto SUVE_PROVIDER. The flat aliases must exist before urfave/cli
+// parses anything, so the flag is pre-scanned here; the root flag itself only
+// validates it.
+func detectEnvironment(args []string) detect.Environment {
+	env := detect.OSEnvironment()
+
+	if name, ok := providerArg(args); ok {
+		getenv := env.Getenv
+		env.Getenv = func(key string) string {
+			if key == detect.ProviderEnvVar {
+				return name
+			}
+
+			return getenv(key)
+		}
+	}
+
+	return env
+}
+
+// providerArg returns the value of the last --provider in args (the program
+// name excluded), in either the "--provider x" or "--provider=x" form. Parsing
+// stops at a "--" terminator.
+func providerArg(args []string) (string, bool) {
+	var (
+		name  string
+		found bool
+	)
+
+	for i := 1; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--":
+			return name, found
+		case arg == "--provider" && i+1 < len(args):
+			name, found = args[i+1], true
+			i++
+		case strings.HasPrefix(arg, "--provider="):
+			name, found = strings.TrimPrefix(arg, "--provider="), true
+		}
+	}
+
+	return name, found
 }
 
 // MakeAppWithDetect builds the app with an explicit provider-detection result.
@@ -48,6 +99,12 @@ func MakeAppWithDetect(det detect.Result) *cli.Command {
 		awscmd.Command(),
 		gcloud.Command(),
 		azure.Command(),
+		vault.Command(),
+		kubernetes.Command(),
+		sops.Command(),
+		local.Command(),
+		auth.Command(),
+		matrixCommand(det),
 	}
 
 	// Flat aliases are prepended only when a service resolves to exactly one
@@ -68,12 +125,15 @@ func MakeAppWithDetect(det detect.Result) *cli.Command {
 		flat = append(flat, c)
 	}
 
+	// Plugin groups come last so a plugin can never shadow a built-in command.
+	commands = append(commands, pluginCommands(append(flat, commands...))...)
+
 	return &cli.Command{
 		Name:        "suve",
 		Usage:       baseUsage,
 		Description: aliasDescription(det),
 		Version:     Version,
-		Flags:       []cli.Flag{debugFlag(), noRedactionFlag()},
+		Flags:       []cli.Flag{providerFlag(), debugFlag(), noRedactionFlag()},
 		Before:      enableDebug(det),
 		Commands:    append(flat, commands...),
 		// EnableShellCompletion adds a hidden `completion` command (bash/zsh/fish/pwsh)
@@ -98,6 +158,47 @@ func MakeAppWithDetect(det detect.Result) *cli.Command {
 	}
 }
 
+// pluginCommands returns a command group per suve-provider-<name> executable
+// on PATH (see internal/provider/plugin), skipping plugins whose name is taken
+// by one of the builtin commands or their aliases.
+func pluginCommands(builtin []*cli.Command) []*cli.Command {
+	taken := map[string]bool{"help": true, "h": true, "completion": true}
+
+	for _, c := range builtin {
+		for _, name := range c.Names() {
+			taken[name] = true
+		}
+	}
+
+	var commands []*cli.Command
+
+	for _, p := range providerplugin.Discover(os.Getenv("PATH")) {
+		if !taken[p.Name] {
+			commands = append(commands, plugin.Command(p))
+		}
+	}
+
+	return commands
+}
+
+// providerFlag defines the global --provider selector (env SUVE_PROVIDER). It
+// picks the provider the flat `param` / `secret` / `stage` aliases target,
+// bypassing detection; it is the way to reach the local provider through them.
+// Its value is consumed by detectEnvironment before parsing, so the flag
+// itself only rejects unknown names.
+func providerFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:    "provider",
+		Usage:   "Provider the top-level param/secret/stage aliases target (aws, gcloud, azure, vault, kubernetes, sops, local)",
+		Sources: cli.EnvVars(detect.ProviderEnvVar),
+		Validator: func(name string) error {
+			_, err := detect.ParseProvider(name)
+
+			return err
+		},
+	}
+}
+
 // debugFlag defines the global --debug switch. It is a persistent flag (v3
 // flags propagate to subcommands unless marked Local), so it works in any
 // position: `suve --debug sm ls` and `suve sm ls --debug` are equivalent. The
@@ -222,21 +323,45 @@ func flatCommand(p provider.Provider, kind provider.Kind) *cli.Command {
 	case provider.KindParam:
 		switch p {
 		case provider.ProviderAWS:
-			return param.Command()
+			return awscmd.FlatParamCommand("param")
 		case provider.ProviderAzure:
 			return azure.FlatParamCommand("param")
 		case provider.ProviderGoogleCloud:
 			// Google Cloud has no parameter store; never a param alias.
 			return nil
+		case provider.ProviderVault:
+			// Vault (KV v2) has no parameter store; never a param alias.
+			return nil
+		case provider.ProviderKubernetes:
+			return kubernetes.FlatParamCommand("param")
+		case provider.ProviderSOPS:
+			// A SOPS file has no parameter store; never a param alias.
+			return nil
+		case provider.ProviderLocal:
+			return local.FlatParamCommand("param")
+		case provider.ProviderPlugin:
+			// Plugins are discovered on PATH, not detected; never an alias.
+			return nil
 		}
 	case provider.KindSecret:
 		switch p {
 		case provider.ProviderAWS:
-			return secret.Command()
+			return awscmd.FlatSecretCommand("secret")
 		case provider.ProviderGoogleCloud:
 			return gcloud.FlatSecretCommand("secret")
 		case provider.ProviderAzure:
 			return azure.FlatSecretCommand("secret")
+		case provider.ProviderVault:
+			return vault.FlatSecretCommand("secret")
+		case provider.ProviderKubernetes:
+			return kubernetes.FlatSecretCommand("secret")
+		case provider.ProviderSOPS:
+			return sops.FlatSecretCommand("secret")
+		case provider.ProviderLocal:
+			return local.FlatSecretCommand("secret")
+		case provider.ProviderPlugin:
+			// Plugins are discovered on PATH, not detected; never an alias.
+			return nil
 		}
 	}
 
@@ -249,11 +374,22 @@ func flatCommand(p provider.Provider, kind provider.Kind) *cli.Command {
 func flatStageCommand(p provider.Provider) *cli.Command {
 	switch p {
 	case provider.ProviderAWS:
-		return stage.Command()
+		return awscmd.FlatStageCommand("stage")
 	case provider.ProviderGoogleCloud:
 		return gcloud.FlatStageCommand("stage")
 	case provider.ProviderAzure:
 		return azure.FlatStageCommand("stage")
+	case provider.ProviderVault:
+		return vault.FlatStageCommand("stage")
+	case provider.ProviderKubernetes:
+		return kubernetes.FlatStageCommand("stage")
+	case provider.ProviderSOPS:
+		return sops.FlatStageCommand("stage")
+	case provider.ProviderLocal:
+		return local.FlatStageCommand("stage")
+	case provider.ProviderPlugin:
+		// Plugins are discovered on PATH, not detected; never an alias.
+		return nil
 	}
 
 	return nil
@@ -278,17 +414,23 @@ func aliasDescription(det detect.Result) string {
 	if len(lines) == 0 {
 		return "No provider is uniquely active in this environment, so there are no " +
 			"top-level 'param'/'secret'/'stage' aliases. Use an explicit group: " +
-			"'suve aws', 'suve gcloud', or 'suve azure'."
+			"'suve aws', 'suve gcloud', 'suve azure', 'suve vault', 'suve kubernetes', 'suve sops', or 'suve local'."
 	}
 
-	via := lo.Ternary(
-		det.AWSViaFallback,
-		" (AWS via ~/.aws/credentials)",
-		" (from environment)",
-	)
+	var via string
+
+	switch {
+	case det.Selected:
+		via = " (selected by --provider / SUVE_PROVIDER)"
+	case det.AWSViaFallback:
+		via = " (AWS via ~/.aws/credentials)"
+	default:
+		via = " (from environment)"
+	}
 
 	return "Active top-level aliases" + via + ":\n" + strings.Join(lines, "\n") +
-		"\nThe explicit groups ('suve aws', 'suve gcloud', 'suve azure') are always available."
+		"\nThe explicit groups ('suve aws', 'suve gcloud', 'suve azure', 'suve vault', 'suve kubernetes', 'suve sops', 'suve local') " +
+		"are always available."
 }
 
 // groupName maps a provider to its command-group name for user-facing messages.
@@ -300,6 +442,16 @@ func groupName(p provider.Provider) string {
 		return "gcloud"
 	case provider.ProviderAzure:
 		return "azure"
+	case provider.ProviderVault:
+		return "vault"
+	case provider.ProviderKubernetes:
+		return "kubernetes"
+	case provider.ProviderSOPS:
+		return "sops"
+	case provider.ProviderLocal:
+		return "local"
+	case provider.ProviderPlugin:
+		// Each plugin is its own group, named after the plugin.
 	}
 
 	return string(p)
@@ -78,6 +78,16 @@ func TestMakeAppWithDetect_flatCommandsAreRunnable(t *testing.T) {
 	app = commands.MakeAppWithDetect(detect.Result{Param: provider.ProviderAzure})
 	err = app.Run(t.Context(), []string{"suve", "param", "--help"})
 	require.NoError(t, err)
+
+	// A flat AWS stage alias carries the --profile/--role-arn flags of the
+	// aws group.
+	var help bytes.Buffer
+
+	app = commands.MakeAppWithDetect(detect.Result{Param: provider.ProviderAWS, Secret: provider.ProviderAWS, Stage: provider.ProviderAWS})
+	app.Writer = &help
+	err = app.Run(t.Context(), []string{"suve", "stage", "--profile", "work", "--help"})
+	require.NoError(t, err)
+	assert.Contains(t, help.String(), "--role-arn")
 }
 
 func TestMakeApp_shellCompletion(t *testing.T) {
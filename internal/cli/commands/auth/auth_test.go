@@ -0,0 +1,48 @@
+package auth_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appcli "github.com/mpyw/suve/internal/cli/commands"
+	"github.com/mpyw/suve/internal/credcache"
+	"github.com/mpyw/suve/internal/keyprovider"
+	"github.com/mpyw/suve/internal/provider/detect"
+)
+
+//nolint:paralleltest // uses t.Setenv
+func TestLogout(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(credcache.EnvCredentialCache, "1")
+	t.Setenv(keyprovider.EnvStagingKey, base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+
+	logout := func() string {
+		t.Helper()
+
+		var stdout, stderr bytes.Buffer
+
+		app := appcli.MakeAppWithDetect(detect.Result{})
+		app.Writer = &stdout
+		app.ErrWriter = &stderr
+
+		require.NoError(t, app.Run(t.Context(), []string{"suve", "auth", "logout"}), stderr.String())
+
+		return stdout.String()
+	}
+
+	assert.Contains(t, logout(), "No cached credentials.")
+
+	cache, err := credcache.Open()
+	require.NoError(t, err)
+	require.NoError(t, cache.Save("aws", map[string]string{"token": "x"}, time.Now().Add(time.Hour)))
+
+	assert.Contains(t, logout(), "Removed 1 cached credential(s)")
+
+	var got map[string]string
+	assert.False(t, cache.Load("aws", &got))
+}
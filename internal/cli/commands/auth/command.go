@@ -0,0 +1,63 @@
+// Package auth provides the "suve auth" command group, which manages the
+// credential cache shared across suve invocations (see internal/credcache).
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/credcache"
+)
+
+// Command returns the auth command group.
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "auth",
+		Usage: "Manage cached cloud credentials",
+		Description: `Manage the credential cache.
+
+With ` + credcache.EnvCredentialCache + `=1, suve keeps short-lived credentials
+(assumed-role and SSO sessions, Google Cloud and Azure access tokens) in
+~/.suve/credentials/ until they expire, so consecutive commands skip the STS
+call or MFA prompt. Entries are encrypted with the staging data key.`,
+		Commands: []*cli.Command{
+			logoutCommand(),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// logoutCommand removes every cached credential.
+func logoutCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "logout",
+		Usage: "Remove every cached credential",
+		Description: `Remove every entry from the credential cache (~/.suve/credentials/).
+
+The next command signs in again through the provider's credential chain. This
+does not sign out of the cloud CLIs (aws sso logout, gcloud auth revoke,
+az logout).`,
+		Action: logout,
+	}
+}
+
+func logout(_ context.Context, cmd *cli.Command) error {
+	removed, err := credcache.Clear()
+	if err != nil {
+		return fmt.Errorf("failed to clear the credential cache: %w", err)
+	}
+
+	if removed == 0 {
+		output.Info(cmd.Root().Writer, "No cached credentials.")
+
+		return nil
+	}
+
+	output.Success(cmd.Root().Writer, "Removed %d cached credential(s)", removed)
+
+	return nil
+}
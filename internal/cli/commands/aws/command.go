@@ -8,12 +8,17 @@
 package aws
 
 import (
+	"context"
+	"os"
+
 	"github.com/urfave/cli/v3"
 
 	"github.com/mpyw/suve/internal/cli/commands/aws/param"
 	"github.com/mpyw/suve/internal/cli/commands/aws/secret"
 	"github.com/mpyw/suve/internal/cli/commands/aws/stage"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/terminal"
+	"github.com/mpyw/suve/internal/provider/aws/infra"
 )
 
 // Command returns the aws command group with the param (Parameter Store),
@@ -29,7 +34,15 @@ func Command() *cli.Command {
   - "suve aws stage"  stages changes to the above before applying them.
 
 Region and credentials come from the ambient AWS configuration (environment,
-shared config/credentials files, or an instance role).`,
+shared config/credentials files, or an instance role). --profile selects a
+profile from the shared config instead, and --role-arn assumes a role on top
+of it; suve prompts for an MFA code when the role needs one. Changes staged
+with --profile are kept apart from those staged without it.`,
+		Flags: credentialFlags(),
+		// Before stashes the credential options in the context, where every AWS
+		// client built below picks them up. Nothing is resolved until a client
+		// is, so `suve aws param --help` works without credentials.
+		Before: resolveCredentials,
 		Commands: []*cli.Command{
 			param.Command(),
 			secret.Command(),
@@ -38,3 +51,73 @@ shared config/credentials files, or an instance role).`,
 		CommandNotFound: cliinternal.CommandNotFound,
 	}
 }
+
+// FlatParamCommand returns the Parameter Store command as a standalone
+// top-level command named `name`. Because there is no parent aws group to
+// carry them, it folds in the credential flags and Before hook. Used for the
+// flat `suve param` alias when AWS is the uniquely active param provider.
+func FlatParamCommand(name string) *cli.Command {
+	return flatten(param.Command(), name)
+}
+
+// FlatSecretCommand returns the Secrets Manager command as a standalone
+// top-level command named `name`, folding in the credential flags like
+// FlatParamCommand.
+func FlatSecretCommand(name string) *cli.Command {
+	return flatten(secret.Command(), name)
+}
+
+// FlatStageCommand returns the staging command as a standalone top-level
+// command named `name`, folding in the credential flags like FlatParamCommand.
+func FlatStageCommand(name string) *cli.Command {
+	return flatten(stage.Command(), name)
+}
+
+func flatten(c *cli.Command, name string) *cli.Command {
+	c.Name = name
+	c.Flags = credentialFlags()
+	c.Before = resolveCredentials
+
+	return c
+}
+
+// credentialFlags returns the shared profile and role flags (a fresh slice per
+// call so each command owns its flag instances).
+func credentialFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "profile",
+			Usage: "Shared config profile to use (defaults to $AWS_PROFILE)",
+		},
+		&cli.StringFlag{
+			Name:  "role-arn",
+			Usage: "ARN of a role to assume with the profile's credentials",
+		},
+		&cli.StringFlag{
+			Name:  "external-id",
+			Usage: "External id to pass when assuming --role-arn",
+		},
+		&cli.StringFlag{
+			Name:  "session-name",
+			Usage: `Role session name for --role-arn (default: "` + infra.DefaultSessionName + `")`,
+		},
+		&cli.StringFlag{
+			Name:  "mfa-serial",
+			Usage: "ARN of the MFA device --role-arn requires (prompts for a code)",
+		},
+	}
+}
+
+// resolveCredentials stashes the credential flags into the context, with a
+// token provider that prompts on the terminal for MFA-protected roles (both
+// --role-arn with --mfa-serial and profiles that set mfa_serial).
+func resolveCredentials(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	return infra.WithOptions(ctx, infra.Options{
+		Profile:       cmd.String("profile"),
+		RoleARN:       cmd.String("role-arn"),
+		ExternalID:    cmd.String("external-id"),
+		SessionName:   cmd.String("session-name"),
+		MFASerial:     cmd.String("mfa-serial"),
+		TokenProvider: terminal.MFATokenProvider(os.Stdin, os.Stderr),
+	}), nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/urfave/cli/v3"
 
 	genericdiff "github.com/mpyw/suve/internal/cli/commands/generic/diff"
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
 	"github.com/mpyw/suve/internal/provider"
@@ -111,5 +112,8 @@ For comparing staged values, use: suve stage param diff`,
 
 			return NewDiffPresenter(store, spec1, spec2), nil
 		},
+		Store: cliinternal.ParamStore,
+		Kind:  provider.KindParam,
+		Axis:  fanout.Regions(),
 	})
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/samber/lo"
 	"github.com/urfave/cli/v3"
 
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	genericlist "github.com/mpyw/suve/internal/cli/commands/generic/list"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/usecase/param"
@@ -62,6 +63,7 @@ EXAMPLES:
 				Usage: "Output format: text (default) or json",
 			},
 		},
+		Axis: fanout.Regions(),
 		NewList: func(
 			ctx context.Context, cmd *cli.Command, withValue bool,
 		) (func(context.Context) ([]genericlist.Entry, error), error) {
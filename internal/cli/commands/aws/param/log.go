@@ -12,6 +12,7 @@ import (
 
 	"github.com/mpyw/suve/internal/cli/colors"
 	"github.com/mpyw/suve/internal/cli/commands/aws/param/paramtype"
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	genericlog "github.com/mpyw/suve/internal/cli/commands/generic/log"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
@@ -27,6 +28,7 @@ type logJSONItem struct {
 	Version  int64   `json:"version"`
 	Type     string  `json:"type,omitempty"`
 	Modified string  `json:"modified,omitempty"`
+	Message  string  `json:"message,omitempty"`
 	Value    *string `json:"value,omitempty"` // nil when error, pointer to distinguish from empty string
 	Error    string  `json:"error,omitempty"`
 }
@@ -70,6 +72,7 @@ func (p *logPresenter) RenderJSON(stdout io.Writer) error {
 	for i, entry := range entries {
 		items[i] = logJSONItem{
 			Version: entry.Version,
+			Message: entry.Message,
 		}
 		if entry.LastModified != nil {
 			items[i].Modified = timeutil.FormatRFC3339(*entry.LastModified)
@@ -143,6 +146,10 @@ func (p *logPresenter) RenderHeader(stdout io.Writer, i int) {
 	if entry.LastModified != nil {
 		output.Printf(stdout, "%s %s\n", colors.For(stdout).FieldLabel("Date:"), timeutil.FormatRFC3339(*entry.LastModified))
 	}
+
+	if entry.Message != "" {
+		output.Printf(stdout, "%s %s\n", colors.For(stdout).FieldLabel("Message:"), entry.Message)
+	}
 }
 
 func (p *logPresenter) RenderValue(stdout io.Writer, i, maxValueLength int) {
@@ -324,6 +331,7 @@ EXAMPLES:
 				Usage: "Maximum value preview length (0 = auto: unlimited for normal, terminal width for oneline)",
 			},
 		},
+		Axis: fanout.Regions(),
 		NewPresenter: func(ctx context.Context, req genericlog.Request) (genericlog.Presenter, error) {
 			store, err := cliinternal.ParamStore(ctx)
 			if err != nil {
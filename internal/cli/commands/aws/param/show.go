@@ -10,6 +10,7 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/mpyw/suve/internal/cli/commands/aws/param/paramtype"
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	genericshow "github.com/mpyw/suve/internal/cli/commands/generic/show"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
@@ -164,7 +165,8 @@ EXAMPLES:
   DB_URL=$(suve param show --raw /app/config)               Use in shell variable`,
 		UsageError: "usage: suve param show <name>",
 		ParseSpec:  awsparamversion.Parse,
-		NewPresenter: func(ctx context.Context, spec *awsparamversion.Spec) (genericshow.Presenter, error) {
+		Axis:       fanout.Regions(),
+		NewPresenter: func(ctx context.Context, _ *cli.Command, spec *awsparamversion.Spec) (genericshow.Presenter, error) {
 			store, err := cliinternal.ParamStore(ctx)
 			if err != nil {
 				return nil, err
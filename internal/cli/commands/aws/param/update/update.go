@@ -13,7 +13,6 @@ import (
 	"github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/confirm"
 	"github.com/mpyw/suve/internal/cli/output"
-	"github.com/mpyw/suve/internal/provider/aws/infra"
 	"github.com/mpyw/suve/internal/usecase/param"
 )
 
@@ -189,7 +188,7 @@ func action(ctx context.Context, cmd *cli.Command) error {
 			Stdout: cmd.Root().Writer,
 			Stderr: cmd.Root().ErrWriter,
 		}
-		if identity, _ := infra.GetAWSIdentity(ctx); identity != nil {
+		if identity, _ := internal.AWSIdentity(ctx); identity != nil {
 			prompter.AccountID = identity.AccountID
 			prompter.Region = identity.Region
 			prompter.Profile = identity.Profile
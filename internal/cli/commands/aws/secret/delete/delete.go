@@ -135,7 +135,7 @@ func action(ctx context.Context, cmd *cli.Command) error {
 	// Get AWS identity for confirmation display
 	var identity *infra.AWSIdentity
 	if !skipConfirm {
-		identity, _ = infra.GetAWSIdentity(ctx)
+		identity, _ = internal.AWSIdentity(ctx)
 	}
 
 	uc := &secret.DeleteUseCase{Store: store}
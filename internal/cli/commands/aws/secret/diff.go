@@ -8,6 +8,7 @@ import (
 	"github.com/urfave/cli/v3"
 
 	genericdiff "github.com/mpyw/suve/internal/cli/commands/generic/diff"
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
 	"github.com/mpyw/suve/internal/provider"
@@ -113,5 +114,8 @@ For comparing staged values, use: suve stage secret diff`,
 
 			return NewDiffPresenter(store, spec1, spec2), nil
 		},
+		Store: cliinternal.SecretStore,
+		Kind:  provider.KindSecret,
+		Axis:  fanout.Regions(),
 	})
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/mpyw/suve/internal/cli/colors"
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	genericlog "github.com/mpyw/suve/internal/cli/commands/generic/log"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
@@ -24,6 +25,7 @@ type logJSONItem struct {
 	VersionID string   `json:"versionId"`
 	Stages    []string `json:"stages,omitempty"`
 	Created   string   `json:"created,omitempty"`
+	Message   string   `json:"message,omitempty"`
 	Value     *string  `json:"value,omitempty"` // nil when error, pointer to distinguish from empty string
 	Error     string   `json:"error,omitempty"`
 }
@@ -74,6 +76,7 @@ func (p *logPresenter) RenderJSON(stdout io.Writer) error {
 	items := lo.Map(p.result.Entries, func(entry secret.LogEntry, _ int) logJSONItem {
 		item := logJSONItem{
 			VersionID: entry.VersionID,
+			Message:   entry.Message,
 		}
 		if len(entry.VersionStage) > 0 {
 			item.Stages = entry.VersionStage
@@ -130,6 +133,10 @@ func (p *logPresenter) RenderHeader(stdout io.Writer, i int) {
 	if entry.CreatedDate != nil {
 		output.Printf(stdout, "%s %s\n", colors.For(stdout).FieldLabel("Date:"), timeutil.FormatRFC3339(*entry.CreatedDate))
 	}
+
+	if entry.Message != "" {
+		output.Printf(stdout, "%s %s\n", colors.For(stdout).FieldLabel("Message:"), entry.Message)
+	}
 }
 
 // RenderValue is a no-op: Secrets Manager log does not show a default value
@@ -260,6 +267,7 @@ EXAMPLES:
 				Usage: "Output format: text (default) or json",
 			},
 		},
+		Axis: fanout.Regions(),
 		NewPresenter: func(ctx context.Context, req genericlog.Request) (genericlog.Presenter, error) {
 			store, err := cliinternal.SecretStore(ctx)
 			if err != nil {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	genericshow "github.com/mpyw/suve/internal/cli/commands/generic/show"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
@@ -69,7 +70,9 @@ func (p *showPresenter) RenderText(stdout io.Writer, value string) {
 
 	out := output.New(stdout)
 	out.Field("Name", result.Name)
-	out.Field("ARN", result.ARN)
+	if result.ARN != "" {
+		out.Field("ARN", result.ARN)
+	}
 
 	if result.VersionID != "" {
 		out.Field("VersionId", result.VersionID)
@@ -156,7 +159,8 @@ EXAMPLES:
   API_KEY=$(suve secret show --raw my-secret)             Use in shell variable`,
 		UsageError: "usage: suve secret show <name>",
 		ParseSpec:  awssecretversion.Parse,
-		NewPresenter: func(ctx context.Context, spec *awssecretversion.Spec) (genericshow.Presenter, error) {
+		Axis:       fanout.Regions(),
+		NewPresenter: func(ctx context.Context, _ *cli.Command, spec *awssecretversion.Spec) (genericshow.Presenter, error) {
 			store, err := cliinternal.SecretStore(ctx)
 			if err != nil {
 				return nil, err
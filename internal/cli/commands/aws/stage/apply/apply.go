@@ -7,16 +7,19 @@ import (
 	"fmt"
 	"io"
 	"maps"
+	"slices"
 	"strings"
 
 	"github.com/urfave/cli/v3"
 
 	"github.com/mpyw/suve/internal/cli/confirm"
 	"github.com/mpyw/suve/internal/cli/output"
-	"github.com/mpyw/suve/internal/parallel"
+	"github.com/mpyw/suve/internal/maputil"
+	"github.com/mpyw/suve/internal/retry"
 	"github.com/mpyw/suve/internal/staging"
 	stgcli "github.com/mpyw/suve/internal/staging/cli"
 	"github.com/mpyw/suve/internal/staging/store"
+	stagingusecase "github.com/mpyw/suve/internal/usecase/staging"
 )
 
 // ServiceApply pairs a service with its (possibly nil) apply strategy. The
@@ -31,10 +34,27 @@ type ServiceApply struct {
 	// StrategyFor, when set, resolves a strategy per namespace so each App
 	// Configuration entry applies under its own namespace. Nil elsewhere.
 	StrategyFor func(namespace string) (staging.ApplyStrategy, error)
+	// Target is the service's resolved staging scope.
+	Target string
+	// DiffFor resolves the strategy that reads an item's remote state under its
+	// namespace; saved plans record and re-check the remote base through it.
+	DiffFor func(namespace string) (staging.DiffStrategy, error)
 	// Entries/Tags are this service's staged changes, pre-listed from its store,
 	// keyed by the (name, namespace) EntryKey.
 	Entries map[staging.EntryKey]staging.Entry
 	Tags    map[staging.EntryKey]staging.TagEntry
+	// Journal, when set, is the apply journal of the service's working store;
+	// JournalScope keys that store's scope, so services sharing one scope are
+	// recorded together (see journal.go).
+	Journal      stagingusecase.ApplyJournal
+	JournalScope string
+}
+
+// appliedKeys holds the keys of one service whose entry and tag writes
+// succeeded.
+type appliedKeys struct {
+	entries []staging.EntryKey
+	tags    []staging.EntryKey
 }
 
 // strategyForNamespace returns the strategy scoped to the given namespace, or the
@@ -67,6 +87,9 @@ type conflictItem struct {
 	key         staging.EntryKey
 }
 
+// flagUnlockLocked unlocks, writes and relocks read-only entries.
+const flagUnlockLocked = "unlock-locked"
+
 // Runner executes the apply command.
 type Runner struct {
 	// Services lists the configured provider services (with staged changes) in
@@ -77,14 +100,34 @@ type Runner struct {
 	Stdout          io.Writer
 	Stderr          io.Writer
 	IgnoreConflicts bool
+	// UnlockLocked writes entries that are locked read-only (App
+	// Configuration) by unlocking them, applying, and locking them again.
+	// Without it a locked entry is not attempted and fails as locked.
+	UnlockLocked bool
+	// Atomic applies all or nothing across every service (see settleAtomic and
+	// usecase/staging/rollback.go).
+	Atomic bool
+	// Message is the change message recorded with every written value (see
+	// staging.WithChangeMessage) and in the apply journal.
+	Message string
+	// MessageTag, when set along with Message, also sets the message as this
+	// tag on each created or updated entry.
+	MessageTag string
+	// Order and Concurrency schedule each service's writes (see
+	// staging.ApplySchedule); throttled writes are retried with Retry (zero
+	// for retry.Default).
+	Order       staging.ApplyOrder
+	Concurrency int
+	Retry       retry.Policy
 }
 
 // Command returns the global apply command for the given provider config.
 func Command(cfg stgcli.GlobalConfig) *cli.Command {
 	return &cli.Command{
-		Name:    "apply",
-		Aliases: []string{"push"},
-		Usage:   "Apply all staged changes",
+		Name:      "apply",
+		Aliases:   []string{"push"},
+		Usage:     "Apply all staged changes",
+		ArgsUsage: "[plan-file]",
 		Description: `Apply all staged changes for the active provider's services.
 
 After successful apply, the staged changes are cleared.
@@ -97,11 +140,39 @@ CONFLICT DETECTION:
    - For existing resources: checks if it was modified after staging
    Use --ignore-conflicts to force apply despite conflicts.
 
+SAVED PLANS:
+   Given a plan file written by 'suve stage plan -o <file>', apply first
+   checks the plan's digest, which catches an accidental edit of the file but
+   not a deliberate one. It then refuses to run if any staged change or remote
+   entry differs from what the plan recorded. The plan's exact check replaces
+   the timestamp-based conflict detection above.
+
+ATOMIC APPLY:
+   With --atomic, every target's current state is recorded before the first
+   write. If any change fails, every entry already written is rolled back:
+   prior values are put back, deleted entries are restored or re-created, and
+   created ones are deleted. Everything stays staged, and each rollback is
+   reported.
+
+` + locksDescription(cfg) + stgcli.ChangeMessageDescription + `
+
+` + stgcli.ScheduleDescription + `
+
+` + stgcli.SelectorDescription(cfg.HasNamespaces()) + `
+   Selector flags cannot be combined with a saved plan.
+
 EXAMPLES:
    suve stage apply                      Apply all staged changes (with confirmation)
    suve stage apply --yes                Apply without confirmation
-   suve stage apply --ignore-conflicts   Apply even if conflicts detected`,
-		Flags: []cli.Flag{
+   suve stage apply --ignore-conflicts   Apply even if conflicts detected
+   suve stage apply --atomic             Apply all or nothing
+   suve stage apply -m "rotate db creds" Record why the changes are made
+   suve stage apply --order order.txt    Apply in the order the file lists
+   suve stage apply --concurrency 3      Apply at most 3 changes at once
+   suve stage apply --match '/app/db/*' --op delete --exclude '/app/db/legacy'
+                                         Apply only the selected changes
+   suve stage apply plan.suve            Apply exactly the reviewed plan`,
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:  "yes",
 				Usage: "Skip confirmation prompt",
@@ -110,7 +181,13 @@ EXAMPLES:
 				Name:  "ignore-conflicts",
 				Usage: "Apply even if the remote store was modified after staging",
 			},
-		},
+			&cli.BoolFlag{
+				Name:  "atomic",
+				Usage: "Apply all or nothing: roll back applied changes if any change fails",
+			},
+		}, slices.Concat(
+			lockFlags(cfg), stgcli.ChangeMessageFlags(), stgcli.ScheduleFlags(), stgcli.SelectorFlags(cfg.HasNamespaces()),
+		)...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			return runAction(ctx, cmd, cfg)
 		},
@@ -126,11 +203,12 @@ type workingStoreResolver func(
 
 // gatherServices lists each CONFIGURED service's staged changes from its OWN
 // store, skipping any service whose scope is not configured (it can hold no
-// staged state). It returns the services that actually have staged changes,
-// their confirmation targets, and the total staged count. resolve is injected so
-// tests can drive skip-unconfigured and store-error propagation.
+// staged state). It returns the services that actually have staged changes
+// selected by sel, their confirmation targets, and the total selected count.
+// resolve is injected so tests can drive skip-unconfigured and store-error
+// propagation.
 func gatherServices(
-	ctx context.Context, cfg stgcli.GlobalConfig, resolve workingStoreResolver,
+	ctx context.Context, cfg stgcli.GlobalConfig, sel staging.Selector, resolve workingStoreResolver,
 ) (svcs []ServiceApply, targets []string, totalStaged int, err error) {
 	for _, spec := range cfg.Services {
 		st, resolved, err := resolve(ctx, spec.ScopeResolver)
@@ -152,8 +230,8 @@ func gatherServices(
 			return nil, nil, 0, err
 		}
 
-		svcEntries := entries[spec.Service]
-		svcTags := tags[spec.Service]
+		svcEntries := sel.SelectEntries(entries[spec.Service])
+		svcTags := sel.SelectTags(tags[spec.Service])
 
 		totalStaged += len(svcEntries) + len(svcTags)
 		if len(svcEntries) == 0 && len(svcTags) == 0 {
@@ -165,14 +243,20 @@ func gatherServices(
 			return nil, nil, 0, err
 		}
 
+		journal, _ := st.(stagingusecase.ApplyJournal)
+
 		targets = append(targets, resolved.Target)
 		svcs = append(svcs, ServiceApply{
-			Service:     spec.Service,
-			Store:       st,
-			Strategy:    strategy,
-			StrategyFor: applyStrategyFor(ctx, spec),
-			Entries:     svcEntries,
-			Tags:        svcTags,
+			Service:      spec.Service,
+			Store:        st,
+			Strategy:     strategy,
+			StrategyFor:  applyStrategyFor(ctx, spec),
+			Target:       resolved.Target,
+			DiffFor:      diffStrategyFor(ctx, spec, strategy),
+			Entries:      svcEntries,
+			Tags:         svcTags,
+			Journal:      journal,
+			JournalScope: resolved.Scope.Key(),
 		})
 	}
 
@@ -180,18 +264,50 @@ func gatherServices(
 }
 
 func runAction(ctx context.Context, cmd *cli.Command, cfg stgcli.GlobalConfig) error {
-	resolve := func(
-		ctx context.Context, resolver staging.ScopeResolver,
-	) (store.ReadWriteOperator, staging.ResolvedScope, error) {
-		return stgcli.WorkingStore(ctx, resolver)
+	if cmd.Args().Len() > 1 {
+		return fmt.Errorf("usage: suve stage apply [plan-file]")
+	}
+
+	sel, err := stgcli.SelectorFromCmd(cmd, cfg.HasNamespaces())
+	if err != nil {
+		return err
+	}
+
+	order, concurrency, err := stgcli.ScheduleFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	var plan *staging.Plan
+
+	if path := cmd.Args().First(); path != "" {
+		if !sel.IsZero() {
+			return errors.New("selector flags cannot be combined with a plan file")
+		}
+
+		if plan, err = readPlan(path); err != nil {
+			return err
+		}
 	}
 
-	svcs, targets, totalStaged, err := gatherServices(ctx, cfg, resolve)
+	svcs, targets, totalStaged, err := gatherServices(ctx, cfg, sel, defaultWorkingStore)
 	if err != nil {
 		return err
 	}
 
+	if plan != nil {
+		if err := verifyPlan(ctx, cmd.Root().ErrWriter, plan, cfg.ProviderLabel, svcs); err != nil {
+			return err
+		}
+	}
+
 	if totalStaged == 0 {
+		if !sel.IsZero() {
+			output.Info(cmd.Root().Writer, "No staged changes match the selection.")
+
+			return nil
+		}
+
 		output.Info(cmd.Root().Writer, "No changes staged.")
 
 		return nil
@@ -216,17 +332,53 @@ func runAction(ctx context.Context, cmd *cli.Command, cfg stgcli.GlobalConfig) e
 		return nil
 	}
 
+	message, messageTag := stgcli.ChangeMessageFromCmd(cmd)
+
 	r := &Runner{
 		Services:        svcs,
 		ProviderLabel:   cfg.ProviderLabel,
 		Stdout:          cmd.Root().Writer,
 		Stderr:          cmd.Root().ErrWriter,
-		IgnoreConflicts: cmd.Bool("ignore-conflicts"),
+		IgnoreConflicts: cmd.Bool("ignore-conflicts") || plan != nil,
+		UnlockLocked:    cfg.HasLocks() && cmd.Bool(flagUnlockLocked),
+		Atomic:          cmd.Bool("atomic"),
+		Message:         message,
+		MessageTag:      messageTag,
+		Order:           order,
+		Concurrency:     concurrency,
 	}
 
 	return r.Run(ctx)
 }
 
+// lockFlags returns --unlock-locked when a service can lock entries read-only.
+func lockFlags(cfg stgcli.GlobalConfig) []cli.Flag {
+	if !cfg.HasLocks() {
+		return nil
+	}
+
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  flagUnlockLocked,
+			Usage: "Unlock read-only entries, apply, and lock them again",
+		},
+	}
+}
+
+// locksDescription documents read-only lock handling when a service can lock
+// entries (empty otherwise).
+func locksDescription(cfg stgcli.GlobalConfig) string {
+	if !cfg.HasLocks() {
+		return ""
+	}
+
+	return `READ-ONLY LOCKS:
+   A locked entry is detected before writing and reported as locked rather
+   than attempted. Use --unlock-locked to unlock it, apply, and lock it again.
+
+`
+}
+
 // applyStrategyFor adapts a spec's StrategyForNamespace to the per-entry resolver
 // used during apply, or nil when the service has no namespace axis.
 func applyStrategyFor(ctx context.Context, spec stgcli.GlobalServiceSpec) func(string) (staging.ApplyStrategy, error) {
@@ -239,8 +391,24 @@ func applyStrategyFor(ctx context.Context, spec stgcli.GlobalServiceSpec) func(s
 	}
 }
 
+// diffStrategyFor adapts a spec's StrategyForNamespace to the per-item remote
+// reader used by saved plans, falling back to the service's single strategy.
+func diffStrategyFor(
+	ctx context.Context, spec stgcli.GlobalServiceSpec, strategy staging.FullStrategy,
+) func(string) (staging.DiffStrategy, error) {
+	return func(namespace string) (staging.DiffStrategy, error) {
+		if spec.StrategyForNamespace == nil {
+			return strategy, nil
+		}
+
+		return spec.StrategyForNamespace(ctx, namespace)
+	}
+}
+
 // Run executes the apply command over the pre-gathered per-service stores.
 func (r *Runner) Run(ctx context.Context) error {
+	ctx = staging.WithChangeMessage(ctx, r.Message)
+
 	// Check for conflicts unless --ignore-conflicts is specified.
 	if !r.IgnoreConflicts {
 		var checks []serviceConflictCheck
@@ -269,64 +437,215 @@ func (r *Runner) Run(ctx context.Context) error {
 
 			return fmt.Errorf("apply rejected: %d conflict(s) detected (use --ignore-conflicts to force)", len(allConflicts))
 		}
+	} else {
+		// Forcing past conflicts (explicitly, or for a reviewed plan) must also
+		// drop the base-version write preconditions, or App Configuration
+		// still rejects a moved setting with 412.
+		for i := range r.Services {
+			r.Services[i].Entries = staging.WithoutBaseVersion(r.Services[i].Entries)
+		}
+	}
+
+	// Detect read-only locks up front, so a locked entry is reported as such
+	// rather than as whatever error the write would have surfaced. An atomic
+	// apply refuses them before any write, as they would fail for sure.
+	locked, lockedCount := r.detectLocked(ctx)
+	if lockedCount > 0 && !r.UnlockLocked {
+		if r.Atomic {
+			return fmt.Errorf("atomic apply rejected: %d locked entries (use --%s to write them)", lockedCount, flagUnlockLocked)
+		}
+
+		output.Warning(r.Stderr, "%d entries locked read-only and not applied; re-run with --%s to unlock, write and relock",
+			lockedCount, flagUnlockLocked)
+	}
+
+	// An atomic apply writes nothing unless every target could be snapshotted;
+	// a journaled one goes ahead, unrecorded, when that fails.
+	var snapshots []map[staging.EntryKey]staging.Snapshot
+
+	switch {
+	case r.Atomic:
+		var err error
+		if snapshots, err = r.snapshot(ctx); err != nil {
+			return fmt.Errorf("atomic apply aborted before writing: %w", err)
+		}
+	case r.journaled():
+		var err error
+		if snapshots, err = r.snapshot(ctx); err != nil {
+			output.Warning(r.Stderr, "apply not recorded in the apply journal: previous state not captured: %v", err)
+		}
 	}
 
 	var totalSucceeded, totalFailed int
 
+	// applied collects, per service, the keys with a successful write; each
+	// service's writer records every key whose write landed, even when a later
+	// step for it failed.
+	applied := make([]appliedKeys, len(r.Services))
+	writers := make([]*stagingusecase.Writer, len(r.Services))
+
+	for i, svc := range r.Services {
+		writers[i] = &stagingusecase.Writer{
+			Resolve:      svc.strategyForNamespace,
+			Schedule:     staging.ApplySchedule{Order: r.Order, Concurrency: r.Concurrency, Retry: r.Retry},
+			Locked:       locked[i],
+			UnlockLocked: r.UnlockLocked,
+			MessageTag:   r.MessageTag,
+		}
+	}
+
 	// Apply value changes in service order.
-	for _, svc := range r.Services {
+	for i, svc := range r.Services {
 		if len(svc.Entries) == 0 {
 			continue
 		}
 
 		output.Info(r.Stdout, "Applying %s...", svc.Strategy.ServiceName())
-		succeeded, failed := r.applyService(ctx, svc)
-		totalSucceeded += succeeded
+		keys, failed := r.applyService(ctx, svc, writers[i])
+		applied[i].entries = keys
+		totalSucceeded += len(keys)
 		totalFailed += failed
 	}
 
 	// Apply tag changes in service order.
-	for _, svc := range r.Services {
+	for i, svc := range r.Services {
 		if len(svc.Tags) == 0 {
 			continue
 		}
 
 		output.Info(r.Stdout, "Applying %s tags...", svc.Strategy.ServiceName())
-		succeeded, failed := r.applyTagService(ctx, svc)
-		totalSucceeded += succeeded
+		keys, failed := r.applyTagService(ctx, svc, writers[i])
+		applied[i].tags = keys
+		totalSucceeded += len(keys)
 		totalFailed += failed
 	}
 
-	// Summary
-	if totalFailed > 0 {
-		return fmt.Errorf("applied %d, failed %d", totalSucceeded, totalFailed)
+	var err error
+
+	if r.Atomic {
+		err = r.settleAtomic(ctx, snapshots, applied, writers, totalFailed)
+	} else if totalFailed > 0 {
+		err = fmt.Errorf("applied %d, failed %d", totalSucceeded, totalFailed)
 	}
 
-	return nil
+	if snapshots != nil {
+		r.journal(ctx, snapshots, applied)
+	}
+
+	return err
 }
 
-func (r *Runner) applyService(ctx context.Context, svc ServiceApply) (succeeded, failed int) {
-	serviceName := svc.Strategy.ServiceName()
+// snapshot captures, per service, the remote state of every staged entry and
+// tagged item before an atomic or journaled apply writes any of them (see
+// stagingusecase.Snapshot).
+func (r *Runner) snapshot(ctx context.Context) ([]map[staging.EntryKey]staging.Snapshot, error) {
+	snapshots := make([]map[staging.EntryKey]staging.Snapshot, len(r.Services))
 
-	// Entries are keyed by the (name, namespace) EntryKey; apply each through the
-	// strategy scoped to its own namespace and unstage under that key.
-	results := parallel.ExecuteMap(ctx, svc.Entries, func(ctx context.Context, key staging.EntryKey, entry staging.Entry) (staging.Operation, error) {
-		strategy, err := svc.strategyForNamespace(key.Namespace)
+	for i, svc := range r.Services {
+		snapshot, err := stagingusecase.Snapshot(ctx, svc.strategyForNamespace, svc.Entries, svc.Tags)
 		if err != nil {
-			return entry.Operation, err
+			return nil, fmt.Errorf("%s: %w", svc.Strategy.ServiceName(), err)
+		}
+
+		snapshots[i] = snapshot
+	}
+
+	return snapshots, nil
+}
+
+// settleAtomic finishes an atomic apply. When nothing failed every service's
+// staged changes are cleared; otherwise every written key of every service is
+// rolled back to its snapshot (see stagingusecase.Rollback) and dropped from
+// applied once rolled back, and everything stays staged.
+func (r *Runner) settleAtomic(
+	ctx context.Context, snapshots []map[staging.EntryKey]staging.Snapshot, applied []appliedKeys,
+	writers []*stagingusecase.Writer, failed int,
+) error {
+	if failed == 0 {
+		for _, svc := range r.Services {
+			for key := range svc.Entries {
+				if err := svc.Store.UnstageEntry(ctx, svc.Service, key); err != nil {
+					output.Warning(r.Stderr, "failed to clear staging for %s: %v", key.Name, err)
+				}
+			}
+
+			for key := range svc.Tags {
+				if err := svc.Store.UnstageTag(ctx, svc.Service, key); err != nil {
+					output.Warning(r.Stderr, "failed to clear staging for %s tags: %v", key.Name, err)
+				}
+			}
+		}
+
+		return nil
+	}
+
+	output.Warning(r.Stderr, "atomic apply failed; rolling back written changes (all changes remain staged)")
+
+	var total, rolledBack int
+
+	for i, svc := range r.Services {
+		serviceName := svc.Strategy.ServiceName()
+		undone := maputil.NewSet[staging.EntryKey]()
+
+		for _, result := range stagingusecase.Rollback(ctx, svc.strategyForNamespace, snapshots[i], writers[i].Written.Keys(), writers[i].Locked) {
+			key := staging.EntryKey{Name: result.Name, Namespace: result.Namespace}
+			total++
+
+			if result.Error != nil {
+				output.Failed(r.Stderr, serviceName+": "+key.Label()+" (rollback)", result.Error)
+
+				continue
+			}
+
+			output.Success(r.Stdout, "%s: Rolled back %s", serviceName, key.Label())
+
+			undone.Add(key)
+			rolledBack++
 		}
 
-		return entry.Operation, strategy.Apply(ctx, key.Name, entry)
-	})
+		applied[i].entries = slices.DeleteFunc(applied[i].entries, undone.Contains)
+		applied[i].tags = slices.DeleteFunc(applied[i].tags, undone.Contains)
+	}
+
+	return fmt.Errorf("atomic apply failed: %d failed; rolled back %d of %d written item(s)", failed, rolledBack, total)
+}
+
+// detectLocked returns, per service, the staged keys whose remote entry is
+// locked read-only, and their total.
+func (r *Runner) detectLocked(ctx context.Context) (locked []map[staging.EntryKey]struct{}, total int) {
+	locked = make([]map[staging.EntryKey]struct{}, len(r.Services))
+
+	for i, svc := range r.Services {
+		locked[i] = staging.DetectLocked(ctx, svc.strategyForNamespace, svc.Entries, svc.Tags)
+		total += len(locked[i])
+	}
+
+	return locked, total
+}
+
+// applyService applies svc's staged entries through writer and returns the
+// keys that were applied and the number that failed.
+func (r *Runner) applyService(
+	ctx context.Context, svc ServiceApply, writer *stagingusecase.Writer,
+) (applied []staging.EntryKey, failed int) {
+	serviceName := svc.Strategy.ServiceName()
+
+	// The live progress is erased before the service's results are printed.
+	progress := stgcli.StartProgress(r.Stderr)
+	progressCtx := progress.Context(ctx)
+	staging.ReportQueued(progressCtx, r.Order, svc.Entries, nil)
+
+	errs, messageTagErrs := writer.WriteEntries(progressCtx, svc.Entries)
+
+	progress.Stop()
 
 	for _, key := range staging.SortedEntryKeys(svc.Entries) {
-		result := results[key]
-		if result.Err != nil {
-			output.Failed(r.Stderr, serviceName+": "+key.Name, result.Err)
+		if err := errs[key]; err != nil {
+			output.Failed(r.Stderr, serviceName+": "+key.Name, err)
 
 			failed++
 		} else {
-			switch result.Value {
+			switch svc.Entries[key].Operation {
 			case staging.OperationCreate:
 				output.Success(r.Stdout, "%s: Created %s", serviceName, key.Name)
 			case staging.OperationUpdate:
@@ -335,51 +654,60 @@ func (r *Runner) applyService(ctx context.Context, svc ServiceApply) (succeeded,
 				output.Success(r.Stdout, "%s: Deleted %s", serviceName, key.Name)
 			}
 
-			if err := svc.Store.UnstageEntry(ctx, svc.Service, key); err != nil {
-				output.Warning(r.Stderr, "failed to clear staging for %s: %v", key.Name, err)
+			if err := messageTagErrs[key]; err != nil {
+				output.Warning(r.Stderr, "%s: failed to set the change message tag on %s: %v", serviceName, key.Name, err)
+			}
+
+			// An atomic apply unstages only once everything succeeded.
+			if !r.Atomic {
+				if err := svc.Store.UnstageEntry(ctx, svc.Service, key); err != nil {
+					output.Warning(r.Stderr, "failed to clear staging for %s: %v", key.Name, err)
+				}
 			}
 
-			succeeded++
+			applied = append(applied, key)
 		}
 	}
 
-	return succeeded, failed
+	return applied, failed
 }
 
-func (r *Runner) applyTagService(ctx context.Context, svc ServiceApply) (succeeded, failed int) {
+// applyTagService applies svc's staged tag changes through writer and returns
+// the keys that were applied and the number that failed.
+func (r *Runner) applyTagService(
+	ctx context.Context, svc ServiceApply, writer *stagingusecase.Writer,
+) (applied []staging.EntryKey, failed int) {
 	serviceName := svc.Strategy.ServiceName()
 
-	// Tags share the (name, namespace) EntryKey; resolve the strategy per
-	// namespace so App Configuration tags target the right store partition.
-	results := parallel.ExecuteMap(ctx, svc.Tags, func(ctx context.Context, key staging.EntryKey, tagEntry staging.TagEntry) (struct{}, error) {
-		strategy, err := svc.strategyForNamespace(key.Namespace)
-		if err != nil {
-			return struct{}{}, err
-		}
+	progress := stgcli.StartProgress(r.Stderr)
+	progressCtx := progress.Context(ctx)
+	staging.ReportQueued(progressCtx, r.Order, nil, svc.Tags)
+
+	errs := writer.WriteTags(progressCtx, svc.Tags)
 
-		return struct{}{}, strategy.ApplyTags(ctx, key.Name, tagEntry)
-	})
+	progress.Stop()
 
 	for _, key := range staging.SortedEntryKeys(svc.Tags) {
 		tagEntry := svc.Tags[key]
 
-		result := results[key]
-		if result.Err != nil {
-			output.Failed(r.Stderr, serviceName+": "+key.Name+" (tags)", result.Err)
+		if err := errs[key]; err != nil {
+			output.Failed(r.Stderr, serviceName+": "+key.Name+" (tags)", err)
 
 			failed++
 		} else {
 			output.Success(r.Stdout, "%s: Tagged %s%s", serviceName, key.Name, formatTagApplySummary(tagEntry))
 
-			if err := svc.Store.UnstageTag(ctx, svc.Service, key); err != nil {
-				output.Warning(r.Stderr, "failed to clear staging for %s tags: %v", key.Name, err)
+			if !r.Atomic {
+				if err := svc.Store.UnstageTag(ctx, svc.Service, key); err != nil {
+					output.Warning(r.Stderr, "failed to clear staging for %s tags: %v", key.Name, err)
+				}
 			}
 
-			succeeded++
+			applied = append(applied, key)
 		}
 	}
 
-	return succeeded, failed
+	return applied, failed
 }
 
 func formatTagApplySummary(tagEntry staging.TagEntry) string {
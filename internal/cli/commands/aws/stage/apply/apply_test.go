@@ -3,7 +3,9 @@ package apply_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +16,8 @@ import (
 	"github.com/mpyw/suve/internal/cli/commands/aws/stage/apply"
 	"github.com/mpyw/suve/internal/cli/commands/internal/apptest"
 	"github.com/mpyw/suve/internal/maputil"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/retry"
 	"github.com/mpyw/suve/internal/staging"
 	"github.com/mpyw/suve/internal/staging/store"
 	"github.com/mpyw/suve/internal/staging/store/testutil"
@@ -651,15 +655,19 @@ func TestRun_ConflictDetection_IgnoreConflicts(t *testing.T) {
 		Value:          lo.ToPtr("updated-value"),
 		StagedAt:       time.Now(),
 		BaseModifiedAt: &baseTime,
+		BaseVersion:    "etag-1",
 	})
 
 	applyCalled := false
 	paramMock := newParamStrategy()
 	// AWS was modified after BaseModifiedAt (conflict)
 	paramMock.fetchLastModifiedVal = time.Now()
-	paramMock.applyFunc = func(_ context.Context, _ string, _ staging.Entry) error {
+	paramMock.applyFunc = func(_ context.Context, _ string, entry staging.Entry) error {
 		applyCalled = true
 
+		// Forcing the apply also drops the If-Match precondition.
+		assert.Empty(t, entry.BaseVersion)
+
 		return nil
 	}
 
@@ -678,6 +686,43 @@ func TestRun_ConflictDetection_IgnoreConflicts(t *testing.T) {
 	assert.True(t, applyCalled, "Apply should be called when IgnoreConflicts is true")
 }
 
+func TestRun_MessageTagFailureIsAWarning(t *testing.T) {
+	t.Parallel()
+
+	key := staging.EntryKey{Name: "/app/config"}
+
+	store := testutil.NewMockStore()
+	require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, key, staging.Entry{
+		Operation: staging.OperationUpdate,
+		Value:     lo.ToPtr("updated-value"),
+		StagedAt:  time.Now(),
+	}))
+
+	paramMock := newParamStrategy()
+	paramMock.applyTagsFunc = func(_ context.Context, _ string, _ staging.TagEntry) error {
+		return errors.New("tag quota exceeded")
+	}
+
+	var buf, errBuf bytes.Buffer
+
+	r := &apply.Runner{
+		Services:        []apply.ServiceApply{paramApply(paramMock, store)},
+		ProviderLabel:   "AWS",
+		Stdout:          &buf,
+		Stderr:          &errBuf,
+		IgnoreConflicts: true,
+		Message:         "rotate",
+		MessageTag:      "change-reason",
+	}
+
+	require.NoError(t, r.Run(t.Context()))
+	assert.Contains(t, buf.String(), "Updated /app/config")
+	assert.Contains(t, errBuf.String(), "failed to set the change message tag on /app/config: tag quota exceeded")
+
+	_, err := store.GetEntry(t.Context(), staging.ServiceParam, key)
+	require.ErrorIs(t, err, staging.ErrNotStaged, "the written entry is unstaged so it is not written twice")
+}
+
 func TestRun_ConflictDetection_NoConflict(t *testing.T) {
 	t.Parallel()
 
@@ -1098,6 +1143,63 @@ func TestRun_FormatTagApplySummaryEmpty(t *testing.T) {
 	assert.NotContains(t, buf.String(), "[-")
 }
 
+func TestRun_OrderAndThrottling(t *testing.T) {
+	t.Parallel()
+
+	store := testutil.NewMockStore()
+
+	for name, op := range map[string]staging.Operation{
+		"/app/old":    staging.OperationDelete,
+		"/app/new":    staging.OperationCreate,
+		"/shared/url": staging.OperationUpdate,
+	} {
+		_ = store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: name}, staging.Entry{
+			Operation: op,
+			Value:     lo.ToPtr("v"),
+			StagedAt:  time.Now(),
+		})
+	}
+
+	var (
+		mu        sync.Mutex
+		applied   []string
+		throttled bool
+	)
+
+	paramMock := newParamStrategy()
+	paramMock.applyFunc = func(_ context.Context, name string, _ staging.Entry) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if name == "/app/new" && !throttled {
+			throttled = true
+
+			return fmt.Errorf("%w: %s", provider.ErrThrottled, name)
+		}
+
+		applied = append(applied, name)
+
+		return nil
+	}
+
+	var buf, errBuf bytes.Buffer
+
+	r := &apply.Runner{
+		Services:      []apply.ServiceApply{paramApply(paramMock, store)},
+		ProviderLabel: "AWS",
+		Stdout:        &buf,
+		Stderr:        &errBuf,
+		Order:         staging.ApplyOrder{Patterns: []string{"/shared"}},
+		Concurrency:   1,
+		Retry:         retry.Policy{MaxAttempts: 2, Sleep: func(context.Context, time.Duration) error { return nil }},
+	}
+
+	require.NoError(t, r.Run(t.Context()))
+	assert.Equal(t, []string{"/shared/url", "/app/new", "/app/old"}, applied)
+	assert.Contains(t, buf.String(), "Created /app/new")
+	assert.Empty(t, errBuf.String())
+}
+
 func paramApply(s staging.ApplyStrategy, st store.ReadWriteOperator) apply.ServiceApply {
 	return serviceApply(staging.ServiceParam, s, st)
 }
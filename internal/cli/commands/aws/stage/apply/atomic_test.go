@@ -0,0 +1,181 @@
+package apply_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/apply"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/testutil"
+)
+
+// rollbackStrategy is a mockStrategy that supports atomic apply and records
+// the names it rolled back.
+type rollbackStrategy struct {
+	*mockStrategy
+
+	mu         sync.Mutex
+	rolledBack []string
+}
+
+func (m *rollbackStrategy) Snapshot(_ context.Context, _ string) (staging.Snapshot, error) {
+	return staging.Snapshot{Exists: true, Value: "old"}, nil
+}
+
+func (m *rollbackStrategy) Rollback(_ context.Context, name string, _ staging.Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rolledBack = append(m.rolledBack, name)
+
+	return nil
+}
+
+// relockFailStrategy is a rollbackStrategy whose entries are all locked and can
+// be unlocked but not locked again, so a write lands and then fails.
+type relockFailStrategy struct {
+	*rollbackStrategy
+}
+
+func (m *relockFailStrategy) FetchLocked(context.Context, string) (bool, error) { return true, nil }
+
+func (m *relockFailStrategy) Unlock(context.Context, string) error { return nil }
+
+func (m *relockFailStrategy) Lock(context.Context, string) error { return errors.New("lock denied") }
+
+func TestRun_Atomic(t *testing.T) {
+	t.Parallel()
+
+	stage := func(t *testing.T) *testutil.MockStore {
+		t.Helper()
+
+		store := testutil.NewMockStore()
+		require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/config"}, staging.Entry{
+			Operation: staging.OperationUpdate, Value: lo.ToPtr("param-value"), StagedAt: time.Now(),
+		}))
+		require.NoError(t, store.StageEntry(t.Context(), staging.ServiceSecret, staging.EntryKey{Name: "my-secret"}, staging.Entry{
+			Operation: staging.OperationUpdate, Value: lo.ToPtr("secret-value"), StagedAt: time.Now(),
+		}))
+
+		return store
+	}
+
+	t.Run("failure in one service rolls back the other", func(t *testing.T) {
+		t.Parallel()
+
+		store := stage(t)
+
+		param := &rollbackStrategy{mockStrategy: newParamStrategy()}
+		param.applyFunc = func(_ context.Context, _ string, _ staging.Entry) error {
+			return errors.New("SSM Parameter Store error")
+		}
+
+		secret := &rollbackStrategy{mockStrategy: newSecretStrategy()}
+
+		var buf, errBuf bytes.Buffer
+
+		r := &apply.Runner{
+			Services:        []apply.ServiceApply{paramApply(param, store), secretApply(secret, store)},
+			ProviderLabel:   "AWS",
+			Stdout:          &buf,
+			Stderr:          &errBuf,
+			IgnoreConflicts: true,
+			Atomic:          true,
+		}
+
+		err := r.Run(t.Context())
+		require.ErrorContains(t, err, "rolled back 1 of 1 written item(s)")
+		assert.Equal(t, []string{"my-secret"}, secret.rolledBack)
+		assert.Empty(t, param.rolledBack, "a failed write is not rolled back")
+		assert.Contains(t, buf.String(), "Secrets Manager: Rolled back my-secret")
+
+		_, err = store.GetEntry(t.Context(), staging.ServiceSecret, staging.EntryKey{Name: "my-secret"})
+		require.NoError(t, err, "the rolled-back change stays staged")
+	})
+
+	t.Run("a write that landed before its key failed is rolled back", func(t *testing.T) {
+		t.Parallel()
+
+		store := stage(t)
+
+		param := &relockFailStrategy{rollbackStrategy: &rollbackStrategy{mockStrategy: newParamStrategy()}}
+		secret := &rollbackStrategy{mockStrategy: newSecretStrategy()}
+
+		var buf, errBuf bytes.Buffer
+
+		r := &apply.Runner{
+			Services:        []apply.ServiceApply{paramApply(param, store), secretApply(secret, store)},
+			ProviderLabel:   "Azure",
+			Stdout:          &buf,
+			Stderr:          &errBuf,
+			IgnoreConflicts: true,
+			UnlockLocked:    true,
+			Atomic:          true,
+		}
+
+		require.ErrorContains(t, r.Run(t.Context()), "of 2 written item(s)")
+		assert.Equal(t, []string{"/app/config"}, param.rolledBack, "rolled back although its relock failed")
+		assert.Equal(t, []string{"my-secret"}, secret.rolledBack)
+	})
+
+	t.Run("success clears staging", func(t *testing.T) {
+		t.Parallel()
+
+		store := stage(t)
+
+		var buf, errBuf bytes.Buffer
+
+		r := &apply.Runner{
+			Services: []apply.ServiceApply{
+				paramApply(&rollbackStrategy{mockStrategy: newParamStrategy()}, store),
+				secretApply(&rollbackStrategy{mockStrategy: newSecretStrategy()}, store),
+			},
+			ProviderLabel:   "AWS",
+			Stdout:          &buf,
+			Stderr:          &errBuf,
+			IgnoreConflicts: true,
+			Atomic:          true,
+		}
+
+		require.NoError(t, r.Run(t.Context()))
+
+		_, err := store.GetEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/config"})
+		require.ErrorIs(t, err, staging.ErrNotStaged)
+		_, err = store.GetEntry(t.Context(), staging.ServiceSecret, staging.EntryKey{Name: "my-secret"})
+		require.ErrorIs(t, err, staging.ErrNotStaged)
+	})
+
+	t.Run("unsupported strategy aborts before writing", func(t *testing.T) {
+		t.Parallel()
+
+		store := stage(t)
+
+		param := newParamStrategy()
+		param.applyFunc = func(_ context.Context, _ string, _ staging.Entry) error {
+			t.Error("nothing may be written")
+
+			return nil
+		}
+
+		var buf, errBuf bytes.Buffer
+
+		r := &apply.Runner{
+			Services:        []apply.ServiceApply{paramApply(param, store)},
+			ProviderLabel:   "AWS",
+			Stdout:          &buf,
+			Stderr:          &errBuf,
+			IgnoreConflicts: true,
+			Atomic:          true,
+		}
+
+		require.ErrorContains(t, r.Run(t.Context()), "does not support atomic apply")
+	})
+}
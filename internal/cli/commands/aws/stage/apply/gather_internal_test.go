@@ -67,7 +67,7 @@ func TestGatherServices_SkipsUnconfigured(t *testing.T) {
 		},
 	}
 
-	svcs, targets, total, err := gatherServices(t.Context(), cfg, resolveFrom(nil))
+	svcs, targets, total, err := gatherServices(t.Context(), cfg, staging.Selector{}, resolveFrom(nil))
 	require.NoError(t, err)
 	assert.Empty(t, svcs, "an unconfigured service holds no staged state and must be skipped")
 	assert.Empty(t, targets)
@@ -90,7 +90,7 @@ func TestGatherServices_ResolverErrorPropagates(t *testing.T) {
 		},
 	}
 
-	svcs, _, _, err := gatherServices(t.Context(), cfg, resolveFrom(nil))
+	svcs, _, _, err := gatherServices(t.Context(), cfg, staging.Selector{}, resolveFrom(nil))
 	require.ErrorIs(t, err, wantErr, "a non-sentinel resolver error must not be swallowed by the skip path")
 	assert.Empty(t, svcs)
 }
@@ -108,7 +108,7 @@ func TestGatherServices_ListEntriesErrorPropagates(t *testing.T) {
 		},
 	}
 
-	svcs, _, _, err := gatherServices(t.Context(), cfg, resolveFrom(map[string]store.ReadWriteOperator{"t": st}))
+	svcs, _, _, err := gatherServices(t.Context(), cfg, staging.Selector{}, resolveFrom(map[string]store.ReadWriteOperator{"t": st}))
 	require.ErrorIs(t, err, wantErr)
 	assert.Empty(t, svcs)
 }
@@ -126,7 +126,7 @@ func TestGatherServices_ListTagsErrorPropagates(t *testing.T) {
 		},
 	}
 
-	svcs, _, _, err := gatherServices(t.Context(), cfg, resolveFrom(map[string]store.ReadWriteOperator{"t": st}))
+	svcs, _, _, err := gatherServices(t.Context(), cfg, staging.Selector{}, resolveFrom(map[string]store.ReadWriteOperator{"t": st}))
 	require.ErrorIs(t, err, wantErr)
 	assert.Empty(t, svcs)
 }
@@ -155,7 +155,7 @@ func TestGatherServices_PerServiceStores(t *testing.T) {
 		},
 	}
 
-	svcs, targets, total, err := gatherServices(t.Context(), cfg, resolveFrom(map[string]store.ReadWriteOperator{
+	svcs, targets, total, err := gatherServices(t.Context(), cfg, staging.Selector{}, resolveFrom(map[string]store.ReadWriteOperator{
 		"store": paramStore,
 		"vault": secretStore,
 	}))
@@ -171,3 +171,39 @@ func TestGatherServices_PerServiceStores(t *testing.T) {
 	assert.NotContains(t, svcs[0].Entries, staging.EntryKey{Name: "kv-secret"})
 	assert.Contains(t, svcs[1].Entries, staging.EntryKey{Name: "kv-secret"})
 }
+
+// TestGatherServices_Select proves a selection narrows what is gathered, and
+// that a service left with nothing selected is skipped like an empty one.
+func TestGatherServices_Select(t *testing.T) {
+	t.Parallel()
+
+	st := testutil.NewMockStore()
+	require.NoError(t, st.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "cfg", Namespace: "prod"}, staging.Entry{
+		Operation: staging.OperationDelete, StagedAt: time.Now(),
+	}))
+	require.NoError(t, st.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "cfg", Namespace: "dev"}, staging.Entry{
+		Operation: staging.OperationDelete, StagedAt: time.Now(),
+	}))
+	require.NoError(t, st.StageEntry(t.Context(), staging.ServiceSecret, staging.EntryKey{Name: "kv-secret"}, staging.Entry{
+		Operation: staging.OperationCreate, Value: lo.ToPtr("sv"), StagedAt: time.Now(),
+	}))
+
+	cfg := stgcli.GlobalConfig{
+		Services: []stgcli.GlobalServiceSpec{
+			{Service: staging.ServiceParam, ParserFactory: staging.AWSParamParserFactory, ScopeResolver: targetResolver("store"), Factory: nilFactory},
+			{Service: staging.ServiceSecret, ParserFactory: staging.AWSSecretParserFactory, ScopeResolver: targetResolver("vault"), Factory: nilFactory},
+		},
+	}
+
+	sel := staging.Selector{Namespaces: []string{"prod"}, Operations: []staging.Operation{staging.OperationDelete}}
+
+	svcs, targets, total, err := gatherServices(t.Context(), cfg, sel, resolveFrom(map[string]store.ReadWriteOperator{
+		"store": st,
+		"vault": st,
+	}))
+	require.NoError(t, err)
+	require.Len(t, svcs, 1)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, []string{"store"}, targets)
+	assert.Equal(t, []staging.EntryKey{{Name: "cfg", Namespace: "prod"}}, staging.SortedEntryKeys(svcs[0].Entries))
+}
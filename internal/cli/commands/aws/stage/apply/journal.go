@@ -0,0 +1,110 @@
+package apply
+
+import (
+	"context"
+
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/parallel"
+	"github.com/mpyw/suve/internal/staging"
+	stagingusecase "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// journaled reports whether any service keeps an apply journal.
+func (r *Runner) journaled() bool {
+	for _, svc := range r.Services {
+		if svc.Journal != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// journal records the applied changes in the apply journal of each scope
+// involved, one record per scope, all under the ID the first record gets. A
+// failure to record is reported but does not fail the apply.
+func (r *Runner) journal(ctx context.Context, snapshots []map[staging.EntryKey]staging.Snapshot, applied []appliedKeys) {
+	var (
+		order   []string
+		records = make(map[string]*staging.ApplyRecord)
+		owners  = make(map[string]ServiceApply)
+	)
+
+	for i, svc := range r.Services {
+		if svc.Journal == nil || len(applied[i].entries)+len(applied[i].tags) == 0 {
+			continue
+		}
+
+		rec, ok := records[svc.JournalScope]
+		if !ok {
+			rec = &staging.ApplyRecord{Message: staging.ChangeMessageFrom(ctx)}
+			records[svc.JournalScope] = rec
+			owners[svc.JournalScope] = svc
+			order = append(order, svc.JournalScope)
+		}
+
+		for _, key := range applied[i].entries {
+			rec.RecordEntry(svc.Service, key, svc.Entries[key].Operation, snapshots[i][key])
+		}
+
+		for _, key := range applied[i].tags {
+			rec.RecordTags(svc.Service, key, svc.Tags[key], snapshots[i][key])
+		}
+	}
+
+	var id string
+
+	for _, scope := range order {
+		rec := records[scope]
+		rec.ID = id
+
+		r.fetchNewVersions(ctx, rec)
+
+		if err := owners[scope].Journal.AppendJournal(ctx, rec); err != nil {
+			output.Warning(r.Stderr, "apply not recorded in the apply journal: %v", err)
+
+			continue
+		}
+
+		id = rec.ID
+	}
+
+	if id != "" {
+		output.Info(r.Stdout, "Recorded as apply %s in the apply journal", id)
+	}
+}
+
+// fetchNewVersions fills in the version each recorded entry is at after the
+// apply, through the strategy of the service that applied it. It is
+// best-effort: an entry whose state cannot be read is recorded without one.
+func (r *Runner) fetchNewVersions(ctx context.Context, rec *staging.ApplyRecord) {
+	services := make(map[staging.Service]ServiceApply, len(r.Services))
+	for _, svc := range r.Services {
+		services[svc.Service] = svc
+	}
+
+	indices := make(map[int]staging.AppliedItem, len(rec.Items))
+
+	for i, item := range rec.Items {
+		if item.Operation != staging.OperationDelete {
+			indices[i] = item
+		}
+	}
+
+	results := parallel.ExecuteMap(ctx, indices, func(ctx context.Context, _ int, item staging.AppliedItem) (string, error) {
+		rollbacker, err := stagingusecase.RollbackerFor(services[item.Service].strategyForNamespace, item.Namespace)
+		if err != nil {
+			return "", err
+		}
+
+		snap, err := rollbacker.Snapshot(ctx, item.Name)
+
+		return snap.Version, err
+	})
+
+	for i, result := range results {
+		if result.Err == nil {
+			rec.Items[i].NewVersion = result.Value
+		}
+	}
+}
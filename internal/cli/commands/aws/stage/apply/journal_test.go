@@ -0,0 +1,84 @@
+package apply_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/apply"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/testutil"
+)
+
+// memoryJournal is an in-memory apply journal.
+type memoryJournal struct {
+	records []*staging.ApplyRecord
+}
+
+func (m *memoryJournal) AppendJournal(_ context.Context, rec *staging.ApplyRecord) error {
+	if rec.ID == "" {
+		rec.ID = fmt.Sprintf("%020d", len(m.records)+1)
+	}
+
+	m.records = append(m.records, rec)
+
+	return nil
+}
+
+func (m *memoryJournal) ReadJournal(context.Context, string) (*staging.ApplyRecord, error) {
+	return nil, nil //nolint:nilnil // not read by apply
+}
+
+// TestRun_Journal pins that an apply is recorded once per scope, every record
+// under one ID, with the previous state of each applied entry.
+func TestRun_Journal(t *testing.T) {
+	t.Parallel()
+
+	store := testutil.NewMockStore()
+	require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/config"}, staging.Entry{
+		Operation: staging.OperationUpdate, Value: lo.ToPtr("param-value"), StagedAt: time.Now(),
+	}))
+	require.NoError(t, store.StageEntry(t.Context(), staging.ServiceSecret, staging.EntryKey{Name: "my-secret"}, staging.Entry{
+		Operation: staging.OperationUpdate, Value: lo.ToPtr("secret-value"), StagedAt: time.Now(),
+	}))
+
+	paramJournal, secretJournal := &memoryJournal{}, &memoryJournal{}
+
+	param := paramApply(&rollbackStrategy{mockStrategy: newParamStrategy()}, store)
+	param.Journal, param.JournalScope = paramJournal, "param-scope"
+
+	secret := secretApply(&rollbackStrategy{mockStrategy: newSecretStrategy()}, store)
+	secret.Journal, secret.JournalScope = secretJournal, "secret-scope"
+
+	var buf, errBuf bytes.Buffer
+
+	r := &apply.Runner{
+		Services:        []apply.ServiceApply{param, secret},
+		ProviderLabel:   "Azure",
+		Stdout:          &buf,
+		Stderr:          &errBuf,
+		IgnoreConflicts: true,
+	}
+
+	require.NoError(t, r.Run(t.Context()))
+	assert.Empty(t, errBuf.String())
+
+	require.Len(t, paramJournal.records, 1)
+	require.Len(t, secretJournal.records, 1)
+
+	id := paramJournal.records[0].ID
+	assert.Equal(t, id, secretJournal.records[0].ID, "one apply, one ID across scopes")
+	assert.Contains(t, buf.String(), "Recorded as apply "+id)
+
+	items := paramJournal.records[0].Items
+	require.Len(t, items, 1)
+	assert.Equal(t, "/app/config", items[0].Name)
+	assert.Equal(t, staging.OperationUpdate, items[0].Operation)
+	assert.Equal(t, "old", items[0].Previous.Value)
+}
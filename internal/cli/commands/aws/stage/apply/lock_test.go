@@ -0,0 +1,144 @@
+package apply_test
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/apply"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/testutil"
+)
+
+// lockingStrategy is a mockStrategy whose entries can be locked read-only; it
+// records the lock calls and writes in order.
+type lockingStrategy struct {
+	*mockStrategy
+
+	mu     sync.Mutex
+	locked map[string]bool
+	calls  []string
+}
+
+func (m *lockingStrategy) record(call string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, call)
+}
+
+func (m *lockingStrategy) FetchLocked(_ context.Context, name string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.locked[name], nil
+}
+
+func (m *lockingStrategy) Lock(_ context.Context, name string) error {
+	m.record("lock " + name)
+
+	return nil
+}
+
+func (m *lockingStrategy) Unlock(_ context.Context, name string) error {
+	m.record("unlock " + name)
+
+	return nil
+}
+
+func TestRun_Locked(t *testing.T) {
+	t.Parallel()
+
+	key := staging.EntryKey{Name: "/app/config"}
+
+	setup := func(t *testing.T) (*testutil.MockStore, *lockingStrategy) {
+		t.Helper()
+
+		store := testutil.NewMockStore()
+		require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, key, staging.Entry{
+			Operation: staging.OperationUpdate, Value: lo.ToPtr("v"), BaseVersion: "etag-1", StagedAt: time.Now(),
+		}))
+
+		strategy := &lockingStrategy{mockStrategy: newParamStrategy(), locked: map[string]bool{key.Name: true}}
+		strategy.applyFunc = func(_ context.Context, name string, entry staging.Entry) error {
+			strategy.record("apply " + name + " base=" + entry.BaseVersion)
+
+			return nil
+		}
+
+		return store, strategy
+	}
+
+	t.Run("locked entry is not attempted", func(t *testing.T) {
+		t.Parallel()
+
+		store, strategy := setup(t)
+
+		var buf, errBuf bytes.Buffer
+
+		r := &apply.Runner{
+			Services:        []apply.ServiceApply{paramApply(strategy, store)},
+			ProviderLabel:   "Azure",
+			Stdout:          &buf,
+			Stderr:          &errBuf,
+			IgnoreConflicts: true,
+		}
+
+		require.ErrorContains(t, r.Run(t.Context()), "failed 1")
+		assert.Empty(t, strategy.calls)
+		assert.Contains(t, errBuf.String(), "1 entries locked read-only and not applied; re-run with --unlock-locked")
+		assert.Contains(t, errBuf.String(), provider.ErrLocked.Error())
+
+		_, err := store.GetEntry(t.Context(), staging.ServiceParam, key)
+		require.NoError(t, err, "the locked entry stays staged")
+	})
+
+	t.Run("atomic apply refuses locked entries", func(t *testing.T) {
+		t.Parallel()
+
+		store, strategy := setup(t)
+
+		r := &apply.Runner{
+			Services:        []apply.ServiceApply{paramApply(strategy, store)},
+			ProviderLabel:   "Azure",
+			Stdout:          &bytes.Buffer{},
+			Stderr:          &bytes.Buffer{},
+			IgnoreConflicts: true,
+			Atomic:          true,
+		}
+
+		require.ErrorContains(t, r.Run(t.Context()), "atomic apply rejected: 1 locked entries")
+		assert.Empty(t, strategy.calls)
+	})
+
+	t.Run("unlock, write and relock", func(t *testing.T) {
+		t.Parallel()
+
+		store, strategy := setup(t)
+
+		var buf, errBuf bytes.Buffer
+
+		r := &apply.Runner{
+			Services:        []apply.ServiceApply{paramApply(strategy, store)},
+			ProviderLabel:   "Azure",
+			Stdout:          &buf,
+			Stderr:          &errBuf,
+			IgnoreConflicts: true,
+			UnlockLocked:    true,
+		}
+
+		require.NoError(t, r.Run(t.Context()))
+		assert.Equal(t, []string{"unlock /app/config", "apply /app/config base=", "lock /app/config"}, strategy.calls)
+		assert.Contains(t, buf.String(), "Updated /app/config")
+
+		_, err := store.GetEntry(t.Context(), staging.ServiceParam, key)
+		require.ErrorIs(t, err, staging.ErrNotStaged)
+	})
+}
@@ -0,0 +1,183 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/staging"
+	stgcli "github.com/mpyw/suve/internal/staging/cli"
+	"github.com/mpyw/suve/internal/staging/store"
+	usecasestaging "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// planFileMode keeps plan files private: their value hashes are keyed with a
+// salt the file carries, so a short value could be guessed from them.
+const planFileMode = 0o600
+
+// PlanCommand returns the global plan command for the given provider config.
+func PlanCommand(cfg stgcli.GlobalConfig) *cli.Command {
+	return &cli.Command{
+		Name:  "plan",
+		Usage: "Capture all staged changes into a plan file for a later apply",
+		Description: `Capture every staged entry and tag change, together with the remote
+version it will be applied over, into a plan.
+
+'suve stage apply <plan>' refuses to run if the staging area or the remote
+state drifted from what the plan recorded, so what is applied is what was
+reviewed. The plan records a SHA-256 digest of its content, which catches an
+accidental edit of the file; anyone can recompute it, so it does not prove
+the plan was not changed on purpose.
+
+Values are not written to the plan. Staged changes, and remote values without
+a version, are recorded as HMAC-SHA256 hashes keyed with a random salt kept in
+the plan. A short value (a PIN, a flag) can still be guessed from its hash by
+whoever holds the file, so keep plans private.
+
+Without --output the plan is only printed.
+
+EXAMPLES:
+   suve stage plan                      Show what an apply would do
+   suve stage plan -o plan.suve         Save the plan for review
+   suve stage apply plan.suve           Apply exactly the saved plan`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Write the plan to this file",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			svcs, _, _, err := gatherServices(ctx, cfg, staging.Selector{}, defaultWorkingStore)
+			if err != nil {
+				return err
+			}
+
+			plan, err := buildPlan(ctx, cfg.ProviderLabel, "", svcs)
+			if err != nil {
+				return err
+			}
+
+			w := cmd.Root().Writer
+			printPlan(w, plan)
+
+			path := cmd.String("output")
+			if path == "" {
+				return nil
+			}
+
+			data, err := plan.Marshal()
+			if err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(path, data, planFileMode); err != nil {
+				return fmt.Errorf("failed to write plan: %w", err)
+			}
+
+			output.Success(w, "Plan saved to %s (%s)", path, plan.Digest)
+
+			return nil
+		},
+	}
+}
+
+// defaultWorkingStore resolves a service's real on-disk working store.
+func defaultWorkingStore(
+	ctx context.Context, resolver staging.ScopeResolver,
+) (store.ReadWriteOperator, staging.ResolvedScope, error) {
+	return stgcli.WorkingStore(ctx, resolver)
+}
+
+// buildPlan captures the gathered services' staged changes into a plan whose
+// hashes are keyed with salt (a fresh one when empty).
+func buildPlan(ctx context.Context, providerLabel, salt string, svcs []ServiceApply) (*staging.Plan, error) {
+	input := usecasestaging.PlanInput{Provider: providerLabel, Salt: salt}
+
+	for _, svc := range svcs {
+		input.Services = append(input.Services, usecasestaging.PlanService{
+			Service:     svc.Service,
+			Target:      svc.Target,
+			StrategyFor: svc.DiffFor,
+			Entries:     svc.Entries,
+			Tags:        svc.Tags,
+		})
+	}
+
+	return (&usecasestaging.PlanUseCase{}).Execute(ctx, input)
+}
+
+// readPlan loads and verifies a saved plan.
+func readPlan(path string) (*staging.Plan, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // the plan path is given by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+
+	plan, err := staging.ParsePlan(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return plan, nil
+}
+
+// verifyPlan rebuilds the plan from the current staging area and remote state,
+// keyed with the saved plan's salt, and rejects the apply if anything drifted
+// from the saved plan.
+func verifyPlan(ctx context.Context, stderr io.Writer, saved *staging.Plan, providerLabel string, svcs []ServiceApply) error {
+	current, err := buildPlan(ctx, providerLabel, saved.Salt, svcs)
+	if err != nil {
+		return err
+	}
+
+	drift := saved.Drift(current)
+	for _, d := range drift {
+		output.Warning(stderr, "plan drift: %s", d)
+	}
+
+	if len(drift) > 0 {
+		return fmt.Errorf("apply rejected: %d difference(s) from the saved plan (run 'suve stage plan' again)", len(drift))
+	}
+
+	return nil
+}
+
+// printPlan lists the plan's changes, one per line, and a summary.
+func printPlan(w io.Writer, plan *staging.Plan) {
+	if len(plan.Items) == 0 {
+		output.Info(w, "No changes staged.")
+
+		return
+	}
+
+	for _, item := range plan.Items {
+		label := item.Key().Label()
+
+		switch {
+		case item.Kind == staging.PlanItemTag:
+			output.Printf(w, "  ~ %s %s (tags)\n", item.Service, label)
+		case item.Operation == staging.OperationCreate:
+			output.Printf(w, "  + %s %s\n", item.Service, label)
+		case item.Operation == staging.OperationDelete:
+			output.Printf(w, "  - %s %s%s\n", item.Service, label, planBaseSuffix(item.Base))
+		default:
+			output.Printf(w, "  ~ %s %s%s\n", item.Service, label, planBaseSuffix(item.Base))
+		}
+	}
+
+	output.Printf(w, "\nPlan: %d change(s) to %s.\n", len(plan.Items), plan.Provider)
+}
+
+// planBaseSuffix renders the remote version a change applies over.
+func planBaseSuffix(base staging.PlanBase) string {
+	if base.Version == "" {
+		return ""
+	}
+
+	return " (from " + base.Version + ")"
+}
@@ -0,0 +1,114 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// remoteDiffStrategy serves FetchCurrent from a name → value map; a missing
+// name is provider.ErrNotFound.
+type remoteDiffStrategy struct {
+	values map[string]string
+}
+
+func (r *remoteDiffStrategy) Service() staging.Service { return staging.ServiceParam }
+func (r *remoteDiffStrategy) ServiceName() string      { return "SSM Parameter Store" }
+func (r *remoteDiffStrategy) ItemName() string         { return "parameter" }
+func (r *remoteDiffStrategy) HasDeleteOptions() bool   { return false }
+
+func (r *remoteDiffStrategy) FetchCurrent(_ context.Context, name string) (*staging.FetchResult, error) {
+	v, ok := r.values[name]
+	if !ok {
+		return nil, provider.ErrNotFound
+	}
+
+	return &staging.FetchResult{Value: v, Identifier: "#" + v}, nil
+}
+
+func (r *remoteDiffStrategy) FetchCurrentTags(_ context.Context, _ string) (map[string]string, error) {
+	return nil, nil //nolint:nilnil // no tags
+}
+
+func planTestServices(remote *remoteDiffStrategy, value string) []ServiceApply {
+	return []ServiceApply{{
+		Service: staging.ServiceParam,
+		Target:  "123456789012/us-east-1",
+		DiffFor: func(string) (staging.DiffStrategy, error) { return remote, nil },
+		Entries: map[staging.EntryKey]staging.Entry{
+			{Name: "/app/a"}: {Operation: staging.OperationUpdate, Value: lo.ToPtr(value)},
+			{Name: "/app/b"}: {Operation: staging.OperationCreate, Value: lo.ToPtr("new")},
+		},
+	}}
+}
+
+func TestVerifyPlan(t *testing.T) {
+	t.Parallel()
+
+	remote := &remoteDiffStrategy{values: map[string]string{"/app/a": "1"}}
+
+	saved, err := buildPlan(t.Context(), "AWS", "", planTestServices(remote, "2"))
+	require.NoError(t, err)
+
+	t.Run("unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		var stderr bytes.Buffer
+		require.NoError(t, verifyPlan(t.Context(), &stderr, saved, "AWS", planTestServices(remote, "2")))
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("staging drifted", func(t *testing.T) {
+		t.Parallel()
+
+		var stderr bytes.Buffer
+
+		err := verifyPlan(t.Context(), &stderr, saved, "AWS", planTestServices(remote, "3"))
+		require.ErrorContains(t, err, "1 difference(s) from the saved plan")
+		assert.Contains(t, stderr.String(), "param /app/a: staged change differs from the plan")
+	})
+
+	t.Run("remote drifted", func(t *testing.T) {
+		t.Parallel()
+
+		moved := &remoteDiffStrategy{values: map[string]string{"/app/a": "5", "/app/b": "x"}}
+
+		var stderr bytes.Buffer
+
+		err := verifyPlan(t.Context(), &stderr, saved, "AWS", planTestServices(moved, "2"))
+		require.ErrorContains(t, err, "2 difference(s)")
+		assert.Contains(t, stderr.String(), "param /app/a: remote changed since the plan was made (#1 -> #5)")
+		assert.Contains(t, stderr.String(), "param /app/b: remote was created since the plan was made")
+	})
+
+	t.Run("nothing staged any more", func(t *testing.T) {
+		t.Parallel()
+
+		var stderr bytes.Buffer
+
+		err := verifyPlan(t.Context(), &stderr, saved, "AWS", nil)
+		require.ErrorContains(t, err, "2 difference(s)")
+		assert.Contains(t, stderr.String(), "no longer staged")
+	})
+}
+
+func TestPrintPlan(t *testing.T) {
+	t.Parallel()
+
+	remote := &remoteDiffStrategy{values: map[string]string{"/app/a": "1"}}
+
+	plan, err := buildPlan(t.Context(), "AWS", "", planTestServices(remote, "2"))
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	printPlan(&stdout, plan)
+
+	assert.Equal(t, "  ~ param /app/a (from #1)\n  + param /app/b\n\nPlan: 2 change(s) to AWS.\n", stdout.String())
+}
@@ -30,8 +30,16 @@ Use 'suve stage secret' for Secrets Manager operations.
 Global commands operate on all staged changes:
    status    Show all staged changes (SSM Parameter Store and Secrets Manager)
    diff      Show diff of all staged changes vs AWS
+   plan      Capture staged changes into a reviewable plan file
    apply     Apply all staged changes to AWS
    reset     Unstage all changes
+   resolve   Merge remote changes into conflicting staged changes
+   switch    Switch the active changeset (-c to create one)
+   branch    List or delete changesets
+   move      Move a staged change to another changeset
+   stash     Shelve staged changes and restore them later
+   history   List past applies
+   undo      Stage the changes that reverse an apply
    export    Export staged changes to a directory (one file per service)
    import    Import staged changes from a directory
 
@@ -47,8 +55,16 @@ EXAMPLES:
 			secret.Command(),
 			status.Command(gcfg),
 			diff.Command(gcfg),
+			apply.PlanCommand(gcfg),
 			apply.Command(gcfg),
 			reset.Command(gcfg),
+			stgcli.NewGlobalResolveCommand(gcfg),
+			stgcli.NewGlobalSwitchCommand(gcfg),
+			stgcli.NewGlobalBranchCommand(gcfg),
+			stgcli.NewGlobalMoveCommand(gcfg),
+			stgcli.NewGlobalStashCommand(gcfg),
+			stgcli.NewGlobalHistoryCommand(gcfg),
+			stgcli.NewGlobalUndoCommand(gcfg),
 			stgcli.NewGlobalExportCommand(gcfg.ScopeResolver),
 			stgcli.NewGlobalImportCommand(gcfg),
 		},
@@ -80,10 +80,13 @@ func Command(cfg stgcli.GlobalConfig) *cli.Command {
 
 For comparing specific versions, use the per-service diff commands.
 
+` + stgcli.SelectorDescription(cfg.HasNamespaces()) + `
+
 EXAMPLES:
-   suve stage diff     Show diff of all staged changes
-   suve stage diff -j  Show diff with JSON formatting`,
-		Flags: []cli.Flag{
+   suve stage diff                     Show diff of all staged changes
+   suve stage diff -j                  Show diff with JSON formatting
+   suve stage diff --match '/app/*'    Show diff of the selected changes`,
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:    "parse-json",
 				Aliases: []string{"j"},
@@ -93,7 +96,7 @@ EXAMPLES:
 				Name:  "no-pager",
 				Usage: "Disable pager output",
 			},
-		},
+		}, stgcli.SelectorFlags(cfg.HasNamespaces())...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			if cmd.Args().Len() > 0 {
 				return fmt.Errorf("usage: suve stage diff (no arguments)")
@@ -113,11 +116,11 @@ type workingStoreResolver func(
 
 // gatherServices lists each CONFIGURED service's staged changes from its OWN
 // store, skipping any service whose scope is not configured (it can hold no
-// staged state). It returns only services that actually have staged changes.
-// resolve is injected so tests can drive skip-unconfigured and store-error
-// propagation.
+// staged state). It returns only services that actually have staged changes
+// selected by sel. resolve is injected so tests can drive skip-unconfigured and
+// store-error propagation.
 func gatherServices(
-	ctx context.Context, cfg stgcli.GlobalConfig, resolve workingStoreResolver,
+	ctx context.Context, cfg stgcli.GlobalConfig, sel staging.Selector, resolve workingStoreResolver,
 ) ([]ServiceStrategy, error) {
 	services := make([]ServiceStrategy, 0, len(cfg.Services))
 
@@ -141,8 +144,8 @@ func gatherServices(
 			return nil, err
 		}
 
-		svcEntries := entries[spec.Service]
-		svcTags := tags[spec.Service]
+		svcEntries := sel.SelectEntries(entries[spec.Service])
+		svcTags := sel.SelectTags(tags[spec.Service])
 
 		if len(svcEntries) == 0 && len(svcTags) == 0 {
 			continue
@@ -167,6 +170,11 @@ func gatherServices(
 }
 
 func runAction(ctx context.Context, cmd *cli.Command, cfg stgcli.GlobalConfig) error {
+	sel, err := stgcli.SelectorFromCmd(cmd, cfg.HasNamespaces())
+	if err != nil {
+		return err
+	}
+
 	opts := Options{
 		ParseJSON: cmd.Bool("parse-json"),
 		NoPager:   cmd.Bool("no-pager"),
@@ -178,12 +186,18 @@ func runAction(ctx context.Context, cmd *cli.Command, cfg stgcli.GlobalConfig) e
 		return stgcli.WorkingStore(ctx, resolver)
 	}
 
-	services, err := gatherServices(ctx, cfg, resolve)
+	services, err := gatherServices(ctx, cfg, sel, resolve)
 	if err != nil {
 		return err
 	}
 
 	if len(services) == 0 {
+		if !sel.IsZero() {
+			output.Warning(cmd.Root().ErrWriter, "no staged changes match the selection")
+
+			return nil
+		}
+
 		output.Warning(cmd.Root().ErrWriter, "nothing staged")
 
 		return nil
@@ -56,7 +56,7 @@ func TestGatherServices_SkipsUnconfigured(t *testing.T) {
 		},
 	}
 
-	svcs, err := gatherServices(t.Context(), cfg, resolveFrom(nil))
+	svcs, err := gatherServices(t.Context(), cfg, staging.Selector{}, resolveFrom(nil))
 	require.NoError(t, err)
 	assert.Empty(t, svcs, "an unconfigured service holds no staged state and must be skipped")
 }
@@ -77,7 +77,7 @@ func TestGatherServices_ResolverErrorPropagates(t *testing.T) {
 		},
 	}
 
-	_, err := gatherServices(t.Context(), cfg, resolveFrom(nil))
+	_, err := gatherServices(t.Context(), cfg, staging.Selector{}, resolveFrom(nil))
 	require.ErrorIs(t, err, wantErr, "a non-sentinel resolver error must not be swallowed by the skip path")
 }
 
@@ -94,7 +94,7 @@ func TestGatherServices_ListEntriesErrorPropagates(t *testing.T) {
 		},
 	}
 
-	_, err := gatherServices(t.Context(), cfg, resolveFrom(map[string]store.ReadWriteOperator{"t": st}))
+	_, err := gatherServices(t.Context(), cfg, staging.Selector{}, resolveFrom(map[string]store.ReadWriteOperator{"t": st}))
 	require.ErrorIs(t, err, wantErr)
 }
 
@@ -111,7 +111,7 @@ func TestGatherServices_ListTagsErrorPropagates(t *testing.T) {
 		},
 	}
 
-	_, err := gatherServices(t.Context(), cfg, resolveFrom(map[string]store.ReadWriteOperator{"t": st}))
+	_, err := gatherServices(t.Context(), cfg, staging.Selector{}, resolveFrom(map[string]store.ReadWriteOperator{"t": st}))
 	require.ErrorIs(t, err, wantErr)
 }
 
@@ -137,7 +137,7 @@ func TestGatherServices_PerServiceStores(t *testing.T) {
 		},
 	}
 
-	svcs, err := gatherServices(t.Context(), cfg, resolveFrom(map[string]store.ReadWriteOperator{
+	svcs, err := gatherServices(t.Context(), cfg, staging.Selector{}, resolveFrom(map[string]store.ReadWriteOperator{
 		"store": paramStore,
 		"vault": secretStore,
 	}))
@@ -96,6 +96,7 @@ Use 'suve stage param reset' to unstage or restore from a version.`,
 			stgcli.NewDiffCommand(config),
 			stgcli.NewApplyCommand(config),
 			stgcli.NewResetCommand(config),
+			stgcli.NewResolveCommand(config),
 			stgcli.NewTagCommand(config),
 			stgcli.NewUntagCommand(config),
 			stgcli.NewExportCommand(config),
@@ -23,8 +23,11 @@ type Runner struct {
 	Store store.ReadWriteOperator
 	// Services lists the provider services in stable display order.
 	Services []stgcli.GlobalServiceSpec
-	Stdout   io.Writer
-	Stderr   io.Writer
+	// Select, when non-zero, unstages just the staged changes it selects
+	// instead of all of them.
+	Select staging.Selector
+	Stdout io.Writer
+	Stderr io.Writer
 }
 
 // storeForService returns the injected Store (test seam) or resolves this
@@ -47,20 +50,33 @@ func Command(cfg stgcli.GlobalConfig) *cli.Command {
 		Description: `Remove all staged changes from the staging area.
 
 This does not affect the remote store - it only clears the local staging area.
+With selector flags in place of --all, only the selected changes are removed.
 
 Use 'suve stage <service> reset' for service-specific operations.
 
+` + stgcli.SelectorDescription(cfg.HasNamespaces()) + `
+
 EXAMPLES:
-   suve stage reset --all    Unstage all changes`,
-		Flags: []cli.Flag{
+   suve stage reset --all                Unstage all changes
+   suve stage reset --match '/app/db/*'  Unstage the selected changes`,
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:  "all",
-				Usage: "Unstage all changes (required)",
+				Usage: "Unstage all changes (required without selector flags)",
 			},
-		},
+		}, stgcli.SelectorFlags(cfg.HasNamespaces())...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			// Require --all flag for safety
-			if !cmd.Bool("all") {
+			sel, err := stgcli.SelectorFromCmd(cmd, cfg.HasNamespaces())
+			if err != nil {
+				return err
+			}
+
+			if !sel.IsZero() && cmd.Bool("all") {
+				return errors.New("usage: suve stage reset takes --all or selector flags, not both")
+			}
+
+			// Require --all (or a selection) for safety
+			if sel.IsZero() && !cmd.Bool("all") {
 				output.Warning(cmd.Root().ErrWriter, "no effect without --all flag")
 				output.Hint(cmd.Root().ErrWriter, "Use 'suve stage reset --all' to unstage all changes")
 
@@ -69,6 +85,7 @@ EXAMPLES:
 
 			r := &Runner{
 				Services: cfg.Services,
+				Select:   sel,
 				Stdout:   cmd.Root().Writer,
 				Stderr:   cmd.Root().ErrWriter,
 			}
@@ -106,6 +123,18 @@ func (r *Runner) Run(ctx context.Context) error {
 			return err
 		}
 
+		if !r.Select.IsZero() {
+			count, err := r.unstageSelected(ctx, st, spec.Service, staged[spec.Service], tagStaged[spec.Service])
+			if err != nil {
+				return err
+			}
+
+			totalCount += count
+			summaries = append(summaries, formatCount(count, spec.ParserFactory().ServiceName()))
+
+			continue
+		}
+
 		count := len(staged[spec.Service]) + len(tagStaged[spec.Service])
 		totalCount += count
 		summaries = append(summaries, formatCount(count, spec.ParserFactory().ServiceName()))
@@ -116,7 +145,17 @@ func (r *Runner) Run(ctx context.Context) error {
 	}
 
 	if totalCount == 0 {
-		output.Info(r.Stdout, "No changes staged.")
+		if r.Select.IsZero() {
+			output.Info(r.Stdout, "No changes staged.")
+		} else {
+			output.Info(r.Stdout, "No staged changes match the selection.")
+		}
+
+		return nil
+	}
+
+	if !r.Select.IsZero() {
+		output.Success(r.Stdout, "Unstaged selected changes (%s)", strings.Join(summaries, ", "))
 
 		return nil
 	}
@@ -126,6 +165,34 @@ func (r *Runner) Run(ctx context.Context) error {
 	return nil
 }
 
+// unstageSelected unstages service's staged entries and tag changes that
+// r.Select selects and returns how many there were. A key that vanished in the
+// meantime (ErrNotStaged) is already what we want.
+func (r *Runner) unstageSelected(
+	ctx context.Context,
+	st store.ReadWriteOperator,
+	service staging.Service,
+	entries map[staging.EntryKey]staging.Entry,
+	tags map[staging.EntryKey]staging.TagEntry,
+) (int, error) {
+	entries = r.Select.SelectEntries(entries)
+	tags = r.Select.SelectTags(tags)
+
+	for key := range entries {
+		if err := st.UnstageEntry(ctx, service, key); err != nil && !errors.Is(err, staging.ErrNotStaged) {
+			return 0, err
+		}
+	}
+
+	for key := range tags {
+		if err := st.UnstageTag(ctx, service, key); err != nil && !errors.Is(err, staging.ErrNotStaged) {
+			return 0, err
+		}
+	}
+
+	return len(entries) + len(tags), nil
+}
+
 func formatCount(count int, serviceName string) string {
 	return strconv.Itoa(count) + " " + serviceName
 }
@@ -56,6 +56,50 @@ func TestRun_NoChanges(t *testing.T) {
 	assert.Contains(t, buf.String(), "No changes staged")
 }
 
+func TestRun_UnstageSelected(t *testing.T) {
+	t.Parallel()
+
+	store := testutil.NewMockStore()
+
+	_ = store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/db/host"}, staging.Entry{
+		Operation: staging.OperationUpdate,
+		Value:     lo.ToPtr("db"),
+		StagedAt:  time.Now(),
+	})
+	_ = store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/web/host"}, staging.Entry{
+		Operation: staging.OperationUpdate,
+		Value:     lo.ToPtr("web"),
+		StagedAt:  time.Now(),
+	})
+	_ = store.StageTag(t.Context(), staging.ServiceSecret, staging.EntryKey{Name: "/app/db/password"}, staging.TagEntry{
+		Add:      map[string]string{"env": "prod"},
+		StagedAt: time.Now(),
+	})
+
+	var buf bytes.Buffer
+
+	r := &reset.Runner{
+		Store:    store,
+		Services: awsServices(),
+		Select:   staging.Selector{Match: []string{"/app/db/*"}},
+		Stdout:   &buf,
+		Stderr:   &bytes.Buffer{},
+	}
+
+	err := r.Run(t.Context())
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Unstaged selected changes (1 SSM Parameter Store, 1 Secrets Manager)")
+
+	entries, err := store.ListEntries(t.Context(), staging.ServiceParam)
+	require.NoError(t, err)
+	assert.Len(t, entries[staging.ServiceParam], 1)
+	assert.Contains(t, entries[staging.ServiceParam], staging.EntryKey{Name: "/app/web/host"})
+
+	tags, err := store.ListTags(t.Context(), staging.ServiceSecret)
+	require.NoError(t, err)
+	assert.Empty(t, tags[staging.ServiceSecret])
+}
+
 func TestRun_UnstageAll(t *testing.T) {
 	t.Parallel()
 
@@ -44,6 +44,8 @@ func (r *Runner) storeForService(ctx context.Context, spec stgcli.GlobalServiceS
 // Options holds the options for the status command.
 type Options struct {
 	Verbose bool
+	// Select narrows the status to the staged changes it selects.
+	Select staging.Selector
 }
 
 // Command returns the status command for the given provider config.
@@ -55,24 +57,32 @@ func Command(cfg stgcli.GlobalConfig) *cli.Command {
 
 Use -v/--verbose to show detailed information including the staged values.
 
+` + stgcli.SelectorDescription(cfg.HasNamespaces()) + `
+
 EXAMPLES:
-   suve stage status     Show all staged changes
-   suve stage status -v  Show detailed information`,
-		Flags: []cli.Flag{
+   suve stage status              Show all staged changes
+   suve stage status -v           Show detailed information
+   suve stage status --op delete  Show only staged deletions`,
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:    "verbose",
 				Aliases: []string{"v"},
 				Usage:   "Show detailed information including values",
 			},
-		},
+		}, stgcli.SelectorFlags(cfg.HasNamespaces())...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			sel, err := stgcli.SelectorFromCmd(cmd, cfg.HasNamespaces())
+			if err != nil {
+				return err
+			}
+
 			r := &Runner{
 				Services: cfg.Services,
 				Stdout:   cmd.Root().Writer,
 				Stderr:   cmd.Root().ErrWriter,
 			}
 
-			return r.Run(ctx, Options{Verbose: cmd.Bool("verbose")})
+			return r.Run(ctx, Options{Verbose: cmd.Bool("verbose"), Select: sel})
 		},
 	}
 }
@@ -104,8 +114,8 @@ func (r *Runner) Run(ctx context.Context, opts Options) error {
 			return err
 		}
 
-		svcEntries := entries[spec.Service]
-		svcTags := tagEntries[spec.Service]
+		svcEntries := opts.Select.SelectEntries(entries[spec.Service])
+		svcTags := opts.Select.SelectTags(tagEntries[spec.Service])
 
 		total := len(svcEntries) + len(svcTags)
 		if total == 0 {
@@ -127,7 +137,11 @@ func (r *Runner) Run(ctx context.Context, opts Options) error {
 	}
 
 	if !printed {
-		output.Info(r.Stdout, "No changes staged.")
+		if opts.Select.IsZero() {
+			output.Info(r.Stdout, "No changes staged.")
+		} else {
+			output.Info(r.Stdout, "No staged changes match the selection.")
+		}
 	}
 
 	return nil
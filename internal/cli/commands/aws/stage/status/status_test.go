@@ -69,6 +69,51 @@ func TestCommand_ShowParamChangesOnly(t *testing.T) {
 	assert.NotContains(t, output, "Staged Secrets Manager changes")
 }
 
+func TestCommand_Select(t *testing.T) {
+	t.Parallel()
+
+	store := testutil.NewMockStore()
+
+	now := time.Now()
+	_ = store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/db/host"}, staging.Entry{
+		Operation: staging.OperationDelete,
+		StagedAt:  now,
+	})
+	_ = store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/web/host"}, staging.Entry{
+		Operation: staging.OperationDelete,
+		StagedAt:  now,
+	})
+	_ = store.StageEntry(t.Context(), staging.ServiceSecret, staging.EntryKey{Name: "db-password"}, staging.Entry{
+		Operation: staging.OperationUpdate,
+		Value:     lo.ToPtr("secret"),
+		StagedAt:  now,
+	})
+
+	var buf bytes.Buffer
+
+	r := &status.Runner{
+		Store:    store,
+		Services: awsServices(),
+		Stdout:   &buf,
+		Stderr:   &bytes.Buffer{},
+	}
+
+	err := r.Run(t.Context(), status.Options{Select: staging.Selector{Match: []string{"/app/db/*"}}})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Staged SSM Parameter Store changes (1)")
+	assert.Contains(t, output, "/app/db/host")
+	assert.NotContains(t, output, "/app/web/host")
+	assert.NotContains(t, output, "Staged Secrets Manager changes")
+
+	buf.Reset()
+
+	err = r.Run(t.Context(), status.Options{Select: staging.Selector{Operations: []staging.Operation{staging.OperationCreate}}})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "No staged changes match the selection")
+}
+
 func TestCommand_ShowSecretChangesOnly(t *testing.T) {
 	t.Parallel()
 
@@ -7,7 +7,10 @@
 // that version history is unsupported instead of crashing. The group otherwise
 // reuses the generic command scaffolding (show,
 // list, diff, create, update, delete, tag, untag) via App Configuration
-// presenters and the shared internal/usecase/azure use cases.
+// presenters and the shared internal/usecase/azure use cases. The snapshot
+// subgroup is App-Config-specific and reaches the store's snapshot extension;
+// show --resolve-refs and env follow Key Vault references through the Key
+// Vault adapter; lock and unlock toggle a setting's read-only flag.
 package param
 
 import (
@@ -71,6 +74,10 @@ variable.`,
 			DeleteCommand(),
 			TagCommand(),
 			UntagCommand(),
+			LockCommand(),
+			UnlockCommand(),
+			EnvCommand(),
+			SnapshotCommand(),
 		},
 		CommandNotFound: cliinternal.CommandNotFound,
 	}
@@ -24,6 +24,9 @@ type CreateRunner struct {
 type CreateOptions struct {
 	Name  string
 	Value string
+	// KeyVaultRef creates a Key Vault reference: Value is the secret URI (or
+	// the {"uri": ...} document) and the reference content-type is set.
+	KeyVaultRef bool
 }
 
 // CreateCommand returns the Azure App Configuration create command.
@@ -41,13 +44,24 @@ The value may be given as a positional argument, read from stdin with
 --value-stdin (so it never appears in argv/ps or shell history), or, when
 omitted, typed into $EDITOR.
 
+With --key-vault-ref the value is a Key Vault secret URI
+(https://<vault>.vault.azure.net/secrets/<name>[/<version>]) and the setting is
+created as a Key Vault reference (with the reference content-type), so Azure
+configuration providers resolve it to the secret.
+
 EXAMPLES:
    suve azure param create app/timeout "30"                  Create simple setting
    suve azure param create app/config '{"host":"db"}'        Create JSON setting
+   suve azure param create --key-vault-ref db/password https://my-vault.vault.azure.net/secrets/db
+                                                             Create a Key Vault reference
    printf '%s' "$V" | suve azure param create app/key --value-stdin  Read value from stdin
    suve azure param create app/key                           Type value into $EDITOR`,
 		Flags: []cli.Flag{
 			cliinternal.ValueStdinFlag(),
+			&cli.BoolFlag{
+				Name:  "key-vault-ref",
+				Usage: "Create a Key Vault reference; the value is the secret URI",
+			},
 		},
 		Action: createAction,
 	}
@@ -86,15 +100,20 @@ func createAction(ctx context.Context, cmd *cli.Command) error {
 		Stderr:  cmd.Root().ErrWriter,
 	}
 
-	return r.Run(ctx, CreateOptions{Name: args.Get(0), Value: value})
+	return r.Run(ctx, CreateOptions{Name: args.Get(0), Value: value, KeyVaultRef: cmd.Bool("key-vault-ref")})
 }
 
 // Run executes the create command.
 func (r *CreateRunner) Run(ctx context.Context, opts CreateOptions) error {
+	valueType := domain.ValueTypePlaintext
+	if opts.KeyVaultRef {
+		valueType = domain.ValueTypeReference
+	}
+
 	result, err := r.UseCase.Execute(ctx, azure.CreateInput{
 		Name:      opts.Name,
 		Value:     opts.Value,
-		ValueType: domain.ValueTypePlaintext,
+		ValueType: valueType,
 	})
 	if err != nil {
 		return err
@@ -103,5 +103,7 @@ EXAMPLES:
 
 			return NewDiffPresenter(store, spec1, spec2), nil
 		},
+		Store: cliinternal.AzureAppConfigStore,
+		Kind:  provider.KindParam,
 	})
 }
@@ -0,0 +1,117 @@
+package param
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/usecase/azure"
+)
+
+// EnvRunner renders settings as environment variable exports.
+type EnvRunner struct {
+	UseCase *azure.EnvUseCase
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// EnvOptions holds the options for the env command.
+type EnvOptions struct {
+	Prefix      string
+	ResolveRefs bool
+	Output      output.Format
+}
+
+// Run executes the env command. Text output is one `export NAME='value'` line
+// per setting, safe to eval; JSON output is a NAME -> value object.
+func (r *EnvRunner) Run(ctx context.Context, opts EnvOptions) error {
+	result, err := r.UseCase.Execute(ctx, azure.EnvInput{Prefix: opts.Prefix, ResolveRefs: opts.ResolveRefs})
+	if err != nil {
+		return err
+	}
+
+	for _, v := range result.Vars {
+		if v.Reference && !v.Resolved {
+			output.Warning(r.Stderr, "%s is a Key Vault reference; exporting the reference, not the secret "+
+				"(use --resolve-refs)", v.Key)
+		}
+	}
+
+	if opts.Output == output.FormatJSON {
+		vars := make(map[string]string, len(result.Vars))
+		for _, v := range result.Vars {
+			vars[v.Name] = v.Value
+		}
+
+		return output.WriteJSON(r.Stdout, vars)
+	}
+
+	for _, v := range result.Vars {
+		output.Printf(r.Stdout, "export %s=%s\n", v.Name, shellQuote(v.Value))
+	}
+
+	return nil
+}
+
+// shellQuote single-quotes s for POSIX shells, escaping embedded single quotes.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// EnvCommand returns the Azure App Configuration env command.
+func EnvCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "env",
+		Usage:     "Export settings as environment variables",
+		ArgsUsage: "[prefix]",
+		Description: `Print settings as shell environment variable exports.
+
+Each key under the prefix becomes one variable: the prefix is stripped, letters
+are upper-cased and every other character becomes "_" (app/db-host -> DB_HOST
+with prefix "app/"). Two keys mapping to the same name are an error. The
+namespace is taken from --namespace and must name a single namespace.
+
+Key Vault references are exported as the reference document unless
+--resolve-refs is given, which follows each one through Key Vault and exports
+the referenced secret instead.
+
+EXAMPLES:
+   suve azure param env app/                          Print export lines
+   eval "$(suve azure param env --resolve-refs app/)"  Load into the current shell
+   suve azure param env --output=json app/            Output a NAME -> value object`,
+		Flags: []cli.Flag{
+			resolveRefsFlag(),
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Output format: text (default) or json",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			outputFormat, err := output.ParseFormat(cmd.String("output"))
+			if err != nil {
+				return err
+			}
+
+			store, err := cliinternal.AzureAppConfigStore(ctx)
+			if err != nil {
+				return err
+			}
+
+			r := &EnvRunner{
+				UseCase: &azure.EnvUseCase{Reader: store, Refs: refResolver()},
+				Stdout:  cmd.Root().Writer,
+				Stderr:  cmd.Root().ErrWriter,
+			}
+
+			return r.Run(ctx, EnvOptions{
+				Prefix:      cmd.Args().First(),
+				ResolveRefs: cmd.Bool("resolve-refs"),
+				Output:      outputFormat,
+			})
+		},
+	}
+}
@@ -23,6 +23,7 @@ type namespaceJSONItem struct {
 	Namespace string  `json:"namespace"`
 	Name      string  `json:"name"`
 	Value     *string `json:"value,omitempty"`
+	Locked    bool    `json:"locked,omitempty"`
 }
 
 // ListOptions holds the parsed flags for the App Configuration list command.
@@ -171,8 +172,11 @@ func collapseToKeyOnly(rows []azure.ListNamespacesEntry) []genericlist.Entry {
 // wildcard --namespace matched, so --hide-namespace cannot show one value.
 var errAmbiguousValue = errors.New("value differs across namespaces; drop --hide-namespace to see each")
 
-// runNamespaced renders the NAMESPACE column (text: <namespace>TAB<key>[TAB<value>];
-// json: {namespace, name, value?}). The null namespace shows as "(NULL)" in text
+// lockedMarker is the trailing text column marking a read-only setting.
+const lockedMarker = "[locked]"
+
+// runNamespaced renders the NAMESPACE column (text: <namespace>TAB<key>[TAB<value>]
+// [TAB[locked]]; json: {namespace, name, value?, locked?}). The null namespace shows as "(NULL)" in text
 // but stays "" in JSON so machine consumers see the raw label.
 func (r *ListRunner) runNamespaced(ctx context.Context, opts ListOptions) error {
 	result, err := r.Namespace.Execute(ctx, azure.ListNamespacesInput{
@@ -184,7 +188,7 @@ func (r *ListRunner) runNamespaced(ctx context.Context, opts ListOptions) error
 
 	if opts.Output == output.FormatJSON {
 		items := lo.Map(result.Entries, func(e azure.ListNamespacesEntry, _ int) namespaceJSONItem {
-			return namespaceJSONItem{Namespace: e.Namespace, Name: e.Name, Value: e.Value}
+			return namespaceJSONItem{Namespace: e.Namespace, Name: e.Name, Value: e.Value, Locked: e.Locked}
 		})
 
 		return output.WriteJSON(r.Stdout, items)
@@ -196,11 +200,18 @@ func (r *ListRunner) runNamespaced(ctx context.Context, opts ListOptions) error
 			ns = aznamespace.NullDisplay
 		}
 
+		line := ns + "\t" + e.Name
 		if opts.Show {
-			output.Printf(r.Stdout, "%s\t%s\t%s\n", ns, e.Name, lo.FromPtr(e.Value))
-		} else {
-			output.Printf(r.Stdout, "%s\t%s\n", ns, e.Name)
+			line += "\t" + lo.FromPtr(e.Value)
+		}
+
+		// A locked setting gets a trailing marker column, after every column a
+		// `cut -f` pipeline already relies on.
+		if e.Locked {
+			line += "\t" + lockedMarker
 		}
+
+		output.Println(r.Stdout, line)
 	}
 
 	return nil
@@ -237,6 +248,9 @@ VALUE DISPLAY:
    Use --show to display setting values alongside keys.
    Output format: <namespace><TAB><key><TAB><value>
 
+READ-ONLY LOCKS:
+   A locked setting ends with an extra "[locked]" column (JSON: "locked": true).
+
 NAMESPACE COLUMN:
    Use --hide-namespace (--hide-ns) to drop the NAMESPACE column and list keys
    only (the neutral, pipe-friendly output).
@@ -0,0 +1,67 @@
+package param_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/cli/commands/azure/param"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/provider/azure/appconfig"
+	"github.com/mpyw/suve/internal/usecase/azure"
+)
+
+// lockerStub records lock/unlock calls.
+type lockerStub struct {
+	calls []string
+}
+
+func (l *lockerStub) Lock(_ context.Context, name string) error {
+	l.calls = append(l.calls, "lock "+name)
+
+	return nil
+}
+
+func (l *lockerStub) Unlock(_ context.Context, name string) error {
+	l.calls = append(l.calls, "unlock "+name)
+
+	return nil
+}
+
+func TestLockRunner(t *testing.T) {
+	t.Parallel()
+
+	locker := &lockerStub{}
+
+	var buf, errBuf bytes.Buffer
+
+	r := &param.LockRunner{UseCase: &azure.LockUseCase{Locker: locker}, Stdout: &buf, Stderr: &errBuf}
+	require.NoError(t, r.Run(t.Context(), param.LockOptions{Name: "app/key"}))
+	require.NoError(t, r.Run(t.Context(), param.LockOptions{Name: "app/key", Unlock: true}))
+
+	assert.Equal(t, []string{"lock app/key", "unlock app/key"}, locker.calls)
+	assert.Contains(t, buf.String(), "Locked setting app/key")
+	assert.Contains(t, buf.String(), "Unlocked setting app/key")
+}
+
+func TestListRunner_LockedMarker(t *testing.T) {
+	t.Parallel()
+
+	rows := []appconfig.KeyNamespace{
+		{Key: "app/a", Value: "a"},
+		{Key: "app/b", Value: "b", Locked: true},
+	}
+
+	var buf, errBuf bytes.Buffer
+
+	r := nsListRunner(rows, nil, &buf, &errBuf)
+	require.NoError(t, r.Run(t.Context(), param.ListOptions{Show: true}))
+	assert.Equal(t, "(NULL)\tapp/a\ta\n(NULL)\tapp/b\tb\t[locked]\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, r.Run(t.Context(), param.ListOptions{Output: output.FormatJSON}))
+	assert.JSONEq(t, `[{"namespace":"","name":"app/a"},{"namespace":"","name":"app/b","locked":true}]`, buf.String())
+}
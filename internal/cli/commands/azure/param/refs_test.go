@@ -0,0 +1,168 @@
+package param_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/cli/commands/azure/param"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/usecase/azure"
+	"github.com/mpyw/suve/internal/version/azureappconfigversion"
+)
+
+const dbRef = `{"uri":"https://my-vault.vault.azure.net/secrets/db-password"}`
+
+// refSettings is an App Configuration stand-in with one plain setting and one
+// Key Vault reference.
+func refSettings() *providermock.Store {
+	entries := map[string]domain.Entry{
+		"app/db-host":     {Name: "app/db-host", Value: "db.internal", Type: domain.ValueTypePlaintext},
+		"app/db/password": {Name: "app/db/password", Value: dbRef, Type: domain.ValueTypeReference},
+	}
+
+	return &providermock.Store{
+		ResolveFunc: func(context.Context, string, string) (provider.VersionRef, error) {
+			return provider.VersionRef{}, nil
+		},
+		ListFunc: func(context.Context) ([]string, error) {
+			return []string{"app/db-host", "app/db/password"}, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			e, ok := entries[name]
+			if !ok {
+				return nil, provider.ErrNotFound
+			}
+
+			return &e, nil
+		},
+	}
+}
+
+// refResolver follows references into a fake vault holding db-password.
+func refResolver() *azure.RefResolver {
+	return &azure.RefResolver{
+		Vault: func(context.Context, string) (provider.Reader, error) {
+			return &providermock.Store{
+				GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+					return &domain.Entry{Name: name, Value: "it's-secret"}, nil
+				},
+			}, nil
+		},
+	}
+}
+
+func TestShowPresenter_KeyVaultReference(t *testing.T) {
+	t.Parallel()
+
+	spec, err := azureappconfigversion.Parse("app/db/password")
+	require.NoError(t, err)
+
+	t.Run("unresolved shows the reference", func(t *testing.T) {
+		t.Parallel()
+
+		presenter := param.NewShowPresenter(refSettings(), spec)
+		require.NoError(t, presenter.Fetch(t.Context()))
+
+		var buf, errBuf bytes.Buffer
+
+		presenter.RenderText(&buf, presenter.Value(false, &errBuf))
+		assert.Contains(t, buf.String(), "Key Vault reference")
+		assert.Contains(t, buf.String(), "https://my-vault.vault.azure.net/secrets/db-password")
+		assert.NotContains(t, buf.String(), "(resolved)")
+	})
+
+	t.Run("resolved shows reference and secret together", func(t *testing.T) {
+		t.Parallel()
+
+		presenter := param.NewResolvingShowPresenter(refSettings(), refResolver(), spec)
+		require.NoError(t, presenter.Fetch(t.Context()))
+
+		var buf, errBuf bytes.Buffer
+
+		value := presenter.Value(false, &errBuf)
+		assert.Equal(t, "it's-secret", value)
+
+		presenter.RenderText(&buf, value)
+		assert.Contains(t, buf.String(), "Key Vault reference (resolved)")
+		assert.Contains(t, buf.String(), "https://my-vault.vault.azure.net/secrets/db-password")
+
+		buf.Reset()
+		require.NoError(t, presenter.RenderJSON(&buf, value))
+		assert.JSONEq(t, `{"name":"app/db/password","tags":{},"value":"it's-secret",
+			"reference":"https://my-vault.vault.azure.net/secrets/db-password","resolved":true}`, buf.String())
+	})
+}
+
+func TestCreateRunner_KeyVaultRef(t *testing.T) {
+	t.Parallel()
+
+	var gotType domain.ValueType
+
+	store := &providermock.Store{
+		CreateFunc: func(
+			_ context.Context, _, _ string, vt domain.ValueType, _ string, _ ...provider.WriteOption,
+		) (domain.Version, error) {
+			gotType = vt
+
+			return domain.Version{}, nil
+		},
+	}
+
+	var buf, errBuf bytes.Buffer
+
+	r := &param.CreateRunner{UseCase: &azure.CreateUseCase{Writer: store}, Stdout: &buf, Stderr: &errBuf}
+	require.NoError(t, r.Run(t.Context(), param.CreateOptions{
+		Name: "db", Value: "https://my-vault.vault.azure.net/secrets/db", KeyVaultRef: true,
+	}))
+	assert.Equal(t, domain.ValueTypeReference, gotType)
+}
+
+func TestEnvRunner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resolved exports are shell-quoted", func(t *testing.T) {
+		t.Parallel()
+
+		var buf, errBuf bytes.Buffer
+
+		r := &param.EnvRunner{
+			UseCase: &azure.EnvUseCase{Reader: refSettings(), Refs: refResolver()},
+			Stdout:  &buf,
+			Stderr:  &errBuf,
+		}
+		require.NoError(t, r.Run(t.Context(), param.EnvOptions{Prefix: "app/", ResolveRefs: true}))
+		assert.Equal(t, "export DB_HOST='db.internal'\nexport DB_PASSWORD='it'\\''s-secret'\n", buf.String())
+		assert.Empty(t, errBuf.String())
+	})
+
+	t.Run("unresolved reference warns", func(t *testing.T) {
+		t.Parallel()
+
+		var buf, errBuf bytes.Buffer
+
+		r := &param.EnvRunner{
+			UseCase: &azure.EnvUseCase{Reader: refSettings(), Refs: refResolver()},
+			Stdout:  &buf,
+			Stderr:  &errBuf,
+		}
+		require.NoError(t, r.Run(t.Context(), param.EnvOptions{Prefix: "app/", Output: output.FormatJSON}))
+		assert.JSONEq(t, `{"DB_HOST":"db.internal","DB_PASSWORD":`+jsonString(dbRef)+`}`, buf.String())
+		assert.Contains(t, errBuf.String(), "app/db/password is a Key Vault reference")
+	})
+}
+
+// jsonString encodes s as a JSON string literal.
+func jsonString(s string) string {
+	var buf bytes.Buffer
+
+	_ = output.WriteJSON(&buf, s)
+
+	return buf.String()
+}
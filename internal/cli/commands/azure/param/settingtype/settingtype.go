@@ -0,0 +1,45 @@
+// Package settingtype maps between the provider-neutral domain.ValueType and the
+// Azure App Configuration setting kinds ("Key-value", "Key Vault reference")
+// offered by the TUI entry form. App Configuration values are otherwise untyped;
+// the only kind that changes how a setting is written is a Key Vault reference,
+// which is stored with the reference content-type.
+package settingtype
+
+import (
+	"github.com/mpyw/suve/internal/domain"
+)
+
+// Setting kind names as displayed by the TUI.
+const (
+	KeyValue          = "Key-value"
+	KeyVaultReference = "Key Vault reference"
+)
+
+// Options returns the setting kind display names in their canonical order.
+func Options() []string {
+	return []string{KeyValue, KeyVaultReference}
+}
+
+// Display maps a domain.ValueType to its setting kind name.
+func Display(t domain.ValueType) string {
+	if t == domain.ValueTypeReference {
+		return KeyVaultReference
+	}
+
+	return KeyValue
+}
+
+// Valid reports whether s is one of Options().
+func Valid(s string) bool {
+	return s == KeyValue || s == KeyVaultReference
+}
+
+// Parse maps a setting kind name to a domain.ValueType. Unknown values map to
+// domain.ValueTypePlaintext, the kind of every ordinary setting.
+func Parse(s string) domain.ValueType {
+	if s == KeyVaultReference {
+		return domain.ValueTypeReference
+	}
+
+	return domain.ValueTypePlaintext
+}
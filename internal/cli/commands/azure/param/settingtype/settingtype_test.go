@@ -0,0 +1,24 @@
+package settingtype_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mpyw/suve/internal/cli/commands/azure/param/settingtype"
+	"github.com/mpyw/suve/internal/domain"
+)
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, label := range settingtype.Options() {
+		assert.True(t, settingtype.Valid(label))
+		assert.Equal(t, label, settingtype.Display(settingtype.Parse(label)))
+	}
+
+	assert.Equal(t, domain.ValueTypeReference, settingtype.Parse(settingtype.KeyVaultReference))
+	assert.Equal(t, domain.ValueTypePlaintext, settingtype.Parse("String"))
+	assert.Equal(t, settingtype.KeyValue, settingtype.Display(domain.ValueTypeSecret))
+	assert.False(t, settingtype.Valid(""))
+}
@@ -10,6 +10,7 @@ import (
 	genericshow "github.com/mpyw/suve/internal/cli/commands/generic/show"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/domain"
 	"github.com/mpyw/suve/internal/jsonutil"
 	"github.com/mpyw/suve/internal/provider"
 	"github.com/mpyw/suve/internal/timeutil"
@@ -23,14 +24,22 @@ type showJSONOutput struct {
 	Modified string            `json:"modified,omitempty"`
 	Tags     map[string]string `json:"tags"`
 	Value    string            `json:"value"`
+	// Reference is the Key Vault secret URI of a Key Vault reference; Resolved
+	// reports that Value is the referenced secret rather than the reference.
+	Reference string `json:"reference,omitempty"`
+	Resolved  bool   `json:"resolved,omitempty"`
+	// Locked reports that the setting is read-only.
+	Locked bool `json:"locked,omitempty"`
 }
 
 // showPresenter renders Azure App Configuration show output. App Configuration
-// is unversioned, so no version/state metadata is rendered.
+// is unversioned, so no version/state metadata is rendered; a Key Vault
+// reference adds its target (and, when resolved, shows the secret as the value).
 type showPresenter struct {
-	uc     *azure.ShowUseCase
-	spec   *azureappconfigversion.Spec
-	result *azure.ShowOutput
+	uc          *azure.ShowUseCase
+	spec        *azureappconfigversion.Spec
+	resolveRefs bool
+	result      *azure.ShowOutput
 }
 
 // NewShowPresenter builds an Azure App Configuration show presenter over the given reader and spec.
@@ -38,9 +47,17 @@ func NewShowPresenter(reader provider.Reader, spec *azureappconfigversion.Spec)
 	return &showPresenter{uc: &azure.ShowUseCase{Reader: reader}, spec: spec}
 }
 
+// NewResolvingShowPresenter builds a show presenter that follows a Key Vault
+// reference through refs, showing the referenced secret as the value.
+func NewResolvingShowPresenter(
+	reader provider.Reader, refs *azure.RefResolver, spec *azureappconfigversion.Spec,
+) genericshow.Presenter {
+	return &showPresenter{uc: &azure.ShowUseCase{Reader: reader, Refs: refs}, spec: spec, resolveRefs: true}
+}
+
 func (p *showPresenter) Fetch(ctx context.Context) error {
 	// App Configuration has no version specifier, so the suffix is always empty.
-	result, err := p.uc.Execute(ctx, azure.ShowInput{Name: p.spec.Name, Suffix: ""})
+	result, err := p.uc.Execute(ctx, azure.ShowInput{Name: p.spec.Name, Suffix: "", ResolveRefs: p.resolveRefs})
 	if err != nil {
 		return err
 	}
@@ -65,6 +82,18 @@ func (p *showPresenter) RenderText(stdout io.Writer, value string) {
 	out := output.New(stdout)
 	out.Field("Name", result.Name)
 
+	if result.Locked {
+		out.Field("Locked", "yes (read-only)")
+	}
+
+	if result.Type == domain.ValueTypeReference {
+		out.Field("Type", referenceTypeLabel(result.Resolved))
+
+		if result.Reference != nil {
+			out.Field("Reference", result.Reference.URI)
+		}
+	}
+
 	if result.CreatedDate != nil {
 		out.Field("Modified", timeutil.FormatRFC3339(*result.CreatedDate))
 	}
@@ -85,8 +114,14 @@ func (p *showPresenter) RenderJSON(stdout io.Writer, value string) error {
 	result := p.result
 
 	jsonOut := showJSONOutput{
-		Name:  result.Name,
-		Value: value,
+		Name:     result.Name,
+		Value:    value,
+		Resolved: result.Resolved,
+		Locked:   result.Locked,
+	}
+
+	if result.Reference != nil {
+		jsonOut.Reference = result.Reference.URI
 	}
 
 	if result.CreatedDate != nil {
@@ -101,6 +136,30 @@ func (p *showPresenter) RenderJSON(stdout io.Writer, value string) error {
 	return output.WriteJSON(stdout, jsonOut)
 }
 
+// referenceTypeLabel is the Type field of a Key Vault reference, noting whether
+// the value below it is the secret or the reference document.
+func referenceTypeLabel(resolved bool) string {
+	if resolved {
+		return "Key Vault reference (resolved)"
+	}
+
+	return "Key Vault reference"
+}
+
+// resolveRefsFlag is the --resolve-refs flag shared by show and env.
+func resolveRefsFlag() *cli.BoolFlag {
+	return &cli.BoolFlag{
+		Name:  "resolve-refs",
+		Usage: "Follow Key Vault references and show the referenced secret",
+	}
+}
+
+// refResolver builds the Key Vault reference resolver the CLI uses: each
+// reference is followed through the Key Vault adapter for the vault it names.
+func refResolver() *azure.RefResolver {
+	return &azure.RefResolver{Vault: cliinternal.AzureKeyVaultReader}
+}
+
 // ShowCommand returns the Azure App Configuration show command.
 func ShowCommand() *cli.Command {
 	return genericshow.Command(genericshow.Config[*azureappconfigversion.Spec]{
@@ -114,18 +173,32 @@ whole argument is the literal key name, not a version specifier).
 Use --raw to output only the value without metadata (for piping/scripting).
 Use --output=json for structured JSON output (cannot be used with --raw).
 
+KEY VAULT REFERENCES:
+  A setting with the Key Vault reference content-type holds only a pointer
+  ({"uri": "https://<vault>.vault.azure.net/secrets/<name>"}). It is shown
+  with its Reference; --resolve-refs follows it through Key Vault (with your
+  Azure credentials) and shows the referenced secret as the value.
+
 EXAMPLES:
   suve azure param show my-key                        Show the setting value
   suve azure param show --raw my-key                  Output raw value (for piping)
-  suve azure param show --output=json my-key          Output as JSON`,
+  suve azure param show --output=json my-key          Output as JSON
+  suve azure param show --resolve-refs db/password    Show the referenced secret`,
 		UsageError: "usage: suve azure param show <key>",
 		ParseSpec:  azureappconfigversion.Parse,
-		NewPresenter: func(ctx context.Context, spec *azureappconfigversion.Spec) (genericshow.Presenter, error) {
+		Flags:      []cli.Flag{resolveRefsFlag()},
+		NewPresenter: func(
+			ctx context.Context, cmd *cli.Command, spec *azureappconfigversion.Spec,
+		) (genericshow.Presenter, error) {
 			store, err := cliinternal.AzureAppConfigStore(ctx)
 			if err != nil {
 				return nil, err
 			}
 
+			if cmd.Bool("resolve-refs") {
+				return NewResolvingShowPresenter(store, refResolver(), spec), nil
+			}
+
 			return NewShowPresenter(store, spec), nil
 		},
 	})
@@ -0,0 +1,633 @@
+package param
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/samber/lo"
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider/azure/appconfig"
+	"github.com/mpyw/suve/internal/provider/azure/appconfig/aznamespace"
+	"github.com/mpyw/suve/internal/staging"
+	stgcli "github.com/mpyw/suve/internal/staging/cli"
+	"github.com/mpyw/suve/internal/staging/store"
+	"github.com/mpyw/suve/internal/timeutil"
+	"github.com/mpyw/suve/internal/usecase/azure"
+	stagingusecase "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// argsUsageSnapshot is the ArgsUsage string shared by the single-snapshot commands.
+const argsUsageSnapshot = "<snapshot>"
+
+// snapshotJSONItem is the JSON shape of one snapshot's metadata.
+type snapshotJSONItem struct {
+	Name       string               `json:"name"`
+	Status     string               `json:"status"`
+	Created    string               `json:"created,omitempty"`
+	Expires    string               `json:"expires,omitempty"`
+	ItemsCount int64                `json:"itemsCount"`
+	Filters    []snapshotJSONFilter `json:"filters,omitempty"`
+}
+
+// snapshotJSONFilter is the JSON shape of one snapshot filter. Namespace is the
+// raw label ("" for the null namespace).
+type snapshotJSONFilter struct {
+	Key       string `json:"key"`
+	Namespace string `json:"namespace"`
+}
+
+// snapshotShowJSONOutput is the JSON shape of `snapshot show`.
+type snapshotShowJSONOutput struct {
+	snapshotJSONItem
+
+	Settings []namespaceJSONItem `json:"settings"`
+}
+
+// snapshotDiffJSONItem is the JSON shape of one snapshot diff change.
+type snapshotDiffJSONItem struct {
+	Name      string  `json:"name"`
+	Namespace string  `json:"namespace"`
+	Change    string  `json:"change"`
+	OldValue  *string `json:"oldValue,omitempty"`
+	NewValue  *string `json:"newValue,omitempty"`
+	// OldContentType and NewContentType are set on a side that has the setting,
+	// "" meaning no content type.
+	OldContentType *string `json:"oldContentType,omitempty"`
+	NewContentType *string `json:"newContentType,omitempty"`
+	Diff           string  `json:"diff,omitempty"`
+}
+
+// snapshotDiffJSONOutput is the JSON shape of `snapshot diff`.
+type snapshotDiffJSONOutput struct {
+	Old       string                 `json:"old"`
+	New       string                 `json:"new"`
+	Unchanged int                    `json:"unchanged"`
+	Changes   []snapshotDiffJSONItem `json:"changes"`
+}
+
+func toSnapshotJSONItem(snap *appconfig.Snapshot) snapshotJSONItem {
+	item := snapshotJSONItem{
+		Name:       snap.Name,
+		Status:     snap.Status,
+		ItemsCount: snap.ItemsCount,
+		Filters: lo.Map(snap.Filters, func(f appconfig.SnapshotFilter, _ int) snapshotJSONFilter {
+			return snapshotJSONFilter{Key: f.Key, Namespace: f.Namespace}
+		}),
+	}
+
+	if snap.Created != nil {
+		item.Created = timeutil.FormatRFC3339(*snap.Created)
+	}
+
+	if snap.Expires != nil {
+		item.Expires = timeutil.FormatRFC3339(*snap.Expires)
+	}
+
+	return item
+}
+
+// namespaceDisplay renders a namespace for text output, showing the null
+// namespace as "(NULL)" like the list command.
+func namespaceDisplay(ns string) string {
+	if ns == "" {
+		return aznamespace.NullDisplay
+	}
+
+	return ns
+}
+
+// changeLabel renders one side of a per-setting diff header.
+func changeLabel(side, key, ns string) string {
+	return fmt.Sprintf("%s:%s [%s]", side, key, namespaceDisplay(ns))
+}
+
+// SnapshotRunner renders the App Configuration snapshot commands that only read
+// or create snapshots (create, list, show, diff).
+type SnapshotRunner struct {
+	Manager azure.SnapshotManager
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// Create creates a snapshot and prints its metadata.
+func (r *SnapshotRunner) Create(ctx context.Context, input azure.SnapshotCreateInput, format output.Format) error {
+	uc := &azure.SnapshotCreateUseCase{Manager: r.Manager}
+
+	snap, err := uc.Execute(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if format == output.FormatJSON {
+		return output.WriteJSON(r.Stdout, toSnapshotJSONItem(snap))
+	}
+
+	output.Success(r.Stdout, "Created snapshot %s (%d setting(s), status %s)", snap.Name, snap.ItemsCount, snap.Status)
+
+	return nil
+}
+
+// List prints every snapshot: text rows are <name><TAB><status><TAB><items><TAB><created>.
+func (r *SnapshotRunner) List(ctx context.Context, format output.Format) error {
+	uc := &azure.SnapshotListUseCase{Manager: r.Manager}
+
+	snaps, err := uc.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	if format == output.FormatJSON {
+		items := lo.Map(snaps, func(snap appconfig.Snapshot, _ int) snapshotJSONItem {
+			return toSnapshotJSONItem(&snap)
+		})
+
+		return output.WriteJSON(r.Stdout, items)
+	}
+
+	for _, snap := range snaps {
+		created := ""
+		if snap.Created != nil {
+			created = timeutil.FormatRFC3339(*snap.Created)
+		}
+
+		output.Printf(r.Stdout, "%s\t%s\t%d\t%s\n", snap.Name, snap.Status, snap.ItemsCount, created)
+	}
+
+	return nil
+}
+
+// Show prints a snapshot's metadata followed by its captured settings.
+func (r *SnapshotRunner) Show(ctx context.Context, name string, format output.Format) error {
+	uc := &azure.SnapshotShowUseCase{Manager: r.Manager}
+
+	result, err := uc.Execute(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if format == output.FormatJSON {
+		return output.WriteJSON(r.Stdout, snapshotShowJSONOutput{
+			snapshotJSONItem: toSnapshotJSONItem(result.Snapshot),
+			Settings: lo.Map(result.Settings, func(row appconfig.KeyNamespace, _ int) namespaceJSONItem {
+				return namespaceJSONItem{Namespace: row.Namespace, Name: row.Key, Value: lo.ToPtr(row.Value)}
+			}),
+		})
+	}
+
+	snap := result.Snapshot
+
+	out := output.New(r.Stdout)
+	out.Field("Snapshot", snap.Name)
+	out.Field("Status", snap.Status)
+
+	if snap.Created != nil {
+		out.Field("Created", timeutil.FormatRFC3339(*snap.Created))
+	}
+
+	if snap.Expires != nil {
+		out.Field("Expires", timeutil.FormatRFC3339(*snap.Expires))
+	}
+
+	out.Field("Items", strconv.FormatInt(snap.ItemsCount, 10))
+
+	for _, f := range snap.Filters {
+		out.Field("Filter", fmt.Sprintf("key=%s namespace=%s", f.Key, namespaceDisplay(f.Namespace)))
+	}
+
+	out.Separator()
+
+	for _, row := range result.Settings {
+		output.Printf(r.Stdout, "%s\t%s\t%s\n", namespaceDisplay(row.Namespace), row.Key, row.Value)
+	}
+
+	return nil
+}
+
+// Diff prints the differences between a snapshot and the live store (or a
+// second snapshot) as per-setting unified diffs, using the shared diff renderer.
+func (r *SnapshotRunner) Diff(ctx context.Context, input azure.SnapshotDiffInput, format output.Format) error {
+	uc := &azure.SnapshotDiffUseCase{Manager: r.Manager}
+
+	result, err := uc.Execute(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if format == output.FormatJSON {
+		items := lo.Map(result.Changes, func(c azure.SnapshotChange, _ int) snapshotDiffJSONItem {
+			return snapshotDiffJSONItem{
+				Name:           c.Key,
+				Namespace:      c.Namespace,
+				Change:         string(c.Kind),
+				OldValue:       c.OldValue,
+				NewValue:       c.NewValue,
+				OldContentType: c.OldContentType,
+				NewContentType: c.NewContentType,
+				Diff: output.DiffRaw(
+					changeLabel(result.OldLabel, c.Key, c.Namespace), changeLabel(result.NewLabel, c.Key, c.Namespace),
+					lo.FromPtr(c.OldValue), lo.FromPtr(c.NewValue),
+				),
+			}
+		})
+
+		return output.WriteJSON(r.Stdout, snapshotDiffJSONOutput{
+			Old: result.OldLabel, New: result.NewLabel, Unchanged: result.Unchanged, Changes: items,
+		})
+	}
+
+	if len(result.Changes) == 0 {
+		output.Info(r.Stderr, "No differences between %s and %s (%d setting(s) identical).",
+			result.OldLabel, result.NewLabel, result.Unchanged)
+
+		return nil
+	}
+
+	for _, c := range result.Changes {
+		if contentTypeChanged(c) {
+			output.Printf(r.Stdout, "%s [%s]: content type %q -> %q\n", c.Key, namespaceDisplay(c.Namespace),
+				lo.FromPtr(c.OldContentType), lo.FromPtr(c.NewContentType))
+		}
+
+		output.Print(r.Stdout, output.Diff(r.Stdout,
+			changeLabel(result.OldLabel, c.Key, c.Namespace), changeLabel(result.NewLabel, c.Key, c.Namespace),
+			lo.FromPtr(c.OldValue), lo.FromPtr(c.NewValue),
+		))
+	}
+
+	return nil
+}
+
+// contentTypeChanged reports whether a modified setting's content type differs
+// between the two sides (its value may be identical).
+func contentTypeChanged(c azure.SnapshotChange) bool {
+	return c.Kind == azure.SnapshotChangeModified && lo.FromPtr(c.OldContentType) != lo.FromPtr(c.NewContentType)
+}
+
+// SnapshotRestoreRunner stages the changes that bring the live store back to a
+// snapshot. Nothing is written to App Configuration: the staged changes go
+// through the usual `stage diff` / `stage apply` review.
+type SnapshotRestoreRunner struct {
+	Diff *azure.SnapshotDiffUseCase
+	// Store is the App Configuration working staging store.
+	Store store.ReadWriteOperator
+	// StrategyFor builds a staging strategy scoped to one namespace, since a
+	// snapshot may span several.
+	StrategyFor func(namespace string) (staging.FullStrategy, error)
+	Stdout      io.Writer
+	Stderr      io.Writer
+}
+
+// errRestoreFailed is returned when at least one setting could not be staged.
+var errRestoreFailed = errors.New("some settings could not be staged")
+
+// Run diffs the snapshot against the live store and stages the inverse of each
+// difference: a setting missing live is staged for creation, a changed one for
+// update to the snapshot value, and one that exists only live for deletion. A
+// created or updated setting also gets the snapshot's content type back, so a
+// Key Vault reference is restored as a reference.
+// Each setting is reported individually; failures do not stop the rest.
+func (r *SnapshotRestoreRunner) Run(ctx context.Context, name string) error {
+	result, err := r.Diff.Execute(ctx, azure.SnapshotDiffInput{Old: name})
+	if err != nil {
+		return err
+	}
+
+	if len(result.Changes) == 0 {
+		output.Info(r.Stdout, "Live store already matches snapshot %s.", name)
+
+		return nil
+	}
+
+	failed := 0
+
+	for _, c := range result.Changes {
+		key := staging.EntryKey{Name: c.Key, Namespace: c.Namespace}
+
+		verb, err := r.stage(ctx, key, c)
+		if err != nil {
+			output.Failed(r.Stderr, key.Label(), err)
+
+			failed++
+
+			continue
+		}
+
+		output.Success(r.Stdout, "Staged %s: %s", verb, key.Label())
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errRestoreFailed, failed, len(result.Changes))
+	}
+
+	output.Hint(r.Stdout, "Review with 'suve azure stage param diff' and apply with 'suve azure stage param apply'")
+
+	return nil
+}
+
+// stage stages one inverse change and returns the verb describing it.
+func (r *SnapshotRestoreRunner) stage(ctx context.Context, key staging.EntryKey, c azure.SnapshotChange) (string, error) {
+	strategy, err := r.StrategyFor(key.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	valueType := domain.ValueTypePlaintext
+	if appconfig.IsKeyVaultRefContentType(lo.FromPtr(c.OldContentType)) {
+		valueType = domain.ValueTypeReference
+	}
+
+	attributes := &staging.SecretAttributes{ContentType: c.OldContentType}
+
+	switch c.Kind {
+	case azure.SnapshotChangeRemoved:
+		uc := &stagingusecase.AddUseCase{Strategy: strategy, Store: r.Store}
+		_, err = uc.Execute(ctx, stagingusecase.AddInput{
+			Key: key, Value: lo.FromPtr(c.OldValue), ValueType: valueType, Attributes: attributes,
+		})
+
+		return "create", err
+	case azure.SnapshotChangeModified:
+		// A setting whose content type alone changed has the same value live,
+		// which the edit would otherwise skip as a no-op.
+		uc := &stagingusecase.EditUseCase{Strategy: strategy, Store: r.Store}
+		_, err = uc.Execute(ctx, stagingusecase.EditInput{
+			Key: key, Value: lo.FromPtr(c.OldValue), ValueType: valueType, Attributes: attributes,
+			Force: contentTypeChanged(c),
+		})
+
+		return "update", err
+	case azure.SnapshotChangeAdded:
+		uc := &stagingusecase.DeleteUseCase{Strategy: strategy, Store: r.Store}
+		_, err = uc.Execute(ctx, stagingusecase.DeleteInput{Key: key})
+
+		return "delete", err
+	default:
+		return "", fmt.Errorf("unknown change kind: %s", c.Kind)
+	}
+}
+
+// snapshotManager resolves the App Configuration store and asserts the
+// snapshot extension.
+func snapshotManager(ctx context.Context) (azure.SnapshotManager, error) {
+	s, err := cliinternal.AzureAppConfigStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	manager, ok := s.(azure.SnapshotManager)
+	if !ok {
+		return nil, appconfig.ErrSnapshotsUnsupported
+	}
+
+	return manager, nil
+}
+
+// snapshotRunner builds a SnapshotRunner for the command context.
+func snapshotRunner(ctx context.Context, cmd *cli.Command) (*SnapshotRunner, error) {
+	manager, err := snapshotManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SnapshotRunner{Manager: manager, Stdout: cmd.Root().Writer, Stderr: cmd.Root().ErrWriter}, nil
+}
+
+// outputFlag is the --output flag shared by the snapshot subcommands.
+func outputFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "output",
+		Usage: "Output format: text (default) or json",
+	}
+}
+
+// SnapshotCommand returns the "azure param snapshot" subcommand group.
+func SnapshotCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "snapshot",
+		Aliases: []string{"snap"},
+		Usage:   "Manage App Configuration snapshots",
+		Description: `Create, inspect, compare and restore App Configuration snapshots.
+
+A snapshot is an immutable, point-in-time set of key-values captured across
+namespaces. Snapshots make release checkpoints for configuration: diff one
+against the live store to see what changed since, or restore it by staging the
+differences for review.
+
+EXAMPLES:
+  suve azure param snapshot create release-42            Capture the current namespace
+  suve azure param --ns '*' snapshot create release-42   Capture every namespace
+  suve azure param snapshot list                         List snapshots
+  suve azure param snapshot show release-42              Show a snapshot's settings
+  suve azure param snapshot diff release-42              Compare with the live store
+  suve azure param snapshot diff release-41 release-42   Compare two snapshots
+  suve azure param snapshot restore release-42           Stage the live store back`,
+		Commands: []*cli.Command{
+			snapshotCreateCommand(),
+			snapshotListCommand(),
+			snapshotShowCommand(),
+			snapshotDiffCommand(),
+			snapshotRestoreCommand(),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+func snapshotCreateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "create",
+		Usage:     "Create a snapshot of the current settings",
+		ArgsUsage: argsUsageSnapshot,
+		Description: `Capture the settings selected by --key and the group's --namespace filter
+into a new, immutable snapshot. The command waits until the snapshot is ready.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "key",
+				Usage: `Key filter ("app/*" = prefix; default all keys)`,
+			},
+			&cli.DurationFlag{
+				Name:  "retention",
+				Usage: "How long an archived snapshot is kept (default: service default)",
+			},
+			outputFlag(),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("usage: suve azure param snapshot create <snapshot>")
+			}
+
+			format, err := output.ParseFormat(cmd.String("output"))
+			if err != nil {
+				return err
+			}
+
+			r, err := snapshotRunner(ctx, cmd)
+			if err != nil {
+				return err
+			}
+
+			return r.Create(ctx, azure.SnapshotCreateInput{
+				Name:      cmd.Args().First(),
+				KeyFilter: cmd.String("key"),
+				Retention: cmd.Duration("retention"),
+			}, format)
+		},
+	}
+}
+
+func snapshotListCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls"},
+		Usage:   "List snapshots",
+		Description: `List every snapshot in the store.
+Output format: <name><TAB><status><TAB><items><TAB><created>`,
+		Flags: []cli.Flag{outputFlag()},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			format, err := output.ParseFormat(cmd.String("output"))
+			if err != nil {
+				return err
+			}
+
+			r, err := snapshotRunner(ctx, cmd)
+			if err != nil {
+				return err
+			}
+
+			return r.List(ctx, format)
+		},
+	}
+}
+
+func snapshotShowCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "show",
+		Usage:     "Show a snapshot and its settings",
+		ArgsUsage: argsUsageSnapshot,
+		Flags:     []cli.Flag{outputFlag()},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("usage: suve azure param snapshot show <snapshot>")
+			}
+
+			format, err := output.ParseFormat(cmd.String("output"))
+			if err != nil {
+				return err
+			}
+
+			r, err := snapshotRunner(ctx, cmd)
+			if err != nil {
+				return err
+			}
+
+			return r.Show(ctx, cmd.Args().First(), format)
+		},
+	}
+}
+
+func snapshotDiffCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "Compare a snapshot with the live store or another snapshot",
+		ArgsUsage: "<snapshot> [other-snapshot]",
+		Description: `Compare a snapshot with the live store (restricted to the keys and namespaces
+the snapshot covers) or with a second snapshot. Each changed setting is shown
+as a unified diff; added and removed settings diff against an empty value.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "no-pager",
+				Usage: "Disable pager output",
+			},
+			outputFlag(),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() < 1 || cmd.Args().Len() > 2 {
+				return errors.New("usage: suve azure param snapshot diff <snapshot> [other-snapshot]")
+			}
+
+			format, err := output.ParseFormat(cmd.String("output"))
+			if err != nil {
+				return err
+			}
+
+			manager, err := snapshotManager(ctx)
+			if err != nil {
+				return err
+			}
+
+			input := azure.SnapshotDiffInput{Old: cmd.Args().Get(0), New: cmd.Args().Get(1)}
+			noPager := cmd.Bool("no-pager") || format == output.FormatJSON
+
+			return cliinternal.WithPager(cmd, noPager, func(stdout, stderr io.Writer) error {
+				r := &SnapshotRunner{Manager: manager, Stdout: stdout, Stderr: stderr}
+
+				return r.Diff(ctx, input, format)
+			})
+		},
+	}
+}
+
+func snapshotRestoreCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "restore",
+		Usage:     "Stage the changes that restore the live store to a snapshot",
+		ArgsUsage: argsUsageSnapshot,
+		Description: `Stage every difference between the live store and a snapshot, so applying
+the staged changes brings the snapshot's keys back to their captured values:
+missing settings are staged for creation, changed ones for update, and settings
+the snapshot's filters cover but it did not capture are staged for deletion.
+
+Nothing is written to Azure. Review with "suve azure stage param diff" and
+apply with "suve azure stage param apply".`,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("usage: suve azure param snapshot restore <snapshot>")
+			}
+
+			manager, err := snapshotManager(ctx)
+			if err != nil {
+				return err
+			}
+
+			workingStore, _, err := stgcli.WorkingStore(ctx, cliinternal.AzureAppConfigStagingScopeResolver)
+			if err != nil {
+				return err
+			}
+
+			// A snapshot may span several namespaces; build one store-backed
+			// strategy per namespace and reuse it across that namespace's settings.
+			strategies := map[string]staging.FullStrategy{}
+
+			r := &SnapshotRestoreRunner{
+				Diff:  &azure.SnapshotDiffUseCase{Manager: manager},
+				Store: workingStore,
+				StrategyFor: func(namespace string) (staging.FullStrategy, error) {
+					if strategy, ok := strategies[namespace]; ok {
+						return strategy, nil
+					}
+
+					strategy, err := cliinternal.AzureAppConfigParamStrategyFactory(
+						cliinternal.WithAzureAppConfigNamespace(ctx, namespace),
+					)
+					if err != nil {
+						return nil, err
+					}
+
+					strategies[namespace] = strategy
+
+					return strategy, nil
+				},
+				Stdout: cmd.Root().Writer,
+				Stderr: cmd.Root().ErrWriter,
+			}
+
+			return r.Run(ctx, cmd.Args().First())
+		},
+	}
+}
@@ -0,0 +1,326 @@
+package param_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/cli/commands/azure/param"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/azure/appconfig"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/testutil"
+	"github.com/mpyw/suve/internal/usecase/azure"
+)
+
+// snapshotManagerStub is an in-memory SnapshotManager over one snapshot and the
+// live rows.
+type snapshotManagerStub struct {
+	snap     []appconfig.KeyNamespace
+	live     []appconfig.KeyNamespace
+	snapshot appconfig.Snapshot
+}
+
+func (s *snapshotManagerStub) CreateSnapshot(
+	_ context.Context, name, _ string, _ time.Duration,
+) (*appconfig.Snapshot, error) {
+	return &appconfig.Snapshot{Name: name, Status: "ready", ItemsCount: int64(len(s.live))}, nil
+}
+
+func (s *snapshotManagerStub) Snapshot(_ context.Context, _ string) (*appconfig.Snapshot, error) {
+	return &s.snapshot, nil
+}
+
+func (s *snapshotManagerStub) ListSnapshots(_ context.Context) ([]appconfig.Snapshot, error) {
+	return []appconfig.Snapshot{s.snapshot}, nil
+}
+
+func (s *snapshotManagerStub) SnapshotSettings(_ context.Context, _ string) ([]appconfig.KeyNamespace, error) {
+	return s.snap, nil
+}
+
+func (s *snapshotManagerStub) LiveSettings(_ context.Context, _ *appconfig.Snapshot) ([]appconfig.KeyNamespace, error) {
+	return s.live, nil
+}
+
+func newSnapshotStub() *snapshotManagerStub {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	return &snapshotManagerStub{
+		snapshot: appconfig.Snapshot{
+			Name: "release-1", Status: "ready", Created: &created, ItemsCount: 3,
+			Filters: []appconfig.SnapshotFilter{{Key: "*", Namespace: ""}},
+		},
+		snap: []appconfig.KeyNamespace{
+			{Key: "app/a", Value: "same"},
+			{Key: "app/b", Value: "old"},
+			{Key: "app/d", Namespace: "dev", Value: "gone"},
+		},
+		live: []appconfig.KeyNamespace{
+			{Key: "app/a", Value: "same"},
+			{Key: "app/b", Value: "new"},
+			{Key: "app/c", Value: "extra"},
+		},
+	}
+}
+
+func TestSnapshotRunner_List(t *testing.T) {
+	t.Parallel()
+
+	var buf, errBuf bytes.Buffer
+
+	r := &param.SnapshotRunner{Manager: newSnapshotStub(), Stdout: &buf, Stderr: &errBuf}
+	require.NoError(t, r.List(t.Context(), output.FormatText))
+	assert.Equal(t, "release-1\tready\t3\t2026-01-02T03:04:05Z\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, r.List(t.Context(), output.FormatJSON))
+	assert.JSONEq(t, `[{"name":"release-1","status":"ready","created":"2026-01-02T03:04:05Z","itemsCount":3,
+		"filters":[{"key":"*","namespace":""}]}]`, buf.String())
+}
+
+func TestSnapshotRunner_Show(t *testing.T) {
+	t.Parallel()
+
+	var buf, errBuf bytes.Buffer
+
+	r := &param.SnapshotRunner{Manager: newSnapshotStub(), Stdout: &buf, Stderr: &errBuf}
+	require.NoError(t, r.Show(t.Context(), "release-1", output.FormatText))
+
+	out := buf.String()
+	assert.Contains(t, out, "release-1")
+	assert.Contains(t, out, "key=* namespace=(NULL)")
+	assert.Contains(t, out, "(NULL)\tapp/b\told\n")
+	assert.Contains(t, out, "dev\tapp/d\tgone\n")
+}
+
+func TestSnapshotRunner_Diff(t *testing.T) {
+	t.Parallel()
+
+	t.Run("text renders one unified diff per changed setting", func(t *testing.T) {
+		t.Parallel()
+
+		var buf, errBuf bytes.Buffer
+
+		r := &param.SnapshotRunner{Manager: newSnapshotStub(), Stdout: &buf, Stderr: &errBuf}
+		require.NoError(t, r.Diff(t.Context(), azure.SnapshotDiffInput{Old: "release-1"}, output.FormatText))
+
+		out := buf.String()
+		assert.Contains(t, out, "--- release-1:app/b [(NULL)]")
+		assert.Contains(t, out, "+++ live:app/b [(NULL)]")
+		assert.Contains(t, out, "-old")
+		assert.Contains(t, out, "+new")
+		assert.Contains(t, out, "+extra")
+		assert.Contains(t, out, "-gone")
+		assert.NotContains(t, out, "app/a")
+	})
+
+	t.Run("json lists changes with their kind", func(t *testing.T) {
+		t.Parallel()
+
+		var buf, errBuf bytes.Buffer
+
+		r := &param.SnapshotRunner{Manager: newSnapshotStub(), Stdout: &buf, Stderr: &errBuf}
+		require.NoError(t, r.Diff(t.Context(), azure.SnapshotDiffInput{Old: "release-1"}, output.FormatJSON))
+		assert.Contains(t, buf.String(), `"unchanged": 1`)
+		assert.Contains(t, buf.String(), `"change": "modified"`)
+		assert.Contains(t, buf.String(), `"change": "added"`)
+		assert.Contains(t, buf.String(), `"change": "removed"`)
+	})
+
+	t.Run("a content type change is shown even when the value is the same", func(t *testing.T) {
+		t.Parallel()
+
+		var buf, errBuf bytes.Buffer
+
+		r := &param.SnapshotRunner{Manager: newRefStub(), Stdout: &buf, Stderr: &errBuf}
+		require.NoError(t, r.Diff(t.Context(), azure.SnapshotDiffInput{Old: "release-1"}, output.FormatText))
+		assert.Contains(t, buf.String(), `app/ref [(NULL)]: content type "`+appconfig.KeyVaultRefContentType+`" -> ""`)
+		assert.NotContains(t, errBuf.String(), "No differences")
+
+		buf.Reset()
+		require.NoError(t, r.Diff(t.Context(), azure.SnapshotDiffInput{Old: "release-1"}, output.FormatJSON))
+		assert.Contains(t, buf.String(), `"oldContentType": "`+appconfig.KeyVaultRefContentType+`"`)
+		assert.Contains(t, buf.String(), `"newContentType": ""`)
+	})
+
+	t.Run("identical sides report no differences", func(t *testing.T) {
+		t.Parallel()
+
+		stub := newSnapshotStub()
+		stub.live = stub.snap
+
+		var buf, errBuf bytes.Buffer
+
+		r := &param.SnapshotRunner{Manager: stub, Stdout: &buf, Stderr: &errBuf}
+		require.NoError(t, r.Diff(t.Context(), azure.SnapshotDiffInput{Old: "release-1"}, output.FormatText))
+		assert.Empty(t, buf.String())
+		assert.Contains(t, errBuf.String(), "No differences")
+	})
+}
+
+// newRefStub is a snapshot holding a Key Vault reference that is plain text in
+// the live store, with the same value.
+func newRefStub() *snapshotManagerStub {
+	const ref = `{"uri":"https://v.vault.azure.net/secrets/s"}`
+
+	stub := newSnapshotStub()
+	stub.snap = []appconfig.KeyNamespace{{Key: "app/ref", Value: ref, ContentType: appconfig.KeyVaultRefContentType}}
+	stub.live = []appconfig.KeyNamespace{{Key: "app/ref", Value: ref}}
+
+	return stub
+}
+
+// liveStore is a providermock store answering Get from the given live rows of
+// one namespace.
+func liveStore(live []appconfig.KeyNamespace, namespace string) *providermock.Store {
+	return &providermock.Store{
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			for _, row := range live {
+				if row.Key == name && row.Namespace == namespace {
+					return &domain.Entry{Name: name, Value: row.Value}, nil
+				}
+			}
+
+			return nil, provider.ErrNotFound
+		},
+	}
+}
+
+func TestSnapshotRestoreRunner_StagesInverseChanges(t *testing.T) {
+	t.Parallel()
+
+	stub := newSnapshotStub()
+	stagingStore := testutil.NewMockStore()
+
+	var buf, errBuf bytes.Buffer
+
+	r := &param.SnapshotRestoreRunner{
+		Diff:  &azure.SnapshotDiffUseCase{Manager: stub},
+		Store: stagingStore,
+		StrategyFor: func(namespace string) (staging.FullStrategy, error) {
+			return staging.NewAzureAppConfigParamStrategy(liveStore(stub.live, namespace)), nil
+		},
+		Stdout: &buf,
+		Stderr: &errBuf,
+	}
+
+	require.NoError(t, r.Run(t.Context(), "release-1"))
+
+	entries, err := stagingStore.ListEntries(t.Context(), staging.ServiceParam)
+	require.NoError(t, err)
+
+	got := entries[staging.ServiceParam]
+	require.Len(t, got, 3)
+
+	updated := got[staging.EntryKey{Name: "app/b"}]
+	assert.Equal(t, staging.OperationUpdate, updated.Operation)
+	assert.Equal(t, "old", lo.FromPtr(updated.Value))
+
+	created := got[staging.EntryKey{Name: "app/d", Namespace: "dev"}]
+	assert.Equal(t, staging.OperationCreate, created.Operation)
+	assert.Equal(t, "gone", lo.FromPtr(created.Value))
+
+	deleted := got[staging.EntryKey{Name: "app/c"}]
+	assert.Equal(t, staging.OperationDelete, deleted.Operation)
+
+	assert.Contains(t, buf.String(), "Staged update: app/b")
+	assert.Contains(t, buf.String(), "Staged create: app/d [dev]")
+	assert.Contains(t, buf.String(), "Staged delete: app/c")
+}
+
+func TestSnapshotRestoreRunner_RestoresContentType(t *testing.T) {
+	t.Parallel()
+
+	stub := newRefStub()
+	stub.snap = append(stub.snap, appconfig.KeyNamespace{Key: "app/typed", Value: "{}", ContentType: "application/json"})
+	stagingStore := testutil.NewMockStore()
+
+	var buf, errBuf bytes.Buffer
+
+	r := &param.SnapshotRestoreRunner{
+		Diff:  &azure.SnapshotDiffUseCase{Manager: stub},
+		Store: stagingStore,
+		StrategyFor: func(namespace string) (staging.FullStrategy, error) {
+			return staging.NewAzureAppConfigParamStrategy(liveStore(stub.live, namespace)), nil
+		},
+		Stdout: &buf,
+		Stderr: &errBuf,
+	}
+
+	require.NoError(t, r.Run(t.Context(), "release-1"))
+
+	entries, err := stagingStore.ListEntries(t.Context(), staging.ServiceParam)
+	require.NoError(t, err)
+
+	got := entries[staging.ServiceParam]
+	require.Len(t, got, 2)
+
+	ref := got[staging.EntryKey{Name: "app/ref"}]
+	assert.Equal(t, staging.OperationUpdate, ref.Operation, "staged although the value is unchanged")
+	assert.Equal(t, domain.ValueTypeReference, ref.ValueType)
+	require.NotNil(t, ref.Attributes)
+	assert.Equal(t, appconfig.KeyVaultRefContentType, lo.FromPtr(ref.Attributes.ContentType))
+
+	typed := got[staging.EntryKey{Name: "app/typed"}]
+	assert.Equal(t, staging.OperationCreate, typed.Operation)
+	assert.Equal(t, domain.ValueTypePlaintext, typed.ValueType)
+	require.NotNil(t, typed.Attributes)
+	assert.Equal(t, "application/json", lo.FromPtr(typed.Attributes.ContentType))
+}
+
+func TestSnapshotRestoreRunner_ReportsFailuresPerEntry(t *testing.T) {
+	t.Parallel()
+
+	stub := newSnapshotStub()
+
+	var buf, errBuf bytes.Buffer
+
+	r := &param.SnapshotRestoreRunner{
+		Diff:  &azure.SnapshotDiffUseCase{Manager: stub},
+		Store: testutil.NewMockStore(),
+		StrategyFor: func(namespace string) (staging.FullStrategy, error) {
+			if namespace == "dev" {
+				return nil, errors.New("boom")
+			}
+
+			return staging.NewAzureAppConfigParamStrategy(liveStore(stub.live, namespace)), nil
+		},
+		Stdout: &buf,
+		Stderr: &errBuf,
+	}
+
+	err := r.Run(t.Context(), "release-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 3")
+	assert.Contains(t, errBuf.String(), "app/d [dev]: boom")
+	assert.Contains(t, buf.String(), "Staged update: app/b")
+}
+
+func TestSnapshotRestoreRunner_NothingToRestore(t *testing.T) {
+	t.Parallel()
+
+	stub := newSnapshotStub()
+	stub.live = stub.snap
+
+	var buf, errBuf bytes.Buffer
+
+	r := &param.SnapshotRestoreRunner{
+		Diff:   &azure.SnapshotDiffUseCase{Manager: stub},
+		Store:  testutil.NewMockStore(),
+		Stdout: &buf,
+		Stderr: &errBuf,
+	}
+
+	require.NoError(t, r.Run(t.Context(), "release-1"))
+	assert.Contains(t, buf.String(), "already matches")
+}
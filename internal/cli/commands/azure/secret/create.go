@@ -7,9 +7,11 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/mpyw/suve/internal/cli/commands/azure/secret/secretattrs"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
 	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
 	"github.com/mpyw/suve/internal/usecase/azure"
 )
 
@@ -24,6 +26,8 @@ type CreateRunner struct {
 type CreateOptions struct {
 	Name  string
 	Value string
+	// Attributes are the first version's Key Vault attributes (zero = none).
+	Attributes provider.SecretAttributes
 }
 
 // CreateCommand returns the Azure Key Vault create command.
@@ -44,14 +48,19 @@ The value may be given as a positional argument, read from stdin with
 --value-stdin (so it never appears in argv/ps or shell history), or, when
 omitted, typed into $EDITOR.
 
+ATTRIBUTES:
+   --content-type, --not-before, --expires and --enabled set the version's
+   Key Vault attributes. Times are RFC3339 or YYYY-MM-DD (midnight UTC).
+
 EXAMPLES:
    suve azure secret create my-api-key "sk-12345"             Create simple secret
    suve azure secret create my-config '{"host":"db"}'         Create JSON secret
    printf '%s' "$V" | suve azure secret create my-key --value-stdin  Read value from stdin
-   suve azure secret create my-key                            Type value into $EDITOR`,
-		Flags: []cli.Flag{
+   suve azure secret create my-key                            Type value into $EDITOR
+   suve azure secret create --expires 2027-01-01 my-key "v"   Create with an expiry`,
+		Flags: append([]cli.Flag{
 			cliinternal.ValueStdinFlag(),
-		},
+		}, secretattrs.Flags()...),
 		Action: createAction,
 	}
 }
@@ -62,6 +71,11 @@ func createAction(ctx context.Context, cmd *cli.Command) error {
 		return errors.New("usage: suve azure secret create <name> [<value>]")
 	}
 
+	attrs, err := secretattrs.Build(secretattrs.FromCmd(cmd))
+	if err != nil {
+		return err
+	}
+
 	value, proceed, err := cliinternal.ResolveValue(ctx, cliinternal.ValueSource{
 		FromStdin: cmd.Bool(cliinternal.FlagValueStdin),
 		HasArg:    args.Len() >= 2, //nolint:mnd // arg 0 is the name, arg 1 is the optional value
@@ -89,7 +103,7 @@ func createAction(ctx context.Context, cmd *cli.Command) error {
 		Stderr:  cmd.Root().ErrWriter,
 	}
 
-	return r.Run(ctx, CreateOptions{Name: args.Get(0), Value: value})
+	return r.Run(ctx, CreateOptions{Name: args.Get(0), Value: value, Attributes: attrs})
 }
 
 // Run executes the create command.
@@ -98,6 +112,7 @@ func (r *CreateRunner) Run(ctx context.Context, opts CreateOptions) error {
 		Name:      opts.Name,
 		Value:     opts.Value,
 		ValueType: domain.ValueTypeSecret,
+		Options:   secretattrs.Options(opts.Attributes),
 	})
 	if err != nil {
 		return err
@@ -2,15 +2,21 @@ package secret
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/urfave/cli/v3"
 
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	genericlist "github.com/mpyw/suve/internal/cli/commands/generic/list"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/usecase/azure"
 )
 
+// flagExpiringWithin is the list flag selecting secrets by upcoming expiry.
+const flagExpiringWithin = "expiring-within"
+
 // ListCommand returns the Azure Key Vault list command.
 func ListCommand() *cli.Command {
 	return genericlist.Command(genericlist.Config{
@@ -24,6 +30,11 @@ With a filter prefix, lists only secrets whose names start with that prefix.
 FILTERING:
    Use --filter to filter results by regex pattern (client-side).
 
+EXPIRY:
+   Use --expiring-within=N to list only secrets whose current version expires
+   within N days. Already-expired secrets are included; secrets without an
+   expiry never match. 0 lists only the already-expired ones.
+
 VALUE DISPLAY:
    Use --show to display secret values alongside names.
    Output format: <name><TAB><value>
@@ -32,6 +43,7 @@ EXAMPLES:
    suve azure secret list                     List all secrets
    suve azure secret list prod                List secrets starting with "prod"
    suve azure secret list --show prod         List with values
+   suve azure secret list --expiring-within=30  List secrets expiring in 30 days
    suve azure secret list --output=json prod  List as JSON`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
@@ -42,11 +54,16 @@ EXAMPLES:
 				Name:  "show",
 				Usage: "Show secret values",
 			},
+			&cli.IntFlag{
+				Name:  flagExpiringWithin,
+				Usage: "List only secrets expiring within this many days (expired included)",
+			},
 			&cli.StringFlag{
 				Name:  "output",
 				Usage: "Output format: text (default) or json",
 			},
 		},
+		Axis: fanout.Vaults(),
 		NewList: func(
 			ctx context.Context, cmd *cli.Command, withValue bool,
 		) (func(context.Context) ([]genericlist.Entry, error), error) {
@@ -62,6 +79,19 @@ EXAMPLES:
 				WithValue: withValue,
 			}
 
+			if cmd.IsSet(flagExpiringWithin) {
+				days := cmd.Int(flagExpiringWithin)
+				if days < 0 {
+					return nil, fmt.Errorf("invalid --%s %d (want 0 or more days)", flagExpiringWithin, days)
+				}
+
+				input.ExpiringWithin = lo.ToPtr(time.Duration(days) * 24 * time.Hour) //nolint:mnd // hours per day
+
+				if lister, ok := store.(azure.ExpiryLister); ok {
+					uc.Expiries = lister
+				}
+			}
+
 			return func(ctx context.Context) ([]genericlist.Entry, error) {
 				result, err := uc.Execute(ctx, input)
 				if err != nil {
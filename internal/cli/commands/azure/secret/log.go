@@ -10,6 +10,7 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/mpyw/suve/internal/cli/colors"
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	genericlog "github.com/mpyw/suve/internal/cli/commands/generic/log"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
@@ -22,12 +23,15 @@ import (
 
 // logJSONItem represents a single version entry in JSON output.
 type logJSONItem struct {
-	Version string            `json:"version"`
-	State   string            `json:"state,omitempty"`
-	Created string            `json:"created,omitempty"`
-	Value   *string           `json:"value,omitempty"`
-	Tags    map[string]string `json:"tags,omitempty"`
-	Error   string            `json:"error,omitempty"`
+	Version   string            `json:"version"`
+	State     string            `json:"state,omitempty"`
+	Created   string            `json:"created,omitempty"`
+	NotBefore string            `json:"notBefore,omitempty"`
+	Expires   string            `json:"expires,omitempty"`
+	Message   string            `json:"message,omitempty"`
+	Value     *string           `json:"value,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	Error     string            `json:"error,omitempty"`
 }
 
 // logPresenter renders Azure Key Vault log output.
@@ -71,12 +75,20 @@ func (p *logPresenter) Len() int { return len(p.result.Entries) }
 
 func (p *logPresenter) RenderJSON(stdout io.Writer) error {
 	items := lo.Map(p.result.Entries, func(entry azure.LogEntry, _ int) logJSONItem {
-		item := logJSONItem{Version: entry.Version, State: entry.State}
+		item := logJSONItem{Version: entry.Version, State: entry.State, Message: entry.Message}
 
 		if entry.CreatedDate != nil {
 			item.Created = timeutil.FormatRFC3339(*entry.CreatedDate)
 		}
 
+		if entry.NotBefore != nil {
+			item.NotBefore = timeutil.FormatRFC3339(*entry.NotBefore)
+		}
+
+		if entry.Expires != nil {
+			item.Expires = timeutil.FormatRFC3339(*entry.Expires)
+		}
+
 		if entry.Error != nil {
 			item.Error = entry.Error.Error()
 		} else {
@@ -109,10 +121,16 @@ func (p *logPresenter) RenderOneline(stdout io.Writer, i, _ int) {
 		stateStr = colors.For(stdout).Current(fmt.Sprintf(" [%s]", entry.State))
 	}
 
-	output.Printf(stdout, "%s%s  %s\n",
+	expiresStr := ""
+	if entry.Expires != nil {
+		expiresStr = "  " + colors.For(stdout).FieldLabel("expires "+timeutil.FormatDate(*entry.Expires))
+	}
+
+	output.Printf(stdout, "%s%s  %s%s\n",
 		colors.For(stdout).Version(entry.Version),
 		stateStr,
 		colors.For(stdout).FieldLabel(dateStr),
+		expiresStr,
 	)
 }
 
@@ -130,6 +148,14 @@ func (p *logPresenter) RenderHeader(stdout io.Writer, i int) {
 		output.Printf(stdout, "%s %s\n", colors.For(stdout).FieldLabel("Date:"), timeutil.FormatRFC3339(*entry.CreatedDate))
 	}
 
+	if entry.NotBefore != nil {
+		output.Printf(stdout, "%s %s\n", colors.For(stdout).FieldLabel("Not Before:"), timeutil.FormatRFC3339(*entry.NotBefore))
+	}
+
+	if entry.Expires != nil {
+		output.Printf(stdout, "%s %s\n", colors.For(stdout).FieldLabel("Expires:"), timeutil.FormatRFC3339(*entry.Expires))
+	}
+
 	// Key Vault tags are per version, so show this version's own tags.
 	if len(entry.Tags) > 0 {
 		pairs := lo.Map(entry.Tags, func(tag domain.Tag, _ int) string {
@@ -138,6 +164,10 @@ func (p *logPresenter) RenderHeader(stdout io.Writer, i int) {
 
 		output.Printf(stdout, "%s %s\n", colors.For(stdout).FieldLabel("Tags:"), strings.Join(pairs, ", "))
 	}
+
+	if entry.Message != "" {
+		output.Printf(stdout, "%s %s\n", colors.For(stdout).FieldLabel("Message:"), entry.Message)
+	}
 }
 
 // RenderValue is a no-op: like the AWS/GoogleCloud secret log, Azure Key Vault log does
@@ -259,6 +289,7 @@ EXAMPLES:
 				Usage: "Output format: text (default) or json",
 			},
 		},
+		Axis: fanout.Vaults(),
 		NewPresenter: func(ctx context.Context, req genericlog.Request) (genericlog.Presenter, error) {
 			store, err := cliinternal.AzureKeyVaultStore(ctx)
 			if err != nil {
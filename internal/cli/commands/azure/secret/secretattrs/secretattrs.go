@@ -0,0 +1,126 @@
+// Package secretattrs builds the Azure Key Vault secret attribute write option
+// (content type, activation window, enabled flag) from CLI flag values, keeping
+// the flag-to-option mapping in one place shared by the secret create and
+// update commands and the Key Vault stage commands.
+package secretattrs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// Flag names for the secret attributes.
+const (
+	FlagContentType = "content-type"
+	FlagNotBefore   = "not-before"
+	FlagExpires     = "expires"
+	FlagEnabled     = "enabled"
+)
+
+// dateLayout is the date-only form accepted by the time flags (midnight UTC).
+const dateLayout = "2006-01-02"
+
+// Flags returns the secret attribute flags.
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  FlagContentType,
+			Usage: "Content type of the secret value (e.g. application/json)",
+		},
+		&cli.StringFlag{
+			Name:  FlagNotBefore,
+			Usage: "Activation time: the version is unusable before it (RFC3339 or YYYY-MM-DD)",
+		},
+		&cli.StringFlag{
+			Name:  FlagExpires,
+			Usage: "Expiry time: the version is unusable after it (RFC3339 or YYYY-MM-DD)",
+		},
+		&cli.BoolFlag{
+			Name:  FlagEnabled,
+			Usage: "Enable (--enabled) or disable (--enabled=false) the version",
+		},
+	}
+}
+
+// Values holds the raw flag values; nil means the flag was not given.
+type Values struct {
+	ContentType *string
+	NotBefore   *string
+	Expires     *string
+	Enabled     *bool
+}
+
+// FromCmd reads the set attribute flags from cmd.
+func FromCmd(cmd *cli.Command) Values {
+	var v Values
+
+	if cmd.IsSet(FlagContentType) {
+		v.ContentType = lo.ToPtr(cmd.String(FlagContentType))
+	}
+
+	if cmd.IsSet(FlagNotBefore) {
+		v.NotBefore = lo.ToPtr(cmd.String(FlagNotBefore))
+	}
+
+	if cmd.IsSet(FlagExpires) {
+		v.Expires = lo.ToPtr(cmd.String(FlagExpires))
+	}
+
+	if cmd.IsSet(FlagEnabled) {
+		v.Enabled = lo.ToPtr(cmd.Bool(FlagEnabled))
+	}
+
+	return v
+}
+
+// Build validates the values and converts them into the attribute option. An
+// all-unset Values yields a zero SecretAttributes (see IsZero).
+func Build(v Values) (provider.SecretAttributes, error) {
+	attrs := provider.SecretAttributes{ContentType: v.ContentType, Enabled: v.Enabled}
+
+	var err error
+
+	if attrs.NotBefore, err = parseTime(FlagNotBefore, v.NotBefore); err != nil {
+		return provider.SecretAttributes{}, err
+	}
+
+	if attrs.Expires, err = parseTime(FlagExpires, v.Expires); err != nil {
+		return provider.SecretAttributes{}, err
+	}
+
+	if attrs.NotBefore != nil && attrs.Expires != nil && !attrs.NotBefore.Before(*attrs.Expires) {
+		return provider.SecretAttributes{}, fmt.Errorf("--%s must be before --%s", FlagNotBefore, FlagExpires)
+	}
+
+	return attrs, nil
+}
+
+// Options wraps the attributes as write options: nil when none is set, so the
+// command behaves exactly as without the flags.
+func Options(attrs provider.SecretAttributes) []provider.WriteOption {
+	if attrs.IsZero() {
+		return nil
+	}
+
+	return []provider.WriteOption{attrs}
+}
+
+// parseTime parses an RFC3339 timestamp or a YYYY-MM-DD date (midnight UTC).
+func parseTime(flag string, s *string) (*time.Time, error) {
+	if s == nil {
+		return nil, nil //nolint:nilnil // intentional: an unset flag is no time and no error
+	}
+
+	for _, layout := range []string{time.RFC3339, dateLayout} {
+		if t, err := time.Parse(layout, *s); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid --%s %q (want RFC3339 or YYYY-MM-DD)", flag, *s)
+}
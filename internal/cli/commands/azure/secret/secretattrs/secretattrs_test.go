@@ -0,0 +1,63 @@
+package secretattrs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/cli/commands/azure/secret/secretattrs"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty values yield no options", func(t *testing.T) {
+		t.Parallel()
+
+		attrs, err := secretattrs.Build(secretattrs.Values{})
+		require.NoError(t, err)
+		assert.True(t, attrs.IsZero())
+		assert.Nil(t, secretattrs.Options(attrs))
+	})
+
+	t.Run("all values", func(t *testing.T) {
+		t.Parallel()
+
+		attrs, err := secretattrs.Build(secretattrs.Values{
+			ContentType: lo.ToPtr("text/plain"),
+			NotBefore:   lo.ToPtr("2026-01-01"),
+			Expires:     lo.ToPtr("2027-01-01T09:00:00+09:00"),
+			Enabled:     lo.ToPtr(false),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "text/plain", *attrs.ContentType)
+		assert.True(t, attrs.NotBefore.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, attrs.Expires.Equal(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)))
+		assert.False(t, *attrs.Enabled)
+		assert.Equal(t, []provider.WriteOption{attrs}, secretattrs.Options(attrs))
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := secretattrs.Build(secretattrs.Values{Expires: lo.ToPtr("next week")})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --expires")
+	})
+
+	t.Run("not-before must precede expires", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := secretattrs.Build(secretattrs.Values{
+			NotBefore: lo.ToPtr("2027-01-01"),
+			Expires:   lo.ToPtr("2026-01-01"),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--not-before must be before --expires")
+	})
+}
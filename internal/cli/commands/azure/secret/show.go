@@ -7,6 +7,7 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	genericshow "github.com/mpyw/suve/internal/cli/commands/generic/show"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
@@ -19,12 +20,17 @@ import (
 
 // showJSONOutput represents the JSON output structure for the show command.
 type showJSONOutput struct {
-	Name    string            `json:"name"`
-	Version string            `json:"version,omitempty"`
-	State   string            `json:"state,omitempty"`
-	Created string            `json:"created,omitempty"`
-	Tags    map[string]string `json:"tags"`
-	Value   string            `json:"value"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	State   string `json:"state,omitempty"`
+	Created string `json:"created,omitempty"`
+	// ContentType, NotBefore and Expires are the version's attributes,
+	// omitted when unset.
+	ContentType string            `json:"contentType,omitempty"`
+	NotBefore   string            `json:"notBefore,omitempty"`
+	Expires     string            `json:"expires,omitempty"`
+	Tags        map[string]string `json:"tags"`
+	Value       string            `json:"value"`
 }
 
 // showPresenter renders Azure Key Vault show output.
@@ -77,6 +83,18 @@ func (p *showPresenter) RenderText(stdout io.Writer, value string) {
 		out.Field("Created", timeutil.FormatRFC3339(*result.CreatedDate))
 	}
 
+	if result.ContentType != "" {
+		out.Field("Content Type", result.ContentType)
+	}
+
+	if result.NotBefore != nil {
+		out.Field("Not Before", timeutil.FormatRFC3339(*result.NotBefore))
+	}
+
+	if result.Expires != nil {
+		out.Field("Expires", timeutil.FormatRFC3339(*result.Expires))
+	}
+
 	if len(result.Tags) > 0 {
 		out.Field("Tags", fmt.Sprintf("%d tag(s)", len(result.Tags)))
 
@@ -103,6 +121,16 @@ func (p *showPresenter) RenderJSON(stdout io.Writer, value string) error {
 		jsonOut.Created = timeutil.FormatRFC3339(*result.CreatedDate)
 	}
 
+	jsonOut.ContentType = result.ContentType
+
+	if result.NotBefore != nil {
+		jsonOut.NotBefore = timeutil.FormatRFC3339(*result.NotBefore)
+	}
+
+	if result.Expires != nil {
+		jsonOut.Expires = timeutil.FormatRFC3339(*result.Expires)
+	}
+
 	jsonOut.Tags = make(map[string]string)
 	for _, tag := range result.Tags {
 		jsonOut.Tags[tag.Key] = tag.Value
@@ -133,7 +161,8 @@ EXAMPLES:
   suve azure secret show --output=json my-secret          Output as JSON`,
 		UsageError: "usage: suve azure secret show <name>",
 		ParseSpec:  azurekvversion.Parse,
-		NewPresenter: func(ctx context.Context, spec *azurekvversion.Spec) (genericshow.Presenter, error) {
+		Axis:       fanout.Vaults(),
+		NewPresenter: func(ctx context.Context, _ *cli.Command, spec *azurekvversion.Spec) (genericshow.Presenter, error) {
 			store, err := cliinternal.AzureKeyVaultStore(ctx)
 			if err != nil {
 				return nil, err
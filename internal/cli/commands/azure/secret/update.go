@@ -7,10 +7,12 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/mpyw/suve/internal/cli/commands/azure/secret/secretattrs"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/confirm"
 	"github.com/mpyw/suve/internal/cli/output"
 	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
 	"github.com/mpyw/suve/internal/usecase/azure"
 )
 
@@ -25,6 +27,18 @@ type UpdateRunner struct {
 type UpdateOptions struct {
 	Name  string
 	Value string
+	// Attributes are the new version's Key Vault attributes (zero = none).
+	Attributes provider.SecretAttributes
+}
+
+// errAttributesUnsupported is returned when the resolved store cannot update
+// attributes in place.
+var errAttributesUnsupported = errors.New("the resolved store does not support attribute updates")
+
+// AttributesRunner executes an attributes-only update.
+type AttributesRunner struct {
+	UseCase *azure.AttributesUseCase
+	Stdout  io.Writer
 }
 
 // UpdateCommand returns the Azure Key Vault update command.
@@ -42,18 +56,26 @@ The value may be given as a positional argument, read from stdin with
 --value-stdin (so it never appears in argv/ps or shell history), or, when
 omitted, typed into $EDITOR.
 
+ATTRIBUTES:
+  --content-type, --not-before, --expires and --enabled set the new version's
+  Key Vault attributes (a new version does not inherit the previous one's).
+  Given without a value (and without --value-stdin), they update the current
+  version in place instead of adding a version. Times are RFC3339 or
+  YYYY-MM-DD (midnight UTC).
+
 EXAMPLES:
   suve azure secret update my-api-key "new-value"       Add a new version
   suve azure secret update --yes my-api-key "new-value" Update without confirmation
   printf '%s' "$V" | suve azure secret update --yes my-key --value-stdin  Read value from stdin
-  suve azure secret update my-key                       Type value into $EDITOR`,
-		Flags: []cli.Flag{
+  suve azure secret update my-key                       Type value into $EDITOR
+  suve azure secret update --expires 2027-06-30 my-key  Extend the current version's expiry`,
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:  "yes",
 				Usage: "Skip confirmation prompt",
 			},
 			cliinternal.ValueStdinFlag(),
-		},
+		}, secretattrs.Flags()...),
 		Action: updateAction,
 	}
 }
@@ -67,6 +89,15 @@ func updateAction(ctx context.Context, cmd *cli.Command) error {
 	name := args.Get(0)
 	skipConfirm := cmd.Bool("yes")
 
+	attrs, err := secretattrs.Build(secretattrs.FromCmd(cmd))
+	if err != nil {
+		return err
+	}
+
+	if !attrs.IsZero() && args.Len() < 2 && !cmd.Bool(cliinternal.FlagValueStdin) { //nolint:mnd // no value argument
+		return updateAttributesAction(ctx, cmd, name, attrs)
+	}
+
 	newValue, proceed, err := cliinternal.ResolveValue(ctx, cliinternal.ValueSource{
 		FromStdin: cmd.Bool(cliinternal.FlagValueStdin),
 		HasArg:    args.Len() >= 2, //nolint:mnd // arg 0 is the name, arg 1 is the optional value
@@ -124,7 +155,52 @@ func updateAction(ctx context.Context, cmd *cli.Command) error {
 		Stderr:  cmd.Root().ErrWriter,
 	}
 
-	return r.Run(ctx, UpdateOptions{Name: name, Value: newValue})
+	return r.Run(ctx, UpdateOptions{Name: name, Value: newValue, Attributes: attrs})
+}
+
+// updateAttributesAction updates the current version's attributes in place,
+// confirming first unless --yes.
+func updateAttributesAction(ctx context.Context, cmd *cli.Command, name string, attrs provider.SecretAttributes) error {
+	if !cmd.Bool("yes") {
+		prompter := &confirm.Prompter{
+			Stdin:  cliinternal.Stdin(cmd),
+			Stdout: cmd.Root().Writer,
+			Stderr: cmd.Root().ErrWriter,
+		}
+
+		confirmed, err := prompter.ConfirmAction("Update attributes of secret", name, false)
+		if err != nil || !confirmed {
+			return err
+		}
+	}
+
+	store, err := cliinternal.AzureKeyVaultStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	updater, ok := store.(provider.AttributeUpdater)
+	if !ok {
+		return errAttributesUnsupported
+	}
+
+	r := &AttributesRunner{
+		UseCase: &azure.AttributesUseCase{Updater: updater},
+		Stdout:  cmd.Root().Writer,
+	}
+
+	return r.Run(ctx, name, attrs)
+}
+
+// Run executes an attributes-only update.
+func (r *AttributesRunner) Run(ctx context.Context, name string, attrs provider.SecretAttributes) error {
+	if err := r.UseCase.Execute(ctx, azure.AttributesInput{Name: name, Attributes: attrs}); err != nil {
+		return err
+	}
+
+	output.Success(r.Stdout, "Updated attributes of secret %s", name)
+
+	return nil
 }
 
 // Run executes the update command.
@@ -133,6 +209,7 @@ func (r *UpdateRunner) Run(ctx context.Context, opts UpdateOptions) error {
 		Name:      opts.Name,
 		Value:     opts.Value,
 		ValueType: domain.ValueTypeSecret,
+		Options:   secretattrs.Options(opts.Attributes),
 	})
 	if err != nil {
 		return err
@@ -9,6 +9,7 @@ import (
 	"github.com/mpyw/suve/internal/cli/commands/aws/stage/diff"
 	"github.com/mpyw/suve/internal/cli/commands/aws/stage/reset"
 	"github.com/mpyw/suve/internal/cli/commands/aws/stage/status"
+	"github.com/mpyw/suve/internal/cli/commands/azure/secret/secretattrs"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/staging"
 	stgcli "github.com/mpyw/suve/internal/staging/cli"
@@ -18,17 +19,36 @@ import (
 const nounSecret = "secret"
 
 // keyVaultStageConfig is the staging config for Azure Key Vault secrets. The
-// ScopeResolver keys on-disk staging state by the resolved vault.
+// ScopeResolver keys on-disk staging state by the resolved vault; add/edit
+// take the secret attribute flags.
 func keyVaultStageConfig() stgcli.CommandConfig {
 	return stgcli.CommandConfig{
-		CommandName:   nounSecret,
-		ItemName:      nounSecret,
-		Factory:       cliinternal.AzureKeyVaultSecretStrategyFactory,
-		ParserFactory: staging.AzureKeyVaultSecretParserFactory,
-		ScopeResolver: cliinternal.AzureKeyVaultStagingScopeResolver,
+		CommandName:       nounSecret,
+		ItemName:          nounSecret,
+		Factory:           cliinternal.AzureKeyVaultSecretStrategyFactory,
+		ParserFactory:     staging.AzureKeyVaultSecretParserFactory,
+		ScopeResolver:     cliinternal.AzureKeyVaultStagingScopeResolver,
+		AttributeFlags:    secretattrs.Flags(),
+		AttributesFromCmd: stagedSecretAttributes,
 	}
 }
 
+// stagedSecretAttributes resolves the staged Key Vault attributes from the
+// attribute flags; nil when none was given.
+func stagedSecretAttributes(cmd *cli.Command) (*staging.SecretAttributes, error) {
+	attrs, err := secretattrs.Build(secretattrs.FromCmd(cmd))
+	if err != nil || attrs.IsZero() {
+		return nil, err
+	}
+
+	return &staging.SecretAttributes{
+		ContentType: attrs.ContentType,
+		NotBefore:   attrs.NotBefore,
+		Expires:     attrs.Expires,
+		Enabled:     attrs.Enabled,
+	}, nil
+}
+
 // appConfigStageConfig is the staging config for Azure App Configuration. The
 // ScopeResolver keys on-disk staging state by the resolved store.
 func appConfigStageConfig() stgcli.CommandConfig {
@@ -48,6 +68,9 @@ func appConfigStageConfig() stgcli.CommandConfig {
 				cliinternal.WithAzureAppConfigNamespace(ctx, namespace),
 			)
 		},
+		// Settings can be locked read-only; apply detects them and can unlock,
+		// write and relock with --unlock-locked.
+		HasLocks: true,
 	}
 }
 
@@ -62,6 +85,7 @@ func keyVaultStageSubcommands(cfg stgcli.CommandConfig) []*cli.Command {
 		stgcli.NewDiffCommand(cfg),
 		stgcli.NewApplyCommand(cfg),
 		stgcli.NewResetCommand(cfg),
+		stgcli.NewResolveCommand(cfg),
 		stgcli.NewTagCommand(cfg),
 		stgcli.NewUntagCommand(cfg),
 		stgcli.NewExportCommand(cfg),
@@ -81,6 +105,7 @@ func appConfigStageSubcommands(cfg stgcli.CommandConfig) []*cli.Command {
 		stgcli.NewDiffCommand(cfg),
 		stgcli.NewApplyCommand(cfg),
 		stgcli.NewResetCommand(cfg),
+		stgcli.NewResolveCommand(cfg),
 		stgcli.NewTagCommand(cfg),
 		stgcli.NewUntagCommand(cfg),
 		stgcli.NewExportCommand(cfg),
@@ -158,8 +183,16 @@ resource can hold no staged state. Set the resource with --store-name /
 variables):
    status    Show all staged changes (Key Vault and App Configuration)
    diff      Show diff of all staged changes vs Azure
+   plan      Capture staged changes into a reviewable plan file
    apply     Apply all staged changes to Azure
    reset     Unstage all changes
+   resolve   Merge remote changes into conflicting staged changes
+   switch    Switch the active changeset (-c to create one)
+   branch    List or delete changesets
+   move      Move a staged change to another changeset
+   stash     Shelve staged changes and restore them later
+   history   List past applies
+   undo      Stage the changes that reverse an apply
 
 EXAMPLES:
    suve azure stage secret add my-secret     Stage a new Key Vault secret
@@ -214,8 +247,16 @@ func StageCommand() *cli.Command {
 			appConfigStageGroup(),
 			status.Command(gcfg),
 			diff.Command(gcfg),
+			apply.PlanCommand(gcfg),
 			apply.Command(gcfg),
 			reset.Command(gcfg),
+			stgcli.NewGlobalResolveCommand(gcfg),
+			stgcli.NewGlobalSwitchCommand(gcfg),
+			stgcli.NewGlobalBranchCommand(gcfg),
+			stgcli.NewGlobalMoveCommand(gcfg),
+			stgcli.NewGlobalStashCommand(gcfg),
+			stgcli.NewGlobalHistoryCommand(gcfg),
+			stgcli.NewGlobalUndoCommand(gcfg),
 		},
 		CommandNotFound: cliinternal.CommandNotFound,
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/mpyw/suve/internal/cli/colors"
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	genericlog "github.com/mpyw/suve/internal/cli/commands/generic/log"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
@@ -23,6 +24,7 @@ type logJSONItem struct {
 	Version string  `json:"version"`
 	State   string  `json:"state,omitempty"`
 	Created string  `json:"created,omitempty"`
+	Message string  `json:"message,omitempty"`
 	Value   *string `json:"value,omitempty"`
 	Error   string  `json:"error,omitempty"`
 }
@@ -68,7 +70,7 @@ func (p *logPresenter) Len() int { return len(p.result.Entries) }
 
 func (p *logPresenter) RenderJSON(stdout io.Writer) error {
 	items := lo.Map(p.result.Entries, func(entry gcloud.LogEntry, _ int) logJSONItem {
-		item := logJSONItem{Version: entry.Version, State: entry.State}
+		item := logJSONItem{Version: entry.Version, State: entry.State, Message: entry.Message}
 
 		if entry.CreatedDate != nil {
 			item.Created = timeutil.FormatRFC3339(*entry.CreatedDate)
@@ -119,6 +121,10 @@ func (p *logPresenter) RenderHeader(stdout io.Writer, i int) {
 	if entry.CreatedDate != nil {
 		output.Printf(stdout, "%s %s\n", colors.For(stdout).FieldLabel("Date:"), timeutil.FormatRFC3339(*entry.CreatedDate))
 	}
+
+	if entry.Message != "" {
+		output.Printf(stdout, "%s %s\n", colors.For(stdout).FieldLabel("Message:"), entry.Message)
+	}
 }
 
 // RenderValue is a no-op: like the AWS secret log, Google Cloud log does not
@@ -241,6 +247,7 @@ EXAMPLES:
 				Usage: "Output format: text (default) or json",
 			},
 		},
+		Axis: fanout.Projects(),
 		NewPresenter: func(ctx context.Context, req genericlog.Request) (genericlog.Presenter, error) {
 			store, err := cliinternal.GoogleCloudSecretStore(ctx)
 			if err != nil {
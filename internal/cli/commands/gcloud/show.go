@@ -7,6 +7,7 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	genericshow "github.com/mpyw/suve/internal/cli/commands/generic/show"
 	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
@@ -139,7 +140,8 @@ EXAMPLES:
   suve gcloud secret show --output=json my-secret          Output as JSON`,
 		UsageError: "usage: suve gcloud secret show <name>",
 		ParseSpec:  gcloudversion.Parse,
-		NewPresenter: func(ctx context.Context, spec *gcloudversion.Spec) (genericshow.Presenter, error) {
+		Axis:       fanout.Projects(),
+		NewPresenter: func(ctx context.Context, _ *cli.Command, spec *gcloudversion.Spec) (genericshow.Presenter, error) {
 			store, err := cliinternal.GoogleCloudSecretStore(ctx)
 			if err != nil {
 				return nil, err
@@ -34,6 +34,13 @@ Google Cloud is secret-only, so 'suve gcloud stage' operates on secrets directly
    diff      Show diff of staged changes vs Google Cloud
    apply     Apply staged changes to Google Cloud
    reset     Unstage changes
+   resolve   Merge remote changes into conflicting staged changes
+   switch    Switch the active changeset (-c to create one)
+   branch    List or delete changesets
+   move      Move a staged change to another changeset
+   stash     Shelve staged changes and restore them later
+   history   List past applies
+   undo      Stage the changes that reverse an apply
    tag/untag Stage label changes
    export    Export staged changes to a directory
    import    Import staged changes from a directory
@@ -46,6 +53,8 @@ EXAMPLES:
 
 // stageSubcommands builds the staging subcommands for the given config.
 func stageSubcommands(cfg stgcli.CommandConfig) []*cli.Command {
+	gcfg := stgcli.SecretOnlyGlobalConfig("Google Cloud", cfg)
+
 	return []*cli.Command{
 		stgcli.NewAddCommand(cfg),
 		stgcli.NewEditCommand(cfg),
@@ -54,6 +63,13 @@ func stageSubcommands(cfg stgcli.CommandConfig) []*cli.Command {
 		stgcli.NewDiffCommand(cfg),
 		stgcli.NewApplyCommand(cfg),
 		stgcli.NewResetCommand(cfg),
+		stgcli.NewResolveCommand(cfg),
+		stgcli.NewGlobalSwitchCommand(gcfg),
+		stgcli.NewGlobalBranchCommand(gcfg),
+		stgcli.NewGlobalMoveCommand(gcfg),
+		stgcli.NewGlobalStashCommand(gcfg),
+		stgcli.NewGlobalHistoryCommand(gcfg),
+		stgcli.NewGlobalUndoCommand(gcfg),
 		stgcli.NewTagCommand(cfg),
 		stgcli.NewUntagCommand(cfg),
 		stgcli.NewExportCommand(cfg),
@@ -6,17 +6,25 @@
 // the version-spec grammar, the diff header labels, the JSON shape, and the
 // identical-versions hints — live behind the Presenter so each provider
 // reproduces its own byte-identical output.
+//
+// With --tree the command compares directories instead of versions: every
+// entry under two prefixes — of the command's store, of two scopes along the
+// provider's fan-out axis, or of the command's store and another provider —
+// paired by relative name and rendered through the same unified diff.
 package diff
 
 import (
 	"context"
+	"errors"
 	"io"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	"github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
 	"github.com/mpyw/suve/internal/jsonutil"
+	"github.com/mpyw/suve/internal/provider"
 )
 
 // Options holds the shared diff options.
@@ -125,6 +133,13 @@ type Config[S any] struct {
 	ParseDiffArgs func(args []string) (S, S, error)
 	// NewPresenter builds the provider Presenter bound to the two specs.
 	NewPresenter func(ctx context.Context, spec1, spec2 S) (Presenter, error)
+	// Store, when set, resolves the command's store for --tree.
+	Store func(ctx context.Context) (provider.Store, error)
+	// Kind is the service --tree --against reads from the other provider.
+	Kind provider.Kind
+	// Axis, when set, lets --tree compare two of the provider's scopes (see
+	// fanout).
+	Axis *fanout.Axis
 }
 
 // Command returns the generic diff command wired with the provider Config.
@@ -133,8 +148,8 @@ func Command[S any](cfg Config[S]) *cli.Command {
 		Name:        "diff",
 		Usage:       cfg.Usage,
 		ArgsUsage:   cfg.ArgsUsage,
-		Description: cfg.Description,
-		Flags: []cli.Flag{
+		Description: cfg.Description + treeDescription(cfg),
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:    "parse-json",
 				Aliases: []string{"j"},
@@ -148,8 +163,18 @@ func Command[S any](cfg Config[S]) *cli.Command {
 				Name:  "output",
 				Usage: "Output format: text (default) or json",
 			},
-		},
+		}, treeFlags(cfg)...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cfg.Store != nil {
+				if cmd.Bool("tree") {
+					return runTree(ctx, cmd, cfg)
+				}
+
+				if cmd.Bool("name-only") || cmd.Bool("stat") || cmd.String("against") != "" || len(cfg.Axis.Scopes(cmd)) > 0 {
+					return errors.New("--name-only, --stat, --against and scope lists require --tree")
+				}
+			}
+
 			spec1, spec2, err := cfg.ParseDiffArgs(cmd.Args().Slice())
 			if err != nil {
 				return err
@@ -0,0 +1,466 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/cli/colors"
+	"github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/jsonutil"
+	"github.com/mpyw/suve/internal/provider/detect"
+	"github.com/mpyw/suve/internal/usecase/treediff"
+)
+
+// devNull labels the missing side of an added or removed entry, as git does.
+const devNull = "/dev/null"
+
+// treeUsageFormat lays out one usage line of the --tree help section.
+const treeUsageFormat = "  %-44s%s"
+
+// statBarWidth caps the +/- bar of a --stat line.
+const statBarWidth = 40
+
+// TreeOptions holds the --tree output options.
+type TreeOptions struct {
+	ParseJSON bool
+	NameOnly  bool
+	Stat      bool
+	Output    output.Format
+}
+
+// treeJSONOutput is the JSON shape of a tree diff.
+type treeJSONOutput struct {
+	Old       treeJSONSide    `json:"old"`
+	New       treeJSONSide    `json:"new"`
+	Entries   []treeJSONEntry `json:"entries"`
+	Unchanged int             `json:"unchanged"`
+}
+
+// treeJSONSide is one side of a tree diff.
+type treeJSONSide struct {
+	Scope  string `json:"scope,omitempty"`
+	Prefix string `json:"prefix"`
+}
+
+// treeJSONEntry is one differing entry. Values and the diff are left out under
+// --name-only and --stat.
+type treeJSONEntry struct {
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	OldName    string  `json:"oldName,omitempty"`
+	NewName    string  `json:"newName,omitempty"`
+	OldValue   *string `json:"oldValue,omitempty"`
+	NewValue   *string `json:"newValue,omitempty"`
+	Insertions int     `json:"insertions"`
+	Deletions  int     `json:"deletions"`
+	Diff       string  `json:"diff,omitempty"`
+}
+
+// TreeRunner executes `diff --tree` over two prefixes.
+type TreeRunner struct {
+	Input   treediff.Input
+	Options TreeOptions
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// treeEntry is a differing entry with its rendered values, labels and diff.
+type treeEntry struct {
+	treediff.Entry
+
+	oldValue   string
+	newValue   string
+	oldLabel   string
+	newLabel   string
+	diff       string
+	insertions int
+	deletions  int
+}
+
+// Run executes the tree diff.
+func (r *TreeRunner) Run(ctx context.Context) error {
+	result, err := (&treediff.UseCase{}).Execute(ctx, r.Input)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]treeEntry, len(result.Entries))
+	for i, e := range result.Entries {
+		entries[i] = r.render(e)
+	}
+
+	if r.Options.Output == output.FormatJSON {
+		return r.writeJSON(entries, result.Unchanged)
+	}
+
+	if len(entries) == 0 {
+		output.Warning(r.Stderr, "no differences (%d identical entries)", result.Unchanged)
+
+		return nil
+	}
+
+	switch {
+	case r.Options.NameOnly:
+		for _, e := range entries {
+			output.Println(r.Stdout, e.Name)
+		}
+	case r.Options.Stat:
+		r.writeStat(entries)
+	default:
+		for _, e := range entries {
+			output.Print(r.Stdout, output.Diff(r.Stdout, e.oldLabel, e.newLabel, e.oldValue, e.newValue))
+		}
+	}
+
+	return nil
+}
+
+// render formats an entry's values (under --parse-json), labels its sides and
+// computes its raw unified diff and line counts.
+func (r *TreeRunner) render(e treediff.Entry) treeEntry {
+	te := treeEntry{Entry: e, oldValue: e.OldValue, newValue: e.NewValue, oldLabel: devNull, newLabel: devNull}
+
+	if r.Options.ParseJSON {
+		switch e.Status {
+		case treediff.StatusAdded:
+			te.newValue = jsonutil.TryFormatOrWarn(te.newValue, r.Stderr, e.NewName)
+		case treediff.StatusRemoved:
+			te.oldValue = jsonutil.TryFormatOrWarn(te.oldValue, r.Stderr, e.OldName)
+		case treediff.StatusChanged:
+			te.oldValue, te.newValue = jsonutil.TryFormatOrWarn2(te.oldValue, te.newValue, r.Stderr, e.Name)
+		}
+	}
+
+	if e.OldName != "" {
+		te.oldLabel = sideLabel(r.Input.Old, e.OldName)
+	}
+
+	if e.NewName != "" {
+		te.newLabel = sideLabel(r.Input.New, e.NewName)
+	}
+
+	te.diff = output.DiffRaw(te.oldLabel, te.newLabel, te.oldValue, te.newValue)
+	te.insertions, te.deletions = countLines(te.diff)
+
+	return te
+}
+
+// sideLabel labels an entry on one side: the full name, led by the side's
+// scope when the sides read different stores ("us-east-1:/app/db-url").
+func sideLabel(side treediff.Side, name string) string {
+	if side.Label == "" {
+		return name
+	}
+
+	return side.Label + ":" + name
+}
+
+// countLines counts the added and removed lines of a raw unified diff, skipping
+// the ---/+++ file labels before the first hunk.
+func countLines(diff string) (int, int) {
+	insertions, deletions := 0, 0
+	inHunk := false
+
+	for line := range strings.SplitSeq(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+		case strings.HasPrefix(line, "+"):
+			insertions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+
+	return insertions, deletions
+}
+
+// writeStat writes one "name | N ++--" line per entry and a summary line.
+func (r *TreeRunner) writeStat(entries []treeEntry) {
+	p := colors.For(r.Stdout)
+
+	nameWidth, countWidth, maxChanges := 0, 0, 0
+	for _, e := range entries {
+		nameWidth = max(nameWidth, len([]rune(e.Name)))
+		countWidth = max(countWidth, len(strconv.Itoa(e.insertions+e.deletions)))
+		maxChanges = max(maxChanges, e.insertions+e.deletions)
+	}
+
+	var added, removed, changed, insertions, deletions int
+
+	for _, e := range entries {
+		plus, minus := e.insertions, e.deletions
+		if maxChanges > statBarWidth {
+			plus = (plus*statBarWidth + maxChanges - 1) / maxChanges
+			minus = (minus*statBarWidth + maxChanges - 1) / maxChanges
+		}
+
+		output.Printf(r.Stdout, " %s | %*d %s%s\n",
+			e.Name+strings.Repeat(" ", nameWidth-len([]rune(e.Name))),
+			countWidth, e.insertions+e.deletions,
+			p.DiffAdded(strings.Repeat("+", plus)), p.DiffRemoved(strings.Repeat("-", minus)),
+		)
+
+		switch e.Status {
+		case treediff.StatusAdded:
+			added++
+		case treediff.StatusRemoved:
+			removed++
+		case treediff.StatusChanged:
+			changed++
+		}
+
+		insertions += e.insertions
+		deletions += e.deletions
+	}
+
+	output.Printf(r.Stdout, " %d entries differ (%d added, %d removed, %d changed), %d insertions(+), %d deletions(-)\n",
+		len(entries), added, removed, changed, insertions, deletions)
+}
+
+// writeJSON writes the tree diff as JSON.
+func (r *TreeRunner) writeJSON(entries []treeEntry, unchanged int) error {
+	out := treeJSONOutput{
+		Old:       treeJSONSide{Scope: r.Input.Old.Label, Prefix: r.Input.Old.Prefix},
+		New:       treeJSONSide{Scope: r.Input.New.Label, Prefix: r.Input.New.Prefix},
+		Entries:   make([]treeJSONEntry, len(entries)),
+		Unchanged: unchanged,
+	}
+
+	full := !r.Options.NameOnly && !r.Options.Stat
+
+	for i, e := range entries {
+		j := treeJSONEntry{
+			Name:       e.Name,
+			Status:     string(e.Status),
+			OldName:    e.OldName,
+			NewName:    e.NewName,
+			Insertions: e.insertions,
+			Deletions:  e.deletions,
+		}
+
+		if full {
+			j.Diff = e.diff
+
+			if e.OldName != "" {
+				j.OldValue = &e.oldValue
+			}
+
+			if e.NewName != "" {
+				j.NewValue = &e.newValue
+			}
+		}
+
+		out.Entries[i] = j
+	}
+
+	return output.WriteJSON(r.Stdout, out)
+}
+
+// treeFlags returns the --tree flags for a command whose Config can resolve a
+// store (none otherwise).
+func treeFlags[S any](cfg Config[S]) []cli.Flag {
+	if cfg.Store == nil {
+		return nil
+	}
+
+	return append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:  "tree",
+			Usage: "Compare every entry under two prefixes, paired by relative name",
+		},
+		&cli.BoolFlag{
+			Name:  "name-only",
+			Usage: "With --tree, print only the names of the differing entries",
+		},
+		&cli.BoolFlag{
+			Name:  "stat",
+			Usage: "With --tree, print a per-entry summary of changed lines",
+		},
+		&cli.StringFlag{
+			Name:  "against",
+			Usage: "With --tree, read the second prefix from this provider (configured from the environment)",
+		},
+	}, treeAxisFlags(cfg)...)
+}
+
+// treeAxisFlags returns the axis flag that names the two scopes of a --tree
+// comparison (none when the provider has no axis).
+func treeAxisFlags[S any](cfg Config[S]) []cli.Flag {
+	if cfg.Axis == nil {
+		return nil
+	}
+
+	return []cli.Flag{&cli.StringSliceFlag{
+		Name:  cfg.Axis.Flag,
+		Usage: "With --tree, compare the prefix in these two scopes (comma-separated)",
+	}}
+}
+
+// treeDescription is the --tree section appended to the help text of a
+// command whose Config can resolve a store.
+func treeDescription[S any](cfg Config[S]) string {
+	if cfg.Store == nil {
+		return ""
+	}
+
+	lines := []string{
+		"",
+		"",
+		"DIRECTORY DIFF:",
+		"  --tree compares every entry under two prefixes, paired by the name relative",
+		"  to each prefix, and reports added, removed and changed entries with a",
+		"  unified diff each. --name-only lists just the differing names and --stat",
+		"  summarizes changed lines; --output=json works with all three.",
+		"",
+		fmt.Sprintf(treeUsageFormat, "diff --tree <prefix1> <prefix2>", "Two prefixes of this store"),
+		fmt.Sprintf(treeUsageFormat, "diff --tree <prefix> --against <provider>", "The same prefix in another provider"),
+	}
+
+	if cfg.Axis != nil {
+		lines = append(lines, fmt.Sprintf(treeUsageFormat, "diff --tree <prefix> --"+cfg.Axis.Flag+" <a>,<b>", "One prefix in two scopes"))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// runTree is the --tree action: it resolves both sides from the positional
+// prefixes and the --against / axis flags, then runs a TreeRunner.
+func runTree[S any](ctx context.Context, cmd *cli.Command, cfg Config[S]) error {
+	input, err := treeInput(ctx, cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	outputFormat, err := output.ParseFormat(cmd.String("output"))
+	if err != nil {
+		return err
+	}
+
+	opts := TreeOptions{
+		ParseJSON: cmd.Bool("parse-json"),
+		NameOnly:  cmd.Bool("name-only"),
+		Stat:      cmd.Bool("stat"),
+		Output:    outputFormat,
+	}
+
+	if opts.NameOnly && opts.Stat {
+		return errors.New("--name-only and --stat cannot be used together")
+	}
+
+	noPager := cmd.Bool("no-pager") || opts.Output == output.FormatJSON
+
+	return internal.WithPager(cmd, noPager, func(stdout, stderr io.Writer) error {
+		r := &TreeRunner{Input: input, Options: opts, Stdout: stdout, Stderr: stderr}
+
+		return r.Run(ctx)
+	})
+}
+
+// treeInput resolves the two sides of a --tree diff: two prefixes of the
+// command's store, or one or two prefixes read from the two scopes the axis
+// flag names or from the command's store and the --against provider.
+func treeInput[S any](ctx context.Context, cmd *cli.Command, cfg Config[S]) (treediff.Input, error) {
+	args := cmd.Args().Slice()
+	if len(args) == 0 || len(args) > 2 { //nolint:mnd // one or two prefixes
+		return treediff.Input{}, errors.New("usage: diff --tree <prefix1> [prefix2]")
+	}
+
+	oldPrefix := treePrefix(args[0])
+	newPrefix := oldPrefix
+
+	if len(args) == 2 { //nolint:mnd // the second prefix
+		newPrefix = treePrefix(args[1])
+	}
+
+	scopes := cfg.Axis.Scopes(cmd)
+	against := cmd.String("against")
+
+	switch {
+	case len(scopes) > 0 && against != "":
+		return treediff.Input{}, fmt.Errorf("--%s and --against cannot be used together", cfg.Axis.Flag)
+	case len(scopes) > 0:
+		if len(scopes) != 2 { //nolint:mnd // one scope per side
+			return treediff.Input{}, fmt.Errorf("--tree compares exactly two --%s", cfg.Axis.Flag)
+		}
+
+		oldSide, err := axisSide(ctx, cfg, scopes[0], oldPrefix)
+		if err != nil {
+			return treediff.Input{}, err
+		}
+
+		newSide, err := axisSide(ctx, cfg, scopes[1], newPrefix)
+		if err != nil {
+			return treediff.Input{}, err
+		}
+
+		return treediff.Input{Old: oldSide, New: newSide}, nil
+	case against != "":
+		p, err := detect.ParseProvider(against)
+		if err != nil {
+			return treediff.Input{}, err
+		}
+
+		oldStore, err := cfg.Store(ctx)
+		if err != nil {
+			return treediff.Input{}, err
+		}
+
+		newStore, err := internal.ProviderStore(ctx, p, cfg.Kind)
+		if err != nil {
+			return treediff.Input{}, err
+		}
+
+		return treediff.Input{
+			Old: treediff.Side{Reader: oldStore, Prefix: oldPrefix},
+			New: treediff.Side{Label: string(p), Reader: newStore, Prefix: newPrefix},
+		}, nil
+	}
+
+	if len(args) < 2 { //nolint:mnd // a same-store comparison needs both prefixes
+		return treediff.Input{}, errors.New("--tree needs two prefixes, or one prefix with two scopes or --against")
+	}
+
+	store, err := cfg.Store(ctx)
+	if err != nil {
+		return treediff.Input{}, err
+	}
+
+	return treediff.Input{
+		Old: treediff.Side{Reader: store, Prefix: oldPrefix},
+		New: treediff.Side{Reader: store, Prefix: newPrefix},
+	}, nil
+}
+
+// axisSide resolves a side read from one scope of the axis.
+func axisSide[S any](ctx context.Context, cfg Config[S], scope, prefix string) (treediff.Side, error) {
+	scoped, err := cfg.Axis.Target(ctx, scope)
+	if err != nil {
+		return treediff.Side{}, err
+	}
+
+	store, err := cfg.Store(scoped)
+	if err != nil {
+		return treediff.Side{}, fmt.Errorf("%s: %w", scope, err)
+	}
+
+	return treediff.Side{Label: scope, Reader: store, Prefix: prefix}, nil
+}
+
+// treePrefix normalizes a --tree prefix: a path-style prefix (leading "/")
+// names a directory, so it gains a trailing "/" and /app never pairs /app2/x.
+func treePrefix(prefix string) string {
+	if strings.HasPrefix(prefix, "/") && !strings.HasSuffix(prefix, "/") {
+		return prefix + "/"
+	}
+
+	return prefix
+}
@@ -0,0 +1,203 @@
+package diff_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	genericdiff "github.com/mpyw/suve/internal/cli/commands/generic/diff"
+	"github.com/mpyw/suve/internal/cli/commands/internal/apptest"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/usecase/treediff"
+)
+
+// treeStore serves the given name → value map.
+func treeStore(values map[string]string) *providermock.Store {
+	return &providermock.Store{
+		ListFunc: func(_ context.Context) ([]string, error) {
+			names := make([]string, 0, len(values))
+			for name := range values {
+				names = append(names, name)
+			}
+
+			return names, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			v, ok := values[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+			}
+
+			return &domain.Entry{Name: name, Value: v}, nil
+		},
+	}
+}
+
+// treeInput compares /stg/app/ against /prod/app/ of one store: db-url
+// changed, debug removed, replicas added and log-level unchanged.
+func treeInput() treediff.Input {
+	store := treeStore(map[string]string{
+		"/stg/app/db-url":     "postgres://stg",
+		"/stg/app/debug":      "true",
+		"/stg/app/log-level":  "warn",
+		"/prod/app/db-url":    "postgres://prod",
+		"/prod/app/log-level": "warn",
+		"/prod/app/replicas":  "3",
+	})
+
+	return treediff.Input{
+		Old: treediff.Side{Reader: store, Prefix: "/stg/app/"},
+		New: treediff.Side{Reader: store, Prefix: "/prod/app/"},
+	}
+}
+
+func runTree(t *testing.T, input treediff.Input, opts genericdiff.TreeOptions) (string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+
+	r := &genericdiff.TreeRunner{Input: input, Options: opts, Stdout: &stdout, Stderr: &stderr}
+	require.NoError(t, r.Run(t.Context()))
+
+	return stdout.String(), stderr.String()
+}
+
+func TestTreeRunner_UnifiedDiffs(t *testing.T) {
+	t.Parallel()
+
+	stdout, _ := runTree(t, treeInput(), genericdiff.TreeOptions{})
+
+	assert.Contains(t, stdout, "--- /stg/app/db-url\n+++ /prod/app/db-url")
+	assert.Contains(t, stdout, "-postgres://stg")
+	assert.Contains(t, stdout, "+postgres://prod")
+	assert.Contains(t, stdout, "--- /stg/app/debug\n+++ /dev/null")
+	assert.Contains(t, stdout, "--- /dev/null\n+++ /prod/app/replicas")
+	assert.NotContains(t, stdout, "log-level")
+}
+
+func TestTreeRunner_NameOnly(t *testing.T) {
+	t.Parallel()
+
+	stdout, _ := runTree(t, treeInput(), genericdiff.TreeOptions{NameOnly: true})
+
+	assert.Equal(t, "db-url\ndebug\nreplicas\n", stdout)
+}
+
+func TestTreeRunner_Stat(t *testing.T) {
+	t.Parallel()
+
+	stdout, _ := runTree(t, treeInput(), genericdiff.TreeOptions{Stat: true})
+
+	assert.Equal(t, " db-url   | 2 +-\n"+
+		" debug    | 1 -\n"+
+		" replicas | 1 +\n"+
+		" 3 entries differ (1 added, 1 removed, 1 changed), 2 insertions(+), 2 deletions(-)\n", stdout)
+}
+
+func TestTreeRunner_JSON(t *testing.T) {
+	t.Parallel()
+
+	stdout, _ := runTree(t, treeInput(), genericdiff.TreeOptions{Output: output.FormatJSON})
+
+	var got struct {
+		Old struct {
+			Prefix string `json:"prefix"`
+		} `json:"old"`
+		Entries []struct {
+			Name     string  `json:"name"`
+			Status   string  `json:"status"`
+			OldValue *string `json:"oldValue"`
+			NewValue *string `json:"newValue"`
+			Diff     string  `json:"diff"`
+		} `json:"entries"`
+		Unchanged int `json:"unchanged"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &got))
+
+	assert.Equal(t, "/stg/app/", got.Old.Prefix)
+	assert.Equal(t, 1, got.Unchanged)
+	require.Len(t, got.Entries, 3)
+	assert.Equal(t, "changed", got.Entries[0].Status)
+	assert.Equal(t, "postgres://stg", *got.Entries[0].OldValue)
+	assert.Contains(t, got.Entries[0].Diff, "+postgres://prod")
+	assert.Equal(t, "removed", got.Entries[1].Status)
+	assert.Nil(t, got.Entries[1].NewValue)
+	assert.Equal(t, "added", got.Entries[2].Status)
+	assert.Nil(t, got.Entries[2].OldValue)
+}
+
+func TestTreeRunner_ScopeLabels(t *testing.T) {
+	t.Parallel()
+
+	east := treeStore(map[string]string{"/app/a": "1"})
+	west := treeStore(map[string]string{"/app/a": "2"})
+
+	stdout, _ := runTree(t, treediff.Input{
+		Old: treediff.Side{Label: "us-east-1", Reader: east, Prefix: "/app/"},
+		New: treediff.Side{Label: "us-west-2", Reader: west, Prefix: "/app/"},
+	}, genericdiff.TreeOptions{})
+
+	assert.Contains(t, stdout, "--- us-east-1:/app/a\n+++ us-west-2:/app/a")
+}
+
+func TestTreeRunner_NoDifferences(t *testing.T) {
+	t.Parallel()
+
+	store := treeStore(map[string]string{"/a/x": "1", "/b/x": "1"})
+
+	stdout, stderr := runTree(t, treediff.Input{
+		Old: treediff.Side{Reader: store, Prefix: "/a/"},
+		New: treediff.Side{Reader: store, Prefix: "/b/"},
+	}, genericdiff.TreeOptions{})
+
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "no differences (1 identical entries)")
+}
+
+func TestCommand_TreeValidation(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		args    []string
+		wantSub string
+	}{
+		{"one prefix without scopes", []string{"suve", "param", "diff", "--tree", "/app/"}, "needs two prefixes"},
+		{"too many prefixes", []string{"suve", "param", "diff", "--tree", "/a/", "/b/", "/c/"}, "usage: diff --tree"},
+		{"stat without tree", []string{"suve", "param", "diff", "--stat", "/app/config"}, "require --tree"},
+		{
+			"name-only and stat",
+			[]string{"suve", "param", "diff", "--tree", "--name-only", "--stat", "/a/", "/b/"},
+			"cannot be used together",
+		},
+		{
+			"three regions",
+			[]string{"suve", "param", "diff", "--tree", "--regions", "a,b,c", "/app/"},
+			"exactly two --regions",
+		},
+		{
+			"regions and against",
+			[]string{"suve", "param", "diff", "--tree", "--regions", "a,b", "--against", "local", "/app/"},
+			"cannot be used together",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := apptest.AWSApp()
+			err := app.Run(t.Context(), tc.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantSub)
+		})
+	}
+}
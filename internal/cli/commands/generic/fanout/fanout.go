@@ -0,0 +1,283 @@
+// Package fanout runs a read command (list, show, log) across several scopes of
+// one provider — AWS regions, Google Cloud projects, Azure Key Vaults — and
+// merges the results under a scope column.
+//
+// A provider opts in by giving the generic command an Axis: the plural flag
+// that names the scopes (--regions us-east-1,eu-west-1) and how to retarget the
+// command context at one of them. The scopes are queried concurrently through
+// parallel.ExecuteMapWithLimit; a scope that fails (say, the secret is missing
+// there) is reported in its own row without failing the others, and the command
+// exits non-zero once everything has been written.
+package fanout
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/parallel"
+)
+
+// Axis is a provider's fan-out dimension.
+type Axis struct {
+	// Flag is the plural flag that lists the scopes ("regions").
+	Flag string
+	// Usage is the flag's help text.
+	Usage string
+	// Target returns ctx retargeted at one scope, so the command's store
+	// resolution picks it up.
+	Target func(ctx context.Context, scope string) (context.Context, error)
+}
+
+// ErrorItem is the JSON row for a scope that failed.
+type ErrorItem struct {
+	Scope string `json:"scope"`
+	Error string `json:"error"`
+}
+
+// Regions is the AWS fan-out over regions (--regions).
+func Regions() *Axis {
+	return &Axis{
+		Flag:  "regions",
+		Usage: "Query each of these AWS regions (comma-separated) and merge the results",
+		Target: func(ctx context.Context, region string) (context.Context, error) {
+			if cliinternal.IsLocalProvider(ctx) || cliinternal.PluginName(ctx) != "" {
+				return nil, errors.New("--regions is only supported for AWS")
+			}
+
+			return cliinternal.WithAWSRegion(ctx, region), nil
+		},
+	}
+}
+
+// Projects is the Google Cloud fan-out over projects (--projects).
+func Projects() *Axis {
+	return &Axis{
+		Flag:  "projects",
+		Usage: "Query each of these Google Cloud projects (comma-separated) and merge the results",
+		Target: func(ctx context.Context, project string) (context.Context, error) {
+			return cliinternal.WithGoogleCloudProject(ctx, project), nil
+		},
+	}
+}
+
+// Vaults is the Azure Key Vault fan-out over vaults (--vaults).
+func Vaults() *Axis {
+	return &Axis{
+		Flag:  "vaults",
+		Usage: "Query each of these Azure Key Vaults (comma-separated) and merge the results",
+		Target: func(ctx context.Context, vault string) (context.Context, error) {
+			return cliinternal.WithAzureVaultName(ctx, vault), nil
+		},
+	}
+}
+
+// Flags returns the axis flag for a command's flag set (none for a nil axis).
+func (a *Axis) Flags() []cli.Flag {
+	if a == nil {
+		return nil
+	}
+
+	return []cli.Flag{&cli.StringSliceFlag{Name: a.Flag, Usage: a.Usage}}
+}
+
+// Scopes returns the scopes the axis flag names, trimmed and de-duplicated in
+// order, or nil when the flag is absent (a single-scope run).
+func (a *Axis) Scopes(cmd *cli.Command) []string {
+	if a == nil {
+		return nil
+	}
+
+	var scopes []string
+
+	seen := map[string]bool{}
+
+	for _, s := range cmd.StringSlice(a.Flag) {
+		for _, part := range strings.Split(s, ",") {
+			if part = strings.TrimSpace(part); part != "" && !seen[part] {
+				seen[part] = true
+				scopes = append(scopes, part)
+			}
+		}
+	}
+
+	return scopes
+}
+
+// RunFunc renders a command for the scope its context targets, as it would for
+// a single-scope run.
+type RunFunc func(ctx context.Context, stdout, stderr io.Writer) error
+
+// Write runs the command for every scope concurrently and writes the merged
+// output in scope order. Text output gets a leading scope column on every line
+// (stderr included); JSON output is one array of the scopes' objects — a show's
+// object, or each element of a list's or log's array — each with a leading
+// "scope" member. A failed scope becomes an "<error: …>" row (an ErrorItem in
+// JSON), and Write then returns an error naming the failed scopes.
+func Write(ctx context.Context, axis *Axis, scopes []string, jsonOutput bool, stdout, stderr io.Writer, run RunFunc) error {
+	results := render(ctx, axis, scopes, run)
+
+	if jsonOutput {
+		if err := writeJSON(stdout, results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.err != nil {
+				output.Printf(stdout, "%s\t<error: %v>\n", r.scope, r.err)
+
+				continue
+			}
+
+			writePrefixed(stdout, r.scope, r.stdout.String())
+		}
+	}
+
+	var failed []string
+
+	for _, r := range results {
+		writePrefixed(stderr, r.scope, r.stderr.String())
+
+		if r.err != nil {
+			failed = append(failed, r.scope)
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("failed in %d of %d scope(s): %s", len(failed), len(results), strings.Join(failed, ", "))
+}
+
+// result is one scope's rendered output.
+type result struct {
+	scope  string
+	stdout bytes.Buffer
+	stderr bytes.Buffer
+	err    error
+}
+
+// render runs the command for every scope through parallel.ExecuteMapWithLimit
+// and returns the outputs in scope order.
+func render(ctx context.Context, axis *Axis, scopes []string, run RunFunc) []*result {
+	entries := make(map[string]*result, len(scopes))
+	for _, s := range scopes {
+		entries[s] = &result{scope: s}
+	}
+
+	done := parallel.ExecuteMapWithLimit(ctx, entries, parallel.DefaultLimit,
+		func(ctx context.Context, scope string, r *result) (struct{}, error) {
+			scoped, err := axis.Target(ctx, scope)
+			if err != nil {
+				return struct{}{}, err
+			}
+
+			return struct{}{}, run(scoped, &r.stdout, &r.stderr)
+		},
+	)
+
+	results := make([]*result, len(scopes))
+	for i, s := range scopes {
+		results[i] = entries[s]
+		results[i].err = done[s].Err
+	}
+
+	return results
+}
+
+// writeJSON merges the scopes' JSON outputs into one array.
+func writeJSON(w io.Writer, results []*result) error {
+	items := []any{}
+
+	for _, r := range results {
+		if r.err != nil {
+			items = append(items, ErrorItem{Scope: r.scope, Error: r.err.Error()})
+
+			continue
+		}
+
+		objs, err := jsonObjects(r.stdout.Bytes())
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range objs {
+			scoped, err := withScope(r.scope, obj)
+			if err != nil {
+				return err
+			}
+
+			items = append(items, scoped)
+		}
+	}
+
+	return output.WriteJSON(w, items)
+}
+
+// jsonObjects splits a command's JSON output into its objects: the elements of
+// an array, or the single object. Empty output (an empty history) has none.
+func jsonObjects(data []byte) ([]json.RawMessage, error) {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case len(data) == 0:
+		return nil, nil
+	case data[0] == '[':
+		var objs []json.RawMessage
+		if err := json.Unmarshal(data, &objs); err != nil {
+			return nil, fmt.Errorf("failed to merge JSON output: %w", err)
+		}
+
+		return objs, nil
+	default:
+		return []json.RawMessage{data}, nil
+	}
+}
+
+// withScope returns the JSON object obj with a leading "scope" member, keeping
+// obj's own members (and their order) as they are.
+func withScope(scope string, obj json.RawMessage) (json.RawMessage, error) {
+	body := bytes.TrimSpace(obj)
+	if len(body) < 2 || body[0] != '{' {
+		return nil, fmt.Errorf("failed to merge JSON output: not an object: %.40s", body)
+	}
+
+	key, err := json.Marshal(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+
+	b.WriteString(`{"scope":`)
+	b.Write(key)
+
+	if rest := bytes.TrimSpace(body[1:]); rest[0] != '}' {
+		b.WriteByte(',')
+	}
+
+	b.Write(body[1:])
+
+	return b.Bytes(), nil
+}
+
+// writePrefixed writes text with every line prefixed by the scope column,
+// ending the last line when the command left it open (a --raw value).
+func writePrefixed(w io.Writer, scope, text string) {
+	for line := range strings.Lines(text) {
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+
+		output.Print(w, scope+"\t"+line)
+	}
+}
@@ -0,0 +1,162 @@
+package fanout_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
+	"github.com/mpyw/suve/internal/cli/output"
+)
+
+type scopeKey struct{}
+
+// testAxis retargets the context by stashing the scope in it.
+func testAxis() *fanout.Axis {
+	return &fanout.Axis{
+		Flag:  "regions",
+		Usage: "regions",
+		Target: func(ctx context.Context, scope string) (context.Context, error) {
+			if scope == "bad-scope" {
+				return nil, errors.New("unsupported scope")
+			}
+
+			return context.WithValue(ctx, scopeKey{}, scope), nil
+		},
+	}
+}
+
+func scopeOf(ctx context.Context) string {
+	s, _ := ctx.Value(scopeKey{}).(string)
+
+	return s
+}
+
+func TestAxis_Scopes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "absent", args: nil, want: nil},
+		{name: "comma-separated", args: []string{"--regions", "us-east-1,eu-west-1"}, want: []string{"us-east-1", "eu-west-1"}},
+		{
+			name: "repeated, trimmed and de-duplicated",
+			args: []string{"--regions", " us-east-1 ,", "--regions", "eu-west-1,us-east-1"},
+			want: []string{"us-east-1", "eu-west-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			axis := testAxis()
+
+			var got []string
+
+			cmd := &cli.Command{
+				Name:  "list",
+				Flags: axis.Flags(),
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					got = axis.Scopes(cmd)
+
+					return nil
+				},
+			}
+			require.NoError(t, cmd.Run(t.Context(), append([]string{"list"}, tt.args...)))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAxis_Nil(t *testing.T) {
+	t.Parallel()
+
+	var axis *fanout.Axis
+
+	assert.Nil(t, axis.Flags())
+	assert.Nil(t, axis.Scopes(&cli.Command{}))
+}
+
+func TestWrite_Text(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+
+	err := fanout.Write(t.Context(), testAxis(), []string{"us-east-1", "eu-west-1", "ap-northeast-1"}, false, &stdout, &stderr,
+		func(ctx context.Context, stdout, stderr io.Writer) error {
+			switch scopeOf(ctx) {
+			case "eu-west-1":
+				return errors.New("parameter not found")
+			case "ap-northeast-1":
+				output.Print(stdout, "value") // no trailing newline, as with --raw
+				output.Print(stderr, "Warning: partial\n")
+			default:
+				output.Print(stdout, "/app/a\n/app/b\n")
+			}
+
+			return nil
+		})
+
+	require.EqualError(t, err, "failed in 1 of 3 scope(s): eu-west-1")
+	assert.Equal(t,
+		"us-east-1\t/app/a\nus-east-1\t/app/b\n"+
+			"eu-west-1\t<error: parameter not found>\n"+
+			"ap-northeast-1\tvalue\n",
+		stdout.String(), "scope order is kept and every line gets the scope column")
+	assert.Equal(t, "ap-northeast-1\tWarning: partial\n", stderr.String())
+}
+
+func TestWrite_JSON(t *testing.T) {
+	t.Parallel()
+
+	var stdout bytes.Buffer
+
+	err := fanout.Write(t.Context(), testAxis(), []string{"a", "b", "c", "d", "bad-scope"}, true, &stdout, io.Discard,
+		func(ctx context.Context, stdout, _ io.Writer) error {
+			switch scopeOf(ctx) {
+			case "a":
+				return output.WriteJSON(stdout, []map[string]string{{"name": "/x"}, {"name": "/y"}})
+			case "b":
+				output.Print(stdout, `{"name":"/x","value":"v"}`)
+			case "c":
+				output.Print(stdout, "{}")
+			case "d":
+				return errors.New("not found")
+			}
+
+			return nil
+		})
+
+	require.EqualError(t, err, "failed in 2 of 5 scope(s): d, bad-scope")
+	assert.JSONEq(t, `[
+		{"scope": "a", "name": "/x"},
+		{"scope": "a", "name": "/y"},
+		{"scope": "b", "name": "/x", "value": "v"},
+		{"scope": "c"},
+		{"scope": "d", "error": "not found"},
+		{"scope": "bad-scope", "error": "unsupported scope"}
+	]`, stdout.String())
+}
+
+func TestWrite_JSONNotObject(t *testing.T) {
+	t.Parallel()
+
+	err := fanout.Write(t.Context(), testAxis(), []string{"a"}, true, io.Discard, io.Discard,
+		func(_ context.Context, stdout, _ io.Writer) error {
+			output.Print(stdout, `"plain"`)
+
+			return nil
+		})
+
+	require.ErrorContains(t, err, "not an object")
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/samber/lo"
 	"github.com/urfave/cli/v3"
 
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	"github.com/mpyw/suve/internal/cli/output"
 )
 
@@ -49,6 +50,8 @@ type Config struct {
 	// NewList builds the entry-producing closure from the CLI context. withValue
 	// mirrors the shared --show flag so the provider can request values.
 	NewList func(ctx context.Context, cmd *cli.Command, withValue bool) (func(context.Context) ([]Entry, error), error)
+	// Axis, when set, adds the provider's multi-scope flag (see fanout).
+	Axis *fanout.Axis
 }
 
 // Runner executes the list command over a provider-supplied entry source.
@@ -117,7 +120,7 @@ func Command(cfg Config) *cli.Command {
 		Usage:       cfg.Usage,
 		ArgsUsage:   cfg.ArgsUsage,
 		Description: cfg.Description,
-		Flags:       cfg.Flags,
+		Flags:       append(cfg.Flags, cfg.Axis.Flags()...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			outputFormat, err := output.ParseFormat(cmd.String("output"))
 			if err != nil {
@@ -129,19 +132,27 @@ func Command(cfg Config) *cli.Command {
 				Output: outputFormat,
 			}
 
-			list, err := cfg.NewList(ctx, cmd, opts.Show)
-			if err != nil {
-				return err
+			run := func(ctx context.Context, stdout, stderr io.Writer) error {
+				list, err := cfg.NewList(ctx, cmd, opts.Show)
+				if err != nil {
+					return err
+				}
+
+				r := &Runner{
+					List:    list,
+					Options: opts,
+					Stdout:  stdout,
+					Stderr:  stderr,
+				}
+
+				return r.Run(ctx)
 			}
 
-			r := &Runner{
-				List:    list,
-				Options: opts,
-				Stdout:  cmd.Root().Writer,
-				Stderr:  cmd.Root().ErrWriter,
+			if scopes := cfg.Axis.Scopes(cmd); len(scopes) > 0 {
+				return fanout.Write(ctx, cfg.Axis, scopes, opts.Output == output.FormatJSON, cmd.Root().Writer, cmd.Root().ErrWriter, run)
 			}
 
-			return r.Run(ctx)
+			return run(ctx, cmd.Root().Writer, cmd.Root().ErrWriter)
 		},
 	}
 }
@@ -33,6 +33,7 @@ func TestCommand_Help(t *testing.T) {
 		err := app.Run(t.Context(), []string{"suve", "param", "list", "--help"})
 		require.NoError(t, err)
 		assert.Contains(t, buf.String(), "List parameters")
+		assert.Contains(t, buf.String(), "--regions")
 		assert.Contains(t, buf.String(), "--recursive")
 		assert.Contains(t, buf.String(), "--filter")
 		assert.Contains(t, buf.String(), "--show")
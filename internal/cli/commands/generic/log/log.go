@@ -16,6 +16,7 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	"github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
 )
@@ -141,6 +142,8 @@ type Config struct {
 	Flags []cli.Flag
 	// NewPresenter builds the provider Presenter bound to the fetch request.
 	NewPresenter func(ctx context.Context, req Request) (Presenter, error)
+	// Axis, when set, adds the provider's multi-scope flag (see fanout).
+	Axis *fanout.Axis
 }
 
 // Command returns the generic log command wired with the provider Config.
@@ -151,7 +154,7 @@ func Command(cfg Config) *cli.Command {
 		Usage:       cfg.Usage,
 		ArgsUsage:   cfg.ArgsUsage,
 		Description: cfg.Description,
-		Flags:       cfg.Flags,
+		Flags:       append(cfg.Flags, cfg.Axis.Flags()...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			if cmd.Args().Len() < 1 {
 				return fmt.Errorf("%s", cfg.UsageError)
@@ -223,18 +226,28 @@ func Command(cfg Config) *cli.Command {
 				}
 			}
 
-			presenter, err := cfg.NewPresenter(ctx, req)
-			if err != nil {
-				return err
+			run := func(ctx context.Context, stdout, stderr io.Writer) error {
+				presenter, err := cfg.NewPresenter(ctx, req)
+				if err != nil {
+					return err
+				}
+
+				r := &Runner{Presenter: presenter, Options: opts, Stdout: stdout, Stderr: stderr}
+
+				return r.Run(ctx)
 			}
 
 			// JSON output disables pager
 			noPager := opts.NoPager || opts.Output == output.FormatJSON
 
+			scopes := cfg.Axis.Scopes(cmd)
+
 			return internal.WithPager(cmd, noPager, func(stdout, stderr io.Writer) error {
-				r := &Runner{Presenter: presenter, Options: opts, Stdout: stdout, Stderr: stderr}
+				if len(scopes) > 0 {
+					return fanout.Write(ctx, cfg.Axis, scopes, opts.Output == output.FormatJSON, stdout, stderr, run)
+				}
 
-				return r.Run(ctx)
+				return run(ctx, stdout, stderr)
 			})
 		},
 	}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/mpyw/suve/internal/cli/commands/generic/fanout"
 	"github.com/mpyw/suve/internal/cli/commands/internal"
 	"github.com/mpyw/suve/internal/cli/output"
 )
@@ -88,9 +89,14 @@ type Config[S any] struct {
 	UsageError string
 	// ParseSpec parses the raw name argument into the provider's version spec.
 	ParseSpec func(arg string) (S, error)
+	// Flags are provider-specific flags appended after the shared ones; the
+	// provider reads them from cmd in NewPresenter.
+	Flags []cli.Flag
 	// NewPresenter builds the provider Presenter bound to the parsed spec (this
 	// is where the provider constructs its AWS client and usecase).
-	NewPresenter func(ctx context.Context, spec S) (Presenter, error)
+	NewPresenter func(ctx context.Context, cmd *cli.Command, spec S) (Presenter, error)
+	// Axis, when set, adds the provider's multi-scope flag (see fanout).
+	Axis *fanout.Axis
 }
 
 // Command returns the generic show command wired with the provider Config.
@@ -100,7 +106,7 @@ func Command[S any](cfg Config[S]) *cli.Command {
 		Usage:       cfg.Usage,
 		ArgsUsage:   cfg.ArgsUsage,
 		Description: cfg.Description,
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:    "parse-json",
 				Aliases: []string{"j"},
@@ -118,7 +124,7 @@ func Command[S any](cfg Config[S]) *cli.Command {
 				Name:  "output",
 				Usage: "Output format: text (default) or json",
 			},
-		},
+		}, append(cfg.Flags, cfg.Axis.Flags()...)...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			if cmd.Args().Len() < 1 {
 				return fmt.Errorf("%s", cfg.UsageError)
@@ -141,11 +147,6 @@ func Command[S any](cfg Config[S]) *cli.Command {
 				return fmt.Errorf("--raw and --output=json cannot be used together")
 			}
 
-			presenter, err := cfg.NewPresenter(ctx, spec)
-			if err != nil {
-				return err
-			}
-
 			opts := Options{
 				ParseJSON: cmd.Bool("parse-json"),
 				NoPager:   cmd.Bool("no-pager"),
@@ -153,13 +154,28 @@ func Command[S any](cfg Config[S]) *cli.Command {
 				Output:    outputFormat,
 			}
 
+			run := func(ctx context.Context, stdout, stderr io.Writer) error {
+				presenter, err := cfg.NewPresenter(ctx, cmd, spec)
+				if err != nil {
+					return err
+				}
+
+				r := &Runner{Presenter: presenter, Options: opts, Stdout: stdout, Stderr: stderr}
+
+				return r.Run(ctx)
+			}
+
 			// Raw mode and JSON output disable pager
 			noPager := opts.NoPager || opts.Raw || opts.Output == output.FormatJSON
 
+			scopes := cfg.Axis.Scopes(cmd)
+
 			return internal.WithPager(cmd, noPager, func(stdout, stderr io.Writer) error {
-				r := &Runner{Presenter: presenter, Options: opts, Stdout: stdout, Stderr: stderr}
+				if len(scopes) > 0 {
+					return fanout.Write(ctx, cfg.Axis, scopes, opts.Output == output.FormatJSON, stdout, stderr, run)
+				}
 
-				return r.Run(ctx)
+				return run(ctx, stdout, stderr)
 			})
 		},
 	}
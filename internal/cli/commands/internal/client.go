@@ -7,22 +7,37 @@ import (
 
 	"github.com/mpyw/suve/internal/provider"
 	"github.com/mpyw/suve/internal/provider/aws"
+	"github.com/mpyw/suve/internal/provider/aws/infra"
 	"github.com/mpyw/suve/internal/provider/azure"
 	"github.com/mpyw/suve/internal/provider/gcloud"
+	"github.com/mpyw/suve/internal/provider/kubernetes"
+	"github.com/mpyw/suve/internal/provider/local"
+	"github.com/mpyw/suve/internal/provider/plugin"
+	"github.com/mpyw/suve/internal/provider/sops"
+	"github.com/mpyw/suve/internal/provider/vault"
 	"github.com/mpyw/suve/internal/staging"
 )
 
 // registry is the provider registry reachable by every CLI command. It is the
 // single composition point where cloud backends are wired in: AWS (param +
-// secret), Google Cloud (secret only), and Azure (Key Vault secret + App
-// Configuration param) are registered here. Top-level command groups build their
-// own provider.Scope and resolve stores through this same registry.
+// secret), Google Cloud (secret only), Azure (Key Vault secret + App
+// Configuration param), Vault (KV v2 secret only), Kubernetes (ConfigMap
+// param + Secret secret), SOPS (encrypted file secret only), and the offline
+// local provider (JSON-file param + secret) are registered here, as are
+// external suve-provider-* plugins. Top-level
+// command groups build their own provider.Scope and resolve stores through this
+// same registry.
 //
 //nolint:gochecknoglobals // process-wide provider registry, built once
 var registry = func() *provider.Registry {
 	reg := aws.NewRegistry()
 	gcloud.Register(reg)
 	azure.Register(reg)
+	vault.Register(reg)
+	kubernetes.Register(reg)
+	sops.Register(reg)
+	local.Register(reg)
+	plugin.Register(reg)
 
 	return reg
 }()
@@ -45,6 +60,142 @@ func gcloudProjectFromContext(ctx context.Context) string {
 	return project
 }
 
+// vaultScopeContextKey keys the resolved Vault address and KV mount stored in
+// the context by the vault command group's Before hook.
+type vaultScopeContextKey struct{}
+
+// vaultScopeCtx holds the Vault scope fields resolved from flags/env.
+type vaultScopeCtx struct {
+	address string
+	mount   string
+}
+
+// WithVaultScope returns a context carrying the resolved Vault address and KV
+// mount. The vault command group sets it once (from --address/VAULT_ADDR and
+// --mount) so every vault subcommand can resolve a store.
+func WithVaultScope(ctx context.Context, address, mount string) context.Context {
+	return context.WithValue(ctx, vaultScopeContextKey{}, vaultScopeCtx{address: address, mount: mount})
+}
+
+// vaultScope builds the Vault scope from the context, or returns a clear error
+// when no address was resolved.
+func vaultScope(ctx context.Context) (provider.Scope, error) {
+	sc, _ := ctx.Value(vaultScopeContextKey{}).(vaultScopeCtx)
+	if sc.address == "" {
+		return provider.Scope{}, errors.New("no Vault address specified: set --address or the VAULT_ADDR environment variable")
+	}
+
+	return provider.VaultScope(sc.address, sc.mount), nil
+}
+
+// kubernetesScopeContextKey keys the Kubernetes context and namespace flags
+// stored in the context by the kubernetes command group's Before hook.
+type kubernetesScopeContextKey struct{}
+
+// kubernetesScopeCtx holds the Kubernetes scope fields as given on the command
+// line (either may be empty).
+type kubernetesScopeCtx struct {
+	kubeContext string
+	namespace   string
+}
+
+// WithKubernetesScope returns a context carrying the --context and --namespace
+// flags. The kubernetes command group sets it once; empty values are filled
+// from the kubeconfig when a store is resolved (see kubernetes.ResolveScope).
+func WithKubernetesScope(ctx context.Context, kubeContext, namespace string) context.Context {
+	return context.WithValue(ctx, kubernetesScopeContextKey{}, kubernetesScopeCtx{kubeContext: kubeContext, namespace: namespace})
+}
+
+// kubernetesScope resolves the Kubernetes scope from the context's flags and the
+// kubeconfig. It reads local files only.
+func kubernetesScope(ctx context.Context) (provider.Scope, error) {
+	sc, _ := ctx.Value(kubernetesScopeContextKey{}).(kubernetesScopeCtx)
+
+	return kubernetes.ResolveScope(sc.kubeContext, sc.namespace)
+}
+
+// sopsFileContextKey keys the --file flag stored in the context by the sops
+// command group's Before hook.
+type sopsFileContextKey struct{}
+
+// WithSOPSFile returns a context carrying the --file flag. The sops command
+// group sets it once; an empty value falls back to SUVE_SOPS_FILE when a store
+// is resolved (see sops.ResolveScope).
+func WithSOPSFile(ctx context.Context, file string) context.Context {
+	return context.WithValue(ctx, sopsFileContextKey{}, file)
+}
+
+// sopsScope resolves the SOPS scope from the context's flag and the
+// environment. It touches no files.
+func sopsScope(ctx context.Context) (provider.Scope, error) {
+	file, _ := ctx.Value(sopsFileContextKey{}).(string)
+
+	return sops.ResolveScope(file)
+}
+
+// localProviderContextKey marks a context whose AWS-shaped commands target the
+// local provider instead of AWS.
+type localProviderContextKey struct{}
+
+// WithLocalProvider returns a context in which ParamStore, SecretStore and the
+// AWS strategy factories resolve the offline local provider. The local command
+// group sets it once, so the AWS command trees serve it unchanged.
+func WithLocalProvider(ctx context.Context) context.Context {
+	return context.WithValue(ctx, localProviderContextKey{}, true)
+}
+
+// IsLocalProvider reports whether ctx was marked by WithLocalProvider.
+func IsLocalProvider(ctx context.Context) bool {
+	local, _ := ctx.Value(localProviderContextKey{}).(bool)
+
+	return local
+}
+
+// pluginContextKey keys the name of the plugin whose AWS-shaped commands are
+// running, stored by a plugin command group's Before hook.
+type pluginContextKey struct{}
+
+// WithPlugin returns a context in which ParamStore, SecretStore and the AWS
+// strategy factories resolve the named suve-provider-* plugin, like
+// WithLocalProvider does for the local provider.
+func WithPlugin(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, pluginContextKey{}, name)
+}
+
+// PluginName returns the plugin set by WithPlugin, or "".
+func PluginName(ctx context.Context) string {
+	name, _ := ctx.Value(pluginContextKey{}).(string)
+
+	return name
+}
+
+// awsRegionContextKey keys an AWS region that overrides the ambient one.
+type awsRegionContextKey struct{}
+
+// WithAWSRegion returns a context in which ParamStore and SecretStore target
+// region instead of the profile's or environment's region. The --regions
+// fan-out sets it once per region.
+func WithAWSRegion(ctx context.Context, region string) context.Context {
+	return context.WithValue(ctx, awsRegionContextKey{}, region)
+}
+
+func awsRegionFromContext(ctx context.Context) string {
+	region, _ := ctx.Value(awsRegionContextKey{}).(string)
+
+	return region
+}
+
+// AWSIdentity returns the AWS caller identity shown on confirmation prompts.
+// Under the local provider or a plugin there is no account to show, so it
+// returns nil without calling STS.
+func AWSIdentity(ctx context.Context) (*infra.AWSIdentity, error) {
+	if IsLocalProvider(ctx) || PluginName(ctx) != "" {
+		return nil, nil //nolint:nilnil // no identity is a valid answer for the local provider and plugins
+	}
+
+	return infra.GetAWSIdentity(ctx)
+}
+
 // azureScopeContextKey keys the resolved Azure scope fields stored in the
 // context by the azure command group's Before hooks.
 type azureScopeContextKey struct{}
@@ -104,8 +255,9 @@ func AzureAppConfigNamespace(ctx context.Context) string {
 }
 
 // storeScope is the provider selector for read/write commands. Only the
-// Provider field is needed: the AWS factory builds its SSM/Secrets Manager
-// client from the ambient AWS config (region from env/profile), so no
+// Provider field is needed (plus a --regions override, see WithAWSRegion): the
+// AWS factory builds its SSM/Secrets Manager client from the ambient AWS config
+// (region from env/profile), so no
 // account/region lookup — and therefore no STS GetCallerIdentity call — is
 // required here. (Account/region only matter for staging-state file keying,
 // which the staging commands build from the AWS identity separately.)
@@ -114,13 +266,15 @@ func AzureAppConfigNamespace(ctx context.Context) string {
 var storeScope = provider.Scope{Provider: provider.ProviderAWS}
 
 // ParamStore resolves a provider.Store for the parameter service via the
-// registry (AWS by default).
+// registry (AWS by default, or local or a plugin; see WithLocalProvider and
+// WithPlugin).
 func ParamStore(ctx context.Context) (provider.Store, error) {
 	return storeForKind(ctx, provider.KindParam)
 }
 
 // SecretStore resolves a provider.Store for the secret service via the
-// registry (AWS by default).
+// registry (AWS by default, or local or a plugin; see WithLocalProvider and
+// WithPlugin).
 func SecretStore(ctx context.Context) (provider.Store, error) {
 	return storeForKind(ctx, provider.KindSecret)
 }
@@ -139,6 +293,50 @@ func GoogleCloudSecretStore(ctx context.Context) (provider.Store, error) {
 	return registry.Store(ctx, provider.GoogleCloudScope(project), provider.KindSecret)
 }
 
+// VaultSecretStore resolves a provider.Store for the Vault KV v2 engine. The
+// address and mount are read from the context (see WithVaultScope).
+func VaultSecretStore(ctx context.Context) (provider.Store, error) {
+	scope, err := vaultScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.Store(ctx, scope, provider.KindSecret)
+}
+
+// KubernetesParamStore resolves a provider.Store over the ConfigMaps of the
+// context's Kubernetes namespace (see WithKubernetesScope).
+func KubernetesParamStore(ctx context.Context) (provider.Store, error) {
+	scope, err := kubernetesScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.Store(ctx, scope, provider.KindParam)
+}
+
+// KubernetesSecretStore resolves a provider.Store over the Secrets of the
+// context's Kubernetes namespace (see WithKubernetesScope).
+func KubernetesSecretStore(ctx context.Context) (provider.Store, error) {
+	scope, err := kubernetesScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.Store(ctx, scope, provider.KindSecret)
+}
+
+// SOPSStore resolves a provider.Store over the leaf keys of the context's SOPS
+// file (see WithSOPSFile).
+func SOPSStore(ctx context.Context) (provider.Store, error) {
+	scope, err := sopsScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.Store(ctx, scope, provider.KindSecret)
+}
+
 // AzureKeyVaultStore resolves a provider.Store for the Azure Key Vault (secret)
 // service. The vault name is read from the context (see WithAzureVaultName); it
 // returns a clear error when no vault name was resolved.
@@ -172,8 +370,38 @@ func AzureAppConfigStore(ctx context.Context) (provider.Store, error) {
 	return registry.Store(ctx, scope, provider.KindParam)
 }
 
+// AzureKeyVaultReader resolves a Key Vault secret reader for the named vault,
+// independent of any --vault-name in the context. It is the seam App
+// Configuration uses to follow a Key Vault reference into the vault the
+// reference names.
+func AzureKeyVaultReader(ctx context.Context, vaultName string) (provider.Reader, error) {
+	return registry.Store(ctx, provider.AzureKeyVaultScope(vaultName), provider.KindSecret)
+}
+
+// ScopeStore resolves a provider.Store for an explicit scope, independent of
+// the command group's context. It is the seam `suve matrix` uses to read the
+// same service from several scopes in one command.
+func ScopeStore(ctx context.Context, scope provider.Scope, kind provider.Kind) (provider.Store, error) {
+	return registry.Store(ctx, scope, kind)
+}
+
 func storeForKind(ctx context.Context, kind provider.Kind) (provider.Store, error) {
-	store, err := registry.Store(ctx, storeScope, kind)
+	scope := storeScope
+
+	switch name := PluginName(ctx); {
+	case name != "":
+		var err error
+
+		if scope, err = plugin.ResolveScope(ctx, name); err != nil {
+			return nil, err
+		}
+	case IsLocalProvider(ctx):
+		scope = provider.LocalScope()
+	default:
+		scope.Region = awsRegionFromContext(ctx)
+	}
+
+	store, err := registry.Store(ctx, scope, kind)
 	if err != nil {
 		return nil, err
 	}
@@ -234,6 +462,138 @@ func GoogleCloudStagingScopeResolver(ctx context.Context) (staging.ResolvedScope
 	}, nil
 }
 
+// VaultSecretStrategyFactory builds a staging FullStrategy for Vault KV v2,
+// wrapping a provider.Store resolved for the context's address and mount. It
+// satisfies staging.StrategyFactory.
+func VaultSecretStrategyFactory(ctx context.Context) (staging.FullStrategy, error) {
+	store, err := VaultSecretStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return staging.NewVaultSecretStrategy(store), nil
+}
+
+// VaultStagingScopeResolver resolves the Vault staging scope from the address
+// and mount stashed in the context (see WithVaultScope). It performs no network
+// calls. It satisfies staging.ScopeResolver.
+func VaultStagingScopeResolver(ctx context.Context) (staging.ResolvedScope, error) {
+	scope, err := vaultScope(ctx)
+	if err != nil {
+		return staging.ResolvedScope{}, err
+	}
+
+	return staging.ResolvedScope{
+		Scope:  scope,
+		Target: fmt.Sprintf("mount %s at %s", scope.VaultMount, scope.VaultAddress),
+	}, nil
+}
+
+// KubernetesParamStrategyFactory builds a staging FullStrategy for Kubernetes
+// ConfigMaps in the context's namespace. It satisfies staging.StrategyFactory.
+func KubernetesParamStrategyFactory(ctx context.Context) (staging.FullStrategy, error) {
+	store, err := KubernetesParamStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return staging.NewKubernetesParamStrategy(store), nil
+}
+
+// KubernetesSecretStrategyFactory builds a staging FullStrategy for Kubernetes
+// Secrets in the context's namespace. It satisfies staging.StrategyFactory.
+func KubernetesSecretStrategyFactory(ctx context.Context) (staging.FullStrategy, error) {
+	store, err := KubernetesSecretStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return staging.NewKubernetesSecretStrategy(store), nil
+}
+
+// KubernetesStagingScopeResolver resolves the Kubernetes staging scope from the
+// context's flags and the kubeconfig (see WithKubernetesScope). It reads local
+// files only. It satisfies staging.ScopeResolver.
+func KubernetesStagingScopeResolver(ctx context.Context) (staging.ResolvedScope, error) {
+	scope, err := kubernetesScope(ctx)
+	if err != nil {
+		return staging.ResolvedScope{}, err
+	}
+
+	return staging.ResolvedScope{
+		Scope:  scope,
+		Target: fmt.Sprintf("namespace %s in context %s", scope.KubeNamespace, scope.KubeContext),
+	}, nil
+}
+
+// SOPSStrategyFactory builds a staging FullStrategy for the context's SOPS
+// file. It satisfies staging.StrategyFactory.
+func SOPSStrategyFactory(ctx context.Context) (staging.FullStrategy, error) {
+	store, err := SOPSStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return staging.NewSOPSStrategy(store), nil
+}
+
+// SOPSStagingScopeResolver resolves the SOPS staging scope from the context's
+// flag and the environment (see WithSOPSFile). It touches no files. It
+// satisfies staging.ScopeResolver.
+func SOPSStagingScopeResolver(ctx context.Context) (staging.ResolvedScope, error) {
+	scope, err := sopsScope(ctx)
+	if err != nil {
+		return staging.ResolvedScope{}, err
+	}
+
+	return staging.ResolvedScope{
+		Scope:  scope,
+		Target: "file " + scope.SOPSFile,
+	}, nil
+}
+
+// LocalStagingScopeResolver resolves the staging scope of the local provider.
+// Every local store shares one scope, so it only reads the directory for the
+// confirmation target. It satisfies staging.ScopeResolver.
+func LocalStagingScopeResolver(_ context.Context) (staging.ResolvedScope, error) {
+	dir, err := local.Dir()
+	if err != nil {
+		return staging.ResolvedScope{}, err
+	}
+
+	return staging.ResolvedScope{
+		Scope:  provider.LocalScope(),
+		Target: "local (" + dir + ")",
+	}, nil
+}
+
+// PluginStagingScopeResolver returns the staging scope resolver of the
+// context's plugin (see WithPlugin) for kind, or for the plugin as a whole when
+// kind is empty. It runs the plugin's handshake; a kind the plugin does not
+// offer is a wrapped staging.ErrServiceNotConfigured. It satisfies
+// staging.ScopeResolver.
+func PluginStagingScopeResolver(kind provider.Kind) staging.ScopeResolver {
+	return func(ctx context.Context) (staging.ResolvedScope, error) {
+		name := PluginName(ctx)
+
+		scope, err := plugin.ResolveScope(ctx, name)
+		if err != nil {
+			return staging.ResolvedScope{}, err
+		}
+
+		if kind != "" && !scope.SupportsService(kind) {
+			return staging.ResolvedScope{}, fmt.Errorf(
+				"%w: plugin %s offers no %s service", staging.ErrServiceNotConfigured, name, kind,
+			)
+		}
+
+		return staging.ResolvedScope{
+			Scope:  scope,
+			Target: "plugin " + name,
+		}, nil
+	}
+}
+
 // AzureKeyVaultSecretStrategyFactory builds a staging FullStrategy for Azure Key
 // Vault secrets, wrapping a provider.Store resolved for the context's vault. It
 // satisfies staging.StrategyFactory.
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/kubernetes"
+	"github.com/mpyw/suve/internal/provider/sops"
+)
+
+// HydrateScope fills empty resource fields on a scope from the environment
+// (flag wins over env), mirroring the GUI's hydrateScope. It completes the
+// scopes that --tui launches, `suve matrix` columns and `diff --tree --against`
+// build outside a command group's Before hook.
+func HydrateScope(s provider.Scope) provider.Scope {
+	switch s.Provider {
+	case provider.ProviderGoogleCloud:
+		if s.ProjectID == "" {
+			s.ProjectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
+		}
+	case provider.ProviderAzure:
+		if s.VaultName == "" {
+			s.VaultName = os.Getenv("AZURE_KEYVAULT_NAME")
+		}
+
+		if s.StoreName == "" {
+			s.StoreName = os.Getenv("AZURE_APPCONFIG_NAME")
+		}
+
+		if s.AppConfigNamespace == "" {
+			s.AppConfigNamespace = os.Getenv("AZURE_APPCONFIG_NAMESPACE")
+		}
+	case provider.ProviderVault:
+		if s.VaultAddress == "" {
+			s.VaultAddress = os.Getenv("VAULT_ADDR")
+		}
+
+		if s.VaultMount == "" {
+			s.VaultMount = "secret"
+		}
+	case provider.ProviderKubernetes:
+		// The kubeconfig fills the current context and its namespace; an
+		// unresolvable kubeconfig is reported by ValidateScope.
+		if resolved, err := kubernetes.ResolveScope(s.KubeContext, s.KubeNamespace); err == nil {
+			s = resolved
+		}
+	case provider.ProviderSOPS:
+		// SUVE_SOPS_FILE fills the file, made absolute; a missing file is
+		// reported by ValidateScope.
+		if resolved, err := sops.ResolveScope(s.SOPSFile); err == nil {
+			s = resolved
+		}
+	case provider.ProviderAWS, provider.ProviderLocal, provider.ProviderPlugin:
+		// Nothing to hydrate: AWS reads the ambient config, local has no fields
+		// and a plugin's scope is its name.
+	}
+
+	return s
+}
+
+// ValidateScope rejects a hydrated scope that cannot resolve any service, with
+// guidance naming the flags/env to set.
+func ValidateScope(s provider.Scope) error {
+	switch s.Provider {
+	case provider.ProviderGoogleCloud:
+		if s.ProjectID == "" {
+			return errors.New("no Google Cloud project: set --project or the GOOGLE_CLOUD_PROJECT environment variable")
+		}
+	case provider.ProviderAzure:
+		if s.VaultName == "" && s.StoreName == "" {
+			return errors.New(
+				"no Azure Key Vault or App Configuration store: set --vault-name / --store-name " +
+					"or the AZURE_KEYVAULT_NAME / AZURE_APPCONFIG_NAME environment variable",
+			)
+		}
+	case provider.ProviderVault:
+		if s.VaultAddress == "" {
+			return errors.New("no Vault address: set --address or the VAULT_ADDR environment variable")
+		}
+	case provider.ProviderKubernetes:
+		if _, err := kubernetes.ResolveScope(s.KubeContext, s.KubeNamespace); err != nil {
+			return err
+		}
+	case provider.ProviderSOPS:
+		if s.SOPSFile == "" {
+			return errors.New("no SOPS file: set --file or the " + sops.FileEnvVar + " environment variable")
+		}
+	case provider.ProviderPlugin:
+		if s.Plugin == "" {
+			return errors.New("no plugin: launch the TUI with 'suve <plugin> --tui'")
+		}
+	case provider.ProviderAWS, provider.ProviderLocal:
+		// AWS resolves its region from the ambient config and local needs no
+		// fields; nothing to validate.
+	}
+
+	return nil
+}
+
+// ProviderStore resolves the kind store of provider p configured purely from
+// the environment, as a bare --tui launch of p would be.
+func ProviderStore(ctx context.Context, p provider.Provider, kind provider.Kind) (provider.Store, error) {
+	scope := HydrateScope(provider.Scope{Provider: p})
+
+	if err := ValidateScope(scope); err != nil {
+		return nil, fmt.Errorf("%s: %w", p, err)
+	}
+
+	return ScopeStore(ctx, scope, kind)
+}
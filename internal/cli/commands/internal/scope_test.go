@@ -0,0 +1,63 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// TestHydrateScope_FillsFromEnv pins that empty resource fields hydrate from
+// the environment (flag values would already be set on the scope and win).
+func TestHydrateScope_FillsFromEnv(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "proj-from-env")
+
+	got := cliinternal.HydrateScope(provider.Scope{Provider: provider.ProviderGoogleCloud})
+	assert.Equal(t, "proj-from-env", got.ProjectID)
+
+	t.Setenv("SUVE_SOPS_FILE", "/repo/secrets.yaml")
+
+	got = cliinternal.HydrateScope(provider.Scope{Provider: provider.ProviderSOPS})
+	assert.Equal(t, provider.SOPSScope("/repo/secrets.yaml"), got)
+}
+
+// TestValidateScope pins the per-provider scope requirements that produce a
+// launch error before the alt-screen takes over (or a matrix/diff side error).
+func TestValidateScope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		scope   provider.Scope
+		wantErr string
+	}{
+		{name: "aws needs nothing", scope: provider.Scope{Provider: provider.ProviderAWS}},
+		{name: "gcloud with project ok", scope: provider.GoogleCloudScope("p")},
+		{name: "gcloud without project", scope: provider.Scope{Provider: provider.ProviderGoogleCloud}, wantErr: "Google Cloud project"},
+		{name: "azure vault ok", scope: provider.AzureKeyVaultScope("v")},
+		{name: "azure store ok", scope: provider.AzureAppConfigScope("s")},
+		{name: "azure neither", scope: provider.Scope{Provider: provider.ProviderAzure}, wantErr: "Azure Key Vault or App Configuration"},
+		{name: "sops with file ok", scope: provider.SOPSScope("/repo/secrets.yaml")},
+		{name: "sops without file", scope: provider.Scope{Provider: provider.ProviderSOPS}, wantErr: "no SOPS file"},
+		{name: "local needs nothing", scope: provider.LocalScope()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := cliinternal.ValidateScope(tt.scope)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Package kubernetes provides CLI commands for Kubernetes ConfigMaps and
+// Secrets, exposed as the "suve kubernetes param <op>" (ConfigMap keys) and
+// "suve kubernetes secret <op>" (Secret keys) command groups plus the
+// "suve kubernetes stage <op>" staging workflow.
+//
+// Both services share one scope (a kubeconfig context and namespace) and one
+// command set, so each command is built once from a service descriptor. The
+// commands reuse the generic scaffolding of the other provider groups via
+// Kubernetes-specific presenters, use cases, and staging strategy.
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// service describes one of the two Kubernetes services so a single command
+// implementation can serve both.
+type service struct {
+	// noun is the subgroup name and the word used in messages ("param" /
+	// "secret").
+	noun string
+	// aliases are the subgroup's extra names.
+	aliases []string
+	// object is the Kubernetes kind backing the service ("ConfigMap" /
+	// "Secret").
+	object string
+	// kind is the provider kind the service reads.
+	kind provider.Kind
+	// valueType is the value type writes carry.
+	valueType domain.ValueType
+	// store resolves the service's provider.Store from the context scope.
+	store func(ctx context.Context) (provider.Store, error)
+	// strategy builds the staging strategy; parser its network-free form.
+	strategy staging.StrategyFactory
+	parser   staging.ParserFactory
+}
+
+// configMaps is the ConfigMap-backed param service.
+func configMaps() service {
+	return service{
+		noun:      "param",
+		aliases:   []string{"params", "configmap", "cm"},
+		object:    "ConfigMap",
+		kind:      provider.KindParam,
+		valueType: domain.ValueTypePlaintext,
+		store:     cliinternal.KubernetesParamStore,
+		strategy:  cliinternal.KubernetesParamStrategyFactory,
+		parser:    staging.KubernetesParamParserFactory,
+	}
+}
+
+// secrets is the Secret-backed secret service.
+func secrets() service {
+	return service{
+		noun:      "secret",
+		aliases:   []string{"secrets"},
+		object:    "Secret",
+		kind:      provider.KindSecret,
+		valueType: domain.ValueTypeSecret,
+		store:     cliinternal.KubernetesSecretStore,
+		strategy:  cliinternal.KubernetesSecretStrategyFactory,
+		parser:    staging.KubernetesSecretParserFactory,
+	}
+}
+
+// Command returns the kubernetes command with the param (ConfigMap) and secret
+// (Secret) subcommand groups.
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:    "kubernetes",
+		Aliases: []string{"k8s", "kube"},
+		Usage:   "Interact with Kubernetes ConfigMaps (param) and Secrets (secret)",
+		Description: `Interact with the ConfigMaps and Secrets of a Kubernetes namespace.
+
+  - "suve kubernetes param"  targets ConfigMap keys.
+  - "suve kubernetes secret" targets Secret keys.
+
+Each entry is one key of one object, named <object>/<key> (e.g.
+app-config/LOG_LEVEL). Kubernetes keeps no value history, so there are no
+version specifiers and no "log" command. The object's labels are shown and
+edited as the entry's tags, so every key of an object shares them.
+
+The cluster is reached the way kubectl reaches it: the kubeconfig is read from
+$KUBECONFIG (else ~/.kube/config), --context picks a context (default: the
+current context) and --namespace picks a namespace (default: the context's
+namespace, else "default").`,
+		Flags: scopeFlags(),
+		// Before stashes the flags in the context so the generic command
+		// presenters (which do not receive *cli.Command) can resolve a store.
+		// The kubeconfig is read only when a store is built, so
+		// `suve kubernetes param --help` works without one.
+		Before: resolveScope,
+		Commands: []*cli.Command{
+			serviceCommand(configMaps()),
+			serviceCommand(secrets()),
+			StageCommand(),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// FlatParamCommand returns the ConfigMap command as a standalone top-level
+// command named `name`. Because there is no parent kubernetes group to carry
+// them, it folds in the --context/--namespace flags and the scope-resolving
+// Before hook. Used for the flat `suve param` alias when Kubernetes is the
+// uniquely active param provider.
+func FlatParamCommand(name string) *cli.Command {
+	return flatServiceCommand(configMaps(), name)
+}
+
+// FlatSecretCommand returns the Secret command as a standalone top-level
+// command named `name`, folding in the scope flags like FlatParamCommand. Used
+// for the flat `suve secret` alias when Kubernetes is the uniquely active
+// secret provider.
+func FlatSecretCommand(name string) *cli.Command {
+	return flatServiceCommand(secrets(), name)
+}
+
+func flatServiceCommand(svc service, name string) *cli.Command {
+	c := serviceCommand(svc)
+	c.Name = name
+	c.Flags = scopeFlags()
+	c.Before = resolveScope
+
+	return c
+}
+
+// scopeFlags returns the shared --context and --namespace flags (a fresh slice
+// per call so each command owns its flag instances).
+func scopeFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "context",
+			Usage: "kubeconfig context to use (defaults to the current context)",
+		},
+		&cli.StringFlag{
+			Name:    "namespace",
+			Aliases: []string{"n", "ns"},
+			Usage:   `Namespace to use (defaults to the context's namespace, else "default")`,
+		},
+	}
+}
+
+// resolveScope stashes the --context and --namespace flags into the context for
+// the subcommands.
+func resolveScope(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	return cliinternal.WithKubernetesScope(ctx, cmd.String("context"), cmd.String("namespace")), nil
+}
+
+// serviceCommand returns the "kubernetes param" or "kubernetes secret"
+// subcommand group.
+func serviceCommand(svc service) *cli.Command {
+	return &cli.Command{
+		Name:    svc.noun,
+		Aliases: svc.aliases,
+		Usage:   "Interact with Kubernetes " + svc.object + " keys",
+		Commands: []*cli.Command{
+			showCommand(svc),
+			diffCommand(svc),
+			listCommand(svc),
+			createCommand(svc),
+			updateCommand(svc),
+			deleteCommand(svc),
+			tagCommand(svc),
+			untagCommand(svc),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
@@ -0,0 +1,104 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/usecase/kubernetes"
+)
+
+// CreateRunner executes the create command.
+type CreateRunner struct {
+	UseCase *kubernetes.CreateUseCase
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// CreateOptions holds the options for the create command.
+type CreateOptions struct {
+	Name      string
+	Value     string
+	ValueType domain.ValueType
+}
+
+// createCommand returns the create command for svc.
+func createCommand(svc service) *cli.Command {
+	return &cli.Command{
+		Name:      "create",
+		Usage:     "Create a new " + svc.object + " key",
+		ArgsUsage: "<object/key> [<value>]",
+		Description: fmt.Sprintf(`Add a new key to a %[1]s, creating the object when it does not exist.
+
+Use this command for new keys only: it fails when the key already exists. To
+change an existing key, use 'suve kubernetes %[2]s update' instead.
+
+The value may be given as a positional argument, read from stdin with
+--value-stdin (so it never appears in argv/ps or shell history), or, when
+omitted, typed into $EDITOR.
+
+EXAMPLES:
+   suve kubernetes %[2]s create app/LOG_LEVEL debug                  Create a key
+   printf '%%s' "$V" | suve kubernetes %[2]s create app/KEY --value-stdin  Read value from stdin
+   suve kubernetes %[2]s create app/KEY                              Type value into $EDITOR`, svc.object, svc.noun),
+		Flags: []cli.Flag{
+			cliinternal.ValueStdinFlag(),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return createAction(ctx, cmd, svc)
+		},
+	}
+}
+
+func createAction(ctx context.Context, cmd *cli.Command, svc service) error {
+	args := cmd.Args()
+	if args.Len() < 1 {
+		return fmt.Errorf("usage: suve kubernetes %s create <object/key> [<value>]", svc.noun)
+	}
+
+	value, proceed, err := cliinternal.ResolveValue(ctx, cliinternal.ValueSource{
+		FromStdin: cmd.Bool(cliinternal.FlagValueStdin),
+		HasArg:    args.Len() >= 2, //nolint:mnd // arg 0 is the name, arg 1 is the optional value
+		Arg:       args.Get(1),
+		Stdin:     cliinternal.Stdin(cmd),
+	})
+	if err != nil {
+		return err
+	}
+
+	if !proceed {
+		output.Info(cmd.Root().Writer, "Empty value, nothing to create.")
+
+		return nil
+	}
+
+	store, err := svc.store(ctx)
+	if err != nil {
+		return err
+	}
+
+	r := &CreateRunner{
+		UseCase: &kubernetes.CreateUseCase{Writer: store},
+		Stdout:  cmd.Root().Writer,
+		Stderr:  cmd.Root().ErrWriter,
+	}
+
+	return r.Run(ctx, CreateOptions{Name: args.Get(0), Value: value, ValueType: svc.valueType})
+}
+
+// Run executes the create command.
+func (r *CreateRunner) Run(ctx context.Context, opts CreateOptions) error {
+	result, err := r.UseCase.Execute(ctx, kubernetes.CreateInput{Name: opts.Name, Value: opts.Value, ValueType: opts.ValueType})
+	if err != nil {
+		return err
+	}
+
+	output.Success(r.Stdout, "Created %s (resourceVersion: %s)", result.Name, result.ResourceVersion)
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/confirm"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/kubernetes/objects"
+	"github.com/mpyw/suve/internal/usecase/kubernetes"
+)
+
+// DeleteRunner executes the delete command.
+type DeleteRunner struct {
+	UseCase *kubernetes.DeleteUseCase
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// DeleteOptions holds the options for the delete command.
+type DeleteOptions struct {
+	Name string
+	// DeleteObject also deletes the object when the key is its last one.
+	DeleteObject bool
+}
+
+// deleteCommand returns the delete command for svc.
+func deleteCommand(svc service) *cli.Command {
+	return &cli.Command{
+		Name:      "delete",
+		Aliases:   []string{"rm"},
+		Usage:     "Delete a " + svc.object + " key",
+		ArgsUsage: "<object/key>",
+		Description: fmt.Sprintf(`Remove a key from a %[1]s. Removing the object's last key leaves the
+object empty, with its labels, annotations and owner references; pass
+--delete-object to delete the object too. Kubernetes has no soft delete, so
+there is nothing to restore.
+
+EXAMPLES:
+   suve kubernetes %[2]s delete app/KEY                  Delete (with confirmation)
+   suve kubernetes %[2]s delete --yes app/KEY            Delete without confirmation
+   suve kubernetes %[2]s delete --delete-object app/KEY  Delete the emptied object too`, svc.object, svc.noun),
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "yes",
+				Usage: "Skip confirmation prompt",
+			},
+			&cli.BoolFlag{
+				Name:  "delete-object",
+				Usage: "Delete the " + svc.object + " too when this removes its last key",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return deleteAction(ctx, cmd, svc)
+		},
+	}
+}
+
+func deleteAction(ctx context.Context, cmd *cli.Command, svc service) error {
+	if cmd.Args().Len() < 1 {
+		return fmt.Errorf("usage: suve kubernetes %s delete <object/key>", svc.noun)
+	}
+
+	name := cmd.Args().First()
+	skipConfirm := cmd.Bool("yes")
+
+	store, err := svc.store(ctx)
+	if err != nil {
+		return err
+	}
+
+	uc := &kubernetes.DeleteUseCase{Store: store}
+
+	if !skipConfirm {
+		currentValue, _ := uc.GetCurrentValue(ctx, name)
+		if currentValue != "" {
+			output.Info(cmd.Root().ErrWriter, "Current value of %s:", name)
+			output.Println(cmd.Root().ErrWriter, "")
+			output.Println(cmd.Root().ErrWriter, output.Indent(currentValue, "  "))
+			output.Println(cmd.Root().ErrWriter, "")
+		}
+	}
+
+	prompter := &confirm.Prompter{
+		Stdin:  cliinternal.Stdin(cmd),
+		Stdout: cmd.Root().Writer,
+		Stderr: cmd.Root().ErrWriter,
+	}
+
+	confirmed, err := prompter.ConfirmDelete(name, skipConfirm)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		return nil
+	}
+
+	r := &DeleteRunner{
+		UseCase: uc,
+		Stdout:  cmd.Root().Writer,
+		Stderr:  cmd.Root().ErrWriter,
+	}
+
+	return r.Run(ctx, DeleteOptions{Name: name, DeleteObject: cmd.Bool("delete-object")})
+}
+
+// Run executes the delete command.
+func (r *DeleteRunner) Run(ctx context.Context, opts DeleteOptions) error {
+	var options []provider.DeleteOption
+	if opts.DeleteObject {
+		options = append(options, objects.DeleteEmptyObject{})
+	}
+
+	result, err := r.UseCase.Execute(ctx, kubernetes.DeleteInput{Name: opts.Name, Options: options})
+	if err != nil {
+		return err
+	}
+
+	output.Success(r.Stdout, "Deleted %s", result.Name)
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	genericdiff "github.com/mpyw/suve/internal/cli/commands/generic/diff"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/usecase/kubernetes"
+	"github.com/mpyw/suve/internal/version/kubernetesversion"
+)
+
+// diffJSONOutput represents the JSON output structure for the diff command.
+type diffJSONOutput struct {
+	OldName   string `json:"oldName"`
+	OldValue  string `json:"oldValue"`
+	NewName   string `json:"newName"`
+	NewValue  string `json:"newValue"`
+	Identical bool   `json:"identical"`
+	Diff      string `json:"diff,omitempty"`
+}
+
+// diffPresenter renders Kubernetes diff output.
+type diffPresenter struct {
+	uc     *kubernetes.DiffUseCase
+	noun   string
+	spec1  *kubernetesversion.Spec
+	spec2  *kubernetesversion.Spec
+	result *kubernetes.DiffOutput
+}
+
+// NewDiffPresenter builds a Kubernetes diff presenter over the given reader and
+// specs. noun ("param" / "secret") is used in the hint.
+func NewDiffPresenter(reader provider.Reader, noun string, spec1, spec2 *kubernetesversion.Spec) genericdiff.Presenter {
+	return &diffPresenter{uc: &kubernetes.DiffUseCase{Reader: reader}, noun: noun, spec1: spec1, spec2: spec2}
+}
+
+func (p *diffPresenter) Fetch(ctx context.Context) error {
+	result, err := p.uc.Execute(ctx, kubernetes.DiffInput{Name1: p.spec1.Name, Name2: p.spec2.Name})
+	if err != nil {
+		return err
+	}
+
+	p.result = result
+
+	return nil
+}
+
+func (p *diffPresenter) OldValue() string { return p.result.OldValue }
+func (p *diffPresenter) NewValue() string { return p.result.NewValue }
+
+func (p *diffPresenter) Labels() (string, string) {
+	return p.result.OldName, p.result.NewName
+}
+
+func (p *diffPresenter) RenderJSON(stdout io.Writer, oldValue, newValue string, identical bool, diff string) error {
+	jsonOut := diffJSONOutput{
+		OldName:   p.result.OldName,
+		OldValue:  oldValue,
+		NewName:   p.result.NewName,
+		NewValue:  newValue,
+		Identical: identical,
+		Diff:      diff,
+	}
+
+	return output.WriteJSON(stdout, jsonOut)
+}
+
+func (p *diffPresenter) Hints(stderr io.Writer) {
+	output.Hint(stderr, "Kubernetes keys are unversioned; compare two distinct keys, e.g.: suve kubernetes %s diff app/a app/b", p.noun)
+}
+
+// diffCommand returns the diff command for svc.
+func diffCommand(svc service) *cli.Command {
+	return genericdiff.Command(genericdiff.Config[*kubernetesversion.Spec]{
+		Usage:     "Show diff between two " + svc.object + " keys",
+		ArgsUsage: "<object/key1> [object/key2]",
+		Description: fmt.Sprintf(`Compare the values of two %[1]s keys in unified diff format.
+
+Kubernetes keeps no value history, so diff compares two distinct keys (of the
+same or different objects). To review pending changes against the cluster, use
+'suve kubernetes stage diff'.
+
+EXAMPLES:
+  suve kubernetes %[2]s diff app/KEY app-v2/KEY              Compare two keys
+  suve kubernetes %[2]s diff --parse-json app/a app/b        Format JSON values before diffing
+  suve kubernetes %[2]s diff --output=json app/a app/b       Output comparison as JSON`, svc.object, svc.noun),
+		ParseDiffArgs: kubernetesversion.ParseDiffArgs,
+		NewPresenter: func(ctx context.Context, spec1, spec2 *kubernetesversion.Spec) (genericdiff.Presenter, error) {
+			store, err := svc.store(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewDiffPresenter(store, svc.noun, spec1, spec2), nil
+		},
+		Store: svc.store,
+		Kind:  svc.kind,
+	})
+}
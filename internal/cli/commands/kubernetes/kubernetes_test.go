@@ -0,0 +1,202 @@
+package kubernetes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appcli "github.com/mpyw/suve/internal/cli/commands"
+	"github.com/mpyw/suve/internal/cli/commands/kubernetes"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/kubernetes/objects"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	k8susecase "github.com/mpyw/suve/internal/usecase/kubernetes"
+	"github.com/mpyw/suve/internal/version/kubernetesversion"
+)
+
+// TestCommandValidation exercises argument/spec validation that fails before any
+// provider store is resolved (so no cluster or kubeconfig is needed).
+func TestCommandValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "create missing args",
+			args:    []string{"suve", "kubernetes", "param", "create"},
+			wantErr: "usage:",
+		},
+		{
+			name:    "delete missing name",
+			args:    []string{"suve", "kubernetes", "secret", "delete"},
+			wantErr: "usage:",
+		},
+		{
+			name:    "show rejects version spec",
+			args:    []string{"suve", "k8s", "param", "show", "app/KEY#1"},
+			wantErr: "versions are not supported",
+		},
+		{
+			name:    "show rejects a bare object name",
+			args:    []string{"suve", "k8s", "secret", "show", "app"},
+			wantErr: "name must be <object>/<key>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := appcli.MakeApp()
+			err := app.Run(t.Context(), tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCreateRunner(t *testing.T) {
+	t.Parallel()
+
+	store := &providermock.Store{
+		CreateFunc: func(
+			_ context.Context, name, value string, valueType domain.ValueType, _ string, _ ...provider.WriteOption,
+		) (domain.Version, error) {
+			assert.Equal(t, "db/password", name)
+			assert.Equal(t, "s3cr3t", value)
+			assert.Equal(t, domain.ValueTypeSecret, valueType)
+
+			return domain.Version{ID: "42"}, nil
+		},
+	}
+
+	var buf, errBuf bytes.Buffer
+
+	r := &kubernetes.CreateRunner{
+		UseCase: &k8susecase.CreateUseCase{Writer: store},
+		Stdout:  &buf,
+		Stderr:  &errBuf,
+	}
+	require.NoError(t, r.Run(t.Context(), kubernetes.CreateOptions{
+		Name: "db/password", Value: "s3cr3t", ValueType: domain.ValueTypeSecret,
+	}))
+	assert.Contains(t, buf.String(), "Created db/password (resourceVersion: 42)")
+}
+
+func TestDeleteRunner(t *testing.T) {
+	t.Parallel()
+
+	var (
+		deleted string
+		options []provider.DeleteOption
+	)
+
+	store := &providermock.Store{
+		DeleteFunc: func(_ context.Context, name string, opts ...provider.DeleteOption) error {
+			deleted, options = name, opts
+
+			return nil
+		},
+	}
+
+	var buf, errBuf bytes.Buffer
+
+	r := &kubernetes.DeleteRunner{
+		UseCase: &k8susecase.DeleteUseCase{Store: store},
+		Stdout:  &buf,
+		Stderr:  &errBuf,
+	}
+	require.NoError(t, r.Run(t.Context(), kubernetes.DeleteOptions{Name: "app/LOG_LEVEL"}))
+	assert.Equal(t, "app/LOG_LEVEL", deleted)
+	assert.Empty(t, options)
+	assert.Contains(t, buf.String(), "Deleted app/LOG_LEVEL")
+
+	require.NoError(t, r.Run(t.Context(), kubernetes.DeleteOptions{Name: "app/LOG_LEVEL", DeleteObject: true}))
+	assert.Equal(t, []provider.DeleteOption{objects.DeleteEmptyObject{}}, options)
+}
+
+func TestShowPresenter(t *testing.T) {
+	t.Parallel()
+
+	store := &providermock.Store{
+		ResolveFunc: func(_ context.Context, _, _ string) (provider.VersionRef, error) {
+			return provider.VersionRef{}, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{
+				Name:    name,
+				Value:   "debug",
+				Type:    domain.ValueTypePlaintext,
+				Version: domain.Version{ID: "1234"},
+				Tags:    []domain.Tag{{Key: "team", Value: "web"}},
+			}, nil
+		},
+	}
+
+	spec, err := kubernetesversion.Parse("app/LOG_LEVEL")
+	require.NoError(t, err)
+
+	presenter := kubernetes.NewShowPresenter(store, spec)
+	require.NoError(t, presenter.Fetch(t.Context()))
+
+	var buf, errBuf bytes.Buffer
+
+	value := presenter.Value(false, &errBuf)
+	presenter.RenderText(&buf, value)
+
+	out := buf.String()
+	assert.Contains(t, out, "app/LOG_LEVEL")
+	assert.Contains(t, out, "1234")
+	assert.Contains(t, out, "debug")
+	assert.Contains(t, out, "Tags")
+
+	var jsonBuf bytes.Buffer
+	require.NoError(t, presenter.RenderJSON(&jsonBuf, value))
+
+	var showOut struct {
+		Name            string            `json:"name"`
+		ResourceVersion string            `json:"resourceVersion"`
+		Tags            map[string]string `json:"tags"`
+		Value           string            `json:"value"`
+	}
+	require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &showOut))
+	assert.Equal(t, "app/LOG_LEVEL", showOut.Name)
+	assert.Equal(t, "1234", showOut.ResourceVersion)
+	assert.Equal(t, "debug", showOut.Value)
+	assert.Equal(t, map[string]string{"team": "web"}, showOut.Tags)
+}
+
+func TestDiffPresenter(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]string{"app/A": "1", "app/B": "2"}
+
+	store := &providermock.Store{
+		ResolveFunc: func(_ context.Context, _, _ string) (provider.VersionRef, error) {
+			return provider.VersionRef{}, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{Name: name, Value: values[name]}, nil
+		},
+	}
+
+	spec1, spec2, err := kubernetesversion.ParseDiffArgs([]string{"app/A", "app/B"})
+	require.NoError(t, err)
+
+	presenter := kubernetes.NewDiffPresenter(store, "param", spec1, spec2)
+	require.NoError(t, presenter.Fetch(t.Context()))
+	assert.Equal(t, "1", presenter.OldValue())
+	assert.Equal(t, "2", presenter.NewValue())
+
+	oldLabel, newLabel := presenter.Labels()
+	assert.Equal(t, "app/A", oldLabel)
+	assert.Equal(t, "app/B", newLabel)
+}
@@ -0,0 +1,80 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/urfave/cli/v3"
+
+	genericlist "github.com/mpyw/suve/internal/cli/commands/generic/list"
+	"github.com/mpyw/suve/internal/usecase/kubernetes"
+)
+
+// listCommand returns the list command for svc.
+func listCommand(svc service) *cli.Command {
+	return genericlist.Command(genericlist.Config{
+		Usage:     "List " + svc.object + " keys",
+		ArgsUsage: "[filter-prefix]",
+		Description: fmt.Sprintf(`List every key of every %[1]s in the namespace as <object>/<key>.
+
+With a filter prefix, lists only names that start with that prefix (e.g.
+"app/" for the keys of the app object). Binary keys are listed but cannot be
+shown or written through suve.
+
+FILTERING:
+   Use --filter to filter results by regex pattern (client-side).
+
+VALUE DISPLAY:
+   Use --show to display values alongside names.
+   Output format: <object/key><TAB><value>
+
+EXAMPLES:
+   suve kubernetes %[2]s list                     List all keys
+   suve kubernetes %[2]s list app/                List the keys of "app"
+   suve kubernetes %[2]s list --show app/         List with values
+   suve kubernetes %[2]s list --output=json app/  List as JSON`, svc.object, svc.noun),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "filter",
+				Usage: "Filter by regex pattern",
+			},
+			&cli.BoolFlag{
+				Name:  "show",
+				Usage: "Show values",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Output format: text (default) or json",
+			},
+		},
+		NewList: func(
+			ctx context.Context, cmd *cli.Command, withValue bool,
+		) (func(context.Context) ([]genericlist.Entry, error), error) {
+			store, err := svc.store(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			uc := &kubernetes.ListUseCase{Reader: store}
+			input := kubernetes.ListInput{
+				Prefix:    cmd.Args().First(),
+				Filter:    cmd.String("filter"),
+				WithValue: withValue,
+			}
+
+			return func(ctx context.Context) ([]genericlist.Entry, error) {
+				result, err := uc.Execute(ctx, input)
+				if err != nil {
+					return nil, err
+				}
+
+				entries := lo.Map(result.Entries, func(e kubernetes.ListEntry, _ int) genericlist.Entry {
+					return genericlist.Entry{Name: e.Name, Value: e.Value, Error: e.Error}
+				})
+
+				return entries, nil
+			}, nil
+		},
+	})
+}
@@ -0,0 +1,145 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	genericshow "github.com/mpyw/suve/internal/cli/commands/generic/show"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/jsonutil"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/timeutil"
+	"github.com/mpyw/suve/internal/usecase/kubernetes"
+	"github.com/mpyw/suve/internal/version/kubernetesversion"
+)
+
+// showJSONOutput represents the JSON output structure for the show command.
+type showJSONOutput struct {
+	Name            string            `json:"name"`
+	ResourceVersion string            `json:"resourceVersion,omitempty"`
+	Created         string            `json:"created,omitempty"`
+	Modified        string            `json:"modified,omitempty"`
+	Tags            map[string]string `json:"tags"`
+	Value           string            `json:"value"`
+}
+
+// showPresenter renders Kubernetes show output.
+type showPresenter struct {
+	uc     *kubernetes.ShowUseCase
+	spec   *kubernetesversion.Spec
+	result *kubernetes.ShowOutput
+}
+
+// NewShowPresenter builds a Kubernetes show presenter over the given reader and
+// spec.
+func NewShowPresenter(reader provider.Reader, spec *kubernetesversion.Spec) genericshow.Presenter {
+	return &showPresenter{uc: &kubernetes.ShowUseCase{Reader: reader}, spec: spec}
+}
+
+func (p *showPresenter) Fetch(ctx context.Context) error {
+	result, err := p.uc.Execute(ctx, kubernetes.ShowInput{Name: p.spec.Name})
+	if err != nil {
+		return err
+	}
+
+	p.result = result
+
+	return nil
+}
+
+func (p *showPresenter) Value(parseJSON bool, stderr io.Writer) string {
+	value := p.result.Value
+	if parseJSON {
+		value = jsonutil.TryFormatOrWarn(value, stderr, "")
+	}
+
+	return value
+}
+
+func (p *showPresenter) RenderText(stdout io.Writer, value string) {
+	result := p.result
+
+	out := output.New(stdout)
+	out.Field("Name", result.Name)
+
+	if result.ResourceVersion != "" {
+		out.Field("ResourceVersion", result.ResourceVersion)
+	}
+
+	if result.CreatedDate != nil {
+		out.Field("Created", timeutil.FormatRFC3339(*result.CreatedDate))
+	}
+
+	if result.ModifiedDate != nil {
+		out.Field("Modified", timeutil.FormatRFC3339(*result.ModifiedDate))
+	}
+
+	if len(result.Tags) > 0 {
+		out.Field("Tags", fmt.Sprintf("%d tag(s)", len(result.Tags)))
+
+		for _, tag := range result.Tags {
+			out.Field("  "+tag.Key, tag.Value)
+		}
+	}
+
+	out.Separator()
+	out.Value(value)
+}
+
+func (p *showPresenter) RenderJSON(stdout io.Writer, value string) error {
+	result := p.result
+
+	jsonOut := showJSONOutput{
+		Name:            result.Name,
+		ResourceVersion: result.ResourceVersion,
+		Value:           value,
+	}
+
+	if result.CreatedDate != nil {
+		jsonOut.Created = timeutil.FormatRFC3339(*result.CreatedDate)
+	}
+
+	if result.ModifiedDate != nil {
+		jsonOut.Modified = timeutil.FormatRFC3339(*result.ModifiedDate)
+	}
+
+	jsonOut.Tags = make(map[string]string)
+	for _, tag := range result.Tags {
+		jsonOut.Tags[tag.Key] = tag.Value
+	}
+
+	return output.WriteJSON(stdout, jsonOut)
+}
+
+// showCommand returns the show command for svc.
+func showCommand(svc service) *cli.Command {
+	return genericshow.Command(genericshow.Config[*kubernetesversion.Spec]{
+		Usage:     "Show a " + svc.object + " key's value with metadata",
+		ArgsUsage: "<object/key>",
+		Description: fmt.Sprintf(`Display one key of a %[1]s along with the object's metadata.
+
+The object's labels are shown as the entry's tags. Kubernetes keeps no value
+history, so no version specifier is accepted.
+
+Use --raw to output only the value without metadata (for piping/scripting).
+Use --output=json for structured JSON output (cannot be used with --raw).
+
+EXAMPLES:
+  suve kubernetes %[2]s show app/KEY                  Show a key
+  suve kubernetes %[2]s show --raw app/KEY            Output raw value (for piping)
+  suve kubernetes %[2]s show --output=json app/KEY    Output as JSON`, svc.object, svc.noun),
+		UsageError: fmt.Sprintf("usage: suve kubernetes %s show <object/key>", svc.noun),
+		ParseSpec:  kubernetesversion.Parse,
+		NewPresenter: func(ctx context.Context, _ *cli.Command, spec *kubernetesversion.Spec) (genericshow.Presenter, error) {
+			store, err := svc.store(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewShowPresenter(store, spec), nil
+		},
+	})
+}
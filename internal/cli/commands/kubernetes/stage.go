@@ -0,0 +1,134 @@
+package kubernetes
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/apply"
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/diff"
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/reset"
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/status"
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	stgcli "github.com/mpyw/suve/internal/staging/cli"
+)
+
+// stageConfig is the staging config for svc. Both services share the
+// ScopeResolver, which keys on-disk staging state by the resolved context and
+// namespace. Kubernetes keeps no description, so no --description flag is
+// registered.
+func stageConfig(svc service) stgcli.CommandConfig {
+	return stgcli.CommandConfig{
+		CommandName:   svc.noun,
+		ItemName:      "key",
+		Factory:       svc.strategy,
+		ParserFactory: svc.parser,
+		ScopeResolver: cliinternal.KubernetesStagingScopeResolver,
+	}
+}
+
+// stageGroup is the "param" or "secret" staging subgroup.
+func stageGroup(svc service) *cli.Command {
+	cfg := stageConfig(svc)
+
+	return &cli.Command{
+		Name:    svc.noun,
+		Aliases: svc.aliases,
+		Usage:   "Staging operations for Kubernetes " + svc.object + " keys",
+		Commands: []*cli.Command{
+			stgcli.NewAddCommand(cfg),
+			stgcli.NewEditCommand(cfg),
+			stgcli.NewDeleteCommand(cfg),
+			stgcli.NewStatusCommand(cfg),
+			stgcli.NewDiffCommand(cfg),
+			stgcli.NewApplyCommand(cfg),
+			stgcli.NewResetCommand(cfg),
+			stgcli.NewResolveCommand(cfg),
+			stgcli.NewTagCommand(cfg),
+			stgcli.NewUntagCommand(cfg),
+			stgcli.NewExportCommand(cfg),
+			stgcli.NewImportCommand(cfg),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// stageDescription is shared by the grouped and flat forms of the command.
+const stageDescription = `Stage changes locally before applying to a Kubernetes namespace.
+
+Use 'suve kubernetes stage param' for ConfigMap keys.
+Use 'suve kubernetes stage secret' for Secret keys.
+
+Staged changes are kept per context and namespace. Global commands operate on
+the staged changes of both services:
+   status    Show all staged changes (ConfigMaps and Secrets)
+   diff      Show diff of all staged changes vs the cluster
+   plan      Capture staged changes into a reviewable plan file
+   apply     Apply all staged changes to the cluster
+   reset     Unstage all changes
+   resolve   Merge remote changes into conflicting staged changes
+   switch    Switch the active changeset (-c to create one)
+   branch    List or delete changesets
+   move      Move a staged change to another changeset
+   stash     Shelve staged changes and restore them later
+   history   List past applies
+   undo      Stage the changes that reverse an apply
+   export    Export staged changes to a directory (one file per service)
+   import    Import staged changes from a directory
+
+EXAMPLES:
+   suve kubernetes stage param add app/LOG_LEVEL   Stage a new ConfigMap key
+   suve kubernetes stage secret edit db/password   Edit and stage a Secret key
+   suve kubernetes stage status                    View all staged changes
+   suve kubernetes stage diff                      Review staged changes vs the cluster
+   suve kubernetes stage apply                     Apply all staged changes`
+
+// stageSubcommands builds the stage subgroups and the global commands.
+func stageSubcommands() []*cli.Command {
+	gcfg := stgcli.KubernetesGlobalConfig(stageConfig(configMaps()), stageConfig(secrets()))
+
+	return []*cli.Command{
+		stageGroup(configMaps()),
+		stageGroup(secrets()),
+		status.Command(gcfg),
+		diff.Command(gcfg),
+		apply.PlanCommand(gcfg),
+		apply.Command(gcfg),
+		reset.Command(gcfg),
+		stgcli.NewGlobalResolveCommand(gcfg),
+		stgcli.NewGlobalSwitchCommand(gcfg),
+		stgcli.NewGlobalBranchCommand(gcfg),
+		stgcli.NewGlobalMoveCommand(gcfg),
+		stgcli.NewGlobalStashCommand(gcfg),
+		stgcli.NewGlobalHistoryCommand(gcfg),
+		stgcli.NewGlobalUndoCommand(gcfg),
+		stgcli.NewGlobalExportCommand(gcfg.ScopeResolver),
+		stgcli.NewGlobalImportCommand(gcfg),
+	}
+}
+
+// StageCommand returns the "kubernetes stage" command with the param
+// (ConfigMap) and secret (Secret) staging subgroups plus the global commands
+// spanning both services.
+func StageCommand() *cli.Command {
+	return &cli.Command{
+		Name:            "stage",
+		Aliases:         []string{"stg"},
+		Usage:           "Manage staged changes for Kubernetes ConfigMaps and Secrets",
+		Description:     stageDescription,
+		Commands:        stageSubcommands(),
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// FlatStageCommand returns the Kubernetes stage command as a standalone
+// top-level command named `name` (e.g. "stage"). Because there is no parent
+// kubernetes group to carry them, it folds in the --context/--namespace flags
+// and the scope-resolving Before hook. Used for the flat `suve stage` alias
+// when Kubernetes is the uniquely active staging provider.
+func FlatStageCommand(name string) *cli.Command {
+	c := StageCommand()
+	c.Name = name
+	c.Flags = scopeFlags()
+	c.Before = resolveScope
+
+	return c
+}
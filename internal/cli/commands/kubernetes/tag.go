@@ -0,0 +1,61 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	generictag "github.com/mpyw/suve/internal/cli/commands/generic/tag"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// tagCommand returns the tag command for svc.
+func tagCommand(svc service) *cli.Command {
+	return generictag.TagCommand(generictag.Config{
+		Usage:     `Add or update tags on a ` + svc.object + ` (Kubernetes calls these "labels")`,
+		ArgsUsage: "<object/key> <key=value>...",
+		Description: fmt.Sprintf(`Add or update one or more tags on the %[1]s holding an existing key.
+
+Tags are key=value pairs. If a tag key already exists, its value is updated.
+You can specify multiple tags in a single command.
+
+NOTE: Kubernetes stores these as the object's "labels", so every key of the
+object shares them. suve uses its cross-provider term "tags" everywhere.
+
+EXAMPLES:
+   suve kubernetes %[2]s tag app/KEY env=prod               Add single tag
+   suve kubernetes %[2]s tag app/KEY env=prod team=backend  Add multiple tags`, svc.object, svc.noun),
+		Noun:       svc.noun,
+		UsageError: fmt.Sprintf("usage: suve kubernetes %s tag <object/key> <key=value> [key=value]", svc.noun),
+		NewTagger:  tagger(svc),
+	})
+}
+
+// untagCommand returns the untag command for svc.
+func untagCommand(svc service) *cli.Command {
+	return generictag.UntagCommand(generictag.Config{
+		Usage:     `Remove tags from a ` + svc.object + ` (Kubernetes calls these "labels")`,
+		ArgsUsage: "<object/key> <key>...",
+		Description: fmt.Sprintf(`Remove one or more tags from the %[1]s holding an existing key.
+
+Specify the tag keys to remove. Non-existent keys are silently ignored.
+
+NOTE: Kubernetes stores these as the object's "labels", so every key of the
+object shares them. suve uses its cross-provider term "tags" everywhere.
+
+EXAMPLES:
+   suve kubernetes %[2]s untag app/KEY deprecated   Remove single tag
+   suve kubernetes %[2]s untag app/KEY env team     Remove multiple tags`, svc.object, svc.noun),
+		Noun:       svc.noun,
+		UsageError: fmt.Sprintf("usage: suve kubernetes %s untag <object/key> <key> [key]", svc.noun),
+		NewTagger:  tagger(svc),
+	})
+}
+
+// tagger builds the provider.Tagger for svc.
+func tagger(svc service) func(ctx context.Context) (provider.Tagger, error) {
+	return func(ctx context.Context) (provider.Tagger, error) {
+		return svc.store(ctx)
+	}
+}
@@ -0,0 +1,143 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/confirm"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/usecase/kubernetes"
+)
+
+// UpdateRunner executes the update command.
+type UpdateRunner struct {
+	UseCase *kubernetes.UpdateUseCase
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// UpdateOptions holds the options for the update command.
+type UpdateOptions struct {
+	Name      string
+	Value     string
+	ValueType domain.ValueType
+}
+
+// updateCommand returns the update command for svc.
+func updateCommand(svc service) *cli.Command {
+	return &cli.Command{
+		Name:      "update",
+		Usage:     "Update a " + svc.object + " key's value",
+		ArgsUsage: "<object/key> [<value>]",
+		Description: fmt.Sprintf(`Overwrite the value of an existing %[1]s key.
+
+Kubernetes keeps no value history, so the previous value is gone once the
+update is applied. Use 'suve kubernetes stage' to review changes first, or
+'suve kubernetes %[2]s create' to add a new key.
+
+The value may be given as a positional argument, read from stdin with
+--value-stdin (so it never appears in argv/ps or shell history), or, when
+omitted, typed into $EDITOR.
+
+EXAMPLES:
+  suve kubernetes %[2]s update app/LOG_LEVEL info          Update (with confirmation)
+  suve kubernetes %[2]s update --yes app/LOG_LEVEL info    Update without confirmation
+  suve kubernetes %[2]s update app/KEY                     Type value into $EDITOR`, svc.object, svc.noun),
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "yes",
+				Usage: "Skip confirmation prompt",
+			},
+			cliinternal.ValueStdinFlag(),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return updateAction(ctx, cmd, svc)
+		},
+	}
+}
+
+func updateAction(ctx context.Context, cmd *cli.Command, svc service) error {
+	args := cmd.Args()
+	if args.Len() < 1 {
+		return fmt.Errorf("usage: suve kubernetes %s update <object/key> [<value>]", svc.noun)
+	}
+
+	name := args.Get(0)
+	skipConfirm := cmd.Bool("yes")
+
+	newValue, proceed, err := cliinternal.ResolveValue(ctx, cliinternal.ValueSource{
+		FromStdin: cmd.Bool(cliinternal.FlagValueStdin),
+		HasArg:    args.Len() >= 2, //nolint:mnd // arg 0 is the name, arg 1 is the optional value
+		Arg:       args.Get(1),
+		Stdin:     cliinternal.Stdin(cmd),
+		// Without --yes we prompt for confirmation on the same stdin below;
+		// reading the value from stdin would leave nothing for that prompt.
+		ConfirmRequired: !skipConfirm,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !proceed {
+		output.Info(cmd.Root().Writer, "Empty value, nothing to update.")
+
+		return nil
+	}
+
+	store, err := svc.store(ctx)
+	if err != nil {
+		return err
+	}
+
+	uc := &kubernetes.UpdateUseCase{Store: store}
+
+	if !skipConfirm {
+		currentValue, _ := uc.GetCurrentValue(ctx, name)
+		if currentValue != "" {
+			diff := output.Diff(cmd.Root().ErrWriter, name+" (current)", name+" (new)", currentValue, newValue)
+			if diff != "" {
+				output.Println(cmd.Root().ErrWriter, diff)
+			}
+		}
+
+		prompter := &confirm.Prompter{
+			Stdin:  cliinternal.Stdin(cmd),
+			Stdout: cmd.Root().Writer,
+			Stderr: cmd.Root().ErrWriter,
+		}
+
+		confirmed, cerr := prompter.ConfirmAction("Update "+svc.object+" key", name, false)
+		if cerr != nil {
+			return cerr
+		}
+
+		if !confirmed {
+			return nil
+		}
+	}
+
+	r := &UpdateRunner{
+		UseCase: uc,
+		Stdout:  cmd.Root().Writer,
+		Stderr:  cmd.Root().ErrWriter,
+	}
+
+	return r.Run(ctx, UpdateOptions{Name: name, Value: newValue, ValueType: svc.valueType})
+}
+
+// Run executes the update command.
+func (r *UpdateRunner) Run(ctx context.Context, opts UpdateOptions) error {
+	result, err := r.UseCase.Execute(ctx, kubernetes.UpdateInput{Name: opts.Name, Value: opts.Value, ValueType: opts.ValueType})
+	if err != nil {
+		return err
+	}
+
+	output.Success(r.Stdout, "Updated %s (resourceVersion: %s)", result.Name, result.ResourceVersion)
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Package local provides the "suve local" command group: the offline provider
+// backed by JSON files under ~/.suve/local/ (or SUVE_LOCAL_DIR). It needs no
+// account or credentials, so it is the safe place to learn the CLI, TUI and
+// staging flows and to script demos.
+//
+// The local stores model AWS Parameter Store and Secrets Manager, so the group
+// reuses the AWS command trees as-is: its Before hook marks the context (see
+// cliinternal.WithLocalProvider) and every store and strategy the AWS commands
+// resolve is then the local one. Only the staging commands are rebuilt, to key
+// staged changes under the local scope instead of an AWS account.
+package local
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	awsparam "github.com/mpyw/suve/internal/cli/commands/aws/param"
+	awssecret "github.com/mpyw/suve/internal/cli/commands/aws/secret"
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+)
+
+// Command returns the local command group with the param, secret and stage
+// subcommands.
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "local",
+		Usage: "Interact with the offline local provider (no cloud account needed)",
+		Description: `Interact with parameters and secrets kept in local JSON files.
+
+  - "suve local param"  behaves like AWS Systems Manager Parameter Store.
+  - "suve local secret" behaves like AWS Secrets Manager.
+  - "suve local stage"  stages changes to the above before applying them.
+
+The stores live in ~/.suve/local/param.json and secret.json (override the
+directory with SUVE_LOCAL_DIR). They keep real version history (#N / ~SHIFT
+for params, AWSCURRENT / AWSPREVIOUS labels for secrets), tags, descriptions,
+and soft delete with restore for secrets.
+
+Values are stored in PLAIN TEXT. Use the local provider to learn suve and to
+script demos, not for real secrets.`,
+		Before: resolveScope,
+		Commands: []*cli.Command{
+			paramCommand(),
+			secretCommand(),
+			StageCommand(),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// FlatParamCommand returns the local param command as a standalone top-level
+// command named `name`, carrying the local Before hook itself. Used for the
+// flat `suve param` alias when the local provider is selected.
+func FlatParamCommand(name string) *cli.Command {
+	return flatCommand(paramCommand(), name)
+}
+
+// FlatSecretCommand returns the local secret command as a standalone top-level
+// command named `name`, like FlatParamCommand.
+func FlatSecretCommand(name string) *cli.Command {
+	return flatCommand(secretCommand(), name)
+}
+
+func flatCommand(c *cli.Command, name string) *cli.Command {
+	c.Name = name
+	c.Before = resolveScope
+
+	return c
+}
+
+// resolveScope points the context at the local provider for the subcommands.
+func resolveScope(ctx context.Context, _ *cli.Command) (context.Context, error) {
+	return cliinternal.WithLocalProvider(ctx), nil
+}
+
+// paramCommand is the AWS Parameter Store command tree, relabelled.
+func paramCommand() *cli.Command {
+	c := awsparam.Command()
+	c.Usage = "Interact with local parameters (Parameter Store semantics)"
+
+	return c
+}
+
+// secretCommand is the AWS Secrets Manager command tree, relabelled.
+func secretCommand() *cli.Command {
+	c := awssecret.Command()
+	c.Usage = "Interact with local secrets (Secrets Manager semantics)"
+
+	return c
+}
@@ -0,0 +1,65 @@
+package local_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appcli "github.com/mpyw/suve/internal/cli/commands"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/detect"
+	providerlocal "github.com/mpyw/suve/internal/provider/local"
+)
+
+// run executes suve with args against a fresh app and returns its stdout.
+func run(t *testing.T, det detect.Result, args ...string) string {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+
+	app := appcli.MakeAppWithDetect(det)
+	app.Writer = &stdout
+	app.ErrWriter = &stderr
+
+	require.NoError(t, app.Run(t.Context(), append([]string{"suve"}, args...)), stderr.String())
+
+	return stdout.String()
+}
+
+func TestLocalCommands(t *testing.T) {
+	// Cannot use t.Parallel() because subtests use t.Setenv
+	t.Run("param history", func(t *testing.T) {
+		t.Setenv(providerlocal.DirEnvVar, t.TempDir())
+
+		run(t, detect.Result{}, "local", "param", "create", "/app/url", "http://a")
+		run(t, detect.Result{}, "local", "param", "update", "--yes", "/app/url", "http://b")
+
+		assert.Contains(t, run(t, detect.Result{}, "local", "param", "show", "--raw", "/app/url~1"), "http://a")
+		assert.Contains(t, run(t, detect.Result{}, "local", "param", "log", "/app/url"), "Version 2 (current)")
+	})
+
+	t.Run("secret soft delete and restore", func(t *testing.T) {
+		t.Setenv(providerlocal.DirEnvVar, t.TempDir())
+
+		run(t, detect.Result{}, "local", "secret", "create", "db", "pw1")
+		run(t, detect.Result{}, "local", "secret", "update", "--yes", "db", "pw2")
+		assert.Equal(t, "pw1", run(t, detect.Result{}, "local", "secret", "show", "--raw", "db:AWSPREVIOUS"))
+
+		run(t, detect.Result{}, "local", "secret", "delete", "--yes", "db")
+		assert.Empty(t, run(t, detect.Result{}, "local", "secret", "list"))
+
+		run(t, detect.Result{}, "local", "secret", "restore", "db")
+		assert.Equal(t, "db\n", run(t, detect.Result{}, "local", "secret", "list"))
+	})
+
+	t.Run("flat aliases", func(t *testing.T) {
+		t.Setenv(providerlocal.DirEnvVar, t.TempDir())
+
+		det := detect.Result{Param: provider.ProviderLocal, Secret: provider.ProviderLocal, Stage: provider.ProviderLocal}
+
+		run(t, det, "param", "create", "/flat", "v")
+		assert.Equal(t, "/flat\n", run(t, det, "param", "list"))
+	})
+}
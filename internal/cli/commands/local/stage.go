@@ -0,0 +1,128 @@
+package local
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/apply"
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/diff"
+	stageparam "github.com/mpyw/suve/internal/cli/commands/aws/stage/param"
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/reset"
+	stagesecret "github.com/mpyw/suve/internal/cli/commands/aws/stage/secret"
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/status"
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	stgcli "github.com/mpyw/suve/internal/staging/cli"
+)
+
+// stageConfigs returns the AWS staging configs re-keyed to the local scope.
+// The strategies follow the context, so only the ScopeResolver changes.
+func stageConfigs() (stgcli.CommandConfig, stgcli.CommandConfig) {
+	paramCfg := stageparam.Config()
+	paramCfg.ScopeResolver = cliinternal.LocalStagingScopeResolver
+
+	secretCfg := stagesecret.Config()
+	secretCfg.ScopeResolver = cliinternal.LocalStagingScopeResolver
+
+	return paramCfg, secretCfg
+}
+
+// stageGroup is the "param" or "secret" staging subgroup.
+func stageGroup(cfg stgcli.CommandConfig, aliases []string, usage string) *cli.Command {
+	return &cli.Command{
+		Name:    cfg.CommandName,
+		Aliases: aliases,
+		Usage:   usage,
+		Commands: []*cli.Command{
+			stgcli.NewAddCommand(cfg),
+			stgcli.NewEditCommand(cfg),
+			stgcli.NewDeleteCommand(cfg),
+			stgcli.NewStatusCommand(cfg),
+			stgcli.NewDiffCommand(cfg),
+			stgcli.NewApplyCommand(cfg),
+			stgcli.NewResetCommand(cfg),
+			stgcli.NewResolveCommand(cfg),
+			stgcli.NewTagCommand(cfg),
+			stgcli.NewUntagCommand(cfg),
+			stgcli.NewExportCommand(cfg),
+			stgcli.NewImportCommand(cfg),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// stageDescription is shared by the grouped and flat forms of the command.
+const stageDescription = `Stage changes locally before applying them to the local stores.
+
+Use 'suve local stage param' for parameters.
+Use 'suve local stage secret' for secrets.
+
+Global commands operate on the staged changes of both services:
+   status    Show all staged changes (params and secrets)
+   diff      Show diff of all staged changes vs the local stores
+   plan      Capture staged changes into a reviewable plan file
+   apply     Apply all staged changes to the local stores
+   reset     Unstage all changes
+   resolve   Merge remote changes into conflicting staged changes
+   switch    Switch the active changeset (-c to create one)
+   branch    List or delete changesets
+   move      Move a staged change to another changeset
+   stash     Shelve staged changes and restore them later
+   history   List past applies
+   undo      Stage the changes that reverse an apply
+   export    Export staged changes to a directory (one file per service)
+   import    Import staged changes from a directory
+
+EXAMPLES:
+   suve local stage param add /app/LOG_LEVEL   Stage a new parameter
+   suve local stage secret edit db-password    Edit and stage a secret
+   suve local stage status                     View all staged changes
+   suve local stage diff                       Review staged changes vs the stores
+   suve local stage apply                      Apply all staged changes`
+
+// stageSubcommands builds the stage subgroups and the global commands.
+func stageSubcommands() []*cli.Command {
+	paramCfg, secretCfg := stageConfigs()
+	gcfg := stgcli.LocalGlobalConfig(paramCfg, secretCfg)
+
+	return []*cli.Command{
+		stageGroup(paramCfg, []string{"params"}, "Staging operations for local parameters"),
+		stageGroup(secretCfg, []string{"secrets"}, "Staging operations for local secrets"),
+		status.Command(gcfg),
+		diff.Command(gcfg),
+		apply.PlanCommand(gcfg),
+		apply.Command(gcfg),
+		reset.Command(gcfg),
+		stgcli.NewGlobalResolveCommand(gcfg),
+		stgcli.NewGlobalSwitchCommand(gcfg),
+		stgcli.NewGlobalBranchCommand(gcfg),
+		stgcli.NewGlobalMoveCommand(gcfg),
+		stgcli.NewGlobalStashCommand(gcfg),
+		stgcli.NewGlobalHistoryCommand(gcfg),
+		stgcli.NewGlobalUndoCommand(gcfg),
+		stgcli.NewGlobalExportCommand(gcfg.ScopeResolver),
+		stgcli.NewGlobalImportCommand(gcfg),
+	}
+}
+
+// StageCommand returns the "local stage" command with the param and secret
+// staging subgroups plus the global commands spanning both services.
+func StageCommand() *cli.Command {
+	return &cli.Command{
+		Name:            "stage",
+		Aliases:         []string{"stg"},
+		Usage:           "Manage staged changes for the local provider",
+		Description:     stageDescription,
+		Commands:        stageSubcommands(),
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// FlatStageCommand returns the local stage command as a standalone top-level
+// command named `name` (e.g. "stage"), carrying the local Before hook itself.
+// Used for the flat `suve stage` alias when the local provider is selected.
+func FlatStageCommand(name string) *cli.Command {
+	c := StageCommand()
+	c.Name = name
+	c.Before = resolveScope
+
+	return c
+}
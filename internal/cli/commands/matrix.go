@@ -0,0 +1,542 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/capability"
+	"github.com/mpyw/suve/internal/cli/colors"
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/detect"
+	"github.com/mpyw/suve/internal/timeutil"
+	"github.com/mpyw/suve/internal/tui"
+	"github.com/mpyw/suve/internal/usecase/matrix"
+)
+
+// What the --scopes of `suve matrix` name (its --by values).
+const (
+	matrixByPrefix   = "prefix"
+	matrixByRegion   = "region"
+	matrixByProject  = "project"
+	matrixByVault    = "vault"
+	matrixByProvider = "provider"
+)
+
+// Service names accepted by `suve matrix --service`.
+const (
+	matrixServiceParam  = "param"
+	matrixServiceSecret = "secret"
+)
+
+// Matrix text markers: a key that holds the same value everywhere, one that is
+// missing somewhere or differs, and the cell of a missing key.
+const (
+	matrixEqualMark  = "="
+	matrixDifferMark = "≠"
+	matrixMissing    = "(missing)"
+	matrixError      = "(error)"
+)
+
+// matrixTarget is one column of a matrix: the label shown for it, the scope its
+// store is resolved for, and the name prefix its keys are relative to.
+type matrixTarget struct {
+	label  string
+	scope  provider.Scope
+	prefix string
+}
+
+// matrixCommand is `suve matrix`: the same keys compared across scopes. det
+// resolves the provider a --by prefix comparison reads, as for the bare
+// aliases.
+func matrixCommand(det detect.Result) *cli.Command {
+	return &cli.Command{
+		Name:      "matrix",
+		Usage:     "Compare the same keys across scopes",
+		ArgsUsage: "[prefix]",
+		Description: `Show a table of every key under [prefix] against each scope, so
+configuration drift between environments stands out. Each cell shows a short
+SHA-256 of the value (never the value itself) and its last-modified date; a key
+missing from a scope is marked (missing).
+
+--by says what the --scopes name:
+
+  prefix    (default) path segments under [prefix] in one store: /app/ with
+            prod,dev compares /app/prod/... against /app/dev/...
+  region    AWS regions
+  project   Google Cloud projects
+  vault     Azure Key Vaults
+  provider  providers (aws, gcloud, azure, vault, kubernetes, sops, local),
+            each configured from the environment
+
+A --by prefix comparison reads the provider the bare param/secret aliases
+target (see --provider). The service defaults to param where every scope offers
+it and secret otherwise.
+
+With --tui the matrix opens in the TUI, where enter diffs a key between the
+first scope and the selected one.
+
+EXAMPLES:
+  suve matrix /app/ --scopes prod,staging,dev                 Compare three environments
+  suve matrix /app/config/ --by region --scopes us-east-1,eu-west-1 --differ
+                                                              Only keys that drifted between regions
+  suve matrix --by project --scopes app-dev,app-prod          Compare two Google Cloud projects
+  suve matrix --by provider --scopes aws,local --service secret
+                                                              Compare AWS secrets with the local store
+  suve matrix /app/ --scopes prod,dev --output=json           Output as JSON for scripting`,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:     "scopes",
+				Usage:    "Scopes to compare (comma-separated, at least two)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "by",
+				Value: matrixByPrefix,
+				Usage: "What the scopes name: prefix, region, project, vault or provider",
+			},
+			&cli.StringFlag{
+				Name:  "service",
+				Usage: "Service to compare: param or secret",
+			},
+			&cli.BoolFlag{
+				Name:  "equal",
+				Usage: "Only show keys that hold the same value in every scope",
+			},
+			&cli.BoolFlag{
+				Name:  "differ",
+				Usage: "Only show keys that are missing from a scope or differ",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Value: "text",
+				Usage: "Output format: text (default) or json",
+			},
+			&cli.BoolFlag{
+				Name:  tuiFlagName,
+				Usage: "Open the matrix in the TUI",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return runMatrix(ctx, cmd, det)
+		},
+	}
+}
+
+// runMatrix is the `suve matrix` action.
+func runMatrix(ctx context.Context, cmd *cli.Command, det detect.Result) error {
+	outputFormat, err := output.ParseFormat(cmd.String("output"))
+	if err != nil {
+		return err
+	}
+
+	filter := matrix.FilterAll
+
+	switch {
+	case cmd.Bool("equal") && cmd.Bool("differ"):
+		return errors.New("--equal and --differ cannot be used together")
+	case cmd.Bool("equal"):
+		filter = matrix.FilterEqual
+	case cmd.Bool("differ"):
+		filter = matrix.FilterDiffer
+	}
+
+	targets, service, err := matrixTargets(det, cmd.String("by"), cmd.Args().First(), splitList(cmd.StringSlice("scopes")), cmd.String("service"))
+	if err != nil {
+		return err
+	}
+
+	kind := provider.KindParam
+	if service == matrixServiceSecret {
+		kind = provider.KindSecret
+	}
+
+	load := func(ctx context.Context, filter matrix.Filter) (*matrix.Output, error) {
+		columns := make([]matrix.Column, 0, len(targets))
+
+		for _, t := range targets {
+			store, err := cliinternal.ScopeStore(ctx, t.scope, kind)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", t.label, err)
+			}
+
+			columns = append(columns, matrix.Column{Scope: t.label, Reader: store, Prefix: t.prefix})
+		}
+
+		return (&matrix.UseCase{}).Execute(ctx, matrix.Input{Columns: columns, Filter: filter})
+	}
+
+	if cmd.Bool(tuiFlagName) {
+		return launchMatrixTUI(ctx, targets[0].scope, service, tui.Matrix{
+			Title:  matrixTitle(cmd.Args().First(), targets),
+			Secret: kind == provider.KindSecret,
+			Filter: filter,
+			Load: func(ctx context.Context) (*matrix.Output, error) {
+				return load(ctx, matrix.FilterAll)
+			},
+		})
+	}
+
+	out, err := load(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == output.FormatJSON {
+		if err := output.WriteJSON(cmd.Root().Writer, matrixJSON(out)); err != nil {
+			return err
+		}
+	} else {
+		writeMatrixText(cmd.Root().Writer, out)
+	}
+
+	return matrixErrors(cmd.Root().ErrWriter, out)
+}
+
+// matrixTitle names a matrix for the TUI page: the prefix and the scopes.
+func matrixTitle(prefix string, targets []matrixTarget) string {
+	labels := make([]string, len(targets))
+	for i, t := range targets {
+		labels[i] = t.label
+	}
+
+	title := "Matrix " + strings.Join(labels, " · ")
+	if prefix != "" {
+		title += " under " + prefix
+	}
+
+	return title
+}
+
+// matrixTargets resolves --by, [prefix] and --scopes into the matrix columns,
+// and the service they are compared in.
+func matrixTargets(det detect.Result, by, prefix string, scopes []string, service string) ([]matrixTarget, string, error) {
+	if len(scopes) < 2 { //nolint:mnd // a comparison needs two sides
+		return nil, "", errors.New("--scopes needs at least two scopes")
+	}
+
+	if service != "" && service != matrixServiceParam && service != matrixServiceSecret {
+		return nil, "", fmt.Errorf("unknown service %q: use param or secret", service)
+	}
+
+	providers := make([]provider.Provider, len(scopes))
+
+	switch by {
+	case matrixByProvider:
+		for i, s := range scopes {
+			p, err := detect.ParseProvider(s)
+			if err != nil {
+				return nil, "", err
+			}
+
+			providers[i] = p
+		}
+	case matrixByPrefix, matrixByRegion, matrixByProject, matrixByVault:
+		p, err := matrixBaseProvider(det, by, service)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for i := range providers {
+			providers[i] = p
+		}
+	default:
+		return nil, "", fmt.Errorf("unknown --by %q: use prefix, region, project, vault or provider", by)
+	}
+
+	service = matrixService(providers, by, service)
+
+	targets := make([]matrixTarget, len(scopes))
+
+	for i, s := range scopes {
+		if !offersService(providers[i], service) {
+			return nil, "", fmt.Errorf("%s has no %s service", groupName(providers[i]), service)
+		}
+
+		scope := cliinternal.HydrateScope(provider.Scope{Provider: providers[i]})
+		targetPrefix := matrixPrefix(prefix, "")
+
+		switch by {
+		case matrixByPrefix:
+			targetPrefix = matrixPrefix(prefix, s)
+		case matrixByRegion:
+			scope.Region = s
+		case matrixByProject:
+			scope.ProjectID = s
+		case matrixByVault:
+			scope.VaultName = s
+		}
+
+		if err := cliinternal.ValidateScope(scope); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", s, err)
+		}
+
+		targets[i] = matrixTarget{label: s, scope: scope, prefix: targetPrefix}
+	}
+
+	return targets, service, nil
+}
+
+// matrixBaseProvider is the provider a single-provider comparison reads: the
+// one that owns the axis, or for --by prefix the provider the bare alias of the
+// service targets.
+func matrixBaseProvider(det detect.Result, by, service string) (provider.Provider, error) {
+	switch by {
+	case matrixByRegion:
+		return provider.ProviderAWS, nil
+	case matrixByProject:
+		return provider.ProviderGoogleCloud, nil
+	case matrixByVault:
+		return provider.ProviderAzure, nil
+	}
+
+	p := det.Param
+	if service == matrixServiceSecret || (service == "" && p == "") {
+		p = det.Secret
+	}
+
+	if p == "" {
+		return "", errors.New("no single provider is active for a --by prefix comparison: name one with --provider (e.g. 'suve --provider aws matrix ...')")
+	}
+
+	return p, nil
+}
+
+// matrixService is the service compared: the requested one, secret for Key
+// Vaults, or param where every provider offers it and secret otherwise.
+func matrixService(providers []provider.Provider, by, service string) string {
+	switch {
+	case service != "":
+		return service
+	case by == matrixByVault:
+		return matrixServiceSecret
+	}
+
+	for _, p := range providers {
+		if !offersService(p, matrixServiceParam) {
+			return matrixServiceSecret
+		}
+	}
+
+	return matrixServiceParam
+}
+
+// offersService reports whether the capability matrix lists service for p.
+func offersService(p provider.Provider, service string) bool {
+	for _, pc := range capability.All() {
+		if pc.Provider != string(p) {
+			continue
+		}
+
+		return slices.ContainsFunc(pc.Services, func(sc capability.ServiceCapability) bool {
+			return sc.Service == service
+		})
+	}
+
+	return false
+}
+
+// matrixPrefix joins the [prefix] argument and a --by prefix scope into the
+// name prefix of a column. A path-style prefix (leading "/") names a
+// directory, so it gains a trailing "/": "/app/" and "prod" give "/app/prod/".
+func matrixPrefix(prefix, scope string) string {
+	p := prefix
+	if scope != "" {
+		if strings.HasPrefix(p, "/") && !strings.HasSuffix(p, "/") {
+			p += "/"
+		}
+
+		p += scope
+	}
+
+	if strings.HasPrefix(p, "/") && !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+
+	return p
+}
+
+// splitList flattens comma-separated flag values, trimmed and de-duplicated in
+// order.
+func splitList(values []string) []string {
+	var out []string
+
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" && !slices.Contains(out, part) {
+				out = append(out, part)
+			}
+		}
+	}
+
+	return out
+}
+
+// launchMatrixTUI opens the matrix in the TUI over the first scope, with the
+// same TTY guard and AWS credential setup as a --tui launch.
+func launchMatrixTUI(ctx context.Context, scope provider.Scope, service string, m tui.Matrix) error {
+	if err := requireTUITTY(); err != nil {
+		return err
+	}
+
+	if scope.Provider == provider.ProviderAWS {
+		ctx = awsTUIContext(ctx, scope)
+	}
+
+	return tui.RunMatrix(ctx, scope, service, m)
+}
+
+// writeMatrixText renders the matrix as an aligned table: a marker column
+// (= same everywhere, ≠ missing somewhere or different), the key, then a
+// "hash date" cell per scope. Missing cells are highlighted.
+func writeMatrixText(w io.Writer, out *matrix.Output) {
+	p := colors.For(w)
+
+	keyWidth := len("KEY")
+	for _, r := range out.Rows {
+		keyWidth = max(keyWidth, len([]rune(r.Key)))
+	}
+
+	cellWidth := matrix.HashLength + len(" 2006-01-02")
+	for _, s := range out.Scopes {
+		cellWidth = max(cellWidth, len([]rune(s)))
+	}
+
+	header := []string{" ", pad("KEY", keyWidth)}
+	for _, s := range out.Scopes {
+		header = append(header, pad(s, cellWidth))
+	}
+
+	output.Println(w, p.FieldLabel(strings.TrimRight(strings.Join(header, "  "), " ")))
+
+	for _, r := range out.Rows {
+		mark := p.Success(matrixEqualMark)
+		if !r.Equal() {
+			mark = p.Warning(matrixDifferMark)
+		}
+
+		line := []string{mark, pad(r.Key, keyWidth)}
+
+		for _, c := range r.Cells {
+			switch {
+			case c.Error != nil:
+				line = append(line, p.Warning(pad(matrixError, cellWidth)))
+			case !c.Present:
+				line = append(line, p.Error(pad(matrixMissing, cellWidth)))
+			default:
+				line = append(line, pad(matrixCellText(c), cellWidth))
+			}
+		}
+
+		output.Println(w, strings.TrimRight(strings.Join(line, "  "), " "))
+	}
+}
+
+// matrixCellText is a present cell's "hash date" text.
+func matrixCellText(c matrix.Cell) string {
+	if c.Modified == nil {
+		return c.Hash
+	}
+
+	return c.Hash + " " + timeutil.FormatDate(*c.Modified)
+}
+
+// pad right-pads s with spaces to width runes.
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-len([]rune(s)), 0))
+}
+
+// matrixErrors reports the scopes that could not be listed and the values that
+// could not be read on w, and returns an error when there were any.
+func matrixErrors(w io.Writer, out *matrix.Output) error {
+	failed := 0
+
+	for _, e := range out.Errors {
+		output.Warning(w, "%s: %v", e.Scope, e.Error)
+
+		failed++
+	}
+
+	for _, r := range out.Rows {
+		for i, c := range r.Cells {
+			if c.Present && c.Error != nil {
+				output.Warning(w, "%s: %v", out.Scopes[i], c.Error)
+
+				failed++
+			}
+		}
+	}
+
+	if failed == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("matrix incomplete: %d error(s)", failed)
+}
+
+// matrixJSONOutput is the JSON shape of a matrix.
+type matrixJSONOutput struct {
+	Scopes []string          `json:"scopes"`
+	Rows   []matrixJSONRow   `json:"rows"`
+	Errors []matrixJSONError `json:"errors,omitempty"`
+}
+
+// matrixJSONRow is one key across the scopes.
+type matrixJSONRow struct {
+	Key   string           `json:"key"`
+	Equal bool             `json:"equal"`
+	Cells []matrixJSONCell `json:"cells"`
+}
+
+// matrixJSONCell is one key in one scope.
+type matrixJSONCell struct {
+	Scope    string `json:"scope"`
+	Present  bool   `json:"present"`
+	Hash     string `json:"hash,omitempty"`
+	Modified string `json:"modified,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// matrixJSONError is a scope that could not be listed.
+type matrixJSONError struct {
+	Scope string `json:"scope"`
+	Error string `json:"error"`
+}
+
+// matrixJSON maps a matrix onto its JSON shape.
+func matrixJSON(out *matrix.Output) matrixJSONOutput {
+	j := matrixJSONOutput{Scopes: out.Scopes, Rows: []matrixJSONRow{}}
+
+	for _, r := range out.Rows {
+		row := matrixJSONRow{Key: r.Key, Equal: r.Equal()}
+
+		for i, c := range r.Cells {
+			cell := matrixJSONCell{Scope: out.Scopes[i], Present: c.Present, Hash: c.Hash}
+
+			if c.Modified != nil {
+				cell.Modified = timeutil.FormatRFC3339(*c.Modified)
+			}
+
+			if c.Error != nil {
+				cell.Error = c.Error.Error()
+			}
+
+			row.Cells = append(row.Cells, cell)
+		}
+
+		j.Rows = append(j.Rows, row)
+	}
+
+	for _, e := range out.Errors {
+		j.Errors = append(j.Errors, matrixJSONError{Scope: e.Scope, Error: e.Error.Error()})
+	}
+
+	return j
+}
@@ -0,0 +1,189 @@
+//nolint:testpackage // white-box: exercises the unexported target resolution and renderers
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/detect"
+	"github.com/mpyw/suve/internal/usecase/matrix"
+)
+
+func TestMatrixPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		prefix, scope, want string
+	}{
+		{prefix: "/app/", scope: "prod", want: "/app/prod/"},
+		{prefix: "/app", scope: "prod", want: "/app/prod/"},
+		{prefix: "/app", want: "/app/"},
+		{prefix: "app-", scope: "prod", want: "app-prod"},
+		{scope: "prod", want: "prod"},
+		{},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matrixPrefix(tt.prefix, tt.scope), "%q + %q", tt.prefix, tt.scope)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"prod", "staging", "dev"}, splitList([]string{"prod, staging", "dev,prod", ""}))
+}
+
+func TestMatrixTargets(t *testing.T) {
+	t.Parallel()
+
+	aws := detect.Result{Param: provider.ProviderAWS, Secret: provider.ProviderAWS}
+
+	t.Run("prefix", func(t *testing.T) {
+		t.Parallel()
+
+		targets, service, err := matrixTargets(aws, matrixByPrefix, "/app", []string{"prod", "dev"}, "")
+		require.NoError(t, err)
+		assert.Equal(t, matrixServiceParam, service)
+		require.Len(t, targets, 2)
+		assert.Equal(t, "/app/prod/", targets[0].prefix)
+		assert.Equal(t, "/app/dev/", targets[1].prefix)
+		assert.Equal(t, provider.ProviderAWS, targets[0].scope.Provider)
+	})
+
+	t.Run("region", func(t *testing.T) {
+		t.Parallel()
+
+		targets, service, err := matrixTargets(detect.Result{}, matrixByRegion, "/app/", []string{"us-east-1", "eu-west-1"}, "secret")
+		require.NoError(t, err)
+		assert.Equal(t, matrixServiceSecret, service)
+		assert.Equal(t, "eu-west-1", targets[1].scope.Region)
+		assert.Equal(t, "/app/", targets[1].prefix)
+	})
+
+	t.Run("vault defaults to secret", func(t *testing.T) {
+		t.Parallel()
+
+		targets, service, err := matrixTargets(detect.Result{}, matrixByVault, "", []string{"kv-prod", "kv-dev"}, "")
+		require.NoError(t, err)
+		assert.Equal(t, matrixServiceSecret, service)
+		assert.Equal(t, "kv-dev", targets[1].scope.VaultName)
+	})
+
+	tests := []struct {
+		name    string
+		det     detect.Result
+		by      string
+		scopes  []string
+		service string
+		wantErr string
+	}{
+		{name: "one scope", det: aws, by: matrixByPrefix, scopes: []string{"prod"}, wantErr: "at least two scopes"},
+		{name: "unknown axis", det: aws, by: "zone", scopes: []string{"a", "b"}, wantErr: "unknown --by"},
+		{name: "unknown service", det: aws, by: matrixByPrefix, scopes: []string{"a", "b"}, service: "blob", wantErr: "unknown service"},
+		{name: "no provider", by: matrixByPrefix, scopes: []string{"a", "b"}, wantErr: "--provider"},
+		{name: "service not offered", by: matrixByProject, scopes: []string{"a", "b"}, service: "param", wantErr: "has no param service"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := matrixTargets(tt.det, tt.by, "/app/", tt.scopes, tt.service)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// matrixFixture is a two-scope matrix: db-url differs, debug is missing from
+// prod, and log-level agrees.
+func matrixFixture() *matrix.Output {
+	modified := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	cell := func(hash string) matrix.Cell {
+		return matrix.Cell{Present: true, Hash: hash, Modified: &modified}
+	}
+
+	return &matrix.Output{
+		Scopes: []string{"prod", "dev"},
+		Rows: []matrix.Row{
+			{Key: "db-url", Cells: []matrix.Cell{cell("aaaaaaaaaaaa"), cell("bbbbbbbbbbbb")}},
+			{Key: "debug", Cells: []matrix.Cell{{}, cell("cccccccccccc")}},
+			{Key: "log-level", Cells: []matrix.Cell{cell("dddddddddddd"), cell("dddddddddddd")}},
+		},
+	}
+}
+
+func TestWriteMatrixText(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	writeMatrixText(&buf, matrixFixture())
+
+	assert.Equal(t,
+		"   KEY        prod                     dev\n"+
+			"≠  db-url     aaaaaaaaaaaa 2024-01-15  bbbbbbbbbbbb 2024-01-15\n"+
+			"≠  debug      (missing)                cccccccccccc 2024-01-15\n"+
+			"=  log-level  dddddddddddd 2024-01-15  dddddddddddd 2024-01-15\n",
+		buf.String())
+}
+
+func TestMatrixJSON(t *testing.T) {
+	t.Parallel()
+
+	out := matrixFixture()
+	out.Errors = []matrix.ScopeError{{Scope: "dev", Error: errors.New("access denied")}}
+
+	b, err := json.Marshal(matrixJSON(out))
+	require.NoError(t, err)
+
+	var got struct {
+		Scopes []string `json:"scopes"`
+		Rows   []struct {
+			Key   string `json:"key"`
+			Equal bool   `json:"equal"`
+			Cells []struct {
+				Scope    string `json:"scope"`
+				Present  bool   `json:"present"`
+				Modified string `json:"modified"`
+			} `json:"cells"`
+		} `json:"rows"`
+		Errors []struct {
+			Scope string `json:"scope"`
+			Error string `json:"error"`
+		} `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(b, &got))
+
+	assert.Equal(t, []string{"prod", "dev"}, got.Scopes)
+	require.Len(t, got.Rows, 3)
+	assert.False(t, got.Rows[1].Equal)
+	assert.False(t, got.Rows[1].Cells[0].Present)
+	assert.True(t, got.Rows[2].Equal)
+	assert.Equal(t, "2024-01-15T10:30:00Z", got.Rows[2].Cells[1].Modified)
+	require.Len(t, got.Errors, 1)
+	assert.Equal(t, "access denied", got.Errors[0].Error)
+}
+
+func TestMatrixErrors(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, matrixErrors(&buf, matrixFixture()))
+	assert.Empty(t, buf.String())
+
+	out := matrixFixture()
+	out.Errors = []matrix.ScopeError{{Scope: "dev", Error: errors.New("access denied")}}
+
+	require.EqualError(t, matrixErrors(&buf, out), "matrix incomplete: 1 error(s)")
+	assert.Contains(t, buf.String(), "dev: access denied")
+}
@@ -0,0 +1,79 @@
+// Package plugin provides the command group of an external provider plugin:
+// "suve <name> param|secret|stage" for each suve-provider-<name> executable
+// found on PATH (see internal/provider/plugin).
+//
+// A plugin implements the provider contracts over JSON-RPC and reports what it
+// offers in a handshake, so the group reuses the AWS command trees the way the
+// local provider does: its Before hook names the plugin in the context (see
+// cliinternal.WithPlugin) and every store and strategy the AWS commands resolve
+// is then the plugin's. Version specs are passed to the plugin verbatim, with
+// the AWS Parameter Store (param) and Secrets Manager (secret) syntax.
+package plugin
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	awsparam "github.com/mpyw/suve/internal/cli/commands/aws/param"
+	awssecret "github.com/mpyw/suve/internal/cli/commands/aws/secret"
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	providerplugin "github.com/mpyw/suve/internal/provider/plugin"
+)
+
+// metadataKey marks a plugin group in cli.Command.Metadata, holding the
+// plugin's name.
+const metadataKey = "plugin"
+
+// Command returns the command group of plugin p, named after it.
+func Command(p providerplugin.Plugin) *cli.Command {
+	return &cli.Command{
+		Name:  p.Name,
+		Usage: "Interact with the " + p.Name + " plugin (" + p.Path + ")",
+		Description: `Interact with the external provider implemented by ` + providerplugin.ExecutablePrefix + p.Name + `.
+
+  - "suve ` + p.Name + ` param"  works like AWS Systems Manager Parameter Store.
+  - "suve ` + p.Name + ` secret" works like AWS Secrets Manager.
+  - "suve ` + p.Name + ` stage"  stages changes to the above before applying them.
+
+The plugin decides which of param and secret it offers, and what version
+specifiers (#VERSION, :LABEL, ~SHIFT) mean for its store.`,
+		Metadata: map[string]any{metadataKey: p.Name},
+		Before:   resolveScope,
+		Commands: []*cli.Command{
+			paramCommand(p.Name),
+			secretCommand(p.Name),
+			StageCommand(p.Name),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// Name returns the plugin a command group was built for by Command, or "".
+func Name(cmd *cli.Command) string {
+	name, _ := cmd.Metadata[metadataKey].(string)
+
+	return name
+}
+
+// resolveScope names the group's plugin in the context for the subcommands.
+// The plugin itself runs only when a store is used, so help works without it.
+func resolveScope(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	return cliinternal.WithPlugin(ctx, Name(cmd)), nil
+}
+
+// paramCommand is the AWS Parameter Store command tree, relabelled.
+func paramCommand(name string) *cli.Command {
+	c := awsparam.Command()
+	c.Usage = "Interact with the " + name + " plugin's parameters"
+
+	return c
+}
+
+// secretCommand is the AWS Secrets Manager command tree, relabelled.
+func secretCommand(name string) *cli.Command {
+	c := awssecret.Command()
+	c.Usage = "Interact with the " + name + " plugin's secrets"
+
+	return c
+}
@@ -0,0 +1,138 @@
+package plugin_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/capability"
+	appcli "github.com/mpyw/suve/internal/cli/commands"
+	"github.com/mpyw/suve/internal/cli/commands/plugin"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/detect"
+	"github.com/mpyw/suve/internal/provider/local/jsonstore"
+	providerplugin "github.com/mpyw/suve/internal/provider/plugin"
+)
+
+// helperDirEnv carries the store directory to the helper plugin process; its
+// presence is what turns TestHelperPlugin into a plugin.
+const helperDirEnv = "SUVE_PLUGIN_TEST_DIR"
+
+// TestHelperPlugin is not a test: run by the wrapper script installPlugin
+// writes, it serves a secret-only plugin over a jsonstore store on stdio.
+func TestHelperPlugin(t *testing.T) {
+	dir := os.Getenv(helperDirEnv)
+	if dir == "" {
+		t.Skip("helper process for the plugin tests")
+	}
+
+	backend := providerplugin.Backend{
+		Capability: capability.ProviderCapability{
+			DisplayName: "Demo",
+			Services: []capability.ServiceCapability{
+				{Service: "secret", DisplayName: "Secret", HasVersionHistory: true, HasStaging: true},
+			},
+		},
+		Stores: map[provider.Kind]provider.Store{
+			provider.KindSecret: jsonstore.NewSecretStore(filepath.Join(dir, "secret.json")),
+		},
+	}
+
+	if err := providerplugin.Serve(t.Context(), backend, os.Stdin, os.Stdout); err != nil {
+		_, _ = os.Stderr.WriteString(err.Error() + "\n")
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+// installPlugin puts suve-provider-<name> wrappers that run TestHelperPlugin
+// on PATH, backed by a fresh store directory, with staging kept in a temp HOME.
+func installPlugin(t *testing.T, names ...string) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the helper plugin is a shell script")
+	}
+
+	bin := t.TempDir()
+	script := "#!/bin/sh\nexec '" + os.Args[0] + "' -test.run='^TestHelperPlugin$'\n"
+
+	for _, name := range names {
+		path := filepath.Join(bin, providerplugin.ExecutablePrefix+name)
+		require.NoError(t, os.WriteFile(path, []byte(script), 0o755)) //nolint:gosec // test executable
+	}
+
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv(helperDirEnv, t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SUVE_STAGING_KEY", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+}
+
+// run executes suve with args against a fresh app and returns its stdout.
+func run(t *testing.T, args ...string) string {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+
+	app := appcli.MakeAppWithDetect(detect.Result{})
+	app.Writer = &stdout
+	app.ErrWriter = &stderr
+
+	require.NoError(t, app.Run(t.Context(), append([]string{"suve"}, args...)), stderr.String())
+
+	return stdout.String()
+}
+
+// find returns the top-level command called name, or nil.
+func find(app *cli.Command, name string) *cli.Command {
+	for _, c := range app.Commands {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func TestPluginCommands(t *testing.T) {
+	// Cannot use t.Parallel() because subtests use t.Setenv
+	t.Run("discovery skips builtin names", func(t *testing.T) {
+		installPlugin(t, "demo", "aws")
+
+		app := appcli.MakeAppWithDetect(detect.Result{})
+
+		demo := find(app, "demo")
+		require.NotNil(t, demo)
+		assert.Equal(t, "demo", plugin.Name(demo))
+		assert.Empty(t, plugin.Name(find(app, "aws")))
+	})
+
+	t.Run("secret history", func(t *testing.T) {
+		installPlugin(t, "demo")
+
+		run(t, "demo", "secret", "create", "db", "pw1")
+		run(t, "demo", "secret", "update", "--yes", "db", "pw2")
+
+		assert.Equal(t, "pw1", run(t, "demo", "secret", "show", "--raw", "db:AWSPREVIOUS"))
+		assert.Equal(t, "db\n", run(t, "demo", "secret", "list"))
+	})
+
+	t.Run("staging skips services the plugin does not offer", func(t *testing.T) {
+		installPlugin(t, "demo")
+
+		run(t, "demo", "secret", "create", "db", "pw1")
+		run(t, "demo", "stage", "secret", "delete", "db")
+		assert.Contains(t, run(t, "demo", "stage", "status"), "db")
+
+		run(t, "demo", "stage", "apply", "--yes")
+		assert.Empty(t, run(t, "demo", "secret", "list"))
+	})
+}
@@ -0,0 +1,109 @@
+package plugin
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/apply"
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/diff"
+	stageparam "github.com/mpyw/suve/internal/cli/commands/aws/stage/param"
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/reset"
+	stagesecret "github.com/mpyw/suve/internal/cli/commands/aws/stage/secret"
+	"github.com/mpyw/suve/internal/cli/commands/aws/stage/status"
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/provider"
+	stgcli "github.com/mpyw/suve/internal/staging/cli"
+)
+
+// stageConfigs returns the AWS staging configs re-keyed to the plugin scope.
+// The strategies follow the context, so only the ScopeResolvers change; each
+// service resolves on its own so one the plugin does not offer is skipped.
+func stageConfigs() (stgcli.CommandConfig, stgcli.CommandConfig) {
+	paramCfg := stageparam.Config()
+	paramCfg.ScopeResolver = cliinternal.PluginStagingScopeResolver(provider.KindParam)
+
+	secretCfg := stagesecret.Config()
+	secretCfg.ScopeResolver = cliinternal.PluginStagingScopeResolver(provider.KindSecret)
+
+	return paramCfg, secretCfg
+}
+
+// stageGroup is the "param" or "secret" staging subgroup.
+func stageGroup(cfg stgcli.CommandConfig, aliases []string, usage string) *cli.Command {
+	return &cli.Command{
+		Name:    cfg.CommandName,
+		Aliases: aliases,
+		Usage:   usage,
+		Commands: []*cli.Command{
+			stgcli.NewAddCommand(cfg),
+			stgcli.NewEditCommand(cfg),
+			stgcli.NewDeleteCommand(cfg),
+			stgcli.NewStatusCommand(cfg),
+			stgcli.NewDiffCommand(cfg),
+			stgcli.NewApplyCommand(cfg),
+			stgcli.NewResetCommand(cfg),
+			stgcli.NewResolveCommand(cfg),
+			stgcli.NewTagCommand(cfg),
+			stgcli.NewUntagCommand(cfg),
+			stgcli.NewExportCommand(cfg),
+			stgcli.NewImportCommand(cfg),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// stageDescription describes the stage group of the plugin called name.
+func stageDescription(name string) string {
+	return `Stage changes locally before applying them through the ` + name + ` plugin.
+
+Use 'suve ` + name + ` stage param' for parameters.
+Use 'suve ` + name + ` stage secret' for secrets.
+
+Global commands operate on the staged changes of every service the plugin offers:
+   status    Show all staged changes
+   diff      Show diff of all staged changes vs the plugin's stores
+   plan      Capture staged changes into a reviewable plan file
+   apply     Apply all staged changes
+   reset     Unstage all changes
+   resolve   Merge remote changes into conflicting staged changes
+   switch    Switch the active changeset (-c to create one)
+   branch    List or delete changesets
+   move      Move a staged change to another changeset
+   stash     Shelve staged changes and restore them later
+   history   List past applies
+   undo      Stage the changes that reverse an apply
+   export    Export staged changes to a directory (one file per service)
+   import    Import staged changes from a directory`
+}
+
+// StageCommand returns the stage command of the plugin called name, with the
+// param and secret staging subgroups plus the global commands.
+func StageCommand(name string) *cli.Command {
+	paramCfg, secretCfg := stageConfigs()
+	gcfg := stgcli.PluginGlobalConfig(name, cliinternal.PluginStagingScopeResolver(""), paramCfg, secretCfg)
+
+	return &cli.Command{
+		Name:        "stage",
+		Aliases:     []string{"stg"},
+		Usage:       "Manage staged changes for the " + name + " plugin",
+		Description: stageDescription(name),
+		Commands: []*cli.Command{
+			stageGroup(paramCfg, []string{"params"}, "Staging operations for the plugin's parameters"),
+			stageGroup(secretCfg, []string{"secrets"}, "Staging operations for the plugin's secrets"),
+			status.Command(gcfg),
+			diff.Command(gcfg),
+			apply.PlanCommand(gcfg),
+			apply.Command(gcfg),
+			reset.Command(gcfg),
+			stgcli.NewGlobalResolveCommand(gcfg),
+			stgcli.NewGlobalSwitchCommand(gcfg),
+			stgcli.NewGlobalBranchCommand(gcfg),
+			stgcli.NewGlobalMoveCommand(gcfg),
+			stgcli.NewGlobalStashCommand(gcfg),
+			stgcli.NewGlobalHistoryCommand(gcfg),
+			stgcli.NewGlobalUndoCommand(gcfg),
+			stgcli.NewGlobalExportCommand(gcfg.ScopeResolver),
+			stgcli.NewGlobalImportCommand(gcfg),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
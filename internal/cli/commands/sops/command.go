@@ -0,0 +1,117 @@
+// Package sops provides CLI commands for the leaf keys of a SOPS-encrypted
+// file, exposed as the "suve sops secret <op>" command group plus the
+// "suve sops stage <op>" staging workflow.
+//
+// SOPS is secret-only (no parameter store). The read/write commands (show, log,
+// list, diff, create, update, delete) and the staging commands reuse the same
+// generic scaffolding as the other provider groups via SOPS-specific
+// presenters, use cases, and staging strategy. Keys carry no tags, so there
+// are no tag/untag commands.
+package sops
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+)
+
+// nounSecret is the command name used across the SOPS secret commands.
+const nounSecret = "secret"
+
+// nounKey is the word used for a SOPS entry in messages.
+const nounKey = "key"
+
+// Command returns the sops command with the secret subcommand group.
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "sops",
+		Usage: "Interact with the keys of a SOPS-encrypted file",
+		Description: `Interact with the leaf keys of a SOPS file encrypted with age.
+
+Each leaf of the YAML or JSON document is one entry, named by its dotted path
+(e.g. db.password; list items use their index, e.g. hosts.0). Set the file with
+--file or the SUVE_SOPS_FILE environment variable.
+
+Versions come from the file's git history: #COMMIT selects the value as
+committed at a commit (any unambiguous prefix) and ~SHIFT counts back over the
+commits that changed the key. An uncommitted change shows up as the
+"worktree" version.
+
+Age identities are read the way the sops CLI reads them: SOPS_AGE_KEY, then
+SOPS_AGE_KEY_FILE, then $XDG_CONFIG_HOME/sops/age/keys.txt. Writes re-encrypt
+the file to its existing recipients; a new file is encrypted to the
+comma-separated recipients in SOPS_AGE_RECIPIENTS.`,
+		Flags: scopeFlags(),
+		// Before stashes the flag in the context so the generic command
+		// presenters (which do not receive *cli.Command) can resolve a store.
+		// The file is read only when a store is used, so
+		// `suve sops secret --help` works without one.
+		Before: resolveScope,
+		Commands: []*cli.Command{
+			SecretCommand(),
+			StageCommand(),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// FlatSecretCommand returns the SOPS secret command as a standalone top-level
+// command named `name` (e.g. "secret"). Because there is no parent sops group
+// to carry them, it folds in the --file flag and the scope-resolving Before
+// hook. Used for the flat `suve secret` alias when SOPS is the uniquely active
+// secret provider.
+func FlatSecretCommand(name string) *cli.Command {
+	c := SecretCommand()
+	c.Name = name
+	c.Flags = scopeFlags()
+	c.Before = resolveScope
+
+	return c
+}
+
+// scopeFlags returns the --file flag (a fresh slice per call so each command
+// owns its flag instances).
+func scopeFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "file",
+			Usage: "SOPS file to use (defaults to $SUVE_SOPS_FILE)",
+		},
+	}
+}
+
+// resolveScope stashes the --file flag into the context for the subcommands.
+func resolveScope(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	return cliinternal.WithSOPSFile(ctx, cmd.String("file")), nil
+}
+
+// SecretCommand returns the "sops secret" subcommand group.
+func SecretCommand() *cli.Command {
+	return &cli.Command{
+		Name:    nounSecret,
+		Aliases: []string{"secrets", "key", "keys"},
+		Usage:   "Interact with the keys of a SOPS file",
+		Commands: []*cli.Command{
+			ShowCommand(),
+			LogCommand(),
+			DiffCommand(),
+			ListCommand(),
+			CreateCommand(),
+			UpdateCommand(),
+			DeleteCommand(),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// versionLabel names a key at a version for diff headers: name#version, or the
+// bare name for the working file.
+func versionLabel(name, version string) string {
+	if version == "" {
+		return name
+	}
+
+	return name + "#" + version
+}
@@ -0,0 +1,103 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/usecase/sops"
+)
+
+// CreateRunner executes the create command.
+type CreateRunner struct {
+	UseCase *sops.CreateUseCase
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// CreateOptions holds the options for the create command.
+type CreateOptions struct {
+	Name  string
+	Value string
+}
+
+// CreateCommand returns the SOPS create command.
+func CreateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "create",
+		Usage:     "Create a new key",
+		ArgsUsage: "<key> [<value>]",
+		Description: `Create a new key in the SOPS file and re-encrypt the file.
+
+Use this command for new keys only: it fails when the key already exists. To
+change an existing key, use 'suve sops secret update' instead. Missing parent
+maps along the dotted path are created. When the file itself does not exist
+yet, it is created and encrypted to the recipients in SOPS_AGE_RECIPIENTS.
+
+The value may be given as a positional argument, read from stdin with
+--value-stdin (so it never appears in argv/ps or shell history), or, when
+omitted, typed into $EDITOR.
+
+EXAMPLES:
+   suve sops secret create api.key "sk-12345"                     Create simple key
+   suve sops secret create db.config '{"host":"db"}'              Create JSON-string key
+   printf '%s' "$V" | suve sops secret create api.key --value-stdin  Read value from stdin
+   suve sops secret create api.key                                Type value into $EDITOR`,
+		Flags: []cli.Flag{
+			cliinternal.ValueStdinFlag(),
+		},
+		Action: createAction,
+	}
+}
+
+func createAction(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() < 1 {
+		return errors.New("usage: suve sops secret create <key> [<value>]")
+	}
+
+	value, proceed, err := cliinternal.ResolveValue(ctx, cliinternal.ValueSource{
+		FromStdin: cmd.Bool(cliinternal.FlagValueStdin),
+		HasArg:    args.Len() >= 2, //nolint:mnd // arg 0 is the name, arg 1 is the optional value
+		Arg:       args.Get(1),
+		Stdin:     cliinternal.Stdin(cmd),
+	})
+	if err != nil {
+		return err
+	}
+
+	if !proceed {
+		output.Info(cmd.Root().Writer, "Empty value, nothing to create.")
+
+		return nil
+	}
+
+	store, err := cliinternal.SOPSStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	r := &CreateRunner{
+		UseCase: &sops.CreateUseCase{Writer: store},
+		Stdout:  cmd.Root().Writer,
+		Stderr:  cmd.Root().ErrWriter,
+	}
+
+	return r.Run(ctx, CreateOptions{Name: args.Get(0), Value: value})
+}
+
+// Run executes the create command.
+func (r *CreateRunner) Run(ctx context.Context, opts CreateOptions) error {
+	result, err := r.UseCase.Execute(ctx, sops.CreateInput{Name: opts.Name, Value: opts.Value})
+	if err != nil {
+		return err
+	}
+
+	output.Success(r.Stdout, "Created key %s", result.Name)
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package sops
+
+import (
+	"context"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	genericdiff "github.com/mpyw/suve/internal/cli/commands/generic/diff"
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/usecase/sops"
+	"github.com/mpyw/suve/internal/version/sopsversion"
+)
+
+// diffJSONOutput represents the JSON output structure for the diff command.
+type diffJSONOutput struct {
+	OldName    string `json:"oldName"`
+	OldVersion string `json:"oldVersion"`
+	OldValue   string `json:"oldValue"`
+	NewName    string `json:"newName"`
+	NewVersion string `json:"newVersion"`
+	NewValue   string `json:"newValue"`
+	Identical  bool   `json:"identical"`
+	Diff       string `json:"diff,omitempty"`
+}
+
+// diffPresenter renders SOPS diff output.
+type diffPresenter struct {
+	uc     *sops.DiffUseCase
+	spec1  *sopsversion.Spec
+	spec2  *sopsversion.Spec
+	result *sops.DiffOutput
+}
+
+// NewDiffPresenter builds a SOPS diff presenter over the given reader and specs.
+func NewDiffPresenter(reader provider.Reader, spec1, spec2 *sopsversion.Spec) genericdiff.Presenter {
+	return &diffPresenter{uc: &sops.DiffUseCase{Reader: reader}, spec1: spec1, spec2: spec2}
+}
+
+func (p *diffPresenter) Fetch(ctx context.Context) error {
+	result, err := p.uc.Execute(ctx, sops.DiffInput{Spec1: p.spec1, Spec2: p.spec2})
+	if err != nil {
+		return err
+	}
+
+	p.result = result
+
+	return nil
+}
+
+func (p *diffPresenter) OldValue() string { return p.result.OldValue }
+func (p *diffPresenter) NewValue() string { return p.result.NewValue }
+
+func (p *diffPresenter) Labels() (string, string) {
+	return versionLabel(p.result.OldName, p.result.OldVersion),
+		versionLabel(p.result.NewName, p.result.NewVersion)
+}
+
+func (p *diffPresenter) RenderJSON(stdout io.Writer, oldValue, newValue string, identical bool, diff string) error {
+	jsonOut := diffJSONOutput{
+		OldName:    p.result.OldName,
+		OldVersion: p.result.OldVersion,
+		OldValue:   oldValue,
+		NewName:    p.result.NewName,
+		NewVersion: p.result.NewVersion,
+		NewValue:   newValue,
+		Identical:  identical,
+		Diff:       diff,
+	}
+
+	return output.WriteJSON(stdout, jsonOut)
+}
+
+func (p *diffPresenter) Hints(stderr io.Writer) {
+	output.Hint(stderr, "To compare with the previous version, use: suve sops secret diff %s~1", p.result.OldName)
+}
+
+// DiffCommand returns the SOPS diff command.
+func DiffCommand() *cli.Command {
+	return genericdiff.Command(genericdiff.Config[*sopsversion.Spec]{
+		Usage:     "Show diff between two versions",
+		ArgsUsage: "<spec1> [spec2] | <key> #<commit1> [#<commit2>]",
+		Description: `Compare two versions of a key in unified diff format.
+If only one version/spec is specified, compares against the working file.
+
+VERSION SPECIFIERS:
+  #COMMIT   The value as committed at a git commit (any unambiguous prefix)
+  ~SHIFT    N changes ago; ~ alone means ~1
+
+EXAMPLES:
+  suve sops secret diff db.password~                          Compare previous with working file
+  suve sops secret diff db.password#3f2a9c db.password#8d1e07 Compare two commits
+  suve sops secret diff --parse-json db.password~             Format JSON values before diffing
+  suve sops secret diff --output=json db.password~            Output comparison as JSON`,
+		ParseDiffArgs: sopsversion.ParseDiffArgs,
+		NewPresenter: func(ctx context.Context, spec1, spec2 *sopsversion.Spec) (genericdiff.Presenter, error) {
+			store, err := cliinternal.SOPSStore(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewDiffPresenter(store, spec1, spec2), nil
+		},
+		Store: cliinternal.SOPSStore,
+		Kind:  provider.KindSecret,
+	})
+}
@@ -0,0 +1,79 @@
+package sops
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"github.com/urfave/cli/v3"
+
+	genericlist "github.com/mpyw/suve/internal/cli/commands/generic/list"
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/usecase/sops"
+)
+
+// ListCommand returns the SOPS list command.
+func ListCommand() *cli.Command {
+	return genericlist.Command(genericlist.Config{
+		Usage:     "List keys",
+		ArgsUsage: "[filter-prefix]",
+		Description: `List the leaf keys of the SOPS file, in the file's own order.
+
+Without a filter prefix, lists every leaf key as a dotted path.
+With a filter prefix, lists only keys that start with that prefix.
+
+FILTERING:
+   Use --filter to filter results by regex pattern (client-side).
+
+VALUE DISPLAY:
+   Use --show to decrypt and display values alongside keys.
+   Output format: <key><TAB><value>
+
+EXAMPLES:
+   suve sops secret list                     List all keys
+   suve sops secret list db.                 List keys starting with "db."
+   suve sops secret list --show db.          List with values
+   suve sops secret list --output=json db.   List as JSON`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "filter",
+				Usage: "Filter by regex pattern",
+			},
+			&cli.BoolFlag{
+				Name:  "show",
+				Usage: "Show key values",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Output format: text (default) or json",
+			},
+		},
+		NewList: func(
+			ctx context.Context, cmd *cli.Command, withValue bool,
+		) (func(context.Context) ([]genericlist.Entry, error), error) {
+			store, err := cliinternal.SOPSStore(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			uc := &sops.ListUseCase{Reader: store}
+			input := sops.ListInput{
+				Prefix:    cmd.Args().First(),
+				Filter:    cmd.String("filter"),
+				WithValue: withValue,
+			}
+
+			return func(ctx context.Context) ([]genericlist.Entry, error) {
+				result, err := uc.Execute(ctx, input)
+				if err != nil {
+					return nil, err
+				}
+
+				entries := lo.Map(result.Entries, func(e sops.ListEntry, _ int) genericlist.Entry {
+					return genericlist.Entry{Name: e.Name, Value: e.Value, Error: e.Error}
+				})
+
+				return entries, nil
+			}, nil
+		},
+	})
+}
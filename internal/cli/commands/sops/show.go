@@ -0,0 +1,124 @@
+package sops
+
+import (
+	"context"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	genericshow "github.com/mpyw/suve/internal/cli/commands/generic/show"
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/jsonutil"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/timeutil"
+	"github.com/mpyw/suve/internal/usecase/sops"
+	"github.com/mpyw/suve/internal/version/sopsversion"
+)
+
+// showJSONOutput represents the JSON output structure for the show command.
+type showJSONOutput struct {
+	Name     string `json:"name"`
+	Version  string `json:"version,omitempty"`
+	Modified string `json:"modified,omitempty"`
+	Value    string `json:"value"`
+}
+
+// showPresenter renders SOPS show output.
+type showPresenter struct {
+	uc     *sops.ShowUseCase
+	spec   *sopsversion.Spec
+	result *sops.ShowOutput
+}
+
+// NewShowPresenter builds a SOPS show presenter over the given reader and spec.
+func NewShowPresenter(reader provider.Reader, spec *sopsversion.Spec) genericshow.Presenter {
+	return &showPresenter{uc: &sops.ShowUseCase{Reader: reader}, spec: spec}
+}
+
+func (p *showPresenter) Fetch(ctx context.Context) error {
+	result, err := p.uc.Execute(ctx, sops.ShowInput{Spec: p.spec})
+	if err != nil {
+		return err
+	}
+
+	p.result = result
+
+	return nil
+}
+
+func (p *showPresenter) Value(parseJSON bool, stderr io.Writer) string {
+	value := p.result.Value
+	if parseJSON {
+		value = jsonutil.TryFormatOrWarn(value, stderr, "")
+	}
+
+	return value
+}
+
+func (p *showPresenter) RenderText(stdout io.Writer, value string) {
+	result := p.result
+
+	out := output.New(stdout)
+	out.Field("Name", result.Name)
+
+	if result.Version != "" {
+		out.Field("Commit", result.Version)
+	}
+
+	if result.CreatedDate != nil {
+		out.Field("Modified", timeutil.FormatRFC3339(*result.CreatedDate))
+	}
+
+	out.Separator()
+	out.Value(value)
+}
+
+func (p *showPresenter) RenderJSON(stdout io.Writer, value string) error {
+	result := p.result
+
+	jsonOut := showJSONOutput{
+		Name:    result.Name,
+		Version: result.Version,
+		Value:   value,
+	}
+
+	if result.CreatedDate != nil {
+		jsonOut.Modified = timeutil.FormatRFC3339(*result.CreatedDate)
+	}
+
+	return output.WriteJSON(stdout, jsonOut)
+}
+
+// ShowCommand returns the SOPS show command.
+func ShowCommand() *cli.Command {
+	return genericshow.Command(genericshow.Config[*sopsversion.Spec]{
+		Usage:     "Show key value with metadata",
+		ArgsUsage: "<key[#COMMIT][~SHIFT]*>",
+		Description: `Decrypt and display a key's value along with its metadata.
+
+Use --raw to output only the value without metadata (for piping/scripting).
+Use --output=json for structured JSON output (cannot be used with --raw).
+
+VERSION SPECIFIERS:
+  #COMMIT   The value as committed at a git commit (any unambiguous prefix)
+  ~SHIFT    N changes ago; ~ alone means ~1
+
+EXAMPLES:
+  suve sops secret show db.password                       Show the working file's value
+  suve sops secret show db.password#3f2a9c                Show the value at a commit
+  suve sops secret show db.password~                      Show the previous value
+  suve sops secret show --raw db.password                 Output raw value (for piping)
+  suve sops secret show --output=json db.password         Output as JSON`,
+		UsageError: "usage: suve sops secret show <key>",
+		ParseSpec:  sopsversion.Parse,
+		NewPresenter: func(ctx context.Context, _ *cli.Command, spec *sopsversion.Spec) (genericshow.Presenter, error) {
+			store, err := cliinternal.SOPSStore(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewShowPresenter(store, spec), nil
+		},
+	})
+}
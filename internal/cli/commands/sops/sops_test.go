@@ -0,0 +1,218 @@
+package sops_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appcli "github.com/mpyw/suve/internal/cli/commands"
+	"github.com/mpyw/suve/internal/cli/commands/sops"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	sopsusecase "github.com/mpyw/suve/internal/usecase/sops"
+	"github.com/mpyw/suve/internal/version/sopsversion"
+)
+
+// TestCommandValidation exercises argument/spec validation that fails before any
+// file is read.
+func TestCommandValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "create missing args",
+			args:    []string{"suve", "sops", "secret", "create"},
+			wantErr: "usage:",
+		},
+		{
+			name:    "delete missing key",
+			args:    []string{"suve", "sops", "secret", "delete"},
+			wantErr: "usage:",
+		},
+		{
+			name:    "show rejects label spec",
+			args:    []string{"suve", "sops", "--file", "secrets.yaml", "secret", "show", "db.password:latest"},
+			wantErr: "labels are not supported",
+		},
+		{
+			name:    "show rejects a non-hex commit",
+			args:    []string{"suve", "sops", "--file", "secrets.yaml", "secret", "show", "db.password#main"},
+			wantErr: "must be followed by a commit hash",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := appcli.MakeApp()
+			err := app.Run(t.Context(), tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// TestRoundTrip drives the real commands against an age-encrypted file.
+func TestRoundTrip(t *testing.T) {
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("SOPS_AGE_KEY", id.String())
+	t.Setenv("SOPS_AGE_KEY_FILE", "")
+	t.Setenv("SOPS_AGE_RECIPIENTS", id.Recipient().String())
+	t.Setenv("SUVE_SOPS_FILE", "")
+
+	path := filepath.Join(t.TempDir(), "secrets.yaml")
+
+	run := func(args ...string) string {
+		t.Helper()
+
+		var buf bytes.Buffer
+
+		app := appcli.MakeApp()
+		app.Writer = &buf
+		app.ErrWriter = &buf
+
+		require.NoError(t, app.Run(t.Context(), append([]string{"suve", "sops", "--file", path, "secret"}, args...)))
+
+		return buf.String()
+	}
+
+	assert.Contains(t, run("create", "db.password", "s3cr3t"), "Created key db.password")
+	run("create", "db.user", "admin")
+	assert.Contains(t, run("update", "--yes", "db.password", "n3w"), "Updated key db.password")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "n3w")
+	assert.Contains(t, string(data), "ENC[AES256_GCM,")
+
+	assert.Equal(t, "n3w", run("show", "--raw", "db.password"))
+	assert.Equal(t, "db.password\ndb.user\n", run("list"))
+
+	assert.Contains(t, run("delete", "--yes", "db.user"), "Deleted key db.user")
+	assert.Equal(t, "db.password\n", run("list"))
+}
+
+func TestDeleteRunner(t *testing.T) {
+	t.Parallel()
+
+	var deleted string
+
+	store := &providermock.Store{
+		DeleteFunc: func(_ context.Context, name string, _ ...provider.DeleteOption) error {
+			deleted = name
+
+			return nil
+		},
+	}
+
+	var buf, errBuf bytes.Buffer
+
+	r := &sops.DeleteRunner{
+		UseCase: &sopsusecase.DeleteUseCase{Store: store},
+		Stdout:  &buf,
+		Stderr:  &errBuf,
+	}
+	require.NoError(t, r.Run(t.Context(), sops.DeleteOptions{Name: "db.password"}))
+	assert.Equal(t, "db.password", deleted)
+	assert.Contains(t, buf.String(), "Deleted key db.password")
+}
+
+func TestShowPresenter(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	store := &providermock.Store{
+		ResolveFunc: func(_ context.Context, _, _ string) (provider.VersionRef, error) {
+			return provider.NewVersionRef("0123456789abcdef"), nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{
+				Name:    name,
+				Value:   "s3cr3t",
+				Type:    domain.ValueTypeSecret,
+				Version: domain.Version{ID: "0123456789ab", Created: &created},
+			}, nil
+		},
+	}
+
+	spec, err := sopsversion.Parse("db.password#0123")
+	require.NoError(t, err)
+
+	presenter := sops.NewShowPresenter(store, spec)
+	require.NoError(t, presenter.Fetch(t.Context()))
+
+	var buf, errBuf bytes.Buffer
+
+	value := presenter.Value(false, &errBuf)
+	presenter.RenderText(&buf, value)
+
+	out := buf.String()
+	assert.Contains(t, out, "db.password")
+	assert.Contains(t, out, "0123456789ab")
+	assert.Contains(t, out, "s3cr3t")
+	assert.NotContains(t, out, "Tags")
+
+	var jsonBuf bytes.Buffer
+	require.NoError(t, presenter.RenderJSON(&jsonBuf, value))
+
+	var showOut struct {
+		Name     string `json:"name"`
+		Version  string `json:"version"`
+		Modified string `json:"modified"`
+		Value    string `json:"value"`
+	}
+	require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &showOut))
+	assert.Equal(t, "db.password", showOut.Name)
+	assert.Equal(t, "0123456789ab", showOut.Version)
+	assert.Equal(t, "2024-05-06T07:08:09Z", showOut.Modified)
+	assert.Equal(t, "s3cr3t", showOut.Value)
+}
+
+func TestDiffPresenter_Labels(t *testing.T) {
+	t.Parallel()
+
+	store := &providermock.Store{
+		ResolveFunc: func(_ context.Context, _, spec string) (provider.VersionRef, error) {
+			if spec == "" {
+				return provider.VersionRef{}, nil
+			}
+
+			return provider.NewVersionRef("0123456789abcdef"), nil
+		},
+		GetFunc: func(_ context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+			if ref.IsLatest() {
+				return &domain.Entry{Name: name, Value: "new"}, nil
+			}
+
+			return &domain.Entry{Name: name, Value: "old", Version: domain.Version{ID: "0123456789ab"}}, nil
+		},
+	}
+
+	spec1, spec2, err := sopsversion.ParseDiffArgs([]string{"db.password~"})
+	require.NoError(t, err)
+
+	presenter := sops.NewDiffPresenter(store, spec1, spec2)
+	require.NoError(t, presenter.Fetch(t.Context()))
+
+	oldLabel, newLabel := presenter.Labels()
+	assert.Equal(t, "db.password#0123456789ab", oldLabel)
+	assert.Equal(t, "db.password", newLabel, "the working file has no commit to name")
+}
@@ -0,0 +1,106 @@
+package sops
+
+import (
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/staging"
+	stgcli "github.com/mpyw/suve/internal/staging/cli"
+)
+
+// sopsStageConfig is the staging command config for SOPS. Because SOPS is
+// secret-only, the single config drives the whole `sops stage` group directly
+// (no param/secret split). The ScopeResolver keys on-disk staging state by the
+// file's absolute path. Keys have no description, so no --description flag is
+// registered.
+func sopsStageConfig() stgcli.CommandConfig {
+	return stgcli.CommandConfig{
+		CommandName:   nounSecret,
+		ItemName:      nounKey,
+		Factory:       cliinternal.SOPSStrategyFactory,
+		ParserFactory: staging.SOPSParserFactory,
+		ScopeResolver: cliinternal.SOPSStagingScopeResolver,
+	}
+}
+
+// stageDescription is shared by the grouped and flat forms of the command.
+const stageDescription = `Stage changes locally before writing them to the SOPS file.
+
+SOPS is secret-only, so 'suve sops stage' operates on keys directly:
+   add       Stage a new key for creation
+   edit      Edit and stage an existing key
+   delete    Stage a key for deletion
+   status    Show staged changes
+   diff      Show diff of staged changes vs the file
+   apply     Write staged changes to the file (one re-encryption per key)
+   reset     Unstage changes
+   resolve   Merge remote changes into conflicting staged changes
+   switch    Switch the active changeset (-c to create one)
+   branch    List or delete changesets
+   move      Move a staged change to another changeset
+   stash     Shelve staged changes and restore them later
+   history   List past applies
+   undo      Stage the changes that reverse an apply
+   export    Export staged changes to a directory
+   import    Import staged changes from a directory
+
+EXAMPLES:
+   suve sops stage add db.password       Stage a new key
+   suve sops stage edit db.password      Edit and stage a key
+   suve sops stage status                View staged changes
+   suve sops stage apply                 Apply staged changes`
+
+// stageSubcommands builds the staging subcommands for the given config. Keys
+// carry no tags, so there is no tag/untag.
+func stageSubcommands(cfg stgcli.CommandConfig) []*cli.Command {
+	gcfg := stgcli.SecretOnlyGlobalConfig("SOPS", cfg)
+
+	return []*cli.Command{
+		stgcli.NewAddCommand(cfg),
+		stgcli.NewEditCommand(cfg),
+		stgcli.NewDeleteCommand(cfg),
+		stgcli.NewStatusCommand(cfg),
+		stgcli.NewDiffCommand(cfg),
+		stgcli.NewApplyCommand(cfg),
+		stgcli.NewResetCommand(cfg),
+		stgcli.NewResolveCommand(cfg),
+		stgcli.NewGlobalSwitchCommand(gcfg),
+		stgcli.NewGlobalBranchCommand(gcfg),
+		stgcli.NewGlobalMoveCommand(gcfg),
+		stgcli.NewGlobalStashCommand(gcfg),
+		stgcli.NewGlobalHistoryCommand(gcfg),
+		stgcli.NewGlobalUndoCommand(gcfg),
+		stgcli.NewExportCommand(cfg),
+		stgcli.NewImportCommand(cfg),
+	}
+}
+
+// StageCommand returns the "sops stage" subcommand group.
+func StageCommand() *cli.Command {
+	return &cli.Command{
+		Name:            "stage",
+		Aliases:         []string{"stg"},
+		Usage:           "Manage staged changes for a SOPS file",
+		Description:     stageDescription,
+		Commands:        stageSubcommands(sopsStageConfig()),
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// FlatStageCommand returns the SOPS stage command as a standalone top-level
+// command named `name` (e.g. "stage"). Because there is no parent sops group
+// to carry them, it folds in the --file flag and the scope-resolving Before
+// hook. Used for the flat `suve stage` alias when SOPS is the uniquely active
+// staging provider.
+func FlatStageCommand(name string) *cli.Command {
+	return &cli.Command{
+		Name:            name,
+		Aliases:         []string{"stg"},
+		Usage:           "Manage staged changes for a SOPS file",
+		Description:     stageDescription,
+		Flags:           scopeFlags(),
+		Before:          resolveScope,
+		Commands:        stageSubcommands(sopsStageConfig()),
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
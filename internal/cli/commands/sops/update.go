@@ -0,0 +1,141 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/confirm"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/usecase/sops"
+)
+
+// UpdateRunner executes the update command.
+type UpdateRunner struct {
+	UseCase *sops.UpdateUseCase
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// UpdateOptions holds the options for the update command.
+type UpdateOptions struct {
+	Name  string
+	Value string
+}
+
+// UpdateCommand returns the SOPS update command.
+func UpdateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "update",
+		Usage:     "Update a key value",
+		ArgsUsage: "<key> [<value>]",
+		Description: `Update the value of an existing key and re-encrypt the file to its
+existing recipients.
+
+The change is written to the working file only; commit it with git to make it
+a version in 'suve sops secret log'. Use 'suve sops secret create' to create a
+new key.
+
+The value may be given as a positional argument, read from stdin with
+--value-stdin (so it never appears in argv/ps or shell history), or, when
+omitted, typed into $EDITOR.
+
+EXAMPLES:
+  suve sops secret update api.key "new-value"       Update the value
+  suve sops secret update --yes api.key "new-value" Update without confirmation
+  printf '%s' "$V" | suve sops secret update --yes api.key --value-stdin  Read value from stdin
+  suve sops secret update api.key                   Type value into $EDITOR`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "yes",
+				Usage: "Skip confirmation prompt",
+			},
+			cliinternal.ValueStdinFlag(),
+		},
+		Action: updateAction,
+	}
+}
+
+func updateAction(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() < 1 {
+		return errors.New("usage: suve sops secret update <key> [<value>]")
+	}
+
+	name := args.Get(0)
+	skipConfirm := cmd.Bool("yes")
+
+	newValue, proceed, err := cliinternal.ResolveValue(ctx, cliinternal.ValueSource{
+		FromStdin: cmd.Bool(cliinternal.FlagValueStdin),
+		HasArg:    args.Len() >= 2, //nolint:mnd // arg 0 is the name, arg 1 is the optional value
+		Arg:       args.Get(1),
+		Stdin:     cliinternal.Stdin(cmd),
+		// Without --yes we prompt for confirmation on the same stdin below;
+		// reading the value from stdin would leave nothing for that prompt.
+		ConfirmRequired: !skipConfirm,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !proceed {
+		output.Info(cmd.Root().Writer, "Empty value, nothing to update.")
+
+		return nil
+	}
+
+	store, err := cliinternal.SOPSStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	uc := &sops.UpdateUseCase{Store: store}
+
+	if !skipConfirm {
+		currentValue, _ := uc.GetCurrentValue(ctx, name)
+		if currentValue != "" {
+			diff := output.Diff(cmd.Root().ErrWriter, name+" (current)", name+" (new)", currentValue, newValue)
+			if diff != "" {
+				output.Println(cmd.Root().ErrWriter, diff)
+			}
+		}
+
+		prompter := &confirm.Prompter{
+			Stdin:  cliinternal.Stdin(cmd),
+			Stdout: cmd.Root().Writer,
+			Stderr: cmd.Root().ErrWriter,
+		}
+
+		confirmed, cerr := prompter.ConfirmAction("Update key", name, false)
+		if cerr != nil {
+			return cerr
+		}
+
+		if !confirmed {
+			return nil
+		}
+	}
+
+	r := &UpdateRunner{
+		UseCase: uc,
+		Stdout:  cmd.Root().Writer,
+		Stderr:  cmd.Root().ErrWriter,
+	}
+
+	return r.Run(ctx, UpdateOptions{Name: name, Value: newValue})
+}
+
+// Run executes the update command.
+func (r *UpdateRunner) Run(ctx context.Context, opts UpdateOptions) error {
+	result, err := r.UseCase.Execute(ctx, sops.UpdateInput{Name: opts.Name, Value: opts.Value})
+	if err != nil {
+		return err
+	}
+
+	output.Success(r.Stdout, "Updated key %s", result.Name)
+
+	return nil
+}
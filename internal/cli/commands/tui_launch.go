@@ -8,8 +8,12 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/commands/plugin"
 	"github.com/mpyw/suve/internal/cli/terminal"
 	"github.com/mpyw/suve/internal/provider"
+	awsprovider "github.com/mpyw/suve/internal/provider/aws"
+	"github.com/mpyw/suve/internal/provider/aws/infra"
 	"github.com/mpyw/suve/internal/provider/detect"
 	"github.com/mpyw/suve/internal/tui"
 )
@@ -50,6 +54,10 @@ func RegisterTUIFlag() {
 
 	for _, group := range App.Commands {
 		p := tuiGroupProvider(group.Name)
+		if plugin.Name(group) != "" {
+			p = provider.ProviderPlugin
+		}
+
 		if p == "" {
 			continue
 		}
@@ -103,19 +111,20 @@ func attachTUIFlag(cmd *cli.Command, p provider.Provider, service string) {
 }
 
 // launchTUIBare handles `suve --tui`: it launches only when exactly one provider
-// is active across the union of all service axes (param + secret + stage), and
+// is active across the union of all service axes (param + secret + stage), or
+// the one named by --provider / SUVE_PROVIDER, and
 // otherwise returns a friendly error naming the candidates and the explicit
 // forms.
 func launchTUIBare(ctx context.Context) (context.Context, error) {
-	det := detect.Resolve(detect.OSEnvironment())
+	det := detect.Resolve(detectEnvironment(os.Args))
 
 	p, err := uniqueTUIProvider(det)
 	if err != nil {
 		return ctx, err
 	}
 
-	// No scope flags on the bare form; hydrateTUIScope fills resource fields from
-	// the environment. No specific service (group-level launch).
+	// No scope flags on the bare form; cliinternal.HydrateScope fills resource
+	// fields from the environment. No specific service (group-level launch).
 	return launchTUI(ctx, provider.Scope{Provider: p}, "")
 }
 
@@ -126,12 +135,16 @@ func launchTUI(ctx context.Context, scope provider.Scope, service string) (conte
 		return ctx, err
 	}
 
-	scope = hydrateTUIScope(scope)
+	scope = cliinternal.HydrateScope(scope)
 
-	if err := validateTUIScope(scope); err != nil {
+	if err := cliinternal.ValidateScope(scope); err != nil {
 		return ctx, err
 	}
 
+	if scope.Provider == provider.ProviderAWS {
+		ctx = awsTUIContext(ctx, scope)
+	}
+
 	if err := tui.Run(ctx, scope, service); err != nil {
 		return ctx, err
 	}
@@ -141,6 +154,33 @@ func launchTUI(ctx context.Context, scope provider.Scope, service string) (conte
 	return ctx, nil
 }
 
+// awsTUIContext resolves the launch scope's AWS credentials while the terminal
+// is still ours, so a role that needs MFA can prompt for its code before the
+// TUI takes over the screen. The returned context shares those credentials
+// with every client the TUI builds (see infra.Options.TokenProvider); a
+// profile picked later that needs a code fails with a hint instead of
+// prompting underneath the TUI.
+func awsTUIContext(ctx context.Context, scope provider.Scope) context.Context {
+	launch := awsprovider.WithScope(infra.WithOptions(ctx, infra.Options{
+		TokenProvider: terminal.MFATokenProvider(os.Stdin, os.Stderr),
+	}), scope)
+
+	// Only a profile or a role can need MFA; the bare ambient chain is left to
+	// the TUI so a machine without credentials does not wait on IMDS here. A
+	// failure is shown by the TUI when it first calls AWS.
+	if scope.AWSProfile != "" || scope.AWSRoleARN != "" || os.Getenv("AWS_PROFILE") != "" {
+		if cfg, err := infra.LoadConfig(launch); err == nil {
+			_, _ = cfg.Credentials.Retrieve(launch)
+		}
+	}
+
+	return infra.WithOptions(ctx, infra.Options{
+		TokenProvider: func() (string, error) {
+			return "", errors.New("this profile needs an MFA code: launch the TUI with 'suve aws --profile <name> --tui' to enter it")
+		},
+	})
+}
+
 // requireTUITTY rejects a non-interactive launch: the TUI takes over the screen
 // and reads keys, so both stdin and stdout must be terminals (same spirit as
 // the $EDITOR TTY gate).
@@ -153,9 +193,12 @@ func requireTUITTY() error {
 }
 
 // tuiScope builds the launch scope for provider p from the command's scope
-// flags (--project for Google Cloud; --vault-name / --store-name / --namespace
-// for Azure). Absent flags stay empty and are hydrated from the environment by
-// hydrateTUIScope. It mirrors the GUI's guiScope.
+// flags (--profile / --role-arn and friends for AWS; --project for Google
+// Cloud; --vault-name / --store-name / --namespace for Azure; --address /
+// --mount for Vault; --context / --namespace for Kubernetes; --file for SOPS;
+// the group itself names a plugin). Absent flags stay empty and are hydrated
+// from the environment by cliinternal.HydrateScope. It mirrors the GUI's
+// guiScope.
 func tuiScope(cmd *cli.Command, p provider.Provider) provider.Scope {
 	s := provider.Scope{Provider: p}
 
@@ -166,62 +209,31 @@ func tuiScope(cmd *cli.Command, p provider.Provider) provider.Scope {
 		s.VaultName = cmd.String("vault-name")
 		s.StoreName = cmd.String("store-name")
 		s.AppConfigNamespace = cmd.String("namespace")
+	case provider.ProviderVault:
+		s.VaultAddress = cmd.String("address")
+		s.VaultMount = cmd.String("mount")
+	case provider.ProviderKubernetes:
+		s.KubeContext = cmd.String("context")
+		s.KubeNamespace = cmd.String("namespace")
+	case provider.ProviderSOPS:
+		s.SOPSFile = cmd.String("file")
+	case provider.ProviderPlugin:
+		s.Plugin = plugin.Name(cmd)
 	case provider.ProviderAWS:
-		// region comes from the ambient AWS config; no scope flag.
-	}
-
-	return s
-}
-
-// hydrateTUIScope fills empty resource fields on a launch scope from the
-// environment (flag wins over env), mirroring the GUI's hydrateScope.
-func hydrateTUIScope(s provider.Scope) provider.Scope {
-	switch s.Provider {
-	case provider.ProviderGoogleCloud:
-		if s.ProjectID == "" {
-			s.ProjectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
-		}
-	case provider.ProviderAzure:
-		if s.VaultName == "" {
-			s.VaultName = os.Getenv("AZURE_KEYVAULT_NAME")
-		}
-
-		if s.StoreName == "" {
-			s.StoreName = os.Getenv("AZURE_APPCONFIG_NAME")
-		}
-
-		if s.AppConfigNamespace == "" {
-			s.AppConfigNamespace = os.Getenv("AZURE_APPCONFIG_NAMESPACE")
-		}
-	case provider.ProviderAWS:
-		// region comes from the ambient AWS config; nothing to hydrate.
+		// The region comes from the ambient AWS config; the flags only select
+		// credentials.
+		s.AWSProfile = cmd.String("profile")
+		s.AWSRoleARN = cmd.String("role-arn")
+		s.AWSExternalID = cmd.String("external-id")
+		s.AWSSessionName = cmd.String("session-name")
+		s.AWSMFASerial = cmd.String("mfa-serial")
+	case provider.ProviderLocal:
+		// One shared directory; no scope flag.
 	}
 
 	return s
 }
 
-// validateTUIScope rejects a launch scope that cannot resolve any service, with
-// guidance naming the flags/env to set.
-func validateTUIScope(s provider.Scope) error {
-	switch s.Provider {
-	case provider.ProviderGoogleCloud:
-		if s.ProjectID == "" {
-			return errors.New("no Google Cloud project: set --project or the GOOGLE_CLOUD_PROJECT environment variable")
-		}
-	case provider.ProviderAzure:
-		if s.VaultName == "" && s.StoreName == "" {
-			return errors.New(
-				"no Azure Key Vault or App Configuration store: set --vault-name / --store-name " +
-					"or the AZURE_KEYVAULT_NAME / AZURE_APPCONFIG_NAME environment variable",
-			)
-		}
-	case provider.ProviderAWS:
-		// AWS resolves its region from the ambient config; nothing to validate.
-	}
-
-	return nil
-}
-
 // uniqueTUIProvider returns the sole provider active across the union of the
 // param, secret, and stage service axes, or an error listing the candidates
 // (or, when none is active, all providers).
@@ -239,7 +251,7 @@ func uniqueTUIProvider(det detect.Result) (provider.Provider, error) {
 }
 
 // activeTUIProviders lists every provider active in any service axis, in stable
-// order (AWS, Google Cloud, Azure).
+// order (AWS, Google Cloud, Azure, Vault, Kubernetes, SOPS, local).
 func activeTUIProviders(det detect.Result) []provider.Provider {
 	present := make(map[provider.Provider]bool)
 
@@ -251,7 +263,10 @@ func activeTUIProviders(det detect.Result) []provider.Provider {
 
 	var out []provider.Provider
 
-	for _, p := range []provider.Provider{provider.ProviderAWS, provider.ProviderGoogleCloud, provider.ProviderAzure} {
+	for _, p := range []provider.Provider{
+		provider.ProviderAWS, provider.ProviderGoogleCloud, provider.ProviderAzure,
+		provider.ProviderVault, provider.ProviderKubernetes, provider.ProviderSOPS, provider.ProviderLocal,
+	} {
 		if present[p] {
 			out = append(out, p)
 		}
@@ -283,6 +298,10 @@ func noActiveProviderMessage() string {
 		"  suve aws --tui",
 		"  suve gcloud --tui",
 		"  suve azure --tui",
+		"  suve vault --tui",
+		"  suve kubernetes --tui",
+		"  suve sops --tui",
+		"  suve local --tui",
 	}
 
 	return "no provider is active in this environment.\n" +
@@ -300,6 +319,14 @@ func tuiGroupProvider(name string) provider.Provider {
 		return provider.ProviderGoogleCloud
 	case "azure":
 		return provider.ProviderAzure
+	case "vault":
+		return provider.ProviderVault
+	case "kubernetes":
+		return provider.ProviderKubernetes
+	case "sops":
+		return provider.ProviderSOPS
+	case "local":
+		return provider.ProviderLocal
 	default:
 		return ""
 	}
@@ -1,4 +1,4 @@
-//nolint:testpackage // white-box: exercises unexported uniqueTUIProvider/activeTUIProviders/hydrate/validate helpers
+//nolint:testpackage // white-box: exercises unexported uniqueTUIProvider/activeTUIProviders helpers
 package commands
 
 import (
@@ -48,6 +48,16 @@ func TestUniqueTUIProvider_Unique(t *testing.T) {
 			},
 			want: provider.ProviderAzure,
 		},
+		{
+			name: "local selected",
+			det: detect.Result{
+				ParamActive:  []provider.Provider{provider.ProviderLocal},
+				SecretActive: []provider.Provider{provider.ProviderLocal},
+				StageActive:  []provider.Provider{provider.ProviderLocal},
+				Selected:     true,
+			},
+			want: provider.ProviderLocal,
+		},
 	}
 
 	for _, tt := range tests {
@@ -120,46 +130,44 @@ func TestActiveTUIProviders_UnionStableOrder(t *testing.T) {
 	}, got)
 }
 
-// TestHydrateTUIScope_FillsFromEnv pins that empty resource fields hydrate from
-// the environment (flag values would already be set on the scope and win).
-func TestHydrateTUIScope_FillsFromEnv(t *testing.T) {
-	t.Setenv("GOOGLE_CLOUD_PROJECT", "proj-from-env")
-
-	got := hydrateTUIScope(provider.Scope{Provider: provider.ProviderGoogleCloud})
-	assert.Equal(t, "proj-from-env", got.ProjectID)
-}
-
-// TestValidateTUIScope pins the per-provider scope requirements that produce a
-// launch error before the alt-screen takes over.
-func TestValidateTUIScope(t *testing.T) {
+// TestProviderArg pins the --provider pre-scan that feeds detection before the
+// flags are parsed.
+func TestProviderArg(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name    string
-		scope   provider.Scope
-		wantErr string
+		name      string
+		args      []string
+		want      string
+		wantFound bool
 	}{
-		{name: "aws needs nothing", scope: provider.Scope{Provider: provider.ProviderAWS}},
-		{name: "gcloud with project ok", scope: provider.GoogleCloudScope("p")},
-		{name: "gcloud without project", scope: provider.Scope{Provider: provider.ProviderGoogleCloud}, wantErr: "Google Cloud project"},
-		{name: "azure vault ok", scope: provider.AzureKeyVaultScope("v")},
-		{name: "azure store ok", scope: provider.AzureAppConfigScope("s")},
-		{name: "azure neither", scope: provider.Scope{Provider: provider.ProviderAzure}, wantErr: "Azure Key Vault or App Configuration"},
+		{name: "absent", args: []string{"suve", "param", "ls"}},
+		{name: "separate value", args: []string{"suve", "--provider", "local", "param", "ls"}, want: "local", wantFound: true},
+		{name: "equals form", args: []string{"suve", "param", "--provider=aws", "ls"}, want: "aws", wantFound: true},
+		{name: "last one wins", args: []string{"suve", "--provider=aws", "--provider", "local"}, want: "local", wantFound: true},
+		{name: "after terminator", args: []string{"suve", "param", "create", "--", "--provider=local"}},
+		{name: "missing value", args: []string{"suve", "--provider"}},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			err := validateTUIScope(tt.scope)
-			if tt.wantErr == "" {
-				assert.NoError(t, err)
-
-				return
-			}
-
-			require.Error(t, err)
-			assert.Contains(t, err.Error(), tt.wantErr)
+			got, found := providerArg(tt.args)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantFound, found)
 		})
 	}
 }
+
+// TestDetectEnvironment_ProviderArgOverlaysEnv pins that a --provider on the
+// command line beats SUVE_PROVIDER for the flat-alias detection.
+func TestDetectEnvironment_ProviderArgOverlaysEnv(t *testing.T) {
+	t.Setenv(detect.ProviderEnvVar, "aws")
+
+	det := detect.Resolve(detectEnvironment([]string{"suve", "--provider", "local", "param", "ls"}))
+	assert.Equal(t, provider.ProviderLocal, det.Param)
+
+	det = detect.Resolve(detectEnvironment([]string{"suve", "param", "ls"}))
+	assert.Equal(t, provider.ProviderAWS, det.Param)
+}
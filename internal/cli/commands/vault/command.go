@@ -0,0 +1,116 @@
+// Package vault provides CLI commands for the HashiCorp Vault KV v2 secrets
+// engine, exposed as the "suve vault secret <op>" command group plus the
+// "suve vault stage <op>" staging workflow.
+//
+// Vault is secret-only (no parameter store). The read/write/tag commands (show,
+// log, list, diff, create, update, delete, restore, tag, untag) and the staging
+// commands reuse the same generic scaffolding as the other provider groups via
+// Vault-specific presenters, use cases, and staging strategy.
+package vault
+
+import (
+	"context"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+)
+
+// nounSecret is the command name / noun used across the Vault secret commands.
+const nounSecret = "secret"
+
+// defaultMount is where `vault server -dev` (and most installs) mount KV v2.
+const defaultMount = "secret"
+
+// Command returns the vault command with the secret subcommand group.
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "vault",
+		Usage: "Interact with HashiCorp Vault KV v2 secrets",
+		Description: `Interact with the HashiCorp Vault KV version 2 secrets engine.
+
+Secrets are paths under a KV mount (e.g. app/db) with integer versions. Set the
+server with --address or the VAULT_ADDR environment variable and the mount with
+--mount (default "secret"). The token is read from VAULT_TOKEN or, when unset,
+~/.vault-token (written by 'vault login'). VAULT_NAMESPACE selects a Vault
+Enterprise namespace.
+
+suve writes a secret's value under the data key "value". Secrets written by
+other tools with a different shape are shown as their JSON data object.`,
+		Flags: scopeFlags(),
+		// Before resolves the address and mount once and stashes them in the
+		// context so the generic command presenters (which do not receive
+		// *cli.Command) can resolve a store. Resolution is deferred to store
+		// construction, so `suve vault secret --help` works without an address.
+		Before: resolveScope,
+		Commands: []*cli.Command{
+			SecretCommand(),
+			StageCommand(),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// FlatSecretCommand returns the Vault secret command as a standalone top-level
+// command named `name` (e.g. "secret"). Because there is no parent vault group
+// to carry them, it folds in the --address/--mount flags and the
+// scope-resolving Before hook. Used for the flat `suve secret` alias when Vault
+// is the uniquely active secret provider.
+func FlatSecretCommand(name string) *cli.Command {
+	c := SecretCommand()
+	c.Name = name
+	c.Flags = scopeFlags()
+	c.Before = resolveScope
+
+	return c
+}
+
+// scopeFlags returns the shared --address and --mount flags (a fresh slice per
+// call so each command owns its flag instances).
+func scopeFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "address",
+			Usage: "Vault server address (defaults to $VAULT_ADDR)",
+		},
+		&cli.StringFlag{
+			Name:  "mount",
+			Value: defaultMount,
+			Usage: "Path the KV v2 secrets engine is mounted at",
+		},
+	}
+}
+
+// resolveScope stashes the resolved address (from --address or VAULT_ADDR) and
+// mount into the context for the subcommands.
+func resolveScope(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	address := cmd.String("address")
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+
+	return cliinternal.WithVaultScope(ctx, address, cmd.String("mount")), nil
+}
+
+// SecretCommand returns the "vault secret" subcommand group.
+func SecretCommand() *cli.Command {
+	return &cli.Command{
+		Name:    nounSecret,
+		Aliases: []string{"secrets", "kv"},
+		Usage:   "Interact with Vault KV v2 secrets",
+		Commands: []*cli.Command{
+			ShowCommand(),
+			LogCommand(),
+			DiffCommand(),
+			ListCommand(),
+			CreateCommand(),
+			UpdateCommand(),
+			DeleteCommand(),
+			RestoreCommand(),
+			TagCommand(),
+			UntagCommand(),
+		},
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
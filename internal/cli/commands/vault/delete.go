@@ -0,0 +1,113 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/confirm"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/usecase/vault"
+)
+
+// DeleteRunner executes the delete command.
+type DeleteRunner struct {
+	UseCase *vault.DeleteUseCase
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// DeleteOptions holds the options for the delete command.
+type DeleteOptions struct {
+	Name string
+}
+
+// DeleteCommand returns the Vault KV v2 delete command.
+func DeleteCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "delete",
+		Aliases:   []string{"rm"},
+		Usage:     "Delete a secret",
+		ArgsUsage: "<path>",
+		Description: `Soft-delete the current version of a secret in Vault KV v2.
+
+The version's data stays on the server and older versions remain readable by
+number. Use 'suve vault secret restore' to undelete it.
+
+EXAMPLES:
+   suve vault secret delete app/db        Delete (with confirmation)
+   suve vault secret delete --yes app/db  Delete without confirmation`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "yes",
+				Usage: "Skip confirmation prompt",
+			},
+		},
+		Action: deleteAction,
+	}
+}
+
+func deleteAction(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() < 1 {
+		return fmt.Errorf("usage: suve vault secret delete <path>")
+	}
+
+	name := cmd.Args().First()
+	skipConfirm := cmd.Bool("yes")
+
+	store, err := cliinternal.VaultSecretStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	uc := &vault.DeleteUseCase{Store: store}
+
+	if !skipConfirm {
+		currentValue, _ := uc.GetCurrentValue(ctx, name)
+		if currentValue != "" {
+			output.Info(cmd.Root().ErrWriter, "Current value of %s:", name)
+			output.Println(cmd.Root().ErrWriter, "")
+			output.Println(cmd.Root().ErrWriter, output.Indent(currentValue, "  "))
+			output.Println(cmd.Root().ErrWriter, "")
+		}
+	}
+
+	prompter := &confirm.Prompter{
+		Stdin:  os.Stdin,
+		Stdout: cmd.Root().Writer,
+		Stderr: cmd.Root().ErrWriter,
+	}
+
+	confirmed, err := prompter.ConfirmDelete(name, skipConfirm)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		return nil
+	}
+
+	r := &DeleteRunner{
+		UseCase: uc,
+		Stdout:  cmd.Root().Writer,
+		Stderr:  cmd.Root().ErrWriter,
+	}
+
+	return r.Run(ctx, DeleteOptions{Name: name})
+}
+
+// Run executes the delete command.
+func (r *DeleteRunner) Run(ctx context.Context, opts DeleteOptions) error {
+	result, err := r.UseCase.Execute(ctx, vault.DeleteInput{Name: opts.Name})
+	if err != nil {
+		return err
+	}
+
+	output.Success(r.Stdout, "Deleted secret %s (restore with: suve vault secret restore %s)", result.Name, result.Name)
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	genericdiff "github.com/mpyw/suve/internal/cli/commands/generic/diff"
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/usecase/vault"
+	"github.com/mpyw/suve/internal/version/vaultversion"
+)
+
+// diffJSONOutput represents the JSON output structure for the diff command.
+type diffJSONOutput struct {
+	OldName    string `json:"oldName"`
+	OldVersion string `json:"oldVersion"`
+	OldValue   string `json:"oldValue"`
+	NewName    string `json:"newName"`
+	NewVersion string `json:"newVersion"`
+	NewValue   string `json:"newValue"`
+	Identical  bool   `json:"identical"`
+	Diff       string `json:"diff,omitempty"`
+}
+
+// diffPresenter renders Vault KV v2 diff output.
+type diffPresenter struct {
+	uc     *vault.DiffUseCase
+	spec1  *vaultversion.Spec
+	spec2  *vaultversion.Spec
+	result *vault.DiffOutput
+}
+
+// NewDiffPresenter builds a Vault diff presenter over the given reader and specs.
+func NewDiffPresenter(reader provider.Reader, spec1, spec2 *vaultversion.Spec) genericdiff.Presenter {
+	return &diffPresenter{uc: &vault.DiffUseCase{Reader: reader}, spec1: spec1, spec2: spec2}
+}
+
+func (p *diffPresenter) Fetch(ctx context.Context) error {
+	result, err := p.uc.Execute(ctx, vault.DiffInput{Spec1: p.spec1, Spec2: p.spec2})
+	if err != nil {
+		return err
+	}
+
+	p.result = result
+
+	return nil
+}
+
+func (p *diffPresenter) OldValue() string { return p.result.OldValue }
+func (p *diffPresenter) NewValue() string { return p.result.NewValue }
+
+func (p *diffPresenter) Labels() (string, string) {
+	return fmt.Sprintf("%s#%s", p.result.OldName, p.result.OldVersion),
+		fmt.Sprintf("%s#%s", p.result.NewName, p.result.NewVersion)
+}
+
+func (p *diffPresenter) RenderJSON(stdout io.Writer, oldValue, newValue string, identical bool, diff string) error {
+	jsonOut := diffJSONOutput{
+		OldName:    p.result.OldName,
+		OldVersion: p.result.OldVersion,
+		OldValue:   oldValue,
+		NewName:    p.result.NewName,
+		NewVersion: p.result.NewVersion,
+		NewValue:   newValue,
+		Identical:  identical,
+		Diff:       diff,
+	}
+
+	return output.WriteJSON(stdout, jsonOut)
+}
+
+func (p *diffPresenter) Hints(stderr io.Writer) {
+	output.Hint(stderr, "To compare with the previous version, use: suve vault secret diff %s~1", p.result.OldName)
+}
+
+// DiffCommand returns the Vault KV v2 diff command.
+func DiffCommand() *cli.Command {
+	return genericdiff.Command(genericdiff.Config[*vaultversion.Spec]{
+		Usage:     "Show diff between two versions",
+		ArgsUsage: "<spec1> [spec2] | <path> #<version1> [#<version2>]",
+		Description: `Compare two versions of a secret in unified diff format.
+If only one version/spec is specified, compares against the latest version.
+
+VERSION SPECIFIERS:
+  #VERSION  Specific version by integer number
+  ~SHIFT    N versions ago (any state); ~ alone means ~1
+
+EXAMPLES:
+  suve vault secret diff app/db~                   Compare previous with latest
+  suve vault secret diff app/db#1 app/db#2      Compare version 1 with version 2
+  suve vault secret diff --parse-json app/db~      Format JSON values before diffing
+  suve vault secret diff --output=json app/db~     Output comparison as JSON`,
+		ParseDiffArgs: vaultversion.ParseDiffArgs,
+		NewPresenter: func(ctx context.Context, spec1, spec2 *vaultversion.Spec) (genericdiff.Presenter, error) {
+			store, err := cliinternal.VaultSecretStore(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewDiffPresenter(store, spec1, spec2), nil
+		},
+		Store: cliinternal.VaultSecretStore,
+		Kind:  provider.KindSecret,
+	})
+}
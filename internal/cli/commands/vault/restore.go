@@ -0,0 +1,78 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/usecase/vault"
+)
+
+// RestoreRunner executes the restore command.
+type RestoreRunner struct {
+	UseCase *vault.RestoreUseCase
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// RestoreOptions holds the options for the restore command.
+type RestoreOptions struct {
+	Name string
+}
+
+// RestoreCommand returns the Vault KV v2 restore command.
+func RestoreCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "restore",
+		Usage:     "Restore a soft-deleted secret",
+		ArgsUsage: "<path>",
+		Description: `Undelete the current version of a soft-deleted secret.
+
+Works as long as the version has not been destroyed.
+
+EXAMPLES:
+   suve vault secret restore app/db    Undelete the current version`,
+		Action: restoreAction,
+	}
+}
+
+func restoreAction(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() < 1 {
+		return fmt.Errorf("usage: suve vault secret restore <path>")
+	}
+
+	store, err := cliinternal.VaultSecretStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	restorer, ok := store.(provider.Restorer)
+	if !ok {
+		return fmt.Errorf("restore is not supported by this provider")
+	}
+
+	r := &RestoreRunner{
+		UseCase: &vault.RestoreUseCase{Restorer: restorer},
+		Stdout:  cmd.Root().Writer,
+		Stderr:  cmd.Root().ErrWriter,
+	}
+
+	return r.Run(ctx, RestoreOptions{Name: cmd.Args().First()})
+}
+
+// Run executes the restore command.
+func (r *RestoreRunner) Run(ctx context.Context, opts RestoreOptions) error {
+	result, err := r.UseCase.Execute(ctx, vault.RestoreInput{Name: opts.Name})
+	if err != nil {
+		return err
+	}
+
+	output.Success(r.Stdout, "Restored secret %s", result.Name)
+
+	return nil
+}
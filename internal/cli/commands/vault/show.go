@@ -0,0 +1,145 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	genericshow "github.com/mpyw/suve/internal/cli/commands/generic/show"
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/jsonutil"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/timeutil"
+	"github.com/mpyw/suve/internal/usecase/vault"
+	"github.com/mpyw/suve/internal/version/vaultversion"
+)
+
+// showJSONOutput represents the JSON output structure for the show command.
+type showJSONOutput struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version,omitempty"`
+	State   string            `json:"state,omitempty"`
+	Created string            `json:"created,omitempty"`
+	Tags    map[string]string `json:"tags"`
+	Value   string            `json:"value"`
+}
+
+// showPresenter renders Vault KV v2 show output.
+type showPresenter struct {
+	uc     *vault.ShowUseCase
+	spec   *vaultversion.Spec
+	result *vault.ShowOutput
+}
+
+// NewShowPresenter builds a Vault show presenter over the given reader and spec.
+func NewShowPresenter(reader provider.Reader, spec *vaultversion.Spec) genericshow.Presenter {
+	return &showPresenter{uc: &vault.ShowUseCase{Reader: reader}, spec: spec}
+}
+
+func (p *showPresenter) Fetch(ctx context.Context) error {
+	result, err := p.uc.Execute(ctx, vault.ShowInput{Spec: p.spec})
+	if err != nil {
+		return err
+	}
+
+	p.result = result
+
+	return nil
+}
+
+func (p *showPresenter) Value(parseJSON bool, stderr io.Writer) string {
+	value := p.result.Value
+	if parseJSON {
+		value = jsonutil.TryFormatOrWarn(value, stderr, "")
+	}
+
+	return value
+}
+
+func (p *showPresenter) RenderText(stdout io.Writer, value string) {
+	result := p.result
+
+	out := output.New(stdout)
+	out.Field("Name", result.Name)
+
+	if result.Version != "" {
+		out.Field("Version", result.Version)
+	}
+
+	if result.State != "" {
+		out.Field("State", result.State)
+	}
+
+	if result.CreatedDate != nil {
+		out.Field("Created", timeutil.FormatRFC3339(*result.CreatedDate))
+	}
+
+	if len(result.Tags) > 0 {
+		out.Field("Tags", fmt.Sprintf("%d tag(s)", len(result.Tags)))
+
+		for _, tag := range result.Tags {
+			out.Field("  "+tag.Key, tag.Value)
+		}
+	}
+
+	out.Separator()
+	out.Value(value)
+}
+
+func (p *showPresenter) RenderJSON(stdout io.Writer, value string) error {
+	result := p.result
+
+	jsonOut := showJSONOutput{
+		Name:    result.Name,
+		Version: result.Version,
+		State:   result.State,
+		Value:   value,
+	}
+
+	if result.CreatedDate != nil {
+		jsonOut.Created = timeutil.FormatRFC3339(*result.CreatedDate)
+	}
+
+	jsonOut.Tags = make(map[string]string)
+	for _, tag := range result.Tags {
+		jsonOut.Tags[tag.Key] = tag.Value
+	}
+
+	return output.WriteJSON(stdout, jsonOut)
+}
+
+// ShowCommand returns the Vault KV v2 show command.
+func ShowCommand() *cli.Command {
+	return genericshow.Command(genericshow.Config[*vaultversion.Spec]{
+		Usage:     "Show secret value with metadata",
+		ArgsUsage: "<path[#VERSION][~SHIFT]*>",
+		Description: `Display a secret's value along with its metadata.
+
+Use --raw to output only the value without metadata (for piping/scripting).
+Use --output=json for structured JSON output (cannot be used with --raw).
+
+VERSION SPECIFIERS:
+  #VERSION  Specific version by integer number
+  ~SHIFT    N versions ago (any state); ~ alone means ~1
+
+EXAMPLES:
+  suve vault secret show app/db                        Show latest version
+  suve vault secret show app/db#3                      Show version 3
+  suve vault secret show app/db~                       Show previous version
+  suve vault secret show --raw app/db                  Output raw value (for piping)
+  suve vault secret show --output=json app/db          Output as JSON`,
+		UsageError: "usage: suve vault secret show <path>",
+		ParseSpec:  vaultversion.Parse,
+		NewPresenter: func(ctx context.Context, _ *cli.Command, spec *vaultversion.Spec) (genericshow.Presenter, error) {
+			store, err := cliinternal.VaultSecretStore(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewShowPresenter(store, spec), nil
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package vault
+
+import (
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/staging"
+	stgcli "github.com/mpyw/suve/internal/staging/cli"
+)
+
+// vaultStageConfig is the staging command config for Vault KV v2. Because Vault
+// is secret-only, the single config drives the whole `vault stage` group
+// directly (no param/secret split). The ScopeResolver keys on-disk staging
+// state by the resolved address and mount. KV v2 has no description, so no
+// --description flag is registered.
+func vaultStageConfig() stgcli.CommandConfig {
+	return stgcli.CommandConfig{
+		CommandName:   nounSecret,
+		ItemName:      nounSecret,
+		Factory:       cliinternal.VaultSecretStrategyFactory,
+		ParserFactory: staging.VaultSecretParserFactory,
+		ScopeResolver: cliinternal.VaultStagingScopeResolver,
+	}
+}
+
+// stageDescription is shared by the grouped and flat forms of the command.
+const stageDescription = `Stage changes locally before applying to Vault KV v2.
+
+Vault is secret-only, so 'suve vault stage' operates on secrets directly:
+   add       Stage a new secret for creation
+   edit      Edit and stage an existing secret
+   delete    Stage a secret for (soft) deletion
+   status    Show staged changes
+   diff      Show diff of staged changes vs Vault
+   apply     Apply staged changes to Vault
+   reset     Unstage changes
+   resolve   Merge remote changes into conflicting staged changes
+   switch    Switch the active changeset (-c to create one)
+   branch    List or delete changesets
+   move      Move a staged change to another changeset
+   stash     Shelve staged changes and restore them later
+   history   List past applies
+   undo      Stage the changes that reverse an apply
+   tag/untag Stage custom_metadata changes
+   export    Export staged changes to a directory
+   import    Import staged changes from a directory
+
+EXAMPLES:
+   suve vault stage add app/db           Stage a new secret
+   suve vault stage edit app/db          Edit and stage a secret
+   suve vault stage status               View staged changes
+   suve vault stage apply                Apply staged changes`
+
+// stageSubcommands builds the staging subcommands for the given config.
+func stageSubcommands(cfg stgcli.CommandConfig) []*cli.Command {
+	gcfg := stgcli.SecretOnlyGlobalConfig("Vault", cfg)
+
+	return []*cli.Command{
+		stgcli.NewAddCommand(cfg),
+		stgcli.NewEditCommand(cfg),
+		stgcli.NewDeleteCommand(cfg),
+		stgcli.NewStatusCommand(cfg),
+		stgcli.NewDiffCommand(cfg),
+		stgcli.NewApplyCommand(cfg),
+		stgcli.NewResetCommand(cfg),
+		stgcli.NewResolveCommand(cfg),
+		stgcli.NewGlobalSwitchCommand(gcfg),
+		stgcli.NewGlobalBranchCommand(gcfg),
+		stgcli.NewGlobalMoveCommand(gcfg),
+		stgcli.NewGlobalStashCommand(gcfg),
+		stgcli.NewGlobalHistoryCommand(gcfg),
+		stgcli.NewGlobalUndoCommand(gcfg),
+		stgcli.NewTagCommand(cfg),
+		stgcli.NewUntagCommand(cfg),
+		stgcli.NewExportCommand(cfg),
+		stgcli.NewImportCommand(cfg),
+	}
+}
+
+// StageCommand returns the "vault stage" subcommand group.
+func StageCommand() *cli.Command {
+	return &cli.Command{
+		Name:            "stage",
+		Aliases:         []string{"stg"},
+		Usage:           "Manage staged changes for Vault KV v2",
+		Description:     stageDescription,
+		Commands:        stageSubcommands(vaultStageConfig()),
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
+
+// FlatStageCommand returns the Vault stage command as a standalone top-level
+// command named `name` (e.g. "stage"). Because there is no parent vault group
+// to carry them, it folds in the --address/--mount flags and the
+// scope-resolving Before hook. Used for the flat `suve stage` alias when Vault
+// is the uniquely active staging provider.
+func FlatStageCommand(name string) *cli.Command {
+	return &cli.Command{
+		Name:            name,
+		Aliases:         []string{"stg"},
+		Usage:           "Manage staged changes for Vault KV v2",
+		Description:     stageDescription,
+		Flags:           scopeFlags(),
+		Before:          resolveScope,
+		Commands:        stageSubcommands(vaultStageConfig()),
+		CommandNotFound: cliinternal.CommandNotFound,
+	}
+}
@@ -0,0 +1,59 @@
+package vault
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	generictag "github.com/mpyw/suve/internal/cli/commands/generic/tag"
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// newTagger builds the Vault KV v2 provider.Tagger.
+func newTagger(ctx context.Context) (provider.Tagger, error) {
+	return cliinternal.VaultSecretStore(ctx)
+}
+
+// TagCommand returns the Vault KV v2 tag command.
+func TagCommand() *cli.Command {
+	return generictag.TagCommand(generictag.Config{
+		Usage:     `Add or update tags on a secret (Vault calls these "custom_metadata")`,
+		ArgsUsage: "<path> <key=value>...",
+		Description: `Add or update one or more tags on an existing secret.
+
+Tags are key=value pairs. If a tag key already exists, its value is updated.
+You can specify multiple tags in a single command.
+
+NOTE: Vault KV v2 stores these as the path's "custom_metadata". suve uses its
+cross-provider term "tags" for this key=value metadata everywhere.
+
+EXAMPLES:
+   suve vault secret tag app/db env=prod                 Add single tag
+   suve vault secret tag app/db env=prod team=backend    Add multiple tags`,
+		Noun:       nounSecret,
+		UsageError: "usage: suve vault secret tag <path> <key=value> [key=value]",
+		NewTagger:  newTagger,
+	})
+}
+
+// UntagCommand returns the Vault KV v2 untag command.
+func UntagCommand() *cli.Command {
+	return generictag.UntagCommand(generictag.Config{
+		Usage:     `Remove tags from a secret (Vault calls these "custom_metadata")`,
+		ArgsUsage: "<path> <key>...",
+		Description: `Remove one or more tags from an existing secret.
+
+Specify the tag keys to remove. Non-existent keys are silently ignored.
+
+NOTE: Vault KV v2 stores these as the path's "custom_metadata". suve uses its
+cross-provider term "tags" for this key=value metadata everywhere.
+
+EXAMPLES:
+   suve vault secret untag app/db deprecated             Remove single tag
+   suve vault secret untag app/db env team               Remove multiple tags`,
+		Noun:       nounSecret,
+		UsageError: "usage: suve vault secret untag <path> <key> [key]",
+		NewTagger:  newTagger,
+	})
+}
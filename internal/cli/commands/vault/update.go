@@ -0,0 +1,139 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	cliinternal "github.com/mpyw/suve/internal/cli/commands/internal"
+	"github.com/mpyw/suve/internal/cli/confirm"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/usecase/vault"
+)
+
+// UpdateRunner executes the update command.
+type UpdateRunner struct {
+	UseCase *vault.UpdateUseCase
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// UpdateOptions holds the options for the update command.
+type UpdateOptions struct {
+	Name  string
+	Value string
+}
+
+// UpdateCommand returns the Vault KV v2 update command.
+func UpdateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "update",
+		Usage:     "Update a secret value",
+		ArgsUsage: "<path> [<value>]",
+		Description: `Update the value of an existing secret by adding a new version.
+
+The new version becomes the latest; prior versions remain accessible by number.
+Use 'suve vault secret create' to create a new secret.
+
+The value may be given as a positional argument, read from stdin with
+--value-stdin (so it never appears in argv/ps or shell history), or, when
+omitted, typed into $EDITOR.
+
+EXAMPLES:
+  suve vault secret update app/api-key "new-value"       Add a new version
+  suve vault secret update --yes app/api-key "new-value" Update without confirmation
+  printf '%s' "$V" | suve vault secret update --yes app/key --value-stdin  Read value from stdin
+  suve vault secret update app/key                       Type value into $EDITOR`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "yes",
+				Usage: "Skip confirmation prompt",
+			},
+			cliinternal.ValueStdinFlag(),
+		},
+		Action: updateAction,
+	}
+}
+
+func updateAction(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() < 1 {
+		return errors.New("usage: suve vault secret update <path> [<value>]")
+	}
+
+	name := args.Get(0)
+	skipConfirm := cmd.Bool("yes")
+
+	newValue, proceed, err := cliinternal.ResolveValue(ctx, cliinternal.ValueSource{
+		FromStdin: cmd.Bool(cliinternal.FlagValueStdin),
+		HasArg:    args.Len() >= 2, //nolint:mnd // arg 0 is the name, arg 1 is the optional value
+		Arg:       args.Get(1),
+		Stdin:     cliinternal.Stdin(cmd),
+		// Without --yes we prompt for confirmation on the same stdin below;
+		// reading the value from stdin would leave nothing for that prompt.
+		ConfirmRequired: !skipConfirm,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !proceed {
+		output.Info(cmd.Root().Writer, "Empty value, nothing to update.")
+
+		return nil
+	}
+
+	store, err := cliinternal.VaultSecretStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	uc := &vault.UpdateUseCase{Store: store}
+
+	if !skipConfirm {
+		currentValue, _ := uc.GetCurrentValue(ctx, name)
+		if currentValue != "" {
+			diff := output.Diff(cmd.Root().ErrWriter, name+" (current)", name+" (new)", currentValue, newValue)
+			if diff != "" {
+				output.Println(cmd.Root().ErrWriter, diff)
+			}
+		}
+
+		prompter := &confirm.Prompter{
+			Stdin:  cliinternal.Stdin(cmd),
+			Stdout: cmd.Root().Writer,
+			Stderr: cmd.Root().ErrWriter,
+		}
+
+		confirmed, cerr := prompter.ConfirmAction("Update secret", name, false)
+		if cerr != nil {
+			return cerr
+		}
+
+		if !confirmed {
+			return nil
+		}
+	}
+
+	r := &UpdateRunner{
+		UseCase: uc,
+		Stdout:  cmd.Root().Writer,
+		Stderr:  cmd.Root().ErrWriter,
+	}
+
+	return r.Run(ctx, UpdateOptions{Name: name, Value: newValue})
+}
+
+// Run executes the update command.
+func (r *UpdateRunner) Run(ctx context.Context, opts UpdateOptions) error {
+	result, err := r.UseCase.Execute(ctx, vault.UpdateInput{Name: opts.Name, Value: opts.Value})
+	if err != nil {
+		return err
+	}
+
+	output.Success(r.Stdout, "Updated secret %s (version: %s)", result.Name, result.Version)
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package vault_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appcli "github.com/mpyw/suve/internal/cli/commands"
+	"github.com/mpyw/suve/internal/cli/commands/vault"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	vaultusecase "github.com/mpyw/suve/internal/usecase/vault"
+	"github.com/mpyw/suve/internal/version/vaultversion"
+)
+
+// TestCommandValidation exercises argument/spec validation that fails before any
+// provider store is resolved (so no Vault server is needed).
+func TestCommandValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "create missing args",
+			args:    []string{"suve", "vault", "secret", "create"},
+			wantErr: "usage:",
+		},
+		{
+			name:    "delete missing path",
+			args:    []string{"suve", "vault", "secret", "delete"},
+			wantErr: "usage:",
+		},
+		{
+			name:    "restore missing path",
+			args:    []string{"suve", "vault", "secret", "restore"},
+			wantErr: "usage:",
+		},
+		{
+			name:    "show rejects label spec",
+			args:    []string{"suve", "vault", "secret", "show", "app/db:latest"},
+			wantErr: "labels are not supported for Vault KV v2",
+		},
+		{
+			name:    "show without an address",
+			args:    []string{"suve", "vault", "--address", "", "secret", "show", "app/db"},
+			wantErr: "no Vault address specified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := appcli.MakeApp()
+			err := app.Run(t.Context(), tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestDeleteRunner(t *testing.T) {
+	t.Parallel()
+
+	var deleted string
+
+	store := &providermock.Store{
+		DeleteFunc: func(_ context.Context, name string, _ ...provider.DeleteOption) error {
+			deleted = name
+
+			return nil
+		},
+	}
+
+	var buf, errBuf bytes.Buffer
+
+	r := &vault.DeleteRunner{
+		UseCase: &vaultusecase.DeleteUseCase{Store: store},
+		Stdout:  &buf,
+		Stderr:  &errBuf,
+	}
+	require.NoError(t, r.Run(t.Context(), vault.DeleteOptions{Name: "app/db"}))
+	assert.Equal(t, "app/db", deleted)
+	assert.Contains(t, buf.String(), "Deleted secret app/db")
+	assert.Contains(t, buf.String(), "suve vault secret restore app/db")
+}
+
+func TestRestoreRunner(t *testing.T) {
+	t.Parallel()
+
+	store := &providermock.Store{
+		RestoreFunc: func(_ context.Context, name string) error {
+			assert.Equal(t, "app/db", name)
+
+			return nil
+		},
+	}
+
+	var buf, errBuf bytes.Buffer
+
+	r := &vault.RestoreRunner{
+		UseCase: &vaultusecase.RestoreUseCase{Restorer: store},
+		Stdout:  &buf,
+		Stderr:  &errBuf,
+	}
+	require.NoError(t, r.Run(t.Context(), vault.RestoreOptions{Name: "app/db"}))
+	assert.Contains(t, buf.String(), "Restored secret app/db")
+}
+
+func TestShowPresenter(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	store := &providermock.Store{
+		ResolveFunc: func(_ context.Context, _, _ string) (provider.VersionRef, error) {
+			return provider.VersionRef{}, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{
+				Name:    name,
+				Value:   "s3cr3t",
+				Type:    domain.ValueTypeSecret,
+				Version: domain.Version{ID: "3", Created: &created},
+				Tags:    []domain.Tag{{Key: "env", Value: "prod"}},
+			}, nil
+		},
+	}
+
+	spec, err := vaultversion.Parse("app/db")
+	require.NoError(t, err)
+
+	presenter := vault.NewShowPresenter(store, spec)
+	require.NoError(t, presenter.Fetch(t.Context()))
+
+	var buf, errBuf bytes.Buffer
+
+	value := presenter.Value(false, &errBuf)
+	presenter.RenderText(&buf, value)
+
+	out := buf.String()
+	assert.Contains(t, out, "app/db")
+	assert.Contains(t, out, "s3cr3t")
+	assert.Contains(t, out, "Tags")
+	assert.NotContains(t, out, "Description")
+
+	var jsonBuf bytes.Buffer
+	require.NoError(t, presenter.RenderJSON(&jsonBuf, value))
+
+	var showOut struct {
+		Name    string            `json:"name"`
+		Version string            `json:"version"`
+		Tags    map[string]string `json:"tags"`
+		Value   string            `json:"value"`
+	}
+	require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &showOut))
+	assert.Equal(t, "app/db", showOut.Name)
+	assert.Equal(t, "3", showOut.Version)
+	assert.Equal(t, "s3cr3t", showOut.Value)
+	assert.Equal(t, map[string]string{"env": "prod"}, showOut.Tags)
+}
@@ -0,0 +1,37 @@
+package terminal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MFATokenProvider returns a function that asks for an MFA code on out and
+// reads it from in, for use as an AWS assume-role token provider. It fails
+// instead of prompting when in is not a terminal, so piped input is never
+// consumed as a code.
+func MFATokenProvider(in io.Reader, out io.Writer) func() (string, error) {
+	return func() (string, error) {
+		if !IsTerminalReader(in) {
+			return "", errors.New("an MFA code is required but stdin is not a terminal")
+		}
+
+		if _, err := io.WriteString(out, "Enter MFA code: "); err != nil {
+			return "", err
+		}
+
+		line, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("failed to read MFA code: %w", err)
+		}
+
+		code := strings.TrimSpace(line)
+		if code == "" {
+			return "", errors.New("no MFA code entered")
+		}
+
+		return code, nil
+	}
+}
@@ -2,9 +2,11 @@ package terminal
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // mockFdWriter implements Fder for testing.
@@ -157,3 +159,48 @@ func TestIsTerminalReader_NonTTY(t *testing.T) {
 	r := &mockFdReader{fd: 0}
 	assert.False(t, IsTerminalReader(r))
 }
+
+// fdInput returns a terminal-shaped reader over input.
+func fdInput(input string) *mockFdReader {
+	r := &mockFdReader{}
+	r.buf.WriteString(input)
+
+	return r
+}
+
+//nolint:paralleltest // Test modifies package globals (IsTTY)
+func TestMFATokenProvider(t *testing.T) {
+	origIsTTY := IsTTY
+
+	defer func() { IsTTY = origIsTTY }()
+
+	IsTTY = func(_ uintptr) bool { return true }
+
+	t.Run("reads the code", func(t *testing.T) {
+		var out bytes.Buffer
+
+		code, err := MFATokenProvider(fdInput(" 123456 \nrest\n"), &out)()
+		require.NoError(t, err)
+		assert.Equal(t, "123456", code)
+		assert.Equal(t, "Enter MFA code: ", out.String())
+	})
+
+	t.Run("accepts a code without newline", func(t *testing.T) {
+		code, err := MFATokenProvider(fdInput("654321"), &bytes.Buffer{})()
+		require.NoError(t, err)
+		assert.Equal(t, "654321", code)
+	})
+
+	t.Run("rejects an empty code", func(t *testing.T) {
+		_, err := MFATokenProvider(fdInput("\n"), &bytes.Buffer{})()
+		require.EqualError(t, err, "no MFA code entered")
+	})
+
+	t.Run("rejects a non-terminal", func(t *testing.T) {
+		var out bytes.Buffer
+
+		_, err := MFATokenProvider(strings.NewReader("123456\n"), &out)()
+		require.Error(t, err)
+		assert.Empty(t, out.String())
+	})
+}
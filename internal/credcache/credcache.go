@@ -0,0 +1,273 @@
+// Package credcache is the opt-in on-disk cache of short-lived cloud
+// credentials (assumed-role and SSO sessions, OAuth access tokens), shared
+// across suve invocations so a shell loop over `suve param show` does not pay
+// for an STS call or an MFA prompt on every command:
+//
+//	~/.suve/credentials/{sha256(name)}
+//
+// The cache is enabled by SUVE_CREDENTIAL_CACHE. Entries are encrypted with the
+// staging data key (see internal/keyprovider) and carry their expiry; an
+// expired, undecryptable or unreadable entry is simply a miss. Credentials are
+// never cached in plaintext: without a key the cache stays off. `suve auth
+// logout` removes every entry (Clear).
+package credcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/mpyw/suve/internal/crypt"
+	"github.com/mpyw/suve/internal/keyprovider"
+	"github.com/mpyw/suve/internal/staging/store/file"
+)
+
+const (
+	// EnvCredentialCache names the env var that enables the cache. Parsed as a
+	// bool; any other non-empty value also enables it.
+	EnvCredentialCache = "SUVE_CREDENTIAL_CACHE"
+
+	baseDirName  = ".suve"
+	cacheDirName = "credentials"
+
+	// expiryMargin is how long before its expiry an entry stops being served,
+	// so a cached credential never lapses in the middle of a command.
+	expiryMargin = 5 * time.Minute
+)
+
+// ErrNoKey is returned by Open when no encryption key is available on this
+// platform (SUVE_STAGING_KEY unset and no OS keychain backend).
+var ErrNoKey = errors.New("no encryption key is available for the credential cache; set SUVE_STAGING_KEY")
+
+// Hooks for testing - these allow tests to override the environment, the home
+// directory, the key provider and the clock.
+//
+//nolint:gochecknoglobals // test hooks for dependency injection
+var (
+	lookupEnvFunc   = os.LookupEnv
+	userHomeDirFunc = os.UserHomeDir
+	resolveKeyFunc  = keyprovider.Resolve
+	mintKeyFunc     = file.MintKey
+	nowFunc         = time.Now
+)
+
+// Cache reads and writes encrypted credential entries.
+type Cache struct {
+	dir string
+	key []byte
+}
+
+// entry is the decrypted on-disk form of one cached credential. Name guards
+// against serving another entry's value on a file-name collision.
+type entry struct {
+	Name    string          `json:"name"`
+	Expires time.Time       `json:"expires"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// Enabled reports whether SUVE_CREDENTIAL_CACHE turns the cache on. Unset or
+// empty is off, a parseable bool is honored (so "0"/"false" stay off), and any
+// other non-empty value counts as on.
+func Enabled() bool {
+	v, ok := lookupEnvFunc(EnvCredentialCache)
+	if !ok || v == "" {
+		return false
+	}
+
+	if b, err := strconv.ParseBool(v); err == nil {
+		return b
+	}
+
+	return true
+}
+
+// Open returns the cache, or nil with a nil error when it is not enabled. The
+// data key is resolved like the staging store's; when the keychain has none
+// yet, it is minted through the staging store (file.MintKey), which refuses
+// while encrypted staging state exists. A platform with no key at all yields
+// ErrNoKey.
+func Open() (*Cache, error) {
+	if !Enabled() {
+		return nil, nil //nolint:nilnil // a disabled cache is not an error
+	}
+
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil { //nolint:mnd // owner-only directory
+		return nil, fmt.Errorf("failed to create credential cache directory: %w", err)
+	}
+
+	key, err := resolveKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cache{dir: dir, key: key}, nil
+}
+
+// resolveKey resolves the data key, minting it on a first run through the
+// staging store's guarded path so the cache and the staging stores never mint
+// two different keys.
+func resolveKey() ([]byte, error) {
+	key, plaintext, needsMint, err := resolveKeyFunc()
+
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("failed to resolve credential cache key: %w", err)
+	case plaintext:
+		return nil, ErrNoKey
+	case !needsMint:
+		return key, nil
+	}
+
+	key, err = mintKeyFunc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve credential cache key: %w", err)
+	}
+
+	return key, nil
+}
+
+// cacheDir returns ~/.suve/credentials.
+func cacheDir() (string, error) {
+	home, err := userHomeDirFunc()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(home, baseDirName, cacheDirName), nil
+}
+
+// path returns the entry file for name. The name is hashed so it can carry
+// profile names, ARNs and scopes without escaping.
+func (c *Cache) path(name string) string {
+	sum := sha256.Sum256([]byte(name))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// Load decodes the entry cached under name into v and reports whether an
+// unexpired one was found. Entries that are expired (or about to be) are
+// removed.
+func (c *Cache) Load(name string, v any) bool {
+	path := c.path(name)
+
+	data, err := os.ReadFile(path) //nolint:gosec // path is a hash under suve's own cache directory
+	if err != nil {
+		return false
+	}
+
+	plain, err := crypt.DecryptWithKey(data, c.key)
+	if err != nil {
+		return false
+	}
+
+	var e entry
+	if err := json.Unmarshal(plain, &e); err != nil || e.Name != name {
+		return false
+	}
+
+	if !nowFunc().Add(expiryMargin).Before(e.Expires) {
+		_ = os.Remove(path)
+
+		return false
+	}
+
+	return json.Unmarshal(e.Value, v) == nil
+}
+
+// Save caches v under name until expires. A value that expires within the
+// expiry margin is not worth caching and is skipped.
+func (c *Cache) Save(name string, v any, expires time.Time) error {
+	if !nowFunc().Add(expiryMargin).Before(expires) {
+		return nil
+	}
+
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	plain, err := json.Marshal(entry{Name: name, Expires: expires, Value: value})
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	data, err := crypt.EncryptWithKey(plain, c.key)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt credentials: %w", err)
+	}
+
+	return writeFileAtomic(c.path(name), data)
+}
+
+// writeFileAtomic writes data to a temp file in the same directory and renames
+// it over path, so a concurrent reader never sees a torn entry.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write credential cache: %w", err)
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("failed to write credential cache: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write credential cache: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write credential cache: %w", err)
+	}
+
+	return nil
+}
+
+// Clear removes every cached entry and returns how many were removed. It needs
+// no key and works whether or not the cache is enabled.
+func Clear() (int, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return 0, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to read credential cache: %w", err)
+	}
+
+	removed := 0
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+			return removed, fmt.Errorf("failed to remove cached credentials: %w", err)
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
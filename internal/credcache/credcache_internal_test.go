@@ -0,0 +1,174 @@
+package credcache //nolint:testpackage // tests override unexported hook vars.
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/crypt"
+	"github.com/mpyw/suve/internal/keyprovider"
+)
+
+// testNow is the fixed clock the tests run at.
+var testNow = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) //nolint:gochecknoglobals // test fixture
+
+// withHooks points the cache at a temp home with the cache enabled, a fixed
+// clock and the given key, restoring the hooks afterwards. It returns the home.
+func withHooks(t *testing.T, key []byte) string {
+	t.Helper()
+
+	home := t.TempDir()
+
+	origLookup, origHome, origResolve, origMint, origNow := lookupEnvFunc, userHomeDirFunc, resolveKeyFunc, mintKeyFunc, nowFunc
+
+	t.Cleanup(func() {
+		lookupEnvFunc, userHomeDirFunc, resolveKeyFunc, mintKeyFunc, nowFunc = origLookup, origHome, origResolve, origMint, origNow
+	})
+
+	lookupEnvFunc = func(k string) (string, bool) {
+		if k == EnvCredentialCache {
+			return "1", true
+		}
+
+		return "", false
+	}
+	userHomeDirFunc = func() (string, error) { return home, nil }
+	resolveKeyFunc = func() ([]byte, bool, bool, error) { return key, false, false, nil }
+	mintKeyFunc = func() ([]byte, error) { t.Fatal("unexpected mint"); return nil, nil }
+	nowFunc = func() time.Time { return testNow }
+
+	return home
+}
+
+func testKey(b byte) []byte { return bytes.Repeat([]byte{b}, 32) }
+
+type creds struct {
+	Token string `json:"token"`
+}
+
+//nolint:paralleltest // overrides package-level hook vars.
+func TestEnabled(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "0": false, "false": false, "1": true, "true": true, "yes": true} {
+		withHooks(t, nil)
+		lookupEnvFunc = func(string) (string, bool) { return value, true }
+
+		assert.Equal(t, want, Enabled(), "value %q", value)
+	}
+
+	withHooks(t, nil)
+	lookupEnvFunc = func(string) (string, bool) { return "", false }
+
+	c, err := Open()
+	require.NoError(t, err)
+	assert.Nil(t, c, "a disabled cache opens as nil")
+}
+
+//nolint:paralleltest // overrides package-level hook vars.
+func TestCache_SaveLoad(t *testing.T) {
+	home := withHooks(t, testKey(1))
+
+	c, err := Open()
+	require.NoError(t, err)
+	require.NotNil(t, c)
+
+	require.NoError(t, c.Save("aws/dev", creds{Token: "t1"}, testNow.Add(time.Hour)))
+
+	var got creds
+	require.True(t, c.Load("aws/dev", &got))
+	assert.Equal(t, "t1", got.Token)
+	assert.False(t, c.Load("aws/prod", &got), "another name misses")
+
+	files, err := os.ReadDir(filepath.Join(home, ".suve", "credentials"))
+	require.NoError(t, err)
+
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(home, ".suve", "credentials", f.Name()))
+		require.NoError(t, err)
+		assert.True(t, crypt.IsEncrypted(data), "entries are encrypted")
+		assert.NotContains(t, string(data), "t1")
+
+		info, err := f.Info()
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	other := &Cache{dir: c.dir, key: testKey(2)}
+	assert.False(t, other.Load("aws/dev", &got), "another key cannot read the entry")
+}
+
+//nolint:paralleltest // overrides package-level hook vars.
+func TestCache_Expiry(t *testing.T) {
+	withHooks(t, testKey(1))
+
+	c, err := Open()
+	require.NoError(t, err)
+
+	require.NoError(t, c.Save("soon", creds{Token: "x"}, testNow.Add(time.Minute)))
+	_, err = os.Stat(c.path("soon"))
+	assert.ErrorIs(t, err, os.ErrNotExist, "a credential inside the margin is not cached")
+
+	require.NoError(t, c.Save("later", creds{Token: "y"}, testNow.Add(10*time.Minute)))
+
+	nowFunc = func() time.Time { return testNow.Add(6 * time.Minute) }
+
+	var got creds
+	assert.False(t, c.Load("later", &got), "an entry about to expire is a miss")
+	_, err = os.Stat(c.path("later"))
+	assert.ErrorIs(t, err, os.ErrNotExist, "and is removed")
+}
+
+//nolint:paralleltest // overrides package-level hook vars.
+func TestOpen_Key(t *testing.T) {
+	t.Run("no key on this platform", func(t *testing.T) {
+		withHooks(t, nil)
+		resolveKeyFunc = func() ([]byte, bool, bool, error) { return nil, true, false, nil }
+
+		_, err := Open()
+		assert.ErrorIs(t, err, ErrNoKey)
+	})
+
+	t.Run("first run mints", func(t *testing.T) {
+		withHooks(t, nil)
+		resolveKeyFunc = func() ([]byte, bool, bool, error) { return nil, false, true, nil }
+		mintKeyFunc = func() ([]byte, error) { return testKey(3), nil }
+
+		c, err := Open()
+		require.NoError(t, err)
+		assert.Equal(t, testKey(3), c.key)
+	})
+
+	t.Run("a refused mint leaves the cache off", func(t *testing.T) {
+		withHooks(t, nil)
+		resolveKeyFunc = func() ([]byte, bool, bool, error) { return nil, false, true, nil }
+		mintKeyFunc = func() ([]byte, error) { return nil, keyprovider.ErrKeychainKeyNotFound }
+
+		_, err := Open()
+		assert.ErrorIs(t, err, keyprovider.ErrKeychainKeyNotFound)
+	})
+}
+
+//nolint:paralleltest // overrides package-level hook vars.
+func TestClear(t *testing.T) {
+	withHooks(t, testKey(1))
+
+	n, err := Clear()
+	require.NoError(t, err)
+	assert.Zero(t, n, "no cache directory yet")
+
+	c, err := Open()
+	require.NoError(t, err)
+	require.NoError(t, c.Save("a", creds{}, testNow.Add(time.Hour)))
+	require.NoError(t, c.Save("b", creds{}, testNow.Add(time.Hour)))
+
+	n, err = Clear()
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	var got creds
+	assert.False(t, c.Load("a", &got))
+}
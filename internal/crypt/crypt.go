@@ -1,4 +1,4 @@
-// Package crypt provides encryption for staging files.
+// Package crypt provides encryption for staging files and the credential cache.
 //
 // Two on-disk formats are supported:
 //
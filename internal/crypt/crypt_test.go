@@ -8,7 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/mpyw/suve/internal/staging/store/file/internal/crypt"
+	"github.com/mpyw/suve/internal/crypt"
 )
 
 func TestEncryptDecrypt(t *testing.T) {
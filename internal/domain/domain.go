@@ -19,6 +19,9 @@ const (
 	ValueTypeSecret ValueType = "secret" // AWS SecureString, Secrets Manager
 	// ValueTypeList is a list of values (AWS StringList).
 	ValueTypeList ValueType = "list" // AWS StringList
+	// ValueTypeReference is a pointer to a secret held in another store rather
+	// than the value itself (Azure App Configuration Key Vault reference).
+	ValueTypeReference ValueType = "reference" // Azure App Configuration Key Vault reference
 )
 
 // Version identifies one version of an entry.
@@ -57,6 +60,15 @@ type Version struct {
 	// tags per version (each version has its own); every other provider keeps
 	// tags at the resource level (see Entry.Tags) and leaves this nil.
 	Tags []Tag
+	// NotBefore and Expires bound THIS version's activation window. Only Azure
+	// Key Vault carries them (the nbf / exp attributes); nil when unset or for
+	// every other provider.
+	NotBefore *time.Time
+	Expires   *time.Time
+	// Message is the change message THIS version was written with (`stage
+	// apply -m`), where the provider keeps one per version (see
+	// provider.ChangeMessage); "" otherwise.
+	Message string
 }
 
 // Tag is a single key/value label attached to an entry.
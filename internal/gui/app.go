@@ -168,7 +168,8 @@ func (a *App) Startup(ctx context.Context) {
 
 // ScopeSelection is the frontend-supplied provider + scope for read/write
 // operations. Only the fields relevant to the chosen provider are read:
-//   - aws: (none; the ambient AWS config supplies the region)
+//   - aws: Profile, the optional shared-config profile (empty means the
+//     ambient credential chain); the profile supplies the region
 //   - googlecloud: ProjectID
 //   - azure: VaultName (Key Vault secret) and/or StoreName (App Configuration
 //     param); Namespace is the optional App Configuration namespace (Azure
@@ -176,6 +177,7 @@ func (a *App) Startup(ctx context.Context) {
 //     means the default (null) namespace.
 type ScopeSelection struct {
 	Provider  string `json:"provider"`
+	Profile   string `json:"profile"`
 	ProjectID string `json:"projectId"`
 	VaultName string `json:"vaultName"`
 	StoreName string `json:"storeName"`
@@ -194,12 +196,28 @@ func (a *App) SelectScope(sel ScopeSelection) error {
 	}
 
 	a.scopeMu.Lock()
-	a.scope = scope
+	a.scope = keepLaunchRole(a.scope, scope)
 	a.scopeMu.Unlock()
 
 	return nil
 }
 
+// keepLaunchRole carries the role assumed at launch (--role-arn) over to next
+// while the AWS profile it was assumed from stays selected. Switching to another
+// profile drops it, as the TUI does: the role was chosen for the launch profile.
+func keepLaunchRole(prev, next provider.Scope) provider.Scope {
+	if next.Provider != provider.ProviderAWS || prev.Provider != provider.ProviderAWS || prev.AWSProfile != next.AWSProfile {
+		return next
+	}
+
+	next.AWSRoleARN = prev.AWSRoleARN
+	next.AWSExternalID = prev.AWSExternalID
+	next.AWSSessionName = prev.AWSSessionName
+	next.AWSMFASerial = prev.AWSMFASerial
+
+	return next
+}
+
 // scopeFromSelection maps a frontend selection to a provider.Scope, rejecting
 // selections whose required fields are empty. For Azure a single scope carries
 // both VaultName and StoreName, so the registry can build either the Key Vault
@@ -207,7 +225,7 @@ func (a *App) SelectScope(sel ScopeSelection) error {
 func scopeFromSelection(sel ScopeSelection) (provider.Scope, error) {
 	switch provider.Provider(sel.Provider) {
 	case provider.ProviderAWS:
-		return provider.Scope{Provider: provider.ProviderAWS}, nil
+		return provider.Scope{Provider: provider.ProviderAWS, AWSProfile: sel.Profile}, nil
 	case provider.ProviderGoogleCloud:
 		if sel.ProjectID == "" {
 			return provider.Scope{}, errGoogleCloudProjectRequired
@@ -265,6 +283,7 @@ func (a *App) EnvScope(providerName string) *ScopeSelection {
 func selectionFromScope(s provider.Scope) *ScopeSelection {
 	return &ScopeSelection{
 		Provider:  string(s.Provider),
+		Profile:   s.AWSProfile,
 		ProjectID: s.ProjectID,
 		VaultName: s.VaultName,
 		StoreName: s.StoreName,
@@ -297,12 +316,15 @@ func (a *App) stagingScopeScoped(sc provider.Scope) (provider.Scope, error) {
 		return sc, nil
 	}
 
-	identity, err := infra.GetAWSIdentity(a.ctx)
+	identity, err := infra.GetAWSIdentity(aws.WithScope(a.ctx, sc))
 	if err != nil {
 		return provider.Scope{}, err
 	}
 
-	return provider.AWSScope(identity.AccountID, identity.Region), nil
+	scope := provider.AWSScope(identity.AccountID, identity.Region)
+	scope.AWSProfile = sc.AWSProfile
+
+	return scope, nil
 }
 
 // stagingScopeForKind resolves the staging scope for ONE service kind. It exists
@@ -473,7 +495,14 @@ func (a *App) getStagingStoreScoped(sc provider.Scope, kind provider.Kind) (stor
 		return nil, err
 	}
 
-	key := scope.Key()
+	// The active changeset is part of the key, so a switch (from the CLI or the
+	// changeset selector) opens the newly active store on the next access.
+	changeset, err := file.ActiveChangeset(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	key := scope.Key() + "@" + changeset
 
 	a.stagingStoreMu.Lock()
 	defer a.stagingStoreMu.Unlock()
@@ -482,7 +511,7 @@ func (a *App) getStagingStoreScoped(sc provider.Scope, kind provider.Kind) (stor
 		return s, nil
 	}
 
-	s, err := file.NewWorkingStore(scope)
+	s, err := file.NewChangesetStore(scope, changeset)
 	if err != nil {
 		return nil, err
 	}
@@ -627,9 +656,10 @@ type AWSIdentityResult struct {
 	Profile   string `json:"profile"`
 }
 
-// GetAWSIdentity returns the current AWS account ID, region, and profile.
+// GetAWSIdentity returns the current AWS account ID, region, and profile,
+// resolved through the selected profile (and launch role) when there is one.
 func (a *App) GetAWSIdentity() (*AWSIdentityResult, error) {
-	identity, err := infra.GetAWSIdentity(a.ctx)
+	identity, err := infra.GetAWSIdentity(aws.WithScope(a.ctx, a.currentScope()))
 	if err != nil {
 		return nil, err
 	}
@@ -640,3 +670,23 @@ func (a *App) GetAWSIdentity() (*AWSIdentityResult, error) {
 		Profile:   identity.Profile,
 	}, nil
 }
+
+// AWSProfile is one profile from the shared AWS config, for the profile picker.
+type AWSProfile struct {
+	Name      string `json:"name"`
+	AccountID string `json:"accountId"`
+}
+
+// ListAWSProfiles returns the profiles in the shared AWS config, sorted by name.
+// AccountID is set when the profile names a role or SSO account. A missing
+// config yields an empty list.
+func (a *App) ListAWSProfiles() []AWSProfile {
+	profiles := infra.ListProfiles()
+	out := make([]AWSProfile, 0, len(profiles))
+
+	for _, p := range profiles {
+		out = append(out, AWSProfile{Name: p.Name, AccountID: p.AccountID})
+	}
+
+	return out
+}
@@ -23,7 +23,7 @@ const modelsPath = "frontend/wailsjs/go/models.ts"
 func dtoContract() []any {
 	return []any{
 		// app.go
-		AWSIdentityResult{}, ScopeSelection{},
+		AWSIdentityResult{}, AWSProfile{}, ScopeSelection{},
 		// providers.go
 		DetectResult{}, ServiceCapability{}, ProviderCapability{},
 		// param.go
@@ -68,6 +68,12 @@ export interface StagedTagEntry {
   service?: Service;
 }
 
+// StagingItemKey names one staged item for a selective StagingApply/StagingReset.
+export interface StagingItemKey {
+  name: string;
+  namespace: string;
+}
+
 export interface ParamLogEntry {
   version: number;
   value: string;
@@ -99,6 +105,7 @@ export interface AWSIdentity {
 // fields relevant to the chosen provider are meaningful.
 export interface ScopeSelection {
   provider: string;
+  profile?: string;
   projectId: string;
   vaultName: string;
   storeName: string;
@@ -170,6 +177,9 @@ export interface MockState {
   pageSize: number;
   // AWS Identity
   awsIdentity: AWSIdentity;
+  // awsProfiles is what ListAWSProfiles returns (the shared-config profiles
+  // offered by the AWS scope form).
+  awsProfiles: { name: string; accountId: string }[];
   // Virtual file system for export/import, keyed by path. Import tests seed
   // entries here; export writes to state.savePath.
   files: Record<string, ExportFile>;
@@ -362,6 +372,10 @@ export const defaultMockState: MockState = {
     region: 'ap-northeast-1',
     profile: 'production',
   },
+  awsProfiles: [
+    { name: 'production', accountId: '123456789012' },
+    { name: 'staging', accountId: '210987654321' },
+  ],
   files: {},
   savePath: '/mock/exports/export.json',
   openPath: '',
@@ -787,10 +801,22 @@ export async function setupWailsMocks(page: Page, customState?: Partial<MockStat
       paramTags: StagedTagEntry[];
       secretTags: StagedTagEntry[];
     }> = {};
+    // Named changesets are further buckets of the provider (default = the
+    // provider's own), mirroring the CLI's per-scope changesets/{name}/ dirs.
+    const changesets: Record<string, { names: string[]; active: string }> = {};
+    function currentChangesets() {
+      const p = state.currentScope?.provider || 'aws';
+      if (!changesets[p]) {
+        changesets[p] = { names: ['default'], active: 'default' };
+      }
+      return changesets[p];
+    }
     function currentBucket() {
       const p = state.currentScope?.provider || 'aws';
-      if (!stagedBuckets[p]) {
-        stagedBuckets[p] = p === 'aws'
+      const cs = currentChangesets().active;
+      const key = cs === 'default' ? p : `${p}@${cs}`;
+      if (!stagedBuckets[key]) {
+        stagedBuckets[key] = key === 'aws'
           ? {
               param: state.stagedParam,
               secret: state.stagedSecret,
@@ -799,7 +825,14 @@ export async function setupWailsMocks(page: Page, customState?: Partial<MockStat
             }
           : { param: [], secret: [], paramTags: [], secretTags: [] };
       }
-      return stagedBuckets[p];
+      return stagedBuckets[key];
+    }
+
+    // itemSelector matches the staged entries/tags of the given items; no keys
+    // match everything (a whole-service apply/reset).
+    function itemSelector(keys?: StagingItemKey[] | null) {
+      return (x: { name: string; namespace?: string }) =>
+        !keys?.length || keys.some((k) => k.name === x.name && (k.namespace ?? '') === (x.namespace ?? ''));
     }
 
     // expectedScopeKey mirrors provider.Scope.Key() PER SERVICE (the #445 fix):
@@ -872,6 +905,7 @@ export async function setupWailsMocks(page: Page, customState?: Partial<MockStat
         // Keep only the provider-relevant fields, matching scopeFromSelection.
         state.currentScope = {
           provider: p,
+          profile: p === 'aws' ? (sel.profile || '') : '',
           projectId: p === 'googlecloud' ? (sel.projectId || '') : '',
           vaultName: p === 'azure' ? (sel.vaultName || '') : '',
           storeName: p === 'azure' ? (sel.storeName || '') : '',
@@ -880,6 +914,7 @@ export async function setupWailsMocks(page: Page, customState?: Partial<MockStat
       },
 
       // AWS Identity
+      ListAWSProfiles: async () => state.awsProfiles,
       GetAWSIdentity: async () => {
         calls.push('GetAWSIdentity');
         if (state.simulateError?.operation === 'GetAWSIdentity') {
@@ -1244,14 +1279,16 @@ export async function setupWailsMocks(page: Page, customState?: Partial<MockStat
           })),
         };
       },
-      StagingApply: async (service: string) => {
+      StagingApply: async (service: string, _ignoreConflicts?: boolean, keys?: StagingItemKey[] | null) => {
         // Reject for the designated service WITHOUT unstaging it, mirroring the
         // backend turning any Execute error into a rejected promise (#477).
         if (state.stagingApplyFailService === service) {
           throw new Error(`staging apply failed for ${service}`);
         }
-        const staged = service === 'param' ? currentBucket().param : currentBucket().secret;
-        const tagStaged = service === 'param' ? currentBucket().paramTags : currentBucket().secretTags;
+        // Checked rows narrow the apply to those items (entries and tags alike).
+        const selected = itemSelector(keys);
+        const staged = (service === 'param' ? currentBucket().param : currentBucket().secret).filter(selected);
+        const tagStaged = (service === 'param' ? currentBucket().paramTags : currentBucket().secretTags).filter(selected);
         const entryCount = staged.length;
         const tagCount = tagStaged.length;
         // The cloud write landed but clearing the staged entries failed: report
@@ -1269,11 +1306,11 @@ export async function setupWailsMocks(page: Page, customState?: Partial<MockStat
           };
         }
         if (service === 'param') {
-          currentBucket().param = [];
-          currentBucket().paramTags = [];
+          currentBucket().param = currentBucket().param.filter((s: any) => !selected(s));
+          currentBucket().paramTags = currentBucket().paramTags.filter((t: any) => !selected(t));
         } else {
-          currentBucket().secret = [];
-          currentBucket().secretTags = [];
+          currentBucket().secret = currentBucket().secret.filter((s: any) => !selected(s));
+          currentBucket().secretTags = currentBucket().secretTags.filter((t: any) => !selected(t));
         }
         // Mirror the Go backend faithfully: empty slices marshal to null (not
         // []), so the frontend must guard spreads/reads. Returning [] here would
@@ -1290,17 +1327,19 @@ export async function setupWailsMocks(page: Page, customState?: Partial<MockStat
           tagFailed: 0,
         };
       },
-      StagingReset: async (service: string) => {
+      StagingReset: async (service: string, keys?: StagingItemKey[] | null) => {
+        const selected = itemSelector(keys);
+        const type = keys?.length ? 'unstagedSelected' : 'all';
         if (service === 'param') {
-          const count = currentBucket().param.length + currentBucket().paramTags.length;
-          currentBucket().param = [];
-          currentBucket().paramTags = [];
-          return { type: 'all', serviceName: 'param', count };
+          const count = currentBucket().param.filter(selected).length + currentBucket().paramTags.filter(selected).length;
+          currentBucket().param = currentBucket().param.filter((s: any) => !selected(s));
+          currentBucket().paramTags = currentBucket().paramTags.filter((t: any) => !selected(t));
+          return { type, serviceName: 'param', count };
         } else {
-          const count = currentBucket().secret.length + currentBucket().secretTags.length;
-          currentBucket().secret = [];
-          currentBucket().secretTags = [];
-          return { type: 'all', serviceName: 'secret', count };
+          const count = currentBucket().secret.filter(selected).length + currentBucket().secretTags.filter(selected).length;
+          currentBucket().secret = currentBucket().secret.filter((s: any) => !selected(s));
+          currentBucket().secretTags = currentBucket().secretTags.filter((t: any) => !selected(t));
+          return { type, serviceName: 'secret', count };
         }
       },
       StagingAdd: async (service: string, name: string, value: string, namespace?: string) => {
@@ -1504,6 +1543,25 @@ export async function setupWailsMocks(page: Page, customState?: Partial<MockStat
 
         return { merged, entryCount, tagCount };
       },
+      StagingChangesets: async () => {
+        const cs = currentChangesets();
+        return { names: [...cs.names], active: cs.active };
+      },
+      StagingSwitchChangeset: async (name: string, create: boolean) => {
+        const cs = currentChangesets();
+        const exists = cs.names.includes(name);
+        if (create && exists) {
+          throw new Error(`changeset already exists: ${name}`);
+        }
+        if (!create && !exists) {
+          throw new Error(`changeset not found: ${name}`);
+        }
+        if (!exists) {
+          cs.names = ['default', ...[...cs.names.slice(1), name].sort()];
+        }
+        cs.active = name;
+        return { names: [...cs.names], active: cs.active };
+      },
       StagingCheckStatus: async (service: string, name: string, namespace?: string) => {
         const staged = service === 'param' ? currentBucket().param : currentBucket().secret;
         const tagStaged = service === 'param' ? currentBucket().paramTags : currentBucket().secretTags;
@@ -321,3 +321,42 @@ test.describe('Azure scope form', () => {
     expect(noOverflow).toBe(true);
   });
 });
+
+test.describe('AWS profile form', () => {
+  test('Change scope lists the shared-config profiles and switches to the picked one', async ({ page }) => {
+    await setupWailsMocks(page);
+    await page.goto('/');
+    await waitForItemList(page);
+
+    await page.getByRole('button', { name: 'Change scope' }).click();
+    const select = page.locator('#aws-profile');
+    await expect(select).toBeVisible();
+    await expect(select.locator('option')).toHaveText(['(ambient)', 'production (123456789012)', 'staging (210987654321)']);
+
+    await select.selectOption('staging');
+    await page.getByRole('button', { name: 'Connect' }).click();
+    await waitForItemList(page);
+
+    const calls = await getSelectScopeCalls(page);
+    expect(calls[calls.length - 1]).toMatchObject({ provider: 'aws', profile: 'staging' });
+    await expect(page.locator('#aws-profile')).toHaveCount(0);
+  });
+
+  test('Change scope prefills the current profile and (ambient) clears it', async ({ page }) => {
+    await setupWailsMocks(page, {
+      currentScope: { provider: 'aws', profile: 'production', projectId: '', vaultName: '', storeName: '', namespace: '' },
+    });
+    await page.goto('/');
+    await waitForItemList(page);
+
+    await page.getByRole('button', { name: 'Change scope' }).click();
+    await expect(page.locator('#aws-profile')).toHaveValue('production');
+
+    await page.locator('#aws-profile').selectOption('');
+    await page.getByRole('button', { name: 'Connect' }).click();
+    await waitForItemList(page);
+
+    const calls = await getSelectScopeCalls(page);
+    expect(calls[calls.length - 1]).toMatchObject({ provider: 'aws', profile: '' });
+  });
+});
@@ -0,0 +1,50 @@
+import { test, expect } from './fixtures/coverage';
+import {
+  setupWailsMocks,
+  createStagedValue,
+  navigateTo,
+} from './fixtures/wails-mock';
+
+// Named changesets: the staging view's selector switches the staging area every
+// staging call reads, like the CLI's `suve stage switch`.
+
+test.describe('Staging changesets', () => {
+  test('creates a changeset, switches to it and back', async ({ page }) => {
+    await setupWailsMocks(page, {
+      stagedParam: [createStagedValue('/app/param-a', 'create', 'pv')],
+    });
+    await page.goto('/');
+    await navigateTo(page, 'Staging');
+
+    const select = page.getByTestId('changeset-select');
+    await expect(select).toHaveValue('default');
+    await expect(page.locator('.entry-item').filter({ hasText: '/app/param-a' })).toBeVisible();
+
+    await select.selectOption('__new__');
+    await page.locator('#new-changeset-name').fill('rotate-db');
+    await page.getByRole('button', { name: 'Create and Switch' }).click();
+
+    // The new changeset is active and starts empty.
+    await expect(select).toHaveValue('rotate-db');
+    await expect(page.locator('.entry-item').filter({ hasText: '/app/param-a' })).toHaveCount(0);
+
+    // Switching back restores the default changeset's staged changes.
+    await select.selectOption('default');
+    await expect(select).toHaveValue('default');
+    await expect(page.locator('.entry-item').filter({ hasText: '/app/param-a' })).toBeVisible();
+  });
+
+  test('rejects creating a changeset that exists', async ({ page }) => {
+    await setupWailsMocks(page);
+    await page.goto('/');
+    await navigateTo(page, 'Staging');
+
+    const select = page.getByTestId('changeset-select');
+    await select.selectOption('__new__');
+    await page.locator('#new-changeset-name').fill('default');
+    await page.getByRole('button', { name: 'Create and Switch' }).click();
+
+    await expect(page.locator('.modal-error')).toContainText('already exists');
+    await expect(select).toHaveValue('default');
+  });
+});
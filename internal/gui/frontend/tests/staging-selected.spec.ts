@@ -0,0 +1,57 @@
+import { test, expect, type Page } from './fixtures/coverage';
+import {
+  setupWailsMocks,
+  createStagedValue,
+  navigateTo,
+} from './fixtures/wails-mock';
+
+// Checked rows narrow Apply/Reset to the checked items — the GUI side of the
+// CLI's selective `stage apply --match ...` / `stage reset --match ...`.
+
+function stagedState() {
+  return {
+    stagedParam: [
+      createStagedValue('/app/param-a', 'create', 'a'),
+      createStagedValue('/app/param-b', 'create', 'b'),
+    ],
+    stagedSecret: [createStagedValue('secret-a', 'create', 'sv')],
+  };
+}
+
+const row = (page: Page, name: string) =>
+  page.locator('.entry-item').filter({ hasText: name });
+
+test.describe('Staging selected items', () => {
+  test('Apply Selected applies only the checked items', async ({ page }) => {
+    await setupWailsMocks(page, stagedState());
+    await page.goto('/');
+    await navigateTo(page, 'Staging');
+
+    await page.getByRole('checkbox', { name: 'Select /app/param-a' }).check();
+    await page.getByRole('button', { name: 'Apply Selected' }).click();
+    await expect(page.locator('.modal')).toContainText('1 selected item(s)');
+    await page.locator('.form-actions').getByRole('button', { name: 'Apply', exact: true }).click();
+    await page.getByRole('button', { name: 'Close' }).click();
+
+    await expect(row(page, '/app/param-a')).toHaveCount(0);
+    await expect(row(page, '/app/param-b')).toBeVisible();
+    await expect(row(page, 'secret-a')).toBeVisible();
+    // The applied item's check is gone with it.
+    await expect(page.getByRole('button', { name: 'Apply All' })).toBeVisible();
+  });
+
+  test('a section Reset resets only its checked items', async ({ page }) => {
+    await setupWailsMocks(page, stagedState());
+    await page.goto('/');
+    await navigateTo(page, 'Staging');
+
+    await page.getByRole('checkbox', { name: 'Select /app/param-b' }).check();
+    await page.locator('.btn-reset-sm').first().click();
+    await expect(page.locator('.modal')).toContainText('1 selected item(s)');
+    await page.locator('.form-actions').locator('.btn-danger').click();
+
+    await expect(row(page, '/app/param-b')).toHaveCount(0);
+    await expect(row(page, '/app/param-a')).toBeVisible();
+    await expect(row(page, 'secret-a')).toBeVisible();
+  });
+});
@@ -18,6 +18,8 @@ export function InitialService():Promise<string>;
 
 export function InspectImportFile(arg1:string):Promise<gui.EnvelopeInfoResult>;
 
+export function ListAWSProfiles():Promise<Array<gui.AWSProfile>>;
+
 export function ParamAddTag(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
 
 export function ParamDelete(arg1:string,arg2:string):Promise<gui.ParamDeleteResult>;
@@ -66,12 +68,14 @@ export function StagingAdd(arg1:string,arg2:string,arg3:string,arg4:string):Prom
 
 export function StagingAddTag(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<gui.StagingAddTagResult>;
 
-export function StagingApply(arg1:string,arg2:boolean):Promise<gui.StagingApplyResult>;
+export function StagingApply(arg1:string,arg2:boolean,arg3:Array<gui.StagingItemKey>):Promise<gui.StagingApplyResult>;
 
 export function StagingCancelAddTag(arg1:string,arg2:string,arg3:string,arg4:string):Promise<gui.StagingCancelAddTagResult>;
 
 export function StagingCancelRemoveTag(arg1:string,arg2:string,arg3:string,arg4:string):Promise<gui.StagingCancelRemoveTagResult>;
 
+export function StagingChangesets():Promise<gui.StagingChangesetsResult>;
+
 export function StagingCheckStatus(arg1:string,arg2:string,arg3:string):Promise<gui.StagingCheckStatusResult>;
 
 export function StagingDelete(arg1:string,arg2:string,arg3:boolean,arg4:number,arg5:string):Promise<gui.StagingDeleteResult>;
@@ -86,8 +90,10 @@ export function StagingImport(arg1:string,arg2:string,arg3:string,arg4:string,ar
 
 export function StagingRemoveTag(arg1:string,arg2:string,arg3:string,arg4:string):Promise<gui.StagingRemoveTagResult>;
 
-export function StagingReset(arg1:string):Promise<gui.StagingResetResult>;
+export function StagingReset(arg1:string,arg2:Array<gui.StagingItemKey>):Promise<gui.StagingResetResult>;
 
 export function StagingStatus():Promise<gui.StagingStatusResult>;
 
+export function StagingSwitchChangeset(arg1:string,arg2:boolean):Promise<gui.StagingChangesetsResult>;
+
 export function StagingUnstage(arg1:string,arg2:string,arg3:string):Promise<gui.StagingUnstageResult>;
@@ -34,6 +34,10 @@ export function InspectImportFile(arg1) {
   return window['go']['gui']['App']['InspectImportFile'](arg1);
 }
 
+export function ListAWSProfiles() {
+  return window['go']['gui']['App']['ListAWSProfiles']();
+}
+
 export function ParamAddTag(arg1, arg2, arg3, arg4) {
   return window['go']['gui']['App']['ParamAddTag'](arg1, arg2, arg3, arg4);
 }
@@ -130,8 +134,8 @@ export function StagingAddTag(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['gui']['App']['StagingAddTag'](arg1, arg2, arg3, arg4, arg5);
 }
 
-export function StagingApply(arg1, arg2) {
-  return window['go']['gui']['App']['StagingApply'](arg1, arg2);
+export function StagingApply(arg1, arg2, arg3) {
+  return window['go']['gui']['App']['StagingApply'](arg1, arg2, arg3);
 }
 
 export function StagingCancelAddTag(arg1, arg2, arg3, arg4) {
@@ -142,6 +146,10 @@ export function StagingCancelRemoveTag(arg1, arg2, arg3, arg4) {
   return window['go']['gui']['App']['StagingCancelRemoveTag'](arg1, arg2, arg3, arg4);
 }
 
+export function StagingChangesets() {
+  return window['go']['gui']['App']['StagingChangesets']();
+}
+
 export function StagingCheckStatus(arg1, arg2, arg3) {
   return window['go']['gui']['App']['StagingCheckStatus'](arg1, arg2, arg3);
 }
@@ -170,14 +178,18 @@ export function StagingRemoveTag(arg1, arg2, arg3, arg4) {
   return window['go']['gui']['App']['StagingRemoveTag'](arg1, arg2, arg3, arg4);
 }
 
-export function StagingReset(arg1) {
-  return window['go']['gui']['App']['StagingReset'](arg1);
+export function StagingReset(arg1, arg2) {
+  return window['go']['gui']['App']['StagingReset'](arg1, arg2);
 }
 
 export function StagingStatus() {
   return window['go']['gui']['App']['StagingStatus']();
 }
 
+export function StagingSwitchChangeset(arg1, arg2) {
+  return window['go']['gui']['App']['StagingSwitchChangeset'](arg1, arg2);
+}
+
 export function StagingUnstage(arg1, arg2, arg3) {
   return window['go']['gui']['App']['StagingUnstage'](arg1, arg2, arg3);
 }
@@ -16,6 +16,20 @@ export namespace gui {
 	        this.profile = source["profile"];
 	    }
 	}
+	export class AWSProfile {
+	    name: string;
+	    accountId: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AWSProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.accountId = source["accountId"];
+	    }
+	}
 	export class DetectResult {
 	    param: string;
 	    secret: string;
@@ -345,6 +359,7 @@ export namespace gui {
 	}
 	export class ScopeSelection {
 	    provider: string;
+	    profile: string;
 	    projectId: string;
 	    vaultName: string;
 	    storeName: string;
@@ -357,6 +372,7 @@ export namespace gui {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.provider = source["provider"];
+	        this.profile = source["profile"];
 	        this.projectId = source["projectId"];
 	        this.vaultName = source["vaultName"];
 	        this.storeName = source["storeName"];
@@ -764,6 +780,20 @@ export namespace gui {
 	        this.name = source["name"];
 	    }
 	}
+	export class StagingChangesetsResult {
+	    names: string[];
+	    active: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StagingChangesetsResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.names = source["names"];
+	        this.active = source["active"];
+	    }
+	}
 	export class StagingCheckStatusResult {
 	    hasEntry: boolean;
 	    hasTags: boolean;
@@ -935,6 +965,20 @@ export namespace gui {
 	        this.tagCount = source["tagCount"];
 	    }
 	}
+	export class StagingItemKey {
+	    name: string;
+	    namespace: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StagingItemKey(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.namespace = source["namespace"];
+	    }
+	}
 	export class StagingRemoveTagResult {
 	    name: string;
 	
@@ -25,6 +25,11 @@ func TestApp_SelectScope(t *testing.T) {
 			sel:       ScopeSelection{Provider: "aws"},
 			wantScope: provider.Scope{Provider: provider.ProviderAWS},
 		},
+		{
+			name:      "aws with profile",
+			sel:       ScopeSelection{Provider: "aws", Profile: "prod"},
+			wantScope: provider.Scope{Provider: provider.ProviderAWS, AWSProfile: "prod"},
+		},
 		{
 			name:      "googlecloud with project",
 			sel:       ScopeSelection{Provider: "googlecloud", ProjectID: "my-project"},
@@ -104,6 +109,7 @@ func TestApp_GetCurrentScope_RoundTrip(t *testing.T) {
 		sel  ScopeSelection
 	}{
 		{name: "aws", sel: ScopeSelection{Provider: "aws"}},
+		{name: "aws with profile", sel: ScopeSelection{Provider: "aws", Profile: "prod"}},
 		{name: "googlecloud", sel: ScopeSelection{Provider: "googlecloud", ProjectID: "proj"}},
 		{
 			name: "azure key vault + app config",
@@ -145,6 +151,27 @@ func TestApp_GetCurrentScope_RoundTrip(t *testing.T) {
 	}
 }
 
+// TestApp_SelectScope_KeepsLaunchRole verifies that the --role-arn the GUI was
+// launched with survives a reselection of the same AWS profile (the frontend
+// echoes the scope back on first render) and is dropped on a profile switch.
+func TestApp_SelectScope_KeepsLaunchRole(t *testing.T) {
+	t.Parallel()
+
+	launch := provider.Scope{
+		Provider:      provider.ProviderAWS,
+		AWSProfile:    "dev",
+		AWSRoleARN:    "arn:aws:iam::111111111111:role/Admin",
+		AWSExternalID: "ext",
+	}
+	app := NewApp(launch, "")
+
+	require.NoError(t, app.SelectScope(*app.GetCurrentScope()))
+	assert.Equal(t, launch, app.currentScope())
+
+	require.NoError(t, app.SelectScope(ScopeSelection{Provider: "aws", Profile: "prod"}))
+	assert.Equal(t, provider.Scope{Provider: provider.ProviderAWS, AWSProfile: "prod"}, app.currentScope())
+}
+
 // TestApp_GetCurrentScope_EnvDerivedInitialScope verifies that the env-derived
 // initial scope (no SelectScope yet) is surfaced for form prefill.
 func TestApp_GetCurrentScope_EnvDerivedInitialScope(t *testing.T) {
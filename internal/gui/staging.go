@@ -96,6 +96,13 @@ type StagingApplyResult struct {
 	TagFailed      int                       `json:"tagFailed"`
 }
 
+// StagingItemKey identifies one staged item (its entry and tag changes) for a
+// selective apply or reset.
+type StagingItemKey struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
 // StagingResetResult represents the result of resetting staged changes.
 type StagingResetResult struct {
 	Type        string `json:"type"`
@@ -104,6 +111,13 @@ type StagingResetResult struct {
 	ServiceName string `json:"serviceName"`
 }
 
+// StagingChangesetsResult lists the staging changesets (the default first) and
+// the active one.
+type StagingChangesetsResult struct {
+	Names  []string `json:"names"`
+	Active string   `json:"active"`
+}
+
 // StagingAddResult represents the result of staging an add operation.
 type StagingAddResult struct {
 	Name string `json:"name"`
@@ -197,13 +211,15 @@ var (
 	}
 
 	resetTypeNames = map[stagingusecase.ResetResultType]string{
-		stagingusecase.ResetResultUnstaged:      "unstaged",
-		stagingusecase.ResetResultUnstagedAll:   "unstagedAll",
-		stagingusecase.ResetResultRestored:      "restored",
-		stagingusecase.ResetResultNotStaged:     "notStaged",
-		stagingusecase.ResetResultNothingStaged: "nothingStaged",
-		stagingusecase.ResetResultSkipped:       "skipped",
-		stagingusecase.ResetResultUnstagedTag:   "unstagedTag",
+		stagingusecase.ResetResultUnstaged:         "unstaged",
+		stagingusecase.ResetResultUnstagedAll:      "unstagedAll",
+		stagingusecase.ResetResultRestored:         "restored",
+		stagingusecase.ResetResultNotStaged:        "notStaged",
+		stagingusecase.ResetResultNothingStaged:    "nothingStaged",
+		stagingusecase.ResetResultSkipped:          "skipped",
+		stagingusecase.ResetResultUnstagedTag:      "unstagedTag",
+		stagingusecase.ResetResultUnstagedSelected: "unstagedSelected",
+		stagingusecase.ResetResultNothingSelected:  "nothingSelected",
 	}
 
 	diffEntryTypeNames = map[stagingusecase.DiffEntryType]string{
@@ -311,8 +327,69 @@ func toStagingTagEntries(tags []stagingusecase.StatusTagEntry) []StagingTagEntry
 	})
 }
 
-// StagingApply applies staged changes for a service.
-func (a *App) StagingApply(service string, ignoreConflicts bool) (*StagingApplyResult, error) {
+// StagingChangesets lists the staging changesets shared by the active scope's
+// services, for the staging view's changeset selector.
+func (a *App) StagingChangesets() (*StagingChangesetsResult, error) {
+	scopes, err := a.changesetScopes(a.currentScope())
+	if err != nil {
+		return nil, err
+	}
+
+	return listChangesets(scopes)
+}
+
+// StagingSwitchChangeset makes name the active staging changeset of every
+// service of the active scope; create creates it first. The staging stores are
+// cached per changeset, so the next staging call reads the switched one.
+func (a *App) StagingSwitchChangeset(name string, create bool) (*StagingChangesetsResult, error) {
+	scopes, err := a.changesetScopes(a.currentScope())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := file.SwitchChangesetIn(scopes, name, create); err != nil {
+		return nil, err
+	}
+
+	return listChangesets(scopes)
+}
+
+// changesetScopes resolves the distinct staging scopes of the services sc
+// supports; they switch changesets together.
+func (a *App) changesetScopes(sc provider.Scope) ([]provider.Scope, error) {
+	var scopes []provider.Scope
+
+	for _, kind := range []provider.Kind{provider.KindParam, provider.KindSecret} {
+		if !sc.SupportsService(kind) {
+			continue
+		}
+
+		scope, err := a.stagingScopeForKindScoped(sc, kind)
+		if err != nil {
+			return nil, err
+		}
+
+		if !lo.ContainsBy(scopes, func(s provider.Scope) bool { return s.Key() == scope.Key() }) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes, nil
+}
+
+// listChangesets builds the changeset listing across scopes.
+func listChangesets(scopes []provider.Scope) (*StagingChangesetsResult, error) {
+	names, active, err := file.ListChangesetsIn(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StagingChangesetsResult{Names: names, Active: active}, nil
+}
+
+// StagingApply applies staged changes for a service, or only those of the items
+// in keys when it is non-empty (the staging view's checked rows).
+func (a *App) StagingApply(service string, ignoreConflicts bool, keys []StagingItemKey) (*StagingApplyResult, error) {
 	sc := a.currentScope()
 
 	store, err := a.getStagingStoreScoped(sc, kindForService(service))
@@ -330,6 +407,12 @@ func (a *App) StagingApply(service string, ignoreConflicts bool) (*StagingApplyR
 		Store:    store,
 	}
 
+	// The file-backed working store keeps the apply journal; record the apply
+	// there so it shows in the history and can be undone.
+	if journal, ok := store.(stagingusecase.ApplyJournal); ok {
+		uc.Journal = journal
+	}
+
 	// App Configuration stages entries across namespaces in one store; apply each
 	// under its own namespace via a namespace-scoped strategy.
 	if service == string(staging.ServiceParam) && isAppConfigParamScope(sc) {
@@ -340,6 +423,7 @@ func (a *App) StagingApply(service string, ignoreConflicts bool) (*StagingApplyR
 
 	result, err := uc.Execute(a.ctx, stagingusecase.ApplyInput{
 		IgnoreConflicts: ignoreConflicts,
+		Select:          itemKeySelector(keys),
 	})
 	// A conflict rejection or a per-entry/tag failure returns a POPULATED result
 	// alongside the error; the failure detail lives in the result's fields
@@ -411,8 +495,9 @@ func newStagingApplyResult(result *stagingusecase.ApplyOutput) *StagingApplyResu
 	return output
 }
 
-// StagingReset resets (unstages) all staged changes for a service.
-func (a *App) StagingReset(service string) (*StagingResetResult, error) {
+// StagingReset resets (unstages) all staged changes for a service, or only those
+// of the items in keys when it is non-empty.
+func (a *App) StagingReset(service string, keys []StagingItemKey) (*StagingResetResult, error) {
 	sc := a.currentScope()
 
 	store, err := a.getStagingStoreScoped(sc, kindForService(service))
@@ -430,7 +515,7 @@ func (a *App) StagingReset(service string) (*StagingResetResult, error) {
 		Store:  store,
 	}
 
-	result, err := uc.Execute(a.ctx, stagingusecase.ResetInput{All: true})
+	result, err := uc.Execute(a.ctx, stagingusecase.ResetInput{All: len(keys) == 0, Select: itemKeySelector(keys)})
 	if err != nil {
 		return nil, err
 	}
@@ -443,6 +528,15 @@ func (a *App) StagingReset(service string) (*StagingResetResult, error) {
 	}, nil
 }
 
+// itemKeySelector selects the items in keys; no keys select everything.
+func itemKeySelector(keys []StagingItemKey) staging.Selector {
+	return staging.Selector{
+		Keys: lo.Map(keys, func(k StagingItemKey, _ int) staging.EntryKey {
+			return staging.EntryKey{Name: k.Name, Namespace: k.Namespace}
+		}),
+	}
+}
+
 // StagingAdd stages a create operation for a new item.
 //
 // namespace selects the Azure App Configuration namespace to stage the create
@@ -439,7 +439,7 @@ func TestApp_StagingReset(t *testing.T) {
 		})
 
 		// Reset param only
-		result, err := app.StagingReset("param")
+		result, err := app.StagingReset("param", nil)
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, "unstagedAll", result.Type)
@@ -467,7 +467,7 @@ func TestApp_StagingReset(t *testing.T) {
 		})
 
 		// Reset secret only
-		result, err := app.StagingReset("secret")
+		result, err := app.StagingReset("secret", nil)
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, "unstagedAll", result.Type)
@@ -479,12 +479,34 @@ func TestApp_StagingReset(t *testing.T) {
 		assert.Empty(t, status.Secret)
 	})
 
+	t.Run("reset only the given items", func(t *testing.T) {
+		t.Parallel()
+		app := setupTestApp(t)
+
+		for _, name := range []string{"/app/a", "/app/b"} {
+			_ = app.stagingStore.StageEntry(app.ctx, staging.ServiceParam, staging.EntryKey{Name: name}, staging.Entry{
+				Operation: staging.OperationUpdate,
+				Value:     lo.ToPtr("value"),
+			})
+		}
+
+		result, err := app.StagingReset("param", []StagingItemKey{{Name: "/app/a"}})
+		require.NoError(t, err)
+		assert.Equal(t, "unstagedSelected", result.Type)
+		assert.Equal(t, 1, result.Count)
+
+		status, err := app.StagingStatus()
+		require.NoError(t, err)
+		require.Len(t, status.Param, 1)
+		assert.Equal(t, "/app/b", status.Param[0].Name)
+	})
+
 	t.Run("reset nothing staged", func(t *testing.T) {
 		t.Parallel()
 		app := setupTestApp(t)
 
 		// Reset when nothing staged
-		result, err := app.StagingReset("param")
+		result, err := app.StagingReset("param", nil)
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, "nothingStaged", result.Type)
@@ -494,7 +516,7 @@ func TestApp_StagingReset(t *testing.T) {
 		t.Parallel()
 		app := setupTestApp(t)
 
-		_, err := app.StagingReset("invalid")
+		_, err := app.StagingReset("invalid", nil)
 		assert.ErrorIs(t, err, errInvalidService)
 	})
 
@@ -502,7 +524,7 @@ func TestApp_StagingReset(t *testing.T) {
 		t.Parallel()
 		app := setupTestApp(t)
 
-		_, err := app.StagingReset("")
+		_, err := app.StagingReset("", nil)
 		assert.ErrorIs(t, err, errInvalidService)
 	})
 }
@@ -1174,7 +1196,7 @@ func TestApp_StagingReset_ErrorPaths(t *testing.T) {
 		// Inject error
 		mockStore.UnstageAllErr = context.DeadlineExceeded
 
-		_, err = app.StagingReset("param")
+		_, err = app.StagingReset("param", nil)
 		require.Error(t, err)
 		assert.ErrorIs(t, err, context.DeadlineExceeded)
 	})
@@ -1329,7 +1351,7 @@ func TestApp_StagingReset_ResetBothEntriesAndTags(t *testing.T) {
 		assert.Len(t, status.ParamTags, 1)
 
 		// Reset
-		result, err := app.StagingReset("param")
+		result, err := app.StagingReset("param", nil)
 		require.NoError(t, err)
 		assert.Equal(t, "unstagedAll", result.Type)
 
@@ -1360,7 +1382,7 @@ func TestApp_StagingApply_ExecuteError(t *testing.T) {
 	)
 
 	require.NotPanics(t, func() {
-		result, err = app.StagingApply(string(staging.ServiceParam), false)
+		result, err = app.StagingApply(string(staging.ServiceParam), false, nil)
 	})
 
 	require.Error(t, err)
@@ -1416,7 +1438,7 @@ func TestApp_StagingApply_NoStagedChanges(t *testing.T) {
 
 	app := setupTestApp(t)
 
-	result, err := app.StagingApply(string(staging.ServiceParam), false)
+	result, err := app.StagingApply(string(staging.ServiceParam), false, nil)
 	require.NoError(t, err)
 	require.NotNil(t, result)
 	assert.Empty(t, result.Conflicts)
@@ -169,3 +169,34 @@ func TestStagingScope_GUICLIParity(t *testing.T) {
 	assert.NotEqual(t, paramScope.Key(), secretScope.Key(),
 		"Azure App Configuration and Key Vault staging must not collide")
 }
+
+// TestApp_StagingSwitchChangeset verifies a changeset switch re-keys the cached
+// staging store, so the next staging call reads the switched changeset.
+func TestApp_StagingSwitchChangeset(t *testing.T) {
+	// Non-parallel: sets process env (staging key + HOME).
+	t.Setenv("SUVE_STAGING_KEY", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	t.Setenv("HOME", t.TempDir())
+
+	app := NewApp(provider.Scope{Provider: provider.ProviderGoogleCloud}, "")
+	app.Startup(t.Context())
+	app.scope = provider.GoogleCloudScope("proj")
+
+	def, err := app.getStagingStore(provider.KindSecret)
+	require.NoError(t, err)
+
+	_, err = app.StagingSwitchChangeset("rotate-db", false)
+	require.Error(t, err, "switching to a missing changeset without create fails")
+
+	result, err := app.StagingSwitchChangeset("rotate-db", true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "rotate-db"}, result.Names)
+	assert.Equal(t, "rotate-db", result.Active)
+
+	named, err := app.getStagingStore(provider.KindSecret)
+	require.NoError(t, err)
+	assert.NotSame(t, def, named, "the switched changeset gets its own store")
+
+	result, err = app.StagingChangesets()
+	require.NoError(t, err)
+	assert.Equal(t, "rotate-db", result.Active)
+}
@@ -1,5 +1,6 @@
 // Package keyprovider resolves the AES-256 data key used to encrypt the
-// working staging state files (param.json/secret.json).
+// working staging state files (param.json/secret.json). The credential cache
+// (internal/credcache) encrypts its entries with the same key.
 //
 // The resolution follows a fixed fallback chain:
 //
@@ -29,7 +29,7 @@ var _ provider.Factory = Factory{}
 // Store builds a Store for the given scope and kind. It returns
 // provider.ErrUnsupportedKind for kinds AWS does not offer.
 func (Factory) Store(ctx context.Context, scope provider.Scope, kind provider.Kind) (provider.Store, error) {
-	cfg, err := infra.LoadConfig(ctx)
+	cfg, err := infra.LoadConfig(WithScope(ctx, scope))
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
@@ -48,6 +48,26 @@ func (Factory) Store(ctx context.Context, scope provider.Scope, kind provider.Ki
 	}
 }
 
+// WithScope returns ctx with the scope's profile and role fields laid over the
+// infra.Options already on it, so a scope picked at runtime (TUI/GUI) wins over
+// the launch flags while keeping their MFA token provider.
+func WithScope(ctx context.Context, scope provider.Scope) context.Context {
+	opts := infra.OptionsFrom(ctx)
+
+	if scope.AWSProfile != "" {
+		opts.Profile = scope.AWSProfile
+	}
+
+	if scope.AWSRoleARN != "" {
+		opts.RoleARN = scope.AWSRoleARN
+		opts.ExternalID = scope.AWSExternalID
+		opts.SessionName = scope.AWSSessionName
+		opts.MFASerial = scope.AWSMFASerial
+	}
+
+	return infra.WithOptions(ctx, opts)
+}
+
 // Register associates the AWS Factory with provider.ProviderAWS in reg.
 func Register(reg *provider.Registry) {
 	reg.Register(provider.ProviderAWS, Factory{})
@@ -8,6 +8,7 @@ import (
 
 	"github.com/mpyw/suve/internal/provider"
 	awsprovider "github.com/mpyw/suve/internal/provider/aws"
+	"github.com/mpyw/suve/internal/provider/aws/infra"
 )
 
 func TestFactory_Param(t *testing.T) {
@@ -54,3 +55,29 @@ func TestRegister_AddsFactory(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, store)
 }
+
+func TestWithScope(t *testing.T) {
+	t.Parallel()
+
+	token := func() (string, error) { return "123456", nil }
+	base := infra.WithOptions(t.Context(), infra.Options{Profile: "launch", RoleARN: "arn:launch", TokenProvider: token})
+
+	t.Run("empty scope keeps the launch options", func(t *testing.T) {
+		t.Parallel()
+
+		opts := infra.OptionsFrom(awsprovider.WithScope(base, provider.AWSScope("", "")))
+		assert.Equal(t, "launch", opts.Profile)
+		assert.Equal(t, "arn:launch", opts.RoleARN)
+	})
+
+	t.Run("scope fields win and keep the token provider", func(t *testing.T) {
+		t.Parallel()
+
+		scope := provider.Scope{Provider: provider.ProviderAWS, AWSProfile: "picked", AWSRoleARN: "arn:picked", AWSExternalID: "ext"}
+		opts := infra.OptionsFrom(awsprovider.WithScope(base, scope))
+		assert.Equal(t, "picked", opts.Profile)
+		assert.Equal(t, "arn:picked", opts.RoleARN)
+		assert.Equal(t, "ext", opts.ExternalID)
+		assert.NotNil(t, opts.TokenProvider)
+	})
+}
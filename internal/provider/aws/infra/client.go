@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -108,28 +109,42 @@ func (l debugLogger) Logf(classification logging.Classification, format string,
 // credentials source — the facts a user needs first when a command unexpectedly
 // returns nothing (see #306). By default the bodyless LogRequest/LogResponse
 // modes are used (metadata only, no secret values); --no-redaction switches to
-// the WithBody modes so full request/response payloads are logged too.
+// the WithBody modes so full request/response payloads are logged too. The
+// profile and role set on the context by WithOptions are applied on top of the
+// ambient chain. Every client built from the config reports a rate-limit
+// rejection wrapped with provider.ErrThrottled (see wrapThrottled).
 func LoadConfig(ctx context.Context) (aws.Config, error) {
+	opts := OptionsFrom(ctx)
+	loadOpts := opts.loadOptions()
+
 	d := debug.From(ctx)
-	if !d.Enabled {
-		return config.LoadDefaultConfig(ctx)
-	}
+	if d.Enabled {
+		logMode := aws.LogRequest | aws.LogResponse | aws.LogRetries
+		if d.NoRedaction {
+			logMode = aws.LogRequestWithBody | aws.LogResponseWithBody | aws.LogRetries
+		}
 
-	logMode := aws.LogRequest | aws.LogResponse | aws.LogRetries
-	if d.NoRedaction {
-		logMode = aws.LogRequestWithBody | aws.LogResponseWithBody | aws.LogRetries
+		loadOpts = append(loadOpts,
+			config.WithClientLogMode(logMode),
+			config.WithLogger(debugLogger{cfg: d}),
+			config.WithLogConfigurationWarnings(true),
+		)
 	}
 
-	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithClientLogMode(logMode),
-		config.WithLogger(debugLogger{cfg: d}),
-		config.WithLogConfigurationWarnings(true),
-	)
+	cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
 	if err != nil {
 		return cfg, err
 	}
 
-	logEffectiveConfig(ctx, d, cfg)
+	cfg.APIOptions = append(cfg.APIOptions, addWrapThrottled)
+
+	opts.assumeRole(&cfg)
+	opts.cacheCredentials(ctx, &cfg)
+	opts.shareCredentials(&cfg)
+
+	if d.Enabled {
+		logEffectiveConfig(ctx, d, cfg, opts)
+	}
 
 	return cfg, nil
 }
@@ -140,17 +155,22 @@ func LoadConfig(ctx context.Context) (aws.Config, error) {
 // anyway; a resolution failure is logged (with the reason) instead of being
 // returned, so the command still fails at the API call exactly as it would
 // without --debug.
-func logEffectiveConfig(ctx context.Context, d debug.Config, cfg aws.Config) {
-	profile := lo.CoalesceOrEmpty(os.Getenv("AWS_PROFILE"), os.Getenv("AWS_DEFAULT_PROFILE"), "default")
+func logEffectiveConfig(ctx context.Context, d debug.Config, cfg aws.Config, opts Options) {
+	profile := lo.CoalesceOrEmpty(opts.Profile, os.Getenv("AWS_PROFILE"), os.Getenv("AWS_DEFAULT_PROFILE"), "default")
+
+	role := ""
+	if opts.RoleARN != "" {
+		role = fmt.Sprintf(" role=%q", opts.RoleARN)
+	}
 
 	creds, err := cfg.Credentials.Retrieve(ctx)
 	if err != nil {
-		d.Logf("aws: region=%q profile=%q credentials resolution failed: %v\n", cfg.Region, profile, err)
+		d.Logf("aws: region=%q profile=%q%s credentials resolution failed: %v\n", cfg.Region, profile, role, err)
 
 		return
 	}
 
-	d.Logf("aws: region=%q profile=%q credentials-source=%s\n", cfg.Region, profile, creds.Source)
+	d.Logf("aws: region=%q profile=%q%s credentials-source=%s\n", cfg.Region, profile, role, creds.Source)
 }
 
 // AWSIdentity contains AWS account ID, region, and profile name.
@@ -161,6 +181,8 @@ type AWSIdentity struct {
 }
 
 // GetAWSIdentity retrieves the current AWS account ID, region, and profile name.
+// The profile is the one selected by WithOptions when set, and otherwise the
+// one found in ~/.aws/config for the account.
 func GetAWSIdentity(ctx context.Context) (*AWSIdentity, error) {
 	cfg, err := LoadConfig(ctx)
 	if err != nil {
@@ -176,10 +198,15 @@ func GetAWSIdentity(ctx context.Context) (*AWSIdentity, error) {
 
 	accountID := lo.FromPtr(output.Account)
 
+	profile := OptionsFrom(ctx).Profile
+	if profile == "" {
+		profile = findProfileByAccountID(accountID)
+	}
+
 	return &AWSIdentity{
 		AccountID: accountID,
 		Region:    cfg.Region,
-		Profile:   findProfileByAccountID(accountID),
+		Profile:   profile,
 	}, nil
 }
 
@@ -233,16 +260,8 @@ func parseAWSConfigProfiles() map[string]string {
 	profiles := make(map[string]string)
 
 	for _, section := range cfg.Sections() {
-		name := section.Name()
-
-		// Extract profile name from section name
-		// Sections are either "default" or "profile <name>"
-		var profileName string
-		if strings.EqualFold(name, "default") {
-			profileName = "default"
-		} else if after, found := strings.CutPrefix(name, "profile "); found {
-			profileName = after
-		} else {
+		profileName, ok := sectionProfileName(section.Name())
+		if !ok {
 			continue
 		}
 
@@ -264,6 +283,58 @@ func parseAWSConfigProfiles() map[string]string {
 	return profiles
 }
 
+// sectionProfileName extracts the profile name from a ~/.aws/config section
+// name. Sections are either "default" or "profile <name>"; anything else (e.g.
+// "sso-session <name>") is not a profile.
+func sectionProfileName(name string) (string, bool) {
+	if strings.EqualFold(name, "default") {
+		return "default", true
+	}
+
+	return strings.CutPrefix(name, "profile ")
+}
+
+// Profile is a named profile from ~/.aws/config.
+type Profile struct {
+	Name string
+	// AccountID comes from sso_account_id or role_arn; it is empty for a
+	// profile that names neither (e.g. static keys in ~/.aws/credentials).
+	AccountID string
+}
+
+// ListProfiles returns the profiles defined in ~/.aws/config, sorted by name.
+// A missing or unreadable config yields no profiles.
+func ListProfiles() []Profile {
+	cfg, err := ini.Load(getAWSConfigPath())
+	if err != nil {
+		return nil
+	}
+
+	accounts := parseAWSConfigProfiles()
+	seen := make(map[string]struct{})
+
+	var profiles []Profile
+
+	for _, section := range cfg.Sections() {
+		name, ok := sectionProfileName(section.Name())
+		if !ok {
+			continue
+		}
+
+		if _, dup := seen[name]; dup {
+			continue
+		}
+
+		seen[name] = struct{}{}
+
+		profiles = append(profiles, Profile{Name: name, AccountID: accounts[name]})
+	}
+
+	slices.SortFunc(profiles, func(a, b Profile) int { return strings.Compare(a.Name, b.Name) })
+
+	return profiles
+}
+
 // getAWSConfigPath returns the path to ~/.aws/config.
 func getAWSConfigPath() string {
 	if configFile := os.Getenv("AWS_CONFIG_FILE"); configFile != "" {
@@ -0,0 +1,68 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/mpyw/suve/internal/credcache"
+	"github.com/mpyw/suve/internal/debug"
+)
+
+// credentialCachePrefix namespaces AWS entries in the credential cache.
+const credentialCachePrefix = "aws\x00"
+
+// cachedCredentials serves credentials from the on-disk credential cache and
+// falls back to next on a miss, caching what next returns when it expires.
+// Long-lived credentials (static keys) never expire and are never written.
+type cachedCredentials struct {
+	cache *credcache.Cache
+	name  string
+	next  aws.CredentialsProvider
+}
+
+// Retrieve implements aws.CredentialsProvider.
+func (p cachedCredentials) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	var creds aws.Credentials
+	if p.cache.Load(p.name, &creds) {
+		return creds, nil
+	}
+
+	creds, err := p.next.Retrieve(ctx)
+	if err != nil || !creds.CanExpire {
+		return creds, err
+	}
+
+	if err := p.cache.Save(p.name, creds, creds.Expires); err != nil {
+		debug.From(ctx).Logf("aws credential cache: %v\n", err)
+	}
+
+	return creds, nil
+}
+
+// cacheCredentials wraps cfg's credentials with the on-disk credential cache
+// when it is enabled (SUVE_CREDENTIAL_CACHE), so assumed-role, MFA and SSO
+// sessions outlive the process. A cache that cannot open (no encryption key)
+// leaves cfg untouched.
+func (o Options) cacheCredentials(ctx context.Context, cfg *aws.Config) {
+	if cfg.Credentials == nil {
+		return
+	}
+
+	cache, err := credcache.Open()
+	if err != nil {
+		debug.From(ctx).Logf("aws credential cache: disabled: %v\n", err)
+
+		return
+	}
+
+	if cache == nil {
+		return
+	}
+
+	cfg.Credentials = aws.NewCredentialsCache(cachedCredentials{
+		cache: cache,
+		name:  credentialCachePrefix + o.credentialsKey(),
+		next:  cfg.Credentials,
+	})
+}
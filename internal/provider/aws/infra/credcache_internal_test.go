@@ -0,0 +1,90 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/credcache"
+	"github.com/mpyw/suve/internal/keyprovider"
+)
+
+// countingProvider returns creds and counts the calls.
+func countingProvider(calls *int, creds aws.Credentials) aws.CredentialsProvider {
+	return aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		*calls++
+
+		return creds, nil
+	})
+}
+
+//nolint:paralleltest // subtests use t.Setenv, so they cannot run in parallel
+func TestCacheCredentials(t *testing.T) {
+	enable := func(t *testing.T) {
+		t.Helper()
+		setAWSTestEnv(t)
+		t.Setenv("HOME", t.TempDir())
+		t.Setenv(credcache.EnvCredentialCache, "1")
+		t.Setenv(keyprovider.EnvStagingKey, base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+	}
+
+	// retrieve wraps next like LoadConfig would for opts and retrieves once.
+	retrieve := func(t *testing.T, opts Options, next aws.CredentialsProvider) aws.Credentials {
+		t.Helper()
+
+		cfg := aws.Config{Credentials: next}
+		opts.cacheCredentials(context.Background(), &cfg)
+
+		creds, err := cfg.Credentials.Retrieve(context.Background())
+		require.NoError(t, err)
+
+		return creds
+	}
+
+	session := aws.Credentials{
+		AccessKeyID: "ASIA", SecretAccessKey: "secret", SessionToken: "token",
+		CanExpire: true, Expires: time.Now().Add(time.Hour),
+	}
+
+	t.Run("sessions are reused across loads", func(t *testing.T) {
+		enable(t)
+
+		calls := 0
+		first := retrieve(t, Options{Profile: "dev"}, countingProvider(&calls, session))
+		second := retrieve(t, Options{Profile: "dev"}, countingProvider(&calls, session))
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, first.SessionToken, second.SessionToken)
+		assert.True(t, second.Expires.Equal(session.Expires))
+
+		retrieve(t, Options{Profile: "prod"}, countingProvider(&calls, session))
+		assert.Equal(t, 2, calls, "another profile is another entry")
+	})
+
+	t.Run("static keys are never written", func(t *testing.T) {
+		enable(t)
+
+		static := aws.Credentials{AccessKeyID: "AKIA", SecretAccessKey: "secret"}
+
+		calls := 0
+		retrieve(t, Options{}, countingProvider(&calls, static))
+		retrieve(t, Options{}, countingProvider(&calls, static))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("disabled cache leaves the provider alone", func(t *testing.T) {
+		setAWSTestEnv(t)
+
+		next := countingProvider(new(int), session)
+		cfg := aws.Config{Credentials: next}
+		Options{}.cacheCredentials(context.Background(), &cfg)
+		assert.NotNil(t, cfg.Credentials)
+		assert.IsType(t, next, cfg.Credentials)
+	})
+}
@@ -6,10 +6,13 @@ import (
 	"os"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/smithy-go/logging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/mpyw/suve/internal/credcache"
 	"github.com/mpyw/suve/internal/debug"
 )
 
@@ -27,6 +30,7 @@ func setAWSTestEnv(t *testing.T) {
 	t.Setenv("AWS_DEFAULT_PROFILE", "")
 	t.Setenv("AWS_CONFIG_FILE", os.DevNull)
 	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", os.DevNull)
+	t.Setenv(credcache.EnvCredentialCache, "")
 }
 
 // TestLoadConfig_debug exercises both branches of LoadConfig.
@@ -77,6 +81,83 @@ func TestLoadConfig_debug(t *testing.T) {
 	})
 }
 
+// TestLoadConfig_options exercises the profile and role selection.
+//
+//nolint:paralleltest // subtests use t.Setenv (via setAWSTestEnv), so they cannot run in parallel
+func TestLoadConfig_options(t *testing.T) {
+	t.Run("profile overrides the environment", func(t *testing.T) {
+		setAWSTestEnv(t)
+		t.Setenv("AWS_CONFIG_FILE", createTempConfig(t, "[profile work]\nregion = eu-west-1\n"))
+		t.Setenv("AWS_SHARED_CREDENTIALS_FILE", createTempConfig(t, "[work]\naws_access_key_id = work\naws_secret_access_key = work\n"))
+		t.Setenv("AWS_REGION", "")
+
+		ctx := WithOptions(context.Background(), Options{Profile: "work"})
+
+		cfg, err := LoadConfig(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "eu-west-1", cfg.Region)
+
+		creds, err := cfg.Credentials.Retrieve(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "work", creds.AccessKeyID)
+	})
+
+	t.Run("role arn assumes the role", func(t *testing.T) {
+		setAWSTestEnv(t)
+
+		ctx := WithOptions(context.Background(), Options{RoleARN: "arn:aws:iam::123456789012:role/Admin"})
+
+		cfg, err := LoadConfig(ctx)
+		require.NoError(t, err)
+		assert.True(t, aws.IsCredentialsProvider(cfg.Credentials, (*stscreds.AssumeRoleProvider)(nil)))
+	})
+
+	t.Run("no role keeps the ambient credentials", func(t *testing.T) {
+		setAWSTestEnv(t)
+
+		cfg, err := LoadConfig(context.Background())
+		require.NoError(t, err)
+		assert.False(t, aws.IsCredentialsProvider(cfg.Credentials, (*stscreds.AssumeRoleProvider)(nil)))
+	})
+
+	t.Run("token provider shares credentials across loads", func(t *testing.T) {
+		setAWSTestEnv(t)
+
+		token := func() (string, error) { return "123456", nil }
+		ctx := WithOptions(context.Background(), Options{RoleARN: "arn:aws:iam::123456789012:role/Shared", TokenProvider: token})
+
+		first, err := LoadConfig(ctx)
+		require.NoError(t, err)
+
+		second, err := LoadConfig(ctx)
+		require.NoError(t, err)
+		assert.Same(t, first.Credentials, second.Credentials)
+	})
+
+	t.Run("debug summary names the role", func(t *testing.T) {
+		setAWSTestEnv(t)
+
+		var buf bytes.Buffer
+
+		ctx := debug.With(context.Background(), debug.Config{Enabled: true, Writer: &buf})
+		ctx = WithOptions(ctx, Options{RoleARN: "arn:aws:iam::123456789012:role/Admin"})
+
+		_, err := LoadConfig(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), `profile="default" role="arn:aws:iam::123456789012:role/Admin"`)
+	})
+}
+
+func TestOptionsFrom(t *testing.T) {
+	t.Parallel()
+
+	assert.Zero(t, OptionsFrom(context.Background()))
+
+	ctx := WithOptions(context.Background(), Options{Profile: "work", RoleARN: "arn"})
+	assert.Equal(t, "work", OptionsFrom(ctx).Profile)
+	assert.Equal(t, "arn", OptionsFrom(ctx).RoleARN)
+}
+
 func TestDebugLogger_prefix(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,125 @@
+package infra
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// DefaultSessionName is the role session name used when --role-arn is given
+// without --session-name.
+const DefaultSessionName = "suve"
+
+// sharedCredentials maps an Options key to the credentials of the first config
+// loaded for it with a TokenProvider, so a process asks for an MFA code once
+// rather than once per client (a staged apply builds several).
+//
+//nolint:gochecknoglobals // process-wide credentials cache
+var sharedCredentials sync.Map
+
+// optionsCtxKey is the unexported context key under which Options is stored.
+type optionsCtxKey struct{}
+
+// Options selects the AWS credentials LoadConfig builds on top of the ambient
+// SDK chain. The zero value changes nothing.
+type Options struct {
+	// Profile is the shared config profile to load instead of AWS_PROFILE.
+	Profile string
+	// RoleARN is a role to assume with the profile's credentials.
+	RoleARN string
+	// ExternalID is passed to AssumeRole with RoleARN.
+	ExternalID string
+	// SessionName is the role session name for RoleARN (DefaultSessionName
+	// when empty).
+	SessionName string
+	// MFASerial is the MFA device ARN (or serial) RoleARN requires.
+	MFASerial string
+	// TokenProvider supplies an MFA code when an assumed role needs one, both
+	// for RoleARN with MFASerial and for a profile whose config sets
+	// mfa_serial. Nil means MFA-protected roles cannot be assumed.
+	TokenProvider func() (string, error)
+}
+
+// WithOptions returns a context carrying opts for LoadConfig.
+func WithOptions(ctx context.Context, opts Options) context.Context {
+	return context.WithValue(ctx, optionsCtxKey{}, opts)
+}
+
+// OptionsFrom returns the Options stored on ctx, or the zero value.
+func OptionsFrom(ctx context.Context) Options {
+	opts, _ := ctx.Value(optionsCtxKey{}).(Options)
+
+	return opts
+}
+
+// loadOptions returns the config.LoadOptions functions that apply opts before
+// the default config is loaded.
+func (o Options) loadOptions() []func(*config.LoadOptions) error {
+	var fns []func(*config.LoadOptions) error
+
+	if o.Profile != "" {
+		fns = append(fns, config.WithSharedConfigProfile(o.Profile))
+	}
+
+	if o.TokenProvider != nil {
+		fns = append(fns, config.WithAssumeRoleCredentialOptions(func(ro *stscreds.AssumeRoleOptions) {
+			ro.TokenProvider = o.TokenProvider
+		}))
+	}
+
+	return fns
+}
+
+// assumeRole replaces cfg's credentials with those of RoleARN, assumed with
+// cfg's current credentials. It is a no-op without RoleARN. The credentials
+// are only fetched on first use, like every other SDK provider.
+func (o Options) assumeRole(cfg *aws.Config) {
+	if o.RoleARN == "" {
+		return
+	}
+
+	provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(*cfg), o.RoleARN, func(ro *stscreds.AssumeRoleOptions) {
+		ro.RoleSessionName = DefaultSessionName
+		if o.SessionName != "" {
+			ro.RoleSessionName = o.SessionName
+		}
+
+		if o.ExternalID != "" {
+			ro.ExternalID = aws.String(o.ExternalID)
+		}
+
+		if o.MFASerial != "" {
+			ro.SerialNumber = aws.String(o.MFASerial)
+			ro.TokenProvider = o.TokenProvider
+		}
+	})
+
+	cfg.Credentials = aws.NewCredentialsCache(provider)
+}
+
+// shareCredentials swaps cfg's credentials for those already loaded for the
+// same options in this process, when a TokenProvider may prompt for them.
+func (o Options) shareCredentials(cfg *aws.Config) {
+	if o.TokenProvider == nil {
+		return
+	}
+
+	shared, _ := sharedCredentials.LoadOrStore(o.credentialsKey(), cfg.Credentials)
+	cfg.Credentials, _ = shared.(aws.CredentialsProvider)
+}
+
+// credentialsKey identifies the credentials opts resolve to: the options
+// themselves plus the environment the SDK chain reads them from.
+func (o Options) credentialsKey() string {
+	return strings.Join([]string{
+		o.Profile, os.Getenv("AWS_PROFILE"), os.Getenv("AWS_DEFAULT_PROFILE"),
+		os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_CONFIG_FILE"), os.Getenv("AWS_SHARED_CREDENTIALS_FILE"),
+		o.RoleARN, o.ExternalID, o.SessionName, o.MFASerial,
+	}, "\x00")
+}
@@ -228,6 +228,39 @@ sso_account_id = 123456789012
 	})
 }
 
+func TestListProfiles(t *testing.T) {
+	// Cannot use t.Parallel() because subtests use t.Setenv
+	t.Run("lists every profile sorted with its account", func(t *testing.T) {
+		configContent := `
+[profile zeta]
+region = us-east-1
+
+[default]
+sso_account_id = 111111111111
+
+[sso-session corp]
+sso_start_url = https://example.awsapps.com/start
+
+[profile admin]
+role_arn = arn:aws:iam::222222222222:role/Admin
+`
+		configPath := createTempConfig(t, configContent)
+		t.Setenv("AWS_CONFIG_FILE", configPath)
+
+		assert.Equal(t, []Profile{
+			{Name: "admin", AccountID: "222222222222"},
+			{Name: "default", AccountID: "111111111111"},
+			{Name: "zeta"},
+		}, ListProfiles())
+	})
+
+	t.Run("returns nil when config file missing", func(t *testing.T) {
+		t.Setenv("AWS_CONFIG_FILE", "/nonexistent/path/config")
+
+		assert.Nil(t, ListProfiles())
+	})
+}
+
 func TestGetAWSConfigPath(t *testing.T) {
 	// Cannot use t.Parallel() because subtests use t.Setenv
 	t.Run("uses AWS_CONFIG_FILE if set", func(t *testing.T) {
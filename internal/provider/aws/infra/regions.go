@@ -0,0 +1,25 @@
+package infra
+
+// regions are the AWS commercial regions, in the order the console lists them.
+//
+//nolint:gochecknoglobals // immutable lookup table
+var regions = []string{
+	"us-east-1", "us-east-2", "us-west-1", "us-west-2",
+	"af-south-1",
+	"ap-east-1", "ap-south-1", "ap-south-2", "ap-southeast-1", "ap-southeast-2", "ap-southeast-3", "ap-southeast-4",
+	"ap-southeast-5", "ap-southeast-7", "ap-northeast-1", "ap-northeast-2", "ap-northeast-3",
+	"ca-central-1", "ca-west-1",
+	"eu-central-1", "eu-central-2", "eu-west-1", "eu-west-2", "eu-west-3", "eu-south-1", "eu-south-2", "eu-north-1",
+	"il-central-1",
+	"me-south-1", "me-central-1",
+	"mx-central-1",
+	"sa-east-1",
+}
+
+// Regions returns the AWS commercial regions for a region picker. The list is
+// static (the SDK ships none, and listing them live needs ec2:DescribeRegions),
+// so a newer or opt-in partition region is not in it; pickers accept any typed
+// region.
+func Regions() []string {
+	return append([]string(nil), regions...)
+}
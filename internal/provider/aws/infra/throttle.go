@@ -0,0 +1,50 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// wrapThrottled marks an operation's rate-limit rejection with
+// provider.ErrThrottled, so stage apply retries it without knowing the SDK's
+// error types. It sits in the Initialize step, outside the SDK's own retry
+// loop, and so only sees the error that remains once those retries are spent.
+//
+//nolint:gochecknoglobals // stateless middleware value
+var wrapThrottled = middleware.InitializeMiddlewareFunc("suve.WrapThrottled", func(
+	ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+) (middleware.InitializeOutput, middleware.Metadata, error) {
+	out, md, err := next.HandleInitialize(ctx, in)
+	if err != nil && isThrottled(err) {
+		err = fmt.Errorf("%w: %w", provider.ErrThrottled, err)
+	}
+
+	return out, md, err
+})
+
+// addWrapThrottled registers wrapThrottled on an operation's stack.
+func addWrapThrottled(stack *middleware.Stack) error {
+	return stack.Initialize.Add(wrapThrottled, middleware.Before)
+}
+
+// isThrottled reports whether err carries one of the SDK's throttling error
+// codes (ThrottlingException, TooManyRequestsException, ...) or the HTTP
+// status 429.
+func isThrottled(err error) bool {
+	if retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == aws.TrueTernary {
+		return true
+	}
+
+	var respErr *smithyhttp.ResponseError
+
+	return errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusTooManyRequests
+}
@@ -0,0 +1,73 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+)
+
+func TestWrapThrottled(t *testing.T) {
+	t.Parallel()
+
+	responseError := func(status int) error {
+		return &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: status}},
+			Err:      errors.New("response"),
+		}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"throttling code", fmt.Errorf("put: %w", &smithy.GenericAPIError{Code: "ThrottlingException"}), true},
+		{"Secrets Manager throttling code", &smithy.GenericAPIError{Code: "Throttling"}, true},
+		{"other code", &smithy.GenericAPIError{Code: "ParameterNotFound"}, false},
+		{"HTTP 429", responseError(http.StatusTooManyRequests), true},
+		{"HTTP 500", responseError(http.StatusInternalServerError), false},
+		{"plain error", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			next := middleware.InitializeHandlerFunc(func(
+				context.Context, middleware.InitializeInput,
+			) (middleware.InitializeOutput, middleware.Metadata, error) {
+				return middleware.InitializeOutput{}, middleware.Metadata{}, tt.err
+			})
+
+			_, _, err := wrapThrottled.HandleInitialize(t.Context(), middleware.InitializeInput{}, next)
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, provider.IsThrottled(err))
+		})
+	}
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestLoadConfig_WrapsThrottled(t *testing.T) {
+	setAWSTestEnv(t)
+
+	cfg, err := LoadConfig(t.Context())
+	require.NoError(t, err)
+
+	stack := middleware.NewStack("test", smithyhttp.NewStackRequest)
+	for _, fn := range cfg.APIOptions {
+		require.NoError(t, fn(stack))
+	}
+
+	_, ok := stack.Initialize.Get(wrapThrottled.ID())
+	assert.True(t, ok)
+}
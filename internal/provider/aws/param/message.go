@@ -0,0 +1,38 @@
+package param
+
+import "strings"
+
+// Parameter Store keeps no per-version metadata besides the description, which
+// GetParameterHistory returns as of each version. A provider.ChangeMessage is
+// therefore written as a suffix of the description:
+//
+//	Database endpoint [suve: rotate db creds for INC-123]
+//
+// The suffix is stripped from the description on read and surfaced as the
+// version's message instead.
+const (
+	messagePrefix = "[suve: "
+	messageSuffix = "]"
+)
+
+// joinDescription appends message to description as the change message suffix.
+func joinDescription(description, message string) string {
+	suffix := messagePrefix + message + messageSuffix
+
+	if description == "" {
+		return suffix
+	}
+
+	return description + " " + suffix
+}
+
+// splitDescription splits a stored description into the description proper
+// and the change message suffix, "" when there is none.
+func splitDescription(stored string) (description, message string) {
+	i := strings.LastIndex(stored, messagePrefix)
+	if i < 0 || !strings.HasSuffix(stored, messageSuffix) || (i > 0 && stored[i-1] != ' ') {
+		return stored, ""
+	}
+
+	return strings.TrimSuffix(stored[:i], " "), stored[i+len(messagePrefix) : len(stored)-len(messageSuffix)]
+}
@@ -159,38 +159,60 @@ func (s *Store) Get(ctx context.Context, name string, ref provider.VersionRef) (
 	// Description is best-effort too: GetParameter's output carries no description,
 	// so it lives in the parameter metadata returned by DescribeParameters. A
 	// metadata-read failure must not fail the value read (same discipline as tags).
-	descOutput, err := s.client.DescribeParameters(ctx, &ssm.DescribeParametersInput{
+	if description, err := s.describeDescription(ctx, aws.ToString(p.Name)); err == nil {
+		entry.Description, _ = splitDescription(description)
+	}
+
+	return entry, nil
+}
+
+// describeDescription returns the parameter's stored description (with any
+// change message suffix) from its DescribeParameters metadata.
+func (s *Store) describeDescription(ctx context.Context, name string) (string, error) {
+	out, err := s.client.DescribeParameters(ctx, &ssm.DescribeParametersInput{
 		ParameterFilters: []types.ParameterStringFilter{{
 			Key:    aws.String("Name"),
 			Option: aws.String("Equals"),
-			Values: []string{aws.ToString(p.Name)},
+			Values: []string{name},
 		}},
 	})
-	if err == nil && descOutput != nil {
-		// Equals filters to the exact name, but match defensively in case an
-		// emulator treats it as a prefix filter.
-		if meta, ok := lo.Find(descOutput.Parameters, func(m types.ParameterMetadata) bool {
-			return aws.ToString(m.Name) == aws.ToString(p.Name)
-		}); ok {
-			entry.Description = aws.ToString(meta.Description)
-		}
+	if err != nil || out == nil {
+		return "", err
 	}
 
-	return entry, nil
+	// Equals filters to the exact name, but match defensively in case an
+	// emulator treats it as a prefix filter.
+	meta, _ := lo.Find(out.Parameters, func(m types.ParameterMetadata) bool {
+		return aws.ToString(m.Name) == name
+	})
+
+	return aws.ToString(meta.Description), nil
 }
 
-// History returns the parameter's version history, newest first.
+// History returns the parameter's version history, newest first. A version's
+// message is the change message suffix of its description, shown only where it
+// changed: a write without a description keeps the previous one, message
+// included, which must not be attributed to that write.
 func (s *Store) History(ctx context.Context, name string) ([]domain.Version, error) {
 	params, err := s.getFullHistory(ctx, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get parameter history: %w", err)
 	}
 
+	var previous string
+
 	versions := lo.Map(params, func(p types.ParameterHistory, _ int) domain.Version {
-		return domain.Version{
+		v := domain.Version{
 			ID:      strconv.FormatInt(p.Version, 10),
 			Created: p.LastModifiedDate,
 		}
+
+		if description := aws.ToString(p.Description); description != previous {
+			_, v.Message = splitDescription(description)
+			previous = description
+		}
+
+		return v
 	})
 
 	slices.Reverse(versions) // newest first
@@ -277,7 +299,7 @@ func (s *Store) List(ctx context.Context) ([]string, error) {
 
 // Create creates a new parameter (Overwrite=false) and returns the resulting
 // version. It returns a wrapped provider.ErrAlreadyExists if the parameter
-// already exists.
+// already exists. A provider.ChangeMessage is appended to the description.
 func (s *Store) Create(
 	ctx context.Context, name, value string, valueType domain.ValueType, description string, opts ...provider.WriteOption,
 ) (domain.Version, error) {
@@ -287,6 +309,11 @@ func (s *Store) Create(
 		Type:      mapDomainToType(valueType),
 		Overwrite: aws.Bool(false),
 	}
+
+	if message := provider.ChangeMessageOf(opts); message != "" {
+		description = joinDescription(description, message)
+	}
+
 	if description != "" {
 		input.Description = aws.String(description)
 	}
@@ -306,7 +333,9 @@ func (s *Store) Create(
 	return domain.Version{ID: strconv.FormatInt(out.Version, 10)}, nil
 }
 
-// Put creates or updates a parameter (Overwrite=true) and returns the resulting version.
+// Put creates or updates a parameter (Overwrite=true) and returns the resulting
+// version. A provider.ChangeMessage is appended to the description; without a
+// new description it goes onto the current one, read back first (best-effort).
 func (s *Store) Put(
 	ctx context.Context, name, value string, valueType domain.ValueType, description string, opts ...provider.WriteOption,
 ) (domain.Version, error) {
@@ -316,6 +345,16 @@ func (s *Store) Put(
 		Type:      mapDomainToType(valueType),
 		Overwrite: aws.Bool(true),
 	}
+
+	if message := provider.ChangeMessageOf(opts); message != "" {
+		if description == "" {
+			current, _ := s.describeDescription(ctx, name)
+			description, _ = splitDescription(current)
+		}
+
+		description = joinDescription(description, message)
+	}
+
 	if description != "" {
 		input.Description = aws.String(description)
 	}
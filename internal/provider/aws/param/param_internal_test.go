@@ -56,3 +56,34 @@ func TestMapDomainToType(t *testing.T) {
 		})
 	}
 }
+
+func TestDescriptionMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		stored      string
+		description string
+		message     string
+	}{
+		{name: "description and message", stored: "DB endpoint [suve: rotate creds]", description: "DB endpoint", message: "rotate creds"},
+		{name: "message only", stored: "[suve: rotate creds]", message: "rotate creds"},
+		{name: "no message", stored: "DB endpoint", description: "DB endpoint"},
+		{name: "bracket inside a word is not a suffix", stored: "a[suve: x]", description: "a[suve: x]"},
+		{name: "empty", stored: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			description, message := splitDescription(tt.stored)
+			assert.Equal(t, tt.description, description)
+			assert.Equal(t, tt.message, message)
+
+			if tt.message != "" {
+				assert.Equal(t, tt.stored, joinDescription(description, message), "join inverts split")
+			}
+		})
+	}
+}
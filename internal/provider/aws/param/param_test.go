@@ -285,6 +285,53 @@ func TestHistory_NewestFirst(t *testing.T) {
 	assert.Equal(t, "1", versions[2].ID)
 }
 
+func TestHistory_ChangeMessages(t *testing.T) {
+	t.Parallel()
+
+	params := historyOldestFirst()
+	params[0].Description = aws.String("DB endpoint [suve: initial import]")
+	// Version 2 was written without a description, so it kept version 1's.
+	params[1].Description = aws.String("DB endpoint [suve: initial import]")
+	params[2].Description = aws.String("DB endpoint [suve: rotate creds]")
+
+	store := param.New(&mockClient{
+		getHistory: func(_ *ssm.GetParameterHistoryInput) (*ssm.GetParameterHistoryOutput, error) {
+			return &ssm.GetParameterHistoryOutput{Parameters: params}, nil
+		},
+	})
+
+	versions, err := store.History(t.Context(), "/my/param")
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, "rotate creds", versions[0].Message)
+	assert.Empty(t, versions[1].Message, "an inherited message is not attributed to a later write")
+	assert.Equal(t, "initial import", versions[2].Message)
+}
+
+func TestPut_ChangeMessageKeepsCurrentDescription(t *testing.T) {
+	t.Parallel()
+
+	var got *ssm.PutParameterInput
+
+	store := param.New(&mockClient{
+		describe: func(_ *ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error) {
+			return &ssm.DescribeParametersOutput{Parameters: []types.ParameterMetadata{
+				{Name: aws.String("/my/param"), Description: aws.String("DB endpoint [suve: older]")},
+			}}, nil
+		},
+		putParameter: func(in *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+			got = in
+
+			return &ssm.PutParameterOutput{Version: 4}, nil
+		},
+	})
+
+	_, err := store.Put(t.Context(), "/my/param", "v", domain.ValueTypePlaintext, "", provider.ChangeMessage{Message: "rotate creds"})
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "DB endpoint [suve: rotate creds]", aws.ToString(got.Description))
+}
+
 func TestList_Paginated(t *testing.T) {
 	t.Parallel()
 
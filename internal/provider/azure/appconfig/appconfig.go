@@ -23,11 +23,17 @@
 //     (Put) therefore GET the current tags and content-type and re-send them;
 //     Tag/Untag are GET-merge-PUT with an OnlyIfUnchanged (ETag) precondition
 //     and a small retry on a 412 conflict, re-sending the unchanged value and
-//     content-type. The content-type is held opaquely (the domain model has no
-//     content-type field) purely to survive a write; a Key Vault reference's
-//     content-type, for instance, must not be dropped by a tag-only edit. This
-//     is unblocked by azappconfig/v2, whose SetSettingOptions carries
-//     ContentType, Tags (map[string]*string) and OnlyIfUnchanged.
+//     content-type. The content-type is otherwise held opaquely (the domain
+//     model has no content-type field) purely to survive a write; a Key Vault
+//     reference's content-type, for instance, must not be dropped by a tag-only
+//     edit. This is unblocked by azappconfig/v2, whose SetSettingOptions
+//     carries ContentType, Tags (map[string]*string) and OnlyIfUnchanged.
+//
+// The one content-type the adapter does interpret is the Key Vault reference
+// (see keyvaultref.go): Get surfaces it as domain.ValueTypeReference, and a
+// Create/Put with that value type writes the reference content-type and the
+// normalized {"uri": ...} document. Following a reference to the secret is NOT
+// done here — it needs the Key Vault adapter, which the azure use cases wire in.
 package appconfig
 
 import (
@@ -37,7 +43,6 @@ import (
 	"maps"
 	"net/http"
 	"slices"
-	"sort"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/data/azappconfig/v2"
@@ -61,7 +66,8 @@ const tagWriteMaxAttempts = 3
 // methods take a resolved literal label ("" = the null/default label); the list
 // method takes a LabelFilter. SetSetting additionally carries the tags and
 // content-type to write (App Config's PUT replaces the whole key-value, so both
-// are always re-sent) and an optional ETag precondition (nil = unconditional).
+// are always re-sent) and an optional ETag precondition (nil = unconditional);
+// AddSetting carries the content-type of a new setting (nil = none).
 // The list method
 // returns a drained slice rather than the SDK's pager so tests can mock the
 // interface trivially; the production adapter (see Wrap) confines the pager
@@ -71,8 +77,10 @@ type Client interface {
 	SetSetting(
 		ctx context.Context, key, value, label string, tags map[string]*string, contentType *string, etag *azcore.ETag,
 	) (azappconfig.SetSettingResponse, error)
-	AddSetting(ctx context.Context, key, value, label string) (azappconfig.AddSettingResponse, error)
-	DeleteSetting(ctx context.Context, key, label string) (azappconfig.DeleteSettingResponse, error)
+	AddSetting(
+		ctx context.Context, key, value, label string, contentType *string,
+	) (azappconfig.AddSettingResponse, error)
+	DeleteSetting(ctx context.Context, key, label string, etag *azcore.ETag) (azappconfig.DeleteSettingResponse, error)
 	ListSettings(ctx context.Context, filter string) ([]azappconfig.Setting, error)
 }
 
@@ -117,27 +125,51 @@ func (s *Store) Resolve(_ context.Context, _, spec string) (provider.VersionRef,
 }
 
 // Get retrieves the setting's current value and maps it to a domain.Entry. Type
-// is always plaintext; Version is left empty (App Configuration has no
-// versions); the setting's tags become Tags.
+// is plaintext, or ValueTypeReference for a Key Vault reference (whose Value is
+// then the raw {"uri": ...} document, not the secret); Version is left empty
+// (App Configuration has no versions); the setting's tags become Tags, and a
+// non-empty content-type and the read-only lock (LockedField) are surfaced as
+// display-only Extra fields.
 func (s *Store) Get(ctx context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+	entry, _, err := s.GetWithETag(ctx, name)
+
+	return entry, err
+}
+
+// GetWithETag is Get that also returns the setting's ETag. App Configuration
+// has no versions, so the ETag is what staging records as a setting's base
+// version and later sends back as a provider.IfMatch precondition.
+func (s *Store) GetWithETag(ctx context.Context, name string) (*domain.Entry, string, error) {
 	label, err := aznamespace.Literal(s.namespace)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	resp, err := s.client.GetSetting(ctx, name, label)
 	if err != nil {
-		return nil, mapError(err, name, "get setting")
+		return nil, "", mapError(err, name, "get setting")
 	}
 
-	return &domain.Entry{
+	contentType := lo.FromPtr(resp.ContentType)
+
+	entry := &domain.Entry{
 		Name:     name,
 		Value:    lo.FromPtr(resp.Value),
-		Type:     domain.ValueTypePlaintext,
+		Type:     valueTypeOf(contentType),
 		Version:  domain.Version{},
 		Tags:     mapTags(resp.Tags),
 		Modified: resp.LastModified,
-	}, nil
+	}
+
+	if contentType != "" {
+		entry.Extra = append(entry.Extra, domain.Field{Label: "Content Type", Value: contentType})
+	}
+
+	if lo.FromPtr(resp.IsReadOnly) {
+		entry.Extra = append(entry.Extra, domain.Field{Label: LockedField, Value: "true"})
+	}
+
+	return entry, string(lo.FromPtr(resp.ETag)), nil
 }
 
 // History returns ErrVersioningUnsupported: App Configuration keeps no version
@@ -183,13 +215,22 @@ func (s *Store) List(ctx context.Context) ([]string, error) {
 // lives in — the axis Azure calls a "label". An empty Namespace is the null
 // (default) namespace. Value carries the setting's current value so a caller can
 // display it without a second round-trip (App Configuration's list response
-// already includes it). This type and ListWithNamespaces are App-Config-specific
-// and are NOT part of the neutral provider seam: only a caller that has
-// type-asserted the concrete App Configuration store can reach them.
+// already includes it). Type is ValueTypeReference for a Key Vault reference
+// (Value is then the {"uri": ...} document) and plaintext otherwise, so a
+// listing can badge references without a per-key Get. This type and
+// ListWithNamespaces are App-Config-specific and are NOT part of the neutral
+// provider seam: only a caller that has type-asserted the concrete App
+// Configuration store can reach them.
 type KeyNamespace struct {
 	Key       string
 	Namespace string
 	Value     string
+	Type      domain.ValueType
+	// ContentType is the setting's raw content type ("" when none), e.g. the
+	// Key Vault reference content type Type is derived from.
+	ContentType string
+	// Locked reports that the setting is read-only (see Store.Lock).
+	Locked bool
 }
 
 // ListWithNamespaces returns every setting across ALL namespaces (LabelFilter
@@ -221,21 +262,7 @@ func (s *Store) listWithNamespaces(ctx context.Context, filter, what string) ([]
 		return nil, fmt.Errorf("failed to list settings %s: %w", what, err)
 	}
 
-	out := lo.Map(settings, func(setting azappconfig.Setting, _ int) KeyNamespace {
-		return KeyNamespace{
-			Key:       lo.FromPtr(setting.Key),
-			Namespace: lo.FromPtr(setting.Label),
-			Value:     lo.FromPtr(setting.Value),
-		}
-	})
-
-	sort.Slice(out, func(i, j int) bool {
-		if out[i].Key != out[j].Key {
-			return out[i].Key < out[j].Key
-		}
-
-		return out[i].Namespace < out[j].Namespace
-	})
+	out := toKeyNamespaces(settings)
 
 	debug.From(ctx).Logf("azure appconfig: listWithNamespaces(filter=%q) -> %d settings\n", filter, len(out))
 
@@ -244,17 +271,25 @@ func (s *Store) listWithNamespaces(ctx context.Context, filter, what string) ([]
 
 // Create creates a new setting (create-only) via AddSetting and returns an empty
 // version (App Configuration is unversioned). It returns a wrapped
-// provider.ErrAlreadyExists if the setting already exists. The valueType and
-// description are ignored. A newly created setting has no tags to preserve.
+// provider.ErrAlreadyExists if the setting already exists. A
+// ValueTypeReference value is written as a Key Vault reference (see
+// referenceWrite); any other valueType, and the description, are ignored. The
+// content type of a provider.SecretAttributes option, when set, is written as
+// given. A newly created setting has no tags to preserve.
 func (s *Store) Create(
-	ctx context.Context, name, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+	ctx context.Context, name, value string, valueType domain.ValueType, _ string, opts ...provider.WriteOption,
 ) (domain.Version, error) {
 	label, err := aznamespace.Literal(s.namespace)
 	if err != nil {
 		return domain.Version{}, err
 	}
 
-	_, err = s.client.AddSetting(ctx, name, value, label)
+	value, contentType, err := referenceWrite(value, valueType, nil)
+	if err != nil {
+		return domain.Version{}, err
+	}
+
+	_, err = s.client.AddSetting(ctx, name, value, label, contentTypeOption(opts, contentType))
 	if err != nil {
 		if isAlreadyExists(err) {
 			return domain.Version{}, fmt.Errorf("%w: %s", provider.ErrAlreadyExists, name)
@@ -270,9 +305,15 @@ func (s *Store) Create(
 // version (App Configuration is unversioned). Because App Configuration's PUT
 // replaces the whole key-value, the current tags and content-type are read
 // first and re-sent so the value write does not clear them (a not-yet-existing
-// setting has neither). The valueType and description are ignored.
+// setting has neither). A ValueTypeReference value is written as a Key Vault
+// reference (see referenceWrite); any other valueType keeps the current
+// content-type, so re-pointing an existing reference stays a reference. The
+// content type of a provider.SecretAttributes option, when set, replaces
+// either (a snapshot restore writes back the exact content type). A
+// provider.IfMatch option makes the write conditional on the setting's ETag; a
+// mismatch fails with a wrapped provider.ErrModified. The description is ignored.
 func (s *Store) Put(
-	ctx context.Context, name, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+	ctx context.Context, name, value string, valueType domain.ValueType, _ string, opts ...provider.WriteOption,
 ) (domain.Version, error) {
 	label, err := aznamespace.Literal(s.namespace)
 	if err != nil {
@@ -284,22 +325,69 @@ func (s *Store) Put(
 		return domain.Version{}, err
 	}
 
-	if _, err := s.client.SetSetting(ctx, name, value, label, tags, contentType, nil); err != nil {
-		return domain.Version{}, fmt.Errorf("failed to set setting: %w", err)
+	value, contentType, err = referenceWrite(value, valueType, contentType)
+	if err != nil {
+		return domain.Version{}, err
+	}
+
+	contentType = contentTypeOption(opts, contentType)
+	etag := ifMatchETag(opts)
+
+	if _, err := s.client.SetSetting(ctx, name, value, label, tags, contentType, etag); err != nil {
+		if etag != nil && isPreconditionFailed(err) {
+			return domain.Version{}, fmt.Errorf("%w: %s (its ETag no longer matches)", provider.ErrModified, name)
+		}
+
+		return domain.Version{}, mapWriteError(err, name, "set setting")
 	}
 
 	return domain.Version{}, nil
 }
 
-// Delete removes a setting. Provider.DeleteOptions (AWS-specific) are ignored.
-func (s *Store) Delete(ctx context.Context, name string, _ ...provider.DeleteOption) error {
+// contentTypeOption returns the content type of the last
+// provider.SecretAttributes option that sets one, or contentType when none
+// does. An empty string clears the content type.
+func contentTypeOption(opts []provider.WriteOption, contentType *string) *string {
+	for _, opt := range opts {
+		if o, ok := opt.(provider.SecretAttributes); ok && o.ContentType != nil {
+			contentType = o.ContentType
+		}
+	}
+
+	return contentType
+}
+
+// ifMatchETag returns the ETag of the last provider.IfMatch option, or nil for
+// an unconditional write or delete.
+func ifMatchETag[O any](opts []O) *azcore.ETag {
+	var etag *azcore.ETag
+
+	for _, opt := range opts {
+		if o, ok := any(opt).(provider.IfMatch); ok && o.Version != "" {
+			etag = lo.ToPtr(azcore.ETag(o.Version))
+		}
+	}
+
+	return etag
+}
+
+// Delete removes a setting. Like Put, a provider.IfMatch option makes it
+// conditional on the setting's ETag, failing with a wrapped
+// provider.ErrModified on a mismatch; other DeleteOptions are ignored.
+func (s *Store) Delete(ctx context.Context, name string, opts ...provider.DeleteOption) error {
 	label, err := aznamespace.Literal(s.namespace)
 	if err != nil {
 		return err
 	}
 
-	if _, err := s.client.DeleteSetting(ctx, name, label); err != nil {
-		return mapError(err, name, "delete setting")
+	etag := ifMatchETag(opts)
+
+	if _, err := s.client.DeleteSetting(ctx, name, label, etag); err != nil {
+		if etag != nil && isPreconditionFailed(err) {
+			return fmt.Errorf("%w: %s (its ETag no longer matches)", provider.ErrModified, name)
+		}
+
+		return mapWriteError(err, name, "delete setting")
 	}
 
 	return nil
@@ -366,7 +454,7 @@ func (s *Store) mutateTags(ctx context.Context, name string, merge func(map[stri
 		}
 
 		if !isPreconditionFailed(err) {
-			return fmt.Errorf("failed to update tags: %w", err)
+			return mapWriteError(err, name, "update tags")
 		}
 
 		lastErr = err
@@ -397,6 +485,23 @@ func (s *Store) currentTagsAndContentType(
 	return resp.Tags, resp.ContentType, nil
 }
 
+// referenceWrite prepares a value write. For ValueTypeReference it normalizes
+// the value to the {"uri": ...} document (a bare secret URI is accepted) and
+// returns the Key Vault reference content-type; otherwise the value and the
+// given content-type pass through unchanged.
+func referenceWrite(value string, valueType domain.ValueType, contentType *string) (string, *string, error) {
+	if valueType != domain.ValueTypeReference {
+		return value, contentType, nil
+	}
+
+	encoded, err := EncodeKeyVaultRef(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return encoded, lo.ToPtr(KeyVaultRefContentType), nil
+}
+
 // cloneTags copies a tags map so a merge does not mutate the value read off the
 // GetSetting response.
 func cloneTags(tags map[string]*string) map[string]*string {
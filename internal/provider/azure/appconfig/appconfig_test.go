@@ -40,8 +40,8 @@ type mockClient struct {
 	setFunc func(
 		ctx context.Context, key, value, label string, tags map[string]*string, contentType *string, etag *azcore.ETag,
 	) (azappconfig.SetSettingResponse, error)
-	addFunc    func(ctx context.Context, key, value, label string) (azappconfig.AddSettingResponse, error)
-	deleteFunc func(ctx context.Context, key, label string) (azappconfig.DeleteSettingResponse, error)
+	addFunc    func(ctx context.Context, key, value, label string, contentType *string) (azappconfig.AddSettingResponse, error)
+	deleteFunc func(ctx context.Context, key, label string, etag *azcore.ETag) (azappconfig.DeleteSettingResponse, error)
 	listFunc   func(ctx context.Context, filter string) ([]azappconfig.Setting, error)
 }
 
@@ -56,13 +56,15 @@ func (m *mockClient) SetSetting(
 }
 
 func (m *mockClient) AddSetting(
-	ctx context.Context, key, value, label string,
+	ctx context.Context, key, value, label string, contentType *string,
 ) (azappconfig.AddSettingResponse, error) {
-	return m.addFunc(ctx, key, value, label)
+	return m.addFunc(ctx, key, value, label, contentType)
 }
 
-func (m *mockClient) DeleteSetting(ctx context.Context, key, label string) (azappconfig.DeleteSettingResponse, error) {
-	return m.deleteFunc(ctx, key, label)
+func (m *mockClient) DeleteSetting(
+	ctx context.Context, key, label string, etag *azcore.ETag,
+) (azappconfig.DeleteSettingResponse, error) {
+	return m.deleteFunc(ctx, key, label, etag)
 }
 
 func (m *mockClient) ListSettings(ctx context.Context, filter string) ([]azappconfig.Setting, error) {
@@ -123,6 +125,29 @@ func TestGet(t *testing.T) {
 	assert.Equal(t, []domain.Tag{{Key: "env", Value: "prod"}}, entry.Tags)
 }
 
+func TestGet_KeyVaultReference(t *testing.T) {
+	t.Parallel()
+
+	const ref = `{"uri":"https://my-vault.vault.azure.net/secrets/db-password"}`
+
+	m := &mockClient{
+		getFunc: func(_ context.Context, key, _ string) (azappconfig.GetSettingResponse, error) {
+			return azappconfig.GetSettingResponse{Setting: azappconfig.Setting{
+				Key:         lo.ToPtr(key),
+				Value:       lo.ToPtr(ref),
+				ContentType: lo.ToPtr(appconfig.KeyVaultRefContentType),
+			}}, nil
+		},
+	}
+	store := appconfig.New(m, "")
+
+	entry, err := store.Get(t.Context(), "db-password", provider.VersionRef{})
+	require.NoError(t, err)
+	assert.Equal(t, ref, entry.Value, "the reference document is returned, not the secret")
+	assert.Equal(t, domain.ValueTypeReference, entry.Type)
+	assert.Equal(t, []domain.Field{{Label: "Content Type", Value: appconfig.KeyVaultRefContentType}}, entry.Extra)
+}
+
 func TestGet_AppliesNamespaceLabel(t *testing.T) {
 	t.Parallel()
 
@@ -343,10 +368,10 @@ func TestListWithNamespaces(t *testing.T) {
 
 	assert.Equal(t, "*", gotFilter, "must list across all namespaces regardless of the store's namespace")
 	assert.Equal(t, []appconfig.KeyNamespace{
-		{Key: "alpha", Namespace: "", Value: "a-null"},
-		{Key: "alpha", Namespace: "dev", Value: "ad"},
-		{Key: "beta", Namespace: "", Value: "b-null"},
-		{Key: "beta", Namespace: "prd", Value: "bp"},
+		{Key: "alpha", Namespace: "", Value: "a-null", Type: domain.ValueTypePlaintext},
+		{Key: "alpha", Namespace: "dev", Value: "ad", Type: domain.ValueTypePlaintext},
+		{Key: "beta", Namespace: "", Value: "b-null", Type: domain.ValueTypePlaintext},
+		{Key: "beta", Namespace: "prd", Value: "bp", Type: domain.ValueTypePlaintext},
 	}, items)
 }
 
@@ -407,10 +432,10 @@ func TestListWithNamespacesScoped(t *testing.T) {
 			assert.Equal(t, tc.wantFilter, gotFilter, "the store namespace must drive the label filter")
 			// The service applies the filter; the store only sorts by (key, namespace).
 			assert.Equal(t, []appconfig.KeyNamespace{
-				{Key: "alpha", Namespace: "", Value: "a-null"},
-				{Key: "alpha", Namespace: "dev", Value: "ad"},
-				{Key: "beta", Namespace: "", Value: "b-null"},
-				{Key: "beta", Namespace: "prd", Value: "bp"},
+				{Key: "alpha", Namespace: "", Value: "a-null", Type: domain.ValueTypePlaintext},
+				{Key: "alpha", Namespace: "dev", Value: "ad", Type: domain.ValueTypePlaintext},
+				{Key: "beta", Namespace: "", Value: "b-null", Type: domain.ValueTypePlaintext},
+				{Key: "beta", Namespace: "prd", Value: "bp", Type: domain.ValueTypePlaintext},
 			}, items)
 		})
 	}
@@ -442,7 +467,7 @@ func TestCreate_AlreadyExists(t *testing.T) {
 			t.Parallel()
 
 			m := &mockClient{
-				addFunc: func(_ context.Context, _, _, _ string) (azappconfig.AddSettingResponse, error) {
+				addFunc: func(_ context.Context, _, _, _ string, _ *string) (azappconfig.AddSettingResponse, error) {
 					return azappconfig.AddSettingResponse{}, errFn()
 				},
 			}
@@ -458,7 +483,7 @@ func TestCreate_Error(t *testing.T) {
 	t.Parallel()
 
 	m := &mockClient{
-		addFunc: func(_ context.Context, _, _, _ string) (azappconfig.AddSettingResponse, error) {
+		addFunc: func(_ context.Context, _, _, _ string, _ *string) (azappconfig.AddSettingResponse, error) {
 			return azappconfig.AddSettingResponse{}, serverError()
 		},
 	}
@@ -476,7 +501,7 @@ func TestCreate_New(t *testing.T) {
 	var added, addedLabel string
 
 	m := &mockClient{
-		addFunc: func(_ context.Context, key, value, label string) (azappconfig.AddSettingResponse, error) {
+		addFunc: func(_ context.Context, key, value, label string, _ *string) (azappconfig.AddSettingResponse, error) {
 			added, addedLabel = key, label
 
 			assert.Equal(t, "v", value)
@@ -493,12 +518,53 @@ func TestCreate_New(t *testing.T) {
 	assert.Empty(t, version.ID) // unversioned
 }
 
+func TestCreate_KeyVaultReference(t *testing.T) {
+	t.Parallel()
+
+	var sentValue string
+
+	var sentContentType *string
+
+	m := &mockClient{
+		addFunc: func(_ context.Context, _, value, _ string, contentType *string) (azappconfig.AddSettingResponse, error) {
+			sentValue, sentContentType = value, contentType
+
+			return azappconfig.AddSettingResponse{}, nil
+		},
+	}
+	store := appconfig.New(m, "")
+
+	_, err := store.Create(t.Context(), "db-password",
+		"https://my-vault.vault.azure.net/secrets/db-password", domain.ValueTypeReference, "")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"uri":"https://my-vault.vault.azure.net/secrets/db-password"}`, sentValue)
+	assert.Equal(t, appconfig.KeyVaultRefContentType, lo.FromPtr(sentContentType))
+}
+
+func TestCreate_InvalidKeyVaultReference(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	m := &mockClient{
+		addFunc: func(_ context.Context, _, _, _ string, _ *string) (azappconfig.AddSettingResponse, error) {
+			called = true
+
+			return azappconfig.AddSettingResponse{}, nil
+		},
+	}
+	store := appconfig.New(m, "")
+
+	_, err := store.Create(t.Context(), "db-password", "hunter2", domain.ValueTypeReference, "")
+	require.ErrorIs(t, err, appconfig.ErrInvalidKeyVaultRef)
+	assert.False(t, called)
+}
+
 func TestCreate_RejectsFilterNamespace(t *testing.T) {
 	t.Parallel()
 
 	called := false
 	m := &mockClient{
-		addFunc: func(_ context.Context, _, _, _ string) (azappconfig.AddSettingResponse, error) {
+		addFunc: func(_ context.Context, _, _, _ string, _ *string) (azappconfig.AddSettingResponse, error) {
 			called = true
 
 			return azappconfig.AddSettingResponse{}, nil
@@ -607,6 +673,78 @@ func TestPut_PreservesContentType(t *testing.T) {
 	assert.Equal(t, kvRef, lo.FromPtr(sentContentType))
 }
 
+func TestPut_KeyVaultReferenceSetsContentType(t *testing.T) {
+	t.Parallel()
+
+	var sentValue string
+
+	var sentContentType *string
+
+	m := &mockClient{
+		getFunc: func(_ context.Context, key, _ string) (azappconfig.GetSettingResponse, error) {
+			return azappconfig.GetSettingResponse{Setting: azappconfig.Setting{
+				Key:         lo.ToPtr(key),
+				Value:       lo.ToPtr("plain"),
+				ContentType: lo.ToPtr("text/plain"),
+			}}, nil
+		},
+		setFunc: func(
+			_ context.Context, _, value, _ string, _ map[string]*string, contentType *string, _ *azcore.ETag,
+		) (azappconfig.SetSettingResponse, error) {
+			sentValue, sentContentType = value, contentType
+
+			return azappconfig.SetSettingResponse{}, nil
+		},
+	}
+	store := appconfig.New(m, "")
+
+	_, err := store.Put(t.Context(), "db-password",
+		`{"uri":"https://my-vault.vault.azure.net/secrets/db-password/v2"}`, domain.ValueTypeReference, "")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"uri":"https://my-vault.vault.azure.net/secrets/db-password/v2"}`, sentValue)
+	assert.Equal(t, appconfig.KeyVaultRefContentType, lo.FromPtr(sentContentType))
+}
+
+func TestPut_ContentTypeOption(t *testing.T) {
+	t.Parallel()
+
+	var sentContentType *string
+
+	m := &mockClient{
+		getFunc: func(_ context.Context, key, _ string) (azappconfig.GetSettingResponse, error) {
+			return azappconfig.GetSettingResponse{Setting: azappconfig.Setting{
+				Key:         lo.ToPtr(key),
+				Value:       lo.ToPtr("ref"),
+				ContentType: lo.ToPtr(appconfig.KeyVaultRefContentType),
+			}}, nil
+		},
+		setFunc: func(
+			_ context.Context, _, _, _ string, _ map[string]*string, contentType *string, _ *azcore.ETag,
+		) (azappconfig.SetSettingResponse, error) {
+			sentContentType = contentType
+
+			return azappconfig.SetSettingResponse{}, nil
+		},
+		addFunc: func(_ context.Context, _, _, _ string, contentType *string) (azappconfig.AddSettingResponse, error) {
+			sentContentType = contentType
+
+			return azappconfig.AddSettingResponse{}, nil
+		},
+	}
+	store := appconfig.New(m, "")
+
+	_, err := store.Put(t.Context(), "k", "ref", domain.ValueTypePlaintext, "",
+		provider.SecretAttributes{ContentType: lo.ToPtr("")})
+	require.NoError(t, err)
+	require.NotNil(t, sentContentType)
+	assert.Empty(t, *sentContentType, "an empty content type clears the reference one")
+
+	_, err = store.Create(t.Context(), "k2", "{}", domain.ValueTypePlaintext, "",
+		provider.SecretAttributes{ContentType: lo.ToPtr("application/json")})
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", lo.FromPtr(sentContentType))
+}
+
 func TestPut_Error(t *testing.T) {
 	t.Parallel()
 
@@ -627,6 +765,51 @@ func TestPut_Error(t *testing.T) {
 	assert.Contains(t, err.Error(), "set setting")
 }
 
+// TestPut_IfMatch asserts a provider.IfMatch option is sent as the
+// OnlyIfUnchanged precondition and that a 412 maps to provider.ErrModified.
+func TestPut_IfMatch(t *testing.T) {
+	t.Parallel()
+
+	var gotETag *azcore.ETag
+
+	m := &mockClient{
+		getFunc: func(_ context.Context, key, _ string) (azappconfig.GetSettingResponse, error) {
+			return azappconfig.GetSettingResponse{Setting: azappconfig.Setting{Key: lo.ToPtr(key), Value: lo.ToPtr("old")}}, nil
+		},
+		setFunc: func(
+			_ context.Context, _, _, _ string, _ map[string]*string, _ *string, e *azcore.ETag,
+		) (azappconfig.SetSettingResponse, error) {
+			gotETag = e
+
+			return azappconfig.SetSettingResponse{}, preconditionFailed()
+		},
+	}
+	store := appconfig.New(m, "")
+
+	_, err := store.Put(t.Context(), "app/timeout", "60", domain.ValueTypePlaintext, "", provider.IfMatch{Version: "v1"})
+	require.ErrorIs(t, err, provider.ErrModified)
+	assert.Equal(t, lo.ToPtr(azcore.ETag("v1")), gotETag)
+}
+
+func TestGetWithETag(t *testing.T) {
+	t.Parallel()
+
+	m := &mockClient{
+		getFunc: func(_ context.Context, key, _ string) (azappconfig.GetSettingResponse, error) {
+			return azappconfig.GetSettingResponse{Setting: azappconfig.Setting{
+				Key:   lo.ToPtr(key),
+				Value: lo.ToPtr("60"),
+				ETag:  lo.ToPtr(azcore.ETag("v7")),
+			}}, nil
+		},
+	}
+
+	entry, etag, err := appconfig.New(m, "").GetWithETag(t.Context(), "app/timeout")
+	require.NoError(t, err)
+	assert.Equal(t, "60", entry.Value)
+	assert.Equal(t, "v7", etag)
+}
+
 func TestPut_RejectsFilterNamespace(t *testing.T) {
 	t.Parallel()
 
@@ -654,8 +837,9 @@ func TestDelete(t *testing.T) {
 	var deleted, deletedLabel string
 
 	m := &mockClient{
-		deleteFunc: func(_ context.Context, key, label string) (azappconfig.DeleteSettingResponse, error) {
+		deleteFunc: func(_ context.Context, key, label string, etag *azcore.ETag) (azappconfig.DeleteSettingResponse, error) {
 			deleted, deletedLabel = key, label
+			assert.Nil(t, etag)
 
 			return azappconfig.DeleteSettingResponse{}, nil
 		},
@@ -671,7 +855,7 @@ func TestDelete_NotFound(t *testing.T) {
 	t.Parallel()
 
 	m := &mockClient{
-		deleteFunc: func(_ context.Context, _, _ string) (azappconfig.DeleteSettingResponse, error) {
+		deleteFunc: func(_ context.Context, _, _ string, _ *azcore.ETag) (azappconfig.DeleteSettingResponse, error) {
 			return azappconfig.DeleteSettingResponse{}, notFound()
 		},
 	}
@@ -685,7 +869,7 @@ func TestDelete_Error(t *testing.T) {
 	t.Parallel()
 
 	m := &mockClient{
-		deleteFunc: func(_ context.Context, _, _ string) (azappconfig.DeleteSettingResponse, error) {
+		deleteFunc: func(_ context.Context, _, _ string, _ *azcore.ETag) (azappconfig.DeleteSettingResponse, error) {
 			return azappconfig.DeleteSettingResponse{}, serverError()
 		},
 	}
@@ -697,12 +881,33 @@ func TestDelete_Error(t *testing.T) {
 	assert.Contains(t, err.Error(), "delete setting")
 }
 
+// TestDelete_IfMatch asserts a provider.IfMatch option is sent as the
+// OnlyIfUnchanged precondition and that a 412 maps to provider.ErrModified.
+func TestDelete_IfMatch(t *testing.T) {
+	t.Parallel()
+
+	var gotETag *azcore.ETag
+
+	m := &mockClient{
+		deleteFunc: func(_ context.Context, _, _ string, e *azcore.ETag) (azappconfig.DeleteSettingResponse, error) {
+			gotETag = e
+
+			return azappconfig.DeleteSettingResponse{}, preconditionFailed()
+		},
+	}
+	store := appconfig.New(m, "")
+
+	err := store.Delete(t.Context(), "app/timeout", provider.IfMatch{Version: "v1"})
+	require.ErrorIs(t, err, provider.ErrModified)
+	assert.Equal(t, lo.ToPtr(azcore.ETag("v1")), gotETag)
+}
+
 func TestDelete_RejectsFilterNamespace(t *testing.T) {
 	t.Parallel()
 
 	called := false
 	m := &mockClient{
-		deleteFunc: func(_ context.Context, _, _ string) (azappconfig.DeleteSettingResponse, error) {
+		deleteFunc: func(_ context.Context, _, _ string, _ *azcore.ETag) (azappconfig.DeleteSettingResponse, error) {
 			called = true
 
 			return azappconfig.DeleteSettingResponse{}, nil
@@ -61,24 +61,44 @@ func (a *apiClient) SetSetting(
 	return a.c.SetSetting(ctx, key, lo.ToPtr(value), opts)
 }
 
-func (a *apiClient) AddSetting(ctx context.Context, key, value, label string) (azappconfig.AddSettingResponse, error) {
-	var opts *azappconfig.AddSettingOptions
+func (a *apiClient) AddSetting(
+	ctx context.Context, key, value, label string, contentType *string,
+) (azappconfig.AddSettingResponse, error) {
+	opts := &azappconfig.AddSettingOptions{ContentType: contentType}
 	if label != "" {
-		opts = &azappconfig.AddSettingOptions{Label: lo.ToPtr(label)}
+		opts.Label = lo.ToPtr(label)
 	}
 
 	return a.c.AddSetting(ctx, key, lo.ToPtr(value), opts)
 }
 
-func (a *apiClient) DeleteSetting(ctx context.Context, key, label string) (azappconfig.DeleteSettingResponse, error) {
-	var opts *azappconfig.DeleteSettingOptions
+// DeleteSetting deletes key under label, with an OnlyIfUnchanged precondition
+// when etag is non-nil.
+func (a *apiClient) DeleteSetting(
+	ctx context.Context, key, label string, etag *azcore.ETag,
+) (azappconfig.DeleteSettingResponse, error) {
+	opts := &azappconfig.DeleteSettingOptions{OnlyIfUnchanged: etag}
 	if label != "" {
-		opts = &azappconfig.DeleteSettingOptions{Label: lo.ToPtr(label)}
+		opts.Label = lo.ToPtr(label)
 	}
 
 	return a.c.DeleteSetting(ctx, key, opts)
 }
 
+// Compile-time assertion that apiClient also satisfies LockClient.
+var _ LockClient = (*apiClient)(nil)
+
+func (a *apiClient) SetReadOnly(
+	ctx context.Context, key, label string, readOnly bool,
+) (azappconfig.SetReadOnlyResponse, error) {
+	var opts *azappconfig.SetReadOnlyOptions
+	if label != "" {
+		opts = &azappconfig.SetReadOnlyOptions{Label: lo.ToPtr(label)}
+	}
+
+	return a.c.SetReadOnly(ctx, key, readOnly, opts)
+}
+
 // listSettingSelector is the selector used to enumerate settings: all keys,
 // restricted by the given LabelFilter. The filter is resolved by the store from
 // the raw --namespace value (empty -> the null-label filter, aznamespace.Filter).
@@ -105,3 +125,70 @@ func (a *apiClient) ListSettings(ctx context.Context, filter string) ([]azappcon
 
 	return out, nil
 }
+
+// Compile-time assertion that apiClient also satisfies SnapshotClient.
+var _ SnapshotClient = (*apiClient)(nil)
+
+// CreateSnapshot starts a snapshot composed of the given filters and polls the
+// long-running operation until the snapshot is provisioned. A nil retention
+// leaves the service default.
+func (a *apiClient) CreateSnapshot(
+	ctx context.Context, name string, filters []azappconfig.SettingFilter, retention *int64,
+) (azappconfig.Snapshot, error) {
+	poller, err := a.c.BeginCreateSnapshot(ctx, name, filters, &azappconfig.CreateSnapshotOptions{
+		RetentionPeriod: retention,
+	})
+	if err != nil {
+		return azappconfig.Snapshot{}, err
+	}
+
+	resp, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return azappconfig.Snapshot{}, err
+	}
+
+	return resp.Snapshot, nil
+}
+
+func (a *apiClient) GetSnapshot(ctx context.Context, name string) (azappconfig.Snapshot, error) {
+	resp, err := a.c.GetSnapshot(ctx, name, nil)
+	if err != nil {
+		return azappconfig.Snapshot{}, err
+	}
+
+	return resp.Snapshot, nil
+}
+
+func (a *apiClient) ListSnapshots(ctx context.Context) ([]azappconfig.Snapshot, error) {
+	pager := a.c.NewListSnapshotsPager(nil)
+
+	var out []azappconfig.Snapshot
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, page.Snapshots...)
+	}
+
+	return out, nil
+}
+
+func (a *apiClient) ListSnapshotSettings(ctx context.Context, name string) ([]azappconfig.Setting, error) {
+	pager := a.c.NewListSettingsForSnapshotPager(name, nil)
+
+	var out []azappconfig.Setting
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, page.Settings...)
+	}
+
+	return out, nil
+}
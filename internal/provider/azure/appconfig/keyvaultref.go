@@ -0,0 +1,120 @@
+package appconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mpyw/suve/internal/domain"
+)
+
+// KeyVaultRefContentType is the content-type App Configuration (and every Azure
+// configuration provider) recognizes as a Key Vault reference. A setting
+// carrying it holds only a small JSON document pointing at a Key Vault secret,
+// not the secret value itself.
+const KeyVaultRefContentType = "application/vnd.microsoft.appconfig.keyvaultref+json;charset=utf-8"
+
+// keyVaultRefMediaType is the media type of KeyVaultRefContentType without
+// parameters, used to recognize references written with or without a charset.
+const keyVaultRefMediaType = "application/vnd.microsoft.appconfig.keyvaultref+json"
+
+// ErrInvalidKeyVaultRef is returned when a Key Vault reference value cannot be
+// parsed or does not point at a Key Vault secret.
+var ErrInvalidKeyVaultRef = errors.New("invalid Key Vault reference")
+
+// KeyVaultRef is a parsed Key Vault reference: the secret it points at.
+type KeyVaultRef struct {
+	// URI is the secret identifier as written in the reference, e.g.
+	// https://my-vault.vault.azure.net/secrets/db-password[/<version>].
+	URI string
+	// VaultName is the vault's name (the first DNS label of the URI's host).
+	VaultName string
+	// SecretName is the referenced secret's name.
+	SecretName string
+	// Version pins a specific secret version; empty follows the latest.
+	Version string
+}
+
+// IsKeyVaultRefContentType reports whether contentType marks a Key Vault
+// reference. Parameters (charset) and letter case are ignored.
+func IsKeyVaultRefContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+
+	return strings.EqualFold(strings.TrimSpace(mediaType), keyVaultRefMediaType)
+}
+
+// valueTypeOf maps a setting's content-type onto the neutral value type: a Key
+// Vault reference is ValueTypeReference, everything else is plaintext.
+func valueTypeOf(contentType string) domain.ValueType {
+	if IsKeyVaultRefContentType(contentType) {
+		return domain.ValueTypeReference
+	}
+
+	return domain.ValueTypePlaintext
+}
+
+// keyVaultRefDocument is the JSON body of a Key Vault reference setting.
+type keyVaultRefDocument struct {
+	URI string `json:"uri"`
+}
+
+// ParseKeyVaultRef parses a Key Vault reference setting value ({"uri": "..."}).
+func ParseKeyVaultRef(value string) (*KeyVaultRef, error) {
+	var doc keyVaultRefDocument
+	if err := json.Unmarshal([]byte(value), &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyVaultRef, err)
+	}
+
+	return parseSecretURI(doc.URI)
+}
+
+// EncodeKeyVaultRef normalizes a reference to the JSON document App
+// Configuration stores. It accepts either that document or a bare secret URI,
+// so a user can paste the identifier straight from the portal.
+func EncodeKeyVaultRef(value string) (string, error) {
+	uri := strings.TrimSpace(value)
+
+	if strings.HasPrefix(uri, "{") {
+		ref, err := ParseKeyVaultRef(uri)
+		if err != nil {
+			return "", err
+		}
+
+		uri = ref.URI
+	} else if _, err := parseSecretURI(uri); err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(keyVaultRefDocument{URI: uri})
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidKeyVaultRef, err)
+	}
+
+	return string(body), nil
+}
+
+// parseSecretURI validates a Key Vault secret identifier
+// (https://<vault>.<domain>/secrets/<name>[/<version>]).
+func parseSecretURI(uri string) (*KeyVaultRef, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("%w: %q is not an https secret identifier", ErrInvalidKeyVaultRef, uri)
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 || len(segments) > 3 || segments[0] != "secrets" || segments[1] == "" {
+		return nil, fmt.Errorf("%w: %q does not name a secret (expected /secrets/<name>[/<version>])",
+			ErrInvalidKeyVaultRef, uri)
+	}
+
+	vaultName, _, _ := strings.Cut(u.Hostname(), ".")
+
+	ref := &KeyVaultRef{URI: uri, VaultName: vaultName, SecretName: segments[1]}
+	if len(segments) == 3 {
+		ref.Version = segments[2]
+	}
+
+	return ref, nil
+}
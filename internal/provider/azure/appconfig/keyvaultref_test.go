@@ -0,0 +1,101 @@
+package appconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider/azure/appconfig"
+)
+
+func TestIsKeyVaultRefContentType(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, appconfig.IsKeyVaultRefContentType(appconfig.KeyVaultRefContentType))
+	assert.True(t, appconfig.IsKeyVaultRefContentType("application/vnd.microsoft.appconfig.keyvaultref+json"))
+	assert.True(t, appconfig.IsKeyVaultRefContentType("Application/VND.Microsoft.AppConfig.KeyVaultRef+JSON ; charset=utf-8"))
+	assert.False(t, appconfig.IsKeyVaultRefContentType("application/json"))
+	assert.False(t, appconfig.IsKeyVaultRefContentType(""))
+}
+
+func TestParseKeyVaultRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    *appconfig.KeyVaultRef
+		wantErr bool
+	}{
+		{
+			name:  "latest",
+			value: `{"uri":"https://my-vault.vault.azure.net/secrets/db-password"}`,
+			want: &appconfig.KeyVaultRef{
+				URI:        "https://my-vault.vault.azure.net/secrets/db-password",
+				VaultName:  "my-vault",
+				SecretName: "db-password",
+			},
+		},
+		{
+			name:  "pinned version",
+			value: `{"uri":"https://my-vault.vault.azure.net/secrets/db-password/abc123"}`,
+			want: &appconfig.KeyVaultRef{
+				URI:        "https://my-vault.vault.azure.net/secrets/db-password/abc123",
+				VaultName:  "my-vault",
+				SecretName: "db-password",
+				Version:    "abc123",
+			},
+		},
+		{name: "not json", value: "https://my-vault.vault.azure.net/secrets/x", wantErr: true},
+		{name: "http scheme", value: `{"uri":"http://my-vault.vault.azure.net/secrets/x"}`, wantErr: true},
+		{name: "keys collection", value: `{"uri":"https://my-vault.vault.azure.net/keys/x"}`, wantErr: true},
+		{name: "missing name", value: `{"uri":"https://my-vault.vault.azure.net/secrets/"}`, wantErr: true},
+		{name: "empty uri", value: `{}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := appconfig.ParseKeyVaultRef(tt.value)
+			if tt.wantErr {
+				require.ErrorIs(t, err, appconfig.ErrInvalidKeyVaultRef)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEncodeKeyVaultRef(t *testing.T) {
+	t.Parallel()
+
+	const want = `{"uri":"https://my-vault.vault.azure.net/secrets/db-password"}`
+
+	t.Run("bare uri is wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := appconfig.EncodeKeyVaultRef("  https://my-vault.vault.azure.net/secrets/db-password\n")
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("document is normalized", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := appconfig.EncodeKeyVaultRef(`{ "uri" : "https://my-vault.vault.azure.net/secrets/db-password" }`)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("invalid is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := appconfig.EncodeKeyVaultRef("hunter2")
+		require.ErrorIs(t, err, appconfig.ErrInvalidKeyVaultRef)
+	})
+}
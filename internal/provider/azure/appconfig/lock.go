@@ -0,0 +1,110 @@
+package appconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azappconfig/v2"
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/azure/appconfig/aznamespace"
+)
+
+// LockedField is the label of the display-only Extra field Get adds to a locked
+// (read-only) setting, so callers holding only a domain.Entry can tell.
+const LockedField = "Locked"
+
+// LockClient is the narrow App Configuration read-only lock surface. Like
+// SnapshotClient it is kept separate from Client so the key-value mocks stay
+// small: the Store reaches it by type-asserting its client.
+type LockClient interface {
+	SetReadOnly(ctx context.Context, key, label string, readOnly bool) (azappconfig.SetReadOnlyResponse, error)
+}
+
+// ErrLocksUnsupported is returned by Lock/Unlock when the store's client does
+// not implement LockClient.
+var ErrLocksUnsupported = errors.New("App Configuration locks are not supported by this client")
+
+// lockClient returns the store's client as a LockClient, or ErrLocksUnsupported.
+func (s *Store) lockClient() (LockClient, error) {
+	lc, ok := s.client.(LockClient)
+	if !ok {
+		return nil, ErrLocksUnsupported
+	}
+
+	return lc, nil
+}
+
+// Lock marks the setting read-only under the store's namespace. Locking an
+// already locked setting is a no-op on the service side.
+func (s *Store) Lock(ctx context.Context, name string) error {
+	return s.setReadOnly(ctx, name, true, "lock setting")
+}
+
+// Unlock clears the setting's read-only flag under the store's namespace.
+func (s *Store) Unlock(ctx context.Context, name string) error {
+	return s.setReadOnly(ctx, name, false, "unlock setting")
+}
+
+func (s *Store) setReadOnly(ctx context.Context, name string, readOnly bool, op string) error {
+	lc, err := s.lockClient()
+	if err != nil {
+		return err
+	}
+
+	label, err := aznamespace.Literal(s.namespace)
+	if err != nil {
+		return err
+	}
+
+	if _, err := lc.SetReadOnly(ctx, name, label, readOnly); err != nil {
+		return mapError(err, name, op)
+	}
+
+	return nil
+}
+
+// Locked reports whether the setting is read-only. A missing setting is not
+// locked (a staged create of it is free to proceed), so ErrNotFound maps to
+// false with a nil error.
+func (s *Store) Locked(ctx context.Context, name string) (bool, error) {
+	label, err := aznamespace.Literal(s.namespace)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := s.client.GetSetting(ctx, name, label)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+
+		return false, mapError(err, name, "get setting")
+	}
+
+	return lo.FromPtr(resp.IsReadOnly), nil
+}
+
+// isLocked reports whether err is the 409 Conflict App Configuration returns
+// for a write to a read-only setting. It is only consulted on PUT/DELETE, where
+// 409 has no other meaning (AddSetting's 409 is an already-exists).
+func isLocked(err error) bool {
+	var re *azcore.ResponseError
+
+	return errors.As(err, &re) && re.StatusCode == http.StatusConflict
+}
+
+// mapWriteError maps a PUT/DELETE failure: a read-only setting becomes a
+// wrapped provider.ErrLocked (instead of the service's bare 409), everything
+// else goes through mapError.
+func mapWriteError(err error, name, op string) error {
+	if isLocked(err) {
+		return fmt.Errorf("%w: %s (unlock it with `suve azure param unlock`)", provider.ErrLocked, name)
+	}
+
+	return mapError(err, name, op)
+}
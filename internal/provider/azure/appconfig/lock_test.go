@@ -0,0 +1,132 @@
+package appconfig_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azappconfig/v2"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/azure/appconfig"
+)
+
+// lockMockClient extends mockClient with the optional LockClient surface.
+type lockMockClient struct {
+	mockClient
+
+	readOnlyFunc func(ctx context.Context, key, label string, readOnly bool) (azappconfig.SetReadOnlyResponse, error)
+}
+
+func (m *lockMockClient) SetReadOnly(
+	ctx context.Context, key, label string, readOnly bool,
+) (azappconfig.SetReadOnlyResponse, error) {
+	return m.readOnlyFunc(ctx, key, label, readOnly)
+}
+
+func TestLockUnlock(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		key, label string
+		readOnly   bool
+	}
+
+	var calls []call
+
+	client := &lockMockClient{
+		readOnlyFunc: func(_ context.Context, key, label string, readOnly bool) (azappconfig.SetReadOnlyResponse, error) {
+			calls = append(calls, call{key, label, readOnly})
+
+			return azappconfig.SetReadOnlyResponse{}, nil
+		},
+	}
+
+	store := appconfig.New(client, "prod")
+	require.NoError(t, store.Lock(t.Context(), "app/key"))
+	require.NoError(t, store.Unlock(t.Context(), "app/key"))
+	assert.Equal(t, []call{{"app/key", "prod", true}, {"app/key", "prod", false}}, calls)
+}
+
+func TestLock_NotFound(t *testing.T) {
+	t.Parallel()
+
+	client := &lockMockClient{
+		readOnlyFunc: func(context.Context, string, string, bool) (azappconfig.SetReadOnlyResponse, error) {
+			return azappconfig.SetReadOnlyResponse{}, notFound()
+		},
+	}
+
+	err := appconfig.New(client, "").Lock(t.Context(), "missing")
+	require.ErrorIs(t, err, provider.ErrNotFound)
+}
+
+func TestLock_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	err := appconfig.New(&mockClient{}, "").Lock(t.Context(), "app/key")
+	require.ErrorIs(t, err, appconfig.ErrLocksUnsupported)
+}
+
+func TestLocked(t *testing.T) {
+	t.Parallel()
+
+	settings := map[string]bool{"locked": true, "open": false}
+	client := &mockClient{
+		getFunc: func(_ context.Context, key, _ string) (azappconfig.GetSettingResponse, error) {
+			readOnly, ok := settings[key]
+			if !ok {
+				return azappconfig.GetSettingResponse{}, notFound()
+			}
+
+			return azappconfig.GetSettingResponse{Setting: azappconfig.Setting{
+				Key: lo.ToPtr(key), Value: lo.ToPtr("v"), IsReadOnly: lo.ToPtr(readOnly),
+			}}, nil
+		},
+	}
+	store := appconfig.New(client, "")
+
+	for key, want := range map[string]bool{"locked": true, "open": false, "missing": false} {
+		got, err := store.Locked(t.Context(), key)
+		require.NoError(t, err, key)
+		assert.Equal(t, want, got, key)
+	}
+
+	entry, err := store.Get(t.Context(), "locked", provider.VersionRef{})
+	require.NoError(t, err)
+	assert.Contains(t, entry.Extra, domain.Field{Label: appconfig.LockedField, Value: "true"})
+}
+
+func TestWriteToLockedSetting(t *testing.T) {
+	t.Parallel()
+
+	client := &mockClient{
+		getFunc: func(_ context.Context, key, _ string) (azappconfig.GetSettingResponse, error) {
+			return azappconfig.GetSettingResponse{Setting: azappconfig.Setting{
+				Key: lo.ToPtr(key), Value: lo.ToPtr("v"), IsReadOnly: lo.ToPtr(true),
+			}}, nil
+		},
+		setFunc: func(
+			context.Context, string, string, string, map[string]*string, *string, *azcore.ETag,
+		) (azappconfig.SetSettingResponse, error) {
+			return azappconfig.SetSettingResponse{}, conflict()
+		},
+		deleteFunc: func(context.Context, string, string, *azcore.ETag) (azappconfig.DeleteSettingResponse, error) {
+			return azappconfig.DeleteSettingResponse{}, conflict()
+		},
+	}
+	store := appconfig.New(client, "")
+
+	_, err := store.Put(t.Context(), "app/key", "new", domain.ValueTypePlaintext, "")
+	require.ErrorIs(t, err, provider.ErrLocked)
+
+	err = store.Delete(t.Context(), "app/key")
+	require.ErrorIs(t, err, provider.ErrLocked)
+
+	err = store.Tag(t.Context(), "app/key", map[string]string{"env": "prod"})
+	require.ErrorIs(t, err, provider.ErrLocked)
+}
@@ -0,0 +1,312 @@
+package appconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azappconfig/v2"
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/debug"
+	"github.com/mpyw/suve/internal/provider/azure/appconfig/aznamespace"
+)
+
+// SnapshotClient is the narrow App Configuration snapshot surface. It is kept
+// separate from Client so the key-value mocks stay small: the Store reaches it
+// by type-asserting its client, and only the production adapter (see Wrap) and
+// tests that exercise snapshots implement it. Like ListSettings, the list
+// methods return drained slices rather than SDK pagers.
+type SnapshotClient interface {
+	CreateSnapshot(
+		ctx context.Context, name string, filters []azappconfig.SettingFilter, retention *int64,
+	) (azappconfig.Snapshot, error)
+	GetSnapshot(ctx context.Context, name string) (azappconfig.Snapshot, error)
+	ListSnapshots(ctx context.Context) ([]azappconfig.Snapshot, error)
+	ListSnapshotSettings(ctx context.Context, name string) ([]azappconfig.Setting, error)
+}
+
+// ErrSnapshotsUnsupported is returned by the snapshot methods when the store's
+// client does not implement SnapshotClient.
+var ErrSnapshotsUnsupported = errors.New("App Configuration snapshots are not supported by this client")
+
+// Snapshot is the SDK-free view of an App Configuration snapshot: an immutable,
+// point-in-time set of key-values selected by Filters across namespaces.
+type Snapshot struct {
+	// Name is the snapshot's name, unique within the store.
+	Name string
+	// Status is the snapshot lifecycle status ("provisioning", "ready",
+	// "archived" or "failed").
+	Status string
+	// Created is the snapshot creation time, if known.
+	Created *time.Time
+	// Expires is when an archived snapshot is purged, if set.
+	Expires *time.Time
+	// ItemsCount is the number of key-values captured.
+	ItemsCount int64
+	// Filters are the key/namespace filters the snapshot was composed from.
+	Filters []SnapshotFilter
+}
+
+// SnapshotFilter is one key/namespace filter of a snapshot, in App
+// Configuration's raw filter grammar ("*" = all, "app/*" = prefix). An empty
+// Namespace selects the null (default) namespace.
+type SnapshotFilter struct {
+	Key       string
+	Namespace string
+}
+
+// snapshotClient returns the store's client as a SnapshotClient, or
+// ErrSnapshotsUnsupported.
+func (s *Store) snapshotClient() (SnapshotClient, error) {
+	sc, ok := s.client.(SnapshotClient)
+	if !ok {
+		return nil, ErrSnapshotsUnsupported
+	}
+
+	return sc, nil
+}
+
+// CreateSnapshot captures every key matching keyFilter ("" = all keys) under
+// the store's configured namespace filter into a new snapshot and waits until it
+// is provisioned. A zero retention leaves the service default for how long the
+// snapshot is kept once archived.
+func (s *Store) CreateSnapshot(
+	ctx context.Context, name, keyFilter string, retention time.Duration,
+) (*Snapshot, error) {
+	sc, err := s.snapshotClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if keyFilter == "" {
+		keyFilter = aznamespace.AllNamespacesFilter
+	}
+
+	filters := []azappconfig.SettingFilter{{
+		KeyFilter:   lo.ToPtr(keyFilter),
+		LabelFilter: lo.ToPtr(aznamespace.Filter(s.namespace)),
+	}}
+
+	var retentionSeconds *int64
+	if retention > 0 {
+		retentionSeconds = lo.ToPtr(int64(retention / time.Second))
+	}
+
+	snap, err := sc.CreateSnapshot(ctx, name, filters, retentionSeconds)
+	if err != nil {
+		if isAlreadyExists(err) {
+			return nil, fmt.Errorf("snapshot %q already exists: %w", name, err)
+		}
+
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	return mapSnapshot(snap), nil
+}
+
+// Snapshot returns the named snapshot's metadata. A missing snapshot maps to a
+// wrapped provider.ErrNotFound.
+func (s *Store) Snapshot(ctx context.Context, name string) (*Snapshot, error) {
+	sc, err := s.snapshotClient()
+	if err != nil {
+		return nil, err
+	}
+
+	snap, err := sc.GetSnapshot(ctx, name)
+	if err != nil {
+		return nil, mapError(err, name, "get snapshot")
+	}
+
+	return mapSnapshot(snap), nil
+}
+
+// ListSnapshots returns every snapshot in the store, sorted by name. Snapshots
+// are store-wide, so the configured namespace does not narrow the listing.
+func (s *Store) ListSnapshots(ctx context.Context) ([]Snapshot, error) {
+	sc, err := s.snapshotClient()
+	if err != nil {
+		return nil, err
+	}
+
+	snaps, err := sc.ListSnapshots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	out := lo.Map(snaps, func(snap azappconfig.Snapshot, _ int) Snapshot {
+		return *mapSnapshot(snap)
+	})
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+
+	return out, nil
+}
+
+// SnapshotSettings returns the key-values captured by the named snapshot, each
+// paired with its namespace, sorted by key then namespace.
+func (s *Store) SnapshotSettings(ctx context.Context, name string) ([]KeyNamespace, error) {
+	sc, err := s.snapshotClient()
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := sc.ListSnapshotSettings(ctx, name)
+	if err != nil {
+		return nil, mapError(err, name, "list snapshot settings")
+	}
+
+	out := toKeyNamespaces(settings)
+
+	debug.From(ctx).Logf("azure appconfig: ListSnapshotSettings(%q) -> %d settings\n", name, len(out))
+
+	return out, nil
+}
+
+// LiveSettings returns the store's CURRENT key-values selected by the given
+// snapshot's filters, sorted by key then namespace. It is the live counterpart
+// of SnapshotSettings: comparing the two shows exactly what changed since the
+// snapshot, without dragging in keys the snapshot never covered. Namespace
+// filters are applied by the service; key filters are matched client-side.
+func (s *Store) LiveSettings(ctx context.Context, snap *Snapshot) ([]KeyNamespace, error) {
+	type identity struct{ key, namespace string }
+
+	seen := map[identity]struct{}{}
+
+	var settings []azappconfig.Setting
+
+	for _, f := range snap.Filters {
+		listed, err := s.client.ListSettings(ctx, aznamespace.Filter(f.Namespace))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list settings: %w", err)
+		}
+
+		for _, setting := range listed {
+			id := identity{key: lo.FromPtr(setting.Key), namespace: lo.FromPtr(setting.Label)}
+			if _, dup := seen[id]; dup || !matchKeyFilter(f.Key, id.key) {
+				continue
+			}
+
+			seen[id] = struct{}{}
+
+			settings = append(settings, setting)
+		}
+	}
+
+	return toKeyNamespaces(settings), nil
+}
+
+// toKeyNamespaces maps settings to (key, namespace, value, type, content type)
+// rows sorted by key then namespace.
+func toKeyNamespaces(settings []azappconfig.Setting) []KeyNamespace {
+	out := lo.Map(settings, func(setting azappconfig.Setting, _ int) KeyNamespace {
+		return KeyNamespace{
+			Key:         lo.FromPtr(setting.Key),
+			Namespace:   lo.FromPtr(setting.Label),
+			Value:       lo.FromPtr(setting.Value),
+			Type:        valueTypeOf(lo.FromPtr(setting.ContentType)),
+			ContentType: lo.FromPtr(setting.ContentType),
+			Locked:      lo.FromPtr(setting.IsReadOnly),
+		}
+	})
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Key != out[j].Key {
+			return out[i].Key < out[j].Key
+		}
+
+		return out[i].Namespace < out[j].Namespace
+	})
+
+	return out
+}
+
+// mapSnapshot converts an SDK snapshot to the SDK-free Snapshot. The null-label
+// filter ("\x00") is decoded back to the empty namespace.
+func mapSnapshot(snap azappconfig.Snapshot) *Snapshot {
+	return &Snapshot{
+		Name:       lo.FromPtr(snap.Name),
+		Status:     string(lo.FromPtr(snap.Status)),
+		Created:    snap.Created,
+		Expires:    snap.Expires,
+		ItemsCount: lo.FromPtr(snap.ItemsCount),
+		Filters: lo.Map(snap.Filters, func(f azappconfig.SettingFilter, _ int) SnapshotFilter {
+			ns := lo.FromPtr(f.LabelFilter)
+			if ns == aznamespace.NullLabelFilter {
+				ns = ""
+			}
+
+			return SnapshotFilter{Key: lo.FromPtr(f.KeyFilter), Namespace: ns}
+		}),
+	}
+}
+
+// matchKeyFilter reports whether key matches an App Configuration key filter:
+// "*" (or empty) matches everything, a trailing "*" matches a prefix, "," ORs
+// alternatives, and "\" escapes a literal "*", "," or "\".
+func matchKeyFilter(filter, key string) bool {
+	if filter == "" {
+		return true
+	}
+
+	return lo.SomeBy(splitKeyFilter(filter), func(term keyFilterTerm) bool {
+		if term.prefix {
+			return strings.HasPrefix(key, term.text)
+		}
+
+		return key == term.text
+	})
+}
+
+// keyFilterTerm is one unescaped alternative of a key filter; prefix marks a
+// trailing unescaped "*".
+type keyFilterTerm struct {
+	text   string
+	prefix bool
+}
+
+// splitKeyFilter splits a key filter on unescaped "," into unescaped terms.
+func splitKeyFilter(filter string) []keyFilterTerm {
+	var (
+		terms   []keyFilterTerm
+		current keyFilterTerm
+		b       strings.Builder
+		escaped bool
+	)
+
+	flush := func() {
+		current.text = b.String()
+		terms = append(terms, current)
+		current = keyFilterTerm{}
+
+		b.Reset()
+	}
+
+	for _, r := range filter {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			flush()
+		case r == '*':
+			current.prefix = true
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	if escaped {
+		b.WriteRune('\\')
+	}
+
+	flush()
+
+	return terms
+}
@@ -0,0 +1,34 @@
+package appconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchKeyFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		filter string
+		key    string
+		want   bool
+	}{
+		{filter: "", key: "anything", want: true},
+		{filter: "*", key: "anything", want: true},
+		{filter: "app/*", key: "app/db", want: true},
+		{filter: "app/*", key: "other", want: false},
+		{filter: "app", key: "app", want: true},
+		{filter: "app", key: "app/db", want: false},
+		{filter: "a,b", key: "b", want: true},
+		{filter: "a,b*", key: "bcd", want: true},
+		{filter: "a,b", key: "c", want: false},
+		{filter: `a\,b`, key: "a,b", want: true},
+		{filter: `a\*`, key: "a*", want: true},
+		{filter: `a\*`, key: "ab", want: false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchKeyFilter(tt.filter, tt.key), "filter=%q key=%q", tt.filter, tt.key)
+	}
+}
@@ -0,0 +1,212 @@
+package appconfig_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azappconfig/v2"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/azure/appconfig"
+)
+
+// snapshotMockClient extends mockClient with the optional SnapshotClient surface.
+type snapshotMockClient struct {
+	mockClient
+
+	createFunc   func(ctx context.Context, name string, filters []azappconfig.SettingFilter, retention *int64) (azappconfig.Snapshot, error)
+	getSnapFunc  func(ctx context.Context, name string) (azappconfig.Snapshot, error)
+	listSnapFunc func(ctx context.Context) ([]azappconfig.Snapshot, error)
+	settingsFunc func(ctx context.Context, name string) ([]azappconfig.Setting, error)
+}
+
+func (m *snapshotMockClient) CreateSnapshot(
+	ctx context.Context, name string, filters []azappconfig.SettingFilter, retention *int64,
+) (azappconfig.Snapshot, error) {
+	return m.createFunc(ctx, name, filters, retention)
+}
+
+func (m *snapshotMockClient) GetSnapshot(ctx context.Context, name string) (azappconfig.Snapshot, error) {
+	return m.getSnapFunc(ctx, name)
+}
+
+func (m *snapshotMockClient) ListSnapshots(ctx context.Context) ([]azappconfig.Snapshot, error) {
+	return m.listSnapFunc(ctx)
+}
+
+func (m *snapshotMockClient) ListSnapshotSettings(ctx context.Context, name string) ([]azappconfig.Setting, error) {
+	return m.settingsFunc(ctx, name)
+}
+
+func setting(key, label, value string) azappconfig.Setting {
+	return azappconfig.Setting{Key: lo.ToPtr(key), Label: lo.ToPtr(label), Value: lo.ToPtr(value)}
+}
+
+func TestSnapshots_UnsupportedClient(t *testing.T) {
+	t.Parallel()
+
+	store := appconfig.New(&mockClient{}, "")
+
+	_, err := store.ListSnapshots(t.Context())
+	require.ErrorIs(t, err, appconfig.ErrSnapshotsUnsupported)
+
+	_, err = store.CreateSnapshot(t.Context(), "snap", "", 0)
+	require.ErrorIs(t, err, appconfig.ErrSnapshotsUnsupported)
+}
+
+func TestCreateSnapshot_FiltersAndRetention(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults to all keys under the null namespace", func(t *testing.T) {
+		t.Parallel()
+
+		client := &snapshotMockClient{
+			createFunc: func(_ context.Context, name string, filters []azappconfig.SettingFilter, retention *int64) (azappconfig.Snapshot, error) {
+				assert.Equal(t, "release-1", name)
+				require.Len(t, filters, 1)
+				assert.Equal(t, "*", *filters[0].KeyFilter)
+				assert.Equal(t, "\x00", *filters[0].LabelFilter)
+				assert.Nil(t, retention)
+
+				return azappconfig.Snapshot{
+					Name:       lo.ToPtr(name),
+					Status:     lo.ToPtr(azappconfig.SnapshotStatusReady),
+					ItemsCount: lo.ToPtr(int64(3)),
+					Filters:    filters,
+				}, nil
+			},
+		}
+
+		snap, err := appconfig.New(client, "").CreateSnapshot(t.Context(), "release-1", "", 0)
+		require.NoError(t, err)
+		assert.Equal(t, "release-1", snap.Name)
+		assert.Equal(t, "ready", snap.Status)
+		assert.Equal(t, int64(3), snap.ItemsCount)
+		// The null-label filter decodes back to the empty namespace.
+		assert.Equal(t, []appconfig.SnapshotFilter{{Key: "*", Namespace: ""}}, snap.Filters)
+	})
+
+	t.Run("key filter, namespace filter and retention are forwarded", func(t *testing.T) {
+		t.Parallel()
+
+		client := &snapshotMockClient{
+			createFunc: func(_ context.Context, _ string, filters []azappconfig.SettingFilter, retention *int64) (azappconfig.Snapshot, error) {
+				assert.Equal(t, "app/*", *filters[0].KeyFilter)
+				assert.Equal(t, "prod", *filters[0].LabelFilter)
+				require.NotNil(t, retention)
+				assert.Equal(t, int64(3600), *retention)
+
+				return azappconfig.Snapshot{}, nil
+			},
+		}
+
+		_, err := appconfig.New(client, "prod").CreateSnapshot(t.Context(), "s", "app/*", time.Hour)
+		require.NoError(t, err)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		t.Parallel()
+
+		client := &snapshotMockClient{
+			createFunc: func(context.Context, string, []azappconfig.SettingFilter, *int64) (azappconfig.Snapshot, error) {
+				return azappconfig.Snapshot{}, conflict()
+			},
+		}
+
+		_, err := appconfig.New(client, "").CreateSnapshot(t.Context(), "s", "", 0)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+	})
+}
+
+func TestSnapshot_NotFound(t *testing.T) {
+	t.Parallel()
+
+	client := &snapshotMockClient{
+		getSnapFunc: func(context.Context, string) (azappconfig.Snapshot, error) {
+			return azappconfig.Snapshot{}, notFound()
+		},
+	}
+
+	_, err := appconfig.New(client, "").Snapshot(t.Context(), "missing")
+	require.ErrorIs(t, err, provider.ErrNotFound)
+}
+
+func TestListSnapshots_SortedByName(t *testing.T) {
+	t.Parallel()
+
+	client := &snapshotMockClient{
+		listSnapFunc: func(context.Context) ([]azappconfig.Snapshot, error) {
+			return []azappconfig.Snapshot{{Name: lo.ToPtr("b")}, {Name: lo.ToPtr("a")}}, nil
+		},
+	}
+
+	snaps, err := appconfig.New(client, "dev").ListSnapshots(t.Context())
+	require.NoError(t, err)
+	require.Len(t, snaps, 2)
+	assert.Equal(t, "a", snaps[0].Name)
+	assert.Equal(t, "b", snaps[1].Name)
+}
+
+func TestSnapshotSettings_Sorted(t *testing.T) {
+	t.Parallel()
+
+	client := &snapshotMockClient{
+		settingsFunc: func(_ context.Context, name string) ([]azappconfig.Setting, error) {
+			assert.Equal(t, "s", name)
+
+			ref := setting("a", "", "3")
+			ref.ContentType = lo.ToPtr(appconfig.KeyVaultRefContentType)
+
+			return []azappconfig.Setting{setting("b", "", "1"), setting("a", "dev", "2"), ref}, nil
+		},
+	}
+
+	rows, err := appconfig.New(client, "").SnapshotSettings(t.Context(), "s")
+	require.NoError(t, err)
+	assert.Equal(t, []appconfig.KeyNamespace{
+		{Key: "a", Namespace: "", Value: "3", Type: domain.ValueTypeReference, ContentType: appconfig.KeyVaultRefContentType},
+		{Key: "a", Namespace: "dev", Value: "2", Type: domain.ValueTypePlaintext},
+		{Key: "b", Namespace: "", Value: "1", Type: domain.ValueTypePlaintext},
+	}, rows)
+}
+
+func TestLiveSettings_RestrictedToSnapshotFilters(t *testing.T) {
+	t.Parallel()
+
+	client := &snapshotMockClient{
+		mockClient: mockClient{
+			listFunc: func(_ context.Context, filter string) ([]azappconfig.Setting, error) {
+				switch filter {
+				case "\x00":
+					return []azappconfig.Setting{setting("app/a", "", "1"), setting("other", "", "2")}, nil
+				case "dev":
+					return []azappconfig.Setting{setting("app/a", "dev", "3")}, nil
+				default:
+					t.Fatalf("unexpected filter %q", filter)
+
+					return nil, nil
+				}
+			},
+		},
+	}
+
+	snap := &appconfig.Snapshot{Filters: []appconfig.SnapshotFilter{
+		{Key: "app/*", Namespace: ""},
+		{Key: "app/*", Namespace: "dev"},
+		// A repeated filter must not duplicate rows.
+		{Key: "app/a", Namespace: ""},
+	}}
+
+	rows, err := appconfig.New(client, "").LiveSettings(t.Context(), snap)
+	require.NoError(t, err)
+	assert.Equal(t, []appconfig.KeyNamespace{
+		{Key: "app/a", Namespace: "", Value: "1", Type: domain.ValueTypePlaintext},
+		{Key: "app/a", Namespace: "dev", Value: "3", Type: domain.ValueTypePlaintext},
+	}, rows)
+}
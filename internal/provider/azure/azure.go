@@ -6,7 +6,8 @@
 //
 // Both clients authenticate via azidentity.NewDefaultAzureCredential (the
 // DefaultAzureCredential chain: environment, workload identity, managed
-// identity, Azure CLI, ...). The concrete Azure SDK clients are built here and
+// identity, Azure CLI, ...), behind the credential cache when it is enabled
+// (see internal/credcache). The concrete Azure SDK clients are built here and
 // handed to the keyvault / appconfig subpackages, which confine every Azure SDK
 // type behind the provider seam.
 package azure
@@ -155,8 +156,9 @@ func keyVaultStore(ctx context.Context, scope provider.Scope) (provider.Store, e
 		}
 		opts := &azsecrets.ClientOptions{
 			ClientOptions: azcore.ClientOptions{
-				Transport: httpClient,
-				Logging:   debugLogOptions(ctx),
+				Transport:       httpClient,
+				Logging:         debugLogOptions(ctx),
+				PerCallPolicies: perCallPolicies(),
 			},
 			DisableChallengeResourceVerification: true,
 		}
@@ -169,7 +171,7 @@ func keyVaultStore(ctx context.Context, scope provider.Scope) (provider.Store, e
 		return keyvault.New(keyvault.Wrap(client)), nil
 	}
 
-	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	cred, err := newCredential(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain Azure credentials: %w", err)
 	}
@@ -177,7 +179,10 @@ func keyVaultStore(ctx context.Context, scope provider.Scope) (provider.Store, e
 	vaultURL := fmt.Sprintf("https://%s.vault.azure.net", scope.VaultName)
 
 	opts := &azsecrets.ClientOptions{
-		ClientOptions: azcore.ClientOptions{Logging: debugLogOptions(ctx)},
+		ClientOptions: azcore.ClientOptions{
+			Logging:         debugLogOptions(ctx),
+			PerCallPolicies: perCallPolicies(),
+		},
 	}
 
 	client, err := azsecrets.NewClient(vaultURL, cred, opts)
@@ -201,6 +206,7 @@ func appConfigStore(ctx context.Context, scope provider.Scope) (provider.Store,
 			ClientOptions: azcore.ClientOptions{
 				InsecureAllowCredentialWithHTTP: true,
 				Logging:                         debugLogOptions(ctx),
+				PerCallPolicies:                 perCallPolicies(),
 			},
 		}
 
@@ -212,7 +218,7 @@ func appConfigStore(ctx context.Context, scope provider.Scope) (provider.Store,
 		return appconfig.New(appconfig.Wrap(client), scope.AppConfigNamespace), nil
 	}
 
-	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	cred, err := newCredential(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain Azure credentials: %w", err)
 	}
@@ -220,7 +226,10 @@ func appConfigStore(ctx context.Context, scope provider.Scope) (provider.Store,
 	endpoint := fmt.Sprintf("https://%s.azconfig.io", scope.StoreName)
 
 	opts := &azappconfig.ClientOptions{
-		ClientOptions: azcore.ClientOptions{Logging: debugLogOptions(ctx)},
+		ClientOptions: azcore.ClientOptions{
+			Logging:         debugLogOptions(ctx),
+			PerCallPolicies: perCallPolicies(),
+		},
 	}
 
 	client, err := azappconfig.NewClient(endpoint, cred, opts)
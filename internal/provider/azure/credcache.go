@@ -0,0 +1,106 @@
+package azure
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	"github.com/mpyw/suve/internal/credcache"
+	"github.com/mpyw/suve/internal/debug"
+)
+
+// credentialCachePrefix namespaces Azure entries in the credential cache.
+const credentialCachePrefix = "azure\x00"
+
+// cachedCredential serves access tokens from the on-disk credential cache and
+// falls back to next on a miss, caching what next returns. A request carrying
+// claims (a continuous access evaluation challenge that rejected the current
+// token) always goes to next.
+type cachedCredential struct {
+	cache    *credcache.Cache
+	identity string
+	next     azcore.TokenCredential
+}
+
+// GetToken implements azcore.TokenCredential.
+func (c cachedCredential) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	if opts.Claims != "" {
+		return c.next.GetToken(ctx, opts)
+	}
+
+	name := strings.Join([]string{
+		c.identity, opts.TenantID, strings.Join(opts.Scopes, " "), strconv.FormatBool(opts.EnableCAE),
+	}, "\x00")
+
+	var tok azcore.AccessToken
+	if c.cache.Load(name, &tok) {
+		return tok, nil
+	}
+
+	tok, err := c.next.GetToken(ctx, opts)
+	if err != nil || tok.ExpiresOn.IsZero() {
+		return tok, err
+	}
+
+	if err := c.cache.Save(name, tok, tok.ExpiresOn); err != nil {
+		debug.From(ctx).Logf("azure credential cache: %v\n", err)
+	}
+
+	return tok, nil
+}
+
+// newCredential returns the DefaultAzureCredential, behind the credential
+// cache when it is enabled (SUVE_CREDENTIAL_CACHE).
+func newCredential(ctx context.Context) (azcore.TokenCredential, error) {
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := credcache.Open()
+	if err != nil {
+		debug.From(ctx).Logf("azure credential cache: disabled: %v\n", err)
+
+		return cred, nil
+	}
+
+	if cache == nil {
+		return cred, nil
+	}
+
+	return cachedCredential{cache: cache, identity: credentialIdentity(), next: cred}, nil
+}
+
+// credentialIdentity identifies whom DefaultAzureCredential signs in as, as far
+// as it can be told without a token: the environment credential's variables
+// and the Azure CLI's current account (azureProfile.json, which `az login` and
+// `az account set` rewrite), so a switch of either is a different cache entry.
+func credentialIdentity() string {
+	parts := []string{credentialCachePrefix}
+
+	for _, key := range []string{"AZURE_TENANT_ID", "AZURE_CLIENT_ID", "AZURE_USERNAME", "AZURE_FEDERATED_TOKEN_FILE"} {
+		parts = append(parts, os.Getenv(key))
+	}
+
+	dir := os.Getenv("AZURE_CONFIG_DIR")
+	if dir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".azure")
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "azureProfile.json")); err == nil { //nolint:gosec // the Azure CLI's own profile file
+		sum := sha256.Sum256(data)
+		parts = append(parts, hex.EncodeToString(sum[:]))
+	}
+
+	return strings.Join(parts, "\x00")
+}
@@ -0,0 +1,67 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/credcache"
+	"github.com/mpyw/suve/internal/keyprovider"
+)
+
+// countingCredential returns tok and counts the calls.
+type countingCredential struct {
+	calls *int
+	tok   azcore.AccessToken
+}
+
+func (c countingCredential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	*c.calls++
+
+	return c.tok, nil
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestCachedCredential(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(credcache.EnvCredentialCache, "1")
+	t.Setenv(keyprovider.EnvStagingKey, base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+
+	cache, err := credcache.Open()
+	require.NoError(t, err)
+
+	calls := 0
+	cred := cachedCredential{
+		cache:    cache,
+		identity: credentialIdentity(),
+		next:     countingCredential{calls: &calls, tok: azcore.AccessToken{Token: "eyJ", ExpiresOn: time.Now().Add(time.Hour)}},
+	}
+	vault := policy.TokenRequestOptions{Scopes: []string{"https://vault.azure.net/.default"}}
+
+	for range 2 {
+		tok, err := cred.GetToken(context.Background(), vault)
+		require.NoError(t, err)
+		assert.Equal(t, "eyJ", tok.Token)
+	}
+
+	assert.Equal(t, 1, calls, "the second request is served from the cache")
+
+	_, err = cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{"https://azconfig.io/.default"}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "another scope is another entry")
+
+	vault.Claims = `{"access_token":{}}`
+	_, err = cred.GetToken(context.Background(), vault)
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls, "a claims challenge bypasses the cache")
+
+	t.Setenv("AZURE_CLIENT_ID", "other")
+	assert.NotEqual(t, cred.identity, credentialIdentity(), "another environment identity is another entry")
+}
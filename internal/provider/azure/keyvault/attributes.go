@@ -0,0 +1,102 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// ContentTypeField is the label of the display-only Extra field Get adds for a
+// secret version carrying a content type.
+const ContentTypeField = "Content Type"
+
+// SecretExpiry is one listed secret's current-version expiry.
+type SecretExpiry struct {
+	Name    string
+	Expires *time.Time
+	Enabled bool
+}
+
+// Compile-time assertion that Store implements the optional attribute update.
+var _ provider.AttributeUpdater = (*Store)(nil)
+
+// UpdateAttributes applies the set attributes to the secret's current version
+// via UpdateSecretProperties, without adding a version. Tags are left as-is.
+func (s *Store) UpdateAttributes(ctx context.Context, name string, attrs provider.SecretAttributes) error {
+	if attrs.IsZero() {
+		return nil
+	}
+
+	// Like tag writes, PATCH needs a concrete version id (see currentTags).
+	_, version, err := s.currentTags(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.UpdateSecretProperties(ctx, name, version, azsecrets.UpdateSecretPropertiesParameters{
+		ContentType:      attrs.ContentType,
+		SecretAttributes: toSDKAttributes(attrs),
+	})
+	if err != nil {
+		return mapError(err, name, "update secret attributes")
+	}
+
+	return nil
+}
+
+// ListExpiries returns every secret with its current version's expiry, in one
+// list call (the attributes come with the listing; no per-secret reads).
+func (s *Store) ListExpiries(ctx context.Context) ([]SecretExpiry, error) {
+	props, err := s.client.ListSecretProperties(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	return lo.Map(props, func(p *azsecrets.SecretProperties, _ int) SecretExpiry {
+		e := SecretExpiry{Name: secretName(p.ID)}
+
+		if attr := p.Attributes; attr != nil {
+			e.Expires = attr.Expires
+			e.Enabled = lo.FromPtr(attr.Enabled)
+		}
+
+		return e
+	}), nil
+}
+
+// secretAttributes folds the recognized WriteOptions into one attribute set.
+// Unknown options are ignored, per the provider.WriteOption pass-through
+// contract; a later option's set fields override an earlier one's.
+func secretAttributes(opts []provider.WriteOption) provider.SecretAttributes {
+	var attrs provider.SecretAttributes
+
+	for _, opt := range opts {
+		if o, ok := opt.(provider.SecretAttributes); ok {
+			attrs.ContentType = lo.CoalesceOrEmpty(o.ContentType, attrs.ContentType)
+			attrs.NotBefore = lo.CoalesceOrEmpty(o.NotBefore, attrs.NotBefore)
+			attrs.Expires = lo.CoalesceOrEmpty(o.Expires, attrs.Expires)
+			attrs.Enabled = lo.CoalesceOrEmpty(o.Enabled, attrs.Enabled)
+		}
+	}
+
+	return attrs
+}
+
+// toSDKAttributes maps the neutral attributes onto the SDK's; nil when none of
+// the SDK attribute fields is set, so the request leaves them untouched.
+func toSDKAttributes(attrs provider.SecretAttributes) *azsecrets.SecretAttributes {
+	if attrs.NotBefore == nil && attrs.Expires == nil && attrs.Enabled == nil {
+		return nil
+	}
+
+	return &azsecrets.SecretAttributes{
+		NotBefore: attrs.NotBefore,
+		Expires:   attrs.Expires,
+		Enabled:   attrs.Enabled,
+	}
+}
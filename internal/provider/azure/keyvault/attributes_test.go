@@ -0,0 +1,181 @@
+package keyvault_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/azure/keyvault"
+)
+
+func TestPut_SecretAttributes(t *testing.T) {
+	t.Parallel()
+
+	nbf := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	exp := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var got azsecrets.SetSecretParameters
+
+	m := &mockClient{
+		setFunc: func(_ context.Context, _ string, params azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+			got = params
+
+			return azsecrets.SetSecretResponse{Secret: azsecrets.Secret{ID: secretID("s", "v2")}}, nil
+		},
+	}
+
+	_, err := keyvault.New(m).Put(t.Context(), "s", "value", domain.ValueTypeSecret, "",
+		provider.SecretAttributes{ContentType: lo.ToPtr("text/plain"), NotBefore: &nbf},
+		provider.SecretAttributes{Expires: &exp, Enabled: lo.ToPtr(false)},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "text/plain", lo.FromPtr(got.ContentType))
+	require.NotNil(t, got.SecretAttributes)
+	assert.Equal(t, &nbf, got.SecretAttributes.NotBefore)
+	assert.Equal(t, &exp, got.SecretAttributes.Expires)
+	assert.Equal(t, lo.ToPtr(false), got.SecretAttributes.Enabled)
+}
+
+func TestPut_NoAttributes(t *testing.T) {
+	t.Parallel()
+
+	var got azsecrets.SetSecretParameters
+
+	m := &mockClient{
+		setFunc: func(_ context.Context, _ string, params azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+			got = params
+
+			return azsecrets.SetSecretResponse{Secret: azsecrets.Secret{ID: secretID("s", "v2")}}, nil
+		},
+	}
+
+	_, err := keyvault.New(m).Put(t.Context(), "s", "value", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	assert.Nil(t, got.ContentType)
+	assert.Nil(t, got.SecretAttributes)
+}
+
+func TestUpdateAttributes(t *testing.T) {
+	t.Parallel()
+
+	exp := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var (
+		gotVersion string
+		got        updParams
+	)
+
+	m := &mockClient{
+		getFunc: func(_ context.Context, name, _ string) (azsecrets.GetSecretResponse, error) {
+			return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{ID: secretID(name, "cur")}}, nil
+		},
+		updateFunc: func(_ context.Context, _, version string, params updParams) (updResp, error) {
+			gotVersion, got = version, params
+
+			return updResp{}, nil
+		},
+	}
+
+	err := keyvault.New(m).UpdateAttributes(t.Context(), "s", provider.SecretAttributes{Expires: &exp})
+	require.NoError(t, err)
+
+	assert.Equal(t, "cur", gotVersion)
+	assert.Nil(t, got.ContentType)
+	assert.Nil(t, got.Tags)
+	require.NotNil(t, got.SecretAttributes)
+	assert.Equal(t, &exp, got.SecretAttributes.Expires)
+	assert.Nil(t, got.SecretAttributes.Enabled)
+}
+
+func TestUpdateAttributes_NotFound(t *testing.T) {
+	t.Parallel()
+
+	m := &mockClient{
+		getFunc: func(_ context.Context, _, _ string) (azsecrets.GetSecretResponse, error) {
+			return azsecrets.GetSecretResponse{}, notFound()
+		},
+	}
+
+	err := keyvault.New(m).UpdateAttributes(t.Context(), "s", provider.SecretAttributes{Enabled: lo.ToPtr(true)})
+	require.ErrorIs(t, err, provider.ErrNotFound)
+}
+
+func TestGet_Attributes(t *testing.T) {
+	t.Parallel()
+
+	nbf := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	exp := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	m := &mockClient{
+		getFunc: func(_ context.Context, name, _ string) (azsecrets.GetSecretResponse, error) {
+			return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{
+				ID:          secretID(name, "v1"),
+				Value:       lo.ToPtr("x"),
+				ContentType: lo.ToPtr("application/json"),
+				Attributes: &azsecrets.SecretAttributes{
+					Enabled: lo.ToPtr(true), NotBefore: &nbf, Expires: &exp,
+				},
+			}}, nil
+		},
+	}
+
+	entry, err := keyvault.New(m).Get(t.Context(), "s", provider.VersionRef{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "enabled", entry.Version.State)
+	assert.Equal(t, &nbf, entry.Version.NotBefore)
+	assert.Equal(t, &exp, entry.Version.Expires)
+	assert.Contains(t, entry.Extra, domain.Field{Label: keyvault.ContentTypeField, Value: "application/json"})
+}
+
+func TestHistory_Expires(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	exp := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	m := &mockClient{
+		listVersFunc: func(_ context.Context, name string) ([]*azsecrets.SecretProperties, error) {
+			return []*azsecrets.SecretProperties{
+				{ID: secretID(name, "v1"), Attributes: &azsecrets.SecretAttributes{Created: &created, Expires: &exp}},
+			}, nil
+		},
+	}
+
+	versions, err := keyvault.New(m).History(t.Context(), "s")
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, &exp, versions[0].Expires)
+	assert.Nil(t, versions[0].NotBefore)
+}
+
+func TestListExpiries(t *testing.T) {
+	t.Parallel()
+
+	exp := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	m := &mockClient{
+		listFunc: func(_ context.Context) ([]*azsecrets.SecretProperties, error) {
+			return []*azsecrets.SecretProperties{
+				{ID: secretID("a", ""), Attributes: &azsecrets.SecretAttributes{Expires: &exp, Enabled: lo.ToPtr(true)}},
+				{ID: secretID("b", "")},
+			}, nil
+		},
+	}
+
+	got, err := keyvault.New(m).ListExpiries(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []keyvault.SecretExpiry{
+		{Name: "a", Expires: &exp, Enabled: true},
+		{Name: "b"},
+	}, got)
+}
@@ -16,6 +16,11 @@
 //     check-then-set is inherently racy; a concurrent create is not detected.
 //   - Tags live on a secret version and are mutated via an UpdateSecretProperties
 //     read-modify-write against the current version.
+//   - Content type, activation window (nbf / exp) and the enabled flag are
+//     per-version attributes: they are written with a new version via the
+//     provider.SecretAttributes WriteOption, or in place via UpdateAttributes.
+//   - A provider.ChangeMessage is stored as the new version's "suve-message"
+//     tag; it is surfaced as Version.Message and hidden from the version's Tags.
 package keyvault
 
 import (
@@ -77,6 +82,10 @@ func New(client Client) *Store {
 	return &Store{client: client}
 }
 
+// messageTag is the version tag under which suve stores the change message a
+// version was written with (see provider.ChangeMessage).
+const messageTag = "suve-message"
+
 // secretVersion is a provider-neutral snapshot of one Key Vault secret version,
 // extracted from the SDK's SecretProperties so ordering and shifting logic never
 // touch Azure types directly.
@@ -85,6 +94,10 @@ type secretVersion struct {
 	created *time.Time
 	enabled bool
 	tags    []domain.Tag
+	message string
+
+	notBefore *time.Time
+	expires   *time.Time
 }
 
 // Resolve parses the version spec (generic) and resolves it to an opaque
@@ -158,7 +171,9 @@ func (s *Store) versionsNewestFirst(ctx context.Context, name string) ([]secretV
 
 // Get retrieves the secret value at the given ref (current when ref is latest)
 // and maps it to a domain.Entry. Type is always secret; the opaque version id
-// and creation time populate Version; the secret's tags become Tags.
+// and creation time populate Version; the secret's tags become Tags. The
+// activation window populates Version.NotBefore/Expires and a content type is
+// appended as a display-only Extra field (ContentTypeField).
 func (s *Store) Get(ctx context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
 	resp, err := s.client.GetSecret(ctx, name, ref.ID())
 	if err != nil {
@@ -170,7 +185,8 @@ func (s *Store) Get(ctx context.Context, name string, ref provider.VersionRef) (
 		Value: lo.FromPtr(resp.Value),
 		Type:  domain.ValueTypeSecret,
 		Version: domain.Version{
-			ID: versionID(resp.ID),
+			ID:      versionID(resp.ID),
+			Message: lo.FromPtr(resp.Tags[messageTag]),
 		},
 		Tags: mapTags(resp.Tags),
 	}
@@ -178,14 +194,22 @@ func (s *Store) Get(ctx context.Context, name string, ref provider.VersionRef) (
 	if attr := resp.Attributes; attr != nil {
 		entry.Version.Created = attr.Created
 		entry.Version.State = enabledLabel(attr.Enabled)
+		entry.Version.NotBefore = attr.NotBefore
+		entry.Version.Expires = attr.Expires
 		entry.Modified = attr.Updated
 	}
 
+	if contentType := lo.FromPtr(resp.ContentType); contentType != "" {
+		entry.Extra = append(entry.Extra, domain.Field{Label: ContentTypeField, Value: contentType})
+	}
+
 	return entry, nil
 }
 
 // History returns the secret's version history, newest first. The per-version
-// enabled/disabled state is surfaced in the neutral Version.State for display.
+// enabled/disabled state is surfaced in the neutral Version.State for display,
+// each version's activation window in Version.NotBefore/Expires, and its change
+// message in Version.Message.
 func (s *Store) History(ctx context.Context, name string) ([]domain.Version, error) {
 	versions, err := s.versionsNewestFirst(ctx, name)
 	if err != nil {
@@ -198,6 +222,10 @@ func (s *Store) History(ctx context.Context, name string) ([]domain.Version, err
 			State:   boolLabel(v.enabled),
 			Created: v.created,
 			Tags:    v.tags,
+			Message: v.message,
+
+			NotBefore: v.notBefore,
+			Expires:   v.expires,
 		}
 	}), nil
 }
@@ -222,9 +250,11 @@ func (s *Store) List(ctx context.Context) ([]string, error) {
 // Key Vault has no create-only API, so this probes with GetSecret first and
 // returns a wrapped provider.ErrAlreadyExists if the secret already exists (see
 // the package doc for the inherent race). The valueType and description are
-// ignored (Key Vault values are always secret and carry no description field).
+// ignored (Key Vault values are always secret and carry no description field);
+// a provider.SecretAttributes option sets the first version's attributes and a
+// provider.ChangeMessage its message tag.
 func (s *Store) Create(
-	ctx context.Context, name, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+	ctx context.Context, name, value string, _ domain.ValueType, _ string, opts ...provider.WriteOption,
 ) (domain.Version, error) {
 	_, err := s.client.GetSecret(ctx, name, "")
 
@@ -235,21 +265,36 @@ func (s *Store) Create(
 		return domain.Version{}, fmt.Errorf("failed to check secret existence: %w", err)
 	}
 
-	return s.setSecret(ctx, name, value)
+	return s.setSecret(ctx, name, value, opts)
 }
 
 // Put adds a new version to the secret (upsert) and returns the resulting
-// version. The valueType and description are ignored.
+// version. The valueType and description are ignored; a
+// provider.SecretAttributes option sets the new version's attributes (they are
+// not carried over from the previous version) and a provider.ChangeMessage its
+// message tag.
 func (s *Store) Put(
-	ctx context.Context, name, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+	ctx context.Context, name, value string, _ domain.ValueType, _ string, opts ...provider.WriteOption,
 ) (domain.Version, error) {
-	return s.setSecret(ctx, name, value)
+	return s.setSecret(ctx, name, value, opts)
 }
 
-// setSecret sets a new secret value (a new version) and returns the resulting
-// domain.Version.
-func (s *Store) setSecret(ctx context.Context, name, value string) (domain.Version, error) {
-	resp, err := s.client.SetSecret(ctx, name, azsecrets.SetSecretParameters{Value: lo.ToPtr(value)})
+// setSecret sets a new secret value (a new version) with the attributes and
+// change message from opts and returns the resulting domain.Version.
+func (s *Store) setSecret(ctx context.Context, name, value string, opts []provider.WriteOption) (domain.Version, error) {
+	attrs := secretAttributes(opts)
+
+	var tags map[string]*string
+	if message := provider.ChangeMessageOf(opts); message != "" {
+		tags = map[string]*string{messageTag: lo.ToPtr(message)}
+	}
+
+	resp, err := s.client.SetSecret(ctx, name, azsecrets.SetSecretParameters{
+		Value:            lo.ToPtr(value),
+		ContentType:      attrs.ContentType,
+		SecretAttributes: toSDKAttributes(attrs),
+		Tags:             tags,
+	})
 	if err != nil {
 		return domain.Version{}, fmt.Errorf("failed to set secret: %w", err)
 	}
@@ -354,11 +399,13 @@ func (s *Store) updateTags(ctx context.Context, name, version string, tags map[s
 
 // toSecretVersion maps SDK SecretProperties to the neutral secretVersion.
 func toSecretVersion(p *azsecrets.SecretProperties) secretVersion {
-	v := secretVersion{id: versionID(p.ID), tags: mapTags(p.Tags)}
+	v := secretVersion{id: versionID(p.ID), tags: mapTags(p.Tags), message: lo.FromPtr(p.Tags[messageTag])}
 
 	if attr := p.Attributes; attr != nil {
 		v.created = attr.Created
 		v.enabled = lo.FromPtr(attr.Enabled)
+		v.notBefore = attr.NotBefore
+		v.expires = attr.Expires
 	}
 
 	return v
@@ -429,13 +476,12 @@ func boolLabel(enabled bool) string {
 }
 
 // mapTags converts an Azure tags map to a sorted slice of neutral domain tags
-// (sorted by key for deterministic display).
+// (sorted by key for deterministic display). The message tag is left out: it is
+// surfaced as Version.Message instead.
 func mapTags(tags map[string]*string) []domain.Tag {
-	if len(tags) == 0 {
-		return nil
-	}
+	keys := it.Filter(maputil.SortedKeys(tags), func(k string) bool { return k != messageTag })
 
-	return slices.Collect(it.Map(maputil.SortedKeys(tags), func(k string) domain.Tag {
+	return slices.Collect(it.Map(keys, func(k string) domain.Tag {
 		return domain.Tag{Key: k, Value: lo.FromPtr(tags[k])}
 	}))
 }
@@ -283,6 +283,49 @@ func TestPut(t *testing.T) {
 	assert.Equal(t, "v2", version.ID)
 }
 
+func TestChangeMessage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("put writes the message as a version tag", func(t *testing.T) {
+		t.Parallel()
+
+		var tags map[string]*string
+
+		m := &mockClient{
+			setFunc: func(_ context.Context, name string, params azsecrets.SetSecretParameters) (azsecrets.SetSecretResponse, error) {
+				tags = params.Tags
+
+				return azsecrets.SetSecretResponse{Secret: azsecrets.Secret{ID: secretID(name, "v2")}}, nil
+			},
+		}
+		store := keyvault.New(m)
+
+		_, err := store.Put(t.Context(), "my-secret", "v", domain.ValueTypeSecret, "", provider.ChangeMessage{Message: "rotate key"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*string{"suve-message": lo.ToPtr("rotate key")}, tags)
+	})
+
+	t.Run("history surfaces the message and hides its tag", func(t *testing.T) {
+		t.Parallel()
+
+		m := &mockClient{
+			listVersFunc: func(_ context.Context, _ string) ([]*azsecrets.SecretProperties, error) {
+				return []*azsecrets.SecretProperties{{
+					ID:   secretID("my-secret", "v2"),
+					Tags: map[string]*string{"suve-message": lo.ToPtr("rotate key"), "env": lo.ToPtr("prod")},
+				}}, nil
+			},
+		}
+		store := keyvault.New(m)
+
+		versions, err := store.History(t.Context(), "my-secret")
+		require.NoError(t, err)
+		require.Len(t, versions, 1)
+		assert.Equal(t, "rotate key", versions[0].Message)
+		assert.Equal(t, []domain.Tag{{Key: "env", Value: "prod"}}, versions[0].Tags)
+	})
+}
+
 func TestDelete(t *testing.T) {
 	t.Parallel()
 
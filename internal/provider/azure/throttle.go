@@ -0,0 +1,34 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// throttlePolicy turns a 429 response into an error wrapping both
+// provider.ErrThrottled and the SDK's *azcore.ResponseError, so stage apply
+// retries a rate-limited write and the stores' error mapping still reads the
+// status. As a per-call policy it runs outside the SDK's retry policy and so
+// only sees the 429 that remains once those retries are spent; the clients
+// return a pipeline error as is.
+type throttlePolicy struct{}
+
+// Do implements policy.Policy.
+func (throttlePolicy) Do(req *policy.Request) (*http.Response, error) {
+	resp, err := req.Next()
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return resp, fmt.Errorf("%w: %w", provider.ErrThrottled, runtime.NewResponseError(resp))
+	}
+
+	return resp, err
+}
+
+// perCallPolicies are the pipeline policies every Azure client is built with.
+func perCallPolicies() []policy.Policy {
+	return []policy.Policy{throttlePolicy{}}
+}
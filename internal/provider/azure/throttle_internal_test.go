@@ -0,0 +1,66 @@
+package azure
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// statusTransport answers every request with the given status.
+type statusTransport int
+
+func (s statusTransport) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: int(s),
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+func TestThrottlePolicy(t *testing.T) {
+	t.Parallel()
+
+	send := func(t *testing.T, status int) (*http.Response, error) {
+		t.Helper()
+
+		pl := runtime.NewPipeline("suve-test", "v0", runtime.PipelineOptions{}, &policy.ClientOptions{
+			Transport:       statusTransport(status),
+			Retry:           policy.RetryOptions{MaxRetries: -1},
+			PerCallPolicies: perCallPolicies(),
+		})
+
+		req, err := runtime.NewRequest(t.Context(), http.MethodGet, "https://example.vault.azure.net/secrets/db")
+		require.NoError(t, err)
+
+		return pl.Do(req)
+	}
+
+	t.Run("429 is marked and keeps the response error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := send(t, http.StatusTooManyRequests)
+		require.ErrorIs(t, err, provider.ErrThrottled)
+
+		var respErr *azcore.ResponseError
+		require.ErrorAs(t, err, &respErr)
+		assert.Equal(t, http.StatusTooManyRequests, respErr.StatusCode)
+	})
+
+	t.Run("other statuses pass through", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := send(t, http.StatusNotFound)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
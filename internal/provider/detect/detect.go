@@ -15,6 +15,15 @@
 //     flat aliases work in AWS CloudShell, where none of the classic vars are set.
 //     GoogleCloud — GOOGLE_CLOUD_PROJECT (secret only)
 //     Azure — AZURE_KEYVAULT_NAME (secret) / AZURE_APPCONFIG_NAME (param)
+//     Vault — VAULT_ADDR (secret only; the KV v2 engine)
+//     SOPS — SUVE_SOPS_FILE (secret only; the file whose leaf keys are entries)
+//     The offline local provider and Kubernetes are never detected; they must be
+//     selected. A kubeconfig ($KUBECONFIG or ~/.kube/config) sits beside most
+//     cloud setups, so counting it would make those users ambiguous.
+//   - SUVE_PROVIDER (or the root --provider flag, which the CLI overlays onto
+//     it) names one provider explicitly. It then is the sole active provider for
+//     every service it offers and the env signals above are ignored, so
+//     `SUVE_PROVIDER=local suve param ls` works beside any cloud setup.
 //   - A flat alias is exposed for a service only when exactly ONE provider is
 //     active for it. Zero or two-plus active means no alias — the user must use
 //     the explicit group (e.g. `suve aws secret`). There is no priority order.
@@ -29,12 +38,18 @@
 package detect
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/mpyw/suve/internal/provider"
 )
 
+// ProviderEnvVar names the provider the flat aliases target, bypassing
+// detection. It accepts the command-group names and aliases (see
+// ParseProvider).
+const ProviderEnvVar = "SUVE_PROVIDER"
+
 // Environment abstracts the inputs the resolver reads, so it can be tested
 // without mutating the real process environment or filesystem.
 type Environment struct {
@@ -64,21 +79,26 @@ type Result struct {
 	// Stage names the single active provider for the staging workflow, or an
 	// empty Provider ("") when staging is not uniquely resolvable (0 or 2+
 	// staging-capable providers active). Staging is supported for AWS (param +
-	// secret), Google Cloud (secret), and Azure (Key Vault secret / App
-	// Configuration param).
+	// secret), Google Cloud (secret), Azure (Key Vault secret / App
+	// Configuration param), Vault (KV v2 secret), Kubernetes (ConfigMap param +
+	// Secret secret), SOPS (file secret), and the local provider (param +
+	// secret) when selected.
 	Stage provider.Provider
 
 	// ParamActive and SecretActive list every provider active for that service,
-	// in stable order (AWS, GoogleCloud, Azure).
+	// in stable order (AWS, GoogleCloud, Azure, Vault, Kubernetes, SOPS).
 	ParamActive  []provider.Provider
 	SecretActive []provider.Provider
 	// StageActive lists every staging-capable provider active, in stable order
-	// (AWS, GoogleCloud, Azure).
+	// (AWS, GoogleCloud, Azure, Vault, Kubernetes, SOPS).
 	StageActive []provider.Provider
 
 	// AWSViaFallback is true when AWS became active only through the
 	// ~/.aws/credentials fallback (no provider was active via env).
 	AWSViaFallback bool
+	// Selected is true when the provider was named by SUVE_PROVIDER (or
+	// --provider) rather than detected.
+	Selected bool
 }
 
 // FlatParam reports whether a top-level `param` alias should be exposed.
@@ -97,6 +117,12 @@ func Resolve(env Environment) Result {
 		getenv = func(string) string { return "" }
 	}
 
+	// An unknown name is reported by the CLI's root flag validation; here it
+	// simply falls back to detection.
+	if p, err := ParseProvider(getenv(ProviderEnvVar)); err == nil && p != "" {
+		return selected(p)
+	}
+
 	awsEnv := getenv("AWS_ACCESS_KEY_ID") != "" ||
 		getenv("AWS_VAULT") != "" ||
 		getenv("AWS_PROFILE") != "" ||
@@ -108,8 +134,10 @@ func Resolve(env Environment) Result {
 	gcloudSecret := getenv("GOOGLE_CLOUD_PROJECT") != ""
 	azureSecret := getenv("AZURE_KEYVAULT_NAME") != ""
 	azureParam := getenv("AZURE_APPCONFIG_NAME") != ""
+	vaultSecret := getenv("VAULT_ADDR") != ""
+	sopsSecret := getenv("SUVE_SOPS_FILE") != ""
 
-	anyEnv := awsEnv || gcloudSecret || azureSecret || azureParam
+	anyEnv := awsEnv || gcloudSecret || azureSecret || azureParam || vaultSecret || sopsSecret
 
 	var res Result
 
@@ -119,7 +147,8 @@ func Resolve(env Environment) Result {
 		res.AWSViaFallback = true
 	}
 
-	// Secret candidates in stable order: AWS, GoogleCloud, Azure (Key Vault).
+	// Secret candidates in stable order: AWS, GoogleCloud, Azure (Key Vault),
+	// Vault, SOPS.
 	if awsActive {
 		res.SecretActive = append(res.SecretActive, provider.ProviderAWS)
 	}
@@ -132,8 +161,16 @@ func Resolve(env Environment) Result {
 		res.SecretActive = append(res.SecretActive, provider.ProviderAzure)
 	}
 
-	// Param candidates in stable order: AWS, Azure (App Configuration). GoogleCloud has
-	// no parameter store.
+	if vaultSecret {
+		res.SecretActive = append(res.SecretActive, provider.ProviderVault)
+	}
+
+	if sopsSecret {
+		res.SecretActive = append(res.SecretActive, provider.ProviderSOPS)
+	}
+
+	// Param candidates in stable order: AWS, Azure (App Configuration).
+	// GoogleCloud, Vault and SOPS have no parameter store.
 	if awsActive {
 		res.ParamActive = append(res.ParamActive, provider.ProviderAWS)
 	}
@@ -143,7 +180,8 @@ func Resolve(env Environment) Result {
 	}
 
 	// Staging-capable providers in stable order: AWS (param + secret), Google
-	// Cloud (secret), Azure (Key Vault secret and/or App Configuration param).
+	// Cloud (secret), Azure (Key Vault secret and/or App Configuration param),
+	// Vault (KV v2 secret), SOPS (file secret).
 	if awsActive {
 		res.StageActive = append(res.StageActive, provider.ProviderAWS)
 	}
@@ -156,6 +194,14 @@ func Resolve(env Environment) Result {
 		res.StageActive = append(res.StageActive, provider.ProviderAzure)
 	}
 
+	if vaultSecret {
+		res.StageActive = append(res.StageActive, provider.ProviderVault)
+	}
+
+	if sopsSecret {
+		res.StageActive = append(res.StageActive, provider.ProviderSOPS)
+	}
+
 	res.Secret = unique(res.SecretActive)
 	res.Param = unique(res.ParamActive)
 	res.Stage = unique(res.StageActive)
@@ -163,6 +209,56 @@ func Resolve(env Environment) Result {
 	return res
 }
 
+// selected is the Result for an explicitly named provider: the sole active
+// provider for each service it offers, and for staging (which all support).
+func selected(p provider.Provider) Result {
+	res := Result{Selected: true}
+
+	res.SecretActive = []provider.Provider{p}
+	res.StageActive = []provider.Provider{p}
+
+	switch p {
+	case provider.ProviderAWS, provider.ProviderAzure, provider.ProviderKubernetes, provider.ProviderLocal:
+		res.ParamActive = []provider.Provider{p}
+	case provider.ProviderGoogleCloud, provider.ProviderVault, provider.ProviderSOPS:
+		// No parameter store, so no param alias.
+	case provider.ProviderPlugin:
+		// Never selected by name: ParseProvider does not know plugins.
+	}
+
+	res.Secret = unique(res.SecretActive)
+	res.Param = unique(res.ParamActive)
+	res.Stage = unique(res.StageActive)
+
+	return res
+}
+
+// ParseProvider maps a command-group name or one of its aliases (as accepted
+// by SUVE_PROVIDER and --provider) to its provider. Empty yields "" and no
+// error; an unknown name is an error listing the valid ones.
+func ParseProvider(name string) (provider.Provider, error) {
+	switch name {
+	case "":
+		return "", nil
+	case "aws":
+		return provider.ProviderAWS, nil
+	case "gcloud", "googlecloud", "google", "gcp": // naming-allow-gcp
+		return provider.ProviderGoogleCloud, nil
+	case "azure", "az":
+		return provider.ProviderAzure, nil
+	case "vault":
+		return provider.ProviderVault, nil
+	case "kubernetes", "k8s", "kube":
+		return provider.ProviderKubernetes, nil
+	case "sops":
+		return provider.ProviderSOPS, nil
+	case "local":
+		return provider.ProviderLocal, nil
+	default:
+		return "", fmt.Errorf("unknown provider %q: use one of aws, gcloud, azure, vault, kubernetes, sops, local", name)
+	}
+}
+
 // unique returns the sole element of ps, or "" when ps has zero or 2+ elements.
 func unique(ps []provider.Provider) provider.Provider {
 	if len(ps) == 1 {
@@ -23,6 +23,8 @@ func TestResolve(t *testing.T) {
 	aws := provider.ProviderAWS
 	gcloud := provider.ProviderGoogleCloud
 	az := provider.ProviderAzure
+	hv := provider.ProviderVault
+	sp := provider.ProviderSOPS
 
 	tests := []struct {
 		name           string
@@ -170,6 +172,52 @@ func TestResolve(t *testing.T) {
 			wantParamSet:  []provider.Provider{aws, az},
 			wantSecretSet: []provider.Provider{aws},
 		},
+		{
+			name:          "Vault only -> secret=Vault, no param",
+			vars:          map[string]string{"VAULT_ADDR": "http://127.0.0.1:8200"},
+			credsExist:    true, // must be ignored: env is active
+			wantSecret:    hv,
+			wantSecretSet: []provider.Provider{hv},
+		},
+		{
+			name:          "AWS + Vault -> secret ambiguous, param=AWS",
+			vars:          map[string]string{"AWS_PROFILE": "dev", "VAULT_ADDR": "http://127.0.0.1:8200"},
+			wantParam:     aws,
+			wantParamSet:  []provider.Provider{aws},
+			wantSecretSet: []provider.Provider{aws, hv},
+		},
+		{
+			name:           "KUBECONFIG alone is not a signal -> AWS fallback",
+			vars:           map[string]string{"KUBECONFIG": "/tmp/kubeconfig"},
+			credsExist:     true,
+			wantParam:      aws,
+			wantSecret:     aws,
+			wantParamSet:   []provider.Provider{aws},
+			wantSecretSet:  []provider.Provider{aws},
+			wantAWSViaFall: true,
+		},
+		{
+			name:          "AWS + KUBECONFIG -> AWS only",
+			vars:          map[string]string{"AWS_PROFILE": "dev", "KUBECONFIG": "/tmp/kubeconfig"},
+			wantParam:     aws,
+			wantSecret:    aws,
+			wantParamSet:  []provider.Provider{aws},
+			wantSecretSet: []provider.Provider{aws},
+		},
+		{
+			name:          "SOPS only -> secret=SOPS, no param",
+			vars:          map[string]string{"SUVE_SOPS_FILE": "secrets.yaml"},
+			credsExist:    true, // must be ignored: env is active
+			wantSecret:    sp,
+			wantSecretSet: []provider.Provider{sp},
+		},
+		{
+			name:          "AWS + SOPS -> secret ambiguous, param=AWS",
+			vars:          map[string]string{"AWS_PROFILE": "dev", "SUVE_SOPS_FILE": "secrets.yaml"},
+			wantParam:     aws,
+			wantParamSet:  []provider.Provider{aws},
+			wantSecretSet: []provider.Provider{aws, sp},
+		},
 		{
 			name:       "GoogleCloud set with creds file present -> no AWS fallback (env is active)",
 			vars:       map[string]string{"GOOGLE_CLOUD_PROJECT": "p"},
@@ -194,8 +242,8 @@ func TestResolve(t *testing.T) {
 			assert.Equal(t, tt.wantSecret != "", got.FlatSecret(), "FlatSecret")
 
 			// Staging-capable providers, in stable order (AWS, Google Cloud,
-			// Azure): AWS/Google Cloud when active for secret; Azure when active
-			// for EITHER service. Stage is the unique active one, or "" for 0/2+.
+			// Azure, Vault, Kubernetes, SOPS): AWS/Google Cloud/Vault/Kubernetes/SOPS
+			// when active for secret; Azure when active for EITHER service. Stage is the unique active one, or "" for 0/2+.
 			var wantStageSet []provider.Provider
 
 			if slices.Contains(tt.wantSecretSet, provider.ProviderAWS) {
@@ -211,6 +259,18 @@ func TestResolve(t *testing.T) {
 				wantStageSet = append(wantStageSet, provider.ProviderAzure)
 			}
 
+			if slices.Contains(tt.wantSecretSet, provider.ProviderVault) {
+				wantStageSet = append(wantStageSet, provider.ProviderVault)
+			}
+
+			if slices.Contains(tt.wantSecretSet, provider.ProviderKubernetes) {
+				wantStageSet = append(wantStageSet, provider.ProviderKubernetes)
+			}
+
+			if slices.Contains(tt.wantSecretSet, provider.ProviderSOPS) {
+				wantStageSet = append(wantStageSet, provider.ProviderSOPS)
+			}
+
 			wantStage := provider.Provider("")
 			if len(wantStageSet) == 1 {
 				wantStage = wantStageSet[0]
@@ -222,3 +282,86 @@ func TestResolve(t *testing.T) {
 		})
 	}
 }
+
+func TestResolve_Selected(t *testing.T) {
+	t.Parallel()
+
+	cloudEnv := map[string]string{"AWS_PROFILE": "dev", "GOOGLE_CLOUD_PROJECT": "p", "KUBECONFIG": "/k"}
+
+	tests := []struct {
+		name       string
+		selection  string
+		wantParam  provider.Provider
+		wantSecret provider.Provider
+	}{
+		{
+			name:       "local overrides every env signal",
+			selection:  "local",
+			wantParam:  provider.ProviderLocal,
+			wantSecret: provider.ProviderLocal,
+		},
+		{
+			name:       "an alias selects its group",
+			selection:  "k8s",
+			wantParam:  provider.ProviderKubernetes,
+			wantSecret: provider.ProviderKubernetes,
+		},
+		{
+			name:       "a secret-only provider gets no param alias",
+			selection:  "vault",
+			wantParam:  "",
+			wantSecret: provider.ProviderVault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			vars := map[string]string{detect.ProviderEnvVar: tt.selection}
+			for k, v := range cloudEnv {
+				vars[k] = v
+			}
+
+			got := detect.Resolve(env(vars, true))
+
+			assert.True(t, got.Selected)
+			assert.False(t, got.AWSViaFallback)
+			assert.Equal(t, tt.wantParam, got.Param, "Param")
+			assert.Equal(t, tt.wantSecret, got.Secret, "Secret")
+			assert.Equal(t, tt.wantSecret, got.Stage, "Stage")
+		})
+	}
+
+	t.Run("unknown name falls back to detection", func(t *testing.T) {
+		t.Parallel()
+
+		got := detect.Resolve(env(map[string]string{detect.ProviderEnvVar: "nope", "AWS_PROFILE": "dev"}, false))
+
+		assert.False(t, got.Selected)
+		assert.Equal(t, provider.ProviderAWS, got.Param)
+	})
+}
+
+func TestParseProvider(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]provider.Provider{
+		"":            "",
+		"aws":         provider.ProviderAWS,
+		"gcloud":      provider.ProviderGoogleCloud,
+		"googlecloud": provider.ProviderGoogleCloud,
+		"az":          provider.ProviderAzure,
+		"vault":       provider.ProviderVault,
+		"kube":        provider.ProviderKubernetes,
+		"sops":        provider.ProviderSOPS,
+		"local":       provider.ProviderLocal,
+	} {
+		got, err := detect.ParseProvider(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	_, err := detect.ParseProvider("oracle")
+	assert.ErrorContains(t, err, `unknown provider "oracle"`)
+}
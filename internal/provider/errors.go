@@ -15,4 +15,21 @@ var (
 	// represent as text (e.g. an AWS Secrets Manager SecretBinary secret).
 	// Callers must not treat such an entry as an empty-string value.
 	ErrBinaryValue = errors.New("binary value is not supported")
+	// ErrLocked indicates a write was rejected because the entry is locked
+	// read-only (e.g. an Azure App Configuration key-value with read-only set).
+	ErrLocked = errors.New("provider: entry is locked (read-only)")
+	// ErrModified indicates a conditional write was rejected because the entry
+	// changed since the version it was conditioned on (see IfMatch).
+	ErrModified = errors.New("provider: entry was modified")
+	// ErrThrottled indicates the backend rejected a request for exceeding its
+	// request rate. The same request may succeed when retried later.
+	ErrThrottled = errors.New("provider: request throttled")
 )
+
+// IsThrottled reports whether err is a rate-limit rejection. Each adapter
+// wraps its backend's throttling error (an AWS throttling error code, an HTTP
+// 429, a gRPC ResourceExhausted status) with ErrThrottled, so callers classify
+// it without importing any cloud SDK.
+func IsThrottled(err error) bool {
+	return errors.Is(err, ErrThrottled)
+}
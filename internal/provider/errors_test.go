@@ -0,0 +1,34 @@
+package provider_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mpyw/suve/internal/provider"
+)
+
+func TestIsThrottled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"sentinel", fmt.Errorf("%w: /app/x", provider.ErrThrottled), true},
+		{"sentinel joined with the SDK error", fmt.Errorf("%w: %w", provider.ErrThrottled, errors.New("429")), true},
+		{"plain error", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, provider.IsThrottled(tt.err))
+		})
+	}
+}
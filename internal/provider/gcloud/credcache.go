@@ -0,0 +1,92 @@
+package gcloud
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+
+	"github.com/mpyw/suve/internal/credcache"
+	"github.com/mpyw/suve/internal/debug"
+)
+
+// credentialCachePrefix namespaces Google Cloud entries in the credential cache.
+const credentialCachePrefix = "googlecloud\x00"
+
+// cachedTokenSource serves OAuth access tokens from the on-disk credential
+// cache and falls back to next on a miss, caching what next returns. Tokens
+// without an expiry are never written.
+type cachedTokenSource struct {
+	ctx   context.Context //nolint:containedctx // oauth2.TokenSource.Token takes no context
+	cache *credcache.Cache
+	name  string
+	next  oauth2.TokenSource
+}
+
+// Token implements oauth2.TokenSource.
+func (s cachedTokenSource) Token() (*oauth2.Token, error) {
+	var tok oauth2.Token
+	if s.cache.Load(s.name, &tok) {
+		return &tok, nil
+	}
+
+	fresh, err := s.next.Token()
+	if err != nil || fresh.Expiry.IsZero() {
+		return fresh, err
+	}
+
+	if err := s.cache.Save(s.name, fresh, fresh.Expiry); err != nil {
+		debug.From(s.ctx).Logf("gcloud credential cache: %v\n", err)
+	}
+
+	return fresh, nil
+}
+
+// cachedCredentialOptions returns the client option that authenticates the
+// Secret Manager client through the credential cache when it is enabled
+// (SUVE_CREDENTIAL_CACHE), or nil to leave Application Default Credentials to
+// the client. Entries are keyed by the ADC credentials themselves, so switching
+// accounts (a new credentials file) never serves another account's token.
+func cachedCredentialOptions(ctx context.Context) []option.ClientOption {
+	cache, err := credcache.Open()
+	if err != nil {
+		debug.From(ctx).Logf("gcloud credential cache: disabled: %v\n", err)
+
+		return nil
+	}
+
+	if cache == nil {
+		return nil
+	}
+
+	scopes := secretmanager.DefaultAuthScopes()
+
+	creds, err := google.FindDefaultCredentials(ctx, scopes...)
+	if err != nil {
+		// Let the client report the missing credentials as it always has.
+		return nil
+	}
+
+	sum := sha256.Sum256(creds.JSON)
+	name := credentialCachePrefix + hex.EncodeToString(sum[:]) + "\x00" + strings.Join(scopes, " ")
+
+	ts := oauth2.ReuseTokenSource(nil, cachedTokenSource{ctx: ctx, cache: cache, name: name, next: creds.TokenSource})
+	opts := []option.ClientOption{option.WithTokenSource(ts)}
+
+	// A token source drops the quota project the ADC file names; carry it over.
+	var file struct {
+		QuotaProjectID string `json:"quota_project_id"`
+	}
+
+	if json.Unmarshal(creds.JSON, &file) == nil && file.QuotaProjectID != "" {
+		opts = append(opts, option.WithQuotaProject(file.QuotaProjectID))
+	}
+
+	return opts
+}
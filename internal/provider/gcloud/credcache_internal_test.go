@@ -0,0 +1,64 @@
+package gcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/mpyw/suve/internal/credcache"
+	"github.com/mpyw/suve/internal/keyprovider"
+)
+
+// countingTokenSource returns tok and counts the calls.
+type countingTokenSource struct {
+	calls *int
+	tok   oauth2.Token
+}
+
+func (s countingTokenSource) Token() (*oauth2.Token, error) {
+	*s.calls++
+	tok := s.tok
+
+	return &tok, nil
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestCachedTokenSource(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(credcache.EnvCredentialCache, "1")
+	t.Setenv(keyprovider.EnvStagingKey, base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+
+	cache, err := credcache.Open()
+	require.NoError(t, err)
+
+	calls := 0
+	fresh := oauth2.Token{AccessToken: "ya29", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}
+
+	for range 2 {
+		ts := cachedTokenSource{ctx: context.Background(), cache: cache, name: "adc", next: countingTokenSource{calls: &calls, tok: fresh}}
+
+		tok, err := ts.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "ya29", tok.AccessToken)
+	}
+
+	assert.Equal(t, 1, calls, "the second source is served from the cache")
+
+	ts := cachedTokenSource{ctx: context.Background(), cache: cache, name: "no-expiry", next: countingTokenSource{calls: &calls, tok: oauth2.Token{AccessToken: "x"}}}
+	_, _ = ts.Token()
+	_, _ = ts.Token()
+	assert.Equal(t, 3, calls, "tokens without an expiry are not cached")
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestCachedCredentialOptions_Disabled(t *testing.T) {
+	t.Setenv(credcache.EnvCredentialCache, "")
+
+	assert.Nil(t, cachedCredentialOptions(context.Background()))
+}
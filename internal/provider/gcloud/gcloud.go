@@ -96,11 +96,12 @@ func debugDialOptions(ctx context.Context) []option.ClientOption {
 }
 
 // newSecretManagerClient builds the Secret Manager client, honoring the
-// emulator seam (EmulatorEnvVar) when set.
+// emulator seam (EmulatorEnvVar) when set and the credential cache when
+// enabled.
 func newSecretManagerClient(ctx context.Context) (*secretmanager.Client, error) {
 	endpoint := os.Getenv(EmulatorEnvVar)
 	if endpoint == "" {
-		return secretmanager.NewClient(ctx, debugDialOptions(ctx)...)
+		return secretmanager.NewClient(ctx, append(debugDialOptions(ctx), cachedCredentialOptions(ctx)...)...)
 	}
 
 	// Emulator: dial plaintext gRPC and skip authentication entirely.
@@ -3,15 +3,21 @@ package secret
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mpyw/suve/internal/provider"
 )
 
 // apiClient adapts the concrete *secretmanager.Client to the narrow Client
 // interface, draining the SDK's list iterators into slices. It is the only
-// place the concrete SDK client and its iterators are referenced.
+// place the concrete SDK client and its iterators are referenced, and so also
+// where a rate-limit rejection is marked with provider.ErrThrottled.
 type apiClient struct {
 	c *secretmanager.Client
 }
@@ -27,13 +33,17 @@ var _ Client = (*apiClient)(nil)
 func (a *apiClient) AccessSecretVersion(
 	ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest,
 ) (*secretmanagerpb.AccessSecretVersionResponse, error) {
-	return a.c.AccessSecretVersion(ctx, req)
+	res, err := a.c.AccessSecretVersion(ctx, req)
+
+	return res, throttled(err)
 }
 
 func (a *apiClient) GetSecretVersion(
 	ctx context.Context, req *secretmanagerpb.GetSecretVersionRequest,
 ) (*secretmanagerpb.SecretVersion, error) {
-	return a.c.GetSecretVersion(ctx, req)
+	res, err := a.c.GetSecretVersion(ctx, req)
+
+	return res, throttled(err)
 }
 
 func (a *apiClient) ListSecretVersions(
@@ -50,7 +60,7 @@ func (a *apiClient) ListSecretVersions(
 		}
 
 		if err != nil {
-			return nil, err
+			return nil, throttled(err)
 		}
 
 		out = append(out, v)
@@ -73,7 +83,7 @@ func (a *apiClient) ListSecrets(
 		}
 
 		if err != nil {
-			return nil, err
+			return nil, throttled(err)
 		}
 
 		out = append(out, s)
@@ -85,27 +95,46 @@ func (a *apiClient) ListSecrets(
 func (a *apiClient) GetSecret(
 	ctx context.Context, req *secretmanagerpb.GetSecretRequest,
 ) (*secretmanagerpb.Secret, error) {
-	return a.c.GetSecret(ctx, req)
+	res, err := a.c.GetSecret(ctx, req)
+
+	return res, throttled(err)
 }
 
 func (a *apiClient) CreateSecret(
 	ctx context.Context, req *secretmanagerpb.CreateSecretRequest,
 ) (*secretmanagerpb.Secret, error) {
-	return a.c.CreateSecret(ctx, req)
+	res, err := a.c.CreateSecret(ctx, req)
+
+	return res, throttled(err)
 }
 
 func (a *apiClient) AddSecretVersion(
 	ctx context.Context, req *secretmanagerpb.AddSecretVersionRequest,
 ) (*secretmanagerpb.SecretVersion, error) {
-	return a.c.AddSecretVersion(ctx, req)
+	res, err := a.c.AddSecretVersion(ctx, req)
+
+	return res, throttled(err)
 }
 
 func (a *apiClient) DeleteSecret(ctx context.Context, req *secretmanagerpb.DeleteSecretRequest) error {
-	return a.c.DeleteSecret(ctx, req)
+	return throttled(a.c.DeleteSecret(ctx, req))
 }
 
 func (a *apiClient) UpdateSecret(
 	ctx context.Context, req *secretmanagerpb.UpdateSecretRequest,
 ) (*secretmanagerpb.Secret, error) {
-	return a.c.UpdateSecret(ctx, req)
+	res, err := a.c.UpdateSecret(ctx, req)
+
+	return res, throttled(err)
+}
+
+// throttled wraps a ResourceExhausted status (Secret Manager's quota and rate
+// limit rejection) with provider.ErrThrottled, keeping the status error so
+// status.Code still reads it. Any other error is returned as is.
+func throttled(err error) error {
+	if err != nil && status.Code(err) == codes.ResourceExhausted {
+		return fmt.Errorf("%w: %w", provider.ErrThrottled, err)
+	}
+
+	return err
 }
@@ -0,0 +1,34 @@
+package secret
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mpyw/suve/internal/provider"
+)
+
+func TestThrottled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ResourceExhausted is marked", func(t *testing.T) {
+		t.Parallel()
+
+		err := throttled(status.Error(codes.ResourceExhausted, "quota exceeded"))
+		require.ErrorIs(t, err, provider.ErrThrottled)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("other statuses pass through", func(t *testing.T) {
+		t.Parallel()
+
+		src := status.Error(codes.NotFound, "missing")
+		assert.Same(t, src, throttled(src))
+		assert.NotErrorIs(t, throttled(errors.New("boom")), provider.ErrThrottled)
+		assert.NoError(t, throttled(nil))
+	})
+}
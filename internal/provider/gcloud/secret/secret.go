@@ -17,6 +17,9 @@
 //     (secretmanagerpb.Secret.Annotations, distinct from the labels that back
 //     suve's tag axis) are exactly "for client tools to store their own state",
 //     so a description annotation adds description support with no collision.
+//   - Versions carry no metadata of their own, so a provider.ChangeMessage is
+//     stored as a secret annotation keyed by the version it was written with
+//     ("suve-message-{version}").
 package secret
 
 import (
@@ -92,6 +95,15 @@ var _ provider.Store = (*Store)(nil)
 // back the tag axis), so this never collides with a user tag.
 const descriptionAnnotation = "description"
 
+// messageAnnotationPrefix prefixes the secret-annotation key under which suve
+// stores the change message a version was written with (see messageAnnotation).
+const messageAnnotationPrefix = "suve-message-"
+
+// messageAnnotation returns the annotation key of version's change message.
+func messageAnnotation(version string) string {
+	return messageAnnotationPrefix + version
+}
+
 // New builds a Store backed by the given client for the given project id.
 func New(client Client, project string) *Store {
 	return &Store{client: client, project: project}
@@ -236,6 +248,7 @@ func (s *Store) Get(ctx context.Context, name string, ref provider.VersionRef) (
 	}); serr == nil && sec != nil {
 		entry.Tags = mapLabels(sec.GetLabels())
 		entry.Description = sec.GetAnnotations()[descriptionAnnotation]
+		entry.Version.Message = sec.GetAnnotations()[messageAnnotation(entry.Version.ID)]
 	}
 
 	return entry, nil
@@ -243,7 +256,8 @@ func (s *Store) Get(ctx context.Context, name string, ref provider.VersionRef) (
 
 // History returns the secret's version history, newest first. The per-version
 // state (enabled/disabled/destroyed) is surfaced in the neutral Version.State
-// for display; destroyed/disabled versions have no accessible value.
+// for display; destroyed/disabled versions have no accessible value. Change
+// messages come from the secret's annotations (best-effort).
 func (s *Store) History(ctx context.Context, name string) ([]domain.Version, error) {
 	versions, err := s.client.ListSecretVersions(ctx, &secretmanagerpb.ListSecretVersionsRequest{
 		Parent: s.secretPath(name),
@@ -254,11 +268,21 @@ func (s *Store) History(ctx context.Context, name string) ([]domain.Version, err
 
 	sortNewestFirst(versions)
 
+	var annotations map[string]string
+	if sec, serr := s.client.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{
+		Name: s.secretPath(name),
+	}); serr == nil && sec != nil {
+		annotations = sec.GetAnnotations()
+	}
+
 	return lo.Map(versions, func(v *secretmanagerpb.SecretVersion, _ int) domain.Version {
+		id := versionNumber(v.GetName())
+
 		return domain.Version{
-			ID:      versionNumber(v.GetName()),
+			ID:      id,
 			State:   stateLabel(v.GetState()),
 			Created: toTime(v.GetCreateTime()),
+			Message: annotations[messageAnnotation(id)],
 		}
 	}), nil
 }
@@ -284,9 +308,10 @@ func (s *Store) List(ctx context.Context) ([]string, error) {
 // Create creates a new secret (create-only) and adds its initial value as the
 // first version. It returns a wrapped provider.ErrAlreadyExists if the secret
 // already exists. The valueType is ignored (Google Cloud values are always
-// secret); a non-empty description is stored as the "description" annotation.
+// secret); a non-empty description is stored as the "description" annotation,
+// and a provider.ChangeMessage as the first version's message annotation.
 func (s *Store) Create(
-	ctx context.Context, name, value string, _ domain.ValueType, description string, _ ...provider.WriteOption,
+	ctx context.Context, name, value string, _ domain.ValueType, description string, opts ...provider.WriteOption,
 ) (domain.Version, error) {
 	_, err := s.client.CreateSecret(ctx, s.createRequest(name, description))
 	if err != nil {
@@ -297,30 +322,45 @@ func (s *Store) Create(
 		return domain.Version{}, fmt.Errorf("failed to create secret: %w", err)
 	}
 
-	return s.addVersion(ctx, name, value)
+	return s.addVersion(ctx, name, value, provider.ChangeMessageOf(opts))
 }
 
 // Put adds a new version to the secret (upsert). If the secret does not yet
 // exist it is created first, then the version is added. The valueType is
 // ignored; a non-empty description is written to the "description" annotation
-// (updating it on an already-existing secret, mirroring the AWS Put contract).
+// (updating it on an already-existing secret, mirroring the AWS Put contract),
+// and a provider.ChangeMessage to the new version's message annotation.
 //
 // Unlike AWS (whose UpdateSecret sets value and description atomically), Secret
-// Manager needs a separate UpdateSecret for the annotation, so on an existing
-// secret the new version is committed first and the annotation second. A failure
-// to write the annotation is reported (never silently dropped, per #666), even
+// Manager needs a separate UpdateSecret for the annotations, so on an existing
+// secret the new version is committed first and the annotations second. A
+// failure to write them is reported (never silently dropped, per #666), even
 // though the value version has already landed.
 func (s *Store) Put(
-	ctx context.Context, name, value string, _ domain.ValueType, description string, _ ...provider.WriteOption,
+	ctx context.Context, name, value string, _ domain.ValueType, description string, opts ...provider.WriteOption,
 ) (domain.Version, error) {
+	message := provider.ChangeMessageOf(opts)
+
 	sv, err := s.client.AddSecretVersion(ctx, s.addRequest(name, value))
 	if err == nil {
-		// The secret already existed: update its description annotation to match.
-		if aerr := s.applyDescription(ctx, name, description); aerr != nil {
+		// The secret already existed: update its description annotation to
+		// match, and record the version's message.
+		version := versionNumber(sv.GetName())
+
+		set := make(map[string]string)
+		if description != "" {
+			set[descriptionAnnotation] = description
+		}
+
+		if message != "" {
+			set[messageAnnotation(version)] = message
+		}
+
+		if aerr := s.applyAnnotations(ctx, name, set); aerr != nil {
 			return domain.Version{}, aerr
 		}
 
-		return domain.Version{ID: versionNumber(sv.GetName())}, nil
+		return domain.Version{ID: version}, nil
 	}
 
 	// Upsert semantics: create the secret on first write, then add the version.
@@ -341,17 +381,26 @@ func (s *Store) Put(
 		}
 	}
 
-	return s.addVersion(ctx, name, value)
+	return s.addVersion(ctx, name, value, message)
 }
 
-// addVersion adds a new secret version and returns the resulting domain.Version.
-func (s *Store) addVersion(ctx context.Context, name, value string) (domain.Version, error) {
+// addVersion adds a new secret version, annotated with message when non-empty,
+// and returns the resulting domain.Version.
+func (s *Store) addVersion(ctx context.Context, name, value, message string) (domain.Version, error) {
 	sv, err := s.client.AddSecretVersion(ctx, s.addRequest(name, value))
 	if err != nil {
 		return domain.Version{}, fmt.Errorf("failed to add secret version: %w", err)
 	}
 
-	return domain.Version{ID: versionNumber(sv.GetName())}, nil
+	version := versionNumber(sv.GetName())
+
+	if message != "" {
+		if err := s.applyAnnotations(ctx, name, map[string]string{messageAnnotation(version): message}); err != nil {
+			return domain.Version{}, err
+		}
+	}
+
+	return domain.Version{ID: version}, nil
 }
 
 // createRequest builds a CreateSecretRequest with automatic replication. A
@@ -378,20 +427,31 @@ func (s *Store) createRequest(name, description string) *secretmanagerpb.CreateS
 }
 
 // applyDescription writes a non-empty description to the "description"
-// annotation of an existing secret via a read-modify-write UpdateSecret,
-// preserving any other annotations. An empty description is a no-op (Put/Create
-// never clear an existing description, mirroring the AWS contract).
+// annotation of an existing secret (see applyAnnotations). An empty description
+// is a no-op (Put/Create never clear an existing description, mirroring the AWS
+// contract).
 func (s *Store) applyDescription(ctx context.Context, name, description string) error {
 	if description == "" {
 		return nil
 	}
 
+	return s.applyAnnotations(ctx, name, map[string]string{descriptionAnnotation: description})
+}
+
+// applyAnnotations sets the given annotations on an existing secret via a
+// read-modify-write UpdateSecret, preserving any other annotations. An empty
+// set is a no-op.
+func (s *Store) applyAnnotations(ctx context.Context, name string, set map[string]string) error {
+	if len(set) == 0 {
+		return nil
+	}
+
 	annotations, err := s.currentAnnotations(ctx, name)
 	if err != nil {
 		return err
 	}
 
-	annotations[descriptionAnnotation] = description
+	maps.Copy(annotations, set)
 
 	_, err = s.client.UpdateSecret(ctx, &secretmanagerpb.UpdateSecretRequest{
 		Secret: &secretmanagerpb.Secret{
@@ -401,7 +461,7 @@ func (s *Store) applyDescription(ctx context.Context, name, description string)
 		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"annotations"}},
 	})
 	if err != nil {
-		return fmt.Errorf("failed to update secret description: %w", err)
+		return fmt.Errorf("failed to update secret annotations: %w", err)
 	}
 
 	return nil
@@ -60,6 +60,12 @@ func (m *mockClient) ListSecrets(
 func (m *mockClient) GetSecret(
 	ctx context.Context, req *secretmanagerpb.GetSecretRequest,
 ) (*secretmanagerpb.Secret, error) {
+	// History reads the secret only for its (best-effort) message annotations,
+	// so tests that don't care about them may leave getFunc unset.
+	if m.getFunc == nil {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
 	return m.getFunc(ctx, req)
 }
 
@@ -255,6 +261,9 @@ func TestHistory(t *testing.T) {
 				{Name: versionName(2), State: secretmanagerpb.SecretVersion_ENABLED},
 			}, nil
 		},
+		getFunc: func(_ context.Context, _ *secretmanagerpb.GetSecretRequest) (*secretmanagerpb.Secret, error) {
+			return &secretmanagerpb.Secret{Annotations: map[string]string{"suve-message-2": "rotate key"}}, nil
+		},
 	}
 	store := newStore(m)
 
@@ -266,7 +275,9 @@ func TestHistory(t *testing.T) {
 	// State carries the per-version lifecycle; StagingLabels is not a GCloud concept.
 	assert.Equal(t, "enabled", versions[0].State)
 	assert.Empty(t, versions[0].StagingLabels)
+	assert.Equal(t, "rotate key", versions[0].Message, "messages come from the per-version annotation")
 	assert.Equal(t, "1", versions[1].ID)
+	assert.Empty(t, versions[1].Message)
 	assert.Equal(t, "destroyed", versions[1].State)
 	assert.Empty(t, versions[1].StagingLabels)
 }
@@ -459,6 +470,33 @@ func TestPut(t *testing.T) {
 		assert.Equal(t, []string{"annotations"}, mask)
 	})
 
+	t.Run("change message annotates the new version", func(t *testing.T) {
+		t.Parallel()
+
+		var written map[string]string
+
+		m := &mockClient{
+			addFunc: func(_ context.Context, _ *secretmanagerpb.AddSecretVersionRequest) (*secretmanagerpb.SecretVersion, error) {
+				return &secretmanagerpb.SecretVersion{Name: versionName(5)}, nil
+			},
+			getFunc: func(_ context.Context, _ *secretmanagerpb.GetSecretRequest) (*secretmanagerpb.Secret, error) {
+				return &secretmanagerpb.Secret{Annotations: map[string]string{"suve-message-4": "older"}}, nil
+			},
+			updateFunc: func(_ context.Context, req *secretmanagerpb.UpdateSecretRequest) (*secretmanagerpb.Secret, error) {
+				written = req.GetSecret().GetAnnotations()
+
+				return req.GetSecret(), nil
+			},
+		}
+		store := newStore(m)
+
+		_, err := store.Put(t.Context(), "my-secret", "value", domain.ValueTypeSecret, "",
+			provider.ChangeMessage{Message: "rotate key"})
+		require.NoError(t, err)
+		// Earlier versions' messages are kept; no description is written.
+		assert.Equal(t, map[string]string{"suve-message-4": "older", "suve-message-5": "rotate key"}, written)
+	})
+
 	t.Run("description applied via update when a concurrent create wins the race", func(t *testing.T) {
 		t.Parallel()
 
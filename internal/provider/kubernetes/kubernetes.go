@@ -0,0 +1,152 @@
+// Package kubernetes wires the Kubernetes adapter into a provider.Factory /
+// provider.Registry:
+//
+//   - KindParam  -> ConfigMap keys in the scope's namespace.
+//   - KindSecret -> Secret keys in the scope's namespace.
+//
+// The cluster is reached through a kubeconfig context, loaded the same way
+// kubectl loads it ($KUBECONFIG, else ~/.kube/config). The typed client-go
+// clients are built here and handed to the objects subpackage, which confines
+// every client-go type behind the provider seam.
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/mpyw/suve/internal/debug"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/kubernetes/objects"
+)
+
+// KubeconfigEnvVar is the kubectl-compatible list of kubeconfig files. When it
+// is set, Kubernetes counts as an active provider for the bare aliases.
+const KubeconfigEnvVar = "KUBECONFIG"
+
+// defaultNamespace is used when neither the flag nor the context names one,
+// matching kubectl.
+const defaultNamespace = "default"
+
+// Factory builds Kubernetes-backed provider.Store values for a scope + kind.
+type Factory struct{}
+
+// Compile-time assertion that Factory implements provider.Factory.
+var _ provider.Factory = Factory{}
+
+// Store builds a ConfigMap (KindParam) or Secret (KindSecret) store for the
+// scope's context and namespace. An empty context or namespace falls back to
+// the kubeconfig's current context and that context's namespace.
+func (Factory) Store(ctx context.Context, scope provider.Scope, kind provider.Kind) (provider.Store, error) {
+	cc := clientConfig(scope.KubeContext, scope.KubeNamespace)
+
+	namespace, _, err := cc.Namespace()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	restConfig, err := cc.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	if d := debug.From(ctx); d.Enabled {
+		restConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return &debugTransport{next: rt, cfg: d}
+		})
+	}
+
+	clientset, err := k8s.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	core := clientset.CoreV1()
+
+	switch kind {
+	case provider.KindParam:
+		return objects.NewConfigMapStore(objects.ConfigMaps(core.ConfigMaps(namespace))), nil
+	case provider.KindSecret:
+		return objects.NewSecretStore(objects.Secrets(core.Secrets(namespace))), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", provider.ErrUnsupportedKind, kind)
+	}
+}
+
+// ResolveScope fills the context and namespace from the kubeconfig: an empty
+// context becomes the current context and an empty namespace becomes the
+// context's namespace (or "default"). The resolved names key staging state, so
+// the same cluster and namespace is always staged under the same scope.
+func ResolveScope(kubeContext, namespace string) (provider.Scope, error) {
+	cc := clientConfig(kubeContext, namespace)
+
+	raw, err := cc.RawConfig()
+	if err != nil {
+		return provider.Scope{}, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	if kubeContext == "" {
+		kubeContext = raw.CurrentContext
+	}
+
+	if kubeContext == "" {
+		return provider.Scope{}, fmt.Errorf(
+			"no Kubernetes context: set --context or a current-context in the kubeconfig ($%s or ~/.kube/config)",
+			KubeconfigEnvVar,
+		)
+	}
+
+	if _, ok := raw.Contexts[kubeContext]; !ok {
+		return provider.Scope{}, fmt.Errorf("kubeconfig has no context %q", kubeContext)
+	}
+
+	if namespace == "" {
+		namespace = raw.Contexts[kubeContext].Namespace
+	}
+
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	return provider.KubernetesScope(kubeContext, namespace), nil
+}
+
+// clientConfig loads the kubeconfig with kubectl's precedence rules, overriding
+// the context and namespace when set.
+func clientConfig(kubeContext, namespace string) clientcmd.ClientConfig {
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+	overrides.Context.Namespace = namespace
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), overrides)
+}
+
+// debugTransport logs each API request's method, path and status. It never
+// logs bodies, which carry Secret data.
+type debugTransport struct {
+	next http.RoundTripper
+	cfg  debug.Config
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.cfg.Logf("kubernetes: %s %s failed: %v (%s)\n", req.Method, req.URL.Path, err, time.Since(start))
+
+		return nil, err
+	}
+
+	t.cfg.Logf("kubernetes: %s %s -> %d (%s)\n", req.Method, req.URL.Path, resp.StatusCode, time.Since(start))
+
+	return resp, nil
+}
+
+// Register associates the Kubernetes Factory with provider.ProviderKubernetes in reg.
+func Register(reg *provider.Registry) {
+	reg.Register(provider.ProviderKubernetes, Factory{})
+}
@@ -0,0 +1,116 @@
+package kubernetes_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/kubernetes"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+current-context: kind-dev
+clusters:
+- name: dev
+  cluster:
+    server: https://127.0.0.1:6443
+users:
+- name: dev
+  user:
+    token: dummy
+contexts:
+- name: kind-dev
+  context:
+    cluster: dev
+    user: dev
+    namespace: payments
+- name: bare
+  context:
+    cluster: dev
+    user: dev
+`
+
+func writeKubeconfig(t *testing.T, content string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv(kubernetes.KubeconfigEnvVar, path)
+}
+
+func TestResolveScope(t *testing.T) {
+	// Cannot use t.Parallel() because subtests use t.Setenv
+	t.Run("current context and its namespace", func(t *testing.T) {
+		writeKubeconfig(t, kubeconfig)
+
+		scope, err := kubernetes.ResolveScope("", "")
+		require.NoError(t, err)
+		assert.Equal(t, provider.KubernetesScope("kind-dev", "payments"), scope)
+	})
+
+	t.Run("explicit namespace wins", func(t *testing.T) {
+		writeKubeconfig(t, kubeconfig)
+
+		scope, err := kubernetes.ResolveScope("", "billing")
+		require.NoError(t, err)
+		assert.Equal(t, provider.KubernetesScope("kind-dev", "billing"), scope)
+	})
+
+	t.Run("context without namespace falls back to default", func(t *testing.T) {
+		writeKubeconfig(t, kubeconfig)
+
+		scope, err := kubernetes.ResolveScope("bare", "")
+		require.NoError(t, err)
+		assert.Equal(t, provider.KubernetesScope("bare", "default"), scope)
+	})
+
+	t.Run("unknown context", func(t *testing.T) {
+		writeKubeconfig(t, kubeconfig)
+
+		_, err := kubernetes.ResolveScope("prod", "")
+		require.ErrorContains(t, err, `kubeconfig has no context "prod"`)
+	})
+
+	t.Run("no current context", func(t *testing.T) {
+		writeKubeconfig(t, "apiVersion: v1\nkind: Config\n")
+
+		_, err := kubernetes.ResolveScope("", "")
+		require.ErrorContains(t, err, "no Kubernetes context")
+	})
+}
+
+func TestFactory_Store(t *testing.T) {
+	// Cannot use t.Parallel() because subtests use t.Setenv
+	t.Run("builds both kinds without touching the network", func(t *testing.T) {
+		writeKubeconfig(t, kubeconfig)
+
+		for _, kind := range []provider.Kind{provider.KindParam, provider.KindSecret} {
+			store, err := kubernetes.Factory{}.Store(t.Context(), provider.KubernetesScope("kind-dev", "payments"), kind)
+			require.NoError(t, err)
+			assert.NotImplements(t, (*provider.Restorer)(nil), store)
+		}
+	})
+
+	t.Run("unsupported kind", func(t *testing.T) {
+		writeKubeconfig(t, kubeconfig)
+
+		store, err := kubernetes.Factory{}.Store(t.Context(), provider.KubernetesScope("kind-dev", "payments"), provider.Kind("bogus"))
+		require.ErrorIs(t, err, provider.ErrUnsupportedKind)
+		assert.Nil(t, store)
+	})
+
+	t.Run("register", func(t *testing.T) {
+		writeKubeconfig(t, kubeconfig)
+
+		reg := provider.NewRegistry()
+		kubernetes.Register(reg)
+
+		_, err := reg.Store(t.Context(), provider.KubernetesScope("kind-dev", "payments"), provider.KindSecret)
+		require.NoError(t, err)
+	})
+}
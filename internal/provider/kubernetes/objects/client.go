@@ -0,0 +1,269 @@
+package objects
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Object is the provider-neutral view of one ConfigMap or Secret that the Store
+// works on: its text data, the keys it holds as binary, and its labels. raw
+// keeps the object as read so an Update re-sends every field the Store does not
+// model (annotations, owner references, a Secret's type, ...) unchanged.
+type Object struct {
+	Name string
+	// Data maps each text key to its value.
+	Data map[string]string
+	// Binary lists the keys whose values are not text (a ConfigMap's binaryData,
+	// or Secret data that is not valid UTF-8). They are listed but not readable
+	// or writable through suve, and an Update leaves them untouched.
+	Binary []string
+	// Labels are the object's labels, surfaced as every key's tags.
+	Labels map[string]string
+	// ResourceVersion is the server's optimistic-concurrency token, sent back on
+	// Update so a concurrent write surfaces as a conflict.
+	ResourceVersion string
+	// Created is the object's creationTimestamp.
+	Created time.Time
+	// Modified is the newest managedFields timestamp (the last write by any
+	// field manager), or nil when the server reports none.
+	Modified *time.Time
+
+	raw any
+}
+
+// Client is the narrow object surface the Store needs, implemented over the
+// typed ConfigMap and Secret clients (see ConfigMaps and Secrets). A missing
+// object is reported with the Kubernetes NotFound status error, and a stale
+// ResourceVersion on Update with the Conflict status error.
+type Client interface {
+	Get(ctx context.Context, name string) (*Object, error)
+	List(ctx context.Context) ([]*Object, error)
+	Create(ctx context.Context, obj *Object) (*Object, error)
+	Update(ctx context.Context, obj *Object) (*Object, error)
+	Delete(ctx context.Context, name, resourceVersion string) error
+}
+
+// configMapClient adapts the typed ConfigMap client.
+type configMapClient struct {
+	api corev1client.ConfigMapInterface
+}
+
+// ConfigMaps wraps a typed ConfigMap client (one namespace) as a Client.
+func ConfigMaps(api corev1client.ConfigMapInterface) Client {
+	return &configMapClient{api: api}
+}
+
+func (c *configMapClient) Get(ctx context.Context, name string) (*Object, error) {
+	cm, err := c.api.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return fromConfigMap(cm), nil
+}
+
+func (c *configMapClient) List(ctx context.Context) ([]*Object, error) {
+	list, err := c.api.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Object, 0, len(list.Items))
+	for i := range list.Items {
+		out = append(out, fromConfigMap(&list.Items[i]))
+	}
+
+	return out, nil
+}
+
+func (c *configMapClient) Create(ctx context.Context, obj *Object) (*Object, error) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: obj.Name, Labels: obj.Labels},
+		Data:       obj.Data,
+	}
+
+	created, err := c.api.Create(ctx, cm, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return fromConfigMap(created), nil
+}
+
+func (c *configMapClient) Update(ctx context.Context, obj *Object) (*Object, error) {
+	cm, _ := obj.raw.(*corev1.ConfigMap)
+	cm = cm.DeepCopy()
+	cm.Data = obj.Data
+	cm.Labels = obj.Labels
+	cm.ResourceVersion = obj.ResourceVersion
+
+	updated, err := c.api.Update(ctx, cm, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return fromConfigMap(updated), nil
+}
+
+func (c *configMapClient) Delete(ctx context.Context, name, resourceVersion string) error {
+	return c.api.Delete(ctx, name, deleteOptions(resourceVersion))
+}
+
+func fromConfigMap(cm *corev1.ConfigMap) *Object {
+	return &Object{
+		Name:            cm.Name,
+		Data:            maps.Clone(cm.Data),
+		Binary:          slices.Sorted(maps.Keys(cm.BinaryData)),
+		Labels:          maps.Clone(cm.Labels),
+		ResourceVersion: cm.ResourceVersion,
+		Created:         cm.CreationTimestamp.Time,
+		Modified:        lastManaged(cm.ManagedFields),
+		raw:             cm,
+	}
+}
+
+// secretClient adapts the typed Secret client.
+type secretClient struct {
+	api corev1client.SecretInterface
+}
+
+// Secrets wraps a typed Secret client (one namespace) as a Client. New Secrets
+// are created with type Opaque.
+func Secrets(api corev1client.SecretInterface) Client {
+	return &secretClient{api: api}
+}
+
+func (c *secretClient) Get(ctx context.Context, name string) (*Object, error) {
+	s, err := c.api.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return fromSecret(s), nil
+}
+
+func (c *secretClient) List(ctx context.Context) ([]*Object, error) {
+	list, err := c.api.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Object, 0, len(list.Items))
+	for i := range list.Items {
+		out = append(out, fromSecret(&list.Items[i]))
+	}
+
+	return out, nil
+}
+
+func (c *secretClient) Create(ctx context.Context, obj *Object) (*Object, error) {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: obj.Name, Labels: obj.Labels},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       toBytes(obj.Data),
+	}
+
+	created, err := c.api.Create(ctx, s, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return fromSecret(created), nil
+}
+
+func (c *secretClient) Update(ctx context.Context, obj *Object) (*Object, error) {
+	s, _ := obj.raw.(*corev1.Secret)
+	s = s.DeepCopy()
+
+	// Binary values are carried over byte-for-byte; every text key comes from
+	// obj.Data, so a removed text key is dropped.
+	data := toBytes(obj.Data)
+	for _, key := range obj.Binary {
+		if v, ok := s.Data[key]; ok {
+			data[key] = v
+		}
+	}
+
+	s.Data = data
+	s.StringData = nil
+	s.Labels = obj.Labels
+	s.ResourceVersion = obj.ResourceVersion
+
+	updated, err := c.api.Update(ctx, s, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return fromSecret(updated), nil
+}
+
+func (c *secretClient) Delete(ctx context.Context, name, resourceVersion string) error {
+	return c.api.Delete(ctx, name, deleteOptions(resourceVersion))
+}
+
+func fromSecret(s *corev1.Secret) *Object {
+	obj := &Object{
+		Name:            s.Name,
+		Data:            make(map[string]string, len(s.Data)),
+		Labels:          maps.Clone(s.Labels),
+		ResourceVersion: s.ResourceVersion,
+		Created:         s.CreationTimestamp.Time,
+		Modified:        lastManaged(s.ManagedFields),
+		raw:             s,
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(s.Data)) {
+		if !utf8.Valid(s.Data[key]) {
+			obj.Binary = append(obj.Binary, key)
+
+			continue
+		}
+
+		obj.Data[key] = string(s.Data[key])
+	}
+
+	return obj
+}
+
+func toBytes(data map[string]string) map[string][]byte {
+	out := make(map[string][]byte, len(data))
+	for k, v := range data {
+		out[k] = []byte(v)
+	}
+
+	return out
+}
+
+// deleteOptions preconditions a delete on the resource version the Store last
+// read, so an object changed in the meantime is not removed.
+func deleteOptions(resourceVersion string) metav1.DeleteOptions {
+	if resourceVersion == "" {
+		return metav1.DeleteOptions{}
+	}
+
+	return metav1.DeleteOptions{Preconditions: &metav1.Preconditions{ResourceVersion: &resourceVersion}}
+}
+
+// lastManaged returns the newest managedFields timestamp, or nil.
+func lastManaged(entries []metav1.ManagedFieldsEntry) *time.Time {
+	var latest *time.Time
+
+	for _, e := range entries {
+		if e.Time == nil {
+			continue
+		}
+
+		if latest == nil || e.Time.After(*latest) {
+			latest = &e.Time.Time
+		}
+	}
+
+	return latest
+}
@@ -0,0 +1,371 @@
+// Package objects implements the provider.Store contract (Reader/Writer/Tagger)
+// over Kubernetes ConfigMaps (params) and Secrets (secrets), confining every
+// client-go type to this package.
+//
+// Each entry is ONE key of one object, named "<object>/<key>". Writes are
+// read-modify-write on the whole object with its resourceVersion as the
+// optimistic-concurrency precondition, retried a few times on a conflict:
+//
+//   - Create adds a key (creating the object when it does not exist yet) and
+//     fails with provider.ErrAlreadyExists if the key is already present.
+//   - Put sets a key, creating the object or key as needed.
+//   - Delete removes a key. Removing the last key leaves the object empty,
+//     keeping its labels, annotations, type and owner references, unless
+//     DeleteEmptyObject asks for the object to go too.
+//   - Tag/Untag edit the object's labels, so every key of an object shares the
+//     same tags.
+//
+// Kubernetes keeps no value history: Resolve accepts no version specifier and
+// History returns kubernetesversion.ErrVersioningUnsupported. The object's
+// resourceVersion is surfaced as the entry's version id, and its newest
+// managedFields timestamp as the modification time (used for staging conflict
+// detection).
+package objects
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/mpyw/suve/internal/debug"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/version/kubernetesversion"
+)
+
+// writeMaxAttempts bounds the read-modify-write retry loop when a concurrent
+// writer bumps the object's resourceVersion between the read and the update.
+const writeMaxAttempts = 3
+
+// DeleteEmptyObject is a provider.DeleteOption asking Delete to delete the
+// object itself when the key it removes is the object's last one. Without it
+// the emptied object is kept.
+type DeleteEmptyObject struct{ provider.DeleteOptionMarker }
+
+// Store is the Kubernetes implementation of provider.Store for one kind of
+// object (ConfigMap or Secret) in one namespace. It implements neither
+// Restorer nor Describer.
+type Store struct {
+	client    Client
+	valueType domain.ValueType
+	noun      string
+}
+
+// Compile-time assertion that Store implements the provider contract.
+var _ provider.Store = (*Store)(nil)
+
+// NewConfigMapStore builds a param Store over ConfigMaps.
+func NewConfigMapStore(client Client) *Store {
+	return &Store{client: client, valueType: domain.ValueTypePlaintext, noun: "ConfigMap"}
+}
+
+// NewSecretStore builds a secret Store over Secrets.
+func NewSecretStore(client Client) *Store {
+	return &Store{client: client, valueType: domain.ValueTypeSecret, noun: "Secret"}
+}
+
+// Resolve validates the name and that no version specifier is present, and
+// returns the latest ref.
+func (s *Store) Resolve(_ context.Context, name, spec string) (provider.VersionRef, error) {
+	if spec != "" {
+		return provider.VersionRef{}, fmt.Errorf("%w", kubernetesversion.ErrVersioningUnsupported)
+	}
+
+	if _, _, err := kubernetesversion.Split(name); err != nil {
+		return provider.VersionRef{}, err
+	}
+
+	return provider.NewVersionRef(""), nil
+}
+
+// Get returns one key of an object. A missing object or key yields
+// provider.ErrNotFound; a binary key yields provider.ErrBinaryValue. The
+// object's labels become the entry's tags.
+func (s *Store) Get(ctx context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+	objName, key, err := kubernetesversion.Split(name)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := s.client.Get(ctx, objName)
+	if err != nil {
+		return nil, s.mapError(err, name)
+	}
+
+	if slices.Contains(obj.Binary, key) {
+		return nil, fmt.Errorf("%s %s: %w", s.noun, name, provider.ErrBinaryValue)
+	}
+
+	value, ok := obj.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("%s %s: %w", s.noun, name, provider.ErrNotFound)
+	}
+
+	created := obj.Created
+
+	return &domain.Entry{
+		Name:     name,
+		Value:    value,
+		Type:     s.valueType,
+		Version:  domain.Version{ID: obj.ResourceVersion, Created: &created},
+		Tags:     tagsOf(obj.Labels),
+		Modified: obj.Modified,
+	}, nil
+}
+
+// History returns ErrVersioningUnsupported: Kubernetes keeps no value history.
+func (s *Store) History(_ context.Context, _ string) ([]domain.Version, error) {
+	return nil, fmt.Errorf("%w (no version history)", kubernetesversion.ErrVersioningUnsupported)
+}
+
+// List returns "<object>/<key>" for every key (text or binary) of every object
+// in the namespace. Ordering is left to the caller.
+func (s *Store) List(ctx context.Context) ([]string, error) {
+	objs, err := s.client.List(ctx)
+	if err != nil {
+		if apierrors.IsTooManyRequests(err) {
+			err = fmt.Errorf("%w: %w", provider.ErrThrottled, err)
+		}
+
+		return nil, fmt.Errorf("failed to list %ss: %w", s.noun, err)
+	}
+
+	var names []string
+
+	for _, obj := range objs {
+		for key := range obj.Data {
+			names = append(names, kubernetesversion.Join(obj.Name, key))
+		}
+
+		for _, key := range obj.Binary {
+			names = append(names, kubernetesversion.Join(obj.Name, key))
+		}
+	}
+
+	debug.From(ctx).Logf("kubernetes: list %ss -> %d objects, %d keys\n", s.noun, len(objs), len(names))
+
+	return names, nil
+}
+
+// Create adds a new key, creating the object when it does not exist. It never
+// overwrites: an existing key yields provider.ErrAlreadyExists. The
+// description is ignored (Kubernetes keys have none).
+func (s *Store) Create(
+	ctx context.Context, name, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+) (domain.Version, error) {
+	return s.write(ctx, name, value, false)
+}
+
+// Put sets a key, creating the object or key as needed.
+func (s *Store) Put(
+	ctx context.Context, name, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+) (domain.Version, error) {
+	return s.write(ctx, name, value, true)
+}
+
+func (s *Store) write(ctx context.Context, name, value string, overwrite bool) (domain.Version, error) {
+	objName, key, err := kubernetesversion.Split(name)
+	if err != nil {
+		return domain.Version{}, err
+	}
+
+	obj, err := s.mutate(ctx, name, func(obj *Object) (bool, error) {
+		if slices.Contains(obj.Binary, key) {
+			return false, fmt.Errorf("%s %s: %w", s.noun, name, provider.ErrBinaryValue)
+		}
+
+		if _, exists := obj.Data[key]; exists && !overwrite {
+			return false, fmt.Errorf("%s %s: %w", s.noun, name, provider.ErrAlreadyExists)
+		}
+
+		if obj.Data == nil {
+			obj.Data = map[string]string{}
+		}
+
+		obj.Data[key] = value
+
+		return true, nil
+	}, func() (*Object, error) {
+		return s.client.Create(ctx, &Object{Name: objName, Data: map[string]string{key: value}})
+	})
+	if err != nil {
+		return domain.Version{}, err
+	}
+
+	return domain.Version{ID: obj.ResourceVersion}, nil
+}
+
+// Delete removes a key. Removing an object's last key leaves the object, empty,
+// unless DeleteEmptyObject is given. A missing object or key yields
+// provider.ErrNotFound.
+func (s *Store) Delete(ctx context.Context, name string, opts ...provider.DeleteOption) error {
+	objName, key, err := kubernetesversion.Split(name)
+	if err != nil {
+		return err
+	}
+
+	deleteEmpty := slices.ContainsFunc(opts, func(opt provider.DeleteOption) bool {
+		_, ok := opt.(DeleteEmptyObject)
+
+		return ok
+	})
+
+	for attempt := 1; ; attempt++ {
+		obj, err := s.client.Get(ctx, objName)
+		if err != nil {
+			return s.mapError(err, name)
+		}
+
+		if slices.Contains(obj.Binary, key) {
+			return fmt.Errorf("%s %s: %w", s.noun, name, provider.ErrBinaryValue)
+		}
+
+		if _, ok := obj.Data[key]; !ok {
+			return fmt.Errorf("%s %s: %w", s.noun, name, provider.ErrNotFound)
+		}
+
+		delete(obj.Data, key)
+
+		if deleteEmpty && len(obj.Data) == 0 && len(obj.Binary) == 0 {
+			err = s.client.Delete(ctx, objName, obj.ResourceVersion)
+		} else {
+			_, err = s.client.Update(ctx, obj)
+		}
+
+		if err == nil {
+			return nil
+		}
+
+		if !apierrors.IsConflict(err) || attempt == writeMaxAttempts {
+			return s.mapError(err, name)
+		}
+	}
+}
+
+// Tag adds or updates labels on the entry's object.
+func (s *Store) Tag(ctx context.Context, name string, add map[string]string) error {
+	_, err := s.mutate(ctx, name, func(obj *Object) (bool, error) {
+		if err := s.requireKey(obj, name); err != nil {
+			return false, err
+		}
+
+		if obj.Labels == nil {
+			obj.Labels = map[string]string{}
+		}
+
+		maps.Copy(obj.Labels, add)
+
+		return true, nil
+	}, nil)
+
+	return err
+}
+
+// Untag removes labels from the entry's object. Absent keys are ignored.
+func (s *Store) Untag(ctx context.Context, name string, keys []string) error {
+	_, err := s.mutate(ctx, name, func(obj *Object) (bool, error) {
+		if err := s.requireKey(obj, name); err != nil {
+			return false, err
+		}
+
+		for _, k := range keys {
+			delete(obj.Labels, k)
+		}
+
+		return true, nil
+	}, nil)
+
+	return err
+}
+
+// requireKey reports provider.ErrNotFound when the entry's key is not on obj,
+// so tagging a non-existent entry fails like the other providers.
+func (s *Store) requireKey(obj *Object, name string) error {
+	_, key, err := kubernetesversion.Split(name)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := obj.Data[key]; ok || slices.Contains(obj.Binary, key) {
+		return nil
+	}
+
+	return fmt.Errorf("%s %s: %w", s.noun, name, provider.ErrNotFound)
+}
+
+// mutate runs a read-modify-write on the entry's object, retrying on a
+// resourceVersion conflict. apply edits the object in place and reports whether
+// it changed. When the object does not exist, create (if non-nil) builds it;
+// otherwise the missing object yields provider.ErrNotFound.
+func (s *Store) mutate(
+	ctx context.Context, name string, apply func(*Object) (bool, error), create func() (*Object, error),
+) (*Object, error) {
+	objName, _, err := kubernetesversion.Split(name)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		obj, err := s.client.Get(ctx, objName)
+
+		switch {
+		case apierrors.IsNotFound(err) && create != nil:
+			obj, err = create()
+		case err != nil:
+			return nil, s.mapError(err, name)
+		default:
+			var changed bool
+
+			changed, err = apply(obj)
+			if err != nil {
+				return nil, err
+			}
+
+			if changed {
+				obj, err = s.client.Update(ctx, obj)
+			}
+		}
+
+		if err == nil {
+			return obj, nil
+		}
+
+		// A conflict (stale resourceVersion) or an AlreadyExists (a concurrent
+		// create won the race) means re-read and try again.
+		retryable := apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+		if !retryable || attempt == writeMaxAttempts {
+			return nil, s.mapError(err, name)
+		}
+	}
+}
+
+// mapError translates Kubernetes status errors to the provider sentinels. A
+// 429 keeps the status error alongside provider.ErrThrottled.
+func (s *Store) mapError(err error, name string) error {
+	switch {
+	case apierrors.IsNotFound(err):
+		return fmt.Errorf("%s %s: %w", s.noun, name, provider.ErrNotFound)
+	case apierrors.IsAlreadyExists(err):
+		return fmt.Errorf("%s %s: %w", s.noun, name, provider.ErrAlreadyExists)
+	case apierrors.IsTooManyRequests(err):
+		return fmt.Errorf("%s %s: %w: %w", s.noun, name, provider.ErrThrottled, err)
+	case errors.Is(err, provider.ErrNotFound), errors.Is(err, provider.ErrAlreadyExists):
+		return err
+	default:
+		return fmt.Errorf("%s %s: %w", s.noun, name, err)
+	}
+}
+
+// tagsOf maps labels to domain tags sorted by key.
+func tagsOf(labels map[string]string) []domain.Tag {
+	tags := make([]domain.Tag, 0, len(labels))
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		tags = append(tags, domain.Tag{Key: k, Value: labels[k]})
+	}
+
+	return tags
+}
@@ -0,0 +1,280 @@
+package objects_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/kubernetes/objects"
+	"github.com/mpyw/suve/internal/version/kubernetesversion"
+)
+
+const ns = "default"
+
+func configMapStore(t *testing.T, objs ...runtime.Object) (*objects.Store, *fake.Clientset) {
+	t.Helper()
+
+	cs := fake.NewClientset(objs...)
+
+	return objects.NewConfigMapStore(objects.ConfigMaps(cs.CoreV1().ConfigMaps(ns))), cs
+}
+
+func secretStore(t *testing.T, objs ...runtime.Object) (*objects.Store, *fake.Clientset) {
+	t.Helper()
+
+	cs := fake.NewClientset(objs...)
+
+	return objects.NewSecretStore(objects.Secrets(cs.CoreV1().Secrets(ns))), cs
+}
+
+func TestStore_ConfigMapGet(t *testing.T) {
+	t.Parallel()
+
+	store, _ := configMapStore(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: ns, Labels: map[string]string{"team": "web", "env": "prod"}},
+		Data:       map[string]string{"LOG_LEVEL": "debug"},
+		BinaryData: map[string][]byte{"logo.png": {0x89, 0x50}},
+	})
+
+	ref, err := store.Resolve(t.Context(), "app/LOG_LEVEL", "")
+	require.NoError(t, err)
+
+	entry, err := store.Get(t.Context(), "app/LOG_LEVEL", ref)
+	require.NoError(t, err)
+	assert.Equal(t, "debug", entry.Value)
+	assert.Equal(t, domain.ValueTypePlaintext, entry.Type)
+	assert.Equal(t, []domain.Tag{{Key: "env", Value: "prod"}, {Key: "team", Value: "web"}}, entry.Tags)
+
+	_, err = store.Get(t.Context(), "app/missing", ref)
+	require.ErrorIs(t, err, provider.ErrNotFound)
+
+	_, err = store.Get(t.Context(), "other/LOG_LEVEL", ref)
+	require.ErrorIs(t, err, provider.ErrNotFound)
+
+	_, err = store.Get(t.Context(), "app/logo.png", ref)
+	require.ErrorIs(t, err, provider.ErrBinaryValue)
+}
+
+func TestStore_ResolveAndHistory(t *testing.T) {
+	t.Parallel()
+
+	store, _ := configMapStore(t)
+
+	_, err := store.Resolve(t.Context(), "app/KEY", "#3")
+	require.ErrorIs(t, err, kubernetesversion.ErrVersioningUnsupported)
+
+	_, err = store.Resolve(t.Context(), "app", "")
+	require.ErrorIs(t, err, kubernetesversion.ErrInvalidName)
+
+	_, err = store.History(t.Context(), "app/KEY")
+	require.ErrorIs(t, err, kubernetesversion.ErrVersioningUnsupported)
+}
+
+func TestStore_SecretGetAndList(t *testing.T) {
+	t.Parallel()
+
+	store, _ := secretStore(t,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: ns},
+			Data:       map[string][]byte{"password": []byte("s3cr3t"), "cert": {0xff, 0xfe}},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: ns},
+			Data:       map[string][]byte{"token": []byte("t")},
+		},
+	)
+
+	entry, err := store.Get(t.Context(), "db/password", provider.VersionRef{})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", entry.Value)
+	assert.Equal(t, domain.ValueTypeSecret, entry.Type)
+
+	_, err = store.Get(t.Context(), "db/cert", provider.VersionRef{})
+	require.ErrorIs(t, err, provider.ErrBinaryValue)
+
+	names, err := store.List(t.Context())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"db/password", "db/cert", "api/token"}, names)
+}
+
+func TestStore_CreateAndPut(t *testing.T) {
+	t.Parallel()
+
+	store, cs := secretStore(t)
+
+	_, err := store.Create(t.Context(), "db/user", "admin", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	s, err := cs.CoreV1().Secrets(ns).Get(t.Context(), "db", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.SecretTypeOpaque, s.Type)
+	assert.Equal(t, []byte("admin"), s.Data["user"])
+
+	_, err = store.Create(t.Context(), "db/password", "pw", domain.ValueTypeSecret, "")
+	require.NoError(t, err, "a new key is added to the existing object")
+
+	_, err = store.Create(t.Context(), "db/user", "other", domain.ValueTypeSecret, "")
+	require.ErrorIs(t, err, provider.ErrAlreadyExists)
+
+	_, err = store.Put(t.Context(), "db/user", "root", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	s, err = cs.CoreV1().Secrets(ns).Get(t.Context(), "db", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"user": []byte("root"), "password": []byte("pw")}, s.Data)
+}
+
+func TestStore_PutPreservesBinaryAndMetadata(t *testing.T) {
+	t.Parallel()
+
+	store, cs := secretStore(t, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: ns, Annotations: map[string]string{"owner": "ops"}},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{"tls.key": {0xff}, "note": []byte("a")},
+	})
+
+	_, err := store.Put(t.Context(), "tls/note", "b", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	s, err := cs.CoreV1().Secrets(ns).Get(t.Context(), "tls", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.SecretTypeTLS, s.Type)
+	assert.Equal(t, map[string]string{"owner": "ops"}, s.Annotations)
+	assert.Equal(t, map[string][]byte{"tls.key": {0xff}, "note": []byte("b")}, s.Data)
+
+	_, err = store.Put(t.Context(), "tls/tls.key", "text", domain.ValueTypeSecret, "")
+	require.ErrorIs(t, err, provider.ErrBinaryValue)
+}
+
+func TestStore_Delete(t *testing.T) {
+	t.Parallel()
+
+	store, cs := configMapStore(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: ns, Labels: map[string]string{"team": "web"}},
+		Data:       map[string]string{"A": "1", "B": "2"},
+	})
+
+	require.NoError(t, store.Delete(t.Context(), "app/A"))
+
+	cm, err := cs.CoreV1().ConfigMaps(ns).Get(t.Context(), "app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"B": "2"}, cm.Data)
+
+	require.ErrorIs(t, store.Delete(t.Context(), "app/A"), provider.ErrNotFound)
+
+	require.NoError(t, store.Delete(t.Context(), "app/B"))
+
+	cm, err = cs.CoreV1().ConfigMaps(ns).Get(t.Context(), "app", metav1.GetOptions{})
+	require.NoError(t, err, "removing the last key keeps the object")
+	assert.Empty(t, cm.Data)
+	assert.Equal(t, map[string]string{"team": "web"}, cm.Labels)
+
+	require.ErrorIs(t, store.Delete(t.Context(), "app/B"), provider.ErrNotFound)
+}
+
+func TestStore_Delete_EmptyObject(t *testing.T) {
+	t.Parallel()
+
+	store, cs := configMapStore(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: ns},
+		Data:       map[string]string{"A": "1", "B": "2"},
+	})
+
+	require.NoError(t, store.Delete(t.Context(), "app/A", objects.DeleteEmptyObject{}))
+
+	_, err := cs.CoreV1().ConfigMaps(ns).Get(t.Context(), "app", metav1.GetOptions{})
+	require.NoError(t, err, "an object with keys left is kept")
+
+	require.NoError(t, store.Delete(t.Context(), "app/B", objects.DeleteEmptyObject{}))
+
+	_, err = cs.CoreV1().ConfigMaps(ns).Get(t.Context(), "app", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "removing the last key deletes the object")
+}
+
+func TestStore_TagUntag(t *testing.T) {
+	t.Parallel()
+
+	store, cs := configMapStore(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: ns, Labels: map[string]string{"team": "web"}},
+		Data:       map[string]string{"A": "1"},
+	})
+
+	require.NoError(t, store.Tag(t.Context(), "app/A", map[string]string{"env": "prod"}))
+	require.NoError(t, store.Untag(t.Context(), "app/A", []string{"team", "absent"}))
+
+	cm, err := cs.CoreV1().ConfigMaps(ns).Get(t.Context(), "app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod"}, cm.Labels)
+	assert.Equal(t, map[string]string{"A": "1"}, cm.Data)
+
+	require.ErrorIs(t, store.Tag(t.Context(), "app/B", map[string]string{"x": "y"}), provider.ErrNotFound)
+	require.ErrorIs(t, store.Tag(t.Context(), "none/A", map[string]string{"x": "y"}), provider.ErrNotFound)
+}
+
+func TestStore_RetriesConflict(t *testing.T) {
+	t.Parallel()
+
+	store, cs := configMapStore(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: ns},
+		Data:       map[string]string{"A": "1"},
+	})
+
+	var updates int
+
+	cs.PrependReactor("update", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		updates++
+		if updates == 1 {
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "app", nil)
+		}
+
+		return false, nil, nil
+	})
+
+	_, err := store.Put(t.Context(), "app/A", "2", domain.ValueTypePlaintext, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, updates)
+
+	entry, err := store.Get(t.Context(), "app/A", provider.VersionRef{})
+	require.NoError(t, err)
+	assert.Equal(t, "2", entry.Value)
+}
+
+func TestStore_PermissionDenied(t *testing.T) {
+	t.Parallel()
+
+	store, cs := secretStore(t)
+	cs.PrependReactor("get", "secrets", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "db", nil)
+	})
+
+	_, err := store.Get(t.Context(), "db/password", provider.VersionRef{})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, provider.ErrNotFound)
+	assert.Contains(t, err.Error(), "forbidden")
+}
+
+func TestStore_Throttled(t *testing.T) {
+	t.Parallel()
+
+	store, cs := configMapStore(t)
+	cs.PrependReactor("*", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewTooManyRequests("slow down", 1)
+	})
+
+	_, err := store.Put(t.Context(), "app/A", "1", domain.ValueTypeSecret, "")
+	require.ErrorIs(t, err, provider.ErrThrottled)
+	assert.True(t, apierrors.IsTooManyRequests(err))
+
+	_, err = store.List(t.Context())
+	require.ErrorIs(t, err, provider.ErrThrottled)
+}
@@ -0,0 +1,7 @@
+package jsonstore
+
+import "time"
+
+// SetNow replaces the clock of a SecretStore, so tests can step past a
+// recovery window.
+func (s *SecretStore) SetNow(now func() time.Time) { s.now = now }
@@ -0,0 +1,110 @@
+package jsonstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gofrs/flock"
+)
+
+// fileMu serializes read-modify-write cycles within the process; the flock in
+// update extends that across processes (two CLI invocations, or CLI and TUI).
+//
+//nolint:gochecknoglobals // process-wide mutex shared by every store
+var fileMu sync.Mutex
+
+// document is one JSON file holding a whole store, read and rewritten whole.
+type document[T any] struct {
+	path string
+}
+
+// read decodes the file. A missing file is an empty store, not an error.
+func (d document[T]) read() (T, error) {
+	var v T
+
+	// The path is derived from the user's home directory; reading it is intentional.
+	data, err := os.ReadFile(d.path) //nolint:gosec // user-owned local store file by design
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return v, nil
+		}
+
+		return v, fmt.Errorf("failed to read local store: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return v, fmt.Errorf("failed to parse local store %s: %w", d.path, err)
+	}
+
+	return v, nil
+}
+
+// update runs fn over the decoded file under an exclusive lock and writes the
+// result back atomically. When fn returns an error nothing is written.
+func (d document[T]) update(fn func(*T) error) error {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	dir := filepath.Dir(d.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil { //nolint:mnd // owner-only directory
+		return fmt.Errorf("failed to create local store directory: %w", err)
+	}
+
+	fl := flock.New(d.path + ".lock")
+	if err := fl.Lock(); err != nil {
+		return fmt.Errorf("failed to lock local store: %w", err)
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	v, err := d.read()
+	if err != nil {
+		return err
+	}
+
+	if err := fn(&v); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode local store: %w", err)
+	}
+
+	return writeFileAtomic(d.path, append(data, '\n'))
+}
+
+// writeFileAtomic writes data to a temp file in the same directory and renames
+// it over path, so a crash never leaves a truncated store behind. The file is
+// owner-only (0600), matching os.CreateTemp's default.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp local store: %w", err)
+	}
+
+	tmpName := tmp.Name()
+
+	// Best-effort cleanup of the temp file if we bail out before renaming.
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("failed to write local store: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close local store: %w", err)
+	}
+
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("failed to replace local store: %w", err)
+	}
+
+	return nil
+}
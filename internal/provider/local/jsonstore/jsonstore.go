@@ -0,0 +1,73 @@
+// Package jsonstore implements the provider.Store contract over plain JSON
+// files, for the offline local provider. Each store is one file rewritten
+// whole on every write, and it models the AWS service it stands in for, so the
+// AWS commands, version specs and staging strategies work on it unchanged:
+//
+//   - ParamStore mirrors SSM Parameter Store: versions are integers counting up
+//     from 1 (#N, ~SHIFT), each version keeps its own value type, and a delete
+//     is immediate.
+//   - SecretStore mirrors Secrets Manager: versions are UUIDs moved between the
+//     AWSCURRENT / AWSPREVIOUS staging labels (:LABEL), and a delete is SOFT —
+//     the secret is hidden until its recovery window ends and can be restored
+//     until then (ForceDelete removes it at once).
+//
+// Both keep tags and a description per entry and retain the newest 100
+// versions. Values are stored in PLAIN TEXT (the files are owner-only): the
+// local provider is for learning and demos, not for real secrets.
+package jsonstore
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/mpyw/suve/internal/domain"
+)
+
+// maxVersions is how many versions an entry retains, as both AWS services do.
+const maxVersions = 100
+
+// ErrScheduledForDeletion is returned for an operation other than Describe,
+// Restore and Delete on a soft-deleted secret.
+var ErrScheduledForDeletion = errors.New("secret is scheduled for deletion; restore it first")
+
+// domainTags maps a tag map to domain tags, sorted by key for stable output.
+func domainTags(tags map[string]string) []domain.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	keys := slices.Sorted(maps.Keys(tags))
+	out := make([]domain.Tag, 0, len(keys))
+
+	for _, k := range keys {
+		out = append(out, domain.Tag{Key: k, Value: tags[k]})
+	}
+
+	return out
+}
+
+// setTags merges add into *tags, allocating the map on first use.
+func setTags(tags *map[string]string, add map[string]string) {
+	if len(add) == 0 {
+		return
+	}
+
+	if *tags == nil {
+		*tags = make(map[string]string, len(add))
+	}
+
+	maps.Copy(*tags, add)
+}
+
+// removeTags deletes keys from tags, dropping the map once it is empty so the
+// file stays tidy.
+func removeTags(tags *map[string]string, keys []string) {
+	for _, k := range keys {
+		delete(*tags, k)
+	}
+
+	if len(*tags) == 0 {
+		*tags = nil
+	}
+}
@@ -0,0 +1,307 @@
+package jsonstore
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/version/awsparamversion"
+)
+
+// paramFile is the on-disk shape of param.json.
+type paramFile struct {
+	Parameters map[string]*paramRecord `json:"parameters"`
+}
+
+// paramRecord is one parameter. Versions are oldest first.
+type paramRecord struct {
+	Description string            `json:"description,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Versions    []paramVersion    `json:"versions"`
+}
+
+// paramVersion is one stored value of a parameter.
+type paramVersion struct {
+	Version  int64            `json:"version"`
+	Value    string           `json:"value"`
+	Type     domain.ValueType `json:"type"`
+	Modified time.Time        `json:"modified"`
+	Message  string           `json:"message,omitempty"`
+}
+
+// latest returns the newest version.
+func (r *paramRecord) latest() paramVersion {
+	return r.Versions[len(r.Versions)-1]
+}
+
+// ParamStore is the Parameter Store-shaped provider.Store over param.json.
+type ParamStore struct {
+	file document[paramFile]
+	now  func() time.Time
+}
+
+// Compile-time assertion that ParamStore implements provider.Store.
+var _ provider.Store = (*ParamStore)(nil)
+
+// NewParamStore builds a ParamStore over the JSON file at path, which is
+// created on the first write.
+func NewParamStore(path string) *ParamStore {
+	return &ParamStore{file: document[paramFile]{path: path}, now: time.Now}
+}
+
+// Resolve parses an SSM-style spec (#N, ~SHIFT) and resolves it to a concrete
+// version number. An empty spec resolves to the latest ref.
+func (s *ParamStore) Resolve(_ context.Context, name, spec string) (provider.VersionRef, error) {
+	parsed, err := awsparamversion.Parse(name + spec)
+	if err != nil {
+		return provider.VersionRef{}, err
+	}
+
+	if !parsed.HasShift() {
+		if parsed.Absolute.Version != nil {
+			return provider.NewVersionRef(strconv.FormatInt(*parsed.Absolute.Version, 10)), nil
+		}
+
+		return provider.NewVersionRef(""), nil
+	}
+
+	rec, err := s.record(name)
+	if err != nil {
+		return provider.VersionRef{}, err
+	}
+
+	versions := slices.Clone(rec.Versions)
+	slices.Reverse(versions) // newest first
+
+	baseIdx := 0
+
+	if parsed.Absolute.Version != nil {
+		var found bool
+
+		_, baseIdx, found = lo.FindIndexOf(versions, func(v paramVersion) bool {
+			return v.Version == *parsed.Absolute.Version
+		})
+		if !found {
+			return provider.VersionRef{}, fmt.Errorf("version %d not found", *parsed.Absolute.Version)
+		}
+	}
+
+	targetIdx := baseIdx + parsed.Shift
+	if targetIdx < 0 || targetIdx >= len(versions) {
+		return provider.VersionRef{}, fmt.Errorf("version shift out of range: ~%d", parsed.Shift)
+	}
+
+	return provider.NewVersionRef(strconv.FormatInt(versions[targetIdx].Version, 10)), nil
+}
+
+// Get returns the parameter at ref (latest when ref is latest).
+func (s *ParamStore) Get(_ context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+	rec, err := s.record(name)
+	if err != nil {
+		return nil, err
+	}
+
+	v := rec.latest()
+
+	if !ref.IsLatest() {
+		var found bool
+
+		v, found = lo.Find(rec.Versions, func(pv paramVersion) bool {
+			return strconv.FormatInt(pv.Version, 10) == ref.ID()
+		})
+		if !found {
+			return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+		}
+	}
+
+	return &domain.Entry{
+		Name:  name,
+		Value: v.Value,
+		Type:  v.Type,
+		Version: domain.Version{
+			ID:      strconv.FormatInt(v.Version, 10),
+			Created: lo.ToPtr(v.Modified),
+			Message: v.Message,
+		},
+		Description: rec.Description,
+		Tags:        domainTags(rec.Tags),
+		Modified:    lo.ToPtr(v.Modified),
+	}, nil
+}
+
+// History returns the retained versions, newest first.
+func (s *ParamStore) History(_ context.Context, name string) ([]domain.Version, error) {
+	rec, err := s.record(name)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := lo.Map(rec.Versions, func(v paramVersion, _ int) domain.Version {
+		return domain.Version{ID: strconv.FormatInt(v.Version, 10), Created: lo.ToPtr(v.Modified), Message: v.Message}
+	})
+
+	slices.Reverse(versions)
+
+	return versions, nil
+}
+
+// List returns every parameter name in sorted order.
+func (s *ParamStore) List(_ context.Context) ([]string, error) {
+	f, err := s.file.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Sorted(maps.Keys(f.Parameters)), nil
+}
+
+// Create creates a parameter at version 1. It returns a wrapped
+// provider.ErrAlreadyExists if the parameter exists. A provider.ChangeMessage
+// is kept with the version.
+func (s *ParamStore) Create(
+	_ context.Context, name, value string, valueType domain.ValueType, description string, opts ...provider.WriteOption,
+) (domain.Version, error) {
+	var version domain.Version
+
+	err := s.file.update(func(f *paramFile) error {
+		if _, ok := f.Parameters[name]; ok {
+			return fmt.Errorf("%w: %s", provider.ErrAlreadyExists, name)
+		}
+
+		if f.Parameters == nil {
+			f.Parameters = map[string]*paramRecord{}
+		}
+
+		rec := &paramRecord{Description: description}
+		version = s.appendVersion(rec, value, valueType, provider.ChangeMessageOf(opts))
+		f.Parameters[name] = rec
+
+		return nil
+	})
+
+	return version, err
+}
+
+// Put creates the parameter or writes a new version of it. An empty
+// description leaves the existing one in place, as PutParameter does. A
+// provider.ChangeMessage is kept with the version.
+func (s *ParamStore) Put(
+	_ context.Context, name, value string, valueType domain.ValueType, description string, opts ...provider.WriteOption,
+) (domain.Version, error) {
+	var version domain.Version
+
+	err := s.file.update(func(f *paramFile) error {
+		if f.Parameters == nil {
+			f.Parameters = map[string]*paramRecord{}
+		}
+
+		rec, ok := f.Parameters[name]
+		if !ok {
+			rec = &paramRecord{}
+			f.Parameters[name] = rec
+		}
+
+		if description != "" {
+			rec.Description = description
+		}
+
+		version = s.appendVersion(rec, value, valueType, provider.ChangeMessageOf(opts))
+
+		return nil
+	})
+
+	return version, err
+}
+
+// Delete removes the parameter and its history at once; Parameter Store has
+// no recovery window. A missing parameter is a wrapped provider.ErrNotFound.
+func (s *ParamStore) Delete(_ context.Context, name string, _ ...provider.DeleteOption) error {
+	return s.file.update(func(f *paramFile) error {
+		if _, ok := f.Parameters[name]; !ok {
+			return fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+		}
+
+		delete(f.Parameters, name)
+
+		return nil
+	})
+}
+
+// Tag adds or updates tags on a parameter.
+func (s *ParamStore) Tag(_ context.Context, name string, add map[string]string) error {
+	if len(add) == 0 {
+		return nil
+	}
+
+	return s.modify(name, func(rec *paramRecord) { setTags(&rec.Tags, add) })
+}
+
+// Untag removes tags (by key) from a parameter.
+func (s *ParamStore) Untag(_ context.Context, name string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return s.modify(name, func(rec *paramRecord) { removeTags(&rec.Tags, keys) })
+}
+
+// record reads one parameter, or a wrapped provider.ErrNotFound.
+func (s *ParamStore) record(name string) (*paramRecord, error) {
+	f, err := s.file.read()
+	if err != nil {
+		return nil, err
+	}
+
+	rec, ok := f.Parameters[name]
+	if !ok || len(rec.Versions) == 0 {
+		return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+	}
+
+	return rec, nil
+}
+
+// modify applies fn to an existing parameter and saves the file.
+func (s *ParamStore) modify(name string, fn func(*paramRecord)) error {
+	return s.file.update(func(f *paramFile) error {
+		rec, ok := f.Parameters[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+		}
+
+		fn(rec)
+
+		return nil
+	})
+}
+
+// appendVersion adds the next version to rec, written with message, dropping
+// the oldest beyond maxVersions. An unset value type is plaintext (an SSM
+// String).
+func (s *ParamStore) appendVersion(rec *paramRecord, value string, valueType domain.ValueType, message string) domain.Version {
+	next := int64(1)
+	if len(rec.Versions) > 0 {
+		next = rec.latest().Version + 1
+	}
+
+	v := paramVersion{
+		Version:  next,
+		Value:    value,
+		Type:     lo.CoalesceOrEmpty(valueType, domain.ValueTypePlaintext),
+		Modified: s.now().UTC(),
+		Message:  message,
+	}
+
+	rec.Versions = append(rec.Versions, v)
+	if len(rec.Versions) > maxVersions {
+		rec.Versions = rec.Versions[len(rec.Versions)-maxVersions:]
+	}
+
+	return domain.Version{ID: strconv.FormatInt(next, 10), Created: lo.ToPtr(v.Modified)}
+}
@@ -0,0 +1,185 @@
+package jsonstore_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/local/jsonstore"
+)
+
+func newParamStore(t *testing.T) (*jsonstore.ParamStore, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "local", "param.json")
+
+	return jsonstore.NewParamStore(path), path
+}
+
+func TestParamStore_Versions(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store, path := newParamStore(t)
+
+	v, err := store.Create(ctx, "/app/url", "v1", "", "the url")
+	require.NoError(t, err)
+	assert.Equal(t, "1", v.ID)
+
+	_, err = store.Create(ctx, "/app/url", "again", "", "")
+	require.ErrorIs(t, err, provider.ErrAlreadyExists)
+
+	v, err = store.Put(ctx, "/app/url", "v2", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+	assert.Equal(t, "2", v.ID)
+
+	entry, err := store.Get(ctx, "/app/url", provider.NewVersionRef(""))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", entry.Value)
+	assert.Equal(t, domain.ValueTypeSecret, entry.Type)
+	assert.Equal(t, "the url", entry.Description, "an empty description keeps the old one")
+
+	old, err := store.Get(ctx, "/app/url", provider.NewVersionRef("1"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1", old.Value)
+	assert.Equal(t, domain.ValueTypePlaintext, old.Type)
+
+	history, err := store.History(ctx, "/app/url")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "2", history[0].ID)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestParamStore_ChangeMessage(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store, _ := newParamStore(t)
+
+	_, err := store.Create(ctx, "/app/url", "v1", "", "", provider.ChangeMessage{Message: "initial import"})
+	require.NoError(t, err)
+
+	_, err = store.Put(ctx, "/app/url", "v2", "", "")
+	require.NoError(t, err)
+
+	entry, err := store.Get(ctx, "/app/url", provider.NewVersionRef("1"))
+	require.NoError(t, err)
+	assert.Equal(t, "initial import", entry.Version.Message)
+
+	history, err := store.History(ctx, "/app/url")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Empty(t, history[0].Message, "each version keeps its own message")
+	assert.Equal(t, "initial import", history[1].Message)
+}
+
+func TestParamStore_Resolve(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store, _ := newParamStore(t)
+
+	for _, value := range []string{"a", "b", "c"} {
+		_, err := store.Put(ctx, "/p", value, "", "")
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr string
+	}{
+		{spec: "", want: ""},
+		{spec: "#2", want: "2"},
+		{spec: "~1", want: "2"},
+		{spec: "#2~1", want: "1"},
+		{spec: "~3", wantErr: "out of range"},
+		{spec: "#9~1", wantErr: "not found"},
+	}
+
+	for _, tt := range tests {
+		ref, err := store.Resolve(ctx, "/p", tt.spec)
+		if tt.wantErr != "" {
+			require.ErrorContains(t, err, tt.wantErr, tt.spec)
+
+			continue
+		}
+
+		require.NoError(t, err, tt.spec)
+		assert.Equal(t, tt.want, ref.ID(), tt.spec)
+	}
+}
+
+func TestParamStore_ListTagDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store, _ := newParamStore(t)
+
+	names, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, names, "a missing file is an empty store")
+
+	for _, name := range []string{"/b", "/a"} {
+		_, err := store.Put(ctx, name, "x", "", "")
+		require.NoError(t, err)
+	}
+
+	names, err = store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/a", "/b"}, names)
+
+	require.NoError(t, store.Tag(ctx, "/a", map[string]string{"team": "x", "env": "dev"}))
+	require.NoError(t, store.Untag(ctx, "/a", []string{"team"}))
+
+	entry, err := store.Get(ctx, "/a", provider.NewVersionRef(""))
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Tag{{Key: "env", Value: "dev"}}, entry.Tags)
+
+	require.ErrorIs(t, store.Tag(ctx, "/missing", map[string]string{"k": "v"}), provider.ErrNotFound)
+
+	require.NoError(t, store.Delete(ctx, "/a"))
+	require.ErrorIs(t, store.Delete(ctx, "/a"), provider.ErrNotFound)
+
+	_, err = store.Get(ctx, "/a", provider.NewVersionRef(""))
+	require.ErrorIs(t, err, provider.ErrNotFound)
+}
+
+func TestParamStore_RetainsNewestVersions(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store, _ := newParamStore(t)
+
+	for range 101 {
+		_, err := store.Put(ctx, "/p", "x", "", "")
+		require.NoError(t, err)
+	}
+
+	history, err := store.History(ctx, "/p")
+	require.NoError(t, err)
+	require.Len(t, history, 100)
+	assert.Equal(t, "101", history[0].ID)
+	assert.Equal(t, "2", history[99].ID)
+}
+
+func TestParamStore_CorruptFile(t *testing.T) {
+	t.Parallel()
+
+	store, path := newParamStore(t)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := store.List(t.Context())
+	require.ErrorContains(t, err, "failed to parse local store")
+}
@@ -0,0 +1,482 @@
+package jsonstore
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	awssecret "github.com/mpyw/suve/internal/provider/aws/secret"
+	"github.com/mpyw/suve/internal/version/awssecretversion"
+)
+
+// Staging labels, named as in Secrets Manager so :AWSCURRENT / :AWSPREVIOUS
+// specs resolve the same way.
+const (
+	labelCurrent  = "AWSCURRENT"
+	labelPrevious = "AWSPREVIOUS"
+)
+
+// defaultRecoveryDays is the recovery window of a delete without options,
+// matching the Secrets Manager default.
+const defaultRecoveryDays = 30
+
+// secretFile is the on-disk shape of secret.json.
+type secretFile struct {
+	Secrets map[string]*secretRecord `json:"secrets"`
+}
+
+// secretRecord is one secret. Versions are oldest first. A non-nil
+// DeletionDate marks the secret as soft-deleted until that time.
+type secretRecord struct {
+	Description  string            `json:"description,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+	DeletionDate *time.Time        `json:"deletionDate,omitempty"`
+	Versions     []secretVersion   `json:"versions"`
+}
+
+// secretVersion is one stored value of a secret and the labels on it.
+type secretVersion struct {
+	ID      string    `json:"id"`
+	Value   string    `json:"value"`
+	Created time.Time `json:"created"`
+	Labels  []string  `json:"labels,omitempty"`
+	Message string    `json:"message,omitempty"`
+}
+
+// current returns the AWSCURRENT version, falling back to the newest.
+func (r *secretRecord) current() secretVersion {
+	if v, ok := lo.Find(r.Versions, func(v secretVersion) bool {
+		return slices.Contains(v.Labels, labelCurrent)
+	}); ok {
+		return v
+	}
+
+	return r.Versions[len(r.Versions)-1]
+}
+
+// SecretStore is the Secrets Manager-shaped provider.Store over secret.json,
+// with soft delete (provider.Restorer) and metadata reads (provider.Describer).
+type SecretStore struct {
+	file document[secretFile]
+	now  func() time.Time
+}
+
+// Compile-time assertions that SecretStore implements the provider contracts.
+var (
+	_ provider.Store     = (*SecretStore)(nil)
+	_ provider.Restorer  = (*SecretStore)(nil)
+	_ provider.Describer = (*SecretStore)(nil)
+)
+
+// NewSecretStore builds a SecretStore over the JSON file at path, which is
+// created on the first write.
+func NewSecretStore(path string) *SecretStore {
+	return &SecretStore{file: document[secretFile]{path: path}, now: time.Now}
+}
+
+// Resolve parses a Secrets Manager-style spec (#ID, :LABEL, ~SHIFT) and
+// resolves it to a concrete version ID. A bare ~N counts back from
+// AWSCURRENT. An empty spec resolves to the latest ref.
+func (s *SecretStore) Resolve(_ context.Context, name, spec string) (provider.VersionRef, error) {
+	parsed, err := awssecretversion.Parse(name + spec)
+	if err != nil {
+		return provider.VersionRef{}, err
+	}
+
+	if !parsed.HasShift() {
+		switch {
+		case parsed.Absolute.ID != nil:
+			return provider.NewVersionRef(*parsed.Absolute.ID), nil
+		case parsed.Absolute.Label == nil:
+			return provider.NewVersionRef(""), nil
+		}
+	}
+
+	rec, err := s.live(name)
+	if err != nil {
+		return provider.VersionRef{}, err
+	}
+
+	versions := slices.Clone(rec.Versions)
+	slices.Reverse(versions) // newest first
+
+	var (
+		baseIdx int
+		found   bool
+	)
+
+	switch {
+	case parsed.Absolute.ID != nil:
+		_, baseIdx, found = lo.FindIndexOf(versions, func(v secretVersion) bool { return v.ID == *parsed.Absolute.ID })
+		if !found {
+			return provider.VersionRef{}, fmt.Errorf("version ID not found: %s", *parsed.Absolute.ID)
+		}
+	case parsed.Absolute.Label != nil:
+		_, baseIdx, found = lo.FindIndexOf(versions, func(v secretVersion) bool {
+			return slices.Contains(v.Labels, *parsed.Absolute.Label)
+		})
+		if !found {
+			return provider.VersionRef{}, fmt.Errorf("version label not found: %s", *parsed.Absolute.Label)
+		}
+	default:
+		_, baseIdx, _ = lo.FindIndexOf(versions, func(v secretVersion) bool { return slices.Contains(v.Labels, labelCurrent) })
+	}
+
+	targetIdx := baseIdx + parsed.Shift
+	if targetIdx < 0 || targetIdx >= len(versions) {
+		return provider.VersionRef{}, fmt.Errorf("version shift out of range: ~%d", parsed.Shift)
+	}
+
+	return provider.NewVersionRef(versions[targetIdx].ID), nil
+}
+
+// Get returns the secret at ref (AWSCURRENT when ref is latest). A
+// soft-deleted secret yields ErrScheduledForDeletion.
+func (s *SecretStore) Get(_ context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+	rec, err := s.live(name)
+	if err != nil {
+		return nil, err
+	}
+
+	v := rec.current()
+
+	if !ref.IsLatest() {
+		var found bool
+
+		v, found = lo.Find(rec.Versions, func(sv secretVersion) bool { return sv.ID == ref.ID() })
+		if !found {
+			return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+		}
+	}
+
+	return &domain.Entry{
+		Name:  name,
+		Value: v.Value,
+		Type:  domain.ValueTypeSecret,
+		Version: domain.Version{
+			ID:            v.ID,
+			StagingLabels: slices.Clone(v.Labels),
+			Created:       lo.ToPtr(v.Created),
+			Message:       v.Message,
+		},
+		Description: rec.Description,
+		Tags:        domainTags(rec.Tags),
+		Modified:    lo.ToPtr(v.Created),
+	}, nil
+}
+
+// History returns the retained versions with their labels, newest first.
+func (s *SecretStore) History(_ context.Context, name string) ([]domain.Version, error) {
+	rec, err := s.live(name)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := lo.Map(rec.Versions, func(v secretVersion, _ int) domain.Version {
+		return domain.Version{
+			ID: v.ID, StagingLabels: slices.Clone(v.Labels), Created: lo.ToPtr(v.Created), Message: v.Message,
+		}
+	})
+
+	slices.Reverse(versions)
+
+	return versions, nil
+}
+
+// List returns the names of the secrets not scheduled for deletion, sorted.
+func (s *SecretStore) List(_ context.Context) ([]string, error) {
+	f, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Sorted(func(yield func(string) bool) {
+		for name, rec := range f.Secrets {
+			if rec.DeletionDate == nil && !yield(name) {
+				return
+			}
+		}
+	}), nil
+}
+
+// Create creates a secret whose first version carries AWSCURRENT. It returns
+// a wrapped provider.ErrAlreadyExists if the secret exists, and
+// ErrScheduledForDeletion if a secret of that name awaits deletion. A
+// provider.ChangeMessage is kept with the version.
+func (s *SecretStore) Create(
+	_ context.Context, name, value string, _ domain.ValueType, description string, opts ...provider.WriteOption,
+) (domain.Version, error) {
+	var version domain.Version
+
+	err := s.update(func(f *secretFile) error {
+		if rec, ok := f.Secrets[name]; ok {
+			if rec.DeletionDate != nil {
+				return fmt.Errorf("%w: %s", ErrScheduledForDeletion, name)
+			}
+
+			return fmt.Errorf("%w: %s", provider.ErrAlreadyExists, name)
+		}
+
+		rec := &secretRecord{Description: description}
+		version = s.appendVersion(rec, value, provider.ChangeMessageOf(opts))
+		f.Secrets[name] = rec
+
+		return nil
+	})
+
+	return version, err
+}
+
+// Put creates the secret or writes a new AWSCURRENT version of it, moving
+// AWSPREVIOUS to the version it replaces. An empty description leaves the
+// existing one in place. A provider.ChangeMessage is kept with the version.
+func (s *SecretStore) Put(
+	_ context.Context, name, value string, _ domain.ValueType, description string, opts ...provider.WriteOption,
+) (domain.Version, error) {
+	var version domain.Version
+
+	err := s.update(func(f *secretFile) error {
+		rec, ok := f.Secrets[name]
+
+		switch {
+		case !ok:
+			rec = &secretRecord{}
+			f.Secrets[name] = rec
+		case rec.DeletionDate != nil:
+			return fmt.Errorf("%w: %s", ErrScheduledForDeletion, name)
+		}
+
+		if description != "" {
+			rec.Description = description
+		}
+
+		version = s.appendVersion(rec, value, provider.ChangeMessageOf(opts))
+
+		return nil
+	})
+
+	return version, err
+}
+
+// Delete schedules the secret for deletion after a recovery window (30 days,
+// or awssecret.RecoveryWindow), during which Restore brings it back.
+// provider.ForceDelete removes it at once, even when already scheduled.
+func (s *SecretStore) Delete(_ context.Context, name string, opts ...provider.DeleteOption) error {
+	force := false
+	days := int64(defaultRecoveryDays)
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case provider.ForceDelete:
+			force = true
+		case awssecret.RecoveryWindow:
+			if o.Days > 0 {
+				days = o.Days
+			}
+		}
+	}
+
+	return s.update(func(f *secretFile) error {
+		rec, ok := f.Secrets[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+		}
+
+		if force {
+			delete(f.Secrets, name)
+
+			return nil
+		}
+
+		if rec.DeletionDate != nil {
+			return fmt.Errorf("%w: %s", ErrScheduledForDeletion, name)
+		}
+
+		rec.DeletionDate = lo.ToPtr(s.now().UTC().AddDate(0, 0, int(days)))
+
+		return nil
+	})
+}
+
+// Restore cancels a pending deletion. Restoring a secret that is not
+// scheduled for deletion is a no-op, as in Secrets Manager.
+func (s *SecretStore) Restore(_ context.Context, name string) error {
+	return s.update(func(f *secretFile) error {
+		rec, ok := f.Secrets[name]
+		if !ok {
+			return fmt.Errorf("failed to restore secret: %w: %s", provider.ErrNotFound, name)
+		}
+
+		rec.DeletionDate = nil
+
+		return nil
+	})
+}
+
+// Describe returns the secret's metadata and AWSCURRENT version without the
+// value. Unlike Get it also answers for a soft-deleted secret, whose deletion
+// date is reported as an extra field.
+func (s *SecretStore) Describe(_ context.Context, name string) (*domain.Entry, error) {
+	f, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	rec, ok := f.Secrets[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+	}
+
+	cur := rec.current()
+
+	entry := &domain.Entry{
+		Name:        name,
+		Type:        domain.ValueTypeSecret,
+		Description: rec.Description,
+		Tags:        domainTags(rec.Tags),
+		Modified:    lo.ToPtr(cur.Created),
+		Version: domain.Version{
+			ID:            cur.ID,
+			StagingLabels: slices.Clone(cur.Labels),
+			Created:       lo.ToPtr(cur.Created),
+		},
+	}
+
+	if rec.DeletionDate != nil {
+		entry.Extra = []domain.Field{{Label: "Deletion Date", Value: rec.DeletionDate.Format(time.RFC3339)}}
+	}
+
+	return entry, nil
+}
+
+// Tag adds or updates tags on a secret.
+func (s *SecretStore) Tag(_ context.Context, name string, add map[string]string) error {
+	if len(add) == 0 {
+		return nil
+	}
+
+	return s.modify(name, func(rec *secretRecord) { setTags(&rec.Tags, add) })
+}
+
+// Untag removes tags (by key) from a secret.
+func (s *SecretStore) Untag(_ context.Context, name string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return s.modify(name, func(rec *secretRecord) { removeTags(&rec.Tags, keys) })
+}
+
+// read decodes the file and drops the secrets whose recovery window is over.
+func (s *SecretStore) read() (secretFile, error) {
+	f, err := s.file.read()
+	if err != nil {
+		return f, err
+	}
+
+	s.purge(&f)
+
+	return f, nil
+}
+
+// update runs fn over the purged file and saves it.
+func (s *SecretStore) update(fn func(*secretFile) error) error {
+	return s.file.update(func(f *secretFile) error {
+		s.purge(f)
+
+		if f.Secrets == nil {
+			f.Secrets = map[string]*secretRecord{}
+		}
+
+		return fn(f)
+	})
+}
+
+// purge removes the secrets whose deletion date has passed.
+func (s *SecretStore) purge(f *secretFile) {
+	now := s.now()
+
+	maps.DeleteFunc(f.Secrets, func(_ string, rec *secretRecord) bool {
+		return rec.DeletionDate != nil && !now.Before(*rec.DeletionDate)
+	})
+}
+
+// live reads one secret that is not scheduled for deletion.
+func (s *SecretStore) live(name string) (*secretRecord, error) {
+	f, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	rec, ok := f.Secrets[name]
+
+	switch {
+	case !ok || len(rec.Versions) == 0:
+		return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+	case rec.DeletionDate != nil:
+		return nil, fmt.Errorf("%w: %s", ErrScheduledForDeletion, name)
+	default:
+		return rec, nil
+	}
+}
+
+// modify applies fn to a live secret and saves the file.
+func (s *SecretStore) modify(name string, fn func(*secretRecord)) error {
+	return s.update(func(f *secretFile) error {
+		rec, ok := f.Secrets[name]
+
+		switch {
+		case !ok:
+			return fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+		case rec.DeletionDate != nil:
+			return fmt.Errorf("%w: %s", ErrScheduledForDeletion, name)
+		}
+
+		fn(rec)
+
+		return nil
+	})
+}
+
+// appendVersion adds a new AWSCURRENT version to rec, written with message:
+// the old current version becomes AWSPREVIOUS and the old previous one loses
+// its label. Beyond maxVersions the oldest unlabeled versions are dropped.
+func (s *SecretStore) appendVersion(rec *secretRecord, value, message string) domain.Version {
+	for i := range rec.Versions {
+		labels := slices.DeleteFunc(rec.Versions[i].Labels, func(l string) bool { return l == labelPrevious })
+
+		if j := slices.Index(labels, labelCurrent); j >= 0 {
+			labels[j] = labelPrevious
+		}
+
+		rec.Versions[i].Labels = lo.Ternary(len(labels) == 0, nil, labels)
+	}
+
+	v := secretVersion{
+		ID:      uuid.NewString(),
+		Value:   value,
+		Created: s.now().UTC(),
+		Labels:  []string{labelCurrent},
+		Message: message,
+	}
+
+	rec.Versions = append(rec.Versions, v)
+
+	for excess := len(rec.Versions) - maxVersions; excess > 0; excess-- {
+		i := slices.IndexFunc(rec.Versions, func(v secretVersion) bool { return len(v.Labels) == 0 })
+		if i < 0 {
+			break
+		}
+
+		rec.Versions = slices.Delete(rec.Versions, i, i+1)
+	}
+
+	return domain.Version{ID: v.ID, StagingLabels: []string{labelCurrent}, Created: lo.ToPtr(v.Created)}
+}
@@ -0,0 +1,201 @@
+package jsonstore_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	awssecret "github.com/mpyw/suve/internal/provider/aws/secret"
+	"github.com/mpyw/suve/internal/provider/local/jsonstore"
+)
+
+func newSecretStore(t *testing.T) *jsonstore.SecretStore {
+	t.Helper()
+
+	return jsonstore.NewSecretStore(filepath.Join(t.TempDir(), "secret.json"))
+}
+
+func TestSecretStore_Labels(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store := newSecretStore(t)
+
+	first, err := store.Create(ctx, "db", "one", "", "db password")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AWSCURRENT"}, first.StagingLabels)
+
+	second, err := store.Put(ctx, "db", "two", "", "")
+	require.NoError(t, err)
+
+	third, err := store.Put(ctx, "db", "three", "", "")
+	require.NoError(t, err)
+
+	history, err := store.History(ctx, "db")
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, third.ID, history[0].ID)
+	assert.Equal(t, []string{"AWSCURRENT"}, history[0].StagingLabels)
+	assert.Equal(t, second.ID, history[1].ID)
+	assert.Equal(t, []string{"AWSPREVIOUS"}, history[1].StagingLabels)
+	assert.Empty(t, history[2].StagingLabels)
+
+	entry, err := store.Get(ctx, "db", provider.NewVersionRef(""))
+	require.NoError(t, err)
+	assert.Equal(t, "three", entry.Value)
+	assert.Equal(t, "db password", entry.Description)
+
+	old, err := store.Get(ctx, "db", provider.NewVersionRef(first.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "one", old.Value)
+
+	_, err = store.Get(ctx, "db", provider.NewVersionRef("no-such-id"))
+	require.ErrorIs(t, err, provider.ErrNotFound)
+}
+
+func TestSecretStore_Resolve(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store := newSecretStore(t)
+
+	first, err := store.Put(ctx, "s", "a", "", "")
+	require.NoError(t, err)
+
+	second, err := store.Put(ctx, "s", "b", "", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr string
+	}{
+		{spec: "", want: ""},
+		{spec: "#" + first.ID, want: first.ID},
+		{spec: ":AWSPREVIOUS", want: first.ID},
+		{spec: ":AWSCURRENT", want: second.ID},
+		{spec: "~1", want: first.ID},
+		{spec: "#" + second.ID + "~1", want: first.ID},
+		{spec: ":PENDING", wantErr: "label not found"},
+		{spec: "~2", wantErr: "out of range"},
+	}
+
+	for _, tt := range tests {
+		ref, err := store.Resolve(ctx, "s", tt.spec)
+		if tt.wantErr != "" {
+			require.ErrorContains(t, err, tt.wantErr, tt.spec)
+
+			continue
+		}
+
+		require.NoError(t, err, tt.spec)
+		assert.Equal(t, tt.want, ref.ID(), tt.spec)
+	}
+}
+
+func TestSecretStore_SoftDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store := newSecretStore(t)
+
+	_, err := store.Put(ctx, "s", "v", "", "")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Delete(ctx, "s", awssecret.RecoveryWindow{Days: 7}))
+
+	names, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, names, "a deleted secret is hidden")
+
+	_, err = store.Get(ctx, "s", provider.NewVersionRef(""))
+	require.ErrorIs(t, err, jsonstore.ErrScheduledForDeletion)
+
+	_, err = store.Put(ctx, "s", "w", "", "")
+	require.ErrorIs(t, err, jsonstore.ErrScheduledForDeletion)
+
+	_, err = store.Create(ctx, "s", "w", "", "")
+	require.ErrorIs(t, err, jsonstore.ErrScheduledForDeletion)
+
+	require.ErrorIs(t, store.Delete(ctx, "s"), jsonstore.ErrScheduledForDeletion)
+
+	described, err := store.Describe(ctx, "s")
+	require.NoError(t, err)
+	require.Len(t, described.Extra, 1)
+	assert.Equal(t, "Deletion Date", described.Extra[0].Label)
+
+	require.NoError(t, store.Restore(ctx, "s"))
+	require.NoError(t, store.Restore(ctx, "s"), "restoring a live secret is a no-op")
+
+	entry, err := store.Get(ctx, "s", provider.NewVersionRef(""))
+	require.NoError(t, err)
+	assert.Equal(t, "v", entry.Value)
+
+	require.ErrorIs(t, store.Restore(ctx, "missing"), provider.ErrNotFound)
+}
+
+func TestSecretStore_ForceDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store := newSecretStore(t)
+
+	_, err := store.Put(ctx, "s", "v", "", "")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Delete(ctx, "s"))
+	require.NoError(t, store.Delete(ctx, "s", provider.ForceDelete{}), "force applies to a scheduled secret")
+
+	_, err = store.Describe(ctx, "s")
+	require.ErrorIs(t, err, provider.ErrNotFound)
+
+	_, err = store.Create(ctx, "s", "fresh", "", "")
+	require.NoError(t, err, "the name is free again")
+}
+
+func TestSecretStore_PurgesExpiredDeletions(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store := newSecretStore(t)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.SetNow(func() time.Time { return now })
+
+	_, err := store.Put(ctx, "s", "v", "", "")
+	require.NoError(t, err)
+	require.NoError(t, store.Delete(ctx, "s"))
+
+	now = now.AddDate(0, 0, 29)
+
+	_, err = store.Describe(ctx, "s")
+	require.NoError(t, err, "still recoverable inside the default 30-day window")
+
+	now = now.AddDate(0, 0, 1)
+
+	_, err = store.Describe(ctx, "s")
+	require.ErrorIs(t, err, provider.ErrNotFound)
+}
+
+func TestSecretStore_Tags(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store := newSecretStore(t)
+
+	_, err := store.Put(ctx, "s", "v", "", "")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Tag(ctx, "s", map[string]string{"b": "2", "a": "1"}))
+	require.NoError(t, store.Untag(ctx, "s", []string{"b"}))
+
+	entry, err := store.Get(ctx, "s", provider.NewVersionRef(""))
+	require.NoError(t, err)
+	require.Len(t, entry.Tags, 1)
+	assert.Equal(t, "a", entry.Tags[0].Key)
+
+	require.ErrorIs(t, store.Untag(ctx, "missing", []string{"a"}), provider.ErrNotFound)
+}
@@ -0,0 +1,76 @@
+// Package local wires the offline JSON-file provider into a provider.Factory /
+// provider.Registry. It needs no account or credentials: parameters and
+// secrets live in ~/.suve/local/param.json and secret.json (or under
+// SUVE_LOCAL_DIR), modelled on AWS Parameter Store and Secrets Manager by the
+// jsonstore subpackage, so every AWS command and staging flow can be tried
+// against it safely.
+package local
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/local/jsonstore"
+)
+
+// DirEnvVar overrides the directory holding the local store files.
+const DirEnvVar = "SUVE_LOCAL_DIR"
+
+// File names of the two stores inside the local directory.
+const (
+	paramFileName  = "param.json"
+	secretFileName = "secret.json"
+)
+
+// Factory builds local provider.Store values for a kind. The scope carries no
+// fields; every local scope shares one directory.
+type Factory struct {
+	// Dir is the directory holding the store files. Empty means Dir().
+	Dir string
+}
+
+// Compile-time assertion that Factory implements provider.Factory.
+var _ provider.Factory = Factory{}
+
+// Store builds the ParamStore or SecretStore over the local directory.
+func (f Factory) Store(_ context.Context, _ provider.Scope, kind provider.Kind) (provider.Store, error) {
+	dir := f.Dir
+	if dir == "" {
+		var err error
+
+		if dir, err = Dir(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch kind {
+	case provider.KindParam:
+		return jsonstore.NewParamStore(filepath.Join(dir, paramFileName)), nil
+	case provider.KindSecret:
+		return jsonstore.NewSecretStore(filepath.Join(dir, secretFileName)), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", provider.ErrUnsupportedKind, kind)
+	}
+}
+
+// Register associates the local Factory with provider.ProviderLocal in reg.
+func Register(reg *provider.Registry) {
+	reg.Register(provider.ProviderLocal, Factory{})
+}
+
+// Dir returns the local store directory: SUVE_LOCAL_DIR, else ~/.suve/local.
+func Dir() (string, error) {
+	if dir := os.Getenv(DirEnvVar); dir != "" {
+		return dir, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate local store directory: %w", err)
+	}
+
+	return filepath.Join(home, ".suve", "local"), nil
+}
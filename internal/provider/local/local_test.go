@@ -0,0 +1,74 @@
+package local_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/local"
+)
+
+func TestDir(t *testing.T) {
+	// Cannot use t.Parallel() because subtests use t.Setenv
+	t.Run("env override", func(t *testing.T) {
+		t.Setenv(local.DirEnvVar, "/tmp/demo")
+
+		dir, err := local.Dir()
+		require.NoError(t, err)
+		assert.Equal(t, "/tmp/demo", dir)
+	})
+
+	t.Run("home default", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv(local.DirEnvVar, "")
+		t.Setenv("HOME", home)
+
+		dir, err := local.Dir()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(home, ".suve", "local"), dir)
+	})
+}
+
+func TestFactory_Store(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	factory := local.Factory{Dir: t.TempDir()}
+
+	params, err := factory.Store(ctx, provider.LocalScope(), provider.KindParam)
+	require.NoError(t, err)
+
+	_, err = params.Put(ctx, "/app/key", "value", "", "")
+	require.NoError(t, err)
+
+	secrets, err := factory.Store(ctx, provider.LocalScope(), provider.KindSecret)
+	require.NoError(t, err)
+
+	names, err := secrets.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, names, "params and secrets are separate files")
+
+	// A second factory over the same directory sees the same data.
+	again, err := local.Factory{Dir: factory.Dir}.Store(ctx, provider.LocalScope(), provider.KindParam)
+	require.NoError(t, err)
+
+	entry, err := again.Get(ctx, "/app/key", provider.NewVersionRef(""))
+	require.NoError(t, err)
+	assert.Equal(t, "value", entry.Value)
+
+	_, err = factory.Store(ctx, provider.LocalScope(), provider.Kind("bogus"))
+	require.ErrorIs(t, err, provider.ErrUnsupportedKind)
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	reg := provider.NewRegistry()
+	local.Register(reg)
+
+	_, err := reg.Store(t.Context(), provider.LocalScope(), provider.KindSecret)
+	require.NoError(t, err)
+}
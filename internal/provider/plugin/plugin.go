@@ -0,0 +1,296 @@
+// Package plugin connects external providers to suve. A plugin is an
+// executable named suve-provider-<name> on PATH; suve runs it and speaks
+// JSON-RPC 2.0 over its stdin and stdout, one JSON message per line.
+//
+// Each call starts the executable, writes one request, and reads one
+// response; stderr is shown only when the call fails. A plugin should answer
+// every request it reads and exit when stdin closes, which also lets it serve
+// several requests per process.
+//
+// The handshake method returns the protocol version and a
+// capability.ProviderCapability naming the services ("param" and/or "secret")
+// the plugin offers and the controls they support. The other methods mirror
+// provider.Reader, Writer, Tagger, Restorer and Describer for one service,
+// selected by the "kind" parameter. Version specs are handed to resolve
+// verbatim (#VERSION, :LABEL, ~SHIFT), so the plugin defines what they mean;
+// the CLI parses them like AWS Parameter Store (param) and Secrets Manager
+// (secret). See Serve for a reference implementation over provider.Store
+// values.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/mpyw/suve/internal/capability"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// ExecutablePrefix prefixes a plugin's name to form its executable's name.
+const ExecutablePrefix = "suve-provider-"
+
+// validName matches the plugin names suve accepts: they become command names
+// and staging directory names, so they are kept to lower-case words.
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Plugin is a discovered plugin executable.
+type Plugin struct {
+	// Name is the plugin's name (the executable name minus the prefix).
+	Name string
+	// Path is the executable's path.
+	Path string
+}
+
+// ValidName reports whether name is usable as a plugin name.
+func ValidName(name string) bool {
+	return validName.MatchString(name)
+}
+
+// Discover lists the plugin executables in the directories of pathList (a
+// PATH-style list), sorted by name. When a name appears in several
+// directories the first one wins, as it would for the shell. Files that are
+// not executable or whose names are not valid plugin names are skipped.
+func Discover(pathList string) []Plugin {
+	seen := map[string]bool{}
+
+	var plugins []Plugin
+
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, e := range entries {
+			name, ok := pluginName(e.Name())
+			if !ok || seen[name] || !isExecutable(e) {
+				continue
+			}
+
+			seen[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: filepath.Join(dir, e.Name())})
+		}
+	}
+
+	slices.SortFunc(plugins, func(a, b Plugin) int { return strings.Compare(a.Name, b.Name) })
+
+	return plugins
+}
+
+// pluginName extracts the plugin name from an executable's file name.
+func pluginName(file string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		file = strings.TrimSuffix(strings.ToLower(file), ".exe")
+	}
+
+	name, ok := strings.CutPrefix(file, ExecutablePrefix)
+	if !ok || !ValidName(name) {
+		return "", false
+	}
+
+	return name, true
+}
+
+// isExecutable reports whether a directory entry is an executable file.
+func isExecutable(e os.DirEntry) bool {
+	info, err := e.Info()
+	if err != nil || info.IsDir() {
+		return false
+	}
+
+	return runtime.GOOS == "windows" || info.Mode()&0o111 != 0
+}
+
+// Client talks to one plugin executable.
+type Client struct {
+	// Name is the plugin's name, used in error messages.
+	Name string
+	// Path is the executable to run.
+	Path string
+}
+
+// NewClient finds the executable of the plugin called name on PATH.
+func NewClient(name string) (*Client, error) {
+	if !ValidName(name) {
+		return nil, fmt.Errorf("invalid plugin name %q", name)
+	}
+
+	path, err := exec.LookPath(ExecutablePrefix + name)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", name, err)
+	}
+
+	return &Client{Name: name, Path: path}, nil
+}
+
+// Info is what a plugin's handshake tells suve.
+type Info struct {
+	// Capability describes the plugin for the frontends. Its Provider is
+	// provider.ProviderPlugin and its DisplayName defaults to the plugin name.
+	Capability capability.ProviderCapability
+	// Kinds are the store kinds the plugin offers, param first.
+	Kinds []provider.Kind
+}
+
+// Handshake asks the plugin what it offers and checks that it speaks this
+// protocol version.
+func (c *Client) Handshake(ctx context.Context) (Info, error) {
+	var res HandshakeResult
+	if err := c.call(ctx, MethodHandshake, Params{ProtocolVersion: ProtocolVersion}, &res); err != nil {
+		return Info{}, err
+	}
+
+	if res.ProtocolVersion != ProtocolVersion {
+		return Info{}, fmt.Errorf(
+			"plugin %s speaks protocol version %d; suve needs version %d",
+			c.Name, res.ProtocolVersion, ProtocolVersion,
+		)
+	}
+
+	capab := res.Capability
+	capab.Provider = string(provider.ProviderPlugin)
+
+	if capab.DisplayName == "" {
+		capab.DisplayName = c.Name
+	}
+
+	if capab.ScopeFields == nil {
+		capab.ScopeFields = []string{}
+	}
+
+	var kinds []provider.Kind
+
+	for _, svc := range capab.Services {
+		kind := provider.Kind(svc.Service)
+		if kind != provider.KindParam && kind != provider.KindSecret {
+			return Info{}, fmt.Errorf("plugin %s offers unknown service %q", c.Name, svc.Service)
+		}
+
+		if slices.Contains(kinds, kind) {
+			return Info{}, fmt.Errorf("plugin %s offers service %q twice", c.Name, svc.Service)
+		}
+
+		kinds = append(kinds, kind)
+	}
+
+	if len(kinds) == 0 {
+		return Info{}, fmt.Errorf("plugin %s offers no service", c.Name)
+	}
+
+	slices.Sort(kinds) // "param" < "secret"
+
+	return Info{Capability: capab, Kinds: kinds}, nil
+}
+
+// call runs one request against the plugin and decodes the result into result
+// (which may be nil when the result carries nothing).
+func (c *Client) call(ctx context.Context, method string, params Params, result any) error {
+	req, err := json.Marshal(request{JSONRPC: jsonrpcVersion, ID: 1, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("plugin %s: failed to encode %s request: %w", c.Name, method, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	// The path is the user's own suve-provider-* executable; running it is the
+	// point of the plugin protocol.
+	cmd := exec.CommandContext(ctx, c.Path) //nolint:gosec // plugin executable found on the user's PATH by design
+	cmd.Stdin = bytes.NewReader(append(req, '\n'))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+
+	line, _, _ := bytes.Cut(stdout.Bytes(), []byte{'\n'})
+	if len(bytes.TrimSpace(line)) == 0 {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("plugin %s: %s: %s", c.Name, method, msg)
+		}
+
+		if runErr != nil {
+			return fmt.Errorf("plugin %s: %s: %w", c.Name, method, runErr)
+		}
+
+		return fmt.Errorf("plugin %s: %s: no response", c.Name, method)
+	}
+
+	var resp response
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return fmt.Errorf("plugin %s: %s: invalid response: %w", c.Name, method, err)
+	}
+
+	if resp.Error != nil {
+		return &Error{Plugin: c.Name, Code: resp.Error.Code, Message: resp.Error.Message}
+	}
+
+	if result == nil || len(resp.Result) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return fmt.Errorf("plugin %s: %s: invalid result: %w", c.Name, method, err)
+	}
+
+	return nil
+}
+
+// Handshake finds the plugin called name on PATH and runs its handshake.
+func Handshake(ctx context.Context, name string) (Info, error) {
+	client, err := NewClient(name)
+	if err != nil {
+		return Info{}, err
+	}
+
+	return client.Handshake(ctx)
+}
+
+// ResolveScope runs the handshake of the plugin called name and returns its
+// scope, carrying the kinds it offers.
+func ResolveScope(ctx context.Context, name string) (provider.Scope, error) {
+	info, err := Handshake(ctx, name)
+	if err != nil {
+		return provider.Scope{}, err
+	}
+
+	return provider.PluginScope(name, info.Kinds...), nil
+}
+
+// Factory builds plugin provider.Store values. The plugin is named by
+// Scope.Plugin and must offer the kind (Scope.PluginKinds, see ResolveScope).
+type Factory struct{}
+
+// Compile-time assertion that Factory implements provider.Factory.
+var _ provider.Factory = Factory{}
+
+// Store builds a Store over the scope's plugin for kind. It runs nothing: the
+// executable is only looked up on PATH.
+func (Factory) Store(_ context.Context, scope provider.Scope, kind provider.Kind) (provider.Store, error) {
+	if !scope.SupportsService(kind) {
+		return nil, fmt.Errorf("%w: plugin %s offers no %s", provider.ErrUnsupportedKind, scope.Plugin, kind)
+	}
+
+	client, err := NewClient(scope.Plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStore(client, kind), nil
+}
+
+// Register associates the plugin Factory with provider.ProviderPlugin in reg.
+func Register(reg *provider.Registry) {
+	reg.Register(provider.ProviderPlugin, Factory{})
+}
@@ -0,0 +1,306 @@
+package plugin_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/capability"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/local/jsonstore"
+	"github.com/mpyw/suve/internal/provider/plugin"
+)
+
+// helperDirEnv carries the store directory to the helper plugin process; its
+// presence is what turns TestHelperPlugin into a plugin.
+const helperDirEnv = "SUVE_PLUGIN_TEST_DIR"
+
+// helperModeEnv selects the helper's handshake: "" offers param and secret,
+// "none" offers no service, "old" speaks another protocol version.
+const helperModeEnv = "SUVE_PLUGIN_TEST_MODE"
+
+// TestHelperPlugin is not a test: run by the wrapper script installPlugin
+// writes, it serves the plugin protocol over jsonstore stores on stdio.
+func TestHelperPlugin(t *testing.T) {
+	dir := os.Getenv(helperDirEnv)
+	if dir == "" {
+		t.Skip("helper process for the plugin tests")
+	}
+
+	caps := capability.ProviderCapability{
+		DisplayName: "Demo",
+		Services: []capability.ServiceCapability{
+			{Service: "secret", DisplayName: "Secret", HasVersionHistory: true, HasStaging: true},
+			{Service: "param", DisplayName: "Param", HasVersionHistory: true, HasStaging: true},
+		},
+	}
+
+	switch os.Getenv(helperModeEnv) {
+	case "none":
+		caps.Services = nil
+	case "old":
+		_, _ = os.Stdout.WriteString(`{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":99}}` + "\n")
+		os.Exit(0)
+	case "crash":
+		_, _ = os.Stderr.WriteString("cannot reach the vault\n")
+		os.Exit(3)
+	}
+
+	backend := plugin.Backend{
+		Capability: caps,
+		Stores: map[provider.Kind]provider.Store{
+			provider.KindParam:  jsonstore.NewParamStore(filepath.Join(dir, "param.json")),
+			provider.KindSecret: jsonstore.NewSecretStore(filepath.Join(dir, "secret.json")),
+		},
+	}
+
+	if err := plugin.Serve(t.Context(), backend, os.Stdin, os.Stdout); err != nil {
+		_, _ = os.Stderr.WriteString(err.Error() + "\n")
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+// installPlugin puts a suve-provider-demo wrapper that runs TestHelperPlugin on
+// PATH, backed by a fresh store directory, in the given handshake mode.
+func installPlugin(t *testing.T, mode string) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the helper plugin is a shell script")
+	}
+
+	bin := t.TempDir()
+	script := "#!/bin/sh\nexec '" + os.Args[0] + "' -test.run='^TestHelperPlugin$'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(bin, plugin.ExecutablePrefix+"demo"), []byte(script), 0o755)) //nolint:gosec // test executable
+
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv(helperDirEnv, t.TempDir())
+	t.Setenv(helperModeEnv, mode)
+}
+
+func TestValidName(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]bool{
+		"acme":       true,
+		"acme-vault": true,
+		"v2":         true,
+		"":           false,
+		"-acme":      false,
+		"Acme":       false,
+		"acme_vault": false,
+		"../acme":    false,
+	} {
+		assert.Equal(t, want, plugin.ValidName(name), name)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("relies on the executable bit")
+	}
+
+	first, second := t.TempDir(), t.TempDir()
+
+	write := func(dir, name string, mode os.FileMode) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), mode))
+	}
+
+	write(first, "suve-provider-acme", 0o755)
+	write(first, "suve-provider-notexec", 0o644)
+	write(first, "suve-provider-Bad_Name", 0o755)
+	write(first, "other-tool", 0o755)
+	write(second, "suve-provider-acme", 0o755)
+	write(second, "suve-provider-beta", 0o755)
+	require.NoError(t, os.Mkdir(filepath.Join(second, "suve-provider-dir"), 0o755))
+
+	pathList := first + string(os.PathListSeparator) + filepath.Join(first, "missing") +
+		string(os.PathListSeparator) + second
+
+	assert.Equal(t, []plugin.Plugin{
+		{Name: "acme", Path: filepath.Join(first, "suve-provider-acme")},
+		{Name: "beta", Path: filepath.Join(second, "suve-provider-beta")},
+	}, plugin.Discover(pathList))
+}
+
+func TestHandshake(t *testing.T) {
+	// Cannot use t.Parallel() because subtests use t.Setenv
+	t.Run("both services", func(t *testing.T) {
+		installPlugin(t, "")
+
+		info, err := plugin.Handshake(t.Context(), "demo")
+		require.NoError(t, err)
+		assert.Equal(t, []provider.Kind{provider.KindParam, provider.KindSecret}, info.Kinds)
+		assert.Equal(t, string(provider.ProviderPlugin), info.Capability.Provider)
+		assert.Equal(t, "Demo", info.Capability.DisplayName)
+		assert.Equal(t, []string{}, info.Capability.ScopeFields)
+
+		scope, err := plugin.ResolveScope(t.Context(), "demo")
+		require.NoError(t, err)
+		assert.Equal(t, provider.PluginScope("demo", provider.KindParam, provider.KindSecret), scope)
+	})
+
+	t.Run("no service", func(t *testing.T) {
+		installPlugin(t, "none")
+
+		_, err := plugin.Handshake(t.Context(), "demo")
+		assert.EqualError(t, err, "plugin demo offers no service")
+	})
+
+	t.Run("protocol mismatch", func(t *testing.T) {
+		installPlugin(t, "old")
+
+		_, err := plugin.Handshake(t.Context(), "demo")
+		assert.ErrorContains(t, err, "protocol version 99")
+	})
+
+	t.Run("crash shows stderr", func(t *testing.T) {
+		installPlugin(t, "crash")
+
+		_, err := plugin.Handshake(t.Context(), "demo")
+		assert.EqualError(t, err, "plugin demo: handshake: cannot reach the vault")
+	})
+
+	t.Run("missing plugin", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+
+		_, err := plugin.Handshake(t.Context(), "demo")
+		assert.Error(t, err)
+	})
+}
+
+func TestStore(t *testing.T) {
+	// Cannot use t.Parallel() because subtests use t.Setenv
+	t.Run("secret round trip", func(t *testing.T) {
+		installPlugin(t, "")
+
+		ctx := t.Context()
+		store, err := plugin.Factory{}.Store(ctx, provider.PluginScope("demo", provider.KindSecret), provider.KindSecret)
+		require.NoError(t, err)
+
+		_, err = store.Create(ctx, "db", "pw1", domain.ValueTypeSecret, "database")
+		require.NoError(t, err)
+		_, err = store.Create(ctx, "db", "again", domain.ValueTypeSecret, "")
+		require.ErrorIs(t, err, provider.ErrAlreadyExists)
+
+		v2, err := store.Put(ctx, "db", "pw2", domain.ValueTypeSecret, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"AWSCURRENT"}, v2.StagingLabels)
+
+		ref, err := store.Resolve(ctx, "db", ":AWSPREVIOUS")
+		require.NoError(t, err)
+
+		prev, err := store.Get(ctx, "db", ref)
+		require.NoError(t, err)
+		assert.Equal(t, "pw1", prev.Value)
+
+		history, err := store.History(ctx, "db")
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, v2.ID, history[0].ID)
+
+		require.NoError(t, store.Tag(ctx, "db", map[string]string{"env": "dev", "team": "core"}))
+		require.NoError(t, store.Untag(ctx, "db", []string{"team"}))
+
+		desc, err := store.(provider.Describer).Describe(ctx, "db")
+		require.NoError(t, err)
+		assert.Equal(t, "database", desc.Description)
+		assert.Equal(t, []domain.Tag{{Key: "env", Value: "dev"}}, desc.Tags)
+
+		require.NoError(t, store.Delete(ctx, "db"))
+
+		names, err := store.List(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, names)
+
+		require.NoError(t, store.(provider.Restorer).Restore(ctx, "db"))
+
+		names, err = store.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"db"}, names)
+
+		require.NoError(t, store.Delete(ctx, "db", provider.ForceDelete{}))
+		_, err = store.Get(ctx, "db", provider.VersionRef{})
+		require.ErrorIs(t, err, provider.ErrNotFound)
+		assert.ErrorContains(t, err, "plugin demo: ")
+	})
+
+	t.Run("param versions", func(t *testing.T) {
+		installPlugin(t, "")
+
+		ctx := t.Context()
+		store, err := plugin.Factory{}.Store(ctx, provider.PluginScope("demo", provider.KindParam), provider.KindParam)
+		require.NoError(t, err)
+
+		_, err = store.Put(ctx, "/app/url", "http://a", domain.ValueTypePlaintext, "")
+		require.NoError(t, err)
+		_, err = store.Put(ctx, "/app/url", "http://b", domain.ValueTypePlaintext, "")
+		require.NoError(t, err)
+
+		ref, err := store.Resolve(ctx, "/app/url", "~1")
+		require.NoError(t, err)
+		assert.Equal(t, "1", ref.ID())
+
+		entry, err := store.Get(ctx, "/app/url", ref)
+		require.NoError(t, err)
+		assert.Equal(t, "http://a", entry.Value)
+		assert.Equal(t, domain.ValueTypePlaintext, entry.Type)
+
+		// The param store has no soft delete.
+		err = store.(provider.Restorer).Restore(ctx, "/app/url")
+		require.ErrorIs(t, err, plugin.ErrUnsupportedMethod)
+	})
+
+	t.Run("kind not offered", func(t *testing.T) {
+		_, err := plugin.Factory{}.Store(t.Context(), provider.PluginScope("demo", provider.KindSecret), provider.KindParam)
+		assert.ErrorIs(t, err, provider.ErrUnsupportedKind)
+	})
+}
+
+func TestServe(t *testing.T) {
+	t.Parallel()
+
+	backend := plugin.Backend{
+		Stores: map[provider.Kind]provider.Store{
+			provider.KindSecret: jsonstore.NewSecretStore(filepath.Join(t.TempDir(), "secret.json")),
+		},
+	}
+
+	t.Run("answers each request in order", func(t *testing.T) {
+		t.Parallel()
+
+		in := strings.NewReader(
+			`{"jsonrpc":"2.0","id":1,"method":"list","params":{"kind":"secret"}}` + "\n" +
+				`{"jsonrpc":"2.0","id":2,"method":"list","params":{"kind":"param"}}` + "\n" +
+				`{"jsonrpc":"2.0","id":3,"method":"rotate","params":{"kind":"secret"}}`,
+		)
+
+		var out bytes.Buffer
+		require.NoError(t, plugin.Serve(t.Context(), backend, in, &out))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":{"names":null}}`, lines[0])
+		assert.JSONEq(t, `{"jsonrpc":"2.0","id":2,"error":{"code":-32602,"message":"unsupported kind: \"param\""}}`, lines[1])
+		assert.JSONEq(t, `{"jsonrpc":"2.0","id":3,"error":{"code":-32601,"message":"plugin: method not supported: rotate"}}`, lines[2])
+	})
+
+	t.Run("malformed request", func(t *testing.T) {
+		t.Parallel()
+
+		err := plugin.Serve(t.Context(), backend, strings.NewReader("{\n"), io.Discard)
+		assert.ErrorContains(t, err, "invalid request")
+	})
+}
@@ -0,0 +1,299 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mpyw/suve/internal/capability"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// ProtocolVersion is the plugin protocol version suve speaks. A plugin echoes
+// it in its handshake; any other version is rejected.
+const ProtocolVersion = 1
+
+// Methods of the plugin protocol. Every method but handshake carries the store
+// kind ("param" or "secret") it targets.
+const (
+	MethodHandshake = "handshake"
+	MethodResolve   = "resolve"
+	MethodGet       = "get"
+	MethodHistory   = "history"
+	MethodList      = "list"
+	MethodCreate    = "create"
+	MethodPut       = "put"
+	MethodDelete    = "delete"
+	MethodTag       = "tag"
+	MethodUntag     = "untag"
+	MethodRestore   = "restore"
+	MethodDescribe  = "describe"
+)
+
+// Error codes a plugin returns. The first two are standard JSON-RPC codes; the
+// rest sit in the range JSON-RPC reserves for the server and map onto the
+// provider sentinel errors.
+const (
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeFailed         = -32000
+	CodeNotFound       = -32001
+	CodeAlreadyExists  = -32002
+	CodeLocked         = -32003
+	CodeBinaryValue    = -32004
+	CodeThrottled      = -32005
+)
+
+// jsonrpcVersion is the JSON-RPC version tag of every message.
+const jsonrpcVersion = "2.0"
+
+// ErrUnsupportedMethod is returned when a plugin does not implement a method
+// (e.g. restore on a store without soft delete).
+var ErrUnsupportedMethod = errors.New("plugin: method not supported")
+
+// request is a JSON-RPC request.
+type request struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  Params `json:"params"`
+}
+
+// response is a JSON-RPC response; exactly one of Result and Error is set.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Params are the parameters of every method; each method reads the fields it
+// needs and ignores the rest.
+type Params struct {
+	// ProtocolVersion is suve's protocol version (handshake).
+	ProtocolVersion int `json:"protocolVersion,omitempty"`
+	// Kind is the store kind the call targets (every method but handshake).
+	Kind provider.Kind `json:"kind,omitempty"`
+	// Name is the entry name.
+	Name string `json:"name,omitempty"`
+	// Spec is the version suffix to resolve, e.g. "#3", ":CURRENT" or "~1"
+	// (resolve). Empty means the latest version.
+	Spec string `json:"spec,omitempty"`
+	// Ref is a version id returned by resolve or history (get). Empty means
+	// the latest version.
+	Ref string `json:"ref,omitempty"`
+	// Value, Type and Description describe the value to write (create, put).
+	Value       string           `json:"value,omitempty"`
+	Type        domain.ValueType `json:"type,omitempty"`
+	Description string           `json:"description,omitempty"`
+	// Force asks for an immediate delete without a recovery window (delete).
+	Force bool `json:"force,omitempty"`
+	// RecoveryWindowDays asks for a recovery window of that many days
+	// (delete). Zero means the plugin's default.
+	RecoveryWindowDays int64 `json:"recoveryWindowDays,omitempty"`
+	// Tags are the tags to add or update (tag).
+	Tags map[string]string `json:"tags,omitempty"`
+	// Keys are the tag keys to remove (untag).
+	Keys []string `json:"keys,omitempty"`
+}
+
+// HandshakeResult is the result of handshake: the protocol version the plugin
+// speaks and what it offers. Capability.Services must list "param" and/or
+// "secret"; Capability.Provider is ignored (suve names plugins itself).
+type HandshakeResult struct {
+	ProtocolVersion int                           `json:"protocolVersion"`
+	Capability      capability.ProviderCapability `json:"capability"`
+}
+
+// ResolveResult is the result of resolve.
+type ResolveResult struct {
+	Ref string `json:"ref"`
+}
+
+// HistoryResult is the result of history, newest first.
+type HistoryResult struct {
+	Versions []Version `json:"versions"`
+}
+
+// ListResult is the result of list.
+type ListResult struct {
+	Names []string `json:"names"`
+}
+
+// Tag is a tag on the wire.
+type Tag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Field is a display-only metadata field on the wire.
+type Field struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Version is domain.Version on the wire (the result of create and put).
+type Version struct {
+	ID            string     `json:"id"`
+	State         string     `json:"state,omitempty"`
+	StagingLabels []string   `json:"stagingLabels,omitempty"`
+	Created       *time.Time `json:"created,omitempty"`
+	Tags          []Tag      `json:"tags,omitempty"`
+}
+
+// Entry is domain.Entry on the wire (the result of get and describe).
+type Entry struct {
+	Name        string           `json:"name"`
+	Value       string           `json:"value"`
+	Type        domain.ValueType `json:"type,omitempty"`
+	Version     Version          `json:"version"`
+	Description string           `json:"description,omitempty"`
+	Tags        []Tag            `json:"tags,omitempty"`
+	Modified    *time.Time       `json:"modified,omitempty"`
+	Extra       []Field          `json:"extra,omitempty"`
+}
+
+// Error is an error reported by a plugin. It unwraps to the provider sentinel
+// matching its code, so callers classify it like any other store's error.
+type Error struct {
+	// Plugin is the plugin's name.
+	Plugin string
+	// Code is the JSON-RPC error code.
+	Code int
+	// Message is the plugin's message.
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("plugin %s: %s", e.Plugin, e.Message)
+}
+
+// Unwrap returns the sentinel error for the code, or nil.
+func (e *Error) Unwrap() error {
+	switch e.Code {
+	case CodeMethodNotFound:
+		return ErrUnsupportedMethod
+	case CodeNotFound:
+		return provider.ErrNotFound
+	case CodeAlreadyExists:
+		return provider.ErrAlreadyExists
+	case CodeLocked:
+		return provider.ErrLocked
+	case CodeBinaryValue:
+		return provider.ErrBinaryValue
+	case CodeThrottled:
+		return provider.ErrThrottled
+	default:
+		return nil
+	}
+}
+
+// codeFor maps a store error onto its protocol error code.
+func codeFor(err error) int {
+	switch {
+	case errors.Is(err, ErrUnsupportedMethod):
+		return CodeMethodNotFound
+	case errors.Is(err, provider.ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, provider.ErrAlreadyExists):
+		return CodeAlreadyExists
+	case errors.Is(err, provider.ErrLocked):
+		return CodeLocked
+	case errors.Is(err, provider.ErrBinaryValue):
+		return CodeBinaryValue
+	case provider.IsThrottled(err):
+		return CodeThrottled
+	default:
+		return CodeFailed
+	}
+}
+
+func toDomainTags(tags []Tag) []domain.Tag {
+	if tags == nil {
+		return nil
+	}
+
+	out := make([]domain.Tag, len(tags))
+	for i, t := range tags {
+		out[i] = domain.Tag{Key: t.Key, Value: t.Value}
+	}
+
+	return out
+}
+
+func fromDomainTags(tags []domain.Tag) []Tag {
+	if tags == nil {
+		return nil
+	}
+
+	out := make([]Tag, len(tags))
+	for i, t := range tags {
+		out[i] = Tag{Key: t.Key, Value: t.Value}
+	}
+
+	return out
+}
+
+func toDomainVersion(v Version) domain.Version {
+	return domain.Version{
+		ID:            v.ID,
+		State:         v.State,
+		StagingLabels: v.StagingLabels,
+		Created:       v.Created,
+		Tags:          toDomainTags(v.Tags),
+	}
+}
+
+func fromDomainVersion(v domain.Version) Version {
+	return Version{
+		ID:            v.ID,
+		State:         v.State,
+		StagingLabels: v.StagingLabels,
+		Created:       v.Created,
+		Tags:          fromDomainTags(v.Tags),
+	}
+}
+
+func toDomainEntry(e Entry) *domain.Entry {
+	entry := &domain.Entry{
+		Name:        e.Name,
+		Value:       e.Value,
+		Type:        e.Type,
+		Version:     toDomainVersion(e.Version),
+		Description: e.Description,
+		Tags:        toDomainTags(e.Tags),
+		Modified:    e.Modified,
+	}
+
+	for _, f := range e.Extra {
+		entry.Extra = append(entry.Extra, domain.Field{Label: f.Label, Value: f.Value})
+	}
+
+	return entry
+}
+
+func fromDomainEntry(e *domain.Entry) Entry {
+	entry := Entry{
+		Name:        e.Name,
+		Value:       e.Value,
+		Type:        e.Type,
+		Version:     fromDomainVersion(e.Version),
+		Description: e.Description,
+		Tags:        fromDomainTags(e.Tags),
+		Modified:    e.Modified,
+	}
+
+	for _, f := range e.Extra {
+		entry.Extra = append(entry.Extra, Field{Label: f.Label, Value: f.Value})
+	}
+
+	return entry
+}
@@ -0,0 +1,178 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mpyw/suve/internal/capability"
+	"github.com/mpyw/suve/internal/provider"
+	awssecret "github.com/mpyw/suve/internal/provider/aws/secret"
+)
+
+// Backend is what Serve exposes: the capability sent in the handshake and a
+// store per offered kind. A store that is not a provider.Restorer or
+// provider.Describer answers those methods with method-not-found.
+type Backend struct {
+	Capability capability.ProviderCapability
+	Stores     map[provider.Kind]provider.Store
+}
+
+// errUnsupportedKind answers a call for a kind the backend does not serve.
+var errUnsupportedKind = errors.New("unsupported kind")
+
+// Serve answers plugin protocol requests read from r on w, one per line,
+// until r is exhausted. It is the reference implementation of the plugin side
+// of the protocol. A request that cannot be decoded ends the session with an
+// error; a failing call is answered with an error response.
+func Serve(ctx context.Context, b Backend, r io.Reader, w io.Writer) error {
+	reader := bufio.NewReader(r)
+	enc := json.NewEncoder(w)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var req request
+			if uerr := json.Unmarshal(line, &req); uerr != nil {
+				return fmt.Errorf("invalid request: %w", uerr)
+			}
+
+			if eerr := enc.Encode(b.answer(ctx, req)); eerr != nil {
+				return fmt.Errorf("failed to write response: %w", eerr)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read request: %w", err)
+		}
+	}
+}
+
+// answer runs one request and builds its response.
+func (b Backend) answer(ctx context.Context, req request) response {
+	resp := response{JSONRPC: jsonrpcVersion, ID: req.ID}
+
+	result, err := b.dispatch(ctx, req.Method, req.Params)
+	if err != nil {
+		code := codeFor(err)
+		if errors.Is(err, errUnsupportedKind) {
+			code = CodeInvalidParams
+		}
+
+		resp.Error = &rpcError{Code: code, Message: err.Error()}
+
+		return resp
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		resp.Error = &rpcError{Code: CodeFailed, Message: err.Error()}
+
+		return resp
+	}
+
+	resp.Result = raw
+
+	return resp
+}
+
+// dispatch calls the store method named by method.
+//
+//nolint:cyclop // one case per protocol method
+func (b Backend) dispatch(ctx context.Context, method string, p Params) (any, error) {
+	if method == MethodHandshake {
+		return HandshakeResult{ProtocolVersion: ProtocolVersion, Capability: b.Capability}, nil
+	}
+
+	store, ok := b.Stores[p.Kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnsupportedKind, p.Kind)
+	}
+
+	switch method {
+	case MethodResolve:
+		ref, err := store.Resolve(ctx, p.Name, p.Spec)
+
+		return ResolveResult{Ref: ref.ID()}, err
+	case MethodGet:
+		entry, err := store.Get(ctx, p.Name, provider.NewVersionRef(p.Ref))
+		if err != nil {
+			return nil, err
+		}
+
+		return fromDomainEntry(entry), nil
+	case MethodHistory:
+		versions, err := store.History(ctx, p.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		res := HistoryResult{Versions: make([]Version, len(versions))}
+		for i, v := range versions {
+			res.Versions[i] = fromDomainVersion(v)
+		}
+
+		return res, nil
+	case MethodList:
+		names, err := store.List(ctx)
+
+		return ListResult{Names: names}, err
+	case MethodCreate:
+		v, err := store.Create(ctx, p.Name, p.Value, p.Type, p.Description)
+
+		return fromDomainVersion(v), err
+	case MethodPut:
+		v, err := store.Put(ctx, p.Name, p.Value, p.Type, p.Description)
+
+		return fromDomainVersion(v), err
+	case MethodDelete:
+		return nil, store.Delete(ctx, p.Name, deleteOptions(p)...)
+	case MethodTag:
+		return nil, store.Tag(ctx, p.Name, p.Tags)
+	case MethodUntag:
+		return nil, store.Untag(ctx, p.Name, p.Keys)
+	case MethodRestore:
+		restorer, ok := store.(provider.Restorer)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedMethod, method)
+		}
+
+		return nil, restorer.Restore(ctx, p.Name)
+	case MethodDescribe:
+		describer, ok := store.(provider.Describer)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedMethod, method)
+		}
+
+		entry, err := describer.Describe(ctx, p.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		return fromDomainEntry(entry), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMethod, method)
+	}
+}
+
+// deleteOptions turns the delete parameters back into delete options.
+func deleteOptions(p Params) []provider.DeleteOption {
+	var opts []provider.DeleteOption
+
+	if p.Force {
+		opts = append(opts, provider.ForceDelete{})
+	}
+
+	if p.RecoveryWindowDays > 0 {
+		opts = append(opts, awssecret.RecoveryWindow{Days: p.RecoveryWindowDays})
+	}
+
+	return opts
+}
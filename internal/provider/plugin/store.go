@@ -0,0 +1,144 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	awssecret "github.com/mpyw/suve/internal/provider/aws/secret"
+)
+
+// Store is the provider.Store of one plugin service. It also implements
+// provider.Restorer and provider.Describer; a plugin without them answers
+// method-not-found, surfaced as a wrapped ErrUnsupportedMethod.
+type Store struct {
+	client *Client
+	kind   provider.Kind
+}
+
+// Compile-time assertions that Store implements the provider contracts.
+var (
+	_ provider.Store     = (*Store)(nil)
+	_ provider.Restorer  = (*Store)(nil)
+	_ provider.Describer = (*Store)(nil)
+)
+
+// NewStore builds a Store for the kind served by client's plugin.
+func NewStore(client *Client, kind provider.Kind) *Store {
+	return &Store{client: client, kind: kind}
+}
+
+// Resolve hands the version spec to the plugin and returns its version ref.
+func (s *Store) Resolve(ctx context.Context, name, spec string) (provider.VersionRef, error) {
+	var res ResolveResult
+	if err := s.client.call(ctx, MethodResolve, Params{Kind: s.kind, Name: name, Spec: spec}, &res); err != nil {
+		return provider.VersionRef{}, err
+	}
+
+	return provider.NewVersionRef(res.Ref), nil
+}
+
+// Get returns the entry at ref.
+func (s *Store) Get(ctx context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+	var res Entry
+	if err := s.client.call(ctx, MethodGet, Params{Kind: s.kind, Name: name, Ref: ref.ID()}, &res); err != nil {
+		return nil, err
+	}
+
+	return toDomainEntry(res), nil
+}
+
+// History returns the entry's versions, newest first.
+func (s *Store) History(ctx context.Context, name string) ([]domain.Version, error) {
+	var res HistoryResult
+	if err := s.client.call(ctx, MethodHistory, Params{Kind: s.kind, Name: name}, &res); err != nil {
+		return nil, err
+	}
+
+	versions := make([]domain.Version, len(res.Versions))
+	for i, v := range res.Versions {
+		versions[i] = toDomainVersion(v)
+	}
+
+	return versions, nil
+}
+
+// List returns every entry name.
+func (s *Store) List(ctx context.Context) ([]string, error) {
+	var res ListResult
+	if err := s.client.call(ctx, MethodList, Params{Kind: s.kind}, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Names, nil
+}
+
+// Create creates a new entry. Provider-specific write options are not sent.
+func (s *Store) Create(
+	ctx context.Context, name, value string, valueType domain.ValueType, description string, _ ...provider.WriteOption,
+) (domain.Version, error) {
+	return s.write(ctx, MethodCreate, name, value, valueType, description)
+}
+
+// Put creates or updates an entry. Provider-specific write options are not
+// sent.
+func (s *Store) Put(
+	ctx context.Context, name, value string, valueType domain.ValueType, description string, _ ...provider.WriteOption,
+) (domain.Version, error) {
+	return s.write(ctx, MethodPut, name, value, valueType, description)
+}
+
+func (s *Store) write(
+	ctx context.Context, method, name, value string, valueType domain.ValueType, description string,
+) (domain.Version, error) {
+	params := Params{Kind: s.kind, Name: name, Value: value, Type: valueType, Description: description}
+
+	var res Version
+	if err := s.client.call(ctx, method, params, &res); err != nil {
+		return domain.Version{}, err
+	}
+
+	return toDomainVersion(res), nil
+}
+
+// Delete deletes an entry, passing on provider.ForceDelete and a Secrets
+// Manager-style recovery window.
+func (s *Store) Delete(ctx context.Context, name string, opts ...provider.DeleteOption) error {
+	params := Params{Kind: s.kind, Name: name}
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case provider.ForceDelete:
+			params.Force = true
+		case awssecret.RecoveryWindow:
+			params.RecoveryWindowDays = o.Days
+		}
+	}
+
+	return s.client.call(ctx, MethodDelete, params, nil)
+}
+
+// Tag adds or updates tags on an entry.
+func (s *Store) Tag(ctx context.Context, name string, add map[string]string) error {
+	return s.client.call(ctx, MethodTag, Params{Kind: s.kind, Name: name, Tags: add}, nil)
+}
+
+// Untag removes tags from an entry.
+func (s *Store) Untag(ctx context.Context, name string, keys []string) error {
+	return s.client.call(ctx, MethodUntag, Params{Kind: s.kind, Name: name, Keys: keys}, nil)
+}
+
+// Restore cancels a pending deletion.
+func (s *Store) Restore(ctx context.Context, name string) error {
+	return s.client.call(ctx, MethodRestore, Params{Kind: s.kind, Name: name}, nil)
+}
+
+// Describe returns an entry's metadata without its value.
+func (s *Store) Describe(ctx context.Context, name string) (*domain.Entry, error) {
+	var res Entry
+	if err := s.client.call(ctx, MethodDescribe, Params{Kind: s.kind, Name: name}, &res); err != nil {
+		return nil, err
+	}
+
+	return toDomainEntry(res), nil
+}
@@ -9,6 +9,7 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/mpyw/suve/internal/domain"
 )
@@ -70,6 +71,63 @@ func (DeleteOptionMarker) deleteOption() {}
 // Restore to recover). Providers without the concept ignore it.
 type ForceDelete struct{ DeleteOptionMarker }
 
+// SecretAttributes sets a secret version's content type, activation window and
+// enabled flag. Azure Key Vault honors it (SetSecret on a new version,
+// UpdateSecretProperties via AttributeUpdater) and Azure App Configuration
+// honors its content type; other providers ignore it. A
+// nil field leaves that attribute unset on a new version and unchanged on an
+// update.
+type SecretAttributes struct {
+	WriteOptionMarker
+
+	ContentType *string
+	NotBefore   *time.Time
+	Expires     *time.Time
+	Enabled     *bool
+}
+
+// IsZero reports whether no attribute is set.
+func (a SecretAttributes) IsZero() bool {
+	return a.ContentType == nil && a.NotBefore == nil && a.Expires == nil && a.Enabled == nil
+}
+
+// IfMatch makes an update or a delete conditional on the entry still being at
+// the given version, failing with a wrapped ErrModified otherwise. It is both a
+// WriteOption and a DeleteOption. Only Azure App Configuration honors it
+// (Version is then an ETag); other providers ignore it.
+type IfMatch struct {
+	WriteOptionMarker
+	DeleteOptionMarker
+
+	Version string
+}
+
+// ChangeMessage records why a write was made (`stage apply -m`) with the
+// version it writes, read back as domain.Version.Message. Providers keep it
+// where the backend has a per-version place for it: AWS SSM as a description
+// suffix, Google Cloud Secret Manager as a secret annotation per version, Azure
+// Key Vault as a version tag, and the local provider in its version record.
+// Other providers ignore it.
+type ChangeMessage struct {
+	WriteOptionMarker
+
+	Message string
+}
+
+// ChangeMessageOf returns the message of the last ChangeMessage in opts, or ""
+// when there is none. For adapter use.
+func ChangeMessageOf(opts []WriteOption) string {
+	var message string
+
+	for _, opt := range opts {
+		if o, ok := opt.(ChangeMessage); ok {
+			message = o.Message
+		}
+	}
+
+	return message
+}
+
 // Reader provides read access to a provider's entries.
 type Reader interface {
 	// Resolve parses a provider-specific version spec string (e.g. "#3~1",
@@ -127,6 +185,13 @@ type Restorer interface {
 	Restore(ctx context.Context, name string) error
 }
 
+// AttributeUpdater updates an entry's attributes in place, without writing a
+// new value or version (e.g. Azure Key Vault). Optional.
+type AttributeUpdater interface {
+	// UpdateAttributes applies the set attributes to the current version.
+	UpdateAttributes(ctx context.Context, name string, attrs SecretAttributes) error
+}
+
 // Describer returns entry metadata without the value. Optional.
 type Describer interface {
 	// Describe returns an entry's metadata without fetching its value.
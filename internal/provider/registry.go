@@ -15,6 +15,18 @@ const (
 	ProviderGoogleCloud Provider = "googlecloud"
 	// ProviderAzure is the Microsoft Azure provider.
 	ProviderAzure Provider = "azure"
+	// ProviderVault is the HashiCorp Vault provider (KV version 2 secrets engine).
+	ProviderVault Provider = "vault"
+	// ProviderKubernetes is the Kubernetes provider (ConfigMaps and Secrets).
+	ProviderKubernetes Provider = "kubernetes"
+	// ProviderSOPS is the SOPS provider (the leaf keys of an age-encrypted file).
+	ProviderSOPS Provider = "sops"
+	// ProviderLocal is the offline provider: JSON files under ~/.suve/local/
+	// that stand in for AWS Parameter Store and Secrets Manager.
+	ProviderLocal Provider = "local"
+	// ProviderPlugin is an external provider: a suve-provider-<name> executable
+	// on PATH spoken to over JSON-RPC (the plugin is named by Scope.Plugin).
+	ProviderPlugin Provider = "plugin"
 )
 
 // Kind selects a store kind within a provider (some providers offer only one).
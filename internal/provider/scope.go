@@ -2,17 +2,29 @@ package provider
 
 import (
 	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
 	"strings"
 )
 
 // Scope identifies a provider-specific namespace for staging state. The set of
 // meaningful fields depends on Provider:
 //
-//   - AWS: AccountID + Region (shared for param and secret).
+//   - AWS: AccountID + Region (shared for param and secret), plus AWSProfile
+//     when one was selected explicitly. The role fields only select
+//     credentials and are not part of the identity.
 //   - GoogleCloud: ProjectID (Secret Manager only).
 //   - Azure: VaultName (Key Vault, secret) or StoreName (App Configuration,
 //     param) — each a globally-unique name that fully identifies the resource,
 //     so no subscription/resource-group is needed.
+//   - Vault: VaultAddress + VaultMount (KV v2 secrets engine, secret only).
+//   - Kubernetes: KubeContext + KubeNamespace (ConfigMaps as param, Secrets as
+//     secret).
+//   - SOPS: SOPSFile (the leaf keys of one encrypted file, secret only).
+//   - Local: no fields — there is one local store per user (param and secret).
+//   - Plugin: Plugin (the executable's name) + PluginKinds (the kinds its
+//     handshake offers).
 //
 // Scope is used both to select a provider factory (Provider field) and to key
 // on-disk staging storage (see Key).
@@ -24,6 +36,17 @@ type Scope struct {
 	AccountID string `json:"accountId,omitempty"`
 	// Region is the AWS region (AWS).
 	Region string `json:"region,omitempty"`
+	// AWSProfile is the shared config profile selected with --profile or in
+	// the TUI/GUI (AWS). Empty means the ambient chain (AWS_PROFILE etc.).
+	AWSProfile string `json:"awsProfile,omitempty"`
+	// AWSRoleARN is a role assumed on top of the profile's credentials (AWS).
+	AWSRoleARN string `json:"awsRoleArn,omitempty"`
+	// AWSExternalID is the external id passed when assuming AWSRoleARN (AWS).
+	AWSExternalID string `json:"awsExternalId,omitempty"`
+	// AWSSessionName is the role session name for AWSRoleARN (AWS).
+	AWSSessionName string `json:"awsSessionName,omitempty"`
+	// AWSMFASerial is the MFA device AWSRoleARN requires (AWS).
+	AWSMFASerial string `json:"awsMfaSerial,omitempty"`
 
 	// ProjectID is the Google Cloud project id (GoogleCloud).
 	ProjectID string `json:"projectId,omitempty"`
@@ -37,6 +60,30 @@ type Scope struct {
 	// the null (default) namespace. A single resolved namespace only; the
 	// filter grammar (`*`/`,`) never reaches staging (see #381).
 	AppConfigNamespace string `json:"appConfigNamespace,omitempty"`
+
+	// VaultAddress is the HashiCorp Vault server address, e.g.
+	// "https://vault.example.com:8200" (Vault).
+	VaultAddress string `json:"vaultAddress,omitempty"`
+	// VaultMount is the path the KV v2 secrets engine is mounted at, e.g.
+	// "secret" (Vault).
+	VaultMount string `json:"vaultMount,omitempty"`
+
+	// KubeContext is the kubeconfig context naming the cluster and user
+	// (Kubernetes).
+	KubeContext string `json:"kubeContext,omitempty"`
+	// KubeNamespace is the namespace holding the ConfigMaps and Secrets
+	// (Kubernetes).
+	KubeNamespace string `json:"kubeNamespace,omitempty"`
+
+	// SOPSFile is the absolute path of the SOPS-encrypted file (SOPS).
+	SOPSFile string `json:"sopsFile,omitempty"`
+
+	// Plugin is the name of an external provider, i.e. the <name> of its
+	// suve-provider-<name> executable (Plugin).
+	Plugin string `json:"plugin,omitempty"`
+	// PluginKinds are the store kinds the plugin offered in its handshake
+	// (Plugin). They are not part of the staging key.
+	PluginKinds []Kind `json:"pluginKinds,omitempty"`
 }
 
 // Key returns a stable, filesystem-safe key identifying the scope. It is used
@@ -44,6 +91,13 @@ type Scope struct {
 func (s Scope) Key() string {
 	switch s.Provider {
 	case ProviderAWS:
+		// Profiles that reach the same account and region (say, read-only and
+		// admin) stage separately. Without an explicit profile the key is the
+		// one used before profiles could be selected.
+		if s.AWSProfile != "" {
+			return fmt.Sprintf("aws/%s/%s@%s", s.AccountID, s.Region, pathSafe.Replace(s.AWSProfile))
+		}
+
 		return fmt.Sprintf("aws/%s/%s", s.AccountID, s.Region)
 	case ProviderGoogleCloud:
 		return fmt.Sprintf("googlecloud/%s", s.ProjectID)
@@ -62,21 +116,41 @@ func (s Scope) Key() string {
 		// its own namespace as part of its identity (see staging.CompositeEntryKey).
 		// The namespace is deliberately NOT in the key.
 		return fmt.Sprintf("azure/appconfig/%s", strings.ToLower(s.StoreName))
+	case ProviderVault:
+		// One Vault server hosts many KV mounts, so both are part of the key.
+		// The address is reduced to its host[:port] (scheme and trailing slash
+		// are not identity) with ':' folded to '_' to stay filesystem-safe.
+		return fmt.Sprintf("vault/%s/%s", vaultHostKey(s.VaultAddress), strings.Trim(s.VaultMount, "/"))
+	case ProviderKubernetes:
+		// Context names are free-form (EKS uses the cluster ARN), so fold the
+		// path and drive separators to keep the key one directory level.
+		return fmt.Sprintf("kubernetes/%s/%s", pathSafe.Replace(s.KubeContext), s.KubeNamespace)
+	case ProviderSOPS:
+		// The file path is the identity. It is escaped reversibly into one
+		// directory level, so two files never share staged state.
+		return fmt.Sprintf("sops/%s", pathEscape.Replace(strings.TrimPrefix(filepath.Clean(s.SOPSFile), "/")))
+	case ProviderLocal:
+		return "local"
+	case ProviderPlugin:
+		return fmt.Sprintf("plugin/%s", s.Plugin)
 	default:
 		return ""
 	}
 }
 
 // SupportsService reports whether the scope's provider offers the given store
-// kind. AWS supports both param and secret; GoogleCloud supports secret only;
-// Azure supports secret (Key Vault) or param (App Configuration) depending on
-// which of VaultName/StoreName is set.
+// kind. AWS, Kubernetes and Local support both param and secret; GoogleCloud, Vault
+// and SOPS support secret only; Azure supports secret (Key Vault) or param (App
+// Configuration) depending on which of VaultName/StoreName is set; a plugin
+// supports the kinds listed in PluginKinds.
 func (s Scope) SupportsService(kind Kind) bool {
 	switch s.Provider {
-	case ProviderAWS:
+	case ProviderAWS, ProviderKubernetes, ProviderLocal:
 		return kind == KindParam || kind == KindSecret
-	case ProviderGoogleCloud:
+	case ProviderGoogleCloud, ProviderVault, ProviderSOPS:
 		return kind == KindSecret
+	case ProviderPlugin:
+		return slices.Contains(s.PluginKinds, kind)
 	case ProviderAzure:
 		// App Configuration (param) and Key Vault (secret) are INDEPENDENT Azure
 		// resources: each is supported iff its own name is set. A scope may carry
@@ -144,3 +218,72 @@ func AzureAppConfigScope(storeName string) Scope {
 		StoreName: storeName,
 	}
 }
+
+// VaultScope creates a Scope for a HashiCorp Vault KV v2 mount on the server at
+// address.
+func VaultScope(address, mount string) Scope {
+	return Scope{
+		Provider:     ProviderVault,
+		VaultAddress: address,
+		VaultMount:   mount,
+	}
+}
+
+// KubernetesScope creates a Scope for a namespace in the cluster named by a
+// kubeconfig context.
+func KubernetesScope(kubeContext, namespace string) Scope {
+	return Scope{
+		Provider:      ProviderKubernetes,
+		KubeContext:   kubeContext,
+		KubeNamespace: namespace,
+	}
+}
+
+// SOPSScope creates a Scope for the SOPS-encrypted file at path, which should
+// be absolute so the staging key does not depend on the working directory.
+func SOPSScope(path string) Scope {
+	return Scope{
+		Provider: ProviderSOPS,
+		SOPSFile: path,
+	}
+}
+
+// LocalScope creates the Scope for the local provider. It carries no fields:
+// the store lives at a fixed place in the user's home directory.
+func LocalScope() Scope {
+	return Scope{Provider: ProviderLocal}
+}
+
+// PluginScope creates a Scope for the external provider named name, offering
+// the given kinds.
+func PluginScope(name string, kinds ...Kind) Scope {
+	return Scope{
+		Provider:    ProviderPlugin,
+		Plugin:      name,
+		PluginKinds: kinds,
+	}
+}
+
+// pathSafe folds characters that are unsafe in a single path segment.
+//
+//nolint:gochecknoglobals // immutable replacer table
+var pathSafe = strings.NewReplacer(":", "_", "/", "_", "\\", "_")
+
+// pathEscape percent-escapes the characters that are unsafe in a single path
+// segment, and the escape character itself, so unlike pathSafe it maps distinct
+// paths to distinct segments.
+//
+//nolint:gochecknoglobals // immutable replacer table
+var pathEscape = strings.NewReplacer("%", "%25", ":", "%3A", "/", "%2F", "\\", "%5C")
+
+// vaultHostKey reduces a Vault address to a filesystem-safe host[:port] key
+// ("https://vault.example.com:8200/" -> "vault.example.com_8200"). An address
+// that does not parse as a URL with a host is used verbatim (minus slashes).
+func vaultHostKey(address string) string {
+	host := address
+	if u, err := url.Parse(address); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	return pathSafe.Replace(strings.ToLower(strings.Trim(host, "/")))
+}
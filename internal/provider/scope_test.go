@@ -21,6 +21,15 @@ func TestScope_Key(t *testing.T) {
 			scope: provider.AWSScope("123456789012", "ap-northeast-1"),
 			want:  "aws/123456789012/ap-northeast-1",
 		},
+		{
+			// An explicit profile keys its own staging; the role does not.
+			name: "aws with profile",
+			scope: provider.Scope{
+				Provider: provider.ProviderAWS, AccountID: "123456789012", Region: "ap-northeast-1",
+				AWSProfile: "team/admin", AWSRoleARN: "arn:aws:iam::123456789012:role/Admin",
+			},
+			want: "aws/123456789012/ap-northeast-1@team_admin",
+		},
 		{
 			name:  "googlecloud",
 			scope: provider.GoogleCloudScope("my-project"),
@@ -64,6 +73,58 @@ func TestScope_Key(t *testing.T) {
 			},
 			want: "azure/appconfig/store1",
 		},
+		{
+			// Scheme and trailing slash are not identity; ':' is folded for
+			// filesystem safety, and the mount keeps its own segment.
+			name:  "vault",
+			scope: provider.VaultScope("https://Vault.example.com:8200/", "secret"),
+			want:  "vault/vault.example.com_8200/secret",
+		},
+		{
+			name:  "vault nested mount",
+			scope: provider.VaultScope("http://127.0.0.1:8200", "/team/kv/"),
+			want:  "vault/127.0.0.1_8200/team/kv",
+		},
+		{
+			name:  "kubernetes",
+			scope: provider.KubernetesScope("kind-dev", "payments"),
+			want:  "kubernetes/kind-dev/payments",
+		},
+		{
+			// EKS names contexts after the cluster ARN; ':' and '/' are folded so
+			// the context stays one path segment.
+			name:  "kubernetes arn context",
+			scope: provider.KubernetesScope("arn:aws:eks:us-east-1:123456789012:cluster/prod", "default"),
+			want:  "kubernetes/arn_aws_eks_us-east-1_123456789012_cluster_prod/default",
+		},
+		{
+			// The file path is escaped into a single segment.
+			name:  "sops",
+			scope: provider.SOPSScope("/home/me/app/secrets.enc.yaml"),
+			want:  "sops/home%2Fme%2Fapp%2Fsecrets.enc.yaml",
+		},
+		{
+			// Underscores and the escape character stay distinct from separators.
+			name:  "sops underscore",
+			scope: provider.SOPSScope("/a/b_c%2F.yaml"),
+			want:  "sops/a%2Fb_c%252F.yaml",
+		},
+		{
+			name:  "sops uncleaned path",
+			scope: provider.SOPSScope("/a/./b//c.yaml"),
+			want:  "sops/a%2Fb%2Fc.yaml",
+		},
+		{
+			name:  "local",
+			scope: provider.LocalScope(),
+			want:  "local",
+		},
+		{
+			// The offered kinds are not identity.
+			name:  "plugin",
+			scope: provider.PluginScope("acme", provider.KindSecret),
+			want:  "plugin/acme",
+		},
 		{
 			name:  "unknown provider",
 			scope: provider.Scope{},
@@ -80,6 +141,13 @@ func TestScope_Key(t *testing.T) {
 	}
 }
 
+func TestScope_Key_SOPSDistinctFiles(t *testing.T) {
+	t.Parallel()
+
+	// Folding separators and underscores alike once made these share a key.
+	assert.NotEqual(t, provider.SOPSScope("/a/b_c.yaml").Key(), provider.SOPSScope("/a_b/c.yaml").Key())
+}
+
 func TestScope_SupportsService(t *testing.T) {
 	t.Parallel()
 
@@ -122,6 +190,30 @@ func TestScope_SupportsService(t *testing.T) {
 			wantParam:  true,
 			wantSecret: true,
 		},
+		{
+			name:       "kubernetes supports both",
+			scope:      provider.KubernetesScope("ctx", "default"),
+			wantParam:  true,
+			wantSecret: true,
+		},
+		{
+			name:       "sops supports secret only",
+			scope:      provider.SOPSScope("/repo/secrets.yaml"),
+			wantParam:  false,
+			wantSecret: true,
+		},
+		{
+			name:       "local supports both",
+			scope:      provider.LocalScope(),
+			wantParam:  true,
+			wantSecret: true,
+		},
+		{
+			name:       "plugin supports its handshake kinds",
+			scope:      provider.PluginScope("acme", provider.KindSecret),
+			wantParam:  false,
+			wantSecret: true,
+		},
 		{
 			name:       "unknown supports nothing",
 			scope:      provider.Scope{},
@@ -198,4 +290,29 @@ func TestScope_Constructors(t *testing.T) {
 	ac := provider.AzureAppConfigScope("store")
 	assert.Equal(t, provider.ProviderAzure, ac.Provider)
 	assert.Equal(t, "store", ac.StoreName)
+
+	v := provider.VaultScope("http://127.0.0.1:8200", "secret")
+	assert.Equal(t, provider.ProviderVault, v.Provider)
+	assert.Equal(t, "http://127.0.0.1:8200", v.VaultAddress)
+	assert.Equal(t, "secret", v.VaultMount)
+	assert.Equal(t, []provider.Kind{provider.KindSecret}, v.SupportedKinds())
+
+	k := provider.KubernetesScope("kind-dev", "payments")
+	assert.Equal(t, provider.ProviderKubernetes, k.Provider)
+	assert.Equal(t, "kind-dev", k.KubeContext)
+	assert.Equal(t, "payments", k.KubeNamespace)
+
+	sp := provider.SOPSScope("/repo/secrets.yaml")
+	assert.Equal(t, provider.ProviderSOPS, sp.Provider)
+	assert.Equal(t, "/repo/secrets.yaml", sp.SOPSFile)
+	assert.Equal(t, []provider.Kind{provider.KindSecret}, sp.SupportedKinds())
+
+	l := provider.LocalScope()
+	assert.Equal(t, provider.ProviderLocal, l.Provider)
+	assert.Equal(t, []provider.Kind{provider.KindParam, provider.KindSecret}, l.SupportedKinds())
+
+	pl := provider.PluginScope("acme", provider.KindParam, provider.KindSecret)
+	assert.Equal(t, provider.ProviderPlugin, pl.Provider)
+	assert.Equal(t, "acme", pl.Plugin)
+	assert.Equal(t, []provider.Kind{provider.KindParam, provider.KindSecret}, pl.SupportedKinds())
 }
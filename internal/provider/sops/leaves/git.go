@@ -0,0 +1,95 @@
+package leaves
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Revision is one committed revision of the SOPS file.
+type Revision struct {
+	Commit string
+	Time   time.Time
+}
+
+// History reads the committed revisions of a file. The default implementation
+// shells out to git; tests substitute a fake.
+type History interface {
+	// Revisions lists the commits that touched path, newest first. A file
+	// outside any repository has no revisions and no error.
+	Revisions(ctx context.Context, path string) ([]Revision, error)
+	// Content returns the file's content at commit.
+	Content(ctx context.Context, path, commit string) ([]byte, error)
+}
+
+// Git is the History backed by the git CLI.
+type Git struct{}
+
+// Compile-time assertion that Git implements History.
+var _ History = Git{}
+
+// Revisions runs git log for the file. When git is not installed, the file is
+// not inside a repository, or the repository has no commits yet, the file
+// simply has no history.
+func (g Git) Revisions(ctx context.Context, path string) ([]Revision, error) {
+	dir, base := filepath.Split(path)
+
+	if _, err := g.run(ctx, dir, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return nil, nil //nolint:nilerr // no repository, no commits or no git: no history
+	}
+
+	out, err := g.run(ctx, dir, "log", "--format=%H%x09%cI", "--", base)
+	if err != nil {
+		return nil, err
+	}
+
+	var revs []Revision
+
+	for line := range strings.Lines(string(out)) {
+		hash, date, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected git log date %q: %w", date, err)
+		}
+
+		revs = append(revs, Revision{Commit: hash, Time: t})
+	}
+
+	return revs, nil
+}
+
+// Content runs git show for the file at commit.
+func (g Git) Content(ctx context.Context, path, commit string) ([]byte, error) {
+	dir, base := filepath.Split(path)
+
+	return g.run(ctx, dir, "show", commit+":./"+base)
+}
+
+// run executes git in dir and returns stdout, folding stderr into the error.
+func (Git) run(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return nil, fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(stderr.String()))
+		}
+
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,554 @@
+// Package leaves implements the provider.Store contract (Reader/Writer/Tagger)
+// for the leaf keys of one SOPS-encrypted file, on top of the sopsfile codec.
+//
+// A SOPS file maps onto suve's model as follows:
+//
+//   - Each non-null scalar leaf is an entry named by its dotted key path
+//     ("db.password", "hosts.0"). Writes re-encrypt the whole file with its
+//     existing data key, so every recipient of the file can still read it.
+//   - Versions are the git commits that changed the key's value, oldest to
+//     newest, identified by abbreviated commit hash. A working-tree value that
+//     differs from the last commit (or a file outside any repository) adds a
+//     newest "worktree" version. A version spec is parsed by sopsversion
+//     (#COMMIT, ~SHIFT). Revisions the configured identities cannot decrypt
+//     (e.g. before a key rotation) are skipped.
+//   - SOPS has no per-key metadata, so tags are unsupported and descriptions
+//     are ignored.
+package leaves
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"filippo.io/age"
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/sops/sopsfile"
+	"github.com/mpyw/suve/internal/version/sopsversion"
+)
+
+// WorktreeVersion is the version ID of an uncommitted value.
+const WorktreeVersion = "worktree"
+
+const (
+	// shortCommitLen is the length of the abbreviated commit hash used as a
+	// version ID.
+	shortCommitLen = 12
+	// newFileMode is the permission of a SOPS file created by suve.
+	newFileMode fs.FileMode = 0o600
+)
+
+// Errors specific to SOPS files.
+var (
+	// ErrTagsUnsupported is returned by Tag and Untag: SOPS keys carry no
+	// metadata.
+	ErrTagsUnsupported = errors.New("tags are not supported for SOPS files")
+	// ErrInvalidKey is returned when a key is not a dotted path of non-empty
+	// segments.
+	ErrInvalidKey = errors.New("invalid key: use a dotted path such as db.password")
+	// ErrNoRecipients is returned when writing to a file that does not exist yet
+	// and no age recipient is configured to encrypt it for.
+	ErrNoRecipients = errors.New("cannot create a new SOPS file without age recipients (set SOPS_AGE_RECIPIENTS)")
+)
+
+// Keys are the age keys a Store decrypts with and, for a file it creates,
+// encrypts to. Existing files are always re-encrypted to their own recipients.
+type Keys struct {
+	Identities []age.Identity
+	Recipients []age.Recipient
+}
+
+// Store is the SOPS file implementation of provider.Store.
+type Store struct {
+	path    string
+	format  sopsfile.Format
+	keys    Keys
+	history History
+	now     func() time.Time
+
+	mu        sync.Mutex
+	revisions map[string]decrypted // decrypted revisions by commit
+}
+
+// decrypted is a cached revision decryption result.
+type decrypted struct {
+	doc *sopsfile.Document
+	err error
+}
+
+// Compile-time assertion that Store implements the provider contract.
+var _ provider.Store = (*Store)(nil)
+
+// New builds a Store for the SOPS file at path (which need not exist yet).
+func New(path string, keys Keys, history History) *Store {
+	return &Store{
+		path:      path,
+		format:    sopsfile.FormatForPath(path),
+		keys:      keys,
+		history:   history,
+		now:       time.Now,
+		revisions: map[string]decrypted{},
+	}
+}
+
+// keyVersion is one version of a key: the commit that set its value, or ""
+// for the working tree.
+type keyVersion struct {
+	commit string
+	time   time.Time
+}
+
+// id returns the version ID shown to users.
+func (v keyVersion) id() string {
+	if v.commit == "" {
+		return WorktreeVersion
+	}
+
+	return shortCommit(v.commit)
+}
+
+// keyHistory is the version history of one key.
+type keyHistory struct {
+	versions  []keyVersion   // newest first
+	inEffect  map[string]int // revision commit -> index into versions of the value it holds
+	revisions []Revision     // every revision of the file, newest first
+}
+
+// Resolve parses the version spec (sopsversion) and resolves it to an opaque
+// VersionRef holding the full commit hash (or "" for the working tree). A
+// #COMMIT prefix must match exactly one revision of the file; a ~shift counts
+// back over the key's versions from the one in effect at that commit (or from
+// the newest).
+func (s *Store) Resolve(ctx context.Context, name, spec string) (provider.VersionRef, error) {
+	parsed, err := sopsversion.Parse(name + spec)
+	if err != nil {
+		return provider.VersionRef{}, err
+	}
+
+	if parsed.Absolute.Commit == nil && !parsed.HasShift() {
+		return provider.NewVersionRef(""), nil
+	}
+
+	hist, err := s.keyHistory(ctx, name)
+	if err != nil {
+		return provider.VersionRef{}, err
+	}
+
+	baseIdx := 0
+
+	if parsed.Absolute.Commit != nil {
+		rev, err := matchRevision(hist.revisions, *parsed.Absolute.Commit)
+		if err != nil {
+			return provider.VersionRef{}, err
+		}
+
+		if !parsed.HasShift() {
+			return provider.NewVersionRef(rev.Commit), nil
+		}
+
+		idx, ok := hist.inEffect[rev.Commit]
+		if !ok {
+			return provider.VersionRef{}, fmt.Errorf("%w: %s has no value at %s", provider.ErrNotFound, name, shortCommit(rev.Commit))
+		}
+
+		baseIdx = idx
+	}
+
+	if len(hist.versions) == 0 {
+		return provider.VersionRef{}, fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+	}
+
+	targetIdx := baseIdx + parsed.Shift
+	if targetIdx < 0 || targetIdx >= len(hist.versions) {
+		return provider.VersionRef{}, fmt.Errorf("version shift out of range: ~%d", parsed.Shift)
+	}
+
+	return provider.NewVersionRef(hist.versions[targetIdx].commit), nil
+}
+
+// Get reads the key from the working file (latest ref) or from the file as
+// committed at the ref's commit. A missing file or key yields a wrapped
+// provider.ErrNotFound.
+func (s *Store) Get(ctx context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+	if ref.IsLatest() {
+		doc, modified, err := s.current()
+		if err != nil {
+			return nil, err
+		}
+
+		value, ok := lookup(doc, name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+		}
+
+		return &domain.Entry{Name: name, Value: value, Type: domain.ValueTypeSecret, Modified: modified}, nil
+	}
+
+	doc, err := s.revision(ctx, ref.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := lookup(doc, name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s at %s", provider.ErrNotFound, name, shortCommit(ref.ID()))
+	}
+
+	entry := &domain.Entry{Name: name, Value: value, Type: domain.ValueTypeSecret, Version: domain.Version{ID: shortCommit(ref.ID())}}
+
+	// The commit time is a cheap extra lookup; omit it rather than fail.
+	if revs, err := s.history.Revisions(ctx, s.path); err == nil {
+		if i := slices.IndexFunc(revs, func(r Revision) bool { return r.Commit == ref.ID() }); i >= 0 {
+			entry.Version.Created = &revs[i].Time
+			entry.Modified = &revs[i].Time
+		}
+	}
+
+	return entry, nil
+}
+
+// History returns the key's versions, newest first. A key that never had a
+// value yields a wrapped provider.ErrNotFound.
+func (s *Store) History(ctx context.Context, name string) ([]domain.Version, error) {
+	hist, err := s.keyHistory(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hist.versions) == 0 {
+		return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+	}
+
+	versions := make([]domain.Version, 0, len(hist.versions))
+	for _, v := range hist.versions {
+		versions = append(versions, domain.Version{ID: v.id(), Created: &v.time})
+	}
+
+	return versions, nil
+}
+
+// List returns the dotted paths of every leaf in the working file, in document
+// order. A missing file yields no names.
+func (s *Store) List(context.Context) ([]string, error) {
+	doc, _, err := s.current()
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	leaves := doc.Leaves()
+
+	names := make([]string, 0, len(leaves))
+	for _, l := range leaves {
+		names = append(names, l.Key)
+	}
+
+	return names, nil
+}
+
+// Create adds a new key, failing with a wrapped provider.ErrAlreadyExists when
+// it already has a value. The valueType and description are ignored.
+func (s *Store) Create(
+	_ context.Context, name, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+) (domain.Version, error) {
+	return s.update(name, func(doc *sopsfile.Document) error {
+		if _, ok := doc.Get(name); ok {
+			return fmt.Errorf("%w: %s", provider.ErrAlreadyExists, name)
+		}
+
+		return doc.Set(name, value)
+	})
+}
+
+// Put sets the key, creating it (and the file) when absent. An existing leaf
+// keeps its YAML type when the value still parses as it. The valueType and
+// description are ignored.
+func (s *Store) Put(
+	_ context.Context, name, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+) (domain.Version, error) {
+	return s.update(name, func(doc *sopsfile.Document) error {
+		return doc.Set(name, value)
+	})
+}
+
+// Delete removes the key from the file, pruning mappings it leaves empty.
+// provider.DeleteOptions are ignored.
+func (s *Store) Delete(_ context.Context, name string, _ ...provider.DeleteOption) error {
+	_, err := s.update(name, func(doc *sopsfile.Document) error {
+		if !doc.Delete(name) {
+			return fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// Tag fails with ErrTagsUnsupported unless there is nothing to add.
+func (s *Store) Tag(_ context.Context, _ string, add map[string]string) error {
+	if len(add) == 0 {
+		return nil
+	}
+
+	return ErrTagsUnsupported
+}
+
+// Untag fails with ErrTagsUnsupported unless there is nothing to remove.
+func (s *Store) Untag(_ context.Context, _ string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return ErrTagsUnsupported
+}
+
+// update applies fn to the decrypted working file (a new empty document when
+// the file does not exist) and writes the re-encrypted result back atomically.
+func (s *Store) update(name string, fn func(doc *sopsfile.Document) error) (domain.Version, error) {
+	if !validKey(name) {
+		return domain.Version{}, fmt.Errorf("%w: %q", ErrInvalidKey, name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, _, err := s.current()
+	if err != nil {
+		return domain.Version{}, err
+	}
+
+	mode := newFileMode
+
+	if doc == nil {
+		if len(s.keys.Recipients) == 0 {
+			return domain.Version{}, ErrNoRecipients
+		}
+
+		if doc, err = sopsfile.New(s.format, s.keys.Recipients); err != nil {
+			return domain.Version{}, err
+		}
+	} else if info, err := os.Stat(s.path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	if err := fn(doc); err != nil {
+		return domain.Version{}, err
+	}
+
+	now := s.now()
+
+	data, err := doc.Marshal(now)
+	if err != nil {
+		return domain.Version{}, fmt.Errorf("failed to encrypt %s: %w", s.path, err)
+	}
+
+	if err := writeFileAtomic(s.path, data, mode); err != nil {
+		return domain.Version{}, err
+	}
+
+	return domain.Version{ID: WorktreeVersion, Created: &now}, nil
+}
+
+// current decrypts the working file. A missing file yields a nil document and
+// no error.
+func (s *Store) current() (*sopsfile.Document, *time.Time, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, nil
+		}
+
+		return nil, nil, fmt.Errorf("failed to read SOPS file: %w", err)
+	}
+
+	doc, err := sopsfile.Decrypt(data, s.format, s.keys.Identities)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt %s: %w", s.path, err)
+	}
+
+	var modified *time.Time
+	if info, err := os.Stat(s.path); err == nil {
+		modified = lo.ToPtr(info.ModTime())
+	}
+
+	return doc, modified, nil
+}
+
+// revision decrypts the file as committed at commit, caching the result.
+func (s *Store) revision(ctx context.Context, commit string) (*sopsfile.Document, error) {
+	s.mu.Lock()
+	cached, ok := s.revisions[commit]
+	s.mu.Unlock()
+
+	if ok {
+		return cached.doc, cached.err
+	}
+
+	data, err := s.history.Content(ctx, s.path, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := sopsfile.Decrypt(data, s.format, s.keys.Identities)
+	if err != nil {
+		err = fmt.Errorf("failed to decrypt %s at %s: %w", s.path, shortCommit(commit), err)
+	}
+
+	s.mu.Lock()
+	s.revisions[commit] = decrypted{doc: doc, err: err}
+	s.mu.Unlock()
+
+	return doc, err
+}
+
+// keyHistory walks the file's revisions oldest to newest, recording a version
+// each time the key's value changes, then adds the working-tree version when it
+// differs from the last committed value.
+func (s *Store) keyHistory(ctx context.Context, name string) (*keyHistory, error) {
+	revs, err := s.history.Revisions(ctx, s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git history of %s: %w", s.path, err)
+	}
+
+	var (
+		versions []keyVersion // oldest first while building
+		last     *string
+	)
+
+	inEffect := map[string]int{}
+
+	for _, rev := range slices.Backward(revs) {
+		doc, err := s.revision(ctx, rev.Commit)
+		if err != nil {
+			continue // e.g. encrypted to keys we no longer hold
+		}
+
+		value, ok := doc.Get(name)
+
+		switch {
+		case !ok:
+			last = nil
+
+			continue
+		case last == nil || *last != value:
+			versions = append(versions, keyVersion{commit: rev.Commit, time: rev.Time})
+			last = &value
+		}
+
+		inEffect[rev.Commit] = len(versions) - 1
+	}
+
+	doc, modified, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+
+	if value, ok := lookup(doc, name); ok && (last == nil || *last != value) {
+		v := keyVersion{}
+		if modified != nil {
+			v.time = *modified
+		}
+
+		versions = append(versions, v)
+	}
+
+	// Flip to newest first, and the in-effect indices with it.
+	slices.Reverse(versions)
+
+	for commit, idx := range inEffect {
+		inEffect[commit] = len(versions) - 1 - idx
+	}
+
+	return &keyHistory{versions: versions, inEffect: inEffect, revisions: revs}, nil
+}
+
+// matchRevision finds the single revision whose hash starts with prefix.
+func matchRevision(revs []Revision, prefix string) (Revision, error) {
+	prefix = strings.ToLower(prefix)
+
+	var matches []Revision
+
+	for _, r := range revs {
+		if strings.HasPrefix(r.Commit, prefix) {
+			matches = append(matches, r)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Revision{}, fmt.Errorf("version not found: %s (no commit of the file matches)", prefix)
+	case 1:
+		return matches[0], nil
+	default:
+		return Revision{}, fmt.Errorf("ambiguous commit prefix %s matches %d commits of the file", prefix, len(matches))
+	}
+}
+
+// lookup reads a key from a possibly nil document.
+func lookup(doc *sopsfile.Document, name string) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+
+	return doc.Get(name)
+}
+
+// validKey reports whether name is a dotted path of non-empty segments.
+func validKey(name string) bool {
+	return name != "" && !slices.Contains(strings.Split(name, sopsfile.KeySeparator), "")
+}
+
+// shortCommit abbreviates a commit hash for display.
+func shortCommit(commit string) string {
+	if len(commit) > shortCommitLen {
+		return commit[:shortCommitLen]
+	}
+
+	return commit
+}
+
+// writeFileAtomic writes data to a temp file in the target directory and
+// renames it over path, so readers never see a partially written file.
+func writeFileAtomic(path string, data []byte, mode fs.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	tmpName := tmp.Name()
+
+	// Best-effort cleanup of the temp file if we bail out before renaming.
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	if err := tmp.Chmod(mode); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("failed to set file mode: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
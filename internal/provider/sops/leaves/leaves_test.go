@@ -0,0 +1,311 @@
+package leaves_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/sops/leaves"
+	"github.com/mpyw/suve/internal/provider/sops/sopsfile"
+	"github.com/mpyw/suve/internal/version/sopsversion"
+)
+
+// fakeHistory serves committed revisions from memory, newest first.
+type fakeHistory struct {
+	revs    []leaves.Revision
+	content map[string][]byte
+}
+
+func (h *fakeHistory) Revisions(context.Context, string) ([]leaves.Revision, error) {
+	return h.revs, nil
+}
+
+func (h *fakeHistory) Content(_ context.Context, _, commit string) ([]byte, error) {
+	data, ok := h.content[commit]
+	if !ok {
+		return nil, fmt.Errorf("unknown commit %s", commit)
+	}
+
+	return data, nil
+}
+
+// commit records the current file content as a new newest revision.
+func (h *fakeHistory) commit(t *testing.T, path, hash string, at time.Time) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	if h.content == nil {
+		h.content = map[string][]byte{}
+	}
+
+	h.content[hash] = data
+	h.revs = append([]leaves.Revision{{Commit: hash, Time: at}}, h.revs...)
+}
+
+func newStore(t *testing.T, name string, history leaves.History) (*leaves.Store, string, *age.X25519Identity) {
+	t.Helper()
+
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), name)
+	keys := leaves.Keys{Identities: []age.Identity{id}, Recipients: []age.Recipient{id.Recipient()}}
+
+	return leaves.New(path, keys, history), path, id
+}
+
+func TestStore_CreateGetList(t *testing.T) {
+	t.Parallel()
+
+	store, path, id := newStore(t, "secrets.yaml", &fakeHistory{})
+
+	names, err := store.List(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, names, "a missing file has no keys")
+
+	_, err = store.Create(t.Context(), "db.password", "pw", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	_, err = store.Create(t.Context(), "db.user", "admin", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	_, err = store.Create(t.Context(), "db.user", "other", domain.ValueTypeSecret, "")
+	require.ErrorIs(t, err, provider.ErrAlreadyExists)
+
+	_, err = store.Create(t.Context(), "db..user", "x", domain.ValueTypeSecret, "")
+	require.ErrorIs(t, err, leaves.ErrInvalidKey)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "admin")
+
+	doc, err := sopsfile.Decrypt(data, sopsfile.FormatYAML, []age.Identity{id})
+	require.NoError(t, err, "the file stays readable with the recipient's identity")
+	assert.Len(t, doc.Leaves(), 2)
+
+	entry, err := store.Get(t.Context(), "db.user", provider.VersionRef{})
+	require.NoError(t, err)
+	assert.Equal(t, "admin", entry.Value)
+	assert.Equal(t, domain.ValueTypeSecret, entry.Type)
+	assert.NotNil(t, entry.Modified)
+
+	_, err = store.Get(t.Context(), "db.missing", provider.VersionRef{})
+	require.ErrorIs(t, err, provider.ErrNotFound)
+
+	names, err = store.List(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db.password", "db.user"}, names)
+}
+
+func TestStore_PutAndDelete(t *testing.T) {
+	t.Parallel()
+
+	store, path, _ := newStore(t, "secrets.json", &fakeHistory{})
+
+	_, err := store.Put(t.Context(), "api.token", "t1", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	require.NoError(t, os.Chmod(path, 0o640))
+
+	_, err = store.Put(t.Context(), "api.token", "t2", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm(), "rewrites keep the file mode")
+
+	entry, err := store.Get(t.Context(), "api.token", provider.VersionRef{})
+	require.NoError(t, err)
+	assert.Equal(t, "t2", entry.Value)
+
+	require.NoError(t, store.Delete(t.Context(), "api.token"))
+	require.ErrorIs(t, store.Delete(t.Context(), "api.token"), provider.ErrNotFound)
+
+	names, err := store.List(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestStore_NoRecipientsForNewFile(t *testing.T) {
+	t.Parallel()
+
+	store := leaves.New(filepath.Join(t.TempDir(), "new.yaml"), leaves.Keys{}, &fakeHistory{})
+
+	_, err := store.Put(t.Context(), "a", "b", domain.ValueTypeSecret, "")
+	require.ErrorIs(t, err, leaves.ErrNoRecipients)
+}
+
+func TestStore_Tags(t *testing.T) {
+	t.Parallel()
+
+	store, _, _ := newStore(t, "secrets.yaml", &fakeHistory{})
+
+	require.NoError(t, store.Tag(t.Context(), "a", nil))
+	require.NoError(t, store.Untag(t.Context(), "a", nil))
+	require.ErrorIs(t, store.Tag(t.Context(), "a", map[string]string{"k": "v"}), leaves.ErrTagsUnsupported)
+	require.ErrorIs(t, store.Untag(t.Context(), "a", []string{"k"}), leaves.ErrTagsUnsupported)
+}
+
+func TestStore_History(t *testing.T) {
+	t.Parallel()
+
+	history := &fakeHistory{}
+	store, path, _ := newStore(t, "secrets.yaml", history)
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	put := func(key, value string) {
+		_, err := store.Put(t.Context(), key, value, domain.ValueTypeSecret, "")
+		require.NoError(t, err)
+	}
+
+	put("a", "1")
+	history.commit(t, path, "aaaa000000000000000000000000000000000001", t0)
+	put("b", "x") // a unchanged in this commit
+	history.commit(t, path, "bbbb000000000000000000000000000000000002", t0.Add(time.Hour))
+	put("a", "2")
+	history.commit(t, path, "cccc000000000000000000000000000000000003", t0.Add(2*time.Hour))
+	put("a", "3") // uncommitted
+
+	versions, err := store.History(t.Context(), "a")
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, leaves.WorktreeVersion, versions[0].ID)
+	assert.Equal(t, "cccc00000000", versions[1].ID)
+	assert.Equal(t, "aaaa00000000", versions[2].ID)
+	assert.Equal(t, t0, *versions[2].Created)
+
+	get := func(spec string) string {
+		t.Helper()
+
+		ref, err := store.Resolve(t.Context(), "a", spec)
+		require.NoError(t, err)
+
+		entry, err := store.Get(t.Context(), "a", ref)
+		require.NoError(t, err)
+
+		return entry.Value
+	}
+
+	assert.Equal(t, "3", get(""))
+	assert.Equal(t, "2", get("~"))
+	assert.Equal(t, "1", get("~2"))
+	assert.Equal(t, "1", get("#bbbb"), "a commit that did not touch the key still shows its value")
+	assert.Equal(t, "2", get("#CCCC"))
+	assert.Equal(t, "1", get("#cccc~1"))
+
+	_, err = store.Resolve(t.Context(), "a", "~3")
+	require.ErrorContains(t, err, "out of range")
+
+	_, err = store.Resolve(t.Context(), "a", "#dddd")
+	require.ErrorContains(t, err, "version not found")
+
+	_, err = store.Resolve(t.Context(), "a", ":label")
+	require.ErrorIs(t, err, sopsversion.ErrLabelUnsupported)
+
+	_, err = store.Resolve(t.Context(), "b", "#aaaa~1")
+	require.ErrorIs(t, err, provider.ErrNotFound, "b had no value yet at the first commit")
+
+	versions, err = store.History(t.Context(), "b")
+	require.NoError(t, err)
+	assert.Len(t, versions, 1, "an unchanged committed value has no worktree version")
+
+	_, err = store.History(t.Context(), "never")
+	require.ErrorIs(t, err, provider.ErrNotFound)
+}
+
+func TestStore_HistorySkipsUndecryptableRevisions(t *testing.T) {
+	t.Parallel()
+
+	other, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	oldPath := filepath.Join(t.TempDir(), "old.yaml")
+	oldStore := leaves.New(oldPath, leaves.Keys{Identities: []age.Identity{other}, Recipients: []age.Recipient{other.Recipient()}}, &fakeHistory{})
+
+	_, err = oldStore.Put(t.Context(), "a", "old", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	old, err := os.ReadFile(oldPath)
+	require.NoError(t, err)
+
+	history := &fakeHistory{content: map[string][]byte{"0001": old}, revs: []leaves.Revision{{Commit: "0001"}}}
+	store, _, _ := newStore(t, "secrets.yaml", history)
+
+	_, err = store.Put(t.Context(), "a", "new", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	versions, err := store.History(t.Context(), "a")
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, leaves.WorktreeVersion, versions[0].ID)
+
+	_, err = store.Get(t.Context(), "a", provider.NewVersionRef("0001"))
+	require.ErrorIs(t, err, sopsfile.ErrNoMatchingIdentity)
+}
+
+func TestGit(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secrets.yaml")
+
+	revs, err := leaves.Git{}.Revisions(t.Context(), path)
+	require.NoError(t, err)
+	assert.Empty(t, revs, "outside a repository there is no history")
+
+	git := func(args ...string) {
+		t.Helper()
+
+		cmd := exec.CommandContext(t.Context(), "git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	git("init", "-q")
+
+	revs, err = leaves.Git{}.Revisions(t.Context(), path)
+	require.NoError(t, err)
+	assert.Empty(t, revs, "a repository without commits has no history")
+
+	for _, content := range []string{"v1\n", "v2\n"} {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		git("add", "secrets.yaml")
+		git("commit", "-q", "-m", content)
+	}
+
+	revs, err = leaves.Git{}.Revisions(t.Context(), path)
+	require.NoError(t, err)
+	require.Len(t, revs, 2)
+
+	data, err := leaves.Git{}.Content(t.Context(), path, revs[1].Commit)
+	require.NoError(t, err)
+	assert.Equal(t, "v1\n", string(data))
+
+	_, err = leaves.Git{}.Content(t.Context(), path, "0000000")
+	require.ErrorContains(t, err, "git show")
+}
@@ -0,0 +1,175 @@
+// Package sops wires the SOPS file adapter into a provider.Factory /
+// provider.Registry. It loads age keys the way the sops CLI does and hands them
+// to the leaves subpackage, which exposes the file's leaf keys as secrets.
+//
+// Identities (to decrypt) come from SOPS_AGE_KEY, then SOPS_AGE_KEY_FILE, then
+// the sops default key file ($XDG_CONFIG_HOME/sops/age/keys.txt, or the OS
+// user config directory). Recipients are only needed to create a new file and
+// come from SOPS_AGE_RECIPIENTS; an existing file is always re-encrypted to its
+// own recipients.
+//
+// A SOPS file offers no parameter store, so the factory returns
+// provider.ErrUnsupportedKind for KindParam.
+package sops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/sops/leaves"
+)
+
+// Environment variables read by the factory. The SOPS_AGE_* names are the sops
+// CLI's own.
+const (
+	// FileEnvVar names the default SOPS file. When it is set, SOPS counts as an
+	// active provider for the bare aliases.
+	FileEnvVar = "SUVE_SOPS_FILE"
+	// AgeKeyEnvVar holds age identities inline.
+	AgeKeyEnvVar = "SOPS_AGE_KEY"
+	// AgeKeyFileEnvVar points at an age identity file.
+	AgeKeyFileEnvVar = "SOPS_AGE_KEY_FILE"
+	// AgeRecipientsEnvVar lists the comma-separated age recipients a new file is
+	// encrypted to.
+	AgeRecipientsEnvVar = "SOPS_AGE_RECIPIENTS"
+)
+
+// Factory builds SOPS-backed provider.Store values for a scope + kind.
+type Factory struct{}
+
+// Compile-time assertion that Factory implements provider.Factory.
+var _ provider.Factory = Factory{}
+
+// Store builds a Store for the file at scope.SOPSFile. SOPS supports only
+// KindSecret; KindParam yields provider.ErrUnsupportedKind.
+func (Factory) Store(_ context.Context, scope provider.Scope, kind provider.Kind) (provider.Store, error) {
+	switch kind {
+	case provider.KindSecret:
+		if scope.SOPSFile == "" {
+			return nil, fmt.Errorf("no SOPS file specified: set --file or %s", FileEnvVar)
+		}
+
+		keys, err := loadKeys()
+		if err != nil {
+			return nil, err
+		}
+
+		return leaves.New(scope.SOPSFile, keys, leaves.Git{}), nil
+	case provider.KindParam:
+		return nil, fmt.Errorf("%w: %s (a SOPS file has no parameter store)", provider.ErrUnsupportedKind, kind)
+	default:
+		return nil, fmt.Errorf("%w: %s", provider.ErrUnsupportedKind, kind)
+	}
+}
+
+// Register associates the SOPS Factory with provider.ProviderSOPS in reg.
+func Register(reg *provider.Registry) {
+	reg.Register(provider.ProviderSOPS, Factory{})
+}
+
+// ResolveScope returns the scope for file, falling back to SUVE_SOPS_FILE. The
+// path is made absolute so the same file is always staged under the same scope,
+// whatever the working directory.
+func ResolveScope(file string) (provider.Scope, error) {
+	if file == "" {
+		file = os.Getenv(FileEnvVar)
+	}
+
+	if file == "" {
+		return provider.Scope{}, fmt.Errorf("no SOPS file specified: set --file or %s", FileEnvVar)
+	}
+
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return provider.Scope{}, fmt.Errorf("failed to resolve SOPS file path: %w", err)
+	}
+
+	return provider.SOPSScope(abs), nil
+}
+
+// loadKeys reads the age identities and recipients from the environment.
+func loadKeys() (leaves.Keys, error) {
+	identities, err := loadIdentities()
+	if err != nil {
+		return leaves.Keys{}, err
+	}
+
+	var recipients []age.Recipient
+
+	for r := range strings.SplitSeq(os.Getenv(AgeRecipientsEnvVar), ",") {
+		if r = strings.TrimSpace(r); r == "" {
+			continue
+		}
+
+		parsed, err := age.ParseX25519Recipient(r)
+		if err != nil {
+			return leaves.Keys{}, fmt.Errorf("invalid %s: %w", AgeRecipientsEnvVar, err)
+		}
+
+		recipients = append(recipients, parsed)
+	}
+
+	return leaves.Keys{Identities: identities, Recipients: recipients}, nil
+}
+
+// loadIdentities returns the age identities from the first configured source.
+// No source at all is not an error here: decrypting then fails with a clear
+// "no age identity" message, while creating a new file still works.
+func loadIdentities() ([]age.Identity, error) {
+	if inline := os.Getenv(AgeKeyEnvVar); inline != "" {
+		ids, err := age.ParseIdentities(strings.NewReader(inline))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", AgeKeyEnvVar, err)
+		}
+
+		return ids, nil
+	}
+
+	path := os.Getenv(AgeKeyFileEnvVar)
+	explicit := path != ""
+
+	if !explicit {
+		dir, err := configDir()
+		if err != nil {
+			return nil, nil //nolint:nilerr // no config directory: no default key file
+		}
+
+		path = filepath.Join(dir, "sops", "age", "keys.txt")
+	}
+
+	// The path is the user's own age key file; reading it is intentional.
+	f, err := os.Open(path) //nolint:gosec // user-owned age key file by design
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to open age key file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	ids, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid age key file %s: %w", path, err)
+	}
+
+	return ids, nil
+}
+
+// configDir is where the sops CLI looks for its default age key file:
+// $XDG_CONFIG_HOME when set (on every OS), else the OS user config directory.
+func configDir() (string, error) {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return xdg, nil
+	}
+
+	return os.UserConfigDir()
+}
@@ -0,0 +1,154 @@
+package sops_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/sops"
+)
+
+// isolate points every key source at an empty temp config so the developer's
+// own age keys never leak into a test.
+func isolate(t *testing.T) {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(sops.AgeKeyEnvVar, "")
+	t.Setenv(sops.AgeKeyFileEnvVar, "")
+	t.Setenv(sops.AgeRecipientsEnvVar, "")
+	t.Setenv(sops.FileEnvVar, "")
+}
+
+func TestResolveScope(t *testing.T) {
+	// Cannot use t.Parallel() because subtests use t.Setenv
+	t.Run("explicit path is made absolute", func(t *testing.T) {
+		isolate(t)
+		t.Chdir(t.TempDir())
+
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+
+		scope, err := sops.ResolveScope("secrets.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, provider.SOPSScope(filepath.Join(wd, "secrets.yaml")), scope)
+	})
+
+	t.Run("falls back to the env var", func(t *testing.T) {
+		isolate(t)
+		t.Setenv(sops.FileEnvVar, "/repo/secrets.json")
+
+		scope, err := sops.ResolveScope("")
+		require.NoError(t, err)
+		assert.Equal(t, "/repo/secrets.json", scope.SOPSFile)
+	})
+
+	t.Run("no file", func(t *testing.T) {
+		isolate(t)
+
+		_, err := sops.ResolveScope("")
+		require.ErrorContains(t, err, sops.FileEnvVar)
+	})
+}
+
+func TestFactory_Store(t *testing.T) {
+	// Cannot use t.Parallel() because subtests use t.Setenv
+	t.Run("unsupported kind", func(t *testing.T) {
+		isolate(t)
+
+		for _, kind := range []provider.Kind{provider.KindParam, provider.Kind("bogus")} {
+			store, err := sops.Factory{}.Store(t.Context(), provider.SOPSScope("/repo/secrets.yaml"), kind)
+			require.ErrorIs(t, err, provider.ErrUnsupportedKind)
+			assert.Nil(t, store)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		isolate(t)
+
+		_, err := sops.Factory{}.Store(t.Context(), provider.SOPSScope(""), provider.KindSecret)
+		require.ErrorContains(t, err, "--file")
+	})
+
+	t.Run("round trip with keys from the default key file", func(t *testing.T) {
+		isolate(t)
+
+		id, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+
+		keyDir := filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "sops", "age")
+		require.NoError(t, os.MkdirAll(keyDir, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(keyDir, "keys.txt"), []byte("# test\n"+id.String()+"\n"), 0o600))
+		t.Setenv(sops.AgeRecipientsEnvVar, " "+id.Recipient().String()+" ,")
+
+		reg := provider.NewRegistry()
+		sops.Register(reg)
+
+		scope := provider.SOPSScope(filepath.Join(t.TempDir(), "secrets.yaml"))
+
+		store, err := reg.Store(t.Context(), scope, provider.KindSecret)
+		require.NoError(t, err)
+
+		_, err = store.Put(t.Context(), "db.password", "pw", domain.ValueTypeSecret, "")
+		require.NoError(t, err)
+
+		entry, err := store.Get(t.Context(), "db.password", provider.VersionRef{})
+		require.NoError(t, err)
+		assert.Equal(t, "pw", entry.Value)
+	})
+
+	t.Run("inline key without a matching identity", func(t *testing.T) {
+		isolate(t)
+
+		owner, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+
+		other, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+
+		path := filepath.Join(t.TempDir(), "secrets.yaml")
+
+		t.Setenv(sops.AgeKeyEnvVar, owner.String())
+		t.Setenv(sops.AgeRecipientsEnvVar, owner.Recipient().String())
+
+		store, err := sops.Factory{}.Store(t.Context(), provider.SOPSScope(path), provider.KindSecret)
+		require.NoError(t, err)
+
+		_, err = store.Put(t.Context(), "a", "b", domain.ValueTypeSecret, "")
+		require.NoError(t, err)
+
+		t.Setenv(sops.AgeKeyEnvVar, other.String())
+
+		store, err = sops.Factory{}.Store(t.Context(), provider.SOPSScope(path), provider.KindSecret)
+		require.NoError(t, err)
+
+		_, err = store.Get(t.Context(), "a", provider.VersionRef{})
+		require.ErrorContains(t, err, "no age identity can decrypt this file")
+	})
+
+	t.Run("invalid key sources", func(t *testing.T) {
+		isolate(t)
+		t.Setenv(sops.AgeKeyEnvVar, "not-a-key")
+
+		_, err := sops.Factory{}.Store(t.Context(), provider.SOPSScope("/repo/secrets.yaml"), provider.KindSecret)
+		require.ErrorContains(t, err, sops.AgeKeyEnvVar)
+
+		t.Setenv(sops.AgeKeyEnvVar, "")
+		t.Setenv(sops.AgeKeyFileEnvVar, filepath.Join(t.TempDir(), "missing.txt"))
+
+		_, err = sops.Factory{}.Store(t.Context(), provider.SOPSScope("/repo/secrets.yaml"), provider.KindSecret)
+		require.ErrorContains(t, err, "failed to open age key file")
+
+		t.Setenv(sops.AgeKeyFileEnvVar, "")
+		t.Setenv(sops.AgeRecipientsEnvVar, "age1bogus")
+
+		_, err = sops.Factory{}.Store(t.Context(), provider.SOPSScope("/repo/secrets.yaml"), provider.KindSecret)
+		require.ErrorContains(t, err, sops.AgeRecipientsEnvVar)
+	})
+}
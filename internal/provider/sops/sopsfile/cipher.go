@@ -0,0 +1,116 @@
+package sopsfile
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// nonceSize is the GCM nonce length SOPS uses (32 bytes rather than the usual
+// 12), so values written here stay readable by the sops CLI and vice versa.
+const nonceSize = 32
+
+// Value type markers carried in the type: field of an ENC[...] string.
+const (
+	typeStr   = "str"
+	typeInt   = "int"
+	typeFloat = "float"
+	typeBool  = "bool"
+	typeBytes = "bytes"
+)
+
+// encPattern matches an encrypted SOPS value.
+var encPattern = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.+),iv:(.+),tag:(.+),type:(.+)\]$`)
+
+// errMalformedValue is returned when a value that should be encrypted does not
+// have the ENC[AES256_GCM,...] shape.
+var errMalformedValue = errors.New("value is not in SOPS encrypted format")
+
+// encryptValue seals plaintext under key with additionalData as AAD and
+// renders it in SOPS' ENC[AES256_GCM,data:...,iv:...,tag:...,type:...] form.
+// An empty plaintext stays empty, as in SOPS.
+func encryptValue(plaintext []byte, typ string, key []byte, additionalData string) (string, error) {
+	if len(plaintext) == 0 {
+		return "", nil
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	iv := make([]byte, nonceSize)
+	if _, err := rand.Read(iv); err != nil {
+		return "", fmt.Errorf("failed to generate IV: %w", err)
+	}
+
+	sealed := gcm.Seal(nil, iv, plaintext, []byte(additionalData))
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+
+	return fmt.Sprintf("ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:%s]",
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(tag),
+		typ,
+	), nil
+}
+
+// decryptValue opens an ENC[...] string and returns the plaintext and its type
+// marker. An empty value decrypts to an empty string.
+func decryptValue(value string, key []byte, additionalData string) ([]byte, string, error) {
+	if value == "" {
+		return nil, typeStr, nil
+	}
+
+	m := encPattern.FindStringSubmatch(value)
+	if m == nil {
+		return nil, "", errMalformedValue
+	}
+
+	var parts [3][]byte
+
+	for i, s := range m[1:4] {
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %w", errMalformedValue, err)
+		}
+
+		parts[i] = b
+	}
+
+	data, iv, tag := parts[0], parts[1], parts[2]
+	if len(iv) != nonceSize {
+		return nil, "", fmt.Errorf("%w: IV must be %d bytes", errMalformedValue, nonceSize)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	plaintext, err := gcm.Open(nil, iv, append(data, tag...), []byte(additionalData))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+
+	return plaintext, m[4], nil
+}
+
+// newGCM builds the AES-256-GCM AEAD with SOPS' nonce size.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data key: %w", err)
+	}
+
+	gcm, err := cipher.NewGCMWithNonceSize(block, nonceSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data key: %w", err)
+	}
+
+	return gcm, nil
+}
@@ -0,0 +1,159 @@
+package sopsfile_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider/sops/sopsfile"
+)
+
+// The files in testdata were written by the sops CLI (3.10.2), not by this
+// package:
+//
+//	sops encrypt --age <recipient of testdata/age.key> plain.yaml > testdata/secrets.yaml
+//	sops encrypt --age <recipient of testdata/age.key> plain.json > testdata/secrets.json
+//
+// testdata/age.key is a throwaway identity made for these fixtures only.
+
+//nolint:gochecknoglobals // fixtures shared by tests
+var fixtures = []struct {
+	name   string
+	format sopsfile.Format
+}{
+	{name: "secrets.yaml", format: sopsfile.FormatYAML},
+	{name: "secrets.json", format: sopsfile.FormatJSON},
+}
+
+//nolint:gochecknoglobals // plaintext the fixtures were encrypted from
+var fixtureLeaves = []sopsfile.Leaf{
+	{Key: "db.user", Value: "admin"},
+	{Key: "db.password", Value: "s3cr3t"},
+	{Key: "db.port", Value: "5432"},
+	{Key: "db.replica.host", Value: "db-ro.internal"},
+	{Key: "api.token", Value: "tok-123"},
+	{Key: "api.enabled", Value: "true"},
+	{Key: "comment_unencrypted", Value: "left in clear"},
+}
+
+func fixtureIdentities(t *testing.T) []age.Identity {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", "age.key"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = f.Close() })
+
+	ids, err := age.ParseIdentities(f)
+	require.NoError(t, err)
+
+	return ids
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestDecrypt_SOPSFixtures(t *testing.T) {
+	t.Parallel()
+
+	for _, fx := range fixtures {
+		t.Run(fx.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := sopsfile.Decrypt(readFixture(t, fx.name), fx.format, fixtureIdentities(t))
+			require.NoError(t, err, "values decrypt and the MAC written by sops verifies")
+			assert.Equal(t, fixtureLeaves, doc.Leaves())
+		})
+	}
+}
+
+func TestDecrypt_SOPSFixtures_MACMismatch(t *testing.T) {
+	t.Parallel()
+
+	for _, fx := range fixtures {
+		t.Run(fx.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The unencrypted value is covered by the MAC even though it is not
+			// encrypted, so changing it in place must be caught.
+			edited := strings.Replace(string(readFixture(t, fx.name)), "left in clear", "edited in clear", 1)
+
+			_, err := sopsfile.Decrypt([]byte(edited), fx.format, fixtureIdentities(t))
+			require.ErrorIs(t, err, sopsfile.ErrMACMismatch)
+		})
+	}
+}
+
+func TestMarshal_SOPSFixtures_WriteBack(t *testing.T) {
+	t.Parallel()
+
+	sops, lookErr := exec.LookPath("sops")
+
+	for _, fx := range fixtures {
+		t.Run(fx.name, func(t *testing.T) {
+			t.Parallel()
+
+			ids := fixtureIdentities(t)
+
+			doc, err := sopsfile.Decrypt(readFixture(t, fx.name), fx.format, ids)
+			require.NoError(t, err)
+
+			require.NoError(t, doc.Set("db.password", "rotated"))
+			require.NoError(t, doc.Set("db.replica.port", "6543"))
+			require.NoError(t, doc.Set("new.nested.key", "v"))
+			assert.True(t, doc.Delete("api.token"))
+
+			out, err := doc.Marshal(now)
+			require.NoError(t, err)
+			assert.NotContains(t, string(out), "rotated")
+			assert.Contains(t, string(out), "left in clear")
+
+			want := []sopsfile.Leaf{
+				{Key: "db.user", Value: "admin"},
+				{Key: "db.password", Value: "rotated"},
+				{Key: "db.port", Value: "5432"},
+				{Key: "db.replica.host", Value: "db-ro.internal"},
+				{Key: "db.replica.port", Value: "6543"},
+				{Key: "api.enabled", Value: "true"},
+				{Key: "comment_unencrypted", Value: "left in clear"},
+				{Key: "new.nested.key", Value: "v"},
+			}
+
+			doc, err = sopsfile.Decrypt(out, fx.format, ids)
+			require.NoError(t, err)
+			assert.Equal(t, want, doc.Leaves())
+
+			if lookErr != nil {
+				t.Log("sops is not installed; skipping the check that sops itself opens the written file")
+
+				return
+			}
+
+			path := filepath.Join(t.TempDir(), fx.name)
+			require.NoError(t, os.WriteFile(path, out, 0o600))
+
+			cmd := exec.CommandContext(t.Context(), sops, "decrypt", path)
+			cmd.Env = append(os.Environ(), "SOPS_AGE_KEY_FILE="+filepath.Join("testdata", "age.key"))
+			plain, err := cmd.Output()
+			require.NoError(t, err, "sops decrypts the written file and verifies its MAC")
+
+			for _, s := range []string{"rotated", "6543", "db-ro.internal", "left in clear"} {
+				assert.Contains(t, string(plain), s)
+			}
+
+			assert.NotContains(t, string(plain), "tok-123")
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package sopsfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// marshalJSON renders a node tree as tab-indented JSON, keeping mapping key
+// order (the sops CLI's JSON layout).
+func marshalJSON(root *yaml.Node) ([]byte, error) {
+	var compact bytes.Buffer
+	if err := writeJSON(&compact, root); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact.Bytes(), "", "\t"); err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	out.WriteByte('\n')
+
+	return out.Bytes(), nil
+}
+
+// writeJSON appends the compact JSON form of node to buf.
+func writeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			writeJSONString(buf, node.Content[i].Value)
+			buf.WriteByte(':')
+
+			if err := writeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+	case yaml.ScalarNode:
+		return writeJSONScalar(buf, node)
+	case yaml.DocumentNode, yaml.AliasNode:
+		return fmt.Errorf("cannot encode YAML node kind %d as JSON", node.Kind)
+	}
+
+	return nil
+}
+
+// writeJSONScalar appends a scalar as a JSON literal of its YAML type.
+func writeJSONScalar(buf *bytes.Buffer, node *yaml.Node) error {
+	var v any
+	if err := node.Decode(&v); err != nil {
+		return fmt.Errorf("failed to decode value %q: %w", node.Value, err)
+	}
+
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case int:
+		buf.WriteString(strconv.Itoa(v))
+	case int64:
+		buf.WriteString(strconv.FormatInt(v, 10))
+	case uint64:
+		buf.WriteString(strconv.FormatUint(v, 10))
+	case float64:
+		buf.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		writeJSONString(buf, node.Value)
+	}
+
+	return nil
+}
+
+// writeJSONString appends s as a JSON string without HTML escaping.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)           // encoding a string cannot fail
+	buf.Truncate(buf.Len() - 1) // drop the trailing newline Encode adds
+}
@@ -0,0 +1,528 @@
+// Package sopsfile reads and writes SOPS-encrypted YAML and JSON documents
+// whose data key is wrapped for age recipients, without depending on the sops
+// CLI or library.
+//
+// The on-disk format follows SOPS exactly so files round-trip with the sops
+// CLI:
+//
+//   - Every scalar leaf is sealed with AES-256-GCM under the file's 32-byte
+//     data key (32-byte IV, AAD = the mapping keys on the way down joined with
+//     ":" plus a trailing ":") and rendered as
+//     ENC[AES256_GCM,data:...,iv:...,tag:...,type:str|int|float|bool].
+//   - The top-level "sops" mapping carries the metadata: the data key wrapped
+//     for each age recipient (armored), lastmodified, and the MAC, a SHA-512
+//     over the plaintext of every leaf encrypted with lastmodified as AAD.
+//   - unencrypted_suffix, encrypted_suffix, unencrypted_regex and
+//     encrypted_regex select which keys are sealed; mac_only_encrypted limits
+//     the MAC to sealed leaves.
+//
+// Writing keeps the existing data key, so every recipient (and any PGP or
+// cloud KMS key group this package does not itself understand) can still
+// decrypt the result. Comments are preserved verbatim as SOPS left them.
+package sopsfile
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"go.yaml.in/yaml/v3"
+)
+
+// Format is the on-disk syntax of a SOPS file.
+type Format int
+
+// Supported formats.
+const (
+	FormatYAML Format = iota
+	FormatJSON
+)
+
+// FormatForPath picks the format from the file extension: ".json" is JSON and
+// anything else is YAML, matching the sops CLI's default.
+func FormatForPath(path string) Format {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return FormatJSON
+	}
+
+	return FormatYAML
+}
+
+const (
+	// metadataKey is the top-level key holding SOPS metadata.
+	metadataKey = "sops"
+	// defaultUnencryptedSuffix is the rule SOPS applies when a file sets none.
+	defaultUnencryptedSuffix = "_unencrypted"
+	// dataKeySize is the length of the AES-256 data key.
+	dataKeySize = 32
+	// metadataVersion is the SOPS format version recorded in new files.
+	metadataVersion = "3.9.0"
+	// yamlIndent matches the indentation the sops CLI emits.
+	yamlIndent = 4
+)
+
+// Errors returned when a file cannot be decrypted.
+var (
+	// ErrNotEncrypted is returned for a document without SOPS metadata.
+	ErrNotEncrypted = errors.New("file is not SOPS-encrypted (no top-level sops metadata)")
+	// ErrNoAgeRecipients is returned when the data key is not wrapped for any
+	// age recipient (e.g. a PGP- or KMS-only file).
+	ErrNoAgeRecipients = errors.New("file has no age recipients; only age-encrypted SOPS files are supported")
+	// ErrNoMatchingIdentity is returned when none of the configured age
+	// identities can unwrap the data key.
+	ErrNoMatchingIdentity = errors.New("no age identity can decrypt this file (set SOPS_AGE_KEY_FILE or SOPS_AGE_KEY)")
+	// ErrMACMismatch is returned when the decrypted content does not match the
+	// file's MAC, i.e. the file was modified outside SOPS.
+	ErrMACMismatch = errors.New("MAC mismatch: the file was modified without re-encrypting")
+)
+
+// ageStanza is one entry of the sops.age list.
+type ageStanza struct {
+	Recipient string `yaml:"recipient"`
+	Enc       string `yaml:"enc"`
+}
+
+// metadata is the subset of the sops mapping this package reads.
+type metadata struct {
+	Age       []ageStanza `yaml:"age"`
+	KeyGroups []struct {
+		Age []ageStanza `yaml:"age"`
+	} `yaml:"key_groups"` //nolint:tagliatelle // SOPS metadata field
+	LastModified      string `yaml:"lastmodified"`
+	MAC               string `yaml:"mac"`
+	UnencryptedSuffix string `yaml:"unencrypted_suffix"` //nolint:tagliatelle // SOPS metadata field
+	EncryptedSuffix   string `yaml:"encrypted_suffix"`   //nolint:tagliatelle // SOPS metadata field
+	UnencryptedRegex  string `yaml:"unencrypted_regex"`  //nolint:tagliatelle // SOPS metadata field
+	EncryptedRegex    string `yaml:"encrypted_regex"`    //nolint:tagliatelle // SOPS metadata field
+	MACOnlyEncrypted  bool   `yaml:"mac_only_encrypted"` //nolint:tagliatelle // SOPS metadata field
+}
+
+// newMetadata is the sops mapping written for a new file, in the key order the
+// sops CLI uses.
+type newMetadata struct {
+	Age               []ageStanza `yaml:"age"`
+	LastModified      string      `yaml:"lastmodified"`
+	MAC               string      `yaml:"mac"`
+	UnencryptedSuffix string      `yaml:"unencrypted_suffix"` //nolint:tagliatelle // SOPS metadata field
+	Version           string      `yaml:"version"`
+}
+
+// Document is a decrypted SOPS document. Its leaves can be read and edited in
+// memory; Marshal re-encrypts it.
+type Document struct {
+	format  Format
+	doc     *yaml.Node // document node (keeps head/foot comments)
+	root    *yaml.Node // decrypted data mapping, without the sops key
+	meta    *yaml.Node // sops metadata mapping
+	rules   rules
+	dataKey []byte
+}
+
+// Decrypt parses data as format, unwraps the data key with identities, decrypts
+// every leaf and verifies the MAC.
+func Decrypt(data []byte, format Format, identities []age.Identity) (*Document, error) {
+	doc, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	root := doc.Content[0]
+
+	meta := removeKey(root, metadataKey)
+	if meta == nil || meta.Kind != yaml.MappingNode {
+		return nil, ErrNotEncrypted
+	}
+
+	var md metadata
+	if err := meta.Decode(&md); err != nil {
+		return nil, fmt.Errorf("invalid sops metadata: %w", err)
+	}
+
+	r, err := newRules(md)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := unwrapDataKey(md, identities)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Document{format: format, doc: doc, root: root, meta: meta, rules: r, dataKey: dataKey}
+
+	if err := d.decryptLeaves(); err != nil {
+		return nil, err
+	}
+
+	if err := d.verifyMAC(md); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// New creates an empty document with a fresh data key wrapped for recipients.
+func New(format Format, recipients []age.Recipient) (*Document, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("at least one age recipient is required")
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	stanzas := make([]ageStanza, 0, len(recipients))
+
+	for _, r := range recipients {
+		enc, err := wrapDataKey(dataKey, r)
+		if err != nil {
+			return nil, err
+		}
+
+		stanzas = append(stanzas, ageStanza{Recipient: recipientString(r), Enc: enc})
+	}
+
+	meta := &yaml.Node{}
+	if err := meta.Encode(newMetadata{
+		Age:               stanzas,
+		UnencryptedSuffix: defaultUnencryptedSuffix,
+		Version:           metadataVersion,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to build sops metadata: %w", err)
+	}
+
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	return &Document{
+		format:  format,
+		doc:     &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}},
+		root:    root,
+		meta:    meta,
+		rules:   rules{unencryptedSuffix: defaultUnencryptedSuffix},
+		dataKey: dataKey,
+	}, nil
+}
+
+// Marshal re-encrypts the document with the existing data key and renders it
+// in the document's format, stamping lastmodified with now and recomputing the
+// MAC. The Document itself stays decrypted and usable.
+func (d *Document) Marshal(now time.Time) ([]byte, error) {
+	root := clone(d.root)
+	hash := sha512.New()
+
+	err := walk(root, nil, nil, func(leaf *yaml.Node, keys, _ []string) error {
+		plaintext, typ, err := plainBytes(leaf)
+		if err != nil {
+			return err
+		}
+
+		encrypted := d.rules.encrypted(keys)
+		if encrypted || !d.rules.macOnlyEncrypted {
+			hash.Write(plaintext)
+		}
+
+		if !encrypted {
+			return nil
+		}
+
+		value, err := encryptValue(plaintext, typ, d.dataKey, aad(keys))
+		if err != nil {
+			return err
+		}
+
+		setString(leaf, value)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lastModified := now.UTC().Format(time.RFC3339)
+
+	mac, err := encryptValue([]byte(strings.ToUpper(hex.EncodeToString(hash.Sum(nil)))), typeStr, d.dataKey, lastModified)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := clone(d.meta)
+	setKey(meta, "lastmodified", lastModified)
+	setKey(meta, "mac", mac)
+
+	root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: metadataKey}, meta)
+
+	if d.format == FormatJSON {
+		return marshalJSON(root)
+	}
+
+	doc := *d.doc
+	doc.Content = []*yaml.Node{root}
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(yamlIndent)
+
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// parse reads a single YAML (or JSON, which YAML subsumes) document whose root
+// is a mapping.
+func parse(data []byte) (*yaml.Node, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+
+	var doc yaml.Node
+	if err := dec.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrNotEncrypted
+		}
+
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	var extra yaml.Node
+	if err := dec.Decode(&extra); !errors.Is(err, io.EOF) {
+		return nil, errors.New("multi-document YAML files are not supported")
+	}
+
+	if len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("file must contain a mapping at the top level")
+	}
+
+	return &doc, nil
+}
+
+// decryptLeaves decrypts every sealed leaf in place, restoring its YAML type.
+func (d *Document) decryptLeaves() error {
+	return walk(d.root, nil, nil, func(leaf *yaml.Node, keys, segs []string) error {
+		if !d.rules.encrypted(keys) {
+			return nil
+		}
+
+		plaintext, typ, err := decryptValue(leaf.Value, d.dataKey, aad(keys))
+		if err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(segs, "."), err)
+		}
+
+		setTyped(leaf, string(plaintext), typ)
+
+		return nil
+	})
+}
+
+// verifyMAC recomputes the MAC over the decrypted leaves and compares it with
+// the one recorded in the metadata.
+func (d *Document) verifyMAC(md metadata) error {
+	hash := sha512.New()
+
+	err := walk(d.root, nil, nil, func(leaf *yaml.Node, keys, _ []string) error {
+		if d.rules.macOnlyEncrypted && !d.rules.encrypted(keys) {
+			return nil
+		}
+
+		plaintext, _, err := plainBytes(leaf)
+		if err != nil {
+			return err
+		}
+
+		hash.Write(plaintext)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	want, _, err := decryptValue(md.MAC, d.dataKey, md.LastModified)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt MAC: %w", err)
+	}
+
+	if !strings.EqualFold(string(want), hex.EncodeToString(hash.Sum(nil))) {
+		return ErrMACMismatch
+	}
+
+	return nil
+}
+
+// unwrapDataKey decrypts the data key from the first age stanza one of the
+// identities can open.
+func unwrapDataKey(md metadata, identities []age.Identity) ([]byte, error) {
+	stanzas := md.Age
+	for _, g := range md.KeyGroups {
+		stanzas = append(stanzas, g.Age...)
+	}
+
+	if len(stanzas) == 0 {
+		return nil, ErrNoAgeRecipients
+	}
+
+	if len(md.KeyGroups) > 1 {
+		return nil, errors.New("SOPS files with multiple key groups (Shamir secret sharing) are not supported")
+	}
+
+	if len(identities) == 0 {
+		return nil, ErrNoMatchingIdentity
+	}
+
+	for _, s := range stanzas {
+		r, err := age.Decrypt(armor.NewReader(strings.NewReader(s.Enc)), identities...)
+		if err != nil {
+			continue
+		}
+
+		key, err := io.ReadAll(r)
+		if err != nil || len(key) != dataKeySize {
+			continue
+		}
+
+		return key, nil
+	}
+
+	return nil, ErrNoMatchingIdentity
+}
+
+// wrapDataKey encrypts the data key to one recipient as an armored age file.
+func wrapDataKey(dataKey []byte, recipient age.Recipient) (string, error) {
+	var buf bytes.Buffer
+
+	aw := armor.NewWriter(&buf)
+
+	w, err := age.Encrypt(aw, recipient)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	if _, err := w.Write(dataKey); err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	if err := aw.Close(); err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// recipientString renders a recipient for the sops.age[].recipient field.
+func recipientString(r age.Recipient) string {
+	if s, ok := r.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return ""
+}
+
+// rules decides which leaves are sealed, from the file's metadata.
+type rules struct {
+	unencryptedSuffix string
+	encryptedSuffix   string
+	unencryptedRegex  *regexp.Regexp
+	encryptedRegex    *regexp.Regexp
+	macOnlyEncrypted  bool
+}
+
+// newRules compiles the encryption rules. A file that sets none gets SOPS'
+// default unencrypted suffix.
+func newRules(md metadata) (rules, error) {
+	r := rules{
+		unencryptedSuffix: md.UnencryptedSuffix,
+		encryptedSuffix:   md.EncryptedSuffix,
+		macOnlyEncrypted:  md.MACOnlyEncrypted,
+	}
+
+	for _, c := range []struct {
+		expr string
+		dst  **regexp.Regexp
+	}{
+		{md.UnencryptedRegex, &r.unencryptedRegex},
+		{md.EncryptedRegex, &r.encryptedRegex},
+	} {
+		if c.expr == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(c.expr)
+		if err != nil {
+			return rules{}, fmt.Errorf("invalid sops metadata regex %q: %w", c.expr, err)
+		}
+
+		*c.dst = re
+	}
+
+	if r.unencryptedSuffix == "" && r.encryptedSuffix == "" && r.unencryptedRegex == nil && r.encryptedRegex == nil {
+		r.unencryptedSuffix = defaultUnencryptedSuffix
+	}
+
+	return r, nil
+}
+
+// encrypted reports whether a leaf under the given mapping keys is sealed,
+// applying the rules in the same order as SOPS.
+func (r rules) encrypted(keys []string) bool {
+	encrypted := true
+
+	if r.unencryptedSuffix != "" {
+		for _, k := range keys {
+			if strings.HasSuffix(k, r.unencryptedSuffix) {
+				encrypted = false
+
+				break
+			}
+		}
+	}
+
+	if r.encryptedSuffix != "" {
+		encrypted = anyKey(keys, func(k string) bool { return strings.HasSuffix(k, r.encryptedSuffix) })
+	}
+
+	if r.unencryptedRegex != nil && anyKey(keys, r.unencryptedRegex.MatchString) {
+		encrypted = false
+	}
+
+	if r.encryptedRegex != nil {
+		encrypted = anyKey(keys, r.encryptedRegex.MatchString)
+	}
+
+	return encrypted
+}
+
+// anyKey reports whether any key satisfies match.
+func anyKey(keys []string, match func(string) bool) bool {
+	for _, k := range keys {
+		if match(k) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// aad is the additional authenticated data SOPS binds to a leaf: its mapping
+// keys joined with ":" plus a trailing ":".
+func aad(keys []string) string {
+	return strings.Join(keys, ":") + ":"
+}
@@ -0,0 +1,183 @@
+package sopsfile
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+)
+
+// fromPlain builds a document whose data is the given plaintext YAML, with the
+// encryption rules from md.
+func fromPlain(t *testing.T, id *age.X25519Identity, plain string, md metadata) *Document {
+	t.Helper()
+
+	doc, err := New(FormatYAML, []age.Recipient{id.Recipient()})
+	require.NoError(t, err)
+
+	var n yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(plain), &n))
+
+	doc.doc = &n
+	doc.root = n.Content[0]
+	doc.rules, err = newRules(md)
+	require.NoError(t, err)
+
+	for key, value := range map[string]string{
+		"encrypted_regex":    md.EncryptedRegex,
+		"unencrypted_suffix": md.UnencryptedSuffix,
+	} {
+		if value != "" {
+			setKey(doc.meta, key, value)
+		}
+	}
+
+	if md.UnencryptedSuffix == "" {
+		removeKey(doc.meta, "unencrypted_suffix")
+	}
+
+	return doc
+}
+
+func TestMarshal_TypesAndRules(t *testing.T) {
+	t.Parallel()
+
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	plain := "# head comment\nport: 5432\nratio: 0.5\nenabled: true\nempty: \"\"\nnothing: null\nhosts:\n- a\n- b\nnote_unencrypted: visible\n"
+	doc := fromPlain(t, id, plain, metadata{UnencryptedSuffix: defaultUnencryptedSuffix})
+
+	out, err := doc.Marshal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+
+	text := string(out)
+	assert.True(t, strings.HasPrefix(text, "# head comment\n"))
+	assert.Contains(t, text, "type:int]")
+	assert.Contains(t, text, "type:float]")
+	assert.Contains(t, text, "type:bool]")
+	assert.Contains(t, text, `empty: ""`)
+	assert.Contains(t, text, "nothing: null")
+	assert.Contains(t, text, "note_unencrypted: visible")
+	assert.Contains(t, text, "    - ENC[", "sequence items are sealed and indented like sops")
+
+	dec, err := Decrypt(out, FormatYAML, []age.Identity{id})
+	require.NoError(t, err)
+	assert.Equal(t, []Leaf{
+		{Key: "port", Value: "5432"},
+		{Key: "ratio", Value: "0.5"},
+		{Key: "enabled", Value: "true"},
+		{Key: "empty", Value: ""},
+		{Key: "hosts.0", Value: "a"},
+		{Key: "hosts.1", Value: "b"},
+		{Key: "note_unencrypted", Value: "visible"},
+	}, dec.Leaves())
+
+	// An edited leaf keeps its type while the new value still parses as it.
+	require.NoError(t, dec.Set("port", "6543"))
+	require.NoError(t, dec.Set("enabled", "yes"))
+
+	out, err = dec.Marshal(time.Now())
+	require.NoError(t, err)
+
+	dec, err = Decrypt(out, FormatYAML, []age.Identity{id})
+	require.NoError(t, err)
+	assert.Equal(t, tagInt, lookup(dec.root, []string{"port"}).Tag)
+	assert.Equal(t, tagStr, lookup(dec.root, []string{"enabled"}).Tag)
+}
+
+func TestMarshal_EncryptedRegex(t *testing.T) {
+	t.Parallel()
+
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	doc := fromPlain(t, id, "data:\n  password: pw\nkind: Secret\n", metadata{EncryptedRegex: "^data$"})
+
+	out, err := doc.Marshal(time.Now())
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "kind: Secret")
+	assert.Contains(t, string(out), "password: ENC[")
+
+	dec, err := Decrypt(out, FormatYAML, []age.Identity{id})
+	require.NoError(t, err)
+
+	v, ok := dec.Get("data.password")
+	require.True(t, ok)
+	assert.Equal(t, "pw", v)
+}
+
+func TestMarshal_JSON(t *testing.T) {
+	t.Parallel()
+
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	doc := fromPlain(t, id, `{"b_unencrypted": 1, "a": {"n": 2.5, "ok": false, "s": "<x>"}, "l": [null]}`, metadata{UnencryptedSuffix: defaultUnencryptedSuffix})
+	doc.format = FormatJSON
+
+	out, err := doc.Marshal(time.Now())
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), "{\n\t\"b_unencrypted\": 1,\n\t\"a\": {\n"))
+	assert.Contains(t, string(out), "\t\"l\": [\n\t\tnull\n\t],")
+
+	dec, err := Decrypt(out, FormatJSON, []age.Identity{id})
+	require.NoError(t, err)
+	assert.Equal(t, []Leaf{
+		{Key: "b_unencrypted", Value: "1"},
+		{Key: "a.n", Value: "2.5"},
+		{Key: "a.ok", Value: "false"},
+		{Key: "a.s", Value: "<x>"},
+	}, dec.Leaves())
+}
+
+func TestRules_Encrypted(t *testing.T) {
+	t.Parallel()
+
+	r, err := newRules(metadata{})
+	require.NoError(t, err)
+	assert.True(t, r.encrypted([]string{"a", "b"}))
+	assert.False(t, r.encrypted([]string{"a_unencrypted", "b"}), "the default suffix applies to the whole subtree")
+
+	r, err = newRules(metadata{EncryptedSuffix: "_enc"})
+	require.NoError(t, err)
+	assert.True(t, r.encrypted([]string{"x_enc", "b"}))
+	assert.False(t, r.encrypted([]string{"x"}))
+
+	r, err = newRules(metadata{UnencryptedRegex: "^public"})
+	require.NoError(t, err)
+	assert.False(t, r.encrypted([]string{"public_key"}))
+	assert.True(t, r.encrypted([]string{"private_key"}))
+
+	_, err = newRules(metadata{EncryptedRegex: "("})
+	require.ErrorContains(t, err, "invalid sops metadata regex")
+}
+
+func TestDecryptValue(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, dataKeySize)
+
+	enc, err := encryptValue([]byte("hello"), typeStr, key, "a:b:")
+	require.NoError(t, err)
+	assert.True(t, encPattern.MatchString(enc))
+
+	plain, typ, err := decryptValue(enc, key, "a:b:")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(plain))
+	assert.Equal(t, typeStr, typ)
+
+	_, _, err = decryptValue(enc, key, "a:c:")
+	require.ErrorContains(t, err, "failed to decrypt value")
+
+	_, _, err = decryptValue("plain", key, "a:")
+	require.ErrorIs(t, err, errMalformedValue)
+
+	empty, err := encryptValue(nil, typeStr, key, "a:")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
@@ -0,0 +1,158 @@
+package sopsfile_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider/sops/sopsfile"
+)
+
+//nolint:gochecknoglobals // fixed timestamp shared by tests
+var now = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func identity(t *testing.T) *age.X25519Identity {
+	t.Helper()
+
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	return id
+}
+
+// encrypted builds a SOPS file from alternating key/value pairs set on a new
+// document, in order.
+func encrypted(t *testing.T, format sopsfile.Format, id *age.X25519Identity, leaves ...string) []byte {
+	t.Helper()
+
+	doc, err := sopsfile.New(format, []age.Recipient{id.Recipient()})
+	require.NoError(t, err)
+
+	for i := 0; i+1 < len(leaves); i += 2 {
+		require.NoError(t, doc.Set(leaves[i], leaves[i+1]))
+	}
+
+	out, err := doc.Marshal(now)
+	require.NoError(t, err)
+
+	return out
+}
+
+func TestFormatForPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, sopsfile.FormatJSON, sopsfile.FormatForPath("secrets.JSON"))
+	assert.Equal(t, sopsfile.FormatYAML, sopsfile.FormatForPath("secrets.enc.yaml"))
+	assert.Equal(t, sopsfile.FormatYAML, sopsfile.FormatForPath("secrets"))
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []sopsfile.Format{sopsfile.FormatYAML, sopsfile.FormatJSON} {
+		id := identity(t)
+		data := encrypted(t, format, id, "db.user", "admin", "db.password", "s3cr3t", "api.token", "t")
+
+		assert.NotContains(t, string(data), "s3cr3t")
+		assert.Contains(t, string(data), "ENC[AES256_GCM,data:")
+		assert.Contains(t, string(data), "2026-01-02T03:04:05Z")
+
+		doc, err := sopsfile.Decrypt(data, format, []age.Identity{id})
+		require.NoError(t, err)
+		assert.Equal(t, []sopsfile.Leaf{
+			{Key: "db.user", Value: "admin"},
+			{Key: "db.password", Value: "s3cr3t"},
+			{Key: "api.token", Value: "t"},
+		}, doc.Leaves())
+	}
+}
+
+func TestDocument_SetAndDelete(t *testing.T) {
+	t.Parallel()
+
+	id := identity(t)
+	data := encrypted(t, sopsfile.FormatYAML, id, "db.user", "admin", "db.password", "pw", "top", "v")
+
+	doc, err := sopsfile.Decrypt(data, sopsfile.FormatYAML, []age.Identity{id})
+	require.NoError(t, err)
+
+	require.Error(t, doc.Set("db", "x"), "a mapping cannot be overwritten by a value")
+	require.Error(t, doc.Set("top.child", "x"), "a value cannot gain children")
+
+	assert.True(t, doc.Delete("db.user"))
+	assert.False(t, doc.Delete("db.user"))
+	assert.True(t, doc.Delete("db.password"))
+	assert.False(t, doc.Delete("db"), "only leaves can be deleted")
+
+	out, err := doc.Marshal(now)
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "db:", "emptied mappings are pruned")
+
+	doc, err = sopsfile.Decrypt(out, sopsfile.FormatYAML, []age.Identity{id})
+	require.NoError(t, err)
+	assert.Equal(t, []sopsfile.Leaf{{Key: "top", Value: "v"}}, doc.Leaves())
+}
+
+func TestDecrypt_Errors(t *testing.T) {
+	t.Parallel()
+
+	id := identity(t)
+	data := encrypted(t, sopsfile.FormatYAML, id, "a", "1")
+
+	t.Run("wrong identity", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sopsfile.Decrypt(data, sopsfile.FormatYAML, []age.Identity{identity(t)})
+		require.ErrorIs(t, err, sopsfile.ErrNoMatchingIdentity)
+	})
+
+	t.Run("no identities", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sopsfile.Decrypt(data, sopsfile.FormatYAML, nil)
+		require.ErrorIs(t, err, sopsfile.ErrNoMatchingIdentity)
+	})
+
+	t.Run("plain file", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sopsfile.Decrypt([]byte("a: 1\n"), sopsfile.FormatYAML, []age.Identity{id})
+		require.ErrorIs(t, err, sopsfile.ErrNotEncrypted)
+	})
+
+	t.Run("pgp only", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sopsfile.Decrypt([]byte("a: x\nsops:\n    pgp:\n        - fp: ABC\n"), sopsfile.FormatYAML, []age.Identity{id})
+		require.ErrorIs(t, err, sopsfile.ErrNoAgeRecipients)
+	})
+
+	t.Run("tampered value", func(t *testing.T) {
+		t.Parallel()
+
+		tampered := strings.Replace(string(data), "a: ENC[", "b: ENC[", 1)
+
+		_, err := sopsfile.Decrypt([]byte(tampered), sopsfile.FormatYAML, []age.Identity{id})
+		require.ErrorContains(t, err, "failed to decrypt value", "the key path is bound as AAD")
+	})
+
+	t.Run("edited without re-encrypting", func(t *testing.T) {
+		t.Parallel()
+
+		edited := strings.Replace(string(data), "sops:", "b_unencrypted: added\nsops:", 1)
+
+		_, err := sopsfile.Decrypt([]byte(edited), sopsfile.FormatYAML, []age.Identity{id})
+		require.ErrorIs(t, err, sopsfile.ErrMACMismatch)
+	})
+
+	t.Run("multiple documents", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sopsfile.Decrypt(append(data, []byte("---\nb: 2\n")...), sopsfile.FormatYAML, []age.Identity{id})
+		require.ErrorContains(t, err, "multi-document")
+	})
+}
@@ -0,0 +1,340 @@
+package sopsfile
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// KeySeparator joins the segments of a leaf's key path ("db.password",
+// "hosts.0").
+const KeySeparator = "."
+
+// YAML tags assigned to decrypted and edited scalars.
+const (
+	tagStr   = "!!str"
+	tagInt   = "!!int"
+	tagFloat = "!!float"
+	tagBool  = "!!bool"
+	tagNull  = "!!null"
+	tagMap   = "!!map"
+)
+
+// ErrNotFound is returned when a key path does not name a scalar leaf.
+var ErrNotFound = errors.New("key not found")
+
+// Leaf is one addressable scalar value of a document.
+type Leaf struct {
+	Key   string
+	Value string
+}
+
+// Leaves returns every non-null scalar leaf in document order. Sequence items
+// are addressed by index. Leaves under a mapping key that itself contains
+// KeySeparator cannot be addressed and are skipped.
+func (d *Document) Leaves() []Leaf {
+	var leaves []Leaf
+
+	_ = walk(d.root, nil, nil, func(leaf *yaml.Node, _, segs []string) error {
+		for _, s := range segs {
+			if strings.Contains(s, KeySeparator) {
+				return nil
+			}
+		}
+
+		leaves = append(leaves, Leaf{Key: strings.Join(segs, KeySeparator), Value: leaf.Value})
+
+		return nil
+	})
+
+	return leaves
+}
+
+// Get returns the value of the scalar leaf at key.
+func (d *Document) Get(key string) (string, bool) {
+	node := lookup(d.root, strings.Split(key, KeySeparator))
+	if node == nil || node.Kind != yaml.ScalarNode || node.ShortTag() == tagNull {
+		return "", false
+	}
+
+	return node.Value, true
+}
+
+// Set writes value at key, creating intermediate mappings as needed. An
+// existing leaf keeps its YAML type when value still parses as that type;
+// anything else is stored as a string.
+func (d *Document) Set(key, value string) error {
+	segs := strings.Split(key, KeySeparator)
+	node := d.root
+
+	for i, seg := range segs {
+		last := i == len(segs)-1
+
+		switch node.Kind {
+		case yaml.MappingNode:
+			child := mappingValue(node, seg)
+			if child == nil {
+				child = &yaml.Node{Kind: yaml.MappingNode, Tag: tagMap}
+				if last {
+					child = &yaml.Node{Kind: yaml.ScalarNode}
+				}
+
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: tagStr, Value: seg}, child)
+			}
+
+			node = child
+		case yaml.SequenceNode:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx > len(node.Content) {
+				return fmt.Errorf("%s: %q is not a valid index into a list of %d items", key, seg, len(node.Content))
+			}
+
+			if idx == len(node.Content) {
+				child := &yaml.Node{Kind: yaml.MappingNode, Tag: tagMap}
+				if last {
+					child = &yaml.Node{Kind: yaml.ScalarNode}
+				}
+
+				node.Content = append(node.Content, child)
+			}
+
+			node = node.Content[idx]
+		case yaml.DocumentNode, yaml.ScalarNode, yaml.AliasNode:
+			return fmt.Errorf("%s: %s is not a mapping", key, strings.Join(segs[:i], KeySeparator))
+		}
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("%s: cannot overwrite a mapping or list with a value", key)
+	}
+
+	setValue(node, value)
+
+	return nil
+}
+
+// Delete removes the leaf at key and prunes mappings left empty by the removal.
+// It reports whether the leaf existed.
+func (d *Document) Delete(key string) bool {
+	segs := strings.Split(key, KeySeparator)
+
+	node := lookup(d.root, segs)
+	if node == nil || node.Kind != yaml.ScalarNode || node.ShortTag() == tagNull {
+		return false
+	}
+
+	for i := len(segs); i > 0; i-- {
+		parent := lookup(d.root, segs[:i-1])
+		removeChild(parent, segs[i-1])
+
+		if parent == d.root || parent.Kind != yaml.MappingNode || len(parent.Content) > 0 {
+			break
+		}
+	}
+
+	return true
+}
+
+// walk visits every non-null scalar leaf below node in document order. keys
+// holds the mapping keys on the way down (the SOPS AAD path, to which sequence
+// items add nothing) and segs the full key path including sequence indices.
+func walk(node *yaml.Node, keys, segs []string, fn func(leaf *yaml.Node, keys, segs []string) error) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k := node.Content[i].Value
+			if err := walk(node.Content[i+1], append(keys[:len(keys):len(keys)], k), append(segs[:len(segs):len(segs)], k), fn); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if err := walk(item, keys, append(segs[:len(segs):len(segs)], strconv.Itoa(i)), fn); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if node.ShortTag() == tagNull {
+			return nil
+		}
+
+		return fn(node, keys, segs)
+	case yaml.AliasNode:
+		return fmt.Errorf("%s: YAML aliases are not supported", strings.Join(segs, KeySeparator))
+	case yaml.DocumentNode:
+		return errors.New("unexpected nested YAML document")
+	}
+
+	return nil
+}
+
+// lookup follows segs from node, returning nil when the path does not exist.
+func lookup(node *yaml.Node, segs []string) *yaml.Node {
+	for _, seg := range segs {
+		switch node.Kind {
+		case yaml.MappingNode:
+			node = mappingValue(node, seg)
+		case yaml.SequenceNode:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(node.Content) {
+				return nil
+			}
+
+			node = node.Content[idx]
+		case yaml.DocumentNode, yaml.ScalarNode, yaml.AliasNode:
+			return nil
+		}
+
+		if node == nil {
+			return nil
+		}
+	}
+
+	return node
+}
+
+// mappingValue returns the value node for key in a mapping, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// removeChild deletes the entry named seg from a mapping or sequence.
+func removeChild(node *yaml.Node, seg string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		removeKey(node, seg)
+	case yaml.SequenceNode:
+		if idx, err := strconv.Atoi(seg); err == nil && idx >= 0 && idx < len(node.Content) {
+			node.Content = append(node.Content[:idx], node.Content[idx+1:]...)
+		}
+	case yaml.DocumentNode, yaml.ScalarNode, yaml.AliasNode:
+	}
+}
+
+// removeKey deletes key from a mapping and returns its value node, or nil.
+func removeKey(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			value := node.Content[i+1]
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+
+			return value
+		}
+	}
+
+	return nil
+}
+
+// setKey sets a string value in a mapping, appending the key when absent.
+func setKey(node *yaml.Node, key, value string) {
+	if v := mappingValue(node, key); v != nil {
+		setString(v, value)
+
+		return
+	}
+
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: tagStr, Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: tagStr, Value: value},
+	)
+}
+
+// clone deep-copies a node tree.
+func clone(node *yaml.Node) *yaml.Node {
+	c := *node
+	c.Content = make([]*yaml.Node, len(node.Content))
+
+	for i, child := range node.Content {
+		c.Content[i] = clone(child)
+	}
+
+	return &c
+}
+
+// setString makes node a plain string scalar.
+func setString(node *yaml.Node, value string) {
+	node.Tag = tagStr
+	node.Value = value
+	node.Style = 0
+}
+
+// setTyped restores a decrypted scalar with the YAML type SOPS recorded.
+func setTyped(node *yaml.Node, plaintext, typ string) {
+	switch typ {
+	case typeInt:
+		node.Tag, node.Value = tagInt, plaintext
+	case typeFloat:
+		node.Tag, node.Value = tagFloat, plaintext
+	case typeBool:
+		node.Tag, node.Value = tagBool, strings.ToLower(plaintext)
+	default:
+		node.Tag, node.Value = tagStr, plaintext
+	}
+
+	node.Style = 0
+}
+
+// setValue writes an edited value, keeping the leaf's current type when the
+// new value still parses as it.
+func setValue(node *yaml.Node, value string) {
+	tag := tagStr
+
+	switch node.ShortTag() {
+	case tagInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+			tag = tagInt
+		}
+	case tagFloat:
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			tag = tagFloat
+		}
+	case tagBool:
+		if value == "true" || value == "false" {
+			tag = tagBool
+		}
+	}
+
+	node.Tag, node.Value, node.Style = tag, value, 0
+}
+
+// plainBytes returns a leaf's plaintext bytes and SOPS type marker, rendered
+// the way SOPS does for both encryption and the MAC (booleans as True/False,
+// floats in shortest decimal form).
+func plainBytes(node *yaml.Node) ([]byte, string, error) {
+	var v any
+	if err := node.Decode(&v); err != nil {
+		return nil, "", fmt.Errorf("failed to decode value %q: %w", node.Value, err)
+	}
+
+	switch v := v.(type) {
+	case string:
+		return []byte(v), typeStr, nil
+	case int:
+		return []byte(strconv.Itoa(v)), typeInt, nil
+	case int64:
+		return []byte(strconv.FormatInt(v, 10)), typeInt, nil
+	case uint64:
+		return []byte(strconv.FormatUint(v, 10)), typeInt, nil
+	case float64:
+		return []byte(strconv.FormatFloat(v, 'f', -1, 64)), typeFloat, nil
+	case bool:
+		if v {
+			return []byte("True"), typeBool, nil
+		}
+
+		return []byte("False"), typeBool, nil
+	case []byte:
+		return v, typeBytes, nil
+	default:
+		return []byte(node.Value), typeStr, nil
+	}
+}
@@ -0,0 +1,166 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mpyw/suve/internal/debug"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// methodList is Vault's LIST verb. Vault also accepts GET with ?list=true, but
+// the dedicated verb is what the official CLI sends.
+const methodList = "LIST"
+
+// Client is a minimal HTTP client for the Vault API: it attaches the token (and
+// optional Enterprise namespace) headers, encodes JSON bodies, and decodes JSON
+// responses. It knows nothing about KV; Store builds the KV v2 paths.
+type Client struct {
+	httpClient *http.Client
+	address    string
+	token      string
+	namespace  string
+}
+
+// NewClient builds a Client for the Vault server at address (e.g.
+// "https://vault.example.com:8200"). A nil httpClient uses http.DefaultClient;
+// an empty namespace sends no X-Vault-Namespace header.
+func NewClient(httpClient *http.Client, address, token, namespace string) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		httpClient: httpClient,
+		address:    strings.TrimRight(address, "/"),
+		token:      token,
+		namespace:  namespace,
+	}
+}
+
+// APIError is a non-2xx Vault response. Vault reports failures as
+// {"errors": ["..."]}; the messages are kept for display.
+type APIError struct {
+	StatusCode int
+	Errors     []string
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("vault: HTTP %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("vault: HTTP %d: %s", e.StatusCode, strings.Join(e.Errors, "; "))
+}
+
+// Is reports a 429 response as provider.ErrThrottled, so a rate-limited
+// request is retried by stage apply (see provider.IsThrottled).
+func (e *APIError) Is(target error) bool {
+	return target == provider.ErrThrottled && e.StatusCode == http.StatusTooManyRequests
+}
+
+// statusCode returns the HTTP status of a Vault APIError, or 0 for any other
+// error (transport failures, decode errors, ...).
+func statusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	return 0
+}
+
+// do sends one request to /v1/{path} and decodes a JSON response into out (when
+// non-nil and the response has a body), numbers as json.Number. path must be
+// escaped already (see escapeName). A non-2xx status yields *APIError. The
+// debug line records only the method, path and status — never bodies, which
+// carry secret values.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var reqBody io.Reader
+
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+
+		reqBody = bytes.NewReader(buf)
+	}
+
+	u := c.address + "/v1/" + strings.TrimLeft(path, "/")
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	if c.token != "" {
+		req.Header.Set("X-Vault-Token", c.token)
+	}
+
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		debug.From(ctx).Logf("vault http: %s /v1/%s failed: %v\n", method, path, err)
+
+		return fmt.Errorf("vault request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	debug.From(ctx).Logf("vault http: %s /v1/%s -> %d in %s\n", method, path, resp.StatusCode, time.Since(start))
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read vault response: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+
+		var payload struct {
+			Errors []string `json:"errors"`
+		}
+		if json.Unmarshal(raw, &payload) == nil {
+			apiErr.Errors = payload.Errors
+		}
+
+		return apiErr
+	}
+
+	if out == nil || len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+
+	// Numbers are kept as json.Number: a secret's data object may hold integers
+	// wider than float64 can carry (IDs, ports), which a write-back must not
+	// change.
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	if err := dec.Decode(out); err != nil {
+		return fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,576 @@
+// Package kv implements the provider.Store contract (Reader/Writer/Tagger) plus
+// provider.Restorer and provider.Describer for the HashiCorp Vault KV version 2
+// secrets engine, over Vault's plain HTTP API (no Vault SDK dependency).
+//
+// KV v2 maps onto suve's model as follows:
+//
+//   - A secret is a path under the mount ("app/db"). Versions are positive
+//     integers that increase by one per write; a version spec is parsed by
+//     vaultversion (#N, ~SHIFT).
+//   - A version's data is a JSON object. suve writes its value under the single
+//     key "value"; on read, a secret whose data is exactly {"value": "<string>"}
+//     yields that string, and any other shape yields the whole object as
+//     compact JSON, so secrets written by other tools stay readable. Writing
+//     such a JSON object back stores it as the data itself, so editing one
+//     field of a multi-key secret keeps the others as they were.
+//   - The path's custom_metadata (a string map on the metadata endpoint) backs
+//     suve's tag axis.
+//   - Delete is a SOFT delete of the current version (DELETE on the data path),
+//     so Restore undeletes it. Destroy is deliberately not exposed; only
+//     provider.ForceDelete (the rollback of a create) deletes the metadata,
+//     removing the path with every version.
+//   - KV v2 has no description field, so descriptions are ignored.
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/lo/it"
+
+	"github.com/mpyw/suve/internal/debug"
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/maputil"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/version/vaultversion"
+)
+
+// valueKey is the data key under which suve stores a secret's value.
+const valueKey = "value"
+
+// Version state labels surfaced in domain.Version.State. A live version has an
+// empty state.
+const (
+	stateDeleted   = "deleted"
+	stateDestroyed = "destroyed"
+)
+
+// Store is the Vault KV v2 implementation of provider.Store, provider.Restorer
+// and provider.Describer for one mount.
+type Store struct {
+	client *Client
+	mount  string
+}
+
+// Compile-time assertions that Store implements the provider contract.
+var (
+	_ provider.Store     = (*Store)(nil)
+	_ provider.Restorer  = (*Store)(nil)
+	_ provider.Describer = (*Store)(nil)
+)
+
+// New builds a Store for the KV v2 engine mounted at mount (e.g. "secret").
+func New(client *Client, mount string) *Store {
+	return &Store{client: client, mount: strings.Trim(mount, "/")}
+}
+
+// versionMeta is one entry of the metadata endpoint's "versions" map, and also
+// the "metadata" object of a data read.
+type versionMeta struct {
+	Version        int64             `json:"version"`
+	CreatedTime    string            `json:"created_time"`  //nolint:tagliatelle // Vault API field
+	DeletionTime   string            `json:"deletion_time"` //nolint:tagliatelle // Vault API field
+	Destroyed      bool              `json:"destroyed"`
+	CustomMetadata map[string]string `json:"custom_metadata"` //nolint:tagliatelle // Vault API field
+}
+
+// metadata is the payload of GET {mount}/metadata/{path}.
+type metadata struct {
+	CurrentVersion int64                  `json:"current_version"` //nolint:tagliatelle // Vault API field
+	UpdatedTime    string                 `json:"updated_time"`    //nolint:tagliatelle // Vault API field
+	CustomMetadata map[string]string      `json:"custom_metadata"` //nolint:tagliatelle // Vault API field
+	Versions       map[string]versionMeta `json:"versions"`
+}
+
+// dataPath, metadataPath and undeletePath build the KV v2 endpoint paths.
+func (s *Store) dataPath(name string) string     { return s.mount + "/data/" + escapeName(name) }
+func (s *Store) metadataPath(name string) string { return s.mount + "/metadata/" + escapeName(name) }
+func (s *Store) undeletePath(name string) string { return s.mount + "/undelete/" + escapeName(name) }
+
+// escapeName escapes each "/"-separated segment of a secret name for the URL
+// path, so a name holding "?", "#" or "%" addresses that secret rather than
+// turning into a query, a fragment or another path.
+func escapeName(name string) string {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// Resolve parses the version spec (generic) and resolves it to an opaque
+// VersionRef holding the integer version string (or "" for the current
+// version). A ~shift counts back positionally from the current version over
+// ALL versions (deleted and destroyed included), mirroring what History shows.
+func (s *Store) Resolve(ctx context.Context, name, spec string) (provider.VersionRef, error) {
+	parsed, err := vaultversion.Parse(name + spec)
+	if err != nil {
+		return provider.VersionRef{}, err
+	}
+
+	if !parsed.HasShift() {
+		if parsed.Absolute.Version != nil {
+			return provider.NewVersionRef(strconv.FormatInt(*parsed.Absolute.Version, 10)), nil
+		}
+
+		return provider.NewVersionRef(""), nil
+	}
+
+	versions, err := s.History(ctx, name)
+	if err != nil {
+		return provider.VersionRef{}, err
+	}
+
+	if len(versions) == 0 {
+		return provider.VersionRef{}, fmt.Errorf("secret has no versions: %s", name)
+	}
+
+	baseIdx := 0
+
+	if parsed.Absolute.Version != nil {
+		want := strconv.FormatInt(*parsed.Absolute.Version, 10)
+
+		_, idx, found := lo.FindIndexOf(versions, func(v domain.Version) bool { return v.ID == want })
+		if !found {
+			return provider.VersionRef{}, fmt.Errorf("version not found: %s", want)
+		}
+
+		baseIdx = idx
+	}
+
+	targetIdx := baseIdx + parsed.Shift
+	if targetIdx < 0 || targetIdx >= len(versions) {
+		return provider.VersionRef{}, fmt.Errorf("version shift out of range: ~%d", parsed.Shift)
+	}
+
+	return provider.NewVersionRef(versions[targetIdx].ID), nil
+}
+
+// Get reads the secret at the given ref (the current version when ref is
+// latest). A missing path, or a version that is soft-deleted or destroyed,
+// yields a wrapped provider.ErrNotFound. The path's custom_metadata becomes
+// Tags.
+func (s *Store) Get(ctx context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+	var query url.Values
+	if id := ref.ID(); id != "" {
+		query = url.Values{"version": {id}}
+	}
+
+	var resp struct {
+		Data struct {
+			Data     map[string]any `json:"data"`
+			Metadata versionMeta    `json:"metadata"`
+		} `json:"data"`
+	}
+
+	if err := s.client.do(ctx, http.MethodGet, s.dataPath(name), query, nil, &resp); err != nil {
+		return nil, mapError(err, name, "read secret")
+	}
+
+	// Vault answers a read of a deleted version with 404 on current servers, but
+	// older ones return 200 with null data; treat both the same.
+	if resp.Data.Data == nil {
+		return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+	}
+
+	value, err := decodeValue(resp.Data.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	created := parseTime(resp.Data.Metadata.CreatedTime)
+
+	return &domain.Entry{
+		Name:  name,
+		Value: value,
+		Type:  domain.ValueTypeSecret,
+		Version: domain.Version{
+			ID:      strconv.FormatInt(resp.Data.Metadata.Version, 10),
+			Created: created,
+			State:   stateLabel(resp.Data.Metadata),
+		},
+		Modified: created,
+		Tags:     mapTags(resp.Data.Metadata.CustomMetadata),
+	}, nil
+}
+
+// History returns every version of the secret, newest first. Soft-deleted and
+// destroyed versions are included with their state, since their numbers still
+// count for ~shift.
+func (s *Store) History(ctx context.Context, name string) ([]domain.Version, error) {
+	meta, err := s.metadata(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := slices.Collect(maps.Values(meta.Versions))
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].Version > versions[j].Version })
+
+	return lo.Map(versions, func(v versionMeta, _ int) domain.Version {
+		return domain.Version{
+			ID:      strconv.FormatInt(v.Version, 10),
+			State:   stateLabel(v),
+			Created: parseTime(v.CreatedTime),
+		}
+	}), nil
+}
+
+// Describe returns the secret's metadata (current version, tags) without
+// reading its value.
+func (s *Store) Describe(ctx context.Context, name string) (*domain.Entry, error) {
+	meta, err := s.metadata(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	current := meta.Versions[strconv.FormatInt(meta.CurrentVersion, 10)]
+
+	return &domain.Entry{
+		Name: name,
+		Type: domain.ValueTypeSecret,
+		Version: domain.Version{
+			ID:      strconv.FormatInt(meta.CurrentVersion, 10),
+			Created: parseTime(current.CreatedTime),
+			State:   stateLabel(current),
+		},
+		Modified: parseTime(meta.UpdatedTime),
+		Tags:     mapTags(meta.CustomMetadata),
+	}, nil
+}
+
+// List returns the full paths of every secret under the mount, walking
+// sub-folders (keys ending in "/") recursively. An empty mount yields no names.
+func (s *Store) List(ctx context.Context) ([]string, error) {
+	names, err := s.listUnder(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	debug.From(ctx).Logf("vault kv: LIST %s -> %d secrets\n", s.mount, len(names))
+
+	return names, nil
+}
+
+// listUnder lists the keys below prefix ("" or a folder ending in "/"),
+// recursing into sub-folders.
+func (s *Store) listUnder(ctx context.Context, prefix string) ([]string, error) {
+	var resp struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+
+	if err := s.client.do(ctx, methodList, s.metadataPath(prefix), nil, nil, &resp); err != nil {
+		// Vault answers LIST on an empty folder with 404.
+		if statusCode(err) == http.StatusNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var names []string
+
+	for _, key := range resp.Data.Keys {
+		if !strings.HasSuffix(key, "/") {
+			names = append(names, prefix+key)
+
+			continue
+		}
+
+		sub, err := s.listUnder(ctx, prefix+key)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, sub...)
+	}
+
+	return names, nil
+}
+
+// Create writes the first version of a new secret using check-and-set 0, so the
+// write fails with a wrapped provider.ErrAlreadyExists when the path already
+// has versions. The valueType and description are ignored.
+func (s *Store) Create(
+	ctx context.Context, name, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+) (domain.Version, error) {
+	v, err := s.write(ctx, name, value, lo.ToPtr(int64(0)))
+	if err != nil {
+		if isCASMismatch(err) {
+			return domain.Version{}, fmt.Errorf("%w: %s", provider.ErrAlreadyExists, name)
+		}
+
+		return domain.Version{}, fmt.Errorf("failed to create secret: %w", err)
+	}
+
+	return v, nil
+}
+
+// Put writes a new version of the secret, creating the path on first write
+// (upsert). The valueType and description are ignored.
+func (s *Store) Put(
+	ctx context.Context, name, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+) (domain.Version, error) {
+	v, err := s.write(ctx, name, value, nil)
+	if err != nil {
+		return domain.Version{}, fmt.Errorf("failed to update secret: %w", err)
+	}
+
+	return v, nil
+}
+
+// write posts value's data (see encodeData) to the data path, with a cas option
+// when cas is non-nil.
+func (s *Store) write(ctx context.Context, name, value string, cas *int64) (domain.Version, error) {
+	body := map[string]any{"data": encodeData(value)}
+	if cas != nil {
+		body["options"] = map[string]int64{"cas": *cas}
+	}
+
+	var resp struct {
+		Data versionMeta `json:"data"`
+	}
+
+	if err := s.client.do(ctx, http.MethodPost, s.dataPath(name), nil, body, &resp); err != nil {
+		return domain.Version{}, err
+	}
+
+	return domain.Version{
+		ID:      strconv.FormatInt(resp.Data.Version, 10),
+		Created: parseTime(resp.Data.CreatedTime),
+	}, nil
+}
+
+// Delete soft-deletes the secret's current version. The data stays on the
+// server and Restore undeletes it. provider.ForceDelete instead deletes the
+// path's metadata with every version, so the path is gone for good and a later
+// Create (check-and-set 0) can write it afresh. Other provider.DeleteOptions
+// (AWS-specific) are ignored. A path with no metadata yields a wrapped
+// provider.ErrNotFound.
+func (s *Store) Delete(ctx context.Context, name string, opts ...provider.DeleteOption) error {
+	// Vault's DELETE on a missing path succeeds silently; check first so a typo
+	// is reported instead of "deleted".
+	if _, err := s.metadata(ctx, name); err != nil {
+		return err
+	}
+
+	path := s.dataPath(name)
+
+	for _, opt := range opts {
+		if _, ok := opt.(provider.ForceDelete); ok {
+			path = s.metadataPath(name)
+		}
+	}
+
+	if err := s.client.do(ctx, http.MethodDelete, path, nil, nil, nil); err != nil {
+		return mapError(err, name, "delete secret")
+	}
+
+	return nil
+}
+
+// Restore undeletes the secret's current version, reversing a soft Delete.
+func (s *Store) Restore(ctx context.Context, name string) error {
+	meta, err := s.metadata(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	current := meta.Versions[strconv.FormatInt(meta.CurrentVersion, 10)]
+	if current.Destroyed {
+		return fmt.Errorf("current version of %s is destroyed and cannot be restored", name)
+	}
+
+	body := map[string][]int64{"versions": {meta.CurrentVersion}}
+	if err := s.client.do(ctx, http.MethodPost, s.undeletePath(name), nil, body, nil); err != nil {
+		return mapError(err, name, "undelete secret")
+	}
+
+	return nil
+}
+
+// Tag adds or updates custom_metadata keys via a read-modify-write of the
+// metadata endpoint (KV v2 replaces custom_metadata wholesale on write).
+func (s *Store) Tag(ctx context.Context, name string, add map[string]string) error {
+	if len(add) == 0 {
+		return nil
+	}
+
+	meta, err := s.metadata(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	custom := make(map[string]string, len(meta.CustomMetadata)+len(add))
+	maps.Copy(custom, meta.CustomMetadata)
+	maps.Copy(custom, add)
+
+	return s.writeCustomMetadata(ctx, name, custom)
+}
+
+// Untag removes custom_metadata keys via a read-modify-write of the metadata
+// endpoint.
+func (s *Store) Untag(ctx context.Context, name string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	meta, err := s.metadata(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	custom := make(map[string]string, len(meta.CustomMetadata))
+	maps.Copy(custom, meta.CustomMetadata)
+
+	for _, k := range keys {
+		delete(custom, k)
+	}
+
+	return s.writeCustomMetadata(ctx, name, custom)
+}
+
+// writeCustomMetadata replaces the path's custom_metadata.
+func (s *Store) writeCustomMetadata(ctx context.Context, name string, custom map[string]string) error {
+	body := map[string]map[string]string{"custom_metadata": custom}
+	if err := s.client.do(ctx, http.MethodPost, s.metadataPath(name), nil, body, nil); err != nil {
+		return mapError(err, name, "update secret metadata")
+	}
+
+	return nil
+}
+
+// metadata reads the path's metadata (versions, current version, custom
+// metadata). A missing path yields a wrapped provider.ErrNotFound.
+func (s *Store) metadata(ctx context.Context, name string) (*metadata, error) {
+	var resp struct {
+		Data metadata `json:"data"`
+	}
+
+	if err := s.client.do(ctx, http.MethodGet, s.metadataPath(name), nil, nil, &resp); err != nil {
+		return nil, mapError(err, name, "read secret metadata")
+	}
+
+	return &resp.Data, nil
+}
+
+// decodeValue turns a version's data object into suve's string value: the
+// "value" string when it is the only key, otherwise the object as compact JSON
+// (encoding/json sorts map keys, so the rendering is stable). The client
+// decodes numbers as json.Number, so they are rendered verbatim.
+func decodeValue(data map[string]any) (string, error) {
+	if len(data) == 1 {
+		if v, ok := data[valueKey].(string); ok {
+			return v, nil
+		}
+	}
+
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode secret data: %w", err)
+	}
+
+	return string(buf), nil
+}
+
+// encodeData returns the data object a value is written as, the inverse of
+// decodeValue: a JSON object that decodeValue renders as itself (any object
+// but {"value": "<string>"}) is written as that object, numbers kept verbatim
+// as on the read side; any other value is written under the "value" key.
+func encodeData(value string) map[string]any {
+	dec := json.NewDecoder(strings.NewReader(value))
+	dec.UseNumber()
+
+	var data map[string]any
+	if err := dec.Decode(&data); err != nil || data == nil {
+		return map[string]any{valueKey: value}
+	}
+
+	// Trailing content makes the value something other than one object.
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return map[string]any{valueKey: value}
+	}
+
+	if _, ok := data[valueKey].(string); ok && len(data) == 1 {
+		return map[string]any{valueKey: value}
+	}
+
+	return data
+}
+
+// mapError maps an HTTP 404 to provider.ErrNotFound and otherwise wraps the
+// error with the given operation description.
+func mapError(err error, name, op string) error {
+	if statusCode(err) == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+	}
+
+	return fmt.Errorf("failed to %s: %w", op, err)
+}
+
+// isCASMismatch reports whether err is Vault's check-and-set rejection, which
+// for a cas=0 write means the path already exists.
+func isCASMismatch(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	return slices.ContainsFunc(apiErr.Errors, func(msg string) bool {
+		return strings.Contains(msg, "check-and-set")
+	})
+}
+
+// stateLabel maps a version's deletion markers to a display label: "" for a
+// live version, "deleted" when soft-deleted, "destroyed" when destroyed.
+func stateLabel(v versionMeta) string {
+	switch {
+	case v.Destroyed:
+		return stateDestroyed
+	case v.DeletionTime != "":
+		return stateDeleted
+	default:
+		return ""
+	}
+}
+
+// parseTime parses a Vault RFC 3339 timestamp, or returns nil when it is empty
+// or malformed.
+func parseTime(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+// mapTags converts custom_metadata to a sorted slice of neutral domain tags
+// (sorted by key for deterministic display).
+func mapTags(custom map[string]string) []domain.Tag {
+	if len(custom) == 0 {
+		return nil
+	}
+
+	return slices.Collect(it.Map(maputil.SortedKeys(custom), func(k string) domain.Tag {
+		return domain.Tag{Key: k, Value: custom[k]}
+	}))
+}
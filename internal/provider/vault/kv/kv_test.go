@@ -0,0 +1,580 @@
+package kv_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/vault/kv"
+)
+
+const (
+	testMount = "secret"
+	testToken = "test-token"
+)
+
+// fakeVersion is one stored version in the fake KV v2 engine.
+type fakeVersion struct {
+	data      map[string]any
+	created   time.Time
+	deleted   bool
+	destroyed bool
+}
+
+// fakeSecret is one path in the fake KV v2 engine.
+type fakeSecret struct {
+	versions []*fakeVersion // index i holds version i+1
+	custom   map[string]string
+}
+
+// fakeVault is a minimal in-memory stand-in for the Vault KV v2 HTTP API,
+// covering the endpoints the adapter uses (data, metadata, undelete, LIST).
+type fakeVault struct {
+	mu      sync.Mutex
+	secrets map[string]*fakeSecret
+	clock   time.Time
+}
+
+func newFakeVault(t *testing.T) (*fakeVault, *kv.Store) {
+	t.Helper()
+
+	f := &fakeVault{
+		secrets: map[string]*fakeSecret{},
+		clock:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	return f, kv.New(kv.NewClient(srv.Client(), srv.URL, testToken, ""), testMount)
+}
+
+// seed writes versions directly (bypassing the HTTP API).
+func (f *fakeVault) seed(path string, values ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, v := range values {
+		f.appendLocked(path, map[string]any{"value": v})
+	}
+}
+
+func (f *fakeVault) appendLocked(path string, data map[string]any) int {
+	s, ok := f.secrets[path]
+	if !ok {
+		s = &fakeSecret{custom: map[string]string{}}
+		f.secrets[path] = s
+	}
+
+	f.clock = f.clock.Add(time.Hour)
+	s.versions = append(s.versions, &fakeVersion{data: data, created: f.clock})
+
+	return len(s.versions)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeErr(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string][]string{"errors": {msg}})
+}
+
+func (v *fakeVersion) meta(n int, custom map[string]string) map[string]any {
+	m := map[string]any{
+		"version":         n,
+		"created_time":    v.created.Format(time.RFC3339Nano),
+		"deletion_time":   "",
+		"destroyed":       v.destroyed,
+		"custom_metadata": custom,
+	}
+	if v.deleted {
+		m["deletion_time"] = v.created.Add(time.Minute).Format(time.RFC3339Nano)
+	}
+
+	return m
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != testToken {
+		writeErr(w, http.StatusForbidden, "permission denied")
+
+		return
+	}
+
+	rest, ok := strings.CutPrefix(r.URL.Path, "/v1/"+testMount+"/")
+	if !ok {
+		writeErr(w, http.StatusNotFound, "no handler for route")
+
+		return
+	}
+
+	endpoint, path, _ := strings.Cut(rest, "/")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case endpoint == "data" && r.Method == http.MethodGet:
+		f.readData(w, r, path)
+	case endpoint == "data" && r.Method == http.MethodPost:
+		f.writeData(w, r, path)
+	case endpoint == "data" && r.Method == http.MethodDelete:
+		if s, ok := f.secrets[path]; ok {
+			s.versions[len(s.versions)-1].deleted = true
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	case endpoint == "metadata" && r.Method == "LIST":
+		f.list(w, path)
+	case endpoint == "metadata" && r.Method == http.MethodGet:
+		f.readMetadata(w, path)
+	case endpoint == "metadata" && r.Method == http.MethodDelete:
+		delete(f.secrets, path)
+
+		w.WriteHeader(http.StatusNoContent)
+	case endpoint == "metadata" && r.Method == http.MethodPost:
+		var body struct {
+			Custom map[string]string `json:"custom_metadata"` //nolint:tagliatelle // Vault API field
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.secrets[path].custom = body.Custom
+
+		w.WriteHeader(http.StatusNoContent)
+	case endpoint == "undelete" && r.Method == http.MethodPost:
+		var body struct {
+			Versions []int `json:"versions"`
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		for _, n := range body.Versions {
+			f.secrets[path].versions[n-1].deleted = false
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeErr(w, http.StatusMethodNotAllowed, "unsupported")
+	}
+}
+
+func (f *fakeVault) readData(w http.ResponseWriter, r *http.Request, path string) {
+	s, ok := f.secrets[path]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string][]string{"errors": {}})
+
+		return
+	}
+
+	n := len(s.versions)
+	if q := r.URL.Query().Get("version"); q != "" {
+		n, _ = strconv.Atoi(q)
+	}
+
+	if n < 1 || n > len(s.versions) {
+		writeJSON(w, http.StatusNotFound, map[string][]string{"errors": {}})
+
+		return
+	}
+
+	v := s.versions[n-1]
+	if v.deleted || v.destroyed {
+		writeJSON(w, http.StatusNotFound, map[string]any{"data": map[string]any{"data": nil, "metadata": v.meta(n, s.custom)}})
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"data": v.data, "metadata": v.meta(n, s.custom)}})
+}
+
+func (f *fakeVault) writeData(w http.ResponseWriter, r *http.Request, path string) {
+	var body struct {
+		Data    map[string]any `json:"data"`
+		Options map[string]int `json:"options"`
+	}
+
+	// Vault stores the data object as sent, so numbers are kept verbatim.
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+
+	if err := dec.Decode(&body); err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if cas, ok := body.Options["cas"]; ok {
+		current := 0
+		if s, exists := f.secrets[path]; exists {
+			current = len(s.versions)
+		}
+
+		if cas != current {
+			writeErr(w, http.StatusBadRequest, "check-and-set parameter did not match the current version")
+
+			return
+		}
+	}
+
+	n := f.appendLocked(path, body.Data)
+	v := f.secrets[path].versions[n-1]
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": v.meta(n, nil)})
+}
+
+func (f *fakeVault) readMetadata(w http.ResponseWriter, path string) {
+	s, ok := f.secrets[path]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string][]string{"errors": {}})
+
+		return
+	}
+
+	versions := map[string]any{}
+	for i, v := range s.versions {
+		versions[strconv.Itoa(i+1)] = v.meta(i+1, nil)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{
+		"current_version": len(s.versions),
+		"updated_time":    s.versions[len(s.versions)-1].created.Format(time.RFC3339Nano),
+		"custom_metadata": s.custom,
+		"versions":        versions,
+	}})
+}
+
+func (f *fakeVault) list(w http.ResponseWriter, prefix string) {
+	seen := map[string]bool{}
+
+	for path := range f.secrets {
+		rest, ok := strings.CutPrefix(path, prefix)
+		if !ok {
+			continue
+		}
+
+		if head, _, isDir := strings.Cut(rest, "/"); isDir {
+			seen[head+"/"] = true
+		} else {
+			seen[rest] = true
+		}
+	}
+
+	if len(seen) == 0 {
+		writeJSON(w, http.StatusNotFound, map[string][]string{"errors": {}})
+
+		return
+	}
+
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"keys": keys}})
+}
+
+func TestStore_GetAndResolve(t *testing.T) {
+	t.Parallel()
+
+	f, store := newFakeVault(t)
+	f.seed("app/db", "v1", "v2", "v3")
+
+	t.Run("current version", func(t *testing.T) {
+		t.Parallel()
+
+		entry, err := store.Get(t.Context(), "app/db", provider.VersionRef{})
+		require.NoError(t, err)
+		assert.Equal(t, "v3", entry.Value)
+		assert.Equal(t, "3", entry.Version.ID)
+		assert.Equal(t, domain.ValueTypeSecret, entry.Type)
+		require.NotNil(t, entry.Version.Created)
+	})
+
+	t.Run("explicit version", func(t *testing.T) {
+		t.Parallel()
+
+		ref, err := store.Resolve(t.Context(), "app/db", "#1")
+		require.NoError(t, err)
+
+		entry, err := store.Get(t.Context(), "app/db", ref)
+		require.NoError(t, err)
+		assert.Equal(t, "v1", entry.Value)
+	})
+
+	t.Run("shift", func(t *testing.T) {
+		t.Parallel()
+
+		ref, err := store.Resolve(t.Context(), "app/db", "~2")
+		require.NoError(t, err)
+		assert.Equal(t, "1", ref.ID())
+	})
+
+	t.Run("shift out of range", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := store.Resolve(t.Context(), "app/db", "~3")
+		require.ErrorContains(t, err, "out of range")
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := store.Get(t.Context(), "nope", provider.VersionRef{})
+		require.ErrorIs(t, err, provider.ErrNotFound)
+	})
+}
+
+func TestStore_Get_NonValueData(t *testing.T) {
+	t.Parallel()
+
+	f, store := newFakeVault(t)
+
+	f.mu.Lock()
+	f.appendLocked("app/multi", map[string]any{"user": "admin", "pass": "hunter2"})
+	f.mu.Unlock()
+
+	entry, err := store.Get(t.Context(), "app/multi", provider.VersionRef{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"pass":"hunter2","user":"admin"}`, entry.Value)
+}
+
+func TestStore_Put_NonValueData(t *testing.T) {
+	t.Parallel()
+
+	f, store := newFakeVault(t)
+
+	f.mu.Lock()
+	f.appendLocked("app/multi", map[string]any{"user": "admin", "pass": "hunter2", "port": 5432})
+	f.mu.Unlock()
+
+	// Editing one field of the rendered object writes the object back.
+	_, err := store.Put(t.Context(), "app/multi", `{"pass":"s3cret","port":5432,"user":"admin"}`, domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	f.mu.Lock()
+	data := f.secrets["app/multi"].versions[1].data
+	f.mu.Unlock()
+
+	assert.Equal(t, map[string]any{"user": "admin", "pass": "s3cret", "port": json.Number("5432")}, data)
+
+	// Anything decodeValue would not render as an object stays under "value".
+	for _, value := range []string{`plain`, `{"value":"x"}`, `["a"]`, `{"a":1} trailing`} {
+		_, err := store.Put(t.Context(), "app/plain", value, domain.ValueTypeSecret, "")
+		require.NoError(t, err)
+
+		entry, err := store.Get(t.Context(), "app/plain", provider.VersionRef{})
+		require.NoError(t, err)
+		assert.Equal(t, value, entry.Value)
+	}
+}
+
+func TestStore_NonValueData_WideNumbers(t *testing.T) {
+	t.Parallel()
+
+	f, store := newFakeVault(t)
+
+	// 2^53 + 1 and a 20-digit ID: both lose digits as float64.
+	const stored = `{"id":12345678901234567890,"pass":"hunter2","port":9007199254740993,"ratio":0.1}`
+
+	_, err := store.Put(t.Context(), "app/multi", stored, domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	entry, err := store.Get(t.Context(), "app/multi", provider.VersionRef{})
+	require.NoError(t, err)
+	assert.Equal(t, stored, entry.Value, "numbers are read back verbatim")
+
+	// Writing back an edit of another field keeps the numbers intact.
+	edited := strings.Replace(entry.Value, "hunter2", "s3cret", 1)
+
+	_, err = store.Put(t.Context(), "app/multi", edited, domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+
+	f.mu.Lock()
+	data := f.secrets["app/multi"].versions[1].data
+	f.mu.Unlock()
+
+	assert.Equal(t, json.Number("9007199254740993"), data["port"])
+	assert.Equal(t, json.Number("12345678901234567890"), data["id"])
+
+	entry, err = store.Get(t.Context(), "app/multi", provider.VersionRef{})
+	require.NoError(t, err)
+	assert.Equal(t, edited, entry.Value)
+}
+
+func TestStore_EscapesNames(t *testing.T) {
+	t.Parallel()
+
+	f, store := newFakeVault(t)
+
+	for _, name := range []string{"app/a?b", "app/c#d", "app/e%2Ff", "app/g h"} {
+		_, err := store.Put(t.Context(), name, "v-"+name, domain.ValueTypeSecret, "")
+		require.NoError(t, err, name)
+
+		f.mu.Lock()
+		_, ok := f.secrets[name]
+		f.mu.Unlock()
+		assert.True(t, ok, "%s is written under its own name", name)
+
+		entry, err := store.Get(t.Context(), name, provider.VersionRef{})
+		require.NoError(t, err, name)
+		assert.Equal(t, "v-"+name, entry.Value)
+	}
+}
+
+func TestStore_CreateAndPut(t *testing.T) {
+	t.Parallel()
+
+	_, store := newFakeVault(t)
+
+	v, err := store.Create(t.Context(), "app/new", "first", domain.ValueTypeSecret, "ignored")
+	require.NoError(t, err)
+	assert.Equal(t, "1", v.ID)
+
+	_, err = store.Create(t.Context(), "app/new", "again", domain.ValueTypeSecret, "")
+	require.ErrorIs(t, err, provider.ErrAlreadyExists)
+
+	v, err = store.Put(t.Context(), "app/new", "second", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+	assert.Equal(t, "2", v.ID)
+
+	entry, err := store.Get(t.Context(), "app/new", provider.VersionRef{})
+	require.NoError(t, err)
+	assert.Equal(t, "second", entry.Value)
+}
+
+func TestStore_DeleteRestore(t *testing.T) {
+	t.Parallel()
+
+	f, store := newFakeVault(t)
+	f.seed("app/db", "v1", "v2")
+
+	require.NoError(t, store.Delete(t.Context(), "app/db"))
+
+	_, err := store.Get(t.Context(), "app/db", provider.VersionRef{})
+	require.ErrorIs(t, err, provider.ErrNotFound)
+
+	history, err := store.History(t.Context(), "app/db")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "2", history[0].ID)
+	assert.Equal(t, "deleted", history[0].State)
+	assert.Empty(t, history[1].State)
+
+	require.NoError(t, store.Restore(t.Context(), "app/db"))
+
+	entry, err := store.Get(t.Context(), "app/db", provider.VersionRef{})
+	require.NoError(t, err)
+	assert.Equal(t, "v2", entry.Value)
+
+	err = store.Delete(t.Context(), "nope")
+	require.ErrorIs(t, err, provider.ErrNotFound)
+}
+
+func TestStore_Delete_Force(t *testing.T) {
+	t.Parallel()
+
+	f, store := newFakeVault(t)
+	f.seed("app/db", "v1")
+
+	require.NoError(t, store.Delete(t.Context(), "app/db", provider.ForceDelete{}))
+
+	_, err := store.History(t.Context(), "app/db")
+	require.ErrorIs(t, err, provider.ErrNotFound, "a forced delete removes every version")
+
+	// The path can be created afresh (check-and-set 0).
+	_, err = store.Create(t.Context(), "app/db", "new", domain.ValueTypeSecret, "")
+	require.NoError(t, err)
+}
+
+func TestStore_TagsAndDescribe(t *testing.T) {
+	t.Parallel()
+
+	f, store := newFakeVault(t)
+	f.seed("app/db", "v1")
+
+	require.NoError(t, store.Tag(t.Context(), "app/db", map[string]string{"env": "prod", "team": "core"}))
+	require.NoError(t, store.Untag(t.Context(), "app/db", []string{"team"}))
+
+	entry, err := store.Describe(t.Context(), "app/db")
+	require.NoError(t, err)
+	assert.Equal(t, "1", entry.Version.ID)
+	assert.Empty(t, entry.Value)
+	assert.Equal(t, []domain.Tag{{Key: "env", Value: "prod"}}, entry.Tags)
+
+	got, err := store.Get(t.Context(), "app/db", provider.VersionRef{})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Tag{{Key: "env", Value: "prod"}}, got.Tags)
+
+	err = store.Tag(t.Context(), "nope", map[string]string{"a": "b"})
+	require.ErrorIs(t, err, provider.ErrNotFound)
+}
+
+func TestStore_List(t *testing.T) {
+	t.Parallel()
+
+	f, store := newFakeVault(t)
+
+	names, err := store.List(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	f.seed("top", "x")
+	f.seed("app/db", "x")
+	f.seed("app/nested/api", "x")
+
+	names, err = store.List(t.Context())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"top", "app/db", "app/nested/api"}, names)
+}
+
+func TestStore_PermissionDenied(t *testing.T) {
+	t.Parallel()
+
+	f, _ := newFakeVault(t)
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	store := kv.New(kv.NewClient(srv.Client(), srv.URL, "wrong", ""), testMount)
+
+	_, err := store.Get(t.Context(), "app/db", provider.VersionRef{})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, provider.ErrNotFound)
+	assert.Contains(t, err.Error(), fmt.Sprintf("HTTP %d", http.StatusForbidden))
+	assert.Contains(t, err.Error(), "permission denied")
+}
+
+func TestStore_Throttled(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeErr(w, http.StatusTooManyRequests, "request path rate limit quota exceeded")
+	}))
+	t.Cleanup(srv.Close)
+
+	store := kv.New(kv.NewClient(srv.Client(), srv.URL, testToken, ""), testMount)
+
+	_, err := store.Put(t.Context(), "app/db", "v", domain.ValueTypeSecret, "")
+	require.ErrorIs(t, err, provider.ErrThrottled)
+	assert.True(t, provider.IsThrottled(err))
+
+	_, err = store.Get(t.Context(), "app/db", provider.VersionRef{})
+	require.ErrorIs(t, err, provider.ErrThrottled)
+	require.NotErrorIs(t, err, provider.ErrNotFound)
+}
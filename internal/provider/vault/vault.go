@@ -0,0 +1,92 @@
+// Package vault wires the HashiCorp Vault KV v2 adapter into a
+// provider.Factory / provider.Registry. It resolves the token the way the
+// Vault CLI does (VAULT_TOKEN, then ~/.vault-token) and hands an HTTP client to
+// the kv subpackage.
+//
+// Vault (as used here) offers no parameter store, so the factory returns
+// provider.ErrUnsupportedKind for KindParam.
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/vault/kv"
+)
+
+// Environment variables read by the factory, named as in the Vault CLI.
+const (
+	// TokenEnvVar holds the Vault token. When unset, ~/.vault-token (written by
+	// `vault login`) is used.
+	TokenEnvVar = "VAULT_TOKEN"
+	// NamespaceEnvVar selects a Vault Enterprise namespace (optional).
+	NamespaceEnvVar = "VAULT_NAMESPACE"
+)
+
+// requestTimeout bounds one Vault request, so an unreachable server fails the
+// command instead of hanging it.
+const requestTimeout = 30 * time.Second
+
+// Factory builds Vault-backed provider.Store values for a scope + kind.
+type Factory struct{}
+
+// Compile-time assertion that Factory implements provider.Factory.
+var _ provider.Factory = Factory{}
+
+// Store builds a Store for the given scope and kind. Vault supports only
+// KindSecret (the KV v2 engine at scope.VaultMount on scope.VaultAddress);
+// KindParam yields provider.ErrUnsupportedKind.
+func (Factory) Store(_ context.Context, scope provider.Scope, kind provider.Kind) (provider.Store, error) {
+	switch kind {
+	case provider.KindSecret:
+		if scope.VaultAddress == "" {
+			return nil, errors.New("no Vault address specified: set --address or VAULT_ADDR")
+		}
+
+		if strings.Trim(scope.VaultMount, "/") == "" {
+			return nil, errors.New("no Vault KV mount specified: set --mount")
+		}
+
+		client := kv.NewClient(&http.Client{Timeout: requestTimeout}, scope.VaultAddress, token(), os.Getenv(NamespaceEnvVar))
+
+		return kv.New(client, scope.VaultMount), nil
+	case provider.KindParam:
+		return nil, fmt.Errorf("%w: %s (Vault has no parameter store)", provider.ErrUnsupportedKind, kind)
+	default:
+		return nil, fmt.Errorf("%w: %s", provider.ErrUnsupportedKind, kind)
+	}
+}
+
+// Register associates the Vault Factory with provider.ProviderVault in reg.
+func Register(reg *provider.Registry) {
+	reg.Register(provider.ProviderVault, Factory{})
+}
+
+// token returns VAULT_TOKEN, falling back to the token helper file the Vault
+// CLI writes on login. A missing token yields "" and Vault answers 403, which
+// surfaces as a normal request error.
+func token() string {
+	if t := os.Getenv(TokenEnvVar); t != "" {
+		return t
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	// The path is the user's own Vault token file; reading it is intentional.
+	b, err := os.ReadFile(filepath.Join(home, ".vault-token")) //nolint:gosec // user-owned Vault token file by design
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(b))
+}
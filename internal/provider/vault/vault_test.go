@@ -0,0 +1,60 @@
+package vault_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/vault"
+)
+
+// TestFactory_Store_UnsupportedKind verifies that Vault (which has no parameter
+// store) rejects KindParam and any unknown kind with provider.ErrUnsupportedKind.
+func TestFactory_Store_UnsupportedKind(t *testing.T) {
+	t.Parallel()
+
+	for _, kind := range []provider.Kind{provider.KindParam, provider.Kind("bogus")} {
+		store, err := vault.Factory{}.Store(t.Context(), provider.VaultScope("http://127.0.0.1:8200", "secret"), kind)
+		require.ErrorIs(t, err, provider.ErrUnsupportedKind)
+		assert.Nil(t, store)
+	}
+}
+
+func TestFactory_Store_Secret(t *testing.T) {
+	t.Parallel()
+
+	t.Run("builds a store without touching the network", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := vault.Factory{}.Store(t.Context(), provider.VaultScope("http://127.0.0.1:8200", "secret"), provider.KindSecret)
+		require.NoError(t, err)
+		assert.Implements(t, (*provider.Restorer)(nil), store)
+		assert.Implements(t, (*provider.Describer)(nil), store)
+	})
+
+	t.Run("missing address", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := vault.Factory{}.Store(t.Context(), provider.VaultScope("", "secret"), provider.KindSecret)
+		require.ErrorContains(t, err, "VAULT_ADDR")
+	})
+
+	t.Run("missing mount", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := vault.Factory{}.Store(t.Context(), provider.VaultScope("http://127.0.0.1:8200", "/"), provider.KindSecret)
+		require.ErrorContains(t, err, "--mount")
+	})
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	reg := provider.NewRegistry()
+	vault.Register(reg)
+
+	_, err := reg.Store(t.Context(), provider.VaultScope("http://127.0.0.1:8200", "secret"), provider.KindSecret)
+	require.NoError(t, err)
+}
@@ -0,0 +1,101 @@
+// Package retry retries operations that fail transiently, with exponential
+// backoff between attempts.
+package retry
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// Default is the policy used when a Policy leaves MaxAttempts 0: five
+// attempts, the first retry after about 200ms, doubling up to 5s between
+// attempts.
+var Default = Policy{
+	MaxAttempts: 5,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+// Policy bounds how an operation is retried. A Policy whose MaxAttempts is 0
+// retries with Default's attempts and delays (keeping its own Sleep).
+type Policy struct {
+	// MaxAttempts is the total number of attempts, the first one included; 1
+	// disables retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. Each later retry doubles
+	// it, and every delay is jittered down to half its value so concurrent
+	// callers throttled together do not retry in lockstep.
+	BaseDelay time.Duration
+	// MaxDelay caps a single delay; 0 keeps every delay at BaseDelay.
+	MaxDelay time.Duration
+	// Sleep waits for d or until ctx is done; nil waits on a timer. Tests
+	// replace it to run without waiting.
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+// Do runs fn until it succeeds, returns an error retryable rejects, or the
+// attempts run out, and returns fn's last error. Before each retry it calls
+// onRetry (when non-nil) with the attempt about to start (2 for the first
+// retry), the delay it waits first, and the error that caused it. A context
+// canceled while waiting ends the retries with the last error of fn.
+func (p Policy) Do(
+	ctx context.Context, retryable func(error) bool,
+	onRetry func(attempt int, delay time.Duration, err error), fn func() error,
+) error {
+	if p.MaxAttempts == 0 {
+		sleep := p.Sleep
+		p = Default
+		p.Sleep = sleep
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.MaxAttempts || !retryable(err) {
+			return err
+		}
+
+		delay := p.delay(attempt)
+		if onRetry != nil {
+			onRetry(attempt+1, delay, err)
+		}
+
+		if p.sleep(ctx, delay) != nil {
+			return err
+		}
+	}
+}
+
+// delay returns the jittered delay after the given failed attempt.
+func (p Policy) delay(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+
+	if p.MaxDelay > 0 {
+		d = min(d, p.MaxDelay)
+	}
+
+	if d <= 1 {
+		return d
+	}
+
+	return d/2 + rand.N(d/2) //nolint:gosec // jitter needs no cryptographic randomness
+}
+
+func (p Policy) sleep(ctx context.Context, d time.Duration) error {
+	if p.Sleep != nil {
+		return p.Sleep(ctx, d)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
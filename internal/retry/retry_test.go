@@ -0,0 +1,135 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/retry"
+)
+
+var errTransient = errors.New("transient")
+
+func isTransient(err error) bool { return errors.Is(err, errTransient) }
+
+// recordSleep returns a Sleep that records each delay instead of waiting.
+func recordSleep(delays *[]time.Duration) func(context.Context, time.Duration) error {
+	return func(_ context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+
+		return nil
+	}
+}
+
+func TestPolicy_Do(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retries a transient error until it succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		var delays []time.Duration
+
+		var attempts []int
+
+		p := retry.Policy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Sleep: recordSleep(&delays)}
+		calls := 0
+
+		err := p.Do(t.Context(), isTransient, func(attempt int, _ time.Duration, err error) {
+			attempts = append(attempts, attempt)
+
+			assert.ErrorIs(t, err, errTransient)
+		}, func() error {
+			calls++
+			if calls < 3 {
+				return errTransient
+			}
+
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, []int{2, 3}, attempts)
+		require.Len(t, delays, 2)
+		assert.GreaterOrEqual(t, delays[0], 50*time.Millisecond)
+		assert.Less(t, delays[0], 100*time.Millisecond)
+		assert.GreaterOrEqual(t, delays[1], 100*time.Millisecond)
+		assert.Less(t, delays[1], 200*time.Millisecond)
+	})
+
+	t.Run("gives up after MaxAttempts", func(t *testing.T) {
+		t.Parallel()
+
+		var delays []time.Duration
+
+		p := retry.Policy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 2 * time.Second, Sleep: recordSleep(&delays)}
+		calls := 0
+
+		err := p.Do(t.Context(), isTransient, nil, func() error {
+			calls++
+
+			return errTransient
+		})
+
+		require.ErrorIs(t, err, errTransient)
+		assert.Equal(t, 3, calls)
+		require.Len(t, delays, 2)
+		assert.LessOrEqual(t, delays[1], 2*time.Second)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("boom")
+		calls := 0
+
+		err := retry.Policy{MaxAttempts: 5, Sleep: recordSleep(new([]time.Duration))}.Do(t.Context(), isTransient, nil, func() error {
+			calls++
+
+			return boom
+		})
+
+		require.ErrorIs(t, err, boom)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("zero MaxAttempts uses the default policy", func(t *testing.T) {
+		t.Parallel()
+
+		var delays []time.Duration
+
+		calls := 0
+
+		err := retry.Policy{Sleep: recordSleep(&delays)}.Do(t.Context(), isTransient, nil, func() error {
+			calls++
+
+			return errTransient
+		})
+
+		require.ErrorIs(t, err, errTransient)
+		assert.Equal(t, retry.Default.MaxAttempts, calls)
+		assert.Len(t, delays, retry.Default.MaxAttempts-1)
+	})
+
+	t.Run("canceled context stops waiting", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		calls := 0
+
+		err := retry.Policy{MaxAttempts: 5, BaseDelay: time.Hour}.Do(ctx, isTransient, nil, func() error {
+			calls++
+
+			return errTransient
+		})
+
+		require.ErrorIs(t, err, errTransient)
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -52,6 +52,10 @@ func (s *AWSParamStrategy) HasDeleteOptions() bool {
 func (s *AWSParamStrategy) Apply(ctx context.Context, name string, entry Entry) error {
 	switch entry.Operation {
 	case OperationCreate:
+		if restored, err := restoreDeleted(ctx, s.store, name, s.ItemName(), entry); restored || err != nil {
+			return err
+		}
+
 		return s.applyCreate(ctx, name, entry)
 	case OperationUpdate:
 		return s.applyUpdate(ctx, name, entry)
@@ -76,7 +80,7 @@ func (s *AWSParamStrategy) applyCreate(ctx context.Context, name string, entry E
 		valueType = domain.ValueTypePlaintext
 	}
 
-	if _, err := s.store.Create(ctx, name, *entry.Value, valueType, lo.FromPtr(entry.Description)); err != nil {
+	if _, err := s.store.Create(ctx, name, *entry.Value, valueType, lo.FromPtr(entry.Description), writeOptions(ctx)...); err != nil {
 		return fmt.Errorf("failed to create parameter: %w", err)
 	}
 
@@ -107,7 +111,7 @@ func (s *AWSParamStrategy) applyUpdate(ctx context.Context, name string, entry E
 	}
 
 	// Put overwrites the existing parameter.
-	if _, err := s.store.Put(ctx, name, *entry.Value, valueType, lo.FromPtr(entry.Description)); err != nil {
+	if _, err := s.store.Put(ctx, name, *entry.Value, valueType, lo.FromPtr(entry.Description), writeOptions(ctx)...); err != nil {
 		return fmt.Errorf("failed to update parameter: %w", err)
 	}
 
@@ -165,6 +169,30 @@ func (s *AWSParamStrategy) FetchLastModified(ctx context.Context, name string) (
 	return time.Time{}, nil
 }
 
+// FetchBase returns the parameter value a staged change was based on from SSM Parameter Store's
+// version history.
+func (s *AWSParamStrategy) FetchBase(ctx context.Context, name, version string, at time.Time) (string, error) {
+	return fetchValueAt(ctx, s.store, name, version, at)
+}
+
+// FetchRemoteState returns the parameter's last modified time and current version
+// for version-based conflict detection.
+func (s *AWSParamStrategy) FetchRemoteState(ctx context.Context, name string) (RemoteState, error) {
+	return fetchRemoteState(ctx, s.store, name, "parameter")
+}
+
+// Snapshot captures the parameter's current state in SSM Parameter Store before
+// an atomic apply.
+func (s *AWSParamStrategy) Snapshot(ctx context.Context, name string) (Snapshot, error) {
+	return snapshotEntry(ctx, s.store, name, "parameter")
+}
+
+// Rollback returns the parameter in SSM Parameter Store to a snapshot taken
+// before an atomic apply.
+func (s *AWSParamStrategy) Rollback(ctx context.Context, name string, snap Snapshot) error {
+	return rollbackEntry(ctx, s.store, name, "parameter", snap)
+}
+
 // FetchCurrent fetches the current value from SSM Parameter Store for diffing.
 func (s *AWSParamStrategy) FetchCurrent(ctx context.Context, name string) (*FetchResult, error) {
 	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
@@ -232,7 +260,8 @@ func (s *AWSParamStrategy) FetchCurrentValue(ctx context.Context, name string) (
 	}
 
 	result := &EditFetchResult{
-		Value: entry.Value,
+		Value:   entry.Value,
+		Version: entry.Version.ID,
 	}
 
 	if entry.Modified != nil {
@@ -340,6 +340,215 @@ func TestParamStrategy_Apply_ValueType(t *testing.T) {
 	})
 }
 
+func TestParamStrategy_FetchBase(t *testing.T) {
+	t.Parallel()
+
+	t1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	mock := &providermock.Store{
+		HistoryFunc: func(_ context.Context, _ string) ([]domain.Version, error) {
+			return []domain.Version{{ID: "2", Created: &t2}, {ID: "1", Created: &t1}}, nil
+		},
+		GetFunc: func(_ context.Context, _ string, ref provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{Value: "value-" + ref.ID()}, nil
+		},
+	}
+
+	s := staging.NewAWSParamStrategy(mock)
+
+	got, err := s.FetchBase(t.Context(), "/app/param", "", t1.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, "value-1", got)
+
+	got, err = s.FetchBase(t.Context(), "/app/param", "", t2)
+	require.NoError(t, err)
+	assert.Equal(t, "value-2", got)
+
+	_, err = s.FetchBase(t.Context(), "/app/param", "", t1.Add(-time.Minute))
+	require.ErrorIs(t, err, staging.ErrBaseUnavailable)
+
+	// A recorded base version wins over the time lookup.
+	got, err = s.FetchBase(t.Context(), "/app/param", "1", t2)
+	require.NoError(t, err)
+	assert.Equal(t, "value-1", got)
+}
+
+func TestParamStrategy_FetchRemoteState(t *testing.T) {
+	t.Parallel()
+
+	modified := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("exists", func(t *testing.T) {
+		t.Parallel()
+
+		mock := &providermock.Store{
+			GetFunc: func(_ context.Context, _ string, _ provider.VersionRef) (*domain.Entry, error) {
+				return &domain.Entry{Version: domain.Version{ID: "7"}, Modified: &modified}, nil
+			},
+		}
+
+		got, err := staging.NewAWSParamStrategy(mock).FetchRemoteState(t.Context(), "/app/param")
+		require.NoError(t, err)
+		assert.Equal(t, staging.RemoteState{LastModified: modified, Version: "7"}, got)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		mock := &providermock.Store{
+			GetFunc: func(_ context.Context, _ string, _ provider.VersionRef) (*domain.Entry, error) {
+				return nil, provider.ErrNotFound
+			},
+		}
+
+		_, err := staging.NewAWSParamStrategy(mock).FetchRemoteState(t.Context(), "/app/param")
+
+		var notFound *staging.ResourceNotFoundError
+		require.ErrorAs(t, err, &notFound)
+	})
+}
+
+func TestParamStrategy_SnapshotRollback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("snapshot of a missing parameter", func(t *testing.T) {
+		t.Parallel()
+
+		mock := &providermock.Store{
+			GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+				return nil, paramNotFound(name)
+			},
+		}
+
+		snap, err := staging.NewAWSParamStrategy(mock).Snapshot(t.Context(), "/app/param")
+		require.NoError(t, err)
+		assert.False(t, snap.Exists)
+	})
+
+	t.Run("created parameter is deleted", func(t *testing.T) {
+		t.Parallel()
+
+		var deleted []provider.DeleteOption
+
+		mock := &providermock.Store{
+			GetFunc: func(_ context.Context, _ string, _ provider.VersionRef) (*domain.Entry, error) {
+				return &domain.Entry{Value: "new"}, nil
+			},
+			DeleteFunc: func(_ context.Context, _ string, opts ...provider.DeleteOption) error {
+				deleted = opts
+
+				return nil
+			},
+		}
+
+		require.NoError(t, staging.NewAWSParamStrategy(mock).Rollback(t.Context(), "/app/param", staging.Snapshot{}))
+		assert.Equal(t, []provider.DeleteOption{provider.ForceDelete{}}, deleted)
+	})
+
+	t.Run("updated parameter gets its value and tags back", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			put     string
+			added   map[string]string
+			removed []string
+		)
+
+		mock := &providermock.Store{
+			GetFunc: func(_ context.Context, _ string, _ provider.VersionRef) (*domain.Entry, error) {
+				return &domain.Entry{
+					Value: "new",
+					Type:  domain.ValueTypePlaintext,
+					Tags:  []domain.Tag{{Key: "env", Value: "dev"}, {Key: "added", Value: "x"}},
+				}, nil
+			},
+			PutFunc: func(
+				_ context.Context, _, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+			) (domain.Version, error) {
+				put = value
+
+				return domain.Version{}, nil
+			},
+			TagFunc: func(_ context.Context, _ string, add map[string]string) error {
+				added = add
+
+				return nil
+			},
+			UntagFunc: func(_ context.Context, _ string, keys []string) error {
+				removed = keys
+
+				return nil
+			},
+		}
+
+		snap := staging.Snapshot{
+			Exists:    true,
+			Value:     "old",
+			ValueType: domain.ValueTypePlaintext,
+			Tags:      map[string]string{"env": "prod"},
+		}
+
+		require.NoError(t, staging.NewAWSParamStrategy(mock).Rollback(t.Context(), "/app/param", snap))
+		assert.Equal(t, "old", put)
+		assert.Equal(t, map[string]string{"env": "prod"}, added)
+		assert.Equal(t, []string{"added"}, removed)
+	})
+
+	t.Run("deleted parameter is restored when the store can", func(t *testing.T) {
+		t.Parallel()
+
+		restored := false
+
+		mock := &providermock.Store{
+			GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+				if !restored {
+					return nil, paramNotFound(name)
+				}
+
+				return &domain.Entry{Value: "old", Type: domain.ValueTypePlaintext}, nil
+			},
+			RestoreFunc: func(_ context.Context, _ string) error {
+				restored = true
+
+				return nil
+			},
+		}
+
+		snap := staging.Snapshot{Exists: true, Value: "old", ValueType: domain.ValueTypePlaintext}
+
+		require.NoError(t, staging.NewAWSParamStrategy(mock).Rollback(t.Context(), "/app/param", snap))
+		assert.True(t, restored)
+	})
+
+	t.Run("deleted parameter is re-created when it cannot be restored", func(t *testing.T) {
+		t.Parallel()
+
+		var created string
+
+		mock := &providermock.Store{
+			GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+				return nil, paramNotFound(name)
+			},
+			RestoreFunc: func(_ context.Context, name string) error {
+				return paramNotFound(name)
+			},
+			CreateFunc: func(
+				_ context.Context, _, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+			) (domain.Version, error) {
+				created = value
+
+				return domain.Version{}, nil
+			},
+		}
+
+		snap := staging.Snapshot{Exists: true, Value: "old", ValueType: domain.ValueTypePlaintext}
+
+		require.NoError(t, staging.NewAWSParamStrategy(mock).Rollback(t.Context(), "/app/param", snap))
+		assert.Equal(t, "old", created)
+	})
+}
+
 func TestParamStrategy_FetchCurrent(t *testing.T) {
 	t.Parallel()
 
@@ -939,3 +1148,31 @@ func TestParamStrategy_FetchCurrentTags(t *testing.T) {
 		assert.Nil(t, tags)
 	})
 }
+
+func TestParamStrategy_Apply_WithChangeMessage(t *testing.T) {
+	t.Parallel()
+
+	var got []provider.WriteOption
+
+	mock := &providermock.Store{
+		GetFunc: func(_ context.Context, _ string, _ provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{Value: "old-value"}, nil
+		},
+		PutFunc: func(
+			_ context.Context, _, _ string, _ domain.ValueType, _ string, opts ...provider.WriteOption,
+		) (domain.Version, error) {
+			got = opts
+
+			return domain.Version{ID: "2"}, nil
+		},
+	}
+
+	s := staging.NewAWSParamStrategy(mock)
+	ctx := staging.WithChangeMessage(t.Context(), "rotate db creds")
+	err := s.Apply(ctx, "/app/param", staging.Entry{
+		Operation: staging.OperationUpdate,
+		Value:     lo.ToPtr("value"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "rotate db creds", provider.ChangeMessageOf(got))
+}
@@ -53,6 +53,10 @@ func (s *AWSSecretStrategy) HasDeleteOptions() bool {
 func (s *AWSSecretStrategy) Apply(ctx context.Context, name string, entry Entry) error {
 	switch entry.Operation {
 	case OperationCreate:
+		if restored, err := restoreDeleted(ctx, s.store, name, s.ItemName(), entry); restored || err != nil {
+			return err
+		}
+
 		return s.applyCreate(ctx, name, entry)
 	case OperationUpdate:
 		return s.applyUpdate(ctx, name, entry)
@@ -64,7 +68,9 @@ func (s *AWSSecretStrategy) Apply(ctx context.Context, name string, entry Entry)
 }
 
 func (s *AWSSecretStrategy) applyCreate(ctx context.Context, name string, entry Entry) error {
-	if _, err := s.store.Create(ctx, name, lo.FromPtr(entry.Value), domain.ValueTypeSecret, lo.FromPtr(entry.Description)); err != nil {
+	if _, err := s.store.Create(
+		ctx, name, lo.FromPtr(entry.Value), domain.ValueTypeSecret, lo.FromPtr(entry.Description), writeOptions(ctx)...,
+	); err != nil {
 		return fmt.Errorf("failed to create secret: %w", err)
 	}
 
@@ -87,7 +93,7 @@ func (s *AWSSecretStrategy) applyUpdate(ctx context.Context, name string, entry
 
 	// Put overwrites the existing secret with a new version and, when provided,
 	// updates the description in the same operation.
-	if _, err := s.store.Put(ctx, name, *entry.Value, domain.ValueTypeSecret, lo.FromPtr(entry.Description)); err != nil {
+	if _, err := s.store.Put(ctx, name, *entry.Value, domain.ValueTypeSecret, lo.FromPtr(entry.Description), writeOptions(ctx)...); err != nil {
 		return fmt.Errorf("failed to update secret: %w", err)
 	}
 
@@ -163,6 +169,31 @@ func (s *AWSSecretStrategy) FetchLastModified(ctx context.Context, name string)
 	return time.Time{}, nil
 }
 
+// FetchBase returns the secret value a staged change was based on from Secrets Manager's
+// version history.
+func (s *AWSSecretStrategy) FetchBase(ctx context.Context, name, version string, at time.Time) (string, error) {
+	return fetchValueAt(ctx, s.store, name, version, at)
+}
+
+// FetchRemoteState returns the secret's last modified time and current version
+// for version-based conflict detection.
+func (s *AWSSecretStrategy) FetchRemoteState(ctx context.Context, name string) (RemoteState, error) {
+	return fetchRemoteState(ctx, s.store, name, "secret")
+}
+
+// Snapshot captures the secret's current state in Secrets Manager before an
+// atomic apply.
+func (s *AWSSecretStrategy) Snapshot(ctx context.Context, name string) (Snapshot, error) {
+	return snapshotEntry(ctx, s.store, name, itemNameSecret)
+}
+
+// Rollback returns the secret in Secrets Manager to a snapshot taken before an
+// atomic apply. A secret the apply deleted is restored from its recovery
+// window; one it created is force-deleted.
+func (s *AWSSecretStrategy) Rollback(ctx context.Context, name string, snap Snapshot) error {
+	return rollbackEntry(ctx, s.store, name, itemNameSecret, snap)
+}
+
 // FetchCurrent fetches the current value from Secrets Manager for diffing.
 func (s *AWSSecretStrategy) FetchCurrent(ctx context.Context, name string) (*FetchResult, error) {
 	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
@@ -228,7 +259,8 @@ func (s *AWSSecretStrategy) FetchCurrentValue(ctx context.Context, name string)
 	}
 
 	result := &EditFetchResult{
-		Value: entry.Value,
+		Value:   entry.Value,
+		Version: entry.Version.ID,
 	}
 
 	if entry.Modified != nil {
@@ -75,6 +75,60 @@ func TestSecretStrategy_Apply(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("create operation restores a deleted secret when staged by undo", func(t *testing.T) {
+		t.Parallel()
+
+		var restored bool
+
+		mock := &providermock.Store{
+			RestoreFunc: func(_ context.Context, name string) error {
+				assert.Equal(t, "my-secret", name)
+
+				restored = true
+
+				return nil
+			},
+			GetFunc: func(_ context.Context, _ string, _ provider.VersionRef) (*domain.Entry, error) {
+				return &domain.Entry{Value: "secret-value", Type: domain.ValueTypeSecret}, nil
+			},
+		}
+
+		s := staging.NewAWSSecretStrategy(mock)
+		err := s.Apply(t.Context(), "my-secret", staging.Entry{
+			Operation: staging.OperationCreate,
+			Value:     lo.ToPtr("secret-value"),
+			Restore:   true,
+		})
+		require.NoError(t, err)
+		assert.True(t, restored)
+	})
+
+	t.Run("create operation falls back to create when restore fails", func(t *testing.T) {
+		t.Parallel()
+
+		var created bool
+
+		mock := &providermock.Store{
+			RestoreFunc: func(context.Context, string) error { return secretNotFound("my-secret") },
+			CreateFunc: func(
+				_ context.Context, _, _ string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+			) (domain.Version, error) {
+				created = true
+
+				return domain.Version{ID: "v1"}, nil
+			},
+		}
+
+		s := staging.NewAWSSecretStrategy(mock)
+		err := s.Apply(t.Context(), "my-secret", staging.Entry{
+			Operation: staging.OperationCreate,
+			Value:     lo.ToPtr("secret-value"),
+			Restore:   true,
+		})
+		require.NoError(t, err)
+		assert.True(t, created)
+	})
+
 	t.Run("create operation error", func(t *testing.T) {
 		t.Parallel()
 
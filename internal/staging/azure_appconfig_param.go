@@ -18,9 +18,12 @@ import (
 //
 //   - Version specifiers (#VERSION, ~SHIFT, :LABEL) are rejected at parse time
 //     via azureappconfigversion.
-//   - Conflict detection is disabled (last-write-wins): FetchLastModified and
-//     the edit base time return zero, so apply never reports a modified-after
-//     conflict. Apply overwrites unconditionally.
+//   - Conflict detection uses the setting's ETag in place of a version: the
+//     edit and delete flows record it as the entry's BaseVersion, apply reports
+//     a conflict when it has changed, and an update sends it as a
+//     provider.IfMatch precondition so a write racing the check still fails.
+//     FetchLastModified returns zero, so nothing is compared by time, and a
+//     store without ETags (a test double) falls back to last-write-wins.
 //   - Tag mutation is supported (azappconfig/v2 GET-merge-PUT + ETag): ApplyTags
 //     forwards TagEntry.Add/Remove to the store's Tag/Untag.
 //
@@ -52,18 +55,25 @@ func (s *AzureAppConfigParamStrategy) HasDeleteOptions() bool { return false }
 func (s *AzureAppConfigParamStrategy) Apply(ctx context.Context, name string, entry Entry) error {
 	switch entry.Operation {
 	case OperationCreate:
+		if restored, err := restoreDeleted(ctx, s.store, name, s.ItemName(), entry); restored || err != nil {
+			return err
+		}
+
 		return s.applyCreate(ctx, name, entry)
 	case OperationUpdate:
 		return s.applyUpdate(ctx, name, entry)
 	case OperationDelete:
-		return s.applyDelete(ctx, name)
+		return s.applyDelete(ctx, name, entry)
 	default:
 		return fmt.Errorf("unknown operation: %s", entry.Operation)
 	}
 }
 
 func (s *AzureAppConfigParamStrategy) applyCreate(ctx context.Context, name string, entry Entry) error {
-	if _, err := s.store.Create(ctx, name, lo.FromPtr(entry.Value), domain.ValueTypePlaintext, lo.FromPtr(entry.Description)); err != nil {
+	if _, err := s.store.Create(
+		ctx, name, lo.FromPtr(entry.Value), appConfigValueType(entry), lo.FromPtr(entry.Description),
+		writeOptions(ctx, attributeOptions(entry.Attributes)...)...,
+	); err != nil {
 		return fmt.Errorf("failed to create setting: %w", err)
 	}
 
@@ -75,16 +85,43 @@ func (s *AzureAppConfigParamStrategy) applyUpdate(ctx context.Context, name stri
 		return nil
 	}
 
-	// Last-write-wins: Put overwrites the current value unconditionally.
-	if _, err := s.store.Put(ctx, name, *entry.Value, domain.ValueTypePlaintext, lo.FromPtr(entry.Description)); err != nil {
+	// A recorded ETag makes the write conditional on the setting being unchanged
+	// since it was staged; without one Put overwrites unconditionally.
+	opts := attributeOptions(entry.Attributes)
+	if entry.BaseVersion != "" {
+		opts = append(opts, provider.IfMatch{Version: entry.BaseVersion})
+	}
+
+	if _, err := s.store.Put(
+		ctx, name, *entry.Value, appConfigValueType(entry), lo.FromPtr(entry.Description), writeOptions(ctx, opts...)...,
+	); err != nil {
 		return fmt.Errorf("failed to update setting: %w", err)
 	}
 
 	return nil
 }
 
-func (s *AzureAppConfigParamStrategy) applyDelete(ctx context.Context, name string) error {
-	if err := s.store.Delete(ctx, name); err != nil {
+// appConfigValueType is the value type a staged App Configuration write applies
+// with. Only a Key Vault reference carries one (so the adapter writes the
+// reference content-type); anything else is plaintext, and the adapter keeps
+// the setting's existing content-type on an update unless the entry's
+// Attributes carry one.
+func appConfigValueType(entry Entry) domain.ValueType {
+	if entry.ValueType == domain.ValueTypeReference {
+		return domain.ValueTypeReference
+	}
+
+	return domain.ValueTypePlaintext
+}
+
+func (s *AzureAppConfigParamStrategy) applyDelete(ctx context.Context, name string, entry Entry) error {
+	// As for an update, a recorded ETag makes the delete conditional.
+	var opts []provider.DeleteOption
+	if entry.BaseVersion != "" {
+		opts = append(opts, provider.IfMatch{Version: entry.BaseVersion})
+	}
+
+	if err := s.store.Delete(ctx, name, opts...); err != nil {
 		if errors.Is(err, provider.ErrNotFound) {
 			return nil
 		}
@@ -95,6 +132,49 @@ func (s *AzureAppConfigParamStrategy) applyDelete(ctx context.Context, name stri
 	return nil
 }
 
+// appConfigLocker is the App-Config-specific read-only lock surface the
+// strategy reaches by type-asserting its store (see appconfig.Store.Lock).
+type appConfigLocker interface {
+	Locked(ctx context.Context, name string) (bool, error)
+	Lock(ctx context.Context, name string) error
+	Unlock(ctx context.Context, name string) error
+}
+
+// errLocksUnsupported is returned by Lock/Unlock when the store has no lock
+// surface (a test double, or a parser-only strategy).
+var errLocksUnsupported = errors.New("store does not support locks")
+
+// FetchLocked reports whether the setting is locked read-only. A store without
+// the lock surface never reports a lock.
+func (s *AzureAppConfigParamStrategy) FetchLocked(ctx context.Context, name string) (bool, error) {
+	locker, ok := s.store.(appConfigLocker)
+	if !ok {
+		return false, nil
+	}
+
+	return locker.Locked(ctx, name)
+}
+
+// Lock marks the setting read-only.
+func (s *AzureAppConfigParamStrategy) Lock(ctx context.Context, name string) error {
+	locker, ok := s.store.(appConfigLocker)
+	if !ok {
+		return errLocksUnsupported
+	}
+
+	return locker.Lock(ctx, name)
+}
+
+// Unlock clears the setting's read-only flag.
+func (s *AzureAppConfigParamStrategy) Unlock(ctx context.Context, name string) error {
+	locker, ok := s.store.(appConfigLocker)
+	if !ok {
+		return errLocksUnsupported
+	}
+
+	return locker.Unlock(ctx, name)
+}
+
 // ApplyTags applies staged tag changes to App Configuration: TagEntry.Add via
 // the store's Tag and TagEntry.Remove via Untag (each a GET-merge-PUT under the
 // scope's namespace label). Additions are applied before removals.
@@ -115,14 +195,53 @@ func (s *AzureAppConfigParamStrategy) ApplyTags(ctx context.Context, name string
 }
 
 // FetchLastModified returns a zero time with a nil error: App Configuration
-// staging uses last-write-wins, so no modified-after conflict is ever reported.
-// The nil error means the delete use case treats every setting as existing
-// (never "not found"); apply is idempotent on a missing setting, so this is
-// consistent with the last-write-wins model.
+// conflicts are detected by ETag (see FetchRemoteState), never by time. Callers
+// that only probe the time treat every setting as existing (never "not
+// found"); apply is idempotent on a missing setting, so this is harmless.
 func (s *AzureAppConfigParamStrategy) FetchLastModified(_ context.Context, _ string) (time.Time, error) {
 	return time.Time{}, nil
 }
 
+// appConfigETagger is the App-Config-specific read the strategy reaches by
+// type-asserting its store (see appconfig.Store.GetWithETag): the ETag stands
+// in for the version App Configuration does not have.
+type appConfigETagger interface {
+	GetWithETag(ctx context.Context, name string) (*domain.Entry, string, error)
+}
+
+// FetchRemoteState returns the setting's ETag as its version, so a staged
+// change conflicts exactly when the setting was written since. A store without
+// ETags reports the zero state, which never conflicts.
+func (s *AzureAppConfigParamStrategy) FetchRemoteState(ctx context.Context, name string) (RemoteState, error) {
+	etagger, ok := s.store.(appConfigETagger)
+	if !ok {
+		return RemoteState{}, nil
+	}
+
+	_, etag, err := etagger.GetWithETag(ctx, name)
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return RemoteState{}, &ResourceNotFoundError{Err: err}
+		}
+
+		return RemoteState{}, fmt.Errorf("failed to get setting: %w", err)
+	}
+
+	return RemoteState{Version: etag}, nil
+}
+
+// Snapshot captures the setting's current state in App Configuration before an
+// atomic apply.
+func (s *AzureAppConfigParamStrategy) Snapshot(ctx context.Context, name string) (Snapshot, error) {
+	return snapshotEntry(ctx, s.store, name, s.ItemName())
+}
+
+// Rollback returns the setting in App Configuration to a snapshot taken before
+// an atomic apply.
+func (s *AzureAppConfigParamStrategy) Rollback(ctx context.Context, name string, snap Snapshot) error {
+	return rollbackEntry(ctx, s.store, name, s.ItemName(), snap)
+}
+
 // FetchCurrent fetches the current value from App Configuration for diffing.
 // App Configuration is unversioned, so the identifier is empty.
 func (s *AzureAppConfigParamStrategy) FetchCurrent(ctx context.Context, name string) (*FetchResult, error) {
@@ -170,10 +289,22 @@ func (s *AzureAppConfigParamStrategy) ParseName(input string) (string, error) {
 	return spec.Name, nil
 }
 
-// FetchCurrentValue fetches the current value for editing. LastModified is left
-// zero so the edit flow records no conflict base (last-write-wins).
+// FetchCurrentValue fetches the current value for editing, with the setting's
+// ETag as the version the edit flow records as its conflict base. LastModified
+// is left zero: App Configuration conflicts are never compared by time.
 func (s *AzureAppConfigParamStrategy) FetchCurrentValue(ctx context.Context, name string) (*EditFetchResult, error) {
-	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
+	var (
+		entry *domain.Entry
+		etag  string
+		err   error
+	)
+
+	if etagger, ok := s.store.(appConfigETagger); ok {
+		entry, etag, err = etagger.GetWithETag(ctx, name)
+	} else {
+		entry, err = s.store.Get(ctx, name, provider.VersionRef{})
+	}
+
 	if err != nil {
 		if errors.Is(err, provider.ErrNotFound) {
 			return nil, &ResourceNotFoundError{Err: err}
@@ -182,7 +313,7 @@ func (s *AzureAppConfigParamStrategy) FetchCurrentValue(ctx context.Context, nam
 		return nil, err
 	}
 
-	return &EditFetchResult{Value: entry.Value}, nil
+	return &EditFetchResult{Value: entry.Value, Version: etag}, nil
 }
 
 // ParseSpec parses a name for reset. App Configuration is unversioned, so a
@@ -75,6 +75,36 @@ func TestAzureAppConfigParamStrategy_Apply(t *testing.T) {
 		assert.True(t, putCalled)
 	})
 
+	t.Run("staged Key Vault reference keeps its value type", func(t *testing.T) {
+		t.Parallel()
+
+		var createType, putType domain.ValueType
+
+		store := &providermock.Store{
+			CreateFunc: func(_ context.Context, _, _ string, vt domain.ValueType, _ string, _ ...provider.WriteOption) (domain.Version, error) {
+				createType = vt
+
+				return domain.Version{}, nil
+			},
+			PutFunc: func(_ context.Context, _, _ string, vt domain.ValueType, _ string, _ ...provider.WriteOption) (domain.Version, error) {
+				putType = vt
+
+				return domain.Version{}, nil
+			},
+		}
+		s := staging.NewAzureAppConfigParamStrategy(store)
+
+		ref := lo.ToPtr("https://my-vault.vault.azure.net/secrets/db")
+		require.NoError(t, s.Apply(t.Context(), "cfg", staging.Entry{
+			Operation: staging.OperationCreate, Value: ref, ValueType: domain.ValueTypeReference,
+		}))
+		require.NoError(t, s.Apply(t.Context(), "cfg", staging.Entry{
+			Operation: staging.OperationUpdate, Value: ref, ValueType: domain.ValueTypeReference,
+		}))
+		assert.Equal(t, domain.ValueTypeReference, createType)
+		assert.Equal(t, domain.ValueTypeReference, putType)
+	})
+
 	t.Run("delete", func(t *testing.T) {
 		t.Parallel()
 
@@ -165,9 +195,9 @@ func TestAzureAppConfigParamStrategy_ErrorWrapping(t *testing.T) {
 	})
 }
 
-// TestAzureAppConfigParamStrategy_LastWriteWins asserts the last-write-wins
-// semantics: FetchLastModified always returns zero time and never touches the
-// store, so no modified-after conflict is ever reported.
+// TestAzureAppConfigParamStrategy_LastWriteWins asserts FetchLastModified always
+// returns zero time and never touches the store: App Configuration conflicts
+// are detected by ETag, never by time.
 func TestAzureAppConfigParamStrategy_LastWriteWins(t *testing.T) {
 	t.Parallel()
 
@@ -178,6 +208,76 @@ func TestAzureAppConfigParamStrategy_LastWriteWins(t *testing.T) {
 	assert.True(t, got.IsZero())
 }
 
+// etagStore is a providermock.Store that also serves App Configuration ETags.
+type etagStore struct {
+	*providermock.Store
+
+	etag string
+}
+
+func (s *etagStore) GetWithETag(ctx context.Context, name string) (*domain.Entry, string, error) {
+	entry, err := s.Get(ctx, name, provider.VersionRef{})
+
+	return entry, s.etag, err
+}
+
+// TestAzureAppConfigParamStrategy_ETag asserts the setting's ETag is the
+// version conflict detection records, and that an update or a delete sends
+// the recorded ETag as an IfMatch precondition.
+func TestAzureAppConfigParamStrategy_ETag(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gotOpts       []provider.WriteOption
+		gotDeleteOpts []provider.DeleteOption
+	)
+
+	store := &etagStore{
+		Store: &providermock.Store{
+			GetFunc: func(_ context.Context, _ string, _ provider.VersionRef) (*domain.Entry, error) {
+				return &domain.Entry{Value: "v"}, nil
+			},
+			PutFunc: func(_ context.Context, _, _ string, _ domain.ValueType, _ string, opts ...provider.WriteOption) (domain.Version, error) {
+				gotOpts = opts
+
+				return domain.Version{}, nil
+			},
+			DeleteFunc: func(_ context.Context, _ string, opts ...provider.DeleteOption) error {
+				gotDeleteOpts = opts
+
+				return nil
+			},
+		},
+		etag: "etag-1",
+	}
+	s := staging.NewAzureAppConfigParamStrategy(store)
+
+	state, err := s.FetchRemoteState(t.Context(), "cfg")
+	require.NoError(t, err)
+	assert.Equal(t, staging.RemoteState{Version: "etag-1"}, state)
+
+	edit, err := s.FetchCurrentValue(t.Context(), "cfg")
+	require.NoError(t, err)
+	assert.Equal(t, "etag-1", edit.Version)
+
+	require.NoError(t, s.Apply(t.Context(), "cfg", staging.Entry{
+		Operation: staging.OperationUpdate, Value: lo.ToPtr("new"), BaseVersion: "etag-1",
+	}))
+	assert.Equal(t, []provider.WriteOption{provider.IfMatch{Version: "etag-1"}}, gotOpts)
+
+	require.NoError(t, s.Apply(t.Context(), "cfg", staging.Entry{
+		Operation: staging.OperationUpdate, Value: lo.ToPtr("new"), BaseVersion: "etag-1",
+		Attributes: &staging.SecretAttributes{ContentType: lo.ToPtr("application/json")},
+	}))
+	assert.Equal(t, []provider.WriteOption{
+		provider.SecretAttributes{ContentType: lo.ToPtr("application/json")},
+		provider.IfMatch{Version: "etag-1"},
+	}, gotOpts, "a staged content type is written with the value")
+
+	require.NoError(t, s.Apply(t.Context(), "cfg", staging.Entry{Operation: staging.OperationDelete, BaseVersion: "etag-1"}))
+	assert.Equal(t, []provider.DeleteOption{provider.IfMatch{Version: "etag-1"}}, gotDeleteOpts)
+}
+
 // TestAzureAppConfigParamStrategy_ApplyTags asserts staged tag changes forward
 // to the store's Tag (adds) and Untag (removes).
 func TestAzureAppConfigParamStrategy_ApplyTags(t *testing.T) {
@@ -25,6 +25,8 @@ import (
 //     delete), so HasDeleteOptions reports false.
 //   - Tags are writable, so tag/untag staging is supported.
 //   - Conflict detection uses the secret's last-modified timestamp, like AWS.
+//   - Staged Entry.Attributes (content type, activation window, enabled) are
+//     written with the new version as a provider.SecretAttributes option.
 //
 // A nil store yields a parser-only strategy (ParseName/ParseSpec).
 type AzureKeyVaultSecretStrategy struct {
@@ -54,6 +56,10 @@ func (s *AzureKeyVaultSecretStrategy) HasDeleteOptions() bool { return false }
 func (s *AzureKeyVaultSecretStrategy) Apply(ctx context.Context, name string, entry Entry) error {
 	switch entry.Operation {
 	case OperationCreate:
+		if restored, err := restoreDeleted(ctx, s.store, name, s.ItemName(), entry); restored || err != nil {
+			return err
+		}
+
 		return s.applyCreate(ctx, name, entry)
 	case OperationUpdate:
 		return s.applyUpdate(ctx, name, entry)
@@ -65,7 +71,10 @@ func (s *AzureKeyVaultSecretStrategy) Apply(ctx context.Context, name string, en
 }
 
 func (s *AzureKeyVaultSecretStrategy) applyCreate(ctx context.Context, name string, entry Entry) error {
-	if _, err := s.store.Create(ctx, name, lo.FromPtr(entry.Value), domain.ValueTypeSecret, lo.FromPtr(entry.Description)); err != nil {
+	opts := writeOptions(ctx, attributeOptions(entry.Attributes)...)
+
+	_, err := s.store.Create(ctx, name, lo.FromPtr(entry.Value), domain.ValueTypeSecret, lo.FromPtr(entry.Description), opts...)
+	if err != nil {
 		return fmt.Errorf("failed to create secret: %w", err)
 	}
 
@@ -78,7 +87,10 @@ func (s *AzureKeyVaultSecretStrategy) applyUpdate(ctx context.Context, name stri
 	}
 
 	// Key Vault versions are immutable: Put adds a new version.
-	if _, err := s.store.Put(ctx, name, *entry.Value, domain.ValueTypeSecret, lo.FromPtr(entry.Description)); err != nil {
+	opts := writeOptions(ctx, attributeOptions(entry.Attributes)...)
+
+	_, err := s.store.Put(ctx, name, *entry.Value, domain.ValueTypeSecret, lo.FromPtr(entry.Description), opts...)
+	if err != nil {
 		return fmt.Errorf("failed to update secret: %w", err)
 	}
 
@@ -97,6 +109,21 @@ func (s *AzureKeyVaultSecretStrategy) applyDelete(ctx context.Context, name stri
 	return nil
 }
 
+// attributeOptions translates staged secret attributes into the provider write
+// option; nil attributes yield no option.
+func attributeOptions(a *SecretAttributes) []provider.WriteOption {
+	if a == nil {
+		return nil
+	}
+
+	return []provider.WriteOption{provider.SecretAttributes{
+		ContentType: a.ContentType,
+		NotBefore:   a.NotBefore,
+		Expires:     a.Expires,
+		Enabled:     a.Enabled,
+	}}
+}
+
 // ApplyTags applies staged tag changes to the secret.
 func (s *AzureKeyVaultSecretStrategy) ApplyTags(ctx context.Context, name string, tagEntry TagEntry) error {
 	if len(tagEntry.Add) > 0 {
@@ -135,6 +162,32 @@ func (s *AzureKeyVaultSecretStrategy) FetchLastModified(ctx context.Context, nam
 	return time.Time{}, nil
 }
 
+// FetchBase returns the secret value a staged change was based on from Key Vault's
+// version history.
+func (s *AzureKeyVaultSecretStrategy) FetchBase(ctx context.Context, name, version string, at time.Time) (string, error) {
+	return fetchValueAt(ctx, s.store, name, version, at)
+}
+
+// FetchRemoteState returns the secret's last modified time and current version
+// for version-based conflict detection.
+func (s *AzureKeyVaultSecretStrategy) FetchRemoteState(ctx context.Context, name string) (RemoteState, error) {
+	return fetchRemoteState(ctx, s.store, name, "secret")
+}
+
+// Snapshot captures the secret's current state in Key Vault before an atomic
+// apply.
+func (s *AzureKeyVaultSecretStrategy) Snapshot(ctx context.Context, name string) (Snapshot, error) {
+	return snapshotEntry(ctx, s.store, name, itemNameSecret)
+}
+
+// Rollback returns the secret in Key Vault to a snapshot taken before an atomic
+// apply. A secret the apply deleted is recovered from soft delete; one it
+// created is deleted, which leaves it soft-deleted until the vault's retention
+// period ends.
+func (s *AzureKeyVaultSecretStrategy) Rollback(ctx context.Context, name string, snap Snapshot) error {
+	return rollbackEntry(ctx, s.store, name, itemNameSecret, snap)
+}
+
 // FetchCurrent fetches the current value from Key Vault for diffing.
 func (s *AzureKeyVaultSecretStrategy) FetchCurrent(ctx context.Context, name string) (*FetchResult, error) {
 	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
@@ -197,7 +250,7 @@ func (s *AzureKeyVaultSecretStrategy) FetchCurrentValue(ctx context.Context, nam
 		return nil, err
 	}
 
-	result := &EditFetchResult{Value: entry.Value}
+	result := &EditFetchResult{Value: entry.Value, Version: entry.Version.ID}
 	if entry.Modified != nil {
 		result.LastModified = *entry.Modified
 	}
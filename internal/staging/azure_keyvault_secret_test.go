@@ -76,6 +76,33 @@ func TestAzureKeyVaultSecretStrategy_Apply(t *testing.T) {
 		assert.True(t, putCalled)
 	})
 
+	t.Run("staged attributes are written with the version", func(t *testing.T) {
+		t.Parallel()
+
+		exp := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		var got []provider.WriteOption
+
+		store := &providermock.Store{
+			PutFunc: func(_ context.Context, _, _ string, _ domain.ValueType, _ string, opts ...provider.WriteOption) (domain.Version, error) {
+				got = opts
+
+				return domain.Version{ID: "def"}, nil
+			},
+		}
+		s := staging.NewAzureKeyVaultSecretStrategy(store)
+
+		err := s.Apply(t.Context(), "sec", staging.Entry{
+			Operation:  staging.OperationUpdate,
+			Value:      lo.ToPtr("v2"),
+			Attributes: &staging.SecretAttributes{Expires: &exp, Enabled: lo.ToPtr(true)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []provider.WriteOption{
+			provider.SecretAttributes{Expires: &exp, Enabled: lo.ToPtr(true)},
+		}, got)
+	})
+
 	t.Run("delete", func(t *testing.T) {
 		t.Parallel()
 
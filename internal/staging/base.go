@@ -0,0 +1,243 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// ErrBaseUnavailable is returned by BaseFetcher when the version a staged
+// change was based on is no longer (or never was) retained.
+var ErrBaseUnavailable = errors.New("base version is not available")
+
+// fetchValueAt reads the value the staged change was based on: the recorded
+// base version when there is one, else the newest version created at or before
+// at. History is newest first; versions without a creation time are skipped.
+func fetchValueAt(ctx context.Context, store provider.Reader, name, version string, at time.Time) (string, error) {
+	if version != "" {
+		entry, err := store.Get(ctx, name, provider.NewVersionRef(version))
+		if err != nil {
+			if errors.Is(err, provider.ErrNotFound) {
+				return "", ErrBaseUnavailable
+			}
+
+			return "", fmt.Errorf("failed to read base version: %w", err)
+		}
+
+		return entry.Value, nil
+	}
+
+	versions, err := store.History(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to read history: %w", err)
+	}
+
+	for _, v := range versions {
+		if v.Created == nil || v.Created.After(at) {
+			continue
+		}
+
+		entry, err := store.Get(ctx, name, provider.NewVersionRef(v.ID))
+		if err != nil {
+			return "", fmt.Errorf("failed to read base version: %w", err)
+		}
+
+		return entry.Value, nil
+	}
+
+	return "", ErrBaseUnavailable
+}
+
+// fetchRemoteState reads the latest version of name for conflict detection,
+// mapping a missing entry to *ResourceNotFoundError like FetchLastModified.
+func fetchRemoteState(ctx context.Context, store provider.Reader, name, itemName string) (RemoteState, error) {
+	entry, err := store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return RemoteState{}, &ResourceNotFoundError{Err: err}
+		}
+
+		return RemoteState{}, fmt.Errorf("failed to get %s: %w", itemName, err)
+	}
+
+	return RemoteState{LastModified: lo.FromPtr(entry.Modified), Version: entry.Version.ID}, nil
+}
+
+// snapshotEntry captures name's current state for Rollbacker.Snapshot.
+func snapshotEntry(ctx context.Context, store provider.Reader, name, itemName string) (Snapshot, error) {
+	entry, err := store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return Snapshot{}, nil
+		}
+
+		return Snapshot{}, fmt.Errorf("failed to snapshot %s: %w", itemName, err)
+	}
+
+	tags := make(map[string]string, len(entry.Tags))
+	for _, tag := range entry.Tags {
+		tags[tag.Key] = tag.Value
+	}
+
+	return Snapshot{
+		Exists:      true,
+		Value:       entry.Value,
+		ValueType:   entry.Type,
+		Description: entry.Description,
+		Version:     entry.Version.ID,
+		Tags:        tags,
+	}, nil
+}
+
+// rollbackEntry returns name to snap for Rollbacker.Rollback. An entry that did
+// not exist is deleted for good (provider.ForceDelete); one that was deleted is
+// restored when the store can cancel the deletion (provider.Restorer) and
+// re-created otherwise. The value is put back only when it differs, so rolling
+// back an untouched entry writes nothing.
+func rollbackEntry(ctx context.Context, store provider.Store, name, itemName string, snap Snapshot) error {
+	current, err := store.Get(ctx, name, provider.VersionRef{})
+	if err != nil && !errors.Is(err, provider.ErrNotFound) {
+		return fmt.Errorf("failed to get %s: %w", itemName, err)
+	}
+
+	if !snap.Exists {
+		if current == nil {
+			return nil
+		}
+
+		if err := store.Delete(ctx, name, provider.ForceDelete{}); err != nil && !errors.Is(err, provider.ErrNotFound) {
+			return fmt.Errorf("failed to delete %s: %w", itemName, err)
+		}
+
+		return nil
+	}
+
+	if current == nil {
+		current, err = recreateEntry(ctx, store, name, itemName, snap)
+		if err != nil {
+			return err
+		}
+	}
+
+	if current.Value != snap.Value || current.Type != snap.ValueType {
+		if _, err := store.Put(ctx, name, snap.Value, snap.ValueType, snap.Description); err != nil {
+			return fmt.Errorf("failed to put back %s: %w", itemName, err)
+		}
+	}
+
+	return syncTags(ctx, store, name, current.Tags, snap.Tags)
+}
+
+// recreateEntry brings back a deleted entry for rollbackEntry and returns its
+// state afterwards: by cancelling its deletion when the store supports
+// provider.Restorer, or else (or when that fails, e.g. the entry was purged)
+// by creating it afresh from the snapshot.
+func recreateEntry(ctx context.Context, store provider.Store, name, itemName string, snap Snapshot) (*domain.Entry, error) {
+	var restoreErr error
+
+	if restorer, ok := store.(provider.Restorer); ok {
+		if restoreErr = restorer.Restore(ctx, name); restoreErr == nil {
+			entry, err := store.Get(ctx, name, provider.VersionRef{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get restored %s: %w", itemName, err)
+			}
+
+			return entry, nil
+		}
+	}
+
+	if _, err := store.Create(ctx, name, snap.Value, snap.ValueType, snap.Description); err != nil {
+		if restoreErr != nil {
+			err = errors.Join(fmt.Errorf("failed to restore %s: %w", itemName, restoreErr), err)
+		}
+
+		return nil, fmt.Errorf("failed to re-create %s: %w", itemName, err)
+	}
+
+	// The re-created entry holds the snapshot's value and no tags yet.
+	return &domain.Entry{Value: snap.Value, Type: snap.ValueType}, nil
+}
+
+// restoreDeleted handles a create staged with Entry.Restore before the
+// strategy's own create. It reports whether it brought the entry back: by
+// cancelling its deletion when the store supports provider.Restorer, then
+// putting the staged value when it differs from the restored one. It reports
+// false, with no error, when the store cannot restore or there is nothing to
+// restore (the entry was purged), so the caller creates the entry afresh.
+func restoreDeleted(ctx context.Context, store provider.Store, name, itemName string, entry Entry) (bool, error) {
+	restorer, ok := store.(provider.Restorer)
+	if !entry.Restore || !ok {
+		return false, nil
+	}
+
+	if err := restorer.Restore(ctx, name); err != nil {
+		return false, nil //nolint:nilerr // an unrestorable entry is re-created instead
+	}
+
+	restored, err := store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		return true, fmt.Errorf("failed to get restored %s: %w", itemName, err)
+	}
+
+	if entry.Value == nil || (restored.Value == *entry.Value && (entry.ValueType == "" || restored.Type == entry.ValueType)) {
+		return true, nil
+	}
+
+	valueType := restored.Type
+	if entry.ValueType != "" {
+		valueType = entry.ValueType
+	}
+
+	if _, err := store.Put(ctx, name, *entry.Value, valueType, lo.FromPtr(entry.Description), writeOptions(ctx)...); err != nil {
+		return true, fmt.Errorf("failed to update restored %s: %w", itemName, err)
+	}
+
+	return true, nil
+}
+
+// syncTags makes name's tags match want, given its current tags.
+func syncTags(ctx context.Context, store provider.Tagger, name string, current []domain.Tag, want map[string]string) error {
+	have := make(map[string]string, len(current))
+	for _, tag := range current {
+		have[tag.Key] = tag.Value
+	}
+
+	add := make(map[string]string)
+
+	for k, v := range want {
+		if hv, ok := have[k]; !ok || hv != v {
+			add[k] = v
+		}
+	}
+
+	var remove []string
+
+	for k := range have {
+		if _, ok := want[k]; !ok {
+			remove = append(remove, k)
+		}
+	}
+
+	if len(add) > 0 {
+		if err := store.Tag(ctx, name, add); err != nil {
+			return fmt.Errorf("failed to restore tags: %w", err)
+		}
+	}
+
+	if len(remove) > 0 {
+		slices.Sort(remove)
+
+		if err := store.Untag(ctx, name, remove); err != nil {
+			return fmt.Errorf("failed to restore tags: %w", err)
+		}
+	}
+
+	return nil
+}
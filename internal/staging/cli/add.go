@@ -31,6 +31,9 @@ type AddOptions struct {
 	// ValueType is the provider-neutral value type to record on the staged entry
 	// (AWS SSM Parameter Store axis). Empty for providers without a type axis.
 	ValueType domain.ValueType
+	// Attributes are the Azure Key Vault secret attributes to record on the
+	// staged entry; nil for every other provider (or when none was given).
+	Attributes *staging.SecretAttributes
 }
 
 // Run executes the add command.
@@ -78,6 +81,7 @@ func (r *AddRunner) Run(ctx context.Context, opts AddOptions) error {
 		Value:       newValue,
 		Description: opts.Description,
 		ValueType:   opts.ValueType,
+		Attributes:  opts.Attributes,
 	})
 	if err != nil {
 		return err
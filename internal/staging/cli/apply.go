@@ -35,8 +35,15 @@ type ApplyRunner struct {
 
 // ApplyOptions holds options for the apply command.
 type ApplyOptions struct {
-	Name            string // Optional: apply only this item, otherwise apply all
-	IgnoreConflicts bool   // Skip conflict detection and force apply
+	Name            string             // Optional: apply only this item, otherwise apply all
+	Select          staging.Selector   // Optional: apply only the changes it selects
+	IgnoreConflicts bool               // Skip conflict detection and force apply
+	UnlockLocked    bool               // Unlock, write and relock read-only entries
+	Atomic          bool               // Roll back applied changes if any change fails
+	Message         string             // Change message recorded with the written values
+	MessageTag      string             // Tag the change message is also set as
+	Order           staging.ApplyOrder // Apply order of the changes beyond creates, updates, deletes
+	Concurrency     int                // Maximum changes applied at once (0 for the default)
 }
 
 // RunInteractive performs the command-level apply flow: it lists staged
@@ -68,6 +75,18 @@ func (r *ApplyRunner) RunInteractive(ctx context.Context, opts ApplyOptions) err
 		return nil
 	}
 
+	// Only the selected changes are applied, confirmed and counted.
+	if !opts.Select.IsZero() {
+		serviceEntries = opts.Select.SelectEntries(serviceEntries)
+		serviceTags = opts.Select.SelectTags(serviceTags)
+
+		if len(serviceEntries) == 0 && len(serviceTags) == 0 {
+			output.Info(r.Stdout, "No staged %s changes match the selection.", r.Parser.ServiceName())
+
+			return nil
+		}
+	}
+
 	// Validate the target name if specified: staged as an entry OR a tag change.
 	// Items are keyed by EntryKey (name, namespace), so match on the key's name —
 	// a name may be staged under several App Configuration namespaces.
@@ -118,11 +137,22 @@ func (r *ApplyRunner) RunInteractive(ctx context.Context, opts ApplyOptions) err
 
 // Run applies the staged changes via the usecase and reports the results.
 func (r *ApplyRunner) Run(ctx context.Context, opts ApplyOptions) error {
-	result, err := r.UseCase.Execute(ctx, stagingusecase.ApplyInput{
+	// The live progress is erased before the results are printed.
+	progress := StartProgress(r.Stderr)
+	result, err := r.UseCase.Execute(progress.Context(ctx), stagingusecase.ApplyInput{
 		Name:            opts.Name,
+		Select:          opts.Select,
 		IgnoreConflicts: opts.IgnoreConflicts,
+		UnlockLocked:    opts.UnlockLocked,
+		Atomic:          opts.Atomic,
+		Message:         opts.Message,
+		MessageTag:      opts.MessageTag,
+		Order:           opts.Order,
+		Concurrency:     opts.Concurrency,
 	})
 
+	progress.Stop()
+
 	// Handle nil result (shouldn't happen but be safe)
 	if result == nil {
 		return err
@@ -134,6 +164,13 @@ func (r *ApplyRunner) Run(ctx context.Context, opts ApplyOptions) error {
 		output.Warning(r.Stderr, "conflict detected for %s: AWS was modified after staging", key.Label())
 	}
 
+	// Locked entries are a failure class of their own: nothing was attempted, and
+	// the fix is an unlock rather than a retry.
+	if len(result.Locked) > 0 {
+		output.Warning(r.Stderr, "%d %s(s) locked read-only and not applied; re-run with --%s to unlock, write and relock",
+			len(result.Locked), result.ItemName, flagUnlockLocked)
+	}
+
 	// Handle "nothing staged" case
 	if len(result.EntryResults) == 0 && len(result.TagResults) == 0 && err == nil {
 		output.Info(r.Stdout, "No %s changes staged.", result.ServiceName)
@@ -168,6 +205,10 @@ func (r *ApplyRunner) Run(ctx context.Context, opts ApplyOptions) error {
 				if entry.UnstageError != nil {
 					output.Warning(r.Stderr, "failed to clear staging for %s: %v", name, entry.UnstageError)
 				}
+
+				if entry.MessageTagError != nil {
+					output.Warning(r.Stderr, "failed to set the change message tag on %s: %v", name, entry.MessageTagError)
+				}
 			}
 
 			break
@@ -195,6 +236,29 @@ func (r *ApplyRunner) Run(ctx context.Context, opts ApplyOptions) error {
 		}
 	}
 
+	// A failed atomic apply rolled back what it had applied; everything it was
+	// asked to apply is still staged.
+	if len(result.Rollbacks) > 0 {
+		output.Warning(r.Stderr, "atomic apply failed; rolling back written changes (all changes remain staged)")
+	}
+
+	for _, rollback := range result.Rollbacks {
+		label := staging.EntryKey{Name: rollback.Name, Namespace: rollback.Namespace}.Label()
+		if rollback.Error != nil {
+			output.Failed(r.Stderr, label+" (rollback)", rollback.Error)
+		} else {
+			output.Success(r.Stdout, "Rolled back %s", label)
+		}
+	}
+
+	// The journal only records the apply: a failure to write it is reported,
+	// but does not fail the apply.
+	if result.JournalError != nil {
+		output.Warning(r.Stderr, "apply not recorded in the apply journal: %v", result.JournalError)
+	} else if result.JournalID != "" {
+		output.Info(r.Stdout, "Recorded as apply %s in the apply journal", result.JournalID)
+	}
+
 	// Return the original error if any (e.g., from conflict detection or failures)
 	return err
 }
@@ -0,0 +1,371 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/file"
+	stagingusecase "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// changesetScopes resolves the distinct staging scopes of the provider's
+// services. Changesets live per scope, so AWS (one account scope for both
+// services) yields one scope and Azure up to two; unconfigured services are
+// skipped.
+func changesetScopes(ctx context.Context, gcfg GlobalConfig) ([]provider.Scope, error) {
+	var scopes []provider.Scope
+
+	seen := map[string]bool{}
+
+	for _, spec := range gcfg.Services {
+		resolved, err := resolveScope(ctx, spec.ScopeResolver)
+		if errors.Is(err, staging.ErrServiceNotConfigured) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if key := resolved.Scope.Key(); !seen[key] {
+			seen[key] = true
+			scopes = append(scopes, resolved.Scope)
+		}
+	}
+
+	if len(scopes) == 0 {
+		return nil, staging.ErrServiceNotConfigured
+	}
+
+	return scopes, nil
+}
+
+// NewGlobalSwitchCommand creates the provider-wide switch command, which
+// selects the changeset every other stage command works on.
+func NewGlobalSwitchCommand(gcfg GlobalConfig) *cli.Command {
+	return &cli.Command{
+		Name:      "switch",
+		Usage:     "Switch the active staging changeset",
+		ArgsUsage: "<changeset>",
+		Description: `Switch the changeset that stage commands read and write. Each changeset is
+an independent set of staged changes kept next to the default one, so an
+urgent fix can be staged and applied without touching a half-staged migration.
+
+Use -c/--create to create the changeset and switch to it in one step. Switch to
+"` + file.DefaultChangeset + `" to return to the original staging area.
+
+EXAMPLES:
+   suve stage switch -c rotate-db     Create changeset rotate-db and switch to it
+   suve stage switch default          Switch back to the default changeset`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "create",
+				Aliases: []string{"c"},
+				Usage:   "Create the changeset before switching to it",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("usage: suve stage switch [-c] <changeset>")
+			}
+
+			name, create := cmd.Args().First(), cmd.Bool("create")
+
+			scopes, err := changesetScopes(ctx, gcfg)
+			if err != nil {
+				return err
+			}
+
+			if err := file.SwitchChangesetIn(scopes, name, create); err != nil {
+				if errors.Is(err, file.ErrChangesetNotFound) {
+					return fmt.Errorf("%w (use -c to create it)", err)
+				}
+
+				return err
+			}
+
+			if create {
+				output.Success(cmd.Root().Writer, "Switched to a new changeset %s", name)
+			} else {
+				output.Success(cmd.Root().Writer, "Switched to changeset %s", name)
+			}
+
+			return nil
+		},
+	}
+}
+
+// NewGlobalBranchCommand creates the provider-wide branch command for listing
+// and deleting changesets.
+func NewGlobalBranchCommand(gcfg GlobalConfig) *cli.Command {
+	list := &cli.Command{
+		Name:  "list",
+		Usage: "List staging changesets",
+		Description: `List the staging changesets. The active one is marked with "*".
+
+EXAMPLES:
+   suve stage branch list    List changesets`,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return listChangesets(ctx, cmd, gcfg)
+		},
+	}
+
+	return &cli.Command{
+		Name:  "branch",
+		Usage: "List or delete staging changesets",
+		Description: `Manage named staging changesets. Use "suve stage switch" to create one or
+change the active one, and "suve stage move" to carry a staged change across.
+
+EXAMPLES:
+   suve stage branch list               List changesets
+   suve stage branch delete rotate-db   Delete an empty changeset`,
+		Commands: []*cli.Command{
+			list,
+			{
+				Name:      "delete",
+				Usage:     "Delete a staging changeset",
+				ArgsUsage: "<changeset>",
+				Description: `Delete a named changeset. The active changeset and the default one cannot be
+deleted. A changeset that still holds staged changes is only deleted with
+--force, which discards them.
+
+EXAMPLES:
+   suve stage branch delete rotate-db           Delete an empty changeset
+   suve stage branch delete --force rotate-db   Delete it with its staged changes`,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "force",
+						Aliases: []string{"f"},
+						Usage:   "Delete even if the changeset still has staged changes",
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.Args().Len() != 1 {
+						return errors.New("usage: suve stage branch delete [--force] <changeset>")
+					}
+
+					return deleteChangeset(ctx, cmd, gcfg, cmd.Args().First(), cmd.Bool("force"))
+				},
+			},
+		},
+		Action: list.Action,
+	}
+}
+
+// listChangesets prints the changesets across the provider's scopes, marking
+// the active one.
+func listChangesets(ctx context.Context, cmd *cli.Command, gcfg GlobalConfig) error {
+	scopes, err := changesetScopes(ctx, gcfg)
+	if err != nil {
+		return err
+	}
+
+	names, active, err := file.ListChangesetsIn(scopes)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+
+		output.Printf(cmd.Root().Writer, "%s %s\n", marker, name)
+	}
+
+	return nil
+}
+
+// deleteChangeset removes the named changeset from every scope that has it.
+func deleteChangeset(ctx context.Context, cmd *cli.Command, gcfg GlobalConfig, name string, force bool) error {
+	if name == file.DefaultChangeset {
+		return errors.New("the default changeset cannot be deleted")
+	}
+
+	scopes, err := changesetScopes(ctx, gcfg)
+	if err != nil {
+		return err
+	}
+
+	existing, err := scopesWithChangeset(scopes, name)
+	if err != nil {
+		return err
+	}
+
+	if len(existing) == 0 {
+		return fmt.Errorf("%w: %s", file.ErrChangesetNotFound, name)
+	}
+
+	for _, scope := range scopes {
+		current, err := file.ActiveChangeset(scope)
+		if err != nil {
+			return err
+		}
+
+		if current == name {
+			return fmt.Errorf("cannot delete the active changeset %s; switch to another one first", name)
+		}
+	}
+
+	if !force {
+		for _, spec := range gcfg.Services {
+			staged, err := changesetHasStaged(ctx, spec, name)
+			if err != nil {
+				return err
+			}
+
+			if staged {
+				return fmt.Errorf("changeset %s has staged changes (use --force to discard them)", name)
+			}
+		}
+	}
+
+	for _, scope := range scopes {
+		if !slices.Contains(existing, scope.Key()) {
+			continue
+		}
+
+		if err := file.DeleteChangeset(scope, name); err != nil {
+			return err
+		}
+	}
+
+	output.Success(cmd.Root().Writer, "Deleted changeset %s", name)
+
+	return nil
+}
+
+// scopesWithChangeset returns the keys of the scopes that have the changeset.
+func scopesWithChangeset(scopes []provider.Scope, name string) ([]string, error) {
+	var keys []string
+
+	for _, scope := range scopes {
+		names, err := file.ListChangesets(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.Contains(names, name) {
+			keys = append(keys, scope.Key())
+		}
+	}
+
+	return keys, nil
+}
+
+// changesetHasStaged reports whether the service has anything staged in the
+// named changeset. A service that is unconfigured or lacks the changeset has
+// nothing staged there.
+func changesetHasStaged(ctx context.Context, spec GlobalServiceSpec, name string) (bool, error) {
+	resolved, err := resolveScope(ctx, spec.ScopeResolver)
+	if errors.Is(err, staging.ErrServiceNotConfigured) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	st, err := file.NewChangesetStore(resolved.Scope, name)
+	if errors.Is(err, file.ErrChangesetNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to create staging store: %w", err)
+	}
+
+	return hasStaged(ctx, st, spec.Service, "")
+}
+
+// NewGlobalMoveCommand creates the provider-wide move command, which carries a
+// staged change from the active changeset to another one.
+func NewGlobalMoveCommand(gcfg GlobalConfig) *cli.Command {
+	return &cli.Command{
+		Name:      "move",
+		Usage:     "Move a staged change to another changeset",
+		ArgsUsage: "<name>",
+		Description: `Move a staged entry, together with its staged tag changes, from the active
+changeset to another one. The move is refused if the entry is already staged
+in the target changeset.
+
+EXAMPLES:
+   suve stage move /app/db-password --to rotate-db   Move the staged change to rotate-db`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "to",
+				Usage:    "Changeset to move the staged change to",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("usage: suve stage move <name> --to <changeset>")
+			}
+
+			name, to := cmd.Args().First(), cmd.String("to")
+			found := false
+
+			for _, spec := range gcfg.Services {
+				source, resolved, err := workingStore(ctx, spec.ScopeResolver)
+				if errors.Is(err, staging.ErrServiceNotConfigured) {
+					continue
+				}
+
+				if err != nil {
+					return err
+				}
+
+				staged, err := hasStaged(ctx, source, spec.Service, name)
+				if err != nil {
+					return err
+				}
+
+				if !staged {
+					continue
+				}
+
+				if source.Changeset() == to {
+					return fmt.Errorf("%s is already staged in the active changeset %s", name, to)
+				}
+
+				target, err := file.NewChangesetStore(resolved.Scope, to)
+				if err != nil {
+					return err
+				}
+
+				result, err := (&stagingusecase.MoveUseCase{Service: spec.Service, Source: source, Target: target}).
+					Execute(ctx, stagingusecase.MoveInput{Name: name})
+				if err != nil {
+					return err
+				}
+
+				found = found || result.Moved()
+
+				for _, key := range result.Entries {
+					output.Success(cmd.Root().Writer, "Moved %s to changeset %s", key.Label(), to)
+				}
+
+				for _, key := range result.Tags {
+					if !slices.Contains(result.Entries, key) {
+						output.Success(cmd.Root().Writer, "Moved tag changes of %s to changeset %s", key.Label(), to)
+					}
+				}
+			}
+
+			if !found {
+				return fmt.Errorf("%s is not staged", name)
+			}
+
+			return nil
+		},
+	}
+}
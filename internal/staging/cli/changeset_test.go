@@ -0,0 +1,75 @@
+package cli_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+	stgcli "github.com/mpyw/suve/internal/staging/cli"
+	"github.com/mpyw/suve/internal/staging/store/file"
+)
+
+// changesetGlobalConfig builds a param + secret GlobalConfig sharing scope.
+func changesetGlobalConfig(scope provider.Scope) stgcli.GlobalConfig {
+	resolver := fixedResolver(scope)
+
+	return stgcli.GlobalConfig{
+		ProviderLabel: "AWS",
+		ScopeResolver: resolver,
+		Services: []stgcli.GlobalServiceSpec{
+			{Service: staging.ServiceParam, ParserFactory: staging.AWSParamParserFactory, ScopeResolver: resolver},
+			{Service: staging.ServiceSecret, ParserFactory: staging.AWSSecretParserFactory, ScopeResolver: resolver},
+		},
+	}
+}
+
+//nolint:paralleltest // uses t.Setenv (HOME/SUVE_STAGING_KEY); cannot run in parallel
+func TestGlobalChangesetCommands(t *testing.T) {
+	t.Run("switch, list, move and delete", func(t *testing.T) {
+		scope := setupExportImportEnv(t)
+		gcfg := changesetGlobalConfig(scope)
+
+		stageEntry(t, scope, staging.ServiceParam, "/app/config", "pval")
+
+		_, _, err := runLeafCmd(t, stgcli.NewGlobalSwitchCommand(gcfg), nil, "rotate-db")
+		require.ErrorIs(t, err, file.ErrChangesetNotFound)
+
+		stdout, _, err := runLeafCmd(t, stgcli.NewGlobalSwitchCommand(gcfg), nil, "-c", "rotate-db")
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "Switched to a new changeset rotate-db")
+		assert.True(t, workingState(t, scope).IsEmpty())
+
+		stdout, _, err = runLeafCmd(t, stgcli.NewGlobalBranchCommand(gcfg), nil, "list")
+		require.NoError(t, err)
+		assert.Equal(t, "  default\n* rotate-db\n", stdout)
+
+		_, _, err = runLeafCmd(t, stgcli.NewGlobalSwitchCommand(gcfg), nil, "default")
+		require.NoError(t, err)
+
+		stdout, _, err = runLeafCmd(t, stgcli.NewGlobalMoveCommand(gcfg), nil, "/app/config", "--to", "rotate-db")
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "Moved /app/config to changeset rotate-db")
+		assert.True(t, workingState(t, scope).IsEmpty())
+
+		_, _, err = runLeafCmd(t, stgcli.NewGlobalBranchCommand(gcfg), nil, "delete", "rotate-db")
+		require.ErrorContains(t, err, "has staged changes")
+
+		_, _, err = runLeafCmd(t, stgcli.NewGlobalSwitchCommand(gcfg), nil, "rotate-db")
+		require.NoError(t, err)
+		assert.False(t, workingState(t, scope).IsEmpty())
+
+		_, _, err = runLeafCmd(t, stgcli.NewGlobalBranchCommand(gcfg), nil, "delete", "--force", "rotate-db")
+		require.ErrorContains(t, err, "active changeset")
+	})
+
+	t.Run("move unstaged name", func(t *testing.T) {
+		scope := setupExportImportEnv(t)
+		gcfg := changesetGlobalConfig(scope)
+
+		_, _, err := runLeafCmd(t, stgcli.NewGlobalMoveCommand(gcfg), nil, "/app/config", "--to", "rotate-db")
+		require.ErrorContains(t, err, "is not staged")
+	})
+}
@@ -106,6 +106,28 @@ func (m *fullMockStrategy) FetchCurrentTags(_ context.Context, _ string) (map[st
 	return nil, nil //nolint:nilnil // mock implementation
 }
 
+// rollbackMockStrategy is a fullMockStrategy that supports atomic apply and
+// fails to apply failName.
+type rollbackMockStrategy struct {
+	*fullMockStrategy
+
+	failName string
+}
+
+func (m *rollbackMockStrategy) Apply(_ context.Context, name string, _ staging.Entry) error {
+	if name == m.failName {
+		return errors.New("apply failed")
+	}
+
+	return nil
+}
+func (m *rollbackMockStrategy) Snapshot(_ context.Context, _ string) (staging.Snapshot, error) {
+	return staging.Snapshot{Exists: true}, nil
+}
+func (m *rollbackMockStrategy) Rollback(_ context.Context, _ string, _ staging.Snapshot) error {
+	return nil
+}
+
 // =============================================================================
 // StatusRunner Tests
 // =============================================================================
@@ -1085,6 +1107,41 @@ func TestApplyRunner_Run(t *testing.T) {
 		assert.Contains(t, stderr.String(), "apply failed")
 	})
 
+	t.Run("apply - atomic rolls back on failure", func(t *testing.T) {
+		t.Parallel()
+
+		store := testutil.NewMockStore()
+		_ = store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/ok"}, staging.Entry{
+			Operation: staging.OperationUpdate,
+			Value:     lo.ToPtr("value"),
+			StagedAt:  time.Now(),
+		})
+		_ = store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/fail"}, staging.Entry{
+			Operation: staging.OperationUpdate,
+			Value:     lo.ToPtr("value"),
+			StagedAt:  time.Now(),
+		})
+
+		var stdout, stderr bytes.Buffer
+
+		r := &cli.ApplyRunner{
+			UseCase: &stagingusecase.ApplyUseCase{
+				Strategy: &rollbackMockStrategy{fullMockStrategy: &fullMockStrategy{service: staging.ServiceParam}, failName: "/app/fail"},
+				Store:    store,
+			},
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		err := r.Run(t.Context(), cli.ApplyOptions{Atomic: true})
+		require.ErrorContains(t, err, "atomic apply failed")
+		assert.Contains(t, stdout.String(), "Rolled back /app/ok")
+		assert.Contains(t, stderr.String(), "all changes remain staged")
+
+		_, err = store.GetEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/ok"})
+		require.NoError(t, err)
+	})
+
 	t.Run("conflict - create operation (resource already exists)", func(t *testing.T) {
 		t.Parallel()
 
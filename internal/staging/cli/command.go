@@ -26,6 +26,8 @@ const (
 	flagOverwrite          = "overwrite"
 	flagForce              = "force"
 	flagAllowScopeMismatch = "allow-scope-mismatch"
+	flagUnlockLocked       = "unlock-locked"
+	flagAtomic             = "atomic"
 	cmdNamePush            = "push"
 	argsUsageName          = "[name]"
 )
@@ -79,6 +81,54 @@ type CommandConfig struct {
 	// empty return means "not specified": create applies plaintext and update
 	// preserves the existing type.
 	ValueTypeFromCmd func(cmd *cli.Command) (domain.ValueType, error)
+
+	// HasLocks reports whether this service's entries can be locked read-only
+	// (Azure App Configuration). When true, `stage apply` registers
+	// --unlock-locked to unlock, write and relock locked entries.
+	HasLocks bool
+
+	// AttributeFlags are provider-specific flags appended to the add and edit
+	// commands so the staged entry can carry secret attributes (the Azure Key
+	// Vault content type / activation window / enabled axis). Nil for providers
+	// without one.
+	AttributeFlags []cli.Flag
+
+	// AttributesFromCmd validates and resolves the staged attributes from the
+	// add/edit command flags (AttributeFlags). A nil return means "not
+	// specified": a previously staged set is kept.
+	AttributesFromCmd func(cmd *cli.Command) (*staging.SecretAttributes, error)
+}
+
+// applyFlags returns the apply command flags, adding --unlock-locked for a
+// service with read-only locks.
+func (c CommandConfig) applyFlags() []cli.Flag {
+	flags := []cli.Flag{
+		&cli.BoolFlag{
+			Name:  flagYes,
+			Usage: usageSkipConfirm,
+		},
+		&cli.BoolFlag{
+			Name:  "ignore-conflicts",
+			Usage: "Apply even if AWS was modified after staging",
+		},
+		&cli.BoolFlag{
+			Name:  flagAtomic,
+			Usage: "Apply all or nothing: roll back applied changes if any change fails",
+		},
+	}
+
+	flags = append(flags, ChangeMessageFlags()...)
+	flags = append(flags, ScheduleFlags()...)
+	flags = append(flags, SelectorFlags(false)...)
+
+	if c.HasLocks {
+		flags = append(flags, &cli.BoolFlag{
+			Name:  flagUnlockLocked,
+			Usage: "Unlock read-only " + c.ItemName + "s, apply, and lock them again",
+		})
+	}
+
+	return flags
 }
 
 // valueTypeFor resolves the staged value type from the command flags, or ""
@@ -91,6 +141,24 @@ func (c CommandConfig) valueTypeFor(cmd *cli.Command) (domain.ValueType, error)
 	return c.ValueTypeFromCmd(cmd)
 }
 
+// attributesFor resolves the staged attributes from the command flags, or nil
+// when the provider has no attribute axis.
+func (c CommandConfig) attributesFor(cmd *cli.Command) (*staging.SecretAttributes, error) {
+	if c.AttributesFromCmd == nil {
+		return nil, nil //nolint:nilnil // intentional: no attribute axis is no attributes and no error
+	}
+
+	return c.AttributesFromCmd(cmd)
+}
+
+// entryFlags returns the provider-specific add/edit flags: --description when
+// honored, then the value-type and attribute flags.
+func (c CommandConfig) entryFlags() []cli.Flag {
+	flags := append(c.descriptionFlags(), c.ValueTypeFlags...)
+
+	return append(flags, c.AttributeFlags...)
+}
+
 // descriptionFlags returns the --description flag for add/edit when the service
 // honors a description, or an empty slice otherwise (so an unsupported provider
 // rejects --description as an unknown flag rather than silently dropping it).
@@ -158,14 +226,19 @@ func NewStatusCommand(cfg CommandConfig) *cli.Command {
 		Usage:       fmt.Sprintf("Show staged %s changes", cfg.ItemName),
 		ArgsUsage:   argsUsageName,
 		Description: statusDescription(cfg),
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:    "verbose",
 				Aliases: []string{"v"},
 				Usage:   "Show detailed information including values",
 			},
-		},
+		}, SelectorFlags(false)...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			sel, err := SelectorFromCmd(cmd, false)
+			if err != nil {
+				return err
+			}
+
 			store, _, err := workingStore(ctx, cfg.ScopeResolver)
 			if err != nil {
 				return err
@@ -173,6 +246,7 @@ func NewStatusCommand(cfg CommandConfig) *cli.Command {
 
 			opts := StatusOptions{
 				Verbose: cmd.Bool("verbose"),
+				Select:  sel,
 			}
 			if cmd.Args().Len() > 0 {
 				opts.Name = cmd.Args().First()
@@ -199,7 +273,7 @@ func NewDiffCommand(cfg CommandConfig) *cli.Command {
 		Usage:       "Show diff between staged and AWS values",
 		ArgsUsage:   argsUsageName,
 		Description: diffDescription(cfg),
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:    "parse-json",
 				Aliases: []string{"j"},
@@ -209,7 +283,7 @@ func NewDiffCommand(cfg CommandConfig) *cli.Command {
 				Name:  "no-pager",
 				Usage: "Disable pager output",
 			},
-		},
+		}, SelectorFlags(false)...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			var name string
 
@@ -217,6 +291,11 @@ func NewDiffCommand(cfg CommandConfig) *cli.Command {
 				return fmt.Errorf("usage: suve stage %s diff [name]", cfg.CommandName)
 			}
 
+			sel, err := SelectorFromCmd(cmd, false)
+			if err != nil {
+				return err
+			}
+
 			if cmd.Args().Len() == 1 {
 				parser := cfg.ParserFactory()
 
@@ -235,6 +314,7 @@ func NewDiffCommand(cfg CommandConfig) *cli.Command {
 
 			opts := DiffOptions{
 				Name:      name,
+				Select:    sel,
 				ParseJSON: cmd.Bool("parse-json"),
 				NoPager:   cmd.Bool("no-pager"),
 			}
@@ -269,9 +349,10 @@ func NewAddCommand(cfg CommandConfig) *cli.Command {
 		ArgsUsage:   "<name> [value]",
 		Description: addDescription(cfg),
 		// The --description flag is gated on HasDescription (#666: unsupported
-		// providers reject it rather than silently drop it); value-type flags are
-		// appended for providers with a value-type axis (AWS SSM param, #664).
-		Flags: append(cfg.descriptionFlags(), cfg.ValueTypeFlags...),
+		// providers reject it rather than silently drop it); value-type and
+		// attribute flags are appended for providers with those axes (AWS SSM
+		// param #664, Azure Key Vault).
+		Flags: cfg.entryFlags(),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			if cmd.Args().Len() < 1 {
 				return fmt.Errorf("usage: suve stage %s add <name> [value]", cfg.CommandName)
@@ -289,6 +370,11 @@ func NewAddCommand(cfg CommandConfig) *cli.Command {
 				return err
 			}
 
+			attributes, err := cfg.attributesFor(cmd)
+			if err != nil {
+				return err
+			}
+
 			store, _, err := workingStore(ctx, cfg.ScopeResolver)
 			if err != nil {
 				return err
@@ -314,6 +400,7 @@ func NewAddCommand(cfg CommandConfig) *cli.Command {
 				Description: cfg.description(cmd),
 				Namespace:   cfg.namespaceFor(ctx),
 				ValueType:   valueType,
+				Attributes:  attributes,
 			})
 		},
 	}
@@ -327,9 +414,10 @@ func NewEditCommand(cfg CommandConfig) *cli.Command {
 		ArgsUsage:   "<name> [value]",
 		Description: editDescription(cfg),
 		// The --description flag is gated on HasDescription (#666: unsupported
-		// providers reject it rather than silently drop it); value-type flags are
-		// appended for providers with a value-type axis (AWS SSM param, #664).
-		Flags: append(cfg.descriptionFlags(), cfg.ValueTypeFlags...),
+		// providers reject it rather than silently drop it); value-type and
+		// attribute flags are appended for providers with those axes (AWS SSM
+		// param #664, Azure Key Vault).
+		Flags: cfg.entryFlags(),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			if cmd.Args().Len() < 1 {
 				return fmt.Errorf("usage: suve stage %s edit <name> [value]", cfg.CommandName)
@@ -347,6 +435,11 @@ func NewEditCommand(cfg CommandConfig) *cli.Command {
 				return err
 			}
 
+			attributes, err := cfg.attributesFor(cmd)
+			if err != nil {
+				return err
+			}
+
 			store, _, err := workingStore(ctx, cfg.ScopeResolver)
 			if err != nil {
 				return err
@@ -372,6 +465,7 @@ func NewEditCommand(cfg CommandConfig) *cli.Command {
 				Description: cfg.description(cmd),
 				Namespace:   cfg.namespaceFor(ctx),
 				ValueType:   valueType,
+				Attributes:  attributes,
 			})
 		},
 	}
@@ -385,24 +479,34 @@ func NewApplyCommand(cfg CommandConfig) *cli.Command {
 		Usage:       fmt.Sprintf("Apply staged %s changes to AWS", cfg.ItemName),
 		ArgsUsage:   argsUsageName,
 		Description: applyDescription(cfg),
-		Flags: []cli.Flag{
-			&cli.BoolFlag{
-				Name:  flagYes,
-				Usage: usageSkipConfirm,
-			},
-			&cli.BoolFlag{
-				Name:  "ignore-conflicts",
-				Usage: "Apply even if AWS was modified after staging",
-			},
-		},
+		Flags:       cfg.applyFlags(),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			store, resolved, err := workingStore(ctx, cfg.ScopeResolver)
 			if err != nil {
 				return err
 			}
 
+			sel, err := SelectorFromCmd(cmd, false)
+			if err != nil {
+				return err
+			}
+
+			order, concurrency, err := ScheduleFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
+			message, messageTag := ChangeMessageFromCmd(cmd)
+
 			opts := ApplyOptions{
+				Select:          sel,
 				IgnoreConflicts: cmd.Bool("ignore-conflicts"),
+				UnlockLocked:    cfg.HasLocks && cmd.Bool(flagUnlockLocked),
+				Atomic:          cmd.Bool(flagAtomic),
+				Message:         message,
+				MessageTag:      messageTag,
+				Order:           order,
+				Concurrency:     concurrency,
 			}
 			if cmd.Args().Len() > 0 {
 				opts.Name = cmd.Args().First()
@@ -425,6 +529,7 @@ func NewApplyCommand(cfg CommandConfig) *cli.Command {
 					Strategy:    strategy,
 					Store:       store,
 					StrategyFor: cfg.applyStrategyFor(ctx),
+					Journal:     store,
 				},
 				Store:       store,
 				Parser:      cfg.ParserFactory(),
@@ -446,25 +551,36 @@ func NewResetCommand(cfg CommandConfig) *cli.Command {
 		Usage:       fmt.Sprintf("Unstage %s or restore to specific version", cfg.ItemName),
 		ArgsUsage:   "[spec]",
 		Description: resetDescription(cfg),
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:  "all",
 				Usage: fmt.Sprintf("Unstage all %ss", cfg.ItemName),
 			},
-		},
+		}, SelectorFlags(false)...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			resetAll := cmd.Bool("all")
 
-			if !resetAll && cmd.Args().Len() < 1 {
+			sel, err := SelectorFromCmd(cmd, false)
+			if err != nil {
+				return err
+			}
+
+			selected := !sel.IsZero()
+
+			switch {
+			case selected && (resetAll || cmd.Args().Len() > 0):
+				return fmt.Errorf("usage: suve stage %s reset takes a <spec>, --all, or selector flags, not several", cfg.CommandName)
+			case !selected && !resetAll && cmd.Args().Len() < 1:
 				return fmt.Errorf("usage: suve stage %s reset <spec> or suve stage %s reset --all", cfg.CommandName, cfg.CommandName)
 			}
 
 			opts := ResetOptions{
 				All:       resetAll,
 				Namespace: cfg.namespaceFor(ctx),
+				Select:    sel,
 			}
 
-			if !resetAll {
+			if !resetAll && !selected {
 				opts.Spec = cmd.Args().First()
 			}
 
@@ -474,9 +590,7 @@ func NewResetCommand(cfg CommandConfig) *cli.Command {
 			// to restore the value from AWS.
 			var hasVersion bool
 
-			if !resetAll && opts.Spec != "" {
-				var err error
-
+			if opts.Spec != "" {
 				_, hasVersion, err = parser.ParseSpec(opts.Spec)
 				if err != nil {
 					return err
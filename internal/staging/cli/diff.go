@@ -40,6 +40,7 @@ func (r *DiffRunner) remoteLabel() string {
 // DiffOptions holds options for the diff command.
 type DiffOptions struct {
 	Name      string // Optional: diff only this item, otherwise diff all
+	Select    staging.Selector
 	ParseJSON bool
 	NoPager   bool
 }
@@ -47,13 +48,20 @@ type DiffOptions struct {
 // Run executes the diff command.
 func (r *DiffRunner) Run(ctx context.Context, opts DiffOptions) error {
 	result, err := r.UseCase.Execute(ctx, stagingusecase.DiffInput{
-		Name: opts.Name,
+		Name:   opts.Name,
+		Select: opts.Select,
 	})
 	if err != nil {
 		return err
 	}
 
 	if len(result.Entries) == 0 && len(result.TagEntries) == 0 {
+		if !opts.Select.IsZero() {
+			output.Warning(r.Stderr, "no staged %ss match the selection", result.ItemName)
+
+			return nil
+		}
+
 		output.Warning(r.Stderr, "no %ss staged", result.ItemName)
 
 		return nil
@@ -31,6 +31,9 @@ type EditOptions struct {
 	// ValueType is the provider-neutral value type to record on the staged entry
 	// (AWS SSM Parameter Store axis). Empty preserves the existing type.
 	ValueType domain.ValueType
+	// Attributes are the Azure Key Vault secret attributes to record on the
+	// staged entry; nil for every other provider (or when none was given).
+	Attributes *staging.SecretAttributes
 }
 
 // Run executes the edit command.
@@ -71,6 +74,7 @@ func (r *EditRunner) Run(ctx context.Context, opts EditOptions) error {
 		Value:       newValue,
 		Description: opts.Description,
 		ValueType:   opts.ValueType,
+		Attributes:  opts.Attributes,
 	})
 	if err != nil {
 		return err
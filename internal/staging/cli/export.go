@@ -43,7 +43,7 @@ func (t *envelopeWriteTarget) WriteEnvelope(_ context.Context, svc staging.Servi
 // commands. Export always writes the working area out wholesale, so there is no
 // --merge / --overwrite here.
 func exportFlags() []cli.Flag {
-	return []cli.Flag{
+	return append([]cli.Flag{
 		&cli.BoolFlag{
 			Name:  "keep",
 			Usage: "Keep staged changes in the working staging area after exporting",
@@ -57,7 +57,7 @@ func exportFlags() []cli.Flag {
 			Name:  flagPassphraseStdin,
 			Usage: usagePassphraseStdin,
 		},
-	}
+	}, SelectorFlags(false)...)
 }
 
 // exportPassphrase prompts for the encryption passphrase once per command.
@@ -183,6 +183,11 @@ func exportAction(service staging.Service, resolver staging.ScopeResolver) func(
 
 		dest := cmd.Args().First()
 
+		sel, err := SelectorFromCmd(cmd, false)
+		if err != nil {
+			return err
+		}
+
 		resolved, err := resolveScope(ctx, resolver)
 		if err != nil {
 			return err
@@ -203,7 +208,7 @@ func exportAction(service staging.Service, resolver staging.ScopeResolver) func(
 			return fmt.Errorf("failed to read the working staging area: %w", err)
 		}
 
-		services := exportServices(service, peek)
+		services := exportServices(service, sel.SelectState(peek))
 		if len(services) == 0 {
 			output.Info(cmd.Root().Writer, "No staged changes to export.")
 
@@ -256,7 +261,7 @@ func exportAction(service staging.Service, resolver staging.ScopeResolver) func(
 			},
 		}
 
-		_, err = uc.Execute(ctx, usestaging.ExportInput{Service: service, Keep: cmd.Bool("keep")})
+		_, err = uc.Execute(ctx, usestaging.ExportInput{Service: service, Keep: cmd.Bool("keep"), Select: sel})
 		if err != nil {
 			if errors.Is(err, usestaging.ErrNothingToExport) {
 				output.Info(cmd.Root().Writer, "No staged changes to export.")
@@ -316,10 +321,13 @@ needed. Each file is a plaintext JSON envelope whose payload is encrypted when
 a passphrase is supplied (empty passphrase = plaintext).
 
 By default the working staging area is cleared after exporting; use --keep to
-retain it.
+retain it. Selector flags export (and clear) just the selected changes.
+
+` + SelectorDescription(false) + `
 
 EXAMPLES:
    suve stage export ./backup                       Export all staged changes to ./backup
+   suve stage export ./backup --match '/app/db/*'   Export only the selected changes
    suve stage export ./backup --keep                Export but keep the working staging area
    echo "secret" | suve stage export ./backup --passphrase-stdin   Encrypt with passphrase from stdin`,
 		Flags:  exportFlags(),
@@ -344,7 +352,10 @@ passphrase is supplied (empty passphrase = plaintext). The parent directory is
 created if needed.
 
 By default the %s entries are cleared from the working staging area after
-exporting; use --keep to retain them.
+exporting; use --keep to retain them. Selector flags export (and clear) just
+the selected changes.
+
+`+SelectorDescription(false)+`
 
 EXAMPLES:
    suve stage %s export ./%s.json                     Export staged %s changes
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"slices"
 
 	"github.com/mpyw/suve/internal/staging"
 )
@@ -28,6 +29,9 @@ type GlobalServiceSpec struct {
 	// (Azure App Configuration keeps all namespaces in one staging store). Nil
 	// for services without a namespace axis — the single Factory strategy is used.
 	StrategyForNamespace func(ctx context.Context, namespace string) (staging.FullStrategy, error)
+	// HasLocks reports whether this service's entries can be locked read-only
+	// (Azure App Configuration), so the global apply registers --unlock-locked.
+	HasLocks bool
 }
 
 // GlobalConfig configures the provider-wide stage commands so a single set of
@@ -44,6 +48,22 @@ type GlobalConfig struct {
 	Services []GlobalServiceSpec
 }
 
+// HasNamespaces reports whether any of the services has a namespace axis (Azure
+// App Configuration), so the global commands can select by --namespace.
+func (c GlobalConfig) HasNamespaces() bool {
+	return slices.ContainsFunc(c.Services, func(spec GlobalServiceSpec) bool {
+		return spec.StrategyForNamespace != nil
+	})
+}
+
+// HasLocks reports whether any of the services can lock entries read-only
+// (Azure App Configuration), so the global apply can unlock them.
+func (c GlobalConfig) HasLocks() bool {
+	return slices.ContainsFunc(c.Services, func(spec GlobalServiceSpec) bool {
+		return spec.HasLocks
+	})
+}
+
 // AWSGlobalConfig builds the GlobalConfig for AWS (param + secret) from the
 // given service factories. It preserves the historical AWS behavior and wording.
 func AWSGlobalConfig(paramCfg, secretCfg CommandConfig) GlobalConfig {
@@ -73,6 +93,7 @@ func AzureGlobalConfig(paramCfg, secretCfg CommandConfig) GlobalConfig {
 				Factory:              paramCfg.Factory,
 				ScopeResolver:        paramCfg.ScopeResolver,
 				StrategyForNamespace: paramCfg.StrategyForNamespace,
+				HasLocks:             paramCfg.HasLocks,
 			},
 			{
 				Service:              staging.ServiceSecret,
@@ -84,3 +105,96 @@ func AzureGlobalConfig(paramCfg, secretCfg CommandConfig) GlobalConfig {
 		},
 	}
 }
+
+// KubernetesGlobalConfig builds the GlobalConfig for Kubernetes. Like AWS, the
+// ConfigMap (param) and Secret (secret) services share one scope — the
+// kubeconfig context and namespace — so both use the param config's
+// ScopeResolver and share one staging bucket.
+func KubernetesGlobalConfig(paramCfg, secretCfg CommandConfig) GlobalConfig {
+	return GlobalConfig{
+		ProviderLabel: "Kubernetes",
+		ScopeResolver: paramCfg.ScopeResolver,
+		Services: []GlobalServiceSpec{
+			{
+				Service:       staging.ServiceParam,
+				ParserFactory: paramCfg.ParserFactory,
+				Factory:       paramCfg.Factory,
+				ScopeResolver: paramCfg.ScopeResolver,
+			},
+			{
+				Service:       staging.ServiceSecret,
+				ParserFactory: secretCfg.ParserFactory,
+				Factory:       secretCfg.Factory,
+				ScopeResolver: paramCfg.ScopeResolver,
+			},
+		},
+	}
+}
+
+// PluginGlobalConfig builds the GlobalConfig for an external plugin labelled
+// label. Its services share one staging bucket (the plugin's scope), but each
+// keeps its own ScopeResolver so a service the plugin does not offer is
+// skipped; scope resolves the bucket for the global export/import.
+func PluginGlobalConfig(label string, scope staging.ScopeResolver, paramCfg, secretCfg CommandConfig) GlobalConfig {
+	return GlobalConfig{
+		ProviderLabel: label,
+		ScopeResolver: scope,
+		Services: []GlobalServiceSpec{
+			{
+				Service:       staging.ServiceParam,
+				ParserFactory: paramCfg.ParserFactory,
+				Factory:       paramCfg.Factory,
+				ScopeResolver: paramCfg.ScopeResolver,
+			},
+			{
+				Service:       staging.ServiceSecret,
+				ParserFactory: secretCfg.ParserFactory,
+				Factory:       secretCfg.Factory,
+				ScopeResolver: secretCfg.ScopeResolver,
+			},
+		},
+	}
+}
+
+// LocalGlobalConfig builds the GlobalConfig for the offline local provider.
+// Its param and secret stores sit side by side in one directory, so like AWS
+// both services share the param config's ScopeResolver and one staging bucket.
+func LocalGlobalConfig(paramCfg, secretCfg CommandConfig) GlobalConfig {
+	return GlobalConfig{
+		ProviderLabel: "Local",
+		ScopeResolver: paramCfg.ScopeResolver,
+		Services: []GlobalServiceSpec{
+			{
+				Service:       staging.ServiceParam,
+				ParserFactory: paramCfg.ParserFactory,
+				Factory:       paramCfg.Factory,
+				ScopeResolver: paramCfg.ScopeResolver,
+			},
+			{
+				Service:       staging.ServiceSecret,
+				ParserFactory: secretCfg.ParserFactory,
+				Factory:       secretCfg.Factory,
+				ScopeResolver: paramCfg.ScopeResolver,
+			},
+		},
+	}
+}
+
+// SecretOnlyGlobalConfig builds the GlobalConfig for a secret-only provider
+// (Google Cloud, SOPS, Vault) labelled label. Those providers drive their stage
+// group from the single CommandConfig; the GlobalConfig only backs the
+// provider-wide changeset commands (switch / branch / move).
+func SecretOnlyGlobalConfig(label string, cfg CommandConfig) GlobalConfig {
+	return GlobalConfig{
+		ProviderLabel: label,
+		ScopeResolver: cfg.ScopeResolver,
+		Services: []GlobalServiceSpec{
+			{
+				Service:       staging.ServiceSecret,
+				ParserFactory: cfg.ParserFactory,
+				Factory:       cfg.Factory,
+				ScopeResolver: cfg.ScopeResolver,
+			},
+		},
+	}
+}
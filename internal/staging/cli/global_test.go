@@ -27,6 +27,7 @@ func TestAWSGlobalConfig(t *testing.T) {
 	// Parser factories are carried through and are network-free.
 	assert.Equal(t, "SSM Parameter Store", cfg.Services[0].ParserFactory().ServiceName())
 	assert.Equal(t, "Secrets Manager", cfg.Services[1].ParserFactory().ServiceName())
+	assert.False(t, cfg.HasLocks())
 }
 
 func TestAzureGlobalConfig(t *testing.T) {
@@ -49,6 +50,7 @@ func TestAzureGlobalConfig(t *testing.T) {
 		ParserFactory:        staging.AzureAppConfigParamParserFactory,
 		ScopeResolver:        paramResolver,
 		StrategyForNamespace: strategyForNamespace,
+		HasLocks:             true,
 	}
 	secret := stgcli.CommandConfig{
 		ParserFactory: staging.AzureKeyVaultSecretParserFactory,
@@ -75,4 +77,97 @@ func TestAzureGlobalConfig(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "vault acme", got.Target)
 	assert.Nil(t, cfg.Services[1].StrategyForNamespace, "Key Vault has no namespace axis")
+
+	// Only App Configuration settings can be locked read-only.
+	assert.True(t, cfg.Services[0].HasLocks)
+	assert.False(t, cfg.Services[1].HasLocks)
+	assert.True(t, cfg.HasLocks())
+}
+
+func TestKubernetesGlobalConfig(t *testing.T) {
+	t.Parallel()
+
+	// ConfigMaps and Secrets live in the same context + namespace, so both
+	// services must resolve the one shared scope.
+	resolver := func(_ context.Context) (staging.ResolvedScope, error) {
+		return staging.ResolvedScope{Target: "namespace payments in context kind-dev"}, nil
+	}
+
+	param := stgcli.CommandConfig{ParserFactory: staging.KubernetesParamParserFactory, ScopeResolver: resolver}
+	secret := stgcli.CommandConfig{ParserFactory: staging.KubernetesSecretParserFactory}
+
+	cfg := stgcli.KubernetesGlobalConfig(param, secret)
+
+	assert.Equal(t, "Kubernetes", cfg.ProviderLabel)
+	require.NotNil(t, cfg.ScopeResolver)
+	require.Len(t, cfg.Services, 2)
+	assert.Equal(t, staging.ServiceParam, cfg.Services[0].Service)
+	assert.Equal(t, staging.ServiceSecret, cfg.Services[1].Service)
+	assert.Equal(t, "Kubernetes ConfigMap", cfg.Services[0].ParserFactory().ServiceName())
+	assert.Equal(t, "Kubernetes Secret", cfg.Services[1].ParserFactory().ServiceName())
+
+	for _, svc := range cfg.Services {
+		require.NotNil(t, svc.ScopeResolver)
+		got, err := svc.ScopeResolver(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "namespace payments in context kind-dev", got.Target)
+	}
+}
+
+func TestLocalGlobalConfig(t *testing.T) {
+	t.Parallel()
+
+	// Both local stores share one directory, so both services must resolve
+	// the one shared scope.
+	resolver := func(_ context.Context) (staging.ResolvedScope, error) {
+		return staging.ResolvedScope{Target: "local (/home/me/.suve/local)"}, nil
+	}
+
+	param := stgcli.CommandConfig{ParserFactory: staging.AWSParamParserFactory, ScopeResolver: resolver}
+	secret := stgcli.CommandConfig{ParserFactory: staging.AWSSecretParserFactory}
+
+	cfg := stgcli.LocalGlobalConfig(param, secret)
+
+	assert.Equal(t, "Local", cfg.ProviderLabel)
+	require.NotNil(t, cfg.ScopeResolver)
+	require.Len(t, cfg.Services, 2)
+	assert.Equal(t, staging.ServiceParam, cfg.Services[0].Service)
+	assert.Equal(t, staging.ServiceSecret, cfg.Services[1].Service)
+
+	for _, svc := range cfg.Services {
+		require.NotNil(t, svc.ScopeResolver)
+		got, err := svc.ScopeResolver(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "local (/home/me/.suve/local)", got.Target)
+	}
+}
+
+func TestPluginGlobalConfig(t *testing.T) {
+	t.Parallel()
+
+	resolver := func(target string) staging.ScopeResolver {
+		return func(_ context.Context) (staging.ResolvedScope, error) {
+			return staging.ResolvedScope{Target: target}, nil
+		}
+	}
+
+	param := stgcli.CommandConfig{ParserFactory: staging.AWSParamParserFactory, ScopeResolver: resolver("param")}
+	secret := stgcli.CommandConfig{ParserFactory: staging.AWSSecretParserFactory, ScopeResolver: resolver("secret")}
+
+	cfg := stgcli.PluginGlobalConfig("acme", resolver("plugin"), param, secret)
+
+	assert.Equal(t, "acme", cfg.ProviderLabel)
+
+	got, err := cfg.ScopeResolver(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "plugin", got.Target)
+
+	// Each service keeps its own resolver, so an unoffered one can be skipped.
+	require.Len(t, cfg.Services, 2)
+
+	for i, want := range []string{"param", "secret"} {
+		got, err := cfg.Services[i].ScopeResolver(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, want, got.Target)
+	}
 }
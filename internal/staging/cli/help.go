@@ -15,13 +15,17 @@ With a %s name, shows the staged change for that specific %s.
 
 Use --verbose to show detailed information including the staged value.
 
+`+SelectorDescription(false)+`
+
 EXAMPLES:
    suve stage %s status              Show all staged %s changes
    suve stage %s status <name>       Show staged change for specific %s
-   suve stage %s status --verbose    Show detailed information`,
+   suve stage %s status --verbose    Show detailed information
+   suve stage %s status --op delete  Show only staged deletions`,
 		cfg.CommandName, cfg.ItemName, cfg.ItemName, cfg.ItemName,
 		cfg.CommandName, cfg.ItemName,
 		cfg.CommandName, cfg.ItemName,
+		cfg.CommandName,
 		cfg.CommandName)
 }
 
@@ -32,14 +36,18 @@ func diffDescription(cfg CommandConfig) string {
 If a %s name is specified, shows diff for that %s only.
 Otherwise, shows diff for all staged %ss.
 
+`+SelectorDescription(false)+`
+
 EXAMPLES:
    suve stage %s diff                   Show diff for all staged %ss
    suve stage %s diff <name>            Show diff for specific %s
-   suve stage %s diff --parse-json      Show diff with JSON formatting`,
+   suve stage %s diff --parse-json      Show diff with JSON formatting
+   suve stage %s diff --match '/app/*'  Show diff for the selected %ss`,
 		cfg.ItemName, cfg.ItemName, cfg.ItemName,
 		cfg.CommandName, cfg.ItemName,
 		cfg.CommandName, cfg.ItemName,
-		cfg.CommandName)
+		cfg.CommandName,
+		cfg.CommandName, cfg.ItemName)
 }
 
 // addDescription returns the Description text for the add command.
@@ -111,19 +119,57 @@ CONFLICT DETECTION:
    - For existing resources: checks if it was modified after staging
    Use --ignore-conflicts to force apply despite conflicts.
 
+ATOMIC APPLY:
+   With --atomic, every target's current state is recorded before the first
+   write. If any change fails, every entry already written is rolled back:
+   prior values are put back, deleted %ss are restored or re-created, and
+   created ones are deleted. Everything stays staged, and each rollback is
+   reported.
+
+`+ChangeMessageDescription+`
+
+`+ScheduleDescription+`
+
+`+SelectorDescription(false)+`
+
 EXAMPLES:
    suve stage %s apply                      Apply all staged %s changes (with confirmation)
    suve stage %s apply <name>               Apply only the specified %s
    suve stage %s apply --yes                Apply without confirmation
-   suve stage %s apply --ignore-conflicts   Apply even if AWS was modified after staging`,
+   suve stage %s apply --ignore-conflicts   Apply even if AWS was modified after staging
+   suve stage %s apply --atomic             Apply all or nothing
+   suve stage %s apply -m "rotate db creds" Record why the changes are made
+   suve stage %s apply --order order.txt    Apply in the order the file lists
+   suve stage %s apply --concurrency 3      Apply at most 3 changes at once
+   suve stage %s apply --match '/app/db/*' --op delete   Apply only the selected changes`,
 		cfg.ItemName,
 		cfg.ItemName, cfg.ItemName,
 		cfg.ItemName,
 		cfg.CommandName,
+		cfg.ItemName,
 		cfg.CommandName, cfg.ItemName,
 		cfg.CommandName, cfg.ItemName,
 		cfg.CommandName,
-		cfg.CommandName)
+		cfg.CommandName,
+		cfg.CommandName,
+		cfg.CommandName,
+		cfg.CommandName,
+		cfg.CommandName,
+		cfg.CommandName) + applyLocksDescription(cfg)
+}
+
+// applyLocksDescription documents read-only lock handling for a service with
+// locks (empty otherwise).
+func applyLocksDescription(cfg CommandConfig) string {
+	if !cfg.HasLocks {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+
+READ-ONLY LOCKS:
+   A locked %s is detected before writing and reported as locked rather than
+   attempted. Use --unlock-locked to unlock it, apply, and lock it again.`, cfg.ItemName)
 }
 
 // resetDescription returns the Description text for the reset command.
@@ -133,18 +179,22 @@ func resetDescription(cfg CommandConfig) string {
 Without a version specifier, the %s is simply removed from staging.
 With a version specifier, the value at that version is fetched and staged.
 
-Use 'suve stage %s reset --all' to unstage all %ss at once.
+Use 'suve stage %s reset --all' to unstage all %ss at once, or selector
+flags in place of a name to unstage just the selected changes.
 
 VERSION SPECIFIERS:
    <name>          Unstage %s (remove from staging)
    <name>#<ver>    Restore to specific version
    <name>~1        Restore to 1 version ago
 
+`+SelectorDescription(false)+`
+
 EXAMPLES:
    suve stage %s reset <name>              Unstage (remove from staging)
    suve stage %s reset <name>#<ver>        Stage value from specific version
    suve stage %s reset <name>~1            Stage value from previous version
-   suve stage %s reset --all               Unstage all %ss`,
+   suve stage %s reset --all               Unstage all %ss
+   suve stage %s reset --match '/app/*'    Unstage the selected changes`,
 		cfg.ItemName,
 		cfg.ItemName,
 		cfg.CommandName, cfg.ItemName,
@@ -152,7 +202,8 @@ EXAMPLES:
 		cfg.CommandName,
 		cfg.CommandName,
 		cfg.CommandName,
-		cfg.CommandName, cfg.ItemName)
+		cfg.CommandName, cfg.ItemName,
+		cfg.CommandName)
 }
 
 // deleteDescription returns the Description text for the delete command.
@@ -0,0 +1,306 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/cli/colors"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/file"
+	"github.com/mpyw/suve/internal/timeutil"
+	stagingusecase "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// listApplies returns the applies recorded across targets, newest first. The
+// records one apply wrote to several scopes are merged into one (see
+// staging.MergeApplyRecords).
+func listApplies(ctx context.Context, targets []scopeTarget) ([]*staging.ApplyRecord, error) {
+	listings := make([][]*staging.ApplyRecord, 0, len(targets))
+
+	for _, target := range targets {
+		found, err := target.store.Journal(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		listings = append(listings, found)
+	}
+
+	return staging.MergeApplyRecords(listings...), nil
+}
+
+// resolveApply returns the recorded apply with the given ID, or the latest one
+// when id is empty.
+func resolveApply(ctx context.Context, targets []scopeTarget, id string) (*staging.ApplyRecord, error) {
+	records, err := listApplies(ctx, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("no applies recorded")
+	}
+
+	if id == "" {
+		return records[0], nil
+	}
+
+	for _, rec := range records {
+		if rec.ID == id {
+			return rec, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", file.ErrJournalNotFound, id)
+}
+
+// NewGlobalHistoryCommand creates the provider-wide history command, which
+// lists past applies from the apply journal.
+func NewGlobalHistoryCommand(gcfg GlobalConfig) *cli.Command {
+	return &cli.Command{
+		Name:      "history",
+		Usage:     "List past applies",
+		ArgsUsage: "[apply-id]",
+		Description: `List the applies recorded in the apply journal, newest first. Every apply
+records what it changed, the previous value and version of each changed
+entry, the new version, tag changes, the time, and the user@host who applied.
+The journal is encrypted like the staging area and keeps the latest 100
+applies per scope.
+
+Given an apply ID, show that apply's changes. Use 'suve stage undo' to stage
+the changes that reverse an apply.
+
+EXAMPLES:
+   suve stage history                          List past applies
+   suve stage history 01760000000000000000     Show one apply
+   suve stage history -v 01760000000000000000  Show one apply with previous values`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "verbose",
+				Aliases: []string{"v"},
+				Usage:   "Show previous values when showing an apply",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() > 1 {
+				return errors.New("usage: suve stage history [-v] [apply-id]")
+			}
+
+			targets, err := scopeTargets(ctx, gcfg)
+			if err != nil {
+				return err
+			}
+
+			w := cmd.Root().Writer
+
+			if id := cmd.Args().First(); id != "" {
+				rec, err := resolveApply(ctx, targets, id)
+				if err != nil {
+					return err
+				}
+
+				printApply(w, gcfg, rec, cmd.Bool("verbose"))
+
+				return nil
+			}
+
+			records, err := listApplies(ctx, targets)
+			if err != nil {
+				return err
+			}
+
+			if len(records) == 0 {
+				output.Info(w, "No applies recorded.")
+
+				return nil
+			}
+
+			pal := colors.For(w)
+
+			for _, rec := range records {
+				line := fmt.Sprintf("%s %s %s %d change(s)", pal.Warning(rec.ID),
+					timeutil.FormatDateTime(rec.AppliedAt), rec.User, len(rec.Items))
+				if rec.Changeset != "" && rec.Changeset != file.DefaultChangeset {
+					line += " " + pal.FieldLabel("(changeset "+rec.Changeset+")")
+				}
+
+				if rec.Message != "" {
+					line += "  " + rec.Message
+				}
+
+				output.Printf(w, "%s\n", line)
+			}
+
+			return nil
+		},
+	}
+}
+
+// printApply prints the changes of one recorded apply, grouped by service.
+func printApply(w io.Writer, gcfg GlobalConfig, rec *staging.ApplyRecord, verbose bool) {
+	pal := colors.For(w)
+
+	output.Printf(w, "%s %s\n", pal.Warning("apply"), pal.Warning(rec.ID))
+	output.Printf(w, "%s %s\n", pal.FieldLabel("Applied:"), timeutil.FormatDateTime(rec.AppliedAt))
+	output.Printf(w, "%s %s\n", pal.FieldLabel("By:"), rec.User)
+
+	if rec.Changeset != "" {
+		output.Printf(w, "%s %s\n", pal.FieldLabel("Changeset:"), rec.Changeset)
+	}
+
+	if rec.Message != "" {
+		output.Printf(w, "%s %s\n", pal.FieldLabel("Message:"), rec.Message)
+	}
+
+	for _, spec := range gcfg.Services {
+		var items []staging.AppliedItem
+
+		for _, item := range rec.Items {
+			if item.Service == spec.Service {
+				items = append(items, item)
+			}
+		}
+
+		if len(items) == 0 {
+			continue
+		}
+
+		output.Printf(w, "\n%s (%d):\n", pal.Warning(spec.ParserFactory().ServiceName()), len(items))
+
+		for _, item := range items {
+			output.Printf(w, "  %s\n", describeAppliedItem(pal, item))
+
+			if verbose && item.Previous.Exists && item.Operation != "" && item.Operation != staging.OperationCreate {
+				output.Printf(w, "    %s %s\n", pal.FieldLabel("previous:"), item.Previous.Value)
+			}
+		}
+	}
+}
+
+// describeAppliedItem renders one applied change on a line: the operation,
+// the entry, the version change and the tag change.
+func describeAppliedItem(pal colors.Palette, item staging.AppliedItem) string {
+	var b strings.Builder
+
+	switch item.Operation {
+	case staging.OperationCreate:
+		b.WriteString(pal.OpAdd("created "))
+	case staging.OperationUpdate:
+		b.WriteString(pal.OpModify("updated "))
+	case staging.OperationDelete:
+		b.WriteString(pal.OpDelete("deleted "))
+	default:
+		b.WriteString(pal.OpModify("tagged  "))
+	}
+
+	b.WriteString(item.Key().Label())
+
+	switch {
+	case item.Operation == staging.OperationDelete && item.Previous.Version != "":
+		b.WriteString(pal.FieldLabel(" (was " + item.Previous.Version + ")"))
+	case item.Operation != "" && item.Previous.Version != "" && item.NewVersion != "":
+		b.WriteString(pal.FieldLabel(" (" + item.Previous.Version + " -> " + item.NewVersion + ")"))
+	case item.Operation != "" && item.NewVersion != "":
+		b.WriteString(pal.FieldLabel(" (" + item.NewVersion + ")"))
+	}
+
+	var tags []string
+	if len(item.TagsAdded) > 0 {
+		tags = append(tags, fmt.Sprintf("+%d", len(item.TagsAdded)))
+	}
+
+	if len(item.TagsRemoved) > 0 {
+		tags = append(tags, fmt.Sprintf("-%d", len(item.TagsRemoved)))
+	}
+
+	if len(tags) > 0 {
+		b.WriteString(" [" + strings.Join(tags, ", ") + "]")
+	}
+
+	return b.String()
+}
+
+// NewGlobalUndoCommand creates the provider-wide undo command, which stages the
+// inverse of a recorded apply.
+func NewGlobalUndoCommand(gcfg GlobalConfig) *cli.Command {
+	return &cli.Command{
+		Name:      "undo",
+		Usage:     "Stage the changes that reverse an apply",
+		ArgsUsage: "[apply-id]",
+		Description: `Stage the inverse of an apply recorded in the apply journal (default the
+latest apply; see 'suve stage history'). Nothing is written remotely: review
+the staged changes with 'suve stage diff' and apply them as usual.
+
+   - An updated entry is staged back to its previous value.
+   - A created entry is staged for deletion.
+   - A deleted entry is staged for re-creation with its previous value and
+     tags. Where the provider keeps deleted entries recoverable (Secrets
+     Manager, Key Vault), apply restores it instead of creating a new one.
+   - Tag changes are staged back to the previous tags.
+
+The staged changes are based on the state the apply left behind, so an entry
+changed remotely since then is reported as a conflict on apply. They are
+merged into what is staged now; an item staged in both takes the undo change
+(staged tag changes are combined per tag key), and each such item is reported.
+
+EXAMPLES:
+   suve stage undo                          Stage the reverse of the latest apply
+   suve stage undo 01760000000000000000     Stage the reverse of one apply`,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() > 1 {
+				return errors.New("usage: suve stage undo [apply-id]")
+			}
+
+			targets, err := scopeTargets(ctx, gcfg)
+			if err != nil {
+				return err
+			}
+
+			rec, err := resolveApply(ctx, targets, cmd.Args().First())
+			if err != nil {
+				return err
+			}
+
+			var staged int
+
+			for _, target := range targets {
+				result, err := (&stagingusecase.UndoUseCase{Working: target.store, Journal: target.store}).
+					Execute(ctx, stagingusecase.UndoInput{ID: rec.ID})
+				if errors.Is(err, file.ErrJournalNotFound) || errors.Is(err, stagingusecase.ErrNothingToUndo) {
+					continue
+				}
+
+				if err != nil {
+					return err
+				}
+
+				staged += result.EntryCount + result.TagCount
+
+				for _, conflict := range result.Conflicts {
+					if conflict.Tag {
+						output.Warning(cmd.Root().ErrWriter, "tag changes of %s were also staged; combined with the undo",
+							conflict.Key.Label())
+					} else {
+						output.Warning(cmd.Root().ErrWriter, "%s was also staged; replaced with the undo",
+							conflict.Key.Label())
+					}
+				}
+			}
+
+			if staged == 0 {
+				return fmt.Errorf("%w: apply %s", stagingusecase.ErrNothingToUndo, rec.ID)
+			}
+
+			output.Success(cmd.Root().Writer, "Staged %d change(s) reversing apply %s; review with 'suve stage diff'",
+				staged, rec.ID)
+
+			return nil
+		},
+	}
+}
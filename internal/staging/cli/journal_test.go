@@ -0,0 +1,84 @@
+package cli_test
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+	stgcli "github.com/mpyw/suve/internal/staging/cli"
+	"github.com/mpyw/suve/internal/staging/store/file"
+)
+
+// recordApply appends an apply record to scope's journal and returns its ID.
+func recordApply(t *testing.T, scope provider.Scope, items ...staging.AppliedItem) string {
+	t.Helper()
+
+	store, err := file.NewWorkingStore(scope)
+	require.NoError(t, err)
+
+	rec := &staging.ApplyRecord{User: "alice@host", Items: items}
+	require.NoError(t, store.AppendJournal(t.Context(), rec))
+
+	return rec.ID
+}
+
+//nolint:paralleltest // uses t.Setenv (HOME/SUVE_STAGING_KEY); cannot run in parallel
+func TestGlobalHistoryAndUndoCommands(t *testing.T) {
+	t.Run("history lists and shows applies, undo stages the inverse", func(t *testing.T) {
+		scope := setupExportImportEnv(t)
+		gcfg := changesetGlobalConfig(scope)
+
+		stdout, _, err := runLeafCmd(t, stgcli.NewGlobalHistoryCommand(gcfg), nil)
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "No applies recorded.")
+
+		id := recordApply(t, scope,
+			staging.AppliedItem{
+				Service: staging.ServiceParam, Name: "/app/config", Operation: staging.OperationUpdate,
+				Previous: staging.Snapshot{Exists: true, Value: "old", Version: "3"}, NewVersion: "4",
+			},
+			staging.AppliedItem{Service: staging.ServiceSecret, Name: "my-secret", Operation: staging.OperationCreate},
+		)
+
+		stdout, _, err = runLeafCmd(t, stgcli.NewGlobalHistoryCommand(gcfg), nil)
+		require.NoError(t, err)
+		assert.Contains(t, stdout, id)
+		assert.Contains(t, stdout, "alice@host 2 change(s)")
+
+		stdout, _, err = runLeafCmd(t, stgcli.NewGlobalHistoryCommand(gcfg), nil, "-v", id)
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "updated /app/config (3 -> 4)")
+		assert.Contains(t, stdout, "previous: old")
+		assert.Contains(t, stdout, "created my-secret")
+
+		stageEntry(t, scope, staging.ServiceParam, "/app/config", "newer")
+
+		stdout, stderr, err := runLeafCmd(t, stgcli.NewGlobalUndoCommand(gcfg), nil)
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "Staged 2 change(s) reversing apply "+id)
+		assert.Contains(t, stderr, "/app/config was also staged")
+
+		state := workingState(t, scope)
+		assert.Equal(t, "old", lo.FromPtr(state.Entries[staging.ServiceParam][staging.EntryKey{Name: "/app/config"}].Value))
+		assert.Equal(t, staging.OperationDelete, state.Entries[staging.ServiceSecret][staging.EntryKey{Name: "my-secret"}].Operation)
+	})
+
+	t.Run("unknown apply", func(t *testing.T) {
+		scope := setupExportImportEnv(t)
+		gcfg := changesetGlobalConfig(scope)
+
+		_, _, err := runLeafCmd(t, stgcli.NewGlobalUndoCommand(gcfg), nil)
+		require.ErrorContains(t, err, "no applies recorded")
+
+		recordApply(t, scope, staging.AppliedItem{
+			Service: staging.ServiceParam, Name: "/app/config", Operation: staging.OperationCreate,
+		})
+
+		_, _, err = runLeafCmd(t, stgcli.NewGlobalHistoryCommand(gcfg), nil, "00000000000000000001")
+		require.ErrorIs(t, err, file.ErrJournalNotFound)
+	})
+}
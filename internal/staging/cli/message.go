@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	flagMessage    = "message"
+	flagMessageTag = "message-tag"
+
+	// MessageTagEnvVar names the environment variable that sets --message-tag.
+	MessageTagEnvVar = "SUVE_MESSAGE_TAG"
+)
+
+// ChangeMessageDescription documents the apply change message flags; it is
+// shared by the per-service and global apply commands.
+const ChangeMessageDescription = `CHANGE MESSAGES:
+   With -m, the message is recorded with every value written, where the
+   provider allows it: a "[suve: ...]" suffix of the SSM parameter description,
+   a version annotation in Secret Manager, a version tag in Key Vault, and the
+   version record of the local store. 'log' shows it next to each version, and
+   'suve stage history' next to the apply. With --message-tag (or
+   SUVE_MESSAGE_TAG), the message is also set as that tag on each created or
+   updated entry, for providers that keep no per-version metadata.`
+
+// ChangeMessageFlags returns the apply flags that record a change message:
+// --message/-m and --message-tag (defaulting to SUVE_MESSAGE_TAG).
+func ChangeMessageFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    flagMessage,
+			Aliases: []string{"m"},
+			Usage:   "Record why the changes are made with every written value",
+		},
+		&cli.StringFlag{
+			Name:    flagMessageTag,
+			Usage:   "Also set the message as this tag on each created or updated entry",
+			Sources: cli.EnvVars(MessageTagEnvVar),
+		},
+	}
+}
+
+// ChangeMessageFromCmd returns the change message and message tag given to an
+// apply command with ChangeMessageFlags.
+func ChangeMessageFromCmd(cmd *cli.Command) (message, tag string) {
+	return cmd.String(flagMessage), cmd.String(flagMessageTag)
+}
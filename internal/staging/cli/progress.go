@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mpyw/suve/internal/cli/terminal"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// progressInterval is how often the live progress is redrawn.
+const progressInterval = 100 * time.Millisecond
+
+// spinnerFrames are the frames of the per-change spinner.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Progress renders the live progress of an apply on a terminal: a count of the
+// finished changes and a spinner line for each change in flight, redrawn in
+// place. Stop erases it, so the results print below the prompt as before.
+type Progress struct {
+	w       io.Writer
+	tracker staging.ApplyTracker
+
+	// mu guards the drawing state.
+	mu    sync.Mutex
+	frame int
+	drawn int
+
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// StartProgress starts drawing the live progress of the applies run under the
+// context Context returns, on w. It returns nil, which draws nothing, when w
+// is not a terminal.
+func StartProgress(w io.Writer) *Progress {
+	if !terminal.IsTerminalWriter(w) {
+		return nil
+	}
+
+	p := newProgress(w)
+	go p.loop()
+
+	return p
+}
+
+func newProgress(w io.Writer) *Progress {
+	return &Progress{w: w, stop: make(chan struct{}), stopped: make(chan struct{})}
+}
+
+// Context returns ctx reporting the apply's progress to p (ctx itself for a
+// nil p).
+func (p *Progress) Context(ctx context.Context) context.Context {
+	if p == nil {
+		return ctx
+	}
+
+	return staging.WithApplyProgress(ctx, p.tracker.Report)
+}
+
+// Stop stops drawing and erases the progress.
+func (p *Progress) Stop() {
+	if p == nil {
+		return
+	}
+
+	close(p.stop)
+	<-p.stopped
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.erase()
+}
+
+func (p *Progress) loop() {
+	defer close(p.stopped)
+
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+			p.frame++
+			p.draw()
+			p.mu.Unlock()
+		}
+	}
+}
+
+// render returns the progress lines.
+func (p *Progress) render() []string {
+	progress := p.tracker.Progress()
+	spinner := spinnerFrames[p.frame%len(spinnerFrames)]
+
+	count := fmt.Sprintf("Applying %d/%d", progress.Done, progress.Total)
+	if progress.Failed > 0 {
+		count += fmt.Sprintf(" (%d failed)", progress.Failed)
+	}
+
+	lines := []string{count}
+
+	for _, ev := range progress.Running {
+		verb := string(ev.Operation)
+		if ev.Tags {
+			verb = "tag"
+		}
+
+		line := fmt.Sprintf("  %s %s %s", spinner, verb, ev.Key.Label())
+		if ev.Kind == staging.ApplyEventRetrying {
+			line += fmt.Sprintf(" (throttled, retry %d in %s)", ev.Attempt, ev.Delay.Round(time.Millisecond))
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// draw redraws the progress over the previous drawing.
+func (p *Progress) draw() {
+	var b strings.Builder
+
+	p.writeErase(&b)
+
+	lines := p.render()
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+
+	p.drawn = len(lines)
+	_, _ = io.WriteString(p.w, b.String())
+}
+
+// erase clears the previous drawing.
+func (p *Progress) erase() {
+	var b strings.Builder
+
+	p.writeErase(&b)
+
+	p.drawn = 0
+	_, _ = io.WriteString(p.w, b.String())
+}
+
+// writeErase moves the cursor back over the drawn lines and clears them.
+func (p *Progress) writeErase(b *strings.Builder) {
+	if p.drawn > 0 {
+		fmt.Fprintf(b, "\x1b[%dF\x1b[J", p.drawn)
+	}
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mpyw/suve/internal/staging"
+)
+
+func TestProgress_Render(t *testing.T) {
+	t.Parallel()
+
+	p := newProgress(&bytes.Buffer{})
+
+	db := staging.EntryKey{Name: "/app/db"}
+	web := staging.EntryKey{Name: "/app/web", Namespace: "prod"}
+	cache := staging.EntryKey{Name: "/app/cache"}
+
+	for _, key := range []staging.EntryKey{db, web, cache} {
+		p.tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventQueued, Key: key})
+	}
+
+	p.tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventStarted, Key: db, Operation: staging.OperationCreate})
+	p.tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventStarted, Key: web, Tags: true})
+	p.tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventStarted, Key: cache, Operation: staging.OperationDelete})
+	p.tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventRetrying, Key: web, Tags: true, Attempt: 2, Delay: 150 * time.Millisecond})
+	p.tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventFinished, Key: cache, Operation: staging.OperationDelete, Err: errors.New("boom")})
+
+	assert.Equal(t, []string{
+		"Applying 1/3 (1 failed)",
+		"  ⠋ create /app/db",
+		"  ⠋ tag /app/web [prod] (throttled, retry 2 in 150ms)",
+	}, p.render())
+}
+
+func TestProgress_DrawErasesThePreviousDrawing(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	p := newProgress(&buf)
+	p.tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventQueued, Key: staging.EntryKey{Name: "/a"}})
+	p.tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventStarted, Key: staging.EntryKey{Name: "/a"}, Operation: staging.OperationUpdate})
+
+	p.draw()
+	assert.Equal(t, "Applying 0/1\n  ⠋ update /a\n", buf.String())
+
+	buf.Reset()
+	p.frame++
+	p.draw()
+	assert.Equal(t, "\x1b[2F\x1b[JApplying 0/1\n  ⠙ update /a\n", buf.String())
+
+	buf.Reset()
+	p.erase()
+	assert.Equal(t, "\x1b[2F\x1b[J", buf.String())
+}
+
+func TestProgress_NilDrawsNothing(t *testing.T) {
+	t.Parallel()
+
+	var p *Progress
+
+	ctx := p.Context(t.Context())
+	assert.Equal(t, t.Context(), ctx)
+	p.Stop()
+	assert.Nil(t, StartProgress(&bytes.Buffer{}))
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/staging"
 	stagingusecase "github.com/mpyw/suve/internal/usecase/staging"
 )
 
@@ -22,6 +23,8 @@ type ResetOptions struct {
 	// Namespace is the App Configuration namespace of the entry to reset (empty
 	// for the null/default namespace and every other provider; ignored with All).
 	Namespace string
+	// Select, when non-zero, unstages just the staged changes it selects.
+	Select staging.Selector
 }
 
 // Run executes the reset command.
@@ -30,6 +33,7 @@ func (r *ResetRunner) Run(ctx context.Context, opts ResetOptions) error {
 		Spec:      opts.Spec,
 		All:       opts.All,
 		Namespace: opts.Namespace,
+		Select:    opts.Select,
 	})
 	if err != nil {
 		return err
@@ -44,6 +48,10 @@ func (r *ResetRunner) Run(ctx context.Context, opts ResetOptions) error {
 		output.Warn(r.Stdout, "%s is not staged", result.Name)
 	case stagingusecase.ResetResultUnstaged:
 		output.Success(r.Stdout, "Unstaged %s", result.Name)
+	case stagingusecase.ResetResultNothingSelected:
+		output.Info(r.Stdout, "No staged %s changes match the selection.", result.ServiceName)
+	case stagingusecase.ResetResultUnstagedSelected:
+		output.Success(r.Stdout, "Unstaged %d selected %s change(s)", result.Count, result.ServiceName)
 	case stagingusecase.ResetResultUnstagedTag:
 		output.Success(r.Stdout, "Unstaged tag changes for %s", result.Name)
 	case stagingusecase.ResetResultRestored:
@@ -0,0 +1,289 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/cli/editor"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store"
+	stagingusecase "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// ResolveRunner executes conflict resolution using a usecase.
+type ResolveRunner struct {
+	UseCase *stagingusecase.ResolveUseCase
+	Stdout  io.Writer
+	Stderr  io.Writer
+	// OpenEditor opens conflicted merges; defaults to editor.Open if nil.
+	OpenEditor editor.OpenFunc
+}
+
+// ResolveOptions holds options for the resolve command.
+type ResolveOptions struct {
+	Name string // Optional: resolve only this item
+	// NoEdit leaves conflicted entries unresolved instead of opening the editor.
+	NoEdit bool
+}
+
+// Run executes the resolve command. It reports whether every conflict was
+// resolved.
+func (r *ResolveRunner) Run(ctx context.Context, opts ResolveOptions) (bool, error) {
+	if !opts.NoEdit {
+		openEditor := r.OpenEditor
+		if openEditor == nil {
+			openEditor = editor.Open
+		}
+
+		r.UseCase.Edit = func(ctx context.Context, key staging.EntryKey, merged string) (string, error) {
+			output.Info(r.Stderr, "Opening editor to resolve conflicts in %s...", key.Label())
+
+			return openEditor(ctx, merged)
+		}
+	}
+
+	result, err := r.UseCase.Execute(ctx, stagingusecase.ResolveInput{Name: opts.Name})
+	if err != nil {
+		return false, err
+	}
+
+	if len(result.Entries) == 0 && len(result.RebasedTags) == 0 {
+		output.Info(r.Stdout, "No %s conflicts.", result.ServiceName)
+
+		return true, nil
+	}
+
+	resolved := true
+
+	for _, e := range result.Entries {
+		label := e.Key.Label()
+
+		switch e.Status {
+		case stagingusecase.ResolveMerged:
+			output.Success(r.Stdout, "%s: Merged %s", result.ServiceName, label)
+		case stagingusecase.ResolveEdited:
+			output.Success(r.Stdout, "%s: Resolved %s (%d conflict(s) edited)", result.ServiceName, label, e.Conflicts)
+		case stagingusecase.ResolveUpToDate:
+			output.Success(r.Stdout, "%s: Unstaged %s (remote already has the merged value)", result.ServiceName, label)
+		case stagingusecase.ResolveUnresolved:
+			resolved = false
+
+			output.Warning(r.Stderr, "%s: %d conflict(s) left unresolved in %s", result.ServiceName, e.Conflicts, label)
+		case stagingusecase.ResolveSkipped:
+			resolved = false
+
+			output.Warning(r.Stderr, "%s: skipped %s: %s", result.ServiceName, label, e.Reason)
+		case stagingusecase.ResolveFailed:
+			resolved = false
+
+			output.Failed(r.Stderr, result.ServiceName+": "+label, e.Error)
+		}
+	}
+
+	for _, key := range result.RebasedTags {
+		output.Success(r.Stdout, "%s: Rebased tag changes for %s", result.ServiceName, key.Label())
+	}
+
+	return resolved, nil
+}
+
+// resolveFlags are the flags shared by the service and global resolve commands.
+func resolveFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "no-edit",
+			Usage: "Leave conflicting entries unresolved instead of opening the editor",
+		},
+	}
+}
+
+// resolveStrategyFor adapts StrategyForNamespace to the ResolveUseCase
+// resolver, or nil when the provider has no namespace axis.
+func resolveStrategyFor(
+	ctx context.Context, strategyForNamespace func(context.Context, string) (staging.FullStrategy, error),
+) func(string) (stagingusecase.ResolveStrategy, error) {
+	if strategyForNamespace == nil {
+		return nil
+	}
+
+	return func(ns string) (stagingusecase.ResolveStrategy, error) {
+		return strategyForNamespace(ctx, ns)
+	}
+}
+
+// errUnresolved is returned when conflicts remain after resolve.
+func errUnresolved() error {
+	return errors.New("some conflicts remain: resolve them, reset the entries, or apply with --ignore-conflicts")
+}
+
+// NewResolveCommand creates a service-specific resolve command.
+func NewResolveCommand(cfg CommandConfig) *cli.Command {
+	return &cli.Command{
+		Name:        "resolve",
+		Usage:       fmt.Sprintf("Merge remote changes into conflicting staged %ss", cfg.ItemName),
+		ArgsUsage:   argsUsageName,
+		Description: resolveDescription(cfg.ItemName, "suve stage "+cfg.CommandName+" resolve"),
+		Flags:       resolveFlags(),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			store, _, err := workingStore(ctx, cfg.ScopeResolver)
+			if err != nil {
+				return err
+			}
+
+			strategy, err := cfg.Factory(ctx)
+			if err != nil {
+				return err
+			}
+
+			r := &ResolveRunner{
+				UseCase: &stagingusecase.ResolveUseCase{
+					Strategy:    strategy,
+					Store:       store,
+					StrategyFor: resolveStrategyFor(ctx, cfg.StrategyForNamespace),
+				},
+				Stdout: cmd.Root().Writer,
+				Stderr: cmd.Root().ErrWriter,
+			}
+
+			resolved, err := r.Run(ctx, ResolveOptions{Name: cmd.Args().First(), NoEdit: cmd.Bool("no-edit")})
+			if err != nil {
+				return err
+			}
+
+			if !resolved {
+				return errUnresolved()
+			}
+
+			return nil
+		},
+	}
+}
+
+// NewGlobalResolveCommand creates the provider-wide resolve command, which
+// resolves conflicts in every configured service.
+func NewGlobalResolveCommand(gcfg GlobalConfig) *cli.Command {
+	return &cli.Command{
+		Name:        "resolve",
+		Usage:       "Merge remote changes into conflicting staged changes",
+		ArgsUsage:   argsUsageName,
+		Description: resolveDescription("entry", "suve stage resolve"),
+		Flags:       resolveFlags(),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			name := cmd.Args().First()
+			allResolved, found := true, false
+
+			for _, spec := range gcfg.Services {
+				st, _, err := workingStore(ctx, spec.ScopeResolver)
+				if errors.Is(err, staging.ErrServiceNotConfigured) {
+					continue
+				}
+
+				if err != nil {
+					return err
+				}
+
+				staged, err := hasStaged(ctx, st, spec.Service, name)
+				if err != nil {
+					return err
+				}
+
+				if !staged {
+					continue
+				}
+
+				found = true
+
+				strategy, err := spec.Factory(ctx)
+				if err != nil {
+					return err
+				}
+
+				r := &ResolveRunner{
+					UseCase: &stagingusecase.ResolveUseCase{
+						Strategy:    strategy,
+						Store:       st,
+						StrategyFor: resolveStrategyFor(ctx, spec.StrategyForNamespace),
+					},
+					Stdout: cmd.Root().Writer,
+					Stderr: cmd.Root().ErrWriter,
+				}
+
+				resolved, err := r.Run(ctx, ResolveOptions{Name: name, NoEdit: cmd.Bool("no-edit")})
+				if err != nil {
+					return err
+				}
+
+				allResolved = allResolved && resolved
+			}
+
+			switch {
+			case !found && name != "":
+				return fmt.Errorf("%s is not staged", name)
+			case !found:
+				output.Info(cmd.Root().Writer, "No changes staged.")
+			case !allResolved:
+				return errUnresolved()
+			}
+
+			return nil
+		},
+	}
+}
+
+// hasStaged reports whether the service has staged entries or tag changes,
+// restricted to the given name when it is not empty.
+func hasStaged(ctx context.Context, st store.ReadOperator, service staging.Service, name string) (bool, error) {
+	entries, err := st.ListEntries(ctx, service)
+	if err != nil {
+		return false, err
+	}
+
+	tags, err := st.ListTags(ctx, service)
+	if err != nil {
+		return false, err
+	}
+
+	for key := range entries[service] {
+		if name == "" || key.Name == name {
+			return true, nil
+		}
+	}
+
+	for key := range tags[service] {
+		if name == "" || key.Name == name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func resolveDescription(itemName, command string) string {
+	return fmt.Sprintf(`Merge remote changes made since staging into conflicting staged %[1]ss.
+
+A staged %[1]s conflicts when the remote was modified after it was staged.
+For each conflict, resolve fetches the version the change was staged against,
+the current remote value and the staged value, and merges them three-way:
+JSON objects key by key, anything else line by line. Changes to different
+keys or lines merge automatically; changes to the same key or line open your
+editor with git-style conflict markers. On success the staged %[1]s is
+re-anchored at the remote's current version, so apply no longer reports it.
+
+Staged tag changes that conflict are re-anchored as-is: they add or remove
+individual tags and apply cleanly on top of the current remote tags.
+
+A staged delete cannot be merged, and neither can an update of an entry that
+was deleted remotely; reset those or apply with --ignore-conflicts. Without
+version history (App Configuration, Kubernetes) the staged and remote values
+are merged without a base, so every difference is a conflict.
+
+EXAMPLES:
+   %[2]s                Resolve every conflict
+   %[2]s <name>         Resolve one %[1]s
+   %[2]s --no-edit      Merge what merges cleanly, leave the rest`, itemName, command)
+}
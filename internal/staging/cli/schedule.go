@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/parallel"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+const (
+	flagOrder       = "order"
+	flagConcurrency = "concurrency"
+)
+
+// ScheduleDescription documents the apply ordering, concurrency and retries; it
+// is shared by the per-service and global apply commands.
+var ScheduleDescription = fmt.Sprintf(`ORDER AND CONCURRENCY:
+   Staged entries are applied creates first, then updates, then deletes, and
+   tag changes last; each phase finishes before the next starts. With
+   --order <file>, the file lists name globs (as --match takes them), one per
+   line, and the changes each one matches are applied, phase by phase, before
+   those of the next line; unmatched changes come last. Blank lines and lines
+   starting with "#" are skipped.
+   --concurrency caps the changes applied at once (%d by default). A request
+   the provider throttles (e.g. SSM's ThrottlingException) is retried with
+   exponential backoff. On a terminal, a live count and a spinner per change
+   in flight show the progress.`, parallel.DefaultLimit)
+
+// ScheduleFlags returns the apply flags that order and pace the writes:
+// --order and --concurrency.
+func ScheduleFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  flagOrder,
+			Usage: "Apply the changes in the order of the name globs listed in this file",
+		},
+		&cli.IntFlag{
+			Name:  flagConcurrency,
+			Usage: "Maximum number of changes applied at once",
+			Value: parallel.DefaultLimit,
+		},
+	}
+}
+
+// ScheduleFromCmd returns the order and concurrency given to an apply command
+// with ScheduleFlags, reading the --order file.
+func ScheduleFromCmd(cmd *cli.Command) (staging.ApplyOrder, int, error) {
+	concurrency := cmd.Int(flagConcurrency)
+	if concurrency < 1 {
+		return staging.ApplyOrder{}, 0, fmt.Errorf("invalid --%s %d: must be at least 1", flagConcurrency, concurrency)
+	}
+
+	file := cmd.String(flagOrder)
+	if file == "" {
+		return staging.ApplyOrder{}, concurrency, nil
+	}
+
+	f, err := os.Open(file) //nolint:gosec // the order file is the user's own choice
+	if err != nil {
+		return staging.ApplyOrder{}, 0, fmt.Errorf("failed to read order file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	order, err := staging.ParseApplyOrder(f)
+	if err != nil {
+		return staging.ApplyOrder{}, 0, fmt.Errorf("invalid order file %s: %w", file, err)
+	}
+
+	return order, concurrency, nil
+}
@@ -12,15 +12,19 @@ import (
 
 // AWSScopeResolver resolves the AWS staging scope from the STS caller identity.
 // It is the default resolver used when a CommandConfig / GlobalConfig does not
-// specify one, preserving the original AWS-only staging behavior.
+// specify one, preserving the original AWS-only staging behavior. A profile
+// selected with --profile (see infra.WithOptions) is part of the scope.
 func AWSScopeResolver(ctx context.Context) (staging.ResolvedScope, error) {
 	identity, err := infra.GetAWSIdentity(ctx)
 	if err != nil {
 		return staging.ResolvedScope{}, fmt.Errorf("failed to get AWS identity: %w", err)
 	}
 
+	scope := provider.AWSScope(identity.AccountID, identity.Region)
+	scope.AWSProfile = infra.OptionsFrom(ctx).Profile
+
 	return staging.ResolvedScope{
-		Scope:  provider.AWSScope(identity.AccountID, identity.Region),
+		Scope:  scope,
 		Target: awsTarget(identity.Profile, identity.AccountID, identity.Region),
 	}, nil
 }
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"github.com/samber/lo"
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/staging"
+)
+
+const (
+	flagMatch           = "match"
+	flagExclude         = "exclude"
+	flagOp              = "op"
+	flagSelectNamespace = "namespace"
+)
+
+// SelectorDescription documents the selector flags of apply, reset, status,
+// diff and export. namespaced adds --namespace, which only the global commands
+// of a provider with namespaces (Azure) take.
+func SelectorDescription(namespaced bool) string {
+	namespace := ""
+	if namespaced {
+		namespace = `
+   --namespace selects App Configuration namespaces ('' is the default one).`
+	}
+
+	return `SELECTING CHANGES:
+   --match and --exclude take name globs, where "*" stops at "/"; a glob also
+   covers everything below a path it matches, so '/app/db/*' selects
+   /app/db/a/b, and --exclude '/app/db/legacy' leaves out that whole subtree.
+   --op selects create, update, delete, or tag (staged tag changes).` + namespace + `
+   Each flag repeats or takes a comma-separated list; a change must satisfy
+   every flag given.`
+}
+
+// SelectorFlags returns the flags that narrow a staging command to part of the
+// staged changes. namespaced adds --namespace; the per-service commands leave
+// it out, as an App Configuration group's own --namespace names the namespace
+// to stage under.
+func SelectorFlags(namespaced bool) []cli.Flag {
+	flags := []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  flagMatch,
+			Usage: "Only staged changes whose name matches this glob",
+		},
+		&cli.StringSliceFlag{
+			Name:  flagExclude,
+			Usage: "Leave out staged changes whose name matches this glob",
+		},
+		&cli.StringSliceFlag{
+			Name:  flagOp,
+			Usage: "Only staged changes of this operation (create, update, delete, tag)",
+		},
+	}
+
+	if namespaced {
+		flags = append(flags, &cli.StringSliceFlag{
+			Name:  flagSelectNamespace,
+			Usage: "Only staged changes in this App Configuration namespace",
+		})
+	}
+
+	return flags
+}
+
+// SelectorFromCmd returns the validated selector given to a command with
+// SelectorFlags(namespaced).
+func SelectorFromCmd(cmd *cli.Command, namespaced bool) (staging.Selector, error) {
+	sel := staging.Selector{
+		Match:   cmd.StringSlice(flagMatch),
+		Exclude: cmd.StringSlice(flagExclude),
+		Operations: lo.Map(cmd.StringSlice(flagOp), func(op string, _ int) staging.Operation {
+			return staging.Operation(op)
+		}),
+	}
+
+	if namespaced && cmd.IsSet(flagSelectNamespace) {
+		sel.Namespaces = cmd.StringSlice(flagSelectNamespace)
+		if len(sel.Namespaces) == 0 {
+			sel.Namespaces = []string{""}
+		}
+	}
+
+	if err := sel.Validate(); err != nil {
+		return staging.Selector{}, err
+	}
+
+	return sel, nil
+}
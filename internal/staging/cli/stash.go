@@ -0,0 +1,458 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/mpyw/suve/internal/cli/colors"
+	"github.com/mpyw/suve/internal/cli/output"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/file"
+	"github.com/mpyw/suve/internal/timeutil"
+	stagingusecase "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// stashRefPattern matches a stash reference: stash@{N} or a bare N.
+var stashRefPattern = regexp.MustCompile(`^(?:stash@\{([0-9]+)\}|([0-9]+))$`)
+
+// scopeTarget is one working store of the provider, with the services staged
+// there. AWS keeps both services in one store; Azure keeps App Configuration
+// and Key Vault in separate ones, so a stash push (or an apply journal record)
+// writes its parts to each under the same ID.
+type scopeTarget struct {
+	store *file.Store
+	specs []GlobalServiceSpec
+}
+
+// scopeTargets opens the provider's distinct working stores, skipping
+// unconfigured services.
+func scopeTargets(ctx context.Context, gcfg GlobalConfig) ([]scopeTarget, error) {
+	var targets []scopeTarget
+
+	index := map[string]int{}
+
+	for _, spec := range gcfg.Services {
+		st, resolved, err := workingStore(ctx, spec.ScopeResolver)
+		if errors.Is(err, staging.ErrServiceNotConfigured) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if i, ok := index[resolved.Scope.Key()]; ok {
+			targets[i].specs = append(targets[i].specs, spec)
+
+			continue
+		}
+
+		index[resolved.Scope.Key()] = len(targets)
+		targets = append(targets, scopeTarget{store: st, specs: []GlobalServiceSpec{spec}})
+	}
+
+	if len(targets) == 0 {
+		return nil, staging.ErrServiceNotConfigured
+	}
+
+	return targets, nil
+}
+
+// listStashes returns the stashes across targets, newest first.
+func listStashes(ctx context.Context, targets []scopeTarget) ([]file.Stash, error) {
+	var stashes []file.Stash
+
+	seen := map[string]bool{}
+
+	for _, target := range targets {
+		found, err := target.store.Stashes(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, stash := range found {
+			if !seen[stash.ID] {
+				seen[stash.ID] = true
+				stashes = append(stashes, stash)
+			}
+		}
+	}
+
+	// Stash IDs are creation timestamps, so newest first is descending ID order.
+	slices.SortFunc(stashes, func(a, b file.Stash) int { return strings.Compare(b.ID, a.ID) })
+
+	return stashes, nil
+}
+
+// resolveStash resolves a stash reference (stash@{N} or N; empty is the newest
+// stash) to the stash and its index.
+func resolveStash(ctx context.Context, targets []scopeTarget, ref string) (file.Stash, int, error) {
+	index := 0
+
+	if ref != "" {
+		m := stashRefPattern.FindStringSubmatch(ref)
+		if m == nil {
+			return file.Stash{}, 0, fmt.Errorf("invalid stash reference %q: use stash@{N} or N", ref)
+		}
+
+		n, err := strconv.Atoi(m[1] + m[2])
+		if err != nil {
+			return file.Stash{}, 0, fmt.Errorf("invalid stash reference %q: %w", ref, err)
+		}
+
+		index = n
+	}
+
+	stashes, err := listStashes(ctx, targets)
+	if err != nil {
+		return file.Stash{}, 0, err
+	}
+
+	if len(stashes) == 0 {
+		return file.Stash{}, 0, errors.New("no stash entries found")
+	}
+
+	if index >= len(stashes) {
+		return file.Stash{}, 0, fmt.Errorf("%s does not exist", stashRef(index))
+	}
+
+	return stashes[index], index, nil
+}
+
+// stashRef formats the reference of the stash at index.
+func stashRef(index int) string {
+	return fmt.Sprintf("stash@{%d}", index)
+}
+
+// stashArg returns the optional stash reference argument, rejecting extras.
+func stashArg(cmd *cli.Command, usage string) (string, error) {
+	if cmd.Args().Len() > 1 {
+		return "", errors.New("usage: " + usage)
+	}
+
+	return cmd.Args().First(), nil
+}
+
+// NewGlobalStashCommand creates the provider-wide stash command, which shelves
+// staged changes and restores them later.
+func NewGlobalStashCommand(gcfg GlobalConfig) *cli.Command {
+	list := newStashListCommand(gcfg)
+
+	return &cli.Command{
+		Name:  "stash",
+		Usage: "Shelve staged changes and restore them later",
+		Description: `Save staged changes away and clear them from the staging area, so something
+unrelated can be staged and applied first. Stashes belong to the active
+changeset and are encrypted like the staging area itself.
+
+Stashes are numbered newest first: stash@{0} is the latest. Commands that take
+a stash default to stash@{0}; a bare number (e.g. 1) works too. Without a
+subcommand, stash lists the stashes.
+
+EXAMPLES:
+   suve stage stash push -m "db migration"     Stash every staged change
+   suve stage stash push /app/db-url           Stash only /app/db-url
+   suve stage stash list                       List stashes
+   suve stage stash show stash@{1}             Show what a stash holds
+   suve stage stash pop                        Restore the latest stash and drop it
+   suve stage stash apply 1                    Restore stash@{1} and keep it
+   suve stage stash drop 1                     Delete stash@{1}`,
+		Commands: []*cli.Command{
+			newStashPushCommand(gcfg),
+			list,
+			newStashShowCommand(gcfg),
+			newStashRestoreCommand(gcfg, "pop", false),
+			newStashRestoreCommand(gcfg, "apply", true),
+			newStashDropCommand(gcfg),
+		},
+		Action: list.Action,
+	}
+}
+
+func newStashPushCommand(gcfg GlobalConfig) *cli.Command {
+	return &cli.Command{
+		Name:      "push",
+		Usage:     "Stash staged changes",
+		ArgsUsage: "[name...]",
+		Description: `Save staged changes as a new stash and clear them from the staging area. With
+names, only those names (and their staged tag changes) are stashed.
+
+EXAMPLES:
+   suve stage stash push                          Stash every staged change
+   suve stage stash push -m "db migration"        Stash with a message
+   suve stage stash push /app/db-url /app/flag    Stash only these names`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "message",
+				Aliases: []string{"m"},
+				Usage:   "Describe the stash",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			targets, err := scopeTargets(ctx, gcfg)
+			if err != nil {
+				return err
+			}
+
+			message := cmd.String("message")
+			if message == "" {
+				message = "WIP on " + targets[0].store.Changeset()
+			}
+
+			id := file.NewStashID()
+			stashed := false
+
+			for _, target := range targets {
+				result, err := (&stagingusecase.StashPushUseCase{Working: target.store, Stash: target.store}).
+					Execute(ctx, stagingusecase.StashPushInput{ID: id, Message: message, Names: cmd.Args().Slice()})
+				if errors.Is(err, stagingusecase.ErrNothingToStash) {
+					continue
+				}
+
+				if err != nil {
+					return err
+				}
+
+				stashed = stashed || result.EntryCount+result.TagCount > 0
+			}
+
+			switch {
+			case stashed:
+				output.Success(cmd.Root().Writer, "Saved staged changes to %s: %s", stashRef(0), message)
+			case cmd.Args().Present():
+				return errors.New("no staged changes match the given names")
+			default:
+				output.Info(cmd.Root().Writer, "No changes staged.")
+			}
+
+			return nil
+		},
+	}
+}
+
+func newStashListCommand(gcfg GlobalConfig) *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "List stashes",
+		Description: `List the stashes of the active changeset, newest first.
+
+EXAMPLES:
+   suve stage stash list    List stashes`,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			targets, err := scopeTargets(ctx, gcfg)
+			if err != nil {
+				return err
+			}
+
+			stashes, err := listStashes(ctx, targets)
+			if err != nil {
+				return err
+			}
+
+			pal := colors.For(cmd.Root().Writer)
+
+			for i, stash := range stashes {
+				output.Printf(cmd.Root().Writer, "%s: %s %s\n",
+					pal.Warning(stashRef(i)), stash.Message, pal.FieldLabel("("+timeutil.FormatDateTime(stash.CreatedAt)+")"))
+			}
+
+			return nil
+		},
+	}
+}
+
+func newStashShowCommand(gcfg GlobalConfig) *cli.Command {
+	return &cli.Command{
+		Name:      "show",
+		Usage:     "Show the changes in a stash",
+		ArgsUsage: "[stash]",
+		Description: `Show the staged changes saved in a stash (default stash@{0}), in the same
+form as "suve stage status".
+
+EXAMPLES:
+   suve stage stash show               Show the latest stash
+   suve stage stash show -v stash@{1}  Show stash@{1} with values`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "verbose",
+				Aliases: []string{"v"},
+				Usage:   "Show detailed information including values",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			ref, err := stashArg(cmd, "suve stage stash show [-v] [stash]")
+			if err != nil {
+				return err
+			}
+
+			targets, err := scopeTargets(ctx, gcfg)
+			if err != nil {
+				return err
+			}
+
+			stash, index, err := resolveStash(ctx, targets, ref)
+			if err != nil {
+				return err
+			}
+
+			w := cmd.Root().Writer
+			output.Printf(w, "%s: %s\n", colors.For(w).Warning(stashRef(index)), stash.Message)
+
+			for _, target := range targets {
+				state, err := target.store.ReadStash(ctx, stash.ID)
+				if errors.Is(err, file.ErrStashNotFound) {
+					continue
+				}
+
+				if err != nil {
+					return err
+				}
+
+				for _, spec := range target.specs {
+					printStashedService(w, spec, state, cmd.Bool("verbose"))
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// printStashedService prints one service's part of a stash like stage status.
+func printStashedService(w io.Writer, spec GlobalServiceSpec, state *staging.State, verbose bool) {
+	entries, tags := state.Entries[spec.Service], state.Tags[spec.Service]
+	if len(entries)+len(tags) == 0 {
+		return
+	}
+
+	parser := spec.ParserFactory()
+	output.Printf(w, "%s (%d):\n", colors.For(w).Warning(parser.ServiceName()), len(entries)+len(tags))
+
+	printer := &staging.EntryPrinter{Writer: w}
+	for _, key := range staging.SortedEntryKeys(entries) {
+		printer.PrintEntry(key, entries[key], verbose, parser.HasDeleteOptions())
+	}
+
+	status := &StatusRunner{Stdout: w}
+	for _, key := range staging.SortedEntryKeys(tags) {
+		tag := tags[key]
+		status.printTagEntry(stagingusecase.StatusTagEntry{
+			Name: key.Name, Namespace: key.Namespace, Add: tag.Add, Remove: tag.Remove, StagedAt: tag.StagedAt,
+		}, verbose)
+	}
+}
+
+func newStashRestoreCommand(gcfg GlobalConfig, name string, keep bool) *cli.Command {
+	usage, description := "Restore a stash and drop it", `Restore a stash (default stash@{0}) into the staging area and drop it.`
+	if keep {
+		usage, description = "Restore a stash and keep it", `Restore a stash (default stash@{0}) into the staging area and keep it.`
+	}
+
+	return &cli.Command{
+		Name:      name,
+		Usage:     usage,
+		ArgsUsage: "[stash]",
+		Description: description + `
+
+Restored changes are merged into what is staged now. An item staged in both
+takes the stashed change (staged tag changes are combined per tag key); each
+such item is reported.
+
+EXAMPLES:
+   suve stage stash ` + name + `              Restore the latest stash
+   suve stage stash ` + name + ` stash@{1}    Restore stash@{1}`,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			ref, err := stashArg(cmd, "suve stage stash "+name+" [stash]")
+			if err != nil {
+				return err
+			}
+
+			targets, err := scopeTargets(ctx, gcfg)
+			if err != nil {
+				return err
+			}
+
+			stash, index, err := resolveStash(ctx, targets, ref)
+			if err != nil {
+				return err
+			}
+
+			for _, target := range targets {
+				result, err := (&stagingusecase.StashPopUseCase{Working: target.store, Stash: target.store}).
+					Execute(ctx, stagingusecase.StashPopInput{ID: stash.ID, Keep: keep})
+				if errors.Is(err, file.ErrStashNotFound) {
+					continue
+				}
+
+				if err != nil {
+					return err
+				}
+
+				for _, conflict := range result.Conflicts {
+					if conflict.Tag {
+						output.Warning(cmd.Root().ErrWriter, "tag changes of %s were also staged; combined with the stashed ones",
+							conflict.Key.Label())
+					} else {
+						output.Warning(cmd.Root().ErrWriter, "%s was also staged; replaced with the stashed change",
+							conflict.Key.Label())
+					}
+				}
+			}
+
+			if keep {
+				output.Success(cmd.Root().Writer, "Restored %s: %s", stashRef(index), stash.Message)
+			} else {
+				output.Success(cmd.Root().Writer, "Restored and dropped %s: %s", stashRef(index), stash.Message)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newStashDropCommand(gcfg GlobalConfig) *cli.Command {
+	return &cli.Command{
+		Name:      "drop",
+		Usage:     "Delete a stash",
+		ArgsUsage: "[stash]",
+		Description: `Delete a stash (default stash@{0}) without restoring it.
+
+EXAMPLES:
+   suve stage stash drop              Delete the latest stash
+   suve stage stash drop stash@{1}    Delete stash@{1}`,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			ref, err := stashArg(cmd, "suve stage stash drop [stash]")
+			if err != nil {
+				return err
+			}
+
+			targets, err := scopeTargets(ctx, gcfg)
+			if err != nil {
+				return err
+			}
+
+			stash, index, err := resolveStash(ctx, targets, ref)
+			if err != nil {
+				return err
+			}
+
+			for _, target := range targets {
+				if err := target.store.DropStash(ctx, stash.ID); err != nil && !errors.Is(err, file.ErrStashNotFound) {
+					return err
+				}
+			}
+
+			output.Success(cmd.Root().Writer, "Dropped %s: %s", stashRef(index), stash.Message)
+
+			return nil
+		},
+	}
+}
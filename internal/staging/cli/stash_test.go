@@ -0,0 +1,74 @@
+package cli_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/staging"
+	stgcli "github.com/mpyw/suve/internal/staging/cli"
+)
+
+//nolint:paralleltest // uses t.Setenv (HOME/SUVE_STAGING_KEY); cannot run in parallel
+func TestGlobalStashCommand(t *testing.T) {
+	t.Run("push, list, show, apply, pop and drop", func(t *testing.T) {
+		scope := setupExportImportEnv(t)
+		gcfg := changesetGlobalConfig(scope)
+
+		stageEntry(t, scope, staging.ServiceParam, "/app/config", "pval")
+		stageEntry(t, scope, staging.ServiceSecret, "my-secret", "sval")
+
+		stdout, _, err := runLeafCmd(t, stgcli.NewGlobalStashCommand(gcfg), nil, "push", "-m", "migration", "/app/config")
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "Saved staged changes to stash@{0}: migration")
+		assert.Empty(t, workingState(t, scope).Entries[staging.ServiceParam])
+		assert.Len(t, workingState(t, scope).Entries[staging.ServiceSecret], 1, "unnamed changes stay staged")
+
+		_, _, err = runLeafCmd(t, stgcli.NewGlobalStashCommand(gcfg), nil, "push")
+		require.NoError(t, err)
+
+		stdout, _, err = runLeafCmd(t, stgcli.NewGlobalStashCommand(gcfg), nil, "list")
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "stash@{0}: WIP on default")
+		assert.Contains(t, stdout, "stash@{1}: migration")
+
+		stdout, _, err = runLeafCmd(t, stgcli.NewGlobalStashCommand(gcfg), nil, "show", "stash@{1}")
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "/app/config")
+		assert.NotContains(t, stdout, "my-secret")
+
+		// apply keeps the stash; staging the same name again reports a conflict.
+		stageEntry(t, scope, staging.ServiceParam, "/app/config", "newer")
+
+		_, stderr, err := runLeafCmd(t, stgcli.NewGlobalStashCommand(gcfg), nil, "apply", "1")
+		require.NoError(t, err)
+		assert.Contains(t, stderr, "/app/config was also staged")
+		assert.Equal(t, "pval", *workingState(t, scope).Entries[staging.ServiceParam][staging.EntryKey{Name: "/app/config"}].Value)
+
+		stdout, _, err = runLeafCmd(t, stgcli.NewGlobalStashCommand(gcfg), nil, "pop")
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "Restored and dropped stash@{0}: WIP on default")
+		assert.Len(t, workingState(t, scope).Entries[staging.ServiceSecret], 1)
+
+		stdout, _, err = runLeafCmd(t, stgcli.NewGlobalStashCommand(gcfg), nil, "drop")
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "Dropped stash@{0}: migration")
+
+		_, _, err = runLeafCmd(t, stgcli.NewGlobalStashCommand(gcfg), nil, "pop")
+		require.ErrorContains(t, err, "no stash entries found")
+	})
+
+	t.Run("push unmatched name", func(t *testing.T) {
+		scope := setupExportImportEnv(t)
+		gcfg := changesetGlobalConfig(scope)
+
+		stageEntry(t, scope, staging.ServiceParam, "/app/config", "pval")
+
+		_, _, err := runLeafCmd(t, stgcli.NewGlobalStashCommand(gcfg), nil, "push", "/other")
+		require.ErrorContains(t, err, "no staged changes match")
+
+		_, _, err = runLeafCmd(t, stgcli.NewGlobalStashCommand(gcfg), nil, "show", "stash@{x}")
+		require.ErrorContains(t, err, "invalid stash reference")
+	})
+}
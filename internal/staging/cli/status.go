@@ -24,13 +24,15 @@ type StatusRunner struct {
 // StatusOptions holds options for the status command.
 type StatusOptions struct {
 	Name    string
+	Select  staging.Selector
 	Verbose bool
 }
 
 // Run executes the status command.
 func (r *StatusRunner) Run(ctx context.Context, opts StatusOptions) error {
 	result, err := r.UseCase.Execute(ctx, stagingusecase.StatusInput{
-		Name: opts.Name,
+		Name:   opts.Name,
+		Select: opts.Select,
 	})
 	if err != nil {
 		return err
@@ -38,6 +40,12 @@ func (r *StatusRunner) Run(ctx context.Context, opts StatusOptions) error {
 
 	totalCount := len(result.Entries) + len(result.TagEntries)
 	if totalCount == 0 {
+		if !opts.Select.IsZero() {
+			output.Info(r.Stdout, "No staged %s changes match the selection.", result.ServiceName)
+
+			return nil
+		}
+
 		output.Info(r.Stdout, "No %s changes staged.", result.ServiceName)
 
 		return nil
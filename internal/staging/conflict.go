@@ -13,9 +13,15 @@ import (
 // of its OWN namespace rather than the default one.
 type ApplyStrategyResolver func(namespace string) (ApplyStrategy, error)
 
-// lastModifiedResults maps each probed EntryKey to its fetched last-modified
-// time (or the fetch error).
-type lastModifiedResults = map[EntryKey]*parallel.Result[time.Time]
+// remoteStateResults maps each probed EntryKey to its fetched remote state (or
+// the fetch error).
+type remoteStateResults = map[EntryKey]*parallel.Result[RemoteState]
+
+// stagedBase is the remote state a staged change was based on.
+type stagedBase struct {
+	At      *time.Time
+	Version string
+}
 
 // CheckConflicts checks if remote resources were modified after staging.
 // Returns the set of EntryKeys that have conflicts.
@@ -27,7 +33,8 @@ type lastModifiedResults = map[EntryKey]*parallel.Result[time.Time]
 // the empty namespace, so behavior is unchanged.
 //
 // For Create operations: conflicts if resource now exists (someone else created it).
-// For Update/Delete operations with BaseModifiedAt: conflicts if the remote was modified after base.
+// For Update/Delete operations with a base: conflicts if the remote's version differs from
+// BaseVersion or, when either side has no version, if it was modified after BaseModifiedAt.
 func CheckConflicts(ctx context.Context, resolve ApplyStrategyResolver, entries map[EntryKey]Entry) map[EntryKey]struct{} {
 	return CheckEntryAndTagConflicts(ctx, resolve, entries, nil)
 }
@@ -53,7 +60,7 @@ func CheckTagConflicts(ctx context.Context, resolve ApplyStrategyResolver, tags
 // CheckEntryAndTagConflicts checks both staged value changes and staged tag
 // changes for conflicts and returns the merged set of conflicting EntryKeys.
 //
-// It fetches each remote's last-modified time at most once, even when the same
+// It fetches each remote's state at most once, even when the same
 // key carries both a value change and a tag change: the two probes previously
 // double-fetched the same remote, wasting I/O and widening the window in which
 // the two timestamps could disagree on second-granular providers. The value and
@@ -80,27 +87,46 @@ func CheckEntryAndTagConflicts(
 	addKeys(keys, toCheckModified)
 	addKeys(keys, toCheckTags)
 
-	results := fetchLastModified(ctx, resolve, keys)
+	results := fetchRemoteStates(ctx, resolve, keys)
 
 	// Create: conflict if the resource now exists (someone else created it).
 	for key := range toCheckCreate {
 		result := results[key]
-		if result.Err == nil && !result.Value.IsZero() {
+		if result.Err == nil && (!result.Value.LastModified.IsZero() || result.Value.Version != "") {
 			conflicts[key] = struct{}{}
 		}
 	}
 
-	// Update/Delete and tag changes: conflict if the remote was modified after
-	// the staged base time.
-	markModifiedAfterBase(toCheckModified, func(e Entry) time.Time { return *e.BaseModifiedAt }, results, conflicts)
-	markModifiedAfterBase(toCheckTags, func(t TagEntry) time.Time { return *t.BaseModifiedAt }, results, conflicts)
+	// Update/Delete and tag changes: conflict if the remote moved past the
+	// staged base. Tag changes record no version, so they compare times only.
+	markModifiedSinceBase(toCheckModified, func(e Entry) stagedBase {
+		return stagedBase{At: e.BaseModifiedAt, Version: e.BaseVersion}
+	}, results, conflicts)
+	markModifiedSinceBase(toCheckTags, func(t TagEntry) stagedBase {
+		return stagedBase{At: t.BaseModifiedAt}
+	}, results, conflicts)
 
 	return conflicts
 }
 
+// WithoutBaseVersion returns a copy of entries with every BaseVersion cleared.
+// Ignoring conflicts must also drop the write preconditions a strategy derives
+// from the base version (App Configuration's If-Match ETag).
+func WithoutBaseVersion(entries map[EntryKey]Entry) map[EntryKey]Entry {
+	out := make(map[EntryKey]Entry, len(entries))
+
+	for key, entry := range entries {
+		entry.BaseVersion = ""
+		out[key] = entry
+	}
+
+	return out
+}
+
 // classifyEntries splits entries into those checked for a Create conflict (the
 // resource now exists) and those checked for a modified-after-base conflict.
-// Entries without a check type (Update/Delete lacking BaseModifiedAt) are dropped.
+// Entries without a check type (Update/Delete with neither BaseModifiedAt nor
+// BaseVersion) are dropped.
 func classifyEntries(entries map[EntryKey]Entry) (create, modified map[EntryKey]Entry) {
 	create = make(map[EntryKey]Entry)
 	modified = make(map[EntryKey]Entry)
@@ -109,7 +135,8 @@ func classifyEntries(entries map[EntryKey]Entry) (create, modified map[EntryKey]
 		switch {
 		case entry.Operation == OperationCreate:
 			create[key] = entry
-		case (entry.Operation == OperationUpdate || entry.Operation == OperationDelete) && entry.BaseModifiedAt != nil:
+		case (entry.Operation == OperationUpdate || entry.Operation == OperationDelete) &&
+			(entry.BaseModifiedAt != nil || entry.BaseVersion != ""):
 			modified[key] = entry
 		}
 	}
@@ -138,42 +165,54 @@ func addKeys[V any](dst map[EntryKey]struct{}, src map[EntryKey]V) {
 	}
 }
 
-// fetchLastModified fetches each key's remote last-modified time in parallel,
-// resolving the strategy for the key's own namespace so a namespaced provider
-// probes each entry against the right remote.
-func fetchLastModified(ctx context.Context, resolve ApplyStrategyResolver, keys map[EntryKey]struct{}) lastModifiedResults {
-	return parallel.ExecuteMap(ctx, keys, func(ctx context.Context, key EntryKey, _ struct{}) (time.Time, error) {
+// fetchRemoteStates fetches each key's remote state in parallel, resolving the
+// strategy for the key's own namespace so a namespaced provider probes each
+// entry against the right remote.
+func fetchRemoteStates(ctx context.Context, resolve ApplyStrategyResolver, keys map[EntryKey]struct{}) remoteStateResults {
+	return parallel.ExecuteMap(ctx, keys, func(ctx context.Context, key EntryKey, _ struct{}) (RemoteState, error) {
 		strategy, err := resolve(key.Namespace)
 		if err != nil {
-			return time.Time{}, err
+			return RemoteState{}, err
 		}
 
-		return strategy.FetchLastModified(ctx, key.Name)
+		return FetchRemoteState(ctx, strategy, key.Name)
 	})
 }
 
-// markModifiedAfterBase adds to conflicts every key whose remote was modified
-// strictly after its staged base time. A fetch error or a zero time (the remote
+// markModifiedSinceBase adds to conflicts every key whose remote moved past its
+// staged base. When both the base and the remote carry a version identifier the
+// two are compared for equality; otherwise the remote conflicts when it was
+// modified strictly after the base time. A fetch error (including a remote that
 // no longer exists) is skipped — the apply will fail on its own. base extracts
-// each item's staged base time.
+// each item's staged base.
 //
-// Strict After: on second-granular providers (e.g. Azure Key Vault) an
-// out-of-band write in the same wall-clock second compares as equal and escapes
-// detection. See docs/staging-state-transitions.md.
-func markModifiedAfterBase[V any](
+// Strict After: on second-granular providers without a version (see
+// docs/staging-state-transitions.md) an out-of-band write in the same
+// wall-clock second compares as equal and escapes detection. Comparing
+// versions closes that window.
+func markModifiedSinceBase[V any](
 	items map[EntryKey]V,
-	base func(V) time.Time,
-	results lastModifiedResults,
+	base func(V) stagedBase,
+	results remoteStateResults,
 	conflicts map[EntryKey]struct{},
 ) {
 	for key, item := range items {
 		result := results[key]
-		if result.Err != nil || result.Value.IsZero() {
+		if result.Err != nil {
 			continue
 		}
 
-		if result.Value.After(base(item)) {
-			conflicts[key] = struct{}{}
+		b, remote := base(item), result.Value
+
+		switch {
+		case b.Version != "" && remote.Version != "":
+			if remote.Version != b.Version {
+				conflicts[key] = struct{}{}
+			}
+		case b.At != nil && !remote.LastModified.IsZero():
+			if remote.LastModified.After(*b.At) {
+				conflicts[key] = struct{}{}
+			}
 		}
 	}
 }
@@ -514,3 +514,74 @@ func TestCheckConflicts_PerNamespace(t *testing.T) {
 
 	assert.Equal(t, map[string]string{"": "k", "dev": "k"}, probed)
 }
+
+// mockVersionedApplyStrategy also implements staging.VersionProber.
+type mockVersionedApplyStrategy struct {
+	mockApplyStrategy
+
+	state staging.RemoteState
+}
+
+func (m *mockVersionedApplyStrategy) FetchRemoteState(context.Context, string) (staging.RemoteState, error) {
+	return m.state, nil
+}
+
+func TestCheckConflicts_Version(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	sameSecond := baseTime.Add(500 * time.Millisecond)
+
+	tests := []struct {
+		name     string
+		remote   staging.RemoteState
+		entry    staging.Entry
+		conflict bool
+	}{
+		{
+			name:     "version changed within the base second",
+			remote:   staging.RemoteState{LastModified: baseTime, Version: "4"},
+			entry:    staging.Entry{Operation: staging.OperationUpdate, BaseModifiedAt: &baseTime, BaseVersion: "3"},
+			conflict: true,
+		},
+		{
+			name:   "same version with a later time",
+			remote: staging.RemoteState{LastModified: sameSecond.Add(time.Hour), Version: "3"},
+			entry:  staging.Entry{Operation: staging.OperationUpdate, BaseModifiedAt: &baseTime, BaseVersion: "3"},
+		},
+		{
+			name:     "version only",
+			remote:   staging.RemoteState{Version: "etag-2"},
+			entry:    staging.Entry{Operation: staging.OperationDelete, BaseVersion: "etag-1"},
+			conflict: true,
+		},
+		{
+			name:     "no staged version falls back to time",
+			remote:   staging.RemoteState{LastModified: baseTime.Add(time.Hour), Version: "4"},
+			entry:    staging.Entry{Operation: staging.OperationUpdate, BaseModifiedAt: &baseTime},
+			conflict: true,
+		},
+		{
+			name:     "create against a versioned remote",
+			remote:   staging.RemoteState{Version: "etag-1"},
+			entry:    staging.Entry{Operation: staging.OperationCreate},
+			conflict: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			strategy := &mockVersionedApplyStrategy{state: tt.remote}
+			key := staging.EntryKey{Name: "item"}
+
+			conflicts := staging.CheckConflicts(t.Context(), resolverFor(strategy), map[staging.EntryKey]staging.Entry{key: tt.entry})
+			if tt.conflict {
+				assert.Contains(t, conflicts, key)
+			} else {
+				assert.Empty(t, conflicts)
+			}
+		})
+	}
+}
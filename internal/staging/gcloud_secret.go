@@ -62,6 +62,10 @@ func (s *GoogleCloudSecretStrategy) HasDeleteOptions() bool {
 func (s *GoogleCloudSecretStrategy) Apply(ctx context.Context, name string, entry Entry) error {
 	switch entry.Operation {
 	case OperationCreate:
+		if restored, err := restoreDeleted(ctx, s.store, name, s.ItemName(), entry); restored || err != nil {
+			return err
+		}
+
 		return s.applyCreate(ctx, name, entry)
 	case OperationUpdate:
 		return s.applyUpdate(ctx, name, entry)
@@ -73,7 +77,9 @@ func (s *GoogleCloudSecretStrategy) Apply(ctx context.Context, name string, entr
 }
 
 func (s *GoogleCloudSecretStrategy) applyCreate(ctx context.Context, name string, entry Entry) error {
-	if _, err := s.store.Create(ctx, name, lo.FromPtr(entry.Value), domain.ValueTypeSecret, lo.FromPtr(entry.Description)); err != nil {
+	if _, err := s.store.Create(
+		ctx, name, lo.FromPtr(entry.Value), domain.ValueTypeSecret, lo.FromPtr(entry.Description), writeOptions(ctx)...,
+	); err != nil {
 		return fmt.Errorf("failed to create secret: %w", err)
 	}
 
@@ -86,7 +92,7 @@ func (s *GoogleCloudSecretStrategy) applyUpdate(ctx context.Context, name string
 	}
 
 	// Secret Manager versions are immutable: Put adds a new version.
-	if _, err := s.store.Put(ctx, name, *entry.Value, domain.ValueTypeSecret, lo.FromPtr(entry.Description)); err != nil {
+	if _, err := s.store.Put(ctx, name, *entry.Value, domain.ValueTypeSecret, lo.FromPtr(entry.Description), writeOptions(ctx)...); err != nil {
 		return fmt.Errorf("failed to update secret: %w", err)
 	}
 
@@ -144,6 +150,30 @@ func (s *GoogleCloudSecretStrategy) FetchLastModified(ctx context.Context, name
 	return time.Time{}, nil
 }
 
+// FetchBase returns the secret value a staged change was based on from Secret Manager's
+// version history.
+func (s *GoogleCloudSecretStrategy) FetchBase(ctx context.Context, name, version string, at time.Time) (string, error) {
+	return fetchValueAt(ctx, s.store, name, version, at)
+}
+
+// FetchRemoteState returns the secret's last modified time and current version
+// for version-based conflict detection.
+func (s *GoogleCloudSecretStrategy) FetchRemoteState(ctx context.Context, name string) (RemoteState, error) {
+	return fetchRemoteState(ctx, s.store, name, "secret")
+}
+
+// Snapshot captures the secret's current state in Secret Manager before an
+// atomic apply.
+func (s *GoogleCloudSecretStrategy) Snapshot(ctx context.Context, name string) (Snapshot, error) {
+	return snapshotEntry(ctx, s.store, name, itemNameSecret)
+}
+
+// Rollback returns the secret in Secret Manager to a snapshot taken before an
+// atomic apply.
+func (s *GoogleCloudSecretStrategy) Rollback(ctx context.Context, name string, snap Snapshot) error {
+	return rollbackEntry(ctx, s.store, name, itemNameSecret, snap)
+}
+
 // FetchCurrent fetches the current value from Secret Manager for diffing.
 func (s *GoogleCloudSecretStrategy) FetchCurrent(ctx context.Context, name string) (*FetchResult, error) {
 	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
@@ -208,7 +238,8 @@ func (s *GoogleCloudSecretStrategy) FetchCurrentValue(ctx context.Context, name
 	}
 
 	result := &EditFetchResult{
-		Value: entry.Value,
+		Value:   entry.Value,
+		Version: entry.Version.ID,
 	}
 
 	if entry.Modified != nil {
@@ -0,0 +1,237 @@
+package staging
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/maputil"
+)
+
+// ApplyRecord is one apply in the apply journal: what it changed, and the state
+// each changed entry was in beforehand, so the apply can be reviewed later
+// (stage history) and undone (stage undo).
+type ApplyRecord struct {
+	// ID identifies the apply; the same ID marks the records one apply wrote
+	// across the scopes of a provider's services.
+	ID string `json:"id"`
+	// AppliedAt is when the apply finished writing.
+	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
+	AppliedAt time.Time `json:"applied_at"`
+	// User identifies who applied, as user@host.
+	User string `json:"user,omitempty"`
+	// Changeset is the changeset the changes were applied from.
+	Changeset string `json:"changeset,omitempty"`
+	// Message is the change message the apply was run with (`stage apply -m`).
+	Message string `json:"message,omitempty"`
+	// Items lists the changes that were applied, sorted by service then key.
+	Items []AppliedItem `json:"items"`
+}
+
+// AppliedItem is one applied change of an ApplyRecord: an entry operation, a
+// tag change, or both for the same entry.
+type AppliedItem struct {
+	Service   Service `json:"service"`
+	Name      string  `json:"name"`
+	Namespace string  `json:"namespace,omitempty"`
+	// Operation is the applied entry operation; empty when only tags changed.
+	Operation Operation `json:"operation,omitempty"`
+	// Previous is the entry's state before the apply.
+	Previous Snapshot `json:"previous"`
+	// NewVersion is the entry's version identifier after the apply; empty when
+	// it was deleted or the provider is unversioned.
+	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
+	NewVersion string `json:"new_version,omitempty"`
+	// TagsAdded holds the tags the apply set.
+	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
+	TagsAdded map[string]string `json:"tags_added,omitempty"`
+	// TagsRemoved holds the tag keys the apply removed.
+	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
+	TagsRemoved []string `json:"tags_removed,omitempty"`
+}
+
+// Key returns the item's entry key.
+func (i AppliedItem) Key() EntryKey {
+	return EntryKey{Name: i.Name, Namespace: i.Namespace}
+}
+
+// RecordEntry adds an applied entry operation on key, whose state beforehand
+// was previous, to the record.
+func (r *ApplyRecord) RecordEntry(service Service, key EntryKey, op Operation, previous Snapshot) {
+	item := r.item(service, key, previous)
+	item.Operation = op
+}
+
+// RecordTags adds an applied tag change on key, whose state beforehand was
+// previous, to the record.
+func (r *ApplyRecord) RecordTags(service Service, key EntryKey, tagEntry TagEntry, previous Snapshot) {
+	item := r.item(service, key, previous)
+	if len(tagEntry.Add) > 0 {
+		item.TagsAdded = maps.Clone(tagEntry.Add)
+	}
+
+	if tagEntry.Remove.Len() > 0 {
+		item.TagsRemoved = tagEntry.Remove.Values()
+		slices.Sort(item.TagsRemoved)
+	}
+}
+
+// item returns the record's item for key, adding it in (service, name,
+// namespace) order when missing, so an entry operation and a tag change of
+// the same key share one item.
+func (r *ApplyRecord) item(service Service, key EntryKey, previous Snapshot) *AppliedItem {
+	cmp := func(item AppliedItem, target AppliedItem) int {
+		if c := strings.Compare(string(item.Service), string(target.Service)); c != 0 {
+			return c
+		}
+
+		if c := strings.Compare(item.Name, target.Name); c != 0 {
+			return c
+		}
+
+		return strings.Compare(item.Namespace, target.Namespace)
+	}
+
+	target := AppliedItem{Service: service, Name: key.Name, Namespace: key.Namespace, Previous: previous}
+
+	i, found := slices.BinarySearchFunc(r.Items, target, cmp)
+	if !found {
+		r.Items = slices.Insert(r.Items, i, target)
+	}
+
+	return &r.Items[i]
+}
+
+// MergeApplyRecords merges per-scope journal listings into one, newest first:
+// the records one apply wrote to several scopes (same ID) become one record
+// holding all their items. The records of the first listing are reused.
+func MergeApplyRecords(listings ...[]*ApplyRecord) []*ApplyRecord {
+	var records []*ApplyRecord
+
+	index := map[string]int{}
+
+	for _, listing := range listings {
+		for _, rec := range listing {
+			if i, ok := index[rec.ID]; ok {
+				records[i].Items = append(records[i].Items, rec.Items...)
+
+				continue
+			}
+
+			index[rec.ID] = len(records)
+			records = append(records, rec)
+		}
+	}
+
+	// Apply IDs are timestamps, so newest first is descending ID order.
+	slices.SortFunc(records, func(a, b *ApplyRecord) int { return strings.Compare(b.ID, a.ID) })
+
+	return records
+}
+
+// InverseState returns the staged changes that undo the record:
+//   - a created entry is deleted, based on the version the apply wrote;
+//   - an updated entry gets its previous value back;
+//   - a deleted entry is re-created (restored where the provider can, see
+//     Entry.Restore) with its previous value and tags;
+//   - a tag change sets the previous values of the tags it touched back and
+//     removes the ones it added.
+//
+// The staged changes are based on the state the apply left behind, so a change
+// made remotely since then surfaces as a conflict when they are applied.
+func (r *ApplyRecord) InverseState(now time.Time) *State {
+	state := NewEmptyState()
+
+	for _, item := range r.Items {
+		if entry, ok := item.inverseEntry(now, r.AppliedAt); ok {
+			if state.Entries[item.Service] == nil {
+				state.Entries[item.Service] = make(map[EntryKey]Entry)
+			}
+
+			state.Entries[item.Service][item.Key()] = entry
+		}
+
+		if tag, ok := item.inverseTags(now); ok {
+			if state.Tags[item.Service] == nil {
+				state.Tags[item.Service] = make(map[EntryKey]TagEntry)
+			}
+
+			state.Tags[item.Service][item.Key()] = tag
+		}
+	}
+
+	return state
+}
+
+// inverseEntry returns the staged entry undoing the item's operation.
+func (i AppliedItem) inverseEntry(now, appliedAt time.Time) (Entry, bool) {
+	switch {
+	case i.Operation == OperationCreate:
+		return Entry{
+			Operation:      OperationDelete,
+			StagedAt:       now,
+			BaseModifiedAt: &appliedAt,
+			BaseVersion:    i.NewVersion,
+		}, true
+	case i.Operation == OperationUpdate && i.Previous.Exists:
+		return Entry{
+			Operation:      OperationUpdate,
+			Value:          lo.ToPtr(i.Previous.Value),
+			Description:    lo.EmptyableToPtr(i.Previous.Description),
+			ValueType:      i.Previous.ValueType,
+			StagedAt:       now,
+			BaseModifiedAt: &appliedAt,
+			BaseVersion:    i.NewVersion,
+		}, true
+	case i.Operation == OperationDelete && i.Previous.Exists:
+		return Entry{
+			Operation:   OperationCreate,
+			Value:       lo.ToPtr(i.Previous.Value),
+			Description: lo.EmptyableToPtr(i.Previous.Description),
+			ValueType:   i.Previous.ValueType,
+			StagedAt:    now,
+			Restore:     true,
+		}, true
+	default:
+		return Entry{}, false
+	}
+}
+
+// inverseTags returns the staged tag change undoing the item's tag changes. A
+// created entry needs none (it is deleted); a deleted one gets its previous
+// tags back whole.
+func (i AppliedItem) inverseTags(now time.Time) (TagEntry, bool) {
+	tag := TagEntry{Add: make(map[string]string), Remove: maputil.NewSet[string](), StagedAt: now}
+
+	switch i.Operation {
+	case OperationCreate:
+		return TagEntry{}, false
+	case OperationDelete:
+		for k, v := range i.Previous.Tags {
+			tag.Add[k] = v
+		}
+	case OperationUpdate, "":
+		for k := range i.TagsAdded {
+			if v, ok := i.Previous.Tags[k]; ok {
+				tag.Add[k] = v
+			} else {
+				tag.Remove.Add(k)
+			}
+		}
+
+		for _, k := range i.TagsRemoved {
+			if v, ok := i.Previous.Tags[k]; ok {
+				tag.Add[k] = v
+			}
+		}
+	}
+
+	if len(tag.Add) == 0 && tag.Remove.Len() == 0 {
+		return TagEntry{}, false
+	}
+
+	return tag, true
+}
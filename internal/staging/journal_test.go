@@ -0,0 +1,111 @@
+package staging_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/maputil"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+func TestApplyRecord_Record(t *testing.T) {
+	t.Parallel()
+
+	prev := staging.Snapshot{Exists: true, Value: "old"}
+
+	var rec staging.ApplyRecord
+	rec.RecordEntry(staging.ServiceSecret, staging.EntryKey{Name: "b"}, staging.OperationUpdate, prev)
+	rec.RecordEntry(staging.ServiceParam, staging.EntryKey{Name: "z"}, staging.OperationCreate, staging.Snapshot{})
+	rec.RecordTags(staging.ServiceSecret, staging.EntryKey{Name: "b"}, staging.TagEntry{
+		Add:    map[string]string{"env": "prod"},
+		Remove: maputil.NewSet("team", "owner"),
+	}, prev)
+
+	require.Len(t, rec.Items, 2, "an entry operation and a tag change of one key share an item")
+	assert.Equal(t, staging.ServiceParam, rec.Items[0].Service, "sorted by service then key")
+
+	item := rec.Items[1]
+	assert.Equal(t, staging.OperationUpdate, item.Operation)
+	assert.Equal(t, map[string]string{"env": "prod"}, item.TagsAdded)
+	assert.Equal(t, []string{"owner", "team"}, item.TagsRemoved)
+}
+
+func TestApplyRecord_InverseState(t *testing.T) {
+	t.Parallel()
+
+	appliedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := appliedAt.Add(time.Hour)
+
+	rec := &staging.ApplyRecord{
+		ID:        "00000000000000000001",
+		AppliedAt: appliedAt,
+		Items: []staging.AppliedItem{
+			{
+				Service: staging.ServiceParam, Name: "/app/created",
+				Operation: staging.OperationCreate, NewVersion: "1",
+			},
+			{
+				Service: staging.ServiceParam, Name: "/app/deleted", Operation: staging.OperationDelete,
+				Previous: staging.Snapshot{Exists: true, Value: "gone", Tags: map[string]string{"env": "prod"}},
+			},
+			{
+				Service: staging.ServiceParam, Name: "/app/tagged",
+				Previous:  staging.Snapshot{Exists: true, Tags: map[string]string{"env": "dev", "team": "a"}},
+				TagsAdded: map[string]string{"env": "prod", "new": "x"}, TagsRemoved: []string{"team"},
+			},
+			{
+				Service: staging.ServiceParam, Name: "/app/updated", Operation: staging.OperationUpdate,
+				Previous:   staging.Snapshot{Exists: true, Value: "v1", Description: "desc", Version: "3"},
+				NewVersion: "4",
+			},
+		},
+	}
+
+	state := rec.InverseState(now)
+	entries := state.Entries[staging.ServiceParam]
+	tags := state.Tags[staging.ServiceParam]
+
+	created := entries[staging.EntryKey{Name: "/app/created"}]
+	assert.Equal(t, staging.OperationDelete, created.Operation)
+	assert.Equal(t, "1", created.BaseVersion, "based on the version the apply wrote")
+	assert.Equal(t, appliedAt, lo.FromPtr(created.BaseModifiedAt))
+	assert.NotContains(t, tags, staging.EntryKey{Name: "/app/created"})
+
+	deleted := entries[staging.EntryKey{Name: "/app/deleted"}]
+	assert.Equal(t, staging.OperationCreate, deleted.Operation)
+	assert.Equal(t, "gone", lo.FromPtr(deleted.Value))
+	assert.True(t, deleted.Restore)
+	assert.Equal(t, map[string]string{"env": "prod"}, tags[staging.EntryKey{Name: "/app/deleted"}].Add)
+
+	updated := entries[staging.EntryKey{Name: "/app/updated"}]
+	assert.Equal(t, staging.OperationUpdate, updated.Operation)
+	assert.Equal(t, "v1", lo.FromPtr(updated.Value))
+	assert.Equal(t, "desc", lo.FromPtr(updated.Description))
+	assert.Equal(t, "4", updated.BaseVersion)
+	assert.Equal(t, now, updated.StagedAt)
+
+	assert.NotContains(t, entries, staging.EntryKey{Name: "/app/tagged"}, "a tag-only change stages no entry")
+
+	tagged := tags[staging.EntryKey{Name: "/app/tagged"}]
+	assert.Equal(t, map[string]string{"env": "dev", "team": "a"}, tagged.Add)
+	assert.Equal(t, []string{"new"}, tagged.Remove.Values())
+}
+
+func TestMergeApplyRecords(t *testing.T) {
+	t.Parallel()
+
+	item := func(name string) staging.AppliedItem { return staging.AppliedItem{Name: name} }
+
+	merged := staging.MergeApplyRecords(
+		[]*staging.ApplyRecord{{ID: "2", Items: []staging.AppliedItem{item("a")}}, {ID: "1"}},
+		[]*staging.ApplyRecord{{ID: "3"}, {ID: "2", Items: []staging.AppliedItem{item("b")}}},
+	)
+
+	require.Len(t, merged, 3)
+	assert.Equal(t, []string{"3", "2", "1"}, []string{merged[0].ID, merged[1].ID, merged[2].ID})
+	assert.Equal(t, []staging.AppliedItem{item("a"), item("b")}, merged[1].Items)
+}
@@ -0,0 +1,256 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/version/kubernetesversion"
+)
+
+// KubernetesStrategy implements the staging strategies for Kubernetes ConfigMap
+// (param) and Secret (secret) keys, both backed by a provider.Store. Keys are
+// UNVERSIONED, so:
+//
+//   - Version specifiers are rejected at parse time via kubernetesversion, and
+//     FetchVersion only ever resolves the current value.
+//   - Conflict detection uses the object's last managed-fields write: a key
+//     edited after staging is reported when any key of its object (or its
+//     labels) changed since, which is the granularity Kubernetes tracks.
+//   - There is no description, so staged descriptions are never written.
+//   - Tags are the object's labels, shared by every key of the object.
+//
+// A nil store yields a parser-only strategy (ParseName/ParseSpec).
+type KubernetesStrategy struct {
+	store   provider.Store
+	service Service
+}
+
+// NewKubernetesParamStrategy creates a ConfigMap staging strategy over the
+// given provider store. A nil store is allowed for parser-only use.
+func NewKubernetesParamStrategy(store provider.Store) *KubernetesStrategy {
+	return &KubernetesStrategy{store: store, service: ServiceParam}
+}
+
+// NewKubernetesSecretStrategy creates a Secret staging strategy over the given
+// provider store. A nil store is allowed for parser-only use.
+func NewKubernetesSecretStrategy(store provider.Store) *KubernetesStrategy {
+	return &KubernetesStrategy{store: store, service: ServiceSecret}
+}
+
+// Service returns the service type.
+func (s *KubernetesStrategy) Service() Service { return s.service }
+
+// ServiceName returns the user-friendly service name.
+func (s *KubernetesStrategy) ServiceName() string {
+	if s.service == ServiceSecret {
+		return "Kubernetes Secret"
+	}
+
+	return "Kubernetes ConfigMap"
+}
+
+// ItemName returns the item name for messages.
+func (s *KubernetesStrategy) ItemName() string { return "key" }
+
+// HasDeleteOptions returns false: Kubernetes deletes have no options.
+func (s *KubernetesStrategy) HasDeleteOptions() bool { return false }
+
+// Apply applies a staged operation to the cluster.
+func (s *KubernetesStrategy) Apply(ctx context.Context, name string, entry Entry) error {
+	switch entry.Operation {
+	case OperationCreate:
+		if restored, err := restoreDeleted(ctx, s.store, name, s.ItemName(), entry); restored || err != nil {
+			return err
+		}
+
+		if _, err := s.store.Create(ctx, name, lo.FromPtr(entry.Value), s.valueType(), "", writeOptions(ctx)...); err != nil {
+			return fmt.Errorf("failed to create key: %w", err)
+		}
+
+		return nil
+	case OperationUpdate:
+		if entry.Value == nil {
+			return nil
+		}
+
+		if _, err := s.store.Put(ctx, name, *entry.Value, s.valueType(), "", writeOptions(ctx)...); err != nil {
+			return fmt.Errorf("failed to update key: %w", err)
+		}
+
+		return nil
+	case OperationDelete:
+		if err := s.store.Delete(ctx, name); err != nil {
+			// Already deleted is considered success.
+			if errors.Is(err, provider.ErrNotFound) {
+				return nil
+			}
+
+			return fmt.Errorf("failed to delete key: %w", err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown operation: %s", entry.Operation)
+	}
+}
+
+// ApplyTags applies staged tag (label) changes to the key's object. Additions
+// are applied before removals.
+func (s *KubernetesStrategy) ApplyTags(ctx context.Context, name string, tagEntry TagEntry) error {
+	if len(tagEntry.Add) > 0 {
+		if err := s.store.Tag(ctx, name, tagEntry.Add); err != nil {
+			return fmt.Errorf("failed to add labels: %w", err)
+		}
+	}
+
+	if tagEntry.Remove.Len() > 0 {
+		if err := s.store.Untag(ctx, name, tagEntry.Remove.Values()); err != nil {
+			return fmt.Errorf("failed to remove labels: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// FetchLastModified returns the object's last managed-fields write. It returns
+// a *ResourceNotFoundError when the key does not exist, and a zero time with a
+// nil error when the server reports no managed fields.
+func (s *KubernetesStrategy) FetchLastModified(ctx context.Context, name string) (time.Time, error) {
+	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return time.Time{}, &ResourceNotFoundError{Err: err}
+		}
+
+		return time.Time{}, fmt.Errorf("failed to get key: %w", err)
+	}
+
+	return lo.FromPtr(entry.Modified), nil
+}
+
+// Snapshot captures the key's current state in the cluster before an atomic
+// apply.
+func (s *KubernetesStrategy) Snapshot(ctx context.Context, name string) (Snapshot, error) {
+	return snapshotEntry(ctx, s.store, name, s.ItemName())
+}
+
+// Rollback returns the key in the cluster to a snapshot taken before an atomic
+// apply. Labels live on the key's object, so they are restored for every key it
+// holds.
+func (s *KubernetesStrategy) Rollback(ctx context.Context, name string, snap Snapshot) error {
+	return rollbackEntry(ctx, s.store, name, s.ItemName(), snap)
+}
+
+// FetchCurrent fetches the current value for diffing. Keys are unversioned, so
+// the identifier is empty.
+func (s *KubernetesStrategy) FetchCurrent(ctx context.Context, name string) (*FetchResult, error) {
+	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FetchResult{Value: entry.Value, Secret: s.service == ServiceSecret}, nil
+}
+
+// FetchCurrentTags fetches the current labels of the key's object. A missing
+// key or an object with no labels yields nil.
+func (s *KubernetesStrategy) FetchCurrentTags(ctx context.Context, name string) (map[string]string, error) {
+	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return nil, nil //nolint:nilnil // intentional: no tags for a non-existent key
+		}
+
+		return nil, fmt.Errorf("failed to get key: %w", err)
+	}
+
+	if len(entry.Tags) == 0 {
+		return nil, nil //nolint:nilnil // intentional: object exists but has no labels
+	}
+
+	tags := make(map[string]string, len(entry.Tags))
+	for _, tag := range entry.Tags {
+		tags[tag.Key] = tag.Value
+	}
+
+	return tags, nil
+}
+
+// ParseName parses and validates an "<object>/<key>" name.
+func (s *KubernetesStrategy) ParseName(input string) (string, error) {
+	spec, err := kubernetesversion.Parse(input)
+	if err != nil {
+		return "", err
+	}
+
+	return spec.Name, nil
+}
+
+// FetchCurrentValue fetches the current value for editing, with the object's
+// last managed-fields write as the conflict base.
+// Returns *ResourceNotFoundError if the key doesn't exist.
+func (s *KubernetesStrategy) FetchCurrentValue(ctx context.Context, name string) (*EditFetchResult, error) {
+	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return nil, &ResourceNotFoundError{Err: err}
+		}
+
+		return nil, err
+	}
+
+	return &EditFetchResult{Value: entry.Value, LastModified: lo.FromPtr(entry.Modified)}, nil
+}
+
+// ParseSpec parses a name for reset. Keys are unversioned, so a version is
+// never present.
+func (s *KubernetesStrategy) ParseSpec(input string) (name string, hasVersion bool, err error) {
+	spec, err := kubernetesversion.Parse(input)
+	if err != nil {
+		return "", false, err
+	}
+
+	return spec.Name, false, nil
+}
+
+// FetchVersion fetches the current value. Keys are unversioned, so this only
+// ever resolves the current value.
+func (s *KubernetesStrategy) FetchVersion(ctx context.Context, input string) (value string, versionLabel string, err error) {
+	spec, err := kubernetesversion.Parse(input)
+	if err != nil {
+		return "", "", err
+	}
+
+	entry, err := s.store.Get(ctx, spec.Name, provider.VersionRef{})
+	if err != nil {
+		return "", "", err
+	}
+
+	return entry.Value, "current", nil
+}
+
+// valueType is the value type writes carry: plaintext for ConfigMaps, secret
+// for Secrets.
+func (s *KubernetesStrategy) valueType() domain.ValueType {
+	if s.service == ServiceSecret {
+		return domain.ValueTypeSecret
+	}
+
+	return domain.ValueTypePlaintext
+}
+
+// KubernetesParamParserFactory yields a parser-only ConfigMap strategy.
+func KubernetesParamParserFactory() Parser {
+	return NewKubernetesParamStrategy(nil)
+}
+
+// KubernetesSecretParserFactory yields a parser-only Secret strategy.
+func KubernetesSecretParserFactory() Parser {
+	return NewKubernetesSecretStrategy(nil)
+}
@@ -0,0 +1,207 @@
+package staging_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/maputil"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/version/kubernetesversion"
+)
+
+func TestKubernetesStrategy_BasicMethods(t *testing.T) {
+	t.Parallel()
+
+	param := staging.NewKubernetesParamStrategy(nil)
+	assert.Equal(t, staging.ServiceParam, param.Service())
+	assert.Equal(t, "Kubernetes ConfigMap", param.ServiceName())
+	assert.Equal(t, "key", param.ItemName())
+	assert.False(t, param.HasDeleteOptions())
+
+	secret := staging.NewKubernetesSecretStrategy(nil)
+	assert.Equal(t, staging.ServiceSecret, secret.Service())
+	assert.Equal(t, "Kubernetes Secret", secret.ServiceName())
+}
+
+func TestKubernetesStrategy_Apply(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create writes with the service's value type and no description", func(t *testing.T) {
+		t.Parallel()
+
+		store := &providermock.Store{
+			CreateFunc: func(
+				_ context.Context, name, value string, valueType domain.ValueType, desc string, _ ...provider.WriteOption,
+			) (domain.Version, error) {
+				assert.Equal(t, "db/password", name)
+				assert.Equal(t, "v1", value)
+				assert.Equal(t, domain.ValueTypeSecret, valueType)
+				assert.Empty(t, desc)
+
+				return domain.Version{ID: "1"}, nil
+			},
+		}
+
+		err := staging.NewKubernetesSecretStrategy(store).Apply(t.Context(), "db/password", staging.Entry{
+			Operation:   staging.OperationCreate,
+			Value:       lo.ToPtr("v1"),
+			Description: lo.ToPtr("ignored"),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("update puts a plaintext ConfigMap value", func(t *testing.T) {
+		t.Parallel()
+
+		var putCalled bool
+
+		store := &providermock.Store{
+			PutFunc: func(
+				_ context.Context, _, value string, valueType domain.ValueType, _ string, _ ...provider.WriteOption,
+			) (domain.Version, error) {
+				putCalled = true
+
+				assert.Equal(t, "v2", value)
+				assert.Equal(t, domain.ValueTypePlaintext, valueType)
+
+				return domain.Version{ID: "2"}, nil
+			},
+		}
+
+		err := staging.NewKubernetesParamStrategy(store).Apply(t.Context(), "app/A",
+			staging.Entry{Operation: staging.OperationUpdate, Value: lo.ToPtr("v2")})
+		require.NoError(t, err)
+		assert.True(t, putCalled)
+	})
+
+	t.Run("delete of a missing key is success", func(t *testing.T) {
+		t.Parallel()
+
+		store := &providermock.Store{
+			DeleteFunc: func(context.Context, string, ...provider.DeleteOption) error {
+				return provider.ErrNotFound
+			},
+		}
+
+		err := staging.NewKubernetesParamStrategy(store).Apply(t.Context(), "app/A", staging.Entry{Operation: staging.OperationDelete})
+		require.NoError(t, err)
+	})
+}
+
+func TestKubernetesStrategy_ApplyTags(t *testing.T) {
+	t.Parallel()
+
+	var added map[string]string
+
+	var removed []string
+
+	store := &providermock.Store{
+		TagFunc: func(_ context.Context, _ string, add map[string]string) error {
+			added = add
+
+			return nil
+		},
+		UntagFunc: func(_ context.Context, _ string, keys []string) error {
+			removed = keys
+
+			return nil
+		},
+	}
+
+	err := staging.NewKubernetesParamStrategy(store).ApplyTags(t.Context(), "app/A", staging.TagEntry{
+		Add:    map[string]string{"env": "prod"},
+		Remove: maputil.NewSet("team"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod"}, added)
+	assert.Equal(t, []string{"team"}, removed)
+}
+
+func TestKubernetesStrategy_FetchLastModified(t *testing.T) {
+	t.Parallel()
+
+	modified := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	store := &providermock.Store{
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			if name == "app/missing" {
+				return nil, provider.ErrNotFound
+			}
+
+			return &domain.Entry{Name: name, Value: "v", Modified: &modified}, nil
+		},
+	}
+	s := staging.NewKubernetesSecretStrategy(store)
+
+	got, err := s.FetchLastModified(t.Context(), "app/A")
+	require.NoError(t, err)
+	assert.Equal(t, modified, got)
+
+	_, err = s.FetchLastModified(t.Context(), "app/missing")
+
+	var notFound *staging.ResourceNotFoundError
+	require.ErrorAs(t, err, &notFound)
+
+	edit, err := s.FetchCurrentValue(t.Context(), "app/A")
+	require.NoError(t, err)
+	assert.Equal(t, modified, edit.LastModified)
+
+	current, err := s.FetchCurrent(t.Context(), "app/A")
+	require.NoError(t, err)
+	assert.True(t, current.Secret)
+	assert.Empty(t, current.Identifier)
+}
+
+func TestKubernetesStrategy_Parse(t *testing.T) {
+	t.Parallel()
+
+	s := staging.KubernetesParamParserFactory()
+
+	name, err := s.ParseName("app/LOG_LEVEL")
+	require.NoError(t, err)
+	assert.Equal(t, "app/LOG_LEVEL", name)
+
+	_, err = s.ParseName("app/LOG_LEVEL#2")
+	require.ErrorIs(t, err, kubernetesversion.ErrVersioningUnsupported)
+
+	_, err = s.ParseName("app")
+	require.ErrorIs(t, err, kubernetesversion.ErrInvalidName)
+
+	name, hasVersion, err := staging.KubernetesSecretParserFactory().ParseSpec("db/password")
+	require.NoError(t, err)
+	assert.Equal(t, "db/password", name)
+	assert.False(t, hasVersion)
+}
+
+func TestKubernetesStrategy_FetchVersion(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+	store := &providermock.Store{
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			if name == "app/B" {
+				return nil, boom
+			}
+
+			return &domain.Entry{Name: name, Value: "v"}, nil
+		},
+	}
+	s := staging.NewKubernetesParamStrategy(store)
+
+	value, label, err := s.FetchVersion(t.Context(), "app/A")
+	require.NoError(t, err)
+	assert.Equal(t, "v", value)
+	assert.Equal(t, "current", label)
+
+	_, _, err = s.FetchVersion(t.Context(), "app/B")
+	require.ErrorIs(t, err, boom)
+}
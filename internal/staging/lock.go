@@ -0,0 +1,87 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mpyw/suve/internal/parallel"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// DetectLocked returns the staged keys whose remote entry is locked read-only,
+// for services whose strategy implements Locker (App Configuration). Each key
+// is probed once even when it has both a value and a tag change, through the
+// strategy resolved for its own namespace. The probe is best-effort: a key
+// whose lock state cannot be read is left to the write itself, which then
+// fails with the provider's own error.
+func DetectLocked(
+	ctx context.Context, resolve ApplyStrategyResolver, entries map[EntryKey]Entry, tags map[EntryKey]TagEntry,
+) map[EntryKey]struct{} {
+	keys := make(map[EntryKey]EntryKey, len(entries)+len(tags))
+	for key := range entries {
+		keys[key] = key
+	}
+
+	for key := range tags {
+		keys[key] = key
+	}
+
+	results := parallel.ExecuteMap(ctx, keys, func(ctx context.Context, _ EntryKey, key EntryKey) (bool, error) {
+		strategy, err := resolve(key.Namespace)
+		if err != nil {
+			return false, err
+		}
+
+		locker, ok := strategy.(Locker)
+		if !ok {
+			return false, nil
+		}
+
+		return locker.FetchLocked(ctx, key.Name)
+	})
+
+	locked := make(map[EntryKey]struct{})
+
+	for key, result := range results {
+		if result.Err == nil && result.Value {
+			locked[key] = struct{}{}
+		}
+	}
+
+	return locked
+}
+
+// LockedError is the failure recorded for a locked entry that was not applied.
+func LockedError(key EntryKey) error {
+	return fmt.Errorf("%w: %s (re-run with --unlock-locked to unlock, write and relock)", provider.ErrLocked, key.Label())
+}
+
+// WithUnlock runs write between an Unlock and a Lock of name, so a locked entry
+// can be written and is left locked again. The relock runs even when the write
+// fails; a relock failure is reported alongside (or instead of) the write
+// error, since an entry left unlocked must never go unnoticed. relock=false
+// skips it (a delete leaves nothing to lock). A strategy that is not a Locker
+// just runs write.
+func WithUnlock(ctx context.Context, strategy ApplyStrategy, name string, relock bool, write func() error) error {
+	locker, ok := strategy.(Locker)
+	if !ok {
+		return write()
+	}
+
+	if err := locker.Unlock(ctx, name); err != nil {
+		return fmt.Errorf("failed to unlock %s: %w", name, err)
+	}
+
+	writeErr := write()
+
+	if !relock && writeErr == nil {
+		return nil
+	}
+
+	if err := locker.Lock(ctx, name); err != nil {
+		return errors.Join(writeErr, fmt.Errorf("failed to relock %s (it is left unlocked): %w", name, err))
+	}
+
+	return writeErr
+}
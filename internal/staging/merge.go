@@ -0,0 +1,463 @@
+package staging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/mpyw/suve/internal/jsonutil"
+)
+
+// Conflict marker lines written around an unresolved region, git-style.
+const (
+	conflictMarkerStaged = "<<<<<<<"
+	conflictMarkerSplit  = "======="
+	conflictMarkerRemote = ">>>>>>>"
+)
+
+// MergeLabels names the two sides in conflict markers.
+type MergeLabels struct {
+	Staged string
+	Remote string
+}
+
+// MergeResult is the outcome of a three-way merge.
+type MergeResult struct {
+	// Value is the merged value. When Conflicts is non-zero it carries conflict
+	// markers around every region both sides changed differently.
+	Value string
+	// Conflicts counts the unresolved regions.
+	Conflicts int
+}
+
+// MergeValues merges the staged and remote values, both derived from base.
+// Values that are JSON objects on all three sides are merged key by key
+// (recursing into nested objects); anything else — and JSON whose keys
+// conflict — is merged line by line. An empty base merges the two sides
+// against nothing, so every differing region is a conflict.
+func MergeValues(base, staged, remote string, labels MergeLabels) MergeResult {
+	if staged == remote {
+		return MergeResult{Value: staged}
+	}
+
+	if merged, ok := mergeJSON(base, staged, remote); ok {
+		return MergeResult{Value: merged}
+	}
+
+	// Line-merge the canonical form of JSON so a key conflict surfaces as
+	// markers around the conflicting key's lines only.
+	if b, s, r, ok := formatJSON3(base, staged, remote); ok {
+		return mergeLines(b, s, r, labels)
+	}
+
+	return mergeLines(base, staged, remote, labels)
+}
+
+// HasConflictMarkers reports whether value still carries a conflict marker
+// written by MergeValues.
+func HasConflictMarkers(value string) bool {
+	for line := range strings.Lines(value) {
+		if strings.HasPrefix(line, conflictMarkerStaged+" ") || strings.HasPrefix(line, conflictMarkerRemote+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mergeJSON merges three JSON objects key by key. It reports false when any
+// side is not a JSON object or a key changed differently on both sides. The
+// merged object keeps the staged side's key order, so a merge shows up as the
+// members it changed rather than a whole-object rewrite.
+func mergeJSON(base, staged, remote string) (string, bool) {
+	b, okB := decodeJSONObject(base)
+	s, okS := decodeJSONObject(staged)
+	r, okR := decodeJSONObject(remote)
+
+	if !okB || !okS || !okR {
+		return "", false
+	}
+
+	merged, ok := mergeObjects(b, s, r)
+	if !ok {
+		return "", false
+	}
+
+	// Keep the staged layout: indented if the staged value spans lines.
+	indent := ""
+	if strings.Contains(strings.TrimSpace(staged), "\n") {
+		indent = "  "
+	}
+
+	buf, err := encodeJSON(merged, indent)
+	if err != nil {
+		return "", false
+	}
+
+	return string(buf), true
+}
+
+// mergeObjects merges the members of three objects. Members come in the
+// staged side's order, followed by those only the remote added, in its order.
+func mergeObjects(base, staged, remote *jsonObject) (*jsonObject, bool) {
+	merged := &jsonObject{values: make(map[string]any, len(staged.values)+len(remote.values))}
+
+	keys := slices.Clone(staged.keys)
+	for _, k := range slices.Concat(remote.keys, base.keys) {
+		if !slices.Contains(keys, k) {
+			keys = append(keys, k)
+		}
+	}
+
+	for _, k := range keys {
+		bv, inB := base.values[k]
+		sv, inS := staged.values[k]
+		rv, inR := remote.values[k]
+
+		bo, okB := bv.(*jsonObject)
+		so, okS := sv.(*jsonObject)
+		ro, okR := rv.(*jsonObject)
+
+		switch {
+		case inS == inR && jsonEqual(sv, rv):
+			// Both sides agree (including both removed).
+		case okB && okS && okR:
+			// Nested objects merge member by member, keeping the staged order.
+			nested, ok := mergeObjects(bo, so, ro)
+			if !ok {
+				return nil, false
+			}
+
+			sv = nested
+		case inS == inB && jsonEqual(sv, bv):
+			// Only the remote changed it.
+			sv, inS = rv, inR
+		case inR == inB && jsonEqual(rv, bv):
+			// Only the staged side changed it.
+		default:
+			return nil, false
+		}
+
+		if inS {
+			merged.keys = append(merged.keys, k)
+			merged.values[k] = sv
+		}
+	}
+
+	return merged, true
+}
+
+// jsonObject is a decoded JSON object that remembers the order of its keys.
+// Values are strings, json.Numbers, bools, nil, []any and *jsonObject.
+type jsonObject struct {
+	keys   []string
+	values map[string]any
+}
+
+// MarshalJSON encodes the members in key order.
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := encodeJSON(k, "")
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := encodeJSON(o.values[k], "")
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// encodeJSON encodes v without escaping HTML characters, indented by indent
+// when it is non-empty.
+func encodeJSON(v any, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// jsonEqual reports whether two decoded JSON values are equal, ignoring the
+// key order of objects.
+func jsonEqual(a, b any) bool {
+	switch a := a.(type) {
+	case *jsonObject:
+		b, ok := b.(*jsonObject)
+		if !ok || len(a.values) != len(b.values) {
+			return false
+		}
+
+		for k, v := range a.values {
+			if w, ok := b.values[k]; !ok || !jsonEqual(v, w) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		b, ok := b.([]any)
+
+		return ok && slices.EqualFunc(a, b, jsonEqual)
+	default:
+		return a == b
+	}
+}
+
+// decodeJSONObject decodes a single JSON object, keeping its key order and
+// numbers verbatim.
+func decodeJSONObject(value string) (*jsonObject, bool) {
+	dec := json.NewDecoder(strings.NewReader(value))
+	dec.UseNumber()
+
+	v, err := decodeJSONValue(dec)
+	if err != nil {
+		return nil, false
+	}
+
+	obj, ok := v.(*jsonObject)
+	if !ok {
+		return nil, false
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, false
+	}
+
+	return obj, true
+}
+
+// decodeJSONValue decodes the next JSON value from dec. A key repeated within
+// an object keeps its first position and its last value, as encoding/json
+// keeps the last.
+func decodeJSONValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		obj := &jsonObject{values: make(map[string]any)}
+
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			key, _ := keyTok.(string)
+
+			value, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, seen := obj.values[key]; !seen {
+				obj.keys = append(obj.keys, key)
+			}
+
+			obj.values[key] = value
+		}
+
+		_, err := dec.Token()
+
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+
+		for dec.More() {
+			value, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			arr = append(arr, value)
+		}
+
+		_, err := dec.Token()
+
+		return arr, err
+	default:
+		return tok, nil
+	}
+}
+
+// formatJSON3 pretty-prints all three values when each is a JSON object.
+func formatJSON3(base, staged, remote string) (string, string, string, bool) {
+	for _, v := range []string{base, staged, remote} {
+		if _, ok := decodeJSONObject(v); !ok {
+			return "", "", "", false
+		}
+	}
+
+	b, _ := jsonutil.TryFormat(base)
+	s, _ := jsonutil.TryFormat(staged)
+	r, _ := jsonutil.TryFormat(remote)
+
+	return b, s, r, true
+}
+
+// mergeLines is a diff3 merge over lines. Regions where base, staged and
+// remote all keep the same lines are stable; between them, a region changed
+// on one side takes that side, a region changed identically on both takes
+// either, and anything else becomes a conflict.
+func mergeLines(base, staged, remote string, labels MergeLabels) MergeResult {
+	b, s, r := splitLines(base), splitLines(staged), splitLines(remote)
+	toS, toR := matchLines(b, s), matchLines(b, r)
+
+	var (
+		out       strings.Builder
+		conflicts int
+	)
+
+	i, j, k := 0, 0, 0
+
+	for i < len(b) || j < len(s) || k < len(r) {
+		// Emit a stable line.
+		if i < len(b) && toS[i] == j && toR[i] == k {
+			out.WriteString(b[i])
+
+			i, j, k = i+1, j+1, k+1
+
+			continue
+		}
+
+		// Find the next base line kept by both sides; the region before it is
+		// an unstable chunk.
+		ni := i
+		for ni < len(b) && (toS[ni] < j || toR[ni] < k) {
+			ni++
+		}
+
+		nj, nk := len(s), len(r)
+		if ni < len(b) {
+			nj, nk = toS[ni], toR[ni]
+		}
+
+		chunkB, chunkS, chunkR := b[i:ni], s[j:nj], r[k:nk]
+
+		switch {
+		case equalLines(chunkS, chunkB):
+			writeLines(&out, chunkR)
+		case equalLines(chunkR, chunkB), equalLines(chunkS, chunkR):
+			writeLines(&out, chunkS)
+		default:
+			conflicts++
+
+			writeConflict(&out, chunkS, chunkR, labels)
+		}
+
+		i, j, k = ni, nj, nk
+	}
+
+	return MergeResult{Value: out.String(), Conflicts: conflicts}
+}
+
+// matchLines maps each line of base to its index in other along a longest
+// common subsequence, or -1 when the line is not kept.
+func matchLines(base, other []string) []int {
+	n, m := len(base), len(other)
+
+	// lcs[i][j] is the LCS length of base[i:] and other[j:].
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if base[i] == other[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	match := make([]int, n)
+
+	i, j := 0, 0
+	for i < n {
+		switch {
+		case j < m && base[i] == other[j]:
+			match[i] = j
+			i, j = i+1, j+1
+		case j < m && lcs[i][j+1] >= lcs[i+1][j]:
+			j++
+		default:
+			match[i] = -1
+			i++
+		}
+	}
+
+	return match
+}
+
+func splitLines(value string) []string {
+	var lines []string
+	for line := range strings.Lines(value) {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func equalLines(a, b []string) bool {
+	return reflect.DeepEqual(a, b) || (len(a) == 0 && len(b) == 0)
+}
+
+func writeLines(out *strings.Builder, lines []string) {
+	for _, line := range lines {
+		out.WriteString(line)
+	}
+}
+
+// writeConflict writes both sides between markers. Each side is terminated
+// with a newline so the markers stay on lines of their own.
+func writeConflict(out *strings.Builder, staged, remote []string, labels MergeLabels) {
+	if out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
+		out.WriteString("\n")
+	}
+
+	out.WriteString(conflictMarkerStaged + " " + labels.Staged + "\n")
+	writeTerminated(out, staged)
+	out.WriteString(conflictMarkerSplit + "\n")
+	writeTerminated(out, remote)
+	out.WriteString(conflictMarkerRemote + " " + labels.Remote + "\n")
+}
+
+func writeTerminated(out *strings.Builder, lines []string) {
+	writeLines(out, lines)
+
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		out.WriteString("\n")
+	}
+}
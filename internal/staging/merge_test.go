@@ -0,0 +1,150 @@
+package staging_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mpyw/suve/internal/staging"
+)
+
+var mergeLabels = staging.MergeLabels{Staged: "staged", Remote: "remote #4"}
+
+func TestMergeValues_JSONDifferentKeys(t *testing.T) {
+	t.Parallel()
+
+	got := staging.MergeValues(
+		`{"user":"app","password":"old","host":"db"}`,
+		`{"user":"app","password":"new","host":"db"}`,
+		`{"user":"app","password":"old","host":"db2","port":5432}`,
+		mergeLabels,
+	)
+
+	assert.Zero(t, got.Conflicts)
+	assert.JSONEq(t, `{"user":"app","password":"new","host":"db2","port":5432}`, got.Value)
+	assert.NotContains(t, got.Value, "\n")
+}
+
+func TestMergeValues_JSONNestedAndRemovedKeys(t *testing.T) {
+	t.Parallel()
+
+	got := staging.MergeValues(
+		"{\n  \"db\": {\"host\": \"a\", \"port\": 1},\n  \"debug\": true\n}",
+		"{\n  \"db\": {\"host\": \"b\", \"port\": 1},\n  \"debug\": true\n}",
+		`{"db":{"host":"a","port":2}}`,
+		mergeLabels,
+	)
+
+	assert.Zero(t, got.Conflicts)
+	assert.JSONEq(t, `{"db":{"host":"b","port":2}}`, got.Value)
+	assert.Contains(t, got.Value, "\n  ", "the staged value's indented layout is kept")
+}
+
+func TestMergeValues_JSONKeepsStagedKeyOrder(t *testing.T) {
+	t.Parallel()
+
+	got := staging.MergeValues(
+		`{"user":"app","password":"old","host":"db","tags":{"z":1,"a":1}}`,
+		`{"user":"app","password":"new","host":"db","tags":{"z":1,"a":1}}`,
+		`{"tags":{"a":1,"z":2},"host":"db2","password":"old","user":"app","port":5432}`,
+		mergeLabels,
+	)
+
+	assert.Zero(t, got.Conflicts)
+	assert.Equal(t, `{"user":"app","password":"new","host":"db2","tags":{"z":2,"a":1},"port":5432}`, got.Value)
+
+	indented := staging.MergeValues(
+		"{\n  \"b\": \"1\",\n  \"a\": \"1\"\n}",
+		"{\n  \"b\": \"2\",\n  \"a\": \"1\"\n}",
+		`{"a":"3","b":"1"}`,
+		mergeLabels,
+	)
+
+	assert.Zero(t, indented.Conflicts)
+	assert.Equal(t, "{\n  \"b\": \"2\",\n  \"a\": \"3\"\n}", indented.Value)
+}
+
+func TestMergeValues_JSONSameKeyConflict(t *testing.T) {
+	t.Parallel()
+
+	got := staging.MergeValues(`{"a":"1","b":"1"}`, `{"a":"2","b":"1"}`, `{"a":"3","b":"1"}`, mergeLabels)
+
+	assert.Equal(t, 1, got.Conflicts)
+	assert.Equal(t, "{\n"+
+		"<<<<<<< staged\n"+
+		"  \"a\": \"2\",\n"+
+		"=======\n"+
+		"  \"a\": \"3\",\n"+
+		">>>>>>> remote #4\n"+
+		"  \"b\": \"1\"\n"+
+		"}", got.Value)
+	assert.True(t, staging.HasConflictMarkers(got.Value))
+}
+
+func TestMergeValues_Lines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		base      string
+		staged    string
+		remote    string
+		want      string
+		conflicts int
+	}{
+		{
+			name:   "different lines",
+			base:   "a=1\nb=1\nc=1\n",
+			staged: "a=2\nb=1\nc=1\n",
+			remote: "a=1\nb=1\nc=3\n",
+			want:   "a=2\nb=1\nc=3\n",
+		},
+		{
+			name:   "insertions on both sides",
+			base:   "a\nz\n",
+			staged: "a\nb\nz\n",
+			remote: "a\nz\ny\n",
+			want:   "a\nb\nz\ny\n",
+		},
+		{
+			name:   "same change on both sides",
+			base:   "a\nb\n",
+			staged: "a\nc\n",
+			remote: "x\na\nc\n",
+			want:   "x\na\nc\n",
+		},
+		{
+			name:      "same line changed differently",
+			base:      "a\nb\nc\n",
+			staged:    "a\nB\nc\n",
+			remote:    "a\nbb\nc\n",
+			want:      "a\n<<<<<<< staged\nB\n=======\nbb\n>>>>>>> remote #4\nc\n",
+			conflicts: 1,
+		},
+		{
+			name:      "no base",
+			base:      "",
+			staged:    "one",
+			remote:    "two",
+			want:      "<<<<<<< staged\none\n=======\ntwo\n>>>>>>> remote #4\n",
+			conflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := staging.MergeValues(tt.base, tt.staged, tt.remote, mergeLabels)
+			assert.Equal(t, tt.want, got.Value)
+			assert.Equal(t, tt.conflicts, got.Conflicts)
+		})
+	}
+}
+
+func TestHasConflictMarkers(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, staging.HasConflictMarkers("a\n=======\nb"))
+	assert.True(t, staging.HasConflictMarkers("a\n>>>>>>> remote\n"))
+}
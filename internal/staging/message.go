@@ -0,0 +1,52 @@
+package staging
+
+import (
+	"context"
+
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// changeMessageKey is the context key of the apply's change message.
+type changeMessageKey struct{}
+
+// WithChangeMessage returns a context carrying the change message an apply was
+// run with (`stage apply -m`). Every strategy passes it on to the provider as a
+// provider.ChangeMessage when it writes a value, so the backend records it where
+// it can; rollbacks never carry it. An empty message leaves ctx unchanged.
+func WithChangeMessage(ctx context.Context, message string) context.Context {
+	if message == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, changeMessageKey{}, message)
+}
+
+// ChangeMessageFrom returns the change message set with WithChangeMessage, or ""
+// when there is none.
+func ChangeMessageFrom(ctx context.Context) string {
+	message, _ := ctx.Value(changeMessageKey{}).(string)
+
+	return message
+}
+
+// TagChangeMessage records ctx's change message as the tag named tag on an
+// applied entry (`stage apply --message-tag`). It is a no-op when either the tag
+// name or the message is empty.
+func TagChangeMessage(ctx context.Context, strategy ApplyStrategy, name, tag string) error {
+	message := ChangeMessageFrom(ctx)
+	if tag == "" || message == "" {
+		return nil
+	}
+
+	return strategy.ApplyTags(ctx, name, TagEntry{Add: map[string]string{tag: message}})
+}
+
+// writeOptions appends ctx's change message, if any, to opts as a
+// provider.ChangeMessage.
+func writeOptions(ctx context.Context, opts ...provider.WriteOption) []provider.WriteOption {
+	if message := ChangeMessageFrom(ctx); message != "" {
+		opts = append(opts, provider.ChangeMessage{Message: message})
+	}
+
+	return opts
+}
@@ -0,0 +1,354 @@
+package staging
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// planVersion is the current version of the saved plan format. Version 1
+// plans carried unkeyed value hashes and are no longer accepted.
+const planVersion = 2
+
+// planDigestPrefix prefixes the hex digest so the algorithm is self-describing.
+const planDigestPrefix = "sha256:"
+
+// planHashPrefix prefixes the keyed hashes of values, tags and staged changes.
+const planHashPrefix = "hmac-sha256:"
+
+// planSaltSize is the size in bytes of a plan's random salt.
+const planSaltSize = 32
+
+// ErrPlanTampered is returned when a plan's digest does not match its content.
+var ErrPlanTampered = errors.New("plan digest mismatch: the plan file was modified after it was written")
+
+// PlanItemKind distinguishes a staged value change from a staged tag change.
+type PlanItemKind string
+
+const (
+	// PlanItemEntry is a staged create/update/delete.
+	PlanItemEntry PlanItemKind = "entry"
+	// PlanItemTag is a staged tag change.
+	PlanItemTag PlanItemKind = "tag"
+)
+
+// PlanBase is the remote state a plan item was planned against. A remote value
+// is recorded only when the provider reports no version, and then only as a
+// hash keyed with the plan's salt (see PlanHasher).
+type PlanBase struct {
+	// Exists reports whether the remote entry existed at plan time.
+	Exists bool `json:"exists"`
+	// Version is the remote version identifier (e.g. "#3"); empty when the
+	// entry is missing or the provider is unversioned.
+	Version string `json:"version,omitempty"`
+	// ValueHash is the keyed hash of the remote value; empty when the entry is
+	// missing or has a Version, which already tells a changed value apart.
+	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
+	ValueHash string `json:"value_hash,omitempty"`
+	// TagsHash is the keyed hash of the remote tags; only recorded for tag
+	// items.
+	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
+	TagsHash string `json:"tags_hash,omitempty"`
+}
+
+// PlanItem is one staged change captured by a plan.
+type PlanItem struct {
+	Service Service `json:"service"`
+	// Target is the resolved staging scope the change applies to (e.g. the AWS
+	// account and region).
+	Target    string       `json:"target"`
+	Name      string       `json:"name"`
+	Namespace string       `json:"namespace,omitempty"`
+	Kind      PlanItemKind `json:"kind"`
+	// Operation is the staged operation; empty for tag items.
+	Operation Operation `json:"operation,omitempty"`
+	// StagedHash is the keyed hash of the staged change (see PlanHasher.Entry
+	// and PlanHasher.TagEntry).
+	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
+	StagedHash string   `json:"staged_hash"`
+	Base       PlanBase `json:"base"`
+}
+
+// Key returns the item's (name, namespace) key.
+func (i PlanItem) Key() EntryKey {
+	return EntryKey{Name: i.Name, Namespace: i.Namespace}
+}
+
+// label renders the item for drift messages.
+func (i PlanItem) label() string {
+	label := fmt.Sprintf("%s %s", i.Service, i.Key().Label())
+	if i.Kind == PlanItemTag {
+		label += " (tags)"
+	}
+
+	return label
+}
+
+// Plan is a saved apply plan: every staged change with the remote base it was
+// planned against. Digest is the unkeyed hash of the rest of the plan, so a
+// plan edited by accident is rejected on read; anyone can recompute it, so it
+// does not stop a deliberate edit.
+type Plan struct {
+	Version int `json:"version"`
+	// Provider is the provider label (e.g. "AWS").
+	Provider string `json:"provider"`
+	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
+	CreatedAt time.Time `json:"created_at"`
+	// Salt is the hex-encoded random key of the plan's hashes (see PlanHasher).
+	// A plan rebuilt to check for drift reuses it.
+	Salt   string     `json:"salt"`
+	Items  []PlanItem `json:"items"`
+	Digest string     `json:"digest"`
+}
+
+// NewPlanSalt returns a fresh random salt for a plan's hashes.
+func NewPlanSalt() (string, error) {
+	salt := make([]byte, planSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate plan salt: %w", err)
+	}
+
+	return hex.EncodeToString(salt), nil
+}
+
+// NewPlan builds a plan over items, whose hashes were keyed with salt, sorted
+// into a stable order, and records its digest.
+func NewPlan(provider string, createdAt time.Time, salt string, items []PlanItem) (*Plan, error) {
+	items = append([]PlanItem{}, items...)
+	slices.SortFunc(items, comparePlanItems)
+
+	p := &Plan{
+		Version:   planVersion,
+		Provider:  provider,
+		CreatedAt: createdAt.UTC().Truncate(time.Second),
+		Salt:      salt,
+		Items:     items,
+	}
+
+	digest, err := p.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	p.Digest = digest
+
+	return p, nil
+}
+
+// ParsePlan decodes a saved plan and verifies its version, salt and digest.
+func ParsePlan(data []byte) (*Plan, error) {
+	var p Plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+
+	if p.Version != planVersion {
+		return nil, fmt.Errorf("unsupported plan version %d (expected %d)", p.Version, planVersion)
+	}
+
+	if _, err := NewPlanHasher(p.Salt); err != nil {
+		return nil, err
+	}
+
+	digest, err := p.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Digest != digest {
+		return nil, ErrPlanTampered
+	}
+
+	return &p, nil
+}
+
+// Marshal encodes the plan for writing to a file.
+func (p *Plan) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plan: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// Drift compares the plan against a plan freshly built from the current
+// staging area and remote state, describing every difference. An empty result
+// means the plan still describes exactly what an apply would do.
+func (p *Plan) Drift(current *Plan) []string {
+	var drift []string
+
+	if p.Provider != current.Provider {
+		drift = append(drift, fmt.Sprintf("plan was made for %s, not %s", p.Provider, current.Provider))
+	}
+
+	planned := make(map[string]PlanItem, len(p.Items))
+	for _, item := range p.Items {
+		planned[planItemID(item)] = item
+	}
+
+	for _, cur := range current.Items {
+		want, ok := planned[planItemID(cur)]
+		if !ok {
+			drift = append(drift, cur.label()+": staged after the plan was made")
+
+			continue
+		}
+
+		delete(planned, planItemID(cur))
+
+		switch {
+		case want.Target != cur.Target:
+			drift = append(drift, fmt.Sprintf("%s: target changed from %s to %s", cur.label(), want.Target, cur.Target))
+		case want.StagedHash != cur.StagedHash:
+			drift = append(drift, cur.label()+": staged change differs from the plan")
+		case want.Base != cur.Base:
+			drift = append(drift, cur.label()+": remote "+describeBaseDrift(want.Base, cur.Base))
+		}
+	}
+
+	for _, item := range p.Items {
+		if _, ok := planned[planItemID(item)]; ok {
+			drift = append(drift, item.label()+": no longer staged")
+		}
+	}
+
+	return drift
+}
+
+// digest hashes the plan with its Digest field cleared.
+func (p *Plan) digest() (string, error) {
+	unsealed := *p
+	unsealed.Digest = ""
+
+	data, err := json.Marshal(unsealed)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode plan: %w", err)
+	}
+
+	return hashBytes(data), nil
+}
+
+// PlanHasher computes a plan's hashes of remote values, remote tags and staged
+// changes as HMAC-SHA256 keyed with the plan's salt. A random key per plan
+// keeps equal values from hashing alike across plans and rules out
+// precomputed tables; the salt travels in the plan, though, so a short value
+// can still be guessed by whoever holds the file. Plans are written private
+// for that reason.
+type PlanHasher struct {
+	key []byte
+}
+
+// NewPlanHasher returns the hasher keyed with a hex-encoded plan salt.
+func NewPlanHasher(salt string) (PlanHasher, error) {
+	key, err := hex.DecodeString(salt)
+	if err != nil || len(key) != planSaltSize {
+		return PlanHasher{}, fmt.Errorf("invalid plan salt: want %d hex-encoded bytes", planSaltSize)
+	}
+
+	return PlanHasher{key: key}, nil
+}
+
+// Value returns the plan hash of a remote value.
+func (h PlanHasher) Value(value string) string {
+	return h.sum([]byte(value))
+}
+
+// Tags returns the plan hash of a remote tag set. A nil and an empty set hash
+// alike.
+func (h PlanHasher) Tags(tags map[string]string) (string, error) {
+	if len(tags) == 0 {
+		tags = map[string]string{}
+	}
+
+	// encoding/json sorts map keys, so the encoding is canonical.
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode tags: %w", err)
+	}
+
+	return h.sum(data), nil
+}
+
+// Entry returns the plan hash of a staged entry. StagedAt is left out:
+// re-staging the identical change does not make a plan stale.
+func (h PlanHasher) Entry(entry Entry) (string, error) {
+	entry.StagedAt = time.Time{}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode staged entry: %w", err)
+	}
+
+	return h.sum(data), nil
+}
+
+// TagEntry returns the plan hash of a staged tag change, ignoring StagedAt
+// like Entry.
+func (h PlanHasher) TagEntry(tagEntry TagEntry) (string, error) {
+	tagEntry.StagedAt = time.Time{}
+
+	data, err := json.Marshal(tagEntry)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode staged tags: %w", err)
+	}
+
+	return h.sum(data), nil
+}
+
+func (h PlanHasher) sum(data []byte) string {
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write(data)
+
+	return planHashPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return planDigestPrefix + hex.EncodeToString(sum[:])
+}
+
+// planItemID identifies an item across two plans.
+func planItemID(i PlanItem) string {
+	return strings.Join([]string{string(i.Service), string(i.Kind), i.Name, i.Namespace}, "\x00")
+}
+
+func comparePlanItems(a, b PlanItem) int {
+	if c := strings.Compare(string(a.Service), string(b.Service)); c != 0 {
+		return c
+	}
+
+	if c := strings.Compare(string(a.Kind), string(b.Kind)); c != 0 {
+		return c
+	}
+
+	if c := strings.Compare(a.Name, b.Name); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.Namespace, b.Namespace)
+}
+
+// describeBaseDrift explains how the remote moved away from the planned base.
+func describeBaseDrift(want, got PlanBase) string {
+	switch {
+	case want.Exists && !got.Exists:
+		return "was deleted since the plan was made"
+	case !want.Exists && got.Exists:
+		return "was created since the plan was made"
+	case want.Version != got.Version:
+		return fmt.Sprintf("changed since the plan was made (%s -> %s)", want.Version, got.Version)
+	case want.TagsHash != got.TagsHash:
+		return "tags changed since the plan was made"
+	default:
+		return "changed since the plan was made"
+	}
+}
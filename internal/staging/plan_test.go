@@ -0,0 +1,144 @@
+package staging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// testSalt is a fixed, valid plan salt.
+var testSalt = strings.Repeat("ab", 32)
+
+func testPlanItems() []staging.PlanItem {
+	return []staging.PlanItem{
+		{
+			Service: staging.ServiceSecret, Target: "acct", Name: "b", Kind: staging.PlanItemEntry,
+			Operation: staging.OperationCreate, StagedHash: "h1",
+		},
+		{
+			Service: staging.ServiceParam, Target: "acct", Name: "a", Kind: staging.PlanItemEntry,
+			Operation: staging.OperationUpdate, StagedHash: "h2", Base: staging.PlanBase{Exists: true, Version: "#1"},
+		},
+	}
+}
+
+func TestNewPlan_SortsAndRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	plan, err := staging.NewPlan("AWS", time.Now(), testSalt, testPlanItems())
+	require.NoError(t, err)
+
+	assert.Equal(t, staging.ServiceParam, plan.Items[0].Service)
+	assert.Equal(t, staging.ServiceSecret, plan.Items[1].Service)
+
+	data, err := plan.Marshal()
+	require.NoError(t, err)
+
+	parsed, err := staging.ParsePlan(data)
+	require.NoError(t, err)
+	assert.Equal(t, plan.Digest, parsed.Digest)
+	assert.Empty(t, plan.Drift(parsed))
+}
+
+func TestParsePlan_Tampered(t *testing.T) {
+	t.Parallel()
+
+	plan, err := staging.NewPlan("AWS", time.Now(), testSalt, testPlanItems())
+	require.NoError(t, err)
+
+	data, err := plan.Marshal()
+	require.NoError(t, err)
+
+	_, err = staging.ParsePlan(bytes.Replace(data, []byte(`"#1"`), []byte(`"#2"`), 1))
+	require.ErrorIs(t, err, staging.ErrPlanTampered)
+}
+
+func TestParsePlan_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := staging.ParsePlan([]byte("not json"))
+	require.Error(t, err)
+
+	_, err = staging.ParsePlan([]byte(`{"version":99}`))
+	require.ErrorContains(t, err, "unsupported plan version 99")
+}
+
+func TestPlan_Drift(t *testing.T) {
+	t.Parallel()
+
+	planned, err := staging.NewPlan("AWS", time.Now(), testSalt, testPlanItems())
+	require.NoError(t, err)
+
+	items := testPlanItems()
+	items[0].StagedHash = "changed"
+	items[1] = staging.PlanItem{Service: staging.ServiceParam, Target: "acct", Name: "c", Kind: staging.PlanItemTag}
+
+	current, err := staging.NewPlan("AWS", time.Now(), testSalt, items)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"param c (tags): staged after the plan was made",
+		"secret b: staged change differs from the plan",
+		"param a: no longer staged",
+	}, planned.Drift(current))
+}
+
+func TestPlanHasher_Entry_IgnoresStagedAt(t *testing.T) {
+	t.Parallel()
+
+	hasher, err := staging.NewPlanHasher(testSalt)
+	require.NoError(t, err)
+
+	entry := staging.Entry{Operation: staging.OperationUpdate, Value: lo.ToPtr("v"), StagedAt: time.Now()}
+
+	h1, err := hasher.Entry(entry)
+	require.NoError(t, err)
+
+	entry.StagedAt = entry.StagedAt.Add(time.Hour)
+
+	h2, err := hasher.Entry(entry)
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2)
+
+	entry.Value = lo.ToPtr("w")
+
+	h3, err := hasher.Entry(entry)
+	require.NoError(t, err)
+	assert.NotEqual(t, h1, h3)
+}
+
+func TestPlanHasher_KeyedBySalt(t *testing.T) {
+	t.Parallel()
+
+	hasher, err := staging.NewPlanHasher(testSalt)
+	require.NoError(t, err)
+
+	salt, err := staging.NewPlanSalt()
+	require.NoError(t, err)
+
+	other, err := staging.NewPlanHasher(salt)
+	require.NoError(t, err)
+
+	assert.Equal(t, hasher.Value("1234"), hasher.Value("1234"))
+	assert.NotEqual(t, hasher.Value("1234"), other.Value("1234"), "the same value hashes apart under another salt")
+	assert.True(t, strings.HasPrefix(hasher.Value("1234"), "hmac-sha256:"))
+	assert.NotContains(t, hasher.Value("1234"), "03ac674216f3e15c761ee1a5e255f067953623c8b388b4459e13f978d7c846f4",
+		"not the plain SHA-256 of the value")
+}
+
+func TestParsePlan_InvalidSalt(t *testing.T) {
+	t.Parallel()
+
+	_, err := staging.ParsePlan([]byte(`{"version":2,"salt":"short"}`))
+	require.ErrorContains(t, err, "invalid plan salt")
+
+	_, err = staging.NewPlanHasher("")
+	require.Error(t, err)
+}
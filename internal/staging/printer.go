@@ -62,6 +62,10 @@ func (p *EntryPrinter) PrintEntry(key EntryKey, entry Entry, verbose, showDelete
 
 			output.Printf(p.Writer, "  %s %s\n", pal.FieldLabel("Value:"), value)
 		}
+
+		if a := entry.Attributes; a != nil {
+			p.printAttributes(*a)
+		}
 	case OperationDelete:
 		if showDeleteOptions && entry.DeleteOptions != nil {
 			switch {
@@ -73,3 +77,24 @@ func (p *EntryPrinter) PrintEntry(key EntryKey, entry Entry, verbose, showDelete
 		}
 	}
 }
+
+// printAttributes prints the staged Key Vault attributes that are set.
+func (p *EntryPrinter) printAttributes(a SecretAttributes) {
+	pal := colors.For(p.Writer)
+
+	if a.ContentType != nil {
+		output.Printf(p.Writer, "  %s %s\n", pal.FieldLabel("Content Type:"), *a.ContentType)
+	}
+
+	if a.NotBefore != nil {
+		output.Printf(p.Writer, "  %s %s\n", pal.FieldLabel("Not Before:"), timeutil.FormatDateTime(*a.NotBefore))
+	}
+
+	if a.Expires != nil {
+		output.Printf(p.Writer, "  %s %s\n", pal.FieldLabel("Expires:"), timeutil.FormatDateTime(*a.Expires))
+	}
+
+	if a.Enabled != nil {
+		output.Printf(p.Writer, "  %s %t\n", pal.FieldLabel("Enabled:"), *a.Enabled)
+	}
+}
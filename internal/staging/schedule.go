@@ -0,0 +1,331 @@
+package staging
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mpyw/suve/internal/parallel"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/retry"
+)
+
+// ApplyOrder orders the changes of an apply into phases that run one after
+// another; the changes within a phase run concurrently. Entries are applied
+// creates first, then updates, then deletes, so a change that moves a value
+// under a new name writes the new name before the old one is removed. Tag
+// changes follow every entry.
+type ApplyOrder struct {
+	// Patterns lists name globs (Selector.Match syntax), earliest first. A
+	// change belongs to the group of the first pattern matching its name, or
+	// to a last group when none does; groups apply in order, each with its own
+	// create, update and delete phases.
+	Patterns []string
+}
+
+// ParseApplyOrder reads an order file (`stage apply --order`): one name glob
+// per line, earliest first. Blank lines and lines starting with "#" are
+// skipped.
+func ParseApplyOrder(r io.Reader) (ApplyOrder, error) {
+	var order ApplyOrder
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return ApplyOrder{}, fmt.Errorf("line %d: invalid pattern %q: %w", line, pattern, err)
+		}
+
+		order.Patterns = append(order.Patterns, pattern)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ApplyOrder{}, err
+	}
+
+	return order, nil
+}
+
+// EntryPhases splits entries into the phases an apply runs in order. Empty
+// phases are left out.
+func (o ApplyOrder) EntryPhases(entries map[EntryKey]Entry) []map[EntryKey]Entry {
+	return phases(entries, func(key EntryKey, entry Entry) int {
+		return o.group(key.Name)*len(operationOrder) + slices.Index(operationOrder, entry.Operation)
+	})
+}
+
+// TagPhases splits tag changes into the phases an apply runs in order: one per
+// group of Patterns. Empty phases are left out.
+func (o ApplyOrder) TagPhases(tags map[EntryKey]TagEntry) []map[EntryKey]TagEntry {
+	return phases(tags, func(key EntryKey, _ TagEntry) int {
+		return o.group(key.Name)
+	})
+}
+
+// operationOrder is the order of the operation phases within a group.
+var operationOrder = []Operation{OperationCreate, OperationUpdate, OperationDelete}
+
+// group returns the index of the first pattern matching name, or
+// len(o.Patterns) when none does.
+func (o ApplyOrder) group(name string) int {
+	for i, pattern := range o.Patterns {
+		if matchName(pattern, name) {
+			return i
+		}
+	}
+
+	return len(o.Patterns)
+}
+
+// phases groups m by the rank each item is given, in ascending rank order.
+func phases[V any](m map[EntryKey]V, rank func(EntryKey, V) int) []map[EntryKey]V {
+	byRank := make(map[int]map[EntryKey]V)
+
+	for key, value := range m {
+		r := rank(key, value)
+		if byRank[r] == nil {
+			byRank[r] = make(map[EntryKey]V)
+		}
+
+		byRank[r][key] = value
+	}
+
+	ranks := make([]int, 0, len(byRank))
+	for r := range byRank {
+		ranks = append(ranks, r)
+	}
+
+	slices.Sort(ranks)
+
+	out := make([]map[EntryKey]V, 0, len(ranks))
+	for _, r := range ranks {
+		out = append(out, byRank[r])
+	}
+
+	return out
+}
+
+// ApplySchedule controls how an apply runs its changes: in which order, how
+// many at once, and how the provider calls throttled on the way are retried.
+type ApplySchedule struct {
+	Order ApplyOrder
+	// Concurrency caps the changes applied at once; 0 is parallel.DefaultLimit.
+	Concurrency int
+	// Retry is the backoff of a throttled provider call (see
+	// provider.IsThrottled); the zero Policy is retry.Default.
+	Retry retry.Policy
+}
+
+// ApplyStep runs one provider call of a change, retrying it with backoff while
+// the provider throttles it. A change made of several calls runs each through
+// its own step, so a retry never repeats a call that already succeeded.
+type ApplyStep func(call func() error) error
+
+// ApplyEntries applies entries phase by phase (see ApplyOrder) through fn and
+// returns each entry's error, nil for an entry that was applied. It reports
+// the progress of every entry to the callback ctx carries (see
+// WithApplyProgress).
+func (s ApplySchedule) ApplyEntries(
+	ctx context.Context, entries map[EntryKey]Entry,
+	fn func(ctx context.Context, key EntryKey, entry Entry, step ApplyStep) error,
+) map[EntryKey]error {
+	return run(ctx, s, s.Order.EntryPhases(entries), func(key EntryKey, entry Entry) ApplyEvent {
+		return ApplyEvent{Key: key, Operation: entry.Operation}
+	}, fn)
+}
+
+// ApplyTags applies tag changes phase by phase through fn, like ApplyEntries.
+func (s ApplySchedule) ApplyTags(
+	ctx context.Context, tags map[EntryKey]TagEntry,
+	fn func(ctx context.Context, key EntryKey, tagEntry TagEntry, step ApplyStep) error,
+) map[EntryKey]error {
+	return run(ctx, s, s.Order.TagPhases(tags), func(key EntryKey, _ TagEntry) ApplyEvent {
+		return ApplyEvent{Key: key, Tags: true}
+	}, fn)
+}
+
+// run applies each phase in turn, the changes of a phase concurrently.
+func run[V any](
+	ctx context.Context, s ApplySchedule, phases []map[EntryKey]V, event func(EntryKey, V) ApplyEvent,
+	fn func(ctx context.Context, key EntryKey, value V, step ApplyStep) error,
+) map[EntryKey]error {
+	report := ApplyProgressFrom(ctx)
+	errs := make(map[EntryKey]error)
+
+	for _, phase := range phases {
+		results := parallel.ExecuteMapWithLimit(ctx, phase, s.Concurrency, func(ctx context.Context, key EntryKey, value V) (struct{}, error) {
+			ev := event(key, value)
+
+			ev.Kind = ApplyEventStarted
+			report(ev)
+
+			err := fn(ctx, key, value, func(call func() error) error {
+				return s.Retry.Do(ctx, provider.IsThrottled, func(attempt int, delay time.Duration, err error) {
+					retrying := ev
+					retrying.Kind, retrying.Attempt, retrying.Delay, retrying.Err = ApplyEventRetrying, attempt, delay, err
+					report(retrying)
+				}, call)
+			})
+
+			ev.Kind, ev.Err = ApplyEventFinished, err
+			report(ev)
+
+			return struct{}{}, err
+		})
+
+		for key, result := range results {
+			errs[key] = result.Err
+		}
+	}
+
+	return errs
+}
+
+// ApplyEventKind is the kind of an ApplyEvent.
+type ApplyEventKind int
+
+// ApplyEventKind constants, in the order a change goes through them.
+const (
+	// ApplyEventQueued reports a change the apply is about to run; every
+	// change is queued before the first one starts, so the queued count is
+	// the apply's total.
+	ApplyEventQueued ApplyEventKind = iota
+	// ApplyEventStarted reports that a change's provider calls begin.
+	ApplyEventStarted
+	// ApplyEventRetrying reports that a throttled call is retried after Delay.
+	ApplyEventRetrying
+	// ApplyEventFinished reports a change that is done, failed when Err is set.
+	ApplyEventFinished
+)
+
+// ApplyEvent reports the progress of one staged change during an apply.
+type ApplyEvent struct {
+	Kind ApplyEventKind
+	Key  EntryKey
+	// Tags marks the key's staged tag change rather than its entry.
+	Tags bool
+	// Operation is the entry's operation (empty for a tag change).
+	Operation Operation
+	// Attempt is the attempt a retry starts (2 for the first retry), and Delay
+	// the wait before it.
+	Attempt int
+	Delay   time.Duration
+	// Err is the throttling error of a retry, or the failure of a finished
+	// change (nil when it was applied).
+	Err error
+}
+
+// applyProgressKey is the context key of the apply's progress callback.
+type applyProgressKey struct{}
+
+// WithApplyProgress returns a context whose apply reports each change's
+// progress to report (the CLI's live progress and the TUI apply dialog). Calls
+// are serialized, so report needs no locking of its own.
+func WithApplyProgress(ctx context.Context, report func(ApplyEvent)) context.Context {
+	var mu sync.Mutex
+
+	return context.WithValue(ctx, applyProgressKey{}, func(ev ApplyEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		report(ev)
+	})
+}
+
+// ApplyProgressFrom returns the progress callback set with WithApplyProgress,
+// or a no-op when there is none.
+func ApplyProgressFrom(ctx context.Context) func(ApplyEvent) {
+	if report, ok := ctx.Value(applyProgressKey{}).(func(ApplyEvent)); ok {
+		return report
+	}
+
+	return func(ApplyEvent) {}
+}
+
+// ReportQueued reports every entry and tag change an apply is about to run as
+// queued, in the order they will run.
+func ReportQueued(ctx context.Context, order ApplyOrder, entries map[EntryKey]Entry, tags map[EntryKey]TagEntry) {
+	report := ApplyProgressFrom(ctx)
+
+	for _, phase := range order.EntryPhases(entries) {
+		for _, key := range SortedEntryKeys(phase) {
+			report(ApplyEvent{Kind: ApplyEventQueued, Key: key, Operation: phase[key].Operation})
+		}
+	}
+
+	for _, phase := range order.TagPhases(tags) {
+		for _, key := range SortedEntryKeys(phase) {
+			report(ApplyEvent{Kind: ApplyEventQueued, Key: key, Tags: true})
+		}
+	}
+}
+
+// ApplyTracker folds the ApplyEvents of an apply into its live progress, for
+// the CLI's progress display and the TUI apply dialog. It is safe for
+// concurrent use; the zero ApplyTracker is ready to use.
+type ApplyTracker struct {
+	mu       sync.Mutex
+	progress ApplyProgress
+}
+
+// ApplyProgress is the progress of an apply at one point.
+type ApplyProgress struct {
+	// Total counts the queued changes, Done the finished ones, and Failed
+	// those of Done that failed.
+	Total  int
+	Done   int
+	Failed int
+	// Running holds the latest event of each change in flight (started, or
+	// retrying after a throttled call), in the order they started.
+	Running []ApplyEvent
+}
+
+// Report folds ev into the progress.
+func (t *ApplyTracker) Report(ev ApplyEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := &t.progress
+	i := slices.IndexFunc(p.Running, func(running ApplyEvent) bool { return running.Key == ev.Key && running.Tags == ev.Tags })
+
+	switch ev.Kind {
+	case ApplyEventQueued:
+		p.Total++
+	case ApplyEventStarted:
+		p.Running = append(p.Running, ev)
+	case ApplyEventRetrying:
+		if i >= 0 {
+			p.Running[i] = ev
+		}
+	case ApplyEventFinished:
+		if i >= 0 {
+			p.Running = slices.Delete(p.Running, i, i+1)
+		}
+
+		p.Done++
+		if ev.Err != nil {
+			p.Failed++
+		}
+	}
+}
+
+// Progress returns the current progress.
+func (t *ApplyTracker) Progress() ApplyProgress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.progress
+	p.Running = slices.Clone(p.Running)
+
+	return p
+}
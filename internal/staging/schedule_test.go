@@ -0,0 +1,237 @@
+package staging_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/retry"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// noWait is a retry policy that retries at once.
+var noWait = retry.Policy{
+	MaxAttempts: 3,
+	Sleep:       func(context.Context, time.Duration) error { return nil },
+}
+
+func TestParseApplyOrder(t *testing.T) {
+	t.Parallel()
+
+	order, err := staging.ParseApplyOrder(strings.NewReader("# shared config first\n/app/shared\n\n  /app/*/db  \n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/app/shared", "/app/*/db"}, order.Patterns)
+
+	_, err = staging.ParseApplyOrder(strings.NewReader("/app\n/app/[\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `line 2: invalid pattern "/app/["`)
+}
+
+func TestApplyOrder_EntryPhases(t *testing.T) {
+	t.Parallel()
+
+	entries := map[staging.EntryKey]staging.Entry{
+		{Name: "/app/old"}:      {Operation: staging.OperationDelete},
+		{Name: "/app/new"}:      {Operation: staging.OperationCreate},
+		{Name: "/app/changed"}:  {Operation: staging.OperationUpdate},
+		{Name: "/shared/host"}:  {Operation: staging.OperationUpdate},
+		{Name: "/shared/stale"}: {Operation: staging.OperationDelete},
+	}
+
+	names := func(phases []map[staging.EntryKey]staging.Entry) [][]string {
+		return lo.Map(phases, func(phase map[staging.EntryKey]staging.Entry, _ int) []string {
+			return lo.Map(staging.SortedEntryKeys(phase), func(key staging.EntryKey, _ int) string { return key.Name })
+		})
+	}
+
+	t.Run("creates, then updates, then deletes", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, [][]string{
+			{"/app/new"},
+			{"/app/changed", "/shared/host"},
+			{"/app/old", "/shared/stale"},
+		}, names(staging.ApplyOrder{}.EntryPhases(entries)))
+	})
+
+	t.Run("order patterns come first", func(t *testing.T) {
+		t.Parallel()
+
+		order := staging.ApplyOrder{Patterns: []string{"/shared"}}
+
+		assert.Equal(t, [][]string{
+			{"/shared/host"},
+			{"/shared/stale"},
+			{"/app/new"},
+			{"/app/changed"},
+			{"/app/old"},
+		}, names(order.EntryPhases(entries)))
+	})
+}
+
+func TestApplyOrder_TagPhases(t *testing.T) {
+	t.Parallel()
+
+	tags := map[staging.EntryKey]staging.TagEntry{
+		{Name: "/app/a"}:    {},
+		{Name: "/shared/b"}: {},
+	}
+
+	phases := staging.ApplyOrder{Patterns: []string{"/shared/*"}}.TagPhases(tags)
+	require.Len(t, phases, 2)
+	assert.Contains(t, phases[0], staging.EntryKey{Name: "/shared/b"})
+	assert.Contains(t, phases[1], staging.EntryKey{Name: "/app/a"})
+
+	assert.Len(t, staging.ApplyOrder{}.TagPhases(tags), 1)
+}
+
+func TestApplySchedule_ApplyEntries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs the phases in order", func(t *testing.T) {
+		t.Parallel()
+
+		entries := map[staging.EntryKey]staging.Entry{
+			{Name: "/d"}: {Operation: staging.OperationDelete},
+			{Name: "/c"}: {Operation: staging.OperationCreate},
+			{Name: "/u"}: {Operation: staging.OperationUpdate},
+		}
+
+		var (
+			mu  sync.Mutex
+			ran []string
+		)
+
+		errs := staging.ApplySchedule{Concurrency: 1}.ApplyEntries(t.Context(), entries,
+			func(_ context.Context, key staging.EntryKey, _ staging.Entry, _ staging.ApplyStep) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				ran = append(ran, key.Name)
+
+				return nil
+			})
+
+		assert.Equal(t, []string{"/c", "/u", "/d"}, ran)
+		assert.Len(t, errs, 3)
+		assert.NoError(t, errs[staging.EntryKey{Name: "/c"}])
+	})
+
+	t.Run("retries a throttled step and reports progress", func(t *testing.T) {
+		t.Parallel()
+
+		key := staging.EntryKey{Name: "/app/x"}
+		entries := map[staging.EntryKey]staging.Entry{key: {Operation: staging.OperationUpdate}}
+
+		var events []staging.ApplyEvent
+
+		ctx := staging.WithApplyProgress(t.Context(), func(ev staging.ApplyEvent) { events = append(events, ev) })
+
+		puts, tags := 0, 0
+		errs := staging.ApplySchedule{Retry: noWait}.ApplyEntries(ctx, entries,
+			func(_ context.Context, _ staging.EntryKey, _ staging.Entry, step staging.ApplyStep) error {
+				if err := step(func() error {
+					puts++
+
+					return nil
+				}); err != nil {
+					return err
+				}
+
+				return step(func() error {
+					if tags++; tags == 1 {
+						return fmt.Errorf("%w: /app/x", provider.ErrThrottled)
+					}
+
+					return nil
+				})
+			})
+
+		require.NoError(t, errs[key])
+		assert.Equal(t, 1, puts, "a retry must not repeat a call that succeeded")
+		assert.Equal(t, 2, tags)
+		assert.Equal(t, []staging.ApplyEventKind{
+			staging.ApplyEventStarted, staging.ApplyEventRetrying, staging.ApplyEventFinished,
+		}, lo.Map(events, func(ev staging.ApplyEvent, _ int) staging.ApplyEventKind { return ev.Kind }))
+		assert.Equal(t, 2, events[1].Attempt)
+		require.ErrorIs(t, events[1].Err, provider.ErrThrottled)
+		assert.Equal(t, staging.OperationUpdate, events[2].Operation)
+		assert.NoError(t, events[2].Err)
+	})
+
+	t.Run("does not retry other failures", func(t *testing.T) {
+		t.Parallel()
+
+		key := staging.EntryKey{Name: "/app/x"}
+		boom := errors.New("boom")
+		calls := 0
+
+		errs := staging.ApplySchedule{Retry: noWait}.ApplyEntries(t.Context(), map[staging.EntryKey]staging.Entry{key: {}},
+			func(_ context.Context, _ staging.EntryKey, _ staging.Entry, step staging.ApplyStep) error {
+				return step(func() error {
+					calls++
+
+					return boom
+				})
+			})
+
+		require.ErrorIs(t, errs[key], boom)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestReportQueued(t *testing.T) {
+	t.Parallel()
+
+	var queued []string
+
+	ctx := staging.WithApplyProgress(t.Context(), func(ev staging.ApplyEvent) {
+		assert.Equal(t, staging.ApplyEventQueued, ev.Kind)
+
+		queued = append(queued, fmt.Sprintf("%s:%v", ev.Key.Name, ev.Tags))
+	})
+
+	staging.ReportQueued(ctx, staging.ApplyOrder{},
+		map[staging.EntryKey]staging.Entry{
+			{Name: "/b"}: {Operation: staging.OperationDelete},
+			{Name: "/a"}: {Operation: staging.OperationCreate},
+		},
+		map[staging.EntryKey]staging.TagEntry{{Name: "/a"}: {}},
+	)
+
+	assert.Equal(t, []string{"/a:false", "/b:false", "/a:true"}, queued)
+}
+
+func TestApplyTracker(t *testing.T) {
+	t.Parallel()
+
+	a := staging.EntryKey{Name: "/a"}
+	b := staging.EntryKey{Name: "/b"}
+
+	var tracker staging.ApplyTracker
+
+	tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventQueued, Key: a})
+	tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventQueued, Key: b})
+	tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventQueued, Key: a, Tags: true})
+	tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventStarted, Key: a})
+	tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventStarted, Key: b})
+	tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventRetrying, Key: b, Attempt: 2})
+	tracker.Report(staging.ApplyEvent{Kind: staging.ApplyEventFinished, Key: a, Err: errors.New("boom")})
+
+	progress := tracker.Progress()
+	assert.Equal(t, 3, progress.Total)
+	assert.Equal(t, 1, progress.Done)
+	assert.Equal(t, 1, progress.Failed)
+	require.Len(t, progress.Running, 1)
+	assert.Equal(t, staging.ApplyEventRetrying, progress.Running[0].Kind)
+	assert.Equal(t, 2, progress.Running[0].Attempt)
+}
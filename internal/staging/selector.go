@@ -0,0 +1,159 @@
+package staging
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// OperationTag selects staged tag changes in a Selector's Operations. It is
+// never the Operation of an Entry.
+const OperationTag Operation = "tag"
+
+// Selector narrows a staging command to a subset of the staged changes: apply,
+// reset, status, diff and export with --match/--exclude/--namespace/--op, and
+// the GUI and TUI staging pages with the items the user marked. Every field
+// that is set must match; the zero Selector selects everything.
+type Selector struct {
+	// Match lists name globs, any of which must match. A glob matches a name
+	// itself or any of its parent paths, so "/app/db/*" selects everything
+	// below /app/db. The syntax is path.Match's: "*" stops at "/".
+	Match []string
+	// Exclude lists name globs that deselect what they match (same syntax as
+	// Match), taking precedence over Match.
+	Exclude []string
+	// Namespaces lists the App Configuration namespaces to select; "" is the
+	// null/default namespace, the only one of every other provider.
+	Namespaces []string
+	// Operations lists the operations to select: OperationCreate,
+	// OperationUpdate and OperationDelete select entries, OperationTag tag
+	// changes.
+	Operations []Operation
+	// Keys lists the exact items to select.
+	Keys []EntryKey
+}
+
+// IsZero reports whether s selects everything.
+func (s Selector) IsZero() bool {
+	return len(s.Match) == 0 && len(s.Exclude) == 0 && len(s.Namespaces) == 0 &&
+		len(s.Operations) == 0 && len(s.Keys) == 0
+}
+
+// Validate reports a malformed glob or an unknown operation.
+func (s Selector) Validate() error {
+	for _, pattern := range slices.Concat(s.Match, s.Exclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	for _, op := range s.Operations {
+		switch op {
+		case OperationCreate, OperationUpdate, OperationDelete, OperationTag:
+		default:
+			return fmt.Errorf("invalid operation %q: must be one of create, update, delete, tag", op)
+		}
+	}
+
+	return nil
+}
+
+// MatchesEntry reports whether s selects the staged entry at key.
+func (s Selector) MatchesEntry(key EntryKey, entry Entry) bool {
+	return s.matchesOperation(entry.Operation) && s.matchesKey(key)
+}
+
+// MatchesTag reports whether s selects the staged tag change at key.
+func (s Selector) MatchesTag(key EntryKey) bool {
+	return s.matchesOperation(OperationTag) && s.matchesKey(key)
+}
+
+// SelectEntries returns the entries of m that s selects. The zero Selector
+// returns m itself.
+func (s Selector) SelectEntries(m map[EntryKey]Entry) map[EntryKey]Entry {
+	if s.IsZero() {
+		return m
+	}
+
+	selected := make(map[EntryKey]Entry)
+
+	for key, entry := range m {
+		if s.MatchesEntry(key, entry) {
+			selected[key] = entry
+		}
+	}
+
+	return selected
+}
+
+// SelectTags returns the tag changes of m that s selects. The zero Selector
+// returns m itself.
+func (s Selector) SelectTags(m map[EntryKey]TagEntry) map[EntryKey]TagEntry {
+	if s.IsZero() {
+		return m
+	}
+
+	selected := make(map[EntryKey]TagEntry)
+
+	for key, tagEntry := range m {
+		if s.MatchesTag(key) {
+			selected[key] = tagEntry
+		}
+	}
+
+	return selected
+}
+
+// SelectState returns a new state holding only what s selects from state. Like
+// SelectEntries, the zero Selector shares state's per-service maps.
+func (s Selector) SelectState(state *State) *State {
+	selected := NewEmptyState()
+
+	for service, entries := range state.Entries {
+		selected.Entries[service] = s.SelectEntries(entries)
+	}
+
+	for service, tags := range state.Tags {
+		selected.Tags[service] = s.SelectTags(tags)
+	}
+
+	return selected
+}
+
+func (s Selector) matchesOperation(op Operation) bool {
+	return len(s.Operations) == 0 || slices.Contains(s.Operations, op)
+}
+
+func (s Selector) matchesKey(key EntryKey) bool {
+	if len(s.Keys) > 0 && !slices.Contains(s.Keys, key) {
+		return false
+	}
+
+	if len(s.Namespaces) > 0 && !slices.Contains(s.Namespaces, key.Namespace) {
+		return false
+	}
+
+	if len(s.Match) > 0 && !slices.ContainsFunc(s.Match, func(pattern string) bool { return matchName(pattern, key.Name) }) {
+		return false
+	}
+
+	return !slices.ContainsFunc(s.Exclude, func(pattern string) bool { return matchName(pattern, key.Name) })
+}
+
+// matchName reports whether the glob pattern matches name or one of its parent
+// paths, so a pattern naming a path prefix selects the whole subtree below it.
+func matchName(pattern, name string) bool {
+	for prefix := name; ; {
+		if ok, _ := path.Match(pattern, prefix); ok {
+			return true
+		}
+
+		i := strings.LastIndex(prefix, "/")
+		if i <= 0 {
+			return false
+		}
+
+		prefix = prefix[:i]
+	}
+}
@@ -0,0 +1,114 @@
+package staging_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/staging"
+)
+
+func TestSelector_IsZero(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, staging.Selector{}.IsZero())
+	assert.False(t, staging.Selector{Match: []string{"/app/*"}}.IsZero())
+	assert.False(t, staging.Selector{Keys: []staging.EntryKey{{Name: "a"}}}.IsZero())
+}
+
+func TestSelector_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, staging.Selector{
+		Match:      []string{"/app/*"},
+		Exclude:    []string{"/app/legacy"},
+		Operations: []staging.Operation{staging.OperationDelete, staging.OperationTag},
+	}.Validate())
+
+	err := staging.Selector{Match: []string{"/app/["}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid pattern "/app/["`)
+
+	err = staging.Selector{Operations: []staging.Operation{"rename"}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid operation "rename"`)
+}
+
+func TestSelector_MatchesEntry(t *testing.T) {
+	t.Parallel()
+
+	update := staging.Entry{Operation: staging.OperationUpdate}
+	del := staging.Entry{Operation: staging.OperationDelete}
+
+	tests := []struct {
+		name     string
+		selector staging.Selector
+		key      staging.EntryKey
+		entry    staging.Entry
+		want     bool
+	}{
+		{"zero selects everything", staging.Selector{}, staging.EntryKey{Name: "/x"}, update, true},
+		{"glob matches", staging.Selector{Match: []string{"/app/db/*"}}, staging.EntryKey{Name: "/app/db/host"}, update, true},
+		{"glob matches below a parent", staging.Selector{Match: []string{"/app/db/*"}}, staging.EntryKey{Name: "/app/db/a/b"}, update, true},
+		{"glob does not match a sibling", staging.Selector{Match: []string{"/app/db/*"}}, staging.EntryKey{Name: "/app/web/host"}, update, false},
+		{"plain name matches its subtree", staging.Selector{Match: []string{"/app"}}, staging.EntryKey{Name: "/app/db/host"}, update, true},
+		{"plain name does not match a prefix", staging.Selector{Match: []string{"/app"}}, staging.EntryKey{Name: "/apple"}, update, false},
+		{"any match is enough", staging.Selector{Match: []string{"/a/*", "/b/*"}}, staging.EntryKey{Name: "/b/x"}, update, true},
+		{
+			"exclude wins over match",
+			staging.Selector{Match: []string{"/app/db/*"}, Exclude: []string{"/app/db/legacy"}},
+			staging.EntryKey{Name: "/app/db/legacy/user"}, update, false,
+		},
+		{"namespace matches", staging.Selector{Namespaces: []string{"prod"}}, staging.EntryKey{Name: "k", Namespace: "prod"}, update, true},
+		{"namespace mismatch", staging.Selector{Namespaces: []string{"prod"}}, staging.EntryKey{Name: "k"}, update, false},
+		{"default namespace selected by empty", staging.Selector{Namespaces: []string{""}}, staging.EntryKey{Name: "k"}, update, true},
+		{"operation matches", staging.Selector{Operations: []staging.Operation{staging.OperationDelete}}, staging.EntryKey{Name: "k"}, del, true},
+		{"operation mismatch", staging.Selector{Operations: []staging.Operation{staging.OperationDelete}}, staging.EntryKey{Name: "k"}, update, false},
+		{"tag operation does not select entries", staging.Selector{Operations: []staging.Operation{staging.OperationTag}}, staging.EntryKey{Name: "k"}, update, false},
+		{"key matches", staging.Selector{Keys: []staging.EntryKey{{Name: "k", Namespace: "dev"}}}, staging.EntryKey{Name: "k", Namespace: "dev"}, update, true},
+		{"key needs the namespace", staging.Selector{Keys: []staging.EntryKey{{Name: "k", Namespace: "dev"}}}, staging.EntryKey{Name: "k"}, update, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.selector.MatchesEntry(tt.key, tt.entry))
+		})
+	}
+}
+
+func TestSelector_MatchesTag(t *testing.T) {
+	t.Parallel()
+
+	key := staging.EntryKey{Name: "/app/db/host"}
+
+	assert.True(t, staging.Selector{}.MatchesTag(key))
+	assert.True(t, staging.Selector{Operations: []staging.Operation{staging.OperationTag}}.MatchesTag(key))
+	assert.False(t, staging.Selector{Operations: []staging.Operation{staging.OperationUpdate}}.MatchesTag(key))
+	assert.False(t, staging.Selector{Match: []string{"/app/web/*"}}.MatchesTag(key))
+}
+
+func TestSelector_SelectState(t *testing.T) {
+	t.Parallel()
+
+	state := staging.NewEmptyState()
+	state.Entries[staging.ServiceParam] = map[staging.EntryKey]staging.Entry{
+		{Name: "/app/db/host"}:  {Operation: staging.OperationUpdate},
+		{Name: "/app/web/host"}: {Operation: staging.OperationUpdate},
+	}
+	state.Entries[staging.ServiceSecret] = map[staging.EntryKey]staging.Entry{
+		{Name: "other"}: {Operation: staging.OperationCreate},
+	}
+	state.Tags[staging.ServiceParam] = map[staging.EntryKey]staging.TagEntry{
+		{Name: "/app/db/port"}: {Add: map[string]string{"env": "prod"}},
+	}
+
+	selected := staging.Selector{Match: []string{"/app/db/*"}}.SelectState(state)
+
+	assert.Equal(t, 1, selected.EntryCount())
+	assert.Contains(t, selected.Entries[staging.ServiceParam], staging.EntryKey{Name: "/app/db/host"})
+	assert.Empty(t, selected.Entries[staging.ServiceSecret])
+	assert.Equal(t, 1, selected.TagCount())
+}
@@ -3,6 +3,8 @@ package staging
 import (
 	"context"
 	"time"
+
+	"github.com/mpyw/suve/internal/domain"
 )
 
 // itemNameSecret is the display item name shared by the secret staging
@@ -99,6 +101,16 @@ type EditFetchResult struct {
 	// LastModified is the last modification time of the resource.
 	// Used for conflict detection when applying staged changes.
 	LastModified time.Time
+	// Version is the identifier of the fetched version, recorded as the staged
+	// entry's BaseVersion. Empty when the provider has none.
+	Version string
+}
+
+// RemoteState is what conflict detection knows about a remote entry: its last
+// modification time and, where the provider has one, its version identifier.
+type RemoteState struct {
+	LastModified time.Time
+	Version      string
 }
 
 // DiffStrategy defines service-specific diff/fetch operations.
@@ -147,6 +159,93 @@ type DeleteStrategy interface {
 	FetchLastModified(ctx context.Context, name string) (time.Time, error)
 }
 
+// Locker is the optional ApplyStrategy extension for services whose entries can
+// be locked read-only (Azure App Configuration). The apply use case
+// type-asserts for it to detect locked entries before writing and, when asked,
+// to unlock, write and relock them.
+type Locker interface {
+	// FetchLocked reports whether the entry is locked. A missing entry is not.
+	FetchLocked(ctx context.Context, name string) (bool, error)
+	// Lock marks the entry read-only.
+	Lock(ctx context.Context, name string) error
+	// Unlock clears the entry's read-only flag.
+	Unlock(ctx context.Context, name string) error
+}
+
+// BaseFetcher is the optional strategy extension for services that keep a
+// version history. Conflict resolution type-asserts for it to recover the value
+// a staged change was based on, so it can three-way merge instead of asking
+// for a reset.
+type BaseFetcher interface {
+	// FetchBase returns the value the staged entry was based on: the given
+	// version (the entry's BaseVersion) when non-empty, else the version current
+	// at the given time (its BaseModifiedAt). It returns ErrBaseUnavailable when
+	// no retained version matches.
+	FetchBase(ctx context.Context, name, version string, at time.Time) (string, error)
+}
+
+// VersionProber is the optional strategy extension for services whose remote
+// entries carry a version identifier. Conflict detection type-asserts for it so
+// a staged entry's BaseVersion can be compared with the current version rather
+// than comparing modification times.
+type VersionProber interface {
+	// FetchRemoteState returns the entry's current state in one read. Like
+	// FetchLastModified it returns a *ResourceNotFoundError when the entry does
+	// not exist.
+	FetchRemoteState(ctx context.Context, name string) (RemoteState, error)
+}
+
+// Snapshot is a remote entry's state captured before an apply writes it, so
+// the write can be undone. The apply journal records it as an applied entry's
+// previous state.
+type Snapshot struct {
+	// Exists reports whether the entry existed. The other fields are only set
+	// when it did.
+	Exists bool   `json:"exists"`
+	Value  string `json:"value,omitempty"`
+	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
+	ValueType   domain.ValueType `json:"value_type,omitempty"`
+	Description string           `json:"description,omitempty"`
+	// Version is the entry's version identifier at snapshot time.
+	Version string            `json:"version,omitempty"`
+	Tags    map[string]string `json:"tags,omitempty"`
+}
+
+// Rollbacker is the optional ApplyStrategy extension atomic apply requires: it
+// snapshots every target before writing and, when any write fails, rolls the
+// ones that succeeded back to their snapshots.
+type Rollbacker interface {
+	// Snapshot captures the entry's current state. A missing entry yields a
+	// Snapshot with Exists false, not an error.
+	Snapshot(ctx context.Context, name string) (Snapshot, error)
+	// Rollback returns the entry to snap: it deletes an entry that did not
+	// exist, re-creates (restoring, where the provider can) one that was
+	// deleted, puts back a changed value and re-syncs the tags. Rolling back an
+	// entry that already matches snap is a no-op.
+	Rollback(ctx context.Context, name string, snap Snapshot) error
+}
+
+// LastModifiedFetcher is the part of ApplyStrategy and DeleteStrategy that
+// FetchRemoteState falls back to.
+type LastModifiedFetcher interface {
+	FetchLastModified(ctx context.Context, name string) (time.Time, error)
+}
+
+// FetchRemoteState returns the remote state of name through VersionProber when
+// the strategy implements it, and otherwise its last modification time alone.
+func FetchRemoteState(ctx context.Context, strategy LastModifiedFetcher, name string) (RemoteState, error) {
+	if prober, ok := strategy.(VersionProber); ok {
+		return prober.FetchRemoteState(ctx, name)
+	}
+
+	lastModified, err := strategy.FetchLastModified(ctx, name)
+	if err != nil {
+		return RemoteState{}, err
+	}
+
+	return RemoteState{LastModified: lastModified}, nil
+}
+
 // FullStrategy combines all service-specific strategy interfaces.
 // This enables unified stage commands that work with either SSM Parameter Store or Secrets Manager.
 type FullStrategy interface {
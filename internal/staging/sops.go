@@ -0,0 +1,286 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/version/sopsversion"
+)
+
+// SOPSStrategy implements the staging strategies for the leaf keys of a
+// SOPS-encrypted file. Like the Vault strategy it is backed by a
+// provider.Store and carries no client dependency:
+//
+//   - Versions are the git commits that changed a key, parsed with sopsversion
+//     (#COMMIT, ~SHIFT); a staged "edit" applies as a rewrite of the working
+//     file via Put.
+//   - A delete removes the key from the file with no options, so
+//     HasDeleteOptions reports false.
+//   - Keys carry no description or tags, so neither is ever written.
+//
+// A nil store yields a parser-only strategy (ParseName/ParseSpec).
+type SOPSStrategy struct {
+	store provider.Store
+}
+
+// NewSOPSStrategy creates a SOPS file staging strategy over the given provider
+// store. A nil store is allowed for parser-only use.
+func NewSOPSStrategy(store provider.Store) *SOPSStrategy {
+	return &SOPSStrategy{store: store}
+}
+
+// Service returns the service type.
+func (s *SOPSStrategy) Service() Service {
+	return ServiceSecret
+}
+
+// ServiceName returns the user-friendly service name.
+func (s *SOPSStrategy) ServiceName() string {
+	return "SOPS"
+}
+
+// ItemName returns the item name for messages.
+func (s *SOPSStrategy) ItemName() string {
+	return itemNameSecret
+}
+
+// HasDeleteOptions returns false: removing a key from a SOPS file has no force
+// / recovery-window options.
+func (s *SOPSStrategy) HasDeleteOptions() bool {
+	return false
+}
+
+// Apply applies a staged operation to the SOPS file.
+func (s *SOPSStrategy) Apply(ctx context.Context, name string, entry Entry) error {
+	switch entry.Operation {
+	case OperationCreate:
+		if restored, err := restoreDeleted(ctx, s.store, name, s.ItemName(), entry); restored || err != nil {
+			return err
+		}
+
+		return s.applyCreate(ctx, name, entry)
+	case OperationUpdate:
+		return s.applyUpdate(ctx, name, entry)
+	case OperationDelete:
+		return s.applyDelete(ctx, name)
+	default:
+		return fmt.Errorf("unknown operation: %s", entry.Operation)
+	}
+}
+
+func (s *SOPSStrategy) applyCreate(ctx context.Context, name string, entry Entry) error {
+	if _, err := s.store.Create(ctx, name, lo.FromPtr(entry.Value), domain.ValueTypeSecret, "", writeOptions(ctx)...); err != nil {
+		return fmt.Errorf("failed to create key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SOPSStrategy) applyUpdate(ctx context.Context, name string, entry Entry) error {
+	if entry.Value == nil {
+		return nil
+	}
+
+	if _, err := s.store.Put(ctx, name, *entry.Value, domain.ValueTypeSecret, "", writeOptions(ctx)...); err != nil {
+		return fmt.Errorf("failed to update key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SOPSStrategy) applyDelete(ctx context.Context, name string) error {
+	if err := s.store.Delete(ctx, name); err != nil {
+		// Already deleted is considered success.
+		if errors.Is(err, provider.ErrNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to delete key: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyTags passes staged tag changes to the store, which rejects any non-empty
+// change: SOPS keys carry no tags.
+func (s *SOPSStrategy) ApplyTags(ctx context.Context, name string, tagEntry TagEntry) error {
+	if len(tagEntry.Add) > 0 {
+		if err := s.store.Tag(ctx, name, tagEntry.Add); err != nil {
+			return err
+		}
+	}
+
+	if tagEntry.Remove.Len() > 0 {
+		if err := s.store.Untag(ctx, name, tagEntry.Remove.Values()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FetchLastModified returns the last modified time of the file holding the
+// key. It returns a *ResourceNotFoundError when the key does not exist, so
+// callers can tell "missing" apart from "exists but has no modification time"
+// (the latter returns a zero time with a nil error).
+func (s *SOPSStrategy) FetchLastModified(ctx context.Context, name string) (time.Time, error) {
+	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return time.Time{}, &ResourceNotFoundError{Err: err}
+		}
+
+		return time.Time{}, fmt.Errorf("failed to get key: %w", err)
+	}
+
+	if entry.Modified != nil {
+		return *entry.Modified, nil
+	}
+
+	return time.Time{}, nil
+}
+
+// FetchBase returns the key's value as of the given time from the git history
+// of the SOPS file.
+func (s *SOPSStrategy) FetchBase(ctx context.Context, name, version string, at time.Time) (string, error) {
+	return fetchValueAt(ctx, s.store, name, version, at)
+}
+
+// Snapshot captures the secret's current state in the working file before an
+// atomic apply.
+func (s *SOPSStrategy) Snapshot(ctx context.Context, name string) (Snapshot, error) {
+	return snapshotEntry(ctx, s.store, name, itemNameSecret)
+}
+
+// Rollback returns the secret in the working file to a snapshot taken before an
+// atomic apply.
+func (s *SOPSStrategy) Rollback(ctx context.Context, name string, snap Snapshot) error {
+	return rollbackEntry(ctx, s.store, name, itemNameSecret, snap)
+}
+
+// FetchCurrent fetches the current value from the working file for diffing.
+// The working file has no commit of its own, so there is no identifier.
+func (s *SOPSStrategy) FetchCurrent(ctx context.Context, name string) (*FetchResult, error) {
+	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		return nil, err
+	}
+
+	var identifier string
+	if entry.Version.ID != "" {
+		identifier = "#" + entry.Version.ID
+	}
+
+	return &FetchResult{
+		Value:      entry.Value,
+		Identifier: identifier,
+		Secret:     true, // SOPS exists to hold secret material.
+	}, nil
+}
+
+// FetchCurrentTags returns no tags: SOPS keys carry none.
+func (s *SOPSStrategy) FetchCurrentTags(context.Context, string) (map[string]string, error) {
+	return nil, nil //nolint:nilnil // intentional: SOPS keys have no tags
+}
+
+// ParseName parses and validates a name for editing (no version specifier).
+func (s *SOPSStrategy) ParseName(input string) (string, error) {
+	spec, err := sopsversion.Parse(input)
+	if err != nil {
+		return "", err
+	}
+
+	if spec.Absolute.Commit != nil || spec.Shift > 0 {
+		return "", fmt.Errorf("key must not contain a version specifier")
+	}
+
+	return spec.Name, nil
+}
+
+// FetchCurrentValue fetches the current value from the working file for
+// editing. Returns *ResourceNotFoundError if the key doesn't exist.
+func (s *SOPSStrategy) FetchCurrentValue(ctx context.Context, name string) (*EditFetchResult, error) {
+	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return nil, &ResourceNotFoundError{Err: err}
+		}
+
+		return nil, err
+	}
+
+	result := &EditFetchResult{
+		Value: entry.Value,
+	}
+
+	if entry.Modified != nil {
+		result.LastModified = *entry.Modified
+	}
+
+	return result, nil
+}
+
+// ParseSpec parses a version spec string for reset.
+func (s *SOPSStrategy) ParseSpec(input string) (name string, hasVersion bool, err error) {
+	spec, err := sopsversion.Parse(input)
+	if err != nil {
+		return "", false, err
+	}
+
+	hasVersion = spec.Absolute.Commit != nil || spec.Shift > 0
+
+	return spec.Name, hasVersion, nil
+}
+
+// FetchVersion fetches the value for a specific version.
+func (s *SOPSStrategy) FetchVersion(ctx context.Context, input string) (value string, versionLabel string, err error) {
+	spec, err := sopsversion.Parse(input)
+	if err != nil {
+		return "", "", err
+	}
+
+	ref, err := s.store.Resolve(ctx, spec.Name, sopsSpecSuffix(spec))
+	if err != nil {
+		return "", "", err
+	}
+
+	entry, err := s.store.Get(ctx, spec.Name, ref)
+	if err != nil {
+		return "", "", err
+	}
+
+	return entry.Value, "#" + entry.Version.ID, nil
+}
+
+// sopsSpecSuffix reconstructs the version-spec suffix (the part after the
+// name) so that name+suffix re-parses to an equivalent spec, as
+// provider.Reader.Resolve expects.
+func sopsSpecSuffix(spec *sopsversion.Spec) string {
+	var b strings.Builder
+
+	if spec.Absolute.Commit != nil {
+		b.WriteString("#")
+		b.WriteString(*spec.Absolute.Commit)
+	}
+
+	if spec.Shift > 0 {
+		b.WriteString("~")
+		b.WriteString(strconv.Itoa(spec.Shift))
+	}
+
+	return b.String()
+}
+
+// SOPSParserFactory creates a Parser without provider access, for operations
+// that don't need to decrypt the file (e.g. status, parsing).
+func SOPSParserFactory() Parser {
+	return NewSOPSStrategy(nil)
+}
@@ -0,0 +1,157 @@
+package staging_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+func TestSOPSStrategy_BasicMethods(t *testing.T) {
+	t.Parallel()
+
+	s := staging.NewSOPSStrategy(nil)
+
+	assert.Equal(t, staging.ServiceSecret, s.Service())
+	assert.Equal(t, "SOPS", s.ServiceName())
+	assert.Equal(t, "secret", s.ItemName())
+	assert.False(t, s.HasDeleteOptions())
+}
+
+func TestSOPSStrategy_Apply(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create ignores a staged description", func(t *testing.T) {
+		t.Parallel()
+
+		store := &providermock.Store{
+			CreateFunc: func(_ context.Context, name, value string, _ domain.ValueType, desc string, _ ...provider.WriteOption) (domain.Version, error) {
+				assert.Equal(t, "db.password", name)
+				assert.Equal(t, "v1", value)
+				assert.Empty(t, desc)
+
+				return domain.Version{ID: "worktree"}, nil
+			},
+		}
+
+		err := staging.NewSOPSStrategy(store).Apply(t.Context(), "db.password", staging.Entry{
+			Operation:   staging.OperationCreate,
+			Value:       lo.ToPtr("v1"),
+			Description: lo.ToPtr("ignored"),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("update rewrites the key via Put", func(t *testing.T) {
+		t.Parallel()
+
+		var putCalled bool
+
+		store := &providermock.Store{
+			PutFunc: func(_ context.Context, _, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption) (domain.Version, error) {
+				putCalled = true
+
+				assert.Equal(t, "v2", value)
+
+				return domain.Version{ID: "worktree"}, nil
+			},
+		}
+
+		err := staging.NewSOPSStrategy(store).Apply(t.Context(), "db.password",
+			staging.Entry{Operation: staging.OperationUpdate, Value: lo.ToPtr("v2")})
+		require.NoError(t, err)
+		assert.True(t, putCalled)
+	})
+
+	t.Run("delete of a missing key is success", func(t *testing.T) {
+		t.Parallel()
+
+		store := &providermock.Store{
+			DeleteFunc: func(_ context.Context, _ string, _ ...provider.DeleteOption) error {
+				return provider.ErrNotFound
+			},
+		}
+
+		err := staging.NewSOPSStrategy(store).Apply(t.Context(), "db.password", staging.Entry{Operation: staging.OperationDelete})
+		require.NoError(t, err)
+	})
+}
+
+func TestSOPSStrategy_FetchCurrent(t *testing.T) {
+	t.Parallel()
+
+	modified := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store := &providermock.Store{
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			if name == "missing" {
+				return nil, provider.ErrNotFound
+			}
+
+			return &domain.Entry{Name: name, Value: "pw", Modified: &modified}, nil
+		},
+	}
+
+	s := staging.NewSOPSStrategy(store)
+
+	result, err := s.FetchCurrent(t.Context(), "db.password")
+	require.NoError(t, err)
+	assert.Equal(t, "pw", result.Value)
+	assert.Empty(t, result.Identifier, "the working file has no commit")
+	assert.True(t, result.Secret)
+
+	lastModified, err := s.FetchLastModified(t.Context(), "db.password")
+	require.NoError(t, err)
+	assert.Equal(t, modified, lastModified)
+
+	_, err = s.FetchLastModified(t.Context(), "missing")
+
+	var notFound *staging.ResourceNotFoundError
+	require.ErrorAs(t, err, &notFound)
+
+	tags, err := s.FetchCurrentTags(t.Context(), "db.password")
+	require.NoError(t, err)
+	assert.Nil(t, tags)
+}
+
+func TestSOPSStrategy_ParseAndFetchVersion(t *testing.T) {
+	t.Parallel()
+
+	s := staging.NewSOPSStrategy(nil)
+
+	name, err := s.ParseName("db.password")
+	require.NoError(t, err)
+	assert.Equal(t, "db.password", name)
+
+	_, err = s.ParseName("db.password#abc123")
+	require.Error(t, err)
+
+	name, hasVersion, err := s.ParseSpec("db.password~1")
+	require.NoError(t, err)
+	assert.Equal(t, "db.password", name)
+	assert.True(t, hasVersion)
+
+	store := &providermock.Store{
+		ResolveFunc: func(_ context.Context, _, spec string) (provider.VersionRef, error) {
+			assert.Equal(t, "#abc123~1", spec)
+
+			return provider.NewVersionRef("0123456789ab"), nil
+		},
+		GetFunc: func(_ context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{Name: name, Value: "old", Version: domain.Version{ID: ref.ID()}}, nil
+		},
+	}
+
+	value, label, err := staging.NewSOPSStrategy(store).FetchVersion(t.Context(), "db.password#abc123~1")
+	require.NoError(t, err)
+	assert.Equal(t, "old", value)
+	assert.Equal(t, "#0123456789ab", label)
+}
@@ -101,10 +101,28 @@ type Entry struct {
 	// Only set for update/delete operations (nil for create since there's no base).
 	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
 	BaseModifiedAt *time.Time `json:"base_modified_at,omitempty"`
+	// BaseVersion records the remote version identifier the value was fetched
+	// at (SSM Parameter Store version number, Secrets Manager version id, Secret
+	// Manager / Key Vault / Vault version, App Configuration ETag). Conflict
+	// detection compares it instead of BaseModifiedAt where both sides have one,
+	// which is immune to clock skew and same-second writes. Empty when the
+	// provider has no version identifier or the entry predates this field.
+	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
+	BaseVersion string `json:"base_version,omitempty"`
 	// DeleteOptions holds Secrets Manager-specific delete options.
 	// Only used when Operation is OperationDelete and service is Secrets Manager.
 	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
 	DeleteOptions *DeleteOptions `json:"delete_options,omitempty"`
+	// Attributes holds Azure Key Vault secret attributes written with the
+	// staged value. Only used for create/update on the Key Vault axis, and for
+	// its content type on the App Configuration axis; nil writes the new
+	// version without attributes (and keeps a setting's content type).
+	Attributes *SecretAttributes `json:"attributes,omitempty"`
+	// Restore marks a create staged by `stage undo` to bring back an entry an
+	// earlier apply deleted: apply first tries to cancel the deletion where the
+	// provider keeps deleted entries recoverable (provider.Restorer), and only
+	// creates the entry afresh when that is not possible.
+	Restore bool `json:"restore,omitempty"`
 }
 
 // TagEntry represents staged tag changes for an entity.
@@ -131,6 +149,17 @@ type DeleteOptions struct {
 	RecoveryWindow int `json:"recovery_window,omitempty"`
 }
 
+// SecretAttributes holds the Azure Key Vault attributes of a staged secret
+// version. A nil field leaves that attribute unset.
+type SecretAttributes struct {
+	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
+	ContentType *string `json:"content_type,omitempty"`
+	//nolint:tagliatelle // JSON uses snake_case for consistency with file storage format
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	Expires   *time.Time `json:"expires,omitempty"`
+	Enabled   *bool      `json:"enabled,omitempty"`
+}
+
 // State represents the entire staging state (v3). Entries and Tags are keyed by
 // EntryKey (name + namespace) and managed separately for cleaner separation of
 // concerns. On disk each item is a structured record carrying its name and
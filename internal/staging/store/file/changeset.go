@@ -0,0 +1,309 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// Named changesets let one scope hold several independent staging areas, e.g.
+// an urgent hotfix staged while a larger migration is half-staged. Each is a
+// complete working store of its own (per-service files, its own lock),
+// encrypted with the same data key:
+//
+//	~/.suve/staging/{scope.Key()}/                     (the default changeset)
+//	~/.suve/staging/{scope.Key()}/changesets/{name}/   (a named changeset)
+//	~/.suve/staging/{scope.Key()}/HEAD                 (the active changeset's name)
+//
+// A missing HEAD means the default changeset is active, so a scope that never
+// used changesets keeps its historical layout untouched.
+const (
+	// DefaultChangeset names the scope's original staging area.
+	DefaultChangeset = "default"
+
+	changesetsDirName = "changesets"
+	headFileName      = "HEAD"
+	maxChangesetName  = 64
+)
+
+var (
+	// ErrChangesetNotFound is returned for a named changeset that was never
+	// created.
+	ErrChangesetNotFound = errors.New("changeset not found")
+	// ErrChangesetExists is returned when creating a changeset that exists.
+	ErrChangesetExists = errors.New("changeset already exists")
+)
+
+// changesetNamePattern restricts names to a single portable path segment.
+var changesetNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateChangesetName reports whether name can name a changeset.
+func ValidateChangesetName(name string) error {
+	if len(name) > maxChangesetName || !changesetNamePattern.MatchString(name) {
+		return fmt.Errorf(
+			"invalid changeset name %q: use up to %d letters, digits, '.', '_' or '-', starting with a letter or digit",
+			name, maxChangesetName)
+	}
+
+	return nil
+}
+
+// changesetDir returns the directory holding the named changeset of scope.
+func changesetDir(scope provider.Scope, name string) (string, error) {
+	dir, err := scopeDir(scope)
+	if err != nil {
+		return "", err
+	}
+
+	if name == DefaultChangeset {
+		return dir, nil
+	}
+
+	if err := ValidateChangesetName(name); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, changesetsDirName, name), nil
+}
+
+// requireChangeset returns ErrChangesetNotFound unless the named changeset of
+// scope exists. The default changeset always exists.
+func requireChangeset(scope provider.Scope, name string) error {
+	if name == DefaultChangeset {
+		return nil
+	}
+
+	dir, err := changesetDir(scope, name)
+	if err != nil {
+		return err
+	}
+
+	ok, err := fileExists(dir)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrChangesetNotFound, name)
+	}
+
+	return nil
+}
+
+// ActiveChangeset returns the name of the scope's active changeset. A HEAD
+// that names a changeset no longer on disk falls back to the default.
+func ActiveChangeset(scope provider.Scope) (string, error) {
+	dir, err := scopeDir(scope)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, headFileName)) //nolint:gosec // path is internal, not user input
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultChangeset, nil
+		}
+
+		return "", fmt.Errorf("failed to read active changeset: %w", err)
+	}
+
+	name := strings.TrimSpace(string(data))
+	if name == "" || requireChangeset(scope, name) != nil {
+		return DefaultChangeset, nil
+	}
+
+	return name, nil
+}
+
+// ListChangesets returns the scope's changesets: the default one first, then
+// the named ones in name order.
+func ListChangesets(scope provider.Scope) ([]string, error) {
+	dir, err := scopeDir(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	dirents, err := os.ReadDir(filepath.Join(dir, changesetsDirName))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to list changesets: %w", err)
+	}
+
+	var names []string
+
+	for _, d := range dirents {
+		if d.IsDir() && ValidateChangesetName(d.Name()) == nil && d.Name() != DefaultChangeset {
+			names = append(names, d.Name())
+		}
+	}
+
+	slices.Sort(names)
+
+	return append([]string{DefaultChangeset}, names...), nil
+}
+
+// CreateChangeset creates an empty named changeset in scope.
+func CreateChangeset(scope provider.Scope, name string) error {
+	if name == DefaultChangeset {
+		return fmt.Errorf("%w: %s", ErrChangesetExists, name)
+	}
+
+	dir, err := changesetDir(scope, name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0o700); err != nil { //nolint:mnd // owner-only directory permissions
+		return fmt.Errorf("failed to create changeset: %w", err)
+	}
+
+	if err := os.Mkdir(dir, 0o700); err != nil { //nolint:mnd // owner-only directory permissions
+		if os.IsExist(err) {
+			return fmt.Errorf("%w: %s", ErrChangesetExists, name)
+		}
+
+		return fmt.Errorf("failed to create changeset: %w", err)
+	}
+
+	return nil
+}
+
+// SwitchChangeset makes the named changeset the scope's active one.
+func SwitchChangeset(scope provider.Scope, name string) error {
+	if err := requireChangeset(scope, name); err != nil {
+		return err
+	}
+
+	dir, err := scopeDir(scope)
+	if err != nil {
+		return err
+	}
+
+	head := filepath.Join(dir, headFileName)
+
+	if name == DefaultChangeset {
+		if err := os.Remove(head); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to switch changeset: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := writeFileAtomic(head, []byte(name+"\n")); err != nil {
+		return fmt.Errorf("failed to switch changeset: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteChangeset removes a named changeset and everything staged in it. The
+// default changeset cannot be deleted.
+func DeleteChangeset(scope provider.Scope, name string) error {
+	if name == DefaultChangeset {
+		return errors.New("the default changeset cannot be deleted")
+	}
+
+	if err := requireChangeset(scope, name); err != nil {
+		return err
+	}
+
+	dir, err := changesetDir(scope, name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to delete changeset: %w", err)
+	}
+
+	return nil
+}
+
+// ListChangesetsIn lists the changesets across scopes — the scopes of one
+// provider's services, which switch together: the default one first, then the
+// union of the named ones in name order, plus the first scope's active one.
+func ListChangesetsIn(scopes []provider.Scope) ([]string, string, error) {
+	active := DefaultChangeset
+
+	var named []string
+
+	for i, scope := range scopes {
+		names, err := ListChangesets(scope)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, name := range names[1:] {
+			if !slices.Contains(named, name) {
+				named = append(named, name)
+			}
+		}
+
+		if i == 0 {
+			if active, err = ActiveChangeset(scope); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	slices.Sort(named)
+
+	return append([]string{DefaultChangeset}, named...), active, nil
+}
+
+// SwitchChangesetIn switches every scope to the named changeset, creating it
+// wherever it is missing so a provider's services (e.g. App Configuration and
+// Key Vault) stay on the same one. With create the changeset must not exist in
+// every scope yet; without it, it must exist in at least one.
+func SwitchChangesetIn(scopes []provider.Scope, name string, create bool) error {
+	if err := ValidateChangesetName(name); err != nil {
+		return err
+	}
+
+	missing := make([]bool, len(scopes))
+	found := 0
+
+	for i, scope := range scopes {
+		err := requireChangeset(scope, name)
+
+		switch {
+		case errors.Is(err, ErrChangesetNotFound):
+			missing[i] = true
+		case err != nil:
+			return err
+		default:
+			found++
+		}
+	}
+
+	switch {
+	case create && found == len(scopes):
+		return fmt.Errorf("%w: %s", ErrChangesetExists, name)
+	case !create && found == 0:
+		return fmt.Errorf("%w: %s", ErrChangesetNotFound, name)
+	}
+
+	for i, scope := range scopes {
+		if missing[i] {
+			if err := CreateChangeset(scope, name); err != nil {
+				return err
+			}
+		}
+
+		if err := SwitchChangeset(scope, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Changeset returns the name of the changeset the store reads and writes.
+func (s *Store) Changeset() string {
+	return s.changeset
+}
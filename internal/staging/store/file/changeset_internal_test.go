@@ -0,0 +1,114 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// TestChangesets exercises create / switch / list / delete and that each
+// changeset is a separate working store.
+//
+//nolint:paralleltest // newSplitStore overrides package-level userHomeDirFunc
+func TestChangesets(t *testing.T) {
+	def := newSplitStore(t)
+	scope := def.scope
+	key := staging.EntryKey{Name: "/app/a"}
+
+	active, err := ActiveChangeset(scope)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultChangeset, active)
+
+	require.NoError(t, CreateChangeset(scope, "rotate-db"))
+	require.ErrorIs(t, CreateChangeset(scope, "rotate-db"), ErrChangesetExists)
+	require.Error(t, CreateChangeset(scope, "../escape"))
+	require.ErrorIs(t, SwitchChangeset(scope, "missing"), ErrChangesetNotFound)
+
+	require.NoError(t, SwitchChangeset(scope, "rotate-db"))
+
+	active, err = ActiveChangeset(scope)
+	require.NoError(t, err)
+	assert.Equal(t, "rotate-db", active)
+
+	named, err := newChangesetStore(scope, "rotate-db")
+	require.NoError(t, err)
+
+	named.key = def.key
+	require.NoError(t, named.StageEntry(t.Context(), staging.ServiceParam, key, staging.Entry{
+		Operation: staging.OperationUpdate, Value: lo.ToPtr("v"),
+	}))
+
+	_, err = def.GetEntry(t.Context(), staging.ServiceParam, key)
+	require.ErrorIs(t, err, staging.ErrNotStaged, "changesets do not share entries")
+
+	dir, err := scopeDir(scope)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, changesetsDirName, "rotate-db", "param.json"))
+
+	names, err := ListChangesets(scope)
+	require.NoError(t, err)
+	assert.Equal(t, []string{DefaultChangeset, "rotate-db"}, names)
+
+	require.NoError(t, DeleteChangeset(scope, "rotate-db"))
+	require.Error(t, DeleteChangeset(scope, DefaultChangeset))
+
+	active, err = ActiveChangeset(scope)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultChangeset, active, "a HEAD naming a deleted changeset falls back to the default")
+
+	require.NoError(t, SwitchChangeset(scope, DefaultChangeset))
+
+	_, err = os.Stat(filepath.Join(dir, headFileName))
+	assert.True(t, os.IsNotExist(err), "switching to the default removes HEAD")
+}
+
+// TestNewChangesetStore_NotFound asserts a named changeset must exist to be
+// opened.
+//
+//nolint:paralleltest // newSplitStore overrides package-level userHomeDirFunc
+func TestNewChangesetStore_NotFound(t *testing.T) {
+	newSplitStore(t)
+
+	_, err := NewChangesetStore(provider.AWSScope("123456789012", "ap-northeast-1"), "nope")
+	require.ErrorIs(t, err, ErrChangesetNotFound)
+}
+
+// TestSwitchChangesetIn asserts a switch across a provider's scopes creates the
+// changeset wherever it is missing and keeps every scope on it.
+//
+//nolint:paralleltest // newSplitStore overrides package-level userHomeDirFunc
+func TestSwitchChangesetIn(t *testing.T) {
+	newSplitStore(t)
+
+	appConfig := provider.AzureAppConfigScope("store")
+	keyVault := provider.AzureKeyVaultScope("vault")
+	scopes := []provider.Scope{appConfig, keyVault}
+
+	require.ErrorIs(t, SwitchChangesetIn(scopes, "hotfix", false), ErrChangesetNotFound)
+	require.NoError(t, CreateChangeset(keyVault, "hotfix"))
+	require.NoError(t, SwitchChangesetIn(scopes, "hotfix", false), "one scope having it is enough")
+
+	names, active, err := ListChangesetsIn(scopes)
+	require.NoError(t, err)
+	assert.Equal(t, []string{DefaultChangeset, "hotfix"}, names)
+	assert.Equal(t, "hotfix", active)
+
+	vaultActive, err := ActiveChangeset(keyVault)
+	require.NoError(t, err)
+	assert.Equal(t, "hotfix", vaultActive)
+
+	require.ErrorIs(t, SwitchChangesetIn(scopes, "hotfix", true), ErrChangesetExists)
+	require.NoError(t, SwitchChangesetIn(scopes, "rotate-db", true))
+
+	names, active, err = ListChangesetsIn(scopes)
+	require.NoError(t, err)
+	assert.Equal(t, []string{DefaultChangeset, "hotfix", "rotate-db"}, names)
+	assert.Equal(t, "rotate-db", active)
+}
@@ -10,9 +10,9 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/mpyw/suve/internal/crypt"
 	"github.com/mpyw/suve/internal/provider"
 	"github.com/mpyw/suve/internal/staging"
-	"github.com/mpyw/suve/internal/staging/store/file/internal/crypt"
 )
 
 // EnvelopeVersion is the current export/import envelope schema version.
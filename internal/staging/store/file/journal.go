@@ -0,0 +1,243 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// The apply journal records every apply of a scope, whichever changeset it was
+// applied from, one encrypted file per apply under the scope directory:
+//
+//	~/.suve/staging/{scope.Key()}/journal/{id}.json
+//
+// IDs have the stash ID format (see NewStashID), so name order is apply order.
+// Only the newest journalLimit records are kept.
+const (
+	journalDirName = "journal"
+	journalExt     = ".json"
+	journalLimit   = 100
+)
+
+// ErrJournalNotFound is returned for an apply ID the journal does not hold.
+var ErrJournalNotFound = errors.New("apply not found in journal")
+
+// identityFunc returns who is applying, for ApplyRecord.User; tests replace it.
+//
+//nolint:gochecknoglobals // test hook for dependency injection
+var identityFunc = currentIdentity
+
+// NewJournalID returns an ID for an apply recorded now.
+func NewJournalID() string {
+	return NewStashID()
+}
+
+// journalDir returns the scope's journal directory.
+func (s *Store) journalDir() (string, error) {
+	dir, err := scopeDir(s.scope)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, journalDirName), nil
+}
+
+// AppendJournal records an apply. An empty ID, AppliedAt, User or Changeset
+// is filled in (see NewJournalID; the changeset is the store's). Records beyond
+// the newest journalLimit are pruned.
+func (s *Store) AppendJournal(_ context.Context, rec *staging.ApplyRecord) error {
+	if rec.ID == "" {
+		rec.ID = NewJournalID()
+	}
+
+	if rec.Changeset == "" {
+		rec.Changeset = s.changeset
+	}
+
+	if !stashIDPattern.MatchString(rec.ID) {
+		return fmt.Errorf("invalid apply id %q", rec.ID)
+	}
+
+	if rec.AppliedAt.IsZero() {
+		rec.AppliedAt = nowFunc().UTC()
+	}
+
+	if rec.User == "" {
+		rec.User = identityFunc()
+	}
+
+	dir, err := s.journalDir()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal apply record: %w", err)
+	}
+
+	data, err = s.seal(data)
+	if err != nil {
+		return err
+	}
+
+	defer s.lock()()
+
+	if err := os.MkdirAll(dir, 0o700); err != nil { //nolint:mnd // owner-only directory permissions
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	if err := writeFileAtomic(filepath.Join(dir, rec.ID+journalExt), data); err != nil {
+		return fmt.Errorf("failed to write apply record: %w", err)
+	}
+
+	ids, err := journalIDs(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids[min(len(ids), journalLimit):] {
+		if err := os.Remove(filepath.Join(dir, id+journalExt)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to prune journal: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Journal returns the scope's apply records, newest first.
+func (s *Store) Journal(ctx context.Context) ([]*staging.ApplyRecord, error) {
+	dir, err := s.journalDir()
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := journalIDs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*staging.ApplyRecord, 0, len(ids))
+
+	for _, id := range ids {
+		rec, err := s.ReadJournal(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+// ReadJournal returns one apply record. It returns ErrJournalNotFound when the
+// journal has no apply with that ID.
+func (s *Store) ReadJournal(_ context.Context, id string) (*staging.ApplyRecord, error) {
+	if !stashIDPattern.MatchString(id) {
+		return nil, fmt.Errorf("%w: %s", ErrJournalNotFound, id)
+	}
+
+	dir, err := s.journalDir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, id+journalExt)) //nolint:gosec // id is validated above
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrJournalNotFound, id)
+		}
+
+		return nil, fmt.Errorf("failed to read apply record: %w", err)
+	}
+
+	data, err = s.open(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var rec staging.ApplyRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("failed to parse apply record %s: %w", id, err)
+	}
+
+	return &rec, nil
+}
+
+// journalEncrypted reports whether any apply record of the scope is encrypted,
+// for the key-loss guard (see scopeEncrypted).
+func (s *Store) journalEncrypted() (bool, error) {
+	dir, err := s.journalDir()
+	if err != nil {
+		return false, err
+	}
+
+	ids, err := journalIDs(dir)
+	if err != nil {
+		return false, err
+	}
+
+	for _, id := range ids {
+		if encrypted, err := isFileEncrypted(filepath.Join(dir, id+journalExt)); err != nil || encrypted {
+			return encrypted, err
+		}
+	}
+
+	return false, nil
+}
+
+// journalIDs lists the apply IDs recorded in dir, newest first.
+func journalIDs(dir string) ([]string, error) {
+	dirents, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to list journal: %w", err)
+	}
+
+	var ids []string
+
+	for _, d := range dirents {
+		id, ok := strings.CutSuffix(d.Name(), journalExt)
+		if d.IsDir() || !ok || !stashIDPattern.MatchString(id) {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	slices.SortFunc(ids, func(a, b string) int { return strings.Compare(b, a) })
+
+	return ids, nil
+}
+
+// currentIdentity returns the OS user and host as user@host, leaving out the
+// parts that cannot be determined.
+func currentIdentity() string {
+	name := os.Getenv("USER")
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		name = u.Username
+	}
+
+	host, _ := os.Hostname()
+
+	switch {
+	case name == "":
+		return host
+	case host == "":
+		return name
+	default:
+		return name + "@" + host
+	}
+}
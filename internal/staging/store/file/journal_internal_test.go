@@ -0,0 +1,101 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/crypt"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// TestJournal exercises append / list / read, the fields AppendJournal fills
+// in, and that apply records are stored encrypted under the scope directory.
+//
+//nolint:paralleltest // newSplitStore and the clock/identity overrides patch package-level hooks
+func TestJournal(t *testing.T) {
+	s := newSplitStore(t)
+
+	origNow, origIdentity := nowFunc, identityFunc
+	t.Cleanup(func() { nowFunc, identityFunc = origNow, origIdentity })
+
+	identityFunc = func() string { return "alice@host" }
+
+	records, err := s.Journal(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	item := staging.AppliedItem{
+		Service:   staging.ServiceParam,
+		Name:      "/app/a",
+		Operation: staging.OperationUpdate,
+		Previous:  staging.Snapshot{Exists: true, Value: "old", Version: "1"},
+	}
+
+	nowFunc = func() time.Time { return time.Unix(100, 0) }
+	older := &staging.ApplyRecord{Items: []staging.AppliedItem{item}}
+	require.NoError(t, s.AppendJournal(t.Context(), older))
+	assert.NotEmpty(t, older.ID)
+	assert.Equal(t, "alice@host", older.User)
+	assert.Equal(t, DefaultChangeset, older.Changeset)
+	assert.True(t, older.AppliedAt.Equal(time.Unix(100, 0)))
+
+	nowFunc = func() time.Time { return time.Unix(200, 0) }
+	newer := &staging.ApplyRecord{User: "bob@ci", Items: []staging.AppliedItem{item}}
+	require.NoError(t, s.AppendJournal(t.Context(), newer))
+	assert.Equal(t, "bob@ci", newer.User, "a set user is kept")
+
+	require.Error(t, s.AppendJournal(t.Context(), &staging.ApplyRecord{ID: "../escape"}))
+
+	records, err = s.Journal(t.Context())
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, newer.ID, records[0].ID, "newest first")
+	assert.Equal(t, older.ID, records[1].ID)
+
+	dir, err := s.journalDir()
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, older.ID+journalExt))
+	require.NoError(t, err)
+	assert.True(t, crypt.IsEncrypted(data), "journaled values are encrypted")
+
+	got, err := s.ReadJournal(t.Context(), older.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []staging.AppliedItem{item}, got.Items)
+
+	encrypted, err := s.scopeEncrypted()
+	require.NoError(t, err)
+	assert.True(t, encrypted, "an encrypted journal counts for the key-loss guard")
+
+	_, err = s.ReadJournal(t.Context(), "00000000000000000001")
+	require.ErrorIs(t, err, ErrJournalNotFound)
+
+	_, err = s.ReadJournal(t.Context(), "../escape")
+	require.ErrorIs(t, err, ErrJournalNotFound)
+}
+
+// TestJournal_Prune pins that only the newest journalLimit applies are kept.
+//
+//nolint:paralleltest // newSplitStore patches package-level hooks
+func TestJournal_Prune(t *testing.T) {
+	s := newSplitStore(t)
+
+	for i := range journalLimit + 2 {
+		require.NoError(t, s.AppendJournal(t.Context(), &staging.ApplyRecord{ID: fmt.Sprintf("%020d", i+1), User: "u"}))
+	}
+
+	dir, err := s.journalDir()
+	require.NoError(t, err)
+
+	ids, err := journalIDs(dir)
+	require.NoError(t, err)
+	require.Len(t, ids, journalLimit)
+	assert.Equal(t, fmt.Sprintf("%020d", journalLimit+2), ids[0])
+	assert.Equal(t, fmt.Sprintf("%020d", 3), ids[len(ids)-1], "the two oldest are pruned")
+}
@@ -0,0 +1,119 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+
+	"github.com/mpyw/suve/internal/keyprovider"
+)
+
+// keyLockFileName is the advisory lockfile directly under ~/.suve/staging that
+// serializes minting the data key. Every scope's working store and the
+// credential cache share the one keychain key, so they share this lock too.
+const keyLockFileName = ".key.lock"
+
+// MintKey mints and stores the data key once keyprovider.Resolve has found the
+// keychain reachable but empty. It is the single minting path for everything
+// that uses the key (the working stores of every scope and the credential
+// cache):
+//
+//   - it runs under the key lockfile and resolves the key again first, so a
+//     concurrent first run that minted while this one waited has its key
+//     returned here instead of being overwritten by a second, different one;
+//   - it refuses to mint while any encrypted staging state exists under
+//     ~/.suve/staging, wrapping keyprovider.ErrKeychainKeyNotFound: that state
+//     was written with the lost key, and a replacement could never decrypt it.
+//
+// A failure to create or acquire the lockfile degrades to minting without it,
+// as the scope lock does.
+func MintKey() ([]byte, error) {
+	root, err := stagingRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(root, 0o700); err == nil { //nolint:mnd // owner-only directory
+		fl := flock.New(filepath.Join(root, keyLockFileName))
+		if err := fl.Lock(); err == nil {
+			defer func() { _ = fl.Unlock() }()
+		}
+	}
+
+	key, plaintext, needsMint, err := resolveKeyFunc()
+
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("failed to resolve staging encryption key: %w", err)
+	case plaintext:
+		return nil, fmt.Errorf("failed to resolve staging encryption key: %w", keyprovider.ErrNoKeyAvailable)
+	case !needsMint:
+		return key, nil
+	}
+
+	encrypted, err := anyEncrypted(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check staging state encryption: %w", err)
+	}
+
+	if encrypted {
+		return nil, fmt.Errorf(
+			"cannot access the staging encryption key while encrypted state exists: %w", keyprovider.ErrKeychainKeyNotFound)
+	}
+
+	key, err = mintKeyFunc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve staging encryption key: %w", err)
+	}
+
+	return key, nil
+}
+
+// stagingRoot returns ~/.suve/staging, the parent of every scope directory.
+func stagingRoot() (string, error) {
+	homeDir, err := userHomeDirFunc()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, baseDirName, stagingDir), nil
+}
+
+// anyEncrypted reports whether any state file under root (the working files,
+// changesets, stashes and apply journals of every scope) is encrypted.
+func anyEncrypted(root string) (bool, error) {
+	found := false
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		encrypted, err := isFileEncrypted(path)
+		if err != nil {
+			return err
+		}
+
+		if encrypted {
+			found = true
+
+			return fs.SkipAll
+		}
+
+		return nil
+	})
+
+	return found, err
+}
@@ -0,0 +1,94 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/crypt"
+	"github.com/mpyw/suve/internal/keyprovider"
+)
+
+// withKeyHooks points the package at a temp home with the given resolver and a
+// mint hook that counts its calls, restoring the hooks afterwards.
+func withKeyHooks(t *testing.T, resolve func() ([]byte, bool, bool, error)) (home string, mints *int) {
+	t.Helper()
+
+	origResolve, origMint, origHome := resolveKeyFunc, mintKeyFunc, userHomeDirFunc
+
+	t.Cleanup(func() {
+		resolveKeyFunc, mintKeyFunc, userHomeDirFunc = origResolve, origMint, origHome
+	})
+
+	home = t.TempDir()
+	mints = new(int)
+
+	userHomeDirFunc = func() (string, error) { return home, nil }
+	resolveKeyFunc = resolve
+	mintKeyFunc = func() ([]byte, error) {
+		*mints++
+
+		return newTestKey(), nil
+	}
+
+	return home, mints
+}
+
+//nolint:paralleltest // overrides package-level hook vars.
+func TestMintKey(t *testing.T) {
+	needsMint := func() ([]byte, bool, bool, error) { return nil, false, true, nil }
+
+	t.Run("first run mints under the key lock", func(t *testing.T) {
+		home, mints := withKeyHooks(t, needsMint)
+
+		key, err := MintKey()
+		require.NoError(t, err)
+		assert.Equal(t, newTestKey(), key)
+		assert.Equal(t, 1, *mints)
+
+		_, err = os.Stat(filepath.Join(home, ".suve", "staging", keyLockFileName))
+		require.NoError(t, err)
+	})
+
+	t.Run("a key minted by a concurrent run is returned, not replaced", func(t *testing.T) {
+		winner := []byte("winner-key-winner-key-winner-key")
+
+		_, mints := withKeyHooks(t, func() ([]byte, bool, bool, error) { return winner, false, false, nil })
+
+		key, err := MintKey()
+		require.NoError(t, err)
+		assert.Equal(t, winner, key)
+		assert.Zero(t, *mints)
+	})
+
+	t.Run("encrypted state in any scope refuses", func(t *testing.T) {
+		home, mints := withKeyHooks(t, needsMint)
+
+		data, err := crypt.EncryptWithKey([]byte("{}"), newTestKey())
+		require.NoError(t, err)
+
+		dir := filepath.Join(home, ".suve", "staging", "gcloud", "other-project", "journal")
+		require.NoError(t, os.MkdirAll(dir, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "0001.json"), data, 0o600))
+
+		_, err = MintKey()
+		require.ErrorIs(t, err, keyprovider.ErrKeychainKeyNotFound)
+		assert.Contains(t, err.Error(), "encrypted state exists")
+		assert.Zero(t, *mints)
+	})
+
+	t.Run("plaintext state does not block", func(t *testing.T) {
+		home, mints := withKeyHooks(t, needsMint)
+
+		dir := filepath.Join(home, ".suve", "staging", "aws", "123456789012", "us-east-1")
+		require.NoError(t, os.MkdirAll(dir, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "param.json"), []byte("{}"), 0o600))
+
+		_, err := MintKey()
+		require.NoError(t, err)
+		assert.Equal(t, 1, *mints)
+	})
+}
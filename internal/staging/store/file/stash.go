@@ -0,0 +1,238 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// Stashes shelve staged changes of a changeset so the working area is free for
+// something unrelated. Each stash is a working-store layout of its own under
+// the changeset directory, encrypted with the same data key, plus a plaintext
+// meta.json holding the message and creation time (never values):
+//
+//	{changeset dir}/stash/{id}/param.json
+//	{changeset dir}/stash/{id}/secret.json
+//	{changeset dir}/stash/{id}/meta.json
+//
+// IDs are zero-padded creation timestamps, so name order is creation order.
+const (
+	stashDirName      = "stash"
+	stashMetaFileName = "meta.json"
+	stashIDWidth      = 20
+)
+
+// ErrStashNotFound is returned for a stash ID the changeset does not hold.
+var ErrStashNotFound = errors.New("stash not found")
+
+// stashIDPattern matches the IDs NewStashID produces.
+var stashIDPattern = regexp.MustCompile(`^[0-9]{20}$`)
+
+// nowFunc is the clock NewStashID reads; tests replace it.
+//
+//nolint:gochecknoglobals // test hook for dependency injection
+var nowFunc = time.Now
+
+// Stash describes one stash of a changeset.
+type Stash struct {
+	// ID identifies the stash; the same ID marks the parts of one push across
+	// the scopes of a provider's services.
+	ID string
+	// Message is the stash message (empty when none was given).
+	Message string
+	// CreatedAt is when the stash was pushed.
+	CreatedAt time.Time
+}
+
+// stashMeta is the on-disk form of a stash's meta.json.
+type stashMeta struct {
+	Message   string    `json:"message,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewStashID returns an ID for a stash pushed now.
+func NewStashID() string {
+	return fmt.Sprintf("%0*d", stashIDWidth, nowFunc().UnixNano())
+}
+
+// stashRoot returns the directory holding the store's stashes.
+func (s *Store) stashRoot() string {
+	return filepath.Join(s.stateDir, stashDirName)
+}
+
+// stashStore returns a Store over the stash directory dir that shares the
+// store's scope and encryption settings.
+func (s *Store) stashStore(dir string) *Store {
+	stash := *s
+	stash.stateDir = dir
+
+	return &stash
+}
+
+// Stashes returns the store's stashes, newest first.
+func (s *Store) Stashes(_ context.Context) ([]Stash, error) {
+	dirents, err := os.ReadDir(s.stashRoot())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to list stashes: %w", err)
+	}
+
+	var stashes []Stash
+
+	for _, d := range dirents {
+		if !d.IsDir() || !stashIDPattern.MatchString(d.Name()) {
+			continue
+		}
+
+		meta, err := readStashMeta(filepath.Join(s.stashRoot(), d.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		stashes = append(stashes, Stash{ID: d.Name(), Message: meta.Message, CreatedAt: meta.CreatedAt})
+	}
+
+	slices.SortFunc(stashes, func(a, b Stash) int { return strings.Compare(b.ID, a.ID) })
+
+	return stashes, nil
+}
+
+// PushStash saves state as a new stash with the given ID (see NewStashID). The
+// stash is written to a temporary directory and renamed into place, so a
+// failed push leaves no partial stash behind.
+func (s *Store) PushStash(_ context.Context, id, message string, state *staging.State) error {
+	if !stashIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid stash id %q", id)
+	}
+
+	defer s.lock()()
+
+	if err := os.MkdirAll(s.stashRoot(), 0o700); err != nil { //nolint:mnd // owner-only directory permissions
+		return fmt.Errorf("failed to create stash directory: %w", err)
+	}
+
+	tmp, err := os.MkdirTemp(s.stashRoot(), "."+id+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create stash directory: %w", err)
+	}
+
+	// Best-effort cleanup if we bail out before renaming.
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	if err := s.stashStore(tmp).writeStateLocked("", state); err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(stashMeta{Message: message, CreatedAt: nowFunc().UTC()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal stash metadata: %w", err)
+	}
+
+	if err := writeFileAtomic(filepath.Join(tmp, stashMetaFileName), meta); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, filepath.Join(s.stashRoot(), id)); err != nil {
+		return fmt.Errorf("failed to save stash: %w", err)
+	}
+
+	return nil
+}
+
+// ReadStash returns the staged changes saved in the stash. It returns
+// ErrStashNotFound when the store has no stash with that ID.
+func (s *Store) ReadStash(_ context.Context, id string) (*staging.State, error) {
+	dir, err := s.stashDir(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.stashStore(dir).drainLocked("", true)
+}
+
+// DropStash deletes the stash. It returns ErrStashNotFound when the store has
+// no stash with that ID.
+func (s *Store) DropStash(_ context.Context, id string) error {
+	dir, err := s.stashDir(id)
+	if err != nil {
+		return err
+	}
+
+	defer s.lock()()
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to drop stash: %w", err)
+	}
+
+	return nil
+}
+
+// stashDir returns the directory of an existing stash.
+func (s *Store) stashDir(id string) (string, error) {
+	if !stashIDPattern.MatchString(id) {
+		return "", fmt.Errorf("%w: %s", ErrStashNotFound, id)
+	}
+
+	dir := filepath.Join(s.stashRoot(), id)
+
+	ok, err := fileExists(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrStashNotFound, id)
+	}
+
+	return dir, nil
+}
+
+// stashesEncrypted reports whether any stash of the store holds encrypted
+// state, for the key-loss guard (see scopeEncrypted).
+func (s *Store) stashesEncrypted() (bool, error) {
+	stashes, err := s.Stashes(context.Background())
+	if err != nil {
+		return false, err
+	}
+
+	for _, stash := range stashes {
+		encrypted, err := s.stashStore(filepath.Join(s.stashRoot(), stash.ID)).IsEncrypted()
+		if err != nil || encrypted {
+			return encrypted, err
+		}
+	}
+
+	return false, nil
+}
+
+// readStashMeta reads a stash's meta.json. A missing or corrupt meta file
+// yields empty metadata rather than hiding the stash.
+func readStashMeta(dir string) (stashMeta, error) {
+	data, err := os.ReadFile(filepath.Join(dir, stashMetaFileName)) //nolint:gosec // path is internal, not user input
+	if err != nil {
+		if os.IsNotExist(err) {
+			return stashMeta{}, nil
+		}
+
+		return stashMeta{}, fmt.Errorf("failed to read stash metadata: %w", err)
+	}
+
+	var meta stashMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return stashMeta{}, nil //nolint:nilerr // a corrupt meta file must not hide the stash
+	}
+
+	return meta, nil
+}
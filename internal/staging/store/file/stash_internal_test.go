@@ -0,0 +1,73 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/crypt"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// TestStashes exercises push / list / read / drop and that stashes are stored
+// encrypted next to the changeset they were pushed from.
+//
+//nolint:paralleltest // newSplitStore and the clock override package-level hooks
+func TestStashes(t *testing.T) {
+	s := newSplitStore(t)
+	key := staging.EntryKey{Name: "/app/a"}
+
+	origNow := nowFunc
+	t.Cleanup(func() { nowFunc = origNow })
+
+	stashes, err := s.Stashes(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, stashes)
+
+	state := staging.NewEmptyState()
+	state.Entries[staging.ServiceParam][key] = staging.Entry{Operation: staging.OperationUpdate, Value: lo.ToPtr("v")}
+
+	nowFunc = func() time.Time { return time.Unix(100, 0) }
+	older := NewStashID()
+	require.NoError(t, s.PushStash(t.Context(), older, "first", state))
+
+	nowFunc = func() time.Time { return time.Unix(200, 0) }
+	newer := NewStashID()
+	require.NoError(t, s.PushStash(t.Context(), newer, "", state))
+
+	require.Error(t, s.PushStash(t.Context(), "../escape", "", state))
+
+	stashes, err = s.Stashes(t.Context())
+	require.NoError(t, err)
+	require.Len(t, stashes, 2)
+	assert.Equal(t, newer, stashes[0].ID, "newest first")
+	assert.Equal(t, "first", stashes[1].Message)
+	assert.True(t, stashes[1].CreatedAt.Equal(time.Unix(100, 0)))
+
+	data, err := os.ReadFile(filepath.Join(s.stashRoot(), older, "param.json"))
+	require.NoError(t, err)
+	assert.True(t, crypt.IsEncrypted(data), "stashed values are encrypted")
+
+	got, err := s.ReadStash(t.Context(), older)
+	require.NoError(t, err)
+	assert.Equal(t, "v", lo.FromPtr(got.Entries[staging.ServiceParam][key].Value))
+
+	encrypted, err := s.scopeEncrypted()
+	require.NoError(t, err)
+	assert.True(t, encrypted, "encrypted stashes count for the key-loss guard")
+
+	require.NoError(t, s.DropStash(t.Context(), older))
+	require.ErrorIs(t, s.DropStash(t.Context(), older), ErrStashNotFound)
+
+	_, err = s.ReadStash(t.Context(), older)
+	require.ErrorIs(t, err, ErrStashNotFound)
+
+	stashes, err = s.Stashes(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, stashes, 1)
+}
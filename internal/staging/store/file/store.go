@@ -6,6 +6,12 @@
 //	~/.suve/staging/{scope.Key()}/param.json    (working, param service)
 //	~/.suve/staging/{scope.Key()}/secret.json   (working, secret service)
 //
+// Named changesets (see changeset.go) keep the same layout one level down, in
+// ~/.suve/staging/{scope.Key()}/changesets/{name}/, and the scope's HEAD file
+// names the active one. Each changeset keeps its stashes (see stash.go) in a
+// stash/ directory of its own, and the scope keeps its apply journal (see
+// journal.go) in ~/.suve/staging/{scope.Key()}/journal/.
+//
 // Working files are encrypted with the keychain-resolved data key (raw-key v2).
 // Plaintext/legacy files remain readable. A path-based single-file mode is
 // retained for testing.
@@ -25,11 +31,11 @@ import (
 
 	"github.com/mattn/go-isatty"
 
+	"github.com/mpyw/suve/internal/crypt"
+	"github.com/mpyw/suve/internal/keyprovider"
 	"github.com/mpyw/suve/internal/provider"
 	"github.com/mpyw/suve/internal/staging"
 	"github.com/mpyw/suve/internal/staging/store"
-	"github.com/mpyw/suve/internal/staging/store/file/internal/crypt"
-	"github.com/mpyw/suve/internal/staging/store/file/internal/keyprovider"
 )
 
 const (
@@ -141,6 +147,9 @@ type Store struct {
 	stateFilePath string
 	// scope drives supported-service iteration for service=="" operations.
 	scope provider.Scope
+	// changeset is the named changeset stateDir belongs to (DefaultChangeset
+	// for the scope directory itself).
+	changeset string
 
 	passphrase string
 	// key, when non-nil, is a 32-byte AES-256 key used for raw-key (v2)
@@ -168,14 +177,21 @@ func scopeDir(scope provider.Scope) (string, error) {
 // param.json and secret.json. No encryption key is configured; use
 // NewWorkingStore for the encrypted working store.
 func NewStore(scope provider.Scope) (*Store, error) {
-	dir, err := scopeDir(scope)
+	return newChangesetStore(scope, DefaultChangeset)
+}
+
+// newChangesetStore creates a split Store for the named changeset of scope,
+// without a key.
+func newChangesetStore(scope provider.Scope, name string) (*Store, error) {
+	dir, err := changesetDir(scope, name)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Store{
-		stateDir: dir,
-		scope:    scope,
+		stateDir:  dir,
+		scope:     scope,
+		changeset: name,
 	}, nil
 }
 
@@ -187,20 +203,37 @@ func NewStore(scope provider.Scope) (*Store, error) {
 // When falling back to plaintext, a one-time warning is emitted to stderr.
 // This is the constructor to use for all working-area operations
 // (stage add/edit/delete/status/diff/apply/reset and the working side of
-// export/import).
+// export/import). It opens the scope's active changeset (see ActiveChangeset).
 func NewWorkingStore(scope provider.Scope) (*Store, error) {
-	s, err := NewStore(scope)
+	name, err := ActiveChangeset(scope)
 	if err != nil {
 		return nil, err
 	}
 
-	// Serialize key resolution and minting across processes under the scope
-	// flock. Without it, two concurrent first runs both observe an empty
-	// keychain, each mints a different random key, and the second store
-	// overwrites the first in the keychain — leaving state written under the
-	// first key permanently undecryptable. Holding the lock across
-	// Resolve/needsMint/Mint makes the loser observe the winner's freshly
-	// stored key via Resolve instead of minting its own.
+	return NewChangesetStore(scope, name)
+}
+
+// NewChangesetStore is NewWorkingStore for a given changeset of scope rather
+// than the active one. It returns ErrChangesetNotFound for a named changeset
+// that was never created.
+func NewChangesetStore(scope provider.Scope, name string) (*Store, error) {
+	if err := requireChangeset(scope, name); err != nil {
+		return nil, err
+	}
+
+	s, err := newChangesetStore(scope, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialize key resolution across processes under the scope flock. The
+	// mint itself (MintKey) also takes the key lockfile shared with every other
+	// scope and the credential cache: without it, two concurrent first runs
+	// both observe an empty keychain, each mints a different random key, and
+	// the second overwrites the first in the keychain — leaving state written
+	// under the first key permanently undecryptable. MintKey resolves again
+	// under that lock, so the loser observes the winner's freshly stored key
+	// instead of minting its own.
 	defer s.lock()()
 
 	key, plaintext, needsMint, err := resolveKeyFunc()
@@ -237,20 +270,11 @@ func NewWorkingStore(scope provider.Scope) (*Store, error) {
 		// The keychain is reachable but empty. Minting a replacement key when
 		// encrypted state already exists would leave that state undecryptable
 		// AND silently overwrite the (lost) key, masking the real cause behind a
-		// later "wrong passphrase or corrupted data" error. Refuse to mint in
+		// later "wrong passphrase or corrupted data" error. MintKey refuses in
 		// that case; otherwise this is a genuine first run.
-		guardErr, checkErr := s.guardKeyLossWithEncryptedState(keyprovider.ErrKeychainKeyNotFound)
-		if checkErr != nil {
-			return nil, checkErr
-		}
-
-		if guardErr != nil {
-			return nil, guardErr
-		}
-
-		key, err = mintKeyFunc()
+		key, err = MintKey()
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve staging encryption key: %w", err)
+			return nil, err
 		}
 	}
 
@@ -281,15 +305,15 @@ func NewWorkingStore(scope provider.Scope) (*Store, error) {
 }
 
 // guardKeyLossWithEncryptedState reports whether the working store must hard-fail
-// because the encryption key is unavailable (a hard keychain failure or a lost
-// keychain entry, given by cause) while encrypted state already exists on disk.
-// When encrypted state exists it returns the explanatory error to surface (so
-// the real cause reaches the user instead of a later misleading decryption
-// failure); otherwise guardErr is nil and the caller may proceed (plaintext
-// fallback or a first-run mint). checkErr is non-nil only when the encryption
-// probe itself failed.
+// because the encryption key is unavailable (a hard keychain failure or no key
+// on this platform, given by cause) while encrypted state already exists on
+// disk. When encrypted state exists it returns the explanatory error to surface
+// (so the real cause reaches the user instead of a later misleading decryption
+// failure); otherwise guardErr is nil and the caller may proceed with the
+// plaintext fallback. checkErr is non-nil only when the encryption probe itself
+// failed. A lost keychain entry is guarded by MintKey instead.
 func (s *Store) guardKeyLossWithEncryptedState(cause error) (guardErr, checkErr error) {
-	encrypted, err := s.IsEncrypted()
+	encrypted, err := s.scopeEncrypted()
 	if err != nil {
 		return nil, fmt.Errorf("failed to check staging state encryption: %w", err)
 	}
@@ -302,6 +326,41 @@ func (s *Store) guardKeyLossWithEncryptedState(cause error) (guardErr, checkErr
 	return nil, nil
 }
 
+// scopeEncrypted reports whether any changeset of the store's scope, any of
+// their stashes, or the scope's apply journal holds encrypted state. They all
+// share the data key, so losing it strands every one of them, not just the
+// changeset being opened.
+func (s *Store) scopeEncrypted() (bool, error) {
+	if encrypted, err := s.journalEncrypted(); err != nil || encrypted {
+		return encrypted, err
+	}
+
+	names, err := ListChangesets(s.scope)
+	if err != nil {
+		return false, err
+	}
+
+	for _, name := range names {
+		st := s
+
+		if name != s.changeset {
+			if st, err = newChangesetStore(s.scope, name); err != nil {
+				return false, err
+			}
+		}
+
+		if encrypted, err := st.IsEncrypted(); err != nil || encrypted {
+			return encrypted, err
+		}
+
+		if encrypted, err := st.stashesEncrypted(); err != nil || encrypted {
+			return encrypted, err
+		}
+	}
+
+	return false, nil
+}
+
 // warnPlaintextOnce emits the unencrypted-storage warning once per process. When
 // cause is non-nil (a hard keychain failure that degraded to plaintext), the
 // underlying keychain error is included so the operator can diagnose it.
@@ -423,21 +482,9 @@ func (s *Store) readFile(path string) (*staging.State, error) {
 		return staging.NewEmptyState(), nil
 	}
 
-	// Decrypt if encrypted. Reading an unencrypted (plaintext/legacy) file is
-	// always allowed so migration from an older/plaintext state works.
-	if crypt.IsEncrypted(data) {
-		switch {
-		case s.key != nil:
-			data, err = crypt.DecryptWithKey(data, s.key)
-		case s.passphrase != "":
-			data, err = crypt.Decrypt(data, s.passphrase)
-		default:
-			return nil, crypt.ErrDecryptionFailed
-		}
-
-		if err != nil {
-			return nil, err
-		}
+	data, err = s.open(data)
+	if err != nil {
+		return nil, err
 	}
 
 	var state staging.State
@@ -486,32 +533,62 @@ func (s *Store) writeFile(path string, state *staging.State) error {
 		return fmt.Errorf("failed to marshal state: %w", err)
 	}
 
-	// Encrypt: prefer the raw key (v2) when configured, else fall back to the
-	// passphrase (v1); otherwise write plaintext.
+	data, err = s.seal(data)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	return nil
+}
+
+// seal encrypts data for writing: with the raw key (v2) when configured, else
+// with the passphrase (v1); otherwise data is written as plaintext.
+func (s *Store) seal(data []byte) ([]byte, error) {
 	switch {
 	case s.key != nil:
-		data, err = crypt.EncryptWithKey(data, s.key)
+		sealed, err := crypt.EncryptWithKey(data, s.key)
 		if err != nil {
-			return fmt.Errorf("failed to encrypt state: %w", err)
+			return nil, fmt.Errorf("failed to encrypt state: %w", err)
 		}
+
+		return sealed, nil
 	case s.passphrase != "":
-		data, err = crypt.Encrypt(data, s.passphrase)
+		sealed, err := crypt.Encrypt(data, s.passphrase)
 		if err != nil {
-			return fmt.Errorf("failed to encrypt state: %w", err)
+			return nil, fmt.Errorf("failed to encrypt state: %w", err)
 		}
+
+		return sealed, nil
 	case s.plaintextFallback && !isInteractiveFunc() && !plaintextConsentGranted():
 		// A working store with no key, about to persist secrets UNENCRYPTED, in a
 		// non-interactive session (CI/pipes/GUI) without an explicit opt-in. An
 		// interactive run keeps the historical warn-and-proceed; automation must
 		// choose encryption (SUVE_STAGING_KEY) or consent (EnvAllowPlaintext).
-		return ErrPlaintextConsentRequired
+		return nil, ErrPlaintextConsentRequired
+	default:
+		return data, nil
 	}
+}
 
-	if err := writeFileAtomic(path, data); err != nil {
-		return fmt.Errorf("failed to write state file: %w", err)
+// open decrypts data read from disk. Reading an unencrypted (plaintext/legacy)
+// file is always allowed so migration from an older/plaintext state works.
+func (s *Store) open(data []byte) ([]byte, error) {
+	if !crypt.IsEncrypted(data) {
+		return data, nil
 	}
 
-	return nil
+	switch {
+	case s.key != nil:
+		return crypt.DecryptWithKey(data, s.key)
+	case s.passphrase != "":
+		return crypt.Decrypt(data, s.passphrase)
+	default:
+		return nil, crypt.ErrDecryptionFailed
+	}
 }
 
 // writeFileAtomic writes data to path atomically: it writes to a temp file in
@@ -10,10 +10,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/mpyw/suve/internal/crypt"
+	"github.com/mpyw/suve/internal/keyprovider"
 	"github.com/mpyw/suve/internal/provider"
 	"github.com/mpyw/suve/internal/staging"
-	"github.com/mpyw/suve/internal/staging/store/file/internal/crypt"
-	"github.com/mpyw/suve/internal/staging/store/file/internal/keyprovider"
 )
 
 func newTestKey() []byte {
@@ -21,6 +21,9 @@ func (EntryActionAdd) isEntryAction() {}
 // EntryActionEdit represents editing an existing entry (update operation).
 type EntryActionEdit struct {
 	Value string
+	// Force stages the update even when Value equals the current value, for a
+	// change that lies outside the value (e.g. a restored content type).
+	Force bool
 }
 
 func (EntryActionEdit) isEntryAction() {}
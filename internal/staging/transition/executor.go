@@ -25,8 +25,11 @@ func NewExecutor(store store.ReadWriteOperator) *Executor {
 // EntryExecuteOptions holds optional metadata for entry execution.
 type EntryExecuteOptions struct {
 	BaseModifiedAt *time.Time       // Base modification time for conflict detection
+	BaseVersion    string           // Base version identifier for conflict detection; empty means none
 	Description    *string          // Optional description for the staged entry
 	ValueType      domain.ValueType // Provider-neutral value type (AWS param axis); empty means unset
+	// Attributes are Azure Key Vault secret attributes for the staged version; nil means unset
+	Attributes *staging.SecretAttributes
 }
 
 // ExecuteEntry executes an entry action and persists the result.
@@ -110,6 +113,8 @@ func (e *Executor) persistEntryState(
 		}
 		if opts != nil {
 			entry.ValueType = opts.ValueType
+			entry.Attributes = opts.Attributes
+
 			if opts.Description != nil {
 				entry.Description = opts.Description
 			}
@@ -125,7 +130,9 @@ func (e *Executor) persistEntryState(
 		}
 		if opts != nil {
 			entry.BaseModifiedAt = opts.BaseModifiedAt
+			entry.BaseVersion = opts.BaseVersion
 			entry.ValueType = opts.ValueType
+			entry.Attributes = opts.Attributes
 
 			if opts.Description != nil {
 				entry.Description = opts.Description
@@ -141,6 +148,7 @@ func (e *Executor) persistEntryState(
 		}
 		if opts != nil {
 			entry.BaseModifiedAt = opts.BaseModifiedAt
+			entry.BaseVersion = opts.BaseVersion
 		}
 
 		err = e.Store.StageEntry(ctx, service, key, entry)
@@ -176,6 +184,19 @@ func (e *Executor) persistTagState(
 	})
 }
 
+// EntryBase is the remote state a staged entry was based on. Re-staging keeps
+// an existing base so conflict detection still compares against the state the
+// first change was made on.
+type EntryBase struct {
+	ModifiedAt *time.Time
+	Version    string
+}
+
+// IsZero reports whether no base was recorded.
+func (b EntryBase) IsZero() bool {
+	return b.ModifiedAt == nil && b.Version == ""
+}
+
 // LoadEntryState loads the current entry state from the store and AWS info.
 func LoadEntryState(
 	ctx context.Context,
@@ -189,18 +210,18 @@ func LoadEntryState(
 	return state, err
 }
 
-// LoadEntryStateWithMetadata loads the current entry state and returns BaseModifiedAt metadata.
-// BaseModifiedAt is used for conflict detection when applying changes.
+// LoadEntryStateWithMetadata loads the current entry state and returns the staged entry's base.
+// The base is used for conflict detection when applying changes.
 func LoadEntryStateWithMetadata(
 	ctx context.Context,
 	store store.ReadOperator,
 	service staging.Service,
 	key staging.EntryKey,
 	currentAWSValue *string,
-) (EntryState, *time.Time, error) {
+) (EntryState, EntryBase, error) {
 	stagedEntry, err := store.GetEntry(ctx, service, key)
 	if err != nil && !errors.Is(err, staging.ErrNotStaged) {
-		return EntryState{}, nil, err
+		return EntryState{}, EntryBase{}, err
 	}
 
 	state := EntryState{
@@ -208,9 +229,9 @@ func LoadEntryStateWithMetadata(
 		StagedState:  EntryStagedStateNotStaged{},
 	}
 
-	var baseModifiedAt *time.Time
+	var base EntryBase
 	if stagedEntry != nil {
-		baseModifiedAt = stagedEntry.BaseModifiedAt
+		base = EntryBase{ModifiedAt: stagedEntry.BaseModifiedAt, Version: stagedEntry.BaseVersion}
 		switch stagedEntry.Operation {
 		case staging.OperationCreate:
 			state.StagedState = EntryStagedStateCreate{
@@ -225,7 +246,7 @@ func LoadEntryStateWithMetadata(
 		}
 	}
 
-	return state, baseModifiedAt, nil
+	return state, base, nil
 }
 
 // LoadStagedTags loads the current staged tags from the store.
@@ -104,10 +104,10 @@ func reduceAdd(state EntryState, action EntryActionAdd) EntryTransitionResult {
 // reduceEdit handles the EDIT action.
 //
 // Transition rules:
-//   - NotStaged → Update     (if value != AWS)
+//   - NotStaged → Update     (if value != AWS, or forced)
 //   - NotStaged → NotStaged  (if value == AWS, auto-skip)
 //   - Create    → Create     (update draft value)
-//   - Update    → Update     (if value != AWS)
+//   - Update    → Update     (if value != AWS, or forced)
 //   - Update    → NotStaged  (if value == AWS, auto-unstage)
 //   - Delete    → ERROR      (must reset first to edit)
 func reduceEdit(state EntryState, action EntryActionEdit) EntryTransitionResult {
@@ -116,14 +116,14 @@ func reduceEdit(state EntryState, action EntryActionEdit) EntryTransitionResult
 	switch state.StagedState.(type) {
 	case EntryStagedStateNotStaged:
 		// Auto-skip if value matches AWS current value
-		if state.CurrentValue == nil || *state.CurrentValue != action.Value {
+		if action.Force || state.CurrentValue == nil || *state.CurrentValue != action.Value {
 			state.StagedState = EntryStagedStateUpdate{DraftValue: action.Value}
 		}
 	case EntryStagedStateCreate:
 		state.StagedState = EntryStagedStateCreate{DraftValue: action.Value}
 	case EntryStagedStateUpdate:
 		// Auto-unstage if value matches AWS current value
-		if state.CurrentValue != nil && *state.CurrentValue == action.Value {
+		if !action.Force && state.CurrentValue != nil && *state.CurrentValue == action.Value {
 			state.StagedState = EntryStagedStateNotStaged{}
 		} else {
 			state.StagedState = EntryStagedStateUpdate{DraftValue: action.Value}
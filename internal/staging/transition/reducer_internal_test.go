@@ -161,6 +161,24 @@ func TestReduceEntry_Edit(t *testing.T) {
 			action:    EntryActionEdit{Value: "current"},
 			wantState: EntryStagedStateNotStaged{},
 		},
+		{
+			name: "NotStaged -> Update (value == AWS, forced)",
+			state: EntryState{
+				CurrentValue: lo.ToPtr("same-value"),
+				StagedState:  EntryStagedStateNotStaged{},
+			},
+			action:    EntryActionEdit{Value: "same-value", Force: true},
+			wantState: EntryStagedStateUpdate{DraftValue: "same-value"},
+		},
+		{
+			name: "Update -> Update (value == AWS, forced)",
+			state: EntryState{
+				CurrentValue: lo.ToPtr("current"),
+				StagedState:  EntryStagedStateUpdate{DraftValue: "something"},
+			},
+			action:    EntryActionEdit{Value: "current", Force: true},
+			wantState: EntryStagedStateUpdate{DraftValue: "current"},
+		},
 		{
 			name: "Delete -> ERROR",
 			state: EntryState{
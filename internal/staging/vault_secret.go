@@ -0,0 +1,303 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/version/vaultversion"
+)
+
+// VaultSecretStrategy implements the staging strategies for HashiCorp Vault KV
+// v2. Like the Google Cloud strategy it is backed by a provider.Store and
+// carries no client dependency:
+//
+//   - Versions are immutable integers, parsed with vaultversion (#N, ~SHIFT); a
+//     staged "edit" applies as a new version via Put.
+//   - A delete is a soft delete of the current version with no options, so
+//     HasDeleteOptions reports false.
+//   - There is no description, so staged descriptions are never written.
+//
+// A nil store yields a parser-only strategy (ParseName/ParseSpec).
+type VaultSecretStrategy struct {
+	store provider.Store
+}
+
+// NewVaultSecretStrategy creates a Vault KV v2 staging strategy
+// over the given provider store. A nil store is allowed for parser-only use.
+func NewVaultSecretStrategy(store provider.Store) *VaultSecretStrategy {
+	return &VaultSecretStrategy{store: store}
+}
+
+// Service returns the service type.
+func (s *VaultSecretStrategy) Service() Service {
+	return ServiceSecret
+}
+
+// ServiceName returns the user-friendly service name.
+func (s *VaultSecretStrategy) ServiceName() string {
+	return "Vault KV"
+}
+
+// ItemName returns the item name for messages.
+func (s *VaultSecretStrategy) ItemName() string {
+	return itemNameSecret
+}
+
+// HasDeleteOptions returns false: Vault KV v2 deletes have no force /
+// recovery-window options.
+func (s *VaultSecretStrategy) HasDeleteOptions() bool {
+	return false
+}
+
+// Apply applies a staged operation to Vault KV v2.
+func (s *VaultSecretStrategy) Apply(ctx context.Context, name string, entry Entry) error {
+	switch entry.Operation {
+	case OperationCreate:
+		if restored, err := restoreDeleted(ctx, s.store, name, s.ItemName(), entry); restored || err != nil {
+			return err
+		}
+
+		return s.applyCreate(ctx, name, entry)
+	case OperationUpdate:
+		return s.applyUpdate(ctx, name, entry)
+	case OperationDelete:
+		return s.applyDelete(ctx, name)
+	default:
+		return fmt.Errorf("unknown operation: %s", entry.Operation)
+	}
+}
+
+func (s *VaultSecretStrategy) applyCreate(ctx context.Context, name string, entry Entry) error {
+	if _, err := s.store.Create(ctx, name, lo.FromPtr(entry.Value), domain.ValueTypeSecret, "", writeOptions(ctx)...); err != nil {
+		return fmt.Errorf("failed to create secret: %w", err)
+	}
+
+	return nil
+}
+
+func (s *VaultSecretStrategy) applyUpdate(ctx context.Context, name string, entry Entry) error {
+	if entry.Value == nil {
+		return nil
+	}
+
+	// KV v2 versions are immutable: Put adds a new version.
+	if _, err := s.store.Put(ctx, name, *entry.Value, domain.ValueTypeSecret, "", writeOptions(ctx)...); err != nil {
+		return fmt.Errorf("failed to update secret: %w", err)
+	}
+
+	return nil
+}
+
+func (s *VaultSecretStrategy) applyDelete(ctx context.Context, name string) error {
+	if err := s.store.Delete(ctx, name); err != nil {
+		// Already deleted is considered success.
+		if errors.Is(err, provider.ErrNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to delete secret: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyTags applies staged tag (custom_metadata) changes to Vault KV v2.
+func (s *VaultSecretStrategy) ApplyTags(ctx context.Context, name string, tagEntry TagEntry) error {
+	if len(tagEntry.Add) > 0 {
+		if err := s.store.Tag(ctx, name, tagEntry.Add); err != nil {
+			return err
+		}
+	}
+
+	if tagEntry.Remove.Len() > 0 {
+		if err := s.store.Untag(ctx, name, tagEntry.Remove.Values()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FetchLastModified returns the last modified time of the secret. It returns a
+// *ResourceNotFoundError when the secret does not exist, so callers can tell
+// "missing" apart from "exists but has no modification time" (the latter returns
+// a zero time with a nil error).
+func (s *VaultSecretStrategy) FetchLastModified(ctx context.Context, name string) (time.Time, error) {
+	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return time.Time{}, &ResourceNotFoundError{Err: err}
+		}
+
+		return time.Time{}, fmt.Errorf("failed to get secret: %w", err)
+	}
+
+	if entry.Modified != nil {
+		return *entry.Modified, nil
+	}
+
+	return time.Time{}, nil
+}
+
+// FetchBase returns the secret value a staged change was based on from Vault's
+// version history.
+func (s *VaultSecretStrategy) FetchBase(ctx context.Context, name, version string, at time.Time) (string, error) {
+	return fetchValueAt(ctx, s.store, name, version, at)
+}
+
+// FetchRemoteState returns the secret's last modified time and current version
+// for version-based conflict detection.
+func (s *VaultSecretStrategy) FetchRemoteState(ctx context.Context, name string) (RemoteState, error) {
+	return fetchRemoteState(ctx, s.store, name, "secret")
+}
+
+// Snapshot captures the secret's current state in Vault before an atomic apply.
+func (s *VaultSecretStrategy) Snapshot(ctx context.Context, name string) (Snapshot, error) {
+	return snapshotEntry(ctx, s.store, name, itemNameSecret)
+}
+
+// Rollback returns the secret in Vault to a snapshot taken before an atomic
+// apply. A put-back value is written as a new version.
+func (s *VaultSecretStrategy) Rollback(ctx context.Context, name string, snap Snapshot) error {
+	return rollbackEntry(ctx, s.store, name, itemNameSecret, snap)
+}
+
+// FetchCurrent fetches the current value from Vault for diffing.
+func (s *VaultSecretStrategy) FetchCurrent(ctx context.Context, name string) (*FetchResult, error) {
+	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FetchResult{
+		Value:      entry.Value,
+		Identifier: "#" + entry.Version.ID,
+		Secret:     true, // KV values are always secret material.
+	}, nil
+}
+
+// FetchCurrentTags fetches the current custom_metadata from Vault.
+func (s *VaultSecretStrategy) FetchCurrentTags(ctx context.Context, name string) (map[string]string, error) {
+	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return nil, nil //nolint:nilnil // intentional: no tags for non-existent resource
+		}
+
+		return nil, fmt.Errorf("failed to get secret: %w", err)
+	}
+
+	if len(entry.Tags) == 0 {
+		return nil, nil //nolint:nilnil // intentional: resource exists but has no tags
+	}
+
+	tags := make(map[string]string, len(entry.Tags))
+	for _, tag := range entry.Tags {
+		tags[tag.Key] = tag.Value
+	}
+
+	return tags, nil
+}
+
+// ParseName parses and validates a name for editing (no version specifier).
+func (s *VaultSecretStrategy) ParseName(input string) (string, error) {
+	spec, err := vaultversion.Parse(input)
+	if err != nil {
+		return "", err
+	}
+
+	if spec.Absolute.Version != nil || spec.Shift > 0 {
+		return "", fmt.Errorf("secret name must not contain a version specifier")
+	}
+
+	return spec.Name, nil
+}
+
+// FetchCurrentValue fetches the current value from Vault for editing.
+// Returns *ResourceNotFoundError if the secret doesn't exist.
+func (s *VaultSecretStrategy) FetchCurrentValue(ctx context.Context, name string) (*EditFetchResult, error) {
+	entry, err := s.store.Get(ctx, name, provider.VersionRef{})
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			return nil, &ResourceNotFoundError{Err: err}
+		}
+
+		return nil, err
+	}
+
+	result := &EditFetchResult{
+		Value:   entry.Value,
+		Version: entry.Version.ID,
+	}
+
+	if entry.Modified != nil {
+		result.LastModified = *entry.Modified
+	}
+
+	return result, nil
+}
+
+// ParseSpec parses a version spec string for reset.
+func (s *VaultSecretStrategy) ParseSpec(input string) (name string, hasVersion bool, err error) {
+	spec, err := vaultversion.Parse(input)
+	if err != nil {
+		return "", false, err
+	}
+
+	hasVersion = spec.Absolute.Version != nil || spec.Shift > 0
+
+	return spec.Name, hasVersion, nil
+}
+
+// FetchVersion fetches the value for a specific version.
+func (s *VaultSecretStrategy) FetchVersion(ctx context.Context, input string) (value string, versionLabel string, err error) {
+	spec, err := vaultversion.Parse(input)
+	if err != nil {
+		return "", "", err
+	}
+
+	ref, err := s.store.Resolve(ctx, spec.Name, vaultSecretSpecSuffix(spec))
+	if err != nil {
+		return "", "", err
+	}
+
+	entry, err := s.store.Get(ctx, spec.Name, ref)
+	if err != nil {
+		return "", "", err
+	}
+
+	return entry.Value, "#" + entry.Version.ID, nil
+}
+
+// vaultSecretSpecSuffix reconstructs the version-spec suffix (the part after the
+// name) so that name+suffix re-parses to an equivalent spec, as
+// provider.Reader.Resolve expects.
+func vaultSecretSpecSuffix(spec *vaultversion.Spec) string {
+	var b strings.Builder
+
+	if spec.Absolute.Version != nil {
+		b.WriteString("#")
+		b.WriteString(strconv.FormatInt(*spec.Absolute.Version, 10))
+	}
+
+	if spec.Shift > 0 {
+		b.WriteString("~")
+		b.WriteString(strconv.Itoa(spec.Shift))
+	}
+
+	return b.String()
+}
+
+// VaultSecretParserFactory creates a Parser without provider access, for
+// operations that don't need Vault access (e.g. status, parsing).
+func VaultSecretParserFactory() Parser {
+	return NewVaultSecretStrategy(nil)
+}
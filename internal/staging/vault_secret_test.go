@@ -0,0 +1,149 @@
+package staging_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/maputil"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+func TestVaultSecretStrategy_BasicMethods(t *testing.T) {
+	t.Parallel()
+
+	s := staging.NewVaultSecretStrategy(nil)
+
+	assert.Equal(t, staging.ServiceSecret, s.Service())
+	assert.Equal(t, "Vault KV", s.ServiceName())
+	assert.Equal(t, "secret", s.ItemName())
+	assert.False(t, s.HasDeleteOptions())
+}
+
+func TestVaultSecretStrategy_Apply(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create ignores a staged description", func(t *testing.T) {
+		t.Parallel()
+
+		store := &providermock.Store{
+			CreateFunc: func(_ context.Context, name, value string, _ domain.ValueType, desc string, _ ...provider.WriteOption) (domain.Version, error) {
+				assert.Equal(t, "app/db", name)
+				assert.Equal(t, "v1", value)
+				assert.Empty(t, desc)
+
+				return domain.Version{ID: "1"}, nil
+			},
+		}
+
+		err := staging.NewVaultSecretStrategy(store).Apply(t.Context(), "app/db", staging.Entry{
+			Operation:   staging.OperationCreate,
+			Value:       lo.ToPtr("v1"),
+			Description: lo.ToPtr("ignored"),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("update adds a version via Put", func(t *testing.T) {
+		t.Parallel()
+
+		var putCalled bool
+
+		store := &providermock.Store{
+			PutFunc: func(_ context.Context, _, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption) (domain.Version, error) {
+				putCalled = true
+
+				assert.Equal(t, "v2", value)
+
+				return domain.Version{ID: "2"}, nil
+			},
+		}
+
+		err := staging.NewVaultSecretStrategy(store).Apply(t.Context(), "app/db",
+			staging.Entry{Operation: staging.OperationUpdate, Value: lo.ToPtr("v2")})
+		require.NoError(t, err)
+		assert.True(t, putCalled)
+	})
+
+	t.Run("delete of a missing secret is success", func(t *testing.T) {
+		t.Parallel()
+
+		store := &providermock.Store{
+			DeleteFunc: func(_ context.Context, _ string, _ ...provider.DeleteOption) error {
+				return provider.ErrNotFound
+			},
+		}
+
+		err := staging.NewVaultSecretStrategy(store).Apply(t.Context(), "app/db", staging.Entry{Operation: staging.OperationDelete})
+		require.NoError(t, err)
+	})
+}
+
+func TestVaultSecretStrategy_ApplyTags(t *testing.T) {
+	t.Parallel()
+
+	var added map[string]string
+
+	var removed []string
+
+	store := &providermock.Store{
+		TagFunc: func(_ context.Context, _ string, add map[string]string) error {
+			added = add
+
+			return nil
+		},
+		UntagFunc: func(_ context.Context, _ string, keys []string) error {
+			removed = keys
+
+			return nil
+		},
+	}
+
+	err := staging.NewVaultSecretStrategy(store).ApplyTags(t.Context(), "app/db", staging.TagEntry{
+		Add:    map[string]string{"env": "prod"},
+		Remove: maputil.NewSet("team"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod"}, added)
+	assert.Equal(t, []string{"team"}, removed)
+}
+
+func TestVaultSecretStrategy_ParseAndFetchVersion(t *testing.T) {
+	t.Parallel()
+
+	s := staging.NewVaultSecretStrategy(nil)
+
+	name, err := s.ParseName("app/db")
+	require.NoError(t, err)
+	assert.Equal(t, "app/db", name)
+
+	_, err = s.ParseName("app/db#2")
+	require.Error(t, err)
+
+	name, hasVersion, err := s.ParseSpec("app/db~1")
+	require.NoError(t, err)
+	assert.Equal(t, "app/db", name)
+	assert.True(t, hasVersion)
+
+	store := &providermock.Store{
+		ResolveFunc: func(_ context.Context, _, spec string) (provider.VersionRef, error) {
+			assert.Equal(t, "#3~1", spec)
+
+			return provider.NewVersionRef("2"), nil
+		},
+		GetFunc: func(_ context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{Name: name, Value: "old", Version: domain.Version{ID: ref.ID()}}, nil
+		},
+	}
+
+	value, label, err := staging.NewVaultSecretStrategy(store).FetchVersion(t.Context(), "app/db#3~1")
+	require.NoError(t, err)
+	assert.Equal(t, "old", value)
+	assert.Equal(t, "#2", label)
+}
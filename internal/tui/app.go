@@ -1,10 +1,11 @@
 // Package tui implements suve's terminal UI — a third frontend beside the CLI
 // and the Wails GUI. It is pure Go and untagged, so it ships in the default CLI
 // build. Like the GUI it consumes internal/usecase/* over the provider Registry
-// and the neutral internal/capability matrix; unlike the GUI, provider and
-// scope are fixed at launch (no in-app switching). This file holds the root
-// model — the app shell that owns the status bar, tab bar, help bar, and the
-// page and dialog stacks, and dispatches every message in the order
+// and the neutral internal/capability matrix; unlike the GUI, the provider is
+// fixed at launch, while its scope (an AWS profile or region, a Google Cloud
+// project, an Azure vault or store) can be switched in the app. This file holds
+// the root model — the app shell that owns the status bar, tab bar, help bar,
+// and the page and dialog stacks, and dispatches every message in the order
 // dialogs → global keys → active page.
 package tui
 
@@ -21,6 +22,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
+	"github.com/mpyw/suve/internal/capability"
 	"github.com/mpyw/suve/internal/provider"
 	"github.com/mpyw/suve/internal/tui/components"
 	"github.com/mpyw/suve/internal/tui/data"
@@ -80,7 +82,7 @@ type identityFetcher func() (components.AWSIdentity, error)
 
 // config is the constructor input for the root model.
 type config struct {
-	// scope is the fixed provider scope the TUI was launched with.
+	// scope is the provider scope the TUI was launched with.
 	scope provider.Scope
 	// service preselects the initial tab ("param"/"secret", or "").
 	service string
@@ -104,10 +106,29 @@ type config struct {
 	// the registry-backed sourceFactory, tests to a providermock-backed one; nil
 	// leaves the Staging tab a placeholder.
 	stagingFor func(service string) data.StagingService
+	// changesetsFor builds the staging page's changeset-selector seam over the
+	// offered services; nil (or a nil seam) hides the selector.
+	changesetsFor func(services []string) data.Changesets
 	// runCtx is the Run context threaded into pages so their fetch commands are
 	// cancelled when the program exits. Tests may leave it nil (newApp defaults it
 	// to context.Background()).
 	runCtx context.Context //nolint:containedctx // threaded into page fetch commands; mirrors the GUI
+	// caps is the capability matrix the tabs are derived from; nil means
+	// capability.All(). A plugin launch appends its handshake capability.
+	caps []capability.ProviderCapability
+	// rescope rebuilds the scope-bound seams (fetchIdentity, sourceFor,
+	// mutatorFor, stagingFor, changesetsFor) for a new scope; only those fields of the returned
+	// config are used. Nil disables in-app scope switching.
+	rescope func(provider.Scope) config
+	// profiles lists the AWS profiles the profile picker offers. Nil (or a
+	// non-AWS scope) disables the picker.
+	profiles func() []dialogs.PickerItem
+	// regions lists the AWS regions the scope picker offers. Nil disables the
+	// scope picker on AWS.
+	regions func() []string
+	// recents remembers the scopes switched to for the scope picker; nil keeps
+	// no history.
+	recents *recentScopes
 }
 
 // dialog is a modal overlay in the app shell's dialog stack. While any dialog
@@ -135,12 +156,20 @@ type escInterceptor interface {
 	interceptEsc() bool
 }
 
-// awsIdentityMsg carries a resolved AWS identity back to the model.
-type awsIdentityMsg struct{ id components.AWSIdentity }
+// awsIdentityMsg carries a resolved AWS identity back to the model. gen is the
+// scope generation it was fetched for, so a lookup that finishes after a scope
+// switch is dropped.
+type awsIdentityMsg struct {
+	id  components.AWSIdentity
+	gen int
+}
 
 // awsIdentityErrMsg reports that the AWS identity lookup failed; the status bar
 // simply stops showing the loading placeholder.
-type awsIdentityErrMsg struct{ err error }
+type awsIdentityErrMsg struct {
+	err error
+	gen int
+}
 
 // App is the root Bubble Tea model — the app shell.
 type App struct {
@@ -180,10 +209,24 @@ type App struct {
 
 	// sourceFor is the injected data seam (see config); runCtx is the Run context
 	// threaded into pages.
-	sourceFor  func(service string) (data.Source, data.StagingProbe)
-	mutatorFor func(service string) data.Mutator
-	stagingFor func(service string) data.StagingService
-	runCtx     context.Context //nolint:containedctx // threaded into page fetch commands; mirrors the GUI
+	sourceFor     func(service string) (data.Source, data.StagingProbe)
+	mutatorFor    func(service string) data.Mutator
+	stagingFor    func(service string) data.StagingService
+	changesetsFor func(services []string) data.Changesets
+	runCtx        context.Context //nolint:containedctx // threaded into page fetch commands; mirrors the GUI
+
+	// caps, rescope, profiles, regions and recents back the profile and scope
+	// switches (see config).
+	caps     []capability.ProviderCapability
+	rescope  func(provider.Scope) config
+	profiles func() []dialogs.PickerItem
+	regions  func() []string
+	recents  *recentScopes
+	// scopeGen counts scope switches, so async results for a scope that has
+	// since been left are dropped. pendingScope is a switch awaiting the
+	// staged-changes warning's answer.
+	scopeGen     int
+	pendingScope *pendingScope
 
 	// status is a transient one-line outcome (staged/applied/skipped/unstaged)
 	// shown just above the help bar; empty renders no row.
@@ -204,7 +247,7 @@ type App struct {
 // seeds the page stack with that tab's placeholder.
 func newApp(cfg config) *App {
 	st := styles.New()
-	tabs := buildTabs(cfg.scope)
+	tabs := buildTabs(cfg.scope, cfg.caps)
 	active := initialTabIndex(tabs, cfg.service)
 
 	m := &App{
@@ -220,10 +263,19 @@ func newApp(cfg config) *App {
 		sourceFor:     cfg.sourceFor,
 		mutatorFor:    cfg.mutatorFor,
 		stagingFor:    cfg.stagingFor,
+		changesetsFor: cfg.changesetsFor,
 		runCtx:        cmp.Or(cfg.runCtx, context.Background()),
+		caps:          cfg.caps,
+		rescope:       cfg.rescope,
+		profiles:      cfg.profiles,
+		regions:       cfg.regions,
+		recents:       cfg.recents,
 		stagedCounts:  map[string]int{},
 	}
 
+	m.keys.Profile.SetEnabled(cfg.scope.Provider == provider.ProviderAWS && cfg.rescope != nil && cfg.profiles != nil)
+	m.keys.Scope.SetEnabled(scopeSwitchable(cfg))
+
 	m.identityLoading = cfg.scope.Provider == provider.ProviderAWS &&
 		cfg.identity == nil && cfg.fetchIdentity != nil
 
@@ -270,14 +322,15 @@ func (m *App) Init() tea.Cmd {
 // fetchIdentityCmd runs the injected identity fetcher off the update loop.
 func (m *App) fetchIdentityCmd() tea.Cmd {
 	fetch := m.fetchIdentity
+	gen := m.scopeGen
 
 	return func() tea.Msg {
 		id, err := fetch()
 		if err != nil {
-			return awsIdentityErrMsg{err: err}
+			return awsIdentityErrMsg{err: err, gen: gen}
 		}
 
-		return awsIdentityMsg{id: id}
+		return awsIdentityMsg{id: id, gen: gen}
 	}
 }
 
@@ -301,13 +354,17 @@ func (m *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, m.forwardResize(msg)
 	case awsIdentityMsg:
-		id := msg.id
-		m.identity = &id
-		m.identityLoading = false
+		if msg.gen == m.scopeGen {
+			id := msg.id
+			m.identity = &id
+			m.identityLoading = false
+		}
 
 		return m, nil
 	case awsIdentityErrMsg:
-		m.identityLoading = false
+		if msg.gen == m.scopeGen {
+			m.identityLoading = false
+		}
 
 		return m, nil
 	case cursor.BlinkMsg:
@@ -344,6 +401,16 @@ func (m *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.openReset(msg)
 	case nav.OpenStagingDetail:
 		return m, m.pushStagingDetail(msg)
+	case nav.OpenChangesets:
+		return m, m.openChangesetPicker(msg)
+	case changesetSwitchedMsg:
+		return m, m.onChangesetSwitched(msg)
+	case nav.OpenJournal:
+		return m, m.loadJournal()
+	case journalLoadedMsg:
+		return m, m.openJournalPicker(msg)
+	case applyUndoneMsg:
+		return m, m.onApplyUndone(msg)
 	case nav.OpenError:
 		m.pushDialog(dialogs.NewError(m.styles, msg.Title, msg.Message), nil)
 
@@ -359,6 +426,12 @@ func (m *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.popDialog()
 
 		return m, nil
+	case dialogs.PickedMsg:
+		m.popDialog()
+
+		return m, m.onPicked(msg)
+	case scopeLeaveMsg:
+		return m, m.onScopeLeave(msg)
 	case nav.PopPage:
 		m.popPage()
 
@@ -628,6 +701,10 @@ func (m *App) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		return m, m.cycleTab(-1)
 	case key.Matches(msg, m.keys.Copy):
 		return m, m.copyFocusedValue()
+	case key.Matches(msg, m.keys.Profile):
+		return m, m.openProfilePicker()
+	case key.Matches(msg, m.keys.Scope):
+		return m, m.openScopePicker()
 	}
 
 	return m.updateActivePage(msg)
@@ -864,7 +941,7 @@ func (m *App) pageForTab(i int) (page, tea.Cmd) {
 
 	if tab.Service == stagingService {
 		if services := m.stagingServicesFor(m.offeredServices()); len(services) > 0 {
-			p := newStagingPage(m.runCtx, services, m.styles, m.keys)
+			p := newStagingPage(m.runCtx, services, m.changesets(), m.styles, m.keys)
 
 			return p, p.Init()
 		}
@@ -926,8 +1003,8 @@ func (m *App) openApply(req nav.OpenApply) tea.Cmd {
 
 	d := dialogs.NewApply(dialogs.ApplyInput{
 		Ctx: m.runCtx, Targets: targets, TargetLine: m.applyTargetLine(),
-		Title: applyTitle(req.Global, targets), EntryCount: req.EntryCount, TagCount: req.TagCount,
-		Styles: m.styles,
+		Title: applyTitle(req.Global, req.Marked != nil, targets), EntryCount: req.EntryCount, TagCount: req.TagCount,
+		Marked: req.Marked, Styles: m.styles,
 	})
 
 	return m.pushDialog(d, nil)
@@ -941,7 +1018,8 @@ func (m *App) openReset(req nav.OpenReset) tea.Cmd {
 	}
 
 	d := dialogs.NewReset(dialogs.ResetInput{
-		Ctx: m.runCtx, Targets: targets, Title: resetTitle(req.Global, targets), Styles: m.styles,
+		Ctx: m.runCtx, Targets: targets, Title: resetTitle(req.Global, req.Marked != nil, targets),
+		Marked: req.Marked, Styles: m.styles,
 	})
 
 	return m.pushDialog(d, nil)
@@ -980,6 +1058,24 @@ func (m *App) applyTargetLine() string {
 		parts = appendKV(parts, "store", m.scope.StoreName)
 
 		return strings.Join(parts, " · ")
+	case provider.ProviderVault:
+		parts := []string{string(provider.ProviderVault)}
+		parts = appendKV(parts, "address", m.scope.VaultAddress)
+		parts = appendKV(parts, "mount", m.scope.VaultMount)
+
+		return strings.Join(parts, " · ")
+	case provider.ProviderKubernetes:
+		parts := []string{string(provider.ProviderKubernetes)}
+		parts = appendKV(parts, "context", m.scope.KubeContext)
+		parts = appendKV(parts, "namespace", m.scope.KubeNamespace)
+
+		return strings.Join(parts, " · ")
+	case provider.ProviderSOPS:
+		return strings.Join(appendKV([]string{string(provider.ProviderSOPS)}, "file", m.scope.SOPSFile), " · ")
+	case provider.ProviderLocal:
+		return string(provider.ProviderLocal)
+	case provider.ProviderPlugin:
+		return string(provider.ProviderPlugin) + " " + m.scope.Plugin
 	default:
 		return string(m.scope.Provider)
 	}
@@ -996,13 +1092,23 @@ func appendKV(parts []string, key, value string) []string {
 
 // applyTitle names the apply confirmation: "— all" for the fan-out, else the
 // single service's label.
-func applyTitle(global bool, targets []data.StagingService) string {
-	return "Apply staged changes — " + targetTitle(global, targets)
+func applyTitle(global, marked bool, targets []data.StagingService) string {
+	return "Apply " + changesNoun(marked) + " — " + targetTitle(global, targets)
 }
 
 // resetTitle names the reset confirmation.
-func resetTitle(global bool, targets []data.StagingService) string {
-	return "Reset staged changes — " + targetTitle(global, targets)
+func resetTitle(global, marked bool, targets []data.StagingService) string {
+	return "Reset " + changesNoun(marked) + " — " + targetTitle(global, targets)
+}
+
+// changesNoun names what an apply/reset acts on: the staging page's marked
+// items, or everything staged.
+func changesNoun(marked bool) string {
+	if marked {
+		return "marked changes"
+	}
+
+	return "staged changes"
 }
 
 // targetTitle is "all" for a global fan-out, else the single target's label.
@@ -1076,11 +1182,17 @@ func (m *App) statusBar() components.StatusBar {
 
 // tabBar builds the tab-bar component for the current state.
 func (m *App) tabBar() components.TabBar {
-	return components.TabBar{
+	bar := components.TabBar{
 		Tabs:   m.tabs,
 		Active: m.activeTab,
 		Styles: m.styles,
 	}
+
+	if m.keys.Scope.Enabled() {
+		bar.Scope = m.scopeLabel()
+	}
+
+	return bar
 }
 
 // View composes the shell and returns a tea.View that also carries the
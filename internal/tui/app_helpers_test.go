@@ -54,16 +54,18 @@ func TestTargetTitle(t *testing.T) {
 }
 
 // TestApplyResetTitle pins the apply/reset confirmation titles compose the fixed
-// prefix with the target title.
+// prefix (naming marked changes for a marked subset) with the target title.
 func TestApplyResetTitle(t *testing.T) {
 	t.Parallel()
 
 	one := &goldenStaging{service: "secret", label: "Secret"}
 
-	assert.Equal(t, "Apply staged changes — Secret", applyTitle(false, []data.StagingService{one}))
-	assert.Equal(t, "Apply staged changes — all", applyTitle(true, []data.StagingService{one}))
-	assert.Equal(t, "Reset staged changes — Secret", resetTitle(false, []data.StagingService{one}))
-	assert.Equal(t, "Reset staged changes — all", resetTitle(true, []data.StagingService{one}))
+	assert.Equal(t, "Apply staged changes — Secret", applyTitle(false, false, []data.StagingService{one}))
+	assert.Equal(t, "Apply staged changes — all", applyTitle(true, false, []data.StagingService{one}))
+	assert.Equal(t, "Apply marked changes — all", applyTitle(true, true, []data.StagingService{one}))
+	assert.Equal(t, "Reset staged changes — Secret", resetTitle(false, false, []data.StagingService{one}))
+	assert.Equal(t, "Reset staged changes — all", resetTitle(true, false, []data.StagingService{one}))
+	assert.Equal(t, "Reset marked changes — Secret", resetTitle(false, true, []data.StagingService{one}))
 }
 
 // TestClipStatus pins the status-line width clamp: a status that fits is returned
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/mpyw/suve/internal/tui/data"
+	"github.com/mpyw/suve/internal/tui/dialogs"
+	"github.com/mpyw/suve/internal/tui/nav"
+)
+
+// pickerChangeset identifies the staging changeset picker's PickedMsg.
+const pickerChangeset = "changeset"
+
+// changesetSwitchedMsg reports a changeset switch finished.
+type changesetSwitchedMsg struct {
+	name string
+	err  error
+}
+
+// changesets resolves the changeset-selector seam over the offered services, or
+// nil when changesets are not wired.
+func (m *App) changesets() data.Changesets {
+	if m.changesetsFor == nil {
+		return nil
+	}
+
+	return m.changesetsFor(m.offeredServices())
+}
+
+// openChangesetPicker pushes the changeset picker with the cursor on the active
+// changeset. It is an input picker, so typing a name no changeset has yet
+// creates it on confirm (the CLI's `stage switch -c`).
+func (m *App) openChangesetPicker(req nav.OpenChangesets) tea.Cmd {
+	items := make([]dialogs.PickerItem, 0, len(req.Names))
+
+	for _, name := range req.Names {
+		item := dialogs.PickerItem{Value: name, Label: name}
+		if name == req.Active {
+			item.Detail = "active"
+		}
+
+		items = append(items, item)
+	}
+
+	return m.pushDialog(dialogs.NewInputPicker(m.styles, pickerChangeset, "Switch staging changeset", items, req.Active), nil)
+}
+
+// switchChangeset switches to the picked changeset off the update loop.
+func (m *App) switchChangeset(name string) tea.Cmd {
+	cs := m.changesets()
+	if cs == nil || name == "" {
+		return nil
+	}
+
+	return func() tea.Msg {
+		return changesetSwitchedMsg{name: name, err: cs.Switch(name)}
+	}
+}
+
+// onChangesetSwitched voices the switch and reloads the staging page, which
+// re-reads the newly active changeset's staged changes.
+func (m *App) onChangesetSwitched(msg changesetSwitchedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.pushDialog(dialogs.NewError(m.styles, "Switch changeset failed", msg.err.Error()), nil)
+
+		return nil
+	}
+
+	m.status = "Switched to changeset " + msg.name
+
+	return m.reloadActivePage()
+}
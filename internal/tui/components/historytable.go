@@ -32,6 +32,9 @@ type HistoryEntry struct {
 	// TagsLine is an indented per-version tag line (Azure Key Vault), empty when
 	// the provider keeps tags at the resource level.
 	TagsLine string
+	// MessageLine is an indented line with the change message the version was
+	// written with, empty when none was recorded.
+	MessageLine string
 }
 
 // HistoryTable is a scrollable, single-select version table with an optional
@@ -239,9 +242,9 @@ func (t *HistoryTable) window() (lines []string, rowOf []int) {
 }
 
 // rowLines returns one row's rendered lines: the version header, an indented
-// value line (masked unless revealed for a secret), and the per-version tag line
-// when present. The value and tag lines share the same indent so they read as
-// details of the header above them.
+// value line (masked unless revealed for a secret), and the per-version tag and
+// message lines when present. The detail lines share the same indent so they
+// read as details of the header above them.
 func (t *HistoryTable) rowLines(idx int) []string {
 	row := t.rows[idx]
 	lines := []string{t.renderRow(idx)}
@@ -254,6 +257,10 @@ func (t *HistoryTable) rowLines(idx int) []string {
 		lines = append(lines, t.styles.PageHint.Render(truncate("     "+row.TagsLine, t.width)))
 	}
 
+	if row.MessageLine != "" {
+		lines = append(lines, t.styles.PageHint.Render(truncate("     "+row.MessageLine, t.width)))
+	}
+
 	return lines
 }
 
@@ -5,6 +5,7 @@
 package components
 
 import (
+	"path/filepath"
 	"strings"
 
 	"charm.land/lipgloss/v2"
@@ -23,9 +24,9 @@ type AWSIdentity struct {
 	Profile string
 }
 
-// StatusBar renders the fixed top line: the provider and its scope. Provider
-// and scope never change for the process lifetime (they are fixed at launch),
-// so the only mutable input is the AWS identity, which loads asynchronously.
+// StatusBar renders the fixed top line: the provider and its scope. The
+// provider is fixed at launch and the scope changes only on an in-app switch,
+// so the other mutable input is the AWS identity, which loads asynchronously.
 type StatusBar struct {
 	Scope  provider.Scope
 	Styles styles.Styles
@@ -43,7 +44,7 @@ func (s StatusBar) View(width int) string {
 	segs := s.scopeSegments()
 
 	parts := make([]string, 0, 1+len(segs))
-	parts = append(parts, s.Styles.StatusValue.Render(providerLabel(s.Scope.Provider)))
+	parts = append(parts, s.Styles.StatusValue.Render(providerLabel(s.Scope)))
 	parts = append(parts, segs...)
 
 	line := s.Styles.StatusBar.Render("suve") + s.Styles.StatusKey.Render("  ") +
@@ -68,6 +69,19 @@ func (s StatusBar) scopeSegments() []string {
 		out = append(out, s.kvSegments("ns", s.Scope.AppConfigNamespace)...)
 
 		return out
+	case provider.ProviderVault:
+		return append(s.kvSegments("addr", s.Scope.VaultAddress), s.kvSegments("mount", s.Scope.VaultMount)...)
+	case provider.ProviderKubernetes:
+		return append(s.kvSegments("context", s.Scope.KubeContext), s.kvSegments("ns", s.Scope.KubeNamespace)...)
+	case provider.ProviderSOPS:
+		// The base name keeps the bar short; the apply prompt shows the full path.
+		return s.kvSegments("file", filepath.Base(s.Scope.SOPSFile))
+	case provider.ProviderLocal:
+		// One shared directory; nothing to show.
+		return nil
+	case provider.ProviderPlugin:
+		// The label already names the plugin.
+		return nil
 	default:
 		return nil
 	}
@@ -103,8 +117,11 @@ func (s StatusBar) kvSegments(key, value string) []string {
 	return []string{s.Styles.StatusKey.Render(key+":") + s.Styles.StatusValue.Render(value)}
 }
 
-// providerLabel maps a provider to its status-bar label.
-func providerLabel(p provider.Provider) string {
+// providerLabel maps a scope's provider to its status-bar label; a plugin is
+// labelled with its name.
+func providerLabel(scope provider.Scope) string {
+	p := scope.Provider
+
 	switch p {
 	case provider.ProviderAWS:
 		return "aws"
@@ -112,6 +129,16 @@ func providerLabel(p provider.Provider) string {
 		return "googlecloud"
 	case provider.ProviderAzure:
 		return "azure"
+	case provider.ProviderVault:
+		return "vault"
+	case provider.ProviderKubernetes:
+		return "kubernetes"
+	case provider.ProviderSOPS:
+		return "sops"
+	case provider.ProviderLocal:
+		return "local"
+	case provider.ProviderPlugin:
+		return "plugin:" + scope.Plugin
 	default:
 		return string(p)
 	}
@@ -20,8 +20,9 @@ type Tab struct {
 // the render drew — the click test derives coordinates from TabAtX rather than
 // hard-coding them, so moving these never breaks it.
 const (
-	tabBarLeftPad = 1
-	tabGap        = 1
+	tabBarLeftPad  = 1
+	tabBarRightPad = 1
+	tabGap         = 1
 )
 
 // TabBar renders the row of tab labels and hit-tests mouse clicks against them.
@@ -29,6 +30,9 @@ type TabBar struct {
 	Tabs   []Tab
 	Active int
 	Styles styles.Styles
+	// Scope, when non-empty, is the switchable scope ("region us-west-2")
+	// right-aligned after the tabs; it is dropped when the row has no room.
+	Scope string
 }
 
 // cells renders each tab to its styled cell, active tab highlighted.
@@ -50,6 +54,13 @@ func (b TabBar) cells() []string {
 func (b TabBar) View(width int) string {
 	line := strings.Repeat(" ", tabBarLeftPad) + strings.Join(b.cells(), strings.Repeat(" ", tabGap))
 
+	if b.Scope != "" && width > 0 {
+		label := b.Styles.StatusKey.Render(b.Scope)
+		if gap := width - lipgloss.Width(line) - lipgloss.Width(label) - tabBarRightPad; gap >= tabGap {
+			line += strings.Repeat(" ", gap) + label
+		}
+	}
+
 	return truncate(line, width)
 }
 
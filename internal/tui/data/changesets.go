@@ -0,0 +1,140 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/file"
+	stagingusecase "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// Changesets is the staging page's changeset-selector and apply-journal seam:
+// the named staging areas the scope's services share (see
+// file.ListChangesetsIn), which one stage reads and writes go to, and the
+// applies recorded for the scopes. Every call touches only the local staging
+// directory, but resolving the scopes may need the AWS caller identity, so the
+// app runs them as commands, never on the update loop.
+type Changesets interface {
+	// List returns every changeset (the default first) and the active one.
+	List() (names []string, active string, err error)
+	// Switch makes name the active changeset, creating it when it does not
+	// exist yet.
+	Switch(name string) error
+	// Applies returns the recorded applies, newest first (the CLI's
+	// `stage history`).
+	Applies() ([]*staging.ApplyRecord, error)
+	// Undo stages the inverse of the apply with the given ID into the active
+	// changeset (the CLI's `stage undo`) and returns how many changes it staged.
+	Undo(id string) (int, error)
+}
+
+// ChangesetScopeResolver resolves the distinct staging scopes of the scope's
+// services, which switch changesets together.
+type ChangesetScopeResolver func() ([]provider.Scope, error)
+
+// changesets is the concrete Changesets over the file store's changesets.
+type changesets struct {
+	scopes ChangesetScopeResolver
+}
+
+// NewChangesets builds the Changesets seam over the scopes resolve yields.
+func NewChangesets(resolve ChangesetScopeResolver) Changesets {
+	return &changesets{scopes: resolve}
+}
+
+func (c *changesets) List() ([]string, string, error) {
+	scopes, err := c.scopes()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return file.ListChangesetsIn(scopes)
+}
+
+func (c *changesets) Switch(name string) error {
+	scopes, err := c.scopes()
+	if err != nil {
+		return err
+	}
+
+	names, _, err := file.ListChangesetsIn(scopes)
+	if err != nil {
+		return err
+	}
+
+	return file.SwitchChangesetIn(scopes, name, !slices.Contains(names, name))
+}
+
+func (c *changesets) Applies() ([]*staging.ApplyRecord, error) {
+	stores, err := c.workingStores()
+	if err != nil {
+		return nil, err
+	}
+
+	listings := make([][]*staging.ApplyRecord, 0, len(stores))
+
+	for _, st := range stores {
+		found, err := st.Journal(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		listings = append(listings, found)
+	}
+
+	return staging.MergeApplyRecords(listings...), nil
+}
+
+func (c *changesets) Undo(id string) (int, error) {
+	stores, err := c.workingStores()
+	if err != nil {
+		return 0, err
+	}
+
+	var staged int
+
+	for _, st := range stores {
+		result, err := (&stagingusecase.UndoUseCase{Working: st, Journal: st}).
+			Execute(context.Background(), stagingusecase.UndoInput{ID: id})
+		if errors.Is(err, file.ErrJournalNotFound) || errors.Is(err, stagingusecase.ErrNothingToUndo) {
+			continue
+		}
+
+		if err != nil {
+			return staged, err
+		}
+
+		staged += result.EntryCount + result.TagCount
+	}
+
+	if staged == 0 {
+		return 0, fmt.Errorf("%w: apply %s", stagingusecase.ErrNothingToUndo, id)
+	}
+
+	return staged, nil
+}
+
+// workingStores opens the active changeset's store of every scope.
+func (c *changesets) workingStores() ([]*file.Store, error) {
+	scopes, err := c.scopes()
+	if err != nil {
+		return nil, err
+	}
+
+	stores := make([]*file.Store, 0, len(scopes))
+
+	for _, scope := range scopes {
+		st, err := file.NewWorkingStore(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		stores = append(stores, st)
+	}
+
+	return stores, nil
+}
@@ -20,11 +20,13 @@ import (
 
 	"github.com/mpyw/suve/internal/capability"
 	"github.com/mpyw/suve/internal/cli/commands/aws/param/paramtype"
+	"github.com/mpyw/suve/internal/cli/commands/azure/param/settingtype"
 	"github.com/mpyw/suve/internal/domain"
 	"github.com/mpyw/suve/internal/provider"
 	"github.com/mpyw/suve/internal/provider/azure/appconfig"
 	"github.com/mpyw/suve/internal/provider/azure/appconfig/aznamespace"
 	"github.com/mpyw/suve/internal/timeutil"
+	"github.com/mpyw/suve/internal/usecase/azure"
 	"github.com/mpyw/suve/internal/usecase/param"
 	"github.com/mpyw/suve/internal/usecase/secret"
 	"github.com/mpyw/suve/internal/version/awsparamversion"
@@ -62,6 +64,11 @@ type Item struct {
 	// Namespace is the entry's Azure App Configuration namespace (empty for the
 	// null namespace and every other provider).
 	Namespace string
+	// Reference marks an Azure App Configuration Key Vault reference, so the list
+	// can badge it.
+	Reference bool
+	// Locked marks a read-only Azure App Configuration setting.
+	Locked bool
 }
 
 // ListResult is a page of list items plus the paging cursor.
@@ -110,6 +117,11 @@ type Detail struct {
 	// ARN is the Secrets Manager ARN surfaced from the entry's Extra metadata,
 	// empty for providers that expose none.
 	ARN string
+	// Reference is the Key Vault secret URI of an App Configuration Key Vault
+	// reference, empty for every other entry. Resolved reports whether Value has
+	// been replaced by the referenced secret (see RefResolver).
+	Reference string
+	Resolved  bool
 }
 
 // HistoryRow is one version row in the detail history.
@@ -133,6 +145,12 @@ type HistoryRow struct {
 	Secret bool
 	// Tags are this version's tags (Azure Key Vault per-version tags only).
 	Tags []Tag
+	// Expires is this version's pre-formatted expiry (Azure Key Vault only),
+	// empty when unset.
+	Expires string
+	// Message is the change message this version was written with, empty when
+	// none was recorded.
+	Message string
 }
 
 // DiffContent carries the two raw version values and their labels so the diff
@@ -170,6 +188,15 @@ type Source interface {
 	Namespaces(ctx context.Context) ([]string, error)
 }
 
+// RefResolver is the optional Source extension that follows an Azure App
+// Configuration Key Vault reference to the secret it points at. Only the App
+// Configuration param source implements it; the browser type-asserts for it.
+type RefResolver interface {
+	// ResolveRef returns d with Value replaced by the referenced secret, marked
+	// Secret so the pane masks it until revealed. d must carry a Reference.
+	ResolveRef(ctx context.Context, d Detail) (Detail, error)
+}
+
 // StoreResolver resolves a param provider.Store for an App Configuration
 // namespace. For non-App-Configuration providers the namespace is ignored and
 // the same store is returned for every call.
@@ -190,6 +217,8 @@ type appConfigNamespaceLister interface {
 type paramSource struct {
 	svcCap  capability.ServiceCapability
 	resolve StoreResolver
+	// refs follows Key Vault references (App Configuration only; nil otherwise).
+	refs *azure.RefResolver
 }
 
 // NewParamSource builds a param Source. resolve returns the param store for a
@@ -199,6 +228,15 @@ func NewParamSource(svcCap capability.ServiceCapability, resolve StoreResolver)
 	return &paramSource{svcCap: svcCap, resolve: resolve}
 }
 
+// NewAppConfigSource builds the Azure App Configuration param Source. vault
+// resolves the Key Vault secret reader a Key Vault reference points into, so the
+// source also implements RefResolver.
+func NewAppConfigSource(
+	svcCap capability.ServiceCapability, resolve StoreResolver, vault azure.VaultReaderFunc,
+) Source {
+	return &paramSource{svcCap: svcCap, resolve: resolve, refs: &azure.RefResolver{Vault: vault}}
+}
+
 func (s *paramSource) Capability() capability.ServiceCapability { return s.svcCap }
 
 func (s *paramSource) List(ctx context.Context, params ListParams) (ListResult, error) {
@@ -266,7 +304,13 @@ func (s *paramSource) listWithNamespaces(
 			return Item{}, false
 		}
 
-		item := Item{Name: row.Key, Namespace: row.Namespace, TypeLabel: paramtype.Display(domain.ValueTypePlaintext)}
+		item := Item{
+			Name:      row.Key,
+			Namespace: row.Namespace,
+			TypeLabel: settingtype.Display(row.Type),
+			Reference: row.Type == domain.ValueTypeReference,
+			Locked:    row.Locked,
+		}
 		if params.WithValue {
 			item.Value = lo.ToPtr(row.Value)
 		}
@@ -307,13 +351,29 @@ func (s *paramSource) Show(ctx context.Context, name, namespace string) (Detail,
 	}
 
 	// App Configuration values are untyped, so only a typed param service (AWS
-	// SSM) shows a Type row — matching the GUI hiding it for App Config.
+	// SSM) shows a Type row — matching the GUI hiding it for App Config. The one
+	// exception is a Key Vault reference, whose target is worth surfacing.
 	if !s.svcCap.HasNamespaces {
 		d.Meta = append(d.Meta, MetaRow{Label: "Type", Value: typeLabel(out.Type, true)})
 	}
 
 	if s.svcCap.HasNamespaces {
+		d.TypeLabel = settingtype.Display(out.Type)
 		d.Meta = append(d.Meta, MetaRow{Label: "Namespace", Value: namespaceDisplay(namespace)})
+
+		if lo.Contains(out.Extra, domain.Field{Label: appconfig.LockedField, Value: "true"}) {
+			d.Meta = append(d.Meta, MetaRow{Label: "Locked", Value: "yes (read-only)"})
+		}
+
+		if out.Type == domain.ValueTypeReference {
+			if ref, err := appconfig.ParseKeyVaultRef(out.Value); err == nil {
+				d.Reference = ref.URI
+				d.Meta = append(d.Meta,
+					MetaRow{Label: "Type", Value: settingtype.KeyVaultReference},
+					MetaRow{Label: "Reference", Value: ref.URI},
+				)
+			}
+		}
 	}
 
 	if out.LastModified != nil {
@@ -323,6 +383,32 @@ func (s *paramSource) Show(ctx context.Context, name, namespace string) (Detail,
 	return d, nil
 }
 
+// ResolveRef follows d's Key Vault reference (see RefResolver). The resolved
+// secret is masked by default, like any Key Vault secret value.
+func (s *paramSource) ResolveRef(ctx context.Context, d Detail) (Detail, error) {
+	if s.refs == nil || d.Reference == "" {
+		return d, nil
+	}
+
+	resolved, err := s.refs.Resolve(ctx, d.Value)
+	if err != nil {
+		return d, err
+	}
+
+	d.Value = resolved.Value
+	d.Secret = true
+	d.Resolved = true
+	d.Meta = lo.Map(d.Meta, func(row MetaRow, _ int) MetaRow {
+		if row.Label == "Type" {
+			row.Value = settingtype.KeyVaultReference + " (resolved)"
+		}
+
+		return row
+	})
+
+	return d, nil
+}
+
 func (s *paramSource) History(ctx context.Context, name, namespace string) ([]HistoryRow, error) {
 	if !s.svcCap.HasVersionHistory {
 		return nil, nil
@@ -349,6 +435,7 @@ func (s *paramSource) History(ctx context.Context, name, namespace string) ([]Hi
 			Date:      formatDate(e.LastModified),
 			IsCurrent: e.IsCurrent,
 			Value:     e.Value,
+			Message:   e.Message,
 			// A SecureString param value is secret material on the value-type axis, so
 			// it is masked by default even though this is the param service (#733).
 			Secret: e.Type == domain.ValueTypeSecret,
@@ -489,6 +576,18 @@ func (s *secretSource) Show(ctx context.Context, name, _ string) (Detail, error)
 		d.Meta = append(d.Meta, MetaRow{Label: "ARN", Value: out.ARN})
 	}
 
+	if out.ContentType != "" {
+		d.Meta = append(d.Meta, MetaRow{Label: "Content Type", Value: out.ContentType})
+	}
+
+	if out.NotBefore != nil {
+		d.Meta = append(d.Meta, MetaRow{Label: "Not Before", Value: timeutil.FormatDateTime(*out.NotBefore)})
+	}
+
+	if out.Expires != nil {
+		d.Meta = append(d.Meta, MetaRow{Label: "Expires", Value: timeutil.FormatDateTime(*out.Expires)})
+	}
+
 	return d, nil
 }
 
@@ -518,6 +617,8 @@ func (s *secretSource) History(ctx context.Context, name, _ string) ([]HistoryRo
 			Tags: lo.Map(e.Tags, func(t domain.Tag, _ int) Tag {
 				return Tag{Key: t.Key, Value: t.Value}
 			}),
+			Expires: formatDate(e.Expires),
+			Message: e.Message,
 		}
 	}), nil
 }
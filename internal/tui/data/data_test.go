@@ -316,3 +316,58 @@ func metaLabels(rows []data.MetaRow) []string {
 
 	return labels
 }
+
+// TestAppConfigSourceKeyVaultReference pins that an App Configuration Key Vault
+// reference surfaces its target in the detail and, once resolved, carries the
+// referenced secret masked like any Key Vault value.
+func TestAppConfigSourceKeyVaultReference(t *testing.T) {
+	t.Parallel()
+
+	const ref = `{"uri":"https://my-vault.vault.azure.net/secrets/db-password"}`
+
+	store := &providermock.Store{
+		ResolveFunc: func(context.Context, string, string) (provider.VersionRef, error) {
+			return provider.VersionRef{}, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{Name: name, Value: ref, Type: domain.ValueTypeReference}, nil
+		},
+	}
+
+	var gotVault, gotSecret string
+
+	vault := func(_ context.Context, vaultName string) (provider.Reader, error) {
+		gotVault = vaultName
+
+		return &providermock.Store{
+			GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+				gotSecret = name
+
+				return &domain.Entry{Name: name, Value: "hunter2"}, nil
+			},
+		}, nil
+	}
+
+	src := data.NewAppConfigSource(capFor(t, "azure", "param"), func(context.Context, string) (provider.Store, error) {
+		return store, nil
+	}, vault)
+
+	d, err := src.Show(t.Context(), "app/db", "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://my-vault.vault.azure.net/secrets/db-password", d.Reference)
+	assert.Equal(t, "Key Vault reference", d.TypeLabel)
+	assert.False(t, d.Secret, "the reference document itself is not secret")
+	assert.Contains(t, metaLabels(d.Meta), "Reference")
+
+	resolver, ok := src.(data.RefResolver)
+	require.True(t, ok, "the App Configuration source resolves references")
+
+	resolved, err := resolver.ResolveRef(t.Context(), d)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", resolved.Value)
+	assert.True(t, resolved.Secret, "the resolved secret is masked by default")
+	assert.True(t, resolved.Resolved)
+	assert.Equal(t, d.Reference, resolved.Reference, "the reference stays visible next to the value")
+	assert.Equal(t, "my-vault", gotVault)
+	assert.Equal(t, "db-password", gotSecret)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/mpyw/suve/internal/capability"
 	"github.com/mpyw/suve/internal/cli/commands/aws/param/paramtype"
+	"github.com/mpyw/suve/internal/cli/commands/azure/param/settingtype"
 	"github.com/mpyw/suve/internal/domain"
 	"github.com/mpyw/suve/internal/maputil"
 	"github.com/mpyw/suve/internal/provider"
@@ -145,7 +146,7 @@ func (m *paramMutator) Create(
 		return WriteOutcome{}, err
 	}
 
-	valueType := paramtype.Parse(typeLabel)
+	valueType := parseTypeLabel(typeLabel)
 
 	// Immediate create is a create-or-update (upsert), matching the GUI (ParamSet)
 	// and the CLI (`param set`): try create first, and if the parameter already
@@ -198,7 +199,7 @@ func (m *paramMutator) Update(
 	uc := &param.UpdateUseCase{Store: store}
 
 	_, err = uc.Execute(ctx, param.UpdateInput{
-		Name: key.Name, Value: value, Type: paramtype.Parse(typeLabel), Description: description,
+		Name: key.Name, Value: value, Type: parseTypeLabel(typeLabel), Description: description,
 	})
 
 	return WriteOutcome{}, err
@@ -476,18 +477,28 @@ const (
 )
 
 // stagedValueType maps a Type display label to the value type to stage. The
-// dialog passes an empty label when it presents no Type control (a secret, an App
-// Configuration setting, or the staging-review edit that cannot seed the current
-// type); an empty value means "no explicit type", which the staging apply treats
-// as plaintext for a create and as "preserve the existing type" for an edit — so
-// an edit from a surface with no Type control never downgrades a staged
+// dialog passes an empty label when it presents no Type control (a secret, or the
+// staging-review edit that cannot seed the current type); an empty value means
+// "no explicit type", which the staging apply treats as plaintext for a create
+// and as "preserve the existing type" for an edit — so an edit from a surface with no Type control never downgrades a staged
 // SecureString. A non-empty label (an offered Type select) is mapped through
-// paramtype.Parse so the chosen type is stored and applied.
+// parseTypeLabel so the chosen type is stored and applied.
 func stagedValueType(typeLabel string) domain.ValueType {
 	if typeLabel == "" {
 		return ""
 	}
 
+	return parseTypeLabel(typeLabel)
+}
+
+// parseTypeLabel maps a Type select label to its value type. The App
+// Configuration labels ("Key-value", "Key Vault reference") and the AWS SSM ones
+// ("String", …) are disjoint, so one parser serves both param services.
+func parseTypeLabel(typeLabel string) domain.ValueType {
+	if settingtype.Valid(typeLabel) {
+		return settingtype.Parse(typeLabel)
+	}
+
 	return paramtype.Parse(typeLabel)
 }
 
@@ -128,6 +128,86 @@ type StagingApplyResult struct {
 	Conflicts []string
 }
 
+// ApplyProgress tracks the live progress of the applies run under the context
+// Context returns, for the apply dialog's count and per-change spinners. It is
+// safe to read while the applies run.
+type ApplyProgress struct {
+	tracker staging.ApplyTracker
+}
+
+// ApplyProgressSnapshot is the progress of the applies at one point.
+type ApplyProgressSnapshot struct {
+	// Total counts the changes queued so far, Done the finished ones, and
+	// Failed those of Done that failed.
+	Total  int
+	Done   int
+	Failed int
+	// Running lists the changes in flight, in the order they started.
+	Running []ApplyProgressItem
+}
+
+// ApplyProgressItem is one change in flight.
+type ApplyProgressItem struct {
+	Name      string
+	Namespace string
+	// Operation is "create" / "update" / "delete", or "tag" for a tag change.
+	Operation string
+	// Retry is the attempt a throttled change is waiting to retry (0 while it
+	// runs unthrottled).
+	Retry int
+}
+
+// Context returns ctx reporting the progress of the applies run under it to p.
+func (p *ApplyProgress) Context(ctx context.Context) context.Context {
+	return staging.WithApplyProgress(ctx, p.tracker.Report)
+}
+
+// Snapshot returns the current progress.
+func (p *ApplyProgress) Snapshot() ApplyProgressSnapshot {
+	progress := p.tracker.Progress()
+
+	return ApplyProgressSnapshot{
+		Total:  progress.Total,
+		Done:   progress.Done,
+		Failed: progress.Failed,
+		Running: lo.Map(progress.Running, func(ev staging.ApplyEvent, _ int) ApplyProgressItem {
+			item := ApplyProgressItem{Name: ev.Key.Name, Namespace: ev.Key.Namespace, Operation: string(ev.Operation)}
+			if ev.Tags {
+				item.Operation = "tag"
+			}
+
+			if ev.Kind == staging.ApplyEventRetrying {
+				item.Retry = ev.Attempt
+			}
+
+			return item
+		}),
+	}
+}
+
+// StagedResolveRow is one conflicting entry's resolve outcome.
+type StagedResolveRow struct {
+	Name      string
+	Namespace string
+	// Status is "merged" / "edited" / "up-to-date" / "unresolved" / "skipped" /
+	// "failed".
+	Status string
+	// Conflicts counts the regions the automatic merge could not resolve.
+	Conflicts int
+	// Merged is the merge with conflict markers of an unresolved entry — the
+	// buffer the page hands to the editor.
+	Merged string
+	// Detail is the skip reason or the failure (empty otherwise).
+	Detail string
+}
+
+// StagingResolveResult is the outcome of resolving a service's conflicts.
+type StagingResolveResult struct {
+	Entries []StagedResolveRow
+	// RebasedTags counts the staged tag changes re-anchored at the remote.
+	RebasedTags int
+}
+
 // StagingResetType mirrors the staging ResetUseCase's ResetResultType so the
 // page can voice the exact outcome.
 type StagingResetType int
@@ -141,6 +221,8 @@ const (
 	StagingResetNothingStaged
 	StagingResetSkipped
 	StagingResetUnstagedTag
+	StagingResetUnstagedSelected
+	StagingResetNothingSelected
 )
 
 // StagingResetResult is the outcome of resetting a service.
@@ -165,12 +247,22 @@ type StagingService interface {
 	// Review returns the staged entries (as diffs) and tag changes; it may
 	// auto-unstage entries whose staged value now equals remote.
 	Review(ctx context.Context) (StagingReview, error)
-	// Apply applies the service's staged changes. A conflict rejection or a
-	// per-entry failure returns a POPULATED result (the detail is in its fields),
-	// not an error; only a hard store failure returns a non-nil error.
-	Apply(ctx context.Context, ignoreConflicts bool) (StagingApplyResult, error)
-	// Reset unstages every staged change for the service.
-	Reset(ctx context.Context) (StagingResetResult, error)
+	// Apply applies the service's staged changes, or only those of the items in
+	// keys when it is non-empty (the page's marked rows). A conflict rejection or
+	// a per-entry failure returns a POPULATED result (the detail is in its
+	// fields), not an error; only a hard store failure returns a non-nil error.
+	Apply(ctx context.Context, ignoreConflicts bool, keys []StagedKey) (StagingApplyResult, error)
+	// Reset unstages every staged change for the service, or only those of the
+	// items in keys when it is non-empty.
+	Reset(ctx context.Context, keys []StagedKey) (StagingResetResult, error)
+	// Resolve merges remote changes into the service's conflicting staged
+	// entries. A clean merge is re-staged; a conflicted one comes back
+	// "unresolved" with its marked-up merge for the editor.
+	Resolve(ctx context.Context) (StagingResolveResult, error)
+	// ResolveEdited re-stages one entry with the user's edit of merged. The
+	// entry stays "unresolved" when markers remain or the remote changed again
+	// since merged was produced (its row then carries the fresh merge).
+	ResolveEdited(ctx context.Context, key StagedKey, merged, edited string) (StagingResolveResult, error)
 	// Unstage removes one item's staged entry and its staged tags.
 	Unstage(ctx context.Context, key StagedKey) error
 	// CancelAddTag drops one staged tag add.
@@ -282,13 +374,17 @@ func compareKey(aName, aNS, bName, bNS string) int {
 	return strings.Compare(aNS, bNS)
 }
 
-func (s *stagingService) Apply(ctx context.Context, ignoreConflicts bool) (StagingApplyResult, error) {
+func (s *stagingService) Apply(ctx context.Context, ignoreConflicts bool, keys []StagedKey) (StagingApplyResult, error) {
 	res, err := s.resolve(ctx)
 	if err != nil {
 		return StagingApplyResult{}, err
 	}
 
 	uc := &stagingusecase.ApplyUseCase{Strategy: res.Strategy, Store: res.Store}
+	if journal, ok := res.Store.(stagingusecase.ApplyJournal); ok {
+		uc.Journal = journal
+	}
+
 	if res.StrategyFor != nil {
 		uc.StrategyFor = func(ns string) (staging.ApplyStrategy, error) {
 			return res.StrategyFor(ns)
@@ -297,7 +393,7 @@ func (s *stagingService) Apply(ctx context.Context, ignoreConflicts bool) (Stagi
 
 	// A conflict/partial-failure returns a populated output with an error; only a
 	// nil output (a store read failure) is a hard error with nothing to show.
-	out, err := uc.Execute(ctx, stagingusecase.ApplyInput{IgnoreConflicts: ignoreConflicts})
+	out, err := uc.Execute(ctx, stagingusecase.ApplyInput{IgnoreConflicts: ignoreConflicts, Select: keySelector(keys)})
 	if out == nil {
 		return StagingApplyResult{}, err
 	}
@@ -347,7 +443,7 @@ func (s *stagingService) newApplyResult(out *stagingusecase.ApplyOutput) Staging
 	}
 }
 
-func (s *stagingService) Reset(ctx context.Context) (StagingResetResult, error) {
+func (s *stagingService) Reset(ctx context.Context, keys []StagedKey) (StagingResetResult, error) {
 	res, err := s.resolve(ctx)
 	if err != nil {
 		return StagingResetResult{}, err
@@ -355,7 +451,7 @@ func (s *stagingService) Reset(ctx context.Context) (StagingResetResult, error)
 
 	uc := &stagingusecase.ResetUseCase{Parser: res.Strategy, Store: res.Store}
 
-	out, err := uc.Execute(ctx, stagingusecase.ResetInput{All: true})
+	out, err := uc.Execute(ctx, stagingusecase.ResetInput{All: len(keys) == 0, Select: keySelector(keys)})
 	if err != nil {
 		return StagingResetResult{}, err
 	}
@@ -367,6 +463,66 @@ func (s *stagingService) Reset(ctx context.Context) (StagingResetResult, error)
 	}, nil
 }
 
+func (s *stagingService) Resolve(ctx context.Context) (StagingResolveResult, error) {
+	return s.resolveConflicts(ctx, "", nil)
+}
+
+func (s *stagingService) ResolveEdited(ctx context.Context, key StagedKey, merged, edited string) (StagingResolveResult, error) {
+	// Hand the edit back only for the merge the user actually edited; any other
+	// merge (another namespace, or a remote that moved on) keeps its markers and
+	// so stays unresolved without touching the store.
+	edit := func(_ context.Context, k staging.EntryKey, m string) (string, error) {
+		if k.Namespace != key.Namespace || m != merged {
+			return m, nil
+		}
+
+		return edited, nil
+	}
+
+	return s.resolveConflicts(ctx, key.Name, edit)
+}
+
+// resolveConflicts runs the resolve use case, optionally scoped to one name.
+func (s *stagingService) resolveConflicts(
+	ctx context.Context, name string, edit stagingusecase.ResolveEditFunc,
+) (StagingResolveResult, error) {
+	res, err := s.resolve(ctx)
+	if err != nil {
+		return StagingResolveResult{}, err
+	}
+
+	uc := &stagingusecase.ResolveUseCase{Strategy: res.Strategy, Store: res.Store, Edit: edit}
+	if res.StrategyFor != nil {
+		uc.StrategyFor = func(ns string) (stagingusecase.ResolveStrategy, error) {
+			return res.StrategyFor(ns)
+		}
+	}
+
+	out, err := uc.Execute(ctx, stagingusecase.ResolveInput{Name: name})
+	if err != nil {
+		return StagingResolveResult{}, err
+	}
+
+	return StagingResolveResult{
+		Entries: lo.Map(out.Entries, func(e stagingusecase.ResolveEntryResult, _ int) StagedResolveRow {
+			row := StagedResolveRow{
+				Name:      e.Key.Name,
+				Namespace: e.Key.Namespace,
+				Status:    resolveStatusLabel(e.Status),
+				Conflicts: e.Conflicts,
+				Merged:    e.Merged,
+				Detail:    e.Reason,
+			}
+			if e.Error != nil {
+				row.Detail = e.Error.Error()
+			}
+
+			return row
+		}),
+		RebasedTags: len(out.RebasedTags),
+	}, nil
+}
+
 func (s *stagingService) Unstage(ctx context.Context, key StagedKey) error {
 	res, err := s.resolve(ctx)
 	if err != nil {
@@ -452,6 +608,15 @@ func stagedDiffType(t stagingusecase.DiffEntryType) StagedDiffType {
 	}
 }
 
+// keySelector selects the items in keys; no keys select everything.
+func keySelector(keys []StagedKey) staging.Selector {
+	return staging.Selector{
+		Keys: lo.Map(keys, func(k StagedKey, _ int) staging.EntryKey {
+			return staging.EntryKey{Name: k.Name, Namespace: k.Namespace}
+		}),
+	}
+}
+
 // stagingResetType maps the use-case reset type onto the neutral one.
 func stagingResetType(t stagingusecase.ResetResultType) StagingResetType {
 	switch t {
@@ -467,6 +632,10 @@ func stagingResetType(t stagingusecase.ResetResultType) StagingResetType {
 		return StagingResetSkipped
 	case stagingusecase.ResetResultUnstagedTag:
 		return StagingResetUnstagedTag
+	case stagingusecase.ResetResultUnstagedSelected:
+		return StagingResetUnstagedSelected
+	case stagingusecase.ResetResultNothingSelected:
+		return StagingResetNothingSelected
 	default:
 		return StagingResetUnstaged
 	}
@@ -485,3 +654,21 @@ func applyStatusLabel(s stagingusecase.ApplyResultStatus) string {
 		return "failed"
 	}
 }
+
+// resolveStatusLabel maps a resolve status enum onto its display label.
+func resolveStatusLabel(s stagingusecase.ResolveStatus) string {
+	switch s {
+	case stagingusecase.ResolveMerged:
+		return "merged"
+	case stagingusecase.ResolveEdited:
+		return "edited"
+	case stagingusecase.ResolveUpToDate:
+		return "up-to-date"
+	case stagingusecase.ResolveUnresolved:
+		return "unresolved"
+	case stagingusecase.ResolveSkipped:
+		return "skipped"
+	default:
+		return "failed"
+	}
+}
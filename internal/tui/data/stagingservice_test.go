@@ -3,6 +3,7 @@ package data_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
@@ -185,8 +186,9 @@ func TestStagingService_Review_StrategyForNamespace(t *testing.T) {
 	assert.Equal(t, "prod", review.Entries[1].Namespace)
 }
 
-// TestStagingService_Reset covers Reset over both a populated and an empty store,
-// pinning stagingResetType's UnstagedAll and NothingStaged mappings and the count.
+// TestStagingService_Reset covers Reset over both a populated and an empty store
+// and over a set of items, pinning stagingResetType's UnstagedAll, NothingStaged
+// and UnstagedSelected mappings and the count.
 //
 //nolint:paralleltest // sets HOME / SUVE_STAGING_KEY via t.Setenv (newStagingService)
 func TestStagingService_Reset(t *testing.T) {
@@ -201,7 +203,7 @@ func TestStagingService_Reset(t *testing.T) {
 			Add: map[string]string{"k": "v"},
 		}))
 
-		out, err := svc.Reset(ctx)
+		out, err := svc.Reset(ctx, nil)
 		require.NoError(t, err)
 		assert.Equal(t, data.StagingResetUnstagedAll, out.Type)
 		assert.Equal(t, 3, out.Count, "two entries plus one tag change")
@@ -214,11 +216,32 @@ func TestStagingService_Reset(t *testing.T) {
 	t.Run("nothing staged", func(t *testing.T) {
 		svc, _ := newStagingService(t, awsParamCap(t), &providermock.Store{}, false)
 
-		out, err := svc.Reset(ctx)
+		out, err := svc.Reset(ctx, nil)
 		require.NoError(t, err)
 		assert.Equal(t, data.StagingResetNothingStaged, out.Type)
 		assert.Zero(t, out.Count)
 	})
+
+	t.Run("unstages only the given items", func(t *testing.T) {
+		svc, st := newStagingService(t, awsParamCap(t), &providermock.Store{}, false)
+
+		stageEntry(ctx, t, st, staging.EntryKey{Name: "/app/A"}, staging.Entry{Operation: staging.OperationCreate, Value: lo.ToPtr("a")})
+		stageEntry(ctx, t, st, staging.EntryKey{Name: "/app/B"}, staging.Entry{Operation: staging.OperationCreate, Value: lo.ToPtr("b")})
+		require.NoError(t, st.StageTag(ctx, staging.ServiceParam, staging.EntryKey{Name: "/app/A"}, staging.TagEntry{
+			Add: map[string]string{"k": "v"},
+		}))
+
+		out, err := svc.Reset(ctx, []data.StagedKey{{Name: "/app/A"}})
+		require.NoError(t, err)
+		assert.Equal(t, data.StagingResetUnstagedSelected, out.Type)
+		assert.Equal(t, 2, out.Count, "the entry and the tag change of /app/A")
+
+		_, err = st.GetEntry(ctx, staging.ServiceParam, staging.EntryKey{Name: "/app/A"})
+		require.ErrorIs(t, err, staging.ErrNotStaged)
+
+		_, err = st.GetEntry(ctx, staging.ServiceParam, staging.EntryKey{Name: "/app/B"})
+		require.NoError(t, err)
+	})
 }
 
 // TestStagingService_Unstage pins the ErrNotStaged-tolerant removal of one item's
@@ -262,6 +285,84 @@ func TestStagingService_Unstage(t *testing.T) {
 	})
 }
 
+// resolveProvider serves /app/CFG at version 2 (modified after staging) with
+// version 1 as the staged base.
+func resolveProvider(remote string) *providermock.Store {
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	modified := base.Add(time.Hour)
+
+	return &providermock.Store{
+		GetFunc: func(_ context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+			if ref.ID() == "1" {
+				return &domain.Entry{Name: name, Value: `{"a":"1","b":"1"}`, Version: domain.Version{ID: "1"}}, nil
+			}
+
+			return &domain.Entry{
+				Name: name, Value: remote, Type: domain.ValueTypePlaintext,
+				Version: domain.Version{ID: "2"}, Modified: &modified,
+			}, nil
+		},
+		HistoryFunc: func(context.Context, string) ([]domain.Version, error) {
+			return []domain.Version{{ID: "2", Created: &modified}, {ID: "1", Created: &base}}, nil
+		},
+	}
+}
+
+// TestStagingService_Resolve covers the page's two-step resolve: a clean merge is
+// re-staged directly, a conflict comes back with its markers, and ResolveEdited
+// stores the user's edit only for the merge that was edited.
+//
+//nolint:paralleltest // sets HOME / SUVE_STAGING_KEY via t.Setenv (newStagingService)
+func TestStagingService_Resolve(t *testing.T) {
+	ctx := context.Background()
+	key := staging.EntryKey{Name: "/app/CFG"}
+	stagedAt := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	stageConfig := func(t *testing.T, st store.ReadWriteOperator, value string) {
+		t.Helper()
+		stageEntry(ctx, t, st, key, staging.Entry{
+			Operation: staging.OperationUpdate, Value: lo.ToPtr(value), BaseModifiedAt: &stagedAt,
+		})
+	}
+
+	t.Run("clean merge", func(t *testing.T) {
+		svc, st := newStagingService(t, awsParamCap(t), resolveProvider(`{"a":"1","b":"3"}`), false)
+		stageConfig(t, st, `{"a":"2","b":"1"}`)
+
+		res, err := svc.Resolve(ctx)
+		require.NoError(t, err)
+		require.Len(t, res.Entries, 1)
+		assert.Equal(t, "merged", res.Entries[0].Status)
+
+		entry, err := st.GetEntry(ctx, staging.ServiceParam, key)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"a":"2","b":"3"}`, *entry.Value)
+	})
+
+	t.Run("conflict then edit", func(t *testing.T) {
+		svc, st := newStagingService(t, awsParamCap(t), resolveProvider(`{"a":"3","b":"1"}`), false)
+		stageConfig(t, st, `{"a":"2","b":"1"}`)
+
+		res, err := svc.Resolve(ctx)
+		require.NoError(t, err)
+		require.Len(t, res.Entries, 1)
+		assert.Equal(t, "unresolved", res.Entries[0].Status)
+		assert.Contains(t, res.Entries[0].Merged, ">>>>>>> remote #2")
+
+		stale, err := svc.ResolveEdited(ctx, data.StagedKey{Name: "/app/CFG"}, "stale merge", `{"a":"9"}`)
+		require.NoError(t, err)
+		assert.Equal(t, "unresolved", stale.Entries[0].Status, "an edit of another merge is not stored")
+
+		res, err = svc.ResolveEdited(ctx, data.StagedKey{Name: "/app/CFG"}, res.Entries[0].Merged, `{"a":"23","b":"1"}`)
+		require.NoError(t, err)
+		assert.Equal(t, "edited", res.Entries[0].Status)
+
+		entry, err := st.GetEntry(ctx, staging.ServiceParam, key)
+		require.NoError(t, err)
+		assert.Equal(t, `{"a":"23","b":"1"}`, *entry.Value)
+	})
+}
+
 // TestStagingService_CancelTags covers CancelAddTag / CancelRemoveTag / editStagedTag,
 // including the drop-to-empty branch that unstages the tag entry entirely and the
 // error surfaced when nothing is staged for the key.
@@ -326,3 +427,34 @@ func TestStagingService_CancelTags(t *testing.T) {
 		require.ErrorIs(t, err, staging.ErrNotStaged)
 	})
 }
+
+func TestApplyProgress(t *testing.T) {
+	t.Parallel()
+
+	var progress data.ApplyProgress
+
+	report := staging.ApplyProgressFrom(progress.Context(t.Context()))
+
+	db := staging.EntryKey{Name: "/app/db", Namespace: "prod"}
+	web := staging.EntryKey{Name: "/app/web"}
+
+	report(staging.ApplyEvent{Kind: staging.ApplyEventQueued, Key: db})
+	report(staging.ApplyEvent{Kind: staging.ApplyEventQueued, Key: web, Tags: true})
+	report(staging.ApplyEvent{Kind: staging.ApplyEventStarted, Key: db, Operation: staging.OperationUpdate})
+	report(staging.ApplyEvent{Kind: staging.ApplyEventStarted, Key: web, Tags: true})
+	report(staging.ApplyEvent{Kind: staging.ApplyEventRetrying, Key: web, Tags: true, Attempt: 2})
+
+	assert.Equal(t, data.ApplyProgressSnapshot{
+		Total: 2,
+		Running: []data.ApplyProgressItem{
+			{Name: "/app/db", Namespace: "prod", Operation: "update"},
+			{Name: "/app/web", Operation: "tag", Retry: 2},
+		},
+	}, progress.Snapshot())
+
+	report(staging.ApplyEvent{Kind: staging.ApplyEventFinished, Key: db, Operation: staging.OperationUpdate})
+
+	snapshot := progress.Snapshot()
+	assert.Equal(t, 1, snapshot.Done)
+	assert.Len(t, snapshot.Running, 1)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/viewport"
@@ -49,6 +50,15 @@ const (
 	phaseResults
 )
 
+// applyProgressInterval is how often the busy view redraws the progress.
+const applyProgressInterval = 100 * time.Millisecond
+
+// applySpinner holds the frames of the per-change spinner of the busy view.
+var applySpinner = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// applyTickMsg advances the busy view's spinners and redraws its progress.
+type applyTickMsg struct{}
+
 // applyResultsMsg carries the aggregated fan-out results back into the dialog.
 type applyResultsMsg struct {
 	results []data.StagingApplyResult
@@ -69,6 +79,7 @@ type applyDialog struct {
 
 	ctx        context.Context //nolint:containedctx // the apply command needs the Run context; mirrors the browser
 	targets    []data.StagingService
+	marked     map[string][]data.StagedKey
 	targetLine string
 	entryCount int
 	tagCount   int
@@ -89,6 +100,10 @@ type applyDialog struct {
 	// hits maps a click on the confirm controls (ignore/apply/cancel) or the
 	// results close hint to the same action their key equivalents perform.
 	hits *hit.Map
+	// progress tracks the running apply for the busy view's count and
+	// per-change spinners; frame is the spinner frame.
+	progress *data.ApplyProgress
+	frame    int
 }
 
 // ApplyInput configures an apply dialog.
@@ -104,7 +119,10 @@ type ApplyInput struct {
 	// EntryCount / TagCount are the staged totals across the targets.
 	EntryCount int
 	TagCount   int
-	Styles     styles.Styles
+	// Marked, when non-nil, applies only the items the staging page marked,
+	// keyed by service.
+	Marked map[string][]data.StagedKey
+	Styles styles.Styles
 }
 
 // NewApply builds an apply dialog.
@@ -112,6 +130,7 @@ func NewApply(in ApplyInput) Model {
 	return &applyDialog{
 		ctx:        in.Ctx,
 		targets:    in.Targets,
+		marked:     in.Marked,
 		targetLine: in.TargetLine,
 		entryCount: in.EntryCount,
 		tagCount:   in.TagCount,
@@ -142,6 +161,14 @@ func (d *applyDialog) Update(msg tea.Msg) (Model, tea.Cmd) {
 		d.syncViewport()
 
 		return d, nil
+	case applyTickMsg:
+		if d.phase != phaseBusy {
+			return d, nil
+		}
+
+		d.frame++
+
+		return d, applyTickCmd()
 	case applyResultsMsg:
 		d.phase = phaseResults
 		d.results = msg.results
@@ -244,8 +271,9 @@ func (d *applyDialog) activate() (Model, tea.Cmd) {
 		d.ignoreConflicts = !d.ignoreConflicts
 	case ctrlApply:
 		d.phase = phaseBusy
+		d.progress = &data.ApplyProgress{}
 
-		return d, d.applyCmd()
+		return d, tea.Batch(d.applyCmd(), applyTickCmd())
 	case ctrlApplyCancel:
 		return d, canceledCmd
 	}
@@ -254,17 +282,20 @@ func (d *applyDialog) activate() (Model, tea.Cmd) {
 }
 
 // applyCmd fans the apply out across every target sequentially (one goroutine,
-// so no shared state races) and aggregates the per-service results.
+// so no shared state races) and aggregates the per-service results. A marked
+// apply narrows each target to its marked items. Every target reports its
+// progress to d.progress, which the busy view draws.
 func (d *applyDialog) applyCmd() tea.Cmd {
-	ctx := d.ctx
+	ctx := d.progress.Context(d.ctx)
 	targets := d.targets
+	marked := d.marked
 	ignore := d.ignoreConflicts
 
 	return func() tea.Msg {
 		results := make([]data.StagingApplyResult, 0, len(targets))
 
 		for _, svc := range targets {
-			res, err := svc.Apply(ctx, ignore)
+			res, err := svc.Apply(ctx, ignore, marked[svc.Service()])
 			if err != nil {
 				return applyResultsMsg{results: results, err: err}
 			}
@@ -276,6 +307,11 @@ func (d *applyDialog) applyCmd() tea.Cmd {
 	}
 }
 
+// applyTickCmd schedules the busy view's next redraw.
+func applyTickCmd() tea.Cmd {
+	return tea.Tick(applyProgressInterval, func(time.Time) tea.Msg { return applyTickMsg{} })
+}
+
 func (d *applyDialog) View() string {
 	if d.phase == phaseResults {
 		return d.resultsView()
@@ -298,7 +334,7 @@ func (d *applyDialog) confirmView() string {
 
 	if d.phase == phaseBusy {
 		d.hits = hit.New() // no controls while applying
-		b.WriteString(d.styles.PageHint.Render("applying…"))
+		b.WriteString(d.progressView())
 
 		return b.String()
 	}
@@ -327,6 +363,34 @@ func (d *applyDialog) confirmView() string {
 	return b.String()
 }
 
+// progressView renders the busy view's "applying 3/10" count and a spinner
+// line for each change in flight.
+func (d *applyDialog) progressView() string {
+	progress := d.progress.Snapshot()
+	if progress.Total == 0 {
+		return d.styles.PageHint.Render("applying…")
+	}
+
+	count := fmt.Sprintf("applying %d/%d…", progress.Done, progress.Total)
+	if progress.Failed > 0 {
+		count += fmt.Sprintf(" (%d failed)", progress.Failed)
+	}
+
+	lines := []string{d.styles.PageHint.Render(count)}
+	spinner := applySpinner[d.frame%len(applySpinner)]
+
+	for _, item := range progress.Running {
+		line := spinner + " " + item.Operation + "  " + entryLabel(item.Name, item.Namespace)
+		if item.Retry > 0 {
+			line += d.styles.PageHint.Render(fmt.Sprintf("  throttled, retry %d", item.Retry))
+		}
+
+		lines = append(lines, d.fit(line))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 // confirmRow renders a focusable confirm control, marking the focused one.
 func (d *applyDialog) confirmRow(c applyControl, label string) string {
 	if applyControl(d.focus) == c {
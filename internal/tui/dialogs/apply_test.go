@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mpyw/suve/internal/capability"
+	"github.com/mpyw/suve/internal/staging"
 	"github.com/mpyw/suve/internal/tui/data"
 	"github.com/mpyw/suve/internal/tui/hit"
 	"github.com/mpyw/suve/internal/tui/styles"
@@ -43,6 +44,8 @@ type stubStaging struct {
 	conflict data.StagingApplyResult
 
 	applied []bool
+	// keys records the item keys every Apply and Reset was narrowed to.
+	keys [][]data.StagedKey
 
 	// resetResult is returned by Reset; resets counts how many times Reset ran
 	// (so a fan-out test can assert every target was reset).
@@ -56,8 +59,9 @@ func (s *stubStaging) Capability() capability.ServiceCapability {
 	return capability.ServiceCapability{}
 }
 
-func (s *stubStaging) Apply(_ context.Context, ignoreConflicts bool) (data.StagingApplyResult, error) {
+func (s *stubStaging) Apply(_ context.Context, ignoreConflicts bool, keys []data.StagedKey) (data.StagingApplyResult, error) {
 	s.applied = append(s.applied, ignoreConflicts)
+	s.keys = append(s.keys, keys)
 
 	if !ignoreConflicts && len(s.conflict.Conflicts) > 0 {
 		return s.conflict, nil
@@ -69,11 +73,19 @@ func (s *stubStaging) Apply(_ context.Context, ignoreConflicts bool) (data.Stagi
 func (s *stubStaging) Review(context.Context) (data.StagingReview, error) {
 	return data.StagingReview{}, nil
 }
-func (s *stubStaging) Reset(context.Context) (data.StagingResetResult, error) {
+func (s *stubStaging) Reset(_ context.Context, keys []data.StagedKey) (data.StagingResetResult, error) {
 	s.resets++
+	s.keys = append(s.keys, keys)
 
 	return s.resetResult, nil
 }
+func (s *stubStaging) Resolve(context.Context) (data.StagingResolveResult, error) {
+	return data.StagingResolveResult{}, nil
+}
+
+func (s *stubStaging) ResolveEdited(context.Context, data.StagedKey, string, string) (data.StagingResolveResult, error) {
+	return data.StagingResolveResult{}, nil
+}
 func (s *stubStaging) Unstage(context.Context, data.StagedKey) error              { return nil }
 func (s *stubStaging) CancelAddTag(context.Context, data.StagedKey, string) error { return nil }
 func (s *stubStaging) CancelRemoveTag(context.Context, data.StagedKey, string) error {
@@ -89,11 +101,23 @@ func drive(t *testing.T, d Model, cmd tea.Cmd) Model {
 		return d
 	}
 
-	next, _ := d.Update(cmd())
+	next, _ := d.Update(applyMsg(t, cmd))
 
 	return next
 }
 
+// applyMsg runs the fan-out of an apply confirmation's command — a batch of the
+// fan-out and the busy view's progress tick — and returns its results message.
+func applyMsg(t *testing.T, cmd tea.Cmd) tea.Msg {
+	t.Helper()
+
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok, "confirming apply batches the fan-out with the progress tick")
+	require.Len(t, batch, 2)
+
+	return batch[0]()
+}
+
 // pressEnter sends an enter key press.
 func pressEnter() tea.KeyPressMsg { return tea.KeyPressMsg{Code: tea.KeyEnter} }
 
@@ -137,6 +161,53 @@ func TestApply_FanOutAggregation(t *testing.T) {
 	assert.Contains(t, view, "Secret")
 }
 
+// TestApply_BusyProgress pins the busy view's live progress: the count of
+// finished changes and a spinner line per change in flight, advanced by the
+// progress tick until the results arrive.
+func TestApply_BusyProgress(t *testing.T) {
+	t.Parallel()
+
+	svc := &stubStaging{service: "param", label: "Param", result: data.StagingApplyResult{ServiceLabel: "Param"}}
+
+	m := NewApply(ApplyInput{
+		Ctx: context.Background(), Targets: []data.StagingService{svc},
+		TargetLine: "aws", Title: "Apply staged changes — Param", EntryCount: 2, Styles: styles.New(),
+	})
+
+	m, _ = m.Update(pressDown()) // focus Apply
+	m, cmd := m.Update(pressEnter())
+
+	d, ok := m.(*applyDialog)
+	require.True(t, ok)
+	assert.Contains(t, d.View(), "applying…", "nothing queued yet")
+
+	report := staging.ApplyProgressFrom(d.progress.Context(t.Context()))
+	a := staging.EntryKey{Name: "/a"}
+	b := staging.EntryKey{Name: "/b", Namespace: "prod"}
+
+	report(staging.ApplyEvent{Kind: staging.ApplyEventQueued, Key: a})
+	report(staging.ApplyEvent{Kind: staging.ApplyEventQueued, Key: b})
+	report(staging.ApplyEvent{Kind: staging.ApplyEventStarted, Key: a, Operation: staging.OperationUpdate})
+	report(staging.ApplyEvent{Kind: staging.ApplyEventStarted, Key: b, Operation: staging.OperationCreate})
+	report(staging.ApplyEvent{Kind: staging.ApplyEventRetrying, Key: b, Operation: staging.OperationCreate, Attempt: 2})
+	report(staging.ApplyEvent{Kind: staging.ApplyEventFinished, Key: a, Operation: staging.OperationUpdate})
+
+	view := d.View()
+	assert.Contains(t, view, "applying 1/2…")
+	assert.Contains(t, view, "⠋ create  /b [prod]")
+	assert.Contains(t, view, "throttled, retry 2")
+	assert.NotContains(t, view, "/a")
+
+	_, tick := d.Update(applyTickMsg{})
+	require.NotNil(t, tick, "the tick repeats while applying")
+	assert.Contains(t, d.View(), "⠙ create", "the spinner advanced")
+
+	m = drive(t, d, cmd)
+
+	_, tick = m.Update(applyTickMsg{})
+	assert.Nil(t, tick, "the tick stops once the results arrive")
+}
+
 // pressPgDown sends a page-down key press (viewport scrolling).
 func pressPgDown() tea.KeyPressMsg { return tea.KeyPressMsg{Code: tea.KeyPgDown} }
 
@@ -360,7 +431,7 @@ func TestApply_DismissReloadsOnResults(t *testing.T) {
 	d, _ = d.Update(pressDown()) // focus Apply
 	d, cmd := d.Update(pressEnter())
 	require.NotNil(t, cmd)
-	d, _ = d.Update(cmd()) // deliver applyResultsMsg → results phase
+	d, _ = d.Update(applyMsg(t, cmd)) // deliver applyResultsMsg → results phase
 
 	dr, ok = d.(DismissReloader)
 	require.True(t, ok)
@@ -409,6 +480,36 @@ func TestReset_FanOutAggregation(t *testing.T) {
 	assert.False(t, next.Busy(), "the dialog clears busy once the reset finishes")
 }
 
+// TestReset_Marked pins that a marked reset narrows its target to the marked
+// items and voices the marked count.
+func TestReset_Marked(t *testing.T) {
+	t.Parallel()
+
+	param := &stubStaging{
+		service: "param", label: "Param",
+		resetResult: data.StagingResetResult{Type: data.StagingResetUnstagedSelected, Count: 2},
+	}
+	marked := map[string][]data.StagedKey{"param": {{Name: "/a"}, {Name: "/b"}}}
+
+	d := NewReset(ResetInput{
+		Ctx: context.Background(), Targets: []data.StagingService{param},
+		Title: "Reset marked changes — Param", Marked: marked, Styles: styles.New(),
+	})
+
+	d, _ = d.Update(pressDown())
+	_, cmd := d.Update(pressEnter())
+	require.NotNil(t, cmd)
+
+	_, doneCmd := d.Update(cmd())
+
+	assert.Equal(t, [][]data.StagedKey{marked["param"]}, param.keys)
+
+	require.NotNil(t, doneCmd)
+	done, ok := doneCmd().(MutationDoneMsg)
+	require.True(t, ok)
+	assert.Equal(t, "Unstaged 2 marked change(s).", done.Status)
+}
+
 // TestApply_MouseClickConfirmControls pins #663's confirm-dialog coverage: a
 // click on the Ignore checkbox, Apply, and Cancel reduces to the same action the
 // key path performs (toggle, confirm, cancel), with coordinates from the drawn
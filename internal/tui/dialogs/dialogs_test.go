@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mpyw/suve/internal/capability"
+	"github.com/mpyw/suve/internal/cli/commands/azure/param/settingtype"
 	"github.com/mpyw/suve/internal/tui/data"
 	"github.com/mpyw/suve/internal/tui/styles"
 )
@@ -319,12 +320,12 @@ func TestEntryForm_NamespaceReadOnlyOnEdit(t *testing.T) {
 	assert.Equal(t, "(default)", namespaceDisplay(""))
 }
 
-// TestEntryForm_TypeSelectGating pins the Type select is offered for the typed
-// AWS SSM param service (App Configuration is untyped; secret has none) in BOTH
-// modes: the value type flows through the staged path as well as the immediate
+// TestEntryForm_TypeSelectGating pins the Type select is offered for both param
+// services (AWS SSM value types; App Configuration key-value vs Key Vault
+// reference; secret has none) in BOTH modes: the value type flows through the staged path as well as the immediate
 // path (the #664 fix), so the select is reachable regardless of the mode toggle
 // — where it was previously hidden in staged mode as a #664 containment. It must
-// stay absent for the untyped services.
+// stay absent for secrets.
 func TestEntryForm_TypeSelectGating(t *testing.T) {
 	t.Parallel()
 
@@ -339,10 +340,11 @@ func TestEntryForm_TypeSelectGating(t *testing.T) {
 	assert.Contains(t, awsParam.View(), "Type", "immediate form draws the Type row")
 
 	appConfig, _ := newEntry(t, appConfigCap(), false)
-	assert.False(t, appConfig.showType(), "App Configuration is untyped in either mode")
+	assert.True(t, appConfig.showType(), "App Configuration offers key-value vs Key Vault reference")
+	assert.Equal(t, settingtype.KeyValue, appConfig.valueType, "App Configuration defaults to a key-value")
 	appConfig.staged = false
 	require.NotNil(t, appConfig.rebuildForm())
-	assert.False(t, appConfig.showType(), "App Configuration is untyped in either mode")
+	assert.True(t, appConfig.showType(), "App Configuration offers the Type select in immediate mode")
 
 	secret, _ := newEntry(t, awsSecretCap(), false)
 	assert.False(t, secret.showType(), "secret has no value type in either mode")
@@ -13,6 +13,7 @@ import (
 
 	"github.com/mpyw/suve/internal/capability"
 	"github.com/mpyw/suve/internal/cli/commands/aws/param/paramtype"
+	"github.com/mpyw/suve/internal/cli/commands/azure/param/settingtype"
 	"github.com/mpyw/suve/internal/cli/editor"
 	"github.com/mpyw/suve/internal/tui/data"
 	"github.com/mpyw/suve/internal/tui/styles"
@@ -190,28 +191,48 @@ func NewEntryForm(in EntryFormInput) (Model, tea.Cmd) {
 	return d, cmd
 }
 
-// showType reports whether the typed-param Type select is offered: only the AWS
-// SSM param service has a value type (App Configuration is untyped; secret has
-// none) — parity with the GUI's ParamTypeOptions. It does NOT depend on the mode
-// toggle, so the select is reachable for a staged create and flows through to
-// apply (the #664/#680 fix); the immediate path maps it via paramtype.Parse and
-// the staged create carries it into the staging store.
+// showType reports whether the Type select is offered: the AWS SSM param service
+// picks its value type (String/SecureString/StringList) and Azure App
+// Configuration picks between a plain key-value and a Key Vault reference (which
+// is written with the reference content-type); secret has none — parity with the
+// GUI's ParamTypeOptions. It does NOT depend on the mode toggle, so the select is
+// reachable for a staged create and flows through to apply (the #664/#680 fix);
+// the immediate path maps it via data's label parsing and the staged create
+// carries it into the staging store.
 //
 // It is hidden on a staged-only surface (the staging review page's edit): there
 // the write is always a staged edit, which preserves the existing type rather
 // than taking a new one, and the dialog cannot seed the entry's current type — so
 // a Type control there could neither be honored nor shown accurately.
 func (d *entryForm) showType() bool {
-	return d.svcCap.Service == serviceParam && !d.svcCap.HasNamespaces && !d.stagedOnly
+	return d.svcCap.Service == serviceParam && !d.stagedOnly
+}
+
+// typeOptions lists the Type select's choices for the service.
+func typeOptions(svcCap capability.ServiceCapability) []string {
+	if svcCap.HasNamespaces {
+		return settingtype.Options()
+	}
+
+	return paramtype.Options()
 }
 
 // defaultTypeLabel picks the Type select's initial value: the seeded label when
-// valid, else the canonical default ("String").
+// valid, else the canonical default ("String", or "Key-value" on App
+// Configuration).
 func defaultTypeLabel(svcCap capability.ServiceCapability, seed string) string {
-	if svcCap.Service != serviceParam || svcCap.HasNamespaces {
+	if svcCap.Service != serviceParam {
 		return ""
 	}
 
+	if svcCap.HasNamespaces {
+		if settingtype.Valid(seed) {
+			return seed
+		}
+
+		return settingtype.KeyValue
+	}
+
 	if paramtype.Validate(seed) == nil && seed != "" {
 		return seed
 	}
@@ -235,7 +256,7 @@ func (d *entryForm) rebuildForm() tea.Cmd {
 
 	if d.showType() {
 		fields = append(fields, huh.NewSelect[string]().Key("type").Title("Type").
-			Options(huh.NewOptions(paramtype.Options()...)...).Value(&d.valueType))
+			Options(huh.NewOptions(typeOptions(d.svcCap)...)...).Value(&d.valueType))
 	}
 
 	fields = append(fields, huh.NewText().Key(fieldKeyValue).Title("Value").Lines(4). //nolint:mnd // value textarea height
@@ -540,9 +561,8 @@ func (d *entryForm) submit() tea.Cmd {
 	key := data.StagedKey{Name: d.name, Namespace: d.namespace}
 	staged := d.staged
 	// Pass the value type only when a Type control was actually offered. When it
-	// was not (a secret, an App Configuration setting, or a staging-review edit
-	// that cannot seed the entry's current type), an empty label signals "no
-	// explicit type" so the staged edit preserves the existing type instead of
+	// was not (a secret, or a staging-review edit that cannot seed the entry's
+	// current type), an empty label signals "no explicit type" so the staged edit preserves the existing type instead of
 	// forcing the select's default and downgrading it.
 	valueType := ""
 	if d.showType() {
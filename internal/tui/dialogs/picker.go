@@ -0,0 +1,242 @@
+package dialogs
+
+import (
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/mpyw/suve/internal/tui/styles"
+)
+
+// pickerChromeRows is the rows the picker draws around its list: the title, the
+// filter line, and the hint, each followed by a blank separator but the last.
+const pickerChromeRows = 5
+
+// Picker keys. Letters type into the filter, so movement is on the arrows (and
+// the emacs ctrl+p/ctrl+n) only — never j/k.
+//
+//nolint:gochecknoglobals // immutable dialog-local bindings
+var (
+	pickerUp     = key.NewBinding(key.WithKeys("up", "ctrl+p"))
+	pickerDown   = key.NewBinding(key.WithKeys("down", "ctrl+n"))
+	pickerSelect = key.NewBinding(key.WithKeys("enter"))
+	pickerErase  = key.NewBinding(key.WithKeys("backspace"))
+)
+
+// PickerItem is one choice in a picker dialog.
+type PickerItem struct {
+	// Value is what PickedMsg carries back when the item is chosen.
+	Value string
+	// Label is the row text.
+	Label string
+	// Detail is shown dimmed after the label (e.g. an account id).
+	Detail string
+}
+
+// PickedMsg is emitted when a picker choice is confirmed. The app pops the
+// dialog and acts on Value according to Picker.
+type PickedMsg struct {
+	// Picker identifies which picker was answered (the id passed to NewPicker).
+	Picker string
+	// Value is the chosen item's Value.
+	Value string
+}
+
+// typedRow is the shown index of the "use <filter>" row an input picker adds
+// when the filter names no item.
+const typedRow = -1
+
+// picker is a modal list chooser: the user narrows the items by typing, moves
+// with ↑↓ and confirms with Enter (Esc, owned by the shell, cancels). It
+// mutates nothing itself; the app applies the choice on PickedMsg. A list
+// taller than the screen scrolls with the cursor.
+type picker struct {
+	dialogLayout
+
+	styles styles.Styles
+	id     string
+	title  string
+	items  []PickerItem
+	// typed lets the filter itself be picked (see NewInputPicker).
+	typed bool
+
+	filter string
+	// shown indexes the items matching filter (typedRow for the typed value);
+	// cursor indexes shown.
+	shown  []int
+	cursor int
+	// offset is the first shown row drawn when the list scrolls.
+	offset int
+}
+
+// NewPicker builds a picker titled title over items, identified by id in its
+// PickedMsg, with the cursor on the item whose Value is current (the first
+// item when none is).
+func NewPicker(st styles.Styles, id, title string, items []PickerItem, current string) Model {
+	return newPicker(st, id, title, items, current, false)
+}
+
+// NewInputPicker is NewPicker for an open-ended choice (a project or vault
+// name): the items are suggestions, and a filter that names none of them can be
+// picked as typed from an extra "use …" row.
+func NewInputPicker(st styles.Styles, id, title string, items []PickerItem, current string) Model {
+	return newPicker(st, id, title, items, current, true)
+}
+
+func newPicker(st styles.Styles, id, title string, items []PickerItem, current string, typed bool) Model {
+	d := &picker{styles: st, id: id, title: title, items: items, typed: typed}
+	d.refilter()
+
+	for i, idx := range d.shown {
+		if items[idx].Value == current {
+			d.cursor = i
+		}
+	}
+
+	return d
+}
+
+func (d *picker) Busy() bool { return false }
+
+func (d *picker) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		d.setSize(msg)
+		d.scrollToCursor()
+
+		return d, nil
+	case tea.KeyPressMsg:
+		return d, d.handleKey(msg)
+	}
+
+	return d, nil
+}
+
+// handleKey moves the cursor, edits the filter, or confirms the choice.
+func (d *picker) handleKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, pickerSelect):
+		if len(d.shown) == 0 {
+			return nil
+		}
+
+		picked := PickedMsg{Picker: d.id, Value: d.filter}
+		if idx := d.shown[d.cursor]; idx != typedRow {
+			picked.Value = d.items[idx].Value
+		}
+
+		return func() tea.Msg { return picked }
+	case key.Matches(msg, pickerUp):
+		d.cursor = max(d.cursor-1, 0)
+	case key.Matches(msg, pickerDown):
+		d.cursor = max(min(d.cursor+1, len(d.shown)-1), 0)
+	case key.Matches(msg, pickerErase):
+		if d.filter != "" {
+			r := []rune(d.filter)
+			d.filter = string(r[:len(r)-1])
+			d.refilter()
+		}
+	default:
+		if msg.Text == "" {
+			return nil
+		}
+
+		d.filter += msg.Text
+		d.refilter()
+	}
+
+	d.scrollToCursor()
+
+	return nil
+}
+
+// refilter recomputes the shown items for the filter (a case-insensitive
+// substring of the label or detail) and resets the cursor to the top. An input
+// picker appends the typed row unless the filter is empty or is exactly an
+// item's value.
+func (d *picker) refilter() {
+	needle := strings.ToLower(d.filter)
+	d.shown = d.shown[:0]
+	exact := false
+
+	for i, it := range d.items {
+		if strings.Contains(strings.ToLower(it.Label+" "+it.Detail), needle) {
+			d.shown = append(d.shown, i)
+		}
+
+		exact = exact || it.Value == d.filter
+	}
+
+	if d.typed && d.filter != "" && !exact {
+		d.shown = append(d.shown, typedRow)
+	}
+
+	d.cursor, d.offset = 0, 0
+}
+
+// rows is how many list rows fit on screen (every row until sized).
+func (d *picker) rows() int {
+	if !d.sized() {
+		return max(len(d.shown), 1)
+	}
+
+	return max(d.availHeight()-pickerChromeRows, 1)
+}
+
+// scrollToCursor moves the visible window so the cursor row is on screen.
+func (d *picker) scrollToCursor() {
+	rows := d.rows()
+
+	switch {
+	case d.cursor < d.offset:
+		d.offset = d.cursor
+	case d.cursor >= d.offset+rows:
+		d.offset = d.cursor - rows + 1
+	}
+}
+
+func (d *picker) View() string {
+	var b strings.Builder
+
+	b.WriteString(d.fit(d.styles.PaneTitle.Render(d.title)))
+	b.WriteString("\n\n")
+	b.WriteString("filter: " + d.filter)
+	b.WriteString("\n\n")
+
+	if len(d.shown) == 0 {
+		b.WriteString(d.styles.PageHint.Render("no match"))
+		b.WriteString("\n")
+	}
+
+	end := min(d.offset+d.rows(), len(d.shown))
+	for i := d.offset; i < end; i++ {
+		b.WriteString(d.row(i))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(d.styles.PageHint.Render("type: filter · ↑↓: move · enter: select · esc: cancel"))
+
+	return b.String()
+}
+
+// row renders shown row i, marking and highlighting the cursor row.
+func (d *picker) row(i int) string {
+	it := PickerItem{Label: "use " + strconv.Quote(d.filter)}
+	if idx := d.shown[i]; idx != typedRow {
+		it = d.items[idx]
+	}
+
+	line := "  " + it.Label
+	if i == d.cursor {
+		line = d.styles.Selection.Render("> " + it.Label)
+	}
+
+	if it.Detail != "" {
+		line += " " + d.styles.PageHint.Render(it.Detail)
+	}
+
+	return d.fit(line)
+}
@@ -0,0 +1,147 @@
+//nolint:testpackage // white-box: inspects the picker's unexported cursor and window
+package dialogs
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/tui/styles"
+)
+
+func pickerItems() []PickerItem {
+	return []PickerItem{
+		{Value: "dev", Label: "dev", Detail: "111111111111"},
+		{Value: "prod", Label: "prod", Detail: "222222222222"},
+		{Value: "prod-admin", Label: "prod-admin", Detail: "222222222222"},
+	}
+}
+
+// picked runs cmd and returns the PickedMsg it emits.
+func picked(t *testing.T, cmd tea.Cmd) PickedMsg {
+	t.Helper()
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(PickedMsg)
+	require.True(t, ok)
+
+	return msg
+}
+
+// TestPicker_Select pins that the cursor starts on the current value, moves
+// with the arrows, and Enter emits the chosen value tagged with the picker id.
+func TestPicker_Select(t *testing.T) {
+	t.Parallel()
+
+	d := NewPicker(styles.New(), "profile", "AWS profile", pickerItems(), "prod")
+
+	_, cmd := d.Update(keyEnter())
+	assert.Equal(t, PickedMsg{Picker: "profile", Value: "prod"}, picked(t, cmd))
+
+	d.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	d.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+
+	_, cmd = d.Update(keyEnter())
+	assert.Equal(t, "prod-admin", picked(t, cmd).Value, "↓ stops at the last item")
+
+	d.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	d.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	d.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+
+	_, cmd = d.Update(keyEnter())
+	assert.Equal(t, "dev", picked(t, cmd).Value, "↑ stops at the first item")
+}
+
+// TestPicker_Filter pins that typing narrows the list by label or detail,
+// backspace widens it again, and Enter on an empty list does nothing.
+func TestPicker_Filter(t *testing.T) {
+	t.Parallel()
+
+	d := NewPicker(styles.New(), "profile", "AWS profile", pickerItems(), "")
+
+	for _, r := range "admin" {
+		d.Update(keyMsg(r))
+	}
+
+	assert.Contains(t, d.View(), "prod-admin")
+	assert.NotContains(t, d.View(), "dev")
+
+	_, cmd := d.Update(keyEnter())
+	assert.Equal(t, "prod-admin", picked(t, cmd).Value)
+
+	d.Update(keyMsg('x'))
+	assert.Contains(t, d.View(), "no match")
+
+	_, cmd = d.Update(keyEnter())
+	assert.Nil(t, cmd)
+
+	for range len("adminx") {
+		d.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	}
+
+	for _, r := range "1111" {
+		d.Update(keyMsg(r))
+	}
+
+	_, cmd = d.Update(keyEnter())
+	assert.Equal(t, "dev", picked(t, cmd).Value, "the detail is searchable too")
+}
+
+// TestPicker_Scroll pins that a list taller than the screen shows a window
+// that follows the cursor.
+func TestPicker_Scroll(t *testing.T) {
+	t.Parallel()
+
+	var items []PickerItem
+	for _, name := range []string{"a1", "a2", "a3", "a4", "a5", "a6", "a7", "a8", "a9"} {
+		items = append(items, PickerItem{Value: name, Label: name})
+	}
+
+	d := NewPicker(styles.New(), "profile", "AWS profile", items, "")
+	d.Update(tea.WindowSizeMsg{Width: 60, Height: 10})
+
+	view := d.View()
+	assert.Contains(t, view, "a1")
+	assert.NotContains(t, view, "a9")
+
+	for range 8 {
+		d.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	}
+
+	view = d.View()
+	assert.Contains(t, view, "a9")
+	assert.NotContains(t, view, "a1")
+}
+
+// TestInputPicker_Typed pins that an input picker offers the filter itself when
+// it names no item, and that an exact item match needs no extra row.
+func TestInputPicker_Typed(t *testing.T) {
+	t.Parallel()
+
+	d := NewInputPicker(styles.New(), "project", "Google Cloud project", pickerItems(), "")
+	assert.NotContains(t, d.View(), "use ", "no typed row for an empty filter")
+
+	for _, r := range "staging" {
+		d.Update(keyMsg(r))
+	}
+
+	assert.Contains(t, d.View(), `use "staging"`)
+
+	_, cmd := d.Update(keyEnter())
+	assert.Equal(t, PickedMsg{Picker: "project", Value: "staging"}, picked(t, cmd))
+
+	for range len("staging") {
+		d.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	}
+
+	for _, r := range "prod" {
+		d.Update(keyMsg(r))
+	}
+
+	assert.NotContains(t, d.View(), "use ", "the filter is an item's value")
+
+	_, cmd = d.Update(keyEnter())
+	assert.Equal(t, "prod", picked(t, cmd).Value)
+}
@@ -45,6 +45,7 @@ type resetDialog struct {
 
 	ctx     context.Context //nolint:containedctx // the reset command needs the Run context; mirrors the browser
 	targets []data.StagingService
+	marked  map[string][]data.StagedKey
 	title   string
 	styles  styles.Styles
 
@@ -60,7 +61,10 @@ type ResetInput struct {
 	Ctx     context.Context //nolint:containedctx // Run context threaded into the reset command; mirrors the browser
 	Targets []data.StagingService
 	// Title is the dialog title (e.g. "Reset staged changes — Secret" / "— all").
-	Title  string
+	Title string
+	// Marked, when non-nil, resets only the items the staging page marked,
+	// keyed by service.
+	Marked map[string][]data.StagedKey
 	Styles styles.Styles
 }
 
@@ -72,6 +76,7 @@ func NewReset(in ResetInput) Model {
 	return &resetDialog{
 		ctx:     in.Ctx,
 		targets: in.Targets,
+		marked:  in.Marked,
 		title:   in.Title,
 		styles:  in.Styles,
 		focus:   int(ctrlResetCancel),
@@ -158,16 +163,18 @@ func (d *resetDialog) activate() (Model, tea.Cmd) {
 	return d, d.resetCmd()
 }
 
-// resetCmd fans the reset out across every target sequentially.
+// resetCmd fans the reset out across every target sequentially, narrowing each
+// to its marked items on a marked reset.
 func (d *resetDialog) resetCmd() tea.Cmd {
 	ctx := d.ctx
 	targets := d.targets
+	marked := d.marked
 
 	return func() tea.Msg {
 		results := make([]data.StagingResetResult, 0, len(targets))
 
 		for _, svc := range targets {
-			res, err := svc.Reset(ctx)
+			res, err := svc.Reset(ctx, marked[svc.Service()])
 			if err != nil {
 				return resetResultsMsg{results: results, err: err}
 			}
@@ -261,6 +268,10 @@ func resetTypeStatus(r data.StagingResetResult) string {
 		return "Restored the staged value."
 	case data.StagingResetSkipped:
 		return "Skipped — value matches the current value."
+	case data.StagingResetUnstagedSelected:
+		return "Unstaged " + strconv.Itoa(r.Count) + " marked change(s)."
+	case data.StagingResetNothingSelected:
+		return "The marked changes are no longer staged."
 	case data.StagingResetNotStaged:
 		return "Not staged."
 	default:
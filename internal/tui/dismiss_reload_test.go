@@ -49,7 +49,12 @@ func resultsPhaseApply(t *testing.T) dialogs.Model {
 	require.NotNil(t, cmd, "confirming apply returns the fan-out command")
 	require.True(t, d.Busy(), "the dialog is busy while applying")
 
-	d, _ = d.Update(cmd()) // fold the applyResultsMsg back in → results phase
+	// Confirming batches the fan-out with the busy view's progress tick; fold the
+	// fan-out's applyResultsMsg back in → results phase.
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok, "confirming apply batches the fan-out with the progress tick")
+
+	d, _ = d.Update(batch[0]())
 	require.False(t, d.Busy(), "the dialog left the busy phase once results arrived")
 
 	return d
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/timeutil"
+	"github.com/mpyw/suve/internal/tui/dialogs"
+)
+
+// pickerJournal identifies the apply journal picker's PickedMsg.
+const pickerJournal = "journal"
+
+// journalLoadedMsg carries the recorded applies for the journal picker.
+type journalLoadedMsg struct {
+	records []*staging.ApplyRecord
+	err     error
+}
+
+// applyUndoneMsg reports an undo finished staging.
+type applyUndoneMsg struct {
+	id     string
+	staged int
+	err    error
+}
+
+// loadJournal reads the recorded applies off the update loop.
+func (m *App) loadJournal() tea.Cmd {
+	cs := m.changesets()
+	if cs == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		records, err := cs.Applies()
+
+		return journalLoadedMsg{records: records, err: err}
+	}
+}
+
+// openJournalPicker pushes the apply journal picker, newest apply first.
+// Picking an apply stages its undo (the CLI's `stage undo`); nothing is written
+// remotely until the staged changes are applied.
+func (m *App) openJournalPicker(msg journalLoadedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.pushDialog(dialogs.NewError(m.styles, "Apply history failed", msg.err.Error()), nil)
+
+		return nil
+	}
+
+	if len(msg.records) == 0 {
+		m.status = "No applies recorded"
+
+		return nil
+	}
+
+	items := make([]dialogs.PickerItem, 0, len(msg.records))
+
+	for _, rec := range msg.records {
+		detail := fmt.Sprintf("%s · %d change(s)", rec.User, len(rec.Items))
+		if rec.Message != "" {
+			detail += " · " + rec.Message
+		}
+
+		items = append(items, dialogs.PickerItem{
+			Value:  rec.ID,
+			Label:  timeutil.FormatDateTime(rec.AppliedAt) + "  " + rec.ID,
+			Detail: detail,
+		})
+	}
+
+	return m.pushDialog(dialogs.NewPicker(m.styles, pickerJournal, "Apply history — pick an apply to stage its undo", items, ""), nil)
+}
+
+// undoApply stages the inverse of the picked apply off the update loop.
+func (m *App) undoApply(id string) tea.Cmd {
+	cs := m.changesets()
+	if cs == nil || id == "" {
+		return nil
+	}
+
+	return func() tea.Msg {
+		staged, err := cs.Undo(id)
+
+		return applyUndoneMsg{id: id, staged: staged, err: err}
+	}
+}
+
+// onApplyUndone voices the undo and reloads the staging page, which shows the
+// staged reversal for review.
+func (m *App) onApplyUndone(msg applyUndoneMsg) tea.Cmd {
+	if msg.err != nil {
+		m.pushDialog(dialogs.NewError(m.styles, "Undo failed", msg.err.Error()), nil)
+
+		return nil
+	}
+
+	m.status = fmt.Sprintf("Staged %d change(s) reversing apply %s", msg.staged, msg.id)
+
+	return m.reloadActivePage()
+}
@@ -53,6 +53,13 @@ type Map struct {
 	// Copy yanks the focused value to the system clipboard via OSC52.
 	Copy key.Binding
 
+	// Profile opens the AWS profile picker. The shell disables it outside an
+	// AWS scope, which also hides it from the help bar.
+	Profile key.Binding
+	// Scope opens the scope picker (AWS region, Google Cloud project, Azure
+	// vault/store). The shell disables it for providers with no such axis.
+	Scope key.Binding
+
 	// Help toggles the short/full help bar; Quit exits the program.
 	Help key.Binding
 	Quit key.Binding
@@ -102,6 +109,14 @@ func Default() Map {
 			key.WithKeys("y"),
 			key.WithHelp("y", "copy"),
 		),
+		Profile: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "switch profile"),
+		),
+		Scope: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "switch scope"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -126,6 +141,6 @@ func (m Map) ShortHelp() []key.Binding {
 // column appended after the active page's own full-help columns.
 func (m Map) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{m.NextTab, m.PrevTab, m.Tab1, m.Help, m.Quit},
+		{m.NextTab, m.PrevTab, m.Tab1, m.Profile, m.Scope, m.Help, m.Quit},
 	}
 }
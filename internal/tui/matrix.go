@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"context"
+
+	"github.com/mpyw/suve/internal/provider"
+	ucmatrix "github.com/mpyw/suve/internal/usecase/matrix"
+)
+
+// Matrix describes the comparison a `suve matrix --tui` launch opens on. The
+// CLI resolves the scopes and stores; the page only renders what Load returns.
+type Matrix struct {
+	// Title heads the matrix pane ("Matrix prod · dev under /app/").
+	Title string
+	// Secret masks both sides of a drill-down diff.
+	Secret bool
+	// Filter is the initial row filter; the page can cycle it.
+	Filter ucmatrix.Filter
+	// Load builds the unfiltered matrix; it runs on open and on refresh.
+	Load func(context.Context) (*ucmatrix.Output, error)
+}
+
+// RunMatrix starts the TUI on a matrix page. scope and service set up the rest
+// of the app (status bar, tabs) exactly as Run does; switching tabs leaves the
+// matrix for the regular browser.
+func RunMatrix(ctx context.Context, scope provider.Scope, service string, mx Matrix) error {
+	model, err := newModel(ctx, scope, service)
+	if err != nil {
+		return err
+	}
+
+	model.pages = []page{newMatrixPage(model.runCtx, mx, model.styles, model.keys)}
+
+	return runProgram(ctx, model)
+}
@@ -20,24 +20,40 @@ type Reload struct{}
 
 // OpenApply asks the app to open the apply confirmation dialog for a set of
 // services. Global marks the fan-out (apply-all) variant; EntryCount/TagCount are
-// the staged totals across the targets, shown on the confirmation.
+// the staged totals across the targets, shown on the confirmation. Marked, when
+// non-nil, narrows each service to the items the staging page marked.
 type OpenApply struct {
 	Services   []string
 	Global     bool
 	EntryCount int
 	TagCount   int
+	Marked     map[string][]data.StagedKey
 }
 
 // OpenReset asks the app to open the reset confirmation dialog for a set of
-// services. Global marks the reset-all variant.
+// services. Global marks the reset-all variant; Marked narrows it like
+// OpenApply's.
 type OpenReset struct {
 	Services []string
 	Global   bool
+	Marked   map[string][]data.StagedKey
 }
 
+// OpenChangesets asks the app to open the staging changeset selector over Names
+// (the default first), with the cursor on Active. Typing a new name creates it.
+type OpenChangesets struct {
+	Names  []string
+	Active string
+}
+
+// OpenJournal asks the app to open the apply journal: the recorded applies,
+// one of which can be picked to stage its undo.
+type OpenJournal struct{}
+
 // OpenStagingDetail asks the app to push a full-diff page comparing an entry's
 // remote value against its staged value (the staging page's `enter` detail),
-// reusing the diff viewer for long values.
+// reusing the diff viewer for long values. The matrix page sends it too, for
+// one key's values in two scopes.
 type OpenStagingDetail struct {
 	Title    string
 	OldLabel string
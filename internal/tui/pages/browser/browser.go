@@ -87,6 +87,7 @@ var (
 	loadMoreKey  = key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "load more"))
 	revealKey    = key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "reveal"))
 	compareKey   = key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "compare"))
+	resolveKey   = key.NewBinding(key.WithKeys("K"), key.WithHelp("K", "resolve refs"))
 	// spaceKey is only for key matching; its help is built per-service by spaceHelp
 	// so the "namespace" label shows only on App Configuration.
 	spaceKey = key.NewBinding(key.WithKeys("space"))
@@ -159,6 +160,9 @@ type Model struct {
 	valuesOn  bool
 	recursive bool
 	focus     focus
+	// resolveRefs follows App Configuration Key Vault references on detail load
+	// (the K toggle); only offered when the source implements data.RefResolver.
+	resolveRefs bool
 
 	// App Configuration namespace filter.
 	namespaces []string // discovered, plus the null/all options
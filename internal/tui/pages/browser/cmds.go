@@ -1,6 +1,8 @@
 package browser
 
 import (
+	"context"
+
 	tea "charm.land/bubbletea/v2"
 
 	"github.com/mpyw/suve/internal/tui/data"
@@ -84,14 +86,37 @@ func (m *Model) loadDetailCmd(name, namespace string) tea.Cmd {
 	seq := m.detailSeq
 	ctx := m.ctx
 	source := m.source
+	resolveRefs := m.resolveRefs
 
 	return func() tea.Msg {
 		d, err := source.Show(ctx, name, namespace)
+		if err == nil && resolveRefs && d.Reference != "" {
+			d = resolveRef(ctx, source, d)
+		}
 
 		return detailLoadedMsg{token: token, seq: seq, d: d, err: err}
 	}
 }
 
+// resolveRef follows d's Key Vault reference when the source can. A failure
+// keeps the reference itself on screen and records the error as a metadata row,
+// so an unreachable vault never blanks an otherwise loaded detail.
+func resolveRef(ctx context.Context, source data.Source, d data.Detail) data.Detail {
+	resolver, ok := source.(data.RefResolver)
+	if !ok {
+		return d
+	}
+
+	resolved, err := resolver.ResolveRef(ctx, d)
+	if err != nil {
+		d.Meta = append(d.Meta, data.MetaRow{Label: "Resolve error", Value: err.Error()})
+
+		return d
+	}
+
+	return resolved
+}
+
 // loadHistoryCmd issues a history (Log) fetch, independent of the detail fetch
 // so a history failure never blanks the value (GUI Promise.allSettled parity).
 func (m *Model) loadHistoryCmd(name, namespace string) tea.Cmd {
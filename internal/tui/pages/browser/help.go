@@ -101,6 +101,11 @@ func (m *Model) viewColumn() []key.Binding {
 		col = append(col, revealKey)
 	}
 
+	// Key Vault reference resolution only exists on App Configuration.
+	if m.canResolveRefs() {
+		col = append(col, resolveKey)
+	}
+
 	// The list/detail split is only resizable in the two-pane layout; below the
 	// stacked threshold the panes fill the width, so the keys would do nothing.
 	if m.width >= twoPaneMinWidth {
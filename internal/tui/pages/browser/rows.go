@@ -1,6 +1,7 @@
 package browser
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/samber/lo"
@@ -32,6 +33,14 @@ func (m *Model) rebuildRows() {
 			badges = append(badges, namespaceBadge(it.Namespace))
 		}
 
+		if it.Reference {
+			badges = append(badges, "kv-ref")
+		}
+
+		if it.Locked {
+			badges = append(badges, "locked")
+		}
+
 		row.Badges = badges
 
 		return row
@@ -94,7 +103,8 @@ func namespaceBadge(namespace string) string {
 
 // historyEntries maps neutral history rows onto the history table's presentation
 // rows: badges are the state OR the staging labels (never both, never inferred),
-// and the tag line is populated only when the provider scopes tags per version.
+// the tag line is populated only when the provider scopes tags per version, and
+// the message line when the version carries a change message.
 func historyEntries(_ styles.Styles, rows []data.HistoryRow, tagsPerVersion bool) []components.HistoryEntry {
 	return lo.Map(rows, func(r data.HistoryRow, _ int) components.HistoryEntry {
 		entry := components.HistoryEntry{
@@ -110,22 +120,32 @@ func historyEntries(_ styles.Styles, rows []data.HistoryRow, tagsPerVersion bool
 			entry.TagsLine = "tags: " + tagsInline(r.Tags)
 		}
 
+		if r.Message != "" {
+			entry.MessageLine = "message: " + r.Message
+		}
+
 		return entry
 	})
 }
 
 // historyBadges returns the version's state OR its staging labels — whichever is
-// populated — never inferring one axis from the other (#419).
+// populated — never inferring one axis from the other (#419), followed by the
+// version's expiry when it has one.
 func historyBadges(r data.HistoryRow) []string {
-	if len(r.StagingLabels) > 0 {
-		return r.StagingLabels
+	var badges []string
+
+	switch {
+	case len(r.StagingLabels) > 0:
+		badges = slices.Clone(r.StagingLabels)
+	case r.State != "":
+		badges = []string{r.State}
 	}
 
-	if r.State != "" {
-		return []string{r.State}
+	if r.Expires != "" {
+		badges = append(badges, "expires "+r.Expires)
 	}
 
-	return nil
+	return badges
 }
 
 // tagsInline renders tags as "k=v · k2=v2".
@@ -330,6 +330,8 @@ func (m *Model) handleActionKey(msg tea.KeyPressMsg) (bool, tea.Cmd) {
 		m.toggleCompare()
 
 		return true, nil
+	case key.Matches(msg, resolveKey):
+		return true, m.toggleResolveRefs()
 	case key.Matches(msg, spaceKey):
 		return true, m.handleSpace()
 	case key.Matches(msg, newKey):
@@ -389,6 +391,31 @@ func (m *Model) toggleValues() tea.Cmd {
 	return m.loadListCmd(false)
 }
 
+// toggleResolveRefs flips Key Vault reference resolution (the K key) and reloads
+// the selected entry's detail. It is a no-op on a source that cannot resolve
+// references (every service but Azure App Configuration).
+func (m *Model) toggleResolveRefs() tea.Cmd {
+	if !m.canResolveRefs() {
+		return nil
+	}
+
+	m.resolveRefs = !m.resolveRefs
+
+	item, ok := m.selectedItem()
+	if !ok {
+		return nil
+	}
+
+	return m.loadDetailCmd(item.Name, item.Namespace)
+}
+
+// canResolveRefs reports whether the source follows Key Vault references.
+func (m *Model) canResolveRefs() bool {
+	_, ok := m.source.(data.RefResolver)
+
+	return ok
+}
+
 // toggleRecursive flips recursive listing (param only; elsewhere `r`/⟳ is a plain
 // refresh) and reloads the list (the `r` key and a click on the recursive chip).
 func (m *Model) toggleRecursive() tea.Cmd {
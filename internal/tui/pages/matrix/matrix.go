@@ -0,0 +1,399 @@
+// Package matrix renders the TUI's matrix page: the same keys compared across
+// several scopes (`suve matrix --tui`), one row per key and one column per
+// scope. A cell shows a short hash of the value and its last-modified date, a
+// missing key is highlighted, and `f` narrows the rows to the keys that differ
+// or agree. Enter drills into the diff page, comparing the selected key in the
+// first scope against the selected column.
+package matrix
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/help"
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/mpyw/suve/internal/timeutil"
+	"github.com/mpyw/suve/internal/tui/components"
+	"github.com/mpyw/suve/internal/tui/keys"
+	"github.com/mpyw/suve/internal/tui/nav"
+	"github.com/mpyw/suve/internal/tui/styles"
+	"github.com/mpyw/suve/internal/usecase/matrix"
+)
+
+// Page-local bindings.
+//
+//nolint:gochecknoglobals // immutable page-local bindings
+var (
+	moveKey    = key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑/↓", "move"))
+	columnKey  = key.NewBinding(key.WithKeys("left", "right", "h", "l"), key.WithHelp("←/→", "column"))
+	compareKey = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "diff with first scope"))
+	filterKey  = key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter"))
+	refreshKey = key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh"))
+)
+
+// Cell texts and row markers, matching the CLI's `suve matrix` table.
+const (
+	equalMark  = "="
+	differMark = "≠"
+	missing    = "(missing)"
+	cellError  = "(error)"
+	cellGap    = "  "
+)
+
+// loadedMsg carries a loaded matrix back to the page.
+type loadedMsg struct {
+	out *matrix.Output
+	err error
+}
+
+// Model is the matrix page.
+type Model struct {
+	// ctx is the Run context threaded through the load command.
+	ctx    context.Context //nolint:containedctx // the load command needs the Run context; mirrors the diff page
+	title  string
+	secret bool
+	load   func(context.Context) (*matrix.Output, error)
+
+	styles styles.Styles
+	keys   keys.Map
+
+	width  int
+	height int
+
+	out     *matrix.Output
+	rows    []matrix.Row
+	filter  matrix.Filter
+	loading bool
+	err     string
+
+	// cursor is the selected row; column the selected scope (never the first,
+	// which every drill-down compares against); offset the first visible row.
+	cursor int
+	column int
+	offset int
+}
+
+// New builds a matrix page. load fetches every row (the page filters them
+// itself); filter is the initial row filter. It does not load yet; Init does.
+func New(
+	ctx context.Context, title string, secret bool, filter matrix.Filter,
+	load func(context.Context) (*matrix.Output, error), st styles.Styles, km keys.Map,
+) *Model {
+	return &Model{
+		ctx:     ctx,
+		title:   title,
+		secret:  secret,
+		load:    load,
+		filter:  filter,
+		styles:  st,
+		keys:    km,
+		loading: true,
+		column:  1,
+	}
+}
+
+// Init dispatches the load.
+func (m *Model) Init() tea.Cmd {
+	return m.loadCmd()
+}
+
+// loadCmd loads the matrix off the update loop.
+func (m *Model) loadCmd() tea.Cmd {
+	ctx, load := m.ctx, m.load
+
+	return func() tea.Msg {
+		out, err := load(ctx)
+
+		return loadedMsg{out: out, err: err}
+	}
+}
+
+// Update handles the load result and keys.
+func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+
+		return m, nil
+	case loadedMsg:
+		m.loading = false
+
+		if msg.err != nil {
+			m.err = msg.err.Error()
+
+			return m, nil
+		}
+
+		m.out, m.err = msg.out, ""
+		m.applyFilter()
+
+		return m, nil
+	case tea.KeyPressMsg:
+		return m.handleKey(msg)
+	default:
+		return m, nil
+	}
+}
+
+// handleKey handles the page-local keys.
+func (m *Model) handleKey(msg tea.KeyPressMsg) (*Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		return m, func() tea.Msg { return nav.PopPage{} }
+	case key.Matches(msg, m.keys.Up):
+		m.move(-1)
+	case key.Matches(msg, m.keys.Down):
+		m.move(1)
+	case key.Matches(msg, columnKey):
+		m.moveColumn(msg.String() == "left" || msg.String() == "h")
+	case key.Matches(msg, filterKey):
+		m.filter = (m.filter + 1) % (matrix.FilterDiffer + 1)
+		m.applyFilter()
+	case key.Matches(msg, refreshKey):
+		m.loading = true
+
+		return m, m.loadCmd()
+	case key.Matches(msg, compareKey):
+		return m, m.compare()
+	}
+
+	return m, nil
+}
+
+// applyFilter recomputes the visible rows for the current filter, keeping the
+// cursor in range.
+func (m *Model) applyFilter() {
+	m.rows = nil
+
+	if m.out == nil {
+		return
+	}
+
+	for _, r := range m.out.Rows {
+		switch {
+		case m.filter == matrix.FilterEqual && !r.Equal(), m.filter == matrix.FilterDiffer && r.Equal():
+			continue
+		}
+
+		m.rows = append(m.rows, r)
+	}
+
+	m.cursor = min(m.cursor, max(len(m.rows)-1, 0))
+}
+
+// move moves the row cursor by delta.
+func (m *Model) move(delta int) {
+	m.cursor = min(max(m.cursor+delta, 0), max(len(m.rows)-1, 0))
+}
+
+// moveColumn selects the previous or next scope, skipping the first.
+func (m *Model) moveColumn(left bool) {
+	if m.out == nil {
+		return
+	}
+
+	if left {
+		m.column = max(m.column-1, 1)
+	} else {
+		m.column = min(m.column+1, len(m.out.Scopes)-1)
+	}
+}
+
+// compare asks the app for a diff of the selected key between the first scope
+// and the selected column. A missing side diffs as empty.
+func (m *Model) compare() tea.Cmd {
+	if m.cursor >= len(m.rows) || m.column >= len(m.out.Scopes) {
+		return nil
+	}
+
+	row := m.rows[m.cursor]
+	base, other := row.Cells[0], row.Cells[m.column]
+
+	req := nav.OpenStagingDetail{
+		Title:    row.Key,
+		OldLabel: cellLabel(m.out.Scopes[0], row.Key, base),
+		NewLabel: cellLabel(m.out.Scopes[m.column], row.Key, other),
+		OldValue: base.Value,
+		NewValue: other.Value,
+		Secret:   m.secret,
+	}
+
+	return func() tea.Msg { return req }
+}
+
+// cellLabel labels one side of a drill-down diff ("prod:db-url").
+func cellLabel(scope, key string, c matrix.Cell) string {
+	label := scope + ":" + key
+	if !c.Present {
+		label += " " + missing
+	}
+
+	return label
+}
+
+// HelpKeyMap reports the page's bindings for the help bar.
+func (m *Model) HelpKeyMap() help.KeyMap {
+	bindings := []key.Binding{moveKey}
+
+	if m.out != nil && len(m.out.Scopes) > 2 { //nolint:mnd // a column choice needs a third scope
+		bindings = append(bindings, columnKey)
+	}
+
+	bindings = append(bindings, compareKey, filterKey, refreshKey)
+
+	return keys.Bindings{Short: bindings, Full: [][]key.Binding{bindings}}
+}
+
+// View renders the matrix inside a pane.
+func (m *Model) View(width, height int) string {
+	m.width, m.height = width, height
+
+	return components.Pane(m.styles, m.paneTitle(), m.body(), width, height)
+}
+
+// paneTitle is the matrix title with the row counts and the active filter.
+func (m *Model) paneTitle() string {
+	title := m.title
+
+	if m.out != nil {
+		differ := 0
+
+		for _, r := range m.out.Rows {
+			if !r.Equal() {
+				differ++
+			}
+		}
+
+		title += " — " + strconv.Itoa(len(m.out.Rows)) + " keys, " + strconv.Itoa(differ) + " differ"
+	}
+
+	switch m.filter {
+	case matrix.FilterEqual:
+		title += " [equal only]"
+	case matrix.FilterDiffer:
+		title += " [differing only]"
+	case matrix.FilterAll:
+	}
+
+	if m.loading && m.out != nil {
+		title += " (refreshing…)"
+	}
+
+	return title
+}
+
+// body renders the scope errors, the header and the visible rows.
+func (m *Model) body() string {
+	switch {
+	case m.err != "":
+		return m.styles.ErrorText.Render(m.err)
+	case m.out == nil:
+		return m.styles.PageHint.Render("loading…")
+	}
+
+	var lines []string
+
+	for _, e := range m.out.Errors {
+		lines = append(lines, m.styles.ErrorText.Render("⚠ "+e.Scope+": "+e.Error.Error()))
+	}
+
+	keyW, cellW := m.widths()
+
+	header := []string{" ", pad("KEY", keyW)}
+	for i, s := range m.out.Scopes {
+		if i == m.column {
+			s = "▸" + s
+		}
+
+		header = append(header, pad(s, cellW))
+	}
+
+	lines = append(lines, m.styles.FieldLabel.Render(strings.Join(header, cellGap)))
+
+	if len(m.rows) == 0 {
+		return strings.Join(append(lines, m.styles.PageHint.Render("(no keys)")), "\n")
+	}
+
+	_, innerH := components.PaneInner(m.width, m.height)
+	visible := max(innerH-len(lines), 1)
+	m.scrollTo(visible)
+
+	for i := m.offset; i < len(m.rows) && i < m.offset+visible; i++ {
+		lines = append(lines, m.rowLine(i, keyW, cellW))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// scrollTo keeps the cursor within the visible window of n rows.
+func (m *Model) scrollTo(n int) {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+
+	if m.cursor >= m.offset+n {
+		m.offset = m.cursor - n + 1
+	}
+}
+
+// widths returns the key and cell column widths.
+func (m *Model) widths() (int, int) {
+	keyW := len("KEY")
+	for _, r := range m.rows {
+		keyW = max(keyW, len([]rune(r.Key)))
+	}
+
+	cellW := matrix.HashLength + len(" 2006-01-02")
+	for _, s := range m.out.Scopes {
+		cellW = max(cellW, len([]rune(s))+1)
+	}
+
+	return keyW, cellW
+}
+
+// rowLine renders row i: the marker, the key and a cell per scope.
+func (m *Model) rowLine(i, keyW, cellW int) string {
+	row := m.rows[i]
+
+	mark := m.styles.DiffAdded.Render(equalMark)
+	if !row.Equal() {
+		mark = m.styles.DiffRemoved.Render(differMark)
+	}
+
+	name := pad(row.Key, keyW)
+	if i == m.cursor {
+		name = m.styles.Selection.Render(name)
+	}
+
+	parts := []string{mark, name}
+
+	for _, c := range row.Cells {
+		switch {
+		case c.Error != nil:
+			parts = append(parts, m.styles.ErrorText.Render(pad(cellError, cellW)))
+		case !c.Present:
+			parts = append(parts, m.styles.DiffRemoved.Render(pad(missing, cellW)))
+		default:
+			parts = append(parts, pad(cellText(c), cellW))
+		}
+	}
+
+	return strings.Join(parts, cellGap)
+}
+
+// cellText is a present cell's "hash date" text.
+func cellText(c matrix.Cell) string {
+	if c.Modified == nil {
+		return c.Hash
+	}
+
+	return c.Hash + " " + timeutil.FormatDate(*c.Modified)
+}
+
+// pad right-pads s with spaces to width runes.
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-len([]rune(s)), 0))
+}
@@ -0,0 +1,122 @@
+package matrix_test
+
+import (
+	"context"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/tui/keys"
+	"github.com/mpyw/suve/internal/tui/nav"
+	"github.com/mpyw/suve/internal/tui/pages/matrix"
+	"github.com/mpyw/suve/internal/tui/styles"
+	ucmatrix "github.com/mpyw/suve/internal/usecase/matrix"
+)
+
+// fixture is a three-scope matrix: db-url differs, log-level agrees, and debug
+// is missing from prod.
+func fixture() *ucmatrix.Output {
+	present := func(value, hash string) ucmatrix.Cell {
+		return ucmatrix.Cell{Present: true, Value: value, Hash: hash}
+	}
+
+	return &ucmatrix.Output{
+		Scopes: []string{"prod", "staging", "dev"},
+		Rows: []ucmatrix.Row{
+			{Key: "db-url", Cells: []ucmatrix.Cell{present("pg://prod", "aaa"), present("pg://stg", "bbb"), present("pg://dev", "ccc")}},
+			{Key: "debug", Cells: []ucmatrix.Cell{{}, present("true", "ddd"), present("true", "ddd")}},
+			{Key: "log-level", Cells: []ucmatrix.Cell{present("warn", "eee"), present("warn", "eee"), present("warn", "eee")}},
+		},
+	}
+}
+
+// loaded builds a page and feeds it the result of its own Init load.
+func loaded(t *testing.T, filter ucmatrix.Filter) *matrix.Model {
+	t.Helper()
+
+	load := func(context.Context) (*ucmatrix.Output, error) { return fixture(), nil }
+	m := matrix.New(t.Context(), "Matrix", true, filter, load, styles.New(), keys.Default())
+
+	cmd := m.Init()
+	require.NotNil(t, cmd)
+
+	m, _ = m.Update(cmd())
+
+	return m
+}
+
+func press(m *matrix.Model, msgs ...tea.KeyPressMsg) (*matrix.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	for _, msg := range msgs {
+		m, cmd = m.Update(msg)
+	}
+
+	return m, cmd
+}
+
+func TestModel_View(t *testing.T) {
+	t.Parallel()
+
+	m := loaded(t, ucmatrix.FilterAll)
+	view := m.View(120, 20)
+
+	assert.Contains(t, view, "3 keys, 2 differ")
+	assert.Contains(t, view, "prod")
+	assert.Contains(t, view, "(missing)")
+	assert.Contains(t, view, "log-level")
+}
+
+func TestModel_FilterCycles(t *testing.T) {
+	t.Parallel()
+
+	m := loaded(t, ucmatrix.FilterDiffer)
+	view := m.View(120, 20)
+	assert.Contains(t, view, "[differing only]")
+	assert.NotContains(t, view, "log-level")
+
+	m, _ = press(m, tea.KeyPressMsg{Code: 'f', Text: "f"})
+	view = m.View(120, 20)
+	assert.NotContains(t, view, "only]")
+	assert.Contains(t, view, "log-level")
+
+	m, _ = press(m, tea.KeyPressMsg{Code: 'f', Text: "f"})
+	view = m.View(120, 20)
+	assert.Contains(t, view, "[equal only]")
+	assert.NotContains(t, view, "db-url")
+}
+
+func TestModel_CompareOpensDiff(t *testing.T) {
+	t.Parallel()
+
+	m := loaded(t, ucmatrix.FilterAll)
+
+	// Second row (debug), third column (dev).
+	_, cmd := press(m,
+		tea.KeyPressMsg{Code: tea.KeyDown},
+		tea.KeyPressMsg{Code: tea.KeyRight},
+		tea.KeyPressMsg{Code: tea.KeyEnter},
+	)
+	require.NotNil(t, cmd)
+
+	req, ok := cmd().(nav.OpenStagingDetail)
+	require.True(t, ok)
+	assert.Equal(t, "debug", req.Title)
+	assert.Equal(t, "prod:debug (missing)", req.OldLabel)
+	assert.Equal(t, "dev:debug", req.NewLabel)
+	assert.Empty(t, req.OldValue)
+	assert.Equal(t, "true", req.NewValue)
+	assert.True(t, req.Secret)
+}
+
+func TestModel_BackPops(t *testing.T) {
+	t.Parallel()
+
+	m := loaded(t, ucmatrix.FilterAll)
+
+	_, cmd := press(m, tea.KeyPressMsg{Code: tea.KeyEscape})
+	require.NotNil(t, cmd)
+	assert.IsType(t, nav.PopPage{}, cmd())
+}
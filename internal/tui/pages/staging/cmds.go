@@ -1,11 +1,25 @@
 package staging
 
 import (
+	"os"
+
 	tea "charm.land/bubbletea/v2"
+	"golang.org/x/term"
 
+	"github.com/mpyw/suve/internal/cli/editor"
 	"github.com/mpyw/suve/internal/tui/data"
 )
 
+// isTTY reports whether the process is attached to a terminal, gating the
+// $EDITOR handoff of a conflicted resolve. It is a package variable so a test
+// can exercise the no-TTY branch without a real terminal (mirrors the entry
+// form's seam).
+//
+//nolint:gochecknoglobals // swappable TTY-detection seam for the editor handoff
+var isTTY = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
 // Async result messages. Each staged read carries the section index and the
 // sequence its fetch was issued with, so the reducer drops a stale response.
 type (
@@ -21,8 +35,34 @@ type (
 		section int
 		err     error
 	}
+	// resolveDoneMsg reports a resolve pass finished. edited marks the pass that
+	// stored an editor result, whose unresolved rows are not queued again.
+	resolveDoneMsg struct {
+		section int
+		result  data.StagingResolveResult
+		edited  bool
+		err     error
+	}
+	// changesetsLoadedMsg carries the changesets and the active one.
+	changesetsLoadedMsg struct {
+		names  []string
+		active string
+		err    error
+	}
+	// resolveEditedMsg carries the editor buffer of a conflicted merge back.
+	resolveEditedMsg struct {
+		pending pendingResolve
+		content string
+		err     error
+	}
 )
 
+// pendingResolve is a conflicted merge waiting for the editor.
+type pendingResolve struct {
+	section int
+	row     data.StagedResolveRow
+}
+
 // unstageCmd runs an unstage (entry + tags) for a row's item off the update loop.
 func (m *Model) unstageCmd(sectionIdx int, key data.StagedKey) tea.Cmd {
 	ctx := m.ctx
@@ -52,3 +92,75 @@ func (m *Model) cancelRemoveTagCmd(sectionIdx int, key data.StagedKey, tagKey st
 		return actionDoneMsg{section: sectionIdx, err: svc.CancelRemoveTag(ctx, key, tagKey)}
 	}
 }
+
+// changesetsCmd lists the changesets off the update loop, or returns nil when
+// the page has no changeset seam.
+func (m *Model) changesetsCmd() tea.Cmd {
+	cs := m.changesets
+	if cs == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		names, active, err := cs.List()
+
+		return changesetsLoadedMsg{names: names, active: active, err: err}
+	}
+}
+
+// resolveCmd merges remote changes into a section's conflicting staged entries.
+func (m *Model) resolveCmd(sectionIdx int) tea.Cmd {
+	ctx := m.ctx
+	svc := m.sections[sectionIdx].svc
+
+	return func() tea.Msg {
+		result, err := svc.Resolve(ctx)
+
+		return resolveDoneMsg{section: sectionIdx, result: result, err: err}
+	}
+}
+
+// resolveEditedCmd stores the editor result of one conflicted merge.
+func (m *Model) resolveEditedCmd(p pendingResolve, content string) tea.Cmd {
+	ctx := m.ctx
+	svc := m.sections[p.section].svc
+	key := data.StagedKey{Name: p.row.Name, Namespace: p.row.Namespace}
+
+	return func() tea.Msg {
+		result, err := svc.ResolveEdited(ctx, key, p.row.Merged, content)
+
+		return resolveDoneMsg{section: p.section, result: result, edited: true, err: err}
+	}
+}
+
+// openResolveEditor hands a conflicted merge to the user's editor through the
+// shared internal/cli/editor command, exactly like the entry form's handoff.
+func (m *Model) openResolveEditor(p pendingResolve) tea.Cmd {
+	tmp, err := os.CreateTemp("", "suve-tui-resolve-*.txt")
+	if err != nil {
+		return func() tea.Msg { return resolveEditedMsg{pending: p, err: err} }
+	}
+
+	name := tmp.Name()
+
+	if _, err := tmp.WriteString(p.row.Merged); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(name)
+
+		return func() tea.Msg { return resolveEditedMsg{pending: p, err: err} }
+	}
+
+	_ = tmp.Close()
+
+	return tea.ExecProcess(editor.Command(m.ctx, name), func(runErr error) tea.Msg {
+		//nolint:gosec // name is the temp file this handler just created, not user input
+		content, readErr := os.ReadFile(name)
+		_ = os.Remove(name)
+
+		if runErr != nil {
+			return resolveEditedMsg{pending: p, err: runErr}
+		}
+
+		return resolveEditedMsg{pending: p, content: string(content), err: readErr}
+	})
+}
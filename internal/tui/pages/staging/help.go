@@ -23,8 +23,8 @@ import (
 //     delete-staged entry never lists an action that would silently do nothing
 //     there (editSelected/onEnter/tagSelected all return early on those rows).
 //
-// esc joins the map only while the auto-unstaged notice is showing, the sole
-// thing esc dismisses here.
+// esc joins the map only while the auto-unstaged notice is showing or items are
+// marked, the only things esc dismisses here.
 func (m *Model) HelpKeyMap() help.KeyMap {
 	return keys.Bindings{Short: m.shortHelp(), Full: m.fullHelp()}
 }
@@ -112,19 +112,24 @@ func (m *Model) fullHelp() [][]key.Binding {
 		navigate = append(navigate, detailKey)
 	}
 
-	if m.noticeVisible() {
+	if m.noticeVisible() || len(m.marked) > 0 {
 		navigate = append(navigate, m.keys.Back)
 	}
 
+	global := []key.Binding{viewKey, applyKey, resetKey, resolveKey, applyAllKey, resetAllKey, refreshKey}
+	if m.changesets != nil {
+		global = append(global, changesetKey, historyKey)
+	}
+
 	return [][]key.Binding{
 		navigate,
 		m.rowActionsColumn(),
-		{viewKey, applyKey, resetKey, applyAllKey, resetAllKey, refreshKey},
+		global,
 	}
 }
 
-// rowActionsColumn is the row-action group for the selected row: unstage on every
-// row; edit / tag / x gated to the rows their handlers actually act on.
+// rowActionsColumn is the row-action group for the selected row: unstage and mark
+// on every row; edit / tag / x gated to the rows their handlers actually act on.
 func (m *Model) rowActionsColumn() []key.Binding {
 	var col []key.Binding
 
@@ -132,7 +137,7 @@ func (m *Model) rowActionsColumn() []key.Binding {
 		col = append(col, editKey)
 	}
 
-	col = append(col, unstageKey)
+	col = append(col, unstageKey, markKey)
 
 	if m.canTagRow() {
 		col = append(col, tagKey)
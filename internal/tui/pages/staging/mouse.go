@@ -18,6 +18,7 @@ import (
 // "click selects" model).
 func (m *Model) handleMouseClick(msg tea.MouseClickMsg) (*Model, tea.Cmd) {
 	m.status = "" // a mouse interaction dismisses the transient invalid-action status
+	m.resolveNote = ""
 
 	if msg.Button != tea.MouseLeft {
 		return m, nil
@@ -79,6 +80,7 @@ func (m *Model) selectClickedRow(id string) (*Model, tea.Cmd) {
 // so there is no dead zone (the staging page has a single scrollable body).
 func (m *Model) handleMouseWheel(msg tea.MouseWheelMsg) (*Model, tea.Cmd) {
 	m.status = "" // a mouse interaction dismisses the transient invalid-action status
+	m.resolveNote = ""
 
 	delta := wheelDelta(msg.Button)
 	if delta == 0 {
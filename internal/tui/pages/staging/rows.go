@@ -32,6 +32,14 @@ type rowRef struct {
 	tagKey string
 }
 
+// markRef identifies a marked item: its section and key. A mark covers the
+// item's staged entry and its tag changes alike, as the selective apply/reset
+// address whole items.
+type markRef struct {
+	section int
+	key     data.StagedKey
+}
+
 // rebuildRows flattens every section's entries and tag changes into the
 // selectable-row list, clamping the selection into range. It also clears the
 // transient invalid-action status: after a reload the row it referred to may no
@@ -69,6 +77,7 @@ func (m *Model) rebuildRows() {
 	}
 
 	m.rows = rows
+	m.pruneMarks()
 
 	if m.selected >= len(rows) {
 		m.selected = max(len(rows)-1, 0)
@@ -127,3 +136,98 @@ func (m *Model) totalCounts(services map[string]bool) (int, int) {
 
 	return entries, tags
 }
+
+// isMarked reports whether the row's item is marked.
+func (m *Model) isMarked(row rowRef) bool {
+	return m.marked[markRef{section: row.section, key: row.key}]
+}
+
+// toggleMark marks or unmarks the selected row's item.
+func (m *Model) toggleMark() {
+	row, ok := m.selectedRow()
+	if !ok {
+		return
+	}
+
+	ref := markRef{section: row.section, key: row.key}
+	if m.marked[ref] {
+		delete(m.marked, ref)
+
+		return
+	}
+
+	if m.marked == nil {
+		m.marked = make(map[markRef]bool)
+	}
+
+	m.marked[ref] = true
+}
+
+// pruneMarks drops the marks of items no longer staged (applied, reset or
+// unstaged since they were marked).
+func (m *Model) pruneMarks() {
+	staged := make(map[markRef]bool, len(m.rows))
+	for _, row := range m.rows {
+		staged[markRef{section: row.section, key: row.key}] = true
+	}
+
+	for ref := range m.marked {
+		if !staged[ref] {
+			delete(m.marked, ref)
+		}
+	}
+}
+
+// markedKeys returns the marked items of the given services, keyed by service
+// and in row order; nil when none of them has a marked item.
+func (m *Model) markedKeys(services []string) map[string][]data.StagedKey {
+	targets := serviceSet(services)
+	seen := make(map[markRef]bool)
+
+	var marked map[string][]data.StagedKey
+
+	for _, row := range m.rows {
+		ref := markRef{section: row.section, key: row.key}
+		service := m.sections[row.section].service
+
+		if !targets[service] || !m.marked[ref] || seen[ref] {
+			continue
+		}
+
+		seen[ref] = true
+
+		if marked == nil {
+			marked = make(map[string][]data.StagedKey)
+		}
+
+		marked[service] = append(marked[service], row.key)
+	}
+
+	return marked
+}
+
+// markedCounts returns the staged entry and tag counts of the marked items, for
+// the apply confirmation of a marked apply.
+func (m *Model) markedCounts(marked map[string][]data.StagedKey) (int, int) {
+	entries, tags := 0, 0
+
+	for i, sec := range m.sections {
+		if len(marked[sec.service]) == 0 {
+			continue
+		}
+
+		for _, e := range sec.entryRows() {
+			if m.marked[markRef{section: i, key: data.StagedKey{Name: e.Name, Namespace: e.Namespace}}] {
+				entries++
+			}
+		}
+
+		for _, t := range sec.review.Tags {
+			if m.marked[markRef{section: i, key: data.StagedKey{Name: t.Name, Namespace: t.Namespace}}] {
+				tags++
+			}
+		}
+	}
+
+	return entries, tags
+}
@@ -1,8 +1,9 @@
 // Package staging implements the TUI's staging review page: per-service sections
 // listing staged entries (as Remote-vs-Staged diffs or raw staged values) and
 // independent staged tag changes, with unstage (`u`, the single removal
-// affordance for both entries and tag changes) / edit-staged row actions and the
-// apply and reset flows. It completes the TUI's stage → review →
+// affordance for both entries and tag changes) / edit-staged row actions, space
+// to mark items for a selective apply/reset, and the apply, reset and
+// conflict-resolve flows. It completes the TUI's stage → review →
 // apply loop. Only the services the launched scope offers get a section; every
 // staged read is a tea.Cmd guarded by a monotonic sequence (the browser's
 // loadSeq pattern), and a staging read failure degrades to a per-section error
@@ -45,16 +46,20 @@ const (
 //
 //nolint:gochecknoglobals // immutable page-local bindings
 var (
-	viewKey     = key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "view"))
-	revealKey   = key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "reveal"))
-	resetKey    = key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reset"))
-	unstageKey  = key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "unstage"))
-	editKey     = key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit"))
-	tagKey      = key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "tags"))
-	applyKey    = key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "apply"))
-	applyAllKey = key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "apply-all"))
-	resetAllKey = key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "reset-all"))
-	refreshKey  = key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "refresh"))
+	viewKey      = key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "view"))
+	revealKey    = key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "reveal"))
+	resetKey     = key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reset"))
+	unstageKey   = key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "unstage"))
+	editKey      = key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit"))
+	tagKey       = key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "tags"))
+	applyKey     = key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "apply"))
+	applyAllKey  = key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "apply-all"))
+	resetAllKey  = key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "reset-all"))
+	resolveKey   = key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "resolve"))
+	changesetKey = key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "changeset"))
+	historyKey   = key.NewBinding(key.WithKeys("h"), key.WithHelp("h", "history"))
+	markKey      = key.NewBinding(key.WithKeys("space"), key.WithHelp("space", "mark"))
+	refreshKey   = key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "refresh"))
 
 	// Help-only bindings for the adaptive help bar. hideKey is the diff-view label
 	// for `x` (which hides a revealed diff there, versus revealing a masked value
@@ -100,6 +105,13 @@ type Model struct {
 
 	sections []*section
 
+	// changesets is the changeset-selector seam (nil hides the selector);
+	// changesetNames/changeset are its last listing and the active changeset,
+	// empty until the first listing lands.
+	changesets     data.Changesets
+	changesetNames []string
+	changeset      string
+
 	styles styles.Styles
 	keys   keys.Map
 
@@ -129,10 +141,21 @@ type Model struct {
 	rows     []rowRef
 	selected int
 
+	// marked holds the items marked with space. While a section has marked
+	// items, its apply/reset act on just those items; esc clears the marks.
+	marked map[markRef]bool
+
 	// actionBusy guards the inline row actions (unstage / cancel-tag) so a
 	// double-press never fires two writes at once (#568).
 	actionBusy bool
 
+	// resolveQueue holds the conflicted merges of the running resolve that still
+	// wait for the editor, one handoff at a time.
+	resolveQueue []pendingResolve
+	// resolveNote is the last resolve outcome for the footer. Unlike status it
+	// survives the reload the resolve triggers, and clears on the next key.
+	resolveNote string
+
 	// scroll is the section-body scroll offset (wheel/overflow).
 	scroll int
 	// scrollToSelection, set when the selection moves, tells the next View to
@@ -167,8 +190,9 @@ func idIndex(id, prefix string) (int, bool) {
 }
 
 // New builds the staging page over the offered services' staging seams (param
-// and/or secret, in tab order). ctx is the Run context threaded through reads.
-func New(ctx context.Context, services []data.StagingService, st styles.Styles, km keys.Map) *Model {
+// and/or secret, in tab order) and the changeset seam (nil hides the changeset
+// selector). ctx is the Run context threaded through reads.
+func New(ctx context.Context, services []data.StagingService, changesets data.Changesets, st styles.Styles, km keys.Map) *Model {
 	sections := make([]*section, 0, len(services))
 	for _, svc := range services {
 		sections = append(sections, &section{
@@ -180,11 +204,12 @@ func New(ctx context.Context, services []data.StagingService, st styles.Styles,
 	}
 
 	return &Model{
-		ctx:      ctx,
-		sections: sections,
-		styles:   st,
-		keys:     km,
-		diffView: true, // diff is the default view (the mock's [diff] value)
+		ctx:        ctx,
+		sections:   sections,
+		changesets: changesets,
+		styles:     st,
+		keys:       km,
+		diffView:   true, // diff is the default view (the mock's [diff] value)
 	}
 }
 
@@ -198,18 +223,18 @@ func (m *Model) Init() tea.Cmd {
 func (m *Model) CapturesInput() bool { return false }
 
 // reload re-reads every section's staged review, each guarded by a fresh
-// sequence, and clears the dismissible notice so a fresh auto-unstage shows.
+// sequence, and the changesets (a switch reloads the page), and clears the dismissible notice so a fresh auto-unstage shows.
 func (m *Model) reload() tea.Cmd {
 	m.noticeDismissed = false
 	m.reveal = false
 	m.diffHidden = false
 
-	cmds := make([]tea.Cmd, 0, len(m.sections))
+	cmds := make([]tea.Cmd, 0, len(m.sections)+1)
 	for i := range m.sections {
 		cmds = append(cmds, m.reviewCmd(i))
 	}
 
-	return tea.Batch(cmds...)
+	return tea.Batch(append(cmds, m.changesetsCmd())...)
 }
 
 // reviewCmd issues a staged-review read for section i, tagged with a fresh
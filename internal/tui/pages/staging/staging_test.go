@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mpyw/suve/internal/capability"
+	stg "github.com/mpyw/suve/internal/staging"
 	"github.com/mpyw/suve/internal/tui/data"
 	"github.com/mpyw/suve/internal/tui/keys"
 	"github.com/mpyw/suve/internal/tui/nav"
@@ -40,6 +41,10 @@ type stubService struct {
 	cancelRemoves []canceledTag
 	applied       []bool
 	resetCalls    int
+
+	resolveResult data.StagingResolveResult
+	resolveCalls  int
+	resolveEdits  []string
 }
 
 func (s *stubService) Service() string                          { return s.service }
@@ -50,18 +55,32 @@ func (s *stubService) Review(context.Context) (data.StagingReview, error) {
 	return s.review, nil
 }
 
-func (s *stubService) Apply(_ context.Context, ignoreConflicts bool) (data.StagingApplyResult, error) {
+func (s *stubService) Apply(_ context.Context, ignoreConflicts bool, _ []data.StagedKey) (data.StagingApplyResult, error) {
 	s.applied = append(s.applied, ignoreConflicts)
 
 	return s.applyResult, s.applyErr
 }
 
-func (s *stubService) Reset(context.Context) (data.StagingResetResult, error) {
+func (s *stubService) Reset(context.Context, []data.StagedKey) (data.StagingResetResult, error) {
 	s.resetCalls++
 
 	return s.resetResult, nil
 }
 
+func (s *stubService) Resolve(context.Context) (data.StagingResolveResult, error) {
+	s.resolveCalls++
+
+	return s.resolveResult, nil
+}
+
+func (s *stubService) ResolveEdited(
+	_ context.Context, key data.StagedKey, _, edited string,
+) (data.StagingResolveResult, error) {
+	s.resolveEdits = append(s.resolveEdits, edited)
+
+	return data.StagingResolveResult{Entries: []data.StagedResolveRow{{Name: key.Name, Status: "edited"}}}, nil
+}
+
 func (s *stubService) Unstage(_ context.Context, key data.StagedKey) error {
 	s.unstaged = append(s.unstaged, key)
 
@@ -111,7 +130,7 @@ func newModel(t *testing.T, secs ...*stubService) *Model {
 		services[i] = s
 	}
 
-	m := New(context.Background(), services, styles.New(), keys.Default())
+	m := New(context.Background(), services, nil, styles.New(), keys.Default())
 
 	for i, s := range secs {
 		m, _ = m.Update(reviewLoadedMsg{section: i, seq: m.sections[i].loadSeq, review: s.review})
@@ -517,6 +536,72 @@ func TestUpdate_UnstageAndApplyKeys(t *testing.T) {
 	assert.ElementsMatch(t, []string{"param", "secret"}, msg.Services)
 }
 
+// TestUpdate_MarkNarrowsApplyAndReset pins space marking: a marked item (its
+// entry and tag rows alike) narrows `a`/`A`/`r` to the marked items of the
+// targeted services, an unmarked section still applies whole, and esc clears the
+// marks.
+func TestUpdate_MarkNarrowsApplyAndReset(t *testing.T) {
+	t.Parallel()
+
+	param := &stubService{
+		service: "param", label: "Param", svcCap: capFor("aws", "param"),
+		review: data.StagingReview{
+			Entries: []data.StagedDiffRow{
+				{Name: "/a", Operation: "update", StagedValue: "v"},
+				{Name: "/b", Operation: "delete"},
+			},
+			Tags: []data.StagedTagRow{{Name: "/a", Adds: []data.Tag{{Key: "k", Value: "v"}}}},
+		},
+	}
+	secret := &stubService{
+		service: "secret", label: "Secret", svcCap: capFor("aws", "secret"),
+		review: data.StagingReview{Entries: []data.StagedDiffRow{{Name: "s", Operation: "create", StagedValue: "v"}}},
+	}
+	m := newModel(t, param, secret)
+	require.Len(t, m.rows, 4, "two entries and one tag add in param, one entry in secret")
+
+	space := tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+
+	m.selected = 0
+	m, _ = m.Update(space)
+	assert.True(t, m.isMarked(m.rows[2]), "marking an entry marks its item's tag rows too")
+	assert.Contains(t, m.View(m.width, m.height), "▸✓")
+
+	_, cmd := m.Update(keyPress('a'))
+	require.NotNil(t, cmd)
+	applyMsg, ok := cmd().(nav.OpenApply)
+	require.True(t, ok)
+	assert.Equal(t, []string{"param"}, applyMsg.Services)
+	assert.Equal(t, map[string][]data.StagedKey{"param": {{Name: "/a"}}}, applyMsg.Marked)
+	assert.Equal(t, 1, applyMsg.EntryCount)
+	assert.Equal(t, 1, applyMsg.TagCount)
+
+	// Apply-all narrows to the sections with marks.
+	_, cmd = m.Update(keyPress('A'))
+	require.NotNil(t, cmd)
+	applyMsg, ok = cmd().(nav.OpenApply)
+	require.True(t, ok)
+	assert.True(t, applyMsg.Global)
+	assert.Equal(t, []string{"param"}, applyMsg.Services)
+
+	// A section without marks still applies and resets whole.
+	m.selected = 3
+	_, cmd = m.Update(keyPress('r'))
+	require.NotNil(t, cmd)
+	resetMsg, ok := cmd().(nav.OpenReset)
+	require.True(t, ok)
+	assert.Equal(t, []string{"secret"}, resetMsg.Services)
+	assert.Nil(t, resetMsg.Marked)
+
+	// A mark on an item no longer staged is dropped on reload.
+	m, _ = m.Update(space)
+	m, _ = m.Update(reviewLoadedMsg{section: 1, seq: m.sections[1].loadSeq})
+	assert.Len(t, m.marked, 1)
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.Empty(t, m.marked, "esc clears the marks")
+}
+
 // TestUpdate_EditAndTagAreStagedOnly pins that the staging review page's `e`
 // (edit) and `t` (tag) launch their dialogs staged-only: the emitted
 // OpenEntryForm/OpenTag carry StagedOnly=true, so the shared mutation dialogs
@@ -583,7 +668,7 @@ func TestUpdate_BadgeCountAuthoritative(t *testing.T) {
 		},
 	}
 
-	m := New(context.Background(), []data.StagingService{sec}, styles.New(), keys.Default())
+	m := New(context.Background(), []data.StagingService{sec}, nil, styles.New(), keys.Default())
 	_, cmd := m.Update(reviewLoadedMsg{section: 0, seq: m.sections[0].loadSeq, review: sec.review})
 
 	require.NotNil(t, cmd)
@@ -808,3 +893,101 @@ func TestHelp_AdaptsToSelectedRowKind(t *testing.T) {
 	assert.NotContains(t, full(), "detail", "the full help drops detail on a tag row")
 	assert.Contains(t, full(), "unstage", "the full help keeps unstage on a tag row")
 }
+
+// TestUpdate_Resolve pins the `m` resolve flow: clean merges are voiced in the
+// footer note (surviving the reload the resolve triggers), and a conflicted
+// merge without a terminal for the editor points at the CLI instead.
+func TestUpdate_Resolve(t *testing.T) { //nolint:paralleltest // swaps the package isTTY seam
+	orig := isTTY
+	isTTY = func() bool { return false }
+
+	t.Cleanup(func() { isTTY = orig })
+
+	sec := &stubService{
+		service: "param", label: "Param", svcCap: capFor("aws", "param"), review: updateReview(),
+		resolveResult: data.StagingResolveResult{Entries: []data.StagedResolveRow{
+			{Name: "/app/web/CDN_URL", Status: "merged"},
+			{Name: "/app/web/API", Status: "unresolved", Conflicts: 1, Merged: "<<<<<<< staged\na\n=======\nb\n>>>>>>> remote #2\n"},
+		}},
+	}
+	m := newModel(t, sec)
+
+	m, cmd := m.Update(keyPress('m'))
+	require.NotNil(t, cmd)
+
+	_, again := m.Update(keyPress('m'))
+	assert.Nil(t, again, "a second press while resolving is ignored")
+
+	m, _ = m.Update(cmd())
+	m, _ = m.Update(reviewLoadedMsg{section: 0, seq: m.sections[0].loadSeq, review: sec.review})
+
+	assert.Equal(t, 1, sec.resolveCalls)
+	assert.Empty(t, m.resolveQueue, "without a TTY the conflicted merge is not queued for the editor")
+	assert.Contains(t, m.View(100, 30), "1 conflict(s) need an editor", "the note survives the reload")
+}
+
+// TestUpdate_ResolveEdited pins that an edited merge is stored through
+// ResolveEdited, while an untouched buffer stores nothing.
+func TestUpdate_ResolveEdited(t *testing.T) {
+	t.Parallel()
+
+	sec := &stubService{service: "param", label: "Param", svcCap: capFor("aws", "param"), review: updateReview()}
+	m := newModel(t, sec)
+
+	pending := pendingResolve{row: data.StagedResolveRow{Name: "/app/web/API", Status: "unresolved", Merged: "<<<<<<< staged\n"}}
+
+	m, cmd := m.Update(resolveEditedMsg{pending: pending, content: "<<<<<<< staged\n"})
+	assert.Nil(t, cmd)
+	assert.Equal(t, "No changes made to /app/web/API.", m.resolveNote)
+
+	m, cmd = m.Update(resolveEditedMsg{pending: pending, content: "merged\n"})
+	require.NotNil(t, cmd)
+
+	m, _ = m.Update(cmd())
+
+	assert.Equal(t, []string{"merged\n"}, sec.resolveEdits)
+	assert.Equal(t, "Param: 1 resolved", m.resolveNote)
+}
+
+// stubChangesets is a fixed data.Changesets listing.
+type stubChangesets struct {
+	names  []string
+	active string
+}
+
+func (s *stubChangesets) List() ([]string, string, error) { return s.names, s.active, nil }
+func (s *stubChangesets) Switch(string) error             { return nil }
+
+func (s *stubChangesets) Applies() ([]*stg.ApplyRecord, error) { return nil, nil }
+func (s *stubChangesets) Undo(string) (int, error)             { return 0, nil }
+
+// TestUpdate_Changesets pins the changeset selector: `c` is inert until the
+// listing lands, then asks the app for the picker over it, and a named active
+// changeset is called out in the header while the default goes unlabelled.
+func TestUpdate_Changesets(t *testing.T) {
+	t.Parallel()
+
+	cs := &stubChangesets{names: []string{"default", "rotate-db"}, active: "rotate-db"}
+	m := New(context.Background(), nil, cs, styles.New(), keys.Default())
+
+	_, cmd := m.Update(keyPress('c'))
+	assert.Nil(t, cmd, "nothing to pick before the listing lands")
+
+	m, _ = m.Update(m.changesetsCmd()())
+	assert.Contains(t, m.View(120, 20), "rotate-db")
+	assert.Contains(t, fullHelpDescs(m.HelpKeyMap().FullHelp()), "changeset")
+
+	_, cmd = m.Update(keyPress('c'))
+	require.NotNil(t, cmd)
+	assert.Equal(t, nav.OpenChangesets{Names: cs.names, Active: "rotate-db"}, cmd())
+
+	assert.Contains(t, fullHelpDescs(m.HelpKeyMap().FullHelp()), "history")
+
+	_, cmd = m.Update(keyPress('h'))
+	require.NotNil(t, cmd)
+	assert.Equal(t, nav.OpenJournal{}, cmd())
+
+	cs.active = "default"
+	m, _ = m.Update(m.changesetsCmd()())
+	assert.NotContains(t, m.View(120, 20), "changeset:")
+}
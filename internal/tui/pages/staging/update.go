@@ -1,9 +1,12 @@
 package staging
 
 import (
+	"strconv"
+
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/mpyw/suve/internal/cli/editor"
 	"github.com/mpyw/suve/internal/tui/components"
 	"github.com/mpyw/suve/internal/tui/data"
 	"github.com/mpyw/suve/internal/tui/nav"
@@ -22,6 +25,18 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 		return m, m.onReviewLoaded(msg)
 	case actionDoneMsg:
 		return m, m.onActionDone(msg)
+	case resolveDoneMsg:
+		return m, m.onResolveDone(msg)
+	case resolveEditedMsg:
+		return m, m.onResolveEdited(msg)
+	case changesetsLoadedMsg:
+		// A listing failure keeps the last known changeset; the reviews surface
+		// any store problem on their own.
+		if msg.err == nil {
+			m.changesetNames, m.changeset = msg.names, msg.active
+		}
+
+		return m, nil
 	case nav.Reload:
 		return m, m.reload()
 	case tea.KeyPressMsg:
@@ -88,14 +103,22 @@ func (m *Model) handleKey(msg tea.KeyPressMsg) (*Model, tea.Cmd) {
 	// Clear the transient invalid-action status on any key; the action below
 	// re-sets it when the pressed key is itself invalid for the selected row.
 	m.status = ""
+	m.resolveNote = ""
 
-	// The auto-unstaged notice dismisses on esc while it is showing.
+	// The auto-unstaged notice dismisses on esc while it is showing; then esc
+	// clears the marks.
 	if key.Matches(msg, m.keys.Back) && m.noticeVisible() {
 		m.noticeDismissed = true
 
 		return m, nil
 	}
 
+	if key.Matches(msg, m.keys.Back) && len(m.marked) > 0 {
+		m.marked = nil
+
+		return m, nil
+	}
+
 	switch {
 	case key.Matches(msg, m.keys.Up):
 		m.moveSelection(-1)
@@ -112,6 +135,10 @@ func (m *Model) handleKey(msg tea.KeyPressMsg) (*Model, tea.Cmd) {
 	case key.Matches(msg, revealKey):
 		m.onReveal()
 
+		return m, nil
+	case key.Matches(msg, markKey):
+		m.toggleMark()
+
 		return m, nil
 	}
 
@@ -135,6 +162,12 @@ func (m *Model) handleActionKey(msg tea.KeyPressMsg) (*Model, tea.Cmd) {
 		return m, m.reset(false)
 	case key.Matches(msg, resetAllKey):
 		return m, m.reset(true)
+	case key.Matches(msg, resolveKey):
+		return m, m.resolveSelected()
+	case key.Matches(msg, changesetKey):
+		return m, m.openChangesets()
+	case key.Matches(msg, historyKey):
+		return m, m.openJournal()
 	case key.Matches(msg, refreshKey):
 		return m, m.reload()
 	}
@@ -314,6 +347,116 @@ func (m *Model) openDetail(row rowRef) tea.Cmd {
 	return func() tea.Msg { return req }
 }
 
+// resolveSelected merges remote changes into the selected section's conflicting
+// staged entries — the `suve stage resolve` flow. Clean merges are re-staged
+// right away; each conflicted merge then opens in the editor in turn.
+func (m *Model) resolveSelected() tea.Cmd {
+	if len(m.sections) == 0 || m.actionBusy || len(m.resolveQueue) > 0 {
+		return nil
+	}
+
+	m.actionBusy = true
+
+	return m.resolveCmd(m.selectedSection())
+}
+
+// onResolveDone voices a resolve pass and reloads. The first pass queues its
+// conflicted merges for the editor; the pass storing an editor result moves on
+// to the next queued merge.
+func (m *Model) onResolveDone(msg resolveDoneMsg) tea.Cmd {
+	m.actionBusy = false
+
+	if msg.section >= len(m.sections) {
+		return nil
+	}
+
+	sec := m.sections[msg.section]
+
+	if msg.err != nil {
+		sec.err = msg.err.Error()
+
+		return m.nextResolveEdit()
+	}
+
+	m.resolveNote = resolveSummary(sec.label, msg.result)
+
+	if !msg.edited {
+		for _, row := range msg.result.Entries {
+			if row.Status == resolveUnresolved && row.Merged != "" {
+				m.resolveQueue = append(m.resolveQueue, pendingResolve{section: msg.section, row: row})
+			}
+		}
+	}
+
+	return tea.Batch(m.reload(), m.nextResolveEdit())
+}
+
+// nextResolveEdit opens the editor on the next queued conflicted merge. Without
+// a terminal to run the editor in, the queue is dropped and the note points at
+// the CLI.
+func (m *Model) nextResolveEdit() tea.Cmd {
+	if len(m.resolveQueue) == 0 {
+		return nil
+	}
+
+	if !isTTY() {
+		m.resolveNote = strconv.Itoa(len(m.resolveQueue)) + " conflict(s) need an editor — run suve stage resolve"
+		m.resolveQueue = nil
+
+		return nil
+	}
+
+	p := m.resolveQueue[0]
+	m.resolveQueue = m.resolveQueue[1:]
+
+	return m.openResolveEditor(p)
+}
+
+// onResolveEdited stores an editor result. An editor failure or an untouched
+// buffer leaves the entry as staged and moves on to the next conflict.
+func (m *Model) onResolveEdited(msg resolveEditedMsg) tea.Cmd {
+	label := msg.pending.row.Name
+
+	if msg.err != nil {
+		m.resolveNote = "editor error: " + msg.err.Error()
+
+		return m.nextResolveEdit()
+	}
+
+	content := editor.Normalize(msg.pending.row.Merged, msg.content)
+	if content == msg.pending.row.Merged {
+		m.resolveNote = "No changes made to " + label + "."
+
+		return m.nextResolveEdit()
+	}
+
+	m.actionBusy = true
+
+	return m.resolveEditedCmd(msg.pending, content)
+}
+
+// openChangesets asks the app for the changeset selector over the last listing.
+// It is a no-op without a changeset seam or before the first listing lands.
+func (m *Model) openChangesets() tea.Cmd {
+	if m.changesets == nil || len(m.changesetNames) == 0 {
+		return nil
+	}
+
+	req := nav.OpenChangesets{Names: m.changesetNames, Active: m.changeset}
+
+	return func() tea.Msg { return req }
+}
+
+// openJournal asks the app for the apply journal. It is a no-op without a
+// changeset seam, which carries the journal too.
+func (m *Model) openJournal() tea.Cmd {
+	if m.changesets == nil {
+		return nil
+	}
+
+	return func() tea.Msg { return nav.OpenJournal{} }
+}
+
 // apply opens the apply confirmation for the selected section (global=false) or
 // every section (global=true).
 func (m *Model) apply(global bool) tea.Cmd {
@@ -322,18 +465,26 @@ func (m *Model) apply(global bool) tea.Cmd {
 
 // applyServices opens the apply confirmation for a set of services, carrying the
 // staged counts for the confirmation. A key press and a click on a section's
-// apply button both reduce to this, so mouse and keyboard stay in lockstep.
+// apply button both reduce to this, so mouse and keyboard stay in lockstep. When
+// any of the services has marked items, only those are applied.
 func (m *Model) applyServices(services []string, global bool) tea.Cmd {
 	if len(services) == 0 {
 		return nil
 	}
 
+	marked := m.markedKeys(services)
+
 	entries, tags := m.totalCounts(serviceSet(services))
+	if marked != nil {
+		services = markedServices(services, marked)
+		entries, tags = m.markedCounts(marked)
+	}
+
 	if entries == 0 && tags == 0 {
 		return nil
 	}
 
-	req := nav.OpenApply{Services: services, Global: global, EntryCount: entries, TagCount: tags}
+	req := nav.OpenApply{Services: services, Global: global, EntryCount: entries, TagCount: tags, Marked: marked}
 
 	return func() tea.Msg { return req }
 }
@@ -344,13 +495,19 @@ func (m *Model) reset(global bool) tea.Cmd {
 }
 
 // resetServices opens the reset confirmation for a set of services (the reduction
-// target for both the reset keys and a click on a section's reset button).
+// target for both the reset keys and a click on a section's reset button),
+// narrowed to the marked items like applyServices.
 func (m *Model) resetServices(services []string, global bool) tea.Cmd {
 	if len(services) == 0 {
 		return nil
 	}
 
-	req := nav.OpenReset{Services: services, Global: global}
+	marked := m.markedKeys(services)
+	if marked != nil {
+		services = markedServices(services, marked)
+	}
+
+	req := nav.OpenReset{Services: services, Global: global, Marked: marked}
 
 	return func() tea.Msg { return req }
 }
@@ -374,6 +531,19 @@ func (m *Model) targetServices(global bool) []string {
 	return []string{m.sections[m.selectedSection()].service}
 }
 
+// markedServices narrows services to those with marked items, keeping order.
+func markedServices(services []string, marked map[string][]data.StagedKey) []string {
+	var out []string
+
+	for _, s := range services {
+		if len(marked[s]) > 0 {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
 // serviceSet builds a set of the given service keys.
 func serviceSet(services []string) map[string]bool {
 	set := make(map[string]bool, len(services))
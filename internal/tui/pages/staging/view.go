@@ -7,6 +7,7 @@ import (
 	"charm.land/lipgloss/v2"
 
 	"github.com/mpyw/suve/internal/provider/azure/appconfig/aznamespace"
+	"github.com/mpyw/suve/internal/staging/store/file"
 	"github.com/mpyw/suve/internal/tui/data"
 	"github.com/mpyw/suve/internal/tui/hit"
 )
@@ -18,6 +19,13 @@ const (
 	operationDelete = "delete"
 )
 
+// Resolve status labels (data.StagedResolveRow.Status) the page acts on.
+const (
+	resolveUnresolved = "unresolved"
+	resolveSkipped    = "skipped"
+	resolveFailed     = "failed"
+)
+
 // stagingGutter is the blank left margin the staging page indents its content by,
 // so its rows are not jammed against the terminal's left edge — the borderless
 // counterpart to the panes' interior padding (#698). Every rendered line is
@@ -169,16 +177,81 @@ func rangeRegion(id string, r [2]int, y, gutter int) *lipgloss.Layer {
 }
 
 // footerLine renders the reserved bottom row: a pending invalid-action status
-// message when one is set (#684), otherwise nothing (the row-action bindings are
-// now shown by the adaptive help bar, #681).
+// message when one is set (#684), else the last resolve outcome, else the count
+// of marked items, otherwise nothing (the row-action bindings are now shown by
+// the adaptive help bar, #681).
 func (m *Model) footerLine(width int) string {
 	if m.status != "" {
 		return m.styles.ErrorText.Render(clip(m.status, width))
 	}
 
+	if m.resolveNote != "" {
+		return m.styles.PageHint.Render(clip(m.resolveNote, width))
+	}
+
+	if len(m.marked) > 0 {
+		note := strconv.Itoa(len(m.marked)) + " marked — apply/reset act on the marked items, esc clears"
+
+		return m.styles.PageHint.Render(clip(note, width))
+	}
+
 	return ""
 }
 
+// resolveSummary voices a resolve pass on one line, e.g. "Param: 2 resolved,
+// 1 unresolved, 1 tag change(s) rebased". A skipped or failed entry appends the
+// first such entry's reason so the note says what to do about it.
+func resolveSummary(label string, res data.StagingResolveResult) string {
+	if len(res.Entries) == 0 && res.RebasedTags == 0 {
+		return label + ": no conflicts"
+	}
+
+	var resolved, unresolved, skipped, failed int
+
+	detail := ""
+
+	for _, e := range res.Entries {
+		switch e.Status {
+		case resolveUnresolved:
+			unresolved++
+		case resolveSkipped:
+			skipped++
+		case resolveFailed:
+			failed++
+		default:
+			resolved++
+		}
+
+		if detail == "" && e.Detail != "" {
+			detail = e.Name + ": " + e.Detail
+		}
+	}
+
+	var parts []string
+
+	for _, p := range []struct {
+		n    int
+		verb string
+	}{
+		{resolved, "resolved"},
+		{unresolved, "unresolved"},
+		{skipped, "skipped"},
+		{failed, "failed"},
+		{res.RebasedTags, "tag change(s) rebased"},
+	} {
+		if p.n > 0 {
+			parts = append(parts, strconv.Itoa(p.n)+" "+p.verb)
+		}
+	}
+
+	note := label + ": " + strings.Join(parts, ", ")
+	if detail != "" {
+		note += " — " + detail
+	}
+
+	return note
+}
+
 // headerLine renders the fixed top line — the view toggle and the global
 // apply-all / reset-all / refresh affordances — and returns each affordance's
 // clickable column range, so a header click reduces to the same action its key
@@ -205,7 +278,14 @@ func (m *Model) headerLine(width int) (string, headerRanges) {
 	toggle := "view: " + m.styles.PaneTitle.Render(bracket("diff", m.diffView)) + " " + bracket("value", !m.diffView)
 	actions := m.styles.PageHint.Render(actionsText)
 
-	return clip(toggle+"   "+actions, width), ranges
+	// A named changeset is called out after the actions (so their ranges stay put);
+	// the default one is the historical staging area and goes unlabelled.
+	var changeset string
+	if m.changeset != "" && m.changeset != file.DefaultChangeset {
+		changeset = "   changeset: " + m.styles.PaneTitle.Render(m.changeset)
+	}
+
+	return clip(toggle+"   "+actions+changeset, width), ranges
 }
 
 // indexSpan finds sub in plain and returns its [start,end) column range.
@@ -424,13 +504,24 @@ func (m *Model) opMarker(op string) string {
 	}
 }
 
-// cursor renders the selection cursor for a row.
+// cursor renders the selection cursor for a row, followed by a check when the
+// row's item is marked.
 func (m *Model) cursor(rowIdx int) string {
+	pointer := " "
 	if rowIdx == m.selected {
-		return m.styles.StatusValue.Render("▸ ")
+		pointer = "▸"
+	}
+
+	mark := " "
+	if rowIdx < len(m.rows) && m.isMarked(m.rows[rowIdx]) {
+		mark = "✓"
+	}
+
+	if pointer == " " && mark == " " {
+		return "  "
 	}
 
-	return "  "
+	return m.styles.StatusValue.Render(pointer + mark)
 }
 
 // clampScroll keeps the scroll offset in range and, when the selection just
@@ -11,6 +11,7 @@ import (
 	"github.com/mpyw/suve/internal/tui/nav"
 	"github.com/mpyw/suve/internal/tui/pages/browser"
 	"github.com/mpyw/suve/internal/tui/pages/diff"
+	matrixpage "github.com/mpyw/suve/internal/tui/pages/matrix"
 	"github.com/mpyw/suve/internal/tui/pages/staging"
 	"github.com/mpyw/suve/internal/tui/styles"
 )
@@ -74,13 +75,36 @@ func (p stagingPage) HelpKeyMap() help.KeyMap       { return p.m.HelpKeyMap() }
 func (p stagingPage) capturesInput() bool { return false }
 
 // newStagingPage builds the staging page adapter over the offered services'
-// staging seams.
-func newStagingPage(ctx context.Context, services []data.StagingService, st styles.Styles, km keys.Map) stagingPage {
-	return stagingPage{m: staging.New(ctx, services, st, km)}
+// staging seams and the changeset seam (nil hides the changeset selector).
+func newStagingPage(
+	ctx context.Context, services []data.StagingService, changesets data.Changesets, st styles.Styles, km keys.Map,
+) stagingPage {
+	return stagingPage{m: staging.New(ctx, services, changesets, st, km)}
+}
+
+// matrixPage adapts *matrixpage.Model to the app's page interface.
+type matrixPage struct{ m *matrixpage.Model }
+
+func (p matrixPage) Update(msg tea.Msg) (page, tea.Cmd) {
+	m, cmd := p.m.Update(msg)
+
+	return matrixPage{m: m}, cmd
+}
+
+func (p matrixPage) View(width, height int) string { return p.m.View(width, height) }
+func (p matrixPage) Init() tea.Cmd                 { return p.m.Init() }
+func (p matrixPage) HelpKeyMap() help.KeyMap       { return p.m.HelpKeyMap() }
+
+// capturesInput is always false: the matrix page has no text input.
+func (p matrixPage) capturesInput() bool { return false }
+
+// newMatrixPage builds the matrix page adapter for a `suve matrix --tui` launch.
+func newMatrixPage(ctx context.Context, mx Matrix, st styles.Styles, km keys.Map) matrixPage {
+	return matrixPage{m: matrixpage.New(ctx, mx.Title, mx.Secret, mx.Filter, mx.Load, st, km)}
 }
 
 // newStaticDiffPage builds a diff page over already-known content (the staging
-// page's remote-vs-staged detail).
+// page's remote-vs-staged detail, and the matrix page's cell comparison).
 func newStaticDiffPage(content data.DiffContent, st styles.Styles, km keys.Map) diffPage {
 	return diffPage{m: diff.NewStatic(content, st, km)}
 }
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/mpyw/suve/internal/tui/dialogs"
+)
+
+// pickerProfile identifies the AWS profile picker's PickedMsg.
+const pickerProfile = "profile"
+
+// ambientProfileLabel is the picker row that returns to the ambient credential
+// chain (AWS_PROFILE, environment keys, instance role).
+const ambientProfileLabel = "(ambient)"
+
+// openProfilePicker pushes the AWS profile picker, listing the ambient chain
+// first and then every profile in the shared config, with the cursor on the
+// current one.
+func (m *App) openProfilePicker() tea.Cmd {
+	if m.profiles == nil {
+		return nil
+	}
+
+	items := append([]dialogs.PickerItem{{Value: "", Label: ambientProfileLabel}}, m.profiles()...)
+
+	return m.pushDialog(dialogs.NewPicker(m.styles, pickerProfile, "Switch AWS profile", items, m.scope.AWSProfile), nil)
+}
+
+// onPicked applies a confirmed picker choice.
+func (m *App) onPicked(msg dialogs.PickedMsg) tea.Cmd {
+	switch {
+	case msg.Picker == pickerProfile:
+		return m.switchProfile(msg.Value)
+	case msg.Picker == pickerScopeAxis:
+		return m.openAxisPicker(msg.Value)
+	case strings.HasPrefix(msg.Picker, pickerScopePrefix):
+		return m.switchAxis(strings.TrimPrefix(msg.Picker, pickerScopePrefix), msg.Value)
+	case msg.Picker == pickerLeaveScope:
+		return m.confirmLeave(msg.Value)
+	case msg.Picker == pickerChangeset:
+		return m.switchChangeset(msg.Value)
+	case msg.Picker == pickerJournal:
+		return m.undoApply(msg.Value)
+	default:
+		return nil
+	}
+}
+
+// switchProfile switches to the AWS profile (empty for the ambient chain), as
+// if relaunched with --profile (see switchScope). The role selected at launch
+// is dropped, since it was chosen for the launch profile; the picked profile's
+// own role_arn still applies. The account and region are resolved afresh from
+// the profile.
+func (m *App) switchProfile(profile string) tea.Cmd {
+	if profile == m.scope.AWSProfile && m.scope.AWSRoleARN == "" {
+		return nil
+	}
+
+	scope := m.scope
+	scope.AccountID, scope.Region = "", ""
+	scope.AWSProfile = profile
+	scope.AWSRoleARN, scope.AWSExternalID, scope.AWSSessionName, scope.AWSMFASerial = "", "", "", ""
+
+	return m.leaveScope(scope, "switched to profile "+profileLabel(profile))
+}
+
+// profileLabel names a profile for the status line.
+func profileLabel(profile string) string {
+	if profile == "" {
+		return ambientProfileLabel
+	}
+
+	return profile
+}
@@ -0,0 +1,86 @@
+//nolint:testpackage // white-box: drives the profile picker through the app shell and inspects its scope
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/tui/components"
+	"github.com/mpyw/suve/internal/tui/dialogs"
+)
+
+// profileApp builds an AWS app whose rescope seam records the scopes it is
+// asked for and returns an identity fetcher naming the profile.
+func profileApp(scope provider.Scope, rescoped *[]provider.Scope) *App {
+	return newApp(config{
+		scope:    scope,
+		identity: awsIdentityFixture(),
+		rescope: func(next provider.Scope) config {
+			*rescoped = append(*rescoped, next)
+
+			return config{fetchIdentity: func() (components.AWSIdentity, error) {
+				return components.AWSIdentity{Account: "222222222222", Region: "us-east-1", Profile: next.AWSProfile}, nil
+			}}
+		},
+		profiles: func() []dialogs.PickerItem {
+			return []dialogs.PickerItem{{Value: "prod", Label: "prod", Detail: "222222222222"}}
+		},
+	})
+}
+
+// TestProfileSwitch pins that P opens the picker on AWS and picking a profile
+// rebuilds the scope (dropping the launch role) and refetches the identity.
+func TestProfileSwitch(t *testing.T) {
+	t.Parallel()
+
+	var rescoped []provider.Scope
+
+	launch := provider.Scope{Provider: provider.ProviderAWS, AWSProfile: "dev", AWSRoleARN: "arn:aws:iam::111111111111:role/Admin"}
+	m := profileApp(launch, &rescoped)
+
+	m = updateApp(t, m, keyPress('P'))
+	require.Len(t, m.dialogs, 1, "P opens the profile picker")
+	assert.Contains(t, m.dialogs[0].View(), "(ambient)")
+	assert.Contains(t, m.dialogs[0].View(), "222222222222")
+
+	next, cmd := m.Update(dialogs.PickedMsg{Picker: pickerProfile, Value: "prod"})
+	m, _ = next.(*App)
+	require.NotNil(t, cmd)
+	assert.Empty(t, m.dialogs, "picking closes the picker")
+	assert.Empty(t, rescoped, "the switch waits for the staged-changes count")
+
+	m = updateApp(t, m, cmd())
+
+	require.Len(t, rescoped, 1)
+	assert.Equal(t, "prod", rescoped[0].AWSProfile)
+	assert.Empty(t, rescoped[0].AWSRoleARN, "the launch role is dropped")
+	assert.Equal(t, rescoped[0], m.scope)
+	assert.True(t, m.identityLoading)
+	assert.Nil(t, m.identity)
+	assert.Equal(t, "switched to profile prod", m.status)
+
+	// An identity fetched for the old profile is dropped; the new one lands.
+	m = updateApp(t, m, awsIdentityMsg{id: *awsIdentityFixture(), gen: 0})
+	assert.Nil(t, m.identity)
+
+	m = updateApp(t, m, awsIdentityMsg{id: components.AWSIdentity{Profile: "prod"}, gen: 1})
+	require.NotNil(t, m.identity)
+	assert.Equal(t, "prod", m.identity.Profile)
+}
+
+// TestProfileSwitch_Disabled pins that P does nothing outside an AWS scope or
+// without the profile seams.
+func TestProfileSwitch_Disabled(t *testing.T) {
+	t.Parallel()
+
+	m := newApp(config{scope: provider.GoogleCloudScope("proj")})
+	m = updateApp(t, m, keyPress('P'))
+	assert.Empty(t, m.dialogs)
+
+	m = newApp(config{scope: provider.Scope{Provider: provider.ProviderAWS}, identity: awsIdentityFixture()})
+	m = updateApp(t, m, keyPress('P'))
+	assert.Empty(t, m.dialogs)
+}
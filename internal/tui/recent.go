@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/mpyw/suve/internal/provider"
+)
+
+const (
+	recentDirName  = ".suve"
+	recentFileName = "recent-scopes.json"
+
+	// recentLimit caps how many values each axis remembers.
+	recentLimit = 10
+)
+
+// Scope axes the scope picker switches, and the keys of the recent-scopes file.
+const (
+	axisRegion  = "aws.region"
+	axisProject = "googlecloud.project"
+	axisVault   = "azure.vault"
+	axisStore   = "azure.store"
+)
+
+// recentScopes is the small history of scope values picked in (or launched
+// into) the TUI, most recent first per axis, so the scope picker can offer
+// them again:
+//
+//	{"googlecloud.project": ["prod-1234", "dev-5678"], "azure.vault": ["kv-prod"]}
+//
+// It is best-effort: an unreadable file is an empty history and a failed write
+// is ignored.
+type recentScopes struct {
+	path string
+}
+
+// newRecentScopes returns the history at ~/.suve/recent-scopes.json, or nil
+// when the home directory is unknown.
+func newRecentScopes() *recentScopes {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	return &recentScopes{path: filepath.Join(home, recentDirName, recentFileName)}
+}
+
+// read loads the whole history.
+func (r *recentScopes) read() map[string][]string {
+	history := map[string][]string{}
+
+	if data, err := os.ReadFile(r.path); err == nil {
+		_ = json.Unmarshal(data, &history)
+	}
+
+	return history
+}
+
+// list returns the remembered values for an axis, most recent first.
+func (r *recentScopes) list(axis string) []string {
+	if r == nil {
+		return nil
+	}
+
+	return r.read()[axis]
+}
+
+// remember moves the scope's value on each axis it has to the front of that
+// axis's history.
+func (r *recentScopes) remember(scope provider.Scope) {
+	if r == nil {
+		return
+	}
+
+	history := r.read()
+
+	for axis, value := range scopeAxes(scope) {
+		if value == "" {
+			continue
+		}
+
+		values := slices.DeleteFunc(history[axis], func(v string) bool { return v == value })
+		history[axis] = slices.Insert(values, 0, value)[:min(len(values)+1, recentLimit)]
+	}
+
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o700); err != nil { //nolint:mnd // owner-only directory permissions
+		return
+	}
+
+	_ = os.WriteFile(r.path, data, 0o600) //nolint:mnd // owner-only history file
+}
+
+// scopeAxes returns the scope's value on each switchable axis of its provider.
+func scopeAxes(scope provider.Scope) map[string]string {
+	switch scope.Provider {
+	case provider.ProviderAWS:
+		return map[string]string{axisRegion: scope.Region}
+	case provider.ProviderGoogleCloud:
+		return map[string]string{axisProject: scope.ProjectID}
+	case provider.ProviderAzure:
+		return map[string]string{axisVault: scope.VaultName, axisStore: scope.StoreName}
+	default:
+		return nil
+	}
+}
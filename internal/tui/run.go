@@ -9,20 +9,30 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/mpyw/suve/internal/capability"
 	"github.com/mpyw/suve/internal/provider"
 	"github.com/mpyw/suve/internal/provider/aws"
 	"github.com/mpyw/suve/internal/provider/aws/infra"
 	"github.com/mpyw/suve/internal/provider/azure"
 	"github.com/mpyw/suve/internal/provider/gcloud"
+	"github.com/mpyw/suve/internal/provider/kubernetes"
+	"github.com/mpyw/suve/internal/provider/local"
+	"github.com/mpyw/suve/internal/provider/plugin"
+	"github.com/mpyw/suve/internal/provider/sops"
+	"github.com/mpyw/suve/internal/provider/vault"
 	"github.com/mpyw/suve/internal/staging/store/file"
 	"github.com/mpyw/suve/internal/tui/components"
+	"github.com/mpyw/suve/internal/tui/dialogs"
 )
 
 // registry is the provider registry backing the TUI's read/write operations. It
 // is the same composition point the CLI and GUI use
 // (internal/cli/commands/internal/client.go, internal/gui/app.go): AWS (param +
-// secret), Google Cloud (secret), and Azure (Key Vault secret + App
-// Configuration param) are registered so any launched scope resolves a store.
+// secret), Google Cloud (secret), Azure (Key Vault secret + App Configuration
+// param), Vault (KV v2 secret), Kubernetes (ConfigMap param + Secret secret),
+// SOPS (encrypted file secret), the offline local provider (JSON-file
+// param + secret), and external provider plugins are registered so any
+// launched scope resolves a store.
 // The TUI composes it through the provider packages — never a cloud SDK
 // directly — keeping the SDK-confinement boundary intact.
 //
@@ -31,14 +41,20 @@ var registry = func() *provider.Registry {
 	reg := aws.NewRegistry()
 	gcloud.Register(reg)
 	azure.Register(reg)
+	vault.Register(reg)
+	kubernetes.Register(reg)
+	sops.Register(reg)
+	local.Register(reg)
+	plugin.Register(reg)
 
 	return reg
 }()
 
-// Run starts the TUI for a fixed provider scope and initial service. Provider
-// and scope are resolved by the caller (the --tui launch wiring) and never
-// change for the process lifetime — relaunch to switch. service preselects the
-// initial tab ("param"/"secret", or "" for the group default). It mirrors the
+// Run starts the TUI for a provider scope and initial service. Provider and
+// scope are resolved by the caller (the --tui launch wiring); the provider never
+// changes for the process lifetime, while the scope can be switched in the app
+// (see switchScope) and is remembered for the scope picker. service preselects
+// the initial tab ("param"/"secret", or "" for the group default). It mirrors the
 // GUI's Run entry shape (internal/gui/run.go) adapted to the terminal.
 func Run(ctx context.Context, scope provider.Scope, service string) error {
 	model, err := newModel(ctx, scope, service)
@@ -46,6 +62,14 @@ func Run(ctx context.Context, scope provider.Scope, service string) error {
 		return err
 	}
 
+	return runProgram(ctx, model)
+}
+
+// runProgram runs the app model until it quits. It is shared by Run and
+// RunMatrix.
+func runProgram(ctx context.Context, model *App) error {
+	model.recents.remember(model.scope)
+
 	// The staging store's plaintext-fallback warning writes straight to stderr;
 	// firing during the alt-screen (it always does in a keychain-less cloud
 	// shell) would corrupt the display. Capture it for the program's lifetime and
@@ -68,7 +92,7 @@ func Run(ctx context.Context, scope provider.Scope, service string) error {
 	// tea.View (Bubble Tea v2 reads them there each frame), so the program needs
 	// only the context here. Browser cloud-shell corruption is handled in the
 	// model via a continuous full-repaint loop (see cloudShellRepaintCmd).
-	_, err = tea.NewProgram(model, tea.WithContext(ctx)).Run()
+	_, err := tea.NewProgram(model, tea.WithContext(ctx)).Run()
 
 	return err
 }
@@ -101,27 +125,79 @@ func (b *lockedBuffer) String() string {
 // e2e harness (NewE2EModel), which drives the very same model through teatest
 // against emulator-backed provider stores instead of a live terminal.
 func newModel(ctx context.Context, scope provider.Scope, service string) (*App, error) {
+	scope, caps, err := handshakePlugin(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := ensureResolvable(ctx, scope); err != nil {
 		return nil, err
 	}
 
-	factory := newSourceFactory(ctx, scope)
+	cfg := scopedConfig(ctx, scope, caps)
+	cfg.scope = scope
+	cfg.service = service
+	cfg.runCtx = ctx
+	cfg.caps = caps
+	cfg.rescope = func(next provider.Scope) config { return scopedConfig(ctx, next, caps) }
+	cfg.profiles = awsProfiles
+	cfg.regions = infra.Regions
+	cfg.recents = newRecentScopes()
 
 	// The page fetch commands receive the Run context through the model's runCtx
-	// field (config.runCtx below), not as a call parameter — contextcheck cannot
+	// field (config.runCtx above), not as a call parameter — contextcheck cannot
 	// see the field-threaded context, so it is silenced here.
 	//nolint:contextcheck // Run context is threaded via config.runCtx into every page fetch command
-	model := newApp(config{
-		scope:         scope,
-		service:       service,
-		fetchIdentity: awsIdentityFetcher(ctx),
+	model := newApp(cfg)
+
+	return model, nil
+}
+
+// scopedConfig builds the scope-bound seams of the app config — the identity
+// fetcher and the registry-backed sourceFactory's read/write/staging seams —
+// for scope. newModel uses it for the launch scope and, as config.rescope, for
+// a profile or scope picked in the app.
+func scopedConfig(ctx context.Context, scope provider.Scope, caps []capability.ProviderCapability) config {
+	factory := newSourceFactory(ctx, scope, caps)
+
+	return config{
+		fetchIdentity: awsIdentityFetcher(ctx, scope),
 		sourceFor:     factory.sourceFor,
 		mutatorFor:    factory.mutatorFor,
 		stagingFor:    factory.stagingService,
-		runCtx:        ctx,
-	})
+		changesetsFor: factory.changesets,
+	}
+}
 
-	return model, nil
+// awsProfiles lists the shared-config profiles for the profile picker, each
+// with its account id when the config names one.
+func awsProfiles() []dialogs.PickerItem {
+	profiles := infra.ListProfiles()
+	items := make([]dialogs.PickerItem, 0, len(profiles))
+
+	for _, p := range profiles {
+		items = append(items, dialogs.PickerItem{Value: p.Name, Label: p.Name, Detail: p.AccountID})
+	}
+
+	return items
+}
+
+// handshakePlugin completes a plugin scope from the plugin's handshake: the
+// kinds it offers, and its capability appended to the static matrix. Other
+// scopes are returned as is, with a nil (static) matrix.
+func handshakePlugin(ctx context.Context, scope provider.Scope) (provider.Scope, []capability.ProviderCapability, error) {
+	if scope.Provider != provider.ProviderPlugin {
+		return scope, nil, nil
+	}
+
+	info, err := plugin.Handshake(ctx, scope.Plugin)
+	if err != nil {
+		return scope, nil, err
+	}
+
+	scope.PluginKinds = info.Kinds
+
+	return scope, append(capability.All(), info.Capability), nil
 }
 
 // ensureResolvable verifies the launched scope can resolve at least one store
@@ -149,19 +225,25 @@ func ensureResolvable(ctx context.Context, scope provider.Scope) error {
 	return lastErr
 }
 
-// awsIdentityFetcher builds the status bar's AWS identity fetcher as a closure
-// over the Run context, so the model never stores a context nor imports the AWS
-// provider package. It is the only TUI seam that touches internal/provider/aws.
-func awsIdentityFetcher(ctx context.Context) identityFetcher {
+// awsIdentityFetcher builds the status bar's AWS identity fetcher for scope as
+// a closure over the Run context, so the model never stores a context nor
+// imports the AWS provider package.
+func awsIdentityFetcher(ctx context.Context, scope provider.Scope) identityFetcher {
 	return func() (components.AWSIdentity, error) {
-		id, err := infra.GetAWSIdentity(ctx)
+		id, err := infra.GetAWSIdentity(aws.WithScope(ctx, scope))
 		if err != nil {
 			return components.AWSIdentity{}, err
 		}
 
+		// A region picked in the app overrides the profile's own.
+		region := id.Region
+		if scope.Region != "" {
+			region = scope.Region
+		}
+
 		return components.AWSIdentity{
 			Account: id.AccountID,
-			Region:  id.Region,
+			Region:  region,
 			Profile: id.Profile,
 		}, nil
 	}
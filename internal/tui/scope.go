@@ -0,0 +1,329 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/tui/data"
+	"github.com/mpyw/suve/internal/tui/dialogs"
+)
+
+// Scope picker ids. pickerScopeAxis asks which Azure axis to switch; an axis's
+// value picker is pickerScopePrefix + the axis; pickerLeaveScope is the
+// staged-changes warning.
+const (
+	pickerScopeAxis   = "scope-axis"
+	pickerScopePrefix = "scope:"
+	pickerLeaveScope  = "leave-scope"
+)
+
+// leaveScopeConfirm is the staged-changes warning's "switch anyway" value.
+const leaveScopeConfirm = "switch"
+
+// profileRegionLabel is the region picker row that returns to the profile's
+// own region.
+const profileRegionLabel = "(profile default)"
+
+// scopeLeaveMsg carries the number of staged changes in the scope being left,
+// counted off the update loop, back to the switch it was counted for. gen is
+// the scope generation the switch was requested in, so a count that lands after
+// another switch is dropped.
+type scopeLeaveMsg struct {
+	next   provider.Scope
+	status string
+	staged int
+	gen    int
+}
+
+// pendingScope is a switch held behind the staged-changes warning.
+type pendingScope struct {
+	scope  provider.Scope
+	status string
+}
+
+// scopeSwitchable reports whether the launch config can switch scope in the
+// app: a rescope seam and a provider with a switchable axis (an AWS region
+// also needs the region list).
+func scopeSwitchable(cfg config) bool {
+	if cfg.rescope == nil {
+		return false
+	}
+
+	switch cfg.scope.Provider {
+	case provider.ProviderAWS:
+		return cfg.regions != nil
+	case provider.ProviderGoogleCloud, provider.ProviderAzure:
+		return true
+	default:
+		return false
+	}
+}
+
+// openScopePicker pushes the picker for the scope's switchable axis; Azure,
+// which has two, first asks which one.
+func (m *App) openScopePicker() tea.Cmd {
+	switch m.scope.Provider {
+	case provider.ProviderAWS:
+		return m.openAxisPicker(axisRegion)
+	case provider.ProviderGoogleCloud:
+		return m.openAxisPicker(axisProject)
+	case provider.ProviderAzure:
+		items := []dialogs.PickerItem{
+			{Value: axisVault, Label: "Key Vault", Detail: m.scope.VaultName},
+			{Value: axisStore, Label: "App Configuration", Detail: m.scope.StoreName},
+		}
+
+		return m.pushDialog(dialogs.NewPicker(m.styles, pickerScopeAxis, "Switch Azure scope", items, ""), nil)
+	default:
+		return nil
+	}
+}
+
+// openAxisPicker pushes the value picker for an axis: the current value, the
+// remembered ones and, for a region, every known region. Any other value can
+// be typed.
+func (m *App) openAxisPicker(axis string) tea.Cmd {
+	current := scopeAxes(m.scope)[axis]
+
+	var items []dialogs.PickerItem
+
+	seen := map[string]bool{}
+	add := func(value, detail string) {
+		if value == "" || seen[value] {
+			return
+		}
+
+		seen[value] = true
+		items = append(items, dialogs.PickerItem{Value: value, Label: value, Detail: detail})
+	}
+
+	if axis == axisRegion {
+		items = append(items, dialogs.PickerItem{Value: "", Label: profileRegionLabel})
+	}
+
+	add(current, "current")
+
+	for _, v := range m.recents.list(axis) {
+		add(v, "recent")
+	}
+
+	if axis == axisRegion {
+		for _, r := range m.regions() {
+			add(r, "")
+		}
+	}
+
+	title := "Switch " + axisTitle(axis)
+
+	return m.pushDialog(dialogs.NewInputPicker(m.styles, pickerScopePrefix+axis, title, items, current), nil)
+}
+
+// switchAxis requests a switch to the scope with axis set to value.
+func (m *App) switchAxis(axis, value string) tea.Cmd {
+	if value == scopeAxes(m.scope)[axis] {
+		return nil
+	}
+
+	next := m.scope
+
+	switch axis {
+	case axisRegion:
+		next.Region = value
+	case axisProject:
+		next.ProjectID = value
+	case axisVault:
+		next.VaultName = value
+	case axisStore:
+		next.StoreName, next.AppConfigNamespace = value, ""
+	default:
+		return nil
+	}
+
+	label := value
+	if axis == axisRegion && value == "" {
+		label = profileRegionLabel
+	}
+
+	return m.leaveScope(next, "switched to "+axisName(axis)+" "+label)
+}
+
+// leaveScope starts a switch to next: it counts the staged changes in the
+// current scope off the update loop (the probes read the staging store), and
+// onScopeLeave switches or warns once the count is in.
+func (m *App) leaveScope(next provider.Scope, status string) tea.Cmd {
+	var probes []data.StagingProbe
+
+	if m.sourceFor != nil {
+		for _, service := range m.offeredServices() {
+			if _, probe := m.sourceFor(service); probe != nil {
+				probes = append(probes, probe)
+			}
+		}
+	}
+
+	ctx, gen := m.runCtx, m.scopeGen
+
+	return func() tea.Msg {
+		staged := 0
+
+		for _, probe := range probes {
+			// An unreadable staging store has nothing to lose by leaving.
+			if snap, err := probe.Staged(ctx); err == nil {
+				staged += snap.EntryCount + snap.TagCount
+			}
+		}
+
+		return scopeLeaveMsg{next: next, status: status, staged: staged, gen: gen}
+	}
+}
+
+// onScopeLeave switches right away when the scope being left has nothing
+// staged, and otherwise holds the switch behind a warning: staging is kept per
+// scope, so the changes are not lost, but they no longer show until the user
+// switches back.
+func (m *App) onScopeLeave(msg scopeLeaveMsg) tea.Cmd {
+	if msg.gen != m.scopeGen {
+		return nil
+	}
+
+	if msg.staged == 0 {
+		return m.switchScope(msg.next, msg.status)
+	}
+
+	m.pendingScope = &pendingScope{scope: msg.next, status: msg.status}
+
+	items := []dialogs.PickerItem{
+		{Value: "", Label: "Stay"},
+		{Value: leaveScopeConfirm, Label: "Switch anyway", Detail: "they stay staged in " + m.scopeLabel()},
+	}
+	title := "Leave " + strconv.Itoa(msg.staged) + " staged change(s)?"
+
+	return m.pushDialog(dialogs.NewPicker(m.styles, pickerLeaveScope, title, items, ""), nil)
+}
+
+// confirmLeave applies the staged-changes warning's answer.
+func (m *App) confirmLeave(value string) tea.Cmd {
+	pending := m.pendingScope
+	m.pendingScope = nil
+
+	if pending == nil || value != leaveScopeConfirm {
+		return nil
+	}
+
+	return m.switchScope(pending.scope, pending.status)
+}
+
+// switchScope rebuilds the app for scope, as if relaunched into it: the data
+// seams (a new sourceFactory, so new stores and staging probes), the identity,
+// the tabs (an Azure vault or store may add or drop one) and every page, keeping
+// the active service when the new scope still offers it. The new scope is
+// remembered for the scope picker.
+func (m *App) switchScope(scope provider.Scope, status string) tea.Cmd {
+	next := m.rescope(scope)
+
+	service := ""
+	if m.activeTab < len(m.tabs) {
+		service = m.tabs[m.activeTab].Service
+	}
+
+	m.scope = scope
+	m.scopeGen++
+	m.fetchIdentity = next.fetchIdentity
+	m.sourceFor = next.sourceFor
+	m.mutatorFor = next.mutatorFor
+	m.stagingFor = next.stagingFor
+	m.changesetsFor = next.changesetsFor
+	m.identity = nil
+	m.identityLoading = scope.Provider == provider.ProviderAWS && m.fetchIdentity != nil
+	m.stagedCounts = map[string]int{}
+	m.tabs = buildTabs(scope, m.caps)
+	m.activeTab = initialTabIndex(m.tabs, service)
+	m.refreshStagingTab()
+
+	var pageCmd tea.Cmd
+
+	if len(m.tabs) > 0 {
+		var p page
+
+		p, pageCmd = m.pageForTab(m.activeTab)
+		m.pages = []page{p}
+	} else {
+		m.pages = []page{newPlaceholderPage(m.styles, "", "no services available for this scope")}
+	}
+
+	m.forwardResizeToTop()
+
+	m.status = status
+
+	recents := m.recents
+	cmds := []tea.Cmd{pageCmd, func() tea.Msg {
+		recents.remember(scope)
+
+		return nil
+	}}
+
+	if m.identityLoading {
+		cmds = append(cmds, m.fetchIdentityCmd())
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// scopeLabel renders the switchable part of the scope for the tab bar and the
+// staged-changes warning ("region us-west-2", "vault kv · store cfg"). An AWS
+// scope without a picked region shows the identity's.
+func (m *App) scopeLabel() string {
+	var parts []string
+
+	switch m.scope.Provider {
+	case provider.ProviderAWS:
+		region := m.scope.Region
+		if region == "" && m.identity != nil {
+			region = m.identity.Region
+		}
+
+		parts = appendKV(parts, axisName(axisRegion), region)
+	case provider.ProviderGoogleCloud:
+		parts = appendKV(parts, axisName(axisProject), m.scope.ProjectID)
+	case provider.ProviderAzure:
+		parts = appendKV(parts, axisName(axisVault), m.scope.VaultName)
+		parts = appendKV(parts, axisName(axisStore), m.scope.StoreName)
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// axisName is an axis's short name ("region").
+func axisName(axis string) string {
+	switch axis {
+	case axisRegion:
+		return "region"
+	case axisProject:
+		return "project"
+	case axisVault:
+		return "vault"
+	case axisStore:
+		return "store"
+	default:
+		return axis
+	}
+}
+
+// axisTitle names an axis in its picker's title.
+func axisTitle(axis string) string {
+	switch axis {
+	case axisRegion:
+		return "AWS region"
+	case axisProject:
+		return "Google Cloud project"
+	case axisVault:
+		return "Key Vault"
+	case axisStore:
+		return "App Configuration store"
+	default:
+		return axis
+	}
+}
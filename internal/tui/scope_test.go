@@ -0,0 +1,174 @@
+//nolint:testpackage // white-box: drives the scope picker through the app shell and inspects its scope
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/tui/data"
+	"github.com/mpyw/suve/internal/tui/dialogs"
+)
+
+// scopeApp builds an app whose rescope seam records the scopes it is asked for,
+// with a recent-scopes history in a temp dir. staged is what each service's
+// staging probe in the launch scope reports.
+func scopeApp(t *testing.T, scope provider.Scope, staged int, rescoped *[]provider.Scope) *App {
+	t.Helper()
+
+	return newApp(config{
+		scope: scope,
+		sourceFor: func(string) (data.Source, data.StagingProbe) {
+			return nil, staticProbe{entryCount: staged}
+		},
+		rescope: func(next provider.Scope) config {
+			*rescoped = append(*rescoped, next)
+
+			return config{}
+		},
+		regions: func() []string { return []string{"us-east-1", "us-west-2"} },
+		recents: &recentScopes{path: filepath.Join(t.TempDir(), recentFileName)},
+	})
+}
+
+// runCmds runs cmd, expanding batches, and feeds every resulting message back
+// into the app.
+func runCmds(t *testing.T, m *App, cmd tea.Cmd) *App {
+	t.Helper()
+
+	if cmd == nil {
+		return m
+	}
+
+	switch msg := cmd().(type) {
+	case nil:
+	case tea.BatchMsg:
+		for _, c := range msg {
+			m = runCmds(t, m, c)
+		}
+	default:
+		next, cmd := m.Update(msg)
+		m, _ = next.(*App)
+		m = runCmds(t, m, cmd)
+	}
+
+	return m
+}
+
+// pick confirms a picker choice and runs what it starts.
+func pick(t *testing.T, m *App, picker, value string) *App {
+	t.Helper()
+
+	next, cmd := m.Update(dialogs.PickedMsg{Picker: picker, Value: value})
+	m, _ = next.(*App)
+
+	return runCmds(t, m, cmd)
+}
+
+// TestScopeSwitch_GoogleCloud pins that S offers the current and recent
+// projects, a typed project switches the scope (rebuilding the seams) and is
+// remembered, and the tab bar shows the active project.
+func TestScopeSwitch_GoogleCloud(t *testing.T) {
+	t.Parallel()
+
+	var rescoped []provider.Scope
+
+	m := scopeApp(t, provider.GoogleCloudScope("dev-1"), 0, &rescoped)
+	m.recents.remember(provider.GoogleCloudScope("old-9"))
+	assert.Contains(t, m.tabBar().View(120), "project dev-1")
+
+	m = updateApp(t, m, keyPress('S'))
+	require.Len(t, m.dialogs, 1, "S opens the scope picker")
+	assert.Contains(t, m.dialogs[0].View(), "dev-1")
+	assert.Contains(t, m.dialogs[0].View(), "old-9")
+
+	m.popDialog()
+	m = pick(t, m, pickerScopePrefix+axisProject, "prod-2")
+
+	require.Len(t, rescoped, 1)
+	assert.Equal(t, "prod-2", rescoped[0].ProjectID)
+	assert.Equal(t, "prod-2", m.scope.ProjectID)
+	assert.Equal(t, "switched to project prod-2", m.status)
+	assert.Contains(t, m.tabBar().View(120), "project prod-2")
+	assert.Equal(t, []string{"prod-2", "old-9"}, m.recents.list(axisProject))
+
+	assert.Nil(t, m.switchAxis(axisProject, "prod-2"), "the current project is a no-op")
+}
+
+// TestScopeSwitch_StagedWarning pins that leaving a scope with staged changes
+// asks first: staying keeps the scope, switching anyway moves on.
+func TestScopeSwitch_StagedWarning(t *testing.T) {
+	t.Parallel()
+
+	var rescoped []provider.Scope
+
+	m := scopeApp(t, provider.Scope{Provider: provider.ProviderAWS}, 2, &rescoped)
+	m = pick(t, m, pickerScopePrefix+axisRegion, "us-west-2")
+
+	require.Len(t, m.dialogs, 1, "staged changes hold the switch behind a warning")
+	assert.Contains(t, m.dialogs[0].View(), "Leave 4 staged change(s)?", "both services' changes are counted")
+	assert.Empty(t, rescoped)
+
+	m.popDialog()
+	m = pick(t, m, pickerLeaveScope, "")
+	assert.Empty(t, rescoped, "staying keeps the scope")
+	assert.Empty(t, m.scope.Region)
+
+	m = pick(t, m, pickerScopePrefix+axisRegion, "us-west-2")
+	m.popDialog()
+	m = pick(t, m, pickerLeaveScope, leaveScopeConfirm)
+
+	require.Len(t, rescoped, 1)
+	assert.Equal(t, "us-west-2", m.scope.Region)
+	assert.Equal(t, "switched to region us-west-2", m.status)
+}
+
+// TestScopeSwitch_AzureTabs pins that Azure asks which axis to switch and that
+// picking a store adds the App Configuration tab, keeping the active service.
+func TestScopeSwitch_AzureTabs(t *testing.T) {
+	t.Parallel()
+
+	var rescoped []provider.Scope
+
+	m := scopeApp(t, provider.AzureKeyVaultScope("kv-dev"), 0, &rescoped)
+	require.Len(t, m.tabs, 2, "Key Vault and Staging")
+
+	m = updateApp(t, m, keyPress('S'))
+	require.Len(t, m.dialogs, 1)
+	assert.Contains(t, m.dialogs[0].View(), "App Configuration")
+
+	m.popDialog()
+	m = pick(t, m, pickerScopeAxis, axisStore)
+	require.Len(t, m.dialogs, 1, "the axis opens its value picker")
+
+	m.popDialog()
+	m = pick(t, m, pickerScopePrefix+axisStore, "cfg-dev")
+
+	assert.Equal(t, "cfg-dev", m.scope.StoreName)
+	assert.Equal(t, "kv-dev", m.scope.VaultName)
+	assert.Len(t, m.tabs, 3, "App Configuration, Key Vault and Staging")
+	assert.Equal(t, serviceSecret, m.tabs[m.activeTab].Service, "the active service is kept")
+	assert.Contains(t, m.tabBar().View(120), "vault kv-dev · store cfg-dev")
+}
+
+// TestScopeSwitch_Disabled pins that S does nothing for a provider without a
+// switchable axis or without the rescope seam, and the tab bar shows no scope.
+func TestScopeSwitch_Disabled(t *testing.T) {
+	t.Parallel()
+
+	m := newApp(config{
+		scope:   provider.VaultScope("http://127.0.0.1:8200", "secret"),
+		rescope: func(provider.Scope) config { return config{} },
+	})
+	m = updateApp(t, m, keyPress('S'))
+	assert.Empty(t, m.dialogs)
+
+	m = newApp(config{scope: provider.GoogleCloudScope("dev-1")})
+	m = updateApp(t, m, keyPress('S'))
+	assert.Empty(t, m.dialogs)
+	assert.NotContains(t, m.tabBar().View(120), "project")
+}
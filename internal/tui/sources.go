@@ -2,10 +2,12 @@ package tui
 
 import (
 	"context"
+	"slices"
 	"sync"
 
 	"github.com/mpyw/suve/internal/capability"
 	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/aws"
 	"github.com/mpyw/suve/internal/provider/aws/infra"
 	"github.com/mpyw/suve/internal/staging"
 	"github.com/mpyw/suve/internal/staging/store"
@@ -22,6 +24,8 @@ import (
 type sourceFactory struct {
 	ctx   context.Context //nolint:containedctx // the TUI resolves stores lazily against the Run context
 	scope provider.Scope
+	// caps is the capability matrix services are looked up in (see matrix).
+	caps []capability.ProviderCapability
 
 	// resolveAWSIdentity resolves the AWS caller identity that keys the AWS staging
 	// scope. It is a field so tests can substitute a call-counting stub; production
@@ -31,17 +35,19 @@ type sourceFactory struct {
 	mu            sync.Mutex
 	stagingStores map[string]store.ReadWriteOperator
 	// awsStagingScope memoizes the AWS staging scope resolved from the STS caller
-	// identity. The launched provider/scope are fixed for the process lifetime, so
-	// the identity is resolved once — not on every staging-store access. A
+	// identity. The factory's scope is fixed (a scope switch builds a new
+	// factory), so the identity is resolved once — not on every staging-store access. A
 	// transient STS failure is not cached, so it retries on the next access.
 	awsStagingScope *provider.Scope
 }
 
-// newSourceFactory builds a factory for a launched scope and Run context.
-func newSourceFactory(ctx context.Context, scope provider.Scope) *sourceFactory {
+// newSourceFactory builds a factory for a launched scope, its capability matrix
+// (nil for capability.All()) and Run context.
+func newSourceFactory(ctx context.Context, scope provider.Scope, caps []capability.ProviderCapability) *sourceFactory {
 	return &sourceFactory{
 		ctx:                ctx,
 		scope:              scope,
+		caps:               caps,
 		resolveAWSIdentity: infra.GetAWSIdentity,
 		stagingStores:      map[string]store.ReadWriteOperator{},
 	}
@@ -50,7 +56,7 @@ func newSourceFactory(ctx context.Context, scope provider.Scope) *sourceFactory
 // sourceFor returns the read source and (best-effort) staging probe for a
 // service tab, or (nil, nil) when the service is unavailable for the scope.
 func (f *sourceFactory) sourceFor(service string) (data.Source, data.StagingProbe) {
-	svcCap, ok := capabilityFor(f.scope.Provider, service)
+	svcCap, ok := capabilityFor(f.caps, f.scope.Provider, service)
 	if !ok {
 		return nil, nil
 	}
@@ -58,6 +64,11 @@ func (f *sourceFactory) sourceFor(service string) (data.Source, data.StagingProb
 	switch service {
 	case string(staging.ServiceParam):
 		src := data.NewParamSource(svcCap, f.paramResolver())
+		if svcCap.HasNamespaces {
+			// Azure App Configuration: Key Vault references resolve through the
+			// same registry-backed Key Vault adapter the secret tab uses.
+			src = data.NewAppConfigSource(svcCap, f.paramResolver(), f.keyVaultReader)
+		}
 
 		return src, f.stagingProbe(provider.KindParam, service)
 	case string(staging.ServiceSecret):
@@ -72,12 +83,18 @@ func (f *sourceFactory) sourceFor(service string) (data.Source, data.StagingProb
 	}
 }
 
+// keyVaultReader resolves the Key Vault secret reader a Key Vault reference
+// points into, independent of the launched scope's vault.
+func (f *sourceFactory) keyVaultReader(ctx context.Context, vaultName string) (provider.Reader, error) {
+	return registry.Store(ctx, provider.AzureKeyVaultScope(vaultName), provider.KindSecret)
+}
+
 // mutatorFor returns the write-path Mutator for a service tab, or nil when the
 // service is unavailable for the scope. It pairs the immediate param/secret use
 // cases with the staged-write strategy and the per-scope-cached staging store,
 // mirroring the GUI's serviceStrategyScoped discipline.
 func (f *sourceFactory) mutatorFor(service string) data.Mutator {
-	svcCap, ok := capabilityFor(f.scope.Provider, service)
+	svcCap, ok := capabilityFor(f.caps, f.scope.Provider, service)
 	if !ok {
 		return nil
 	}
@@ -103,7 +120,7 @@ func (f *sourceFactory) mutatorFor(service string) data.Mutator {
 // never touches the keychain/registry on the update loop; a key-loss surfaces as
 // the review's error.
 func (f *sourceFactory) stagingService(service string) data.StagingService {
-	svcCap, ok := capabilityFor(f.scope.Provider, service)
+	svcCap, ok := capabilityFor(f.caps, f.scope.Provider, service)
 	if !ok || !svcCap.HasStaging {
 		return nil
 	}
@@ -188,20 +205,24 @@ func (f *sourceFactory) stagingStoreResolver(svcCap capability.ServiceCapability
 }
 
 // paramStrategyBuilder builds the provider-specific param staging strategy over a
-// resolved store (Azure App Configuration vs AWS SSM), mirroring the GUI's
-// serviceStrategyScoped.
+// resolved store (Azure App Configuration / Kubernetes ConfigMaps / AWS SSM),
+// mirroring the GUI's serviceStrategyScoped.
 func (f *sourceFactory) paramStrategyBuilder() data.StrategyBuilder {
 	return func(s provider.Store) staging.FullStrategy {
-		if f.scope.Provider == provider.ProviderAzure {
+		switch f.scope.Provider {
+		case provider.ProviderAzure:
 			return staging.NewAzureAppConfigParamStrategy(s)
+		case provider.ProviderKubernetes:
+			return staging.NewKubernetesParamStrategy(s)
+		default:
+			return staging.NewAWSParamStrategy(s)
 		}
-
-		return staging.NewAWSParamStrategy(s)
 	}
 }
 
 // secretStrategyBuilder builds the provider-specific secret staging strategy over
-// a resolved store (Google Cloud / Azure Key Vault / AWS Secrets Manager).
+// a resolved store (Google Cloud / Azure Key Vault / Vault KV / Kubernetes
+// Secrets / SOPS files / AWS Secrets Manager).
 func (f *sourceFactory) secretStrategyBuilder() data.StrategyBuilder {
 	return func(s provider.Store) staging.FullStrategy {
 		switch f.scope.Provider {
@@ -209,6 +230,12 @@ func (f *sourceFactory) secretStrategyBuilder() data.StrategyBuilder {
 			return staging.NewGoogleCloudSecretStrategy(s)
 		case provider.ProviderAzure:
 			return staging.NewAzureKeyVaultSecretStrategy(s)
+		case provider.ProviderVault:
+			return staging.NewVaultSecretStrategy(s)
+		case provider.ProviderKubernetes:
+			return staging.NewKubernetesSecretStrategy(s)
+		case provider.ProviderSOPS:
+			return staging.NewSOPSStrategy(s)
 		default:
 			return staging.NewAWSSecretStrategy(s)
 		}
@@ -233,7 +260,7 @@ func (f *sourceFactory) paramResolver() data.StoreResolver {
 // the service has no staging workflow. Building the on-disk store (which may
 // touch the keychain) is deferred to the first probe, off the update loop.
 func (f *sourceFactory) stagingProbe(kind provider.Kind, service string) data.StagingProbe {
-	svcCap, ok := capabilityFor(f.scope.Provider, service)
+	svcCap, ok := capabilityFor(f.caps, f.scope.Provider, service)
 	if !ok || !svcCap.HasStaging {
 		return nil
 	}
@@ -262,7 +289,14 @@ func (f *sourceFactory) stagingStore(kind provider.Kind) (store.ReadWriteOperato
 		return nil, err
 	}
 
-	key := scope.Key()
+	// The active changeset is part of the key, so a switch (from the CLI or the
+	// changeset selector) opens the newly active store on the next access.
+	changeset, err := file.ActiveChangeset(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	key := scope.Key() + "@" + changeset
 
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -271,7 +305,7 @@ func (f *sourceFactory) stagingStore(kind provider.Kind) (store.ReadWriteOperato
 		return s, nil
 	}
 
-	s, err := file.NewWorkingStore(scope)
+	s, err := file.NewChangesetStore(scope, changeset)
 	if err != nil {
 		return nil, err
 	}
@@ -281,6 +315,46 @@ func (f *sourceFactory) stagingStore(kind provider.Kind) (store.ReadWriteOperato
 	return s, nil
 }
 
+// changesets returns the changeset-selector seam over the staging scopes of the
+// offered services, or nil when none of them has a staging workflow.
+func (f *sourceFactory) changesets(services []string) data.Changesets {
+	var kinds []provider.Kind
+
+	for _, service := range services {
+		svcCap, ok := capabilityFor(f.caps, f.scope.Provider, service)
+		if !ok || !svcCap.HasStaging {
+			continue
+		}
+
+		if service == string(staging.ServiceSecret) {
+			kinds = append(kinds, provider.KindSecret)
+		} else {
+			kinds = append(kinds, provider.KindParam)
+		}
+	}
+
+	if len(kinds) == 0 {
+		return nil
+	}
+
+	return data.NewChangesets(func() ([]provider.Scope, error) {
+		var scopes []provider.Scope
+
+		for _, kind := range kinds {
+			scope, err := f.stagingScope(kind)
+			if err != nil {
+				return nil, err
+			}
+
+			if !slices.ContainsFunc(scopes, func(s provider.Scope) bool { return s.Key() == scope.Key() }) {
+				scopes = append(scopes, scope)
+			}
+		}
+
+		return scopes, nil
+	})
+}
+
 // stagingScope resolves the service-specific scope that keys staging state,
 // mirroring the GUI's stagingScopeForKind: Azure's two services live in separate
 // buckets, and AWS is keyed by the STS caller identity.
@@ -308,9 +382,10 @@ func (f *sourceFactory) stagingScope(kind provider.Kind) (provider.Scope, error)
 }
 
 // resolveAWSStagingScope resolves (and memoizes) the AWS staging scope from the
-// STS caller identity. Because the launched provider/scope are fixed for the
-// process lifetime, the identity is resolved once and reused across every
-// staging-store access rather than issuing a fresh GetCallerIdentity per probe.
+// STS caller identity and the scope's profile and region. A factory serves one
+// scope (a profile or region switch builds a new one), so the identity is
+// resolved once and reused across every staging-store access rather than
+// issuing a fresh GetCallerIdentity per probe.
 // Only a successful resolution is cached, so a transient STS failure retries.
 func (f *sourceFactory) resolveAWSStagingScope() (provider.Scope, error) {
 	f.mu.Lock()
@@ -320,12 +395,19 @@ func (f *sourceFactory) resolveAWSStagingScope() (provider.Scope, error) {
 		return *f.awsStagingScope, nil
 	}
 
-	identity, err := f.resolveAWSIdentity(f.ctx)
+	identity, err := f.resolveAWSIdentity(aws.WithScope(f.ctx, f.scope))
 	if err != nil {
 		return provider.Scope{}, err
 	}
 
-	scope := provider.AWSScope(identity.AccountID, identity.Region)
+	// A region picked in the app overrides the profile's own.
+	region := identity.Region
+	if f.scope.Region != "" {
+		region = f.scope.Region
+	}
+
+	scope := provider.AWSScope(identity.AccountID, region)
+	scope.AWSProfile = f.scope.AWSProfile
 	f.awsStagingScope = &scope
 
 	return scope, nil
@@ -350,9 +432,9 @@ func (p *lazyStagingProbe) Staged(ctx context.Context) (data.StagingSnapshot, er
 }
 
 // capabilityFor looks up the ServiceCapability for a provider+service in the
-// neutral matrix, so every gate reads one source of truth.
-func capabilityFor(prov provider.Provider, service string) (capability.ServiceCapability, bool) {
-	for _, pc := range capability.All() {
+// neutral matrix caps (see matrix), so every gate reads one source of truth.
+func capabilityFor(caps []capability.ProviderCapability, prov provider.Provider, service string) (capability.ServiceCapability, bool) {
+	for _, pc := range matrix(caps) {
 		if pc.Provider != string(prov) {
 			continue
 		}
@@ -372,17 +454,26 @@ func capabilityFor(prov provider.Provider, service string) (capability.ServiceCa
 func parserFor(prov provider.Provider, service string) (staging.Parser, error) {
 	switch service {
 	case string(staging.ServiceParam):
-		if prov == provider.ProviderAzure {
+		switch prov {
+		case provider.ProviderAzure:
 			return &staging.AzureAppConfigParamStrategy{}, nil
+		case provider.ProviderKubernetes:
+			return staging.KubernetesParamParserFactory(), nil
+		default:
+			return &staging.AWSParamStrategy{}, nil
 		}
-
-		return &staging.AWSParamStrategy{}, nil
 	case string(staging.ServiceSecret):
 		switch prov {
 		case provider.ProviderGoogleCloud:
 			return &staging.GoogleCloudSecretStrategy{}, nil
 		case provider.ProviderAzure:
 			return &staging.AzureKeyVaultSecretStrategy{}, nil
+		case provider.ProviderVault:
+			return &staging.VaultSecretStrategy{}, nil
+		case provider.ProviderKubernetes:
+			return staging.KubernetesSecretParserFactory(), nil
+		case provider.ProviderSOPS:
+			return staging.SOPSParserFactory(), nil
 		default:
 			return &staging.AWSSecretStrategy{}, nil
 		}
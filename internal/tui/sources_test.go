@@ -22,7 +22,7 @@ func TestStagingScope_AWSIdentityMemoized(t *testing.T) {
 
 	var calls int
 
-	f := newSourceFactory(t.Context(), provider.Scope{Provider: provider.ProviderAWS})
+	f := newSourceFactory(t.Context(), provider.Scope{Provider: provider.ProviderAWS}, nil)
 	f.resolveAWSIdentity = func(context.Context) (*infra.AWSIdentity, error) {
 		calls++
 
@@ -51,7 +51,7 @@ func TestStagingScope_AWSIdentityErrorNotCached(t *testing.T) {
 
 	var calls int
 
-	f := newSourceFactory(t.Context(), provider.Scope{Provider: provider.ProviderAWS})
+	f := newSourceFactory(t.Context(), provider.Scope{Provider: provider.ProviderAWS}, nil)
 	f.resolveAWSIdentity = func(context.Context) (*infra.AWSIdentity, error) {
 		calls++
 		if calls == 1 {
@@ -82,7 +82,7 @@ func TestStagingScope_AWSPrehydratedSkipsResolution(t *testing.T) {
 	var calls int
 
 	scope := provider.AWSScope("999999999999", "eu-west-1")
-	f := newSourceFactory(t.Context(), scope)
+	f := newSourceFactory(t.Context(), scope, nil)
 	f.resolveAWSIdentity = func(context.Context) (*infra.AWSIdentity, error) {
 		calls++
 
@@ -59,13 +59,20 @@ func (s *goldenStaging) Review(context.Context) (data.StagingReview, error) {
 	return s.review, nil
 }
 
-func (s *goldenStaging) Apply(context.Context, bool) (data.StagingApplyResult, error) {
+func (s *goldenStaging) Apply(context.Context, bool, []data.StagedKey) (data.StagingApplyResult, error) {
 	return s.applyResult, nil
 }
 
-func (s *goldenStaging) Reset(context.Context) (data.StagingResetResult, error) {
+func (s *goldenStaging) Reset(context.Context, []data.StagedKey) (data.StagingResetResult, error) {
 	return data.StagingResetResult{}, nil
 }
+func (s *goldenStaging) Resolve(context.Context) (data.StagingResolveResult, error) {
+	return data.StagingResolveResult{}, nil
+}
+
+func (s *goldenStaging) ResolveEdited(context.Context, data.StagedKey, string, string) (data.StagingResolveResult, error) {
+	return data.StagingResolveResult{}, nil
+}
 func (s *goldenStaging) Unstage(context.Context, data.StagedKey) error              { return nil }
 func (s *goldenStaging) CancelAddTag(context.Context, data.StagedKey, string) error { return nil }
 func (s *goldenStaging) CancelRemoveTag(context.Context, data.StagedKey, string) error {
@@ -543,7 +550,7 @@ func TestStaging_RoundTrip(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, before.EntryCount(), "the staged create is present before apply")
 
-	res, err := svc.Apply(ctx, false)
+	res, err := svc.Apply(ctx, false, nil)
 	require.NoError(t, err)
 	require.Empty(t, res.Conflicts, "no conflict for a create")
 
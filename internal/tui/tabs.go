@@ -16,17 +16,17 @@ const (
 )
 
 // buildTabs derives the tab bar for a launched scope from the neutral
-// capability matrix. It filters capability.All() to the scope's provider, gates
+// capability matrix caps (see matrix). It filters it to the scope's provider, gates
 // each service on scope presence (Azure Key Vault needs a vault name, App
 // Configuration needs a store name — via provider.Scope.SupportsService), and
 // appends a Staging tab when any offered service supports staging.
-func buildTabs(scope provider.Scope) []components.Tab {
+func buildTabs(scope provider.Scope, caps []capability.ProviderCapability) []components.Tab {
 	var (
 		tabs       []components.Tab
 		hasStaging bool
 	)
 
-	for _, pc := range capability.All() {
+	for _, pc := range matrix(caps) {
 		if pc.Provider != string(scope.Provider) {
 			continue
 		}
@@ -51,6 +51,16 @@ func buildTabs(scope provider.Scope) []components.Tab {
 	return tabs
 }
 
+// matrix returns caps, or capability.All() when caps is nil: only a plugin
+// launch, whose capability comes from its handshake, extends the static matrix.
+func matrix(caps []capability.ProviderCapability) []capability.ProviderCapability {
+	if caps == nil {
+		return capability.All()
+	}
+
+	return caps
+}
+
 // serviceAvailable reports whether a capability service is reachable for the
 // scope, applying Azure's per-service scope gating through the neutral
 // SupportsService seam. Non-Azure providers list only services they support, so
@@ -0,0 +1,30 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// AttributesInput holds input for the attributes use case.
+type AttributesInput struct {
+	Name       string
+	Attributes provider.SecretAttributes
+}
+
+// AttributesUseCase updates a Key Vault secret's attributes (content type,
+// activation window, enabled flag) in place, without adding a version.
+type AttributesUseCase struct {
+	Updater provider.AttributeUpdater
+}
+
+// Execute runs the attributes use case. A missing secret surfaces as the
+// provider's wrapped provider.ErrNotFound.
+func (u *AttributesUseCase) Execute(ctx context.Context, input AttributesInput) error {
+	if err := u.Updater.UpdateAttributes(ctx, input.Name, input.Attributes); err != nil {
+		return fmt.Errorf("failed to update attributes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package azure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/azure/keyvault"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/usecase/azure"
+)
+
+type expiryListerMock struct {
+	expiries []keyvault.SecretExpiry
+}
+
+func (m *expiryListerMock) ListExpiries(_ context.Context) ([]keyvault.SecretExpiry, error) {
+	return m.expiries, nil
+}
+
+type attributeUpdaterMock struct {
+	name  string
+	attrs provider.SecretAttributes
+}
+
+func (m *attributeUpdaterMock) UpdateAttributes(_ context.Context, name string, attrs provider.SecretAttributes) error {
+	m.name, m.attrs = name, attrs
+
+	return nil
+}
+
+func TestListUseCase_ExpiringWithin(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	expired := now.Add(-time.Hour)
+	soon := now.Add(5 * 24 * time.Hour)
+	later := now.Add(60 * 24 * time.Hour)
+
+	lister := &expiryListerMock{expiries: []keyvault.SecretExpiry{
+		{Name: "later", Expires: &later},
+		{Name: "soon", Expires: &soon},
+		{Name: "never"},
+		{Name: "expired", Expires: &expired},
+	}}
+
+	uc := &azure.ListUseCase{Reader: &providermock.Store{}, Expiries: lister}
+	out, err := uc.Execute(t.Context(), azure.ListInput{ExpiringWithin: lo.ToPtr(30 * 24 * time.Hour)})
+	require.NoError(t, err)
+
+	assert.Equal(t, []azure.ListEntry{
+		{Name: "expired", Expires: &expired},
+		{Name: "soon", Expires: &soon},
+	}, out.Entries)
+}
+
+func TestListUseCase_ExpiringWithinUnsupported(t *testing.T) {
+	t.Parallel()
+
+	uc := &azure.ListUseCase{Reader: &providermock.Store{}}
+	_, err := uc.Execute(t.Context(), azure.ListInput{ExpiringWithin: lo.ToPtr(time.Duration(0))})
+	require.ErrorIs(t, err, azure.ErrExpiryUnsupported)
+}
+
+func TestCreateUseCase_PassesOptions(t *testing.T) {
+	t.Parallel()
+
+	attrs := provider.SecretAttributes{ContentType: lo.ToPtr("text/plain")}
+
+	store := &providermock.Store{
+		CreateFunc: func(
+			_ context.Context, _, _ string, _ domain.ValueType, _ string, opts ...provider.WriteOption,
+		) (domain.Version, error) {
+			assert.Equal(t, []provider.WriteOption{attrs}, opts)
+
+			return domain.Version{ID: "v1"}, nil
+		},
+	}
+
+	uc := &azure.CreateUseCase{Writer: store}
+	_, err := uc.Execute(t.Context(), azure.CreateInput{Name: "s", Value: "x", Options: []provider.WriteOption{attrs}})
+	require.NoError(t, err)
+}
+
+func TestAttributesUseCase(t *testing.T) {
+	t.Parallel()
+
+	exp := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	updater := &attributeUpdaterMock{}
+
+	uc := &azure.AttributesUseCase{Updater: updater}
+	err := uc.Execute(t.Context(), azure.AttributesInput{Name: "s", Attributes: provider.SecretAttributes{Expires: &exp}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "s", updater.name)
+	assert.Equal(t, &exp, updater.attrs.Expires)
+}
+
+func TestShowUseCase_Attributes(t *testing.T) {
+	t.Parallel()
+
+	nbf := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	exp := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store := &providermock.Store{
+		ResolveFunc: func(_ context.Context, _, _ string) (provider.VersionRef, error) {
+			return provider.VersionRef{}, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{
+				Name:    name,
+				Version: domain.Version{ID: "v1", NotBefore: &nbf, Expires: &exp},
+				Extra:   []domain.Field{{Label: keyvault.ContentTypeField, Value: "application/json"}},
+			}, nil
+		},
+	}
+
+	out, err := (&azure.ShowUseCase{Reader: store}).Execute(t.Context(), azure.ShowInput{Name: "s"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/json", out.ContentType)
+	assert.Equal(t, &nbf, out.NotBefore)
+	assert.Equal(t, &exp, out.Expires)
+}
@@ -12,7 +12,10 @@ import (
 type CreateInput struct {
 	Name      string
 	Value     string
-	ValueType domain.ValueType // secret (Key Vault) or plaintext (App Configuration)
+	ValueType domain.ValueType // secret (Key Vault), or plaintext / reference (App Configuration)
+	// Options are provider-specific write options (e.g. Key Vault
+	// provider.SecretAttributes), passed through to the writer uninterpreted.
+	Options []provider.WriteOption
 }
 
 // CreateOutput holds the result of the create use case.
@@ -30,7 +33,7 @@ type CreateUseCase struct {
 // the entry already exists the provider returns a wrapped
 // provider.ErrAlreadyExists and no overwrite occurs.
 func (u *CreateUseCase) Execute(ctx context.Context, input CreateInput) (*CreateOutput, error) {
-	version, err := u.Writer.Create(ctx, input.Name, input.Value, input.ValueType, "")
+	version, err := u.Writer.Create(ctx, input.Name, input.Value, input.ValueType, "", input.Options...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create entry: %w", err)
 	}
@@ -0,0 +1,148 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/parallel"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// ErrEnvNameCollision is returned when two keys map to the same environment
+// variable name (e.g. "app/db-host" and "app/db_host").
+var ErrEnvNameCollision = errors.New("keys map to the same environment variable name")
+
+// EnvInput holds input for the env use case.
+type EnvInput struct {
+	// Prefix selects the keys to export and is stripped from each variable name
+	// ("app/" exports app/db/host as DB_HOST).
+	Prefix string
+	// ResolveRefs follows Key Vault references so the secret, not the reference
+	// document, is exported (requires EnvUseCase.Refs).
+	ResolveRefs bool
+}
+
+// EnvVar is one exported environment variable.
+type EnvVar struct {
+	// Name is the environment variable name derived from Key.
+	Name string
+	// Key is the App Configuration key it was exported from.
+	Key string
+	// Value is the setting's value, or the referenced secret when Resolved.
+	Value string
+	// Reference marks a Key Vault reference; Resolved reports whether Value is
+	// the referenced secret (false = the raw reference document).
+	Reference bool
+	Resolved  bool
+}
+
+// EnvOutput holds the exported variables, sorted by name.
+type EnvOutput struct {
+	Vars []EnvVar
+}
+
+// EnvUseCase exports settings as environment variables.
+type EnvUseCase struct {
+	Reader provider.Reader
+	// Refs follows Key Vault references when EnvInput.ResolveRefs is set.
+	Refs *RefResolver
+}
+
+// Execute runs the env use case. Values are read per key in parallel; any read
+// or resolution failure fails the whole export, since a partial environment is
+// worse than none.
+func (u *EnvUseCase) Execute(ctx context.Context, input EnvInput) (*EnvOutput, error) {
+	names, err := u.Reader.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entries: %w", err)
+	}
+
+	keys := lo.Filter(names, func(name string, _ int) bool {
+		return strings.HasPrefix(name, input.Prefix)
+	})
+
+	keyMap := lo.SliceToMap(keys, func(key string) (string, string) { return key, key })
+
+	results := parallel.ExecuteMap(ctx, keyMap, func(ctx context.Context, _ string, key string) (EnvVar, error) {
+		return u.fetch(ctx, key, input)
+	})
+
+	vars := make([]EnvVar, 0, len(results))
+	byName := make(map[string]string, len(results))
+
+	for _, key := range keys {
+		result := results[key]
+		if result.Err != nil {
+			return nil, fmt.Errorf("%s: %w", key, result.Err)
+		}
+
+		v := result.Value
+		if other, dup := byName[v.Name]; dup {
+			return nil, fmt.Errorf("%w: %q and %q both export %s", ErrEnvNameCollision, other, key, v.Name)
+		}
+
+		byName[v.Name] = key
+
+		vars = append(vars, v)
+	}
+
+	slices.SortFunc(vars, func(a, b EnvVar) int { return strings.Compare(a.Name, b.Name) })
+
+	return &EnvOutput{Vars: vars}, nil
+}
+
+// fetch reads one key and, when asked, follows its Key Vault reference.
+func (u *EnvUseCase) fetch(ctx context.Context, key string, input EnvInput) (EnvVar, error) {
+	entry, err := u.Reader.Get(ctx, key, provider.VersionRef{})
+	if err != nil {
+		return EnvVar{}, err
+	}
+
+	v := EnvVar{
+		Name:      EnvName(strings.TrimPrefix(key, input.Prefix)),
+		Key:       key,
+		Value:     entry.Value,
+		Reference: entry.Type == domain.ValueTypeReference,
+	}
+
+	if v.Reference && input.ResolveRefs && u.Refs != nil {
+		resolved, err := u.Refs.Resolve(ctx, entry.Value)
+		if err != nil {
+			return EnvVar{}, err
+		}
+
+		v.Value = resolved.Value
+		v.Resolved = true
+	}
+
+	return v, nil
+}
+
+// EnvName derives an environment variable name from a key: letters are
+// upper-cased, every other character outside [A-Za-z0-9_] becomes "_", and a
+// leading digit is prefixed with "_" so the result is a valid shell name.
+func EnvName(key string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToUpper(key) {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+
+	name := b.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+
+	return name
+}
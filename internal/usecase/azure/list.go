@@ -2,16 +2,19 @@ package azure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/samber/lo"
 
 	"github.com/mpyw/suve/internal/debug"
 	"github.com/mpyw/suve/internal/parallel"
 	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/azure/keyvault"
 )
 
 // ListInput holds input for the list use case.
@@ -19,13 +22,19 @@ type ListInput struct {
 	Prefix    string // Name prefix filter (case-sensitive)
 	Filter    string // Regex filter pattern (client-side)
 	WithValue bool   // Include values
+	// ExpiringWithin keeps only Key Vault secrets whose current version expires
+	// before now+ExpiringWithin (already-expired secrets included); nil lists
+	// every secret. Requires ListUseCase.Expiries.
+	ExpiringWithin *time.Duration
 }
 
 // ListEntry represents a single entry in list output.
 type ListEntry struct {
 	Name  string
 	Value *string // nil when error or not requested
-	Error error
+	// Expires is the current version's expiry, set only under ExpiringWithin.
+	Expires *time.Time
+	Error   error
 }
 
 // ListOutput holds the result of the list use case.
@@ -36,8 +45,20 @@ type ListOutput struct {
 // ListUseCase executes list operations.
 type ListUseCase struct {
 	Reader provider.Reader
+	// Expiries lists secret expiries for ListInput.ExpiringWithin (Key Vault).
+	Expiries ExpiryLister
 }
 
+// ExpiryLister is the Key-Vault-specific listing extension that returns each
+// secret's current-version expiry in one call.
+type ExpiryLister interface {
+	ListExpiries(ctx context.Context) ([]keyvault.SecretExpiry, error)
+}
+
+// ErrExpiryUnsupported is returned when an expiry filter is requested from a
+// store that does not track expiries.
+var ErrExpiryUnsupported = errors.New("this store does not support expiry filtering")
+
 // Execute runs the list use case. The provider returns every name; the name
 // prefix filter and the client-side regex filter are applied here.
 func (u *ListUseCase) Execute(ctx context.Context, input ListInput) (*ListOutput, error) {
@@ -52,9 +73,9 @@ func (u *ListUseCase) Execute(ctx context.Context, input ListInput) (*ListOutput
 		}
 	}
 
-	names, err := u.Reader.List(ctx)
+	names, expiries, err := u.list(ctx, input.ExpiringWithin)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list entries: %w", err)
+		return nil, err
 	}
 
 	filtered := lo.Filter(names, func(name string, _ int) bool {
@@ -78,7 +99,45 @@ func (u *ListUseCase) Execute(ctx context.Context, input ListInput) (*ListOutput
 	// regardless of the provider API's native ordering (#480).
 	slices.Sort(filtered)
 
-	return u.buildOutput(ctx, input.WithValue, filtered), nil
+	output := u.buildOutput(ctx, input.WithValue, filtered)
+
+	for i := range output.Entries {
+		output.Entries[i].Expires = expiries[output.Entries[i].Name]
+	}
+
+	return output, nil
+}
+
+// list returns the entry names, narrowed to secrets expiring within the window
+// (with their expiries) when one is given.
+func (u *ListUseCase) list(ctx context.Context, within *time.Duration) ([]string, map[string]*time.Time, error) {
+	if within == nil {
+		names, err := u.Reader.List(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list entries: %w", err)
+		}
+
+		return names, nil, nil
+	}
+
+	if u.Expiries == nil {
+		return nil, nil, ErrExpiryUnsupported
+	}
+
+	all, err := u.Expiries.ListExpiries(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list entries: %w", err)
+	}
+
+	deadline := time.Now().Add(*within)
+
+	expiring := lo.Filter(all, func(e keyvault.SecretExpiry, _ int) bool {
+		return e.Expires != nil && e.Expires.Before(deadline)
+	})
+
+	return lo.Map(expiring, func(e keyvault.SecretExpiry, _ int) string { return e.Name }),
+		lo.SliceToMap(expiring, func(e keyvault.SecretExpiry) (string, *time.Time) { return e.Name, e.Expires }),
+		nil
 }
 
 // buildOutput creates the output, fetching values in parallel when requested.
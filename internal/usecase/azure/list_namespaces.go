@@ -37,6 +37,8 @@ type ListNamespacesEntry struct {
 	Namespace string
 	Name      string
 	Value     *string
+	// Locked reports that the setting is read-only.
+	Locked bool
 }
 
 // ListNamespacesOutput holds the result of the namespace-aware list use case.
@@ -80,7 +82,7 @@ func (u *ListNamespacesUseCase) Execute(ctx context.Context, input ListNamespace
 			return ListNamespacesEntry{}, false
 		}
 
-		entry := ListNamespacesEntry{Namespace: row.Namespace, Name: row.Key}
+		entry := ListNamespacesEntry{Namespace: row.Namespace, Name: row.Key, Locked: row.Locked}
 		if input.WithValue {
 			entry.Value = lo.ToPtr(row.Value)
 		}
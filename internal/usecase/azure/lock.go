@@ -0,0 +1,50 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+)
+
+// Locker is the App-Config-specific read-only lock extension. Only the Azure
+// App Configuration store implements it (Lock/Unlock); callers type-assert the
+// resolved store to reach it.
+type Locker interface {
+	Lock(ctx context.Context, name string) error
+	Unlock(ctx context.Context, name string) error
+}
+
+// LockInput holds input for the lock use case.
+type LockInput struct {
+	Name string
+	// Unlock clears the read-only flag instead of setting it.
+	Unlock bool
+}
+
+// LockOutput holds the result of the lock use case.
+type LockOutput struct {
+	Name string
+	// Locked is the setting's lock state after the operation.
+	Locked bool
+}
+
+// LockUseCase locks or unlocks an App Configuration setting.
+type LockUseCase struct {
+	Locker Locker
+}
+
+// Execute runs the lock use case.
+func (u *LockUseCase) Execute(ctx context.Context, input LockInput) (*LockOutput, error) {
+	if input.Unlock {
+		if err := u.Locker.Unlock(ctx, input.Name); err != nil {
+			return nil, fmt.Errorf("failed to unlock entry: %w", err)
+		}
+
+		return &LockOutput{Name: input.Name}, nil
+	}
+
+	if err := u.Locker.Lock(ctx, input.Name); err != nil {
+		return nil, fmt.Errorf("failed to lock entry: %w", err)
+	}
+
+	return &LockOutput{Name: input.Name, Locked: true}, nil
+}
@@ -29,8 +29,15 @@ type LogEntry struct {
 	CreatedDate *time.Time
 	// Tags attached to THIS version. Key Vault scopes tags per version, so each
 	// version carries its own set.
-	Tags  []domain.Tag
-	Error error // Error from fetching value, if any (e.g. disabled versions)
+	Tags []domain.Tag
+	// NotBefore and Expires are THIS version's activation window (Key Vault),
+	// nil when unset.
+	NotBefore *time.Time
+	Expires   *time.Time
+	// Message is the change message THIS version was written with (`stage
+	// apply -m`), empty when none was recorded.
+	Message string
+	Error   error // Error from fetching value, if any (e.g. disabled versions)
 }
 
 // LogOutput holds the result of the log use case.
@@ -110,6 +117,9 @@ func (u *LogUseCase) Execute(ctx context.Context, input LogInput) (*LogOutput, e
 			Value:       value,
 			CreatedDate: v.Created,
 			Tags:        v.Tags,
+			NotBefore:   v.NotBefore,
+			Expires:     v.Expires,
+			Message:     v.Message,
 			Error:       fetchErr,
 		}
 	})
@@ -0,0 +1,78 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/azure/appconfig"
+)
+
+// VaultReaderFunc returns the Key Vault secret reader for a vault name. The CLI
+// and TUI build it from the provider registry, so a reference is followed
+// through the same Key Vault adapter `suve azure secret show` uses.
+type VaultReaderFunc func(ctx context.Context, vaultName string) (provider.Reader, error)
+
+// ResolvedRef is a followed Key Vault reference: the reference itself and the
+// referenced secret's value.
+type ResolvedRef struct {
+	Ref   *appconfig.KeyVaultRef
+	Value string
+}
+
+// RefResolver follows App Configuration Key Vault references to the secrets
+// they point at. Readers are built once per vault and reused, so resolving many
+// references into one vault (an env export) authenticates once. It is safe for
+// concurrent use.
+type RefResolver struct {
+	Vault VaultReaderFunc
+
+	mu      sync.Mutex
+	readers map[string]provider.Reader
+}
+
+// Resolve parses value as a Key Vault reference document and reads the secret
+// it points at: the pinned version when the URI carries one, else the current.
+func (r *RefResolver) Resolve(ctx context.Context, value string) (*ResolvedRef, error) {
+	ref, err := appconfig.ParseKeyVaultRef(value)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := r.reader(ctx, ref.VaultName)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := reader.Get(ctx, ref.SecretName, provider.NewVersionRef(ref.Version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve Key Vault reference %s: %w", ref.URI, err)
+	}
+
+	return &ResolvedRef{Ref: ref, Value: entry.Value}, nil
+}
+
+// reader returns the memoized reader for a vault, building it on first use. A
+// failed build is not cached, so a transient error retries on the next call.
+func (r *RefResolver) reader(ctx context.Context, vaultName string) (provider.Reader, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if reader, ok := r.readers[vaultName]; ok {
+		return reader, nil
+	}
+
+	reader, err := r.Vault(ctx, vaultName)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.readers == nil {
+		r.readers = map[string]provider.Reader{}
+	}
+
+	r.readers[vaultName] = reader
+
+	return reader, nil
+}
@@ -0,0 +1,180 @@
+package azure_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/azure/appconfig"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/usecase/azure"
+)
+
+const dbRef = `{"uri":"https://my-vault.vault.azure.net/secrets/db-password"}`
+
+// settingsStore is an App Configuration stand-in holding the given entries.
+func settingsStore(entries ...domain.Entry) *providermock.Store {
+	return &providermock.Store{
+		ResolveFunc: func(context.Context, string, string) (provider.VersionRef, error) {
+			return provider.VersionRef{}, nil
+		},
+		ListFunc: func(context.Context) ([]string, error) {
+			names := make([]string, 0, len(entries))
+			for _, e := range entries {
+				names = append(names, e.Name)
+			}
+
+			return names, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			for _, e := range entries {
+				if e.Name == name {
+					return &e, nil
+				}
+			}
+
+			return nil, provider.ErrNotFound
+		},
+	}
+}
+
+// vaultResolver serves secrets from one fake vault and counts reader builds.
+func vaultResolver(builds *atomic.Int32, secrets map[string]string) *azure.RefResolver {
+	return &azure.RefResolver{
+		Vault: func(_ context.Context, vaultName string) (provider.Reader, error) {
+			builds.Add(1)
+
+			if vaultName != "my-vault" {
+				return nil, errors.New("unknown vault")
+			}
+
+			return &providermock.Store{
+				GetFunc: func(_ context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+					value, ok := secrets[name+"@"+ref.ID()]
+					if !ok {
+						return nil, provider.ErrNotFound
+					}
+
+					return &domain.Entry{Name: name, Value: value}, nil
+				},
+			}, nil
+		},
+	}
+}
+
+func TestRefResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	var builds atomic.Int32
+
+	r := vaultResolver(&builds, map[string]string{"db-password@": "hunter2", "db-password@v1": "old"})
+
+	got, err := r.Resolve(t.Context(), dbRef)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", got.Value)
+	assert.Equal(t, "db-password", got.Ref.SecretName)
+
+	got, err = r.Resolve(t.Context(), `{"uri":"https://my-vault.vault.azure.net/secrets/db-password/v1"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "old", got.Value, "a pinned version is read by id")
+	assert.Equal(t, int32(1), builds.Load(), "the vault reader is memoized")
+
+	_, err = r.Resolve(t.Context(), "not a reference")
+	require.ErrorIs(t, err, appconfig.ErrInvalidKeyVaultRef)
+
+	_, err = r.Resolve(t.Context(), `{"uri":"https://my-vault.vault.azure.net/secrets/missing"}`)
+	require.ErrorIs(t, err, provider.ErrNotFound)
+}
+
+func TestShowUseCase_KeyVaultReference(t *testing.T) {
+	t.Parallel()
+
+	var builds atomic.Int32
+
+	store := settingsStore(domain.Entry{Name: "db", Value: dbRef, Type: domain.ValueTypeReference})
+	uc := &azure.ShowUseCase{Reader: store, Refs: vaultResolver(&builds, map[string]string{"db-password@": "hunter2"})}
+
+	t.Run("unresolved keeps the document", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := uc.Execute(t.Context(), azure.ShowInput{Name: "db"})
+		require.NoError(t, err)
+		assert.Equal(t, dbRef, out.Value)
+		assert.Equal(t, domain.ValueTypeReference, out.Type)
+		assert.False(t, out.Resolved)
+		require.NotNil(t, out.Reference)
+		assert.Equal(t, "my-vault", out.Reference.VaultName)
+	})
+
+	t.Run("resolved returns the secret", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := uc.Execute(t.Context(), azure.ShowInput{Name: "db", ResolveRefs: true})
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", out.Value)
+		assert.True(t, out.Resolved)
+		assert.Equal(t, "https://my-vault.vault.azure.net/secrets/db-password", out.Reference.URI)
+	})
+}
+
+func TestEnvUseCase(t *testing.T) {
+	t.Parallel()
+
+	var builds atomic.Int32
+
+	store := settingsStore(
+		domain.Entry{Name: "app/db-host", Value: "db.internal", Type: domain.ValueTypePlaintext},
+		domain.Entry{Name: "app/db/password", Value: dbRef, Type: domain.ValueTypeReference},
+		domain.Entry{Name: "other/key", Value: "x", Type: domain.ValueTypePlaintext},
+	)
+	refs := vaultResolver(&builds, map[string]string{"db-password@": "hunter2"})
+
+	t.Run("prefix is stripped and references resolved", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := (&azure.EnvUseCase{Reader: store, Refs: refs}).Execute(t.Context(),
+			azure.EnvInput{Prefix: "app/", ResolveRefs: true})
+		require.NoError(t, err)
+		assert.Equal(t, []azure.EnvVar{
+			{Name: "DB_HOST", Key: "app/db-host", Value: "db.internal"},
+			{Name: "DB_PASSWORD", Key: "app/db/password", Value: "hunter2", Reference: true, Resolved: true},
+		}, out.Vars)
+	})
+
+	t.Run("unresolved reference exports the document", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := (&azure.EnvUseCase{Reader: store}).Execute(t.Context(), azure.EnvInput{Prefix: "app/db/"})
+		require.NoError(t, err)
+		assert.Equal(t, []azure.EnvVar{
+			{Name: "PASSWORD", Key: "app/db/password", Value: dbRef, Reference: true},
+		}, out.Vars)
+	})
+
+	t.Run("colliding names fail", func(t *testing.T) {
+		t.Parallel()
+
+		clash := settingsStore(
+			domain.Entry{Name: "a-b", Value: "1"},
+			domain.Entry{Name: "a_b", Value: "2"},
+		)
+
+		_, err := (&azure.EnvUseCase{Reader: clash}).Execute(t.Context(), azure.EnvInput{})
+		require.ErrorIs(t, err, azure.ErrEnvNameCollision)
+	})
+}
+
+func TestEnvName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "DB_HOST", azure.EnvName("db-host"))
+	assert.Equal(t, "APP_DB_HOST", azure.EnvName("app/db.host"))
+	assert.Equal(t, "_1X", azure.EnvName("1x"))
+	assert.Equal(t, "_", azure.EnvName(""))
+}
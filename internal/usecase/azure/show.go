@@ -8,12 +8,17 @@ import (
 
 	"github.com/mpyw/suve/internal/domain"
 	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/azure/appconfig"
+	"github.com/mpyw/suve/internal/provider/azure/keyvault"
 )
 
 // ShowInput holds input for the show use case.
 type ShowInput struct {
 	Name   string
 	Suffix string // reconstructed version suffix ("#id", "~2", or "")
+	// ResolveRefs follows an App Configuration Key Vault reference to the
+	// secret it points at (requires ShowUseCase.Refs).
+	ResolveRefs bool
 }
 
 // ShowTag represents a tag key-value pair.
@@ -30,11 +35,31 @@ type ShowOutput struct {
 	State       string // enabled/disabled (Key Vault, best-effort), may be ""
 	CreatedDate *time.Time
 	Tags        []ShowTag
+	// Type is the entry's value type; ValueTypeReference marks an App
+	// Configuration Key Vault reference.
+	Type domain.ValueType
+	// Reference is the parsed Key Vault reference when the entry is one (nil
+	// otherwise, or when the document does not parse).
+	Reference *appconfig.KeyVaultRef
+	// Resolved reports that Value holds the referenced secret (ResolveRefs)
+	// rather than the reference document, so callers must treat it as secret.
+	Resolved bool
+	// Locked reports that the App Configuration setting is read-only.
+	Locked bool
+	// ContentType is the entry's content type, when it carries one.
+	ContentType string
+	// NotBefore and Expires are the Key Vault secret version's activation
+	// window, nil when unset.
+	NotBefore *time.Time
+	Expires   *time.Time
 }
 
 // ShowUseCase executes show operations.
 type ShowUseCase struct {
 	Reader provider.Reader
+	// Refs follows Key Vault references when ShowInput.ResolveRefs is set; nil
+	// leaves references unresolved.
+	Refs *RefResolver
 }
 
 // Execute runs the show use case. Version resolution (opaque ids and ~shift for
@@ -51,7 +76,7 @@ func (u *ShowUseCase) Execute(ctx context.Context, input ShowInput) (*ShowOutput
 		return nil, err
 	}
 
-	return &ShowOutput{
+	out := &ShowOutput{
 		Name:        entry.Name,
 		Value:       entry.Value,
 		Version:     entry.Version.ID,
@@ -60,5 +85,46 @@ func (u *ShowUseCase) Execute(ctx context.Context, input ShowInput) (*ShowOutput
 		Tags: lo.Map(entry.Tags, func(tag domain.Tag, _ int) ShowTag {
 			return ShowTag{Key: tag.Key, Value: tag.Value}
 		}),
-	}, nil
+		Type:        entry.Type,
+		Locked:      lo.ContainsBy(entry.Extra, isLockedField),
+		ContentType: extraValue(entry, keyvault.ContentTypeField),
+		NotBefore:   entry.Version.NotBefore,
+		Expires:     entry.Version.Expires,
+	}
+
+	if entry.Type != domain.ValueTypeReference {
+		return out, nil
+	}
+
+	if !input.ResolveRefs || u.Refs == nil {
+		// Unresolved: still surface the target when the document parses, but a
+		// malformed reference is shown as-is rather than failing the read.
+		out.Reference, _ = appconfig.ParseKeyVaultRef(entry.Value)
+
+		return out, nil
+	}
+
+	resolved, err := u.Refs.Resolve(ctx, entry.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	out.Reference = resolved.Ref
+	out.Value = resolved.Value
+	out.Resolved = true
+
+	return out, nil
+}
+
+// extraValue returns the value of the display-only Extra field with the given
+// label, or "" when the entry has no such field.
+func extraValue(entry *domain.Entry, label string) string {
+	f, _ := lo.Find(entry.Extra, func(f domain.Field) bool { return f.Label == label })
+
+	return f.Value
+}
+
+// isLockedField reports whether f is the App Configuration read-only marker.
+func isLockedField(f domain.Field) bool {
+	return f.Label == appconfig.LockedField && f.Value == "true"
 }
@@ -0,0 +1,233 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mpyw/suve/internal/provider/azure/appconfig"
+)
+
+// SnapshotManager is the App-Config-specific snapshot extension. Only the Azure
+// App Configuration store implements it; callers type-assert the resolved store
+// to reach it, exactly like NamespaceLister. The neutral provider seam is
+// untouched.
+type SnapshotManager interface {
+	CreateSnapshot(ctx context.Context, name, keyFilter string, retention time.Duration) (*appconfig.Snapshot, error)
+	Snapshot(ctx context.Context, name string) (*appconfig.Snapshot, error)
+	ListSnapshots(ctx context.Context) ([]appconfig.Snapshot, error)
+	SnapshotSettings(ctx context.Context, name string) ([]appconfig.KeyNamespace, error)
+	LiveSettings(ctx context.Context, snap *appconfig.Snapshot) ([]appconfig.KeyNamespace, error)
+}
+
+// ErrSnapshotNameRequired is returned when a snapshot operation is given no name.
+var ErrSnapshotNameRequired = errors.New("snapshot name is required")
+
+// SnapshotCreateInput holds input for the snapshot create use case.
+type SnapshotCreateInput struct {
+	Name string
+	// KeyFilter selects the keys to capture in App Configuration's filter
+	// grammar ("" = all keys, "app/*" = prefix).
+	KeyFilter string
+	// Retention is how long the snapshot is kept once archived (0 = service
+	// default).
+	Retention time.Duration
+}
+
+// SnapshotCreateUseCase creates an App Configuration snapshot.
+type SnapshotCreateUseCase struct {
+	Manager SnapshotManager
+}
+
+// Execute runs the snapshot create use case.
+func (u *SnapshotCreateUseCase) Execute(ctx context.Context, input SnapshotCreateInput) (*appconfig.Snapshot, error) {
+	if input.Name == "" {
+		return nil, ErrSnapshotNameRequired
+	}
+
+	return u.Manager.CreateSnapshot(ctx, input.Name, input.KeyFilter, input.Retention)
+}
+
+// SnapshotListUseCase lists App Configuration snapshots.
+type SnapshotListUseCase struct {
+	Manager SnapshotManager
+}
+
+// Execute runs the snapshot list use case.
+func (u *SnapshotListUseCase) Execute(ctx context.Context) ([]appconfig.Snapshot, error) {
+	return u.Manager.ListSnapshots(ctx)
+}
+
+// SnapshotShowOutput holds a snapshot's metadata and captured key-values.
+type SnapshotShowOutput struct {
+	Snapshot *appconfig.Snapshot
+	Settings []appconfig.KeyNamespace
+}
+
+// SnapshotShowUseCase shows an App Configuration snapshot.
+type SnapshotShowUseCase struct {
+	Manager SnapshotManager
+}
+
+// Execute runs the snapshot show use case.
+func (u *SnapshotShowUseCase) Execute(ctx context.Context, name string) (*SnapshotShowOutput, error) {
+	if name == "" {
+		return nil, ErrSnapshotNameRequired
+	}
+
+	snap, err := u.Manager.Snapshot(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := u.Manager.SnapshotSettings(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SnapshotShowOutput{Snapshot: snap, Settings: settings}, nil
+}
+
+// SnapshotChangeKind classifies one (key, namespace) difference between the
+// old and new side of a snapshot diff.
+type SnapshotChangeKind string
+
+const (
+	// SnapshotChangeAdded marks a key-value present only on the new side.
+	SnapshotChangeAdded SnapshotChangeKind = "added"
+	// SnapshotChangeRemoved marks a key-value present only on the old side.
+	SnapshotChangeRemoved SnapshotChangeKind = "removed"
+	// SnapshotChangeModified marks a key-value whose value or content type
+	// differs.
+	SnapshotChangeModified SnapshotChangeKind = "modified"
+)
+
+// SnapshotChange is one differing (key, namespace). OldValue and
+// OldContentType are nil for an added key-value, and NewValue and
+// NewContentType for a removed one.
+type SnapshotChange struct {
+	Key            string
+	Namespace      string
+	Kind           SnapshotChangeKind
+	OldValue       *string
+	NewValue       *string
+	OldContentType *string
+	NewContentType *string
+}
+
+// SnapshotDiffInput holds input for the snapshot diff use case. Old is the
+// snapshot to compare from; New is a second snapshot, or "" to compare against
+// the live store (restricted to Old's filters).
+type SnapshotDiffInput struct {
+	Old string
+	New string
+}
+
+// SnapshotDiffOutput holds the differences between the two sides, sorted by key
+// then namespace. Unchanged counts the key-values identical on both sides.
+type SnapshotDiffOutput struct {
+	OldLabel  string
+	NewLabel  string
+	Changes   []SnapshotChange
+	Unchanged int
+}
+
+// SnapshotDiffUseCase compares a snapshot with the live store or another
+// snapshot.
+type SnapshotDiffUseCase struct {
+	Manager SnapshotManager
+}
+
+// LiveLabel is the diff-side label used for the live store.
+const LiveLabel = "live"
+
+// Execute runs the snapshot diff use case.
+func (u *SnapshotDiffUseCase) Execute(ctx context.Context, input SnapshotDiffInput) (*SnapshotDiffOutput, error) {
+	if input.Old == "" {
+		return nil, ErrSnapshotNameRequired
+	}
+
+	oldSnap, err := u.Manager.Snapshot(ctx, input.Old)
+	if err != nil {
+		return nil, err
+	}
+
+	oldRows, err := u.Manager.SnapshotSettings(ctx, input.Old)
+	if err != nil {
+		return nil, err
+	}
+
+	newLabel := LiveLabel
+
+	var newRows []appconfig.KeyNamespace
+
+	if input.New == "" {
+		newRows, err = u.Manager.LiveSettings(ctx, oldSnap)
+	} else {
+		newLabel = input.New
+		newRows, err = u.Manager.SnapshotSettings(ctx, input.New)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SnapshotDiffOutput{OldLabel: input.Old, NewLabel: newLabel}
+	out.Changes, out.Unchanged = diffKeyNamespaces(oldRows, newRows)
+
+	return out, nil
+}
+
+// diffKeyNamespaces merges two (key, namespace)-sorted row sets into their
+// differences, preserving the sort order. A row is unchanged only when both its
+// value and its content type match: a Key Vault reference that became plain
+// text keeps its value but is no longer the same setting.
+func diffKeyNamespaces(oldRows, newRows []appconfig.KeyNamespace) ([]SnapshotChange, int) {
+	less := func(a, b appconfig.KeyNamespace) bool {
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+
+		return a.Namespace < b.Namespace
+	}
+
+	var (
+		changes   []SnapshotChange
+		unchanged int
+		i, j      int
+	)
+
+	for i < len(oldRows) || j < len(newRows) {
+		switch {
+		case j >= len(newRows) || (i < len(oldRows) && less(oldRows[i], newRows[j])):
+			o := oldRows[i]
+			changes = append(changes, SnapshotChange{
+				Key: o.Key, Namespace: o.Namespace, Kind: SnapshotChangeRemoved,
+				OldValue: &o.Value, OldContentType: &o.ContentType,
+			})
+			i++
+		case i >= len(oldRows) || less(newRows[j], oldRows[i]):
+			n := newRows[j]
+			changes = append(changes, SnapshotChange{
+				Key: n.Key, Namespace: n.Namespace, Kind: SnapshotChangeAdded,
+				NewValue: &n.Value, NewContentType: &n.ContentType,
+			})
+			j++
+		default:
+			o, n := oldRows[i], newRows[j]
+			if o.Value == n.Value && o.ContentType == n.ContentType {
+				unchanged++
+			} else {
+				changes = append(changes, SnapshotChange{
+					Key: o.Key, Namespace: o.Namespace, Kind: SnapshotChangeModified,
+					OldValue: &o.Value, NewValue: &n.Value, OldContentType: &o.ContentType, NewContentType: &n.ContentType,
+				})
+			}
+
+			i++
+			j++
+		}
+	}
+
+	return changes, unchanged
+}
@@ -0,0 +1,159 @@
+package azure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider/azure/appconfig"
+	"github.com/mpyw/suve/internal/usecase/azure"
+)
+
+// snapshotManagerMock is an in-memory SnapshotManager: snapshots maps a name to
+// its captured rows, and live holds the live store's rows.
+type snapshotManagerMock struct {
+	snapshots map[string][]appconfig.KeyNamespace
+	live      []appconfig.KeyNamespace
+	created   *azure.SnapshotCreateInput
+}
+
+func (m *snapshotManagerMock) CreateSnapshot(
+	_ context.Context, name, keyFilter string, retention time.Duration,
+) (*appconfig.Snapshot, error) {
+	m.created = &azure.SnapshotCreateInput{Name: name, KeyFilter: keyFilter, Retention: retention}
+
+	return &appconfig.Snapshot{Name: name}, nil
+}
+
+func (m *snapshotManagerMock) Snapshot(_ context.Context, name string) (*appconfig.Snapshot, error) {
+	if _, ok := m.snapshots[name]; !ok {
+		return nil, errors.New("snapshot not found")
+	}
+
+	return &appconfig.Snapshot{Name: name, ItemsCount: int64(len(m.snapshots[name]))}, nil
+}
+
+func (m *snapshotManagerMock) ListSnapshots(_ context.Context) ([]appconfig.Snapshot, error) {
+	return lo.Map(lo.Keys(m.snapshots), func(name string, _ int) appconfig.Snapshot {
+		return appconfig.Snapshot{Name: name}
+	}), nil
+}
+
+func (m *snapshotManagerMock) SnapshotSettings(_ context.Context, name string) ([]appconfig.KeyNamespace, error) {
+	rows, ok := m.snapshots[name]
+	if !ok {
+		return nil, errors.New("snapshot not found")
+	}
+
+	return rows, nil
+}
+
+func (m *snapshotManagerMock) LiveSettings(_ context.Context, _ *appconfig.Snapshot) ([]appconfig.KeyNamespace, error) {
+	return m.live, nil
+}
+
+func TestSnapshotCreateUseCase(t *testing.T) {
+	t.Parallel()
+
+	m := &snapshotManagerMock{}
+	uc := &azure.SnapshotCreateUseCase{Manager: m}
+
+	_, err := uc.Execute(t.Context(), azure.SnapshotCreateInput{})
+	require.ErrorIs(t, err, azure.ErrSnapshotNameRequired)
+
+	snap, err := uc.Execute(t.Context(), azure.SnapshotCreateInput{Name: "s", KeyFilter: "app/*", Retention: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, "s", snap.Name)
+	assert.Equal(t, &azure.SnapshotCreateInput{Name: "s", KeyFilter: "app/*", Retention: time.Hour}, m.created)
+}
+
+func TestSnapshotShowUseCase(t *testing.T) {
+	t.Parallel()
+
+	m := &snapshotManagerMock{snapshots: map[string][]appconfig.KeyNamespace{
+		"s": {{Key: "a", Value: "1"}},
+	}}
+
+	out, err := (&azure.SnapshotShowUseCase{Manager: m}).Execute(t.Context(), "s")
+	require.NoError(t, err)
+	assert.Equal(t, "s", out.Snapshot.Name)
+	assert.Equal(t, []appconfig.KeyNamespace{{Key: "a", Value: "1"}}, out.Settings)
+}
+
+func TestSnapshotDiffUseCase(t *testing.T) {
+	t.Parallel()
+
+	const ref = `{"uri":"https://v.vault.azure.net/secrets/s"}`
+
+	snap := []appconfig.KeyNamespace{
+		{Key: "a", Namespace: "", Value: "same"},
+		{Key: "b", Namespace: "", Value: "old"},
+		{Key: "b", Namespace: "dev", Value: "gone"},
+		{Key: "d", Namespace: "", Value: "removed"},
+		{Key: "e", Namespace: "", Value: ref, ContentType: appconfig.KeyVaultRefContentType},
+	}
+	live := []appconfig.KeyNamespace{
+		{Key: "a", Namespace: "", Value: "same"},
+		{Key: "b", Namespace: "", Value: "new"},
+		{Key: "c", Namespace: "", Value: "added", ContentType: "text/plain"},
+		{Key: "e", Namespace: "", Value: ref},
+	}
+
+	m := &snapshotManagerMock{
+		snapshots: map[string][]appconfig.KeyNamespace{"s1": snap, "s2": live},
+		live:      live,
+	}
+
+	want := []azure.SnapshotChange{
+		{
+			Key: "b", Namespace: "", Kind: azure.SnapshotChangeModified,
+			OldValue: lo.ToPtr("old"), NewValue: lo.ToPtr("new"), OldContentType: lo.ToPtr(""), NewContentType: lo.ToPtr(""),
+		},
+		{Key: "b", Namespace: "dev", Kind: azure.SnapshotChangeRemoved, OldValue: lo.ToPtr("gone"), OldContentType: lo.ToPtr("")},
+		{Key: "c", Namespace: "", Kind: azure.SnapshotChangeAdded, NewValue: lo.ToPtr("added"), NewContentType: lo.ToPtr("text/plain")},
+		{Key: "d", Namespace: "", Kind: azure.SnapshotChangeRemoved, OldValue: lo.ToPtr("removed"), OldContentType: lo.ToPtr("")},
+		{
+			Key: "e", Namespace: "", Kind: azure.SnapshotChangeModified, OldValue: lo.ToPtr(ref), NewValue: lo.ToPtr(ref),
+			OldContentType: lo.ToPtr(appconfig.KeyVaultRefContentType), NewContentType: lo.ToPtr(""),
+		},
+	}
+
+	t.Run("against the live store", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := (&azure.SnapshotDiffUseCase{Manager: m}).Execute(t.Context(), azure.SnapshotDiffInput{Old: "s1"})
+		require.NoError(t, err)
+		assert.Equal(t, "s1", out.OldLabel)
+		assert.Equal(t, azure.LiveLabel, out.NewLabel)
+		assert.Equal(t, 1, out.Unchanged)
+		assert.Equal(t, want, out.Changes)
+	})
+
+	t.Run("against another snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := (&azure.SnapshotDiffUseCase{Manager: m}).Execute(t.Context(), azure.SnapshotDiffInput{Old: "s1", New: "s2"})
+		require.NoError(t, err)
+		assert.Equal(t, "s2", out.NewLabel)
+		assert.Equal(t, want, out.Changes)
+	})
+
+	t.Run("missing snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := (&azure.SnapshotDiffUseCase{Manager: m}).Execute(t.Context(), azure.SnapshotDiffInput{Old: "nope"})
+		require.Error(t, err)
+	})
+
+	t.Run("name required", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := (&azure.SnapshotDiffUseCase{Manager: m}).Execute(t.Context(), azure.SnapshotDiffInput{})
+		require.ErrorIs(t, err, azure.ErrSnapshotNameRequired)
+	})
+}
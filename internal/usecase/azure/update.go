@@ -14,6 +14,9 @@ type UpdateInput struct {
 	Name      string
 	Value     string
 	ValueType domain.ValueType // secret (Key Vault) or plaintext (App Configuration)
+	// Options are provider-specific write options (e.g. Key Vault
+	// provider.SecretAttributes), passed through to the writer uninterpreted.
+	Options []provider.WriteOption
 }
 
 // UpdateOutput holds the result of the update use case.
@@ -55,7 +58,7 @@ func (u *UpdateUseCase) Execute(ctx context.Context, input UpdateInput) (*Update
 		return nil, err
 	}
 
-	version, err := u.Store.Put(ctx, input.Name, input.Value, input.ValueType, "")
+	version, err := u.Store.Put(ctx, input.Name, input.Value, input.ValueType, "", input.Options...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update entry: %w", err)
 	}
@@ -27,7 +27,10 @@ type LogEntry struct {
 	State       string // enabled/disabled/destroyed, may be ""
 	Value       string
 	CreatedDate *time.Time
-	Error       error // Error from fetching value, if any (e.g. disabled/destroyed versions)
+	// Message is the change message THIS version was written with (`stage
+	// apply -m`), empty when none was recorded.
+	Message string
+	Error   error // Error from fetching value, if any (e.g. disabled/destroyed versions)
 }
 
 // LogOutput holds the result of the log use case.
@@ -104,6 +107,7 @@ func (u *LogUseCase) Execute(ctx context.Context, input LogInput) (*LogOutput, e
 			State:       v.State,
 			Value:       value,
 			CreatedDate: v.Created,
+			Message:     v.Message,
 			Error:       fetchErr,
 		}
 	})
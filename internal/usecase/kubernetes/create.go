@@ -0,0 +1,39 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// CreateInput holds input for the create use case.
+type CreateInput struct {
+	Name      string
+	Value     string
+	ValueType domain.ValueType // plaintext (ConfigMap) or secret (Secret)
+}
+
+// CreateOutput holds the result of the create use case.
+type CreateOutput struct {
+	Name            string
+	ResourceVersion string
+}
+
+// CreateUseCase executes create operations.
+type CreateUseCase struct {
+	Writer provider.Writer
+}
+
+// Execute runs the create use case. It adds the key to its object (creating the
+// object when needed); if the key already exists the provider returns a wrapped
+// provider.ErrAlreadyExists and no overwrite occurs.
+func (u *CreateUseCase) Execute(ctx context.Context, input CreateInput) (*CreateOutput, error) {
+	version, err := u.Writer.Create(ctx, input.Name, input.Value, input.ValueType, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create entry: %w", err)
+	}
+
+	return &CreateOutput{Name: input.Name, ResourceVersion: version.ID}, nil
+}
@@ -0,0 +1,58 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// DiffInput holds input for the diff use case. Entries are unversioned, so a
+// diff compares two distinct keys.
+type DiffInput struct {
+	Name1 string
+	Name2 string
+}
+
+// DiffOutput holds the result of the diff use case.
+type DiffOutput struct {
+	OldName  string
+	OldValue string
+	NewName  string
+	NewValue string
+}
+
+// DiffUseCase executes diff operations.
+type DiffUseCase struct {
+	Reader provider.Reader
+}
+
+// Execute runs the diff use case.
+func (u *DiffUseCase) Execute(ctx context.Context, input DiffInput) (*DiffOutput, error) {
+	entry1, err := u.get(ctx, input.Name1)
+	if err != nil {
+		return nil, err
+	}
+
+	entry2, err := u.get(ctx, input.Name2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiffOutput{
+		OldName:  entry1.Name,
+		OldValue: entry1.Value,
+		NewName:  entry2.Name,
+		NewValue: entry2.Value,
+	}, nil
+}
+
+// get resolves and fetches the latest (only) value of a key.
+func (u *DiffUseCase) get(ctx context.Context, name string) (*domain.Entry, error) {
+	ref, err := u.Reader.Resolve(ctx, name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return u.Reader.Get(ctx, name, ref)
+}
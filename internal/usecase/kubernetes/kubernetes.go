@@ -0,0 +1,14 @@
+// Package kubernetes provides use cases for Kubernetes ConfigMap (param) and
+// Secret (secret) keys.
+//
+// The use cases are written against the provider-neutral Reader/Writer/Store
+// interfaces and serve both CLI subgroups: the caller picks the store and the
+// value type. Entries are unversioned ("<object>/<key>" only), so the use cases
+// take plain names rather than version specs, and no log use case exists.
+package kubernetes
+
+import "errors"
+
+// ErrEntryNotFound is returned by the update use case when the target key does
+// not exist.
+var ErrEntryNotFound = errors.New("entry not found")
@@ -0,0 +1,161 @@
+package kubernetes_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/usecase/kubernetes"
+)
+
+func TestShowUseCase(t *testing.T) {
+	t.Parallel()
+
+	store := &providermock.Store{
+		ResolveFunc: func(_ context.Context, _, spec string) (provider.VersionRef, error) {
+			assert.Empty(t, spec)
+
+			return provider.VersionRef{}, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{
+				Name:    name,
+				Value:   "debug",
+				Version: domain.Version{ID: "4711"},
+				Tags:    []domain.Tag{{Key: "team", Value: "web"}},
+			}, nil
+		},
+	}
+
+	out, err := (&kubernetes.ShowUseCase{Reader: store}).Execute(t.Context(), kubernetes.ShowInput{Name: "app/LOG_LEVEL"})
+	require.NoError(t, err)
+	assert.Equal(t, "app/LOG_LEVEL", out.Name)
+	assert.Equal(t, "debug", out.Value)
+	assert.Equal(t, "4711", out.ResourceVersion)
+	assert.Equal(t, []kubernetes.ShowTag{{Key: "team", Value: "web"}}, out.Tags)
+}
+
+func TestDiffUseCase(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]string{"app/A": "1", "app/B": "2"}
+	store := &providermock.Store{
+		ResolveFunc: func(context.Context, string, string) (provider.VersionRef, error) {
+			return provider.VersionRef{}, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{Name: name, Value: values[name]}, nil
+		},
+	}
+
+	out, err := (&kubernetes.DiffUseCase{Reader: store}).Execute(t.Context(), kubernetes.DiffInput{Name1: "app/A", Name2: "app/B"})
+	require.NoError(t, err)
+	assert.Equal(t, &kubernetes.DiffOutput{OldName: "app/A", OldValue: "1", NewName: "app/B", NewValue: "2"}, out)
+}
+
+func TestCreateUseCase(t *testing.T) {
+	t.Parallel()
+
+	store := &providermock.Store{
+		CreateFunc: func(
+			_ context.Context, name, value string, valueType domain.ValueType, _ string, _ ...provider.WriteOption,
+		) (domain.Version, error) {
+			assert.Equal(t, "db/password", name)
+			assert.Equal(t, "v", value)
+			assert.Equal(t, domain.ValueTypeSecret, valueType)
+
+			return domain.Version{ID: "12"}, nil
+		},
+	}
+
+	out, err := (&kubernetes.CreateUseCase{Writer: store}).Execute(t.Context(), kubernetes.CreateInput{
+		Name: "db/password", Value: "v", ValueType: domain.ValueTypeSecret,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "12", out.ResourceVersion)
+}
+
+func TestUpdateUseCase(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		store := &providermock.Store{
+			GetFunc: func(context.Context, string, provider.VersionRef) (*domain.Entry, error) {
+				return nil, provider.ErrNotFound
+			},
+		}
+
+		_, err := (&kubernetes.UpdateUseCase{Store: store}).Execute(t.Context(), kubernetes.UpdateInput{Name: "app/A", Value: "v"})
+		require.ErrorIs(t, err, kubernetes.ErrEntryNotFound)
+	})
+
+	t.Run("overwrites", func(t *testing.T) {
+		t.Parallel()
+
+		store := &providermock.Store{
+			GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+				return &domain.Entry{Name: name, Value: "old"}, nil
+			},
+			PutFunc: func(
+				_ context.Context, _, value string, _ domain.ValueType, _ string, _ ...provider.WriteOption,
+			) (domain.Version, error) {
+				assert.Equal(t, "new", value)
+
+				return domain.Version{ID: "13"}, nil
+			},
+		}
+
+		out, err := (&kubernetes.UpdateUseCase{Store: store}).Execute(t.Context(), kubernetes.UpdateInput{Name: "app/A", Value: "new"})
+		require.NoError(t, err)
+		assert.Equal(t, "13", out.ResourceVersion)
+	})
+}
+
+func TestDeleteUseCase(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+	store := &providermock.Store{
+		GetFunc: func(context.Context, string, provider.VersionRef) (*domain.Entry, error) {
+			return nil, provider.ErrNotFound
+		},
+		DeleteFunc: func(context.Context, string, ...provider.DeleteOption) error { return boom },
+	}
+	uc := &kubernetes.DeleteUseCase{Store: store}
+
+	value, err := uc.GetCurrentValue(t.Context(), "app/A")
+	require.NoError(t, err)
+	assert.Empty(t, value)
+
+	_, err = uc.Execute(t.Context(), kubernetes.DeleteInput{Name: "app/A"})
+	require.ErrorIs(t, err, boom)
+	assert.Contains(t, err.Error(), "failed to delete entry")
+}
+
+func TestListUseCase(t *testing.T) {
+	t.Parallel()
+
+	store := &providermock.Store{
+		ListFunc: func(context.Context) ([]string, error) {
+			return []string{"db/user", "app/B", "app/A"}, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{Name: name, Value: "v:" + name}, nil
+		},
+	}
+
+	out, err := (&kubernetes.ListUseCase{Reader: store}).Execute(t.Context(), kubernetes.ListInput{Prefix: "app/", WithValue: true})
+	require.NoError(t, err)
+	require.Len(t, out.Entries, 2)
+	assert.Equal(t, "app/A", out.Entries[0].Name)
+	assert.Equal(t, "v:app/A", *out.Entries[0].Value)
+	assert.Equal(t, "app/B", out.Entries[1].Name)
+}
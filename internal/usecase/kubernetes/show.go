@@ -0,0 +1,61 @@
+package kubernetes
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// ShowInput holds input for the show use case.
+type ShowInput struct {
+	Name string
+}
+
+// ShowTag represents a tag (object label) key-value pair.
+type ShowTag struct {
+	Key   string
+	Value string
+}
+
+// ShowOutput holds the result of the show use case.
+type ShowOutput struct {
+	Name            string
+	Value           string
+	ResourceVersion string // the object's resourceVersion
+	CreatedDate     *time.Time
+	ModifiedDate    *time.Time // the object's last managed-fields write, if reported
+	Tags            []ShowTag
+}
+
+// ShowUseCase executes show operations.
+type ShowUseCase struct {
+	Reader provider.Reader
+}
+
+// Execute runs the show use case.
+func (u *ShowUseCase) Execute(ctx context.Context, input ShowInput) (*ShowOutput, error) {
+	ref, err := u.Reader.Resolve(ctx, input.Name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := u.Reader.Get(ctx, input.Name, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ShowOutput{
+		Name:            entry.Name,
+		Value:           entry.Value,
+		ResourceVersion: entry.Version.ID,
+		CreatedDate:     entry.Version.Created,
+		ModifiedDate:    entry.Modified,
+		Tags: lo.Map(entry.Tags, func(tag domain.Tag, _ int) ShowTag {
+			return ShowTag{Key: tag.Key, Value: tag.Value}
+		}),
+	}, nil
+}
@@ -0,0 +1,231 @@
+// Package matrix provides the use case behind `suve matrix`: the same keys
+// compared across several scopes — path prefixes of one store, or the stores of
+// several regions, projects, vaults or providers — to spot configuration drift
+// between environments.
+package matrix
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mpyw/suve/internal/parallel"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// HashLength is the number of hex digits of a value's SHA-256 shown for a cell.
+const HashLength = 12
+
+// minColumns is the fewest scopes a comparison makes sense for.
+const minColumns = 2
+
+// Filter selects the rows a matrix keeps.
+type Filter int
+
+const (
+	// FilterAll keeps every row.
+	FilterAll Filter = iota
+	// FilterEqual keeps the rows whose key holds the same value in every scope.
+	FilterEqual
+	// FilterDiffer keeps the rows whose key is missing somewhere or differs.
+	FilterDiffer
+)
+
+// Column is one scope of the comparison: the entries of Reader whose names
+// start with Prefix, keyed by the rest of the name.
+type Column struct {
+	Scope  string
+	Reader provider.Reader
+	Prefix string
+}
+
+// Input holds input for the matrix use case.
+type Input struct {
+	Columns []Column
+	Filter  Filter
+}
+
+// Cell is one key in one scope.
+type Cell struct {
+	// Present reports whether the key exists in the scope.
+	Present bool
+	// Value is the key's current value; it is kept for drill-down and never
+	// rendered in the matrix itself.
+	Value string
+	// Hash is the first HashLength hex digits of the value's SHA-256.
+	Hash string
+	// Modified is the last-modified time, if the provider knows it.
+	Modified *time.Time
+	// Error is set when the scope could not be listed or the value not read.
+	Error error
+}
+
+// Row is one relative key across every scope, cells in column order.
+type Row struct {
+	Key   string
+	Cells []Cell
+}
+
+// Equal reports whether the key holds the same value in every scope.
+func (r Row) Equal() bool {
+	for _, c := range r.Cells {
+		if !c.Present || c.Error != nil || c.Hash != r.Cells[0].Hash {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ScopeError is a scope that could not be listed.
+type ScopeError struct {
+	Scope string
+	Error error
+}
+
+// Output holds the result of the matrix use case.
+type Output struct {
+	Scopes []string
+	Rows   []Row
+	// Errors lists the scopes that could not be listed; their cells carry the
+	// error instead of a value.
+	Errors []ScopeError
+}
+
+// UseCase executes matrix comparisons.
+type UseCase struct{}
+
+// cellKey addresses one key in one column.
+type cellKey struct {
+	column int
+	key    string
+}
+
+// Execute lists every scope concurrently, reads each key's current value and
+// returns the keys sorted, filtered by input.Filter.
+func (u *UseCase) Execute(ctx context.Context, input Input) (*Output, error) {
+	if len(input.Columns) < minColumns {
+		return nil, errors.New("a matrix needs at least two scopes")
+	}
+
+	columns := make(map[int]Column, len(input.Columns))
+	for i, c := range input.Columns {
+		columns[i] = c
+	}
+
+	listed := parallel.ExecuteMap(ctx, columns, func(ctx context.Context, _ int, c Column) ([]string, error) {
+		names, err := c.Reader.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var keys []string
+
+		for _, name := range names {
+			if key, ok := strings.CutPrefix(name, c.Prefix); ok && key != "" {
+				keys = append(keys, key)
+			}
+		}
+
+		return keys, nil
+	})
+
+	out := &Output{}
+	present := map[cellKey]bool{}
+
+	var keys []string
+
+	for i, c := range input.Columns {
+		out.Scopes = append(out.Scopes, c.Scope)
+
+		if err := listed[i].Err; err != nil {
+			out.Errors = append(out.Errors, ScopeError{Scope: c.Scope, Error: err})
+
+			continue
+		}
+
+		for _, key := range listed[i].Value {
+			present[cellKey{i, key}] = true
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	cells := u.fetch(ctx, input.Columns, present)
+
+	for _, key := range keys {
+		row := Row{Key: key, Cells: make([]Cell, len(input.Columns))}
+
+		for i := range input.Columns {
+			if err := listed[i].Err; err != nil {
+				row.Cells[i] = Cell{Error: err}
+
+				continue
+			}
+
+			row.Cells[i] = cells[cellKey{i, key}]
+		}
+
+		if keep(row, input.Filter) {
+			out.Rows = append(out.Rows, row)
+		}
+	}
+
+	return out, nil
+}
+
+// fetch reads the current value of every present key concurrently. A key that
+// disappeared since it was listed is reported missing.
+func (u *UseCase) fetch(ctx context.Context, columns []Column, present map[cellKey]bool) map[cellKey]Cell {
+	results := parallel.ExecuteMap(ctx, present, func(ctx context.Context, k cellKey, _ bool) (Cell, error) {
+		c := columns[k.column]
+
+		entry, err := c.Reader.Get(ctx, c.Prefix+k.key, provider.VersionRef{})
+		if err != nil {
+			return Cell{}, err
+		}
+
+		sum := sha256.Sum256([]byte(entry.Value))
+
+		return Cell{
+			Present:  true,
+			Value:    entry.Value,
+			Hash:     hex.EncodeToString(sum[:])[:HashLength],
+			Modified: entry.Modified,
+		}, nil
+	})
+
+	cells := make(map[cellKey]Cell, len(results))
+
+	for k, r := range results {
+		switch {
+		case errors.Is(r.Err, provider.ErrNotFound):
+			cells[k] = Cell{}
+		case r.Err != nil:
+			cells[k] = Cell{Present: true, Error: fmt.Errorf("failed to read %s: %w", columns[k.column].Prefix+k.key, r.Err)}
+		default:
+			cells[k] = r.Value
+		}
+	}
+
+	return cells
+}
+
+// keep applies the row filter.
+func keep(row Row, filter Filter) bool {
+	switch filter {
+	case FilterEqual:
+		return row.Equal()
+	case FilterDiffer:
+		return !row.Equal()
+	default:
+		return true
+	}
+}
@@ -0,0 +1,154 @@
+package matrix_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/usecase/matrix"
+)
+
+// mapStore serves the given name → value map, with a fixed modified time.
+func mapStore(values map[string]string) *providermock.Store {
+	modified := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	return &providermock.Store{
+		ListFunc: func(_ context.Context) ([]string, error) {
+			names := make([]string, 0, len(values))
+			for name := range values {
+				names = append(names, name)
+			}
+
+			return names, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			v, ok := values[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+			}
+
+			return &domain.Entry{Name: name, Value: v, Modified: &modified}, nil
+		},
+	}
+}
+
+func TestUseCase_Execute_Prefixes(t *testing.T) {
+	t.Parallel()
+
+	store := mapStore(map[string]string{
+		"/app/prod/db-url":    "postgres://prod",
+		"/app/prod/log-level": "warn",
+		"/app/dev/db-url":     "postgres://dev",
+		"/app/dev/log-level":  "warn",
+		"/app/dev/debug":      "true",
+		"/other/db-url":       "ignored",
+	})
+
+	uc := &matrix.UseCase{}
+
+	out, err := uc.Execute(t.Context(), matrix.Input{Columns: []matrix.Column{
+		{Scope: "prod", Reader: store, Prefix: "/app/prod/"},
+		{Scope: "dev", Reader: store, Prefix: "/app/dev/"},
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"prod", "dev"}, out.Scopes)
+	require.Len(t, out.Rows, 3)
+
+	dbURL, debug, logLevel := out.Rows[0], out.Rows[1], out.Rows[2]
+
+	assert.Equal(t, "debug", debug.Key)
+	assert.False(t, debug.Cells[0].Present, "missing in prod")
+	assert.True(t, debug.Cells[1].Present)
+	assert.False(t, debug.Equal())
+
+	assert.Equal(t, "db-url", dbURL.Key)
+	assert.NotEqual(t, dbURL.Cells[0].Hash, dbURL.Cells[1].Hash)
+	assert.Len(t, dbURL.Cells[0].Hash, matrix.HashLength)
+	assert.Equal(t, "postgres://prod", dbURL.Cells[0].Value)
+	assert.False(t, dbURL.Equal())
+
+	assert.Equal(t, "log-level", logLevel.Key)
+	assert.True(t, logLevel.Equal())
+	require.NotNil(t, logLevel.Cells[0].Modified)
+}
+
+func TestUseCase_Execute_Filter(t *testing.T) {
+	t.Parallel()
+
+	a := mapStore(map[string]string{"same": "1", "changed": "1", "only-a": "1"})
+	b := mapStore(map[string]string{"same": "1", "changed": "2"})
+
+	columns := []matrix.Column{{Scope: "a", Reader: a}, {Scope: "b", Reader: b}}
+
+	tests := []struct {
+		name   string
+		filter matrix.Filter
+		want   []string
+	}{
+		{name: "all", filter: matrix.FilterAll, want: []string{"changed", "only-a", "same"}},
+		{name: "equal", filter: matrix.FilterEqual, want: []string{"same"}},
+		{name: "differ", filter: matrix.FilterDiffer, want: []string{"changed", "only-a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := &matrix.UseCase{}
+
+			out, err := uc.Execute(t.Context(), matrix.Input{Columns: columns, Filter: tt.filter})
+			require.NoError(t, err)
+
+			keys := make([]string, 0, len(out.Rows))
+			for _, r := range out.Rows {
+				keys = append(keys, r.Key)
+			}
+
+			assert.Equal(t, tt.want, keys)
+		})
+	}
+}
+
+func TestUseCase_Execute_ScopeError(t *testing.T) {
+	t.Parallel()
+
+	ok := mapStore(map[string]string{"key": "v"})
+	broken := &providermock.Store{
+		ListFunc: func(_ context.Context) ([]string, error) {
+			return nil, errors.New("access denied")
+		},
+	}
+
+	uc := &matrix.UseCase{}
+
+	out, err := uc.Execute(t.Context(), matrix.Input{Columns: []matrix.Column{
+		{Scope: "us-east-1", Reader: ok},
+		{Scope: "eu-west-1", Reader: broken},
+	}})
+	require.NoError(t, err, "a scope that cannot be listed does not fail the others")
+
+	require.Len(t, out.Errors, 1)
+	assert.Equal(t, "eu-west-1", out.Errors[0].Scope)
+	require.Len(t, out.Rows, 1)
+	assert.True(t, out.Rows[0].Cells[0].Present)
+	require.EqualError(t, out.Rows[0].Cells[1].Error, "access denied")
+	assert.False(t, out.Rows[0].Equal())
+}
+
+func TestUseCase_Execute_TooFewScopes(t *testing.T) {
+	t.Parallel()
+
+	uc := &matrix.UseCase{}
+
+	_, err := uc.Execute(t.Context(), matrix.Input{Columns: []matrix.Column{{Scope: "only", Reader: mapStore(nil)}}})
+	require.Error(t, err)
+}
@@ -27,7 +27,10 @@ type LogEntry struct {
 	Value        string
 	LastModified *time.Time
 	IsCurrent    bool
-	Error        error // Error from fetching value, if any
+	// Message is the change message THIS version was written with (`stage
+	// apply -m`), empty when none was recorded.
+	Message string
+	Error   error // Error from fetching value, if any
 }
 
 // LogOutput holds the result of the log use case.
@@ -108,6 +111,7 @@ func (u *LogUseCase) Execute(ctx context.Context, input LogInput) (*LogOutput, e
 			Version:      parseVersion(v.ID),
 			LastModified: v.Created,
 			IsCurrent:    parseVersion(v.ID) == maxVersionNum,
+			Message:      v.Message,
 			Error:        fetchErr,
 		}
 		// Record a per-version fetch failure on the entry rather than aborting
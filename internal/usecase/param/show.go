@@ -32,6 +32,9 @@ type ShowOutput struct {
 	Description  string
 	LastModified *time.Time
 	Tags         []ShowTag
+	// Extra carries the entry's display-only provider metadata (e.g. the App
+	// Configuration read-only lock).
+	Extra []domain.Field
 }
 
 // ShowUseCase executes show operations.
@@ -61,6 +64,7 @@ func (u *ShowUseCase) Execute(ctx context.Context, input ShowInput) (*ShowOutput
 		Tags: lo.Map(entry.Tags, func(tag domain.Tag, _ int) ShowTag {
 			return ShowTag{Key: tag.Key, Value: tag.Value}
 		}),
+		Extra: entry.Extra,
 	}
 
 	return output, nil
@@ -37,8 +37,13 @@ type LogEntry struct {
 	IsCurrent    bool
 	// Tags attached to THIS version. Only Azure Key Vault scopes tags per version;
 	// empty for providers whose tags live at the resource level.
-	Tags  []domain.Tag
-	Error error // Error from fetching value, if any
+	Tags []domain.Tag
+	// Expires is THIS version's expiry (Azure Key Vault only), nil when unset.
+	Expires *time.Time
+	// Message is the change message THIS version was written with (`stage
+	// apply -m`), empty when none was recorded.
+	Message string
+	Error   error // Error from fetching value, if any
 }
 
 // LogOutput holds the result of the log use case.
@@ -127,6 +132,8 @@ func (u *LogUseCase) Execute(ctx context.Context, input LogInput) (*LogOutput, e
 			CreatedDate:  v.Created,
 			IsCurrent:    v.ID == currentVersion,
 			Tags:         v.Tags,
+			Expires:      v.Expires,
+			Message:      v.Message,
 			Error:        fetchErr,
 		}
 	})
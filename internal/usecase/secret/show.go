@@ -40,6 +40,11 @@ type ShowOutput struct {
 	Description  string
 	CreatedDate  *time.Time
 	Tags         []ShowTag
+	// ContentType, NotBefore and Expires are the Azure Key Vault version
+	// attributes; empty/nil for every other provider.
+	ContentType string
+	NotBefore   *time.Time
+	Expires     *time.Time
 }
 
 // ShowUseCase executes show operations.
@@ -73,6 +78,9 @@ func (u *ShowUseCase) Execute(ctx context.Context, input ShowInput) (*ShowOutput
 		Tags: lo.Map(entry.Tags, func(tag domain.Tag, _ int) ShowTag {
 			return ShowTag{Key: tag.Key, Value: tag.Value}
 		}),
+		ContentType: extraValue(entry, "Content Type"),
+		NotBefore:   entry.Version.NotBefore,
+		Expires:     entry.Version.Expires,
 	}
 
 	return output, nil
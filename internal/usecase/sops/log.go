@@ -0,0 +1,146 @@
+package sops
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// LogInput holds input for the log use case.
+type LogInput struct {
+	Name       string
+	MaxResults int32
+	Since      *time.Time
+	Until      *time.Time
+	Reverse    bool
+}
+
+// LogEntry represents a single version entry.
+type LogEntry struct {
+	Version     string
+	Value       string
+	CreatedDate *time.Time
+	Error       error // Error from fetching value, if any (e.g. a commit no identity can decrypt)
+}
+
+// LogOutput holds the result of the log use case.
+type LogOutput struct {
+	Name    string
+	Entries []LogEntry
+	// InitialIncluded reports whether the oldest entry in Entries is the very
+	// first version that ever existed. It is false when the window was cut by
+	// --number or a date filter, so the oldest shown version is not a creation.
+	InitialIncluded bool
+}
+
+// LogUseCase executes log operations.
+type LogUseCase struct {
+	Reader provider.Reader
+}
+
+// Execute runs the log use case: fetch the version history (newest first), cap
+// to MaxResults, optionally reverse, apply date filters, then retrieve each
+// surviving version's value. A per-version fetch failure (a commit encrypted
+// to recipients the current identities cannot decrypt) is recorded on the
+// entry's Error field rather than aborting the whole listing.
+func (u *LogUseCase) Execute(ctx context.Context, input LogInput) (*LogOutput, error) {
+	versions, err := u.Reader.History(ctx, input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list key versions: %w", err)
+	}
+
+	if len(versions) == 0 {
+		return &LogOutput{Name: input.Name}, nil
+	}
+
+	// The complete history is newest first, so its last element is the very
+	// first version that ever existed. Remember it before truncation so we can
+	// tell whether the oldest shown version is genuinely the initial one.
+	initialVersion := versions[len(versions)-1].ID
+
+	// An uncommitted value has no commit to name it by, so every version is
+	// fetched by its ~shift from the newest instead.
+	shifts := make(map[string]int, len(versions))
+	for i, v := range versions {
+		shifts[v.ID] = i
+	}
+
+	// Apply date filters BEFORE the count limit: -n must return up to N versions
+	// that match --since/--until, not N newest-then-filtered to fewer.
+	versions = lo.Filter(versions, func(v domain.Version, _ int) bool {
+		if input.Since == nil && input.Until == nil {
+			return true
+		}
+
+		// Skip versions without a timestamp when date filters are applied.
+		if v.Created == nil {
+			return false
+		}
+
+		if input.Since != nil && v.Created.Before(*input.Since) {
+			return false
+		}
+
+		if input.Until != nil && v.Created.After(*input.Until) {
+			return false
+		}
+
+		return true
+	})
+
+	if input.MaxResults > 0 && len(versions) > int(input.MaxResults) {
+		versions = versions[:input.MaxResults]
+	}
+
+	if input.Reverse {
+		slices.Reverse(versions)
+	}
+
+	entries := lo.Map(versions, func(v domain.Version, _ int) LogEntry {
+		value, fetchErr := u.getValue(ctx, input.Name, shifts[v.ID])
+
+		return LogEntry{
+			Version:     v.ID,
+			Value:       value,
+			CreatedDate: v.Created,
+			Error:       fetchErr,
+		}
+	})
+
+	return &LogOutput{
+		Name:    input.Name,
+		Entries: entries,
+		InitialIncluded: slices.ContainsFunc(entries, func(e LogEntry) bool {
+			return e.Version == initialVersion
+		}),
+	}, nil
+}
+
+// getValue fetches the value of the version shift versions back from the
+// newest, tolerating fetch failures by returning them as an error (the caller
+// records it per-entry).
+func (u *LogUseCase) getValue(ctx context.Context, name string, shift int) (string, error) {
+	var spec string
+	if shift > 0 {
+		spec = "~" + strconv.Itoa(shift)
+	}
+
+	ref, err := u.Reader.Resolve(ctx, name, spec)
+	if err != nil {
+		return "", err
+	}
+
+	entry, err := u.Reader.Get(ctx, name, ref)
+	if err != nil {
+		return "", err
+	}
+
+	return entry.Value, nil
+}
@@ -0,0 +1,53 @@
+package sops
+
+import (
+	"context"
+	"time"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/version/sopsversion"
+)
+
+// ShowInput holds input for the show use case.
+type ShowInput struct {
+	Spec *sopsversion.Spec
+}
+
+// ShowOutput holds the result of the show use case.
+type ShowOutput struct {
+	Name        string
+	Value       string
+	Version     string     // short commit hash, or "" for the working file
+	CreatedDate *time.Time // commit time, or the file's modification time for the working file
+}
+
+// ShowUseCase executes show operations.
+type ShowUseCase struct {
+	Reader provider.Reader
+}
+
+// Execute runs the show use case. #commit and ~shift resolution are provided by
+// the adapter behind provider.Reader.
+func (u *ShowUseCase) Execute(ctx context.Context, input ShowInput) (*ShowOutput, error) {
+	ref, err := u.Reader.Resolve(ctx, input.Spec.Name, specSuffix(input.Spec))
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := u.Reader.Get(ctx, input.Spec.Name, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	createdDate := entry.Version.Created
+	if createdDate == nil {
+		createdDate = entry.Modified
+	}
+
+	return &ShowOutput{
+		Name:        entry.Name,
+		Value:       entry.Value,
+		Version:     entry.Version.ID,
+		CreatedDate: createdDate,
+	}, nil
+}
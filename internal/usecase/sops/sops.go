@@ -0,0 +1,40 @@
+// Package sops provides use cases for the leaf keys of a SOPS-encrypted file.
+//
+// The use cases are written against the provider-neutral Reader/Writer/Store
+// interfaces, like the Vault ones, and expose the same shape minus what a file
+// cannot hold: versions are short commit hashes from the file's git history,
+// and there are no tags, labels, descriptions or restore.
+package sops
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/mpyw/suve/internal/version/sopsversion"
+)
+
+// ErrKeyNotFound is returned by the update use case when the target key does
+// not exist.
+var ErrKeyNotFound = errors.New("key not found")
+
+// specSuffix reconstructs the version-spec suffix (the part after the name)
+// from a parsed spec, so that name+suffix re-parses to an equivalent spec. It
+// is handed to provider.Reader.Resolve, which re-parses name+suffix internally.
+//
+// Examples: {Commit:"abc123"} -> "#abc123"; {Shift:2} -> "~2"; {} -> "" (latest).
+func specSuffix(spec *sopsversion.Spec) string {
+	var b strings.Builder
+
+	if spec.Absolute.Commit != nil {
+		b.WriteString("#")
+		b.WriteString(*spec.Absolute.Commit)
+	}
+
+	if spec.Shift > 0 {
+		b.WriteString("~")
+		b.WriteString(strconv.Itoa(spec.Shift))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,128 @@
+package sops_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/usecase/sops"
+	"github.com/mpyw/suve/internal/version/sopsversion"
+)
+
+func TestShowUseCase(t *testing.T) {
+	t.Parallel()
+
+	modified := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("commit", func(t *testing.T) {
+		t.Parallel()
+
+		store := &providermock.Store{
+			ResolveFunc: func(_ context.Context, _, spec string) (provider.VersionRef, error) {
+				assert.Equal(t, "#abc123~1", spec)
+
+				return provider.NewVersionRef("0123456789ab"), nil
+			},
+			GetFunc: func(_ context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+				return &domain.Entry{Name: name, Value: "old", Version: domain.Version{ID: ref.ID(), Created: &modified}}, nil
+			},
+		}
+
+		spec, err := sopsversion.Parse("db.password#abc123~1")
+		require.NoError(t, err)
+
+		out, err := (&sops.ShowUseCase{Reader: store}).Execute(t.Context(), sops.ShowInput{Spec: spec})
+		require.NoError(t, err)
+		assert.Equal(t, "db.password", out.Name)
+		assert.Equal(t, "old", out.Value)
+		assert.Equal(t, "0123456789ab", out.Version)
+		assert.Equal(t, &modified, out.CreatedDate)
+	})
+
+	t.Run("working file falls back to the modification time", func(t *testing.T) {
+		t.Parallel()
+
+		store := &providermock.Store{
+			ResolveFunc: func(_ context.Context, _, _ string) (provider.VersionRef, error) {
+				return provider.VersionRef{}, nil
+			},
+			GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+				return &domain.Entry{Name: name, Value: "new", Modified: &modified}, nil
+			},
+		}
+
+		spec, err := sopsversion.Parse("db.password")
+		require.NoError(t, err)
+
+		out, err := (&sops.ShowUseCase{Reader: store}).Execute(t.Context(), sops.ShowInput{Spec: spec})
+		require.NoError(t, err)
+		assert.Empty(t, out.Version)
+		assert.Equal(t, &modified, out.CreatedDate)
+	})
+}
+
+func TestUpdateUseCase_NotFound(t *testing.T) {
+	t.Parallel()
+
+	store := &providermock.Store{
+		GetFunc: func(_ context.Context, _ string, _ provider.VersionRef) (*domain.Entry, error) {
+			return nil, provider.ErrNotFound
+		},
+	}
+
+	_, err := (&sops.UpdateUseCase{Store: store}).Execute(t.Context(), sops.UpdateInput{Name: "db.password", Value: "v"})
+	require.ErrorIs(t, err, sops.ErrKeyNotFound)
+}
+
+func TestListUseCase_KeepsDocumentOrder(t *testing.T) {
+	t.Parallel()
+
+	store := &providermock.Store{
+		ListFunc: func(context.Context) ([]string, error) {
+			return []string{"db.user", "api.token", "db.password"}, nil
+		},
+	}
+
+	out, err := (&sops.ListUseCase{Reader: store}).Execute(t.Context(), sops.ListInput{Prefix: "db."})
+	require.NoError(t, err)
+	require.Len(t, out.Entries, 2)
+	assert.Equal(t, "db.user", out.Entries[0].Name)
+	assert.Equal(t, "db.password", out.Entries[1].Name)
+}
+
+func TestLogUseCase_FetchesByShift(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]string{"": "3", "~1": "2", "~2": "1"}
+
+	store := &providermock.Store{
+		HistoryFunc: func(context.Context, string) ([]domain.Version, error) {
+			return []domain.Version{{ID: "worktree"}, {ID: "cccc00000000"}, {ID: "aaaa00000000"}}, nil
+		},
+		ResolveFunc: func(_ context.Context, _, spec string) (provider.VersionRef, error) {
+			if spec == "~2" {
+				return provider.VersionRef{}, errors.New("undecryptable")
+			}
+
+			return provider.NewVersionRef(spec), nil
+		},
+		GetFunc: func(_ context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{Name: name, Value: values[ref.ID()]}, nil
+		},
+	}
+
+	out, err := (&sops.LogUseCase{Reader: store}).Execute(t.Context(), sops.LogInput{Name: "a"})
+	require.NoError(t, err)
+	require.Len(t, out.Entries, 3)
+	assert.Equal(t, "3", out.Entries[0].Value)
+	assert.Equal(t, "2", out.Entries[1].Value)
+	require.Error(t, out.Entries[2].Error)
+	assert.True(t, out.InitialIncluded)
+}
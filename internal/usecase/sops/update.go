@@ -0,0 +1,64 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+)
+
+// UpdateInput holds input for the update use case.
+type UpdateInput struct {
+	Name  string
+	Value string
+}
+
+// UpdateOutput holds the result of the update use case.
+type UpdateOutput struct {
+	Name    string
+	Version string
+}
+
+// UpdateUseCase executes update operations.
+type UpdateUseCase struct {
+	Store provider.Store
+}
+
+// GetCurrentValue fetches the current key value for preview. A non-existent
+// key yields an empty value with no error; any other read failure is
+// propagated.
+func (u *UpdateUseCase) GetCurrentValue(ctx context.Context, name string) (string, error) {
+	entry, err := u.Store.Get(ctx, name, provider.VersionRef{})
+
+	switch {
+	case errors.Is(err, provider.ErrNotFound):
+		return "", nil
+	case err != nil:
+		return "", err
+	}
+
+	return entry.Value, nil
+}
+
+// Execute runs the update use case. It rewrites an existing key in the file;
+// if the key doesn't exist it returns ErrKeyNotFound. A read failure other
+// than not-found is propagated unchanged.
+func (u *UpdateUseCase) Execute(ctx context.Context, input UpdateInput) (*UpdateOutput, error) {
+	_, err := u.Store.Get(ctx, input.Name, provider.VersionRef{})
+
+	switch {
+	case errors.Is(err, provider.ErrNotFound):
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, input.Name)
+	case err != nil:
+		return nil, err
+	}
+
+	version, err := u.Store.Put(ctx, input.Name, input.Value, domain.ValueTypeSecret, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to update key: %w", err)
+	}
+
+	return &UpdateOutput{Name: input.Name, Version: version.ID}, nil
+}
@@ -32,6 +32,9 @@ type AddInput struct {
 	// StringList); other providers leave it empty. An empty value applies as
 	// plaintext, so callers that do not set it keep the prior behavior.
 	ValueType domain.ValueType
+	// Attributes are Azure Key Vault secret attributes for the staged create;
+	// nil keeps a previously staged set (and stages none otherwise).
+	Attributes *staging.SecretAttributes
 }
 
 // AddOutput holds the result of the add use case.
@@ -80,13 +83,15 @@ func (u *AddUseCase) Execute(ctx context.Context, input AddInput) (*AddOutput, e
 	// Resolve the staged value type. When the caller specifies none, preserve a
 	// previously staged type so re-staging the create (e.g. re-editing the draft
 	// without --secure) never silently downgrades a SecureString to plain String.
-	valueType := input.ValueType
-	if valueType == "" {
+	// Staged attributes are preserved the same way.
+	valueType, attributes := input.ValueType, input.Attributes
+	if valueType == "" || attributes == nil {
 		existing, gerr := u.Store.GetEntry(ctx, service, key)
 
 		switch {
 		case gerr == nil:
-			valueType = existing.ValueType
+			valueType = lo.CoalesceOrEmpty(valueType, existing.ValueType)
+			attributes = lo.CoalesceOrEmpty(attributes, existing.Attributes)
 		case !errors.Is(gerr, staging.ErrNotStaged):
 			return nil, gerr
 		}
@@ -101,7 +106,7 @@ func (u *AddUseCase) Execute(ctx context.Context, input AddInput) (*AddOutput, e
 	// Execute the transition
 	executor := transition.NewExecutor(u.Store)
 
-	opts := &transition.EntryExecuteOptions{ValueType: valueType}
+	opts := &transition.EntryExecuteOptions{ValueType: valueType, Attributes: attributes}
 	if input.Description != "" {
 		opts.Description = &input.Description
 	}
@@ -91,6 +91,36 @@ func TestAddUseCase_Execute_RecordsValueType(t *testing.T) {
 	assert.Equal(t, domain.ValueTypeSecret, entry.ValueType)
 }
 
+func TestAddUseCase_Execute_PreservesStagedAttributes(t *testing.T) {
+	t.Parallel()
+
+	exp := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	key := staging.EntryKey{Name: "/app/secure"}
+
+	store := testutil.NewMockStore()
+	uc := &usecasestaging.AddUseCase{
+		Strategy: newMockEditStrategyNotFound(),
+		Store:    store,
+	}
+
+	_, err := uc.Execute(t.Context(), usecasestaging.AddInput{
+		Key:        key,
+		Value:      "v1",
+		Attributes: &staging.SecretAttributes{Expires: &exp},
+	})
+	require.NoError(t, err)
+
+	// Re-staging without attributes keeps the staged ones.
+	_, err = uc.Execute(t.Context(), usecasestaging.AddInput{Key: key, Value: "v2"})
+	require.NoError(t, err)
+
+	entry, err := store.GetEntry(t.Context(), staging.ServiceParam, key)
+	require.NoError(t, err)
+	require.NotNil(t, entry.Attributes)
+	assert.Equal(t, &exp, entry.Attributes.Expires)
+	assert.Equal(t, "v2", lo.FromPtr(entry.Value))
+}
+
 func TestAddUseCase_Execute_PreservesStagedTypeOnReAddWithoutType(t *testing.T) {
 	t.Parallel()
 
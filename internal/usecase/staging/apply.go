@@ -2,12 +2,13 @@ package staging
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
 
 	"github.com/mpyw/suve/internal/maputil"
-	"github.com/mpyw/suve/internal/parallel"
+	"github.com/mpyw/suve/internal/retry"
 	"github.com/mpyw/suve/internal/staging"
 	"github.com/mpyw/suve/internal/staging/store"
 )
@@ -16,6 +17,30 @@ import (
 type ApplyInput struct {
 	Name            string // Optional: apply only this item
 	IgnoreConflicts bool   // Skip conflict detection
+	// Select narrows the apply to the staged changes it selects (all of them
+	// when zero); combined with Name, both must match.
+	Select staging.Selector
+	// UnlockLocked writes entries that are locked read-only by unlocking them,
+	// applying, and locking them again. Without it a locked entry is not
+	// attempted and is reported in ApplyOutput.Locked.
+	UnlockLocked bool
+	// Atomic applies all or nothing: every target is snapshotted before the
+	// first write, and when anything fails every key already written is rolled
+	// back (see ApplyOutput.Rollbacks) and everything stays staged. Every
+	// strategy involved must implement staging.Rollbacker.
+	Atomic bool
+	// Message is the change message recorded with every written value where
+	// the provider keeps one (see staging.WithChangeMessage), and in the apply
+	// journal.
+	Message string
+	// MessageTag, when set along with Message, also sets the message as this
+	// tag on each created or updated entry.
+	MessageTag string
+	// Order orders the changes into phases: creates, then updates, then
+	// deletes, within the groups its patterns set up (see staging.ApplyOrder).
+	Order staging.ApplyOrder
+	// Concurrency caps the changes applied at once; 0 is parallel.DefaultLimit.
+	Concurrency int
 }
 
 // ApplyResultStatus represents the status of an apply operation.
@@ -41,6 +66,11 @@ type ApplyEntryResult struct {
 	// from the staging store afterwards failed. The entry is still staged, so a
 	// later apply would re-run it; callers must surface this rather than ignore it.
 	UnstageError error
+	// MessageTagError is set when the value was written but setting the change
+	// message as ApplyInput.MessageTag failed. The entry still counts as applied
+	// and is unstaged (re-applying it would write the value again); callers
+	// report this as a warning.
+	MessageTagError error
 }
 
 // ApplyTagResult represents the result of applying tag changes.
@@ -73,6 +103,29 @@ type ApplyOutput struct {
 	// entry so callers can render the namespace badge; empty namespace renders as
 	// the bare name.
 	Conflicts []staging.EntryKey
+	// Locked carries the key of each staged change that was not applied because
+	// the remote entry is locked read-only (App Configuration), sorted. Each is
+	// also a failed entry/tag result wrapping provider.ErrLocked.
+	Locked []staging.EntryKey
+	// Rollbacks reports, for an atomic apply that failed, each rolled-back key
+	// sorted by (Namespace, Name).
+	Rollbacks []ApplyRollbackResult
+	// JournalID identifies the apply's record in the apply journal; empty when
+	// nothing was recorded.
+	JournalID string
+	// JournalError is set when the apply could not be recorded in the journal.
+	// The apply itself is unaffected.
+	JournalError error
+}
+
+// ApplyRollbackResult represents the result of rolling back one key after a
+// failed atomic apply.
+type ApplyRollbackResult struct {
+	Name string
+	// Namespace is the App Configuration namespace of the key (empty elsewhere).
+	Namespace string
+	// Error is set when the rollback failed, leaving the key as applied.
+	Error error
 }
 
 // ApplyUseCase executes apply operations.
@@ -85,6 +138,13 @@ type ApplyUseCase struct {
 	// across namespaces). When nil, Strategy applies every entry — the case for
 	// namespace-agnostic providers (AWS, Google Cloud, Key Vault).
 	StrategyFor func(namespace string) (staging.ApplyStrategy, error)
+	// Journal, when set, records each apply with the previous state of every
+	// changed entry (captured through staging.Rollbacker before writing), so it
+	// can be listed and undone later.
+	Journal ApplyJournal
+	// Retry is the backoff of a throttled provider call; the zero Policy is
+	// retry.Default.
+	Retry retry.Policy
 }
 
 // strategyForNamespace returns the apply strategy scoped to the given namespace,
@@ -99,6 +159,8 @@ func (u *ApplyUseCase) strategyForNamespace(namespace string) (staging.ApplyStra
 
 // Execute runs the apply use case.
 func (u *ApplyUseCase) Execute(ctx context.Context, input ApplyInput) (*ApplyOutput, error) {
+	ctx = staging.WithChangeMessage(ctx, input.Message)
+
 	service := u.Strategy.Service()
 	serviceName := u.Strategy.ServiceName()
 	itemName := u.Strategy.ItemName()
@@ -149,14 +211,19 @@ func (u *ApplyUseCase) Execute(ctx context.Context, input ApplyInput) (*ApplyOut
 		tags = filteredTags
 	}
 
+	entries = input.Select.SelectEntries(entries)
+	tags = input.Select.SelectTags(tags)
+
 	if len(entries) == 0 && len(tags) == 0 {
 		return output, nil
 	}
 
 	// Check for conflicts. Both value entries and tag changes carry a
 	// BaseModifiedAt; a remote modified after that base time is a conflict for
-	// either kind. The merged check fetches each remote once — even when a key
-	// has both a value and a tag change — and reports that key once.
+	// either kind, and a value entry that also recorded a BaseVersion conflicts
+	// when the remote version differs instead. The merged check fetches each
+	// remote once — even when a key has both a value and a tag change — and
+	// reports that key once.
 	if !input.IgnoreConflicts {
 		conflicts := staging.CheckEntryAndTagConflicts(ctx, u.strategyForNamespace, entries, tags)
 
@@ -167,55 +234,98 @@ func (u *ApplyUseCase) Execute(ctx context.Context, input ApplyInput) (*ApplyOut
 
 			return output, fmt.Errorf("apply rejected: %d conflict(s) detected", len(conflicts))
 		}
+	} else {
+		entries = staging.WithoutBaseVersion(entries)
+	}
+
+	// Detect read-only locks up front, so a locked entry is reported as such
+	// rather than as whatever error the write would have surfaced.
+	locked := staging.DetectLocked(ctx, u.strategyForNamespace, entries, tags)
+	if len(locked) > 0 && !input.UnlockLocked {
+		output.Locked = staging.SortedEntryKeys(locked)
+	}
+
+	// An atomic apply snapshots every target up front and writes nothing
+	// unless all of them could be captured. A locked entry would fail for sure,
+	// so it is refused before any write too. A journaled apply snapshots the
+	// same way but goes ahead, unrecorded, when that fails.
+	var snapshots map[staging.EntryKey]staging.Snapshot
+
+	switch {
+	case input.Atomic:
+		if len(output.Locked) > 0 {
+			return output, fmt.Errorf("atomic apply rejected: %d locked entries (use --unlock-locked to write them)", len(output.Locked))
+		}
+
+		if snapshots, err = Snapshot(ctx, u.strategyForNamespace, entries, tags); err != nil {
+			return output, fmt.Errorf("atomic apply aborted before writing: %w", err)
+		}
+	case u.Journal != nil:
+		var snapErr error
+		if snapshots, snapErr = Snapshot(ctx, u.strategyForNamespace, entries, tags); snapErr != nil {
+			output.JournalError = fmt.Errorf("previous state not captured: %w", snapErr)
+		}
 	}
 
+	writer := &Writer{
+		Resolve:      u.strategyForNamespace,
+		Schedule:     staging.ApplySchedule{Order: input.Order, Concurrency: input.Concurrency, Retry: u.Retry},
+		Locked:       locked,
+		UnlockLocked: input.UnlockLocked,
+		MessageTag:   input.MessageTag,
+	}
+
+	staging.ReportQueued(ctx, input.Order, entries, tags)
+
 	// Apply entries
 	if len(entries) > 0 {
-		u.applyEntries(ctx, service, entries, output)
+		u.applyEntries(ctx, service, writer, entries, input, output)
 	}
 
 	// Apply tags
 	if len(tags) > 0 {
-		u.applyTags(ctx, service, tags, output)
+		u.applyTags(ctx, service, writer, tags, input, output)
 	}
 
-	// Calculate total failures
-	totalFailed := output.EntryFailed + output.TagFailed
-	if totalFailed > 0 {
-		return output, fmt.Errorf("applied %d entries, %d tags; failed %d entries, %d tags",
+	if input.Atomic {
+		err = u.settleAtomic(ctx, service, snapshots, writer.Written.Keys(), locked, output)
+	} else if totalFailed := output.EntryFailed + output.TagFailed; totalFailed > 0 {
+		summary := fmt.Sprintf("applied %d entries, %d tags; failed %d entries, %d tags",
 			output.EntrySucceeded, output.TagSucceeded, output.EntryFailed, output.TagFailed)
+		if len(output.Locked) > 0 {
+			summary += fmt.Sprintf(" (%d locked)", len(output.Locked))
+		}
+
+		err = errors.New(summary)
 	}
 
-	return output, nil
-}
+	u.journal(ctx, service, entries, tags, snapshots, output)
 
-func (u *ApplyUseCase) applyEntries(ctx context.Context, service staging.Service, entries map[staging.EntryKey]staging.Entry, output *ApplyOutput) {
-	// Execute apply operations in parallel. Entries are keyed by EntryKey; each is
-	// applied through the strategy scoped to its own namespace (App Configuration)
-	// or the single strategy (other providers).
-	results := parallel.ExecuteMap(ctx, entries, func(ctx context.Context, key staging.EntryKey, entry staging.Entry) (staging.Operation, error) {
-		strategy, err := u.strategyForNamespace(key.Namespace)
-		if err != nil {
-			return entry.Operation, err
-		}
+	return output, err
+}
 
-		return entry.Operation, strategy.Apply(ctx, key.Name, entry)
-	})
+func (u *ApplyUseCase) applyEntries(
+	ctx context.Context, service staging.Service, writer *Writer, entries map[staging.EntryKey]staging.Entry,
+	input ApplyInput, output *ApplyOutput,
+) {
+	// Execute apply operations phase by phase, each phase in parallel, each
+	// entry through the strategy scoped to its own namespace (see Writer).
+	errs, messageTagErrs := writer.WriteEntries(ctx, entries)
 
 	// Collect results
-	for key := range entries {
-		result := results[key]
+	for key, entry := range entries {
 		resultEntry := ApplyEntryResult{
-			Name:      key.Name,
-			Namespace: key.Namespace,
+			Name:            key.Name,
+			Namespace:       key.Namespace,
+			MessageTagError: messageTagErrs[key],
 		}
 
-		if result.Err != nil {
+		if err := errs[key]; err != nil {
 			resultEntry.Status = ApplyResultFailed
-			resultEntry.Error = result.Err
+			resultEntry.Error = err
 			output.EntryFailed++
 		} else {
-			switch result.Value {
+			switch entry.Operation {
 			case staging.OperationCreate:
 				resultEntry.Status = ApplyResultCreated
 			case staging.OperationUpdate:
@@ -226,8 +336,9 @@ func (u *ApplyUseCase) applyEntries(ctx context.Context, service staging.Service
 			// Unstage successful operations. A failure here leaves the entry
 			// staged after a successful cloud apply, so record it rather than
 			// discarding it — a silent leftover would be re-applied next time.
-			if err := u.Store.UnstageEntry(ctx, service, key); err != nil {
-				resultEntry.UnstageError = err
+			// An atomic apply unstages only once everything succeeded.
+			if !input.Atomic {
+				resultEntry.UnstageError = u.Store.UnstageEntry(ctx, service, key)
 			}
 
 			output.EntrySucceeded++
@@ -248,21 +359,15 @@ func (u *ApplyUseCase) applyEntries(ctx context.Context, service staging.Service
 	})
 }
 
-func (u *ApplyUseCase) applyTags(ctx context.Context, service staging.Service, tags map[staging.EntryKey]staging.TagEntry, output *ApplyOutput) {
-	// Execute tag apply operations in parallel, each through the strategy scoped
-	// to the tagged item's own namespace.
-	results := parallel.ExecuteMap(ctx, tags, func(ctx context.Context, key staging.EntryKey, tagEntry staging.TagEntry) (struct{}, error) {
-		strategy, err := u.strategyForNamespace(key.Namespace)
-		if err != nil {
-			return struct{}{}, err
-		}
-
-		return struct{}{}, strategy.ApplyTags(ctx, key.Name, tagEntry)
-	})
+func (u *ApplyUseCase) applyTags(
+	ctx context.Context, service staging.Service, writer *Writer, tags map[staging.EntryKey]staging.TagEntry,
+	input ApplyInput, output *ApplyOutput,
+) {
+	// Execute tag apply operations like entries (see Writer).
+	errs := writer.WriteTags(ctx, tags)
 
 	// Collect results
 	for key, tagEntry := range tags {
-		result := results[key]
 		resultTag := ApplyTagResult{
 			Name:      key.Name,
 			Namespace: key.Namespace,
@@ -270,14 +375,15 @@ func (u *ApplyUseCase) applyTags(ctx context.Context, service staging.Service, t
 			RemoveTag: tagEntry.Remove,
 		}
 
-		if result.Err != nil {
-			resultTag.Error = result.Err
+		if err := errs[key]; err != nil {
+			resultTag.Error = err
 			output.TagFailed++
 		} else {
 			// Unstage successful operations (see applyEntries: record rather
-			// than discard a post-apply unstage failure).
-			if err := u.Store.UnstageTag(ctx, service, key); err != nil {
-				resultTag.UnstageError = err
+			// than discard a post-apply unstage failure, and defer it in an
+			// atomic apply).
+			if !input.Atomic {
+				resultTag.UnstageError = u.Store.UnstageTag(ctx, service, key)
 			}
 
 			output.TagSucceeded++
@@ -3,6 +3,7 @@ package staging_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -11,6 +12,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/retry"
 	"github.com/mpyw/suve/internal/staging"
 	"github.com/mpyw/suve/internal/staging/store/testutil"
 	usecasestaging "github.com/mpyw/suve/internal/usecase/staging"
@@ -230,6 +233,49 @@ func TestApplyUseCase_Execute_FilterByName_NotStaged(t *testing.T) {
 	assert.Contains(t, err.Error(), "not staged")
 }
 
+func TestApplyUseCase_Execute_Select(t *testing.T) {
+	t.Parallel()
+
+	store := testutil.NewMockStore()
+	require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/db/host"}, staging.Entry{
+		Operation: staging.OperationDelete,
+		StagedAt:  time.Now(),
+	}))
+	require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/db/legacy"}, staging.Entry{
+		Operation: staging.OperationDelete,
+		StagedAt:  time.Now(),
+	}))
+	require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/db/user"}, staging.Entry{
+		Operation: staging.OperationUpdate,
+		Value:     lo.ToPtr("admin"),
+		StagedAt:  time.Now(),
+	}))
+
+	uc := &usecasestaging.ApplyUseCase{
+		Strategy: newMockApplyStrategy(),
+		Store:    store,
+	}
+
+	output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{
+		IgnoreConflicts: true,
+		Select: staging.Selector{
+			Match:      []string{"/app/db/*"},
+			Exclude:    []string{"/app/db/legacy"},
+			Operations: []staging.Operation{staging.OperationDelete},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.EntryResults, 1)
+	assert.Equal(t, "/app/db/host", output.EntryResults[0].Name)
+
+	// Everything not selected stays staged.
+	entries, err := store.ListEntries(t.Context(), staging.ServiceParam)
+	require.NoError(t, err)
+	assert.ElementsMatch(t,
+		[]staging.EntryKey{{Name: "/app/db/legacy"}, {Name: "/app/db/user"}},
+		staging.SortedEntryKeys(entries[staging.ServiceParam]))
+}
+
 func TestApplyUseCase_Execute_PartialFailure(t *testing.T) {
 	t.Parallel()
 
@@ -710,3 +756,193 @@ func TestApplyUseCase_Execute_PerNamespaceResolver(t *testing.T) {
 	got := []string{output.EntryResults[0].Namespace, output.EntryResults[1].Namespace}
 	assert.ElementsMatch(t, []string{"dev", "prd"}, got)
 }
+
+// =============================================================================
+// Change Message Tests
+// =============================================================================
+
+// mockMessageStrategy records the change message each write saw and the tags
+// applied per name; tagErr fails every tag write.
+type mockMessageStrategy struct {
+	*mockApplyStrategy
+
+	mu       sync.Mutex
+	messages map[string]string
+	tags     map[string]map[string]string
+	tagErr   error
+}
+
+func (m *mockMessageStrategy) Apply(ctx context.Context, name string, entry staging.Entry) error {
+	m.mu.Lock()
+	m.messages[name] = staging.ChangeMessageFrom(ctx)
+	m.mu.Unlock()
+
+	return m.mockApplyStrategy.Apply(ctx, name, entry)
+}
+
+func (m *mockMessageStrategy) ApplyTags(_ context.Context, name string, tagEntry staging.TagEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.tagErr != nil {
+		return m.tagErr
+	}
+
+	m.tags[name] = tagEntry.Add
+
+	return nil
+}
+
+func TestApplyUseCase_Execute_ChangeMessage(t *testing.T) {
+	t.Parallel()
+
+	store := testutil.NewMockStore()
+	require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/new"}, staging.Entry{
+		Operation: staging.OperationCreate,
+		Value:     lo.ToPtr("v"),
+		StagedAt:  time.Now(),
+	}))
+	require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/old"}, staging.Entry{
+		Operation: staging.OperationDelete,
+		StagedAt:  time.Now(),
+	}))
+
+	strategy := &mockMessageStrategy{
+		mockApplyStrategy: newMockApplyStrategy(),
+		messages:          make(map[string]string),
+		tags:              make(map[string]map[string]string),
+	}
+
+	uc := &usecasestaging.ApplyUseCase{Strategy: strategy, Store: store}
+
+	output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{
+		IgnoreConflicts: true,
+		Message:         "rotate db creds",
+		MessageTag:      "change-reason",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, output.EntrySucceeded)
+
+	assert.Equal(t, "rotate db creds", strategy.messages["/app/new"], "writes see the message")
+	assert.Equal(t, map[string]string{"change-reason": "rotate db creds"}, strategy.tags["/app/new"])
+	assert.NotContains(t, strategy.tags, "/app/old", "a deleted entry is not tagged")
+}
+
+func TestApplyUseCase_Execute_ChangeMessageTagFailure(t *testing.T) {
+	t.Parallel()
+
+	key := staging.EntryKey{Name: "/app/new"}
+
+	store := testutil.NewMockStore()
+	require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, key, staging.Entry{
+		Operation: staging.OperationCreate,
+		Value:     lo.ToPtr("v"),
+		StagedAt:  time.Now(),
+	}))
+
+	strategy := &mockMessageStrategy{
+		mockApplyStrategy: newMockApplyStrategy(),
+		messages:          make(map[string]string),
+		tags:              make(map[string]map[string]string),
+		tagErr:            errors.New("tag quota exceeded"),
+	}
+
+	uc := &usecasestaging.ApplyUseCase{Strategy: strategy, Store: store}
+
+	output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{
+		IgnoreConflicts: true,
+		Message:         "rotate db creds",
+		MessageTag:      "change-reason",
+	})
+	require.NoError(t, err, "the written value is not failed by its tag")
+	assert.Equal(t, 1, output.EntrySucceeded)
+	require.Len(t, output.EntryResults, 1)
+	assert.Equal(t, usecasestaging.ApplyResultCreated, output.EntryResults[0].Status)
+	require.ErrorContains(t, output.EntryResults[0].MessageTagError, "tag quota exceeded")
+
+	_, err = store.GetEntry(t.Context(), staging.ServiceParam, key)
+	require.ErrorIs(t, err, staging.ErrNotStaged, "the written entry is unstaged so it is not written twice")
+}
+
+// mockOrderStrategy records the order of its writes and throttles the first
+// write of each name in throttle.
+type mockOrderStrategy struct {
+	*mockApplyStrategy
+
+	mu        sync.Mutex
+	applied   []string
+	throttle  map[string]bool
+	throttled map[string]int
+}
+
+func (m *mockOrderStrategy) Apply(_ context.Context, name string, _ staging.Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.throttle[name] && m.throttled[name] == 0 {
+		m.throttled[name]++
+
+		return fmt.Errorf("%w: %s", provider.ErrThrottled, name)
+	}
+
+	m.applied = append(m.applied, name)
+
+	return nil
+}
+
+func TestApplyUseCase_Execute_OrderAndThrottling(t *testing.T) {
+	t.Parallel()
+
+	store := testutil.NewMockStore()
+	for name, op := range map[string]staging.Operation{
+		"/app/old":    staging.OperationDelete,
+		"/app/new":    staging.OperationCreate,
+		"/app/host":   staging.OperationUpdate,
+		"/shared/url": staging.OperationUpdate,
+	} {
+		require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: name}, staging.Entry{
+			Operation: op,
+			Value:     lo.ToPtr("v"),
+			StagedAt:  time.Now(),
+		}))
+	}
+
+	strategy := &mockOrderStrategy{
+		mockApplyStrategy: newMockApplyStrategy(),
+		throttle:          map[string]bool{"/app/new": true},
+		throttled:         make(map[string]int),
+	}
+
+	uc := &usecasestaging.ApplyUseCase{
+		Strategy: strategy,
+		Store:    store,
+		Retry:    retry.Policy{MaxAttempts: 3, Sleep: func(context.Context, time.Duration) error { return nil }},
+	}
+
+	var (
+		queued  int
+		retried []string
+	)
+
+	ctx := staging.WithApplyProgress(t.Context(), func(ev staging.ApplyEvent) {
+		switch ev.Kind {
+		case staging.ApplyEventQueued:
+			queued++
+		case staging.ApplyEventRetrying:
+			retried = append(retried, ev.Key.Name)
+		case staging.ApplyEventStarted, staging.ApplyEventFinished:
+		}
+	})
+
+	output, err := uc.Execute(ctx, usecasestaging.ApplyInput{
+		IgnoreConflicts: true,
+		Order:           staging.ApplyOrder{Patterns: []string{"/shared"}},
+		Concurrency:     1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, output.EntrySucceeded)
+
+	assert.Equal(t, []string{"/shared/url", "/app/new", "/app/host", "/app/old"}, strategy.applied)
+	assert.Equal(t, 4, queued)
+	assert.Equal(t, []string{"/app/new"}, retried)
+}
@@ -48,17 +48,17 @@ func (u *DeleteUseCase) Execute(ctx context.Context, input DeleteInput) (*Delete
 		}
 	}
 
-	// Fetch LastModified to determine existence (and, for existing resources, to
+	// Fetch the remote state to determine existence (and, for existing resources, to
 	// record a conflict-detection base). Existence is inferred from the ERROR,
 	// not from the returned timestamp: a resource that exists but carries no
 	// modification time still yields a zero time and must NOT be misread as
 	// "not found".
 	var (
-		lastModified time.Time
+		remote       staging.RemoteState
 		currentValue *string
 	)
 
-	fetched, err := u.Strategy.FetchLastModified(ctx, input.Key.Name)
+	fetched, err := staging.FetchRemoteState(ctx, u.Strategy, input.Key.Name)
 	if err != nil {
 		// ResourceNotFoundError means the resource doesn't exist; leave
 		// currentValue nil so the reducer either errors (nothing to delete) or
@@ -70,7 +70,7 @@ func (u *DeleteUseCase) Execute(ctx context.Context, input DeleteInput) (*Delete
 		// Resource exists on AWS - a non-nil currentValue signals existence to
 		// the reducer. A zero fetched time here means "exists, modification time
 		// unknown" and is preserved as-is.
-		lastModified = fetched
+		remote = fetched
 		currentValue = new(string)
 	}
 
@@ -101,7 +101,7 @@ func (u *DeleteUseCase) Execute(ctx context.Context, input DeleteInput) (*Delete
 	} else {
 		// Stage delete with options (single persist)
 		if err := u.stageDeleteWithOptions(
-			ctx, service, key, lastModified, hasDeleteOptions, input.Force, input.RecoveryWindow,
+			ctx, service, key, remote, hasDeleteOptions, input.Force, input.RecoveryWindow,
 		); err != nil {
 			return nil, err
 		}
@@ -136,13 +136,12 @@ func (u *DeleteUseCase) Execute(ctx context.Context, input DeleteInput) (*Delete
 // stageDeleteWithOptions stages a delete entry with optional delete options.
 //
 //nolint:lll // function parameters are descriptive for clarity
-func (u *DeleteUseCase) stageDeleteWithOptions(ctx context.Context, service staging.Service, key staging.EntryKey, lastModified time.Time, hasDeleteOptions, force bool, recoveryWindow int) error {
+func (u *DeleteUseCase) stageDeleteWithOptions(ctx context.Context, service staging.Service, key staging.EntryKey, remote staging.RemoteState, hasDeleteOptions, force bool, recoveryWindow int) error {
 	entry := staging.Entry{
-		Operation: staging.OperationDelete,
-		StagedAt:  time.Now(),
-	}
-	if !lastModified.IsZero() {
-		entry.BaseModifiedAt = &lastModified
+		Operation:      staging.OperationDelete,
+		StagedAt:       time.Now(),
+		BaseModifiedAt: anchor(remote.LastModified),
+		BaseVersion:    remote.Version,
 	}
 
 	if hasDeleteOptions {
@@ -17,6 +17,9 @@ import (
 // DiffInput holds input for the diff use case.
 type DiffInput struct {
 	Name string // Optional: diff only this item
+	// Select narrows the diff to the staged changes it selects (all of them
+	// when zero).
+	Select staging.Selector
 }
 
 // DiffEntryType represents the type of diff entry.
@@ -147,6 +150,9 @@ func (u *DiffUseCase) Execute(ctx context.Context, input DiffInput) (*DiffOutput
 		tagEntries = filteredTags
 	}
 
+	entries = input.Select.SelectEntries(entries)
+	tagEntries = input.Select.SelectTags(tagEntries)
+
 	// Process entries
 	if len(entries) > 0 {
 		// Fetch all current values in parallel, each through the strategy scoped
@@ -3,7 +3,6 @@ package staging
 import (
 	"context"
 	"errors"
-	"time"
 	"unicode/utf8"
 
 	"github.com/samber/lo"
@@ -27,6 +26,12 @@ type EditInput struct {
 	// existing (staged or cloud) type, so callers that do not set it keep the
 	// prior type-preserving behavior.
 	ValueType domain.ValueType
+	// Attributes are Azure Key Vault secret attributes for the staged update;
+	// nil keeps a previously staged set (and stages none otherwise).
+	Attributes *staging.SecretAttributes
+	// Force stages the update even when Value equals the current value, so a
+	// change carried only by Attributes is not skipped.
+	Force bool
 }
 
 // EditOutput holds the result of the edit use case.
@@ -74,43 +79,44 @@ func (u *EditUseCase) Execute(ctx context.Context, input EditInput) (*EditOutput
 	// Determine if we need to fetch from AWS
 	var currentValue *string
 
-	var awsBaseModifiedAt *time.Time
+	var awsBase transition.EntryBase
 
 	if stagedEntry != nil && stagedEntry.Operation == staging.OperationCreate {
 		// Staged as Create → resource doesn't exist in AWS, skip fetch
 		currentValue = nil
-		awsBaseModifiedAt = nil
 	} else {
 		// Not staged or staged as Update/Delete → fetch from AWS
 		var err error
 
-		currentValue, awsBaseModifiedAt, err = u.fetchCurrentState(ctx, key.Name)
+		currentValue, awsBase, err = u.fetchCurrentState(ctx, key.Name)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	// Build entry state from already-fetched data (avoid redundant GetEntry call)
-	entryState, existingBaseModifiedAt := u.buildEntryState(stagedEntry, currentValue)
+	entryState, base := u.buildEntryState(stagedEntry, currentValue)
 
-	// Use existing BaseModifiedAt if available, otherwise use AWS
-	baseModifiedAt := existingBaseModifiedAt
-	if baseModifiedAt == nil {
-		baseModifiedAt = awsBaseModifiedAt
+	// Use the existing base if available, otherwise use AWS
+	if base.IsZero() {
+		base = awsBase
 	}
 
 	// Resolve the staged value type. When the caller specifies none, preserve a
 	// previously staged type so a follow-up edit never silently downgrades it;
 	// aws_param then falls back to the existing cloud type when this stays empty.
-	valueType := input.ValueType
-	if valueType == "" && stagedEntry != nil {
-		valueType = stagedEntry.ValueType
+	valueType, attributes := input.ValueType, input.Attributes
+	if stagedEntry != nil {
+		valueType = lo.CoalesceOrEmpty(valueType, stagedEntry.ValueType)
+		attributes = lo.CoalesceOrEmpty(attributes, stagedEntry.Attributes)
 	}
 
 	// Build options with metadata
 	opts := &transition.EntryExecuteOptions{
-		BaseModifiedAt: baseModifiedAt,
+		BaseModifiedAt: base.ModifiedAt,
+		BaseVersion:    base.Version,
 		ValueType:      valueType,
+		Attributes:     attributes,
 	}
 	if input.Description != "" {
 		opts.Description = &input.Description
@@ -120,7 +126,7 @@ func (u *EditUseCase) Execute(ctx context.Context, input EditInput) (*EditOutput
 	executor := transition.NewExecutor(u.Store)
 	_, wasNotStaged := entryState.StagedState.(transition.EntryStagedStateNotStaged)
 
-	result, err := executor.ExecuteEntry(ctx, service, key, entryState, transition.EntryActionEdit{Value: input.Value}, opts)
+	result, err := executor.ExecuteEntry(ctx, service, key, entryState, transition.EntryActionEdit{Value: input.Value, Force: input.Force}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -139,16 +145,16 @@ func (u *EditUseCase) Execute(ctx context.Context, input EditInput) (*EditOutput
 }
 
 // buildEntryState constructs EntryState from already-fetched staged entry and AWS value.
-func (u *EditUseCase) buildEntryState(stagedEntry *staging.Entry, currentAWSValue *string) (transition.EntryState, *time.Time) {
+func (u *EditUseCase) buildEntryState(stagedEntry *staging.Entry, currentAWSValue *string) (transition.EntryState, transition.EntryBase) {
 	state := transition.EntryState{
 		CurrentValue: currentAWSValue,
 		StagedState:  transition.EntryStagedStateNotStaged{},
 	}
 
-	var baseModifiedAt *time.Time
+	var base transition.EntryBase
 
 	if stagedEntry != nil {
-		baseModifiedAt = stagedEntry.BaseModifiedAt
+		base = transition.EntryBase{ModifiedAt: stagedEntry.BaseModifiedAt, Version: stagedEntry.BaseVersion}
 
 		switch stagedEntry.Operation {
 		case staging.OperationCreate:
@@ -164,25 +170,20 @@ func (u *EditUseCase) buildEntryState(stagedEntry *staging.Entry, currentAWSValu
 		}
 	}
 
-	return state, baseModifiedAt
+	return state, base
 }
 
-// fetchCurrentState fetches the current AWS value and last modified time.
-func (u *EditUseCase) fetchCurrentState(ctx context.Context, name string) (*string, *time.Time, error) {
+// fetchCurrentState fetches the current AWS value, last modified time and version.
+func (u *EditUseCase) fetchCurrentState(ctx context.Context, name string) (*string, transition.EntryBase, error) {
 	result, err := u.Strategy.FetchCurrentValue(ctx, name)
 	if err != nil {
-		return nil, nil, err
+		return nil, transition.EntryBase{}, err
 	}
 
 	// Always use the value pointer - empty string is a valid AWS value
 	currentValue := &result.Value
 
-	var baseModifiedAt *time.Time
-	if !result.LastModified.IsZero() {
-		baseModifiedAt = &result.LastModified
-	}
-
-	return currentValue, baseModifiedAt, nil
+	return currentValue, transition.EntryBase{ModifiedAt: anchor(result.LastModified), Version: result.Version}, nil
 }
 
 // BaselineInput holds input for getting baseline value.
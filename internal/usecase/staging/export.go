@@ -33,6 +33,9 @@ type ExportInput struct {
 	Service staging.Service
 	// Keep preserves the working staging area after exporting.
 	Keep bool
+	// Select narrows the export to the staged changes it selects (all of them
+	// when zero); only those are cleared from the working staging area.
+	Select staging.Selector
 }
 
 // ExportOutput holds the result of the export use case.
@@ -66,7 +69,7 @@ func (u *ExportUseCase) Execute(ctx context.Context, input ExportInput) (*Export
 	// Determine which services actually have data to export, along with their
 	// extracted per-service state. A service filter narrows to that one service;
 	// otherwise every non-empty service is written.
-	targets := exportTargets(input.Service, workingState)
+	targets := exportTargets(input.Service, input.Select, workingState)
 	if len(targets) == 0 {
 		return nil, ErrNothingToExport
 	}
@@ -116,9 +119,10 @@ type exportTarget struct {
 }
 
 // exportTargets returns the services that should be exported together with their
-// extracted state. With a service filter it is that single service when it has
-// data; otherwise it is every service (param, secret) whose state is non-empty.
-func exportTargets(service staging.Service, state *staging.State) []exportTarget {
+// extracted state, narrowed by sel. With a service filter it is that single
+// service when it has data; otherwise it is every service (param, secret) whose
+// state is non-empty.
+func exportTargets(service staging.Service, sel staging.Selector, state *staging.State) []exportTarget {
 	services := []staging.Service{staging.ServiceParam, staging.ServiceSecret}
 	if service != "" {
 		services = []staging.Service{service}
@@ -127,7 +131,7 @@ func exportTargets(service staging.Service, state *staging.State) []exportTarget
 	var targets []exportTarget
 
 	for _, svc := range services {
-		svcState := state.ExtractService(svc)
+		svcState := sel.SelectState(state.ExtractService(svc))
 		if svcState.IsEmpty() {
 			continue
 		}
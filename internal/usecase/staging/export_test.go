@@ -217,6 +217,29 @@ func TestExportUseCase_Execute(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("selector exports and clears only the selected changes", func(t *testing.T) {
+		t.Parallel()
+
+		working := testutil.NewMockStore()
+		stageEntry(t, working, staging.ServiceParam, "/app/db/host", "h")
+		stageEntry(t, working, staging.ServiceParam, "/app/web/host", "w")
+
+		writer := &recordingWriter{}
+		usecase := &stagingusecase.ExportUseCase{Working: working, Target: writer}
+
+		output, err := usecase.Execute(t.Context(), stagingusecase.ExportInput{
+			Select: staging.Selector{Match: []string{"/app/db/*"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, output.EntryCount)
+		assert.Contains(t, writer.written[staging.ServiceParam].Entries[staging.ServiceParam], staging.EntryKey{Name: "/app/db/host"})
+
+		_, err = working.GetEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/db/host"})
+		require.ErrorIs(t, err, staging.ErrNotStaged)
+		_, err = working.GetEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/web/host"})
+		require.NoError(t, err)
+	})
+
 	t.Run("counts entries and tags", func(t *testing.T) {
 		t.Parallel()
 
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/mpyw/suve/internal/staging"
 	"github.com/mpyw/suve/internal/staging/store"
@@ -136,21 +135,23 @@ func (u *ImportUseCase) Execute(ctx context.Context, input ImportInput) (*Import
 	return output, nil
 }
 
-// reAnchorState re-bases every staged item that carries a BaseModifiedAt onto
-// the target scope's current LastModified, mutating sourceState in place. A
-// Create entry carries no base and is left untouched. When the target resource
-// does not exist (or reports no modification time) the item is left unanchored
-// (BaseModifiedAt = nil) so it never trips a spurious conflict against a
+// reAnchorState re-bases every staged item that carries a base (BaseModifiedAt
+// or BaseVersion) onto the target scope's current LastModified and version,
+// mutating sourceState in place. A Create entry carries no base and is left
+// untouched. When the target resource does not exist (or reports neither a
+// modification time nor a version) the item is left unanchored so it never trips a spurious conflict against a
 // timeline it never belonged to; a genuine fetch failure is recorded as a
 // warning and likewise unanchors the item rather than aborting the whole import.
 func (u *ImportUseCase) reAnchorState(ctx context.Context, sourceState *staging.State, output *ImportOutput) {
 	for svc, entries := range sourceState.Entries {
 		for key, entry := range entries {
-			if entry.BaseModifiedAt == nil {
+			if entry.BaseModifiedAt == nil && entry.BaseVersion == "" {
 				continue
 			}
 
-			entry.BaseModifiedAt = u.currentBase(ctx, svc, key, output)
+			remote := u.currentBase(ctx, svc, key, output)
+			entry.BaseModifiedAt = anchor(remote.LastModified)
+			entry.BaseVersion = remote.Version
 			entries[key] = entry
 		}
 	}
@@ -161,28 +162,28 @@ func (u *ImportUseCase) reAnchorState(ctx context.Context, sourceState *staging.
 				continue
 			}
 
-			tag.BaseModifiedAt = u.currentBase(ctx, svc, key, output)
+			tag.BaseModifiedAt = anchor(u.currentBase(ctx, svc, key, output).LastModified)
 			tags[key] = tag
 		}
 	}
 }
 
-// currentBase fetches key's current LastModified in the target scope and returns
-// it as a conflict-detection base, or nil when the resource is missing, reports
-// no modification time, or cannot be fetched. A fetch failure (anything other
-// than a not-found) appends a warning to output.
+// currentBase fetches key's current remote state in the target scope as a
+// conflict-detection base, or the zero state when the resource is missing or
+// cannot be fetched. A fetch failure (anything other than a not-found) appends
+// a warning to output.
 func (u *ImportUseCase) currentBase(
 	ctx context.Context, svc staging.Service, key staging.EntryKey, output *ImportOutput,
-) *time.Time {
+) staging.RemoteState {
 	strategy, err := u.ReAnchor(svc, key.Namespace)
 	if err != nil {
 		output.Warnings = append(output.Warnings, fmt.Sprintf(
 			"could not re-anchor %s: %v; left unanchored (conflict detection skipped)", key.Label(), err))
 
-		return nil
+		return staging.RemoteState{}
 	}
 
-	lastModified, err := strategy.FetchLastModified(ctx, key.Name)
+	remote, err := staging.FetchRemoteState(ctx, strategy, key.Name)
 	if err != nil {
 		// A missing resource has no base to anchor to; leave it unanchored without
 		// a warning. Any other error is surfaced so the unanchored item is visible.
@@ -191,16 +192,12 @@ func (u *ImportUseCase) currentBase(
 				"could not re-anchor %s: %v; left unanchored (conflict detection skipped)", key.Label(), err))
 		}
 
-		return nil
-	}
-
-	if lastModified.IsZero() {
-		// The resource exists but carries no modification time (or the provider
-		// disables conflict detection): there is nothing to anchor to.
-		return nil
+		return staging.RemoteState{}
 	}
 
-	return &lastModified
+	// A resource that carries no modification time or version (or a provider
+	// that disables conflict detection) yields the zero state: nothing to anchor to.
+	return remote
 }
 
 // reconcileImport merges sourceState into workingState in place per the import
@@ -0,0 +1,162 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mpyw/suve/internal/parallel"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store"
+)
+
+// ApplyJournal is the apply journal kept next to a working store: a record of
+// each apply, addressed by ID.
+type ApplyJournal interface {
+	// AppendJournal records an apply, filling in its ID when empty.
+	AppendJournal(ctx context.Context, rec *staging.ApplyRecord) error
+	// ReadJournal returns the record of an apply.
+	ReadJournal(ctx context.Context, id string) (*staging.ApplyRecord, error)
+}
+
+// journal records the changes an apply made in u.Journal and reports the
+// outcome on output. Nothing is recorded when the apply made no change or the
+// previous states could not be captured (output.JournalError then says why);
+// changes an atomic apply rolled back are left out.
+func (u *ApplyUseCase) journal(
+	ctx context.Context, service staging.Service, entries map[staging.EntryKey]staging.Entry,
+	tags map[staging.EntryKey]staging.TagEntry, snapshots map[staging.EntryKey]staging.Snapshot, output *ApplyOutput,
+) {
+	if u.Journal == nil || snapshots == nil {
+		return
+	}
+
+	rolledBack := make(map[staging.EntryKey]struct{})
+
+	for _, result := range output.Rollbacks {
+		if result.Error == nil {
+			rolledBack[staging.EntryKey{Name: result.Name, Namespace: result.Namespace}] = struct{}{}
+		}
+	}
+
+	rec := &staging.ApplyRecord{Message: staging.ChangeMessageFrom(ctx)}
+
+	for _, result := range output.EntryResults {
+		key := staging.EntryKey{Name: result.Name, Namespace: result.Namespace}
+		if _, ok := rolledBack[key]; result.Error == nil && !ok {
+			rec.RecordEntry(service, key, entries[key].Operation, snapshots[key])
+		}
+	}
+
+	for _, result := range output.TagResults {
+		key := staging.EntryKey{Name: result.Name, Namespace: result.Namespace}
+		if _, ok := rolledBack[key]; result.Error == nil && !ok {
+			rec.RecordTags(service, key, tags[key], snapshots[key])
+		}
+	}
+
+	if len(rec.Items) == 0 {
+		return
+	}
+
+	u.fetchNewVersions(ctx, rec)
+
+	if err := u.Journal.AppendJournal(ctx, rec); err != nil {
+		output.JournalError = err
+
+		return
+	}
+
+	output.JournalID = rec.ID
+}
+
+// fetchNewVersions fills in the version each recorded entry is at after the
+// apply. It is best-effort: an entry whose state cannot be read is recorded
+// without one.
+func (u *ApplyUseCase) fetchNewVersions(ctx context.Context, rec *staging.ApplyRecord) {
+	indices := make(map[int]staging.EntryKey, len(rec.Items))
+
+	for i, item := range rec.Items {
+		if item.Operation != staging.OperationDelete {
+			indices[i] = item.Key()
+		}
+	}
+
+	results := parallel.ExecuteMap(ctx, indices, func(ctx context.Context, _ int, key staging.EntryKey) (string, error) {
+		rollbacker, err := RollbackerFor(u.strategyForNamespace, key.Namespace)
+		if err != nil {
+			return "", err
+		}
+
+		snap, err := rollbacker.Snapshot(ctx, key.Name)
+
+		return snap.Version, err
+	})
+
+	for i, result := range results {
+		if result.Err == nil {
+			rec.Items[i].NewVersion = result.Value
+		}
+	}
+}
+
+// UndoInput holds input for the undo use case.
+type UndoInput struct {
+	// ID identifies the apply to undo.
+	ID string
+}
+
+// UndoOutput holds the result of the undo use case.
+type UndoOutput struct {
+	// Record is the apply being undone.
+	Record *staging.ApplyRecord
+	// EntryCount is the number of entries staged.
+	EntryCount int
+	// TagCount is the number of tag entries staged.
+	TagCount int
+	// Conflicts lists the staged inverse changes that replaced (entries) or
+	// were merged into (tag changes) changes already staged, sorted as for
+	// stash pop.
+	Conflicts []StashConflict
+}
+
+// ErrNothingToUndo is returned when an apply recorded no change that can be
+// undone.
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// UndoUseCase stages the inverse of a recorded apply (see
+// staging.ApplyRecord.InverseState) so it can be reviewed and applied.
+type UndoUseCase struct {
+	Working store.WorkingStore
+	Journal ApplyJournal
+}
+
+// Execute runs the undo use case. The inverse changes are merged into the
+// working area in one atomic read-modify-write, with stash pop semantics.
+func (u *UndoUseCase) Execute(ctx context.Context, input UndoInput) (*UndoOutput, error) {
+	rec, err := u.Journal.ReadJournal(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	inverse := rec.InverseState(time.Now())
+	if inverse.IsEmpty() {
+		return nil, fmt.Errorf("%w: apply %s", ErrNothingToUndo, rec.ID)
+	}
+
+	output := &UndoOutput{Record: rec, EntryCount: inverse.EntryCount(), TagCount: inverse.TagCount()}
+
+	err = u.Working.Update(ctx, "", func(working *staging.State) error {
+		output.Conflicts = stashConflicts(working, inverse)
+
+		reconcileImport(working, inverse, ImportInput{Mode: ImportModeMerge}, &ImportOutput{})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,144 @@
+package staging_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/testutil"
+	usecasestaging "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// memoryJournal is an in-memory ApplyJournal.
+type memoryJournal map[string]*staging.ApplyRecord
+
+func (m memoryJournal) AppendJournal(_ context.Context, rec *staging.ApplyRecord) error {
+	if rec.ID == "" {
+		rec.ID = fmt.Sprintf("%020d", len(m)+1)
+	}
+
+	m[rec.ID] = rec
+
+	return nil
+}
+
+func (m memoryJournal) ReadJournal(_ context.Context, id string) (*staging.ApplyRecord, error) {
+	rec, ok := m[id]
+	if !ok {
+		return nil, fmt.Errorf("no apply %s", id)
+	}
+
+	return rec, nil
+}
+
+func TestApplyUseCase_Execute_Journal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records the applied changes with their previous state", func(t *testing.T) {
+		t.Parallel()
+
+		store := testutil.NewMockStore()
+		stageUpdates(t, store, "/app/a", "/app/b")
+
+		journal := memoryJournal{}
+		uc := &usecasestaging.ApplyUseCase{Strategy: newMockRollbackStrategy(), Store: store, Journal: journal}
+
+		output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{IgnoreConflicts: true, Message: "rotate creds"})
+		require.NoError(t, err)
+		require.NoError(t, output.JournalError)
+		require.Contains(t, journal, output.JournalID)
+
+		rec := journal[output.JournalID]
+		assert.Equal(t, "rotate creds", rec.Message)
+		require.Len(t, rec.Items, 2)
+		assert.Equal(t, "/app/a", rec.Items[0].Name)
+		assert.Equal(t, staging.OperationUpdate, rec.Items[0].Operation)
+		assert.Equal(t, "old-/app/a", rec.Items[0].Previous.Value)
+	})
+
+	t.Run("leaves out failed and rolled back changes", func(t *testing.T) {
+		t.Parallel()
+
+		store := testutil.NewMockStore()
+		stageUpdates(t, store, "/app/a", "/app/b", "/app/fail")
+
+		strategy := newMockRollbackStrategy()
+		strategy.applyErrors["/app/fail"] = errors.New("aws error")
+		strategy.rollbackErrs["/app/b"] = errors.New("access denied")
+
+		journal := memoryJournal{}
+		uc := &usecasestaging.ApplyUseCase{Strategy: strategy, Store: store, Journal: journal}
+
+		output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{IgnoreConflicts: true, Atomic: true})
+		require.Error(t, err)
+
+		rec := journal[output.JournalID]
+		require.NotNil(t, rec)
+		require.Len(t, rec.Items, 1, "only the change whose rollback failed is still applied")
+		assert.Equal(t, "/app/b", rec.Items[0].Name)
+	})
+
+	t.Run("a snapshot failure skips the journal but still applies", func(t *testing.T) {
+		t.Parallel()
+
+		store := testutil.NewMockStore()
+		stageUpdates(t, store, "/app/a")
+
+		strategy := newMockRollbackStrategy()
+		strategy.snapshotErr = errors.New("throttled")
+
+		journal := memoryJournal{}
+		uc := &usecasestaging.ApplyUseCase{Strategy: strategy, Store: store, Journal: journal}
+
+		output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{IgnoreConflicts: true})
+		require.NoError(t, err)
+		assert.Equal(t, 1, output.EntrySucceeded)
+		require.ErrorContains(t, output.JournalError, "previous state not captured")
+		assert.Empty(t, journal)
+	})
+}
+
+func TestUndoUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stages the inverse and reports replaced changes", func(t *testing.T) {
+		t.Parallel()
+
+		journal := memoryJournal{"1": {
+			ID: "1",
+			Items: []staging.AppliedItem{{
+				Service: staging.ServiceParam, Name: "/app/a", Operation: staging.OperationUpdate,
+				Previous: staging.Snapshot{Exists: true, Value: "old"},
+			}},
+		}}
+
+		working := testutil.NewMockStore()
+		stageEntry(t, working, staging.ServiceParam, "/app/a", "staged")
+
+		output, err := (&usecasestaging.UndoUseCase{Working: working, Journal: journal}).
+			Execute(t.Context(), usecasestaging.UndoInput{ID: "1"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, output.EntryCount)
+		require.Len(t, output.Conflicts, 1)
+
+		entry, err := working.GetEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/a"})
+		require.NoError(t, err)
+		assert.Equal(t, "old", lo.FromPtr(entry.Value))
+	})
+
+	t.Run("nothing to undo", func(t *testing.T) {
+		t.Parallel()
+
+		journal := memoryJournal{"1": {ID: "1"}}
+
+		_, err := (&usecasestaging.UndoUseCase{Working: testutil.NewMockStore(), Journal: journal}).
+			Execute(t.Context(), usecasestaging.UndoInput{ID: "1"})
+		require.ErrorIs(t, err, usecasestaging.ErrNothingToUndo)
+	})
+}
@@ -0,0 +1,119 @@
+package staging_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/testutil"
+	usecasestaging "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// lockingApplyStrategy is a mockApplyStrategy whose entries can be locked.
+type lockingApplyStrategy struct {
+	*mockApplyStrategy
+
+	mu     sync.Mutex
+	locked map[string]bool
+	calls  []string
+}
+
+func (m *lockingApplyStrategy) Apply(ctx context.Context, name string, entry staging.Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, "apply "+name)
+
+	if m.locked[name] {
+		return provider.ErrLocked
+	}
+
+	return m.mockApplyStrategy.Apply(ctx, name, entry)
+}
+
+func (m *lockingApplyStrategy) FetchLocked(_ context.Context, name string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.locked[name], nil
+}
+
+func (m *lockingApplyStrategy) Lock(_ context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, "lock "+name)
+	m.locked[name] = true
+
+	return nil
+}
+
+func (m *lockingApplyStrategy) Unlock(_ context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, "unlock "+name)
+	m.locked[name] = false
+
+	return nil
+}
+
+func stageLockFixture(t *testing.T) *testutil.MockStore {
+	t.Helper()
+
+	store := testutil.NewMockStore()
+	for _, name := range []string{"app/locked", "app/open"} {
+		require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: name}, staging.Entry{
+			Operation: staging.OperationUpdate,
+			Value:     lo.ToPtr("v"),
+			StagedAt:  time.Now(),
+		}))
+	}
+
+	return store
+}
+
+func TestApplyUseCase_Execute_LockedReported(t *testing.T) {
+	t.Parallel()
+
+	strategy := &lockingApplyStrategy{mockApplyStrategy: newMockApplyStrategy(), locked: map[string]bool{"app/locked": true}}
+	uc := &usecasestaging.ApplyUseCase{Strategy: strategy, Store: stageLockFixture(t)}
+
+	output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{IgnoreConflicts: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 locked")
+	assert.Equal(t, []staging.EntryKey{{Name: "app/locked"}}, output.Locked)
+	assert.Equal(t, 1, output.EntrySucceeded)
+	assert.Equal(t, 1, output.EntryFailed)
+
+	for _, r := range output.EntryResults {
+		if r.Name == "app/locked" {
+			require.ErrorIs(t, r.Error, provider.ErrLocked)
+		}
+	}
+
+	assert.Equal(t, []string{"apply app/open"}, strategy.calls, "a locked entry is not attempted")
+}
+
+func TestApplyUseCase_Execute_UnlockLocked(t *testing.T) {
+	t.Parallel()
+
+	strategy := &lockingApplyStrategy{mockApplyStrategy: newMockApplyStrategy(), locked: map[string]bool{"app/locked": true}}
+	uc := &usecasestaging.ApplyUseCase{Strategy: strategy, Store: stageLockFixture(t)}
+
+	output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{IgnoreConflicts: true, UnlockLocked: true})
+	require.NoError(t, err)
+	assert.Empty(t, output.Locked)
+	assert.Equal(t, 2, output.EntrySucceeded)
+	assert.True(t, strategy.locked["app/locked"], "the entry is locked again after the write")
+
+	lockedCalls := lo.Filter(strategy.calls, func(c string, _ int) bool { return c != "apply app/open" })
+	assert.Equal(t, []string{"unlock app/locked", "apply app/locked", "lock app/locked"}, lockedCalls)
+}
@@ -0,0 +1,117 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store"
+)
+
+// ErrAlreadyStagedInTarget is returned when a moved item is already staged in
+// the destination changeset; moving would silently overwrite it.
+var ErrAlreadyStagedInTarget = errors.New("already staged in the target changeset")
+
+// MoveInput holds input for the move use case.
+type MoveInput struct {
+	// Name is the staged name to move. Every namespace it is staged under moves
+	// with it, together with its staged tag changes.
+	Name string
+}
+
+// MoveOutput holds the result of the move use case.
+type MoveOutput struct {
+	Entries []staging.EntryKey // Moved entry keys, sorted
+	Tags    []staging.EntryKey // Moved tag keys, sorted
+}
+
+// Moved reports whether anything was moved.
+func (o *MoveOutput) Moved() bool {
+	return len(o.Entries)+len(o.Tags) > 0
+}
+
+// MoveUseCase moves staged changes of one service from one changeset to another.
+type MoveUseCase struct {
+	Service staging.Service
+	Source  store.ReadWriteOperator
+	Target  store.ReadWriteOperator
+}
+
+// Execute runs the move use case. Conflicts with the target are checked before
+// anything is written, and each item is staged into the target before it is
+// unstaged from the source, so an interrupted move leaves a copy rather than
+// losing the change.
+func (u *MoveUseCase) Execute(ctx context.Context, input MoveInput) (*MoveOutput, error) {
+	entries, err := u.Source.ListEntries(ctx, u.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := u.Source.ListTags(ctx, u.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &MoveOutput{
+		Entries: keysNamed(staging.SortedEntryKeys(entries[u.Service]), input.Name),
+		Tags:    keysNamed(staging.SortedEntryKeys(tags[u.Service]), input.Name),
+	}
+
+	for _, key := range out.Entries {
+		if _, err := u.Target.GetEntry(ctx, u.Service, key); !errors.Is(err, staging.ErrNotStaged) {
+			return nil, targetError(key, err)
+		}
+	}
+
+	for _, key := range out.Tags {
+		if _, err := u.Target.GetTag(ctx, u.Service, key); !errors.Is(err, staging.ErrNotStaged) {
+			return nil, targetError(key, err)
+		}
+	}
+
+	for _, key := range out.Entries {
+		if err := u.Target.StageEntry(ctx, u.Service, key, entries[u.Service][key]); err != nil {
+			return nil, err
+		}
+
+		if err := u.Source.UnstageEntry(ctx, u.Service, key); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range out.Tags {
+		if err := u.Target.StageTag(ctx, u.Service, key, tags[u.Service][key]); err != nil {
+			return nil, err
+		}
+
+		if err := u.Source.UnstageTag(ctx, u.Service, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// keysNamed filters keys down to those with the given name, keeping order.
+func keysNamed(keys []staging.EntryKey, name string) []staging.EntryKey {
+	var out []staging.EntryKey
+
+	for _, key := range keys {
+		if key.Name == name {
+			out = append(out, key)
+		}
+	}
+
+	return out
+}
+
+// targetError maps a target lookup result to the move failure: a nil error
+// means the key is already staged there.
+func targetError(key staging.EntryKey, err error) error {
+	if err == nil {
+		return fmt.Errorf("%s: %w", key.Label(), ErrAlreadyStagedInTarget)
+	}
+
+	return err
+}
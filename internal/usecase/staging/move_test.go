@@ -0,0 +1,84 @@
+package staging_test
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/testutil"
+	usecasestaging "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+func TestMoveUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	t.Run("moves every namespace and its tags", func(t *testing.T) {
+		t.Parallel()
+
+		source := testutil.NewMockStore()
+		target := testutil.NewMockStore()
+		plain := staging.EntryKey{Name: "db-url"}
+		prod := staging.EntryKey{Name: "db-url", Namespace: "prod"}
+		other := staging.EntryKey{Name: "other"}
+
+		source.AddEntry(staging.ServiceParam, plain, staging.Entry{Operation: staging.OperationUpdate, Value: lo.ToPtr("a")})
+		source.AddEntry(staging.ServiceParam, prod, staging.Entry{Operation: staging.OperationDelete})
+		source.AddEntry(staging.ServiceParam, other, staging.Entry{Operation: staging.OperationUpdate, Value: lo.ToPtr("b")})
+		source.AddTag(staging.ServiceParam, plain, staging.TagEntry{Add: map[string]string{"env": "prod"}})
+
+		uc := &usecasestaging.MoveUseCase{Service: staging.ServiceParam, Source: source, Target: target}
+		out, err := uc.Execute(t.Context(), usecasestaging.MoveInput{Name: "db-url"})
+		require.NoError(t, err)
+		assert.True(t, out.Moved())
+		assert.Equal(t, []staging.EntryKey{plain, prod}, out.Entries)
+		assert.Equal(t, []staging.EntryKey{plain}, out.Tags)
+
+		moved, err := target.GetEntry(t.Context(), staging.ServiceParam, prod)
+		require.NoError(t, err)
+		assert.Equal(t, staging.OperationDelete, moved.Operation)
+
+		_, err = target.GetTag(t.Context(), staging.ServiceParam, plain)
+		require.NoError(t, err)
+
+		_, err = source.GetEntry(t.Context(), staging.ServiceParam, plain)
+		require.ErrorIs(t, err, staging.ErrNotStaged)
+
+		_, err = source.GetEntry(t.Context(), staging.ServiceParam, other)
+		require.NoError(t, err)
+	})
+
+	t.Run("nothing staged", func(t *testing.T) {
+		t.Parallel()
+
+		uc := &usecasestaging.MoveUseCase{
+			Service: staging.ServiceParam,
+			Source:  testutil.NewMockStore(),
+			Target:  testutil.NewMockStore(),
+		}
+		out, err := uc.Execute(t.Context(), usecasestaging.MoveInput{Name: "db-url"})
+		require.NoError(t, err)
+		assert.False(t, out.Moved())
+	})
+
+	t.Run("already staged in target", func(t *testing.T) {
+		t.Parallel()
+
+		source := testutil.NewMockStore()
+		target := testutil.NewMockStore()
+		key := staging.EntryKey{Name: "db-url"}
+
+		source.AddEntry(staging.ServiceParam, key, staging.Entry{Operation: staging.OperationUpdate, Value: lo.ToPtr("a")})
+		target.AddEntry(staging.ServiceParam, key, staging.Entry{Operation: staging.OperationUpdate, Value: lo.ToPtr("b")})
+
+		uc := &usecasestaging.MoveUseCase{Service: staging.ServiceParam, Source: source, Target: target}
+		_, err := uc.Execute(t.Context(), usecasestaging.MoveInput{Name: "db-url"})
+		require.ErrorIs(t, err, usecasestaging.ErrAlreadyStagedInTarget)
+
+		kept, err := source.GetEntry(t.Context(), staging.ServiceParam, key)
+		require.NoError(t, err)
+		assert.Equal(t, "a", lo.FromPtr(kept.Value))
+	})
+}
@@ -0,0 +1,196 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mpyw/suve/internal/parallel"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// PlanService is one service's staged changes for a plan.
+type PlanService struct {
+	Service staging.Service
+	// Target is the service's resolved staging scope.
+	Target string
+	// StrategyFor resolves the strategy that reads an item's remote state under
+	// its namespace.
+	StrategyFor func(namespace string) (staging.DiffStrategy, error)
+	Entries     map[staging.EntryKey]staging.Entry
+	Tags        map[staging.EntryKey]staging.TagEntry
+}
+
+// PlanInput holds input for the plan use case.
+type PlanInput struct {
+	// Provider is the provider label recorded in the plan.
+	Provider string
+	// Salt keys the plan's hashes (see staging.PlanHasher); empty draws a fresh
+	// one. Rebuilding a saved plan to check it for drift passes its salt.
+	Salt     string
+	Services []PlanService
+}
+
+// PlanUseCase captures staged changes and their remote base into a plan.
+type PlanUseCase struct {
+	// Now returns the plan's creation time; nil uses time.Now.
+	Now func() time.Time
+}
+
+// Execute reads the remote base of every staged entry and tag change and
+// records them in a plan. Any read other than "not found" fails the plan: a
+// plan with an unknown base could not detect drift.
+func (u *PlanUseCase) Execute(ctx context.Context, input PlanInput) (*staging.Plan, error) {
+	salt := input.Salt
+	if salt == "" {
+		var err error
+		if salt, err = staging.NewPlanSalt(); err != nil {
+			return nil, err
+		}
+	}
+
+	hasher, err := staging.NewPlanHasher(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []staging.PlanItem
+
+	for _, svc := range input.Services {
+		entryItems, err := planEntries(ctx, hasher, svc)
+		if err != nil {
+			return nil, err
+		}
+
+		tagItems, err := planTags(ctx, hasher, svc)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, entryItems...)
+		items = append(items, tagItems...)
+	}
+
+	now := time.Now
+	if u.Now != nil {
+		now = u.Now
+	}
+
+	return staging.NewPlan(input.Provider, now(), salt, items)
+}
+
+func planEntries(ctx context.Context, hasher staging.PlanHasher, svc PlanService) ([]staging.PlanItem, error) {
+	results := parallel.ExecuteMap(ctx, svc.Entries, func(ctx context.Context, key staging.EntryKey, _ staging.Entry) (staging.PlanBase, error) {
+		return fetchPlanBase(ctx, hasher, svc, key, false)
+	})
+
+	items := make([]staging.PlanItem, 0, len(svc.Entries))
+
+	for _, key := range staging.SortedEntryKeys(svc.Entries) {
+		entry := svc.Entries[key]
+
+		result := results[key]
+		if result.Err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", key.Label(), result.Err)
+		}
+
+		hash, err := hasher.Entry(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, staging.PlanItem{
+			Service:    svc.Service,
+			Target:     svc.Target,
+			Name:       key.Name,
+			Namespace:  key.Namespace,
+			Kind:       staging.PlanItemEntry,
+			Operation:  entry.Operation,
+			StagedHash: hash,
+			Base:       result.Value,
+		})
+	}
+
+	return items, nil
+}
+
+func planTags(ctx context.Context, hasher staging.PlanHasher, svc PlanService) ([]staging.PlanItem, error) {
+	results := parallel.ExecuteMap(ctx, svc.Tags, func(ctx context.Context, key staging.EntryKey, _ staging.TagEntry) (staging.PlanBase, error) {
+		return fetchPlanBase(ctx, hasher, svc, key, true)
+	})
+
+	items := make([]staging.PlanItem, 0, len(svc.Tags))
+
+	for _, key := range staging.SortedEntryKeys(svc.Tags) {
+		result := results[key]
+		if result.Err != nil {
+			return nil, fmt.Errorf("failed to read %s tags: %w", key.Label(), result.Err)
+		}
+
+		hash, err := hasher.TagEntry(svc.Tags[key])
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, staging.PlanItem{
+			Service:    svc.Service,
+			Target:     svc.Target,
+			Name:       key.Name,
+			Namespace:  key.Namespace,
+			Kind:       staging.PlanItemTag,
+			StagedHash: hash,
+			Base:       result.Value,
+		})
+	}
+
+	return items, nil
+}
+
+// fetchPlanBase reads the remote entry (and, for tag items, its tags). The
+// value is hashed only when the remote reports no version to detect a change
+// by.
+func fetchPlanBase(
+	ctx context.Context, hasher staging.PlanHasher, svc PlanService, key staging.EntryKey, withTags bool,
+) (staging.PlanBase, error) {
+	strategy, err := svc.StrategyFor(key.Namespace)
+	if err != nil {
+		return staging.PlanBase{}, err
+	}
+
+	var base staging.PlanBase
+
+	current, err := strategy.FetchCurrent(ctx, key.Name)
+
+	var notFound *staging.ResourceNotFoundError
+
+	switch {
+	case errors.Is(err, provider.ErrNotFound), errors.As(err, &notFound):
+	case err != nil:
+		return staging.PlanBase{}, err
+	default:
+		base.Exists = true
+		base.Version = current.Identifier
+
+		if base.Version == "" {
+			base.ValueHash = hasher.Value(current.Value)
+		}
+	}
+
+	if !withTags {
+		return base, nil
+	}
+
+	tags, err := strategy.FetchCurrentTags(ctx, key.Name)
+	if err != nil {
+		return staging.PlanBase{}, err
+	}
+
+	base.TagsHash, err = hasher.Tags(tags)
+	if err != nil {
+		return staging.PlanBase{}, err
+	}
+
+	return base, nil
+}
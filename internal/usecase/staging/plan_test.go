@@ -0,0 +1,148 @@
+package staging_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+	usecasestaging "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+func planService(strategy staging.DiffStrategy) usecasestaging.PlanService {
+	return usecasestaging.PlanService{
+		Service: staging.ServiceParam,
+		Target:  "123456789012/us-east-1",
+		StrategyFor: func(string) (staging.DiffStrategy, error) {
+			return strategy, nil
+		},
+		Entries: map[staging.EntryKey]staging.Entry{
+			{Name: "/app/new"}:      {Operation: staging.OperationCreate, Value: lo.ToPtr("v1")},
+			{Name: "/app/existing"}: {Operation: staging.OperationUpdate, Value: lo.ToPtr("v2")},
+		},
+		Tags: map[staging.EntryKey]staging.TagEntry{
+			{Name: "/app/existing"}: {Add: map[string]string{"env": "prod"}},
+		},
+	}
+}
+
+func TestPlanUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	strategy := newMockDiffStrategy()
+	strategy.fetchErrors["/app/new"] = provider.ErrNotFound
+	strategy.fetchResults["/app/existing"] = &staging.FetchResult{Value: "v1", Identifier: "#3"}
+	strategy.fetchTagResults["/app/existing"] = map[string]string{"team": "a"}
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	uc := &usecasestaging.PlanUseCase{Now: func() time.Time { return now }}
+
+	plan, err := uc.Execute(t.Context(), usecasestaging.PlanInput{
+		Provider: "AWS",
+		Services: []usecasestaging.PlanService{planService(strategy)},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "AWS", plan.Provider)
+	assert.Equal(t, now, plan.CreatedAt)
+	assert.NotEmpty(t, plan.Digest)
+	require.Len(t, plan.Items, 3)
+
+	existing, created, tags := plan.Items[0], plan.Items[1], plan.Items[2]
+
+	assert.Equal(t, "/app/existing", existing.Name)
+	assert.Equal(t, staging.OperationUpdate, existing.Operation)
+	assert.Equal(t, staging.PlanBase{Exists: true, Version: "#3"}, existing.Base, "a versioned value is not hashed")
+
+	assert.Equal(t, "/app/new", created.Name)
+	assert.Equal(t, staging.PlanBase{}, created.Base)
+
+	assert.Equal(t, staging.PlanItemTag, tags.Kind)
+	assert.NotEmpty(t, tags.Base.TagsHash)
+}
+
+func TestPlanUseCase_Execute_DetectsRemoteDrift(t *testing.T) {
+	t.Parallel()
+
+	strategy := newMockDiffStrategy()
+	strategy.fetchErrors["/app/new"] = provider.ErrNotFound
+	strategy.fetchResults["/app/existing"] = &staging.FetchResult{Value: "v1", Identifier: "#3"}
+
+	uc := &usecasestaging.PlanUseCase{}
+	input := usecasestaging.PlanInput{Provider: "AWS", Services: []usecasestaging.PlanService{planService(strategy)}}
+
+	planned, err := uc.Execute(t.Context(), input)
+	require.NoError(t, err)
+
+	strategy.fetchResults["/app/existing"] = &staging.FetchResult{Value: "v9", Identifier: "#4"}
+	delete(strategy.fetchErrors, "/app/new")
+
+	input.Salt = planned.Salt
+
+	current, err := uc.Execute(t.Context(), input)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"param /app/existing: remote changed since the plan was made (#3 -> #4)",
+		"param /app/new: remote was created since the plan was made",
+		"param /app/existing (tags): remote changed since the plan was made (#3 -> #4)",
+	}, planned.Drift(current))
+}
+
+func TestPlanUseCase_Execute_UnversionedValue(t *testing.T) {
+	t.Parallel()
+
+	strategy := newMockDiffStrategy()
+	strategy.fetchErrors["/app/new"] = provider.ErrNotFound
+	strategy.fetchResults["/app/existing"] = &staging.FetchResult{Value: "1234"}
+
+	uc := &usecasestaging.PlanUseCase{}
+	input := usecasestaging.PlanInput{Provider: "AWS", Services: []usecasestaging.PlanService{planService(strategy)}}
+
+	planned, err := uc.Execute(t.Context(), input)
+	require.NoError(t, err)
+
+	existing := planned.Items[0]
+	require.NotEmpty(t, existing.Base.ValueHash, "an unversioned value is hashed")
+
+	hasher, err := staging.NewPlanHasher(planned.Salt)
+	require.NoError(t, err)
+	assert.Equal(t, hasher.Value("1234"), existing.Base.ValueHash, "keyed with the plan's salt")
+
+	input.Salt = planned.Salt
+
+	unchanged, err := uc.Execute(t.Context(), input)
+	require.NoError(t, err)
+	assert.Empty(t, planned.Drift(unchanged))
+
+	strategy.fetchResults["/app/existing"] = &staging.FetchResult{Value: "4321"}
+
+	changed, err := uc.Execute(t.Context(), input)
+	require.NoError(t, err)
+	assert.Contains(t, planned.Drift(changed), "param /app/existing: remote changed since the plan was made")
+
+	input.Salt = ""
+
+	resalted, err := uc.Execute(t.Context(), input)
+	require.NoError(t, err)
+	assert.NotEqual(t, planned.Salt, resalted.Salt, "each plan draws its own salt")
+}
+
+func TestPlanUseCase_Execute_FetchError(t *testing.T) {
+	t.Parallel()
+
+	strategy := newMockDiffStrategy()
+	strategy.fetchErrors["/app/existing"] = errors.New("throttled")
+
+	_, err := (&usecasestaging.PlanUseCase{}).Execute(t.Context(), usecasestaging.PlanInput{
+		Services: []usecasestaging.PlanService{planService(strategy)},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read /app/existing")
+	assert.Contains(t, err.Error(), "throttled")
+}
@@ -17,6 +17,9 @@ type ResetInput struct {
 	// empty is the null/default namespace and the only value for every other
 	// provider. Ignored when All is set (UnstageAll clears every namespace).
 	Namespace string
+	// Select, when non-zero, unstages just the staged changes it selects, in
+	// place of Spec and All.
+	Select staging.Selector
 }
 
 // ResetResultType represents the type of reset result.
@@ -31,6 +34,8 @@ const (
 	ResetResultNothingStaged
 	ResetResultSkipped     // Restore was skipped because value matches current AWS
 	ResetResultUnstagedTag // Only staged tag changes were unstaged (entry itself not staged)
+	ResetResultUnstagedSelected
+	ResetResultNothingSelected // Nothing staged matches ResetInput.Select
 )
 
 // ResetOutput holds the result of the reset use case.
@@ -38,7 +43,7 @@ type ResetOutput struct {
 	Type         ResetResultType
 	Name         string
 	VersionLabel string
-	Count        int // Number of items unstaged (for UnstagedAll)
+	Count        int // Number of items unstaged (for UnstagedAll and UnstagedSelected)
 	ServiceName  string
 	ItemName     string
 }
@@ -55,6 +60,10 @@ func (u *ResetUseCase) Execute(ctx context.Context, input ResetInput) (*ResetOut
 	serviceName := u.Parser.ServiceName()
 	itemName := u.Parser.ItemName()
 
+	if !input.Select.IsZero() {
+		return u.unstageSelected(ctx, input.Select, serviceName, itemName)
+	}
+
 	if input.All {
 		return u.unstageAll(ctx, serviceName, itemName)
 	}
@@ -107,6 +116,51 @@ func (u *ResetUseCase) unstageAll(ctx context.Context, serviceName, itemName str
 	}, nil
 }
 
+// unstageSelected unstages each staged entry and tag change that sel selects.
+// A key that vanished in the meantime (ErrNotStaged) is already what we want.
+func (u *ResetUseCase) unstageSelected(ctx context.Context, sel staging.Selector, serviceName, itemName string) (*ResetOutput, error) {
+	service := u.Parser.Service()
+
+	staged, err := u.Store.ListEntries(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+
+	stagedTags, err := u.Store.ListTags(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := sel.SelectEntries(staged[service])
+	tags := sel.SelectTags(stagedTags[service])
+
+	if len(entries) == 0 && len(tags) == 0 {
+		return &ResetOutput{
+			Type:        ResetResultNothingSelected,
+			ServiceName: serviceName,
+		}, nil
+	}
+
+	for key := range entries {
+		if err := u.Store.UnstageEntry(ctx, service, key); err != nil && !errors.Is(err, staging.ErrNotStaged) {
+			return nil, err
+		}
+	}
+
+	for key := range tags {
+		if err := u.Store.UnstageTag(ctx, service, key); err != nil && !errors.Is(err, staging.ErrNotStaged) {
+			return nil, err
+		}
+	}
+
+	return &ResetOutput{
+		Type:        ResetResultUnstagedSelected,
+		Count:       len(entries) + len(tags),
+		ServiceName: serviceName,
+		ItemName:    itemName,
+	}, nil
+}
+
 func (u *ResetUseCase) unstage(ctx context.Context, name, namespace, _, _ string) (*ResetOutput, error) {
 	service := u.Parser.Service()
 	key := staging.EntryKey{Name: name, Namespace: namespace}
@@ -180,7 +234,7 @@ func (u *ResetUseCase) restore(ctx context.Context, spec, name, namespace string
 
 	// Load current state with AWS value for auto-skip, keeping any existing
 	// staged base so conflict detection is preserved across the restore.
-	entryState, existingBaseModifiedAt, err := transition.LoadEntryStateWithMetadata(ctx, u.Store, service, key, currentValue)
+	entryState, base, err := transition.LoadEntryStateWithMetadata(ctx, u.Store, service, key, currentValue)
 	if err != nil {
 		return nil, err
 	}
@@ -189,16 +243,15 @@ func (u *ResetUseCase) restore(ctx context.Context, spec, name, namespace string
 	_, wasNotStaged := entryState.StagedState.(transition.EntryStagedStateNotStaged)
 
 	// Anchor the conflict base: reuse an existing staged base when present,
-	// otherwise fall back to the current AWS LastModified (zero-time → nil),
-	// mirroring the edit use case.
-	baseModifiedAt := existingBaseModifiedAt
-	if baseModifiedAt == nil && !fetchResult.LastModified.IsZero() {
-		lastModified := fetchResult.LastModified
-		baseModifiedAt = &lastModified
+	// otherwise fall back to the current AWS LastModified (zero-time → nil) and
+	// version, mirroring the edit use case.
+	if base.IsZero() {
+		base = transition.EntryBase{ModifiedAt: anchor(fetchResult.LastModified), Version: fetchResult.Version}
 	}
 
 	opts := &transition.EntryExecuteOptions{
-		BaseModifiedAt: baseModifiedAt,
+		BaseModifiedAt: base.ModifiedAt,
+		BaseVersion:    base.Version,
 	}
 
 	// Execute the edit transition with the restored value
@@ -674,3 +674,49 @@ func TestResetUseCase_Execute_ListTagsError(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "list tags error")
 }
+
+func TestResetUseCase_Execute_UnstageSelected(t *testing.T) {
+	t.Parallel()
+
+	store := testutil.NewMockStore()
+	for _, name := range []string{"/app/db/host", "/app/db/legacy/user", "/app/web/host"} {
+		require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: name}, staging.Entry{
+			Operation: staging.OperationUpdate,
+			Value:     lo.ToPtr("value"),
+			StagedAt:  time.Now(),
+		}))
+	}
+
+	require.NoError(t, store.StageTag(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/db/port"}, staging.TagEntry{
+		Add:      map[string]string{"env": "prod"},
+		StagedAt: time.Now(),
+	}))
+
+	uc := &usecasestaging.ResetUseCase{
+		Parser: newMockParser(),
+		Store:  store,
+	}
+
+	output, err := uc.Execute(t.Context(), usecasestaging.ResetInput{
+		Select: staging.Selector{Match: []string{"/app/db/*"}, Exclude: []string{"/app/db/legacy"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, usecasestaging.ResetResultUnstagedSelected, output.Type)
+	assert.Equal(t, 2, output.Count) // /app/db/host + the /app/db/port tag
+
+	entries, err := store.ListEntries(t.Context(), staging.ServiceParam)
+	require.NoError(t, err)
+	assert.ElementsMatch(t,
+		[]staging.EntryKey{{Name: "/app/db/legacy/user"}, {Name: "/app/web/host"}},
+		staging.SortedEntryKeys(entries[staging.ServiceParam]))
+
+	tags, err := store.ListTags(t.Context(), staging.ServiceParam)
+	require.NoError(t, err)
+	assert.Empty(t, tags[staging.ServiceParam])
+
+	output, err = uc.Execute(t.Context(), usecasestaging.ResetInput{
+		Select: staging.Selector{Operations: []staging.Operation{staging.OperationDelete}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, usecasestaging.ResetResultNothingSelected, output.Type)
+}
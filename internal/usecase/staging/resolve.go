@@ -0,0 +1,324 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store"
+)
+
+// ResolveStrategy is what conflict resolution needs from a service: the remote
+// last-modified time (to detect and re-anchor conflicts) and the current
+// value. Strategies that also implement staging.BaseFetcher get a real
+// three-way merge; others merge against an empty base.
+type ResolveStrategy interface {
+	staging.ApplyStrategy
+	staging.DiffStrategy
+}
+
+// ResolveEditFunc lets the user resolve a conflicted merge by hand. It
+// receives the merge result with conflict markers and returns the edited value.
+type ResolveEditFunc func(ctx context.Context, key staging.EntryKey, merged string) (string, error)
+
+// ResolveInput holds input for the resolve use case.
+type ResolveInput struct {
+	Name string // Optional: resolve only this item
+}
+
+// ResolveStatus is the outcome of resolving one conflicting entry.
+type ResolveStatus int
+
+// ResolveStatus constants.
+const (
+	// ResolveMerged means staged and remote changes merged cleanly.
+	ResolveMerged ResolveStatus = iota
+	// ResolveEdited means the conflicts were resolved in the editor.
+	ResolveEdited
+	// ResolveUpToDate means the remote already holds the merged value, so the
+	// entry was unstaged.
+	ResolveUpToDate
+	// ResolveUnresolved means conflicts remain; the entry is left as it was.
+	ResolveUnresolved
+	// ResolveSkipped means the staged change cannot be merged (a staged delete
+	// of a changed entry, or an update of a since-deleted one).
+	ResolveSkipped
+	// ResolveFailed means reading the remote or writing the staging area failed.
+	ResolveFailed
+)
+
+// ResolveEntryResult is the result of resolving one conflicting entry.
+type ResolveEntryResult struct {
+	Key    staging.EntryKey
+	Status ResolveStatus
+	// Conflicts counts the regions the automatic merge could not resolve.
+	Conflicts int
+	// Merged is the marked-up merge of an unresolved entry, for resolving it
+	// by hand.
+	Merged string
+	// Reason explains a skipped entry.
+	Reason string
+	Error  error
+}
+
+// ResolveOutput holds the result of the resolve use case.
+type ResolveOutput struct {
+	ServiceName string
+	ItemName    string
+	Entries     []ResolveEntryResult
+	// RebasedTags are staged tag changes whose base was re-anchored: a staged
+	// tag change adds or removes individual keys, so it applies cleanly on top
+	// of whatever tags the remote now holds.
+	RebasedTags []staging.EntryKey
+}
+
+// ResolveUseCase merges conflicting staged entries with the remote changes
+// made since they were staged.
+type ResolveUseCase struct {
+	Strategy ResolveStrategy
+	Store    store.ReadWriteOperator
+	// StrategyFor, when set, resolves the strategy for a given namespace (see
+	// ApplyUseCase.StrategyFor).
+	StrategyFor func(namespace string) (ResolveStrategy, error)
+	// Edit opens a conflicted merge for manual resolution. Nil leaves
+	// conflicted entries unresolved.
+	Edit ResolveEditFunc
+}
+
+func (u *ResolveUseCase) strategyForNamespace(namespace string) (ResolveStrategy, error) {
+	if u.StrategyFor == nil {
+		return u.Strategy, nil
+	}
+
+	return u.StrategyFor(namespace)
+}
+
+// Execute finds the staged entries and tag changes that conflict with the
+// remote, merges each entry three-way (base, staged, remote) and re-stages
+// the merge result anchored at the remote's current version and modification
+// time.
+func (u *ResolveUseCase) Execute(ctx context.Context, input ResolveInput) (*ResolveOutput, error) {
+	service := u.Strategy.Service()
+	itemName := u.Strategy.ItemName()
+
+	output := &ResolveOutput{
+		ServiceName: u.Strategy.ServiceName(),
+		ItemName:    itemName,
+	}
+
+	stagedEntries, err := u.Store.ListEntries(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+
+	stagedTags, err := u.Store.ListTags(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := stagedEntries[service]
+	tags := stagedTags[service]
+
+	if input.Name != "" {
+		entries = filterByName(entries, input.Name)
+		tags = filterByName(tags, input.Name)
+
+		if len(entries) == 0 && len(tags) == 0 {
+			return nil, fmt.Errorf("%s %s is not staged", itemName, input.Name)
+		}
+	}
+
+	resolveApply := func(namespace string) (staging.ApplyStrategy, error) {
+		return u.strategyForNamespace(namespace)
+	}
+
+	entryConflicts := staging.CheckConflicts(ctx, resolveApply, entries)
+	tagConflicts := staging.CheckTagConflicts(ctx, resolveApply, tags)
+
+	for _, key := range staging.SortedEntryKeys(entryConflicts) {
+		output.Entries = append(output.Entries, u.resolveEntry(ctx, service, key, entries[key]))
+	}
+
+	for _, key := range staging.SortedEntryKeys(tagConflicts) {
+		if err := u.rebaseTag(ctx, service, key, tags[key]); err != nil {
+			return output, err
+		}
+
+		output.RebasedTags = append(output.RebasedTags, key)
+	}
+
+	return output, nil
+}
+
+func (u *ResolveUseCase) resolveEntry(
+	ctx context.Context, service staging.Service, key staging.EntryKey, entry staging.Entry,
+) ResolveEntryResult {
+	result := ResolveEntryResult{Key: key}
+
+	fail := func(err error) ResolveEntryResult {
+		result.Status = ResolveFailed
+		result.Error = err
+
+		return result
+	}
+
+	if entry.Operation == staging.OperationDelete {
+		result.Status = ResolveSkipped
+		result.Reason = "a staged delete cannot be merged with remote changes; reset it or apply with --ignore-conflicts"
+
+		return result
+	}
+
+	strategy, err := u.strategyForNamespace(key.Namespace)
+	if err != nil {
+		return fail(err)
+	}
+
+	// Read the remote state before the value: if the remote changes in
+	// between, the entry is anchored too early and conflicts again, rather
+	// than silently dropping that change.
+	remote, err := staging.FetchRemoteState(ctx, strategy, key.Name)
+	if err != nil {
+		var notFound *staging.ResourceNotFoundError
+		if errors.As(err, &notFound) {
+			result.Status = ResolveSkipped
+			result.Reason = "the remote was deleted after staging; reset it or stage it again as a create"
+
+			return result
+		}
+
+		return fail(err)
+	}
+
+	current, err := strategy.FetchCurrent(ctx, key.Name)
+	if err != nil {
+		return fail(err)
+	}
+
+	base, err := u.fetchBase(ctx, strategy, key, entry)
+	if err != nil {
+		return fail(err)
+	}
+
+	merged := staging.MergeValues(base, lo.FromPtr(entry.Value), current.Value, staging.MergeLabels{
+		Staged: "staged",
+		Remote: "remote " + current.Identifier,
+	})
+
+	result.Status = ResolveMerged
+	result.Conflicts = merged.Conflicts
+	value := merged.Value
+
+	if merged.Conflicts > 0 {
+		if u.Edit == nil {
+			result.Status = ResolveUnresolved
+			result.Merged = merged.Value
+
+			return result
+		}
+
+		edited, err := u.Edit(ctx, key, merged.Value)
+		if err != nil {
+			return fail(err)
+		}
+
+		if staging.HasConflictMarkers(edited) {
+			result.Status = ResolveUnresolved
+			result.Merged = edited
+
+			return result
+		}
+
+		result.Status = ResolveEdited
+		value = edited
+	}
+
+	if value == current.Value {
+		if err := u.Store.UnstageEntry(ctx, service, key); err != nil {
+			return fail(err)
+		}
+
+		result.Status = ResolveUpToDate
+
+		return result
+	}
+
+	// The remote now exists, so a staged create becomes an update of it.
+	entry.Operation = staging.OperationUpdate
+	entry.Value = &value
+	entry.StagedAt = time.Now()
+	entry.BaseModifiedAt = anchor(remote.LastModified)
+	entry.BaseVersion = remote.Version
+
+	if err := u.Store.StageEntry(ctx, service, key, entry); err != nil {
+		return fail(err)
+	}
+
+	return result
+}
+
+// fetchBase recovers the value the staged entry was based on. A staged create
+// has no base; a strategy without history or a base no longer retained merges
+// against an empty base, which turns every differing region into a conflict.
+func (u *ResolveUseCase) fetchBase(
+	ctx context.Context, strategy ResolveStrategy, key staging.EntryKey, entry staging.Entry,
+) (string, error) {
+	fetcher, ok := strategy.(staging.BaseFetcher)
+	if !ok || entry.Operation == staging.OperationCreate ||
+		(entry.BaseModifiedAt == nil && entry.BaseVersion == "") {
+		return "", nil
+	}
+
+	base, err := fetcher.FetchBase(ctx, key.Name, entry.BaseVersion, lo.FromPtr(entry.BaseModifiedAt))
+	if errors.Is(err, staging.ErrBaseUnavailable) || errors.Is(err, provider.ErrNotFound) {
+		return "", nil
+	}
+
+	return base, err
+}
+
+// rebaseTag re-anchors a conflicting staged tag change at the remote's current
+// modification time.
+func (u *ResolveUseCase) rebaseTag(ctx context.Context, service staging.Service, key staging.EntryKey, tagEntry staging.TagEntry) error {
+	strategy, err := u.strategyForNamespace(key.Namespace)
+	if err != nil {
+		return err
+	}
+
+	modifiedAt, err := strategy.FetchLastModified(ctx, key.Name)
+	if err != nil {
+		return fmt.Errorf("failed to rebase tags of %s: %w", key.Label(), err)
+	}
+
+	tagEntry.BaseModifiedAt = anchor(modifiedAt)
+
+	return u.Store.StageTag(ctx, service, key, tagEntry)
+}
+
+// anchor returns the base time to record, or nil for a provider that reports
+// no modification time.
+func anchor(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+// filterByName keeps the items staged under name, in any namespace.
+func filterByName[V any](items map[staging.EntryKey]V, name string) map[staging.EntryKey]V {
+	filtered := make(map[staging.EntryKey]V)
+
+	for key, v := range items {
+		if key.Name == name {
+			filtered[key] = v
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,224 @@
+package staging_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/testutil"
+	usecasestaging "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// mockResolveStrategy serves one remote entry per name: its value, version,
+// last-modified time and (for BaseFetcher) the value at the staged base.
+type mockResolveStrategy struct {
+	*mockServiceStrategy
+
+	values   map[string]string
+	modified map[string]time.Time
+	bases    map[string]string
+}
+
+func (m *mockResolveStrategy) Apply(context.Context, string, staging.Entry) error        { return nil }
+func (m *mockResolveStrategy) ApplyTags(context.Context, string, staging.TagEntry) error { return nil }
+
+func (m *mockResolveStrategy) FetchLastModified(_ context.Context, name string) (time.Time, error) {
+	t, ok := m.modified[name]
+	if !ok {
+		return time.Time{}, &staging.ResourceNotFoundError{}
+	}
+
+	return t, nil
+}
+
+func (m *mockResolveStrategy) FetchCurrent(_ context.Context, name string) (*staging.FetchResult, error) {
+	return &staging.FetchResult{Value: m.values[name], Identifier: "#2"}, nil
+}
+
+func (m *mockResolveStrategy) FetchCurrentTags(context.Context, string) (map[string]string, error) {
+	return nil, nil //nolint:nilnil // mock implementation
+}
+
+func (m *mockResolveStrategy) FetchBase(_ context.Context, name, _ string, _ time.Time) (string, error) {
+	base, ok := m.bases[name]
+	if !ok {
+		return "", staging.ErrBaseUnavailable
+	}
+
+	return base, nil
+}
+
+var (
+	resolveStagedAt = time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	resolveRemoteAt = resolveStagedAt.Add(time.Hour)
+)
+
+func newResolveFixture(t *testing.T, staged string, remote string) (*testutil.MockStore, *mockResolveStrategy) {
+	t.Helper()
+
+	st := testutil.NewMockStore()
+	require.NoError(t, st.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/config"}, staging.Entry{
+		Operation:      staging.OperationUpdate,
+		Value:          lo.ToPtr(staged),
+		BaseModifiedAt: lo.ToPtr(resolveStagedAt),
+	}))
+
+	strategy := &mockResolveStrategy{
+		mockServiceStrategy: newParamStrategy(),
+		values:              map[string]string{"/app/config": remote},
+		modified:            map[string]time.Time{"/app/config": resolveRemoteAt},
+		bases:               map[string]string{"/app/config": `{"a":"1","b":"1"}`},
+	}
+
+	return st, strategy
+}
+
+func stagedConfig(t *testing.T, st *testutil.MockStore) staging.Entry {
+	t.Helper()
+
+	entry, err := st.GetEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/config"})
+	require.NoError(t, err)
+
+	return *entry
+}
+
+func TestResolveUseCase_Execute_AutoMerge(t *testing.T) {
+	t.Parallel()
+
+	st, strategy := newResolveFixture(t, `{"a":"2","b":"1"}`, `{"a":"1","b":"3"}`)
+
+	out, err := (&usecasestaging.ResolveUseCase{Strategy: strategy, Store: st}).Execute(t.Context(), usecasestaging.ResolveInput{})
+	require.NoError(t, err)
+
+	require.Len(t, out.Entries, 1)
+	assert.Equal(t, usecasestaging.ResolveMerged, out.Entries[0].Status)
+
+	entry := stagedConfig(t, st)
+	assert.JSONEq(t, `{"a":"2","b":"3"}`, *entry.Value)
+	assert.Equal(t, resolveRemoteAt, *entry.BaseModifiedAt, "the merge is re-anchored at the remote")
+}
+
+func TestResolveUseCase_Execute_ConflictWithoutEditor(t *testing.T) {
+	t.Parallel()
+
+	st, strategy := newResolveFixture(t, `{"a":"2","b":"1"}`, `{"a":"3","b":"1"}`)
+
+	out, err := (&usecasestaging.ResolveUseCase{Strategy: strategy, Store: st}).Execute(t.Context(), usecasestaging.ResolveInput{})
+	require.NoError(t, err)
+
+	require.Len(t, out.Entries, 1)
+	assert.Equal(t, usecasestaging.ResolveUnresolved, out.Entries[0].Status)
+	assert.Equal(t, 1, out.Entries[0].Conflicts)
+	assert.Contains(t, out.Entries[0].Merged, "<<<<<<< staged")
+	assert.JSONEq(t, `{"a":"2","b":"1"}`, *stagedConfig(t, st).Value, "an unresolved entry is left as staged")
+}
+
+func TestResolveUseCase_Execute_ConflictResolvedInEditor(t *testing.T) {
+	t.Parallel()
+
+	st, strategy := newResolveFixture(t, `{"a":"2","b":"1"}`, `{"a":"3","b":"1"}`)
+
+	var shown string
+
+	uc := &usecasestaging.ResolveUseCase{
+		Strategy: strategy,
+		Store:    st,
+		Edit: func(_ context.Context, _ staging.EntryKey, merged string) (string, error) {
+			shown = merged
+
+			return `{"a":"23","b":"1"}`, nil
+		},
+	}
+
+	out, err := uc.Execute(t.Context(), usecasestaging.ResolveInput{})
+	require.NoError(t, err)
+
+	assert.Contains(t, shown, "<<<<<<< staged")
+	assert.Contains(t, shown, ">>>>>>> remote #2")
+	assert.Equal(t, usecasestaging.ResolveEdited, out.Entries[0].Status)
+	assert.Equal(t, `{"a":"23","b":"1"}`, *stagedConfig(t, st).Value)
+}
+
+func TestResolveUseCase_Execute_MarkersLeftInEditor(t *testing.T) {
+	t.Parallel()
+
+	st, strategy := newResolveFixture(t, "x", "y")
+
+	uc := &usecasestaging.ResolveUseCase{
+		Strategy: strategy,
+		Store:    st,
+		Edit: func(_ context.Context, _ staging.EntryKey, merged string) (string, error) {
+			return merged, nil
+		},
+	}
+
+	out, err := uc.Execute(t.Context(), usecasestaging.ResolveInput{})
+	require.NoError(t, err)
+	assert.Equal(t, usecasestaging.ResolveUnresolved, out.Entries[0].Status)
+}
+
+func TestResolveUseCase_Execute_UpToDate(t *testing.T) {
+	t.Parallel()
+
+	st, strategy := newResolveFixture(t, `{"a":"2","b":"1"}`, `{"a":"2","b":"1"}`)
+
+	out, err := (&usecasestaging.ResolveUseCase{Strategy: strategy, Store: st}).Execute(t.Context(), usecasestaging.ResolveInput{})
+	require.NoError(t, err)
+	assert.Equal(t, usecasestaging.ResolveUpToDate, out.Entries[0].Status)
+
+	_, err = st.GetEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/config"})
+	require.Error(t, err, "an entry the remote already holds is unstaged")
+}
+
+func TestResolveUseCase_Execute_SkipsDeleteAndDeletedRemote(t *testing.T) {
+	t.Parallel()
+
+	st, strategy := newResolveFixture(t, "x", "y")
+	require.NoError(t, st.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/gone"}, staging.Entry{
+		Operation: staging.OperationDelete, BaseModifiedAt: lo.ToPtr(resolveStagedAt),
+	}))
+	strategy.modified["/app/gone"] = resolveRemoteAt
+
+	delete(strategy.modified, "/app/config")
+
+	out, err := (&usecasestaging.ResolveUseCase{Strategy: strategy, Store: st}).Execute(t.Context(), usecasestaging.ResolveInput{})
+	require.NoError(t, err)
+
+	require.Len(t, out.Entries, 1, "a deleted remote is not a conflict for an update")
+	assert.Equal(t, usecasestaging.ResolveSkipped, out.Entries[0].Status)
+	assert.Contains(t, out.Entries[0].Reason, "staged delete")
+}
+
+func TestResolveUseCase_Execute_RebasesTags(t *testing.T) {
+	t.Parallel()
+
+	st, strategy := newResolveFixture(t, "x", "x")
+	key := staging.EntryKey{Name: "/app/config"}
+	require.NoError(t, st.UnstageEntry(t.Context(), staging.ServiceParam, key))
+	require.NoError(t, st.StageTag(t.Context(), staging.ServiceParam, key, staging.TagEntry{
+		Add: map[string]string{"env": "prod"}, BaseModifiedAt: lo.ToPtr(resolveStagedAt),
+	}))
+
+	out, err := (&usecasestaging.ResolveUseCase{Strategy: strategy, Store: st}).Execute(t.Context(), usecasestaging.ResolveInput{})
+	require.NoError(t, err)
+	assert.Equal(t, []staging.EntryKey{key}, out.RebasedTags)
+
+	tag, err := st.GetTag(t.Context(), staging.ServiceParam, key)
+	require.NoError(t, err)
+	assert.Equal(t, resolveRemoteAt, *tag.BaseModifiedAt)
+}
+
+func TestResolveUseCase_Execute_NotStaged(t *testing.T) {
+	t.Parallel()
+
+	st, strategy := newResolveFixture(t, "x", "y")
+
+	_, err := (&usecasestaging.ResolveUseCase{Strategy: strategy, Store: st}).Execute(t.Context(), usecasestaging.ResolveInput{Name: "/other"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parameter /other is not staged")
+}
@@ -0,0 +1,185 @@
+package staging
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mpyw/suve/internal/parallel"
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// Snapshot captures the remote state of every entry and tagged item before an
+// atomic or journaled apply writes any of them, each through the strategy
+// resolve returns for its namespace. It fails when a strategy cannot roll back
+// or a snapshot cannot be taken.
+func Snapshot(
+	ctx context.Context, resolve staging.ApplyStrategyResolver,
+	entries map[staging.EntryKey]staging.Entry, tags map[staging.EntryKey]staging.TagEntry,
+) (map[staging.EntryKey]staging.Snapshot, error) {
+	keys := make(map[staging.EntryKey]staging.EntryKey, len(entries)+len(tags))
+	for key := range entries {
+		keys[key] = key
+	}
+
+	for key := range tags {
+		keys[key] = key
+	}
+
+	results := parallel.ExecuteMap(ctx, keys, func(ctx context.Context, _ staging.EntryKey, key staging.EntryKey) (staging.Snapshot, error) {
+		rollbacker, err := RollbackerFor(resolve, key.Namespace)
+		if err != nil {
+			return staging.Snapshot{}, err
+		}
+
+		return rollbacker.Snapshot(ctx, key.Name)
+	})
+
+	snapshots := make(map[staging.EntryKey]staging.Snapshot, len(results))
+
+	for _, key := range staging.SortedEntryKeys(results) {
+		result := results[key]
+		if result.Err != nil {
+			return nil, fmt.Errorf("%s: %w", key.Label(), result.Err)
+		}
+
+		snapshots[key] = result.Value
+	}
+
+	return snapshots, nil
+}
+
+// RollbackerFor returns the strategy resolve returns for namespace as a
+// staging.Rollbacker.
+func RollbackerFor(resolve staging.ApplyStrategyResolver, namespace string) (staging.Rollbacker, error) {
+	strategy, err := resolve(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	rollbacker, ok := strategy.(staging.Rollbacker)
+	if !ok {
+		return nil, fmt.Errorf("%s does not support atomic apply", strategy.ServiceName())
+	}
+
+	return rollbacker, nil
+}
+
+// WrittenKeys records the keys whose value or tag write landed on the remote,
+// whether or not a later step for the same key failed, so a failed atomic
+// apply rolls back every one of them. The zero value is ready to use and it is
+// safe for concurrent use.
+type WrittenKeys struct {
+	mu   sync.Mutex
+	keys map[staging.EntryKey]struct{}
+}
+
+// Add records key as written.
+func (w *WrittenKeys) Add(key staging.EntryKey) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.keys == nil {
+		w.keys = make(map[staging.EntryKey]struct{})
+	}
+
+	w.keys[key] = struct{}{}
+}
+
+// Keys returns a copy of the written keys.
+func (w *WrittenKeys) Keys() map[staging.EntryKey]struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return maps.Clone(w.keys)
+}
+
+// Rollback restores each written key to its snapshot, through the strategy
+// resolve returns for its namespace, and reports the outcome per key sorted by
+// (Namespace, Name). A locked key was written between an unlock and a relock,
+// so it is rolled back the same way; one that did not exist before is deleted
+// and needs no relock.
+func Rollback(
+	ctx context.Context, resolve staging.ApplyStrategyResolver, snapshots map[staging.EntryKey]staging.Snapshot,
+	written, locked map[staging.EntryKey]struct{},
+) []ApplyRollbackResult {
+	results := parallel.ExecuteMap(ctx, written, func(ctx context.Context, key staging.EntryKey, _ struct{}) (struct{}, error) {
+		rollbacker, err := RollbackerFor(resolve, key.Namespace)
+		if err != nil {
+			return struct{}{}, err
+		}
+
+		snap := snapshots[key]
+		rollback := func() error { return rollbacker.Rollback(ctx, key.Name, snap) }
+
+		if _, isLocked := locked[key]; isLocked {
+			strategy, err := resolve(key.Namespace)
+			if err != nil {
+				return struct{}{}, err
+			}
+
+			return struct{}{}, staging.WithUnlock(ctx, strategy, key.Name, snap.Exists, rollback)
+		}
+
+		return struct{}{}, rollback()
+	})
+
+	rollbacks := make([]ApplyRollbackResult, 0, len(results))
+
+	for key, result := range results {
+		rollbacks = append(rollbacks, ApplyRollbackResult{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Error:     result.Err,
+		})
+	}
+
+	slices.SortFunc(rollbacks, func(a, b ApplyRollbackResult) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return rollbacks
+}
+
+// settleAtomic finishes an atomic apply. When every write succeeded the
+// changes are unstaged; otherwise every written key is rolled back to its
+// snapshot — including one whose later step failed — and everything stays
+// staged.
+func (u *ApplyUseCase) settleAtomic(
+	ctx context.Context, service staging.Service, snapshots map[staging.EntryKey]staging.Snapshot,
+	written, locked map[staging.EntryKey]struct{}, output *ApplyOutput,
+) error {
+	if output.EntryFailed+output.TagFailed == 0 {
+		for i, result := range output.EntryResults {
+			key := staging.EntryKey{Name: result.Name, Namespace: result.Namespace}
+			output.EntryResults[i].UnstageError = u.Store.UnstageEntry(ctx, service, key)
+		}
+
+		for i, result := range output.TagResults {
+			key := staging.EntryKey{Name: result.Name, Namespace: result.Namespace}
+			output.TagResults[i].UnstageError = u.Store.UnstageTag(ctx, service, key)
+		}
+
+		return nil
+	}
+
+	output.Rollbacks = Rollback(ctx, u.strategyForNamespace, snapshots, written, locked)
+
+	var rolledBack int
+
+	for _, result := range output.Rollbacks {
+		if result.Error == nil {
+			rolledBack++
+		}
+	}
+
+	return fmt.Errorf("atomic apply failed: %d entries, %d tags failed; rolled back %d of %d written item(s)",
+		output.EntryFailed, output.TagFailed, rolledBack, len(written))
+}
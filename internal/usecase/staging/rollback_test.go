@@ -0,0 +1,178 @@
+package staging_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/testutil"
+	usecasestaging "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// mockRollbackStrategy is an apply strategy that implements staging.Rollbacker
+// and records the rollbacks it was asked for.
+type mockRollbackStrategy struct {
+	*mockApplyStrategy
+
+	snapshotErr  error
+	rollbackErrs map[string]error
+
+	mu         sync.Mutex
+	rolledBack map[string]staging.Snapshot
+}
+
+func (m *mockRollbackStrategy) Snapshot(_ context.Context, name string) (staging.Snapshot, error) {
+	if m.snapshotErr != nil {
+		return staging.Snapshot{}, m.snapshotErr
+	}
+
+	return staging.Snapshot{Exists: true, Value: "old-" + name}, nil
+}
+
+func (m *mockRollbackStrategy) Rollback(_ context.Context, name string, snap staging.Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rolledBack[name] = snap
+
+	return m.rollbackErrs[name]
+}
+
+func newMockRollbackStrategy() *mockRollbackStrategy {
+	return &mockRollbackStrategy{
+		mockApplyStrategy: newMockApplyStrategy(),
+		rollbackErrs:      make(map[string]error),
+		rolledBack:        make(map[string]staging.Snapshot),
+	}
+}
+
+func stageUpdates(t *testing.T, store *testutil.MockStore, names ...string) {
+	t.Helper()
+
+	for _, name := range names {
+		require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: name}, staging.Entry{
+			Operation: staging.OperationUpdate,
+			Value:     lo.ToPtr("new-" + name),
+			StagedAt:  time.Now(),
+		}))
+	}
+}
+
+// relockFailStrategy is a mockRollbackStrategy whose entries are all locked
+// and can be unlocked but not locked again, so a write lands and then fails.
+type relockFailStrategy struct {
+	*mockRollbackStrategy
+}
+
+func (m *relockFailStrategy) FetchLocked(context.Context, string) (bool, error) { return true, nil }
+
+func (m *relockFailStrategy) Unlock(context.Context, string) error { return nil }
+
+func (m *relockFailStrategy) Lock(context.Context, string) error { return errors.New("lock denied") }
+
+func TestApplyUseCase_Execute_Atomic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all succeed", func(t *testing.T) {
+		t.Parallel()
+
+		store := testutil.NewMockStore()
+		stageUpdates(t, store, "/app/a", "/app/b")
+
+		strategy := newMockRollbackStrategy()
+		uc := &usecasestaging.ApplyUseCase{Strategy: strategy, Store: store}
+
+		output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{IgnoreConflicts: true, Atomic: true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, output.EntrySucceeded)
+		assert.Empty(t, output.Rollbacks)
+		assert.Empty(t, strategy.rolledBack)
+
+		_, err = store.GetEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/a"})
+		require.ErrorIs(t, err, staging.ErrNotStaged)
+	})
+
+	t.Run("a failure rolls back the rest and keeps everything staged", func(t *testing.T) {
+		t.Parallel()
+
+		store := testutil.NewMockStore()
+		stageUpdates(t, store, "/app/a", "/app/b", "/app/fail")
+
+		strategy := newMockRollbackStrategy()
+		strategy.applyErrors["/app/fail"] = errors.New("aws error")
+		strategy.rollbackErrs["/app/b"] = errors.New("access denied")
+
+		uc := &usecasestaging.ApplyUseCase{Strategy: strategy, Store: store}
+
+		output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{IgnoreConflicts: true, Atomic: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "rolled back 1 of 2 written item(s)")
+		assert.Equal(t, 1, output.EntryFailed)
+
+		require.Len(t, output.Rollbacks, 2)
+		assert.Equal(t, "/app/a", output.Rollbacks[0].Name)
+		require.NoError(t, output.Rollbacks[0].Error)
+		assert.Equal(t, "/app/b", output.Rollbacks[1].Name)
+		require.Error(t, output.Rollbacks[1].Error)
+
+		assert.Equal(t, "old-/app/a", strategy.rolledBack["/app/a"].Value, "rolled back to its snapshot")
+		assert.NotContains(t, strategy.rolledBack, "/app/fail", "a failed write is not rolled back")
+
+		for _, name := range []string{"/app/a", "/app/b", "/app/fail"} {
+			_, err = store.GetEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: name})
+			require.NoError(t, err, "%s stays staged", name)
+		}
+	})
+
+	t.Run("a write that landed before its key failed is rolled back", func(t *testing.T) {
+		t.Parallel()
+
+		store := testutil.NewMockStore()
+		stageUpdates(t, store, "/app/a")
+
+		strategy := &relockFailStrategy{mockRollbackStrategy: newMockRollbackStrategy()}
+		uc := &usecasestaging.ApplyUseCase{Strategy: strategy, Store: store}
+
+		output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{IgnoreConflicts: true, Atomic: true, UnlockLocked: true})
+		require.ErrorContains(t, err, "of 1 written item(s)")
+		assert.Equal(t, 1, output.EntryFailed)
+		require.Len(t, output.Rollbacks, 1)
+		assert.Equal(t, "old-/app/a", strategy.rolledBack["/app/a"].Value)
+	})
+
+	t.Run("snapshot failure aborts before writing", func(t *testing.T) {
+		t.Parallel()
+
+		store := testutil.NewMockStore()
+		stageUpdates(t, store, "/app/a")
+
+		strategy := newMockRollbackStrategy()
+		strategy.snapshotErr = errors.New("throttled")
+
+		uc := &usecasestaging.ApplyUseCase{Strategy: strategy, Store: store}
+
+		output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{IgnoreConflicts: true, Atomic: true})
+		require.ErrorContains(t, err, "atomic apply aborted before writing")
+		assert.Empty(t, output.EntryResults)
+	})
+
+	t.Run("strategy without rollback support", func(t *testing.T) {
+		t.Parallel()
+
+		store := testutil.NewMockStore()
+		stageUpdates(t, store, "/app/a")
+
+		uc := &usecasestaging.ApplyUseCase{Strategy: newMockApplyStrategy(), Store: store}
+
+		output, err := uc.Execute(t.Context(), usecasestaging.ApplyInput{IgnoreConflicts: true, Atomic: true})
+		require.ErrorContains(t, err, "does not support atomic apply")
+		assert.Empty(t, output.EntryResults)
+	})
+}
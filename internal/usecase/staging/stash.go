@@ -0,0 +1,210 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store"
+)
+
+// StashStack is the stash side of a working store: shelved copies of staged
+// state kept next to it, addressed by ID.
+type StashStack interface {
+	// PushStash saves state as a new stash.
+	PushStash(ctx context.Context, id, message string, state *staging.State) error
+	// ReadStash returns the state saved in a stash.
+	ReadStash(ctx context.Context, id string) (*staging.State, error)
+	// DropStash deletes a stash.
+	DropStash(ctx context.Context, id string) error
+}
+
+// ErrNothingToStash is returned when nothing staged matches a stash push.
+var ErrNothingToStash = errors.New("no staged changes to stash")
+
+// StashPushInput holds input for the stash push use case.
+type StashPushInput struct {
+	// ID identifies the new stash.
+	ID string
+	// Message describes the stash.
+	Message string
+	// Names limits the stash to these staged names (every namespace and service
+	// they are staged under, with their tag changes). Empty stashes everything.
+	Names []string
+}
+
+// StashPushOutput holds the result of the stash push use case.
+type StashPushOutput struct {
+	// EntryCount is the number of entries stashed.
+	EntryCount int
+	// TagCount is the number of tag entries stashed.
+	TagCount int
+}
+
+// StashPushUseCase shelves staged changes into a new stash and clears them from
+// the working area.
+type StashPushUseCase struct {
+	Working store.WorkingStore
+	Stash   StashStack
+}
+
+// Execute runs the stash push use case. The stash is saved before anything is
+// cleared, and each stashed key is then unstaged individually (re-read under
+// its own lock, as export does), so a concurrent stage of another key survives.
+func (u *StashPushUseCase) Execute(ctx context.Context, input StashPushInput) (*StashPushOutput, error) {
+	working, err := u.Working.Drain(ctx, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	stashed := stateNamed(working, input.Names)
+	if stashed.IsEmpty() {
+		return nil, ErrNothingToStash
+	}
+
+	if err := u.Stash.PushStash(ctx, input.ID, input.Message, stashed); err != nil {
+		return nil, err
+	}
+
+	for svc, entries := range stashed.Entries {
+		for key := range entries {
+			if err := u.Working.UnstageEntry(ctx, svc, key); err != nil && !errors.Is(err, staging.ErrNotStaged) {
+				return nil, err
+			}
+		}
+	}
+
+	for svc, tags := range stashed.Tags {
+		for key := range tags {
+			if err := u.Working.UnstageTag(ctx, svc, key); err != nil && !errors.Is(err, staging.ErrNotStaged) {
+				return nil, err
+			}
+		}
+	}
+
+	return &StashPushOutput{EntryCount: stashed.EntryCount(), TagCount: stashed.TagCount()}, nil
+}
+
+// stateNamed returns the part of state staged under one of names, or all of it
+// when names is empty.
+func stateNamed(state *staging.State, names []string) *staging.State {
+	if len(names) == 0 {
+		return state
+	}
+
+	out := staging.NewEmptyState()
+
+	for svc, entries := range state.Entries {
+		for key, entry := range entries {
+			if slices.Contains(names, key.Name) {
+				if out.Entries[svc] == nil {
+					out.Entries[svc] = make(map[staging.EntryKey]staging.Entry)
+				}
+
+				out.Entries[svc][key] = entry
+			}
+		}
+	}
+
+	for svc, tags := range state.Tags {
+		for key, tag := range tags {
+			if slices.Contains(names, key.Name) {
+				if out.Tags[svc] == nil {
+					out.Tags[svc] = make(map[staging.EntryKey]staging.TagEntry)
+				}
+
+				out.Tags[svc][key] = tag
+			}
+		}
+	}
+
+	return out
+}
+
+// StashConflict is a stashed item that was also staged in the working area when
+// the stash was restored. The stashed side won (ImportModeMerge semantics:
+// entries are replaced, tag changes are unioned per tag key).
+type StashConflict struct {
+	Service staging.Service
+	Key     staging.EntryKey
+	// Tag reports a staged tag change rather than a staged entry.
+	Tag bool
+}
+
+// StashPopInput holds input for the stash pop use case.
+type StashPopInput struct {
+	// ID identifies the stash to restore.
+	ID string
+	// Keep keeps the stash after restoring it (stash apply); otherwise it is
+	// dropped (stash pop).
+	Keep bool
+}
+
+// StashPopOutput holds the result of the stash pop use case.
+type StashPopOutput struct {
+	// EntryCount is the number of entries restored.
+	EntryCount int
+	// TagCount is the number of tag entries restored.
+	TagCount int
+	// Conflicts lists the restored items that were staged in the working area
+	// too, sorted by service, then entries before tags, then key.
+	Conflicts []StashConflict
+}
+
+// StashPopUseCase restores a stash into the working area.
+type StashPopUseCase struct {
+	Working store.WorkingStore
+	Stash   StashStack
+}
+
+// Execute runs the stash pop use case. The stash is merged into the working
+// area in one atomic read-modify-write, and dropped only once that succeeded.
+func (u *StashPopUseCase) Execute(ctx context.Context, input StashPopInput) (*StashPopOutput, error) {
+	stashed, err := u.Stash.ReadStash(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &StashPopOutput{EntryCount: stashed.EntryCount(), TagCount: stashed.TagCount()}
+
+	err = u.Working.Update(ctx, "", func(working *staging.State) error {
+		output.Conflicts = stashConflicts(working, stashed)
+
+		reconcileImport(working, stashed, ImportInput{Mode: ImportModeMerge}, &ImportOutput{})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.Keep {
+		if err := u.Stash.DropStash(ctx, input.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
+
+// stashConflicts lists the items of stashed that working also stages.
+func stashConflicts(working, stashed *staging.State) []StashConflict {
+	var conflicts []StashConflict
+
+	for _, svc := range []staging.Service{staging.ServiceParam, staging.ServiceSecret} {
+		for _, key := range staging.SortedEntryKeys(stashed.Entries[svc]) {
+			if _, ok := working.Entries[svc][key]; ok {
+				conflicts = append(conflicts, StashConflict{Service: svc, Key: key})
+			}
+		}
+
+		for _, key := range staging.SortedEntryKeys(stashed.Tags[svc]) {
+			if _, ok := working.Tags[svc][key]; ok {
+				conflicts = append(conflicts, StashConflict{Service: svc, Key: key, Tag: true})
+			}
+		}
+	}
+
+	return conflicts
+}
@@ -0,0 +1,114 @@
+package staging_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/staging"
+	"github.com/mpyw/suve/internal/staging/store/testutil"
+	stagingusecase "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+// memoryStashes is an in-memory StashStack.
+type memoryStashes map[string]*staging.State
+
+func (m memoryStashes) PushStash(_ context.Context, id, _ string, state *staging.State) error {
+	m[id] = state
+
+	return nil
+}
+
+func (m memoryStashes) ReadStash(_ context.Context, id string) (*staging.State, error) {
+	state, ok := m[id]
+	if !ok {
+		return nil, fmt.Errorf("no stash %s", id)
+	}
+
+	return state, nil
+}
+
+func (m memoryStashes) DropStash(_ context.Context, id string) error {
+	delete(m, id)
+
+	return nil
+}
+
+func TestStashUseCases(t *testing.T) {
+	t.Parallel()
+
+	t.Run("push stashes the named changes and pop restores them", func(t *testing.T) {
+		t.Parallel()
+
+		working := testutil.NewMockStore()
+		stashes := memoryStashes{}
+
+		stageEntry(t, working, staging.ServiceParam, "/app/a", "a")
+		stageEntry(t, working, staging.ServiceSecret, "b", "b")
+		require.NoError(t, working.StageTag(t.Context(), staging.ServiceSecret, staging.EntryKey{Name: "b"},
+			staging.TagEntry{Add: map[string]string{"env": "prod"}}))
+
+		pushed, err := (&stagingusecase.StashPushUseCase{Working: working, Stash: stashes}).
+			Execute(t.Context(), stagingusecase.StashPushInput{ID: "1", Names: []string{"b"}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, pushed.EntryCount)
+		assert.Equal(t, 1, pushed.TagCount)
+
+		_, err = working.GetEntry(t.Context(), staging.ServiceSecret, staging.EntryKey{Name: "b"})
+		require.ErrorIs(t, err, staging.ErrNotStaged)
+		_, err = working.GetEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/a"})
+		require.NoError(t, err, "unnamed changes stay staged")
+
+		popped, err := (&stagingusecase.StashPopUseCase{Working: working, Stash: stashes}).
+			Execute(t.Context(), stagingusecase.StashPopInput{ID: "1"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, popped.EntryCount)
+		assert.Empty(t, popped.Conflicts)
+		assert.Empty(t, stashes, "pop drops the stash")
+
+		_, err = working.GetTag(t.Context(), staging.ServiceSecret, staging.EntryKey{Name: "b"})
+		require.NoError(t, err)
+	})
+
+	t.Run("nothing staged", func(t *testing.T) {
+		t.Parallel()
+
+		working := testutil.NewMockStore()
+		stageEntry(t, working, staging.ServiceParam, "/app/a", "a")
+
+		_, err := (&stagingusecase.StashPushUseCase{Working: working, Stash: memoryStashes{}}).
+			Execute(t.Context(), stagingusecase.StashPushInput{ID: "1", Names: []string{"/other"}})
+		require.ErrorIs(t, err, stagingusecase.ErrNothingToStash)
+	})
+
+	t.Run("apply keeps the stash and reports conflicts", func(t *testing.T) {
+		t.Parallel()
+
+		working := testutil.NewMockStore()
+		stashes := memoryStashes{}
+
+		stageEntry(t, working, staging.ServiceParam, "/app/a", "stashed")
+
+		_, err := (&stagingusecase.StashPushUseCase{Working: working, Stash: stashes}).
+			Execute(t.Context(), stagingusecase.StashPushInput{ID: "1"})
+		require.NoError(t, err)
+
+		stageEntry(t, working, staging.ServiceParam, "/app/a", "working")
+
+		applied, err := (&stagingusecase.StashPopUseCase{Working: working, Stash: stashes}).
+			Execute(t.Context(), stagingusecase.StashPopInput{ID: "1", Keep: true})
+		require.NoError(t, err)
+		assert.Equal(t, []stagingusecase.StashConflict{
+			{Service: staging.ServiceParam, Key: staging.EntryKey{Name: "/app/a"}},
+		}, applied.Conflicts)
+		assert.Contains(t, stashes, "1", "apply keeps the stash")
+
+		entry, err := working.GetEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/a"})
+		require.NoError(t, err)
+		assert.Equal(t, "stashed", lo.FromPtr(entry.Value), "the stashed side wins")
+	})
+}
@@ -14,6 +14,9 @@ import (
 // StatusInput holds input for the status use case.
 type StatusInput struct {
 	Name string // Optional: if set, show only this item
+	// Select narrows the status to the staged changes it selects (all of them
+	// when zero).
+	Select staging.Selector
 }
 
 // StatusEntry represents a single staged entry (create/update/delete).
@@ -85,7 +88,7 @@ func (u *StatusUseCase) Execute(ctx context.Context, input StatusInput) (*Status
 	matched := false
 
 	for key, entry := range entries[service] {
-		if input.Name != "" && key.Name != input.Name {
+		if (input.Name != "" && key.Name != input.Name) || !input.Select.MatchesEntry(key, entry) {
 			continue
 		}
 
@@ -94,7 +97,7 @@ func (u *StatusUseCase) Execute(ctx context.Context, input StatusInput) (*Status
 	}
 
 	for key, tagEntry := range tagEntries[service] {
-		if input.Name != "" && key.Name != input.Name {
+		if (input.Name != "" && key.Name != input.Name) || !input.Select.MatchesTag(key) {
 			continue
 		}
 
@@ -313,3 +313,44 @@ func TestStatusUseCase_Execute_ListTagsError(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "list tags error")
 }
+
+func TestStatusUseCase_Execute_Select(t *testing.T) {
+	t.Parallel()
+
+	store := testutil.NewMockStore()
+	now := time.Now()
+
+	require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/db/host"}, staging.Entry{
+		Operation: staging.OperationUpdate,
+		Value:     lo.ToPtr("value"),
+		StagedAt:  now,
+	}))
+	require.NoError(t, store.StageEntry(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/db/user"}, staging.Entry{
+		Operation: staging.OperationDelete,
+		StagedAt:  now,
+	}))
+	require.NoError(t, store.StageTag(t.Context(), staging.ServiceParam, staging.EntryKey{Name: "/app/db/host"}, staging.TagEntry{
+		Add:      map[string]string{"env": "prod"},
+		StagedAt: now,
+	}))
+
+	uc := &usecasestaging.StatusUseCase{
+		Strategy: newParamStrategy(),
+		Store:    store,
+	}
+
+	output, err := uc.Execute(t.Context(), usecasestaging.StatusInput{
+		Select: staging.Selector{Match: []string{"/app/db/*"}, Operations: []staging.Operation{staging.OperationDelete}},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.Entries, 1)
+	assert.Equal(t, "/app/db/user", output.Entries[0].Name)
+	assert.Empty(t, output.TagEntries)
+
+	output, err = uc.Execute(t.Context(), usecasestaging.StatusInput{
+		Select: staging.Selector{Operations: []staging.Operation{staging.OperationTag}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, output.Entries)
+	assert.Len(t, output.TagEntries, 1)
+}
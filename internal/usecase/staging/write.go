@@ -0,0 +1,122 @@
+package staging
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mpyw/suve/internal/staging"
+)
+
+// Writer writes staged value and tag changes to the remote. It is the write
+// step shared by ApplyUseCase and the global stage apply runner, which differ
+// only in how they gather, report and unstage the changes: each change goes
+// through the strategy of its own namespace on the schedule, a locked entry is
+// refused or written between an unlock and a relock, the change message tag is
+// set on each written value, and every landed write is recorded for an atomic
+// rollback.
+type Writer struct {
+	// Resolve returns the apply strategy of a key's namespace.
+	Resolve staging.ApplyStrategyResolver
+	// Schedule orders the writes into phases, caps how many run at once and
+	// retries throttled calls.
+	Schedule staging.ApplySchedule
+	// Locked holds the keys whose remote entry is locked read-only (see
+	// staging.DetectLocked).
+	Locked map[staging.EntryKey]struct{}
+	// UnlockLocked writes a locked entry between an unlock and a relock; without
+	// it a locked entry fails with staging.LockedError.
+	UnlockLocked bool
+	// MessageTag, when set, also sets the change message as this tag on each
+	// created or updated entry (see staging.TagChangeMessage).
+	MessageTag string
+	// Written collects every key whose value or tag write landed, even when a
+	// later step for the same key failed.
+	Written WrittenKeys
+}
+
+// WriteEntries writes entries and returns the error of each failed one. A
+// failed message tag does not fail its entry, whose value is written by then
+// (re-applying it would write the value again); it is returned in tagErrs for
+// the caller to report as a warning.
+func (w *Writer) WriteEntries(
+	ctx context.Context, entries map[staging.EntryKey]staging.Entry,
+) (errs, tagErrs map[staging.EntryKey]error) {
+	var mu sync.Mutex
+
+	tagErrs = make(map[staging.EntryKey]error)
+
+	errs = w.Schedule.ApplyEntries(ctx, entries, func(ctx context.Context, key staging.EntryKey, entry staging.Entry, step staging.ApplyStep) error {
+		strategy, err := w.Resolve(key.Namespace)
+		if err != nil {
+			return err
+		}
+
+		apply := func() error {
+			if err := step(func() error { return strategy.Apply(ctx, key.Name, entry) }); err != nil {
+				return err
+			}
+
+			w.Written.Add(key)
+
+			if entry.Operation == staging.OperationDelete {
+				return nil
+			}
+
+			if err := step(func() error { return staging.TagChangeMessage(ctx, strategy, key.Name, w.MessageTag) }); err != nil {
+				mu.Lock()
+				tagErrs[key] = err
+				mu.Unlock()
+			}
+
+			return nil
+		}
+
+		if _, isLocked := w.Locked[key]; isLocked {
+			if !w.UnlockLocked {
+				return staging.LockedError(key)
+			}
+
+			// Unlocking changes the entry's version (App Configuration's ETag), so
+			// the base version already checked for conflicts can no longer serve
+			// as a write precondition.
+			entry.BaseVersion = ""
+
+			return staging.WithUnlock(ctx, strategy, key.Name, entry.Operation != staging.OperationDelete, apply)
+		}
+
+		return apply()
+	})
+
+	return errs, tagErrs
+}
+
+// WriteTags writes tag changes and returns the error of each failed one
+// (locked entries as in WriteEntries).
+func (w *Writer) WriteTags(ctx context.Context, tags map[staging.EntryKey]staging.TagEntry) map[staging.EntryKey]error {
+	return w.Schedule.ApplyTags(ctx, tags, func(ctx context.Context, key staging.EntryKey, tagEntry staging.TagEntry, step staging.ApplyStep) error {
+		strategy, err := w.Resolve(key.Namespace)
+		if err != nil {
+			return err
+		}
+
+		apply := func() error {
+			if err := step(func() error { return strategy.ApplyTags(ctx, key.Name, tagEntry) }); err != nil {
+				return err
+			}
+
+			w.Written.Add(key)
+
+			return nil
+		}
+
+		if _, isLocked := w.Locked[key]; isLocked {
+			if !w.UnlockLocked {
+				return staging.LockedError(key)
+			}
+
+			return staging.WithUnlock(ctx, strategy, key.Name, true, apply)
+		}
+
+		return apply()
+	})
+}
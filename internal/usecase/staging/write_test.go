@@ -0,0 +1,75 @@
+package staging_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/staging"
+	usecasestaging "github.com/mpyw/suve/internal/usecase/staging"
+)
+
+func TestWriter_WriteEntries(t *testing.T) {
+	t.Parallel()
+
+	errTag := errors.New("tag write denied")
+
+	strategy := &mockMessageStrategy{
+		mockApplyStrategy: newMockApplyStrategy(),
+		messages:          make(map[string]string),
+		tags:              make(map[string]map[string]string),
+		tagErr:            errTag,
+	}
+	strategy.applyErrors["/app/fail"] = errors.New("write denied")
+
+	ok := staging.EntryKey{Name: "/app/ok"}
+	fail := staging.EntryKey{Name: "/app/fail"}
+	locked := staging.EntryKey{Name: "/app/locked"}
+
+	writer := &usecasestaging.Writer{
+		Resolve:    func(string) (staging.ApplyStrategy, error) { return strategy, nil },
+		Locked:     map[staging.EntryKey]struct{}{locked: {}},
+		MessageTag: "change-reason",
+	}
+
+	ctx := staging.WithChangeMessage(t.Context(), "rotate db creds")
+
+	errs, tagErrs := writer.WriteEntries(ctx, map[staging.EntryKey]staging.Entry{
+		ok:     {Operation: staging.OperationUpdate, Value: lo.ToPtr("v")},
+		fail:   {Operation: staging.OperationUpdate, Value: lo.ToPtr("v")},
+		locked: {Operation: staging.OperationUpdate, Value: lo.ToPtr("v")},
+	})
+
+	require.NoError(t, errs[ok], "a failed message tag does not fail the entry")
+	require.ErrorIs(t, tagErrs[ok], errTag)
+	require.Error(t, errs[fail])
+	require.ErrorIs(t, errs[locked], provider.ErrLocked)
+	assert.Equal(t, map[staging.EntryKey]struct{}{ok: {}}, writer.Written.Keys())
+}
+
+func TestWriter_WriteTags(t *testing.T) {
+	t.Parallel()
+
+	strategy := &mockMessageStrategy{
+		mockApplyStrategy: newMockApplyStrategy(),
+		messages:          make(map[string]string),
+		tags:              make(map[string]map[string]string),
+	}
+
+	key := staging.EntryKey{Name: "/app/ok"}
+	writer := &usecasestaging.Writer{
+		Resolve: func(string) (staging.ApplyStrategy, error) { return strategy, nil },
+	}
+
+	errs := writer.WriteTags(t.Context(), map[staging.EntryKey]staging.TagEntry{
+		key: {Add: map[string]string{"env": "prod"}},
+	})
+
+	require.NoError(t, errs[key])
+	assert.Equal(t, map[string]string{"env": "prod"}, strategy.tags["/app/ok"])
+	assert.Equal(t, map[staging.EntryKey]struct{}{key: {}}, writer.Written.Keys())
+}
@@ -0,0 +1,133 @@
+// Package treediff provides the use case behind `diff --tree`: two prefixes —
+// of one store, or of the stores of two scopes or providers — compared entry by
+// entry, pairing the entries by their name relative to each prefix.
+package treediff
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/usecase/matrix"
+)
+
+// Status is how an entry differs between the two sides.
+type Status string
+
+const (
+	// StatusAdded is an entry only the new side has.
+	StatusAdded Status = "added"
+	// StatusRemoved is an entry only the old side has.
+	StatusRemoved Status = "removed"
+	// StatusChanged is an entry both sides have with different values.
+	StatusChanged Status = "changed"
+)
+
+// Side is one side of the comparison: the entries of Reader whose names start
+// with Prefix. Label names the side's scope in output ("us-east-1"); it is
+// empty when both sides read the same store.
+type Side struct {
+	Label  string
+	Reader provider.Reader
+	Prefix string
+}
+
+// Input holds input for the tree diff use case.
+type Input struct {
+	Old Side
+	New Side
+}
+
+// Entry is one relative name that differs between the sides.
+type Entry struct {
+	// Name is the name relative to both prefixes.
+	Name   string
+	Status Status
+	// OldName and NewName are the full names on each side; the one of a
+	// missing side is empty.
+	OldName string
+	NewName string
+	// OldValue and NewValue are the current values; the one of a missing side
+	// is empty.
+	OldValue string
+	NewValue string
+}
+
+// Output holds the result of the tree diff use case.
+type Output struct {
+	// Entries are the differing entries, sorted by Name.
+	Entries []Entry
+	// Unchanged counts the entries that hold the same value on both sides.
+	Unchanged int
+}
+
+// UseCase executes tree diffs.
+type UseCase struct{}
+
+// Execute lists both prefixes, reads every entry and pairs them by relative
+// name. Unlike a matrix, a side that cannot be listed or an entry that cannot
+// be read fails the whole diff.
+func (u *UseCase) Execute(ctx context.Context, input Input) (*Output, error) {
+	sides := []Side{input.Old, input.New}
+
+	columns := make([]matrix.Column, len(sides))
+	for i, s := range sides {
+		columns[i] = matrix.Column{Scope: sideName(s), Reader: s.Reader, Prefix: s.Prefix}
+	}
+
+	mx, err := (&matrix.UseCase{}).Execute(ctx, matrix.Input{Columns: columns})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mx.Errors) > 0 {
+		return nil, fmt.Errorf("failed to list %s: %w", mx.Errors[0].Scope, mx.Errors[0].Error)
+	}
+
+	out := &Output{}
+
+	for _, row := range mx.Rows {
+		for _, c := range row.Cells {
+			if c.Error != nil {
+				return nil, c.Error
+			}
+		}
+
+		oldCell, newCell := row.Cells[0], row.Cells[1]
+		entry := Entry{Name: row.Key, OldValue: oldCell.Value, NewValue: newCell.Value}
+
+		if oldCell.Present {
+			entry.OldName = input.Old.Prefix + row.Key
+		}
+
+		if newCell.Present {
+			entry.NewName = input.New.Prefix + row.Key
+		}
+
+		switch {
+		case !oldCell.Present:
+			entry.Status = StatusAdded
+		case !newCell.Present:
+			entry.Status = StatusRemoved
+		case oldCell.Value != newCell.Value:
+			entry.Status = StatusChanged
+		default:
+			out.Unchanged++
+
+			continue
+		}
+
+		out.Entries = append(out.Entries, entry)
+	}
+
+	return out, nil
+}
+
+// sideName names a side in errors: its label (if any) and prefix.
+func sideName(s Side) string {
+	if s.Label == "" {
+		return s.Prefix
+	}
+
+	return s.Label + ":" + s.Prefix
+}
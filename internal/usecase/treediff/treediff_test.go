@@ -0,0 +1,120 @@
+package treediff_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/usecase/treediff"
+)
+
+// mapStore serves the given name → value map.
+func mapStore(values map[string]string) *providermock.Store {
+	return &providermock.Store{
+		ListFunc: func(_ context.Context) ([]string, error) {
+			names := make([]string, 0, len(values))
+			for name := range values {
+				names = append(names, name)
+			}
+
+			return names, nil
+		},
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			v, ok := values[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, name)
+			}
+
+			return &domain.Entry{Name: name, Value: v}, nil
+		},
+	}
+}
+
+func TestUseCase_Execute_SameStore(t *testing.T) {
+	t.Parallel()
+
+	store := mapStore(map[string]string{
+		"/stg/app/db-url":     "postgres://stg",
+		"/stg/app/log-level":  "warn",
+		"/stg/app/debug":      "true",
+		"/prod/app/db-url":    "postgres://prod",
+		"/prod/app/log-level": "warn",
+		"/prod/app/replicas":  "3",
+	})
+
+	uc := &treediff.UseCase{}
+
+	out, err := uc.Execute(t.Context(), treediff.Input{
+		Old: treediff.Side{Reader: store, Prefix: "/stg/app/"},
+		New: treediff.Side{Reader: store, Prefix: "/prod/app/"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, out.Unchanged)
+	assert.Equal(t, []treediff.Entry{
+		{
+			Name: "db-url", Status: treediff.StatusChanged,
+			OldName: "/stg/app/db-url", NewName: "/prod/app/db-url",
+			OldValue: "postgres://stg", NewValue: "postgres://prod",
+		},
+		{Name: "debug", Status: treediff.StatusRemoved, OldName: "/stg/app/debug", OldValue: "true"},
+		{Name: "replicas", Status: treediff.StatusAdded, NewName: "/prod/app/replicas", NewValue: "3"},
+	}, out.Entries)
+}
+
+func TestUseCase_Execute_TwoStores(t *testing.T) {
+	t.Parallel()
+
+	east := mapStore(map[string]string{"/app/a": "1", "/app/b": "2"})
+	west := mapStore(map[string]string{"/app/a": "1", "/app/b": "2"})
+
+	out, err := (&treediff.UseCase{}).Execute(t.Context(), treediff.Input{
+		Old: treediff.Side{Label: "us-east-1", Reader: east, Prefix: "/app/"},
+		New: treediff.Side{Label: "us-west-2", Reader: west, Prefix: "/app/"},
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, out.Entries)
+	assert.Equal(t, 2, out.Unchanged)
+}
+
+func TestUseCase_Execute_ListError(t *testing.T) {
+	t.Parallel()
+
+	broken := &providermock.Store{
+		ListFunc: func(_ context.Context) ([]string, error) {
+			return nil, errors.New("access denied")
+		},
+	}
+
+	_, err := (&treediff.UseCase{}).Execute(t.Context(), treediff.Input{
+		Old: treediff.Side{Label: "us-east-1", Reader: mapStore(nil), Prefix: "/app/"},
+		New: treediff.Side{Label: "eu-west-1", Reader: broken, Prefix: "/app/"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list eu-west-1:/app/")
+	assert.Contains(t, err.Error(), "access denied")
+}
+
+func TestUseCase_Execute_GetError(t *testing.T) {
+	t.Parallel()
+
+	store := mapStore(map[string]string{"/a/x": "1"})
+	store.GetFunc = func(_ context.Context, _ string, _ provider.VersionRef) (*domain.Entry, error) {
+		return nil, errors.New("throttled")
+	}
+
+	_, err := (&treediff.UseCase{}).Execute(t.Context(), treediff.Input{
+		Old: treediff.Side{Reader: store, Prefix: "/a/"},
+		New: treediff.Side{Reader: mapStore(nil), Prefix: "/b/"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "throttled")
+}
@@ -0,0 +1,40 @@
+// Package vault provides use cases for HashiCorp Vault KV v2 operations.
+//
+// The use cases are written against the provider-neutral Reader/Writer/Store
+// interfaces, exactly like the Google Cloud ones, and expose the same shape:
+// integer versions, no ARN, no labels, no description. A version's deletion
+// state (deleted/destroyed) is surfaced via the neutral Version.State.
+package vault
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/mpyw/suve/internal/version/vaultversion"
+)
+
+// ErrSecretNotFound is returned by the update use case when the target secret
+// does not exist.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// specSuffix reconstructs the version-spec suffix (the part after the name)
+// from a parsed spec, so that name+suffix re-parses to an equivalent spec. It
+// is handed to provider.Reader.Resolve, which re-parses name+suffix internally.
+//
+// Examples: {Version:3} -> "#3"; {Shift:2} -> "~2"; {} -> "" (latest).
+func specSuffix(spec *vaultversion.Spec) string {
+	var b strings.Builder
+
+	if spec.Absolute.Version != nil {
+		b.WriteString("#")
+		b.WriteString(strconv.FormatInt(*spec.Absolute.Version, 10))
+	}
+
+	if spec.Shift > 0 {
+		b.WriteString("~")
+		b.WriteString(strconv.Itoa(spec.Shift))
+	}
+
+	return b.String()
+}
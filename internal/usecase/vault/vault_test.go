@@ -0,0 +1,112 @@
+package vault_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/domain"
+	"github.com/mpyw/suve/internal/provider"
+	"github.com/mpyw/suve/internal/provider/providermock"
+	"github.com/mpyw/suve/internal/usecase/vault"
+	"github.com/mpyw/suve/internal/version/vaultversion"
+)
+
+func TestShowUseCase(t *testing.T) {
+	t.Parallel()
+
+	store := &providermock.Store{
+		ResolveFunc: func(_ context.Context, _, spec string) (provider.VersionRef, error) {
+			assert.Equal(t, "~1", spec)
+
+			return provider.NewVersionRef("2"), nil
+		},
+		GetFunc: func(_ context.Context, name string, ref provider.VersionRef) (*domain.Entry, error) {
+			return &domain.Entry{
+				Name:    name,
+				Value:   "hello",
+				Version: domain.Version{ID: ref.ID()},
+				Tags:    []domain.Tag{{Key: "env", Value: "prod"}},
+			}, nil
+		},
+	}
+
+	spec, err := vaultversion.Parse("app/db~1")
+	require.NoError(t, err)
+
+	out, err := (&vault.ShowUseCase{Reader: store}).Execute(t.Context(), vault.ShowInput{Spec: spec})
+	require.NoError(t, err)
+	assert.Equal(t, "app/db", out.Name)
+	assert.Equal(t, "hello", out.Value)
+	assert.Equal(t, "2", out.Version)
+	assert.Equal(t, []vault.ShowTag{{Key: "env", Value: "prod"}}, out.Tags)
+}
+
+func TestCreateUseCase(t *testing.T) {
+	t.Parallel()
+
+	store := &providermock.Store{
+		CreateFunc: func(
+			_ context.Context, name, value string, _ domain.ValueType, description string, _ ...provider.WriteOption,
+		) (domain.Version, error) {
+			assert.Equal(t, "app/db", name)
+			assert.Equal(t, "v", value)
+			assert.Empty(t, description)
+
+			return domain.Version{ID: "1"}, nil
+		},
+	}
+
+	out, err := (&vault.CreateUseCase{Writer: store}).Execute(t.Context(), vault.CreateInput{Name: "app/db", Value: "v"})
+	require.NoError(t, err)
+	assert.Equal(t, "1", out.Version)
+}
+
+func TestUpdateUseCase_NotFound(t *testing.T) {
+	t.Parallel()
+
+	store := &providermock.Store{
+		GetFunc: func(_ context.Context, name string, _ provider.VersionRef) (*domain.Entry, error) {
+			return nil, provider.ErrNotFound
+		},
+	}
+
+	_, err := (&vault.UpdateUseCase{Store: store}).Execute(t.Context(), vault.UpdateInput{Name: "app/db", Value: "v"})
+	require.ErrorIs(t, err, vault.ErrSecretNotFound)
+}
+
+func TestRestoreUseCase(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		store := &providermock.Store{
+			RestoreFunc: func(_ context.Context, name string) error {
+				assert.Equal(t, "app/db", name)
+
+				return nil
+			},
+		}
+
+		out, err := (&vault.RestoreUseCase{Restorer: store}).Execute(t.Context(), vault.RestoreInput{Name: "app/db"})
+		require.NoError(t, err)
+		assert.Equal(t, "app/db", out.Name)
+	})
+
+	t.Run("error wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("boom")
+		store := &providermock.Store{
+			RestoreFunc: func(_ context.Context, _ string) error { return boom },
+		}
+
+		_, err := (&vault.RestoreUseCase{Restorer: store}).Execute(t.Context(), vault.RestoreInput{Name: "app/db"})
+		require.ErrorIs(t, err, boom)
+		assert.Contains(t, err.Error(), "failed to restore secret")
+	})
+}
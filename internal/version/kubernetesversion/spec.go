@@ -0,0 +1,100 @@
+// Package kubernetesversion provides name parsing for Kubernetes ConfigMap and
+// Secret keys.
+//
+// An entry is one key of one object, addressed as "<object>/<key>". Kubernetes
+// keeps no value history for an object, so there is no version specifier to
+// parse. Neither object names (DNS subdomains) nor data keys ([-._a-zA-Z0-9]+)
+// may contain '#', '~', or ':', so any of them is rejected with a clear error
+// rather than silently becoming part of the name.
+package kubernetesversion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mpyw/suve/internal/version"
+)
+
+// ErrVersioningUnsupported is returned for a version specifier and by the
+// provider's History: Kubernetes keeps no value history.
+var ErrVersioningUnsupported = errors.New("versions are not supported for Kubernetes ConfigMaps and Secrets")
+
+// ErrInvalidName is returned when a name is not of the form "<object>/<key>".
+var ErrInvalidName = errors.New("name must be <object>/<key>")
+
+// AbsoluteSpec is empty: Kubernetes has no absolute version specifier. It
+// exists only to satisfy the shared version.Spec type parameter.
+type AbsoluteSpec struct{}
+
+// Spec represents a parsed Kubernetes entry name.
+//
+// Grammar: <object>/<key>
+//
+// Examples: app-config/LOG_LEVEL, db-credentials/password.
+type Spec = version.Spec[AbsoluteSpec]
+
+// Parse parses a Kubernetes entry name. The (whitespace-trimmed) input must be
+// "<object>/<key>" with both parts non-empty; a '#', '~', or ':' yields
+// ErrVersioningUnsupported. Empty input yields version.ErrEmptySpec.
+func Parse(input string) (*Spec, error) {
+	name := strings.TrimSpace(input)
+	if name == "" {
+		return nil, version.ErrEmptySpec
+	}
+
+	if strings.ContainsAny(name, "#~:") {
+		return nil, fmt.Errorf("%w: %q", ErrVersioningUnsupported, name)
+	}
+
+	if _, _, err := Split(name); err != nil {
+		return nil, err
+	}
+
+	return &Spec{Name: name}, nil
+}
+
+// Split splits "<object>/<key>" into its object name and data key.
+func Split(name string) (object, key string, err error) {
+	object, key, ok := strings.Cut(name, "/")
+	if !ok || object == "" || key == "" || strings.Contains(key, "/") {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+
+	return object, key, nil
+}
+
+// Join builds the "<object>/<key>" entry name.
+func Join(object, key string) string {
+	return object + "/" + key
+}
+
+// ParseDiffArgs parses diff command arguments. Entries are unversioned, so
+// only one or two names are accepted (a single name compares against itself).
+func ParseDiffArgs(args []string) (*Spec, *Spec, error) {
+	const usage = "usage: suve kubernetes <param|secret> diff <object/key1> [object/key2]"
+
+	switch len(args) {
+	case 1:
+		spec, err := Parse(args[0])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return spec, &Spec{Name: spec.Name}, nil
+	case 2: //nolint:mnd // two-key comparison
+		spec1, err := Parse(args[0])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		spec2, err := Parse(args[1])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return spec1, spec2, nil
+	default:
+		return nil, nil, errors.New(usage)
+	}
+}
@@ -0,0 +1,78 @@
+package kubernetesversion_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/version"
+	"github.com/mpyw/suve/internal/version/kubernetesversion"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		wantName string
+		wantErr  error
+	}{
+		{name: "object and key", input: "app-config/LOG_LEVEL", wantName: "app-config/LOG_LEVEL"},
+		{name: "dotted key", input: "web/config.yaml", wantName: "web/config.yaml"},
+		{name: "whitespace trimmed", input: "  db/password  ", wantName: "db/password"},
+		{name: "empty", input: "  ", wantErr: version.ErrEmptySpec},
+		{name: "missing key", input: "db", wantErr: kubernetesversion.ErrInvalidName},
+		{name: "empty object", input: "/password", wantErr: kubernetesversion.ErrInvalidName},
+		{name: "empty key", input: "db/", wantErr: kubernetesversion.ErrInvalidName},
+		{name: "nested key", input: "db/a/b", wantErr: kubernetesversion.ErrInvalidName},
+		{name: "version specifier", input: "db/password#3", wantErr: kubernetesversion.ErrVersioningUnsupported},
+		{name: "shift specifier", input: "db/password~", wantErr: kubernetesversion.ErrVersioningUnsupported},
+		{name: "label specifier", input: "db/password:prod", wantErr: kubernetesversion.ErrVersioningUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec, err := kubernetesversion.Parse(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, spec.Name)
+		})
+	}
+}
+
+func TestSplitJoin(t *testing.T) {
+	t.Parallel()
+
+	object, key, err := kubernetesversion.Split("db/password")
+	require.NoError(t, err)
+	assert.Equal(t, "db", object)
+	assert.Equal(t, "password", key)
+	assert.Equal(t, "db/password", kubernetesversion.Join(object, key))
+}
+
+func TestParseDiffArgs(t *testing.T) {
+	t.Parallel()
+
+	spec1, spec2, err := kubernetesversion.ParseDiffArgs([]string{"a/x"})
+	require.NoError(t, err)
+	assert.Equal(t, "a/x", spec1.Name)
+	assert.Equal(t, "a/x", spec2.Name)
+
+	spec1, spec2, err = kubernetesversion.ParseDiffArgs([]string{"a/x", "b/y"})
+	require.NoError(t, err)
+	assert.Equal(t, "a/x", spec1.Name)
+	assert.Equal(t, "b/y", spec2.Name)
+
+	_, _, err = kubernetesversion.ParseDiffArgs(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "usage:")
+}
@@ -0,0 +1,112 @@
+// Package sopsversion provides version spec parsing for the leaf keys of a
+// SOPS-encrypted file (key#COMMIT~SHIFT).
+//
+// A key's versions are the git commits that changed its value, so the "#"
+// specifier takes a commit hash (or an unambiguous prefix of one) rather than a
+// number. There are no labels, so a ":LABEL" specifier is rejected at parse
+// time with a clear error so the mistake never reaches the provider.
+package sopsversion
+
+import (
+	"errors"
+
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/cli/diffargs"
+	"github.com/mpyw/suve/internal/version"
+	"github.com/mpyw/suve/internal/version/internal"
+)
+
+// SOPS-specific errors.
+var (
+	// ErrInvalidCommit is returned when # is not followed by a commit hash.
+	ErrInvalidCommit = errors.New("# must be followed by a commit hash")
+	// ErrLabelUnsupported is returned when a :LABEL specifier is used. SOPS file
+	// versions are git commits, so a colon specifier is always invalid.
+	ErrLabelUnsupported = errors.New(": labels are not supported for SOPS files (versions are git commits)")
+)
+
+// AbsoluteSpec represents the absolute version specifier for a SOPS file key.
+type AbsoluteSpec struct {
+	Commit *string // Commit hash or prefix (#COMMIT)
+}
+
+// Spec represents a parsed SOPS file key version specification.
+//
+// Grammar: <key>[#<commit>]<shift>*
+//   - #<commit>  optional commit hash or prefix (0 or 1)
+//   - <shift>    ~ or ~<N>, repeatable (0 or more, cumulative)
+//
+// A ":LABEL" specifier is rejected: SOPS files have no labels.
+//
+// Examples: db.password, db.password#1a2b3c4, db.password~1, db.password#1a2b3c4~2.
+type Spec = version.Spec[AbsoluteSpec]
+
+// hasAbsoluteSpec returns true if a commit is already set.
+func hasAbsoluteSpec(abs AbsoluteSpec) bool {
+	return abs.Commit != nil
+}
+
+// parser defines the SOPS-specific parsing logic. The '#' parser accepts a
+// hexadecimal commit hash; the ':' parser exists ONLY to reject label syntax
+// cleanly (its IsChar never matches, so any ':' triggers ErrLabelUnsupported
+// rather than being silently folded into the key).
+//
+//nolint:gochecknoglobals // stateless parser configuration
+var parser = version.AbsoluteParser[AbsoluteSpec]{
+	Parsers: []version.SpecifierParser[AbsoluteSpec]{
+		{
+			PrefixChar: '#',
+			IsChar:     isHexChar,
+			Error:      ErrInvalidCommit,
+			Duplicated: hasAbsoluteSpec,
+			Apply: func(value string, abs AbsoluteSpec) (AbsoluteSpec, error) {
+				abs.Commit = lo.ToPtr(value)
+
+				return abs, nil
+			},
+		},
+		{
+			PrefixChar: ':',
+			IsChar:     func(byte) bool { return false },
+			Error:      ErrLabelUnsupported,
+			Apply: func(_ string, abs AbsoluteSpec) (AbsoluteSpec, error) {
+				return abs, ErrLabelUnsupported
+			},
+		},
+	},
+	Zero: func() AbsoluteSpec {
+		return AbsoluteSpec{}
+	},
+}
+
+// Parse parses a SOPS file key version specification string.
+//
+// Grammar: <key>[#<commit>]<shift>*
+//
+// Shift syntax (Git-like, repeatable):
+//   - ~      go back 1 version
+//   - ~N     go back N versions (e.g., ~2)
+//   - ~~     go back 2 versions (same as ~1~1)
+//   - ~1~2   cumulative: go back 3 versions
+func Parse(input string) (*Spec, error) {
+	return version.Parse(input, parser)
+}
+
+// ParseDiffArgs parses diff command arguments for SOPS file keys. This is a
+// convenience wrapper around diffargs.ParseArgs with SOPS-specific settings.
+func ParseDiffArgs(args []string) (*Spec, *Spec, error) {
+	return diffargs.ParseArgs(
+		args,
+		Parse,
+		hasAbsoluteSpec,
+		"#~",
+		"usage: suve sops secret diff <spec1> [spec2] | <key> #<commit1> [#<commit2>]",
+	)
+}
+
+// isHexChar reports whether c is a hexadecimal digit, the alphabet of git
+// commit hashes.
+func isHexChar(c byte) bool {
+	return internal.IsDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
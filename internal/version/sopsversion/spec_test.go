@@ -0,0 +1,129 @@
+package sopsversion_test
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/version/sopsversion"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		input      string
+		wantName   string
+		wantCommit *string
+		wantShift  int
+		wantErr    bool
+	}{
+		{
+			name:     "dotted key",
+			input:    "db.password",
+			wantName: "db.password",
+		},
+		{
+			name:       "with commit prefix",
+			input:      "db.password#1a2b3c4",
+			wantName:   "db.password",
+			wantCommit: lo.ToPtr("1a2b3c4"),
+		},
+		{
+			name:      "with shift",
+			input:     "db.password~2",
+			wantName:  "db.password",
+			wantShift: 2,
+		},
+		{
+			name:       "commit with cumulative shifts",
+			input:      "db.password#ABCDEF~1~",
+			wantName:   "db.password",
+			wantCommit: lo.ToPtr("ABCDEF"),
+			wantShift:  2,
+		},
+		{
+			name:     "list index segment",
+			input:    "servers.0.host",
+			wantName: "servers.0.host",
+		},
+		{
+			name:    "non-hex commit rejected",
+			input:   "db.password#main",
+			wantErr: true,
+		},
+		{
+			name:    "hash without commit",
+			input:   "db.password#",
+			wantErr: true,
+		},
+		{
+			name:    "label rejected",
+			input:   "db.password:latest",
+			wantErr: true,
+		},
+		{
+			name:    "empty input",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec, err := sopsversion.Parse(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, spec.Name)
+			assert.Equal(t, tt.wantCommit, spec.Absolute.Commit)
+			assert.Equal(t, tt.wantShift, spec.Shift)
+		})
+	}
+}
+
+func TestParse_LabelErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	_, err := sopsversion.Parse("db.password#abc:latest")
+	require.ErrorIs(t, err, sopsversion.ErrLabelUnsupported)
+}
+
+func TestParseDiffArgs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("key plus two commits", func(t *testing.T) {
+		t.Parallel()
+
+		spec1, spec2, err := sopsversion.ParseDiffArgs([]string{"db.password", "#abc", "#def"})
+		require.NoError(t, err)
+		assert.Equal(t, lo.ToPtr("abc"), spec1.Absolute.Commit)
+		assert.Equal(t, lo.ToPtr("def"), spec2.Absolute.Commit)
+	})
+
+	t.Run("single spec compares against current", func(t *testing.T) {
+		t.Parallel()
+
+		spec1, spec2, err := sopsversion.ParseDiffArgs([]string{"db.password~1"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, spec1.Shift)
+		assert.Nil(t, spec2.Absolute.Commit)
+		assert.Zero(t, spec2.Shift)
+	})
+
+	t.Run("no args rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := sopsversion.ParseDiffArgs(nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,111 @@
+// Package vaultversion provides version spec parsing for HashiCorp Vault KV v2
+// secrets (path#VERSION~SHIFT).
+//
+// KV v2 versions are positive integers that increase by one per write; there
+// are no labels or aliases beyond "current". The grammar therefore matches the
+// Google Cloud one (an integer #VERSION plus ~SHIFT), and a ":LABEL" specifier
+// is rejected at parse time with a clear error so the mistake never reaches the
+// provider.
+package vaultversion
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/samber/lo"
+
+	"github.com/mpyw/suve/internal/cli/diffargs"
+	"github.com/mpyw/suve/internal/version"
+	"github.com/mpyw/suve/internal/version/internal"
+)
+
+// Vault KV v2-specific errors.
+var (
+	// ErrInvalidVersion is returned when # is not followed by a version number.
+	ErrInvalidVersion = errors.New("# must be followed by a version number")
+	// ErrLabelUnsupported is returned when a :LABEL specifier is used. Vault KV
+	// v2 has no version labels (versions are integers), so a colon specifier is
+	// always invalid.
+	ErrLabelUnsupported = errors.New(": labels are not supported for Vault KV v2 (versions are integers)")
+)
+
+// AbsoluteSpec represents the absolute version specifier for Vault KV v2.
+type AbsoluteSpec struct {
+	Version *int64 // Explicit version number (#VERSION)
+}
+
+// Spec represents a parsed Vault KV v2 version specification.
+//
+// Grammar: <path>[#<N>]<shift>*
+//   - #<N>     optional version number (0 or 1)
+//   - <shift>  ~ or ~<N>, repeatable (0 or more, cumulative)
+//
+// A ":LABEL" specifier is rejected: Vault KV v2 has no labels.
+//
+// Examples: app/db, app/db#3, app/db~1, app/db#5~2, app/db~~.
+type Spec = version.Spec[AbsoluteSpec]
+
+// parser defines the Vault KV v2-specific parsing logic. The '#' parser accepts
+// an integer version; the ':' parser exists ONLY to reject label syntax cleanly
+// (its IsChar never matches, so any ':' triggers ErrLabelUnsupported rather
+// than being silently folded into the path).
+//
+//nolint:gochecknoglobals // stateless parser configuration
+var parser = version.AbsoluteParser[AbsoluteSpec]{
+	Parsers: []version.SpecifierParser[AbsoluteSpec]{
+		{
+			PrefixChar: '#',
+			IsChar:     internal.IsDigit,
+			Error:      ErrInvalidVersion,
+			Duplicated: func(abs AbsoluteSpec) bool {
+				return abs.Version != nil
+			},
+			Apply: func(value string, abs AbsoluteSpec) (AbsoluteSpec, error) {
+				v, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return abs, err
+				}
+
+				abs.Version = lo.ToPtr(v)
+
+				return abs, nil
+			},
+		},
+		{
+			PrefixChar: ':',
+			IsChar:     func(byte) bool { return false },
+			Error:      ErrLabelUnsupported,
+			Apply: func(_ string, abs AbsoluteSpec) (AbsoluteSpec, error) {
+				return abs, ErrLabelUnsupported
+			},
+		},
+	},
+	Zero: func() AbsoluteSpec {
+		return AbsoluteSpec{}
+	},
+}
+
+// Parse parses a Vault KV v2 version specification string.
+//
+// Grammar: <path>[#<N>]<shift>*
+//
+// Shift syntax (Git-like, repeatable):
+//   - ~      go back 1 version
+//   - ~N     go back N versions (e.g., ~2)
+//   - ~~     go back 2 versions (same as ~1~1)
+//   - ~1~2   cumulative: go back 3 versions
+func Parse(input string) (*Spec, error) {
+	return version.Parse(input, parser)
+}
+
+// ParseDiffArgs parses diff command arguments for Vault KV v2. This is a
+// convenience wrapper around diffargs.ParseArgs with Vault-specific settings.
+func ParseDiffArgs(args []string) (*Spec, *Spec, error) {
+	return diffargs.ParseArgs(
+		args,
+		Parse,
+		func(abs AbsoluteSpec) bool { return abs.Version != nil },
+		"#~",
+		"usage: suve vault secret diff <spec1> [spec2] | <path> #<version1> [#<version2>]",
+	)
+}
@@ -0,0 +1,129 @@
+package vaultversion_test
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mpyw/suve/internal/version/vaultversion"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       string
+		wantName    string
+		wantVersion *int64
+		wantShift   int
+		wantErr     bool
+	}{
+		{
+			name:     "simple path",
+			input:    "app/db",
+			wantName: "app/db",
+		},
+		{
+			name:        "with version",
+			input:       "app/db#3",
+			wantName:    "app/db",
+			wantVersion: lo.ToPtr(int64(3)),
+		},
+		{
+			name:      "with single shift",
+			input:     "app/db~",
+			wantName:  "app/db",
+			wantShift: 1,
+		},
+		{
+			name:      "with double tilde",
+			input:     "app/db~~",
+			wantName:  "app/db",
+			wantShift: 2,
+		},
+		{
+			name:        "version with shift",
+			input:       "app/db#5~2",
+			wantName:    "app/db",
+			wantVersion: lo.ToPtr(int64(5)),
+			wantShift:   2,
+		},
+		{
+			name:    "label rejected",
+			input:   "app/db:current",
+			wantErr: true,
+		},
+		{
+			name:    "hash followed by non-digit",
+			input:   "app/db#abc",
+			wantErr: true,
+		},
+		{
+			name:    "multiple absolute specifiers rejected",
+			input:   "app/db#3#4",
+			wantErr: true,
+		},
+		{
+			name:    "empty input",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec, err := vaultversion.Parse(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, spec.Name)
+			assert.Equal(t, tt.wantVersion, spec.Absolute.Version)
+			assert.Equal(t, tt.wantShift, spec.Shift)
+		})
+	}
+}
+
+func TestParse_LabelErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	_, err := vaultversion.Parse("app/db:current")
+	require.ErrorIs(t, err, vaultversion.ErrLabelUnsupported)
+}
+
+func TestParseDiffArgs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("single spec compares against latest", func(t *testing.T) {
+		t.Parallel()
+
+		spec1, spec2, err := vaultversion.ParseDiffArgs([]string{"app/db#3"})
+		require.NoError(t, err)
+		assert.Equal(t, lo.ToPtr(int64(3)), spec1.Absolute.Version)
+		assert.Nil(t, spec2.Absolute.Version)
+	})
+
+	t.Run("name plus two specifiers", func(t *testing.T) {
+		t.Parallel()
+
+		spec1, spec2, err := vaultversion.ParseDiffArgs([]string{"app/db", "#1", "#2"})
+		require.NoError(t, err)
+		assert.Equal(t, lo.ToPtr(int64(1)), spec1.Absolute.Version)
+		assert.Equal(t, lo.ToPtr(int64(2)), spec2.Absolute.Version)
+	})
+
+	t.Run("no args rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := vaultversion.ParseDiffArgs([]string{})
+		require.Error(t, err)
+	})
+}